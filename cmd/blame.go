@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/git"
+)
+
+var blameRepo string
+
+var blameCmd = &cobra.Command{
+	Use:   "blame <commit>",
+	Short: "Show the session a merge or PR commit came from",
+	Long: `Reads the Plural-Session trailer and the provenance note (refs/notes/plural) of a
+commit in the repo in the current directory, or --repo, and prints the session
+recorded there, with its details if it still exists here.
+
+Commits record their session only in repos whose repo_provenance setting turns
+on trailers or notes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBlame,
+}
+
+func init() {
+	blameCmd.Flags().StringVar(&blameRepo, "repo", ".", "Repo the commit is in")
+	rootCmd.AddCommand(blameCmd)
+}
+
+func runBlame(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	return blameCommit(cmd.Context(), git.NewGitService(), cfg, blameRepo, args[0], cmd.OutOrStdout())
+}
+
+// blameCommit prints the session commit in repoPath records, looking it up in cfg.
+func blameCommit(ctx context.Context, gitService *git.GitService, cfg *config.Config, repoPath, commit string, w io.Writer) error {
+	provenance, err := gitService.ReadProvenance(ctx, repoPath, commit)
+	if err != nil {
+		return err
+	}
+	if provenance.SessionID == "" {
+		return errors.New("commit " + provenance.Commit + " records no Plural session (no " + git.TrailerSession + " trailer or provenance note)")
+	}
+
+	fmt.Fprintf(w, "Commit:      %s\n", provenance.Commit)
+	if sess := cfg.GetSession(provenance.SessionID); sess != nil {
+		fmt.Fprintf(w, "Session:     %s  %s (%s)\n", sess.ID, sess.Name, filepath.Base(sess.RepoPath))
+		fmt.Fprintf(w, "Branch:      %s\n", sess.Branch)
+		if sess.WorkTree != "" {
+			fmt.Fprintf(w, "Worktree:    %s\n", sess.WorkTree)
+		}
+	} else {
+		fmt.Fprintf(w, "Session:     %s (no longer here)\n", provenance.SessionID)
+	}
+	if provenance.PromptedBy != "" {
+		fmt.Fprintf(w, "Prompted by: %s\n", provenance.PromptedBy)
+	}
+	if provenance.Note != "" {
+		fmt.Fprintf(w, "\nNote (%s):\n  %s\n", git.ProvenanceNotesRef, strings.ReplaceAll(provenance.Note, "\n", "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/git"
+)
+
+// blameTestRepo returns a repo with one commit recording session-1 in a
+// trailer and a note, and one recording nothing, checked out.
+func blameTestRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+	run("init")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test User")
+	run("commit", "--allow-empty", "-m", "Fix login\n\nPlural-Session: session-1\nPlural-Prompted-By: abc123")
+	run("notes", "--ref=plural", "add", "-m", "Plural-Session: session-1\nPlural-Session-Name: api/fix-login", "HEAD")
+	run("tag", "recorded")
+	run("commit", "--allow-empty", "-m", "Unrelated")
+	return dir
+}
+
+func TestBlameCommit(t *testing.T) {
+	repo := blameTestRepo(t)
+	cfg := &config.Config{
+		Repos:    []string{"/src/api"},
+		Sessions: []config.Session{{ID: "session-1", Name: "api/fix-login", RepoPath: "/src/api", Branch: "fix-login", WorkTree: "/wt/session-1"}},
+	}
+
+	var buf bytes.Buffer
+	if err := blameCommit(context.Background(), git.NewGitService(), cfg, repo, "recorded", &buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"session-1  api/fix-login (api)", "Branch:      fix-login", "Prompted by: abc123", "Plural-Session-Name: api/fix-login"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in the output:\n%s", want, buf.String())
+		}
+	}
+
+	// A session no longer in the config is still named
+	buf.Reset()
+	if err := blameCommit(context.Background(), git.NewGitService(), &config.Config{}, repo, "recorded", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "session-1 (no longer here)") {
+		t.Errorf("expected the missing session reported, got:\n%s", buf.String())
+	}
+
+	if err := blameCommit(context.Background(), git.NewGitService(), cfg, repo, "HEAD", &buf); err == nil || !strings.Contains(err.Error(), "records no Plural session") {
+		t.Errorf("expected an error for a commit recording no session, got %v", err)
+	}
+}
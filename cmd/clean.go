@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -13,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/zhubert/plural/internal/config"
 	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/mcp"
 	"github.com/zhubert/plural/internal/paths"
 	"github.com/zhubert/plural/internal/process"
 	"github.com/zhubert/plural/internal/session"
@@ -147,7 +149,9 @@ func runCleanWithReader(input io.Reader) error {
 
 	// Prune orphans in parallel (from --prune)
 	sessionSvc := session.NewSessionService()
-	ctx := context.Background()
+	// Ctrl-C stops pruning worktrees after the ones under way
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
 	var prunedWorktrees, prunedMessages, prunedProcesses, prunedContainers, prunedTempFiles int
 	var worktreesErr, messagesErr, processesErr, containersErr error
@@ -232,6 +236,7 @@ func findStaleTempFiles() []string {
 }
 
 // findStaleTempFilesInDirs searches the given directories for stale plural temp files.
+// Sockets that still answer a ping belong to a running session and are skipped.
 func findStaleTempFilesInDirs(configDir, tmpDir string) []string {
 	var files []string
 
@@ -251,7 +256,12 @@ func findStaleTempFilesInDirs(configDir, tmpDir string) []string {
 			files = append(files, matches...)
 		}
 		if matches, err := filepath.Glob(filepath.Join(tmpDir, "pl-*.sock")); err == nil {
-			files = append(files, matches...)
+			for _, socketPath := range matches {
+				// Leave sockets owned by a running plural instance alone
+				if !mcp.ProbeSocket(socketPath) {
+					files = append(files, socketPath)
+				}
+			}
 		}
 	}
 
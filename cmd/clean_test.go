@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"bufio"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -87,6 +89,42 @@ func TestFindStaleTempFilesInDirs(t *testing.T) {
 	}
 }
 
+func TestFindStaleTempFilesInDirs_SkipsLiveSockets(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A socket with a running listener belongs to a live session
+	livePath := filepath.Join(tmpDir, "pl-live.sock")
+	server, err := net.Listen("unix", livePath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer server.Close()
+	go func() {
+		for {
+			conn, err := server.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if _, err := bufio.NewReader(conn).ReadString('\n'); err == nil {
+					conn.Write([]byte(`{"type":"ping"}` + "\n"))
+				}
+			}()
+		}
+	}()
+
+	stalePath := filepath.Join(tmpDir, "pl-stale.sock")
+	if err := os.WriteFile(stalePath, []byte("test"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	files := findStaleTempFilesInDirs("", tmpDir)
+	if len(files) != 1 || files[0] != stalePath {
+		t.Errorf("expected only the stale socket, got %v", files)
+	}
+}
+
 func TestFindStaleTempFilesInDirs_EmptyDirs(t *testing.T) {
 	files := findStaleTempFilesInDirs("", "")
 	if len(files) != 0 {
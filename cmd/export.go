@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/zhubert/plural/internal/config"
+)
+
+var (
+	exportOut            string
+	exportIncludeHistory bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export repos, settings, and sessions for backup or another machine",
+	Long: `Writes a JSON bundle of the registered repos, their settings, global settings,
+and sessions, to restore with plural import. Message history is left out unless
+--include-history is given. No credentials are exported: API keys and tokens
+stay in the environment, the keychain, and the gh CLI.
+
+Without --out, the bundle is written to standard output.`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOut, "out", "o", "", "Write the bundle to this file instead of standard output")
+	exportCmd.Flags().BoolVar(&exportIncludeHistory, "include-history", false, "Include each session's message history")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	return exportConfig(cfg, exportOut, exportIncludeHistory, cmd.OutOrStdout())
+}
+
+// exportConfig writes the bundle of cfg to the file out, or to w if out is empty.
+func exportConfig(cfg *config.Config, out string, includeHistory bool, w io.Writer) error {
+	bundle, err := cfg.Export(includeHistory)
+	if err != nil {
+		return fmt.Errorf("failed to export: %w", err)
+	}
+	bundle.PluralVersion = version
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if out == "" {
+		_, err := w.Write(data)
+		return err
+	}
+	// Only the user should read it, as it may hold their conversations
+	if err := os.WriteFile(out, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	_, err = fmt.Fprintf(w, "Exported %d repo(s) and %d session(s) to %s\n", len(cfg.GetRepos()), len(cfg.GetSessions()), out)
+	return err
+}
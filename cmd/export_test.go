@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/paths"
+)
+
+// useTempHome points the config and data directories at a new temporary home.
+func useTempHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+	return home
+}
+
+// exportTestSessions returns two sessions of listTestSessions, with worktrees.
+func exportTestSessions() []config.Session {
+	sessions := listTestSessions()[1:3]
+	for i := range sessions {
+		sessions[i].WorkTree = "/wt/" + sessions[i].ID
+	}
+	return sessions
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	useTempHome(t)
+	src := &config.Config{Repos: []string{"/src/api"}, Sessions: exportTestSessions()}
+	if err := config.SaveSessionMessages(src.Sessions[0].ID, []config.Message{{Role: "user", Content: "add the tests"}}, 0); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "backup.json")
+	var buf bytes.Buffer
+	if err := exportConfig(src, out, true, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Exported 1 repo(s) and 2 session(s)") {
+		t.Errorf("unexpected export output: %q", buf.String())
+	}
+	if info, err := os.Stat(out); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("expected the bundle readable only by the user, got %v, %v", info, err)
+	}
+
+	// Import on a new machine that already has one of the sessions
+	home := useTempHome(t)
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := config.ParseBundle(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := &config.Config{Repos: []string{"/src/api"}, Sessions: exportTestSessions()[1:]}
+	dst.SetFilePath(filepath.Join(home, "config.json"))
+	buf.Reset()
+	if err := importBundle(context.Background(), dst, bundle, false, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Registered 0 new repo(s)", "Added 1 session(s), 1 with message history", "Skipped 1 session(s)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in the import output:\n%s", want, buf.String())
+		}
+	}
+
+	saved, err := os.ReadFile(filepath.Join(home, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(saved), src.Sessions[0].ID) {
+		t.Errorf("expected the imported session saved, got:\n%s", saved)
+	}
+	messages, err := config.LoadSessionMessages(src.Sessions[0].ID)
+	if err != nil || len(messages) != 1 || messages[0].Content != "add the tests" {
+		t.Errorf("expected the message history restored, got %v, %v", messages, err)
+	}
+}
+
+func TestImportCommandFlags(t *testing.T) {
+	if err := importCmd.Args(importCmd, nil); err == nil {
+		t.Error("import should require a file")
+	}
+	if importCmd.Flags().Lookup("on-conflict") == nil || importCmd.Flags().Lookup("worktrees") == nil {
+		t.Error("import should accept --on-conflict and --worktrees")
+	}
+	if exportCmd.Flags().Lookup("out") == nil || exportCmd.Flags().Lookup("include-history") == nil {
+		t.Error("export should accept --out and --include-history")
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/session"
+)
+
+var (
+	importOnConflict string
+	importWorktrees  bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import repos, settings, and sessions written by plural export",
+	Long: `Registers the repos, and adds the settings and sessions, of a bundle written by
+plural export, restoring their message history if it was exported.
+
+Settings already set here are kept, and a session whose ID is already here is
+skipped; with --on-conflict overwrite, the bundle's replace them. With
+--worktrees, each imported session not yet merged gets its worktree recreated
+from its branch, in the repo or on origin.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importOnConflict, "on-conflict", "skip", `What to do with sessions and settings already here: "skip" or "overwrite"`)
+	importCmd.Flags().BoolVar(&importWorktrees, "worktrees", false, "Recreate the worktrees of imported sessions")
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	var overwrite bool
+	switch importOnConflict {
+	case "skip":
+	case "overwrite":
+		overwrite = true
+	default:
+		return fmt.Errorf(`--on-conflict must be "skip" or "overwrite", got %q`, importOnConflict)
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	bundle, err := config.ParseBundle(data)
+	if err != nil {
+		return fmt.Errorf("cannot import %s: %w", args[0], err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+
+	var worktrees *session.SessionService
+	if importWorktrees {
+		worktrees = session.NewSessionService()
+		worktrees.SetWorktreeDirs(cfg.GetWorktreeDir)
+	}
+	return importBundle(cmd.Context(), cfg, bundle, overwrite, worktrees, cmd.OutOrStdout())
+}
+
+// importBundle merges bundle into cfg and saves it, then restores the imported
+// sessions' message history. With worktrees, the imported sessions not yet
+// merged also get their worktrees recreated. What can't be restored is
+// reported as a warning rather than stopping the import.
+func importBundle(ctx context.Context, cfg *config.Config, bundle *config.Bundle, overwrite bool, worktrees *session.SessionService, w io.Writer) error {
+	merged, result, err := cfg.MergeBundle(bundle, overwrite)
+	if err != nil {
+		return fmt.Errorf("cannot import: %w", err)
+	}
+
+	var warnings []string
+	if worktrees != nil {
+		for _, id := range result.Imported() {
+			sess := merged.GetSession(id)
+			if sess.IsMerged() || sess.MergedToParent {
+				continue
+			}
+			path, err := worktrees.RestoreWorktree(ctx, *sess)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("no worktree for %s: %v", sess.Name, err))
+				continue
+			}
+			merged.UpdateSessionWorkTree(id, path)
+		}
+	}
+	if err := merged.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	history := 0
+	for _, id := range result.Imported() {
+		messages := bundle.Messages[id]
+		if len(messages) == 0 {
+			continue
+		}
+		if err := config.SaveSessionMessages(id, messages, config.MaxSessionMessageLines); err != nil {
+			warnings = append(warnings, fmt.Sprintf("no message history for %s: %v", merged.GetSession(id).Name, err))
+			continue
+		}
+		history++
+	}
+
+	fmt.Fprintf(w, "Registered %d new repo(s)\n", len(result.ReposAdded))
+	fmt.Fprintf(w, "Added %d session(s)", len(result.Added))
+	if len(result.Overwritten) > 0 {
+		fmt.Fprintf(w, ", replaced %d", len(result.Overwritten))
+	}
+	if history > 0 {
+		fmt.Fprintf(w, ", %d with message history", history)
+	}
+	fmt.Fprintln(w)
+	if len(result.Skipped) > 0 {
+		fmt.Fprintf(w, "Skipped %d session(s) already here (use --on-conflict overwrite to replace them)\n", len(result.Skipped))
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "Warning: %s\n", warning)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zhubert/plural/internal/config"
+)
+
+// listSchemaVersion is the version of the list --json format. It changes when a
+// field is removed or changes meaning; new fields may appear within a version.
+const listSchemaVersion = 1
+
+// listCompatibility is the compatibility note in the list --json envelope.
+const listCompatibility = "Fields may be added within a schema_version; removing or changing a field increments it. Identify sessions by id; name is for display only."
+
+var (
+	listJSON   bool
+	listLimit  int
+	listCursor string
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List sessions",
+	Long: `Lists sessions ordered by repo path, creation time, then ID, the same on every run.
+Each session is identified by its full ID and its 8-character short form; names
+are for display only and may change.
+
+With --json, prints a versioned envelope for scripts. With --limit, prints one
+page and a cursor for the next; pass it back with --cursor.`,
+	Args: cobra.NoArgs,
+	RunE: runList,
+}
+
+func init() {
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Print JSON for scripts")
+	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Print at most this many sessions (0 = all)")
+	listCmd.Flags().StringVar(&listCursor, "cursor", "", "Continue after the page that returned this cursor")
+	rootCmd.AddCommand(listCmd)
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	page, err := pageSessions(cfg.GetSessions(), listCursor, listLimit)
+	if err != nil {
+		return err
+	}
+	if listJSON {
+		return writeSessionListJSON(cmd.OutOrStdout(), page)
+	}
+	return writeSessionList(cmd.OutOrStdout(), page)
+}
+
+// sessionPage is one page of sessions in config.CompareSessions order.
+type sessionPage struct {
+	Sessions   []config.Session
+	NextCursor string // Empty on the last page
+}
+
+// pageSessions returns the sessions after cursor, at most limit of them (0 = all).
+// A cursor names the last session of a page by its sort key, so pages stay
+// consistent when sessions are added or deleted in between.
+func pageSessions(sessions []config.Session, cursor string, limit int) (sessionPage, error) {
+	if limit < 0 {
+		return sessionPage{}, errors.New("--limit must not be negative")
+	}
+	sorted := config.SortedSessions(sessions)
+	if cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return sessionPage{}, err
+		}
+		start := len(sorted)
+		for i, sess := range sorted {
+			if config.CompareSessions(sess, after) > 0 {
+				start = i
+				break
+			}
+		}
+		sorted = sorted[start:]
+	}
+
+	var page sessionPage
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+		page.NextCursor = encodeCursor(sorted[limit-1])
+	}
+	page.Sessions = sorted
+	return page, nil
+}
+
+// encodeCursor returns the opaque cursor for the page ending at sess.
+func encodeCursor(sess config.Session) string {
+	key := strings.Join([]string{sess.RepoPath, sess.CreatedAt.UTC().Format(time.RFC3339Nano), sess.ID}, "\x00")
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// decodeCursor returns the sort key a cursor names, as a session.
+func decodeCursor(cursor string) (config.Session, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return config.Session{}, errors.New("invalid cursor")
+	}
+	parts := strings.Split(string(data), "\x00")
+	if len(parts) != 3 {
+		return config.Session{}, errors.New("invalid cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return config.Session{}, errors.New("invalid cursor")
+	}
+	return config.Session{RepoPath: parts[0], CreatedAt: createdAt, ID: parts[2]}, nil
+}
+
+// sessionStatus describes where a session's work stands.
+func sessionStatus(sess config.Session) string {
+	switch {
+	case sess.MergedToParent:
+		return "merged_to_parent"
+	case sess.IsMerged():
+		return "merged"
+	case sess.PRClosed:
+		return "pr_closed"
+	case sess.PRCreated:
+		return "pr_open"
+	}
+	return "active"
+}
+
+// listSessionJSON is a session in the list --json output.
+type listSessionJSON struct {
+	ID           string     `json:"id"`
+	ShortID      string     `json:"short_id"`
+	Name         string     `json:"name"`
+	RepoPath     string     `json:"repo_path"`
+	Branch       string     `json:"branch"`
+	BaseBranch   string     `json:"base_branch"`
+	ParentID     string     `json:"parent_id"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastActivity *time.Time `json:"last_activity"` // null if Claude never responded
+}
+
+// listJSONEnvelope is the list --json output.
+type listJSONEnvelope struct {
+	SchemaVersion int               `json:"schema_version"`
+	Compatibility string            `json:"compatibility"`
+	Sessions      []listSessionJSON `json:"sessions"`
+	NextCursor    *string           `json:"next_cursor"` // null on the last page
+}
+
+func writeSessionListJSON(w io.Writer, page sessionPage) error {
+	out := listJSONEnvelope{
+		SchemaVersion: listSchemaVersion,
+		Compatibility: listCompatibility,
+		Sessions:      make([]listSessionJSON, 0, len(page.Sessions)),
+	}
+	for _, sess := range page.Sessions {
+		item := listSessionJSON{
+			ID:         sess.ID,
+			ShortID:    config.ShortID(sess.ID),
+			Name:       sess.Name,
+			RepoPath:   sess.RepoPath,
+			Branch:     sess.Branch,
+			BaseBranch: sess.BaseBranch,
+			ParentID:   sess.ParentID,
+			Status:     sessionStatus(sess),
+			CreatedAt:  sess.CreatedAt.UTC(),
+		}
+		if !sess.LastActivity.IsZero() {
+			lastActivity := sess.LastActivity.UTC()
+			item.LastActivity = &lastActivity
+		}
+		out.Sessions = append(out.Sessions, item)
+	}
+	if page.NextCursor != "" {
+		out.NextCursor = &page.NextCursor
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeSessionList(w io.Writer, page sessionPage) error {
+	if len(page.Sessions) == 0 && page.NextCursor == "" {
+		_, err := fmt.Fprintln(w, "No sessions.")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SHORT ID\tID\tREPO\tSTATUS\tNAME")
+	for _, sess := range page.Sessions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", config.ShortID(sess.ID), sess.ID, filepath.Base(sess.RepoPath), sessionStatus(sess), sess.Name)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if page.NextCursor != "" {
+		_, err := fmt.Fprintf(w, "\nMore sessions: plural list --limit %d --cursor %s\n", len(page.Sessions), page.NextCursor)
+		return err
+	}
+	return nil
+}
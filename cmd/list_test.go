@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhubert/plural/internal/config"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// checkGolden compares got to testdata/name, or rewrites it with -update.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v (run go test ./cmd -run List -update)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func listTestSessions() []config.Session {
+	t0 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	return []config.Session{
+		{ID: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Name: "fix-login", RepoPath: "/src/webapp", Branch: "fix-login", BaseBranch: "main", CreatedAt: t0, PRCreated: true, LastActivity: t0.Add(2 * time.Hour)},
+		{ID: "9b2e4c1a-0d3f-4e5a-8b6c-7d8e9f0a1b2c", Name: "add-tests", RepoPath: "/src/api", Branch: "add-tests", BaseBranch: "main", CreatedAt: t0.Add(time.Hour), Merged: true},
+		{ID: "1c9d8e7f-6a5b-4c3d-2e1f-0a9b8c7d6e5f", Name: "refactor", RepoPath: "/src/api", Branch: "refactor", BaseBranch: "main", CreatedAt: t0},
+		{ID: "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", Name: "refactor-fork", RepoPath: "/src/api", Branch: "refactor-fork", BaseBranch: "refactor", ParentID: "1c9d8e7f-6a5b-4c3d-2e1f-0a9b8c7d6e5f", CreatedAt: t0},
+	}
+}
+
+func TestListJSON_Golden(t *testing.T) {
+	page, err := pageSessions(listTestSessions(), "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeSessionListJSON(&buf, page); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "list.golden.json", buf.Bytes())
+}
+
+func TestListJSON_PageGolden(t *testing.T) {
+	page, err := pageSessions(listTestSessions(), "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeSessionListJSON(&buf, page); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "list_page.golden.json", buf.Bytes())
+}
+
+func TestListText_Golden(t *testing.T) {
+	page, err := pageSessions(listTestSessions(), "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeSessionList(&buf, page); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "list.golden.txt", buf.Bytes())
+}
+
+func TestPageSessions_Cursor(t *testing.T) {
+	sessions := listTestSessions()
+	var ids []string
+	cursor := ""
+	for range len(sessions) + 1 {
+		page, err := pageSessions(sessions, cursor, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, sess := range page.Sessions {
+			ids = append(ids, sess.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	all, _ := pageSessions(sessions, "", 0)
+	if len(ids) != len(all.Sessions) {
+		t.Fatalf("paging returned %d sessions, want %d", len(ids), len(all.Sessions))
+	}
+	for i, sess := range all.Sessions {
+		if ids[i] != sess.ID {
+			t.Errorf("page %d returned %s, want %s", i, ids[i], sess.ID)
+		}
+	}
+
+	// A session deleted after a page was returned does not shift the next page
+	first, _ := pageSessions(sessions, "", 2)
+	remaining := config.SortedSessions(sessions)[1:]
+	next, err := pageSessions(remaining, first.NextCursor, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Sessions[0].ID != all.Sessions[2].ID {
+		t.Errorf("expected the next page to start at %s, got %s", all.Sessions[2].ID, next.Sessions[0].ID)
+	}
+}
+
+func TestPageSessions_InvalidInput(t *testing.T) {
+	if _, err := pageSessions(nil, "not a cursor!", 0); err == nil || !strings.Contains(err.Error(), "invalid cursor") {
+		t.Errorf("expected an invalid cursor error, got %v", err)
+	}
+	if _, err := pageSessions(nil, "", -1); err == nil {
+		t.Error("expected an error for a negative limit")
+	}
+}
@@ -20,6 +20,7 @@ var autoApprove bool
 var mcpSessionID string
 var mcpSupervisor bool
 var mcpHostTools bool
+var mcpDeniedTools []string
 
 var mcpServerCmd = &cobra.Command{
 	Use:    "mcp-server",
@@ -36,6 +37,7 @@ func init() {
 	mcpServerCmd.Flags().StringVar(&mcpSessionID, "session-id", "", "Session ID for logging")
 	mcpServerCmd.Flags().BoolVar(&mcpSupervisor, "supervisor", false, "Enable supervisor tools (create/list/merge child sessions)")
 	mcpServerCmd.Flags().BoolVar(&mcpHostTools, "host-tools", false, "Enable host operation tools (create_pr, push_branch)")
+	mcpServerCmd.Flags().StringArrayVar(&mcpDeniedTools, "deny-tool", nil, "Deny permission requests matching this tool pattern, even when auto-approving (repeatable)")
 	rootCmd.AddCommand(mcpServerCmd)
 }
 
@@ -67,6 +69,13 @@ func runMCPServer(cmd *cobra.Command, args []string) error {
 	}
 	defer logger.Close()
 
+	// Container sessions can't see the TUI's toggle, so they get --debug-mcp
+	if debugMCP {
+		if err := mcp.SetWireTrace(true); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to turn on MCP wire tracing: %v\n", err)
+		}
+	}
+
 	// Connect to TUI — via listen (container reverse-TCP mode), TCP (legacy), or Unix socket (host mode).
 	//
 	// In --listen mode (container sessions), the MCP subprocess listens on a port inside the
@@ -112,6 +121,7 @@ func runMCPServer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --socket, --tcp, or --listen must be specified")
 	}
 	defer client.Close()
+	client.SetSessionID(sessionID)
 
 	// Create channels for MCP server communication.
 	// Response channels are buffered (1) so that if the server exits while a
@@ -236,6 +246,9 @@ func runMCPServer(cmd *cobra.Command, args []string) error {
 	if autoApprove {
 		allowedTools = []string{"*"}
 	}
+	if len(mcpDeniedTools) > 0 {
+		serverOpts = append(serverOpts, mcp.WithDeniedTools(mcpDeniedTools))
+	}
 	server := mcp.NewServer(os.Stdin, os.Stdout, reqChan, respChan, questionChan, answerChan, planApprovalChan, planResponseChan, allowedTools, sessionID, serverOpts...)
 	err = server.Run()
 	fmt.Fprintf(os.Stderr, "[mcp] JSONRPC server exited (err=%v)\n", err)
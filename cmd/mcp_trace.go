@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/mcp"
+)
+
+var mcpTraceProblemsOnly bool
+
+var mcpTraceCmd = &cobra.Command{
+	Use:   "mcp-trace <session-id>",
+	Short: "Pair the traced MCP socket requests of a session with their responses",
+	Long: `Reads the wire trace in a session's MCP log, recorded while plural runs with
+--debug-mcp or after pressing m in the log viewer, pairs each request the MCP
+server sent the TUI with its response by type and request ID, and flags the
+ones that never got an answer and where they got stuck.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMCPTrace,
+}
+
+func init() {
+	mcpTraceCmd.Flags().BoolVar(&mcpTraceProblemsOnly, "problems", false, "Only show requests that weren't answered")
+	rootCmd.AddCommand(mcpTraceCmd)
+}
+
+func runMCPTrace(cmd *cobra.Command, args []string) error {
+	path, err := logger.MCPLogPath(args[0])
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("no MCP log for session %s: %w", args[0], err)
+	}
+	defer f.Close()
+
+	records, err := mcp.ParseWireTrace(f)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("no wire trace in %s; run plural with --debug-mcp, or press m in the log viewer, and try again", path)
+	}
+	printWireExchanges(cmd.OutOrStdout(), mcp.PairWireTrace(records), mcpTraceProblemsOnly)
+	return nil
+}
+
+// printWireExchanges prints a line per exchange, or per unanswered exchange
+// with problemsOnly, then a count of each.
+func printWireExchanges(w io.Writer, exchanges []*mcp.WireExchange, problemsOnly bool) {
+	problems := 0
+	for _, ex := range exchanges {
+		problem := ex.Status != mcp.WireAnswered
+		if problem {
+			problems++
+		}
+		if problemsOnly && !problem {
+			continue
+		}
+		id := ex.ID
+		if id == "" {
+			id = "-"
+		}
+		latency := "-"
+		if !problem {
+			latency = ex.Latency().Round(time.Millisecond).String()
+		}
+		marker := " "
+		if problem {
+			marker = "!"
+		}
+		fmt.Fprintf(w, "%s %s  %-18s %-10s %8s  %s\n",
+			marker, ex.Start().Local().Format("15:04:05.000"), ex.Type, id, latency, ex.Status)
+	}
+	fmt.Fprintf(w, "\n%d requests, %d answered, %d unanswered\n", len(exchanges), len(exchanges)-problems, problems)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhubert/plural/internal/mcp"
+)
+
+func TestPrintWireExchanges(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	exchanges := []*mcp.WireExchange{
+		{Type: "permission", ID: "1", MCPSent: start, MCPReceived: start.Add(42 * time.Millisecond), Status: mcp.WireAnswered},
+		{Type: "question", ID: "2", MCPSent: start, TUIReceived: start, Status: mcp.WireUnanswered},
+	}
+
+	var buf bytes.Buffer
+	printWireExchanges(&buf, exchanges, false)
+	out := buf.String()
+	for _, want := range []string{"permission", "42ms", "! ", "question", mcp.WireUnanswered, "2 requests, 1 answered, 1 unanswered"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the output:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	printWireExchanges(&buf, exchanges, true)
+	if strings.Contains(buf.String(), "permission") {
+		t.Errorf("expected only unanswered requests with problemsOnly:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "question") {
+		t.Errorf("expected the unanswered request:\n%s", buf.String())
+	}
+}
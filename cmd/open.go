@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zhubert/plural/internal/config"
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open <session-id>",
+	Short: "Launch the TUI with a session selected and focused",
+	Long: `Launches the TUI with the given session selected in the sidebar and its chat
+focused, for shell aliases and external tools. Fails with the list of known
+session IDs if the ID does not exist.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOpen,
+}
+
+func init() {
+	openCmd.Flags().BoolVar(&inlineMode, "inline", false, "Run without the alternate screen or mouse capture, showing only the chat (for logging/capture)")
+	openCmd.Flags().BoolVar(&shareLAN, "share-lan", false, shareLANUsage)
+	rootCmd.AddCommand(openCmd)
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	return runApp(strings.TrimSpace(args[0]))
+}
+
+// describeSessions lists session IDs with their names and repos, in the order
+// plural list uses, for error messages.
+func describeSessions(sessions []config.Session) string {
+	if len(sessions) == 0 {
+		return "There are no sessions yet. Run plural and press n to create one."
+	}
+	var sb strings.Builder
+	sb.WriteString("Available sessions:\n")
+	for _, sess := range config.SortedSessions(sessions) {
+		fmt.Fprintf(&sb, "  %s  %s (%s)\n", sess.ID, sess.Name, filepath.Base(sess.RepoPath))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
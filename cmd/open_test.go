@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+)
+
+func TestOpenCommandRequiresSessionID(t *testing.T) {
+	if err := openCmd.Args(openCmd, nil); err == nil {
+		t.Error("open should require a session ID")
+	}
+	if err := openCmd.Args(openCmd, []string{"a", "b"}); err == nil {
+		t.Error("open should accept only one session ID")
+	}
+	if err := openCmd.Args(openCmd, []string{"abc"}); err != nil {
+		t.Errorf("open should accept one session ID, got %v", err)
+	}
+}
+
+func TestOpenCommandHasInlineFlag(t *testing.T) {
+	if openCmd.Flags().Lookup("inline") == nil {
+		t.Error("open should accept --inline")
+	}
+}
+
+func TestDescribeSessions(t *testing.T) {
+	got := describeSessions([]config.Session{
+		{ID: "abc123", Name: "fix-login", RepoPath: "/src/webapp"},
+		{ID: "def456", Name: "add-tests", RepoPath: "/src/api"},
+	})
+	for _, want := range []string{"Available sessions:", "abc123  fix-login (webapp)", "def456  add-tests (api)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("describeSessions missing %q in:\n%s", want, got)
+		}
+	}
+
+	if got := describeSessions(nil); !strings.Contains(got, "no sessions") {
+		t.Errorf("describeSessions(nil) = %q, want a hint that there are no sessions", got)
+	}
+}
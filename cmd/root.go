@@ -9,11 +9,18 @@ import (
 	"github.com/zhubert/plural/internal/cli"
 	"github.com/zhubert/plural/internal/config"
 	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/mcp"
+	"github.com/zhubert/plural/internal/web"
 )
 
 var (
 	debugMode             bool
 	quietMode             bool
+	debugMCP              bool
+	inlineMode            bool
+	shareLAN              bool
+	serveAddr             string
+	resumeLast            bool
 	version, commit, date string
 )
 
@@ -37,8 +44,22 @@ func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", true, "Enable debug logging (on by default)")
 	rootCmd.PersistentFlags().BoolVarP(&quietMode, "quiet", "q", false, "Reduce logging to info level only")
+	rootCmd.PersistentFlags().BoolVar(&debugMCP, "debug-mcp", false, debugMCPUsage)
+	rootCmd.Flags().BoolVar(&inlineMode, "inline", false, "Run without the alternate screen or mouse capture, showing only the chat (for logging/capture)")
+	rootCmd.Flags().BoolVar(&shareLAN, "share-lan", false, shareLANUsage)
+	rootCmd.Flags().StringVar(&serveAddr, "serve", "", serveUsage)
+	rootCmd.Flags().BoolVar(&resumeLast, "resume-last", false, "Open the most recently active session on startup (default from resume_last_session in config)")
 }
 
+// shareLANUsage describes the --share-lan flag.
+const shareLANUsage = "Let shared sessions be watched from other machines on the local network (default: localhost only)"
+
+// debugMCPUsage describes the --debug-mcp flag.
+const debugMCPUsage = "Trace every message between the TUI and MCP servers in the sessions' MCP logs (see plural mcp-trace; toggle with m in the log viewer)"
+
+// serveUsage describes the --serve flag.
+const serveUsage = "Serve a read-only web view of the selected session at this address, e.g. :8099 (localhost unless a host is given)"
+
 func initConfig() {
 	if quietMode {
 		logger.SetDebug(false)
@@ -63,6 +84,11 @@ func versionTemplate() string {
 }
 
 func runTUI(cmd *cobra.Command, args []string) error {
+	return runApp("")
+}
+
+// runApp runs the TUI, opening the session startupSessionID if it is non-empty.
+func runApp(startupSessionID string) error {
 	// Validate prerequisites
 	prereqs := cli.DefaultPrerequisites()
 	if err := cli.ValidateRequired(prereqs); err != nil {
@@ -78,9 +104,34 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	// Ensure logger is closed on exit
 	defer logger.Close()
 
+	// Off unless asked for, even if a previous run left it on
+	if err := mcp.SetWireTrace(debugMCP); err != nil {
+		logger.Get().Warn("failed to set MCP wire tracing", "error", err)
+	}
+
 	// Create and run the app
 	m := app.New(cfg, version)
 	defer m.Close()
+	if inlineMode {
+		m.SetLayout(app.LayoutInline)
+	}
+	if shareLAN {
+		m.SetShareLAN(true)
+	}
+	if serveAddr != "" {
+		server, err := web.Start(serveAddr)
+		if err != nil {
+			return err
+		}
+		m.SetWebView(server)
+	}
+	if startupSessionID != "" {
+		if err := m.OpenSessionOnStartup(startupSessionID); err != nil {
+			return fmt.Errorf("%w\n\n%s", err, describeSessions(cfg.GetSessions()))
+		}
+	} else if resumeLast || cfg.GetResumeLastSession() {
+		m.ResumeLastSessionOnStartup()
+	}
 	p := tea.NewProgram(m)
 
 	if _, err := p.Run(); err != nil {
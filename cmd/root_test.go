@@ -27,6 +27,26 @@ func TestQuietFlagExists(t *testing.T) {
 	}
 }
 
+func TestInlineFlagExists(t *testing.T) {
+	flag := rootCmd.Flags().Lookup("inline")
+	if flag == nil {
+		t.Fatal("--inline flag not found")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("--inline default = %q, want %q", flag.DefValue, "false")
+	}
+}
+
+func TestServeFlagExists(t *testing.T) {
+	flag := rootCmd.Flags().Lookup("serve")
+	if flag == nil {
+		t.Fatal("--serve flag not found")
+	}
+	if flag.DefValue != "" {
+		t.Errorf("--serve default = %q, want no web view", flag.DefValue)
+	}
+}
+
 func TestInitConfig_DefaultDebugEnabled(t *testing.T) {
 	// Save and restore package state
 	origDebug, origQuiet := debugMode, quietMode
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/spf13/cobra"
+	"github.com/zhubert/plural/internal/share"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <url>",
+	Short: "Watch a shared session read-only",
+	Long: `Connects to a session someone is sharing from plural (press S on a session)
+and shows its transcript live in a read-only view. Nothing you type is sent to
+the shared session.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	shareURL, err := parseShareURL(args[0])
+	if err != nil {
+		return err
+	}
+	if _, err := tea.NewProgram(share.NewViewer(shareURL)).Run(); err != nil {
+		return fmt.Errorf("error running viewer: %w", err)
+	}
+	return nil
+}
+
+// parseShareURL validates a share URL as printed by the host.
+func parseShareURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid share URL %q: expected http://host:port/share/<token>/events", raw)
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import "testing"
+
+func TestWatchCommandRequiresURL(t *testing.T) {
+	if err := watchCmd.Args(watchCmd, nil); err == nil {
+		t.Error("watch should require a URL")
+	}
+	if err := watchCmd.Args(watchCmd, []string{"http://127.0.0.1:1/share/x/events"}); err != nil {
+		t.Errorf("watch should accept one URL, got %v", err)
+	}
+}
+
+func TestParseShareURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "http://127.0.0.1:4000/share/abc/events", want: "http://127.0.0.1:4000/share/abc/events"},
+		{raw: "  http://192.168.1.5:4000/share/abc/events\n", want: "http://192.168.1.5:4000/share/abc/events"},
+		{raw: "127.0.0.1:4000/share/abc/events", wantErr: true},
+		{raw: "ftp://host/share/abc/events", wantErr: true},
+		{raw: "http:///share/abc/events", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseShareURL(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseShareURL(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseShareURL(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
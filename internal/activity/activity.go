@@ -0,0 +1,238 @@
+// Package activity maintains a chronological feed of notable events across all sessions.
+//
+// Events are kept in a bounded in-memory ring for the feed view and appended to
+// a per-day JSONL file in the state directory for later inspection. Writes happen
+// in the background, so recording an event never waits on the disk.
+package activity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/paths"
+)
+
+// DefaultCapacity is the number of events retained in memory.
+const DefaultCapacity = 500
+
+// Kind identifies the type of an activity event.
+type Kind string
+
+const (
+	KindResponseCompleted    Kind = "response_completed"
+	KindPermissionRequested  Kind = "permission_requested"
+	KindPermissionResolved   Kind = "permission_resolved"
+	KindQuestionAsked        Kind = "question_asked"
+	KindPlanApprovalRequired Kind = "plan_approval_requested"
+	KindMerged               Kind = "merged"
+	KindPRCreated            Kind = "pr_created"
+	KindPRMerged             Kind = "pr_merged"
+	KindPRClosed             Kind = "pr_closed"
+	KindCIFailed             Kind = "ci_failed"
+	KindError                Kind = "error"
+)
+
+// Severity controls how an event is highlighted in the feed.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeveritySuccess Severity = "success"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Event is a single notable occurrence in a session.
+type Event struct {
+	Time        time.Time `json:"time"`
+	SessionID   string    `json:"session_id"`
+	SessionName string    `json:"session_name,omitempty"`
+	Kind        Kind      `json:"kind"`
+	Severity    Severity  `json:"severity"`
+	Message     string    `json:"message"`
+}
+
+// Feed is a thread-safe bounded ring of events.
+type Feed struct {
+	mu      sync.Mutex
+	events  []Event       // Ring buffer storage
+	next    int           // Index where the next event will be written
+	count   int           // Number of events currently stored
+	dir     string        // Directory for per-day JSONL files (empty disables persistence)
+	pending []Event       // Events recorded but not yet written to disk
+	err     error         // Last error writing events to disk
+	closed  bool          // Whether Close has stopped the writer
+	wake    chan struct{} // Signals the writer that events are pending
+	done    chan struct{} // Closed once the writer has exited
+
+	flushMu sync.Mutex // Serializes writes so events land in order
+}
+
+// NewFeed creates a feed retaining up to capacity events.
+// If dir is non-empty, every recorded event is also appended to <dir>/YYYY-MM-DD.jsonl
+// by a background writer, which Close stops.
+func NewFeed(capacity int, dir string) *Feed {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	f := &Feed{
+		events: make([]Event, capacity),
+		dir:    dir,
+	}
+	if dir != "" {
+		f.wake = make(chan struct{}, 1)
+		f.done = make(chan struct{})
+		go f.writeLoop()
+	}
+	return f
+}
+
+// DefaultDir returns the directory where activity logs are written.
+func DefaultDir() (string, error) {
+	return paths.ActivityDir()
+}
+
+// Record adds an event to the feed, stamping it with the current time if unset.
+// The event is kept in memory at once and queued to be written to disk.
+func (f *Feed) Record(e Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	f.events[f.next] = e
+	f.next = (f.next + 1) % len(f.events)
+	if f.count < len(f.events) {
+		f.count++
+	}
+
+	if f.wake == nil || f.closed {
+		return
+	}
+	f.pending = append(f.pending, e)
+	select {
+	case f.wake <- struct{}{}:
+	default: // The writer is already due to run
+	}
+}
+
+// Flush writes the events recorded since the last flush to their day files.
+func (f *Feed) Flush() error {
+	f.flushMu.Lock()
+	defer f.flushMu.Unlock()
+
+	f.mu.Lock()
+	pending := f.pending
+	f.pending = nil
+	f.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+	err := appendEvents(f.dir, pending)
+	if err != nil {
+		f.mu.Lock()
+		f.err = err
+		f.mu.Unlock()
+	}
+	return err
+}
+
+// Close writes any events still pending and stops the background writer.
+// Returns the last error writing events to disk, if any.
+func (f *Feed) Close() error {
+	f.mu.Lock()
+	if f.wake == nil || f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	close(f.wake)
+	f.mu.Unlock()
+
+	<-f.done
+	f.Flush()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// writeLoop writes pending events each time Record signals, until Close.
+func (f *Feed) writeLoop() {
+	defer close(f.done)
+	for range f.wake {
+		if err := f.Flush(); err != nil {
+			logger.WithComponent("activity").Warn("failed to persist activity events", "error", err)
+		}
+	}
+}
+
+// Events returns a copy of the stored events, newest first.
+func (f *Feed) Events() []Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := make([]Event, 0, f.count)
+	for i := 1; i <= f.count; i++ {
+		idx := (f.next - i + len(f.events)) % len(f.events)
+		result = append(result, f.events[idx])
+	}
+	return result
+}
+
+// Len returns the number of events currently stored.
+func (f *Feed) Len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.count
+}
+
+// DayFilePath returns the JSONL file path for events recorded on the given day.
+func DayFilePath(dir string, t time.Time) string {
+	return filepath.Join(dir, t.Format("2006-01-02")+".jsonl")
+}
+
+// appendEvents writes each event as a single JSON line to its day file.
+func appendEvents(dir string, events []Event) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create activity directory: %w", err)
+	}
+
+	// Open each day file once per run of consecutive events recorded that day
+	for len(events) > 0 {
+		path := DayFilePath(dir, events[0].Time)
+		var data []byte
+		for len(events) > 0 && DayFilePath(dir, events[0].Time) == path {
+			line, err := json.Marshal(events[0])
+			if err != nil {
+				return fmt.Errorf("failed to marshal activity event: %w", err)
+			}
+			data = append(append(data, line...), '\n')
+			events = events[1:]
+		}
+		if err := appendFile(path, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendFile appends data to the file at path, creating it if needed.
+func appendFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open activity log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write activity event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package activity
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeed_EventsNewestFirst(t *testing.T) {
+	f := NewFeed(10, "")
+	for i := range 3 {
+		f.Record(Event{SessionID: fmt.Sprintf("s%d", i), Message: "msg"})
+	}
+
+	events := f.Events()
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	want := []string{"s2", "s1", "s0"}
+	for i, e := range events {
+		if e.SessionID != want[i] {
+			t.Errorf("events[%d].SessionID = %q, want %q", i, e.SessionID, want[i])
+		}
+		if e.Time.IsZero() {
+			t.Errorf("events[%d] should be stamped with a time", i)
+		}
+	}
+}
+
+func TestFeed_RingBounded(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		records  int
+		wantLen  int
+		newest   string
+		oldest   string
+	}{
+		{name: "under capacity", capacity: 5, records: 3, wantLen: 3, newest: "e2", oldest: "e0"},
+		{name: "exactly full", capacity: 3, records: 3, wantLen: 3, newest: "e2", oldest: "e0"},
+		{name: "wraps around", capacity: 3, records: 7, wantLen: 3, newest: "e6", oldest: "e4"},
+		{name: "zero capacity uses default", capacity: 0, records: DefaultCapacity + 2, wantLen: DefaultCapacity, newest: fmt.Sprintf("e%d", DefaultCapacity+1), oldest: "e2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFeed(tt.capacity, "")
+			for i := range tt.records {
+				f.Record(Event{Message: fmt.Sprintf("e%d", i)})
+			}
+
+			if f.Len() != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", f.Len(), tt.wantLen)
+			}
+			events := f.Events()
+			if len(events) != tt.wantLen {
+				t.Fatalf("len(Events()) = %d, want %d", len(events), tt.wantLen)
+			}
+			if events[0].Message != tt.newest {
+				t.Errorf("newest = %q, want %q", events[0].Message, tt.newest)
+			}
+			if events[len(events)-1].Message != tt.oldest {
+				t.Errorf("oldest = %q, want %q", events[len(events)-1].Message, tt.oldest)
+			}
+		})
+	}
+}
+
+func TestFeed_EventsReturnsCopy(t *testing.T) {
+	f := NewFeed(3, "")
+	f.Record(Event{Message: "original"})
+
+	events := f.Events()
+	events[0].Message = "mutated"
+
+	if got := f.Events()[0].Message; got != "original" {
+		t.Errorf("feed was mutated through Events() result: got %q", got)
+	}
+}
+
+func TestFeed_PersistsPerDayJSONL(t *testing.T) {
+	dir := t.TempDir()
+	f := NewFeed(10, dir)
+
+	day1 := time.Date(2025, 3, 1, 23, 59, 0, 0, time.Local)
+	day2 := time.Date(2025, 3, 2, 0, 1, 0, 0, time.Local)
+
+	events := []Event{
+		{Time: day1, SessionID: "a", SessionName: "alpha", Kind: KindResponseCompleted, Severity: SeveritySuccess, Message: "Response completed"},
+		{Time: day1, SessionID: "b", Kind: KindError, Severity: SeverityError, Message: "boom"},
+		{Time: day2, SessionID: "a", Kind: KindMerged, Severity: SeveritySuccess, Message: "Merged"},
+	}
+	for _, e := range events {
+		f.Record(e)
+	}
+	// Close waits for the background writer and writes what it hasn't yet
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	readLines := func(path string) []Event {
+		t.Helper()
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("open %s: %v", path, err)
+		}
+		defer file.Close()
+
+		var result []Event
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var e Event
+			if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+				t.Fatalf("invalid JSONL line %q: %v", scanner.Text(), err)
+			}
+			result = append(result, e)
+		}
+		return result
+	}
+
+	got1 := readLines(DayFilePath(dir, day1))
+	if len(got1) != 2 {
+		t.Fatalf("expected 2 events in day1 file, got %d", len(got1))
+	}
+	if got1[0].SessionName != "alpha" || got1[0].Kind != KindResponseCompleted {
+		t.Errorf("unexpected first event: %+v", got1[0])
+	}
+	if got1[1].Severity != SeverityError {
+		t.Errorf("expected error severity, got %q", got1[1].Severity)
+	}
+
+	got2 := readLines(DayFilePath(dir, day2))
+	if len(got2) != 1 || got2[0].Kind != KindMerged {
+		t.Errorf("unexpected day2 events: %+v", got2)
+	}
+}
+
+func TestFeed_PersistenceErrorKeepsEvent(t *testing.T) {
+	// Use a regular file as the directory so MkdirAll fails
+	blocker := t.TempDir() + "/not-a-dir"
+	if err := os.WriteFile(blocker, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewFeed(3, blocker)
+	f.Record(Event{Message: "kept"})
+	if err := f.Close(); err == nil {
+		t.Error("expected persistence error")
+	}
+	if f.Len() != 1 {
+		t.Errorf("event should still be kept in memory, Len() = %d", f.Len())
+	}
+}
+
+func TestFeed_RecordAfterCloseKeepsEventInMemory(t *testing.T) {
+	dir := t.TempDir()
+	day := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	f := NewFeed(3, dir)
+	f.Record(Event{Time: day, Message: "written"})
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f.Record(Event{Time: day, Message: "late"})
+	if f.Len() != 2 {
+		t.Errorf("expected both events in memory, Len() = %d", f.Len())
+	}
+	data, err := os.ReadFile(DayFilePath(dir, day))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 1 {
+		t.Errorf("expected only the event recorded before Close on disk, got %d lines", lines)
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
+
+func TestDayFilePath(t *testing.T) {
+	got := DayFilePath("/state/activity", time.Date(2025, 12, 31, 10, 0, 0, 0, time.UTC))
+	if want := "/state/activity/2025-12-31.jsonl"; got != want {
+		t.Errorf("DayFilePath = %q, want %q", got, want)
+	}
+}
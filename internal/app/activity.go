@@ -0,0 +1,71 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/activity"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// newActivityFeed creates the global activity feed, persisting to the state directory when available.
+func newActivityFeed() *activity.Feed {
+	dir, err := activity.DefaultDir()
+	if err != nil {
+		logger.Get().Warn("activity log directory unavailable, feed will not be persisted", "error", err)
+		dir = ""
+	}
+	return activity.NewFeed(activity.DefaultCapacity, dir)
+}
+
+// recordActivity adds a notable session event to the global activity feed.
+func (m *Model) recordActivity(sessionID string, kind activity.Kind, severity activity.Severity, message string) {
+	sessionName := sessionID
+	if sess := m.config.GetSession(sessionID); sess != nil {
+		sessionName = ui.SessionDisplayName(sess.Branch, sess.Name)
+	}
+
+	event := activity.Event{
+		SessionID:   sessionID,
+		SessionName: sessionName,
+		Kind:        kind,
+		Severity:    severity,
+		Message:     message,
+	}
+	if m.heldByFocusMode(sessionID) {
+		m.focusMode.missed = append(m.focusMode.missed, event)
+	}
+	if m.activity == nil {
+		return
+	}
+	m.activity.Record(event)
+
+	// Keep an open feed live
+	if m.chat.IsInActivityFeedMode() {
+		m.chat.SetActivityFeedEvents(m.activity.Events())
+	}
+}
+
+// jumpToActivitySession closes the activity feed and selects the session of the highlighted event.
+func (m *Model) jumpToActivitySession() (tea.Model, tea.Cmd) {
+	event := m.chat.SelectedActivityEvent()
+	if event == nil {
+		return m, nil
+	}
+	sessionID := event.SessionID
+	m.chat.ExitActivityFeedMode()
+
+	sess := m.config.GetSession(sessionID)
+	if sess == nil {
+		return m, m.ShowFlashWarning("Session no longer exists")
+	}
+
+	m.sidebar.SelectSession(sess.ID)
+	if m.activeSession == nil || m.activeSession.ID != sess.ID {
+		m.selectSession(sess)
+	} else {
+		m.focus = FocusChat
+		m.sidebar.SetFocused(false)
+		m.chat.SetFocused(true)
+	}
+	return m, nil
+}
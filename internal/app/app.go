@@ -4,16 +4,20 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/activity"
 	"github.com/zhubert/plural/internal/changelog"
 	"github.com/zhubert/plural/internal/claude"
 	"github.com/zhubert/plural/internal/claudeconfig"
 	"github.com/zhubert/plural/internal/clipboard"
 	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/fileindex"
 	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/issues"
 	"github.com/zhubert/plural/internal/keys"
@@ -23,7 +27,9 @@ import (
 	"github.com/zhubert/plural/internal/plugins"
 	"github.com/zhubert/plural/internal/process"
 	"github.com/zhubert/plural/internal/session"
+	"github.com/zhubert/plural/internal/share"
 	"github.com/zhubert/plural/internal/ui"
+	"github.com/zhubert/plural/internal/web"
 )
 
 // Focus represents which panel is focused
@@ -96,13 +102,96 @@ type Model struct {
 	// Pending container action to execute after async prerequisite checks pass (nil when inactive)
 	pendingContainerAction func() (tea.Model, tea.Cmd)
 
+	// Global activity feed of notable events across all sessions
+	activity *activity.Feed
+
+	// Paste cleaning state
+	pasteUndo         *PasteUndo      // Last cleaned paste that can be restored (nil when none)
+	pasteCleanChoices map[string]bool // Per-session answer to the paste cleaning prompt (true = clean)
+
+	// Question auto-answers waiting out their grace period, by session ID
+	autoAnswers   map[string]*PendingAutoAnswer
+	autoAnswerSeq int
+
+	// Whether the last message history autosave failed (suppresses repeat flashes)
+	autosaveFailing bool
+
 	// Terminal capability flags
 	kittyKeyboard bool // Terminal supports Kitty keyboard protocol (Shift+Enter distinguishable)
+
+	// Layout chosen at startup (split or inline)
+	layout Layout
+
+	// Session to open on startup (plural open <session-id>), empty for none
+	startupSessionID string
+
+	// Installed Claude CLI's version as last asked, empty until known
+	claudeVersion string
+
+	// Read-only share servers by session ID, and whether they listen on the LAN
+	shares   map[string]*share.Server
+	shareLAN bool
+
+	// Cached file indexes of worktrees for the file picker, by session ID; nil while being built
+	fileIndexes map[string]*fileindex.Index
+
+	// Web view mirroring the active session (plural --serve), nil when not serving
+	webView *web.Server
+
+	// Cached changed files per session, and the overlaps between sessions derived from them
+	changedFiles map[string][]string
+	overlaps     map[string][]FileOverlap
+
+	// Time of the last clock check, used to detect system sleep
+	lastClockCheck time.Time
+
+	// Runs periodic jobs (polling, autosave, clock checks) off a single ticker
+	scheduler *scheduler
+
+	// Session whose message history is loading in the background, or ""
+	loadingSessionID string
+
+	// Whether all sessions are paused: in-progress turns were interrupted and messages are held until resumed
+	paused bool
+
+	// Focus mode state: only the active session's chat is shown (nil when off)
+	focusMode *focusModeState
+
+	// Sessions whose PR's CI is failing, and the CI runs whose failing logs were
+	// last sent to each session (see ciRunsKey)
+	ciFailing     map[string]bool
+	ciLogsSent    map[string]string
+	ciLogsLoading map[string]bool
+
+	// Tool uses that may write files, by tool use ID, awaiting their results
+	pendingWrites map[string]pendingWrite
+
+	// Newer release found by the update check (nil if none)
+	availableUpdate *changelog.Release
+
+	// Session whose worktree is being created in the background (nil if none)
+	pendingCreate *pendingSessionCreate
+
+	// Sessions being deleted from the Bulk Action modal (nil if none)
+	pendingBulkDelete *pendingBulkDelete
+
+	// Sessions whose next merge or PR skips the repo hooks, and hooks running by session
+	skipHooks map[string]bool
+	hookRuns  map[string]*hookRun
+
+	// Sessions archived once their merge, and any hooks after it, succeed
+	archiveAfterMerge map[string]bool
 }
 
 // StartupModalMsg is sent on app start to trigger welcome/changelog modals
 type StartupModalMsg struct{}
 
+// OpenSessionMsg is sent on app start to select and focus the session requested on the command line,
+// or the most recently active one when resuming
+type OpenSessionMsg struct {
+	SessionID string
+}
+
 // ClaudeResponseMsg is sent when Claude sends a response chunk
 type ClaudeResponseMsg struct {
 	SessionID string
@@ -247,10 +336,37 @@ func New(cfg *config.Config, version string) *Model {
 	if savedTheme == "" {
 		savedTheme = string(ui.DefaultTheme)
 	}
+	// Until the terminal answers with its background color, COLORFGBG tells it
+	if dark, ok := colorFGBGIsDark(os.Getenv("COLORFGBG")); ok && followsBackground(cfg) {
+		savedTheme = themeForBackground(cfg, dark)
+	}
 	ui.SetThemeByName(savedTheme)
 
+	// Register a custom syntax style from a file, so that it can be selected
+	if path := cfg.GetCustomSyntaxStyle(); path != "" {
+		name, err := ui.LoadSyntaxStyle(path)
+		if err == nil {
+			logger.Get().Info("loaded custom syntax style", "name", name, "path", path)
+		}
+		cfg.CheckCustomSyntaxStyle(err)
+	}
+	ui.SetSyntaxStyle(cfg.GetSyntaxStyle())
+	ui.SetToolCategoryColors(cfg.GetToolCategoryColors())
+	emphasize, warn, suggestCancel := cfg.GetThinkingEscalation()
+	ui.SetThinkingEscalation(ui.ThinkingEscalation{Emphasize: emphasize, Warn: warn, SuggestCancel: suggestCancel})
+	ui.GetViewContext().SetMaxChatWidth(cfg.GetMaxChatWidth())
+
+	// Report config problems that still let it load; the theme list lives in ui
+	var themes []string
+	for _, name := range ui.ThemeNames() {
+		themes = append(themes, string(name))
+	}
+	cfg.CheckTheme(themes)
+	logConfigProblems(cfg)
+
 	gitSvc := git.NewGitService()
 	sessionSvc := session.NewSessionService()
+	sessionSvc.SetWorktreeDirs(cfg.GetWorktreeDir)
 
 	// Migrate worktrees from legacy .plural-worktrees to centralized directory
 	if err := sessionSvc.MigrateWorktrees(context.Background(), cfg); err != nil {
@@ -278,15 +394,38 @@ func New(cfg *config.Config, version string) *Model {
 		issueRegistry:  issueRegistry,
 		state:          StateIdle,
 		windowFocused:  true, // Assume window is focused on startup
+		activity:       newActivityFeed(),
+
+		pasteCleanChoices: make(map[string]bool),
+		autoAnswers:       make(map[string]*PendingAutoAnswer),
+		shares:            make(map[string]*share.Server),
+		fileIndexes:       make(map[string]*fileindex.Index),
+		changedFiles:      make(map[string][]string),
+		ciFailing:         make(map[string]bool),
+		ciLogsSent:        make(map[string]string),
+		ciLogsLoading:     make(map[string]bool),
+		pendingWrites:     make(map[string]pendingWrite),
+		skipHooks:         make(map[string]bool),
+		hookRuns:          make(map[string]*hookRun),
+		archiveAfterMerge: make(map[string]bool),
 	}
 
 	// Configure footer to use shortcut registry for dynamic bindings
 	m.footer.SetBindingsGenerator(m.getApplicableFooterBindings)
 
 	// Load sessions into sidebar (filtered by active workspace)
+	m.sidebar.SetRepos(cfg.GetRepos())
 	m.sidebar.SetSessions(m.getFilteredSessions())
 	m.sidebar.SetFocused(true)
 
+	m.chat.SetCompactToolUses(cfg.GetCompactToolUses())
+	m.chat.SetSanitizePaste(cfg.GetPasteSanitize())
+	m.chat.SetCompletionFlash(cfg.GetCompletionFlashMs(), cfg.GetCompletionFlashStats())
+	clipboard.SetPreference(cfg.GetClipboard())
+
+	m.scheduler = m.newScheduler(time.Now())
+	m.sidebar.SetBackgroundPaused(m.scheduler.paused)
+
 	// Restore preview state from config (in case app was closed during a preview)
 	if cfg.IsPreviewActive() {
 		m.header.SetPreviewActive(true)
@@ -299,7 +438,14 @@ func New(cfg *config.Config, version string) *Model {
 // This should be called when the application is exiting.
 func (m *Model) Close() {
 	logger.Get().Info("closing and shutting down all sessions")
+	m.stopAllShares()
+	m.stopWebView()
 	m.sessionMgr.Shutdown()
+	if m.activity != nil {
+		if err := m.activity.Close(); err != nil {
+			logger.Get().Warn("failed to persist activity events", "error", err)
+		}
+	}
 }
 
 // State helper methods
@@ -318,6 +464,10 @@ func (m *Model) CanSendMessage() bool {
 	if m.activeSession.MergedToParent {
 		return false
 	}
+	// Sessions whose repo or worktree was deleted have nowhere to run
+	if m.sidebar.IsMissing(m.activeSession.ID) {
+		return false
+	}
 	// Check if the active session is currently waiting for a response, has a merge in progress,
 	// or has a container initializing. Each session can operate independently.
 	state := m.sessionMgr.StateManager().GetIfExists(m.activeSession.ID)
@@ -340,9 +490,15 @@ func (m *Model) sessionState() *manager.SessionStateManager {
 	return m.sessionMgr.StateManager()
 }
 
-// getFilteredSessions returns all sessions.
+// getFilteredSessions returns the sessions listed in the sidebar: all of
+// them, or only those with the tag it is filtered by.
 func (m *Model) getFilteredSessions() []config.Session {
-	return m.config.GetSessions()
+	sessions := m.config.GetSessions()
+	tag := m.sidebar.TagFilter()
+	if tag == "" {
+		return sessions
+	}
+	return slices.DeleteFunc(sessions, func(sess config.Session) bool { return !sess.HasTag(tag) })
 }
 
 // refreshDiffStats updates the header with current git diff statistics for the active session
@@ -379,10 +535,63 @@ func (m *Model) Init() tea.Cmd {
 		func() tea.Msg {
 			return StartupModalMsg{}
 		},
-		PRPollTick(),
+		SchedulerTick(),
+		m.pollChangedFiles(),
+		m.refreshMissingSessions(),
+		m.openStartupSession(),
+		m.checkForUpdate(),
+		m.requestBackgroundColor(),
+		refreshClaudeVersion(),
 	)
 }
 
+// OpenSessionOnStartup selects and focuses the given session once the app starts.
+// Must be called before the program starts. Returns an error if no session has that ID.
+func (m *Model) OpenSessionOnStartup(sessionID string) error {
+	if m.config.GetSession(sessionID) == nil {
+		return fmt.Errorf("no session with ID %q", sessionID)
+	}
+	m.startupSessionID = sessionID
+	return nil
+}
+
+// ResumeLastSessionOnStartup opens the most recently active session once the app
+// starts. Must be called before the program starts. Returns false if there are no sessions.
+func (m *Model) ResumeLastSessionOnStartup() bool {
+	sess := m.config.MostRecentSession()
+	if sess == nil {
+		return false
+	}
+	m.startupSessionID = sess.ID
+	return true
+}
+
+// openStartupSession returns a command that opens the session requested on the
+// command line, or nil if none was.
+func (m *Model) openStartupSession() tea.Cmd {
+	if m.startupSessionID == "" {
+		return nil
+	}
+	sessionID := m.startupSessionID
+	return func() tea.Msg {
+		return OpenSessionMsg{SessionID: sessionID}
+	}
+}
+
+// handleOpenSessionMsg selects the session in the sidebar and focuses its chat.
+func (m *Model) handleOpenSessionMsg(msg OpenSessionMsg) (tea.Model, tea.Cmd) {
+	sess := m.config.GetSession(msg.SessionID)
+	if sess == nil {
+		// Deleted since startup validation
+		return m, m.ShowFlashError(fmt.Sprintf("Session %s no longer exists", msg.SessionID))
+	}
+	logger.WithSession(sess.ID).Info("opening session on startup")
+	m.sidebar.SelectSession(sess.ID)
+	if m.activeSession == nil || m.activeSession.ID != sess.ID {
+		m.selectSession(sess)
+	}
+	return m, nil
+}
 
 // Update handles messages
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -396,10 +605,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.FocusMsg:
 		m.windowFocused = true
+		m.scheduler.blurred = false
 		logger.Get().Debug("window focused")
+		// The OS appearance may have changed while away
+		cmds = append(cmds, m.requestBackgroundColor())
+
+	case tea.BackgroundColorMsg:
+		m.applyTerminalBackground(msg.IsDark())
 
 	case tea.BlurMsg:
 		m.windowFocused = false
+		m.scheduler.blurred = true
 		logger.Get().Debug("window blurred")
 
 	case tea.KeyboardEnhancementsMsg:
@@ -427,6 +643,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			preview = preview[:ui.PasteContentPreviewLen] + "..."
 		}
 		logger.Get().Debug("paste received", "length", len(content), "preview", preview)
+		if m.focus == FocusChat && m.activeSession != nil && !m.modal.IsVisible() {
+			if cmd, handled := m.handleTextPaste(content); handled {
+				return m, cmd
+			}
+		}
 
 	case tea.KeyPressMsg:
 		logger.Get().Debug("key press received", "key", msg.String(), "focus", m.focus, "modalVisible", m.modal.IsVisible())
@@ -436,6 +657,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleModalKey(msg)
 		}
 
+		// Todo focus mode takes every key but Ctrl+C (see shortcutTodoFocus)
+		if m.chat.IsInTodoFocusMode() && msg.String() != keys.CtrlC {
+			chat, cmd := m.chat.Update(msg)
+			m.chat = chat
+			return m, cmd
+		}
+
 		// Handle Escape to exit multi-select mode, search mode, view changes mode, log viewer, or interrupt streaming
 		if msg.String() == keys.Escape {
 			// First check if sidebar is in multi-select mode
@@ -448,6 +676,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.sidebar.ExitSearchMode()
 				return m, nil
 			}
+			// Then clear a search filter kept after leaving search mode
+			if m.sidebar.HasFilter() && m.sidebar.IsFocused() {
+				m.sidebar.ClearFilter()
+				return m, nil
+			}
 			// Check if view changes mode is active (regardless of focus)
 			if m.chat.IsInViewChangesMode() {
 				m.chat.ExitViewChangesMode()
@@ -458,42 +691,33 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.chat.ExitLogViewerMode()
 				return m, nil
 			}
+			// Check if the live diff is showing (regardless of focus)
+			if m.chat.IsInLiveDiffMode() {
+				m.exitLiveDiff()
+				return m, nil
+			}
+			// Check if activity feed is active (regardless of focus)
+			if m.chat.IsInActivityFeedMode() {
+				m.chat.ExitActivityFeedMode()
+				return m, nil
+			}
 			// Then check for streaming interruption
 			if m.activeSession != nil {
-				if state := m.sessionState().GetIfExists(m.activeSession.ID); state != nil {
-					if cancel := state.GetStreamCancel(); cancel != nil {
-						logger.WithSession(m.activeSession.ID).Debug("interrupting streaming")
-						cancel()
-						// Send SIGINT to interrupt the Claude process (handles sub-agent work)
-						if m.claudeRunner != nil {
-							if err := m.claudeRunner.Interrupt(); err != nil {
-								logger.WithSession(m.activeSession.ID).Error("failed to interrupt Claude", "error", err)
-							}
-						}
-						m.sessionState().StopWaiting(m.activeSession.ID)
-						m.sidebar.SetStreaming(m.activeSession.ID, false)
-						m.chat.SetWaiting(false)
-						// Save partial response to runner before finishing
-						var saveErr error
-						if content := m.chat.GetStreaming(); content != "" {
-							m.claudeRunner.AddAssistantMessage(content + "\n[Interrupted]")
-							saveErr = m.sessionMgr.SaveRunnerMessages(m.activeSession.ID, m.claudeRunner)
-						}
-						m.chat.AppendStreaming("\n[Interrupted]\n")
-						m.chat.FinishStreaming()
-						// Check if any sessions are still streaming
-						if !m.hasAnyStreamingSessions() {
-							m.setState(StateIdle)
-						}
-						if saveErr != nil {
-							return m, m.ShowFlashError("Failed to save session messages")
-						}
-						return m, nil
-					}
+				interrupted, saveErr := m.interruptSession(m.activeSession.ID)
+				if saveErr != nil {
+					return m, m.ShowFlashError("Failed to save session messages")
+				}
+				if interrupted {
+					return m, nil
 				}
 			}
 		}
 
+		// Enter in the activity feed jumps to the highlighted event's session
+		if msg.String() == keys.Enter && m.chat.IsInActivityFeedMode() {
+			return m.jumpToActivitySession()
+		}
+
 		// Handle chat-focused keys when chat is focused with an active session
 		if m.focus == FocusChat && m.activeSession != nil {
 			key := msg.String()
@@ -502,15 +726,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			state := m.sessionState().GetIfExists(m.activeSession.ID)
 			if state != nil {
 				if req := state.GetPendingPermission(); req != nil {
-					switch key {
-					case "y", "Y", "n", "N", "a", "A":
-						return m.handlePermissionResponse(key, m.activeSession.ID, req)
+					if m.chat.HasDestructivePermission() {
+						if isConfirmationKey(key) {
+							return m.handleDestructivePermissionKey(key, m.activeSession.ID, req)
+						}
+					} else {
+						switch key {
+						case "y", "Y", "n", "N", "a", "A":
+							return m.handlePermissionResponse(key, m.activeSession.ID, req)
+						}
 					}
 				}
 			}
 
-			// Question response (reuse state from permission check)
-			if state != nil && state.GetPendingQuestion() != nil {
+			// Question response (reuse state from permission check); questions being
+			// auto-answered are not shown, so keys go to the input as usual
+			if state != nil && state.GetPendingQuestion() != nil && !m.canUndoAutoAnswer() {
 				switch key {
 				case "1", "2", "3", "4", "5":
 					num := int(key[0] - '0')
@@ -548,6 +779,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+			// Ctrl+; opens the snippet picker
+			if key == keys.CtrlSemi {
+				return m.showSnippetPicker()
+			}
+
+			// Opt+O opens the file picker (ctrl+f is left to the live diff toggle, and
+			// opt+f to the input's word forward)
+			if key == keys.AltO && !m.chat.IsInLiveDiffMode() {
+				return m.showFilePicker()
+			}
+
 			// Ctrl+V for image pasting (fallback for terminals that send raw key presses)
 			if key == keys.CtrlV {
 				return m.handleImagePaste()
@@ -617,15 +859,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case keys.Enter:
 			switch m.focus {
 			case FocusSidebar:
+				// Leave search mode, keeping the filter
+				if m.sidebar.IsSearchMode() {
+					m.sidebar, _ = m.sidebar.Update(msg)
+				}
 				// Select session
 				if sess := m.sidebar.SelectedSession(); sess != nil {
 					if m.activeSession == nil || m.activeSession.ID != sess.ID {
-						m.selectSession(sess)
-					} else {
-						m.focus = FocusChat
-						m.sidebar.SetFocused(false)
-						m.chat.SetFocused(true)
+						return m, m.openSession(sess)
 					}
+					m.focus = FocusChat
+					m.sidebar.SetFocused(false)
+					m.chat.SetFocused(true)
 					return m, nil
 				}
 			case FocusChat:
@@ -637,7 +882,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					sessState := m.sessionState().GetIfExists(m.activeSession.ID)
 					if sessState != nil && sessState.GetIsWaiting() {
 						input := m.chat.GetInput()
-						if input != "" {
+						if strings.TrimSpace(input) != "" {
 							sessState.SetPendingMsg(input)
 							m.chat.ClearInput()
 							m.chat.SetQueuedMessage(input)
@@ -651,12 +896,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ClaudeResponseMsg:
 		return m.handleClaudeResponseMsg(msg)
 
+	case FileIndexMsg:
+		return m.handleFileIndexMsg(msg)
+
 	case PermissionRequestMsg:
 		return m.handlePermissionRequestMsg(msg)
 
 	case QuestionRequestMsg:
 		return m.handleQuestionRequestMsg(msg)
 
+	case AutoAnswerQuestionMsg:
+		return m.handleAutoAnswerQuestionMsg(msg)
+
 	case PlanApprovalRequestMsg:
 		return m.handlePlanApprovalRequestMsg(msg)
 
@@ -696,6 +947,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ChangelogFetchedMsg:
 		return m.handleChangelogFetchedMsg(msg)
 
+	case UpdateAvailableMsg:
+		return m.handleUpdateAvailableMsg(msg)
+
+	case CommandOutputMsg:
+		return m.handleCommandOutputMsg(msg)
+
+	case SessionCreateProgressMsg:
+		return m.handleSessionCreateProgressMsg(msg)
+
+	case BulkDeleteProgressMsg:
+		return m.handleBulkDeleteProgressMsg(msg)
+
+	case HookOutputMsg:
+		return m.handleHookOutputMsg(msg)
+
 	case AsanaProjectsFetchedMsg:
 		return m.handleAsanaProjectsFetchedMsg(msg)
 
@@ -708,20 +974,72 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ContainerImageBuiltMsg:
 		return m.handleContainerImageBuiltMsg(msg)
 
-	case PRPollTickMsg:
-		// Re-schedule next tick and check PR statuses for eligible sessions
-		checkCmd := checkPRStatuses(m.config.GetSessions(), m.gitService)
-		if checkCmd != nil {
-			return m, tea.Batch(PRPollTick(), checkCmd)
-		}
-		return m, PRPollTick()
+	case SchedulerTickMsg:
+		return m.handleSchedulerTickMsg(msg)
 
 	case PRBatchStatusCheckMsg:
 		return m.handlePRBatchStatusCheckMsg(msg)
 
+	case CIFailingRunsMsg:
+		return m.handleCIFailingRunsMsg(msg)
+
+	case CILogsFetchedMsg:
+		return m.handleCILogsFetchedMsg(msg)
+
+	case MergedBranchesMsg:
+		return m.handleMergedBranchesMsg(msg)
+
+	case MissingSessionsMsg:
+		return m.handleMissingSessionsMsg(msg)
+
+	case ClaudeVersionMsg:
+		return m.handleClaudeVersionMsg(msg)
+
+	case MergeCheckMsg:
+		return m.handleMergeCheckMsg(msg)
+
+	case CleanupMergedMsg:
+		return m.handleCleanupMergedMsg(msg)
+
+	case FocusTimeUpMsg:
+		return m.handleFocusTimeUpMsg(msg)
+
+	case DefaultBranchCheckMsg:
+		return m.handleDefaultBranchCheckMsg(msg)
+
+	case RemoteBranchesMsg:
+		return m.handleRemoteBranchesMsg(msg)
+
+	case ChangedFilesMsg:
+		return m.handleChangedFilesMsg(msg)
+
+	case LiveDiffMsg:
+		return m.handleLiveDiffMsg(msg)
+
+	case HistoryLoadedMsg:
+		return m.handleHistoryLoadedMsg(msg)
+
+	case ImageFittedMsg:
+		return m.handleImageFittedMsg(msg)
+
+	case SnapshotTakenMsg:
+		return m.handleSnapshotTakenMsg(msg)
+
+	case SnapshotDiffMsg:
+		return m.handleSnapshotDiffMsg(msg)
+
+	case SleepResumeMsg:
+		return m.handleSleepResumeMsg(msg)
+
+	case AutosaveDoneMsg:
+		return m.handleAutosaveDoneMsg(msg)
+
 	case StartupModalMsg:
 		return m.handleStartupModals()
 
+	case OpenSessionMsg:
+		return m.handleOpenSessionMsg(msg)
+
 	case ui.HelpShortcutTriggeredMsg:
 		// Handle shortcut triggered from help modal
 		return m.handleHelpShortcutTrigger(msg.Key)
@@ -759,6 +1077,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmd := buildingState.AdvanceSpinner(typedMsg)
 			cmds = append(cmds, cmd)
 		}
+		if prState, ok := m.modal.State.(*ui.PRProgressState); ok {
+			cmd := prState.AdvanceSpinner(typedMsg)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+	case ui.TodoMarksChangedMsg:
+		m.handleTodoMarksChangedMsg(typedMsg)
 		return m, tea.Batch(cmds...)
 	case ui.SelectionCopyMsg:
 		chat, cmd := m.chat.Update(msg)
@@ -781,6 +1106,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.footer.SetFlash("Failed to copy to clipboard", ui.FlashError)
 		cmds = append(cmds, ui.FlashTick())
 		return m, tea.Batch(cmds...)
+	case ui.ClipboardCopiedMsg:
+		// Say how the text was copied, since OSC 52 depends on the terminal
+		if typedMsg.TooLarge {
+			m.footer.SetFlash(fmt.Sprintf("%s (via %s; the terminal may cut off copies this large)", typedMsg.Confirm, typedMsg.Mechanism), ui.FlashWarning)
+		} else {
+			m.footer.SetFlash(fmt.Sprintf("%s (via %s)", typedMsg.Confirm, typedMsg.Mechanism), ui.FlashSuccess)
+		}
+		cmds = append(cmds, ui.FlashTick())
+		return m, tea.Batch(cmds...)
 	}
 
 	// Route scroll keys and mouse wheel to chat panel even when sidebar is focused
@@ -789,7 +1123,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.focus == FocusSidebar && m.activeSession != nil {
 		if keyMsg, isKey := msg.(tea.KeyPressMsg); isKey {
 			switch keyMsg.String() {
-			case keys.PgUp, keys.PgDown, keys.CtrlU, keys.CtrlD, keys.Home, keys.End:
+			case keys.PgUp, keys.PgDown, keys.CtrlU, keys.CtrlD, keys.Home, keys.End, keys.Left, keys.Right:
 				chat, cmd := m.chat.Update(msg)
 				m.chat = chat
 				cmds = append(cmds, cmd)
@@ -829,8 +1163,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Auto-select session when navigating with keyboard
 		if _, isKey := msg.(tea.KeyPressMsg); isKey {
 			if sess := m.sidebar.SelectedSession(); sess != nil {
-				if m.activeSession == nil || m.activeSession.ID != sess.ID {
-					m.selectSession(sess)
+				if (m.activeSession == nil || m.activeSession.ID != sess.ID) && m.loadingSessionID != sess.ID {
+					cmds = append(cmds, m.openSession(sess))
 					// Keep focus on sidebar (selectSession moves it to chat)
 					m.focus = FocusSidebar
 					m.sidebar.SetFocused(true)
@@ -842,6 +1176,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		chat, cmd := m.chat.Update(msg)
 		m.chat = chat
 		cmds = append(cmds, cmd)
+
+		// Typing ";;" in the input opens the snippet picker in its place
+		if keyMsg, ok := msg.(tea.KeyPressMsg); ok && keyMsg.Text == ";" && m.focus == FocusChat &&
+			m.activeSession != nil && m.chat.TrimInputTrigger(snippetTrigger) {
+			_, cmd := m.showSnippetPicker()
+			cmds = append(cmds, cmd)
+		}
 	}
 
 	return m, tea.Batch(cmds...)
@@ -1039,10 +1380,29 @@ func (m *Model) addClaudeCodeMCPApprovals(runner claude.RunnerInterface, sess *c
 	}
 }
 
+// selectNewSession selects a session the user just created, focusing its chat input
+// unless focus_input_on_new_session is turned off (the inline layout, having no
+// sidebar, always focuses it).
+func (m *Model) selectNewSession(sess *config.Session) {
+	m.sidebar.SelectSession(sess.ID)
+	m.selectSession(sess)
+	if !m.config.GetFocusInputOnNewSession() && !m.inline() {
+		// Keep focus on sidebar (selectSession moves it to chat)
+		m.focus = FocusSidebar
+		m.sidebar.SetFocused(true)
+		m.chat.SetFocused(false)
+	}
+}
+
 func (m *Model) selectSession(sess *config.Session) {
 	if sess == nil {
 		return
 	}
+	m.loadingSessionID = ""
+	// Selecting a session whose repo or worktree was deleted offers to archive or delete it instead
+	if m.openMissingSession(sess) {
+		return
+	}
 
 	// Get previous session state to save
 	var previousSessionID, previousInput, previousStreaming string
@@ -1050,6 +1410,7 @@ func (m *Model) selectSession(sess *config.Session) {
 		previousSessionID = m.activeSession.ID
 		previousInput = m.chat.GetInput()
 		previousStreaming = m.chat.GetStreaming()
+		m.chat.SaveScroll(previousSessionID)
 	}
 
 	// Use SessionManager to handle selection (creates/reuses runner, gathers state)
@@ -1068,9 +1429,17 @@ func (m *Model) selectSession(sess *config.Session) {
 	if m.chat.IsInViewChangesMode() {
 		m.chat.ExitViewChangesMode()
 	}
+	if m.chat.IsInTodoFocusMode() {
+		m.chat.ExitTodoFocusMode()
+	}
+	// Show the conversation or live diff, whichever this session last showed
+	m.restoreLiveDiff(sess.ID)
 
 	// Update UI components with session state
 	m.chat.SetSession(sess.Name, result.Messages)
+	m.chat.SetSessionInfo(sessionInfo(m.config, sess))
+	m.publishWebView()
+	m.restoreBookmarks(sess)
 	m.header.SetSessionName(result.HeaderName)
 	m.header.SetBaseBranch(result.BaseBranch)
 	// Show preview indicator if this session is being previewed
@@ -1109,14 +1478,16 @@ func (m *Model) selectSession(sess *config.Session) {
 
 	// Restore pending permission
 	if result.Permission != nil {
-		m.chat.SetPendingPermission(result.Permission.Tool, result.Permission.Description)
+		m.showPendingPermission(sess.ID, result.Permission)
 	} else {
 		m.chat.ClearPendingPermission()
 	}
 
-	// Restore pending question
-	if result.Question != nil {
+	// Restore pending question, unless it is being auto-answered
+	if result.Question != nil && m.autoAnswers[sess.ID] == nil {
 		m.chat.SetPendingQuestion(result.Question.Questions)
+		answers, _ := m.questionRuleAnswers(sess.ID, result.Question.Questions)
+		m.chat.PreselectQuestionAnswers(answers)
 	} else {
 		m.chat.ClearPendingQuestion()
 	}
@@ -1165,6 +1536,9 @@ func (m *Model) selectSession(sess *config.Session) {
 		m.chat.ClearQueuedMessage()
 	}
 
+	// Land where the conversation was left, now that it's fully rendered
+	m.chat.RestoreScroll(sess.ID)
+
 	logger.WithSession(sess.ID).Debug("session selected and focused")
 }
 
@@ -1186,19 +1560,7 @@ func (m *Model) handleImagePaste() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Validate the image
-	if err := img.Validate(); err != nil {
-		logger.Get().Warn("image validation failed", "error", err)
-		// Show error message in chat
-		m.chat.AppendStreaming(fmt.Sprintf("\n[Error: %s]\n", err.Error()))
-		return m, nil
-	}
-
-	// Attach the image
-	logger.Get().Info("attaching image", "sizeKB", img.SizeKB(), "mediaType", img.MediaType)
-	m.chat.AttachImage(img.Data, img.MediaType)
-
-	return m, nil
+	return m.attachImage(img)
 }
 
 func (m *Model) sendMessage() (tea.Model, tea.Cmd) {
@@ -1212,9 +1574,12 @@ func (m *Model) sendMessage() (tea.Model, tea.Cmd) {
 		return m.handleExitCommand()
 	}
 
-	// Need either text or image
-	if input == "" && !hasImage {
-		return m, nil
+	// Need either text or image; whitespace alone, newlines included, isn't text
+	if strings.TrimSpace(input) == "" {
+		if !hasImage {
+			return m, m.ShowFlashInfo(emptyInputHint)
+		}
+		input = ""
 	}
 	if !m.CanSendMessage() {
 		return m, nil
@@ -1233,19 +1598,36 @@ func (m *Model) sendMessage() (tea.Model, tea.Cmd) {
 					return shortcutMCPServers(m)
 				case ActionOpenPlugins:
 					return shortcutPlugins(m)
+				case ActionOpenSnippets:
+					return m.showSnippets()
+				case ActionOpenFilePicker:
+					return m.showFilePicker()
+				case ActionRunCommand:
+					return m, m.runCommandForInput(result.Command)
 				}
 			}
 
 			// Display the command as user message and response
 			m.chat.AddUserMessage(input)
 			if result.Response != "" {
-				m.chat.AddSystemMessage(result.Response)
+				m.chat.AddSystemMessage(claude.KindCommand, result.Response)
 			}
 			return m, nil
 		}
 		// If not handled, fall through to send to Claude
 	}
 
+	// Local commands still work while paused; messages to Claude stay in the input until resumed
+	if m.paused {
+		return m, m.ShowFlashWarning(pausedHint)
+	}
+
+	// A session resuming with another Claude CLI version may need the user's go-ahead
+	versionCmd, proceed := m.checkCLIVersion()
+	if !proceed {
+		return m, versionCmd
+	}
+
 	inputPreview := input
 	if len(inputPreview) > ui.InputMessagePreviewLen {
 		inputPreview = inputPreview[:ui.InputMessagePreviewLen] + "..."
@@ -1259,6 +1641,16 @@ func (m *Model) sendMessage() (tea.Model, tea.Cmd) {
 	// Build content blocks
 	var content []claude.ContentBlock
 
+	// Tell Claude about todo items the user marked since the last message
+	cmds := []tea.Cmd{versionCmd}
+	if report := m.reportTodoMarks(sessionID); report != "" {
+		content = append(content, claude.ContentBlock{
+			Type: claude.ContentTypeText,
+			Text: report,
+		})
+		cmds = append(cmds, m.ShowFlashInfo("Told Claude about your todo list marks"))
+	}
+
 	// Add text if present
 	if input != "" {
 		content = append(content, claude.ContentBlock{
@@ -1292,12 +1684,10 @@ func (m *Model) sendMessage() (tea.Model, tea.Cmd) {
 	m.chat.AddUserMessage(displayMsg)
 	m.chat.ClearInput()
 
-	// Create context for this request
-	ctx, cancel := context.WithCancel(context.Background())
-	m.sessionState().StartWaiting(sessionID, cancel)
+	// Start Claude request with content blocks (the pause was checked above)
+	listenCmds, _ := m.startTurn(sessionID, runner, content)
 	startTime, _ := m.sessionState().GetWaitStart(sessionID)
 	m.chat.SetWaitingWithStart(true, startTime)
-	m.sidebar.SetStreaming(sessionID, true)
 	m.sidebar.SetIdleWithResponse(sessionID, false)
 	m.setState(StateStreamingClaude)
 
@@ -1308,43 +1698,54 @@ func (m *Model) sendMessage() (tea.Model, tea.Cmd) {
 		m.chat.SetContainerInitializing(true, time.Now())
 	}
 
-	// Start Claude request with content blocks
-	responseChan := runner.SendContent(ctx, content)
-
 	// Return commands to listen for session events plus UI ticks
-	cmds := append(m.sessionListeners(sessionID, runner, responseChan),
+	cmds = append(cmds, listenCmds...)
+	cmds = append(cmds,
 		m.sidebar.SidebarTick(),
 		m.chat.SpinnerTick(),
+		m.printToScrollback("user", displayMsg),
 	)
 	return m, tea.Batch(cmds...)
 }
 
+// emptyInputHint is shown instead of sending an empty message.
+const emptyInputHint = "Nothing to send; type a message first"
+
 // handleExitCommand handles the "exit" text command.
 // If no sessions are currently streaming, it exits immediately.
 // If sessions are streaming, it shows a confirmation modal.
+// With quit_key_behavior "ctrl-c-only" it only points at Ctrl+C.
 func (m *Model) handleExitCommand() (tea.Model, tea.Cmd) {
 	log := logger.Get()
 
+	if m.config.GetQuitKeyBehavior() == config.QuitKeyCtrlCOnly {
+		return m, m.ShowFlashInfo("Press Ctrl+C to quit (quit_key_behavior is ctrl-c-only)")
+	}
+
 	// Check if any sessions are actively streaming (waiting for Claude response)
 	if !m.sessionMgr.HasActiveStreaming() {
 		log.Info("no active streaming sessions, exiting immediately")
 		return m, tea.Quit
 	}
 
-	// Count streaming sessions for the modal message
-	streamingCount := 0
-	for _, runner := range m.sessionMgr.GetRunners() {
-		if runner.IsStreaming() {
-			streamingCount++
-		}
-	}
-
 	// Show confirmation modal
+	streamingCount := m.streamingSessionCount()
 	log.Debug("showing exit confirmation modal", "streamingCount", streamingCount)
 	m.modal.Show(ui.NewConfirmExitState(streamingCount))
 	return m, nil
 }
 
+// streamingSessionCount returns the number of sessions waiting for a Claude response.
+func (m *Model) streamingSessionCount() int {
+	count := 0
+	for _, runner := range m.sessionMgr.GetRunners() {
+		if runner.IsStreaming() {
+			count++
+		}
+	}
+	return count
+}
+
 // Note: Permission, question, plan approval, and merge result handling has been
 // moved to listeners.go for better organization.
 
@@ -1364,7 +1765,8 @@ func (m *Model) mergeConversationHistory(childSessionID, parentSessionID string)
 
 	// Add a separator message to indicate the merge
 	separatorMsg := config.Message{
-		Role:    "assistant",
+		Role:    claude.RoleSystem,
+		Kind:    claude.KindMerge,
 		Content: "\n---\n[Merged from child session]\n---\n",
 	}
 
@@ -1467,8 +1869,16 @@ func (m *Model) showExploreOptionsModal() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleStartupModals checks and shows welcome or changelog modals on startup
+// handleStartupModals checks and shows welcome or changelog modals on startup,
+// along with a notice if the config file has problems
 func (m *Model) handleStartupModals() (tea.Model, tea.Cmd) {
+	notice := m.configProblemsNotice()
+	_, cmd := m.showStartupModal()
+	return m, tea.Batch(notice, cmd)
+}
+
+// showStartupModal shows the highest-priority startup modal, if any.
+func (m *Model) showStartupModal() (tea.Model, tea.Cmd) {
 	// Priority 0: Preview mode warning (highest priority - user needs to know immediately)
 	if m.config.IsPreviewActive() {
 		sessionID := m.config.GetPreviewSessionID()
@@ -1479,14 +1889,21 @@ func (m *Model) handleStartupModals() (tea.Model, tea.Cmd) {
 		}
 	}
 
-	// Priority 1: Welcome modal for first-time users
+	// Priority 1: Sessions saved with names git or the terminal can't use
+	if repairs := m.pendingSessionNameRepairs(); len(repairs) > 0 {
+		logger.Get().Warn("sessions have names to repair", "count", len(repairs))
+		m.modal.Show(ui.NewRepairSessionNamesState(repairs))
+		return m, nil
+	}
+
+	// Priority 2: Welcome modal for first-time users
 	if !m.config.HasSeenWelcome() {
 		logger.Get().Debug("showing welcome modal for first-time user")
 		m.modal.Show(ui.NewWelcomeState())
 		return m, nil
 	}
 
-	// Priority 2: Changelog modal for new versions
+	// Priority 3: Changelog modal for new versions
 	// Skip for dev builds; fetch changelog from GitHub asynchronously
 	if m.version != "" && m.version != "dev" {
 		lastSeen := m.config.GetLastSeenVersion()
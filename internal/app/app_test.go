@@ -1,9 +1,11 @@
 package app
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	tea "charm.land/bubbletea/v2"
 	"github.com/zhubert/plural/internal/config"
 	"github.com/zhubert/plural/internal/ui"
 )
@@ -173,3 +175,183 @@ func TestSelectSession_ContainerInitProgressBar_ClearsWhenNotInitializing(t *tes
 		t.Error("Container progress bar should be cleared when not initializing in state manager")
 	}
 }
+
+func TestSelectNewSession_FocusInputOnNewSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		focusInput  bool
+		wantFocus   Focus
+		wantChatFoc bool
+	}{
+		{"enabled focuses chat input", true, FocusChat, true},
+		{"disabled keeps sidebar focus", false, FocusSidebar, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfigWithSessions()
+			cfg.SetFocusInputOnNewSession(tt.focusInput)
+			m := testModelWithSize(cfg, 120, 40)
+			m.sidebar.SetSessions(cfg.Sessions)
+
+			m.selectNewSession(&cfg.Sessions[1])
+
+			if m.activeSession == nil || m.activeSession.ID != cfg.Sessions[1].ID {
+				t.Fatal("Expected the new session to be active")
+			}
+			if m.focus != tt.wantFocus {
+				t.Errorf("focus = %v, want %v", m.focus, tt.wantFocus)
+			}
+			if m.chat.IsFocused() != tt.wantChatFoc {
+				t.Errorf("chat focused = %v, want %v", m.chat.IsFocused(), tt.wantChatFoc)
+			}
+			if sel := m.sidebar.SelectedSession(); sel == nil || sel.ID != cfg.Sessions[1].ID {
+				t.Error("Expected the sidebar to select the new session")
+			}
+		})
+	}
+}
+
+func TestInlineLayout(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModel(cfg)
+	m.SetLayout(LayoutInline)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	v := m.View()
+	if v.AltScreen {
+		t.Error("inline layout should not use the alternate screen")
+	}
+	if v.MouseMode != tea.MouseModeNone {
+		t.Errorf("inline layout should not capture the mouse, got mode %v", v.MouseMode)
+	}
+	if strings.Contains(m.RenderToString(), "bugfix") {
+		t.Error("inline layout should not render the sidebar")
+	}
+
+	// The hidden sidebar is never focused
+	if m.focus != FocusChat || !m.chat.IsFocused() {
+		t.Error("inline layout should start with the chat focused")
+	}
+	m.selectSession(&cfg.Sessions[0])
+	m.Update(keyPress("tab"))
+	if m.focus != FocusChat || m.sidebar.IsFocused() {
+		t.Error("Tab should not move focus to the hidden sidebar")
+	}
+
+	// Sidebar search has nothing to show in the inline layout
+	m.focus = FocusSidebar
+	m.chat.SetFocused(false)
+	if _, _, handled := m.ExecuteShortcut("/"); handled {
+		t.Error("sidebar search should be disabled in the inline layout")
+	}
+
+	if cmd := m.printToScrollback("assistant", "done"); cmd == nil {
+		t.Error("expected messages to be printed to scrollback in the inline layout")
+	}
+}
+
+func TestOpenSessionOnStartup(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	if err := m.OpenSessionOnStartup("no-such-session"); err == nil {
+		t.Error("Expected an error for an unknown session ID")
+	}
+
+	// The last session in the sidebar, so the selection has to move to it
+	target := cfg.Sessions[len(cfg.Sessions)-1].ID
+	if err := m.OpenSessionOnStartup(target); err != nil {
+		t.Fatalf("OpenSessionOnStartup(%q) failed: %v", target, err)
+	}
+	cmd := m.openStartupSession()
+	if cmd == nil {
+		t.Fatal("Expected a command to open the session on startup")
+	}
+	result, _ := m.Update(cmd())
+	m = result.(*Model)
+
+	if m.activeSession == nil || m.activeSession.ID != target {
+		t.Fatalf("Expected %s to be the active session, got %v", target, m.activeSession)
+	}
+	if sel := m.sidebar.SelectedSession(); sel == nil || sel.ID != target {
+		t.Errorf("Expected %s to be selected in the sidebar", target)
+	}
+	if m.focus != FocusChat {
+		t.Error("Expected the opened session's chat to be focused")
+	}
+}
+
+func TestResumeLastSessionOnStartup(t *testing.T) {
+	if m := testModel(&config.Config{}); m.ResumeLastSessionOnStartup() {
+		t.Error("Expected nothing to resume without sessions")
+	}
+
+	cfg := testConfigWithSessions()
+	target := cfg.Sessions[1].ID
+	cfg.SetSessionLastActivity(target, time.Now())
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	if !m.ResumeLastSessionOnStartup() {
+		t.Fatal("Expected the most recent session to be resumed")
+	}
+	result, _ := m.Update(m.openStartupSession()())
+	m = result.(*Model)
+
+	if m.activeSession == nil || m.activeSession.ID != target {
+		t.Fatalf("Expected %s to be the active session, got %v", target, m.activeSession)
+	}
+	if m.focus != FocusChat {
+		t.Error("Expected the resumed session's chat to be focused")
+	}
+}
+
+func TestOpenStartupSession_NoneRequested(t *testing.T) {
+	m := testModel(testConfigWithSessions())
+	if cmd := m.openStartupSession(); cmd != nil {
+		t.Error("Expected no startup command when no session was requested")
+	}
+}
+
+func TestSplitLayout(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	if m.Layout() != LayoutSplit {
+		t.Fatalf("expected split layout by default, got %v", m.Layout())
+	}
+	v := m.View()
+	if !v.AltScreen || v.MouseMode != tea.MouseModeCellMotion {
+		t.Error("split layout should use the alternate screen with mouse support")
+	}
+	if !strings.Contains(m.RenderToString(), "bugfix") {
+		t.Error("split layout should render the sidebar")
+	}
+	if cmd := m.printToScrollback("assistant", "done"); cmd != nil {
+		t.Error("split layout should not print to scrollback")
+	}
+}
+
+func TestStartupNotice_ConfigProblems(t *testing.T) {
+	cfg := testConfig()
+	m := testModelWithSize(cfg, 120, 40)
+	m.Update(StartupModalMsg{})
+	if m.footer.HasFlash() {
+		t.Error("expected no notice for a config without problems")
+	}
+
+	cfg = testConfig()
+	cfg.Theme = "no-such-theme"
+	m = testModelWithSize(cfg, 120, 40)
+	if problems := cfg.Problems(); len(problems) != 1 || problems[0].Path != "theme" {
+		t.Fatalf("expected an unknown theme problem, got %v", problems)
+	}
+	m.Update(StartupModalMsg{})
+	if !m.footer.HasFlash() {
+		t.Error("expected a startup notice about the config problem")
+	}
+}
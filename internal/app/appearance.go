@@ -0,0 +1,78 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// followsBackground returns whether the theme switches with the terminal's
+// background, as it does once a theme for dark or light backgrounds is set.
+// A theme picked in the settings is kept instead.
+func followsBackground(cfg *config.Config) bool {
+	if cfg.GetTheme() != "" {
+		return false
+	}
+	dark, light := cfg.GetBackgroundThemes()
+	return dark != "" || light != ""
+}
+
+// themeForBackground returns the theme to use on a dark or light terminal
+// background: the one set for it, otherwise the default.
+func themeForBackground(cfg *config.Config, dark bool) string {
+	darkTheme, lightTheme := cfg.GetBackgroundThemes()
+	preferred := lightTheme
+	if dark {
+		preferred = darkTheme
+	}
+	if preferred != "" {
+		return preferred
+	}
+	return string(ui.DefaultTheme)
+}
+
+// colorFGBGIsDark returns whether the background described by a COLORFGBG
+// value ("fg;bg", or "fg;default;bg" in some terminals) is dark, and whether
+// the value says at all. Backgrounds 0 to 6 and 8 of the 16 ANSI colors are
+// dark; 7 and 9 to 15 are light.
+func colorFGBGIsDark(value string) (dark, ok bool) {
+	fields := strings.Split(value, ";")
+	if len(fields) < 2 {
+		return false, false
+	}
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+	return bg < 7 || bg == 8, true
+}
+
+// requestBackgroundColor returns the command asking the terminal for its
+// background color (OSC 11) when the theme switches with it, or nil. The answer
+// arrives as a tea.BackgroundColorMsg; a terminal that doesn't answer leaves
+// the theme as it is, without anything waiting on it.
+func (m *Model) requestBackgroundColor() tea.Cmd {
+	if !followsBackground(m.config) {
+		return nil
+	}
+	return tea.RequestBackgroundColor
+}
+
+// applyTerminalBackground switches to the theme for a dark or light terminal
+// background, if the theme follows the background and isn't that one already.
+func (m *Model) applyTerminalBackground(dark bool) {
+	if !followsBackground(m.config) {
+		return
+	}
+	theme := themeForBackground(m.config, dark)
+	if ui.GetTheme(ui.ThemeName(theme)).Name == ui.CurrentTheme().Name {
+		return
+	}
+	logger.Get().Info("switching theme with the terminal background", "theme", theme, "dark", dark)
+	ui.SetThemeByName(theme)
+	m.chat.RefreshStyles()
+}
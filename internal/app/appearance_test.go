@@ -0,0 +1,100 @@
+package app
+
+import (
+	"image/color"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestColorFGBGIsDark(t *testing.T) {
+	tests := []struct {
+		value    string
+		wantDark bool
+		wantOK   bool
+	}{
+		{"15;0", true, true},
+		{"0;15", false, true},
+		{"0;7", false, true},
+		{"7;8", true, true},
+		{"15;default;0", true, true},
+		{"", false, false},
+		{"15", false, false},
+		{"15;default", false, false},
+		{"0;42", false, false},
+	}
+	for _, tt := range tests {
+		dark, ok := colorFGBGIsDark(tt.value)
+		if dark != tt.wantDark || ok != tt.wantOK {
+			t.Errorf("colorFGBGIsDark(%q) = %v, %v, want %v, %v", tt.value, dark, ok, tt.wantDark, tt.wantOK)
+		}
+	}
+}
+
+func TestThemeForBackground(t *testing.T) {
+	cfg := testConfig()
+	cfg.ThemeDark = string(ui.ThemeNord)
+	if got := themeForBackground(cfg, true); got != string(ui.ThemeNord) {
+		t.Errorf("expected the dark theme on a dark background, got %q", got)
+	}
+	if got := themeForBackground(cfg, false); got != string(ui.DefaultTheme) {
+		t.Errorf("expected the default theme with no light theme set, got %q", got)
+	}
+}
+
+func TestUpdate_BackgroundColorSwitchesTheme(t *testing.T) {
+	orig := ui.CurrentThemeName()
+	defer ui.SetTheme(orig)
+
+	cfg := testConfig()
+	cfg.ThemeDark = string(ui.ThemeNord)
+	cfg.ThemeLight = string(ui.ThemeLight)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	if m.requestBackgroundColor() == nil {
+		t.Error("expected the background color requested when dark and light themes are set")
+	}
+
+	m.Update(tea.BackgroundColorMsg{Color: color.White})
+	if got := ui.CurrentTheme().Name; got != ui.GetTheme(ui.ThemeLight).Name {
+		t.Errorf("expected the light theme on a light background, got %s", got)
+	}
+	m.Update(tea.BackgroundColorMsg{Color: color.Black})
+	if got := ui.CurrentTheme().Name; got != ui.GetTheme(ui.ThemeNord).Name {
+		t.Errorf("expected the dark theme on a dark background, got %s", got)
+	}
+}
+
+func TestUpdate_BackgroundColorIgnoredWithoutBackgroundThemes(t *testing.T) {
+	orig := ui.CurrentThemeName()
+	defer ui.SetTheme(orig)
+
+	m, _ := testModelWithMocks(testConfig(), 120, 40)
+	ui.SetTheme(ui.ThemeDracula)
+	if m.requestBackgroundColor() != nil {
+		t.Error("expected no background color request without dark or light themes")
+	}
+	m.Update(tea.BackgroundColorMsg{Color: color.White})
+	if got := ui.CurrentTheme().Name; got != ui.GetTheme(ui.ThemeDracula).Name {
+		t.Errorf("expected the theme left as it was, got %s", got)
+	}
+}
+
+func TestUpdate_BackgroundColorKeepsSavedTheme(t *testing.T) {
+	orig := ui.CurrentThemeName()
+	defer ui.SetTheme(orig)
+
+	cfg := testConfig()
+	cfg.ThemeDark = string(ui.ThemeNord)
+	cfg.ThemeLight = string(ui.ThemeLight)
+	cfg.SetTheme(string(ui.ThemeDracula))
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	ui.SetTheme(ui.ThemeDracula)
+	if m.requestBackgroundColor() != nil {
+		t.Error("expected no background color request with a theme saved")
+	}
+	m.Update(tea.BackgroundColorMsg{Color: color.White})
+	if got := ui.CurrentTheme().Name; got != ui.GetTheme(ui.ThemeDracula).Name {
+		t.Errorf("expected the saved theme kept, got %s", got)
+	}
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/manager"
+)
+
+// AutosaveDoneMsg carries the result of a message history autosave cycle
+type AutosaveDoneMsg struct {
+	Saved int // Number of sessions whose history was written
+	Error error
+}
+
+// autosaveMessages returns a command that saves every session's message history in the background.
+func autosaveMessages(sm *manager.SessionManager) tea.Cmd {
+	return func() tea.Msg {
+		saved, err := sm.AutosaveMessages()
+		return AutosaveDoneMsg{Saved: saved, Error: err}
+	}
+}
+
+// autosave returns a command starting an autosave cycle.
+func (m *Model) autosave() tea.Cmd {
+	return autosaveMessages(m.sessionMgr)
+}
+
+// handleAutosaveDoneMsg logs the result of an autosave cycle. Only the first failure
+// in a row is flashed so a persistent problem (e.g. a full disk) does not flash every cycle.
+func (m *Model) handleAutosaveDoneMsg(msg AutosaveDoneMsg) (tea.Model, tea.Cmd) {
+	log := logger.WithComponent("autosave")
+	if msg.Error != nil {
+		log.Error("failed to autosave message history", "error", msg.Error)
+		if m.autosaveFailing {
+			return m, nil
+		}
+		m.autosaveFailing = true
+		return m, m.ShowFlashError("Failed to autosave message history")
+	}
+	m.autosaveFailing = false
+	if msg.Saved > 0 {
+		log.Debug("autosaved message history", "sessions", msg.Saved)
+	}
+	return m, nil
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// resolveBookmarks returns the indices in messages of a session's saved bookmarks.
+// A bookmark whose message is no longer at its index, because older messages were
+// trimmed from the saved history, is found again by its hash; one whose message is
+// gone is dropped.
+func resolveBookmarks(saved []config.MessageBookmark, messages []claude.Message) []int {
+	hashes := make([]string, len(messages))
+	for i, msg := range messages {
+		hashes[i] = config.MessageHash(msg.Role, msg.Content)
+	}
+
+	var indices []int
+	for _, bookmark := range saved {
+		index := -1
+		if bookmark.Index >= 0 && bookmark.Index < len(hashes) && hashes[bookmark.Index] == bookmark.Hash {
+			index = bookmark.Index
+		} else {
+			for i, hash := range hashes {
+				if hash == bookmark.Hash && !slices.Contains(indices, i) {
+					index = i
+					break
+				}
+			}
+		}
+		if index >= 0 && !slices.Contains(indices, index) {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// restoreBookmarks shows the saved bookmarks of the session in the chat.
+func (m *Model) restoreBookmarks(sess *config.Session) {
+	if len(sess.Bookmarks) == 0 {
+		return
+	}
+	m.chat.SetBookmarks(resolveBookmarks(sess.Bookmarks, m.chat.GetMessages()))
+}
+
+// saveBookmarks stores the chat's bookmarks with the active session.
+func (m *Model) saveBookmarks() tea.Cmd {
+	messages := m.chat.GetMessages()
+	var bookmarks []config.MessageBookmark
+	for _, i := range m.chat.Bookmarks() {
+		bookmarks = append(bookmarks, config.MessageBookmark{
+			Index: i,
+			Hash:  config.MessageHash(messages[i].Role, messages[i].Content),
+		})
+	}
+	m.config.SetSessionBookmarks(m.activeSession.ID, bookmarks)
+	return m.saveConfigOrFlash()
+}
+
+// shortcutToggleBookmark bookmarks the message at the top of the chat, or removes
+// its bookmark.
+func shortcutToggleBookmark(m *Model) (tea.Model, tea.Cmd) {
+	index, bookmarked := m.chat.ToggleBookmark()
+	if index < 0 {
+		return m, m.ShowFlashInfo("No message to bookmark")
+	}
+	logger.WithSession(m.activeSession.ID).Debug("toggled message bookmark", "index", index, "bookmarked", bookmarked)
+
+	flash := m.ShowFlashInfo("Removed bookmark")
+	if bookmarked {
+		flash = m.ShowFlashInfo("Bookmarked message (opt-n jumps to the next bookmark)")
+	}
+	return m, tea.Batch(flash, m.saveBookmarks())
+}
+
+// shortcutNextBookmark scrolls the chat to the next bookmarked message.
+func shortcutNextBookmark(m *Model) (tea.Model, tea.Cmd) {
+	if m.chat.JumpToNextBookmark() < 0 {
+		return m, m.ShowFlashInfo("No bookmarks in this session (opt-m bookmarks a message)")
+	}
+	return m, nil
+}
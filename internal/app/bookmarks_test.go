@@ -0,0 +1,61 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/config"
+)
+
+func TestResolveBookmarks(t *testing.T) {
+	messages := []claude.Message{
+		{Role: "user", Content: "question"},
+		{Role: "assistant", Content: "the answer"},
+		{Role: "user", Content: "follow-up"},
+		{Role: "assistant", Content: "revisit this"},
+	}
+	hash := func(i int) string { return config.MessageHash(messages[i].Role, messages[i].Content) }
+	saved := []config.MessageBookmark{
+		{Index: 1, Hash: hash(1)},
+		{Index: 3, Hash: hash(3)},
+		{Index: 5, Hash: config.MessageHash("assistant", "trimmed away")},
+	}
+
+	if got := resolveBookmarks(saved, messages); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("resolveBookmarks() = %v, want [1 3]", got)
+	}
+
+	// Once the first two messages are trimmed from the saved history, the
+	// remaining bookmark is found again by its hash
+	if got := resolveBookmarks(saved, messages[2:]); !slices.Equal(got, []int{1}) {
+		t.Errorf("resolveBookmarks() after trimming = %v, want [1]", got)
+	}
+}
+
+func TestBookmarks_PersistWithSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m.chat.AddUserMessage("question")
+	m.chat.AddSystemMessage(claude.KindCommand, "the answer")
+	m.Update(tea.KeyPressMsg{Code: 'm', Mod: tea.ModAlt})
+	if !m.footer.HasFlash() {
+		t.Error("expected a flash confirming the bookmark")
+	}
+
+	// The whole conversation fits, so the first message is at the top
+	saved := m.config.GetSession("session-1").Bookmarks
+	if len(saved) != 1 || saved[0].Index != 0 || saved[0].Hash != config.MessageHash("user", "question") {
+		t.Fatalf("expected the bookmark to be saved with the session, got %+v", saved)
+	}
+
+	m.chat.SetSession("session1", m.chat.GetMessages())
+	m.restoreBookmarks(m.config.GetSession("session-1"))
+	if !m.chat.IsBookmarked(0) {
+		t.Error("expected the bookmark to be restored")
+	}
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+	"github.com/zhubert/plural/internal/workpool"
+)
+
+// BulkDeleteProgressMsg reports on sessions being deleted from the Bulk Action
+// modal: how many worktrees are deleted so far or, when Done, which sessions
+// the deletion ran for.
+type BulkDeleteProgressMsg struct {
+	Deleted    int
+	Done       bool
+	SessionIDs []string // Set when Done
+}
+
+// pendingBulkDelete is sessions whose worktrees are being deleted in the background.
+type pendingBulkDelete struct {
+	ch    chan BulkDeleteProgressMsg
+	total int
+}
+
+// executeBulkDelete deletes the sessions' worktrees in the background, showing
+// progress in the Bulk Action modal. Closing the modal stops the deletions not
+// yet started; the sessions whose deletion ran are removed once it's done.
+func (m *Model) executeBulkDelete(state *ui.BulkActionState) (tea.Model, tea.Cmd) {
+	if m.pendingBulkDelete != nil {
+		m.modal.SetError("Still deleting the previous sessions")
+		return m, nil
+	}
+
+	// Sessions already gone from the config have no worktree to delete
+	var sessions []*config.Session
+	var missing []string
+	for _, id := range state.SessionIDs {
+		if sess := m.config.GetSession(id); sess != nil {
+			sessions = append(sessions, sess)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	state.StartDeleting()
+
+	ctx := m.modal.Context()
+	ch := make(chan BulkDeleteProgressMsg, len(sessions)+1) // Never blocks
+	m.pendingBulkDelete = &pendingBulkDelete{ch: ch, total: len(state.SessionIDs)}
+	sessionService := m.sessionService
+	gitService := m.gitService
+	opts := workpool.Options{
+		Limit: m.config.GetMaxConcurrentJobs(),
+		Progress: func(done, total int) {
+			ch <- BulkDeleteProgressMsg{Deleted: done}
+		},
+	}
+	go func() {
+		log := logger.Get()
+		errs := workpool.Run(ctx, len(sessions), opts, func(ctx context.Context, i int) error {
+			// A deletion under way finishes, so no worktree is left half removed
+			ctx = context.WithoutCancel(ctx)
+			if err := gitService.DeleteSnapshots(ctx, sessions[i].RepoPath, sessions[i].ID); err != nil {
+				log.Warn("failed to delete snapshots during bulk delete", "session", sessions[i].ID, "error", err)
+			}
+			return sessionService.Delete(ctx, sessions[i])
+		})
+		deleted := missing
+		for i, err := range errs {
+			if !workpool.Started(err) {
+				continue
+			}
+			if err != nil {
+				log.Warn("failed to delete worktree during bulk delete", "session", sessions[i].ID, "error", err)
+			}
+			deleted = append(deleted, sessions[i].ID)
+		}
+		ch <- BulkDeleteProgressMsg{Done: true, SessionIDs: deleted}
+	}()
+	return m, listenForBulkDelete(ch)
+}
+
+// listenForBulkDelete creates a command waiting for the next update from
+// sessions being deleted in the background.
+func listenForBulkDelete(ch <-chan BulkDeleteProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// handleBulkDeleteProgressMsg shows progress from sessions being deleted in the
+// background and, once it is done, removes the sessions whose deletion ran.
+func (m *Model) handleBulkDeleteProgressMsg(msg BulkDeleteProgressMsg) (tea.Model, tea.Cmd) {
+	pending := m.pendingBulkDelete
+	if pending == nil {
+		return m, nil
+	}
+	state, _ := m.modal.State.(*ui.BulkActionState)
+	if state != nil && !state.Deleting {
+		state = nil
+	}
+
+	if !msg.Done {
+		if state != nil {
+			state.SetDeleteProgress(msg.Deleted)
+		}
+		return m, listenForBulkDelete(pending.ch)
+	}
+	m.pendingBulkDelete = nil
+	sessionIDs := msg.SessionIDs
+
+	// Clean up state for each session (must be sequential - UI operations)
+	for _, id := range sessionIDs {
+		m.cleanupDeletedSession(id)
+	}
+
+	// Batch remove all sessions from config and clean up orphaned parent refs
+	deleted := m.config.RemoveSessions(sessionIDs)
+	m.config.ClearOrphanedParentIDs(sessionIDs)
+
+	var cmds []tea.Cmd
+	if cmd := m.saveConfigOrFlash(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
+	// Exit multi-select mode and update sidebar
+	m.sidebar.ExitMultiSelect()
+	m.sidebar.SetSessions(m.getFilteredSessions())
+	if state != nil {
+		m.modal.Hide()
+	}
+
+	if len(sessionIDs) < pending.total {
+		cmds = append(cmds, m.ShowFlashInfo(fmt.Sprintf("Deleted %d of %d session(s); stopped before the rest", deleted, pending.total)))
+	} else {
+		cmds = append(cmds, m.ShowFlashSuccess(fmt.Sprintf("Deleted %d session(s)", deleted)))
+	}
+	return m, tea.Batch(cmds...)
+}
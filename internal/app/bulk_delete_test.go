@@ -0,0 +1,120 @@
+package app
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	pexec "github.com/zhubert/plural/internal/exec"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/session"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// bulkDeleteTestModel returns a model showing the Bulk Action modal for every
+// session, with git mocked by the returned executor.
+func bulkDeleteTestModel(t *testing.T) (*Model, *pexec.MockExecutor) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	cfg := testConfigWithSessions()
+	cfg.SetFilePath(filepath.Join(home, "config.json"))
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	mockExec := pexec.NewMockExecutor(nil)
+	m.SetSessionService(session.NewSessionServiceWithExecutor(mockExec))
+	m.SetGitService(git.NewGitServiceWithExecutor(mockExec))
+	m.modal.Show(ui.NewBulkActionState([]string{"session-1", "session-2", "session-3"}))
+	return m, mockExec
+}
+
+// drainBulkDelete feeds the background bulk delete's updates to the model
+// until it is done, returning the progress shown along the way.
+func drainBulkDelete(t *testing.T, m *Model) []int {
+	t.Helper()
+	var progress []int
+	for m.pendingBulkDelete != nil {
+		msg := listenForBulkDelete(m.pendingBulkDelete.ch)()
+		m.Update(msg)
+		if state, ok := m.modal.State.(*ui.BulkActionState); ok {
+			progress = append(progress, state.Deleted)
+		}
+	}
+	return progress
+}
+
+// sessionIDs returns the IDs of the sessions in the config.
+func sessionIDs(cfg *config.Config) []string {
+	var ids []string
+	for _, sess := range cfg.GetSessions() {
+		ids = append(ids, sess.ID)
+	}
+	return ids
+}
+
+func TestBulkDelete_ShowsProgressAndRemovesSessions(t *testing.T) {
+	m, mockExec := bulkDeleteTestModel(t)
+	snapshotRefs := git.SnapshotRefPrefix + "session-1/"
+	mockExec.AddRule(func(dir, name string, args []string) bool {
+		return len(args) > 0 && args[0] == "for-each-ref" && args[len(args)-1] == snapshotRefs
+	}, pexec.MockResponse{Stdout: []byte(snapshotRefs + "1\tabc123\t2026-01-02T15:04:05Z\n")})
+
+	m = sendKey(m, "enter")
+	state, ok := m.modal.State.(*ui.BulkActionState)
+	if !ok || !state.Deleting {
+		t.Fatal("expected the modal to show the deletion under way")
+	}
+
+	progress := drainBulkDelete(t, m)
+	if want := []int{1, 2, 3}; !slices.Equal(progress, want) {
+		t.Errorf("progress shown %v, want %v", progress, want)
+	}
+	if m.modal.IsVisible() {
+		t.Error("expected the modal closed once done")
+	}
+	if ids := sessionIDs(m.config); len(ids) != 0 {
+		t.Errorf("expected every session deleted, left %v", ids)
+	}
+	if !hasCall(mockExec, "/test/repo1", "update-ref", "-d", snapshotRefs+"1") {
+		t.Error("expected the deleted session's snapshots removed")
+	}
+}
+
+func TestBulkDelete_ClosingModalStopsTheRest(t *testing.T) {
+	m, mockExec := bulkDeleteTestModel(t)
+	m.config.SetMaxConcurrentJobs(1)
+
+	// Hold the first worktree's removal until the modal is closed
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockExec.AddRule(func(dir, name string, args []string) bool {
+		if slices.Contains(args, "/test/worktree1") {
+			close(started)
+			<-release
+		}
+		return false
+	}, pexec.MockResponse{})
+
+	m = sendKey(m, "enter")
+	<-started
+	m = sendKey(m, "esc")
+	close(release)
+	drainBulkDelete(t, m)
+
+	if ids := sessionIDs(m.config); !slices.Equal(ids, []string{"session-2", "session-3"}) {
+		t.Errorf("expected only the session under way deleted, left %v", ids)
+	}
+	for _, call := range mockExec.GetCalls() {
+		if slices.Contains(call.Args, "/test/worktree2") || slices.Contains(call.Args, "/test/worktree3") {
+			t.Errorf("expected no git command for sessions not started, ran %v", call.Args)
+		}
+	}
+	if !m.footer.HasFlash() {
+		t.Error("expected a flash saying the rest were not deleted")
+	}
+}
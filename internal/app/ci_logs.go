@@ -0,0 +1,215 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/activity"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// CIFailingRunsMsg carries the failing CI runs of a session's PR, the first step
+// of fetching their logs.
+type CIFailingRunsMsg struct {
+	SessionID string
+	Runs      []git.CIRun
+	External  []string // Failed checks outside GitHub Actions
+	Error     error
+}
+
+// CILogsFetchedMsg carries the failed job logs of a session's failing CI runs.
+type CILogsFetchedMsg struct {
+	SessionID string
+	RunsKey   string // Identifies the runs, see ciRunsKey
+	Logs      []git.CIJobLog
+	External  []string
+	Error     error
+}
+
+// ciRunsKey identifies a set of CI runs, so logs already sent to a session are
+// not fetched and sent again. A re-run is a new attempt of the same run, so
+// its logs are sent too.
+func ciRunsKey(runs []git.CIRun) string {
+	ids := make([]string, len(runs))
+	for i, run := range runs {
+		ids[i] = fmt.Sprintf("%s#%d", run.ID, run.Attempt)
+	}
+	slices.Sort(ids)
+	return strings.Join(ids, ",")
+}
+
+// trackCIStatus records the CI status of a session's open PR, flashing a hint
+// to fetch the logs when it turns failing.
+func (m *Model) trackCIStatus(sessionID string, status git.CIStatus) tea.Cmd {
+	failing := status == git.CIStatusFailing
+	wasFailing := m.ciFailing[sessionID]
+	if !failing {
+		delete(m.ciFailing, sessionID)
+		return nil
+	}
+	m.ciFailing[sessionID] = true
+	if wasFailing {
+		return nil
+	}
+
+	m.recordActivity(sessionID, activity.KindCIFailed, activity.SeverityWarning, "CI failing on the PR")
+	sess := m.config.GetSession(sessionID)
+	if sess == nil || m.heldByFocusMode(sessionID) {
+		return nil
+	}
+	return m.ShowFlashWarning("CI failing: " + ui.SessionDisplayName(sess.Branch, sess.Name) + " (L to fetch the failing logs)")
+}
+
+// findFailingCIRuns creates a command that looks up the failing CI runs of a
+// branch's PR.
+func (m *Model) findFailingCIRuns(sessionID, repoPath, branch string) tea.Cmd {
+	gitSvc := m.gitService
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		runs, external, err := gitSvc.FailingCIRuns(ctx, repoPath, branch)
+		return CIFailingRunsMsg{SessionID: sessionID, Runs: runs, External: external, Error: err}
+	}
+}
+
+// fetchCILogs creates a command that fetches the failed job logs of CI runs.
+func (m *Model) fetchCILogs(sessionID, repoPath string, runs []git.CIRun, external []string) tea.Cmd {
+	gitSvc := m.gitService
+	maxLines := m.config.GetCILogLines()
+	key := ciRunsKey(runs)
+	ids := make([]string, len(runs))
+	for i, run := range runs {
+		ids[i] = run.ID
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		logs, err := gitSvc.FetchFailedCILogs(ctx, repoPath, ids, maxLines)
+		return CILogsFetchedMsg{SessionID: sessionID, RunsKey: key, Logs: logs, External: external, Error: err}
+	}
+}
+
+// handleCIFailingRunsMsg fetches the logs of the failing runs found, unless
+// they were already sent to the session.
+func (m *Model) handleCIFailingRunsMsg(msg CIFailingRunsMsg) (tea.Model, tea.Cmd) {
+	sess := m.config.GetSession(msg.SessionID)
+	if sess == nil {
+		delete(m.ciLogsLoading, msg.SessionID)
+		return m, nil
+	}
+	log := logger.WithSession(sess.ID)
+
+	if msg.Error != nil {
+		delete(m.ciLogsLoading, sess.ID)
+		log.Warn("failed to list failing CI runs", "error", msg.Error)
+		return m, m.ShowFlashError(msg.Error.Error())
+	}
+	if len(msg.Runs) == 0 {
+		delete(m.ciLogsLoading, sess.ID)
+		if len(msg.External) > 0 {
+			return m, m.ShowFlashWarning("Only checks outside GitHub Actions are failing; fetch their logs by hand: " + strings.Join(msg.External, ", "))
+		}
+		return m, m.ShowFlashInfo("No failing CI runs on the PR")
+	}
+
+	key := ciRunsKey(msg.Runs)
+	if m.ciLogsSent[sess.ID] == key {
+		delete(m.ciLogsLoading, sess.ID)
+		return m, m.ShowFlashInfo("The logs of these failing CI runs were already sent to this session")
+	}
+
+	log.Info("fetching failing CI logs", "runs", key)
+	return m, tea.Batch(
+		m.ShowFlashInfo(fmt.Sprintf("Fetching logs of %d failing CI run(s)...", len(msg.Runs))),
+		m.fetchCILogs(sess.ID, sess.RepoPath, msg.Runs, msg.External),
+	)
+}
+
+// handleCILogsFetchedMsg sends the fetched CI logs to the session's Claude.
+func (m *Model) handleCILogsFetchedMsg(msg CILogsFetchedMsg) (tea.Model, tea.Cmd) {
+	delete(m.ciLogsLoading, msg.SessionID)
+	sess := m.config.GetSession(msg.SessionID)
+	if sess == nil {
+		return m, nil
+	}
+	log := logger.WithSession(sess.ID)
+
+	if msg.Error != nil {
+		log.Warn("failed to fetch failing CI logs", "error", msg.Error)
+		return m, m.ShowFlashError(msg.Error.Error())
+	}
+	if len(msg.Logs) == 0 {
+		return m, m.ShowFlashWarning("GitHub returned no logs for the failed CI jobs")
+	}
+	if state := m.sessionState().GetIfExists(sess.ID); m.paused || (state != nil && (state.GetIsWaiting() || state.IsMerging())) {
+		return m, m.ShowFlashWarning("Session is busy; press L again to send the CI logs when it is idle")
+	}
+
+	m.ciLogsSent[sess.ID] = msg.RunsKey
+	log.Debug("sending failing CI logs to Claude", "runs", msg.RunsKey, "jobs", len(msg.Logs))
+	return m.sendPromptToSession(sess, ciLogsPrompt(msg.Logs, msg.External))
+}
+
+// ciLogsPrompt composes the prompt asking Claude to fix failing CI, with the tail
+// of each failed job's log in a fenced block under the job's name.
+func ciLogsPrompt(logs []git.CIJobLog, external []string) string {
+	var sb strings.Builder
+	sb.WriteString("CI is failing on this branch's PR. Here are the ends of the failed jobs' logs:\n\n")
+	for _, jobLog := range logs {
+		sb.WriteString(fmt.Sprintf("### %s (run %s)\n", jobLog.Job, jobLog.RunID))
+		if jobLog.Omitted > 0 {
+			sb.WriteString(fmt.Sprintf("Last %d lines; %d earlier lines omitted.\n", len(jobLog.Lines), jobLog.Omitted))
+		}
+		fence := codeFence(jobLog.Lines)
+		sb.WriteString(fence + "text\n")
+		for _, line := range jobLog.Lines {
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString(fence + "\n\n")
+	}
+	if len(external) > 0 {
+		sb.WriteString("These checks outside GitHub Actions also failed: " + strings.Join(external, ", ") + "\n\n")
+	}
+	sb.WriteString("Please find the cause of each failure and fix it.")
+	return sb.String()
+}
+
+// codeFence returns a backtick fence longer than any run of backticks in lines,
+// so the log cannot close its block early.
+func codeFence(lines []string) string {
+	longest := 0
+	for _, line := range lines {
+		run := 0
+		for _, r := range line {
+			if r == '`' {
+				run++
+				longest = max(longest, run)
+			} else {
+				run = 0
+			}
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// shortcutFetchCILogs fetches the failing CI logs of the selected session's PR
+// and sends them to Claude.
+func shortcutFetchCILogs(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	if m.ciLogsLoading[sess.ID] {
+		return m, m.ShowFlashInfo("Already fetching the failing CI logs...")
+	}
+	m.ciLogsLoading[sess.ID] = true
+	return m, tea.Batch(
+		m.ShowFlashInfo("Looking for failing CI runs..."),
+		m.findFailingCIRuns(sess.ID, sess.RepoPath, sess.Branch),
+	)
+}
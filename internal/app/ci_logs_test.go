@@ -0,0 +1,108 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	pexec "github.com/zhubert/plural/internal/exec"
+	"github.com/zhubert/plural/internal/git"
+)
+
+func TestFetchCILogs_SendsAndDedupes(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].PRCreated = true
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddExactMatch("gh", []string{"pr", "checks", "feature-branch", "--json", "name,bucket,link"}, pexec.MockResponse{
+		Stdout: []byte(`[{"name": "test", "bucket": "fail", "link": "https://github.com/o/r/actions/runs/111/job/1"}]`),
+	})
+	mock.AddExactMatch("gh", []string{"run", "view", "111", "--log-failed"}, pexec.MockResponse{
+		Stdout: []byte("test\tRun go test\t2026-01-02T03:04:05.0000000Z --- FAIL: TestFoo\n"),
+	})
+	m.gitService = git.NewGitServiceWithExecutor(mock)
+
+	_, cmd := shortcutFetchCILogs(m)
+	if cmd == nil || !m.footer.HasFlash() {
+		t.Fatal("expected a progress flash and a fetch")
+	}
+	if _, again := shortcutFetchCILogs(m); again == nil || !m.ciLogsLoading["session-1"] {
+		t.Fatal("expected a second press to be refused while fetching")
+	}
+
+	_, cmd = m.handleCIFailingRunsMsg(m.findFailingCIRuns("session-1", "/test/repo1", "feature-branch")().(CIFailingRunsMsg))
+	if cmd == nil {
+		t.Fatal("expected the logs to be fetched")
+	}
+	runs := []git.CIRun{{ID: "111", Checks: []string{"test"}}}
+	m.Update(m.fetchCILogs("session-1", "/test/repo1", runs, nil)())
+
+	if m.activeSession == nil || m.activeSession.ID != "session-1" {
+		t.Fatal("expected the session to be made active")
+	}
+	if state := m.sessionState().GetIfExists("session-1"); state == nil || !state.GetIsWaiting() {
+		t.Fatal("expected the logs to be sent to Claude")
+	}
+	if view := ansi.Strip(m.chat.View()); !strings.Contains(view, "test (run 111)") {
+		t.Errorf("expected the job header in the prompt, got:\n%s", view)
+	}
+	if m.ciLogsLoading["session-1"] {
+		t.Error("expected the fetch to be done")
+	}
+
+	// The same failing run again is not sent twice
+	m.Update(CIFailingRunsMsg{SessionID: "session-1", Runs: runs})
+	if got := ansi.Strip(m.footer.View()); !strings.Contains(got, "already sent") {
+		t.Errorf("expected the duplicate to be reported, got %q", got)
+	}
+
+	// A re-run of the failed run is fetched again
+	m.ciLogsLoading["session-1"] = true
+	if _, cmd := m.handleCIFailingRunsMsg(CIFailingRunsMsg{SessionID: "session-1", Runs: []git.CIRun{{ID: "111", Attempt: 2, Checks: []string{"test"}}}}); cmd == nil || !m.ciLogsLoading["session-1"] {
+		t.Error("expected the logs of a re-run to be fetched")
+	}
+}
+
+func TestTrackCIStatus_FlashesWhenTurningFailing(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].PRCreated = true
+	m, _ := testModelWithMocks(cfg, 120, 40)
+
+	if m.trackCIStatus("session-1", git.CIStatusPending) != nil {
+		t.Error("expected no flash for pending CI")
+	}
+	if m.trackCIStatus("session-1", git.CIStatusFailing) == nil {
+		t.Fatal("expected a flash when CI turns failing")
+	}
+	if got := ansi.Strip(m.footer.View()); !strings.Contains(got, "L to fetch") {
+		t.Errorf("expected a hint to fetch the logs, got %q", got)
+	}
+	if m.trackCIStatus("session-1", git.CIStatusFailing) != nil {
+		t.Error("expected no second flash while CI stays failing")
+	}
+
+	m.trackCIStatus("session-1", git.CIStatusPassing)
+	if m.trackCIStatus("session-1", git.CIStatusFailing) == nil {
+		t.Error("expected a flash when CI fails again")
+	}
+}
+
+func TestCILogsPrompt(t *testing.T) {
+	logs := []git.CIJobLog{
+		{RunID: "111", Job: "test", Lines: []string{"--- FAIL: TestFoo"}, Omitted: 40},
+		{RunID: "111", Job: "docs", Lines: []string{"bad fence ```go"}},
+	}
+	prompt := ciLogsPrompt(logs, []string{"buildkite"})
+
+	for _, want := range []string{
+		"### test (run 111)\nLast 1 lines; 40 earlier lines omitted.\n```text\n--- FAIL: TestFoo\n```\n",
+		"### docs (run 111)\n````text\nbad fence ```go\n````\n",
+		"also failed: buildkite",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected the prompt to contain %q, got:\n%s", want, prompt)
+		}
+	}
+}
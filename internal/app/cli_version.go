@@ -0,0 +1,106 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/cli"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// detectClaudeVersion returns the installed Claude CLI's version. A variable so
+// tests can stub it.
+var detectClaudeVersion = cli.ClaudeVersion
+
+// ClaudeVersionMsg carries the installed Claude CLI's version, or "" if it is unknown.
+type ClaudeVersionMsg struct {
+	Version string
+}
+
+// refreshClaudeVersion returns a command that asks the Claude CLI its version.
+// It runs in the background since the CLI can be slow to answer.
+func refreshClaudeVersion() tea.Cmd {
+	return func() tea.Msg {
+		return ClaudeVersionMsg{Version: detectClaudeVersion()}
+	}
+}
+
+// handleClaudeVersionMsg remembers the Claude CLI's version for checkCLIVersion.
+func (m *Model) handleClaudeVersionMsg(msg ClaudeVersionMsg) (tea.Model, tea.Cmd) {
+	m.claudeVersion = msg.Version
+	return m, nil
+}
+
+// checkCLIVersion is called before a message starts the active session's Claude
+// CLI process. It records the CLI's version, as last asked, with the session and,
+// if the session resumes a conversation last run with another version, asks,
+// warns, or carries on as cli_version_change says. Returns false if the message
+// must wait for the user's answer, with any command to run either way; the
+// version is asked again in the background so an upgrade is noticed next time.
+func (m *Model) checkCLIVersion() (tea.Cmd, bool) {
+	sess := m.activeSession
+	// Container sessions run the CLI installed in their image, not the host's
+	if sess == nil || sess.Containerized || m.claudeRunner == nil || m.claudeRunner.Health().ProcessRunning {
+		return nil, true
+	}
+	current := m.claudeVersion
+	refresh := refreshClaudeVersion()
+	if current == "" || current == sess.CLIVersion {
+		return refresh, true
+	}
+	previous := sess.CLIVersion
+	if !sess.Started || !cli.VersionChanged(previous, current) {
+		return tea.Batch(refresh, m.recordCLIVersion(sess.ID, current)), true
+	}
+
+	log := logger.WithSession(sess.ID)
+	switch m.config.GetCLIVersionChange() {
+	case config.CLIVersionChangeIgnore:
+		log.Info("resuming with another Claude CLI version", "previous", previous, "current", current)
+		return tea.Batch(refresh, m.recordCLIVersion(sess.ID, current)), true
+	case config.CLIVersionChangeWarn:
+		log.Warn("resuming with another Claude CLI version", "previous", previous, "current", current)
+		text := fmt.Sprintf("Claude CLI changed from %s to %s since this session last ran", previous, current)
+		return tea.Batch(refresh, m.recordCLIVersion(sess.ID, current), m.ShowFlashWarning(text)), true
+	}
+	log.Info("asking before resuming with another Claude CLI version", "previous", previous, "current", current)
+	m.modal.Show(ui.NewCLIVersionChangedState(sess.ID, sess.Name, previous, current))
+	return refresh, false
+}
+
+// recordCLIVersion saves the Claude CLI version a session runs with.
+func (m *Model) recordCLIVersion(sessionID, version string) tea.Cmd {
+	m.config.SetSessionCLIVersion(sessionID, version)
+	if m.activeSession != nil && m.activeSession.ID == sessionID {
+		m.activeSession.CLIVersion = version
+		m.chat.SetSessionInfo(sessionInfo(m.config, m.activeSession))
+	}
+	return m.saveConfigOrFlash()
+}
+
+// handleCLIVersionChangedModal handles key events for the CLI Version Changed
+// modal. Continuing sends the message left in the input; cancelling keeps it there.
+func (m *Model) handleCLIVersionChangedModal(key string, msg tea.KeyPressMsg, state *ui.CLIVersionChangedState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		m.modal.Hide()
+		if !state.ShouldContinue() || m.activeSession == nil || m.activeSession.ID != state.SessionID {
+			return m, nil
+		}
+		logger.WithSession(state.SessionID).Info("resuming with another Claude CLI version", "previous", state.PreviousVersion, "current", state.CurrentVersion)
+		saveCmd := m.recordCLIVersion(state.SessionID, state.CurrentVersion)
+		model, cmd := m.sendMessage()
+		return model, tea.Batch(saveCmd, cmd)
+	case keys.Up, keys.Down, "j", "k":
+		modal, cmd := m.modal.Update(msg)
+		m.modal = modal
+		return m, cmd
+	}
+	return m, nil
+}
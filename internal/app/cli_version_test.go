@@ -0,0 +1,120 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// cliVersionTestModel returns a model with session-1, last run with Claude CLI
+// 2.0.14, active and a message in the input, with 2.0.15 now installed.
+func cliVersionTestModel(t *testing.T, behavior string) *Model {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+	detectClaudeVersion = func() string { return "2.0.15" }
+	t.Cleanup(func() { detectClaudeVersion = func() string { return "" } })
+
+	cfg := testConfigWithSessions()
+	cfg.SetFilePath(filepath.Join(home, "config.json"))
+	cfg.Sessions[0].CLIVersion = "2.0.14"
+	cfg.SetCLIVersionChange(behavior)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.Update(refreshClaudeVersion()())
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+	m.chat.SetInput("carry on")
+	return m
+}
+
+func TestCLIVersionChange_AsksBeforeResuming(t *testing.T) {
+	m := cliVersionTestModel(t, config.CLIVersionChangeAsk)
+
+	m.sendMessage()
+	state, ok := m.modal.State.(*ui.CLIVersionChangedState)
+	if !ok {
+		t.Fatalf("expected the CLI version modal, got %T", m.modal.State)
+	}
+	if state.PreviousVersion != "2.0.14" || state.CurrentVersion != "2.0.15" {
+		t.Errorf("modal shows %s → %s, want 2.0.14 → 2.0.15", state.PreviousVersion, state.CurrentVersion)
+	}
+	if m.chat.GetInput() != "carry on" {
+		t.Error("expected the message kept in the input while asking")
+	}
+
+	m = sendKey(m, "enter")
+	if m.modal.IsVisible() {
+		t.Error("expected the modal closed after continuing")
+	}
+	if m.chat.GetInput() != "" {
+		t.Error("expected the message sent after continuing")
+	}
+	if got := m.config.GetSession("session-1").CLIVersion; got != "2.0.15" {
+		t.Errorf("recorded CLI version %q, want 2.0.15", got)
+	}
+}
+
+func TestCLIVersionChange_CancelKeepsMessage(t *testing.T) {
+	m := cliVersionTestModel(t, config.CLIVersionChangeAsk)
+
+	m.sendMessage()
+	m = sendKey(m, "esc")
+	if m.modal.IsVisible() || m.chat.GetInput() != "carry on" {
+		t.Error("expected the modal closed with the message left in the input")
+	}
+	if got := m.config.GetSession("session-1").CLIVersion; got != "2.0.14" {
+		t.Errorf("expected the recorded version unchanged, got %q", got)
+	}
+}
+
+func TestCLIVersionChange_Warn(t *testing.T) {
+	m := cliVersionTestModel(t, config.CLIVersionChangeWarn)
+
+	m.sendMessage()
+	if m.modal.IsVisible() {
+		t.Error("expected no modal when warning")
+	}
+	if !m.footer.HasFlash() || m.chat.GetInput() != "" {
+		t.Error("expected the message sent with a warning")
+	}
+	if got := m.config.GetSession("session-1").CLIVersion; got != "2.0.15" {
+		t.Errorf("recorded CLI version %q, want 2.0.15", got)
+	}
+}
+
+func TestCLIVersionChange_NewSessionRecordsVersion(t *testing.T) {
+	m := cliVersionTestModel(t, config.CLIVersionChangeAsk)
+	m.activeSession.Started = false
+	m.activeSession.CLIVersion = ""
+
+	m.sendMessage()
+	if m.modal.IsVisible() {
+		t.Error("expected no question for a session without a conversation")
+	}
+	if got := m.config.GetSession("session-1").CLIVersion; got != "2.0.15" {
+		t.Errorf("recorded CLI version %q, want 2.0.15", got)
+	}
+}
+
+func TestCLIVersionChange_AsksAgainInBackground(t *testing.T) {
+	m := cliVersionTestModel(t, config.CLIVersionChangeIgnore)
+	m.claudeVersion = ""
+
+	cmd, _ := m.checkCLIVersion()
+	if cmd == nil {
+		t.Fatal("expected a command asking the CLI its version")
+	}
+	if got := m.config.GetSession("session-1").CLIVersion; got != "2.0.14" {
+		t.Errorf("expected nothing recorded before the version is known, got %q", got)
+	}
+	m.Update(cmd())
+	if m.claudeVersion != "2.0.15" {
+		t.Errorf("claudeVersion = %q after asking, want 2.0.15", m.claudeVersion)
+	}
+}
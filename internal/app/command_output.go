@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// commandOutputTimeout is how long a command run with /run may take before
+// it is stopped and whatever it printed is inserted.
+const commandOutputTimeout = 60 * time.Second
+
+// scrollbackReadBytes caps how much of the end of the scrollback file is read.
+const scrollbackReadBytes = 256 * 1024
+
+// CommandOutputMsg carries the output of a command run with /run, or of the
+// scrollback file, as a block to insert into the session's input.
+type CommandOutputMsg struct {
+	SessionID string
+	Block     string
+	Error     error
+}
+
+// runCommandForInput creates a command that runs command in the active
+// session's worktree and inserts its output into the input. With no command,
+// the end of the command_output_file scrollback file is inserted instead.
+func (m *Model) runCommandForInput(command string) tea.Cmd {
+	sess := m.activeSession
+	maxLines := m.config.GetCommandOutputLines()
+
+	if command == "" {
+		path := expandHomePath(m.config.GetCommandOutputFile())
+		return func() tea.Msg {
+			data, err := readFileTail(path, scrollbackReadBytes)
+			if err != nil {
+				return CommandOutputMsg{SessionID: sess.ID, Error: fmt.Errorf("reading scrollback file: %w", err)}
+			}
+			lines, omitted := git.TailLines(data, maxLines)
+			return CommandOutputMsg{SessionID: sess.ID, Block: outputBlock("End of "+path+":", lines, omitted)}
+		}
+	}
+
+	gitSvc := m.gitService
+	logger.WithSession(sess.ID).Info("running command for input", "command", command, "dir", sess.WorkTree)
+	return tea.Batch(
+		m.ShowFlashInfo("Running "+command+"..."),
+		func() tea.Msg {
+			output, err := gitSvc.RunShellCommand(context.Background(), sess.WorkTree, command, commandOutputTimeout, maxLines)
+			if err != nil {
+				return CommandOutputMsg{SessionID: sess.ID, Error: fmt.Errorf("running %s: %w", command, err)}
+			}
+			label := "Output of `" + command + "`"
+			switch {
+			case output.TimedOut:
+				label += fmt.Sprintf(" (stopped after %s)", commandOutputTimeout)
+			case output.ExitCode != 0:
+				label += fmt.Sprintf(" (exit status %d)", output.ExitCode)
+			}
+			return CommandOutputMsg{SessionID: sess.ID, Block: outputBlock(label+":", output.Lines, output.Omitted)}
+		},
+	)
+}
+
+// handleCommandOutputMsg inserts command output into the input of its session,
+// if that session is still the one shown.
+func (m *Model) handleCommandOutputMsg(msg CommandOutputMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		logger.WithSession(msg.SessionID).Warn("failed to capture command output", "error", msg.Error)
+		return m, m.ShowFlashError(msg.Error.Error())
+	}
+	if m.activeSession == nil || m.activeSession.ID != msg.SessionID {
+		return m, m.ShowFlashWarning("Command output discarded: its session is no longer shown")
+	}
+	m.chat.InsertInput(msg.Block)
+	return m, nil
+}
+
+// outputBlock labels lines of output and puts them in a fenced block, noting
+// how many earlier lines were cut.
+func outputBlock(label string, lines []string, omitted int) string {
+	var sb strings.Builder
+	sb.WriteString(label + "\n")
+	if omitted > 0 {
+		sb.WriteString(fmt.Sprintf("Last %d lines; %d earlier lines omitted.\n", len(lines), omitted))
+	}
+	fence := codeFence(lines)
+	sb.WriteString(fence + "text\n")
+	for _, line := range lines {
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString(fence + "\n")
+	return sb.String()
+}
+
+// readFileTail reads up to maxBytes from the end of the file at path, starting
+// at a line boundary when the file is longer.
+func readFileTail(path string, maxBytes int64) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	offset := max(info.Size()-maxBytes, 0)
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return "", err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	text := string(data)
+	if offset > 0 {
+		// Drop the partial line the read started in
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			text = text[i+1:]
+		}
+	}
+	return text, nil
+}
+
+// expandHomePath expands a leading ~ in path to the home directory.
+func expandHomePath(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
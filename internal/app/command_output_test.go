@@ -0,0 +1,118 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	pexec "github.com/zhubert/plural/internal/exec"
+	"github.com/zhubert/plural/internal/git"
+)
+
+// commandOutputMsgFrom runs cmd, and the commands it batches, until it finds a CommandOutputMsg.
+func commandOutputMsgFrom(t *testing.T, cmd tea.Cmd) CommandOutputMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	switch msg := cmd().(type) {
+	case CommandOutputMsg:
+		return msg
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if c == nil {
+				continue
+			}
+			if out, ok := c().(CommandOutputMsg); ok {
+				return out
+			}
+		}
+	}
+	t.Fatal("expected a CommandOutputMsg")
+	return CommandOutputMsg{}
+}
+
+func TestRunCommandForInput(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddExactMatch("sh", []string{"-c", "go vet ./..."}, pexec.MockResponse{Stdout: []byte("main.go:3: unreachable code\n")})
+	m.gitService = git.NewGitServiceWithExecutor(mock)
+
+	m.chat.SetInput("/run go vet ./...")
+	result, cmd := m.sendMessage()
+	m = result.(*Model)
+	if m.chat.GetInput() != "" {
+		t.Errorf("expected the slash command to be cleared from the input, got %q", m.chat.GetInput())
+	}
+
+	msg := commandOutputMsgFrom(t, cmd)
+	if calls := mock.GetCalls(); len(calls) != 1 || calls[0].Dir != "/test/worktree1" {
+		t.Errorf("expected the command to run in the session's worktree, got %+v", calls)
+	}
+
+	result, _ = m.Update(msg)
+	m = result.(*Model)
+	want := "Output of `go vet ./...`:\n```text\nmain.go:3: unreachable code\n```"
+	if got := strings.TrimSpace(m.chat.GetInput()); got != want {
+		t.Errorf("input = %q, want %q", got, want)
+	}
+}
+
+func TestRunCommandForInput_ScrollbackFile(t *testing.T) {
+	cfg := testConfigWithSessions()
+	path := filepath.Join(t.TempDir(), "scrollback.log")
+	if err := os.WriteFile(path, []byte("$ make\n\x1b[31merror\x1b[0m: build failed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.CommandOutputFile = path
+	cfg.CommandOutputLines = 1
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	msg := commandOutputMsgFrom(t, m.runCommandForInput(""))
+	if msg.Error != nil {
+		t.Fatalf("unexpected error: %v", msg.Error)
+	}
+	if !strings.Contains(msg.Block, "End of "+path) || !strings.Contains(msg.Block, "1 earlier lines omitted") ||
+		!strings.Contains(msg.Block, "error: build failed") || strings.Contains(msg.Block, "$ make") {
+		t.Errorf("unexpected block %q", msg.Block)
+	}
+}
+
+func TestHandleCommandOutputMsg_OtherSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	result, _ := m.Update(CommandOutputMsg{SessionID: "session-2", Block: "output"})
+	m = result.(*Model)
+	if m.chat.GetInput() != "" {
+		t.Error("expected output for another session not to be inserted")
+	}
+	if !m.footer.HasFlash() {
+		t.Error("expected a flash saying the output was discarded")
+	}
+}
+
+func TestReadFileTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	if err := os.WriteFile(path, []byte("first line\nsecond\nthird\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The read starts mid "first line", which is dropped
+	got, err := readFileTail(path, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "second\nthird\n" {
+		t.Errorf("readFileTail = %q", got)
+	}
+}
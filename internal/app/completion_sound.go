@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// completionSoundTimeout bounds how long a completion sound command may run.
+const completionSoundTimeout = 10 * time.Second
+
+// completionSound returns a command that plays the configured completion sound:
+// the terminal bell for "bell", otherwise the configured shell command. Returns
+// nil when no sound is configured.
+func (m *Model) completionSound() tea.Cmd {
+	sound := strings.TrimSpace(m.config.GetCompletionSound())
+	switch sound {
+	case "":
+		return nil
+	case "bell":
+		return tea.Raw(string(rune(ansi.BEL)))
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), completionSoundTimeout)
+		defer cancel()
+		if output, err := exec.CommandContext(ctx, "sh", "-c", sound).CombinedOutput(); err != nil {
+			logger.Get().Warn("completion sound command failed", "command", sound, "error", err, "output", strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+}
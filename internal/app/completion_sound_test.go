@@ -0,0 +1,38 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestCompletionSound(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModel(cfg)
+
+	if cmd := m.completionSound(); cmd != nil {
+		t.Error("expected no sound by default")
+	}
+
+	cfg.SetCompletionSound("bell")
+	cmd := m.completionSound()
+	if cmd == nil {
+		t.Fatal("expected a command for the bell")
+	}
+	if _, ok := cmd().(tea.RawMsg); !ok {
+		t.Error("expected the bell written raw to the terminal")
+	}
+
+	played := filepath.Join(t.TempDir(), "played")
+	cfg.SetCompletionSound("touch " + played)
+	cmd = m.completionSound()
+	if cmd == nil {
+		t.Fatal("expected a command for the sound command")
+	}
+	cmd()
+	if _, err := os.Stat(played); err != nil {
+		t.Errorf("expected the sound command to run: %v", err)
+	}
+}
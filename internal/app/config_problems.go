@@ -0,0 +1,37 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// logConfigProblems logs the problems found when the config file was loaded,
+// with the full report at debug level.
+func logConfigProblems(cfg *config.Config) {
+	problems := cfg.Problems()
+	if len(problems) == 0 {
+		return
+	}
+	log := logger.WithComponent("config")
+	log.Warn("config file has problems", "file", cfg.FilePath(), "count", len(problems))
+	for _, p := range problems {
+		log.Debug("config problem", "path", p.Path, "problem", p.Message)
+	}
+}
+
+// configProblemsNotice returns a flash summarizing problems in the config file,
+// or nil if there are none.
+func (m *Model) configProblemsNotice() tea.Cmd {
+	problems := m.config.Problems()
+	switch len(problems) {
+	case 0:
+		return nil
+	case 1:
+		return m.ShowFlashWarning(fmt.Sprintf("Config problem in %s: %s", m.config.FilePath(), problems[0]))
+	default:
+		return m.ShowFlashWarning(fmt.Sprintf("%d config problems in %s (see log)", len(problems), m.config.FilePath()))
+	}
+}
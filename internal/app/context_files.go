@@ -0,0 +1,71 @@
+package app
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// shortcutContextFiles opens the context files overlay for the selected session.
+func shortcutContextFiles(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	m.modal.Show(ui.NewContextFilesState(sess.ID, ui.SessionDisplayName(sess.Branch, sess.Name), m.config.GetSessionContextFiles(sess.ID)))
+	return m, nil
+}
+
+// handleContextFilesModal handles key events for the context files overlay.
+// Changes are saved immediately and apply the next time a fresh CLI session starts.
+func (m *Model) handleContextFilesModal(key string, msg tea.KeyPressMsg, state *ui.ContextFilesState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		if state.GetInput() == "" {
+			return m, nil
+		}
+		sess := m.config.GetSession(state.SessionID)
+		if sess == nil {
+			m.modal.Hide()
+			return m, m.ShowFlashError("Session no longer exists")
+		}
+		path, err := manager.ValidateContextFile(sess.WorkTree, state.GetInput())
+		if err != nil {
+			state.Error = err.Error()
+			return m, nil
+		}
+		files := m.config.GetSessionContextFiles(sess.ID)
+		if slices.Contains(files, path) {
+			state.Error = path + " is already a context file"
+			return m, nil
+		}
+		files = append(files, path)
+		logger.WithSession(sess.ID).Info("added context file", "path", path)
+		state.Input.SetValue("")
+		state.SelectedIndex = len(files) - 1
+		return m, m.setContextFiles(state, files)
+	case keys.CtrlD:
+		path := state.SelectedFile()
+		if path == "" {
+			return m, nil
+		}
+		files := slices.DeleteFunc(m.config.GetSessionContextFiles(state.SessionID), func(f string) bool { return f == path })
+		logger.WithSession(state.SessionID).Info("removed context file", "path", path)
+		return m, m.setContextFiles(state, files)
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// setContextFiles saves a session's context files and refreshes the overlay.
+func (m *Model) setContextFiles(state *ui.ContextFilesState, files []string) tea.Cmd {
+	m.config.SetSessionContextFiles(state.SessionID, files)
+	state.SetFiles(files)
+	state.Error = ""
+	return m.saveConfigOrFlash()
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// defaultBranchCheckTimeout bounds the fetch that re-resolves a repo's default branch.
+const defaultBranchCheckTimeout = 30 * time.Second
+
+// DefaultBranchCheckMsg carries a repo's default branch as re-resolved from origin
+// while the merge modal is open.
+type DefaultBranchCheckMsg struct {
+	SessionID       string
+	RepoPath        string
+	BaseBranch      string   // Session's recorded base branch
+	PreviousDefault string   // Default branch known locally before the refresh
+	DefaultBranch   string   // Default branch after the refresh
+	BaseGone        bool     // Whether BaseBranch no longer exists on origin
+	RemoteBranches  []string // Origin's branches after pruning (nil if listing failed)
+}
+
+// checkDefaultBranch returns a command that re-resolves the default branch of the
+// session's repo from origin and checks whether the session's base branch still exists.
+// It runs in the background since it fetches from origin.
+func checkDefaultBranch(gitSvc *git.GitService, sessionID, repoPath, baseBranch, previousDefault string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultBranchCheckTimeout)
+		defer cancel()
+		msg := DefaultBranchCheckMsg{
+			SessionID:       sessionID,
+			RepoPath:        repoPath,
+			BaseBranch:      baseBranch,
+			PreviousDefault: previousDefault,
+			DefaultBranch:   gitSvc.RefreshDefaultBranch(ctx, repoPath),
+		}
+		msg.BaseGone = gitSvc.BaseBranchGone(ctx, repoPath, baseBranch)
+		if branches, err := gitSvc.ListRemoteBranches(ctx, repoPath); err == nil {
+			msg.RemoteBranches = branches
+		}
+		return msg
+	}
+}
+
+// RemoteBranchesMsg carries origin's branches, as last fetched, for completing the
+// PR base branch in the merge modal.
+type RemoteBranchesMsg struct {
+	SessionID string
+	Branches  []string
+}
+
+// listRemoteBranches returns a command that lists origin's branches without fetching.
+func listRemoteBranches(gitSvc *git.GitService, sessionID, repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		branches, err := gitSvc.ListRemoteBranches(context.Background(), repoPath)
+		if err != nil {
+			logger.WithSession(sessionID).Warn("failed to list remote branches for PR base completion", "error", err)
+		}
+		return RemoteBranchesMsg{SessionID: sessionID, Branches: branches}
+	}
+}
+
+// handleRemoteBranchesMsg fills in the merge modal's PR base completions, unless the
+// default branch check already replaced them with a fresher list.
+func (m *Model) handleRemoteBranchesMsg(msg RemoteBranchesMsg) (tea.Model, tea.Cmd) {
+	state, ok := m.modal.State.(*ui.MergeState)
+	if !ok || len(state.BaseBranchInput.AvailableSuggestions()) > 0 {
+		return m, nil
+	}
+	if sess := m.sidebar.SelectedSession(); sess == nil || sess.ID != msg.SessionID {
+		return m, nil
+	}
+	state.BaseBranchInput.SetSuggestions(msg.Branches)
+	return m, nil
+}
+
+// handleDefaultBranchCheckMsg updates the merge modal with the re-resolved default
+// branch, warning when the session's base branch is gone from origin. The merge
+// options are judged again against a default branch that changed.
+func (m *Model) handleDefaultBranchCheckMsg(msg DefaultBranchCheckMsg) (tea.Model, tea.Cmd) {
+	log := logger.WithSession(msg.SessionID)
+	if msg.DefaultBranch != msg.PreviousDefault {
+		log.Info("default branch changed on origin", "previous", msg.PreviousDefault, "default", msg.DefaultBranch)
+	}
+
+	state, ok := m.modal.State.(*ui.MergeState)
+	if !ok {
+		return m, nil
+	}
+	sess := m.sidebar.SelectedSession()
+	if sess == nil || sess.ID != msg.SessionID {
+		return m, nil
+	}
+	if msg.DefaultBranch != msg.PreviousDefault {
+		if divergence, err := m.gitService.GetBranchDivergence(context.Background(), sess.RepoPath, sess.Branch, msg.DefaultBranch); err == nil {
+			state.SetCommitsAhead(divergence.Ahead)
+		}
+	}
+	if msg.BaseGone && msg.BaseBranch != msg.DefaultBranch {
+		log.Warn("session base branch no longer exists on origin", "baseBranch", msg.BaseBranch, "default", msg.DefaultBranch)
+		state.SetStaleBaseBranch(msg.BaseBranch, msg.DefaultBranch, m.config.CountSessionsWithBaseBranch(msg.RepoPath, msg.BaseBranch))
+	}
+	state.UpdateDefaultBranch(msg.PreviousDefault, msg.DefaultBranch, msg.RemoteBranches)
+	return m, nil
+}
+
+// migrateSessionsBaseBranch moves every session of the repo off the stale base branch
+// shown in the merge modal onto the re-resolved default branch.
+func (m *Model) migrateSessionsBaseBranch(repoPath string, state *ui.MergeState) tea.Cmd {
+	stale, target := state.StaleBaseBranch, state.DefaultBranch
+	count := m.config.MigrateSessionsBaseBranch(repoPath, stale, target)
+	state.MigratableSessions = 0
+	logger.Get().Info("migrated sessions to new default branch", "repoPath", repoPath, "from", stale, "to", target, "sessions", count)
+
+	m.sidebar.SetSessions(m.getFilteredSessions())
+	if m.activeSession != nil && m.activeSession.RepoPath == repoPath && m.activeSession.BaseBranch == stale {
+		m.activeSession.BaseBranch = target
+		m.header.SetBaseBranch(target)
+		m.chat.SetSessionInfo(sessionInfo(m.config, m.activeSession))
+	}
+
+	noun := "session"
+	if count != 1 {
+		noun = "sessions"
+	}
+	return tea.Batch(m.saveConfigOrFlash(), m.ShowFlashSuccess(fmt.Sprintf("Updated %d %s from %s to %s", count, noun, stale, target)))
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"regexp"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/mcp"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// compileDestructiveRules compiles a repo's destructive command rules for the
+// classifier. Patterns that don't compile are skipped; config validation
+// reports them.
+func compileDestructiveRules(rules config.DestructiveCommandRules) claude.DestructiveRules {
+	compile := func(patterns []string) []*regexp.Regexp {
+		var compiled []*regexp.Regexp
+		for _, pattern := range patterns {
+			if re, err := regexp.Compile(pattern); err == nil {
+				compiled = append(compiled, re)
+			}
+		}
+		return compiled
+	}
+	return claude.DestructiveRules{Extra: compile(rules.Patterns), Allow: compile(rules.Allow)}
+}
+
+// classifyPermission returns the command of a Bash permission request and its
+// destructive parts, judged against the session's worktree and its repo's
+// rules. Other tools have no command and nothing destructive.
+func (m *Model) classifyPermission(sessionID string, req *mcp.PermissionRequest) (string, []claude.DestructiveMatch) {
+	if req.Tool != "Bash" {
+		return "", nil
+	}
+	command, _ := req.Arguments["command"].(string)
+	if command == "" {
+		return "", nil
+	}
+	var worktree string
+	var rules claude.DestructiveRules
+	if sess := m.config.GetSession(sessionID); sess != nil {
+		worktree = sess.WorkTree
+		rules = compileDestructiveRules(m.config.GetRepoDestructiveCommands(sess.RepoPath))
+	}
+	return command, claude.ClassifyDestructiveCommand(command, worktree, rules)
+}
+
+// showPendingPermission shows a session's permission request in the chat, as
+// the prompt that asks for typing ui.DestructiveConfirmPhrase when it runs a
+// destructive command.
+func (m *Model) showPendingPermission(sessionID string, req *mcp.PermissionRequest) {
+	if command, matches := m.classifyPermission(sessionID, req); len(matches) > 0 {
+		m.chat.SetPendingDestructivePermission(req.Tool, req.Description, command, matches)
+		return
+	}
+	m.chat.SetPendingPermission(req.Tool, req.Description)
+}
+
+// handleDestructivePermissionKey handles keys while a destructive permission
+// prompt is shown: n denies, enter allows once the phrase is typed, and other
+// keys type it. There's no always-allow for destructive commands.
+func (m *Model) handleDestructivePermissionKey(key, sessionID string, req *mcp.PermissionRequest) (tea.Model, tea.Cmd) {
+	switch key {
+	case "n", "N":
+		return m.handlePermissionResponse("n", sessionID, req)
+	case keys.Enter:
+		if m.chat.PermissionConfirmed() {
+			return m.handlePermissionResponse("y", sessionID, req)
+		}
+		return m, m.ShowFlashWarning("Type " + ui.DestructiveConfirmPhrase + " to run this command, or n to deny it")
+	}
+	m.chat.TypePermissionConfirmation(key)
+	return m, nil
+}
+
+// isConfirmationKey returns whether a key goes to a destructive permission
+// prompt: characters, backspace, and enter. Others (tab, ctrl+c) work as usual.
+func isConfirmationKey(key string) bool {
+	return key == keys.Enter || key == keys.Backspace || key == keys.Space || utf8.RuneCountInString(key) == 1
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/mcp"
+)
+
+// simulateBashPermissionRequest injects a permission request to run a Bash command.
+func simulateBashPermissionRequest(m *Model, sessionID, command string) *Model {
+	result, _ := m.Update(PermissionRequestMsg{
+		SessionID: sessionID,
+		Request: mcp.PermissionRequest{
+			Tool:        "Bash",
+			Description: "Run: " + command,
+			Arguments:   map[string]any{"command": command},
+		},
+	})
+	return result.(*Model)
+}
+
+func TestDestructivePermission_RequiresTypingAllow(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+	sessionID := m.activeSession.ID
+
+	m = simulateBashPermissionRequest(m, sessionID, "git push --force origin main")
+	if !m.chat.HasDestructivePermission() {
+		t.Fatal("expected a force push to get the destructive prompt")
+	}
+
+	// Single keystrokes don't allow it
+	m = sendKey(m, "y")
+	m = sendKey(m, "enter")
+	if !m.chat.HasPendingPermission() {
+		t.Fatal("expected y and enter not to allow a destructive command")
+	}
+
+	for _, key := range []string{"backspace", "a", "l", "l", "o", "w"} {
+		m = sendKey(m, key)
+	}
+	if !m.chat.PermissionConfirmed() {
+		t.Fatal("expected the typed phrase to confirm")
+	}
+	m = sendKey(m, "enter")
+	if m.chat.HasPendingPermission() {
+		t.Error("expected typing allow and pressing enter to allow the command")
+	}
+	if state := m.sessionState().GetIfExists(sessionID); state != nil && state.GetPendingPermission() != nil {
+		t.Error("expected the session's pending permission cleared")
+	}
+}
+
+func TestDestructivePermission_Deny(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m = simulateBashPermissionRequest(m, m.activeSession.ID, "rm -rf /etc")
+	m = sendKey(m, "n")
+	if m.chat.HasPendingPermission() {
+		t.Error("expected n to deny a destructive command")
+	}
+}
+
+func TestDestructivePermission_OrdinaryCommand(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m = simulateBashPermissionRequest(m, m.activeSession.ID, "go test ./...")
+	if !m.chat.HasPendingPermission() || m.chat.HasDestructivePermission() {
+		t.Fatal("expected the usual prompt for an ordinary command")
+	}
+	m = sendKey(m, "y")
+	if m.chat.HasPendingPermission() {
+		t.Error("expected y to allow an ordinary command")
+	}
+}
+
+func TestDestructivePermission_RepoRules(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+	sess := m.activeSession
+
+	cfg.SetRepoDestructiveCommands(sess.RepoPath, config.DestructiveCommandRules{
+		Patterns: []string{`terraform destroy`},
+		Allow:    []string{`origin wip/`},
+	})
+
+	if _, matches := m.classifyPermission(sess.ID, &mcp.PermissionRequest{Tool: "Bash", Arguments: map[string]any{"command": "terraform destroy"}}); len(matches) != 1 {
+		t.Errorf("expected the repo's pattern to flag the command, got %v", matches)
+	}
+	if _, matches := m.classifyPermission(sess.ID, &mcp.PermissionRequest{Tool: "Bash", Arguments: map[string]any{"command": "git push -f origin wip/x"}}); len(matches) != 0 {
+		t.Errorf("expected the repo's allow pattern to override the force push, got %v", matches)
+	}
+	if _, matches := m.classifyPermission(sess.ID, &mcp.PermissionRequest{Tool: "Edit", Arguments: map[string]any{"command": "rm -rf /"}}); len(matches) != 0 {
+		t.Errorf("expected only Bash commands classified, got %v", matches)
+	}
+}
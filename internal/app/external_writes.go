@@ -0,0 +1,75 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/session"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// pendingWrite is a tool use that may write files, awaiting its result.
+type pendingWrite struct {
+	ToolName string
+	Targets  []string // As given by the tool (see claude.ResponseChunk.WriteTargets)
+}
+
+// externalWriteWarning is the chat line warning that path was written outside the worktree.
+func externalWriteWarning(path string) string {
+	return "\n" + ui.ExternalWritePrefix + " " + path + "\n"
+}
+
+// trackExternalWrites records the files a tool use may write. When its result
+// arrives, each of them that lies outside the session's worktree is warned about
+// in the chat and added to the session's external writes, for review before
+// merging. Returns the command saving the config when one was added.
+func (m *Model) trackExternalWrites(sessionID string, chunk claude.ResponseChunk, isActiveSession bool) tea.Cmd {
+	switch chunk.Type {
+	case claude.ChunkTypeToolUse:
+		if len(chunk.WriteTargets) > 0 && chunk.ToolUseID != "" {
+			m.pendingWrites[chunk.ToolUseID] = pendingWrite{ToolName: chunk.ToolName, Targets: chunk.WriteTargets}
+		}
+		return nil
+	case claude.ChunkTypeToolResult:
+	default:
+		return nil
+	}
+
+	write, ok := m.pendingWrites[chunk.ToolUseID]
+	if !ok {
+		return nil
+	}
+	delete(m.pendingWrites, chunk.ToolUseID)
+	// A failed Write or Edit changed nothing, but a command may write before failing
+	if chunk.ToolError && write.ToolName != "Bash" {
+		return nil
+	}
+	sess := m.config.GetSession(sessionID)
+	// A container is its own sandbox; paths in its tool uses are the container's
+	if sess == nil || sess.WorkTree == "" || sess.Containerized {
+		return nil
+	}
+
+	added := false
+	for _, target := range write.Targets {
+		path, outside := session.OutsideWorktree(sess.WorkTree, target)
+		if !outside {
+			continue
+		}
+		logger.WithSession(sessionID).Warn("tool wrote outside worktree", "tool", write.ToolName, "path", path, "worktree", sess.WorkTree)
+		if isActiveSession {
+			m.chat.AppendStreaming(externalWriteWarning(path))
+		} else {
+			state := m.sessionState().GetOrCreate(sessionID)
+			state.FlushToolUseRollup(ui.GetToolIcon, ui.ToolUseInProgress, ui.ToolUseComplete)
+			state.AppendStreamingContent(externalWriteWarning(path))
+		}
+		if m.config.AddSessionExternalWrite(sessionID, path) {
+			added = true
+		}
+	}
+	if !added {
+		return nil
+	}
+	return m.saveConfigOrFlash()
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/claude"
+)
+
+func TestTrackExternalWrites(t *testing.T) {
+	root := t.TempDir()
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].WorkTree = filepath.Join(root, "worktree1")
+	cfg.Sessions[2].WorkTree = filepath.Join(root, "worktree3")
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	toolUse := func(id, tool string, targets ...string) claude.ResponseChunk {
+		return claude.ResponseChunk{Type: claude.ChunkTypeToolUse, ToolName: tool, ToolUseID: id, WriteTargets: targets}
+	}
+	result := func(id string, failed bool) claude.ResponseChunk {
+		return claude.ResponseChunk{Type: claude.ChunkTypeToolResult, ToolUseID: id, ToolError: failed}
+	}
+
+	// Writes inside the worktree aren't reported
+	m.trackExternalWrites("session-1", toolUse("t1", "Write", "main.go"), true)
+	if cmd := m.trackExternalWrites("session-1", result("t1", false), true); cmd != nil {
+		t.Error("expected no save for a write inside the worktree")
+	}
+
+	// A failed Edit wrote nothing
+	m.trackExternalWrites("session-1", toolUse("t2", "Edit", "../repo1/main.go"), true)
+	m.trackExternalWrites("session-1", result("t2", true), true)
+
+	// Reported once the tool completes
+	m.trackExternalWrites("session-1", toolUse("t3", "Bash", "../repo1/main.go"), true)
+	if len(m.config.GetSession("session-1").ExternalWrites) != 0 {
+		t.Fatal("expected nothing recorded before the tool completes")
+	}
+	if cmd := m.trackExternalWrites("session-1", result("t3", true), true); cmd == nil {
+		t.Error("expected the config to be saved")
+	}
+	want := filepath.Join(root, "repo1", "main.go")
+	if got := m.config.GetSession("session-1").ExternalWrites; len(got) != 1 || got[0] != want {
+		t.Fatalf("expected %s recorded, got %v", want, got)
+	}
+	if view := ansi.Strip(m.chat.View()); !strings.Contains(view, "wrote outside worktree") {
+		t.Errorf("expected a warning in the chat, got:\n%s", view)
+	}
+	if len(m.pendingWrites) != 0 {
+		t.Errorf("expected completed tool uses to be forgotten, got %v", m.pendingWrites)
+	}
+
+	// Sessions in the background get the warning in their streaming content
+	m.trackExternalWrites("session-3", toolUse("t4", "Write", "/elsewhere/x.go"), false)
+	m.trackExternalWrites("session-3", result("t4", false), false)
+	if state := m.sessionState().GetIfExists("session-3"); state == nil || !strings.Contains(state.GetStreamingContent(), "/elsewhere/x.go") {
+		t.Error("expected the warning in the background session's streaming content")
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/fileindex"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// FileIndexMsg carries the file index of a session's worktree, built in the background.
+type FileIndexMsg struct {
+	SessionID string
+	Index     *fileindex.Index
+	Err       error
+}
+
+// buildFileIndex returns a command that indexes a worktree's files in the background.
+func buildFileIndex(sessionID, worktree string) tea.Cmd {
+	return func() tea.Msg {
+		ix, err := fileindex.Build(worktree, fileindex.MaxFiles)
+		return FileIndexMsg{SessionID: sessionID, Index: ix, Err: err}
+	}
+}
+
+// showFilePicker opens the file picker for the active session's worktree. The
+// worktree is indexed the first time and the index cached until it goes stale.
+func (m *Model) showFilePicker() (tea.Model, tea.Cmd) {
+	sess := m.activeSession
+	if sess == nil {
+		return m, nil
+	}
+	state := ui.NewFilePickerState(ui.SessionDisplayName(sess.Branch, sess.Name))
+	m.modal.Show(state)
+
+	ix, indexed := m.fileIndexes[sess.ID]
+	if ix != nil {
+		state.SetSearch(ix.Search, ix.Truncated)
+		return m, nil
+	}
+	if indexed {
+		return m, nil // Already being built
+	}
+	m.fileIndexes[sess.ID] = nil
+	return m, buildFileIndex(sess.ID, sess.WorkTree)
+}
+
+// handleFileIndexMsg caches a built file index and lists its files if the
+// picker is open for that session.
+func (m *Model) handleFileIndexMsg(msg FileIndexMsg) (tea.Model, tea.Cmd) {
+	state, pickerOpen := m.modal.State.(*ui.FilePickerState)
+	pickerOpen = pickerOpen && m.activeSession != nil && m.activeSession.ID == msg.SessionID
+	if msg.Err != nil {
+		logger.WithSession(msg.SessionID).Warn("failed to index worktree files", "error", msg.Err)
+		delete(m.fileIndexes, msg.SessionID)
+		if pickerOpen {
+			state.Error = "Failed to read worktree: " + msg.Err.Error()
+		}
+		return m, nil
+	}
+	if _, building := m.fileIndexes[msg.SessionID]; !building {
+		// Invalidated while it was built, so it may already be stale; it is
+		// still used for an open picker but not cached
+		logger.WithSession(msg.SessionID).Debug("file index went stale while building")
+	} else {
+		m.fileIndexes[msg.SessionID] = msg.Index
+	}
+	if pickerOpen {
+		state.SetSearch(msg.Index.Search, msg.Index.Truncated)
+	}
+	return m, nil
+}
+
+// invalidateFileIndex drops a session's cached file index, after its worktree
+// changed, so the picker indexes it again the next time it opens.
+func (m *Model) invalidateFileIndex(sessionID string) {
+	delete(m.fileIndexes, sessionID)
+}
+
+// invalidateChangedFileIndexes drops the file indexes of sessions whose changed
+// files differ from the cached ones, since files may have been added or removed.
+func (m *Model) invalidateChangedFileIndexes(changed map[string][]string) {
+	for id, files := range changed {
+		if !slices.Equal(m.changedFiles[id], files) {
+			m.invalidateFileIndex(id)
+		}
+	}
+}
+
+// handleFilePickerModal handles key events in the file picker.
+func (m *Model) handleFilePickerModal(key string, msg tea.KeyPressMsg, state *ui.FilePickerState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		paths := state.GetPaths()
+		if len(paths) == 0 {
+			return m, nil
+		}
+		m.modal.Hide()
+		m.chat.InsertInput(joinPromptPaths(paths))
+		return m, nil
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// joinPromptPaths joins paths with spaces for the prompt, quoting paths that
+// contain whitespace so each stays one path.
+func joinPromptPaths(paths []string) string {
+	quoted := make([]string, len(paths))
+	for i, path := range paths {
+		if strings.ContainsAny(path, " \t") {
+			path = `"` + path + `"`
+		}
+		quoted[i] = path
+	}
+	return strings.Join(quoted, " ")
+}
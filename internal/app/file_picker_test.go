@@ -0,0 +1,119 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestFilePicker_InsertsWorktreePaths(t *testing.T) {
+	worktree := t.TempDir()
+	for name, content := range map[string]string{
+		".gitignore":            "*.log\n",
+		"main.go":               "package main\n",
+		"internal/auth.go":      "package internal\n",
+		"docs/release notes.md": "",
+		"debug.log":             "",
+	} {
+		path := filepath.Join(worktree, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].WorkTree = worktree
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.selectSession(&cfg.Sessions[0])
+
+	// The first open indexes the worktree in the background
+	result, cmd := m.Update(keyPress(keys.AltO))
+	m = result.(*Model)
+	state, ok := m.modal.State.(*ui.FilePickerState)
+	if !ok || cmd == nil {
+		t.Fatalf("expected opt-o to open the file picker and index the worktree, got %T", m.modal.State)
+	}
+	result, _ = m.Update(cmd())
+	m = result.(*Model)
+	if slices.Contains(state.Matches, "debug.log") || !slices.Contains(state.Matches, "internal/auth.go") {
+		t.Fatalf("expected the worktree's files without ignored ones, got %v", state.Matches)
+	}
+
+	m = typeText(m, "auth")
+	m = sendKey(m, keys.Enter)
+	if m.modal.IsVisible() || m.chat.GetInput() != "internal/auth.go" {
+		t.Fatalf("expected the path inserted, input = %q", m.chat.GetInput())
+	}
+
+	// Reopening uses the cached index; Tab marks several paths
+	m.chat.ClearInput()
+	result, cmd = m.Update(keyPress(keys.AltO))
+	m = result.(*Model)
+	if cmd != nil {
+		t.Error("expected the cached index reused")
+	}
+	m = typeText(m, ".md")
+	m = sendKey(m, keys.Tab)
+	m.modal.State.(*ui.FilePickerState).Input.SetValue("")
+	m = typeText(m, "main")
+	m = sendKey(m, keys.Tab)
+	m = sendKey(m, keys.Enter)
+	if got, want := m.chat.GetInput(), `"docs/release notes.md" main.go`; got != want {
+		t.Errorf("input = %q, want %q", got, want)
+	}
+
+	// A change to the worktree's changed files drops the cached index
+	m.handleChangedFilesMsg(ChangedFilesMsg{Files: map[string][]string{cfg.Sessions[0].ID: {"new.go"}}})
+	if _, cached := m.fileIndexes[cfg.Sessions[0].ID]; cached {
+		t.Error("expected the index invalidated")
+	}
+}
+
+func TestFileCommand_OpensPicker(t *testing.T) {
+	m := testModel(testConfigWithSessions())
+	if result := m.handleSlashCommand("/file"); !result.Handled || result.Action != ActionOpenFilePicker {
+		t.Errorf("expected /file to open the file picker, got %+v", result)
+	}
+}
+
+func TestFilePicker_CtrlFInChatTogglesLiveDiff(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.selectSession(&cfg.Sessions[0])
+	m.focus = FocusChat
+	m.sidebar.SetFocused(false)
+	m.chat.SetFocused(true)
+
+	m = sendKey(m, keys.CtrlF)
+	if m.modal.IsVisible() {
+		t.Fatalf("expected no file picker from ctrl-f, got %T", m.modal.State)
+	}
+	if !m.chat.IsInLiveDiffMode() {
+		t.Error("expected ctrl-f in the chat to toggle the live diff")
+	}
+}
+
+func TestFilePicker_AltFInChatMovesWordForward(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.selectSession(&cfg.Sessions[0])
+	m.focus = FocusChat
+	m.sidebar.SetFocused(false)
+	m.chat.SetFocused(true)
+
+	result, _ := m.Update(tea.KeyPressMsg{Code: 'f', Mod: tea.ModAlt})
+	m = result.(*Model)
+	if m.modal.IsVisible() {
+		t.Errorf("expected opt-f left to the input's word forward, got %T", m.modal.State)
+	}
+}
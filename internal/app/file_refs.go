@@ -0,0 +1,72 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// editorLineArgs returns the arguments opening path at line, and at col unless
+// it is 0, in the named editor. Editors differ in how they take a line to jump
+// to; those not known here get "+line path", which most terminal editors accept.
+func editorLineArgs(editor, path string, line, col int) []string {
+	location := path + ":" + strconv.Itoa(line)
+	if col > 0 {
+		location += ":" + strconv.Itoa(col)
+	}
+	switch strings.TrimSuffix(filepath.Base(editor), ".exe") {
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		return []string{"--goto", location}
+	case "subl", "zed", "hx", "helix", "micro":
+		return []string{location}
+	case "idea", "goland", "pycharm", "webstorm", "rubymine", "clion", "rustrover":
+		args := []string{"--line", strconv.Itoa(line)}
+		if col > 0 {
+			args = append(args, "--column", strconv.Itoa(col))
+		}
+		return append(args, path)
+	case "emacs", "emacsclient", "kak":
+		if col > 0 {
+			return []string{fmt.Sprintf("+%d:%d", line, col), path}
+		}
+	case "nano":
+		if col > 0 {
+			return []string{fmt.Sprintf("+%d,%d", line, col), path}
+		}
+	case "vim", "nvim", "gvim", "mvim":
+		if col > 0 {
+			return []string{fmt.Sprintf("+call cursor(%d,%d)", line, col), path}
+		}
+	}
+	return []string{"+" + strconv.Itoa(line), path}
+}
+
+// editorCommandAt returns the command opening path at a line, and column unless
+// it is 0, in the user's editor.
+func editorCommandAt(path string, line, col int) *exec.Cmd {
+	fields := editorFields()
+	return exec.Command(fields[0], append(fields[1:], editorLineArgs(fields[0], path, line, col)...)...)
+}
+
+// shortcutOpenFileRef opens the path:line reference nearest the bottom of the
+// chat in the user's editor at that line. Relative paths are in the session's worktree.
+func shortcutOpenFileRef(m *Model) (tea.Model, tea.Cmd) {
+	if m.activeSession == nil {
+		return m, nil
+	}
+	ref, ok := m.chat.NearestFileRef()
+	if !ok {
+		return m, m.ShowFlashInfo("No file:line reference in the chat")
+	}
+	path := touchedFilePath(m.activeSession, ref.Path)
+	if _, err := statPath(path); errors.Is(err, fs.ErrNotExist) {
+		return m, m.ShowFlashWarning("Can't find " + ref.Path + " in the worktree")
+	}
+	return m, execEditor(editorCommandAt(path, ref.Line, ref.Column), path)
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	pclaude "github.com/zhubert/plural/internal/claude"
+)
+
+func TestEditorLineArgs(t *testing.T) {
+	tests := []struct {
+		editor    string
+		line, col int
+		want      []string
+	}{
+		{"vi", 12, 0, []string{"+12", "a.go"}},
+		{"/usr/bin/nvim", 12, 3, []string{"+call cursor(12,3)", "a.go"}},
+		{"nano", 12, 3, []string{"+12,3", "a.go"}},
+		{"emacsclient", 12, 3, []string{"+12:3", "a.go"}},
+		{"code", 12, 0, []string{"--goto", "a.go:12"}},
+		{"subl", 12, 3, []string{"a.go:12:3"}},
+		{"goland", 12, 3, []string{"--line", "12", "--column", "3", "a.go"}},
+		{"unknown-editor", 12, 3, []string{"+12", "a.go"}},
+	}
+	for _, tt := range tests {
+		if got := editorLineArgs(tt.editor, "a.go", tt.line, tt.col); !slices.Equal(got, tt.want) {
+			t.Errorf("editorLineArgs(%q) = %v, want %v", tt.editor, got, tt.want)
+		}
+	}
+}
+
+func TestEditorCommandAt(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	cmd := editorCommandAt("/tmp/a.go", 4, 0)
+	if !slices.Equal(cmd.Args, []string{"code", "--wait", "--goto", "/tmp/a.go:4"}) {
+		t.Errorf("unexpected args: %v", cmd.Args)
+	}
+}
+
+func TestOpenFileRef(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.activeSession = &cfg.Sessions[0]
+	m.chat.SetSession("test", nil)
+
+	if _, _, handled := m.ExecuteShortcut("O"); !handled || !m.footer.HasFlash() {
+		t.Fatal("expected O to flash that there is no reference")
+	}
+
+	m.footer.ClearFlash()
+	m.chat.SetSession("test", []pclaude.Message{{Role: "assistant", Content: "See internal/ui/chat.go:412"}})
+	if _, cmd, handled := m.ExecuteShortcut("O"); !handled || cmd == nil || m.footer.HasFlash() {
+		t.Error("expected O to open the reference")
+	}
+}
@@ -0,0 +1,195 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/activity"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// focusModeState is the state of focus mode, which shows only the active
+// session's chat. Other sessions' events are held back until it is left.
+type focusModeState struct {
+	until  time.Time        // When it ends by itself; zero when it lasts until left
+	missed []activity.Event // Other sessions' events while focused, summarized on leaving
+}
+
+// FocusTimeUpMsg ends a time-boxed focus mode. Until identifies the focus mode
+// it was scheduled for, so a tick from one left early is ignored.
+type FocusTimeUpMsg struct {
+	Until time.Time
+}
+
+// focusSummaryCategories groups activity kinds for the summary shown on leaving
+// focus mode, in display order, with the phrase for one session and for several.
+var focusSummaryCategories = []struct {
+	kinds     []activity.Kind
+	one, many string
+}{
+	{[]activity.Kind{activity.KindResponseCompleted}, "completed", "completed"},
+	{[]activity.Kind{activity.KindPermissionRequested}, "needs permission", "need permission"},
+	{[]activity.Kind{activity.KindQuestionAsked}, "has a question", "have questions"},
+	{[]activity.Kind{activity.KindPlanApprovalRequired}, "needs plan approval", "need plan approval"},
+	{[]activity.Kind{activity.KindError}, "hit an error", "hit errors"},
+	{[]activity.Kind{activity.KindMerged, activity.KindPRCreated, activity.KindPRMerged, activity.KindPRClosed, activity.KindCIFailed}, "has PR or merge updates", "have PR or merge updates"},
+}
+
+// focusSummary summarizes events missed in focus mode by how many sessions had
+// each kind, e.g. "2 sessions completed, 1 needs permission". Returns "" if none
+// are worth mentioning.
+func focusSummary(events []activity.Event) string {
+	var parts []string
+	for _, category := range focusSummaryCategories {
+		sessions := make(map[string]bool)
+		for _, event := range events {
+			for _, kind := range category.kinds {
+				if event.Kind == kind {
+					sessions[event.SessionID] = true
+				}
+			}
+		}
+		if len(sessions) == 0 {
+			continue
+		}
+		phrase := category.many
+		if len(sessions) == 1 {
+			phrase = category.one
+		}
+		part := fmt.Sprintf("%d %s", len(sessions), phrase)
+		if len(parts) == 0 {
+			// Only the first part names what is being counted
+			noun := "sessions"
+			if len(sessions) == 1 {
+				noun = "session"
+			}
+			part = fmt.Sprintf("%d %s %s", len(sessions), noun, phrase)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// focusedSessionID returns the session focus mode shows: the active one, or the
+// one whose history is loading.
+func (m *Model) focusedSessionID() string {
+	if m.activeSession != nil {
+		return m.activeSession.ID
+	}
+	return m.loadingSessionID
+}
+
+// heldByFocusMode returns whether an event of the session is held back from the
+// user because focus mode shows another one.
+func (m *Model) heldByFocusMode(sessionID string) bool {
+	return m.focusMode != nil && sessionID != m.focusedSessionID()
+}
+
+// viewMode returns the layout mode for the view context.
+func (m *Model) viewMode() ui.ViewMode {
+	if m.focusMode != nil {
+		return ui.ViewModeFocus
+	}
+	return ui.ViewModeNormal
+}
+
+// resizeKeepingScroll lays the view out again for a mode change, keeping the
+// reader's place in the conversation as it re-wraps.
+func (m *Model) resizeKeepingScroll() {
+	pos := m.chat.ScrollPosition()
+	m.updateSizes()
+	m.chat.SetScrollPosition(pos)
+}
+
+// enterFocusMode hides everything but the active session's chat. If focus_minutes
+// is set, it returns a command that leaves focus mode when the time is up.
+func (m *Model) enterFocusMode() tea.Cmd {
+	if m.focusMode != nil {
+		return nil
+	}
+	state := &focusModeState{}
+	var cmd tea.Cmd
+	if minutes := m.config.GetFocusMinutes(); minutes > 0 {
+		duration := time.Duration(minutes) * time.Minute
+		until := time.Now().Add(duration)
+		state.until = until
+		cmd = tea.Tick(duration, func(time.Time) tea.Msg {
+			return FocusTimeUpMsg{Until: until}
+		})
+	}
+	m.focusMode = state
+
+	m.focus = FocusChat
+	m.sidebar.SetFocused(false)
+	m.chat.SetFocused(true)
+	m.resizeKeepingScroll()
+	logger.WithSession(m.focusedSessionID()).Info("entered focus mode", "until", state.until)
+	return cmd
+}
+
+// exitFocusMode restores the full layout and summarizes what other sessions did
+// in the meantime. prefix, if set, leads the flash message.
+func (m *Model) exitFocusMode(prefix string) tea.Cmd {
+	if m.focusMode == nil {
+		return nil
+	}
+	missed := m.focusMode.missed
+	m.focusMode = nil
+	m.resizeKeepingScroll()
+	logger.WithSession(m.focusedSessionID()).Info("left focus mode", "missedEvents", len(missed))
+
+	message := prefix
+	if summary := focusSummary(missed); summary != "" {
+		if message != "" {
+			message += " "
+		}
+		message += "While you were focused: " + summary
+	}
+	if message == "" {
+		return nil
+	}
+	return m.ShowFlashInfo(message)
+}
+
+// handleFocusTimeUpMsg leaves a time-boxed focus mode whose time is up.
+func (m *Model) handleFocusTimeUpMsg(msg FocusTimeUpMsg) (tea.Model, tea.Cmd) {
+	if m.focusMode == nil || !m.focusMode.until.Equal(msg.Until) {
+		return m, nil
+	}
+	return m, m.exitFocusMode("Focus time is up.")
+}
+
+// renderFocusMode renders the focus mode view: a one-line status over the chat.
+func (m *Model) renderFocusMode(isStreaming bool) string {
+	var remaining time.Duration
+	if !m.focusMode.until.IsZero() {
+		remaining = max(time.Until(m.focusMode.until), 0)
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.header.FocusView(isStreaming, remaining),
+		m.chat.View(),
+	)
+}
+
+// shortcutToggleFocusMode enters focus mode on the selected session, opening it
+// if needed, or leaves focus mode.
+func shortcutToggleFocusMode(m *Model) (tea.Model, tea.Cmd) {
+	if m.focusMode != nil {
+		return m, m.exitFocusMode("")
+	}
+	var openCmd tea.Cmd
+	if m.focus == FocusSidebar {
+		if sess := m.sidebar.SelectedSession(); sess != nil && (m.activeSession == nil || m.activeSession.ID != sess.ID) {
+			openCmd = m.openSession(sess)
+		}
+	}
+	if m.focusedSessionID() == "" {
+		return m, openCmd
+	}
+	return m, tea.Batch(openCmd, m.enterFocusMode())
+}
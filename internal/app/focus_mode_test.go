@@ -0,0 +1,136 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/activity"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestFocusMode_EnterAndLeave(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	t.Cleanup(func() { ui.GetViewContext().SetMode(ui.ViewModeNormal) })
+
+	m = sendKey(m, "enter")
+	m = typeText(m, "half-written draft")
+	m = sendKey(m, "tab")
+
+	m = sendKey(m, "Z")
+	if m.focusMode == nil {
+		t.Fatal("expected focus mode")
+	}
+	if m.focus != FocusChat {
+		t.Error("expected the chat to be focused in focus mode")
+	}
+	if ctx := ui.GetViewContext(); ctx.Mode != ui.ViewModeFocus || ctx.ChatWidth != 120 || ctx.SidebarWidth != 0 {
+		t.Errorf("expected a full-width chat layout, got mode %v chat %d sidebar %d", ctx.Mode, ctx.ChatWidth, ctx.SidebarWidth)
+	}
+	view := ansi.Strip(m.RenderToString())
+	if !strings.Contains(view, "· idle") {
+		t.Errorf("expected the focus status line, got:\n%s", view)
+	}
+	if strings.Contains(view, " plural") {
+		t.Error("expected the header to be hidden in focus mode")
+	}
+	if m.chat.GetInput() != "half-written draft" {
+		t.Errorf("expected the draft to survive entering focus mode, got %q", m.chat.GetInput())
+	}
+
+	// Tab leaves focus mode rather than moving to the hidden sidebar
+	m = sendKey(m, "tab")
+	if m.focusMode != nil {
+		t.Fatal("expected Tab to leave focus mode")
+	}
+	if ctx := ui.GetViewContext(); ctx.Mode != ui.ViewModeNormal || ctx.SidebarWidth == 0 {
+		t.Error("expected the normal layout to be restored")
+	}
+	if m.chat.GetInput() != "half-written draft" {
+		t.Errorf("expected the draft to survive leaving focus mode, got %q", m.chat.GetInput())
+	}
+}
+
+func TestFocusMode_SummarizesOtherSessions(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	t.Cleanup(func() { ui.GetViewContext().SetMode(ui.ViewModeNormal) })
+
+	m = sendKey(m, "enter")
+	shortcutToggleFocusMode(m)
+	activeID := m.activeSession.ID
+
+	m.recordActivity("session-2", activity.KindResponseCompleted, activity.SeveritySuccess, "Response completed")
+	m.recordActivity("session-2", activity.KindPermissionRequested, activity.SeverityWarning, "Permission requested: Bash")
+	// Events of the focused session are shown as usual, not held back
+	m.recordActivity(activeID, activity.KindResponseCompleted, activity.SeveritySuccess, "Response completed")
+
+	if len(m.focusMode.missed) != 2 {
+		t.Fatalf("expected 2 held events, got %d", len(m.focusMode.missed))
+	}
+
+	shortcutToggleFocusMode(m)
+	if !m.footer.HasFlash() {
+		t.Fatal("expected a summary flash on leaving focus mode")
+	}
+	if got := ansi.Strip(m.footer.View()); !strings.Contains(got, "While you were focused: 1 session completed, 1 needs permission") {
+		t.Errorf("unexpected summary: %q", got)
+	}
+}
+
+func TestFocusMode_TimeBoxed(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetFocusMinutes(25)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	t.Cleanup(func() { ui.GetViewContext().SetMode(ui.ViewModeNormal) })
+
+	m = sendKey(m, "enter")
+	if _, cmd := shortcutToggleFocusMode(m); cmd == nil {
+		t.Fatal("expected a timer for time-boxed focus mode")
+	}
+	if view := ansi.Strip(m.RenderToString()); !strings.Contains(view, "25m left") {
+		t.Errorf("expected the time left in the status line, got:\n%s", view)
+	}
+
+	// A tick for an earlier focus mode is ignored
+	m.Update(FocusTimeUpMsg{Until: m.focusMode.until.Add(-time.Minute)})
+	if m.focusMode == nil {
+		t.Fatal("expected a stale tick to be ignored")
+	}
+
+	m.Update(FocusTimeUpMsg{Until: m.focusMode.until})
+	if m.focusMode != nil {
+		t.Error("expected focus mode to end when its time is up")
+	}
+}
+
+func TestFocusSummary(t *testing.T) {
+	events := []activity.Event{
+		{SessionID: "a", Kind: activity.KindResponseCompleted},
+		{SessionID: "a", Kind: activity.KindResponseCompleted},
+		{SessionID: "b", Kind: activity.KindResponseCompleted},
+		{SessionID: "c", Kind: activity.KindPermissionRequested},
+		{SessionID: "c", Kind: activity.KindPermissionResolved},
+	}
+	if got, want := focusSummary(events), "2 sessions completed, 1 needs permission"; got != want {
+		t.Errorf("focusSummary() = %q, want %q", got, want)
+	}
+
+	if got := focusSummary([]activity.Event{{SessionID: "a", Kind: activity.KindPermissionResolved}}); got != "" {
+		t.Errorf("expected no summary for resolved permissions, got %q", got)
+	}
+
+	events = []activity.Event{
+		{SessionID: "a", Kind: activity.KindError},
+		{SessionID: "b", Kind: activity.KindQuestionAsked},
+		{SessionID: "c", Kind: activity.KindQuestionAsked},
+	}
+	if got, want := focusSummary(events), "2 sessions have questions, 1 hit an error"; got != want {
+		t.Errorf("focusSummary() = %q, want %q", got, want)
+	}
+}
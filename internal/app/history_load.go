@@ -0,0 +1,84 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/manager"
+)
+
+// HistoryLoadedMsg is sent when a session's message history has been read in the background
+type HistoryLoadedMsg struct {
+	SessionID string
+}
+
+// loadHistory returns a command that reads a session's message history in the background.
+func loadHistory(sessionMgr *manager.SessionManager, sess config.Session) tea.Cmd {
+	return func() tea.Msg {
+		sessionMgr.PreloadHistory(&sess)
+		return HistoryLoadedMsg{SessionID: sess.ID}
+	}
+}
+
+// openSession selects a session the user picked. Sessions with a large history
+// that has not been read yet show a loading indicator while it loads in the
+// background, and are selected once it has; the returned command does the loading.
+func (m *Model) openSession(sess *config.Session) tea.Cmd {
+	if m.openMissingSession(sess) {
+		return nil
+	}
+	if sess.ID == m.loadingSessionID {
+		m.focus = FocusChat
+		m.sidebar.SetFocused(false)
+		m.chat.SetFocused(true)
+		return nil
+	}
+	if !m.sessionMgr.NeedsHistoryLoad(sess.ID) {
+		m.selectSession(sess)
+		return nil
+	}
+
+	logger.WithSession(sess.ID).Info("loading message history in background")
+	if m.activeSession != nil {
+		m.sessionMgr.StashUIState(m.activeSession.ID, m.chat.GetInput(), m.chat.GetStreaming())
+		m.chat.SaveScroll(m.activeSession.ID)
+	}
+	m.activeSession = nil
+	m.claudeRunner = nil
+	m.loadingSessionID = sess.ID
+	if m.chat.IsInViewChangesMode() {
+		m.chat.ExitViewChangesMode()
+	}
+	m.chat.ExitLiveDiffMode()
+	m.chat.SetLoadingSession(sess.Name)
+	m.header.SetSessionName(sess.Name)
+
+	m.focus = FocusChat
+	m.sidebar.SetFocused(false)
+	m.chat.SetFocused(true)
+	return loadHistory(m.sessionMgr, *sess)
+}
+
+// handleHistoryLoadedMsg selects a session once its history has loaded, unless the
+// user has since moved on to another session. The loaded history stays cached
+// for whenever the session is next selected.
+func (m *Model) handleHistoryLoadedMsg(msg HistoryLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.SessionID != m.loadingSessionID {
+		return m, nil
+	}
+	m.loadingSessionID = ""
+	sess := m.config.GetSession(msg.SessionID)
+	if sess == nil {
+		m.chat.ClearSession()
+		return m, nil
+	}
+
+	sidebarFocused := m.focus == FocusSidebar
+	m.selectSession(sess)
+	if sidebarFocused {
+		m.focus = FocusSidebar
+		m.sidebar.SetFocused(true)
+		m.chat.SetFocused(false)
+	}
+	return m, nil
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/paths"
+)
+
+func TestOpenSession_LargeHistoryLoadsInBackground(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	large := strings.Repeat("x", manager.LargeHistoryBytes)
+	if err := config.SaveSessionMessages("session-1", []config.Message{{Role: "assistant", Content: large}}, 0); err != nil {
+		t.Fatalf("SaveSessionMessages: %v", err)
+	}
+
+	cmd := m.openSession(m.config.GetSession("session-1"))
+	if cmd == nil {
+		t.Fatal("expected a command loading the history")
+	}
+	if m.activeSession != nil {
+		t.Error("expected no active session while the history loads")
+	}
+	if !m.chat.IsLoadingSession() || !strings.Contains(m.chat.View(), "Loading repo1/session1...") {
+		t.Error("expected the chat to show the session loading")
+	}
+
+	// Selecting it again while loading does not start another load
+	if m.openSession(m.config.GetSession("session-1")) != nil {
+		t.Error("expected no second load of the same session")
+	}
+
+	m.Update(cmd())
+	if m.activeSession == nil || m.activeSession.ID != "session-1" {
+		t.Fatal("expected the session to be selected once loaded")
+	}
+	if m.chat.IsLoadingSession() {
+		t.Error("expected the loading indicator to be gone")
+	}
+	if msgs := m.claudeRunner.GetMessages(); len(msgs) != 1 || msgs[0].Content != large {
+		t.Errorf("expected the loaded history, got %d messages", len(msgs))
+	}
+}
+
+func TestHandleHistoryLoadedMsg_IgnoredAfterSwitching(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m.loadingSessionID = "session-1"
+	m.selectSession(m.config.GetSession("session-2"))
+	m.Update(HistoryLoadedMsg{SessionID: "session-1"})
+
+	if m.activeSession == nil || m.activeSession.ID != "session-2" {
+		t.Error("expected the session switched to to stay selected")
+	}
+}
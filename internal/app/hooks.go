@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/activity"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// hookTimeout is how long a repo's hooks may run in all before they are stopped.
+const hookTimeout = 10 * time.Minute
+
+// Events repo hooks run after, as named in the chat and the merge modal.
+const (
+	hookEventMerge    = "post-merge"
+	hookEventPRCreate = "post-PR-create"
+)
+
+// HookOutputMsg reports on the repo hooks run for a session after a merge to
+// main or a PR was created.
+type HookOutputMsg struct {
+	SessionID string
+	Result    git.HookResult
+	Finished  bool // No more hooks will run
+}
+
+// hookRun is a session's repo hooks running in the background.
+type hookRun struct {
+	event  string // hookEventMerge or hookEventPRCreate
+	total  int    // Hooks to run
+	ch     <-chan git.HookResult
+	cancel context.CancelFunc
+}
+
+// recordHookChoice remembers whether the repo hooks run after the merge or PR
+// about to start for a session, as chosen in the merge modal. Without a choice
+// recorded they run.
+func (m *Model) recordHookChoice(sessionID string, state *ui.MergeState) {
+	if state != nil && !state.RunHooks {
+		m.skipHooks[sessionID] = true
+	} else {
+		delete(m.skipHooks, sessionID)
+	}
+}
+
+// startHooks runs the hooks of the session's repo for the merge or PR just
+// done, one after another in the main repo, streaming their output into the
+// chat. Returns nil if the repo has none for it. A failing hook stops the rest
+// but leaves the merge or PR as it is.
+func (m *Model) startHooks(sessionID string, mergeType manager.MergeType, prURL string) tea.Cmd {
+	sess := m.config.GetSession(sessionID)
+	if sess == nil || m.hookRuns[sessionID] != nil {
+		return nil
+	}
+	hooks := m.config.GetRepoHooks(sess.RepoPath)
+	commands, event, target := hooks.PostMerge, hookEventMerge, ""
+	if mergeType == manager.MergeTypePR {
+		commands, event = hooks.PostPRCreate, hookEventPRCreate
+		if sess.PRProgress != nil {
+			target = sess.PRProgress.BaseBranch
+		}
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+	if target == "" {
+		target = m.gitService.GetDefaultBranch(context.Background(), sess.RepoPath)
+	}
+
+	env := git.HookEnv{SessionID: sess.ID, Branch: sess.Branch, TargetBranch: target, PRURL: prURL}
+	logger.WithSession(sessionID).Info("running repo hooks", "event", event, "hooks", len(commands), "dir", sess.RepoPath)
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	run := &hookRun{event: event, total: len(commands), cancel: cancel}
+	run.ch = m.gitService.RunHooks(ctx, sess.RepoPath, commands, env)
+	m.hookRuns[sessionID] = run
+	return listenForHookOutput(sessionID, run.ch)
+}
+
+// listenForHookOutput waits for the next report on a session's hooks.
+func listenForHookOutput(sessionID string, ch <-chan git.HookResult) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := <-ch
+		if !ok {
+			return HookOutputMsg{SessionID: sessionID, Finished: true}
+		}
+		return HookOutputMsg{SessionID: sessionID, Result: result}
+	}
+}
+
+// handleHookOutputMsg streams a hook's output into its session's chat, in a
+// block collapsed once done, and warns when a hook fails.
+func (m *Model) handleHookOutputMsg(msg HookOutputMsg) (tea.Model, tea.Cmd) {
+	run := m.hookRuns[msg.SessionID]
+	if run == nil {
+		return m, nil
+	}
+	isActiveSession := m.activeSession != nil && m.activeSession.ID == msg.SessionID
+	appendOutput := func(content string) {
+		if isActiveSession {
+			m.chat.AppendOutput(content)
+		} else {
+			m.sessionState().GetOrCreate(msg.SessionID).AppendStreamingContent(content)
+		}
+	}
+
+	if msg.Finished {
+		run.cancel()
+		delete(m.hookRuns, msg.SessionID)
+		return m, tea.Batch(m.finishMergeOutput(msg.SessionID, isActiveSession), m.archiveAfterMergeDone(msg.SessionID))
+	}
+
+	var cmd tea.Cmd
+	result := msg.Result
+	switch {
+	case result.Done:
+		appendOutput(ui.HookOutputEnd())
+		if result.Error != nil {
+			logger.WithSession(msg.SessionID).Warn("repo hook failed", "event", run.event, "command", result.Command, "error", result.Error)
+			text := fmt.Sprintf("%s hook %q failed: %v", run.event, result.Command, result.Error)
+			note := "Hook failed: " + result.Error.Error() + ". The merge or PR is kept."
+			if skipped := run.total - result.Index - 1; skipped > 0 {
+				note += fmt.Sprintf(" %d later hook(s) not run.", skipped)
+			}
+			if m.archiveAfterMerge[msg.SessionID] {
+				// Kept so the failure can be looked into
+				delete(m.archiveAfterMerge, msg.SessionID)
+				note += " The session is kept instead of archived."
+			}
+			appendOutput(note + "\n")
+			m.recordActivity(msg.SessionID, activity.KindError, activity.SeverityWarning, text)
+			cmd = m.ShowFlashWarning(text)
+		}
+	case result.Line != "":
+		appendOutput(ui.HookOutputLine(result.Line))
+	default:
+		appendOutput(ui.HookOutputStart(run.event, result.Command))
+	}
+	return m, tea.Batch(cmd, listenForHookOutput(msg.SessionID, run.ch))
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/config"
+	pexec "github.com/zhubert/plural/internal/exec"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// hooksTestModel returns a model showing session-1, whose repo has hooks, with
+// git and the hooks run by the returned executor.
+func hooksTestModel(t *testing.T, hooks config.RepoHooks) (*Model, *pexec.MockExecutor) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	cfg := testConfigWithSessions()
+	cfg.SetFilePath(filepath.Join(home, "config.json"))
+	cfg.SetRepoHooks("/test/repo1", hooks)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	// The activity feed writes to home in the background; finish before it's removed
+	t.Cleanup(func() { m.activity.Close() })
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.selectSession(m.config.GetSession("session-1"))
+
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddExactMatch("git", []string{"symbolic-ref", "refs/remotes/origin/HEAD"}, pexec.MockResponse{Stdout: []byte("refs/remotes/origin/main\n")})
+	m.SetGitService(git.NewGitServiceWithExecutor(mockExec))
+	return m, mockExec
+}
+
+// finishMerge reports a merge of mergeType done for session-1, then feeds the
+// hooks' output to the model until they are done.
+func finishMerge(t *testing.T, m *Model, mergeType manager.MergeType, prURL string) {
+	t.Helper()
+	_, cancel := context.WithCancel(context.Background())
+	m.sessionState().StartMerge("session-1", make(chan git.Result), cancel, mergeType)
+	m.Update(MergeResultMsg{SessionID: "session-1", Result: git.Result{Done: true, PRURL: prURL}})
+	for m.hookRuns["session-1"] != nil {
+		run := m.hookRuns["session-1"]
+		m.Update(listenForHookOutput("session-1", run.ch)())
+	}
+}
+
+// hookCalls returns the hooks the mock ran, by command.
+func hookCalls(mock *pexec.MockExecutor) map[string]pexec.MockCall {
+	calls := make(map[string]pexec.MockCall)
+	for _, call := range mock.GetCalls() {
+		if call.Name == "env" {
+			calls[call.Args[len(call.Args)-1]] = call
+		}
+	}
+	return calls
+}
+
+func TestHooks_RunAfterMergeWithEnv(t *testing.T) {
+	m, mockExec := hooksTestModel(t, config.RepoHooks{PostMerge: []string{"./deploy.sh", "notify"}})
+	mockExec.AddRule(func(dir, name string, args []string) bool {
+		return name == "env" && slices.Contains(args, "./deploy.sh")
+	}, pexec.MockResponse{Stdout: []byte("deployed to staging\n")})
+
+	finishMerge(t, m, manager.MergeTypeMerge, "")
+
+	calls := hookCalls(mockExec)
+	if len(calls) != 2 {
+		t.Fatalf("expected both post-merge hooks run, got %v", calls)
+	}
+	call := calls["./deploy.sh"]
+	if call.Dir != "/test/repo1" {
+		t.Errorf("expected the hook run in the main repo, ran in %q", call.Dir)
+	}
+	for _, want := range []string{"PLURAL_SESSION_ID=session-1", "PLURAL_BRANCH=" + m.config.GetSession("session-1").Branch, "PLURAL_TARGET_BRANCH=main"} {
+		if !slices.Contains(call.Args, want) {
+			t.Errorf("expected %s in the hook's environment, got %q", want, call.Args)
+		}
+	}
+	if slices.ContainsFunc(call.Args, func(arg string) bool { return strings.HasPrefix(arg, "PLURAL_PR_URL=") }) {
+		t.Errorf("expected no PR URL after a merge, got %q", call.Args)
+	}
+
+	messages := m.chat.GetMessages()
+	last := messages[len(messages)-1].Content
+	if !strings.Contains(last, ui.HookOutputStart("post-merge", "./deploy.sh")) || !strings.Contains(last, "deployed to staging") {
+		t.Errorf("expected the hook's output in the chat, got %q", last)
+	}
+	if msg := messages[len(messages)-1]; msg.Role != claude.RoleSystem || msg.Kind != claude.KindMerge {
+		t.Errorf("expected the output kept as a merge system message, got %s %q", msg.Role, msg.Kind)
+	}
+	if !m.config.GetSession("session-1").Merged {
+		t.Error("expected the session marked merged")
+	}
+}
+
+func TestMergeOutput_BackgroundSessionSavedAsSystemMessage(t *testing.T) {
+	m, _ := hooksTestModel(t, config.RepoHooks{})
+	m.selectSession(m.config.GetSession("session-2"))
+
+	m.sessionState().GetOrCreate("session-1").AppendStreamingContent("Merged feature-branch into main\n")
+	finishMerge(t, m, manager.MergeTypeMerge, "")
+
+	messages := m.sessionMgr.GetRunner("session-1").GetMessages()
+	if len(messages) == 0 {
+		t.Fatal("expected the merge output kept in the session's transcript")
+	}
+	last := messages[len(messages)-1]
+	if last.Role != claude.RoleSystem || last.Kind != claude.KindMerge || !strings.Contains(last.Content, "Merged feature-branch") {
+		t.Errorf("expected a merge system message, got %+v", last)
+	}
+}
+
+func TestHooks_PRCreateGetsPRURL(t *testing.T) {
+	m, mockExec := hooksTestModel(t, config.RepoHooks{PostMerge: []string{"deploy"}, PostPRCreate: []string{"announce"}})
+	m.config.SetSessionPRProgress("session-1", &config.PRProgress{BaseBranch: "release"})
+
+	finishMerge(t, m, manager.MergeTypePR, "https://github.com/owner/repo/pull/7")
+
+	calls := hookCalls(mockExec)
+	if _, ran := calls["deploy"]; ran || len(calls) != 1 {
+		t.Fatalf("expected only the post-PR-create hook run, got %v", calls)
+	}
+	args := calls["announce"].Args
+	if !slices.Contains(args, "PLURAL_PR_URL=https://github.com/owner/repo/pull/7") || !slices.Contains(args, "PLURAL_TARGET_BRANCH=release") {
+		t.Errorf("expected the PR's URL and base in the environment, got %q", args)
+	}
+}
+
+func TestHooks_FailureWarnsWithoutRollingBack(t *testing.T) {
+	m, mockExec := hooksTestModel(t, config.RepoHooks{PostMerge: []string{"./deploy.sh", "notify"}})
+	mockExec.AddRule(func(dir, name string, args []string) bool {
+		return name == "env" && slices.Contains(args, "./deploy.sh")
+	}, pexec.MockResponse{Stderr: []byte("[Error: no credentials]\n"), Err: errors.New("exit status 1")})
+
+	finishMerge(t, m, manager.MergeTypeMerge, "")
+
+	if _, ran := hookCalls(mockExec)["notify"]; ran {
+		t.Error("expected no hook run after one fails")
+	}
+	if !m.footer.HasFlash() {
+		t.Error("expected a warning that the hook failed")
+	}
+	if !m.config.GetSession("session-1").Merged {
+		t.Error("expected the merge kept when a hook fails")
+	}
+	for _, call := range mockExec.GetCalls() {
+		if call.Name == "git" && slices.ContainsFunc([]string{"reset", "revert"}, func(cmd string) bool { return call.Args[0] == cmd }) {
+			t.Errorf("expected nothing rolled back, ran git %v", call.Args)
+		}
+	}
+	messages := m.chat.GetMessages()
+	last := messages[len(messages)-1].Content
+	if !strings.Contains(last, "[Error: no credentials]") || !strings.Contains(last, "Hook failed: exit status 1") {
+		t.Errorf("expected the hook's output and failure in the chat, got %q", last)
+	}
+}
+
+func TestHooks_SkippedFromMergeModal(t *testing.T) {
+	m, mockExec := hooksTestModel(t, config.RepoHooks{PostMerge: []string{"./deploy.sh"}})
+	state := ui.NewMergeState("session", true, "", "", false)
+	state.SetHooks(1, 0)
+	state.RunHooks = false
+	m.recordHookChoice("session-1", state)
+
+	finishMerge(t, m, manager.MergeTypeMerge, "")
+	if calls := hookCalls(mockExec); len(calls) != 0 {
+		t.Errorf("expected the hooks skipped, ran %v", calls)
+	}
+
+	// The choice only applies to that merge
+	finishMerge(t, m, manager.MergeTypeMerge, "")
+	if calls := hookCalls(mockExec); len(calls) != 1 {
+		t.Errorf("expected the hooks run on the next merge, ran %v", calls)
+	}
+}
@@ -1,15 +1,24 @@
 package app
 
 import (
+	"bytes"
 	"errors"
+	"image"
+	"image/png"
+	"math/rand"
 	"slices"
 	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/activity"
 	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/clipboard"
+	"github.com/zhubert/plural/internal/config"
+	pexec "github.com/zhubert/plural/internal/exec"
 	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/manager"
 	"github.com/zhubert/plural/internal/mcp"
 	"github.com/zhubert/plural/internal/ui"
 )
@@ -771,6 +780,25 @@ func TestViewChanges_NavigateFiles(t *testing.T) {
 	}
 }
 
+func TestViewChanges_ToggleSplit(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	m.chat.EnterViewChangesMode(testFileDiffs())
+
+	m = sendKey(m, "s")
+	if !m.chat.IsViewChangesSplit() {
+		t.Error("Expected 's' to switch to split diffs")
+	}
+
+	m = sendKey(m, "s")
+	if m.chat.IsViewChangesSplit() {
+		t.Error("Expected 's' to switch back to unified diffs")
+	}
+}
+
 func TestViewChanges_EscapeFromSidebarFocus(t *testing.T) {
 	cfg := testConfigWithSessions()
 	m, _ := testModelWithMocks(cfg, 120, 40)
@@ -1203,6 +1231,29 @@ func TestSidebar_SearchMode(t *testing.T) {
 	}
 }
 
+func TestSidebar_SearchFilterKeptUntilEscape(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "/")
+	m = sendKey(m, "b")
+	m = sendKey(m, "u")
+	m = sendKey(m, "enter")
+	if m.sidebar.IsSearchMode() || !m.sidebar.HasFilter() {
+		t.Fatal("expected enter to leave search mode keeping the filter")
+	}
+	if m.activeSession == nil || m.activeSession.ID != "session-3" {
+		t.Errorf("expected the match opened, got %v", m.activeSession)
+	}
+
+	m = sendKey(m, "tab")
+	m = sendKey(m, "esc")
+	if m.sidebar.HasFilter() {
+		t.Error("expected esc in the sidebar to clear the filter")
+	}
+}
+
 func TestSidebar_AutoSelectsSessionOnNavigate(t *testing.T) {
 	cfg := testConfigWithSessions()
 	m := testModelWithSize(cfg, 120, 40)
@@ -1450,6 +1501,47 @@ func TestChat_NoSendWithoutSession(t *testing.T) {
 	}
 }
 
+func TestChat_NoSendForBlankInput(t *testing.T) {
+	for _, input := range []string{"", "   ", "\n\n", " \n\t\n "} {
+		cfg := testConfigWithSessions()
+		m, factory := testModelWithMocks(cfg, 120, 40)
+		m.sidebar.SetSessions(cfg.Sessions)
+		m = sendKey(m, "enter")
+
+		sent := false
+		factory.GetMock(m.activeSession.ID).OnSend = func([]claude.ContentBlock) { sent = true }
+		m.chat.SetInput(input)
+		m = sendKey(m, "enter")
+
+		if sent {
+			t.Errorf("input %q: expected nothing sent", input)
+		}
+		if !m.footer.HasFlash() {
+			t.Errorf("input %q: expected a footer hint", input)
+		}
+		if len(m.chat.GetMessages()) != 0 {
+			t.Errorf("input %q: expected no message added to the chat", input)
+		}
+	}
+}
+
+func TestChat_SendImageWithBlankInput(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, factory := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	var content []claude.ContentBlock
+	factory.GetMock(m.activeSession.ID).OnSend = func(c []claude.ContentBlock) { content = c }
+	m.chat.AttachImage([]byte("fake image data"), "image/png")
+	m.chat.SetInput(" \n\n")
+	m.sendMessage()
+
+	if len(content) != 1 || content[0].Type != claude.ContentTypeImage {
+		t.Errorf("expected only the image sent, got %+v", content)
+	}
+}
+
 // =============================================================================
 // Keyboard Shortcuts Tests
 // =============================================================================
@@ -2285,3 +2377,569 @@ func TestConfirmExitModal_VimNavigation(t *testing.T) {
 // =============================================================================
 // Workspace Filtering Tests
 // =============================================================================
+
+// =============================================================================
+// Activity Feed Tests
+// =============================================================================
+
+func TestActivityFeed_RecordsSessionEvents(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	if m.activeSession == nil {
+		t.Fatal("Expected active session")
+	}
+	sessionID := m.activeSession.ID
+
+	m = simulatePermissionRequest(m, sessionID, "Bash", "Run: ls")
+	m = sendKey(m, "y")
+	m = simulateClaudeResponse(m, sessionID, textChunk("Done"))
+	m = simulateClaudeResponse(m, sessionID, doneChunk())
+
+	events := m.activity.Events()
+	wantKinds := []activity.Kind{
+		activity.KindResponseCompleted,
+		activity.KindPermissionResolved,
+		activity.KindPermissionRequested,
+	}
+	if len(events) != len(wantKinds) {
+		t.Fatalf("expected %d events, got %d: %+v", len(wantKinds), len(events), events)
+	}
+	for i, kind := range wantKinds {
+		if events[i].Kind != kind {
+			t.Errorf("events[%d].Kind = %q, want %q", i, events[i].Kind, kind)
+		}
+		if events[i].SessionID != sessionID {
+			t.Errorf("events[%d].SessionID = %q, want %q", i, events[i].SessionID, sessionID)
+		}
+	}
+	if events[0].SessionName == "" || events[0].SessionName == sessionID {
+		t.Errorf("expected display name for session, got %q", events[0].SessionName)
+	}
+}
+
+func TestActivityFeed_ToggleAndEscape(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "F")
+	if !m.chat.IsInActivityFeedMode() {
+		t.Fatal("Expected 'F' to open the activity feed")
+	}
+	if m.focus != FocusChat {
+		t.Error("Expected focus on chat while activity feed is open")
+	}
+
+	m = sendKey(m, "esc")
+	if m.chat.IsInActivityFeedMode() {
+		t.Error("Expected Escape to close the activity feed")
+	}
+}
+
+func TestActivityFeed_EnterJumpsToSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	// Select the first session, then record an event for another one
+	m = sendKey(m, "enter")
+	if m.activeSession == nil || m.activeSession.ID != "session-1" {
+		t.Fatal("Expected session-1 to be active")
+	}
+	m.recordActivity("session-1", activity.KindResponseCompleted, activity.SeveritySuccess, "Response completed")
+	m.recordActivity("session-3", activity.KindError, activity.SeverityError, "Error: boom")
+
+	m = sendKey(m, "tab")
+	m = sendKey(m, "F")
+	if !m.chat.IsInActivityFeedMode() {
+		t.Fatal("Expected activity feed to be open")
+	}
+
+	// Newest event (session-3) is selected first
+	m = sendKey(m, "enter")
+	if m.chat.IsInActivityFeedMode() {
+		t.Error("Expected activity feed to close after Enter")
+	}
+	if m.activeSession == nil || m.activeSession.ID != "session-3" {
+		t.Fatalf("Expected jump to session-3, got %v", m.activeSession)
+	}
+	if m.focus != FocusChat {
+		t.Error("Expected chat focus after jumping to session")
+	}
+
+	// Navigate down to the older event and jump back
+	m = sendKey(m, "tab")
+	m = sendKey(m, "F")
+	m = sendKey(m, "down")
+	m = sendKey(m, "enter")
+	if m.activeSession == nil || m.activeSession.ID != "session-1" {
+		t.Fatalf("Expected jump to session-1, got %v", m.activeSession)
+	}
+}
+
+func TestActivityFeed_EnterOnDeletedSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m.recordActivity("gone-session", activity.KindError, activity.SeverityError, "Error: boom")
+
+	m = sendKey(m, "F")
+	m = sendKey(m, "enter")
+	if m.chat.IsInActivityFeedMode() {
+		t.Error("Expected activity feed to close after Enter")
+	}
+	if m.activeSession != nil {
+		t.Error("Expected no session to be selected for a missing session")
+	}
+}
+
+func TestCompactToolUses_CtrlTExpandsBursts(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetCompactToolUses(true)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	if m.activeSession == nil {
+		t.Fatal("Expected active session")
+	}
+
+	m.chat.AppendToolUse("Read", "a.go", "tool-1")
+	m.chat.AppendToolUse("Grep", "TODO", "tool-2")
+	m.chat.AppendStreaming("Found it.")
+	m.chat.FinishStreaming()
+
+	if !m.chat.HasCompactToolGroups() {
+		t.Fatal("Expected compact tool groups after startup applied the config")
+	}
+	view := m.chat.View()
+	if !strings.Contains(view, "2 tool uses") {
+		t.Errorf("Expected compacted summary in view, got:\n%s", view)
+	}
+
+	m = sendKey(m, keys.CtrlT)
+	view = m.chat.View()
+	if strings.Contains(view, "2 tool uses") || !strings.Contains(view, "a.go") {
+		t.Errorf("Expected ctrl-t to expand the burst, got:\n%s", view)
+	}
+}
+
+// noisyPaste is a terminal paste with prompts and trailing padding that cleaning removes.
+const noisyPaste = "$ go test ./...   \n" +
+	"--- FAIL: TestParse (0.00s)\n" +
+	"$ go vet ./...\n" +
+	"ok\n"
+
+func pasteIntoChat(m *Model, content string) *Model {
+	result, _ := m.Update(tea.PasteMsg{Content: content})
+	return result.(*Model)
+}
+
+func TestPasteCleaning_AskPromptsOncePerSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	if m.focus != FocusChat {
+		t.Fatal("Expected chat focus after selecting a session")
+	}
+
+	m = pasteIntoChat(m, noisyPaste)
+	state, ok := m.modal.State.(*ui.PasteCleanState)
+	if !ok {
+		t.Fatalf("Expected PasteCleanState in ask mode, got %T", m.modal.State)
+	}
+	if !strings.HasPrefix(state.Summary, "cleaned paste:") {
+		t.Errorf("Expected cleaning summary in prompt, got %q", state.Summary)
+	}
+	if m.chat.GetInput() != "" {
+		t.Errorf("Expected paste to wait for the prompt, got input %q", m.chat.GetInput())
+	}
+
+	// Accept "Clean pastes in this session"
+	m = sendKey(m, "enter")
+	if m.modal.IsVisible() {
+		t.Fatal("Expected prompt to close")
+	}
+	want := "go test ./...\n--- FAIL: TestParse (0.00s)\ngo vet ./...\nok"
+	if got := m.chat.GetInput(); got != want {
+		t.Errorf("Expected cleaned paste %q, got %q", want, got)
+	}
+	if cfg.GetPasteCleaning() != config.PasteCleaningAsk {
+		t.Errorf("Per-session answer should not change config, got %q", cfg.GetPasteCleaning())
+	}
+
+	// The second paste in the same session is cleaned without asking
+	m.chat.ClearInput()
+	m = pasteIntoChat(m, noisyPaste)
+	if m.modal.IsVisible() {
+		t.Fatalf("Expected no second prompt in the same session, got %T", m.modal.State)
+	}
+	if got := m.chat.GetInput(); got != want {
+		t.Errorf("Expected cleaned paste %q, got %q", want, got)
+	}
+}
+
+func TestPasteCleaning_EscapePastesRaw(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m = pasteIntoChat(m, noisyPaste)
+	if _, ok := m.modal.State.(*ui.PasteCleanState); !ok {
+		t.Fatalf("Expected PasteCleanState, got %T", m.modal.State)
+	}
+	m = sendKey(m, keys.Escape)
+	if m.modal.IsVisible() {
+		t.Fatal("Expected prompt to close on Escape")
+	}
+	if got := m.chat.GetInput(); !strings.Contains(got, "$ go test") {
+		t.Errorf("Expected raw paste on Escape, got %q", got)
+	}
+
+	// Escape does not record an answer, so the next paste asks again
+	m.chat.ClearInput()
+	m = pasteIntoChat(m, noisyPaste)
+	if _, ok := m.modal.State.(*ui.PasteCleanState); !ok {
+		t.Errorf("Expected prompt again after Escape, got %T", m.modal.State)
+	}
+}
+
+func TestPasteCleaning_AlwaysCleansAndCtrlZUndoes(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetPasteCleaning(config.PasteCleaningAlways)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m = typeText(m, "see: ")
+	m = pasteIntoChat(m, noisyPaste)
+	if m.modal.IsVisible() {
+		t.Fatalf("Expected no prompt in always mode, got %T", m.modal.State)
+	}
+	if got := m.chat.GetInput(); strings.Contains(got, "$ ") || !strings.HasPrefix(got, "see: go test") {
+		t.Errorf("Expected cleaned paste after typed text, got %q", got)
+	}
+
+	m = sendKey(m, keys.CtrlZ)
+	if got := m.chat.GetInput(); !strings.HasPrefix(got, "see: $ go test ./...") {
+		t.Errorf("Expected ctrl-z to restore the raw paste, got %q", got)
+	}
+	if m.pasteUndo != nil {
+		t.Error("Expected undo to be consumed")
+	}
+}
+
+func TestPasteCleaning_NeverAndSingleLineAreUntouched(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	// Single-line pastes never prompt
+	m = pasteIntoChat(m, "$ make test   ")
+	if m.modal.IsVisible() {
+		t.Fatalf("Expected no prompt for a single-line paste, got %T", m.modal.State)
+	}
+
+	m.chat.ClearInput()
+	cfg.SetPasteCleaning(config.PasteCleaningNever)
+	m = pasteIntoChat(m, noisyPaste)
+	if m.modal.IsVisible() {
+		t.Fatalf("Expected no prompt in never mode, got %T", m.modal.State)
+	}
+	if got := m.chat.GetInput(); !strings.Contains(got, "$ go test") {
+		t.Errorf("Expected raw paste in never mode, got %q", got)
+	}
+}
+
+func TestPasteSanitize_NormalizesWithoutPrompt(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	// CRLF and trailing spaces alone are sanitized silently, not offered for cleaning
+	m = pasteIntoChat(m, "func main() {  \r\n\tfmt.Println()\t\r\n}\r\n")
+	if m.modal.IsVisible() {
+		t.Fatalf("Expected no cleaning prompt for whitespace-only changes, got %T", m.modal.State)
+	}
+	if got := m.chat.GetInput(); strings.ContainsAny(got, "\r") || strings.Contains(got, "{ ") {
+		t.Errorf("Expected sanitized paste, got %q", got)
+	}
+}
+
+func TestPasteSanitize_LeavesFencedCodeAlone(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	cfg.SetPasteCleaning(config.PasteCleaningNever)
+
+	// The paste lands inside a code fence the user opened
+	m.chat.SetInput("```md\n")
+	m = pasteIntoChat(m, "line one  \nline two")
+	if got := m.chat.GetInput(); !strings.Contains(got, "line one  \nline two") {
+		t.Errorf("Expected trailing whitespace inside the fence to be kept, got %q", got)
+	}
+}
+
+func TestPasteSanitize_Disabled(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetPasteSanitize(false)
+	cfg.SetPasteCleaning(config.PasteCleaningNever)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m = pasteIntoChat(m, "make test  \nok")
+	if got := m.chat.GetInput(); got != "make test  \nok" {
+		t.Errorf("Expected paste untouched with sanitizing disabled, got %q", got)
+	}
+}
+
+// noisyImage returns a PNG of random pixels, which compresses poorly.
+func noisyImage(t *testing.T, width, height int) *clipboard.ImageData {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(rng.Intn(256))
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return &clipboard.ImageData{Data: buf.Bytes(), MediaType: "image/png", Width: width, Height: height}
+}
+
+func TestAttachImage_DownscalesToMaxImageKB(t *testing.T) {
+	cfg := testConfigWithSessions()
+	img := noisyImage(t, 400, 300)
+	limitKB := img.SizeKB() / 8
+	cfg.SetMaxImageKB(limitKB)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	// The image is downscaled in the background, then attached
+	_, cmd := m.attachImage(img)
+	if m.chat.HasPendingImage() || cmd == nil {
+		t.Fatal("Expected the image downscaled before it's attached")
+	}
+	m.Update(cmd())
+	if !m.chat.HasPendingImage() {
+		t.Fatal("Expected image to be attached after downscaling")
+	}
+	if got := m.chat.GetPendingImageSizeKB(); got > limitKB {
+		t.Errorf("Attached image is %dKB, over the %dKB limit", got, limitKB)
+	}
+	if !m.footer.HasFlash() {
+		t.Error("Expected a flash reporting the downscale")
+	}
+}
+
+func TestAttachImage_UnderLimitUnchanged(t *testing.T) {
+	cfg := testConfigWithSessions()
+	img := noisyImage(t, 40, 30)
+	cfg.SetMaxImageKB(img.SizeKB() + 10)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m.attachImage(img)
+	data, mediaType := m.chat.GetPendingImage()
+	if !bytes.Equal(data, img.Data) || mediaType != "image/png" {
+		t.Error("Expected an image under the limit to be attached as-is")
+	}
+}
+
+func TestAttachImage_DropsImageForInactiveSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	img := noisyImage(t, 400, 300)
+	cfg.SetMaxImageKB(img.SizeKB() / 8)
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	_, cmd := m.attachImage(img)
+	m.selectSession(&cfg.Sessions[1])
+	m.Update(cmd())
+	if m.chat.HasPendingImage() {
+		t.Error("Expected the image dropped once its session is no longer active")
+	}
+}
+
+func TestAttachImage_StillTooLargeAsks(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		keys       []string
+		wantAttach bool
+	}{
+		{name: "attach anyway", keys: []string{"enter"}, wantAttach: true},
+		{name: "cancel option", keys: []string{"down", "enter"}},
+		{name: "escape", keys: []string{"esc"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfigWithSessions()
+			cfg.SetMaxImageKB(1) // Unreachable for a noisy image
+			m, _ := testModelWithMocks(cfg, 120, 40)
+			m.sidebar.SetSessions(cfg.Sessions)
+			m = sendKey(m, "enter")
+
+			_, cmd := m.attachImage(noisyImage(t, 300, 300))
+			m.Update(cmd())
+			state, ok := m.modal.State.(*ui.ImageTooLargeState)
+			if !ok {
+				t.Fatalf("Expected ImageTooLargeState, got %T", m.modal.State)
+			}
+			if m.chat.HasPendingImage() {
+				t.Fatal("Image should not be attached before the user chooses")
+			}
+			if state.SizeKB <= state.LimitKB || state.LimitKB != 1 {
+				t.Errorf("Expected sizes in prompt, got %dKB over %dKB", state.SizeKB, state.LimitKB)
+			}
+
+			for _, k := range tt.keys {
+				m = sendKey(m, k)
+			}
+			if m.modal.IsVisible() {
+				t.Error("Expected modal to close")
+			}
+			if m.chat.HasPendingImage() != tt.wantAttach {
+				t.Errorf("HasPendingImage = %v, want %v", m.chat.HasPendingImage(), tt.wantAttach)
+			}
+			if data, _ := m.chat.GetPendingImage(); tt.wantAttach && !bytes.Equal(data, state.Data) {
+				t.Error("Expected the downscaled image to be attached")
+			}
+		})
+	}
+}
+
+func simulatePRStep(m *Model, sessionID string, update git.PRStepUpdate, err error) *Model {
+	result, _ := m.Update(MergeResultMsg{
+		SessionID: sessionID,
+		Result:    git.Result{PRStep: &update, Error: err, Done: err != nil},
+	})
+	return result.(*Model)
+}
+
+func TestPRPipeline_FailurePersistsProgressAndResumes(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	sess := cfg.GetSession(m.activeSession.ID)
+
+	// Start the pipeline as the merge modal would
+	m.sessionState().StartMerge(sess.ID, make(chan git.Result), func() {}, manager.MergeTypePR)
+	m.modal.Show(ui.NewPRProgressState(sess.ID, sess.Name, prStepItems(nil)))
+
+	m = simulatePRStep(m, sess.ID, git.PRStepUpdate{Step: git.PRStepCommit, Status: git.PRStepDone, BaseBranch: "main"}, nil)
+	m = simulatePRStep(m, sess.ID, git.PRStepUpdate{Step: git.PRStepPush, Status: git.PRStepDone, BaseBranch: "main", PushedSHA: "0123456789abcdef"}, nil)
+	m = simulatePRStep(m, sess.ID, git.PRStepUpdate{Step: git.PRStepGenerate, Status: git.PRStepDone, BaseBranch: "main", Title: "Add feature", Body: "Details"}, nil)
+	m = simulatePRStep(m, sess.ID, git.PRStepUpdate{Step: git.PRStepCreate, Status: git.PRStepRunning, BaseBranch: "main"}, nil)
+
+	state, ok := m.modal.State.(*ui.PRProgressState)
+	if !ok {
+		t.Fatalf("Expected PRProgressState, got %T", m.modal.State)
+	}
+	if state.Steps[1].State != ui.PRStepDone || state.Steps[1].Detail != "at 0123456" {
+		t.Errorf("Expected push step done at 0123456, got %+v", state.Steps[1])
+	}
+	if state.Steps[3].State != ui.PRStepRunning {
+		t.Errorf("Expected create step running, got %+v", state.Steps[3])
+	}
+
+	createErr := errors.New("PR creation failed: gh auth login required")
+	m = simulatePRStep(m, sess.ID, git.PRStepUpdate{Step: git.PRStepCreate, Status: git.PRStepFailed, BaseBranch: "main", Error: createErr.Error()}, createErr)
+
+	if !m.modal.IsVisible() || !state.Failed() {
+		t.Fatal("Expected progress modal to stay open showing the failure")
+	}
+	progress := cfg.GetSession(sess.ID).PRProgress
+	if progress == nil {
+		t.Fatal("Expected PR progress to be persisted on the session")
+	}
+	if progress.PushedSHA != "0123456789abcdef" || progress.Title != "Add feature" || progress.FailedStep != string(git.PRStepCreate) {
+		t.Errorf("Unexpected persisted progress: %+v", progress)
+	}
+
+	// Reopening the merge modal shows where the previous attempt stopped
+	m = sendKey(m, keys.Escape)
+	m.sessionState().StopMerge(sess.ID)
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddExactMatch("git", []string{"remote", "get-url", "origin"}, pexec.MockResponse{
+		Stdout: []byte("git@github.com:owner/repo.git\n"),
+	})
+	m.SetGitService(git.NewGitServiceWithExecutor(mockExec))
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, keys.Tab)
+	m = sendKey(m, "m")
+	mergeState, ok := m.modal.State.(*ui.MergeState)
+	if !ok {
+		t.Fatalf("Expected MergeState, got %T", m.modal.State)
+	}
+	if len(mergeState.PRSteps) != len(git.PRSteps) || mergeState.PRSteps[3].State != ui.PRStepFailed {
+		t.Errorf("Expected merge modal to show the failed create step, got %+v", mergeState.PRSteps)
+	}
+}
+
+func TestPRPipeline_SuccessClearsProgress(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetSessionPRProgress(cfg.Sessions[0].ID, &config.PRProgress{BaseBranch: "main", FailedStep: string(git.PRStepPush), Error: "failed to push"})
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	sessionID := m.activeSession.ID
+	m.sessionState().StartMerge(sessionID, make(chan git.Result), func() {}, manager.MergeTypePR)
+	m.modal.Show(ui.NewPRProgressState(sessionID, "session", prStepItems(nil)))
+
+	m = simulatePRStep(m, sessionID, git.PRStepUpdate{Step: git.PRStepCreate, Status: git.PRStepDone, BaseBranch: "main"}, nil)
+	m = simulateMergeResult(m, sessionID, "\nPull request created successfully!\n", nil, true, nil, "")
+
+	sess := cfg.GetSession(sessionID)
+	if !sess.PRCreated {
+		t.Error("Expected session to be marked PR created")
+	}
+	if sess.PRProgress != nil {
+		t.Errorf("Expected PR progress to be cleared, got %+v", sess.PRProgress)
+	}
+	if m.modal.IsVisible() {
+		t.Errorf("Expected progress modal to close on success, got %T", m.modal.State)
+	}
+}
+
+func TestPRStepItems(t *testing.T) {
+	items := prStepItems(&config.PRProgress{
+		PushedSHA:  "0123456789abcdef",
+		Title:      "Add feature",
+		FailedStep: string(git.PRStepCreate),
+		Error:      "PR creation failed: auth",
+	})
+
+	want := []ui.PRStepState{ui.PRStepDone, ui.PRStepDone, ui.PRStepDone, ui.PRStepFailed}
+	for i, item := range items {
+		if item.State != want[i] {
+			t.Errorf("step %d (%s) state = %v, want %v", i, item.Label, item.State, want[i])
+		}
+	}
+	if items[2].Detail != "Add feature" || items[3].Detail != "PR creation failed: auth" {
+		t.Errorf("Unexpected details: %+v", items)
+	}
+
+	for _, item := range prStepItems(nil) {
+		if item.State != ui.PRStepPending {
+			t.Errorf("Expected all steps pending without progress, got %+v", item)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// Layout selects how the app occupies the terminal. It is chosen once at startup.
+type Layout int
+
+const (
+	// LayoutSplit is the default full-screen layout: sidebar and chat side by side
+	// on the alternate screen, with mouse support.
+	LayoutSplit Layout = iota
+	// LayoutInline runs on the normal screen without mouse capture, showing only the
+	// chat. Sessions are switched with the session switcher, and sent and completed
+	// messages are printed to the terminal's scrollback so they can be logged or captured.
+	LayoutInline
+)
+
+func (l Layout) String() string {
+	switch l {
+	case LayoutInline:
+		return "inline"
+	default:
+		return "split"
+	}
+}
+
+// SetLayout sets the layout. Must be called before the program starts. The
+// inline layout hides the sidebar, so it starts with the chat focused.
+func (m *Model) SetLayout(l Layout) {
+	m.layout = l
+	if l == LayoutInline {
+		m.focus = FocusChat
+		m.sidebar.SetFocused(false)
+		m.chat.SetFocused(true)
+	}
+	logger.Get().Info("layout selected", "layout", l)
+}
+
+// Layout returns the layout chosen at startup.
+func (m *Model) Layout() Layout {
+	return m.layout
+}
+
+// inline returns whether the app runs in the inline layout.
+func (m *Model) inline() bool {
+	return m.layout == LayoutInline
+}
+
+// printToScrollback prints a chat message above the inline view so it stays in the
+// terminal's scrollback. Returns nil outside the inline layout.
+func (m *Model) printToScrollback(role, content string) tea.Cmd {
+	if !m.inline() || content == "" {
+		return nil
+	}
+	return tea.Println(ui.RenderScrollbackMessage(role, content, m.width-ui.ContentPadding))
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/activity"
 	"github.com/zhubert/plural/internal/claude"
 	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/logger"
@@ -274,6 +275,17 @@ func (m *Model) handlePermissionResponse(key string, sessionID string, req *mcp.
 	// Send response
 	runner.SendPermissionResponse(resp)
 
+	verdict := "denied"
+	severity := activity.SeverityWarning
+	if always {
+		verdict = "always allowed"
+		severity = activity.SeverityInfo
+	} else if allowed {
+		verdict = "allowed"
+		severity = activity.SeverityInfo
+	}
+	m.recordActivity(sessionID, activity.KindPermissionResolved, severity, "Permission "+verdict+": "+req.Tool)
+
 	// Clear pending permission
 	if state := m.sessionState().GetIfExists(sessionID); state != nil {
 		state.SetPendingPermission(nil)
@@ -287,6 +299,11 @@ func (m *Model) handlePermissionResponse(key string, sessionID string, req *mcp.
 
 // submitQuestionResponse sends the collected question answers back to Claude
 func (m *Model) submitQuestionResponse(sessionID string) (tea.Model, tea.Cmd) {
+	return m.respondToQuestion(sessionID, m.chat.GetQuestionAnswers())
+}
+
+// respondToQuestion sends answers to the session's pending question and clears it
+func (m *Model) respondToQuestion(sessionID string, answers map[string]string) (tea.Model, tea.Cmd) {
 	log := logger.WithSession(sessionID)
 	runner := m.sessionMgr.GetRunner(sessionID)
 	if runner == nil {
@@ -305,8 +322,6 @@ func (m *Model) submitQuestionResponse(sessionID string) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Get answers from chat
-	answers := m.chat.GetQuestionAnswers()
 	log.Debug("question response", "answerCount", len(answers))
 
 	// Build response
@@ -322,7 +337,9 @@ func (m *Model) submitQuestionResponse(sessionID string) (tea.Model, tea.Cmd) {
 	state.SetPendingQuestion(nil)
 	m.sidebar.SetPendingPermission(sessionID, false)
 	m.sidebar.SetPendingQuestion(sessionID, false)
-	m.chat.ClearPendingQuestion()
+	if m.activeSession != nil && m.activeSession.ID == sessionID {
+		m.chat.ClearPendingQuestion()
+	}
 
 	// Continue listening for session events
 	return m, tea.Batch(m.sessionListeners(sessionID, runner, nil)...)
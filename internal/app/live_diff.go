@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/git"
+)
+
+const liveDiffRefreshInterval = 2 * time.Second
+
+// LiveDiffMsg carries the current worktree diff of a session
+type LiveDiffMsg struct {
+	SessionID string
+	Diff      string
+	Err       error
+}
+
+// fetchLiveDiff returns a command that reads a session's worktree diff in the
+// background. Untracked files are included as new-file diffs.
+func fetchLiveDiff(gitSvc *git.GitService, sessionID, worktree string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		status, err := gitSvc.GetWorktreeStatus(ctx, worktree)
+		if err != nil {
+			return LiveDiffMsg{SessionID: sessionID, Err: err}
+		}
+		var diff strings.Builder
+		diff.WriteString(status.Diff)
+		for _, f := range status.FileDiffs {
+			if f.Status == "?" {
+				diff.WriteString(f.Diff + "\n")
+			}
+		}
+		return LiveDiffMsg{SessionID: sessionID, Diff: diff.String()}
+	}
+}
+
+// shortcutToggleLiveDiff switches the chat panel of the selected session between
+// the conversation and its live worktree diff. The choice is remembered per session.
+func shortcutToggleLiveDiff(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	if m.activeSession == nil || m.activeSession.ID != sess.ID {
+		m.selectSession(sess)
+	}
+	if m.chat.IsInLiveDiffMode() {
+		m.exitLiveDiff()
+		return m, nil
+	}
+	m.sessionState().GetOrCreate(sess.ID).SetShowLiveDiff(true)
+	m.enterLiveDiff(sess.ID)
+	return m, fetchLiveDiff(m.gitService, sess.ID, sess.WorkTree)
+}
+
+// exitLiveDiff returns the chat panel of the active session to the conversation.
+func (m *Model) exitLiveDiff() {
+	m.chat.ExitLiveDiffMode()
+	if m.activeSession != nil {
+		if state := m.sessionState().GetIfExists(m.activeSession.ID); state != nil {
+			state.SetShowLiveDiff(false)
+		}
+	}
+}
+
+// restoreLiveDiff shows the live diff for a session being selected if it was
+// showing when the user last left it. The next refresh fills it in.
+func (m *Model) restoreLiveDiff(sessionID string) {
+	m.chat.ExitLiveDiffMode()
+	if state := m.sessionState().GetIfExists(sessionID); state != nil && state.GetShowLiveDiff() {
+		m.enterLiveDiff(sessionID)
+	}
+}
+
+// enterLiveDiff shows the live diff for a session, highlighting at the first
+// refresh what changed since the diff was last shown for it.
+func (m *Model) enterLiveDiff(sessionID string) {
+	m.chat.EnterLiveDiffMode()
+	if state := m.sessionState().GetIfExists(sessionID); state != nil {
+		if seen, ok := state.GetLiveDiffSeen(); ok {
+			m.chat.SetLiveDiffBaseline(seen)
+		}
+	}
+}
+
+// liveDiffInterval returns how often the live diff is re-read: every
+// liveDiffRefreshInterval while it is showing, and never otherwise.
+func (m *Model) liveDiffInterval() time.Duration {
+	if !m.chat.IsInLiveDiffMode() {
+		return 0
+	}
+	return liveDiffRefreshInterval
+}
+
+// refreshLiveDiff returns a command re-reading the live diff if it is showing, or nil.
+func (m *Model) refreshLiveDiff() tea.Cmd {
+	if m.chat.IsInLiveDiffMode() && m.activeSession != nil {
+		return fetchLiveDiff(m.gitService, m.activeSession.ID, m.activeSession.WorkTree)
+	}
+	return nil
+}
+
+// handleLiveDiffMsg shows a freshly read diff, unless the user has since switched
+// sessions or returned to the conversation. The diff is remembered for the
+// session so that what changed while away is highlighted on return.
+func (m *Model) handleLiveDiffMsg(msg LiveDiffMsg) (tea.Model, tea.Cmd) {
+	if m.activeSession != nil && m.activeSession.ID == msg.SessionID && m.chat.IsInLiveDiffMode() {
+		m.chat.SetLiveDiff(msg.Diff, msg.Err)
+		if msg.Err == nil {
+			m.sessionState().GetOrCreate(msg.SessionID).SetLiveDiffSeen(msg.Diff)
+		}
+	}
+	return m, nil
+}
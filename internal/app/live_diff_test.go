@@ -0,0 +1,106 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/keys"
+)
+
+func TestLiveDiff_TogglesAndRefreshes(t *testing.T) {
+	repo := initSnapshotRepo(t)
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].RepoPath = repo
+	cfg.Sessions[0].WorkTree = repo
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	sessionID := cfg.Sessions[0].ID
+
+	if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, cmd := m.Update(keyPress(keys.CtrlF))
+	m = result.(*Model)
+	if !m.chat.IsInLiveDiffMode() || m.activeSession == nil || m.activeSession.ID != sessionID {
+		t.Fatal("expected ctrl-f to open the live diff of the selected session")
+	}
+	if cmd == nil {
+		t.Fatal("expected a command reading the diff")
+	}
+	diffMsg, ok := cmd().(LiveDiffMsg)
+	if !ok || diffMsg.Err != nil || !strings.Contains(diffMsg.Diff, "+func main() {}") {
+		t.Fatalf("expected the worktree diff, got %+v", diffMsg)
+	}
+	result, _ = m.Update(diffMsg)
+	m = result.(*Model)
+	if view := m.chat.View(); !strings.Contains(view, "Live diff") || !strings.Contains(view, "func main() {}") {
+		t.Errorf("expected the diff in the chat panel, got:\n%s", view)
+	}
+
+	// The scheduled refresh re-reads the diff while it is showing
+	if m.refreshLiveDiff() == nil || m.liveDiffInterval() <= 0 {
+		t.Fatal("expected the refresh to re-read the diff")
+	}
+
+	// Switching away and back restores the diff view for this session only
+	m.selectSession(&cfg.Sessions[1])
+	if m.chat.IsInLiveDiffMode() {
+		t.Error("expected the other session to show its conversation")
+	}
+	m.selectSession(&cfg.Sessions[0])
+	if !m.chat.IsInLiveDiffMode() {
+		t.Error("expected the live diff restored when returning to the session")
+	}
+
+	m = sendKey(m, keys.CtrlF)
+	if m.chat.IsInLiveDiffMode() {
+		t.Error("expected ctrl-f to return to the conversation")
+	}
+	if interval := m.liveDiffInterval(); interval > 0 {
+		t.Errorf("expected the refresh stopped with the live diff closed, got an interval of %v", interval)
+	}
+	m.selectSession(&cfg.Sessions[1])
+	m.selectSession(&cfg.Sessions[0])
+	if m.chat.IsInLiveDiffMode() {
+		t.Error("expected the conversation kept after toggling back")
+	}
+}
+
+func TestLiveDiff_IgnoresDiffForOtherSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m.selectSession(&cfg.Sessions[0])
+	m.chat.EnterLiveDiffMode()
+	result, _ := m.Update(LiveDiffMsg{SessionID: cfg.Sessions[1].ID, Diff: "diff --git a/other.go b/other.go\n"})
+	m = result.(*Model)
+	if strings.Contains(m.chat.View(), "other.go") {
+		t.Error("expected a diff for another session to be ignored")
+	}
+}
+
+func TestLiveDiff_HighlightsChangesSinceLastSeen(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	sessionID := cfg.Sessions[0].ID
+
+	m = sendKey(m, keys.CtrlF)
+	diff := "diff --git a/main.go b/main.go\n@@ -1 +1 @@\n+first\n"
+	result, _ := m.Update(LiveDiffMsg{SessionID: sessionID, Diff: diff})
+	m = result.(*Model)
+
+	// Away from the session, Claude adds a hunk
+	m.selectSession(&cfg.Sessions[1])
+	m.selectSession(&cfg.Sessions[0])
+	result, _ = m.Update(LiveDiffMsg{SessionID: sessionID, Diff: diff + "@@ -9 +9 @@\n+second\n"})
+	m = result.(*Model)
+
+	if view := m.chat.View(); !strings.Contains(view, "1 hunk changed") {
+		t.Errorf("expected the hunk added while away highlighted, got:\n%s", view)
+	}
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// recordArchiveChoice remembers whether the session is archived once the merge
+// about to start for it succeeds, as chosen in the merge modal.
+func (m *Model) recordArchiveChoice(sessionID string, state *ui.MergeState) {
+	if state != nil && state.ShouldArchiveAfterMerge() {
+		m.archiveAfterMerge[sessionID] = true
+	} else {
+		delete(m.archiveAfterMerge, sessionID)
+	}
+}
+
+// archiveAfterMergeDone archives a session merged with archiving chosen, once
+// nothing more runs for it: right after the merge, or after the repo hooks that
+// follow it. Returns nil if archiving wasn't chosen or hooks are still running.
+func (m *Model) archiveAfterMergeDone(sessionID string) tea.Cmd {
+	if !m.archiveAfterMerge[sessionID] || m.hookRuns[sessionID] != nil {
+		return nil
+	}
+	delete(m.archiveAfterMerge, sessionID)
+	sess := m.config.GetSession(sessionID)
+	if sess == nil {
+		return nil
+	}
+	return m.archiveMergedSession(sess)
+}
+
+// archiveMergedSession writes a merged session's transcript to the archive and
+// removes the session and its worktree. If the transcript can't be written,
+// the session is kept.
+func (m *Model) archiveMergedSession(sess *config.Session) tea.Cmd {
+	log := logger.WithSession(sess.ID)
+	path, err := config.ArchiveSessionMessages(sess.ID, ui.SessionDisplayName(sess.Branch, sess.Name))
+	if err != nil {
+		log.Error("failed to archive transcript after merge", "error", err)
+		return m.ShowFlashError("Merged, but failed to archive the session: " + err.Error())
+	}
+	log.Info("archived session after merge", "path", path)
+	return tea.Batch(m.deleteSession(sess, true), m.ShowFlashSuccess("Merged and archived to "+path))
+}
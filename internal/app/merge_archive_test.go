@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	pexec "github.com/zhubert/plural/internal/exec"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// chooseArchiveAfterMerge records archiving session-1 after its next merge, as
+// checked in the merge modal.
+func chooseArchiveAfterMerge(m *Model) {
+	state := ui.NewMergeState("session", true, "", "", false)
+	state.ArchiveAfterMerge = true
+	m.recordArchiveChoice("session-1", state)
+}
+
+// archivedTranscript returns whether session-1's transcript was archived.
+func archivedTranscript(t *testing.T) bool {
+	t.Helper()
+	dir, err := paths.ArchiveDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(filepath.Join(dir, "session-1.txt"))
+	return err == nil
+}
+
+func TestArchiveAfterMerge(t *testing.T) {
+	m, _ := hooksTestModel(t, config.RepoHooks{})
+	chooseArchiveAfterMerge(m)
+
+	finishMerge(t, m, manager.MergeTypeMerge, "")
+	if m.config.GetSession("session-1") != nil {
+		t.Error("expected the merged session removed")
+	}
+	if !archivedTranscript(t) {
+		t.Error("expected the transcript archived")
+	}
+	if m.activeSession != nil {
+		t.Error("expected the archived session no longer shown")
+	}
+}
+
+func TestArchiveAfterMerge_NotChosen(t *testing.T) {
+	m, _ := hooksTestModel(t, config.RepoHooks{})
+
+	finishMerge(t, m, manager.MergeTypeMerge, "")
+	if m.config.GetSession("session-1") == nil {
+		t.Error("expected the session kept when archiving wasn't chosen")
+	}
+}
+
+func TestArchiveAfterMerge_NotOnConflict(t *testing.T) {
+	m, _ := hooksTestModel(t, config.RepoHooks{})
+	chooseArchiveAfterMerge(m)
+
+	_, cancel := context.WithCancel(context.Background())
+	m.sessionState().StartMerge("session-1", make(chan git.Result), cancel, manager.MergeTypeMerge)
+	m.Update(MergeResultMsg{SessionID: "session-1", Result: git.Result{Error: errors.New("conflict"), ConflictedFiles: []string{"a.go"}}})
+	if m.config.GetSession("session-1") == nil {
+		t.Fatal("expected the session kept after a conflict")
+	}
+
+	// The choice doesn't carry over to the merge after the conflict is resolved
+	finishMerge(t, m, manager.MergeTypeMerge, "")
+	if m.config.GetSession("session-1") == nil || archivedTranscript(t) {
+		t.Error("expected the session kept after a later merge")
+	}
+}
+
+func TestArchiveAfterMerge_NotAfterPR(t *testing.T) {
+	m, _ := hooksTestModel(t, config.RepoHooks{})
+	chooseArchiveAfterMerge(m)
+
+	finishMerge(t, m, manager.MergeTypePR, "https://github.com/o/r/pull/1")
+	if m.config.GetSession("session-1") == nil {
+		t.Error("expected the session kept after creating a PR")
+	}
+}
+
+func TestArchiveAfterMerge_WaitsForHooks(t *testing.T) {
+	m, mockExec := hooksTestModel(t, config.RepoHooks{PostMerge: []string{"./deploy.sh"}})
+	chooseArchiveAfterMerge(m)
+
+	_, cancel := context.WithCancel(context.Background())
+	m.sessionState().StartMerge("session-1", make(chan git.Result), cancel, manager.MergeTypeMerge)
+	m.Update(MergeResultMsg{SessionID: "session-1", Result: git.Result{Done: true}})
+	if m.config.GetSession("session-1") == nil {
+		t.Fatal("expected the session kept while its hooks run")
+	}
+	for m.hookRuns["session-1"] != nil {
+		m.Update(listenForHookOutput("session-1", m.hookRuns["session-1"].ch)())
+	}
+	if _, ran := hookCalls(mockExec)["./deploy.sh"]; !ran {
+		t.Error("expected the hook run")
+	}
+	if m.config.GetSession("session-1") != nil {
+		t.Error("expected the session archived once its hooks finished")
+	}
+}
+
+func TestArchiveAfterMerge_KeptWhenHookFails(t *testing.T) {
+	m, mockExec := hooksTestModel(t, config.RepoHooks{PostMerge: []string{"./deploy.sh"}})
+	mockExec.AddRule(func(dir, name string, args []string) bool {
+		return name == "env" && slices.Contains(args, "./deploy.sh")
+	}, pexec.MockResponse{Err: errors.New("exit status 1")})
+	chooseArchiveAfterMerge(m)
+
+	finishMerge(t, m, manager.MergeTypeMerge, "")
+	if m.config.GetSession("session-1") == nil {
+		t.Error("expected the session kept when a hook fails")
+	}
+}
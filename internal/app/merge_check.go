@@ -0,0 +1,236 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/activity"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/session"
+	"github.com/zhubert/plural/internal/ui"
+	"github.com/zhubert/plural/internal/workpool"
+)
+
+// MergedBranchesMsg carries the sessions the periodic poll found merged upstream
+type MergedBranchesMsg struct {
+	SessionIDs []string
+}
+
+// MergeCheckMsg carries the result of checking whether one session is merged, on demand
+type MergeCheckMsg struct {
+	SessionID string
+	Merged    bool
+	Err       error
+}
+
+// mergeCheckCandidates returns the sessions whose branch may since have been
+// merged outside Plural.
+func mergeCheckCandidates(sessions []config.Session) []config.Session {
+	var candidates []config.Session
+	for _, sess := range sessions {
+		if sess.IsMerged() || sess.MergedToParent || sess.Branch == "" || sess.WorkTree == "" {
+			continue
+		}
+		candidates = append(candidates, sess)
+	}
+	return candidates
+}
+
+// checkMergedBranches returns a command that checks which candidate sessions'
+// branches are contained in their repo's default branch, up to limit at a time,
+// until ctx is canceled. Squash merges are left to the PR poller, so this only runs git.
+func checkMergedBranches(ctx context.Context, sessions []config.Session, gitSvc *git.GitService, limit int) tea.Cmd {
+	candidates := mergeCheckCandidates(sessions)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		log := logger.WithComponent("merge-check")
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		opts := workpool.Options{Limit: limit}
+
+		// Merge target of each repo, looked up once
+		var repos []string
+		targets := make(map[string]string) // Repo path -> merge target
+		for _, sess := range candidates {
+			if _, ok := targets[sess.RepoPath]; !ok {
+				targets[sess.RepoPath] = ""
+				repos = append(repos, sess.RepoPath)
+			}
+		}
+		repoTargets := make([]string, len(repos))
+		workpool.Run(ctx, len(repos), opts, func(ctx context.Context, i int) error {
+			repoTargets[i] = gitSvc.MergeTarget(ctx, repos[i])
+			return nil
+		})
+		for i, repo := range repos {
+			targets[repo] = repoTargets[i]
+		}
+
+		contained := make([]bool, len(candidates))
+		workpool.Run(ctx, len(candidates), opts, func(ctx context.Context, i int) error {
+			sess := candidates[i]
+			var err error
+			contained[i], err = gitSvc.IsBranchContained(ctx, sess.RepoPath, sess.Branch, targets[sess.RepoPath])
+			if err != nil {
+				log.Debug("merge check failed", "sessionID", sess.ID, "branch", sess.Branch, "error", err)
+			}
+			return err
+		})
+
+		var merged []string
+		for i, sess := range candidates {
+			if contained[i] {
+				merged = append(merged, sess.ID)
+			}
+		}
+		return MergedBranchesMsg{SessionIDs: merged}
+	}
+}
+
+// checkMerged returns a command that fetches origin and checks whether a session's
+// branch is merged, falling back to its PR state for squash merges.
+func checkMerged(gitSvc *git.GitService, sess config.Session) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		gitSvc.RefreshDefaultBranch(ctx, sess.RepoPath)
+		merged, err := gitSvc.IsBranchMerged(ctx, sess.RepoPath, sess.Branch)
+		return MergeCheckMsg{SessionID: sess.ID, Merged: merged, Err: err}
+	}
+}
+
+// markMergedUpstream records that a session's branch was found merged.
+func (m *Model) markMergedUpstream(sessionID string) tea.Cmd {
+	sess := m.config.GetSession(sessionID)
+	if sess == nil || sess.IsMerged() {
+		return nil
+	}
+	sessionName := ui.SessionDisplayName(sess.Branch, sess.Name)
+	logger.WithSession(sessionID).Info("branch merged upstream", "session", sessionName)
+	m.config.MarkSessionMergedUpstream(sessionID)
+	m.recordActivity(sessionID, activity.KindMerged, activity.SeveritySuccess, "Branch merged upstream")
+	return m.ShowFlashSuccess("Branch merged: " + sessionName)
+}
+
+// handleMergedBranchesMsg marks the sessions the periodic poll found merged.
+func (m *Model) handleMergedBranchesMsg(msg MergedBranchesMsg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, sessionID := range msg.SessionIDs {
+		if cmd := m.markMergedUpstream(sessionID); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if len(cmds) == 0 {
+		return m, nil
+	}
+	cmds = append(cmds, m.saveConfigOrFlash())
+	m.sidebar.SetSessions(m.getFilteredSessions())
+	return m, tea.Batch(cmds...)
+}
+
+// handleMergeCheckMsg reports the result of an on-demand merge check.
+func (m *Model) handleMergeCheckMsg(msg MergeCheckMsg) (tea.Model, tea.Cmd) {
+	sess := m.config.GetSession(msg.SessionID)
+	if sess == nil {
+		return m, nil
+	}
+	sessionName := ui.SessionDisplayName(sess.Branch, sess.Name)
+	if msg.Err != nil {
+		logger.WithSession(msg.SessionID).Warn("merge check failed", "error", msg.Err)
+		return m, m.ShowFlashError("Could not check whether " + sessionName + " is merged")
+	}
+	if !msg.Merged {
+		return m, m.ShowFlashInfo("Not merged yet: " + sessionName)
+	}
+	cmd := m.markMergedUpstream(msg.SessionID)
+	m.sidebar.SetSessions(m.getFilteredSessions())
+	return m, tea.Batch(cmd, m.saveConfigOrFlash())
+}
+
+// shortcutCheckMerged checks on demand whether the selected session's branch is merged.
+func shortcutCheckMerged(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	if sess.IsMerged() {
+		return m, m.ShowFlashInfo("Already merged: " + ui.SessionDisplayName(sess.Branch, sess.Name))
+	}
+	return m, tea.Batch(m.ShowFlashInfo("Checking whether the branch is merged..."), checkMerged(m.gitService, *sess))
+}
+
+// CleanupMergedMsg carries the result of cleaning up a merged session's worktree
+// and branch in the background.
+type CleanupMergedMsg struct {
+	SessionID   string
+	Dirty       bool   // The worktree has uncommitted changes, so nothing was deleted
+	ArchivePath string // Where the session's transcript was archived
+	Err         error
+}
+
+// cleanupMerged returns a command that archives a merged session's transcript,
+// then deletes its worktree, local branch and snapshots, unless the worktree has
+// uncommitted changes. Nothing is deleted if the transcript can't be archived.
+func cleanupMerged(gitSvc *git.GitService, sessionSvc *session.SessionService, sess config.Session) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		log := logger.WithSession(sess.ID)
+		if _, err := os.Stat(sess.WorkTree); err == nil {
+			status, err := gitSvc.GetWorktreeStatus(ctx, sess.WorkTree)
+			if err != nil {
+				return CleanupMergedMsg{SessionID: sess.ID, Err: fmt.Errorf("failed to check for uncommitted changes: %w", err)}
+			}
+			if status.HasChanges {
+				return CleanupMergedMsg{SessionID: sess.ID, Dirty: true}
+			}
+		}
+
+		path, err := config.ArchiveSessionMessages(sess.ID, ui.SessionDisplayName(sess.Branch, sess.Name))
+		if err != nil {
+			return CleanupMergedMsg{SessionID: sess.ID, Err: fmt.Errorf("failed to archive the transcript: %w", err)}
+		}
+		log.Info("archived merged session before cleanup", "path", path)
+
+		if err := sessionSvc.Delete(ctx, &sess); err != nil {
+			log.Warn("failed to delete worktree", "error", err)
+			// Continue with session removal even if worktree deletion fails
+		}
+		if err := gitSvc.DeleteSnapshots(ctx, sess.RepoPath, sess.ID); err != nil {
+			log.Warn("failed to delete snapshots", "error", err)
+		}
+		return CleanupMergedMsg{SessionID: sess.ID, ArchivePath: path}
+	}
+}
+
+// handleCleanupMergedMsg removes a merged session once its worktree is deleted,
+// or reports why it was kept.
+func (m *Model) handleCleanupMergedMsg(msg CleanupMergedMsg) (tea.Model, tea.Cmd) {
+	sess := m.config.GetSession(msg.SessionID)
+	if sess == nil {
+		return m, nil
+	}
+	sessionName := ui.SessionDisplayName(sess.Branch, sess.Name)
+	switch {
+	case msg.Err != nil:
+		logger.WithSession(msg.SessionID).Warn("failed to clean up merged session", "error", msg.Err)
+		return m, m.ShowFlashError("Could not clean up " + sessionName + ": " + msg.Err.Error())
+	case msg.Dirty:
+		return m, m.ShowFlashWarning("Uncommitted changes in " + sessionName + "; commit or discard them first")
+	}
+	saveCmd := m.removeSession(msg.SessionID)
+	return m, tea.Batch(saveCmd, m.ShowFlashSuccess("Cleaned up "+sessionName+"; transcript archived to "+msg.ArchivePath))
+}
+
+// shortcutCleanupMerged archives a merged session's transcript and deletes the
+// session along with its worktree and local branch, unless the worktree has
+// uncommitted changes.
+func shortcutCleanupMerged(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	logger.WithSession(sess.ID).Info("cleaning up merged session", "session", ui.SessionDisplayName(sess.Branch, sess.Name))
+	return m, cleanupMerged(m.gitService, m.sessionService, *sess)
+}
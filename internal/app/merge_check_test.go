@@ -0,0 +1,135 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/paths"
+)
+
+func TestMergeCheckCandidates(t *testing.T) {
+	sessions := []config.Session{
+		{ID: "s1", RepoPath: "/repo", Branch: "b1", WorkTree: "/wt1"},                       // candidate
+		{ID: "s2", RepoPath: "/repo", Branch: "b2", WorkTree: "/wt2", Merged: true},         // merged by Plural
+		{ID: "s3", RepoPath: "/repo", Branch: "b3", WorkTree: "/wt3", PRMerged: true},       // PR merged
+		{ID: "s4", RepoPath: "/repo", Branch: "b4", WorkTree: "/wt4", MergedUpstream: true}, // already found merged
+		{ID: "s5", RepoPath: "/repo", Branch: "b5", WorkTree: "/wt5", MergedToParent: true}, // merged to parent
+		{ID: "s6", RepoPath: "/repo", Branch: "b6"},                                         // no worktree
+		{ID: "s7", RepoPath: "/repo", Branch: "b7", WorkTree: "/wt7", PRCreated: true},      // candidate
+	}
+
+	candidates := mergeCheckCandidates(sessions)
+	if len(candidates) != 2 || candidates[0].ID != "s1" || candidates[1].ID != "s7" {
+		t.Errorf("expected s1 and s7, got %+v", candidates)
+	}
+}
+
+func TestHandleMergedBranchesMsg(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+
+	m.Update(MergedBranchesMsg{SessionIDs: []string{"session-1", "no-such-session"}})
+
+	if sess := m.config.GetSession("session-1"); !sess.MergedUpstream {
+		t.Error("expected session-1 to be marked merged upstream")
+	}
+	if sess := m.config.GetSession("session-2"); sess.IsMerged() {
+		t.Error("expected session-2 to be left alone")
+	}
+	if !m.footer.HasFlash() {
+		t.Error("expected a flash announcing the merge")
+	}
+}
+
+func TestHandleMergeCheckMsg_NotMerged(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+
+	m.Update(MergeCheckMsg{SessionID: "session-1"})
+
+	if m.config.GetSession("session-1").IsMerged() {
+		t.Error("expected the session to stay unmerged")
+	}
+	if !m.footer.HasFlash() {
+		t.Error("expected a flash saying it is not merged")
+	}
+
+	m.Update(MergeCheckMsg{SessionID: "session-1", Merged: true})
+	if !m.config.GetSession("session-1").MergedUpstream {
+		t.Error("expected the session to be marked merged upstream")
+	}
+}
+
+func TestCleanupMerged(t *testing.T) {
+	// Cleanup deletes the session's saved messages, so keep them out of the real home
+	t.Setenv("HOME", t.TempDir())
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	// A worktree with uncommitted changes
+	worktree := t.TempDir()
+	if out, err := exec.Command("git", "init", worktree).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(worktree, "dirty.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].WorkTree = worktree
+	cfg.Sessions[0].MergedUpstream = true
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.sidebar.SelectSession("session-1")
+
+	// The worktree is checked and deleted in the background
+	_, cmd := m.Update(keyPress("X"))
+	if cmd == nil {
+		t.Fatal("expected a command cleaning up the session")
+	}
+	msg, ok := cmd().(CleanupMergedMsg)
+	if !ok || !msg.Dirty {
+		t.Fatalf("expected the worktree reported dirty, got %+v", msg)
+	}
+	m.Update(msg)
+	if m.config.GetSession("session-1") == nil {
+		t.Fatal("expected a session with uncommitted changes to be kept")
+	}
+	if _, err := os.Stat(worktree); err != nil {
+		t.Fatal("expected a dirty worktree to be kept")
+	}
+
+	if err := os.Remove(filepath.Join(worktree, "dirty.txt")); err != nil {
+		t.Fatal(err)
+	}
+	_, cmd = m.Update(keyPress("X"))
+	if cmd == nil {
+		t.Fatal("expected a command cleaning up the session")
+	}
+	msg, ok = cmd().(CleanupMergedMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("expected the session cleaned up, got %+v", msg)
+	}
+	m.Update(msg)
+	if m.config.GetSession("session-1") != nil {
+		t.Error("expected the merged session to be cleaned up")
+	}
+	if _, err := os.Stat(msg.ArchivePath); err != nil {
+		t.Errorf("expected the transcript archived before cleanup: %v", err)
+	}
+}
+
+func TestCleanupMerged_OnlyForMergedSessions(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.sidebar.SelectSession("session-1")
+
+	m = sendKey(m, "X")
+	if m.config.GetSession("session-1") == nil {
+		t.Error("expected an unmerged session to be kept")
+	}
+}
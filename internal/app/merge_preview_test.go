@@ -0,0 +1,214 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	pexec "github.com/zhubert/plural/internal/exec"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// hasCall returns whether the mock ran git with args in dir.
+func hasCall(mock *pexec.MockExecutor, dir string, args ...string) bool {
+	return slices.ContainsFunc(mock.GetCalls(), func(call pexec.MockCall) bool {
+		return call.Dir == dir && call.Name == "git" && slices.Equal(call.Args, args)
+	})
+}
+
+func TestMergePreview_ConfirmRunsPreviewedMerge(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddExactMatch("git", []string{"status", "--porcelain"}, pexec.MockResponse{Stdout: []byte(" M main.go\n")})
+	mock.AddExactMatch("git", []string{"symbolic-ref", "refs/remotes/origin/HEAD"}, pexec.MockResponse{Stdout: []byte("refs/remotes/origin/main\n")})
+	mock.AddExactMatch("git", []string{"rev-list", "--count", "--left-right", "origin/main...main"}, pexec.MockResponse{Stdout: []byte("2\t0\n")})
+	m.SetGitService(git.NewGitServiceWithExecutor(mock))
+
+	sess := m.config.GetSession("session-1")
+	m.pendingCommit = &PendingCommit{
+		SessionID:  sess.ID,
+		Type:       manager.MergeTypeMerge,
+		MergeState: ui.NewMergeState(sess.Name, true, "1 file changed", "", false),
+	}
+	m.modal.Show(ui.NewEditCommitState("Add feature", "merge"))
+	m = sendKey(m, keys.CtrlS)
+
+	state, ok := m.modal.State.(*ui.MergeState)
+	if !ok || state.Preview == nil {
+		t.Fatalf("expected the merge preview, got %T", m.modal.State)
+	}
+	p := state.Preview
+	if !slices.Equal(p.Files, []string{"main.go"}) || p.CommitMessage != "Add feature" || p.TargetBranch != "main" || p.Behind != 2 || p.Push {
+		t.Errorf("unexpected preview: %+v", p)
+	}
+	if view := ansi.Strip(state.Render()); !strings.Contains(view, "pulls 2 commit(s) from origin/main") {
+		t.Errorf("expected the pull in the preview, got:\n%s", view)
+	}
+	for _, call := range mock.GetCalls() {
+		if slices.ContainsFunc([]string{"add", "commit", "checkout", "fetch", "pull", "merge"}, func(cmd string) bool { return call.Args[0] == cmd }) {
+			t.Errorf("expected the preview to change nothing, ran git %s", strings.Join(call.Args, " "))
+		}
+	}
+	if s := m.sessionState().GetIfExists(sess.ID); s != nil && s.IsMerging() {
+		t.Fatal("expected no merge before confirming")
+	}
+
+	m = sendKey(m, keys.Enter)
+	if m.modal.IsVisible() {
+		t.Error("expected the modal to close on confirm")
+	}
+	s := m.sessionState().GetIfExists(sess.ID)
+	if s == nil || !s.IsMerging() {
+		t.Fatal("expected the merge to start on confirm")
+	}
+	for result := range s.GetMergeChan() {
+		if result.Error != nil {
+			t.Fatalf("merge failed: %v", result.Error)
+		}
+	}
+
+	if !hasCall(mock, sess.WorkTree, "commit", "-m", p.CommitMessage) {
+		t.Error("expected the merge to commit with the previewed message")
+	}
+	if !hasCall(mock, sess.RepoPath, "checkout", p.TargetBranch) {
+		t.Error("expected the merge to check out the previewed target")
+	}
+	if !hasCall(mock, sess.RepoPath, "pull", "--ff-only") {
+		t.Error("expected the merge to pull as previewed")
+	}
+	if slices.ContainsFunc(mock.GetCalls(), func(call pexec.MockCall) bool { return call.Args[0] == "push" }) {
+		t.Error("expected no push, as previewed")
+	}
+}
+
+func TestMergePreview_EscapeReturnsToOptions(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.SetGitService(git.NewGitServiceWithExecutor(pexec.NewMockExecutor(nil)))
+
+	state := ui.NewMergeState("repo1/session1", true, "", "", false)
+	m.showMergePreview(m.config.GetSession("session-1"), state, "", false)
+	if state.Preview == nil || !m.modal.IsVisible() {
+		t.Fatal("expected the merge preview")
+	}
+
+	m = sendKey(m, keys.Escape)
+	if !m.modal.IsVisible() || state.Preview != nil {
+		t.Error("expected Esc to return to the merge options")
+	}
+	if s := m.sessionState().GetIfExists("session-1"); s != nil && s.IsMerging() {
+		t.Error("expected no merge")
+	}
+}
+
+func TestMergePreview_ConfirmRunsPreviewedRebase(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddExactMatch("git", []string{"symbolic-ref", "refs/remotes/origin/HEAD"}, pexec.MockResponse{Stdout: []byte("refs/remotes/origin/main\n")})
+	m.SetGitService(git.NewGitServiceWithExecutor(mock))
+
+	sess := m.config.GetSession("session-1")
+	state := ui.NewMergeState(sess.Name, true, "", "", false)
+	state.SetCommitsAhead(1)
+	state.SelectedIndex = slices.Index(state.Options, "Rebase onto main")
+	m.modal.Show(state)
+	m = sendKey(m, keys.Enter)
+
+	if state.Preview == nil || !state.Preview.Rebase {
+		t.Fatalf("expected the rebase preview, got %+v", state.Preview)
+	}
+	if view := ansi.Strip(state.Render()); !strings.Contains(view, "Rebases onto, then fast-forwards main") {
+		t.Errorf("expected the rebase in the preview, got:\n%s", view)
+	}
+
+	m = sendKey(m, keys.Enter)
+	s := m.sessionState().GetIfExists(sess.ID)
+	if s == nil || s.GetMergeType() != manager.MergeTypeRebase {
+		t.Fatal("expected the rebase to start on confirm")
+	}
+	for result := range s.GetMergeChan() {
+		if result.Error != nil {
+			t.Fatalf("rebase failed: %v", result.Error)
+		}
+	}
+	if !hasCall(mock, sess.WorkTree, "rebase", "main") {
+		t.Error("expected the branch rebased onto main in its worktree")
+	}
+	if !hasCall(mock, sess.RepoPath, "merge", "--ff-only", sess.Branch) {
+		t.Error("expected main fast-forwarded to the branch")
+	}
+}
+
+func TestMergeConflict_RebaseAbortsRebase(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	// The rebase's state directory exists until it's aborted
+	rebaseDir := filepath.Join(t.TempDir(), "rebase-merge")
+	if err := os.Mkdir(rebaseDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddExactMatch("git", []string{"rev-parse", "--git-path", "rebase-merge"}, pexec.MockResponse{Stdout: []byte(rebaseDir + "\n")})
+	mock.AddExactMatch("git", []string{"rev-parse", "--git-path", "rebase-apply"}, pexec.MockResponse{Stdout: []byte(filepath.Join(t.TempDir(), "rebase-apply") + "\n")})
+	mock.AddRule(func(dir, name string, args []string) bool {
+		if slices.Equal(args, []string{"rebase", "--abort"}) {
+			os.RemoveAll(rebaseDir)
+			return true
+		}
+		return false
+	}, pexec.MockResponse{})
+	mock.AddExactMatch("git", []string{"rev-parse", "--abbrev-ref", "HEAD"}, pexec.MockResponse{Stdout: []byte("main\n")})
+	m.SetGitService(git.NewGitServiceWithExecutor(mock))
+
+	sess := m.config.GetSession("session-1")
+	m.sessionState().StartMerge(sess.ID, make(chan git.Result), func() {}, manager.MergeTypeRebase)
+	m.Update(MergeResultMsg{SessionID: sess.ID, Result: git.Result{
+		Error:           errors.New("rebase conflict"),
+		Done:            true,
+		ConflictedFiles: []string{"main.go"},
+		RepoPath:        sess.WorkTree,
+		OriginalBranch:  "other",
+	}})
+
+	state, ok := m.modal.State.(*ui.MergeConflictState)
+	if !ok || !state.Rebase || state.Options[1] != "Abort rebase" {
+		t.Fatalf("expected the rebase conflict modal, got %+v", m.modal.State)
+	}
+
+	m = sendKey(m, keys.Down)
+	m = sendKey(m, keys.Enter)
+	s := m.sessionState().GetIfExists(sess.ID)
+	if s == nil || s.GetMergeType() != manager.MergeTypeAbort {
+		t.Fatal("expected the abort to start")
+	}
+	for result := range s.GetMergeChan() {
+		if result.Error != nil {
+			t.Fatalf("abort failed: %v", result.Error)
+		}
+	}
+	if !hasCall(mock, sess.WorkTree, "rebase", "--abort") {
+		t.Error("expected the rebase aborted in the worktree")
+	}
+	if hasCall(mock, sess.WorkTree, "merge", "--abort") {
+		t.Error("expected no merge abort for a rebase")
+	}
+	if !hasCall(mock, sess.RepoPath, "checkout", "other") {
+		t.Error("expected the main repo's original branch checked out again")
+	}
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// missingCheckInterval is how often sessions are checked for a deleted repo or worktree
+const missingCheckInterval = 10 * time.Second
+
+// statPath checks that a path exists. Tests replace it, since their sessions
+// point at placeholder paths.
+var statPath = os.Stat
+
+// missingPath returns the session's repo path or worktree if it no longer
+// exists, or "" if both do. Other stat errors, such as permission errors,
+// don't count: the path may still be there.
+func missingPath(sess *config.Session) string {
+	for _, path := range []string{sess.RepoPath, sess.WorkTree} {
+		if path == "" {
+			continue
+		}
+		if _, err := statPath(path); errors.Is(err, fs.ErrNotExist) {
+			return path
+		}
+	}
+	return ""
+}
+
+// MissingSessionsMsg reports which of the sessions checked have lost their repo
+// or worktree.
+type MissingSessionsMsg struct {
+	Checked []string          // IDs of the sessions checked
+	Missing map[string]string // Session ID to the path that no longer exists
+}
+
+// refreshMissingSessions returns a command that checks in the background
+// whether each session's repo or worktree still exists.
+func (m *Model) refreshMissingSessions() tea.Cmd {
+	sessions := m.config.GetSessions()
+	return func() tea.Msg {
+		msg := MissingSessionsMsg{Missing: make(map[string]string)}
+		for i := range sessions {
+			msg.Checked = append(msg.Checked, sessions[i].ID)
+			if path := missingPath(&sessions[i]); path != "" {
+				msg.Missing[sessions[i].ID] = path
+			}
+		}
+		return msg
+	}
+}
+
+// handleMissingSessionsMsg marks the sessions whose repo or worktree was deleted
+// as missing in the sidebar, and flashes a warning if the active session just
+// went missing.
+func (m *Model) handleMissingSessionsMsg(msg MissingSessionsMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, id := range msg.Checked {
+		// Deleted while it was being checked
+		if m.config.GetSession(id) == nil {
+			continue
+		}
+		path, missing := msg.Missing[id]
+		if missing && !m.sidebar.IsMissing(id) {
+			logger.WithSession(id).Warn("session repo or worktree is missing", "path", path)
+			if m.activeSession != nil && m.activeSession.ID == id {
+				cmd = m.ShowFlashWarning("This session's repo or worktree was deleted")
+			}
+		}
+		m.sidebar.SetMissing(id, missing)
+	}
+	return m, cmd
+}
+
+// openMissingSession marks the session missing and offers to archive or
+// delete it, if its repo or worktree is gone. Returns whether it was missing,
+// in which case it must not be selected.
+func (m *Model) openMissingSession(sess *config.Session) bool {
+	path := missingPath(sess)
+	if path == "" {
+		m.sidebar.SetMissing(sess.ID, false)
+		return false
+	}
+	logger.WithSession(sess.ID).Warn("not selecting session, its repo or worktree is missing", "path", path)
+	m.sidebar.SetMissing(sess.ID, true)
+	m.modal.Show(ui.NewMissingSessionState(sess.ID, ui.SessionDisplayName(sess.Branch, sess.Name), path))
+	return true
+}
+
+// handleMissingSessionModal handles key events for the Missing Session modal.
+func (m *Model) handleMissingSessionModal(key string, msg tea.KeyPressMsg, state *ui.MissingSessionState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		sess := m.config.GetSession(state.SessionID)
+		if sess == nil || (!state.ShouldArchive() && !state.ShouldDelete()) {
+			m.modal.Hide()
+			return m, nil
+		}
+		if state.ShouldDelete() {
+			m.modal.Hide()
+			return m, m.deleteSession(sess, false)
+		}
+		path, err := config.ArchiveSessionMessages(sess.ID, state.SessionName)
+		if err != nil {
+			logger.WithSession(sess.ID).Error("failed to archive transcript", "error", err)
+			m.modal.SetError("Failed to archive transcript: " + err.Error())
+			return m, nil
+		}
+		m.modal.Hide()
+		return m, tea.Batch(m.deleteSession(sess, false), m.ShowFlashSuccess("Transcript archived to "+path))
+	case keys.Up, keys.Down, "j", "k":
+		modal, cmd := m.modal.Update(msg)
+		m.modal = modal
+		return m, cmd
+	}
+	return m, nil
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestMissingSession_RemovedWorktree(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+	statPath = os.Stat
+	t.Cleanup(func() { statPath = func(string) (os.FileInfo, error) { return nil, nil } })
+
+	repo, worktree := t.TempDir(), filepath.Join(t.TempDir(), "worktree")
+	if err := os.Mkdir(worktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := testConfigWithSessions()
+	cfg.Sessions = cfg.Sessions[:1]
+	cfg.Sessions[0].RepoPath, cfg.Sessions[0].WorkTree = repo, worktree
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.selectSession(m.config.GetSession("session-1"))
+	if m.sidebar.IsMissing("session-1") || !m.CanSendMessage() {
+		t.Fatal("expected the session usable while its worktree exists")
+	}
+
+	if err := os.RemoveAll(worktree); err != nil {
+		t.Fatal(err)
+	}
+	if _, cmd := m.Update(m.refreshMissingSessions()()); cmd == nil {
+		t.Error("expected a warning that the active session went missing")
+	}
+	if !m.sidebar.IsMissing("session-1") {
+		t.Fatal("expected the session marked missing")
+	}
+	if m.CanSendMessage() {
+		t.Error("expected sending disabled for a missing session")
+	}
+
+	// Git actions are refused rather than failing on the missing worktree
+	m.focus = FocusSidebar
+	m.sidebar.SetFocused(true)
+	m.chat.SetFocused(false)
+	if _, _, handled := m.ExecuteShortcut("m"); !handled || m.modal.IsVisible() {
+		t.Error("expected merge refused without opening its modal")
+	}
+
+	// Selecting it offers to archive or delete it instead
+	if err := config.SaveSessionMessages("session-1", []config.Message{{Role: "user", Content: "add the feature"}}, 100); err != nil {
+		t.Fatalf("SaveSessionMessages: %v", err)
+	}
+	m.openSession(m.config.GetSession("session-1"))
+	state, ok := m.modal.State.(*ui.MissingSessionState)
+	if !ok {
+		t.Fatalf("expected the missing session modal, got %T", m.modal.State)
+	}
+	if state.MissingPath != worktree {
+		t.Errorf("expected the worktree reported missing, got %q", state.MissingPath)
+	}
+
+	m = sendKey(m, "enter")
+	if m.config.GetSession("session-1") != nil {
+		t.Error("expected the session removed after archiving")
+	}
+	if m.sidebar.IsMissing("session-1") || m.modal.IsVisible() {
+		t.Error("expected the missing mark and modal cleared")
+	}
+	archiveDir, _ := paths.ArchiveDir()
+	data, err := os.ReadFile(filepath.Join(archiveDir, "session-1.txt"))
+	if err != nil {
+		t.Fatalf("expected an archived transcript: %v", err)
+	}
+	if !strings.Contains(string(data), "add the feature") {
+		t.Errorf("expected the conversation in the transcript, got %q", data)
+	}
+}
@@ -27,10 +27,46 @@ func (m *Model) handleModalKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m.handleConfirmDeleteModal(key, msg, s)
 	case *ui.ConfirmDeleteRepoState:
 		return m.handleConfirmDeleteRepoModal(key, msg, s)
+	case *ui.MissingSessionState:
+		return m.handleMissingSessionModal(key, msg, s)
+	case *ui.CLIVersionChangedState:
+		return m.handleCLIVersionChangedModal(key, msg, s)
+	case *ui.RepairSessionNamesState:
+		return m.handleRepairSessionNamesModal(key, msg, s)
+	case *ui.ModelPickerState:
+		return m.handleModelPickerModal(key, msg, s)
+	case *ui.EditTagsState:
+		return m.handleEditTagsModal(key, msg, s)
+	case *ui.TagFilterState:
+		return m.handleTagFilterModal(key, msg, s)
 	case *ui.ConfirmExitState:
 		return m.handleConfirmExitModal(key, msg, s)
 	case *ui.PreviewActiveState:
 		return m.handlePreviewActiveModal(key, msg, s)
+	case *ui.PasteCleanState:
+		return m.handlePasteCleanModal(key, msg, s)
+	case *ui.ImageTooLargeState:
+		return m.handleImageTooLargeModal(key, msg, s)
+	case *ui.ContextFilesState:
+		return m.handleContextFilesModal(key, msg, s)
+	case *ui.SnippetPickerState:
+		return m.handleSnippetPickerModal(key, msg, s)
+	case *ui.FilePickerState:
+		return m.handleFilePickerModal(key, msg, s)
+	case *ui.SnippetFileState:
+		return m.handleSnippetFileModal(key, msg, s)
+	case *ui.SnippetsState:
+		return m.handleSnippetsModal(key, msg, s)
+	case *ui.TemplatePickerState:
+		return m.handleTemplatePickerModal(key, msg, s)
+	case *ui.TemplateVariablesState:
+		return m.handleTemplateVariablesModal(key, msg, s)
+	case *ui.FileOverlapState:
+		return m.handleFileOverlapModal(key, msg, s)
+	case *ui.TouchedFilesState:
+		return m.handleTouchedFilesModal(key, msg, s)
+	case *ui.PRProgressState:
+		return m.handlePRProgressModal(key, msg, s)
 	case *ui.ForkSessionState:
 		return m.handleForkSessionModal(key, msg, s)
 	case *ui.RenameSessionState:
@@ -53,6 +89,8 @@ func (m *Model) handleModalKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m.handleEditCommitModal(key, msg, s)
 	case *ui.MergeConflictState:
 		return m.handleMergeConflictModal(key, msg, s)
+	case *ui.SnapshotPickerState:
+		return m.handleSnapshotPickerModal(key, msg, s)
 	case *ui.ReviewCommentsState:
 		return m.handleReviewCommentsModal(key, msg, s)
 
@@ -76,10 +114,14 @@ func (m *Model) handleModalKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m.handleWelcomeModal(key, msg, s)
 	case *ui.ChangelogState:
 		return m.handleChangelogModal(key, msg, s)
+	case *ui.ReleaseNotesState:
+		return m.handleReleaseNotesModal(key, msg)
 	case *ui.HelpState:
 		return m.handleHelpModal(key, msg, s)
 	case *ui.SearchMessagesState:
 		return m.handleSearchMessagesModal(key, msg, s)
+	case *ui.SessionSwitcherState:
+		return m.handleSessionSwitcherModal(key, msg, s)
 
 	// Issue/task modals (modal_handlers_issues.go)
 	case *ui.ExploreOptionsState:
@@ -4,7 +4,6 @@ import (
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
-	"github.com/zhubert/plural/internal/clipboard"
 	"github.com/zhubert/plural/internal/config"
 	"github.com/zhubert/plural/internal/keys"
 	"github.com/zhubert/plural/internal/logger"
@@ -210,6 +209,9 @@ func (m *Model) handleSettingsModal(key string, msg tea.KeyPressMsg, state *ui.S
 		m.config.SetDefaultBranchPrefix(state.GetBranchPrefix())
 		m.config.SetNotificationsEnabled(state.GetNotificationsEnabled())
 		m.config.SetAutoCleanupMerged(state.AutoCleanupMerged)
+		m.config.SetCompactToolUses(state.CompactToolUses)
+		m.chat.SetCompactToolUses(state.CompactToolUses)
+		m.config.SetFocusInputOnNewSession(state.FocusInputOnNew)
 		// Apply theme if changed
 		if state.ThemeChanged() {
 			selectedTheme := ui.GetSelectedSettingsTheme(state)
@@ -217,6 +219,11 @@ func (m *Model) handleSettingsModal(key string, msg tea.KeyPressMsg, state *ui.S
 			m.config.SetTheme(string(selectedTheme))
 			m.chat.RefreshStyles()
 		}
+		if state.SyntaxStyleChanged() {
+			ui.SetSyntaxStyle(state.GetSelectedSyntaxStyle())
+			m.config.SetSyntaxStyle(state.GetSelectedSyntaxStyle())
+			m.chat.RefreshStyles()
+		}
 		if err := m.config.Save(); err != nil {
 			logger.Get().Error("failed to save settings", "error", err)
 			m.modal.SetError("Failed to save: " + err.Error())
@@ -244,12 +251,8 @@ func (m *Model) handleCopyCommandModal(key string, getCmd func() string, setCopi
 		m.modal.Hide()
 		return m, nil
 	case keys.Enter:
-		if err := clipboard.WriteText(getCmd()); err != nil {
-			logger.Get().Error("failed to copy to clipboard", "error", err)
-			return m, m.ShowFlashError("Failed to copy to clipboard")
-		}
 		setCopied()
-		return m, nil
+		return m, ui.CopyToClipboard(getCmd(), "Copied to clipboard")
 	}
 	return m, nil
 }
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"github.com/zhubert/plural/internal/claude"
 	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/keys"
 	"github.com/zhubert/plural/internal/logger"
 	"github.com/zhubert/plural/internal/manager"
@@ -16,10 +18,21 @@ import (
 
 // handleMergeModal handles key events for the Merge/PR modal.
 func (m *Model) handleMergeModal(key string, msg tea.KeyPressMsg, state *ui.MergeState) (tea.Model, tea.Cmd) {
+	if state.Preview != nil {
+		return m.handleMergePreview(key, msg, state)
+	}
 	switch key {
 	case keys.Escape:
 		m.modal.Hide()
 		return m, nil
+	case "u":
+		if sess := m.sidebar.SelectedSession(); sess != nil && state.CanMigrateBaseBranch() {
+			return m, m.migrateSessionsBaseBranch(sess.RepoPath, state)
+		}
+	case "c":
+		if sess := m.sidebar.SelectedSession(); sess != nil && !state.BaseBranchFocused {
+			return m, m.copyMergeCommands(sess, state)
+		}
 	case keys.Enter:
 		option := state.GetSelectedOption()
 		sess := m.sidebar.SelectedSession()
@@ -37,7 +50,18 @@ func (m *Model) handleMergeModal(key string, msg tea.KeyPressMsg, state *ui.Merg
 			log.Debug("commit message generation already pending")
 			return m, nil
 		}
+		if state.MainRepoChanges != "" && (option == "Merge to main" || option == "Rebase onto main") && !state.AutoStash {
+			m.modal.SetError("Commit or stash the main repo's changes first, or enable auto-stash (s)")
+			return m, nil
+		}
+		baseBranch := state.GetPRBaseBranch()
+		if err := m.checkSomethingToMerge(sess, option, baseBranch, state.DefaultBranch); err != nil {
+			log.Info("nothing to merge", "option", option, "reason", err)
+			m.modal.SetError(err.Error())
+			return m, nil
+		}
 		log.Debug("starting merge operation", "option", option, "branch", sess.Branch, "worktree", sess.WorkTree)
+		autoStash := state.NeedsAutoStash()
 		m.modal.Hide()
 		if m.activeSession == nil || m.activeSession.ID != sess.ID {
 			m.selectSession(sess)
@@ -60,6 +84,8 @@ func (m *Model) handleMergeModal(key string, msg tea.KeyPressMsg, state *ui.Merg
 			mergeType = manager.MergeTypePR
 		case "Push updates to PR":
 			mergeType = manager.MergeTypePush
+		case "Rebase onto main":
+			mergeType = manager.MergeTypeRebase
 		default:
 			mergeType = manager.MergeTypeMerge
 		}
@@ -87,6 +113,9 @@ func (m *Model) handleMergeModal(key string, msg tea.KeyPressMsg, state *ui.Merg
 				SessionID:       sess.ID,
 				Type:            mergeType,
 				ParentSessionID: "",
+				BaseBranch:      baseBranch,
+				AutoStash:       autoStash,
+				MergeState:      state,
 			}
 			if parentSess != nil {
 				m.pendingCommit.ParentSessionID = parentSess.ID
@@ -98,32 +127,27 @@ func (m *Model) handleMergeModal(key string, msg tea.KeyPressMsg, state *ui.Merg
 		// Finish any existing streaming before starting merge operation
 		m.chat.FinishStreaming()
 		mergeCtx, cancel := context.WithCancel(context.Background())
+		var prCmd tea.Cmd
 		switch mergeType {
 		case manager.MergeTypePR:
-			log.Info("creating PR (no uncommitted changes)")
-			m.chat.AppendStreaming("Creating PR for " + sess.Branch + "...\n\n")
-			m.sessionState().StartMerge(sess.ID, m.gitService.CreatePR(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, sess.BaseBranch, "", sess.GetIssueRef(), sess.ID), cancel, manager.MergeTypePR)
+			log.Info("creating PR (no uncommitted changes)", "baseBranch", baseBranch)
+			m.recordHookChoice(sess.ID, state)
+			prCmd = m.startPR(mergeCtx, cancel, sess, baseBranch, "")
 		case manager.MergeTypePush:
 			log.Info("pushing updates (no uncommitted changes)")
 			m.chat.AppendStreaming("Pushing updates to " + sess.Branch + "...\n\n")
 			m.sessionState().StartMerge(sess.ID, m.gitService.PushUpdates(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, ""), cancel, manager.MergeTypePush)
 		case manager.MergeTypeParent:
 			log.Info("merging to parent (no uncommitted changes)", "parentBranch", parentSess.Branch)
+			m.recordArchiveChoice(sess.ID, state)
 			m.chat.AppendStreaming("Merging " + sess.Branch + " to parent " + parentSess.Branch + "...\n\n")
 			m.sessionState().StartMerge(sess.ID, m.gitService.MergeToParent(mergeCtx, sess.WorkTree, sess.Branch, parentSess.WorkTree, parentSess.Branch, ""), cancel, manager.MergeTypeParent)
 		default:
-			// Check if squash-on-merge is enabled for this repo
-			if m.config.GetSquashOnMerge(sess.RepoPath) {
-				log.Info("squash merging to main (no uncommitted changes)")
-				m.chat.AppendStreaming("Squash merging " + sess.Branch + " to main...\n\n")
-				m.sessionState().StartMerge(sess.ID, m.gitService.SquashMergeToMain(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, ""), cancel, manager.MergeTypeMerge)
-			} else {
-				log.Info("merging to main (no uncommitted changes)")
-				m.chat.AppendStreaming("Merging " + sess.Branch + " to main...\n\n")
-				m.sessionState().StartMerge(sess.ID, m.gitService.MergeToMain(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, ""), cancel, manager.MergeTypeMerge)
-			}
+			// Merges to main are confirmed on the preview first, which starts its own merge
+			cancel()
+			return m, m.showMergePreview(sess, state, "", autoStash)
 		}
-		return m, m.listenForMergeResult(sess.ID)
+		return m, tea.Batch(m.listenForMergeResult(sess.ID), prCmd)
 	}
 	// Forward other keys to the modal for navigation handling
 	modal, cmd := m.modal.Update(msg)
@@ -131,6 +155,180 @@ func (m *Model) handleMergeModal(key string, msg tea.KeyPressMsg, state *ui.Merg
 	return m, cmd
 }
 
+// checkSomethingToMerge returns why merging or rebasing sess to main or opening
+// a PR for it would bring in nothing, as for a session with no commits or
+// changes of its own, or whose repo has no commits yet. Returns nil for other
+// merge options, when the worktree has changes to commit first, or when that
+// can't be told. defaultBranch is the default branch as re-resolved from
+// origin, or "" to use the locally known one.
+func (m *Model) checkSomethingToMerge(sess *config.Session, option, baseBranch, defaultBranch string) error {
+	if option != "Merge to main" && option != "Rebase onto main" && option != "Create PR" {
+		return nil
+	}
+	ctx := context.Background()
+	if status, err := m.gitService.GetWorktreeStatus(ctx, sess.WorkTree); err != nil || status.HasChanges {
+		return nil
+	}
+	target := baseBranch
+	if option != "Create PR" || target == "" {
+		target = cmp.Or(defaultBranch, m.gitService.GetDefaultBranch(ctx, sess.RepoPath))
+	}
+	return m.gitService.CheckSomethingToMerge(ctx, sess.RepoPath, sess.Branch, target)
+}
+
+// copyMergeCommands copies the git commands the merge option selected in the
+// modal would run, for running it by hand or checking what it does.
+func (m *Model) copyMergeCommands(sess *config.Session, state *ui.MergeState) tea.Cmd {
+	option := state.GetSelectedOption()
+	plan := git.MergePlan{
+		RepoPath:     sess.RepoPath,
+		WorktreePath: sess.WorkTree,
+		Branch:       sess.Branch,
+		AutoStash:     state.NeedsAutoStash(),
+		BaseBranch:    state.GetPRBaseBranch(),
+		DefaultBranch: state.DefaultBranch,
+	}
+	switch option {
+	case "Merge to parent":
+		plan.Kind = git.MergeKindParent
+		parent := m.config.GetSession(sess.ParentID)
+		if parent == nil {
+			m.modal.SetError("Parent session not found")
+			return nil
+		}
+		plan.ParentWorktreePath = parent.WorkTree
+	case "Create PR":
+		plan.Kind = git.MergeKindPR
+	case "Push updates to PR":
+		plan.Kind = git.MergeKindPush
+	case "Rebase onto main":
+		plan.Kind = git.MergeKindRebase
+	default:
+		plan.Kind = git.MergeKindMerge
+		if m.config.GetSquashOnMerge(sess.RepoPath) {
+			plan.Kind = git.MergeKindSquash
+		}
+	}
+	if err := m.gitService.FillMergePlan(context.Background(), &plan); err != nil {
+		logger.WithSession(sess.ID).Warn("failed to plan merge commands", "error", err)
+		m.modal.SetError("Could not check the worktree: " + err.Error())
+		return nil
+	}
+
+	state.CommandsCopied = option
+	return ui.CopyToClipboard(git.FormatCommands(plan.Commands()), "Copied merge commands")
+}
+
+// showMergePreview shows what merging sess to main with commitMsg, or rebasing it
+// if that is the option selected, will do on the merge modal's confirmation
+// screen. It gathers the same facts the merge acts on (see git.FillMergePlan)
+// without changing anything; Enter there starts the merge.
+func (m *Model) showMergePreview(sess *config.Session, state *ui.MergeState, commitMsg string, autoStash bool) tea.Cmd {
+	plan := git.MergePlan{
+		Kind:         git.MergeKindMerge,
+		RepoPath:     sess.RepoPath,
+		WorktreePath: sess.WorkTree,
+		Branch:       sess.Branch,
+		CommitMsg:    commitMsg,
+		AutoStash:    autoStash,
+	}
+	if state.GetSelectedOption() == "Rebase onto main" {
+		plan.Kind = git.MergeKindRebase
+	} else if m.config.GetSquashOnMerge(sess.RepoPath) {
+		plan.Kind = git.MergeKindSquash
+	}
+	if err := m.gitService.FillMergePlan(context.Background(), &plan); err != nil {
+		logger.WithSession(sess.ID).Warn("failed to preview merge", "error", err)
+		m.chat.AppendStreaming(fmt.Sprintf("Error checking worktree status: %v\n", err))
+		return nil
+	}
+
+	preview := ui.MergePreview{
+		Files:         plan.Files,
+		CommitMessage: plan.CommitMsg,
+		TargetBranch:  plan.DefaultBranch,
+		Squash:        plan.Kind == git.MergeKindSquash,
+		Rebase:        plan.Kind == git.MergeKindRebase,
+		HasRemote:     plan.HasRemote,
+		AutoStash:     plan.AutoStash,
+		Push:          plan.Pushes(),
+	}
+	if plan.Divergence != nil {
+		preview.Compared = true
+		preview.Ahead, preview.Behind = plan.Divergence.Ahead, plan.Divergence.Behind
+	}
+	state.ShowPreview(preview)
+	m.modal.Show(state)
+	return nil
+}
+
+// handleMergePreview handles key events on the merge modal's confirmation screen.
+func (m *Model) handleMergePreview(key string, msg tea.KeyPressMsg, state *ui.MergeState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		state.HidePreview()
+		return m, nil
+	case keys.Enter:
+		preview := state.Preview
+		sess := m.sidebar.SelectedSession()
+		if sess == nil {
+			return m, nil
+		}
+		if state := m.sessionState().GetIfExists(sess.ID); state != nil && state.IsMerging() {
+			logger.WithSession(sess.ID).Debug("merge already in progress")
+			return m, nil
+		}
+		m.modal.Hide()
+		logger.WithSession(sess.ID).Info("merging to main after preview", "files", len(preview.Files), "runHooks", state.RunHooks)
+		mergeCtx, cancel := context.WithCancel(context.Background())
+		m.recordHookChoice(sess.ID, state)
+		m.recordArchiveChoice(sess.ID, state)
+		m.startMergeToMain(mergeCtx, cancel, sess, preview.CommitMessage, preview.AutoStash, preview.Rebase)
+		return m, m.listenForMergeResult(sess.ID)
+	}
+	// Forward other keys, such as toggling the hooks, to the modal
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// startMergeToMain merges sess into its repo's default branch, squashing if the repo
+// has squash-on-merge enabled, or with rebase, rebases it onto the default branch
+// and fast-forwards that. With autoStash, uncommitted changes in the main repo
+// are stashed before the checkout and re-applied after the merge.
+func (m *Model) startMergeToMain(mergeCtx context.Context, cancel context.CancelFunc, sess *config.Session, commitMsg string, autoStash, rebase bool) {
+	squash := !rebase && m.config.GetSquashOnMerge(sess.RepoPath)
+	gitService := m.gitServiceFor(sess)
+	run := func() <-chan git.Result {
+		switch {
+		case rebase:
+			return gitService.RebaseOntoMain(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, commitMsg)
+		case squash:
+			return gitService.SquashMergeToMain(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, commitMsg)
+		}
+		return gitService.MergeToMain(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, commitMsg)
+	}
+
+	mergeType := manager.MergeTypeMerge
+	switch {
+	case rebase:
+		mergeType = manager.MergeTypeRebase
+		m.chat.AppendStreaming("Rebasing " + sess.Branch + " onto main...\n\n")
+	case squash:
+		m.chat.AppendStreaming("Squash merging " + sess.Branch + " to main...\n\n")
+	default:
+		m.chat.AppendStreaming("Merging " + sess.Branch + " to main...\n\n")
+	}
+	var ch <-chan git.Result
+	if autoStash {
+		ch = m.gitService.WithAutoStash(mergeCtx, sess.RepoPath, git.StashLabel("merge", sess.Branch), run)
+	} else {
+		ch = run()
+	}
+	logger.WithSession(sess.ID).Debug("started merge to main", "squash", squash, "rebase", rebase, "autoStash", autoStash)
+	m.sessionState().StartMerge(sess.ID, ch, cancel, mergeType)
+}
+
 // handleLoadingCommitModal handles key events for the Loading Commit modal.
 func (m *Model) handleLoadingCommitModal(key string, _ tea.KeyPressMsg, _ *ui.LoadingCommitState) (tea.Model, tea.Cmd) {
 	switch key {
@@ -184,18 +382,27 @@ func (m *Model) handleEditCommitModal(key string, msg tea.KeyPressMsg, state *ui
 
 		mergeType := m.pendingCommit.Type
 		parentSessionID := m.pendingCommit.ParentSessionID
+		baseBranch := m.pendingCommit.BaseBranch
+		autoStash := m.pendingCommit.AutoStash
+		mergeState := m.pendingCommit.MergeState
 		m.pendingCommit = nil
 
+		if (mergeType == manager.MergeTypeMerge || mergeType == manager.MergeTypeRebase) && mergeState != nil {
+			// Merges to main are confirmed on the preview first
+			return m, m.showMergePreview(sess, mergeState, commitMsg, autoStash)
+		}
+
 		// Proceed with merge/PR/push using the edited commit message
 		// Finish any existing streaming before starting merge operation
 		m.chat.FinishStreaming()
 		log := logger.WithSession(sess.ID)
 		mergeCtx, cancel := context.WithCancel(context.Background())
+		var prCmd tea.Cmd
 		switch mergeType {
 		case manager.MergeTypePR:
-			log.Info("creating PR with user-edited commit message")
-			m.chat.AppendStreaming("Creating PR for " + sess.Branch + "...\n\n")
-			m.sessionState().StartMerge(sess.ID, m.gitService.CreatePR(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, sess.BaseBranch, commitMsg, sess.GetIssueRef(), sess.ID), cancel, manager.MergeTypePR)
+			log.Info("creating PR with user-edited commit message", "baseBranch", baseBranch)
+			m.recordHookChoice(sess.ID, mergeState)
+			prCmd = m.startPR(mergeCtx, cancel, sess, baseBranch, commitMsg)
 		case manager.MergeTypePush:
 			log.Info("pushing updates with user-edited commit message")
 			m.chat.AppendStreaming("Pushing updates to " + sess.Branch + "...\n\n")
@@ -208,21 +415,14 @@ func (m *Model) handleEditCommitModal(key string, msg tea.KeyPressMsg, state *ui
 				return m, nil
 			}
 			log.Info("merging to parent with user-edited commit message", "parentBranch", parentSess.Branch)
+			m.recordArchiveChoice(sess.ID, mergeState)
 			m.chat.AppendStreaming("Merging " + sess.Branch + " to parent " + parentSess.Branch + "...\n\n")
 			m.sessionState().StartMerge(sess.ID, m.gitService.MergeToParent(mergeCtx, sess.WorkTree, sess.Branch, parentSess.WorkTree, parentSess.Branch, commitMsg), cancel, manager.MergeTypeParent)
 		default:
-			// Check if squash-on-merge is enabled for this repo
-			if m.config.GetSquashOnMerge(sess.RepoPath) {
-				log.Info("squash merging to main with user-edited commit message")
-				m.chat.AppendStreaming("Squash merging " + sess.Branch + " to main...\n\n")
-				m.sessionState().StartMerge(sess.ID, m.gitService.SquashMergeToMain(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, commitMsg), cancel, manager.MergeTypeMerge)
-			} else {
-				log.Info("merging to main with user-edited commit message")
-				m.chat.AppendStreaming("Merging " + sess.Branch + " to main...\n\n")
-				m.sessionState().StartMerge(sess.ID, m.gitService.MergeToMain(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, commitMsg), cancel, manager.MergeTypeMerge)
-			}
+			log.Info("merging to main with user-edited commit message")
+			m.startMergeToMain(mergeCtx, cancel, sess, commitMsg, autoStash, mergeType == manager.MergeTypeRebase)
 		}
-		return m, m.listenForMergeResult(sess.ID)
+		return m, tea.Batch(m.listenForMergeResult(sess.ID), prCmd)
 	}
 	// Forward other keys to the modal for textarea handling
 	modal, cmd := m.modal.Update(msg)
@@ -277,7 +477,10 @@ func (m *Model) handleMergeConflictModal(key string, msg tea.KeyPressMsg, state
 		switch option {
 		case 0: // "Have Claude resolve"
 			return m.handleClaudeResolveConflict(state)
-		case 1: // "Abort merge"
+		case 1: // "Abort merge" or "Abort rebase"
+			if state.Rebase {
+				return m.handleAbortRebase(state)
+			}
 			return m.handleAbortMerge(state)
 		case 2: // "Resolve manually"
 			return m.handleManualResolve(state)
@@ -320,54 +523,84 @@ Please resolve these merge conflicts by:
 4. Removing the conflict markers
 5. Stage the resolved files with git add
 6. Commit the merge with a descriptive commit message explaining the resolution`, filesList.String())
+	if state.Rebase {
+		prompt = fmt.Sprintf(`Rebasing onto main stopped on conflicts in these files:
+%s
+Please resolve these conflicts by:
+1. Reading each conflicted file
+2. Understanding both versions (between <<<<<<< and >>>>>>> markers)
+3. Editing the file to combine the changes appropriately
+4. Removing the conflict markers
+5. Stage the resolved files with git add
+6. Continue the rebase with GIT_EDITOR=true git rebase --continue, resolving any further conflicts the same way`, filesList.String())
+	}
+
+	// Include the conflicts themselves, so Claude sees them without reading every file first
+	diff, err := m.gitService.GetConflictDiff(context.Background(), state.RepoPath)
+	if err != nil {
+		logger.WithSession(sess.ID).Warn("failed to get conflict diff", "error", err)
+	} else if diff = strings.TrimSpace(diff); diff != "" {
+		if len(diff) > git.MaxDiffSize {
+			diff = diff[:git.MaxDiffSize] + "\n... (diff truncated)"
+		}
+		prompt += "\n\nThe conflicts:\n```diff\n" + diff + "\n```"
+	}
 
 	logger.WithSession(sess.ID).Debug("sending conflict resolution prompt to Claude")
-	m.chat.AddUserMessage(prompt)
 
-	// Store conflict info for later commit
-	m.pendingConflict = &PendingConflict{
-		SessionID: state.SessionID,
-		RepoPath:  state.RepoPath,
+	// Store conflict info for later commit; a rebase commits as it continues
+	if !state.Rebase {
+		m.pendingConflict = &PendingConflict{
+			SessionID: state.SessionID,
+			RepoPath:  state.RepoPath,
+		}
 	}
+	return m.sendPromptToSession(sess, prompt)
+}
 
-	// Get runner
-	runner := m.sessionMgr.GetRunner(sess.ID)
-	if runner == nil {
-		m.chat.AppendStreaming("[Error: Could not get Claude runner]\n")
+// handleAbortMerge aborts the merge stopped on conflicts, streaming its output
+// to the session's chat, and checks out the branch the repo was on before it.
+func (m *Model) handleAbortMerge(state *ui.MergeConflictState) (tea.Model, tea.Cmd) {
+	sess := m.config.GetSession(state.SessionID)
+	if sess == nil {
+		m.chat.AppendStreaming("[Error: Session not found]\n")
 		return m, nil
 	}
 
-	m.claudeRunner = runner
-
-	// Create context for this request
-	ctx, cancel := context.WithCancel(context.Background())
-	m.sessionState().StartWaiting(sess.ID, cancel)
-	startTime, _ := m.sessionState().GetWaitStart(sess.ID)
-	m.chat.SetWaitingWithStart(true, startTime)
-	m.sidebar.SetStreaming(sess.ID, true)
-	m.setState(StateStreamingClaude)
-
-	// Send to Claude
-	content := []claude.ContentBlock{{Type: claude.ContentTypeText, Text: prompt}}
-	responseChan := runner.SendContent(ctx, content)
+	// Make sure this session is active
+	if m.activeSession == nil || m.activeSession.ID != sess.ID {
+		m.selectSession(sess)
+	}
 
-	cmds := append(m.sessionListeners(sess.ID, runner, responseChan),
-		m.sidebar.SidebarTick(),
-		m.chat.SpinnerTick(),
-	)
-	return m, tea.Batch(cmds...)
+	m.chat.FinishStreaming()
+	m.chat.AppendStreaming("Aborting the merge in " + state.RepoPath + "...\n\n")
+	mergeCtx, cancel := context.WithCancel(context.Background())
+	logger.WithSession(sess.ID).Info("aborting conflicted merge", "repoPath", state.RepoPath, "originalBranch", state.OriginalBranch)
+	m.sessionState().StartMerge(sess.ID, m.gitService.AbortConflictedMerge(mergeCtx, state.RepoPath, state.OriginalBranch), cancel, manager.MergeTypeAbort)
+	return m, m.listenForMergeResult(sess.ID)
 }
 
-// handleAbortMerge aborts the in-progress merge.
-func (m *Model) handleAbortMerge(state *ui.MergeConflictState) (tea.Model, tea.Cmd) {
-	ctx := context.Background()
-	err := m.gitService.AbortMerge(ctx, state.RepoPath)
-	if err != nil {
-		m.chat.AppendStreaming(fmt.Sprintf("[Error aborting merge: %v]\n", err))
-	} else {
-		m.chat.AppendStreaming("Merge aborted successfully.\n")
+// handleAbortRebase aborts the rebase stopped on conflicts in the session's
+// worktree, streaming its output to the session's chat, and checks out the
+// branch the main repo was on before it.
+func (m *Model) handleAbortRebase(state *ui.MergeConflictState) (tea.Model, tea.Cmd) {
+	sess := m.config.GetSession(state.SessionID)
+	if sess == nil {
+		m.chat.AppendStreaming("[Error: Session not found]\n")
+		return m, nil
 	}
-	return m, nil
+
+	// Make sure this session is active
+	if m.activeSession == nil || m.activeSession.ID != sess.ID {
+		m.selectSession(sess)
+	}
+
+	m.chat.FinishStreaming()
+	m.chat.AppendStreaming("Aborting the rebase in " + state.RepoPath + "...\n\n")
+	mergeCtx, cancel := context.WithCancel(context.Background())
+	logger.WithSession(sess.ID).Info("aborting conflicted rebase", "worktree", state.RepoPath, "originalBranch", state.OriginalBranch)
+	m.sessionState().StartMerge(sess.ID, m.gitService.AbortRebase(mergeCtx, state.RepoPath, sess.RepoPath, state.OriginalBranch), cancel, manager.MergeTypeAbort)
+	return m, m.listenForMergeResult(sess.ID)
 }
 
 // handleManualResolve shows info for manual conflict resolution.
@@ -381,8 +614,14 @@ func (m *Model) handleManualResolve(state *ui.MergeConflictState) (tea.Model, te
 	}
 	msg.WriteString("\nAfter resolving:\n")
 	msg.WriteString("  git add <files>\n")
-	msg.WriteString("  git commit\n\n")
-	msg.WriteString("Or abort with: git merge --abort\n")
+	if state.Rebase {
+		msg.WriteString("  git rebase --continue\n\n")
+		msg.WriteString("Then rebase onto main again to fast-forward it.\n")
+		msg.WriteString("Or abort with: git rebase --abort\n")
+	} else {
+		msg.WriteString("  git commit\n\n")
+		msg.WriteString("Or abort with: git merge --abort\n")
+	}
 
 	m.chat.AppendStreaming(msg.String())
 	return m, nil
@@ -422,11 +661,6 @@ func (m *Model) sendReviewCommentsToSession(sessionID string, comments []ui.Revi
 		return m, m.ShowFlashError("Session not found")
 	}
 
-	// Make sure this session is active
-	if m.activeSession == nil || m.activeSession.ID != sess.ID {
-		m.selectSession(sess)
-	}
-
 	// Build the prompt from selected comments
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("The following PR review comments need to be addressed (%d comment(s)):\n\n", len(comments)))
@@ -450,10 +684,16 @@ func (m *Model) sendReviewCommentsToSession(sessionID string, comments []ui.Revi
 
 	sb.WriteString("Please address each of these review comments. For code changes, make the necessary edits. For questions, provide a response and make any relevant code changes.")
 
-	prompt := sb.String()
-
 	logger.WithSession(sess.ID).Debug("sending review comments to Claude", "commentCount", len(comments))
-	m.chat.AddUserMessage(prompt)
+	return m.sendPromptToSession(sess, sb.String())
+}
+
+// sendPromptToSession sends a prompt Plural composed to the session's Claude,
+// making the session active first.
+func (m *Model) sendPromptToSession(sess *config.Session, prompt string) (tea.Model, tea.Cmd) {
+	if m.activeSession == nil || m.activeSession.ID != sess.ID {
+		m.selectSession(sess)
+	}
 
 	// Get runner
 	runner := m.sessionMgr.GetRunner(sess.ID)
@@ -464,20 +704,19 @@ func (m *Model) sendReviewCommentsToSession(sessionID string, comments []ui.Revi
 
 	m.claudeRunner = runner
 
-	// Create context for this request
-	ctx, cancel := context.WithCancel(context.Background())
-	m.sessionState().StartWaiting(sess.ID, cancel)
+	// Send to Claude, unless paused, which holds the prompt until resumed
+	content := []claude.ContentBlock{{Type: claude.ContentTypeText, Text: prompt}}
+	listenCmds, started := m.startTurn(sess.ID, runner, content)
+	if !started {
+		return m, m.ShowFlashWarning(pausedQueuedHint)
+	}
+	m.chat.AddUserMessage(prompt)
 	startTime, _ := m.sessionState().GetWaitStart(sess.ID)
 	m.chat.SetWaitingWithStart(true, startTime)
-	m.sidebar.SetStreaming(sess.ID, true)
 	m.sidebar.SetIdleWithResponse(sess.ID, false)
 	m.setState(StateStreamingClaude)
 
-	// Send to Claude
-	content := []claude.ContentBlock{{Type: claude.ContentTypeText, Text: prompt}}
-	responseChan := runner.SendContent(ctx, content)
-
-	cmds := append(m.sessionListeners(sess.ID, runner, responseChan),
+	cmds := append(listenCmds,
 		m.sidebar.SidebarTick(),
 		m.chat.SpinnerTick(),
 	)
@@ -523,3 +762,26 @@ func (m *Model) checkConflictResolution(sessionID string) tea.Cmd {
 	m.pendingConflict = nil
 	return flashCmd
 }
+
+// handleSnapshotPickerModal handles key events for the Compare Snapshots modal.
+func (m *Model) handleSnapshotPickerModal(key string, msg tea.KeyPressMsg, state *ui.SnapshotPickerState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		if !state.Choose() {
+			return m, nil
+		}
+		m.modal.Hide()
+		sess := m.sidebar.SelectedSession()
+		if sess == nil {
+			return m, nil
+		}
+		from, to := state.GetRange()
+		return m, diffSnapshots(m.gitService, sess.ID, sess.WorkTree, from, to)
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
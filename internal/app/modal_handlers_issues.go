@@ -12,6 +12,7 @@ import (
 	"github.com/zhubert/plural/internal/issues"
 	"github.com/zhubert/plural/internal/keys"
 	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/manager"
 	"github.com/zhubert/plural/internal/process"
 	"github.com/zhubert/plural/internal/session"
 	"github.com/zhubert/plural/internal/ui"
@@ -51,6 +52,9 @@ func (m *Model) handleSelectRepoForIssuesModal(key string, msg tea.KeyPressMsg,
 			return m, nil
 		}
 		return m.showIssueSourceOrFetch(repoPath)
+	case "b":
+		m.showNewSessionForm(state.GetSelectedRepo())
+		return m, nil
 	case keys.Up, "k", keys.Down, "j":
 		// Forward navigation keys to modal
 		modal, cmd := m.modal.Update(msg)
@@ -88,6 +92,9 @@ func (m *Model) handleSelectIssueSourceModal(key string, msg tea.KeyPressMsg, st
 		// Default: GitHub
 		m.modal.Show(ui.NewImportIssuesState(state.RepoPath, repoName, process.ContainersSupported(), claude.ContainerAuthAvailable()))
 		return m, m.fetchIssues(state.RepoPath, "github", "")
+	case "b":
+		m.showNewSessionForm(state.RepoPath)
+		return m, nil
 	case keys.Up, "k", keys.Down, "j":
 		// Forward navigation keys to modal
 		modal, cmd := m.modal.Update(msg)
@@ -162,6 +169,9 @@ func (m *Model) handleImportIssuesModal(key string, msg tea.KeyPressMsg, state *
 		}
 		m.modal.Hide()
 		return m.createSessionsFromIssues(state.RepoPath, selected, state.GetUseContainers())
+	case "b":
+		m.showNewSessionForm(state.RepoPath)
+		return m, nil
 	case keys.Up, "k", keys.Down, "j", keys.Space, keys.Tab:
 		// Forward navigation and space (toggle) keys to modal
 		modal, cmd := m.modal.Update(msg)
@@ -192,11 +202,11 @@ func (m *Model) createSessionsFromIssues(repoPath string, selectedIssues []ui.Is
 		if m.issueRegistry != nil {
 			provider := m.issueRegistry.GetProvider(issues.Source(issue.Source))
 			if provider != nil {
-				branchName = provider.GenerateBranchName(issues.Issue{
+				branchName = session.SanitizeBranchName(provider.GenerateBranchName(issues.Issue{
 					ID:     issue.ID,
 					Title:  issue.Title,
 					Source: issues.Source(issue.Source),
-				})
+				}))
 			}
 		}
 		// Fallback branch name
@@ -300,19 +310,12 @@ func (m *Model) createSessionsFromIssues(repoPath string, selectedIssues []ui.Is
 
 			runner := result.Runner
 
-			// Start streaming for this session
-			ctx, cancel := context.WithCancel(context.Background())
-			m.sessionState().StartWaiting(sess.ID, cancel)
-			m.sidebar.SetStreaming(sess.ID, true)
-
 			logger.WithSession(sess.ID).Debug("auto-starting issue session", "issue", sess.GetIssueRef())
 
-			// Send the initial message to Claude
+			// Send the initial message to Claude, held while paused
 			content := []claude.ContentBlock{{Type: claude.ContentTypeText, Text: initialMsg}}
-			responseChan := runner.SendContent(ctx, content)
-
-			// Add listeners for this session
-			cmds = append(cmds, m.sessionListeners(sess.ID, runner, responseChan)...)
+			listenCmds, _ := m.startTurn(sess.ID, runner, content)
+			cmds = append(cmds, listenCmds...)
 		}
 
 		// Switch to the first session's UI
@@ -321,14 +324,17 @@ func (m *Model) createSessionsFromIssues(repoPath string, selectedIssues []ui.Is
 			m.selectSession(firstSession)
 
 			// Update UI for the active session
-			if m.claudeRunner != nil {
-				startTime, _ := m.sessionState().GetWaitStart(firstSession.ID)
+			if startTime, waiting := m.sessionState().GetWaitStart(firstSession.ID); waiting && m.claudeRunner != nil {
 				m.chat.SetWaitingWithStart(true, startTime)
 			}
 		}
 
-		m.setState(StateStreamingClaude)
-		cmds = append(cmds, m.sidebar.SidebarTick(), m.chat.SpinnerTick())
+		if m.paused {
+			cmds = append(cmds, m.ShowFlashWarning(pausedQueuedHint))
+		} else {
+			m.setState(StateStreamingClaude)
+			cmds = append(cmds, m.sidebar.SidebarTick(), m.chat.SpinnerTick())
+		}
 	}
 
 	if len(cmds) > 0 {
@@ -380,8 +386,8 @@ func (m *Model) createParallelSessions(selectedOptions []ui.OptionItem) (tea.Mod
 
 	for _, opt := range selectedOptions {
 		// Use generated branch name or fallback
-		branchName, ok := branchNames[opt.Number]
-		if !ok || branchName == "" {
+		branchName := session.SanitizeBranchName(branchNames[opt.Number])
+		if branchName == "" {
 			branchName = fmt.Sprintf("option-%d", opt.Number)
 		}
 
@@ -398,10 +404,7 @@ func (m *Model) createParallelSessions(selectedOptions []ui.OptionItem) (tea.Mod
 		// Build message history: parent messages only (option prompt will be added by SendContent)
 		var messages []config.Message
 		for _, msg := range parentMessages {
-			messages = append(messages, config.Message{
-				Role:    msg.Role,
-				Content: msg.Content,
-			})
+			messages = append(messages, manager.ToConfigMessage(msg))
 		}
 
 		// Option prompt to send (will be added to history by SendContent)
@@ -463,19 +466,12 @@ func (m *Model) createParallelSessions(selectedOptions []ui.OptionItem) (tea.Mod
 
 			runner := result.Runner
 
-			// Start streaming for this session
-			ctx, cancel := context.WithCancel(context.Background())
-			m.sessionState().StartWaiting(sess.ID, cancel)
-			m.sidebar.SetStreaming(sess.ID, true)
-
 			logger.WithSession(sess.ID).Debug("auto-starting parallel session", "prompt", optionPrompt)
 
-			// Send the option choice to Claude
+			// Send the option choice to Claude, held while paused
 			content := []claude.ContentBlock{{Type: claude.ContentTypeText, Text: optionPrompt}}
-			responseChan := runner.SendContent(ctx, content)
-
-			// Add listeners for this session
-			cmds = append(cmds, m.sessionListeners(sess.ID, runner, responseChan)...)
+			listenCmds, _ := m.startTurn(sess.ID, runner, content)
+			cmds = append(cmds, listenCmds...)
 		}
 
 		// Switch to the first session's UI
@@ -486,14 +482,17 @@ func (m *Model) createParallelSessions(selectedOptions []ui.OptionItem) (tea.Mod
 			// Update UI for the active session - the user message is already in the runner's
 			// message history (added by SendContent) and selectSession sets the chat messages
 			// from the runner, so we don't need to add it again here
-			if m.claudeRunner != nil {
-				startTime, _ := m.sessionState().GetWaitStart(firstSession.ID)
+			if startTime, waiting := m.sessionState().GetWaitStart(firstSession.ID); waiting && m.claudeRunner != nil {
 				m.chat.SetWaitingWithStart(true, startTime)
 			}
 		}
 
-		m.setState(StateStreamingClaude)
-		cmds = append(cmds, m.sidebar.SidebarTick(), m.chat.SpinnerTick())
+		if m.paused {
+			cmds = append(cmds, m.ShowFlashWarning(pausedQueuedHint))
+		} else {
+			m.setState(StateStreamingClaude)
+			cmds = append(cmds, m.sidebar.SidebarTick(), m.chat.SpinnerTick())
+		}
 	}
 
 	if len(cmds) > 0 {
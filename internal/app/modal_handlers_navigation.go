@@ -119,6 +119,34 @@ func normalizeHelpDisplayKey(displayKey string) string {
 	}
 }
 
+// handleSessionSwitcherModal handles key events for the Session Switcher modal.
+func (m *Model) handleSessionSwitcherModal(key string, msg tea.KeyPressMsg, state *ui.SessionSwitcherState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		item := state.GetSelected()
+		if item == nil {
+			return m, nil
+		}
+		m.modal.Hide()
+		sess := m.config.GetSession(item.ID)
+		if sess == nil {
+			return m, nil
+		}
+		m.sidebar.SelectSession(sess.ID)
+		if m.activeSession == nil || m.activeSession.ID != sess.ID {
+			return m, m.openSession(sess)
+		}
+		return m, nil
+	}
+	// Forward other keys to the modal for filtering and navigation
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
 // handleSearchMessagesModal handles key events for the Search Messages modal.
 func (m *Model) handleSearchMessagesModal(key string, msg tea.KeyPressMsg, state *ui.SearchMessagesState) (tea.Model, tea.Cmd) {
 	switch key {
@@ -3,9 +3,9 @@ package app
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"slices"
 	"strings"
-	"sync"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -19,6 +19,7 @@ import (
 	"github.com/zhubert/plural/internal/process"
 	"github.com/zhubert/plural/internal/session"
 	"github.com/zhubert/plural/internal/ui"
+	"github.com/zhubert/plural/internal/workpool"
 )
 
 // ContainerImageBuiltMsg is sent when the async container image build completes.
@@ -207,6 +208,10 @@ func (m *Model) handleAddRepoModal(key string, msg tea.KeyPressMsg, state *ui.Ad
 			m.modal.SetError(err.Error())
 			return m, nil
 		}
+		if err := session.CheckNotInWorktree(path, m.sessionWorktrees()); err != nil {
+			m.modal.SetError(err.Error())
+			return m, nil
+		}
 		if !m.config.AddRepo(path) {
 			m.modal.SetError("Repository already added")
 			return m, nil
@@ -215,13 +220,18 @@ func (m *Model) handleAddRepoModal(key string, msg tea.KeyPressMsg, state *ui.Ad
 			m.modal.SetError("Failed to save: " + err.Error())
 			return m, nil
 		}
+		m.sidebar.SetRepos(m.config.GetRepos())
 		m.sidebar.SetSessions(m.getFilteredSessions())
+		var flashCmd tea.Cmd
+		if m.sessionService.IsEmptyRepo(ctx, path) {
+			flashCmd = m.ShowFlashWarning(filepath.Base(path) + " has no commits yet; a first commit is offered when you create a session")
+		}
 		if state.ReturnToNewSession {
 			m.modal.Show(ui.NewNewSessionState(m.config.GetRepos(), process.ContainersSupported(), claude.ContainerAuthAvailable()))
-			return m, nil
+			return m, flashCmd
 		}
 		m.modal.Hide()
-		return m, nil
+		return m, flashCmd
 	}
 	// Forward other keys to the modal for text input handling
 	modal, cmd := m.modal.Update(msg)
@@ -229,6 +239,16 @@ func (m *Model) handleAddRepoModal(key string, msg tea.KeyPressMsg, state *ui.Ad
 	return m, cmd
 }
 
+// sessionWorktrees returns the worktree paths of all sessions, which must not be
+// added as repos.
+func (m *Model) sessionWorktrees() []string {
+	var worktrees []string
+	for _, sess := range m.config.GetSessions() {
+		worktrees = append(worktrees, sess.WorkTree)
+	}
+	return worktrees
+}
+
 // handleAddReposFromGlob expands a glob pattern and adds all matching git repositories.
 // Validation checks are parallelized for better performance with many directories.
 // When returnToNewSession is true, returns to the new session modal instead of hiding.
@@ -246,34 +266,20 @@ func (m *Model) handleAddReposFromGlob(ctx context.Context, pattern string, retu
 	}
 
 	// Parallelize validation checks
-	type validationResult struct {
-		dir   string
-		valid bool
-	}
-
-	results := make(chan validationResult, len(dirs))
-	var wg sync.WaitGroup
-
-	for _, dir := range dirs {
-		wg.Add(1)
-		go func(dir string) {
-			defer wg.Done()
-			err := m.sessionService.ValidateRepo(ctx, dir)
-			results <- validationResult{dir: dir, valid: err == nil}
-		}(dir)
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
+	worktrees := m.sessionWorktrees()
+	errs := workpool.Run(ctx, len(dirs), workpool.Options{Limit: m.config.GetMaxConcurrentJobs()}, func(ctx context.Context, i int) error {
+		if err := m.sessionService.ValidateRepo(ctx, dirs[i]); err != nil {
+			return err
+		}
+		return session.CheckNotInWorktree(dirs[i], worktrees)
+	})
 
 	// Collect valid repos
 	var validDirs []string
 	skipped := 0
-	for result := range results {
-		if result.valid {
-			validDirs = append(validDirs, result.dir)
+	for i, err := range errs {
+		if err == nil {
+			validDirs = append(validDirs, dirs[i])
 		} else {
 			skipped++
 		}
@@ -295,6 +301,7 @@ func (m *Model) handleAddReposFromGlob(ctx context.Context, pattern string, retu
 			m.modal.SetError("Failed to save: " + err.Error())
 			return m, nil
 		}
+		m.sidebar.SetRepos(m.config.GetRepos())
 		m.sidebar.SetSessions(m.getFilteredSessions())
 	}
 
@@ -321,6 +328,18 @@ func (m *Model) handleAddReposFromGlob(ctx context.Context, pattern string, retu
 
 // handleNewSessionModal handles key events for the New Session modal.
 func (m *Model) handleNewSessionModal(key string, msg tea.KeyPressMsg, state *ui.NewSessionState) (tea.Model, tea.Cmd) {
+	if state.Creating {
+		if key == keys.Escape {
+			m.cancelSessionCreate(state)
+		}
+		return m, nil
+	}
+	if state.LFSPrompt {
+		return m.handleLFSPrompt(key, msg, state)
+	}
+	if state.InitialCommitPrompt {
+		return m.handleInitialCommitPrompt(key, state)
+	}
 	switch key {
 	case keys.Escape:
 		m.modal.Hide()
@@ -363,46 +382,19 @@ func (m *Model) handleNewSessionModal(key string, msg tea.KeyPressMsg, state *ui
 			return m, cmd
 		}
 		return m, nil
-	case keys.Enter:
-		repoPath := state.GetSelectedRepo()
-		if repoPath == "" {
-			return m, nil
-		}
-		branchName := state.GetBranchName()
-		// Validate branch name
-		if err := session.ValidateBranchName(branchName); err != nil {
-			m.modal.SetError(err.Error())
-			return m, nil
-		}
-		// Get branch prefix and build full branch name for existence check
-		branchPrefix := m.config.GetDefaultBranchPrefix()
-		fullBranchName := branchPrefix + branchName
-		if branchName == "" {
-			fullBranchName = "" // Will be auto-generated
-		}
-		// Check if branch already exists
-		ctx := context.Background()
-		if fullBranchName != "" && m.sessionService.BranchExists(ctx, repoPath, fullBranchName) {
-			m.modal.SetError("Branch already exists: " + fullBranchName)
-			return m, nil
-		}
-		var basePoint session.BasePoint
-		switch state.GetBaseIndex() {
-		case 0:
-			basePoint = session.BasePointHead
-		case 1:
-			basePoint = session.BasePointLocalDefault
-		default:
-			basePoint = session.BasePointOrigin
+	case "i":
+		// Switch to the issue picker, unless typing a branch name
+		if state.Focus == 2 {
+			modal, cmd := m.modal.Update(msg)
+			m.modal = modal
+			return m, cmd
 		}
-		// Check container prerequisites asynchronously BEFORE creating the session
-		useContainers := state.GetUseContainers()
-		if useContainers {
-			return m.checkContainerPrerequisitesAsync(func() (tea.Model, tea.Cmd) {
-				return m.createNewSession(repoPath, branchName, branchPrefix, basePoint, true)
-			})
+		if repoPath := state.GetSelectedRepo(); repoPath != "" && m.hasIssueSource(repoPath) {
+			return m.showIssueSourceOrFetch(repoPath)
 		}
-		return m.createNewSession(repoPath, branchName, branchPrefix, basePoint, false)
+		return m, nil
+	case keys.Enter:
+		return m.submitNewSession(state)
 	}
 	// Forward other keys (tab, shift+tab, up, down, etc.) to modal for handling
 	modal, cmd := m.modal.Update(msg)
@@ -410,18 +402,80 @@ func (m *Model) handleNewSessionModal(key string, msg tea.KeyPressMsg, state *ui
 	return m, cmd
 }
 
+// submitNewSession validates the New Session form and creates the session.
+func (m *Model) submitNewSession(state *ui.NewSessionState) (tea.Model, tea.Cmd) {
+	repoPath := state.GetSelectedRepo()
+	if repoPath == "" {
+		return m, nil
+	}
+	// Validate branch name
+	branchName, err := session.NormalizeBranchName(state.GetBranchName())
+	if err != nil {
+		m.modal.SetError(err.Error())
+		return m, nil
+	}
+	// Get branch prefix and build full branch name for existence check
+	branchPrefix := m.config.GetDefaultBranchPrefix()
+	fullBranchName := branchPrefix + branchName
+	if branchName == "" {
+		fullBranchName = "" // Will be auto-generated
+	}
+	// Check if branch already exists
+	ctx := context.Background()
+	if fullBranchName != "" && m.sessionService.BranchExists(ctx, repoPath, fullBranchName) {
+		m.modal.SetError("Branch already exists: " + fullBranchName)
+		return m, nil
+	}
+	// A repo without commits has nothing to branch from until its first commit
+	if m.sessionService.IsEmptyRepo(ctx, repoPath) {
+		state.ShowInitialCommitPrompt(m.initialCommitMessage())
+		return m, nil
+	}
+	// Ask how to check out LFS files the first time a session is made from an LFS repo
+	if m.config.GetLFSMode(repoPath) == "" && session.UsesLFS(repoPath) {
+		state.ShowLFSPrompt()
+		return m, nil
+	}
+	var basePoint session.BasePoint
+	switch state.GetBaseIndex() {
+	case 0:
+		basePoint = session.BasePointHead
+	case 1:
+		basePoint = session.BasePointLocalDefault
+	default:
+		basePoint = session.BasePointOrigin
+	}
+	// Check container prerequisites asynchronously BEFORE creating the session
+	useContainers := state.GetUseContainers()
+	if useContainers {
+		return m.checkContainerPrerequisitesAsync(func() (tea.Model, tea.Cmd) {
+			return m.createNewSession(repoPath, branchName, branchPrefix, basePoint, true)
+		})
+	}
+	return m.createNewSession(repoPath, branchName, branchPrefix, basePoint, false)
+}
+
 // createNewSession is the shared session-creation logic used by handleNewSessionModal.
 // It is extracted so it can be called either directly (non-container) or from a
 // pendingContainerAction closure (after async prerequisite checks pass).
 func (m *Model) createNewSession(repoPath, branchName, branchPrefix string, basePoint session.BasePoint, useContainers bool) (tea.Model, tea.Cmd) {
 	ctx := context.Background()
 	logger.Get().Debug("creating new session", "repo", repoPath, "branch", branchName, "prefix", branchPrefix, "basePoint", basePoint)
+	// Checking out LFS files can take minutes, so LFS repos are created in the background
+	if session.UsesLFS(repoPath) {
+		return m.createNewSessionAsync(repoPath, branchName, branchPrefix, basePoint, useContainers)
+	}
 	sess, err := m.sessionService.Create(ctx, repoPath, branchName, branchPrefix, basePoint)
 	if err != nil {
 		logger.Get().Error("failed to create session", "error", err)
 		m.modal.SetError(err.Error())
 		return m, nil
 	}
+	return m.finishNewSession(sess, useContainers)
+}
+
+// finishNewSession adds a newly created session to the config and selects it.
+func (m *Model) finishNewSession(sess *config.Session, useContainers bool) (tea.Model, tea.Cmd) {
 	logger.WithSession(sess.ID).Info("session created", "name", sess.Name)
 	if useContainers {
 		sess.Containerized = true
@@ -433,8 +487,7 @@ func (m *Model) createNewSession(repoPath, branchName, branchPrefix string, base
 		return m, nil
 	}
 	m.sidebar.SetSessions(m.getFilteredSessions())
-	m.sidebar.SelectSession(sess.ID)
-	m.selectSession(sess)
+	m.selectNewSession(sess)
 	m.modal.Hide()
 	return m, nil
 }
@@ -448,54 +501,7 @@ func (m *Model) handleConfirmDeleteModal(key string, msg tea.KeyPressMsg, state
 	case keys.Enter:
 		var saveCmd tea.Cmd
 		if sess := m.sidebar.SelectedSession(); sess != nil {
-			log := logger.WithSession(sess.ID)
-			deleteWorktree := state.ShouldDeleteWorktree()
-			log.Debug("deleting session", "name", sess.Name, "deleteWorktree", deleteWorktree)
-
-			// Delete worktree if requested
-			if deleteWorktree {
-				ctx := context.Background()
-				if err := m.sessionService.Delete(ctx, sess); err != nil {
-					log.Warn("failed to delete worktree", "error", err)
-					// Continue with session removal even if worktree deletion fails
-				}
-			}
-
-			m.config.RemoveSession(sess.ID)
-			m.config.ClearOrphanedParentIDs([]string{sess.ID})
-			if cmd := m.saveConfigOrFlash(); cmd != nil {
-				saveCmd = cmd
-			}
-			config.DeleteSessionMessages(sess.ID)
-			m.sidebar.SetSessions(m.getFilteredSessions())
-			// Clean up runner and all per-session state via SessionManager
-			deletedRunner := m.sessionMgr.DeleteSession(sess.ID)
-			m.sidebar.SetPendingPermission(sess.ID, false)
-			m.sidebar.SetPendingQuestion(sess.ID, false)
-			m.sidebar.SetIdleWithResponse(sess.ID, false)
-			m.sidebar.SetUncommittedChanges(sess.ID, false)
-			m.sidebar.SetHasNewComments(sess.ID, false)
-			activeSessionID := "<nil>"
-			if m.activeSession != nil {
-				activeSessionID = m.activeSession.ID
-			}
-			log.Debug("checking if active session should be cleared", "activeSessionExists", m.activeSession != nil, "activeSessionID", activeSessionID)
-			if m.activeSession != nil && m.activeSession.ID == sess.ID {
-				log.Debug("clearing active session and chat")
-				m.activeSession = nil
-				m.claudeRunner = nil
-				m.chat.ClearSession()
-				m.header.SetSessionName("")
-				m.header.SetBaseBranch("")
-				m.header.SetDiffStats(nil)
-			} else {
-				log.Debug("not clearing chat - deleted session was not the active session")
-			}
-			if deletedRunner != nil {
-				log.Info("session deleted successfully (runner stopped)")
-			} else {
-				log.Info("session deleted successfully")
-			}
+			saveCmd = m.deleteSession(sess, state.ShouldDeleteWorktree())
 		}
 		m.modal.Hide()
 		return m, saveCmd
@@ -508,6 +514,82 @@ func (m *Model) handleConfirmDeleteModal(key string, msg tea.KeyPressMsg, state
 	return m, nil
 }
 
+// deleteSession removes a session from the list and stops its runner, first
+// removing its worktree and branch if deleteWorktree is set. Returns the config
+// save command.
+func (m *Model) deleteSession(sess *config.Session, deleteWorktree bool) tea.Cmd {
+	log := logger.WithSession(sess.ID)
+	log.Debug("deleting session", "name", sess.Name, "deleteWorktree", deleteWorktree)
+
+	// Delete worktree if requested
+	if deleteWorktree {
+		ctx := context.Background()
+		if err := m.sessionService.Delete(ctx, sess); err != nil {
+			log.Warn("failed to delete worktree", "error", err)
+			// Continue with session removal even if worktree deletion fails
+		}
+	}
+
+	if err := m.gitService.DeleteSnapshots(context.Background(), sess.RepoPath, sess.ID); err != nil {
+		log.Warn("failed to delete snapshots", "error", err)
+	}
+
+	return m.removeSession(sess.ID)
+}
+
+// removeSession removes a session whose worktree has been dealt with from the
+// config and releases everything plural holds for it.
+func (m *Model) removeSession(sessionID string) tea.Cmd {
+	m.config.RemoveSession(sessionID)
+	m.config.ClearOrphanedParentIDs([]string{sessionID})
+	saveCmd := m.saveConfigOrFlash()
+	m.sidebar.SetSessions(m.getFilteredSessions())
+	if deletedRunner := m.cleanupDeletedSession(sessionID); deletedRunner != nil {
+		logger.WithSession(sessionID).Info("session deleted successfully (runner stopped)")
+	} else {
+		logger.WithSession(sessionID).Info("session deleted successfully")
+	}
+	return saveCmd
+}
+
+// cleanupDeletedSession releases everything plural holds for a session removed
+// from the config: its saved messages, share, runner and per-session state,
+// scroll position and sidebar indicators. The chat is cleared if it was the active session. Returns
+// the runner if one was stopped.
+func (m *Model) cleanupDeletedSession(sessionID string) claude.RunnerInterface {
+	log := logger.WithSession(sessionID)
+	config.DeleteSessionMessages(sessionID)
+	// Clean up runner and all per-session state via SessionManager
+	m.stopShare(sessionID)
+	deletedRunner := m.sessionMgr.DeleteSession(sessionID)
+	m.sidebar.SetPendingPermission(sessionID, false)
+	m.sidebar.SetPendingQuestion(sessionID, false)
+	m.sidebar.SetIdleWithResponse(sessionID, false)
+	m.sidebar.SetUncommittedChanges(sessionID, false)
+	m.sidebar.SetHasNewComments(sessionID, false)
+	m.sidebar.SetMissing(sessionID, false)
+	m.invalidateFileIndex(sessionID)
+	m.chat.ForgetScroll(sessionID)
+	activeSessionID := "<nil>"
+	if m.activeSession != nil {
+		activeSessionID = m.activeSession.ID
+	}
+	log.Debug("checking if active session should be cleared", "activeSessionExists", m.activeSession != nil, "activeSessionID", activeSessionID)
+	if m.activeSession != nil && m.activeSession.ID == sessionID {
+		log.Debug("clearing active session and chat")
+		m.activeSession = nil
+		m.claudeRunner = nil
+		m.chat.ClearSession()
+		m.publishWebView()
+		m.header.SetSessionName("")
+		m.header.SetBaseBranch("")
+		m.header.SetDiffStats(nil)
+	} else {
+		log.Debug("not clearing chat - deleted session was not the active session")
+	}
+	return deletedRunner
+}
+
 // handleForkSessionModal handles key events for the Fork Session modal.
 func (m *Model) handleForkSessionModal(key string, msg tea.KeyPressMsg, state *ui.ForkSessionState) (tea.Model, tea.Cmd) {
 	switch key {
@@ -515,9 +597,9 @@ func (m *Model) handleForkSessionModal(key string, msg tea.KeyPressMsg, state *u
 		m.modal.Hide()
 		return m, nil
 	case keys.Enter:
-		branchName := state.GetBranchName()
 		// Validate branch name
-		if err := session.ValidateBranchName(branchName); err != nil {
+		branchName, err := session.NormalizeBranchName(state.GetBranchName())
+		if err != nil {
 			m.modal.SetError(err.Error())
 			return m, nil
 		}
@@ -604,8 +686,7 @@ func (m *Model) createForkSession(repoPath, parentSessionID, branchName, branchP
 		return m, nil
 	}
 	m.sidebar.SetSessions(m.getFilteredSessions())
-	m.sidebar.SelectSession(sess.ID)
-	m.selectSession(sess)
+	m.selectNewSession(sess)
 	m.modal.Hide()
 
 	if messageCopyFailed {
@@ -615,55 +696,32 @@ func (m *Model) createForkSession(repoPath, parentSessionID, branchName, branchP
 }
 
 // handleRenameSessionModal handles key events for the Rename Session modal.
+// Only the session's display name changes; its branch and worktree are kept.
 func (m *Model) handleRenameSessionModal(key string, msg tea.KeyPressMsg, state *ui.RenameSessionState) (tea.Model, tea.Cmd) {
 	switch key {
 	case keys.Escape:
 		m.modal.Hide()
 		return m, nil
 	case keys.Enter:
-		newName := state.GetNewName()
+		newName := session.CleanSessionName(state.GetNewName())
 		if newName == "" {
 			m.modal.SetError("Name cannot be empty")
 			return m, nil
 		}
 
-		// Get the session to access worktree path and old branch name
 		sess := m.config.GetSession(state.SessionID)
 		if sess == nil {
 			m.modal.SetError("Session not found")
 			return m, nil
 		}
 
-		oldBranch := sess.Branch
-
-		// Apply branch prefix if configured
-		branchPrefix := m.config.GetDefaultBranchPrefix()
-		newBranch := branchPrefix + newName
-
-		// Validate the new branch name
-		if err := session.ValidateBranchName(newName); err != nil {
-			m.modal.SetError(err.Error())
+		// Check if another session of the repo has the name
+		if m.config.SessionNameTaken(sess.RepoPath, newName, sess.ID) {
+			m.modal.SetError("Another session in this repo is named " + newName)
 			return m, nil
 		}
 
-		// Check if new branch already exists (unless it's the same name)
-		ctx := context.Background()
-		if newBranch != oldBranch && m.sessionService.BranchExists(ctx, sess.RepoPath, newBranch) {
-			m.modal.SetError("Branch already exists: " + newBranch)
-			return m, nil
-		}
-
-		// Rename the git branch
-		if newBranch != oldBranch {
-			if err := m.gitService.RenameBranch(ctx, sess.WorkTree, oldBranch, newBranch); err != nil {
-				m.modal.SetError("Failed to rename branch: " + err.Error())
-				return m, nil
-			}
-		}
-
-		// Update the session name and branch in config
-		// Name stores the full branch name (same as branch) for display
-		if !m.config.RenameSession(state.SessionID, newBranch, newBranch) {
+		if !m.config.RenameSession(state.SessionID, newName, sess.Branch) {
 			m.modal.SetError("Failed to rename session")
 			return m, nil
 		}
@@ -671,14 +729,14 @@ func (m *Model) handleRenameSessionModal(key string, msg tea.KeyPressMsg, state
 			m.modal.SetError("Failed to save: " + err.Error())
 			return m, nil
 		}
-		logger.WithSession(state.SessionID).Info("renamed session", "branch", newBranch)
+		logger.WithSession(state.SessionID).Info("renamed session", "name", newName)
 
-		// Update sidebar and header
+		// Update sidebar, header and chat
 		m.sidebar.SetSessions(m.getFilteredSessions())
 		if m.activeSession != nil && m.activeSession.ID == state.SessionID {
-			m.activeSession.Name = newBranch
-			m.activeSession.Branch = newBranch
-			m.header.SetSessionName(newBranch)
+			m.activeSession.Name = newName
+			m.header.SetSessionName(newName)
+			m.chat.SetSessionInfo(sessionInfo(m.config, m.activeSession))
 		}
 		m.modal.Hide()
 		return m, nil
@@ -713,6 +771,7 @@ func (m *Model) handleConfirmDeleteRepoModal(key string, msg tea.KeyPressMsg, st
 			return m, nil
 		}
 		logger.Get().Info("repository deleted successfully", "path", repoPath)
+		m.sidebar.SetRepos(m.config.GetRepos())
 		m.sidebar.SetSessions(m.getFilteredSessions())
 
 		if state.FromSidebar {
@@ -783,15 +842,11 @@ func (m *Model) handleBroadcastModal(key string, msg tea.KeyPressMsg, state *ui.
 			return m, nil
 		}
 
-		// Get the optional session name
-		sessionName := strings.TrimSpace(state.GetName())
-
-		// Validate session name if provided
-		if sessionName != "" {
-			if err := session.ValidateBranchName(sessionName); err != nil {
-				m.modal.SetError(err.Error())
-				return m, nil
-			}
+		// Get and validate the optional session name
+		sessionName, err := session.NormalizeBranchName(state.GetName())
+		if err != nil {
+			m.modal.SetError(err.Error())
+			return m, nil
 		}
 
 		// Check container prerequisites asynchronously BEFORE creating sessions
@@ -824,56 +879,43 @@ func (m *Model) createBroadcastSessions(repoPaths []string, prompt string, sessi
 	groupID := uuid.New().String()
 	branchPrefix := m.config.GetDefaultBranchPrefix()
 
-	// Use a semaphore to limit concurrent session creation (avoid overwhelming git/network)
-	const maxConcurrent = 10
-	sem := make(chan struct{}, maxConcurrent)
-
-	// Thread-safe collection of results
-	var mu sync.Mutex
-	var createdSessions []*config.Session
-	var failedRepos []string
 	// Create sessions in parallel with a bounded context so they don't run forever
-	// if the app is shutting down or git operations hang.
+	// if the app is shutting down or git operations hang. The concurrency limit
+	// avoids overwhelming git and the network.
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
-	var wg sync.WaitGroup
-	for _, repoPath := range repoPaths {
-		wg.Add(1)
-		go func(repoPath string) {
-			defer wg.Done()
-
-			// Acquire semaphore
-			sem <- struct{}{}
-			defer func() { <-sem }()
-
-			sess, err := m.sessionService.Create(ctx, repoPath, sessionName, branchPrefix, session.BasePointOrigin)
-			if err != nil {
-				log.Error("failed to create session for broadcast", "repo", repoPath, "error", err)
-				mu.Lock()
-				failedRepos = append(failedRepos, repoPath)
-				mu.Unlock()
-				return
-			}
+	created := make([]*config.Session, len(repoPaths))
+	errs := workpool.Run(ctx, len(repoPaths), workpool.Options{Limit: m.config.GetMaxConcurrentJobs()}, func(ctx context.Context, i int) error {
+		repoPath := repoPaths[i]
+		sess, err := m.sessionService.Create(ctx, repoPath, sessionName, branchPrefix, session.BasePointOrigin)
+		if err != nil {
+			return err
+		}
 
-			// Set the broadcast group ID
-			sess.BroadcastGroupID = groupID
+		// Set the broadcast group ID
+		sess.BroadcastGroupID = groupID
 
-			// Set containerized flag (image existence already verified above)
-			if useContainers {
-				sess.Containerized = true
-			}
+		// Set containerized flag (image existence already verified above)
+		if useContainers {
+			sess.Containerized = true
+		}
 
-			mu.Lock()
-			createdSessions = append(createdSessions, sess)
-			mu.Unlock()
+		created[i] = sess
+		logger.WithSession(sess.ID).Info("created broadcast session", "repo", repoPath, "groupID", groupID)
+		return nil
+	})
 
-			logger.WithSession(sess.ID).Info("created broadcast session", "repo", repoPath, "groupID", groupID)
-		}(repoPath)
+	var createdSessions []*config.Session
+	var failedRepos []string
+	for i, err := range errs {
+		if err != nil {
+			log.Error("failed to create session for broadcast", "repo", repoPaths[i], "error", err)
+			failedRepos = append(failedRepos, repoPaths[i])
+			continue
+		}
+		createdSessions = append(createdSessions, created[i])
 	}
 
-	// Wait for all session creations to complete
-	wg.Wait()
-
 	// Add all sessions to config (after parallel creation completes)
 	for _, sess := range createdSessions {
 		m.config.AddSession(*sess)
@@ -921,31 +963,24 @@ func (m *Model) createBroadcastSessions(repoPaths []string, prompt string, sessi
 		runner := result.Runner
 		sessionID := sess.ID
 
-		// Create context for this request
-		reqCtx, cancel := context.WithCancel(context.Background())
-		m.sessionState().StartWaiting(sessionID, cancel)
-		m.sidebar.SetStreaming(sessionID, true)
-
-		// Send the content
-		responseChan := runner.SendContent(reqCtx, content)
-
-		// Add listeners for this session
-		cmds = append(cmds, m.sessionListeners(sessionID, runner, responseChan)...)
+		// Send the content, held while paused
+		listenCmds, _ := m.startTurn(sessionID, runner, content)
+		cmds = append(cmds, listenCmds...)
 	}
 
-	// Set the app state to streaming
-	m.setState(StateStreamingClaude)
-
-	// Add UI update ticks
-	cmds = append(cmds, m.sidebar.SidebarTick(), m.chat.SpinnerTick())
-
 	// Show status message
 	msg := fmt.Sprintf("Broadcasting to %d repo(s)", len(createdSessions))
 	if len(failedRepos) > 0 {
 		msg += fmt.Sprintf(" (failed: %d)", len(failedRepos))
 	}
 
-	cmds = append(cmds, m.ShowFlashSuccess(msg))
+	if m.paused {
+		cmds = append(cmds, m.ShowFlashWarning(pausedQueuedHint))
+	} else {
+		// Set the app state to streaming and add UI update ticks
+		m.setState(StateStreamingClaude)
+		cmds = append(cmds, m.sidebar.SidebarTick(), m.chat.SpinnerTick(), m.ShowFlashSuccess(msg))
+	}
 
 	if saveCmd != nil {
 		cmds = append(cmds, saveCmd)
@@ -972,33 +1007,24 @@ func (m *Model) broadcastToSessions(sessions []config.Session, prompt string) (t
 		runner claude.RunnerInterface
 	}
 
-	results := make(chan runnerResult, len(sessions))
-	var wg sync.WaitGroup
-
-	for _, sess := range sessions {
-		wg.Add(1)
-		go func(sess config.Session) {
-			defer wg.Done()
-			runner := m.sessionMgr.GetOrCreateRunner(&sess)
-			m.sessionMgr.ConfigureRunnerDefaults(runner, &sess)
-			m.addClaudeCodeMCPApprovals(runner, &sess)
-			results <- runnerResult{sess: sess, runner: runner}
-		}(sess)
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
+	runners := make([]claude.RunnerInterface, len(sessions))
+	workpool.Run(context.Background(), len(sessions), workpool.Options{Limit: m.config.GetMaxConcurrentJobs()}, func(ctx context.Context, i int) error {
+		sess := &sessions[i]
+		runner := m.sessionMgr.GetOrCreateRunner(sess)
+		m.sessionMgr.ConfigureRunnerDefaults(runner, sess)
+		m.addClaudeCodeMCPApprovals(runner, sess)
+		runners[i] = runner
+		return nil
+	})
 
 	// Collect runners
 	var sessionsWithRunners []runnerResult
-	for result := range results {
-		if result.runner == nil {
-			log.Error("failed to get runner for broadcast session", "sessionID", result.sess.ID)
+	for i, sess := range sessions {
+		if runners[i] == nil {
+			log.Error("failed to get runner for broadcast session", "sessionID", sess.ID)
 			continue
 		}
-		sessionsWithRunners = append(sessionsWithRunners, result)
+		sessionsWithRunners = append(sessionsWithRunners, runnerResult{sess: sess, runner: runners[i]})
 	}
 
 	// Second pass: sequentially set up streaming and send content (modifies app state)
@@ -1009,30 +1035,23 @@ func (m *Model) broadcastToSessions(sessions []config.Session, prompt string) (t
 		sessionID := result.sess.ID
 		runner := result.runner
 
-		// Create context for this request
-		reqCtx, cancel := context.WithCancel(context.Background())
-		m.sessionState().StartWaiting(sessionID, cancel)
-		m.sidebar.SetStreaming(sessionID, true)
-
-		// Send the content
-		responseChan := runner.SendContent(reqCtx, content)
-
-		// Add listeners for this session
-		cmds = append(cmds, m.sessionListeners(sessionID, runner, responseChan)...)
+		// Send the content, held while paused
+		listenCmds, _ := m.startTurn(sessionID, runner, content)
+		cmds = append(cmds, listenCmds...)
 		sentCount++
 	}
 
-	// Set the app state to streaming
-	m.setState(StateStreamingClaude)
-
 	// Clear the chat input since we're sending it
 	m.chat.ClearInput()
 
-	// Add UI update ticks
-	cmds = append(cmds, m.sidebar.SidebarTick(), m.chat.SpinnerTick())
-
-	// Show status message
-	cmds = append(cmds, m.ShowFlashSuccess(fmt.Sprintf("Sent to %d session(s)", sentCount)))
+	if m.paused {
+		cmds = append(cmds, m.ShowFlashWarning(pausedQueuedHint))
+	} else {
+		// Set the app state to streaming, add UI update ticks and show status
+		m.setState(StateStreamingClaude)
+		cmds = append(cmds, m.sidebar.SidebarTick(), m.chat.SpinnerTick())
+		cmds = append(cmds, m.ShowFlashSuccess(fmt.Sprintf("Sent to %d session(s)", sentCount)))
+	}
 
 	return m, tea.Batch(cmds...)
 }
@@ -1132,7 +1151,7 @@ func (m *Model) createPRsForSessions(sessions []config.Session) (tea.Model, tea.
 		sessionLog := logger.WithSession(sess.ID)
 		sessionLog.Info("starting PR creation")
 		mergeCtx, cancel := context.WithCancel(context.Background())
-		m.sessionState().StartMerge(sess.ID, m.gitService.CreatePR(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, sess.BaseBranch, "", sess.GetIssueRef(), sess.ID), cancel, manager.MergeTypePR)
+		m.sessionState().StartMerge(sess.ID, m.gitServiceFor(&sess).ResumePR(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, sess.BaseBranch, "", sess.GetIssueRef(), m.config.GetPRTemplate(sess.RepoPath), sess.ID, sess.PRProgress), cancel, manager.MergeTypePR)
 
 		// Add listener for merge result
 		cmds = append(cmds, m.listenForMergeResult(sess.ID))
@@ -1160,6 +1179,9 @@ func (m *Model) createPRsForSessions(sessions []config.Session) (tea.Model, tea.
 
 // handleBulkActionModal handles key events for the Bulk Action modal.
 func (m *Model) handleBulkActionModal(key string, msg tea.KeyPressMsg, state *ui.BulkActionState) (tea.Model, tea.Cmd) {
+	if state.Deleting && key != keys.Escape {
+		return m, nil
+	}
 	switch key {
 	case keys.Escape:
 		m.modal.Hide()
@@ -1167,7 +1189,7 @@ func (m *Model) handleBulkActionModal(key string, msg tea.KeyPressMsg, state *ui
 	case keys.Enter:
 		switch state.GetAction() {
 		case ui.BulkActionDelete:
-			return m.executeBulkDelete(state.SessionIDs)
+			return m.executeBulkDelete(state)
 		case ui.BulkActionCreatePRs:
 			return m.executeBulkCreatePRs(state.SessionIDs)
 		case ui.BulkActionSendPrompt:
@@ -1186,71 +1208,6 @@ func (m *Model) handleBulkActionModal(key string, msg tea.KeyPressMsg, state *ui
 	return m, cmd
 }
 
-// executeBulkDelete deletes multiple sessions
-func (m *Model) executeBulkDelete(sessionIDs []string) (tea.Model, tea.Cmd) {
-	log := logger.Get()
-	ctx := context.Background()
-
-	// Delete worktrees in parallel using bounded concurrency
-	const maxConcurrent = 10
-	sem := make(chan struct{}, maxConcurrent)
-	var wg sync.WaitGroup
-	for _, id := range sessionIDs {
-		sess := m.config.GetSession(id)
-		if sess == nil {
-			continue
-		}
-		wg.Add(1)
-		go func(s *config.Session) {
-			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-			if err := m.sessionService.Delete(ctx, s); err != nil {
-				log.Warn("failed to delete worktree during bulk delete", "session", s.ID, "error", err)
-			}
-		}(sess)
-	}
-	wg.Wait()
-
-	// Clean up state for each session (must be sequential - UI operations)
-	for _, id := range sessionIDs {
-		config.DeleteSessionMessages(id)
-		m.sessionMgr.DeleteSession(id)
-		m.sidebar.SetPendingPermission(id, false)
-		m.sidebar.SetPendingQuestion(id, false)
-		m.sidebar.SetIdleWithResponse(id, false)
-		m.sidebar.SetUncommittedChanges(id, false)
-		m.sidebar.SetHasNewComments(id, false)
-
-		// Clear active session if deleted
-		if m.activeSession != nil && m.activeSession.ID == id {
-			m.activeSession = nil
-			m.claudeRunner = nil
-			m.chat.ClearSession()
-			m.header.SetSessionName("")
-			m.header.SetBaseBranch("")
-			m.header.SetDiffStats(nil)
-		}
-	}
-
-	// Batch remove all sessions from config and clean up orphaned parent refs
-	deleted := m.config.RemoveSessions(sessionIDs)
-	m.config.ClearOrphanedParentIDs(sessionIDs)
-
-	var cmds []tea.Cmd
-	if cmd := m.saveConfigOrFlash(); cmd != nil {
-		cmds = append(cmds, cmd)
-	}
-
-	// Exit multi-select mode and update sidebar
-	m.sidebar.ExitMultiSelect()
-	m.sidebar.SetSessions(m.getFilteredSessions())
-	m.modal.Hide()
-
-	cmds = append(cmds, m.ShowFlashSuccess(fmt.Sprintf("Deleted %d session(s)", deleted)))
-	return m, tea.Batch(cmds...)
-}
-
 // executeBulkMove moves multiple sessions to a workspace
 // executeBulkCreatePRs creates PRs for multiple sessions
 func (m *Model) executeBulkCreatePRs(sessionIDs []string) (tea.Model, tea.Cmd) {
@@ -1322,10 +1279,18 @@ func (m *Model) handleSessionSettingsModal(key string, msg tea.KeyPressMsg, stat
 
 		if newBranch != oldBranch {
 			// Validate the new branch name
-			if err := session.ValidateBranchName(newName); err != nil {
+			normalized, err := session.NormalizeBranchName(newName)
+			if err != nil {
 				m.modal.SetError(err.Error())
 				return m, nil
 			}
+			newBranch = branchPrefix + normalized
+		}
+		if newBranch != oldBranch {
+			if m.config.SessionNameTaken(sess.RepoPath, newBranch, sess.ID) {
+				m.modal.SetError("Another session in this repo is named " + newBranch)
+				return m, nil
+			}
 
 			// Check if new branch already exists
 			ctx := context.Background()
@@ -1340,13 +1305,27 @@ func (m *Model) handleSessionSettingsModal(key string, msg tea.KeyPressMsg, stat
 				return m, nil
 			}
 
-			// Update session name and branch
-			if !m.config.RenameSession(state.SessionID, newBranch, newBranch) {
+			// Sessions named after their branch follow it; a name given with
+			// the rename action is kept
+			newSessionName := newBranch
+			if sess.Name != oldBranch {
+				newSessionName = sess.Name
+			}
+			if !m.config.RenameSession(state.SessionID, newSessionName, newBranch) {
 				m.modal.SetError("Failed to rename session")
 				return m, nil
 			}
 		}
 
+		if state.GetMirrorOutput() != sess.MirrorOutput {
+			if err := m.sessionMgr.SetMirrorOutput(state.SessionID, state.GetMirrorOutput()); err != nil {
+				m.modal.SetError("Failed to set mirror file: " + err.Error())
+				return m, nil
+			}
+		}
+
+		m.config.SetSessionAutoApprovePlans(state.SessionID, state.GetAutoApprovePlans())
+
 		// Save per-repo settings
 		m.config.SetAsanaProject(state.RepoPath, state.GetAsanaProject())
 		m.config.SetLinearTeam(state.RepoPath, state.GetLinearTeam())
@@ -1362,10 +1341,11 @@ func (m *Model) handleSessionSettingsModal(key string, msg tea.KeyPressMsg, stat
 		// Update sidebar and header
 		m.sidebar.SetSessions(m.getFilteredSessions())
 		if m.activeSession != nil && m.activeSession.ID == state.SessionID {
-			if newBranch := branchPrefix + newName; newBranch != oldBranch {
-				m.activeSession.Name = newBranch
-				m.activeSession.Branch = newBranch
-				m.header.SetSessionName(newBranch)
+			if updated := m.config.GetSession(state.SessionID); updated != nil && updated.Branch != oldBranch {
+				m.activeSession.Name = updated.Name
+				m.activeSession.Branch = updated.Branch
+				m.header.SetSessionName(updated.Name)
+				m.chat.SetSessionInfo(sessionInfo(m.config, m.activeSession))
 			}
 		}
 		m.modal.Hide()
@@ -1,15 +1,22 @@
 package app
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	tea "charm.land/bubbletea/v2"
 	pexec "github.com/zhubert/plural/internal/exec"
 
+	"github.com/zhubert/plural/internal/claude"
 	"github.com/zhubert/plural/internal/config"
 	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/paths"
 	"github.com/zhubert/plural/internal/session"
 	"github.com/zhubert/plural/internal/ui"
 )
@@ -489,22 +496,69 @@ func TestRenameSessionModal_EmptyName(t *testing.T) {
 	}
 }
 
-func TestRenameSessionModal_InvalidBranchName(t *testing.T) {
+func TestRenameSessionModal_KeepsBranch(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
 	cfg := testConfigWithSessions()
+	cfg.SetFilePath(filepath.Join(home, "config.json"))
 	m := testModelWithSize(cfg, 120, 40)
 	m.sidebar.SetSessions(cfg.Sessions)
+	selected := m.sidebar.SelectedSession()
 
 	m = sendKey(m, "r")
 	state := m.modal.State.(*ui.RenameSessionState)
+	if state.GetNewName() != ui.SessionDisplayName(selected.Branch, selected.Name) {
+		t.Errorf("Expected the modal pre-filled with %q, got %q", ui.SessionDisplayName(selected.Branch, selected.Name), state.GetNewName())
+	}
 
-	// Set invalid name
-	state.SetNewName("invalid..name")
+	// Names aren't branches, so git's rules don't apply
+	state.SetNewName("  Login page..redo ")
+	m = sendKey(m, "enter")
+
+	if m.modal.IsVisible() {
+		t.Fatalf("Modal should close after renaming, error %q", m.modal.GetError())
+	}
+	sess := m.config.GetSession(selected.ID)
+	if sess.Name != "Login page..redo" {
+		t.Errorf("Expected name %q, got %q", "Login page..redo", sess.Name)
+	}
+	if sess.Branch != selected.Branch || sess.WorkTree != selected.WorkTree {
+		t.Errorf("Branch and worktree should be kept, got %q and %q", sess.Branch, sess.WorkTree)
+	}
+	if !strings.Contains(m.sidebar.View(), "Login page..redo") {
+		t.Error("Sidebar should show the new name")
+	}
 
+	// The session settings edit the branch, leaving the name alone
+	m.showSessionSettings(sess)
 	m = sendKey(m, "enter")
+	if got := m.config.GetSession(selected.ID); got.Name != "Login page..redo" || got.Branch != selected.Branch {
+		t.Errorf("Saving session settings changed the name or branch to %q, %q", got.Name, got.Branch)
+	}
+}
 
-	// Should show error
-	if m.modal.GetError() == "" {
-		t.Error("Expected error for invalid branch name")
+func TestRenameSessionModal_DuplicateName(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "r")
+	state := m.modal.State.(*ui.RenameSessionState)
+
+	// Another session of the same repo is on this branch
+	state.SetNewName("plural-abc123")
+
+	m = sendKey(m, "enter")
+
+	if !strings.Contains(m.modal.GetError(), "Another session in this repo") {
+		t.Errorf("Expected a duplicate name error, got %q", m.modal.GetError())
+	}
+	if sess := m.config.GetSession(state.SessionID); sess.Branch == "plural-abc123" {
+		t.Error("Session should not be renamed to a duplicate name")
 	}
 }
 
@@ -552,6 +606,8 @@ func TestSettingsModal_Open(t *testing.T) {
 		cfg.GetDefaultBranchPrefix(),
 		cfg.GetNotificationsEnabled(),
 		false,
+		false,
+		true,
 	))
 	if !m.modal.IsVisible() {
 		t.Fatal("Settings modal should be visible")
@@ -572,6 +628,8 @@ func TestSettingsModal_CancelWithEscape(t *testing.T) {
 		cfg.GetDefaultBranchPrefix(),
 		cfg.GetNotificationsEnabled(),
 		false,
+		false,
+		true,
 	))
 	state := m.modal.State.(*ui.SettingsState)
 
@@ -600,6 +658,8 @@ func TestSettingsModal_ToggleNotifications(t *testing.T) {
 		cfg.GetDefaultBranchPrefix(),
 		cfg.GetNotificationsEnabled(),
 		false,
+		false,
+		true,
 	))
 	state := m.modal.State.(*ui.SettingsState)
 
@@ -632,6 +692,48 @@ func TestSessionSettingsModal_SavesAsanaProject(t *testing.T) {
 	}
 }
 
+func TestSessionSettingsModal_TogglesMirrorOutput(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	sess := cfg.Sessions[0]
+	runner := claude.NewMockRunner(sess.ID, true, nil)
+	m.sessionMgr.SetRunner(sess.ID, runner)
+
+	state := ui.NewSessionSettingsState(sess.ID, sess.Branch, sess.Branch, "main", false,
+		sess.RepoPath, false, "", false, "")
+	m.modal.Show(state)
+	state.MirrorOutput = true
+	m = sendKey(m, "enter")
+
+	if !m.config.GetSession(sess.ID).MirrorOutput {
+		t.Error("expected mirror output to be enabled in config")
+	}
+	wantPath, err := manager.MirrorFilePath(sess.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := runner.GetMirrorFile(); got != wantPath {
+		t.Errorf("runner mirror file = %q, want %q", got, wantPath)
+	}
+
+	// Disabling stops the running session's mirror
+	state = ui.NewSessionSettingsState(sess.ID, sess.Branch, sess.Branch, "main", false,
+		sess.RepoPath, false, "", false, "")
+	state.SetMirrorOutput(true, wantPath)
+	m.modal.Show(state)
+	state.MirrorOutput = false
+	m = sendKey(m, "enter")
+
+	if m.config.GetSession(sess.ID).MirrorOutput {
+		t.Error("expected mirror output to be disabled in config")
+	}
+	if got := runner.GetMirrorFile(); got != "" {
+		t.Errorf("runner mirror file = %q, want none", got)
+	}
+}
+
 func TestSessionSettingsModal_SavesLinearTeam(t *testing.T) {
 	cfg := testConfigWithSessions()
 	m := testModelWithSize(cfg, 120, 40)
@@ -725,6 +827,8 @@ func TestSettingsModal_UpdatesBranchPrefix(t *testing.T) {
 		cfg.GetDefaultBranchPrefix(),
 		cfg.GetNotificationsEnabled(),
 		false,
+		false,
+		true,
 	))
 	state := m.modal.State.(*ui.SettingsState)
 
@@ -739,6 +843,27 @@ func TestSettingsModal_UpdatesBranchPrefix(t *testing.T) {
 	}
 }
 
+func TestSettingsModal_SavesCompactToolUses(t *testing.T) {
+	cfg := testConfig()
+	m := testModelWithSize(cfg, 120, 40)
+
+	m.modal.Show(ui.NewSettingsState(
+		cfg.GetDefaultBranchPrefix(),
+		cfg.GetNotificationsEnabled(),
+		false,
+		false,
+		true,
+	))
+	state := m.modal.State.(*ui.SettingsState)
+	state.CompactToolUses = true
+
+	m = sendKey(m, "enter")
+
+	if !m.config.GetCompactToolUses() {
+		t.Error("Expected compact tool uses to be saved to config")
+	}
+}
+
 // =============================================================================
 // MCP Servers Modal Tests
 // =============================================================================
@@ -919,6 +1044,117 @@ func TestMergeModal_PRSessionMergeState(t *testing.T) {
 	}
 }
 
+func TestMergeModal_MainRepoChangesRequireAutoStash(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	// The main repo has uncommitted changes; the session worktree is clean
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddRule(func(dir, name string, args []string) bool {
+		return dir == "/test/repo1" && name == "git" && len(args) > 0 && args[0] == "status"
+	}, pexec.MockResponse{Stdout: []byte(" M main.go\n")})
+	mockExec.AddPrefixMatch("git", []string{}, pexec.MockResponse{})
+	m.SetGitService(git.NewGitServiceWithExecutor(mockExec))
+
+	m = sendKey(m, "m")
+	state, ok := m.modal.State.(*ui.MergeState)
+	if !ok {
+		t.Fatalf("Expected MergeState, got %T", m.modal.State)
+	}
+	if state.MainRepoChanges == "" {
+		t.Fatal("Expected main repo changes to be reported")
+	}
+	if !state.NeedsAutoStash() {
+		t.Error("Expected auto-stash to be on by default")
+	}
+	if !strings.Contains(state.Render(), "Main repo has uncommitted changes") {
+		t.Error("Expected the merge modal to warn about main repo changes")
+	}
+
+	// Turning auto-stash off aborts the merge instead of checking out over the changes
+	m = sendKey(m, "s")
+	if state.NeedsAutoStash() {
+		t.Fatal("Expected s to turn auto-stash off")
+	}
+	m = sendKey(m, "enter")
+	if !m.modal.IsVisible() || m.modal.GetError() == "" {
+		t.Error("Expected the modal to stay open with an error")
+	}
+	if sessState := m.sessionState().GetIfExists(cfg.Sessions[0].ID); sessState != nil && sessState.IsMerging() {
+		t.Error("Merge should not start while the main repo has unstashed changes")
+	}
+}
+
+func TestMergeModal_StaleBaseBranchOffersMigration(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].BaseBranch = "master"
+	cfg.Sessions[1].BaseBranch = "master"
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	// origin/HEAD still names master until the default branch is re-resolved
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddExactMatch("git", []string{"symbolic-ref", "refs/remotes/origin/HEAD"}, pexec.MockResponse{
+		Stdout: []byte("refs/remotes/origin/master\n"),
+	})
+	// The branch has commits main lacks, but none master lacks
+	mockExec.AddExactMatch("git", []string{"rev-list", "--count", "--left-right", "main..." + cfg.Sessions[0].Branch}, pexec.MockResponse{
+		Stdout: []byte("0\t2\n"),
+	})
+	mockExec.AddPrefixMatch("git", []string{}, pexec.MockResponse{})
+	m.SetGitService(git.NewGitServiceWithExecutor(mockExec))
+
+	m = sendKey(m, "m")
+	state, ok := m.modal.State.(*ui.MergeState)
+	if !ok {
+		t.Fatalf("Expected MergeState, got %T", m.modal.State)
+	}
+	if got := state.GetPRBaseBranch(); got != "master" {
+		t.Fatalf("Expected PR base prefilled with the recorded base, got %q", got)
+	}
+	if slices.Contains(state.Options, "Rebase onto main") {
+		t.Fatal("Expected no rebase offered before the default branch is re-resolved")
+	}
+
+	// The background check finds the default branch renamed to main
+	result, _ := m.Update(DefaultBranchCheckMsg{
+		SessionID:       cfg.Sessions[0].ID,
+		RepoPath:        "/test/repo1",
+		BaseBranch:      "master",
+		PreviousDefault: "master",
+		DefaultBranch:   "main",
+		BaseGone:        true,
+		RemoteBranches:  []string{"main"},
+	})
+	m = result.(*Model)
+	if got := state.GetPRBaseBranch(); got != "main" {
+		t.Errorf("Expected PR base to move to the new default, got %q", got)
+	}
+	if !slices.Contains(state.Options, "Rebase onto main") {
+		t.Errorf("Expected the branch judged against the new default, got options %v", state.Options)
+	}
+	if !strings.Contains(state.Render(), "Base branch master no longer exists on origin") {
+		t.Error("Expected a warning about the stale base branch")
+	}
+	if !state.CanMigrateBaseBranch() || state.MigratableSessions != 2 {
+		t.Fatalf("Expected migration of 2 sessions to be offered, got %d", state.MigratableSessions)
+	}
+
+	m = sendKey(m, "u")
+	for _, id := range []string{cfg.Sessions[0].ID, cfg.Sessions[1].ID} {
+		if got := cfg.GetSession(id).BaseBranch; got != "main" {
+			t.Errorf("Session %s BaseBranch = %q after migration, want main", id, got)
+		}
+	}
+	if state.CanMigrateBaseBranch() {
+		t.Error("Expected migration offer to be cleared after migrating")
+	}
+	if !m.modal.IsVisible() {
+		t.Error("Expected the merge modal to stay open after migrating")
+	}
+}
+
 func TestMergeModal_Cancel(t *testing.T) {
 	cfg := testConfigWithSessions()
 	m := testModelWithSize(cfg, 120, 40)
@@ -1080,6 +1316,68 @@ func TestMergeConflictModal_Cancel(t *testing.T) {
 	}
 }
 
+func TestMergeConflictModal_AbortMerge(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	// A merge is in progress until it's aborted, and main is checked out
+	mock := pexec.NewMockExecutor(nil)
+	aborted := false
+	mock.AddRule(func(dir, name string, args []string) bool {
+		return !aborted && slices.Equal(args, []string{"rev-parse", "--verify", "MERGE_HEAD"})
+	}, pexec.MockResponse{Stdout: []byte("abc123\n")})
+	mock.AddExactMatch("git", []string{"rev-parse", "--verify", "MERGE_HEAD"}, pexec.MockResponse{Err: errors.New("not a merge")})
+	mock.AddRule(func(dir, name string, args []string) bool {
+		if slices.Equal(args, []string{"merge", "--abort"}) {
+			aborted = true
+			return true
+		}
+		return false
+	}, pexec.MockResponse{})
+	mock.AddExactMatch("git", []string{"rev-parse", "--abbrev-ref", "HEAD"}, pexec.MockResponse{Stdout: []byte("main\n")})
+	mock.AddPrefixMatch("git", []string{"checkout"}, pexec.MockResponse{})
+	m.gitService = git.NewGitServiceWithExecutor(mock)
+
+	state := ui.NewMergeConflictState("session-1", "repo1/session1", []string{"main.go"}, "/test/repo1")
+	state.OriginalBranch = "feature-branch"
+	m.modal.Show(state)
+
+	// "Abort merge" is the second option
+	m = sendKey(m, "down")
+	m = sendKey(m, "enter")
+	if m.modal.IsVisible() {
+		t.Fatal("Modal should close when aborting")
+	}
+	if m.sessionState().GetIfExists("session-1").GetMergeType() != manager.MergeTypeAbort {
+		t.Fatal("Expected an abort to be running")
+	}
+
+	for range 10 {
+		if !m.sessionState().GetIfExists("session-1").IsMerging() {
+			break
+		}
+		result, _ := m.Update(m.listenForMergeResult("session-1")())
+		m = result.(*Model)
+	}
+	if m.sessionState().GetIfExists("session-1").IsMerging() {
+		t.Fatal("Abort should have finished")
+	}
+
+	checkedOut := false
+	for _, call := range mock.GetCalls() {
+		if slices.Equal(call.Args, []string{"checkout", "feature-branch"}) {
+			checkedOut = true
+		}
+	}
+	if !checkedOut {
+		t.Error("Expected the original branch to be checked out")
+	}
+	if sess := m.config.GetSession("session-1"); sess.Merged {
+		t.Error("Aborting should not mark the session merged")
+	}
+}
+
 // =============================================================================
 // Import Issues Modal Tests (UI only - no actual GitHub calls)
 // =============================================================================
@@ -1401,6 +1699,30 @@ func TestSearchMessagesModal_Cancel(t *testing.T) {
 	}
 }
 
+func TestSessionSwitcherModal_SwitchesSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "ctrl+g")
+	if _, ok := m.modal.State.(*ui.SessionSwitcherState); !ok {
+		t.Fatalf("Expected SessionSwitcherState, got %T", m.modal.State)
+	}
+
+	m = typeText(m, "bugfix")
+	m = sendKey(m, "enter")
+
+	if m.modal.IsVisible() {
+		t.Error("Modal should close after switching")
+	}
+	if m.activeSession == nil || m.activeSession.ID != "session-3" {
+		t.Fatalf("Expected session-3 to be active, got %v", m.activeSession)
+	}
+	if sel := m.sidebar.SelectedSession(); sel == nil || sel.ID != "session-3" {
+		t.Error("Expected sidebar selection to follow the switch")
+	}
+}
+
 // =============================================================================
 // Session State Preservation Tests
 // =============================================================================
@@ -1862,7 +2184,7 @@ func TestCreateParallelSessions_InheritsContainerizedFlag(t *testing.T) {
 	// Set up mock executor for git and session services
 	mockExec := pexec.NewMockExecutor(nil)
 	// Mock git worktree add (used by CreateFromBranch)
-	mockExec.AddPrefixMatch("git", []string{"worktree", "add"}, pexec.MockResponse{
+	mockExec.AddPrefixMatch("env", []string{"GIT_LFS_FORCE_PROGRESS=1", "git", "worktree", "add"}, pexec.MockResponse{
 		Stdout: []byte("Preparing worktree\n"),
 	})
 	// Mock claude branch name generation (used by GenerateBranchNamesFromOptions)
@@ -1931,7 +2253,7 @@ func TestCreateParallelSessions_NonContainerizedParent(t *testing.T) {
 
 	// Set up mock executor
 	mockExec := pexec.NewMockExecutor(nil)
-	mockExec.AddPrefixMatch("git", []string{"worktree", "add"}, pexec.MockResponse{
+	mockExec.AddPrefixMatch("env", []string{"GIT_LFS_FORCE_PROGRESS=1", "git", "worktree", "add"}, pexec.MockResponse{
 		Stdout: []byte("Preparing worktree\n"),
 	})
 	mockExec.AddPrefixMatch("claude", []string{"--print"}, pexec.MockResponse{
@@ -1986,3 +2308,121 @@ func TestSessionSettingsModal_CancelWithEscape(t *testing.T) {
 		t.Error("expected modal to be hidden after escape")
 	}
 }
+
+func TestMergeModal_PrefillsPRBaseBranch(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].BaseBranch = "develop"
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddExactMatch("git", []string{"remote", "get-url", "origin"}, pexec.MockResponse{
+		Stdout: []byte("git@github.com:owner/repo.git\n"),
+	})
+	mockExec.AddExactMatch("git", []string{"branch", "-r"}, pexec.MockResponse{
+		Stdout: []byte("  origin/HEAD -> origin/main\n  origin/develop\n  origin/main\n  origin/release-2\n"),
+	})
+	m.SetGitService(git.NewGitServiceWithExecutor(mockExec))
+
+	// Remote branches are listed in the background
+	_, cmd := m.Update(keyPress("m"))
+	state, ok := m.modal.State.(*ui.MergeState)
+	if !ok {
+		t.Fatalf("Expected MergeState, got %T", m.modal.State)
+	}
+	if hasCall(mockExec, "/test/repo1", "branch", "-r") {
+		t.Error("Expected remote branches not to be listed while opening the modal")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected a batch of background commands")
+	}
+	var listed bool
+	for _, c := range batch {
+		if msg, ok := c().(RemoteBranchesMsg); ok {
+			m.Update(msg)
+			listed = true
+		}
+	}
+	if !listed {
+		t.Fatal("Expected a command listing the remote branches")
+	}
+	if !slices.Contains(state.Options, "Create PR") {
+		t.Fatalf("Expected 'Create PR' option with a remote, got %v", state.Options)
+	}
+	if got := state.GetPRBaseBranch(); got != "develop" {
+		t.Errorf("Expected PR base prefilled from session base branch, got %q", got)
+	}
+
+	// Select "Create PR", edit the base and complete it from the remote branches
+	for state.GetSelectedOption() != "Create PR" {
+		m = sendKey(m, "down")
+	}
+	m = sendKey(m, "tab")
+	if !state.BaseBranchFocused {
+		t.Fatal("Expected Tab to focus the base branch input")
+	}
+	state.BaseBranchInput.SetValue("")
+	m = typeText(m, "rel")
+	m = sendKey(m, "tab")
+	if got := state.GetPRBaseBranch(); got != "release-2" {
+		t.Errorf("Expected Tab to complete the base branch, got %q", got)
+	}
+}
+
+// =============================================================================
+// Context Files Modal Tests
+// =============================================================================
+
+func TestContextFilesModal_AddAndRemove(t *testing.T) {
+	cfg := testConfigWithSessions()
+	worktree := t.TempDir()
+	cfg.Sessions[0].WorkTree = worktree
+	if err := os.WriteFile(filepath.Join(worktree, "NOTES.md"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	sessionID := cfg.Sessions[0].ID
+
+	m = sendKey(m, "C")
+	state, ok := m.modal.State.(*ui.ContextFilesState)
+	if !ok {
+		t.Fatalf("Expected ContextFilesState, got %T", m.modal.State)
+	}
+
+	// Paths must exist in the worktree
+	m = typeText(m, "missing.md")
+	m = sendKey(m, "enter")
+	if !strings.Contains(state.Error, "does not exist") {
+		t.Errorf("Expected validation error, got %q", state.Error)
+	}
+	if len(cfg.GetSessionContextFiles(sessionID)) != 0 {
+		t.Error("Invalid path should not be added")
+	}
+
+	state.Input.SetValue("./NOTES.md")
+	m = sendKey(m, "enter")
+	if got := cfg.GetSessionContextFiles(sessionID); !slices.Equal(got, []string{"NOTES.md"}) {
+		t.Fatalf("Expected NOTES.md added, got %v", got)
+	}
+	if state.Error != "" || state.GetInput() != "" {
+		t.Errorf("Expected error and input cleared, got %q %q", state.Error, state.GetInput())
+	}
+
+	state.Input.SetValue("NOTES.md")
+	m = sendKey(m, "enter")
+	if !strings.Contains(state.Error, "already") {
+		t.Errorf("Expected duplicate error, got %q", state.Error)
+	}
+
+	m = sendKey(m, "ctrl+d")
+	if got := cfg.GetSessionContextFiles(sessionID); len(got) != 0 {
+		t.Errorf("Expected context file removed, got %v", got)
+	}
+
+	m = sendKey(m, "esc")
+	if m.modal.IsVisible() {
+		t.Error("Expected modal to close on Esc")
+	}
+}
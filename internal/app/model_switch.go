@@ -0,0 +1,75 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// shortcutSwitchModel opens the picker switching the model the selected
+// session's Claude runs as from its next message on.
+func shortcutSwitchModel(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	// A container's CLI can't resume its conversation, so restarting it with another model would lose it
+	if sess.Containerized {
+		return m, m.ShowFlashWarning("Container sessions can't switch models mid-conversation")
+	}
+	if runner := m.sessionMgr.GetRunner(sess.ID); runner != nil && runner.IsStreaming() {
+		return m, m.ShowFlashWarning("Wait for Claude to finish before switching models")
+	}
+	m.modal.Show(ui.NewModelPickerState(sess.ID, ui.SessionDisplayName(sess.Branch, sess.Name), sess.Model))
+	return m, nil
+}
+
+// handleModelPickerModal handles key events for the Switch Model modal.
+func (m *Model) handleModelPickerModal(key string, msg tea.KeyPressMsg, state *ui.ModelPickerState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		m.modal.Hide()
+		return m, m.switchModel(state.SessionID, state.SelectedModel())
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// switchModel switches a session's model from its next message on, saving it
+// so the session resumes with it, and marks the switch in the conversation.
+func (m *Model) switchModel(sessionID, model string) tea.Cmd {
+	sess := m.config.GetSession(sessionID)
+	if sess == nil || sess.Model == model {
+		return nil
+	}
+	if err := m.sessionMgr.SetModel(sessionID, model); err != nil {
+		logger.WithSession(sessionID).Error("failed to switch model", "error", err)
+		return m.ShowFlashError("Failed to switch model")
+	}
+
+	// Mark the switch where it happened, if there is a conversation to mark
+	marker := ui.ModelSwitchMarker(model)
+	if runner := m.sessionMgr.GetRunner(sessionID); runner != nil && len(runner.GetMessages()) > 0 {
+		runner.AddSystemMessage(claude.KindModelSwitch, marker)
+		if err := m.sessionMgr.SaveRunnerMessages(sessionID, runner); err != nil {
+			logger.WithSession(sessionID).Error("failed to save messages after switching model", "error", err)
+		}
+		if m.activeSession != nil && m.activeSession.ID == sessionID {
+			m.chat.AddSystemMessage(claude.KindModelSwitch, marker)
+		}
+	}
+	if m.activeSession != nil && m.activeSession.ID == sessionID {
+		m.activeSession.Model = model
+	}
+
+	if cmd := m.saveConfigOrFlash(); cmd != nil {
+		return cmd
+	}
+	if model == "" {
+		return m.ShowFlashSuccess("Switched to the default model")
+	}
+	return m.ShowFlashSuccess("Switched to " + model)
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestSwitchModel_MarksConversation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	cfg := testConfigWithSessions()
+	cfg.SetFilePath(filepath.Join(t.TempDir(), "config.json"))
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	sess := &cfg.Sessions[0]
+	runner := claude.NewMockRunner(sess.ID, true, []claude.Message{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}})
+	m.sessionMgr.SetRunner(sess.ID, runner)
+	m.activeSession = sess
+
+	m.ExecuteShortcut("K")
+	state, ok := m.modal.State.(*ui.ModelPickerState)
+	if !ok {
+		t.Fatalf("expected the model picker, got %T", m.modal.State)
+	}
+	state.SelectedIndex = len(state.Models) - 1 // opus
+	m = sendKey(m, "enter")
+
+	if got := runner.GetModel(); got != "opus" {
+		t.Errorf("runner model = %q, want opus", got)
+	}
+	if got := cfg.GetSession(sess.ID).Model; got != "opus" {
+		t.Errorf("saved model = %q, want opus", got)
+	}
+	messages := runner.GetMessages()
+	if last := messages[len(messages)-1]; last.Content != ui.ModelSwitchMarker("opus") || last.Role != claude.RoleSystem || last.Kind != claude.KindModelSwitch {
+		t.Errorf("expected the switch marked in the conversation, last message %q", last.Content)
+	}
+
+	// Picking the same model again changes nothing
+	m.switchModel(sess.ID, "opus")
+	if got := len(runner.GetMessages()); got != len(messages) {
+		t.Errorf("expected no second marker, got %d messages", got)
+	}
+}
+
+func TestSwitchModel_RefusedForContainers(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].Containerized = true
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m.ExecuteShortcut("K")
+	if m.modal.IsVisible() || !m.footer.HasFlash() {
+		t.Error("expected container sessions to be told they can't switch models")
+	}
+}
@@ -1,13 +1,15 @@
 package app
 
 import (
-	"context"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/activity"
 	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/config"
 	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/logger"
 	"github.com/zhubert/plural/internal/manager"
@@ -26,9 +28,13 @@ func (m *Model) handleClaudeResponseMsg(msg ClaudeResponseMsg) (tea.Model, tea.C
 	}
 
 	isActiveSession := m.activeSession != nil && m.activeSession.ID == msg.SessionID
+	m.publishShare(msg.SessionID)
+	if isActiveSession {
+		m.publishWebView()
+	}
 
 	if msg.Chunk.Error != nil {
-		return m.handleClaudeError(msg.SessionID, msg.Chunk.Error.Error(), isActiveSession)
+		return m.handleClaudeError(msg.SessionID, msg.Chunk.Error, isActiveSession)
 	}
 
 	if msg.Chunk.Done {
@@ -39,17 +45,20 @@ func (m *Model) handleClaudeResponseMsg(msg ClaudeResponseMsg) (tea.Model, tea.C
 }
 
 // handleClaudeError handles error responses from Claude.
-func (m *Model) handleClaudeError(sessionID string, errMsg string, isActiveSession bool) (tea.Model, tea.Cmd) {
+func (m *Model) handleClaudeError(sessionID string, err error, isActiveSession bool) (tea.Model, tea.Cmd) {
+	errMsg := err.Error()
 	logger.WithSession(sessionID).Error("error in session", "error", errMsg)
+	m.recordActivity(sessionID, activity.KindError, activity.SeverityError, "Error: "+errMsg)
 	m.sidebar.SetStreaming(sessionID, false)
 	m.sessionState().StopWaiting(sessionID)
 
 	if isActiveSession {
 		m.chat.SetWaiting(false)
-		m.chat.AppendStreaming("\n[Error: " + errMsg + "]")
+		m.chat.AppendError(ui.ErrorClass(err), errMsg)
 	} else {
-		// Store error for non-active session
-		m.sessionState().GetOrCreate(sessionID).AppendStreamingContent("\n[Error: " + errMsg + "]")
+		// Store error for non-active session, merged into the same error before it
+		state := m.sessionState().GetOrCreate(sessionID)
+		state.SetStreamingContent(ui.AppendErrorLine(state.GetStreamingContent(), errMsg, time.Now()))
 	}
 
 	// Check if any sessions are still streaming
@@ -63,8 +72,11 @@ func (m *Model) handleClaudeError(sessionID string, errMsg string, isActiveSessi
 // handleClaudeDone handles completion of Claude streaming.
 func (m *Model) handleClaudeDone(sessionID string, runner claude.RunnerInterface, isActiveSession bool) (tea.Model, tea.Cmd) {
 	logger.WithSession(sessionID).Info("completed streaming")
+	m.recordActivity(sessionID, activity.KindResponseCompleted, activity.SeveritySuccess, "Response completed")
 	m.sidebar.SetStreaming(sessionID, false)
 	m.sidebar.SetIdleWithResponse(sessionID, true)
+	// Claude may have created or deleted files
+	m.invalidateFileIndex(sessionID)
 
 	// Flush any pending tool uses, clear streaming content, and clear subagent indicator
 	if state := m.sessionState().GetIfExists(sessionID); state != nil {
@@ -80,8 +92,11 @@ func (m *Model) handleClaudeDone(sessionID string, runner claude.RunnerInterface
 		m.chat.FinishStreaming()
 		// Clear subagent indicator
 		m.chat.ClearSubagentModel()
-		// Start completion flash animation
-		completionCmd = m.chat.StartCompletionFlash()
+		// Start completion flash animation, and play the completion sound if configured
+		completionCmd = tea.Batch(m.chat.StartCompletionFlash(), m.completionSound())
+		if messages := m.chat.GetMessages(); m.inline() && len(messages) > 0 && messages[len(messages)-1].Role == "assistant" {
+			completionCmd = tea.Batch(completionCmd, m.printToScrollback("assistant", messages[len(messages)-1].Content))
+		}
 
 		// Refresh diff stats after Claude finishes (files may have changed)
 		m.refreshDiffStats()
@@ -90,6 +105,7 @@ func (m *Model) handleClaudeDone(sessionID string, runner claude.RunnerInterface
 	// Mark session as started and save messages
 	sess := m.sessionMgr.GetSession(sessionID)
 	if sess != nil && runner.SessionStarted() {
+		m.config.SetSessionLastActivity(sess.ID, time.Now())
 		if !sess.Started {
 			m.config.MarkSessionStarted(sess.ID)
 			sess.Started = true
@@ -102,12 +118,12 @@ func (m *Model) handleClaudeDone(sessionID string, runner claude.RunnerInterface
 			if isActiveSession {
 				m.chat.SetContainerInitializing(false, time.Time{})
 			}
-			if cmd := m.saveConfigOrFlash(); cmd != nil {
-				if completionCmd != nil {
-					completionCmd = tea.Batch(completionCmd, cmd)
-				} else {
-					completionCmd = cmd
-				}
+		}
+		if cmd := m.saveConfigOrFlash(); cmd != nil {
+			if completionCmd != nil {
+				completionCmd = tea.Batch(completionCmd, cmd)
+			} else {
+				completionCmd = cmd
 			}
 		}
 		// Save messages for this session
@@ -134,7 +150,8 @@ func (m *Model) handleClaudeDone(sessionID string, runner claude.RunnerInterface
 	m.detectOptionsInSession(sessionID, runner)
 
 	// Send desktop notification if window is not focused and notifications are enabled
-	if !m.windowFocused && m.config.GetNotificationsEnabled() {
+	// (held back for other sessions in focus mode, which summarizes them on leaving)
+	if !m.windowFocused && m.config.GetNotificationsEnabled() && !m.heldByFocusMode(sessionID) {
 		sessionName := sessionID
 		if sess != nil {
 			sessionName = ui.SessionDisplayName(sess.Branch, sess.Name)
@@ -172,6 +189,18 @@ func (m *Model) handleClaudeStreaming(sessionID string, chunk claude.ResponseChu
 		state.SetWaitStartTime(time.Time{})
 	}
 
+	// Final result stats close out a turn - retain them for the session activity sparkline
+	var statsSaveCmd tea.Cmd
+	if chunk.Type == claude.ChunkTypeStreamStats && isFinalStreamStats(chunk.Stats) {
+		if m.config.AddSessionTurnStats(sessionID, config.TurnStats{
+			CompletedAt:  time.Now(),
+			OutputTokens: chunk.Stats.OutputTokens,
+			DurationMs:   chunk.Stats.DurationMs,
+		}) {
+			statsSaveCmd = m.saveConfigOrFlash()
+		}
+	}
+
 	if isActiveSession {
 		m.chat.SetWaiting(false)
 		// Handle different chunk types
@@ -187,8 +216,10 @@ func (m *Model) handleClaudeStreaming(sessionID string, chunk claude.ResponseChu
 		case claude.ChunkTypeTodoUpdate:
 			// Update the todo list display
 			if chunk.TodoList != nil {
-				m.sessionState().GetOrCreate(sessionID).SetCurrentTodoList(chunk.TodoList)
-				m.chat.SetTodoList(chunk.TodoList)
+				// Keep the user's marks on items Claude hasn't changed
+				list := claude.MergeUserMarks(m.chat.GetTodoList(), chunk.TodoList)
+				m.sessionState().GetOrCreate(sessionID).SetCurrentTodoList(list)
+				m.chat.SetTodoList(list)
 			}
 		case claude.ChunkTypeStreamStats:
 			// Update streaming statistics display
@@ -214,9 +245,24 @@ func (m *Model) handleClaudeStreaming(sessionID string, chunk claude.ResponseChu
 		// Store streaming content for non-active session
 		m.handleNonActiveSessionStreaming(sessionID, chunk)
 	}
+	externalWriteCmd := m.trackExternalWrites(sessionID, chunk, isActiveSession)
+	m.trackTouchedFiles(sessionID, chunk)
 
 	// Continue listening for more chunks from this session
-	return m, tea.Batch(m.sessionListeners(sessionID, runner, nil)...)
+	cmds := m.sessionListeners(sessionID, runner, nil)
+	if statsSaveCmd != nil {
+		cmds = append(cmds, statsSaveCmd)
+	}
+	if externalWriteCmd != nil {
+		cmds = append(cmds, externalWriteCmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// isFinalStreamStats reports whether stats came from Claude's result message.
+// Intermediate stats emitted during streaming carry neither duration nor cost.
+func isFinalStreamStats(stats *claude.StreamStats) bool {
+	return stats != nil && (stats.DurationMs > 0 || stats.TotalCostUSD > 0)
 }
 
 // handleNonActiveSessionStreaming handles streaming content for non-active sessions.
@@ -240,7 +286,7 @@ func (m *Model) handleNonActiveSessionStreaming(sessionID string, chunk claude.R
 	case claude.ChunkTypeTodoUpdate:
 		// Store todo list for non-active session
 		if chunk.TodoList != nil {
-			state.SetCurrentTodoList(chunk.TodoList)
+			state.SetCurrentTodoList(claude.MergeUserMarks(state.GetCurrentTodoList(), chunk.TodoList))
 		}
 
 	case claude.ChunkTypeSubagentStatus:
@@ -267,12 +313,22 @@ func (m *Model) handleNonActiveSessionStreaming(sessionID string, chunk claude.R
 func (m *Model) handleMergeResultMsg(msg MergeResultMsg) (tea.Model, tea.Cmd) {
 	isActiveSession := m.activeSession != nil && m.activeSession.ID == msg.SessionID
 
+	var stepCmd tea.Cmd
+	if msg.Result.PRStep != nil {
+		stepCmd = m.recordPRStep(msg.SessionID, msg.Result.PRStep)
+	}
+	if msg.Result.KeptStash != "" {
+		stepCmd = m.ShowFlashWarning("Uncommitted main repo changes were left in stash " + git.ShortSHA(msg.Result.KeptStash))
+	}
+
 	if msg.Result.Error != nil {
-		return m.handleMergeError(msg.SessionID, msg.Result, isActiveSession)
+		model, cmd := m.handleMergeError(msg.SessionID, msg.Result, isActiveSession)
+		return model, tea.Batch(stepCmd, cmd)
 	}
 
 	if msg.Result.Done {
-		return m.handleMergeDone(msg.SessionID, isActiveSession)
+		model, cmd := m.handleMergeDone(msg.SessionID, msg.Result.PRURL, isActiveSession)
+		return model, tea.Batch(stepCmd, cmd)
 	}
 
 	// Still receiving merge output
@@ -281,11 +337,15 @@ func (m *Model) handleMergeResultMsg(msg MergeResultMsg) (tea.Model, tea.Cmd) {
 	} else {
 		m.sessionState().GetOrCreate(msg.SessionID).AppendStreamingContent(msg.Result.Output)
 	}
-	return m, m.listenForMergeResult(msg.SessionID)
+	return m, tea.Batch(stepCmd, m.listenForMergeResult(msg.SessionID))
 }
 
 // handleMergeError handles merge operation errors.
 func (m *Model) handleMergeError(sessionID string, result git.Result, isActiveSession bool) (tea.Model, tea.Cmd) {
+	// Hooks and archiving only follow a merge or PR that succeeded
+	delete(m.skipHooks, sessionID)
+	delete(m.archiveAfterMerge, sessionID)
+
 	// Check if this is a merge conflict with conflicted files
 	if len(result.ConflictedFiles) > 0 {
 		// Show conflict resolution modal
@@ -295,13 +355,24 @@ func (m *Model) handleMergeError(sessionID string, result git.Result, isActiveSe
 			sessionName = ui.SessionDisplayName(sess.Branch, sess.Name)
 		}
 		logger.WithSession(sessionID).Warn("merge conflict detected", "files", result.ConflictedFiles)
-		m.modal.Show(ui.NewMergeConflictState(sessionID, sessionName, result.ConflictedFiles, result.RepoPath))
+		m.recordActivity(sessionID, activity.KindError, activity.SeverityWarning, fmt.Sprintf("Merge conflict in %d file(s)", len(result.ConflictedFiles)))
+		conflictState := ui.NewMergeConflictState(sessionID, sessionName, result.ConflictedFiles, result.RepoPath)
+		conflictState.OriginalBranch = result.OriginalBranch
+		if state := m.sessionState().GetIfExists(sessionID); state != nil && state.GetMergeType() == manager.MergeTypeRebase {
+			conflictState.SetRebase()
+		}
+		m.modal.Show(conflictState)
 		// Clean up merge state
 		m.sessionState().StopMerge(sessionID)
 		return m, nil
 	}
 
-	// Regular error (not a conflict)
+	// Regular error (not a conflict). Errors raised before the first PR step
+	// (e.g., gh not installed) would otherwise leave the progress modal spinning.
+	if progressState, ok := m.modal.State.(*ui.PRProgressState); ok && progressState.SessionID == sessionID && !progressState.Failed() {
+		m.modal.Hide()
+	}
+	m.recordActivity(sessionID, activity.KindError, activity.SeverityError, "Merge failed: "+result.Error.Error())
 	if isActiveSession {
 		m.chat.AppendStreaming("\n[Error: " + result.Error.Error() + "]\n")
 	} else {
@@ -313,27 +384,40 @@ func (m *Model) handleMergeError(sessionID string, result git.Result, isActiveSe
 	return m, nil
 }
 
-// handleMergeDone handles successful completion of merge operations.
-func (m *Model) handleMergeDone(sessionID string, isActiveSession bool) (tea.Model, tea.Cmd) {
-	var cmds []tea.Cmd
-
+// finishMergeOutput ends the streamed output of a merge, or of the hooks run
+// after it, keeping it as a system message: in the chat if the session is shown,
+// otherwise in its transcript for when the user switches back.
+func (m *Model) finishMergeOutput(sessionID string, isActiveSession bool) tea.Cmd {
 	if isActiveSession {
-		m.chat.FinishStreaming()
-	} else {
-		// Store completed merge output as a message for when user switches back
-		if state := m.sessionState().GetIfExists(sessionID); state != nil {
-			content := state.GetStreamingContent()
-			if content != "" {
-				if runner := m.sessionMgr.GetRunner(sessionID); runner != nil {
-					runner.AddAssistantMessage(content)
-					if err := m.sessionMgr.SaveRunnerMessages(sessionID, runner); err != nil {
-						cmds = append(cmds, m.ShowFlashError("Failed to save session messages"))
-					}
-				}
-				state.SetStreamingContent("")
-			}
+		m.chat.FinishSystemOutput(claude.KindMerge)
+		return nil
+	}
+	state := m.sessionState().GetIfExists(sessionID)
+	if state == nil {
+		return nil
+	}
+	content := state.GetStreamingContent()
+	if content == "" {
+		return nil
+	}
+	var cmd tea.Cmd
+	if runner := m.sessionMgr.GetRunner(sessionID); runner != nil {
+		runner.AddSystemMessage(claude.KindMerge, content)
+		if err := m.sessionMgr.SaveRunnerMessages(sessionID, runner); err != nil {
+			cmd = m.ShowFlashError("Failed to save session messages")
 		}
 	}
+	state.SetStreamingContent("")
+	return cmd
+}
+
+// handleMergeDone handles successful completion of merge operations. prURL is
+// the URL of the PR created, if any.
+func (m *Model) handleMergeDone(sessionID, prURL string, isActiveSession bool) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	if cmd := m.finishMergeOutput(sessionID, isActiveSession); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
 
 	// Mark session as merged or PR created based on operation type
 	log := logger.WithSession(sessionID)
@@ -342,13 +426,30 @@ func (m *Model) handleMergeDone(sessionID string, isActiveSession bool) (tea.Mod
 	if state != nil {
 		mergeType = state.GetMergeType()
 	}
+	// Repo hooks follow a merge to main or a new PR, unless skipped in the merge modal
+	skipHooks := m.skipHooks[sessionID]
+	delete(m.skipHooks, sessionID)
+	if !skipHooks && (mergeType == manager.MergeTypeMerge || mergeType == manager.MergeTypeRebase || mergeType == manager.MergeTypePR) {
+		// Started before the PR progress, which has the PR's base, is cleared
+		if cmd := m.startHooks(sessionID, mergeType, prURL); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
 	switch mergeType {
 	case manager.MergeTypePR:
 		m.config.MarkSessionPRCreated(sessionID)
+		m.config.SetSessionPRProgress(sessionID, nil)
 		log.Info("marked session as PR created")
-	case manager.MergeTypeMerge:
+		if progressState, ok := m.modal.State.(*ui.PRProgressState); ok && progressState.SessionID == sessionID {
+			m.modal.Hide()
+		}
+		m.recordActivity(sessionID, activity.KindPRCreated, activity.SeveritySuccess, "Pull request created")
+	case manager.MergeTypeAbort:
+		log.Info("aborted conflicted merge or rebase")
+	case manager.MergeTypeMerge, manager.MergeTypeRebase:
 		m.config.MarkSessionMerged(sessionID)
-		log.Info("marked session as merged")
+		log.Info("marked session as merged", "mergeType", mergeType)
+		m.recordActivity(sessionID, activity.KindMerged, activity.SeveritySuccess, "Merged to main")
 	case manager.MergeTypeParent:
 		// Get child session to find parent
 		childSess := m.config.GetSession(sessionID)
@@ -363,6 +464,7 @@ func (m *Model) handleMergeDone(sessionID string, isActiveSession bool) (tea.Mod
 		}
 		m.config.MarkSessionMergedToParent(sessionID)
 		log.Info("marked session as merged to parent")
+		m.recordActivity(sessionID, activity.KindMerged, activity.SeveritySuccess, "Merged to parent")
 	}
 
 	if err := m.config.Save(); err != nil {
@@ -374,6 +476,12 @@ func (m *Model) handleMergeDone(sessionID string, isActiveSession bool) (tea.Mod
 	// Clean up merge state for this session
 	m.sessionState().StopMerge(sessionID)
 
+	if mergeType == manager.MergeTypeMerge || mergeType == manager.MergeTypeRebase || mergeType == manager.MergeTypeParent {
+		if cmd := m.archiveAfterMergeDone(sessionID); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+
 	if len(cmds) > 0 {
 		return m, tea.Batch(cmds...)
 	}
@@ -395,9 +503,10 @@ func (m *Model) handleSendPendingMessageMsg(msg SendPendingMessageMsg) (tea.Mode
 		return m, nil
 	}
 
-	// Check if session is currently busy (e.g., merge in progress or already streaming again)
+	// Check if session is currently busy (e.g., merge in progress or already streaming
+	// again), or paused; resuming sends the message
 	state := m.sessionState().GetIfExists(msg.SessionID)
-	if state != nil && (state.GetIsWaiting() || state.IsMerging()) {
+	if state != nil && (state.GetIsWaiting() || state.IsMerging() || m.paused) {
 		// Re-queue the message to try again later
 		state.SetPendingMsg(pendingMsg)
 		return m, nil
@@ -419,21 +528,16 @@ func (m *Model) handleSendPendingMessageMsg(msg SendPendingMessageMsg) (tea.Mode
 		m.chat.AddUserMessage(pendingMsg)
 	}
 
-	// Create context and start streaming
-	ctx, cancel := context.WithCancel(context.Background())
-	m.sessionState().StartWaiting(msg.SessionID, cancel)
+	// Send the message (the pause was checked above)
+	content := []claude.ContentBlock{{Type: claude.ContentTypeText, Text: pendingMsg}}
+	listenCmds, _ := m.startTurn(msg.SessionID, runner, content)
 	startTime, _ := m.sessionState().GetWaitStart(msg.SessionID)
 	if isActiveSession {
 		m.chat.SetWaitingWithStart(true, startTime)
 	}
-	m.sidebar.SetStreaming(msg.SessionID, true)
 	m.setState(StateStreamingClaude)
 
-	// Send the message
-	content := []claude.ContentBlock{{Type: claude.ContentTypeText, Text: pendingMsg}}
-	responseChan := runner.SendContent(ctx, content)
-
-	cmds := append(m.sessionListeners(msg.SessionID, runner, responseChan),
+	cmds := append(listenCmds,
 		m.sidebar.SidebarTick(),
 		m.chat.SpinnerTick(),
 	)
@@ -453,12 +557,13 @@ func (m *Model) handlePermissionRequestMsg(msg PermissionRequestMsg) (tea.Model,
 
 	// Store permission request for this session (inline, not modal)
 	log.Debug("permission request received", "tool", msg.Request.Tool)
+	m.recordActivity(msg.SessionID, activity.KindPermissionRequested, activity.SeverityWarning, "Permission requested: "+msg.Request.Tool)
 	m.sessionState().GetOrCreate(msg.SessionID).SetPendingPermission(&msg.Request)
 	m.sidebar.SetPendingPermission(msg.SessionID, true)
 
 	// If this is the active session, show permission in chat
 	if m.activeSession != nil && m.activeSession.ID == msg.SessionID {
-		m.chat.SetPendingPermission(msg.Request.Tool, msg.Request.Description)
+		m.showPendingPermission(msg.SessionID, &msg.Request)
 	}
 
 	// Continue listening for session events
@@ -478,17 +583,29 @@ func (m *Model) handleQuestionRequestMsg(msg QuestionRequestMsg) (tea.Model, tea
 
 	// Store question request for this session
 	log.Debug("question request received", "questionCount", len(msg.Request.Questions))
+	m.recordActivity(msg.SessionID, activity.KindQuestionAsked, activity.SeverityWarning, "Question asked")
 	m.sessionState().GetOrCreate(msg.SessionID).SetPendingQuestion(&msg.Request)
+
+	// Continue listening for session events
+	cmds := m.sessionListeners(msg.SessionID, runner, nil)
+
+	// Questions fully covered by auto-answer rules are answered after a grace period
+	answers, auto, rulesCmd := m.applyQuestionRules(msg.SessionID, msg.Request.Questions)
+	cmds = append(cmds, rulesCmd)
+	if auto {
+		return m, tea.Batch(cmds...)
+	}
+
 	m.sidebar.SetPendingPermission(msg.SessionID, true) // Reuse permission indicator for questions
 	m.sidebar.SetPendingQuestion(msg.SessionID, true)
 
-	// If this is the active session, show question in chat
+	// If this is the active session, show question in chat with any rule answers pre-selected
 	if m.activeSession != nil && m.activeSession.ID == msg.SessionID {
 		m.chat.SetPendingQuestion(msg.Request.Questions)
+		m.chat.PreselectQuestionAnswers(answers)
 	}
 
-	// Continue listening for session events
-	return m, tea.Batch(m.sessionListeners(msg.SessionID, runner, nil)...)
+	return m, tea.Batch(cmds...)
 }
 
 // handlePlanApprovalRequestMsg handles plan approval requests from Claude.
@@ -505,6 +622,13 @@ func (m *Model) handlePlanApprovalRequestMsg(msg PlanApprovalRequestMsg) (tea.Mo
 	// Store plan approval request for this session
 	log.Debug("plan approval request received", "planChars", len(msg.Request.Plan), "allowedPrompts", len(msg.Request.AllowedPrompts))
 	m.sessionState().GetOrCreate(msg.SessionID).SetPendingPlanApproval(&msg.Request)
+
+	// Plans meeting the repo's criteria are approved without asking, if the session opted in
+	if m.shouldAutoApprovePlan(msg.SessionID, msg.Request) {
+		return m.autoApprovePlan(msg.SessionID)
+	}
+
+	m.recordActivity(msg.SessionID, activity.KindPlanApprovalRequired, activity.SeverityWarning, "Plan approval requested")
 	m.sidebar.SetPendingPermission(msg.SessionID, true) // Reuse permission indicator for plan approval
 
 	// If this is the active session, show plan approval in chat
@@ -624,12 +748,14 @@ func (m *Model) handlePRBatchStatusCheckMsg(msg PRBatchStatusCheckMsg) (tea.Mode
 		case git.PRStateMerged:
 			log.Info("PR merged on GitHub", "session", sessionName)
 			m.config.MarkSessionPRMerged(result.SessionID)
+			m.recordActivity(result.SessionID, activity.KindPRMerged, activity.SeveritySuccess, "PR merged on GitHub")
 			changed = true
 			cmds = append(cmds, m.ShowFlashSuccess("PR merged: "+sessionName))
 
 		case git.PRStateClosed:
 			log.Info("PR closed on GitHub", "session", sessionName)
 			m.config.MarkSessionPRClosed(result.SessionID)
+			m.recordActivity(result.SessionID, activity.KindPRClosed, activity.SeverityWarning, "PR closed on GitHub")
 			changed = true
 			cmds = append(cmds, m.ShowFlashWarning("PR closed: "+sessionName))
 
@@ -647,6 +773,9 @@ func (m *Model) handlePRBatchStatusCheckMsg(msg PRBatchStatusCheckMsg) (tea.Mode
 				m.config.UpdateSessionPRCommentCount(result.SessionID, result.CommentCount)
 				changed = true
 			}
+			if cmd := m.trackCIStatus(result.SessionID, result.CI); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 	}
 
@@ -141,6 +141,44 @@ func TestNonActiveSessionStreaming_TextChunk(t *testing.T) {
 	}
 }
 
+func TestStreamStats_RecordsTurnStatsForFinalResult(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	// Select session-1 (the active session) and register a runner for non-active session-3
+	m = sendKey(m, "enter")
+	m.sessionMgr.GetOrCreateRunner(&cfg.Sessions[2])
+
+	// Intermediate stats during streaming carry no duration or cost and are not recorded
+	m = simulateClaudeResponse(m, "session-1", claude.ResponseChunk{
+		Type:  claude.ChunkTypeStreamStats,
+		Stats: &claude.StreamStats{OutputTokens: 50},
+	})
+	if sess := m.config.GetSession("session-1"); len(sess.TurnStats) != 0 {
+		t.Fatalf("intermediate stats should not be recorded, got %+v", sess.TurnStats)
+	}
+
+	// Final stats are recorded for both active and non-active sessions
+	m = simulateClaudeResponse(m, "session-1", claude.ResponseChunk{
+		Type:  claude.ChunkTypeStreamStats,
+		Stats: &claude.StreamStats{OutputTokens: 1200, DurationMs: 4500, TotalCostUSD: 0.02},
+	})
+	m = simulateClaudeResponse(m, "session-3", claude.ResponseChunk{
+		Type:  claude.ChunkTypeStreamStats,
+		Stats: &claude.StreamStats{OutputTokens: 300, DurationMs: 900},
+	})
+
+	sess := m.config.GetSession("session-1")
+	if len(sess.TurnStats) != 1 || sess.TurnStats[0].OutputTokens != 1200 || sess.TurnStats[0].DurationMs != 4500 {
+		t.Errorf("unexpected session-1 turn stats: %+v", sess.TurnStats)
+	}
+	sess = m.config.GetSession("session-3")
+	if len(sess.TurnStats) != 1 || sess.TurnStats[0].OutputTokens != 300 {
+		t.Errorf("unexpected session-3 turn stats: %+v", sess.TurnStats)
+	}
+}
+
 func TestNonActiveSessionStreaming_ToolUseChunk(t *testing.T) {
 	cfg := testConfigWithSessions()
 	m, _ := testModelWithMocks(cfg, 120, 40)
@@ -787,3 +825,25 @@ func TestClose_NoPanic(t *testing.T) {
 	m.Close()
 }
 
+
+func TestAutosaveDoneMsg_FlashesFirstFailureOnly(t *testing.T) {
+	cfg := testConfig()
+	m := testModelWithSize(cfg, 120, 40)
+
+	m.Update(AutosaveDoneMsg{Error: errors.New("disk full")})
+	if !m.footer.HasFlash() {
+		t.Fatal("Expected first autosave failure to flash")
+	}
+
+	m.footer.ClearFlash()
+	m.Update(AutosaveDoneMsg{Error: errors.New("disk full")})
+	if m.footer.HasFlash() {
+		t.Error("Expected repeated autosave failure not to flash again")
+	}
+
+	m.Update(AutosaveDoneMsg{Saved: 1})
+	m.Update(AutosaveDoneMsg{Error: errors.New("disk full")})
+	if !m.footer.HasFlash() {
+		t.Error("Expected failure after a successful autosave to flash")
+	}
+}
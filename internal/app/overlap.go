@@ -0,0 +1,176 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+	"github.com/zhubert/plural/internal/workpool"
+)
+
+const overlapPollInterval = 30 * time.Second
+
+// ChangedFilesMsg carries the changed files of the sessions that were checked.
+// Sessions whose status could not be read are absent and keep their cached files.
+type ChangedFilesMsg struct {
+	Files map[string][]string // Session ID -> paths with uncommitted changes
+}
+
+// FileOverlap is another session of the same repo that changed some of the same files.
+type FileOverlap struct {
+	SessionID string
+	Files     []string // Paths changed by both sessions, sorted
+}
+
+// overlapCandidates returns the sessions whose changes could still conflict on merge:
+// unmerged sessions with a worktree, in repos with at least two such sessions.
+func overlapCandidates(sessions []config.Session) []config.Session {
+	perRepo := make(map[string]int)
+	var active []config.Session
+	for _, sess := range sessions {
+		if sess.IsMerged() || sess.WorkTree == "" {
+			continue
+		}
+		perRepo[sess.RepoPath]++
+		active = append(active, sess)
+	}
+	var candidates []config.Session
+	for _, sess := range active {
+		if perRepo[sess.RepoPath] > 1 {
+			candidates = append(candidates, sess)
+		}
+	}
+	return candidates
+}
+
+// fetchChangedFiles returns a command that reads the worktree status of every
+// overlap candidate in the background, up to limit at a time, until ctx is
+// canceled. Returns nil if there are none.
+func fetchChangedFiles(ctx context.Context, sessions []config.Session, gitSvc *git.GitService, limit int) tea.Cmd {
+	candidates := overlapCandidates(sessions)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		statuses := make([][]string, len(candidates))
+		errs := workpool.Run(ctx, len(candidates), workpool.Options{Limit: limit}, func(ctx context.Context, i int) error {
+			status, err := gitSvc.GetWorktreeStatus(ctx, candidates[i].WorkTree)
+			if err != nil {
+				return err
+			}
+			statuses[i] = status.Files
+			return nil
+		})
+
+		files := make(map[string][]string, len(candidates))
+		for i, sess := range candidates {
+			if errs[i] != nil {
+				logger.WithSession(sess.ID).Debug("failed to read worktree status for overlap check", "error", errs[i])
+				continue
+			}
+			files[sess.ID] = statuses[i]
+		}
+		return ChangedFilesMsg{Files: files}
+	}
+}
+
+// detectOverlaps intersects the cached changed files of sessions in the same repo.
+// Returns, for each session with overlaps, the other sessions and the shared paths.
+func detectOverlaps(sessions []config.Session, changed map[string][]string) map[string][]FileOverlap {
+	overlaps := make(map[string][]FileOverlap)
+	candidates := overlapCandidates(sessions)
+	for i, a := range candidates {
+		if len(changed[a.ID]) == 0 {
+			continue
+		}
+		aFiles := make(map[string]bool, len(changed[a.ID]))
+		for _, f := range changed[a.ID] {
+			aFiles[f] = true
+		}
+		for _, b := range candidates[i+1:] {
+			if b.RepoPath != a.RepoPath {
+				continue
+			}
+			var shared []string
+			for _, f := range changed[b.ID] {
+				if aFiles[f] {
+					shared = append(shared, f)
+				}
+			}
+			if len(shared) == 0 {
+				continue
+			}
+			slices.Sort(shared)
+			shared = slices.Compact(shared)
+			overlaps[a.ID] = append(overlaps[a.ID], FileOverlap{SessionID: b.ID, Files: shared})
+			overlaps[b.ID] = append(overlaps[b.ID], FileOverlap{SessionID: a.ID, Files: shared})
+		}
+	}
+	return overlaps
+}
+
+// pollChangedFiles returns a command refreshing the changed files of every session.
+func (m *Model) pollChangedFiles() tea.Cmd {
+	return fetchChangedFiles(m.scheduler.backgroundContext(), m.config.GetSessions(), m.gitService, m.config.GetMaxConcurrentJobs())
+}
+
+// handleChangedFilesMsg caches freshly read changed files and updates the overlap badges.
+func (m *Model) handleChangedFilesMsg(msg ChangedFilesMsg) (tea.Model, tea.Cmd) {
+	m.invalidateChangedFileIndexes(msg.Files)
+	for id, files := range msg.Files {
+		m.changedFiles[id] = files
+	}
+	m.refreshOverlaps()
+	return m, nil
+}
+
+// refreshOverlaps recomputes overlaps from the cached changed files and marks the
+// affected sessions in the sidebar.
+func (m *Model) refreshOverlaps() {
+	sessions := m.config.GetSessions()
+	overlaps := detectOverlaps(sessions, m.changedFiles)
+	for _, sess := range sessions {
+		m.sidebar.SetFileOverlap(sess.ID, len(overlaps[sess.ID]) > 0)
+	}
+	m.overlaps = overlaps
+	m.sidebar.SetSessions(m.getFilteredSessions())
+}
+
+// overlapItems returns a session's overlaps with display names, for the modals.
+func (m *Model) overlapItems(sessionID string) []ui.FileOverlapItem {
+	var items []ui.FileOverlapItem
+	for _, o := range m.overlaps[sessionID] {
+		name := o.SessionID
+		if other := m.config.GetSession(o.SessionID); other != nil {
+			name = ui.SessionDisplayName(other.Branch, other.Name)
+		}
+		items = append(items, ui.FileOverlapItem{SessionName: name, Files: o.Files})
+	}
+	return items
+}
+
+// shortcutShowOverlaps lists the files the selected session changed that other sessions changed too.
+func shortcutShowOverlaps(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	m.modal.Show(ui.NewFileOverlapState(ui.SessionDisplayName(sess.Branch, sess.Name), m.overlapItems(sess.ID)))
+	return m, nil
+}
+
+// handleFileOverlapModal handles key events for the overlapping changes modal.
+func (m *Model) handleFileOverlapModal(key string, msg tea.KeyPressMsg, state *ui.FileOverlapState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape, keys.Enter, "q":
+		m.modal.Hide()
+	}
+	return m, nil
+}
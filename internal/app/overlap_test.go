@@ -0,0 +1,162 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	pexec "github.com/zhubert/plural/internal/exec"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestDetectOverlaps(t *testing.T) {
+	sessions := []config.Session{
+		{ID: "a", RepoPath: "/repo1", WorkTree: "/wt/a"},
+		{ID: "b", RepoPath: "/repo1", WorkTree: "/wt/b"},
+		{ID: "c", RepoPath: "/repo1", WorkTree: "/wt/c"},
+		{ID: "d", RepoPath: "/repo2", WorkTree: "/wt/d"},
+		{ID: "merged", RepoPath: "/repo1", WorkTree: "/wt/merged", Merged: true},
+	}
+	changed := map[string][]string{
+		"a":      {"main.go", "util.go", "README.md"},
+		"b":      {"util.go", "main.go"},
+		"c":      {"other.go"},
+		"d":      {"main.go"},   // Same path, different repo
+		"merged": {"README.md"}, // Already merged, can no longer conflict
+	}
+
+	got := detectOverlaps(sessions, changed)
+	want := map[string][]FileOverlap{
+		"a": {{SessionID: "b", Files: []string{"main.go", "util.go"}}},
+		"b": {{SessionID: "a", Files: []string{"main.go", "util.go"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("detectOverlaps() = %+v, want %+v", got, want)
+	}
+
+	if got := detectOverlaps(sessions, nil); len(got) != 0 {
+		t.Errorf("expected no overlaps without cached files, got %+v", got)
+	}
+}
+
+func TestOverlapCandidates_SkipsLoneSessions(t *testing.T) {
+	cfg := testConfigWithSessions()
+	var ids []string
+	for _, sess := range overlapCandidates(cfg.Sessions) {
+		ids = append(ids, sess.ID)
+	}
+	// session-3 is the only session of its repo, so it cannot overlap
+	if !reflect.DeepEqual(ids, []string{"session-1", "session-2"}) {
+		t.Errorf("overlapCandidates() = %v, want [session-1 session-2]", ids)
+	}
+}
+
+func TestFetchChangedFiles(t *testing.T) {
+	cfg := testConfigWithSessions()
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddExactMatch("git", []string{"status", "--porcelain"}, pexec.MockResponse{
+		Stdout: []byte(" M main.go\n?? notes.md\n"),
+	})
+
+	cmd := fetchChangedFiles(context.Background(), cfg.Sessions, git.NewGitServiceWithExecutor(mockExec), 0)
+	if cmd == nil {
+		t.Fatal("expected a command for repos with several sessions")
+	}
+	msg, ok := cmd().(ChangedFilesMsg)
+	if !ok {
+		t.Fatalf("expected ChangedFilesMsg, got %T", msg)
+	}
+	want := map[string][]string{
+		"session-1": {"main.go", "notes.md"},
+		"session-2": {"main.go", "notes.md"},
+	}
+	if !reflect.DeepEqual(msg.Files, want) {
+		t.Errorf("Files = %v, want %v", msg.Files, want)
+	}
+
+	if cmd := fetchChangedFiles(context.Background(), cfg.Sessions[2:], git.NewGitServiceWithExecutor(mockExec), 0); cmd != nil {
+		t.Error("expected no command when no repo has several sessions")
+	}
+}
+
+func TestChangedFilesMsg_MarksOverlappingSessions(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+
+	m.Update(ChangedFilesMsg{Files: map[string][]string{
+		"session-1": {"main.go", "a.go"},
+		"session-2": {"main.go"},
+		"session-3": {"main.go"},
+	}})
+
+	if !m.sidebar.HasFileOverlap("session-1") || !m.sidebar.HasFileOverlap("session-2") {
+		t.Error("expected both sessions editing main.go in repo1 to be marked")
+	}
+	if m.sidebar.HasFileOverlap("session-3") {
+		t.Error("expected the session in another repo not to be marked")
+	}
+
+	// The overlap clears once the other session's changes are gone
+	m.Update(ChangedFilesMsg{Files: map[string][]string{"session-2": nil}})
+	if m.sidebar.HasFileOverlap("session-1") || m.sidebar.HasFileOverlap("session-2") {
+		t.Error("expected overlap badges to clear")
+	}
+}
+
+func TestShowOverlapsModal(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+
+	// Without overlaps the shortcut does nothing
+	m = sendKey(m, "o")
+	if m.modal.IsVisible() {
+		t.Fatal("expected no modal without overlaps")
+	}
+
+	m.Update(ChangedFilesMsg{Files: map[string][]string{
+		"session-1": {"main.go"},
+		"session-2": {"main.go"},
+	}})
+	m = sendKey(m, "o")
+	state, ok := m.modal.State.(*ui.FileOverlapState)
+	if !ok {
+		t.Fatalf("expected FileOverlapState, got %T", m.modal.State)
+	}
+	want := []ui.FileOverlapItem{{SessionName: ui.SessionDisplayName(cfg.Sessions[1].Branch, cfg.Sessions[1].Name), Files: []string{"main.go"}}}
+	if !reflect.DeepEqual(state.Overlaps, want) {
+		t.Errorf("Overlaps = %+v, want %+v", state.Overlaps, want)
+	}
+
+	m = sendKey(m, keys.Escape)
+	if m.modal.IsVisible() {
+		t.Error("expected Esc to close the modal")
+	}
+}
+
+func TestMergeModal_WarnsAboutOverlaps(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.Update(ChangedFilesMsg{Files: map[string][]string{"session-2": {"main.go"}}})
+
+	// Opening the merge modal refreshes the session's own files first
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddExactMatch("git", []string{"status", "--porcelain"}, pexec.MockResponse{
+		Stdout: []byte(" M main.go\n"),
+	})
+	m.SetGitService(git.NewGitServiceWithExecutor(mockExec))
+
+	m = sendKey(m, "m")
+	state, ok := m.modal.State.(*ui.MergeState)
+	if !ok {
+		t.Fatalf("expected MergeState, got %T", m.modal.State)
+	}
+	if len(state.Overlaps) != 1 || !reflect.DeepEqual(state.Overlaps[0].Files, []string{"main.go"}) {
+		t.Errorf("expected merge modal to warn about main.go, got %+v", state.Overlaps)
+	}
+	if !m.sidebar.HasFileOverlap("session-1") {
+		t.Error("expected the refreshed session to be marked")
+	}
+}
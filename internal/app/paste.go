@@ -0,0 +1,207 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/clipboard"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/paste"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// handleTextPaste cleans noisy multi-line pastes into the chat input according to the
+// paste cleaning mode. Returns handled=false when the paste should be inserted as-is.
+func (m *Model) handleTextPaste(raw string) (tea.Cmd, bool) {
+	if !paste.IsMultiline(raw) {
+		return nil, false
+	}
+	mode := m.config.GetPasteCleaning()
+	if mode == config.PasteCleaningNever {
+		return nil, false
+	}
+	result := paste.Clean(raw)
+	if !result.Changed() || result.Text == strings.TrimRight(m.chat.SanitizePaste(raw), "\n") {
+		// Nothing beyond what paste sanitizing already removes (or a trailing newline)
+		return nil, false
+	}
+
+	sessionID := m.activeSession.ID
+	clean := mode == config.PasteCleaningAlways
+	if mode == config.PasteCleaningAsk {
+		choice, answered := m.pasteCleanChoices[sessionID]
+		if !answered {
+			// Ask once per session; the paste is inserted when the prompt is answered
+			m.modal.Show(ui.NewPasteCleanState(sessionID, raw, result.Text, result.Summary()))
+			return nil, true
+		}
+		clean = choice
+	}
+	if !clean {
+		return nil, false
+	}
+	return m.insertCleanedPaste(sessionID, raw, result), true
+}
+
+// insertCleanedPaste inserts the cleaned paste and remembers the raw text for undo.
+func (m *Model) insertCleanedPaste(sessionID, raw string, result paste.Result) tea.Cmd {
+	inserted, cmd := m.chat.InsertPaste(result.Text)
+	m.pasteUndo = &PasteUndo{
+		SessionID: sessionID,
+		Raw:       raw,
+		Cleaned:   inserted,
+	}
+	logger.WithSession(sessionID).Debug("cleaned paste", "rawLength", len(raw), "removed", result.RemovedBytes)
+	return tea.Batch(cmd, m.ShowFlashInfo(result.Summary()+" (ctrl-z to undo)"))
+}
+
+// handlePasteCleanModal handles key events for the paste cleaning prompt.
+func (m *Model) handlePasteCleanModal(key string, msg tea.KeyPressMsg, state *ui.PasteCleanState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		// Paste raw this time without remembering an answer
+		m.modal.Hide()
+		_, cmd := m.chat.InsertPaste(state.Raw)
+		return m, cmd
+	case keys.Enter:
+		m.modal.Hide()
+		clean := false
+		switch state.GetChoice() {
+		case ui.PasteCleanSession:
+			clean = true
+			m.pasteCleanChoices[state.SessionID] = true
+		case ui.PasteRawSession:
+			m.pasteCleanChoices[state.SessionID] = false
+		case ui.PasteCleanAlways:
+			clean = true
+			m.config.SetPasteCleaning(config.PasteCleaningAlways)
+		case ui.PasteCleanNever:
+			m.config.SetPasteCleaning(config.PasteCleaningNever)
+		}
+
+		var cmds []tea.Cmd
+		if state.GetChoice() == ui.PasteCleanAlways || state.GetChoice() == ui.PasteCleanNever {
+			cmds = append(cmds, m.saveConfigOrFlash())
+		}
+		if clean {
+			cmds = append(cmds, m.insertCleanedPaste(state.SessionID, state.Raw, paste.Clean(state.Raw)))
+		} else {
+			_, cmd := m.chat.InsertPaste(state.Raw)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// ImageFittedMsg is sent when a pasted image has been downscaled in the background
+// to fit max_image_kb.
+type ImageFittedMsg struct {
+	SessionID  string
+	OriginalKB int
+	LimitKB    int
+	Fitted     *clipboard.ImageData
+	Fits       bool // Whether Fitted is within the limit
+	Err        error
+}
+
+// fitImage returns a command that downscales img to fit within limitKB.
+func fitImage(sessionID string, img *clipboard.ImageData, limitKB int) tea.Cmd {
+	return func() tea.Msg {
+		fitted, ok, err := img.Fit(limitKB * 1024)
+		return ImageFittedMsg{SessionID: sessionID, OriginalKB: img.SizeKB(), LimitKB: limitKB, Fitted: fitted, Fits: ok, Err: err}
+	}
+}
+
+// attachImage attaches a pasted image to the pending message. An image over
+// max_image_kb is first downscaled in the background; see handleImageFittedMsg.
+func (m *Model) attachImage(img *clipboard.ImageData) (tea.Model, tea.Cmd) {
+	if limitKB := m.config.GetMaxImageKB(); limitKB > 0 && img.SizeKB() > limitKB && m.activeSession != nil {
+		return m, fitImage(m.activeSession.ID, img, limitKB)
+	}
+	m.attachValidImage(img)
+	return m, nil
+}
+
+// handleImageFittedMsg attaches a downscaled image or, if it still doesn't fit,
+// lets the user choose whether to attach it. Images for a session no longer
+// active are dropped.
+func (m *Model) handleImageFittedMsg(msg ImageFittedMsg) (tea.Model, tea.Cmd) {
+	if m.activeSession == nil || m.activeSession.ID != msg.SessionID {
+		logger.WithSession(msg.SessionID).Debug("dropping downscaled image for inactive session")
+		return m, nil
+	}
+	if msg.Err != nil {
+		logger.Get().Warn("failed to downscale image", "error", msg.Err)
+		m.chat.AppendStreaming(fmt.Sprintf("\n[Error: %s]\n", msg.Err.Error()))
+		return m, nil
+	}
+	logger.Get().Info("downscaled image", "fromKB", msg.OriginalKB, "toKB", msg.Fitted.SizeKB(), "limitKB", msg.LimitKB, "fits", msg.Fits)
+	if !msg.Fits {
+		m.modal.Show(ui.NewImageTooLargeState(msg.Fitted.Data, msg.Fitted.MediaType, msg.LimitKB))
+		return m, nil
+	}
+	if !m.attachValidImage(msg.Fitted) {
+		return m, nil
+	}
+	return m, m.ShowFlashInfo(fmt.Sprintf("Image downscaled from %dKB to %dKB", msg.OriginalKB, msg.Fitted.SizeKB()))
+}
+
+// attachValidImage validates an image within the size limit and attaches it,
+// reporting whether it did.
+func (m *Model) attachValidImage(img *clipboard.ImageData) bool {
+	if err := img.Validate(); err != nil {
+		logger.Get().Warn("image validation failed", "error", err)
+		// Show error message in chat
+		m.chat.AppendStreaming(fmt.Sprintf("\n[Error: %s]\n", err.Error()))
+		return false
+	}
+
+	// Attach the image
+	logger.Get().Info("attaching image", "sizeKB", img.SizeKB(), "mediaType", img.MediaType)
+	m.chat.AttachImage(img.Data, img.MediaType)
+	return true
+}
+
+// handleImageTooLargeModal handles key events for the oversized image prompt.
+func (m *Model) handleImageTooLargeModal(key string, msg tea.KeyPressMsg, state *ui.ImageTooLargeState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		m.modal.Hide()
+		if !state.ShouldAttach() {
+			return m, nil
+		}
+		img := &clipboard.ImageData{Data: state.Data, MediaType: state.MediaType}
+		if err := img.Validate(); err != nil {
+			return m, m.ShowFlashError(err.Error())
+		}
+		logger.Get().Info("attaching oversized image", "sizeKB", state.SizeKB, "limitKB", state.LimitKB)
+		m.chat.AttachImage(state.Data, state.MediaType)
+		return m, nil
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// canUndoPasteCleaning returns whether the active session has a cleaned paste to restore.
+func (m *Model) canUndoPasteCleaning() bool {
+	return m.pasteUndo != nil && m.activeSession != nil && m.pasteUndo.SessionID == m.activeSession.ID
+}
+
+func shortcutUndoPasteCleaning(m *Model) (tea.Model, tea.Cmd) {
+	undo := m.pasteUndo
+	m.pasteUndo = nil
+	if !m.chat.ReplaceInInput(undo.Cleaned, undo.Raw) {
+		return m, m.ShowFlashWarning("Cleaned paste was edited or sent; nothing to undo")
+	}
+	return m, m.ShowFlashInfo("Restored raw paste")
+}
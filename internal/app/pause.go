@@ -0,0 +1,164 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// pausedHint tells the user how to get out of the paused state.
+const pausedHint = "All sessions are paused; press P in the sidebar to resume"
+
+// pausedQueuedHint tells the user that messages were held by the pause.
+const pausedQueuedHint = "All sessions are paused; messages are queued until you press P in the sidebar to resume"
+
+// interruptSession cancels a session's in-progress turn, keeping the partial
+// response in its history marked as interrupted. Returns whether a turn was in
+// progress, and any error saving the history.
+func (m *Model) interruptSession(sessionID string) (bool, error) {
+	state := m.sessionState().GetIfExists(sessionID)
+	if state == nil {
+		return false, nil
+	}
+	cancel := state.GetStreamCancel()
+	if cancel == nil {
+		return false, nil
+	}
+
+	log := logger.WithSession(sessionID)
+	log.Debug("interrupting streaming")
+	cancel()
+
+	isActiveSession := m.activeSession != nil && m.activeSession.ID == sessionID
+	runner := m.sessionMgr.GetRunner(sessionID)
+	if isActiveSession {
+		runner = m.claudeRunner
+	}
+	// Send SIGINT to interrupt the Claude process (handles sub-agent work)
+	if runner != nil {
+		if err := runner.Interrupt(); err != nil {
+			log.Error("failed to interrupt Claude", "error", err)
+		}
+	}
+	m.sessionState().StopWaiting(sessionID)
+	m.sidebar.SetStreaming(sessionID, false)
+
+	var content string
+	if isActiveSession {
+		m.chat.SetWaiting(false)
+		content = m.chat.GetStreaming()
+	} else {
+		state.FlushToolUseRollup(ui.GetToolIcon, ui.ToolUseInProgress, ui.ToolUseComplete)
+		content = state.GetStreamingContent()
+		state.SetStreamingContent("")
+	}
+
+	// Save partial response to runner before finishing
+	var saveErr error
+	if content != "" && runner != nil {
+		runner.AddAssistantMessage(content + "\n[Interrupted]")
+		saveErr = m.sessionMgr.SaveRunnerMessages(sessionID, runner)
+	}
+	if isActiveSession {
+		m.chat.AppendStreaming("\n[Interrupted]\n")
+		m.chat.FinishStreaming()
+	}
+
+	// Check if any sessions are still streaming
+	if !m.hasAnyStreamingSessions() {
+		m.setState(StateIdle)
+	}
+	return true, saveErr
+}
+
+// startTurn sends content to a session's runner, marking the session as waiting,
+// and returns the commands listening for the response. While all sessions are
+// paused, the text of content is queued as the session's pending message, sent
+// on resume, and started is false.
+func (m *Model) startTurn(sessionID string, runner claude.RunnerInterface, content []claude.ContentBlock) (cmds []tea.Cmd, started bool) {
+	if m.paused {
+		var texts []string
+		for _, block := range content {
+			if block.Type == claude.ContentTypeText && block.Text != "" {
+				texts = append(texts, block.Text)
+			}
+		}
+		state := m.sessionState().GetOrCreate(sessionID)
+		if pending := state.GetPendingMsg(); pending != "" {
+			texts = append([]string{pending}, texts...)
+		}
+		msg := strings.Join(texts, "\n\n")
+		state.SetPendingMsg(msg)
+		if m.activeSession != nil && m.activeSession.ID == sessionID {
+			m.chat.SetQueuedMessage(msg)
+		}
+		logger.WithSession(sessionID).Debug("held message while paused")
+		return nil, false
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.sessionState().StartWaiting(sessionID, cancel)
+	m.sidebar.SetStreaming(sessionID, true)
+	responseChan := runner.SendContent(ctx, content)
+	return m.sessionListeners(sessionID, runner, responseChan), true
+}
+
+// pauseAllSessions interrupts every session's in-progress turn and holds new
+// messages until resumed. Sessions stay alive and resumable.
+func (m *Model) pauseAllSessions() tea.Cmd {
+	m.paused = true
+	m.header.SetPaused(true)
+
+	interrupted := 0
+	saveFailed := false
+	for _, sess := range m.config.GetSessions() {
+		ok, err := m.interruptSession(sess.ID)
+		if err != nil {
+			logger.WithSession(sess.ID).Error("failed to save interrupted response", "error", err)
+			saveFailed = true
+		}
+		if ok {
+			interrupted++
+		}
+	}
+	logger.Get().Info("paused all sessions", "interrupted", interrupted)
+
+	if saveFailed {
+		return m.ShowFlashError("Paused, but failed to save some session messages")
+	}
+	if interrupted == 0 {
+		return m.ShowFlashWarning("Paused all sessions")
+	}
+	return m.ShowFlashWarning(fmt.Sprintf("Paused all sessions (%d interrupted)", interrupted))
+}
+
+// resumeAllSessions lifts the pause and sends any messages queued while paused.
+func (m *Model) resumeAllSessions() tea.Cmd {
+	m.paused = false
+	m.header.SetPaused(false)
+	logger.Get().Info("resumed all sessions")
+
+	cmds := []tea.Cmd{m.ShowFlashSuccess("Resumed all sessions")}
+	for _, sess := range m.config.GetSessions() {
+		if state := m.sessionState().GetIfExists(sess.ID); state != nil && state.GetPendingMsg() != "" {
+			sessionID := sess.ID
+			cmds = append(cmds, func() tea.Msg {
+				return SendPendingMessageMsg{SessionID: sessionID}
+			})
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// shortcutTogglePauseAll pauses all sessions, or resumes them if paused.
+func shortcutTogglePauseAll(m *Model) (tea.Model, tea.Cmd) {
+	if m.paused {
+		return m, m.resumeAllSessions()
+	}
+	return m, m.pauseAllSessions()
+}
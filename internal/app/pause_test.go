@@ -0,0 +1,139 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/config"
+)
+
+func TestPauseAllSessions(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, factory := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	activeID := m.activeSession.ID
+	m.sessionState().StartWaiting(activeID, func() {})
+	m.chat.SetWaiting(true)
+	m.chat.AppendStreaming("Partial answer")
+
+	// A turn in progress in a session that is not shown
+	background := cfg.GetSession("session-2")
+	m.sessionMgr.GetOrCreateRunner(background)
+	m.sessionState().StartWaiting(background.ID, func() {})
+	m.sessionState().GetOrCreate(background.ID).AppendStreamingContent("Background answer")
+
+	shortcutTogglePauseAll(m)
+
+	if !m.paused {
+		t.Fatal("expected sessions to be paused")
+	}
+	for _, id := range []string{activeID, background.ID} {
+		if state := m.sessionState().GetIfExists(id); state.GetIsWaiting() {
+			t.Errorf("expected %s to stop waiting", id)
+		}
+		msgs := factory.GetMock(id).GetMessages()
+		if len(msgs) == 0 || !strings.HasSuffix(msgs[len(msgs)-1].Content, "\n[Interrupted]") {
+			t.Errorf("expected %s to keep its partial answer, got %+v", id, msgs)
+		}
+	}
+	if !strings.Contains(ansi.Strip(m.header.View()), "[PAUSED]") {
+		t.Error("expected the paused banner in the header")
+	}
+
+	// Messages are held while paused
+	m = typeText(m, "hello")
+	m = sendKey(m, "enter")
+	if m.sessionState().GetIfExists(activeID).GetIsWaiting() {
+		t.Error("expected no message to be sent while paused")
+	}
+	if m.chat.GetInput() != "hello" {
+		t.Errorf("expected the message to stay in the input, got %q", m.chat.GetInput())
+	}
+	if !m.footer.HasFlash() {
+		t.Error("expected a flash explaining the pause")
+	}
+
+	// The sessions are still there and take messages once resumed
+	shortcutTogglePauseAll(m)
+	if m.paused || strings.Contains(ansi.Strip(m.header.View()), "[PAUSED]") {
+		t.Error("expected sessions to be resumed")
+	}
+	m = sendKey(m, "enter")
+	if !m.sessionState().GetIfExists(activeID).GetIsWaiting() {
+		t.Error("expected the message to be sent after resuming")
+	}
+}
+
+func TestResumeAllSessions_SendsHeldMessages(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.sessionMgr.GetOrCreateRunner(cfg.GetSession("session-2"))
+
+	shortcutTogglePauseAll(m)
+	m.sessionState().GetOrCreate("session-2").SetPendingMsg("queued")
+
+	// A queued message that comes due while paused stays queued
+	m.Update(SendPendingMessageMsg{SessionID: "session-2"})
+	if m.sessionState().GetIfExists("session-2").GetIsWaiting() {
+		t.Fatal("expected the queued message to be held while paused")
+	}
+
+	_, cmd := shortcutTogglePauseAll(m)
+	batch, _ := cmd().(tea.BatchMsg)
+	for _, c := range batch {
+		if msg, ok := c().(SendPendingMessageMsg); ok {
+			m.Update(msg)
+		}
+	}
+	if !m.sessionState().GetIfExists("session-2").GetIsWaiting() {
+		t.Error("expected the queued message to be sent on resume")
+	}
+}
+
+func TestPausedBroadcastIsHeldUntilResume(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, factory := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	shortcutTogglePauseAll(m)
+	m.broadcastToSessions([]config.Session{*cfg.GetSession("session-2")}, "run the tests")
+
+	state := m.sessionState().GetIfExists("session-2")
+	if state == nil || state.GetIsWaiting() {
+		t.Fatal("expected the broadcast to be held while paused")
+	}
+	if got := state.GetPendingMsg(); got != "run the tests" {
+		t.Errorf("expected the broadcast queued, got %q", got)
+	}
+	if mock := factory.GetMock("session-2"); mock != nil {
+		for _, msg := range mock.GetMessages() {
+			if msg.Role == "user" {
+				t.Errorf("expected nothing sent to Claude, got %+v", msg)
+			}
+		}
+	}
+
+	// A prompt Plural composes, like conflict resolution, is held too
+	m.sendPromptToSession(cfg.GetSession("session-1"), "resolve the conflicts")
+	if state := m.sessionState().GetIfExists("session-1"); state == nil || state.GetIsWaiting() || state.GetPendingMsg() != "resolve the conflicts" {
+		t.Error("expected the composed prompt to be held while paused")
+	}
+
+	_, cmd := shortcutTogglePauseAll(m)
+	batch, _ := cmd().(tea.BatchMsg)
+	for _, c := range batch {
+		if msg, ok := c().(SendPendingMessageMsg); ok {
+			m.Update(msg)
+		}
+	}
+	for _, id := range []string{"session-1", "session-2"} {
+		if !m.sessionState().GetIfExists(id).GetIsWaiting() {
+			t.Errorf("expected the held message for %s to be sent on resume", id)
+		}
+	}
+}
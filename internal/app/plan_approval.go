@@ -0,0 +1,146 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/mcp"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+var (
+	// shellFencePattern matches the opening of a fenced code block in a shell language.
+	shellFencePattern = regexp.MustCompile("(?m)^\\s*```(?:bash|sh|shell|zsh|console)\\s*$")
+
+	// shellPromptPattern matches command lines written as "$ cmd".
+	shellPromptPattern = regexp.MustCompile(`(?m)^\s*\$ \S`)
+
+	// codeSpanPattern matches inline code spans.
+	codeSpanPattern = regexp.MustCompile("`([^`\n]+)`")
+
+	// slashPathPattern matches path-like words containing a slash, e.g. internal/app/app.go.
+	// Words must start after whitespace or an opening bracket, which skips URLs.
+	slashPathPattern = regexp.MustCompile(`(?:^|[\s(\[*"'])((?:\.{0,2}/)?[\w.@-]+(?:/[\w.@-]+)+/?)`)
+
+	// fileNamePattern matches a bare file name with an extension, e.g. main.go.
+	fileNamePattern = regexp.MustCompile(`^[\w.-]+\.[A-Za-z0-9]{1,10}$`)
+)
+
+// planPaths returns the worktree-relative files and directories a plan names:
+// words containing a slash anywhere, plus file names in inline code. Paths that
+// resolve outside the worktree are returned as-is so they fail any prefix check.
+func planPaths(plan, worktree string) []string {
+	var candidates []string
+	for _, match := range slashPathPattern.FindAllStringSubmatch(plan, -1) {
+		candidates = append(candidates, match[1])
+	}
+	for _, match := range codeSpanPattern.FindAllStringSubmatch(plan, -1) {
+		span := strings.TrimSpace(match[1])
+		if !strings.ContainsAny(span, " \t") && !strings.Contains(span, "://") &&
+			(strings.Contains(span, "/") || fileNamePattern.MatchString(span)) {
+			candidates = append(candidates, span)
+		}
+	}
+
+	var paths []string
+	for _, p := range candidates {
+		p = strings.TrimRight(p, ".,:;")
+		if filepath.IsAbs(p) && worktree != "" {
+			if rel, err := filepath.Rel(worktree, p); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+				p = rel
+			}
+		}
+		p = filepath.Clean(p)
+		if p != "." && !slices.Contains(paths, p) { // "." comes from patterns like ./...
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// underPrefix reports whether the worktree-relative path is prefix or inside it.
+func underPrefix(path, prefix string) bool {
+	prefix = filepath.Clean(prefix)
+	if filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, "../") {
+		return false
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// evaluatePlan checks a plan against auto-approval criteria. Returns whether the
+// plan meets them and, if not, the first criterion it fails.
+func evaluatePlan(criteria config.PlanApprovalCriteria, req mcp.PlanApprovalRequest, worktree string) (bool, string) {
+	if criteria.MaxPlanChars > 0 && len([]rune(req.Plan)) > criteria.MaxPlanChars {
+		return false, fmt.Sprintf("plan is longer than %d characters", criteria.MaxPlanChars)
+	}
+
+	if !criteria.AllowShell {
+		if len(req.AllowedPrompts) > 0 {
+			return false, "plan requests shell permissions"
+		}
+		if shellFencePattern.MatchString(req.Plan) || shellPromptPattern.MatchString(req.Plan) {
+			return false, "plan contains shell commands"
+		}
+	}
+
+	if len(criteria.PathPrefixes) > 0 {
+		paths := planPaths(req.Plan, worktree)
+		if len(paths) == 0 {
+			return false, "plan names no files"
+		}
+		for _, p := range paths {
+			if !slices.ContainsFunc(criteria.PathPrefixes, func(prefix string) bool { return underPrefix(p, prefix) }) {
+				return false, "plan touches " + p
+			}
+		}
+	}
+
+	return true, ""
+}
+
+// shouldAutoApprovePlan reports whether a session opted in to plan auto-approval
+// and the plan meets its repo's criteria. The decision is logged either way.
+func (m *Model) shouldAutoApprovePlan(sessionID string, req mcp.PlanApprovalRequest) bool {
+	sess := m.config.GetSession(sessionID)
+	if sess == nil || !sess.AutoApprovePlans {
+		return false
+	}
+	log := logger.WithSession(sessionID)
+	criteria := m.config.GetPlanApprovalCriteria(sess.RepoPath)
+	if criteria == nil {
+		log.Debug("plan auto-approval enabled but repo has no criteria", "repo", sess.RepoPath)
+		return false
+	}
+
+	ok, reason := evaluatePlan(*criteria, req, sess.WorkTree)
+	if !ok {
+		log.Info("plan not auto-approved", "reason", reason)
+		return false
+	}
+	log.Info("auto-approved plan", "planChars", len(req.Plan), "paths", planPaths(req.Plan, sess.WorkTree))
+	return true
+}
+
+// autoApprovePlan records the auto-approval in the transcript and approves the
+// pending plan.
+func (m *Model) autoApprovePlan(sessionID string) (tea.Model, tea.Cmd) {
+	text := "\n" + ui.AutoApprovedPrefix + " Plan meets this repo's approval criteria\n"
+	isActive := m.activeSession != nil && m.activeSession.ID == sessionID
+	if isActive {
+		m.chat.AppendStreaming(text)
+	} else if state := m.sessionState().GetIfExists(sessionID); state != nil {
+		state.AppendStreamingContent(text)
+	}
+
+	_, cmd := m.submitPlanApprovalResponse(sessionID, true)
+	if isActive {
+		return m, tea.Batch(cmd, m.ShowFlashInfo("Plan auto-approved"))
+	}
+	return m, cmd
+}
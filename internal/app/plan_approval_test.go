@@ -0,0 +1,164 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/mcp"
+)
+
+func TestPlanPaths(t *testing.T) {
+	plan := "## Plan\n\n" +
+		"1. Update internal/ui/chat.go and `docs/notes.md`.\n" +
+		"2. Add a test to `chat_test.go` (see https://example.com/docs/guide).\n" +
+		"3. Touch /test/worktree1/README.md, and ./internal/ui/chat.go again.\n" +
+		"4. Run `go test ./...` afterwards.\n"
+
+	got := planPaths(plan, "/test/worktree1")
+	want := []string{"internal/ui/chat.go", "README.md", "docs/notes.md", "chat_test.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("planPaths() = %q, want %q", got, want)
+	}
+}
+
+func TestEvaluatePlan(t *testing.T) {
+	docsOnly := config.PlanApprovalCriteria{PathPrefixes: []string{"docs/", "README.md"}}
+
+	tests := []struct {
+		name       string
+		criteria   config.PlanApprovalCriteria
+		plan       string
+		prompts    []mcp.AllowedPrompt
+		wantOK     bool
+		wantReason string
+	}{
+		{
+			name:     "files under allowed prefixes",
+			criteria: docsOnly,
+			plan:     "Fix typos in docs/setup.md and `README.md`.",
+			wantOK:   true,
+		},
+		{
+			name:       "file outside prefixes",
+			criteria:   docsOnly,
+			plan:       "Update docs/setup.md and internal/app/app.go.",
+			wantReason: "plan touches internal/app/app.go",
+		},
+		{
+			name:       "escapes prefix via parent dir",
+			criteria:   docsOnly,
+			plan:       "Edit docs/../internal/app.go.",
+			wantReason: "plan touches internal/app.go",
+		},
+		{
+			name:       "no files named",
+			criteria:   docsOnly,
+			plan:       "Refactor the whole thing.",
+			wantReason: "plan names no files",
+		},
+		{
+			name:       "shell permissions requested",
+			criteria:   config.PlanApprovalCriteria{},
+			plan:       "Fix the bug.",
+			prompts:    []mcp.AllowedPrompt{{Tool: "Bash", Prompt: "run tests"}},
+			wantReason: "plan requests shell permissions",
+		},
+		{
+			name:       "shell code block",
+			criteria:   config.PlanApprovalCriteria{},
+			plan:       "Steps:\n```bash\nrm -rf build\n```\n",
+			wantReason: "plan contains shell commands",
+		},
+		{
+			name:       "shell prompt line",
+			criteria:   config.PlanApprovalCriteria{},
+			plan:       "Then:\n  $ make install\n",
+			wantReason: "plan contains shell commands",
+		},
+		{
+			name:     "shell allowed",
+			criteria: config.PlanApprovalCriteria{AllowShell: true},
+			plan:     "Steps:\n```sh\nmake test\n```\n",
+			prompts:  []mcp.AllowedPrompt{{Tool: "Bash", Prompt: "run tests"}},
+			wantOK:   true,
+		},
+		{
+			name:       "plan too long",
+			criteria:   config.PlanApprovalCriteria{MaxPlanChars: 10},
+			plan:       "This plan is far too long.",
+			wantReason: "plan is longer than 10 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mcp.PlanApprovalRequest{Plan: tt.plan, AllowedPrompts: tt.prompts}
+			ok, reason := evaluatePlan(tt.criteria, req, "/test/worktree1")
+			if ok != tt.wantOK || reason != tt.wantReason {
+				t.Errorf("evaluatePlan() = (%v, %q), want (%v, %q)", ok, reason, tt.wantOK, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestHandlePlanApprovalRequest_AutoApproves(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].AutoApprovePlans = true
+	cfg.SetPlanApprovalCriteria("/test/repo1", &config.PlanApprovalCriteria{PathPrefixes: []string{"docs/"}})
+	m, factory := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	sessionID := m.activeSession.ID
+	mock := factory.GetMock(sessionID)
+	if mock == nil {
+		t.Fatal("no mock runner")
+	}
+	var responses []mcp.PlanApprovalResponse
+	mock.OnPlanApprovalResp = func(resp mcp.PlanApprovalResponse) {
+		responses = append(responses, resp)
+	}
+
+	m = simulatePlanApprovalRequest(m, sessionID, "Reword docs/intro.md.", nil)
+	if len(responses) != 1 || !responses[0].Approved {
+		t.Fatalf("expected the plan to be approved automatically, got %+v", responses)
+	}
+	if state := m.sessionState().GetIfExists(sessionID); state != nil && state.GetPendingPlanApproval() != nil {
+		t.Error("expected no pending plan approval")
+	}
+	if !strings.Contains(m.chat.GetStreaming(), "[Auto-approved]") {
+		t.Error("expected the auto-approval to be recorded in the transcript")
+	}
+
+	// A plan outside the criteria still waits for the user
+	m = simulatePlanApprovalRequest(m, sessionID, "Rewrite internal/app/app.go.", nil)
+	if len(responses) != 1 {
+		t.Errorf("expected no automatic response, got %+v", responses)
+	}
+	if state := m.sessionState().GetIfExists(sessionID); state == nil || state.GetPendingPlanApproval() == nil {
+		t.Error("expected the plan to be pending approval")
+	}
+}
+
+func TestHandlePlanApprovalRequest_AutoApprovalIsOptIn(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetPlanApprovalCriteria("/test/repo1", &config.PlanApprovalCriteria{PathPrefixes: []string{"docs/"}})
+	m, factory := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	sessionID := m.activeSession.ID
+	mock := factory.GetMock(sessionID)
+	if mock == nil {
+		t.Fatal("no mock runner")
+	}
+	approved := false
+	mock.OnPlanApprovalResp = func(resp mcp.PlanApprovalResponse) { approved = true }
+
+	m = simulatePlanApprovalRequest(m, sessionID, "Reword docs/intro.md.", nil)
+	if approved {
+		t.Error("expected no auto-approval for a session that has not opted in")
+	}
+}
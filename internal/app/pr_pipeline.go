@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// startPR runs the PR pipeline for sess, resuming from any unfinished attempt recorded
+// on the session, and shows per-step progress. Returns the progress spinner's tick.
+func (m *Model) startPR(mergeCtx context.Context, cancel context.CancelFunc, sess *config.Session, baseBranch, commitMsg string) tea.Cmd {
+	// The sidebar's copy of the session may predate the progress recorded by the last attempt
+	if fresh := m.config.GetSession(sess.ID); fresh != nil {
+		sess = fresh
+	}
+	m.chat.AppendStreaming("Creating PR for " + sess.Branch + "...\n\n")
+	m.sessionState().StartMerge(sess.ID, m.gitServiceFor(sess).ResumePR(mergeCtx, sess.RepoPath, sess.WorkTree, sess.Branch, baseBranch, commitMsg, sess.GetIssueRef(), m.config.GetPRTemplate(sess.RepoPath), sess.ID, sess.PRProgress), cancel, manager.MergeTypePR)
+
+	// Steps start pending; the pipeline reports which ones it reuses
+	progressState := ui.NewPRProgressState(sess.ID, ui.SessionDisplayName(sess.Branch, sess.Name), prStepItems(nil))
+	m.modal.Show(progressState)
+	return progressState.Spinner.Tick
+}
+
+// prStepItems converts the recorded progress of an unfinished PR attempt into step items.
+// Steps before the failed one are shown as done, with the artifacts they produced.
+func prStepItems(progress *config.PRProgress) []ui.PRStepItem {
+	failedIdx := -1
+	if progress != nil {
+		failedIdx = slices.Index(git.PRSteps, git.PRStep(progress.FailedStep))
+	}
+
+	items := make([]ui.PRStepItem, len(git.PRSteps))
+	for i, step := range git.PRSteps {
+		items[i] = ui.PRStepItem{Label: step.Label(), State: ui.PRStepPending}
+		switch {
+		case i == failedIdx:
+			items[i].State = ui.PRStepFailed
+			items[i].Detail = progress.Error
+		case i < failedIdx:
+			items[i].State = ui.PRStepDone
+			switch step {
+			case git.PRStepPush:
+				if progress.PushedSHA != "" {
+					items[i].Detail = "at " + git.ShortSHA(progress.PushedSHA)
+				}
+			case git.PRStepGenerate:
+				items[i].Detail = progress.Title
+			}
+		}
+	}
+	return items
+}
+
+// recordPRStep persists the artifacts of a PR pipeline step on the session so a later
+// retry can resume, and updates the progress modal if it is showing this session.
+func (m *Model) recordPRStep(sessionID string, update *git.PRStepUpdate) tea.Cmd {
+	log := logger.WithSession(sessionID)
+
+	if progressState, ok := m.modal.State.(*ui.PRProgressState); ok && progressState.SessionID == sessionID {
+		index := slices.Index(git.PRSteps, update.Step)
+		switch update.Status {
+		case git.PRStepRunning:
+			progressState.SetStep(index, ui.PRStepRunning, "")
+		case git.PRStepDone:
+			progressState.SetStep(index, ui.PRStepDone, prStepDetail(update))
+		case git.PRStepFailed:
+			progressState.SetStep(index, ui.PRStepFailed, update.Error)
+		}
+	}
+
+	sess := m.config.GetSession(sessionID)
+	if sess == nil {
+		return nil
+	}
+	var progress config.PRProgress
+	if sess.PRProgress != nil {
+		progress = *sess.PRProgress
+	}
+	progress.BaseBranch = update.BaseBranch
+
+	switch update.Status {
+	case git.PRStepRunning:
+		// Clear the previous failure; saved to disk once the step finishes
+		progress.FailedStep, progress.Error = "", ""
+		m.config.SetSessionPRProgress(sessionID, &progress)
+		return nil
+	case git.PRStepDone:
+		switch update.Step {
+		case git.PRStepPush:
+			progress.PushedSHA = update.PushedSHA
+		case git.PRStepGenerate:
+			progress.Title, progress.Body = update.Title, update.Body
+		}
+	case git.PRStepFailed:
+		progress.FailedStep, progress.Error = string(update.Step), update.Error
+		log.Warn("PR step failed", "step", update.Step, "error", update.Error)
+	}
+	m.config.SetSessionPRProgress(sessionID, &progress)
+	if err := m.config.Save(); err != nil {
+		log.Error("failed to save PR progress", "error", err)
+		return m.ShowFlashError("Failed to save PR progress")
+	}
+	return nil
+}
+
+// prStepDetail returns the note shown next to a completed step.
+func prStepDetail(update *git.PRStepUpdate) string {
+	switch update.Step {
+	case git.PRStepPush:
+		if update.PushedSHA == "" {
+			return ""
+		}
+		if update.Reused {
+			return "already pushed at " + git.ShortSHA(update.PushedSHA)
+		}
+		return "at " + git.ShortSHA(update.PushedSHA)
+	case git.PRStepGenerate:
+		if update.Title == "" {
+			return "using commit info"
+		}
+		return update.Title
+	}
+	return ""
+}
+
+// handlePRProgressModal handles key events for the PR progress modal.
+func (m *Model) handlePRProgressModal(key string, _ tea.KeyPressMsg, state *ui.PRProgressState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		// The pipeline keeps running; its output still streams to the chat
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		if !state.Failed() {
+			return m, nil
+		}
+		sess := m.config.GetSession(state.SessionID)
+		if sess == nil {
+			m.modal.Hide()
+			return m, nil
+		}
+		if sessState := m.sessionState().GetIfExists(sess.ID); sessState != nil && sessState.IsMerging() {
+			return m, nil
+		}
+		baseBranch := sess.BaseBranch
+		if sess.PRProgress != nil && sess.PRProgress.BaseBranch != "" {
+			baseBranch = sess.PRProgress.BaseBranch
+		}
+		if sess.PRProgress != nil {
+			logger.WithSession(sess.ID).Info("retrying PR creation", "failedStep", sess.PRProgress.FailedStep)
+		}
+		m.chat.FinishStreaming()
+		mergeCtx, cancel := context.WithCancel(context.Background())
+		cmd := m.startPR(mergeCtx, cancel, sess, baseBranch, "")
+		return m, tea.Batch(cmd, m.listenForMergeResult(sess.ID))
+	}
+	return m, nil
+}
@@ -8,18 +8,17 @@ import (
 	"github.com/zhubert/plural/internal/config"
 	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/workpool"
 )
 
 const prPollInterval = 30 * time.Second
 
-// PRPollTickMsg triggers a PR status check cycle
-type PRPollTickMsg time.Time
-
 // PRStatusResult carries the result of checking a single session's PR state within a batch
 type PRStatusResult struct {
 	SessionID    string
 	State        git.PRState
 	CommentCount int // Total comments + reviews from gh pr list
+	CI           git.CIStatus
 }
 
 // PRBatchStatusCheckMsg carries the results of checking all eligible sessions' PR states
@@ -28,11 +27,13 @@ type PRBatchStatusCheckMsg struct {
 	Error   error
 }
 
-// PRPollTick returns a command that sends a PRPollTickMsg after the poll interval
-func PRPollTick() tea.Cmd {
-	return tea.Tick(prPollInterval, func(t time.Time) tea.Msg {
-		return PRPollTickMsg(t)
-	})
+// pollPRStatuses returns a command checking the PR statuses of eligible sessions
+// and looking for branches merged outside Plural.
+func (m *Model) pollPRStatuses() tea.Cmd {
+	ctx := m.scheduler.backgroundContext()
+	sessions := m.config.GetSessions()
+	limit := m.config.GetMaxConcurrentJobs()
+	return tea.Batch(checkPRStatuses(ctx, sessions, m.gitService, limit), checkMergedBranches(ctx, sessions, m.gitService, limit))
 }
 
 // eligibleSession holds the info needed to check a session's PR state
@@ -43,11 +44,11 @@ type eligibleSession struct {
 }
 
 // getEligibleSessions filters sessions to those that need PR state checking.
-// Eligible sessions are those with PRCreated=true and PRMerged=false and PRClosed=false and Merged=false.
+// Eligible sessions are those with PRCreated=true and PRClosed=false that are not merged.
 func getEligibleSessions(sessions []config.Session) []eligibleSession {
 	var eligible []eligibleSession
 	for _, sess := range sessions {
-		if !sess.PRCreated || sess.PRMerged || sess.PRClosed || sess.Merged {
+		if !sess.PRCreated || sess.PRClosed || sess.IsMerged() {
 			continue
 		}
 		eligible = append(eligible, eligibleSession{
@@ -59,9 +60,10 @@ func getEligibleSessions(sessions []config.Session) []eligibleSession {
 	return eligible
 }
 
-// checkPRStatuses returns a single command that checks PR state for all eligible sessions.
-// Sessions are grouped by repo so only one gh CLI call is made per repo.
-func checkPRStatuses(sessions []config.Session, gitSvc *git.GitService) tea.Cmd {
+// checkPRStatuses returns a single command that checks PR state for all eligible
+// sessions until ctx is canceled. Sessions are grouped by repo so only one gh
+// CLI call is made per repo, and up to limit repos are checked at a time.
+func checkPRStatuses(ctx context.Context, sessions []config.Session, gitSvc *git.GitService, limit int) tea.Cmd {
 	eligible := getEligibleSessions(sessions)
 	if len(eligible) == 0 {
 		return nil
@@ -69,40 +71,52 @@ func checkPRStatuses(sessions []config.Session, gitSvc *git.GitService) tea.Cmd
 
 	return func() tea.Msg {
 		log := logger.WithComponent("pr-poller")
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 		defer cancel()
 
 		// Group sessions by repo path
+		var repos []string
 		repoSessions := make(map[string][]eligibleSession)
 		for _, s := range eligible {
+			if _, ok := repoSessions[s.RepoPath]; !ok {
+				repos = append(repos, s.RepoPath)
+			}
 			repoSessions[s.RepoPath] = append(repoSessions[s.RepoPath], s)
 		}
 
-		var results []PRStatusResult
-
 		// One gh call per repo
-		for repoPath, sessions := range repoSessions {
+		repoResults := make([][]PRStatusResult, len(repos))
+		workpool.Run(ctx, len(repos), workpool.Options{Limit: limit}, func(ctx context.Context, i int) error {
+			repoPath := repos[i]
+			sessions := repoSessions[repoPath]
 			branches := make([]string, len(sessions))
-			for i, s := range sessions {
-				branches[i] = s.Branch
+			for j, s := range sessions {
+				branches[j] = s.Branch
 			}
 
 			batchResults, err := gitSvc.GetBatchPRStatesWithComments(ctx, repoPath, branches)
 			if err != nil {
 				log.Debug("batch PR status check failed", "repo", repoPath, "error", err)
-				continue
+				return err
 			}
 
 			// Build a branch->sessionID lookup for this repo
 			for _, s := range sessions {
 				if br, ok := batchResults[s.Branch]; ok {
-					results = append(results, PRStatusResult{
+					repoResults[i] = append(repoResults[i], PRStatusResult{
 						SessionID:    s.ID,
 						State:        br.State,
 						CommentCount: br.CommentCount,
+						CI:           br.CI,
 					})
 				}
 			}
+			return nil
+		})
+
+		var results []PRStatusResult
+		for _, r := range repoResults {
+			results = append(results, r...)
 		}
 
 		return PRBatchStatusCheckMsg{Results: results}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"testing"
 
 	"github.com/zhubert/plural/internal/config"
@@ -60,7 +61,7 @@ func TestCheckPRStatuses_ReturnsNilWhenNoEligible(t *testing.T) {
 		{ID: "s1", RepoPath: "/repo", Branch: "b1"}, // no PR
 	}
 
-	cmd := checkPRStatuses(sessions, gitSvc)
+	cmd := checkPRStatuses(context.Background(), sessions, gitSvc, 0)
 	if cmd != nil {
 		t.Error("expected nil cmd when no eligible sessions exist")
 	}
@@ -70,7 +71,7 @@ func TestCheckPRStatuses_ReturnsNilForEmpty(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
 	gitSvc := git.NewGitServiceWithExecutor(mock)
 
-	cmd := checkPRStatuses([]config.Session{}, gitSvc)
+	cmd := checkPRStatuses(context.Background(), []config.Session{}, gitSvc, 0)
 	if cmd != nil {
 		t.Error("expected nil cmd for empty sessions")
 	}
@@ -85,7 +86,7 @@ func TestCheckPRStatuses_ReturnsCmdWhenEligible(t *testing.T) {
 		{ID: "s2", RepoPath: "/repo1", Branch: "b2", PRCreated: true},
 	}
 
-	cmd := checkPRStatuses(sessions, gitSvc)
+	cmd := checkPRStatuses(context.Background(), sessions, gitSvc, 0)
 	if cmd == nil {
 		t.Fatal("expected non-nil cmd for sessions with eligible PRs")
 	}
@@ -93,7 +94,7 @@ func TestCheckPRStatuses_ReturnsCmdWhenEligible(t *testing.T) {
 
 func TestCheckPRStatuses_PropagatesCommentCount(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
-	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews", "--limit", "200"}, pexec.MockResponse{
+	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews,statusCheckRollup", "--limit", "200"}, pexec.MockResponse{
 		Stdout: []byte(`[
 			{
 				"state": "OPEN",
@@ -109,7 +110,7 @@ func TestCheckPRStatuses_PropagatesCommentCount(t *testing.T) {
 		{ID: "s1", RepoPath: "/repo1", Branch: "b1", PRCreated: true},
 	}
 
-	cmd := checkPRStatuses(sessions, gitSvc)
+	cmd := checkPRStatuses(context.Background(), sessions, gitSvc, 0)
 	if cmd == nil {
 		t.Fatal("expected non-nil cmd")
 	}
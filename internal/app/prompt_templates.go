@@ -0,0 +1,80 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// promptTemplateItems converts the saved prompt templates for the template
+// modals. Only the first template of a name is listed, as validation warns.
+func promptTemplateItems(templates []config.PromptTemplate) []ui.PromptTemplateItem {
+	var items []ui.PromptTemplateItem
+	seen := make(map[string]bool)
+	for _, t := range templates {
+		if t.Name == "" || seen[t.Name] {
+			continue
+		}
+		seen[t.Name] = true
+		items = append(items, ui.PromptTemplateItem{Name: t.Name, Text: t.Text, Variables: t.Variables()})
+	}
+	return items
+}
+
+// shortcutPromptTemplates opens the prompt template picker for the chat input.
+func shortcutPromptTemplates(m *Model) (tea.Model, tea.Cmd) {
+	m.modal.Show(ui.NewTemplatePickerState(promptTemplateItems(m.config.GetPromptTemplates())))
+	return m, nil
+}
+
+// insertPromptTemplate fills in a template's variables and inserts the prompt
+// at the chat input cursor, for editing before it is sent.
+func (m *Model) insertPromptTemplate(item ui.PromptTemplateItem, values map[string]string) (tea.Model, tea.Cmd) {
+	m.modal.Hide()
+	template := config.PromptTemplate{Name: item.Name, Text: item.Text}
+	m.chat.InsertInput(template.Fill(values))
+	logger.Get().Debug("inserted prompt template", "name", item.Name, "variables", len(item.Variables))
+	return m, nil
+}
+
+// handleTemplatePickerModal handles key events in the prompt template picker.
+func (m *Model) handleTemplatePickerModal(key string, msg tea.KeyPressMsg, state *ui.TemplatePickerState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		item := state.GetSelected()
+		if item == nil {
+			return m, nil
+		}
+		if len(item.Variables) > 0 {
+			m.modal.Show(ui.NewTemplateVariablesState(*item))
+			return m, nil
+		}
+		return m.insertPromptTemplate(*item, nil)
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// handleTemplateVariablesModal handles key events while a template's variables
+// are asked for, inserting the prompt once the last one is entered.
+func (m *Model) handleTemplateVariablesModal(key string, msg tea.KeyPressMsg, state *ui.TemplateVariablesState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		if state.Next() {
+			return m.insertPromptTemplate(state.Template, state.Values)
+		}
+		return m, nil
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
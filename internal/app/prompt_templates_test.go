@@ -0,0 +1,75 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestPromptTemplates_FillVariables(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetPromptTemplates([]config.PromptTemplate{
+		{Name: "review", Text: "Review {file} for {concern}. Keep {file} compiling."},
+		{Name: "summary", Text: "Summarize the diff"},
+	})
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m = sendKey(m, keys.AltP)
+	if _, ok := m.modal.State.(*ui.TemplatePickerState); !ok {
+		t.Fatalf("expected TemplatePickerState, got %T", m.modal.State)
+	}
+	m = typeText(m, "rev")
+	m = sendKey(m, "enter")
+	state, ok := m.modal.State.(*ui.TemplateVariablesState)
+	if !ok {
+		t.Fatalf("expected TemplateVariablesState, got %T", m.modal.State)
+	}
+
+	// Each variable is asked for once, in order; shift-tab goes back to fix one
+	m = typeText(m, "app.go")
+	m = sendKey(m, "enter")
+	if state.Index != 1 {
+		t.Fatalf("expected the second variable asked for, at %d", state.Index)
+	}
+	m = sendKey(m, keys.ShiftTab)
+	m = typeText(m, "x")
+	m = sendKey(m, "enter")
+	m = typeText(m, "races")
+	m = sendKey(m, "enter")
+
+	if m.modal.IsVisible() {
+		t.Fatal("expected the modal closed once every variable is entered")
+	}
+	if got, want := m.chat.GetInput(), "Review app.gox for races. Keep app.gox compiling."; got != want {
+		t.Errorf("input = %q, want %q", got, want)
+	}
+}
+
+func TestPromptTemplates_NoVariablesInsertsDirectly(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetPromptTemplates([]config.PromptTemplate{{Name: "summary", Text: "Summarize the diff"}})
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m = sendKey(m, keys.AltP)
+	m = sendKey(m, "enter")
+	if m.modal.IsVisible() || m.chat.GetInput() != "Summarize the diff" {
+		t.Errorf("expected the template inserted without questions, input %q", m.chat.GetInput())
+	}
+}
+
+func TestPromptTemplateItems_SkipsDuplicates(t *testing.T) {
+	items := promptTemplateItems([]config.PromptTemplate{
+		{Name: "review", Text: "Review {file}"},
+		{Name: "review", Text: "Shadowed"},
+		{Name: "", Text: "Unnamed"},
+	})
+	if len(items) != 1 || items[0].Text != "Review {file}" || len(items[0].Variables) != 1 {
+		t.Errorf("expected only the first review template listed, got %+v", items)
+	}
+}
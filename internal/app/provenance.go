@@ -0,0 +1,42 @@
+package app
+
+import (
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// gitServiceFor returns the git service to merge sess or open its PR with,
+// recording the session in the commits made as its repo's provenance settings
+// select (see config.RepoProvenance). Prompts are recorded by hash only.
+func (m *Model) gitServiceFor(sess *config.Session) *git.GitService {
+	settings := m.config.GetRepoProvenance(sess.RepoPath)
+	if !settings.Trailers && !settings.Notes {
+		return m.gitService
+	}
+
+	log := logger.WithSession(sess.ID)
+	p := &git.Provenance{
+		SessionID:   sess.ID,
+		SessionName: sess.Name,
+		Trailers:    settings.Trailers,
+		Note:        settings.Notes,
+	}
+	messages, err := config.LoadSessionMessages(sess.ID)
+	if err != nil {
+		log.Warn("failed to load messages for provenance", "error", err)
+	}
+	for _, msg := range messages {
+		if msg.Role == "user" {
+			p.Prompts = append(p.Prompts, git.HashPrompt(msg.Content))
+		}
+	}
+	if settings.Notes {
+		if stats, err := getSessionUsageStats(sess.ID, sess.WorkTree); err == nil {
+			p.TotalTokens, p.CostUSD = stats.TotalTokens, stats.EstimatedCostUSD
+		} else {
+			log.Debug("no usage stats for provenance note", "error", err)
+		}
+	}
+	return m.gitService.WithProvenance(p)
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/mcp"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// questionAutoAnswerGrace is how long an auto-answer is held before it is sent,
+// giving the user a chance to undo it and answer interactively.
+const questionAutoAnswerGrace = 5 * time.Second
+
+// AutoAnswerQuestionMsg is sent when the grace period of an auto-answer ends.
+type AutoAnswerQuestionMsg struct {
+	SessionID string
+	Seq       int // Matches PendingAutoAnswer.Seq; stale messages are ignored
+}
+
+// matchQuestionRules returns the answers rules give to questions (question text ->
+// option label). For each question, the first rule that matches its text and names
+// one of its options wins; questions no rule covers are left out. Rules that cannot
+// be evaluated (invalid regexes) are skipped and reported once each in errs.
+func matchQuestionRules(rules []config.QuestionRule, questions []mcp.Question) (map[string]string, []error) {
+	answers := make(map[string]string)
+	var errs []error
+	invalid := make(map[int]bool)
+	for _, q := range questions {
+		for i, rule := range rules {
+			if invalid[i] {
+				continue
+			}
+			matched, err := rule.Matches(q.Question)
+			if err != nil {
+				invalid[i] = true
+				errs = append(errs, err)
+				continue
+			}
+			if !matched {
+				continue
+			}
+			if label, ok := findOptionLabel(q.Options, rule.Answer); ok {
+				answers[q.Question] = label
+				break
+			}
+		}
+	}
+	return answers, errs
+}
+
+// findOptionLabel returns the label of the option named answer (case-insensitive).
+func findOptionLabel(options []mcp.QuestionOption, answer string) (string, bool) {
+	for _, opt := range options {
+		if strings.EqualFold(opt.Label, answer) {
+			return opt.Label, true
+		}
+	}
+	return "", false
+}
+
+// questionRuleAnswers evaluates the auto-answer rules of the session's repo against questions.
+func (m *Model) questionRuleAnswers(sessionID string, questions []mcp.Question) (map[string]string, []error) {
+	sess := m.config.GetSession(sessionID)
+	if sess == nil {
+		return nil, nil
+	}
+	rules := m.config.GetQuestionRules(sess.RepoPath)
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return matchQuestionRules(rules, questions)
+}
+
+// applyQuestionRules checks a question request against the auto-answer rules. When
+// rules answer every question, the response is held for the grace period and auto is
+// true. Otherwise the answers rules did give are returned for pre-selection.
+func (m *Model) applyQuestionRules(sessionID string, questions []mcp.Question) (answers map[string]string, auto bool, cmd tea.Cmd) {
+	log := logger.WithSession(sessionID)
+	answers, errs := m.questionRuleAnswers(sessionID, questions)
+
+	var cmds []tea.Cmd
+	for _, err := range errs {
+		log.Warn("skipping question rule", "error", err)
+	}
+	if len(errs) > 0 {
+		cmds = append(cmds, m.ShowFlashWarning(fmt.Sprintf("Skipped question rule: %v", errs[0])))
+	}
+
+	if len(questions) == 0 || len(answers) < len(questions) {
+		return answers, false, tea.Batch(cmds...)
+	}
+
+	m.autoAnswerSeq++
+	pending := &PendingAutoAnswer{Seq: m.autoAnswerSeq, Answers: answers}
+	m.autoAnswers[sessionID] = pending
+	log.Info("auto-answering question", "answers", answers)
+
+	if m.activeSession != nil && m.activeSession.ID == sessionID {
+		cmds = append(cmds, m.ShowFlashInfo(fmt.Sprintf("Auto-answering %s (ctrl-z to answer yourself)", summarizeAutoAnswers(questions, answers))))
+	}
+	cmds = append(cmds, tea.Tick(questionAutoAnswerGrace, func(time.Time) tea.Msg {
+		return AutoAnswerQuestionMsg{SessionID: sessionID, Seq: pending.Seq}
+	}))
+	return answers, true, tea.Batch(cmds...)
+}
+
+// summarizeAutoAnswers describes auto-answers for the footer, e.g. "Add tests? → Yes".
+func summarizeAutoAnswers(questions []mcp.Question, answers map[string]string) string {
+	if len(questions) == 1 {
+		return questions[0].Question + " → " + answers[questions[0].Question]
+	}
+	return fmt.Sprintf("%d questions", len(questions))
+}
+
+// formatAutoAnsweredText formats the transcript lines recording auto-answers.
+func formatAutoAnsweredText(questions []mcp.Question, answers map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	for _, q := range questions {
+		sb.WriteString(ui.AutoAnsweredPrefix + " " + q.Question + " → " + answers[q.Question] + "\n")
+	}
+	return sb.String()
+}
+
+// handleAutoAnswerQuestionMsg sends an auto-answer once its grace period has passed
+// without being undone.
+func (m *Model) handleAutoAnswerQuestionMsg(msg AutoAnswerQuestionMsg) (tea.Model, tea.Cmd) {
+	pending := m.autoAnswers[msg.SessionID]
+	if pending == nil || pending.Seq != msg.Seq {
+		return m, nil
+	}
+	delete(m.autoAnswers, msg.SessionID)
+
+	state := m.sessionState().GetIfExists(msg.SessionID)
+	if state == nil || state.GetPendingQuestion() == nil {
+		return m, nil
+	}
+	text := formatAutoAnsweredText(state.GetPendingQuestion().Questions, pending.Answers)
+	if m.activeSession != nil && m.activeSession.ID == msg.SessionID {
+		m.chat.AppendStreaming(text)
+	} else {
+		state.AppendStreamingContent(text)
+	}
+	return m.respondToQuestion(msg.SessionID, pending.Answers)
+}
+
+// canUndoAutoAnswer returns whether the active session has an auto-answer that has not been sent yet.
+func (m *Model) canUndoAutoAnswer() bool {
+	return m.activeSession != nil && m.autoAnswers[m.activeSession.ID] != nil
+}
+
+// shortcutUndoAutoAnswer cancels a pending auto-answer and shows the question
+// prompt with the rule answers pre-selected.
+func shortcutUndoAutoAnswer(m *Model) (tea.Model, tea.Cmd) {
+	sessionID := m.activeSession.ID
+	pending := m.autoAnswers[sessionID]
+	delete(m.autoAnswers, sessionID)
+
+	state := m.sessionState().GetIfExists(sessionID)
+	if state == nil || state.GetPendingQuestion() == nil {
+		return m, nil
+	}
+	logger.WithSession(sessionID).Info("auto-answer undone")
+	m.sidebar.SetPendingPermission(sessionID, true) // Reuse permission indicator for questions
+	m.sidebar.SetPendingQuestion(sessionID, true)
+	m.chat.SetPendingQuestion(state.GetPendingQuestion().Questions)
+	m.chat.PreselectQuestionAnswers(pending.Answers)
+	return m, m.ShowFlashInfo("Auto-answer cancelled")
+}
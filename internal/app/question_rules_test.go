@@ -0,0 +1,217 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/mcp"
+)
+
+func yesNoQuestion(text string) mcp.Question {
+	return mcp.Question{
+		Question: text,
+		Header:   "Confirm",
+		Options: []mcp.QuestionOption{
+			{Label: "Yes", Description: "Do it"},
+			{Label: "No", Description: "Skip it"},
+		},
+	}
+}
+
+func TestMatchQuestionRules(t *testing.T) {
+	questions := []mcp.Question{
+		yesNoQuestion("Should I add tests?"),
+		yesNoQuestion("Should I update the changelog?"),
+	}
+
+	tests := []struct {
+		name     string
+		rules    []config.QuestionRule
+		want     map[string]string
+		wantErrs int
+	}{
+		{
+			name:  "no rules",
+			rules: nil,
+			want:  map[string]string{},
+		},
+		{
+			name:  "substring rule answers matching question only",
+			rules: []config.QuestionRule{{Match: "add tests", Answer: "yes"}},
+			want:  map[string]string{"Should I add tests?": "Yes"},
+		},
+		{
+			name: "regex rule",
+			rules: []config.QuestionRule{
+				{Match: `^Should I (add|update) `, Regex: true, Answer: "No"},
+			},
+			want: map[string]string{"Should I add tests?": "No", "Should I update the changelog?": "No"},
+		},
+		{
+			name: "first matching rule wins",
+			rules: []config.QuestionRule{
+				{Match: "tests", Answer: "No"},
+				{Match: "should", Answer: "Yes"},
+			},
+			want: map[string]string{"Should I add tests?": "No", "Should I update the changelog?": "Yes"},
+		},
+		{
+			name: "rule naming a missing option falls through",
+			rules: []config.QuestionRule{
+				{Match: "tests", Answer: "Maybe"},
+				{Match: "tests", Answer: "Yes"},
+			},
+			want: map[string]string{"Should I add tests?": "Yes"},
+		},
+		{
+			name: "invalid regex is skipped and reported once",
+			rules: []config.QuestionRule{
+				{Match: "(unclosed", Regex: true, Answer: "Yes"},
+				{Match: "changelog", Answer: "No"},
+			},
+			want:     map[string]string{"Should I update the changelog?": "No"},
+			wantErrs: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := matchQuestionRules(tt.rules, questions)
+			if len(errs) != tt.wantErrs {
+				t.Errorf("got %d errors (%v), want %d", len(errs), errs, tt.wantErrs)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got answers %v, want %v", got, tt.want)
+			}
+			for q, label := range tt.want {
+				if got[q] != label {
+					t.Errorf("answer for %q = %q, want %q", q, got[q], label)
+				}
+			}
+		})
+	}
+}
+
+// setupQuestionRulesModel returns a model with the first session active and a rule
+// answering "add tests" questions with Yes, plus the captured question answers.
+func setupQuestionRulesModel(t *testing.T) (*Model, string, *map[string]string) {
+	t.Helper()
+	cfg := testConfigWithSessions()
+	cfg.SetQuestionRules("/test/repo1", []config.QuestionRule{{Match: "add tests", Answer: "Yes"}})
+	m, factory := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	sessionID := m.activeSession.ID
+
+	mock := factory.GetMock(sessionID)
+	if mock == nil {
+		t.Fatal("No mock runner")
+	}
+	var captured map[string]string
+	mock.OnQuestionResp = func(resp mcp.QuestionResponse) {
+		captured = resp.Answers
+	}
+	return m, sessionID, &captured
+}
+
+func TestQuestionRules_AutoAnswerAfterGracePeriod(t *testing.T) {
+	m, sessionID, captured := setupQuestionRulesModel(t)
+
+	m = simulateQuestionRequest(m, sessionID, []mcp.Question{yesNoQuestion("Should I add tests?")})
+
+	if m.chat.HasPendingQuestion() {
+		t.Error("fully matched question should not be shown")
+	}
+	pending := m.autoAnswers[sessionID]
+	if pending == nil {
+		t.Fatal("expected auto-answer to be held for the grace period")
+	}
+	if *captured != nil {
+		t.Fatal("response should not be sent before the grace period ends")
+	}
+
+	// Keys go to the input while the auto-answer is pending
+	m = sendKey(m, "1")
+	if *captured != nil {
+		t.Fatal("number keys should not answer a question being auto-answered")
+	}
+
+	result, _ := m.Update(AutoAnswerQuestionMsg{SessionID: sessionID, Seq: pending.Seq})
+	m = result.(*Model)
+
+	if (*captured)["Should I add tests?"] != "Yes" {
+		t.Errorf("expected auto-answer Yes to be sent, got %v", *captured)
+	}
+	if state := m.sessionState().GetIfExists(sessionID); state != nil && state.GetPendingQuestion() != nil {
+		t.Error("pending question should be cleared after auto-answering")
+	}
+	if !strings.Contains(m.chat.GetStreaming(), "[Auto-answered] Should I add tests? → Yes") {
+		t.Errorf("expected auto-answer recorded in transcript, got %q", m.chat.GetStreaming())
+	}
+}
+
+func TestQuestionRules_UndoReasks(t *testing.T) {
+	m, sessionID, captured := setupQuestionRulesModel(t)
+
+	m = simulateQuestionRequest(m, sessionID, []mcp.Question{yesNoQuestion("Should I add tests?")})
+	seq := m.autoAnswers[sessionID].Seq
+
+	m = sendKey(m, "ctrl+z")
+
+	if m.autoAnswers[sessionID] != nil {
+		t.Error("undo should cancel the pending auto-answer")
+	}
+	if !m.chat.HasPendingQuestion() {
+		t.Fatal("undo should show the question prompt")
+	}
+
+	// The grace period timer firing afterwards must not answer
+	result, _ := m.Update(AutoAnswerQuestionMsg{SessionID: sessionID, Seq: seq})
+	m = result.(*Model)
+	if *captured != nil {
+		t.Fatalf("undone auto-answer should not be sent, got %v", *captured)
+	}
+
+	// The rule's answer is pre-selected, so Enter confirms it
+	m = sendKey(m, "enter")
+	if (*captured)["Should I add tests?"] != "Yes" {
+		t.Errorf("expected pre-selected answer Yes, got %v", *captured)
+	}
+}
+
+func TestQuestionRules_PartialMatchPreselects(t *testing.T) {
+	m, sessionID, captured := setupQuestionRulesModel(t)
+
+	questions := []mcp.Question{
+		yesNoQuestion("Should I update the changelog?"),
+		{
+			Question: "Should I add tests?",
+			Header:   "Tests",
+			Options: []mcp.QuestionOption{
+				{Label: "No"},
+				{Label: "Yes"},
+			},
+		},
+	}
+	m = simulateQuestionRequest(m, sessionID, questions)
+
+	if m.autoAnswers[sessionID] != nil {
+		t.Fatal("partially matched request should not be auto-answered")
+	}
+	if !m.chat.HasPendingQuestion() {
+		t.Fatal("partially matched request should show the prompt")
+	}
+
+	// First question is unmatched (defaults to the first option), second is pre-selected
+	m = sendKey(m, "enter")
+	m = sendKey(m, "enter")
+
+	if (*captured)["Should I update the changelog?"] != "Yes" {
+		t.Errorf("unmatched question should default to the first option, got %v", *captured)
+	}
+	if (*captured)["Should I add tests?"] != "Yes" {
+		t.Errorf("matched question should be pre-selected, got %v", *captured)
+	}
+}
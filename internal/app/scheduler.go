@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// schedulerTickInterval is how often the scheduler looks for due jobs. Job
+// intervals are effectively rounded up to a multiple of it.
+const schedulerTickInterval = time.Second
+
+// SchedulerTickMsg drives every periodic job (see scheduler)
+type SchedulerTickMsg time.Time
+
+// SchedulerTick returns a command that sends a SchedulerTickMsg after the tick interval
+func SchedulerTick() tea.Cmd {
+	return tea.Tick(schedulerTickInterval, func(t time.Time) tea.Msg {
+		return SchedulerTickMsg(t)
+	})
+}
+
+// periodicJob is work the scheduler runs every interval.
+type periodicJob struct {
+	name         string
+	interval     func() time.Duration // Re-read each run so config changes apply; <= 0 disables the job
+	run          func() tea.Cmd
+	background   bool // Git and network polling, held while background activity is paused
+	whileBlurred bool // Keeps running while the terminal window is unfocused
+	next         time.Time
+}
+
+// scheduler runs periodic jobs off a single ticker, so pausing background
+// activity and suspending work while the window is unfocused act in one place.
+// A held job keeps its due time, so it runs on the first tick after it is released.
+type scheduler struct {
+	jobs    []*periodicJob
+	paused  bool // Background activity is paused
+	blurred bool // The terminal window is unfocused
+
+	ctx    context.Context // Background jobs' context, canceled on pausing
+	cancel context.CancelFunc
+}
+
+// register adds a job, due one interval after now.
+func (s *scheduler) register(job periodicJob, now time.Time) {
+	if interval := job.interval(); interval > 0 {
+		job.next = now.Add(interval)
+	}
+	s.jobs = append(s.jobs, &job)
+}
+
+// held returns whether a job is skipped for now even if due.
+func (s *scheduler) held(job *periodicJob) bool {
+	return (s.paused && job.background) || (s.blurred && !job.whileBlurred)
+}
+
+// tick runs the jobs due at now that are not held, and schedules their next
+// run one interval later. Returns their commands.
+func (s *scheduler) tick(now time.Time) []tea.Cmd {
+	var cmds []tea.Cmd
+	for _, job := range s.jobs {
+		interval := job.interval()
+		if interval <= 0 {
+			// Disabled; when enabled again it is due one interval later
+			job.next = time.Time{}
+			continue
+		}
+		if job.next.IsZero() {
+			job.next = now.Add(interval)
+			continue
+		}
+		if now.Before(job.next) || s.held(job) {
+			continue
+		}
+		job.next = now.Add(interval)
+		cmds = append(cmds, job.run())
+	}
+	return cmds
+}
+
+// runBackground runs every enabled background job at once, paused or not, and
+// schedules their next run one interval after now. Returns their commands.
+func (s *scheduler) runBackground(now time.Time) []tea.Cmd {
+	var cmds []tea.Cmd
+	for _, job := range s.jobs {
+		interval := job.interval()
+		if !job.background || interval <= 0 {
+			continue
+		}
+		job.next = now.Add(interval)
+		cmds = append(cmds, job.run())
+	}
+	return cmds
+}
+
+// backgroundContext returns the context background jobs poll in. Pausing
+// background activity cancels it, stopping the polls in flight; jobs run after
+// that get a new one.
+func (s *scheduler) backgroundContext() context.Context {
+	if s.ctx == nil {
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+	}
+	return s.ctx
+}
+
+// cancelBackground stops the background jobs' polls in flight.
+func (s *scheduler) cancelBackground() {
+	if s.cancel != nil {
+		s.cancel()
+		s.ctx, s.cancel = nil, nil
+	}
+}
+
+// everyInterval returns an interval func for a fixed interval.
+func everyInterval(d time.Duration) func() time.Duration {
+	return func() time.Duration { return d }
+}
+
+// newScheduler returns the scheduler of the model's periodic jobs, with
+// background activity paused if the config says to start that way.
+func (m *Model) newScheduler(now time.Time) *scheduler {
+	s := &scheduler{paused: m.config.GetPauseBackgroundActivity()}
+	s.register(periodicJob{
+		name:         "pr-status",
+		interval:     everyInterval(prPollInterval),
+		run:          m.pollPRStatuses,
+		background:   true,
+		whileBlurred: true,
+	}, now)
+	s.register(periodicJob{
+		name:         "changed-files",
+		interval:     everyInterval(overlapPollInterval),
+		run:          m.pollChangedFiles,
+		background:   true,
+		whileBlurred: true,
+	}, now)
+	// Nobody is reading the live diff while the window is unfocused, and the job
+	// stops while no live diff is showing
+	s.register(periodicJob{
+		name:       "live-diff",
+		interval:   m.liveDiffInterval,
+		run:        m.refreshLiveDiff,
+		background: true,
+	}, now)
+	s.register(periodicJob{
+		name:         "clock-check",
+		interval:     everyInterval(clockCheckInterval),
+		run:          m.checkClock,
+		whileBlurred: true,
+	}, now)
+	// A stat per session, cheap enough to keep running while paused
+	s.register(periodicJob{
+		name:     "missing-sessions",
+		interval: everyInterval(missingCheckInterval),
+		run:      m.refreshMissingSessions,
+	}, now)
+	s.register(periodicJob{
+		name:         "autosave",
+		interval:     func() time.Duration { return time.Duration(m.config.GetMessageAutosaveSec()) * time.Second },
+		run:          m.autosave,
+		whileBlurred: true,
+	}, now)
+	return s
+}
+
+// handleSchedulerTickMsg runs the periodic jobs that are due and schedules the next tick.
+func (m *Model) handleSchedulerTickMsg(msg SchedulerTickMsg) (tea.Model, tea.Cmd) {
+	cmds := m.scheduler.tick(time.Time(msg))
+	return m, tea.Batch(append(cmds, SchedulerTick())...)
+}
+
+// setBackgroundPaused pauses or resumes background activity, marking the
+// polled sidebar badges as stale while it is paused. Pausing stops the polls
+// in flight.
+func (m *Model) setBackgroundPaused(paused bool) {
+	m.scheduler.paused = paused
+	if paused {
+		m.scheduler.cancelBackground()
+	}
+	m.sidebar.SetBackgroundPaused(paused)
+	logger.Get().Info("background activity", "paused", paused)
+}
+
+// shortcutToggleBackgroundActivity pauses or resumes periodic git status, PR,
+// and live diff polling. Jobs that came due while paused run on resuming.
+func shortcutToggleBackgroundActivity(m *Model) (tea.Model, tea.Cmd) {
+	m.setBackgroundPaused(!m.scheduler.paused)
+	if m.scheduler.paused {
+		return m, m.ShowFlashInfo("Background activity paused; press g to refresh, G to resume")
+	}
+	return m, m.ShowFlashInfo("Background activity resumed")
+}
+
+// shortcutRefreshNow runs every background job at once, even while background
+// activity is paused.
+func shortcutRefreshNow(m *Model) (tea.Model, tea.Cmd) {
+	m.refreshDiffStats()
+	cmds := m.scheduler.runBackground(time.Now())
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,252 @@
+package app
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// job returns the registered job with the given name, or nil.
+func (s *scheduler) job(name string) *periodicJob {
+	for _, job := range s.jobs {
+		if job.name == name {
+			return job
+		}
+	}
+	return nil
+}
+
+// recordingScheduler returns a scheduler whose jobs append their name to ran
+// when run.
+func recordingScheduler(now time.Time, ran *[]string, jobs ...periodicJob) *scheduler {
+	s := &scheduler{}
+	for _, job := range jobs {
+		name := job.name
+		job.run = func() tea.Cmd {
+			*ran = append(*ran, name)
+			return nil
+		}
+		s.register(job, now)
+	}
+	return s
+}
+
+func TestScheduler_RunsJobsAtTheirIntervals(t *testing.T) {
+	start := time.Now()
+	var ran []string
+	s := recordingScheduler(start, &ran,
+		periodicJob{name: "fast", interval: everyInterval(2 * time.Second), whileBlurred: true},
+		periodicJob{name: "slow", interval: everyInterval(5 * time.Second), whileBlurred: true},
+	)
+
+	for sec := 1; sec <= 6; sec++ {
+		s.tick(start.Add(time.Duration(sec) * time.Second))
+	}
+	want := []string{"fast", "fast", "slow", "fast"} // At 2s, 4s, 5s, and 6s
+	if !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+}
+
+func TestScheduler_DisabledJob(t *testing.T) {
+	start := time.Now()
+	var ran []string
+	interval := time.Duration(0)
+	s := recordingScheduler(start, &ran,
+		periodicJob{name: "autosave", interval: func() time.Duration { return interval }, whileBlurred: true},
+	)
+
+	s.tick(start.Add(time.Hour))
+	if len(ran) != 0 {
+		t.Fatalf("expected a disabled job not to run, ran %v", ran)
+	}
+
+	// Enabled again, it is due one interval later
+	interval = 10 * time.Second
+	enabled := start.Add(2 * time.Hour)
+	s.tick(enabled)
+	s.tick(enabled.Add(9 * time.Second))
+	if len(ran) != 0 {
+		t.Fatalf("expected the job to wait an interval after being enabled, ran %v", ran)
+	}
+	s.tick(enabled.Add(10 * time.Second))
+	if len(ran) != 1 {
+		t.Errorf("expected the job to run an interval after being enabled, ran %v", ran)
+	}
+}
+
+func TestScheduler_PauseHoldsBackgroundJobs(t *testing.T) {
+	start := time.Now()
+	var ran []string
+	s := recordingScheduler(start, &ran,
+		periodicJob{name: "poll", interval: everyInterval(30 * time.Second), background: true, whileBlurred: true},
+		periodicJob{name: "clock", interval: everyInterval(15 * time.Second), whileBlurred: true},
+	)
+	s.paused = true
+
+	s.tick(start.Add(15 * time.Second))
+	s.tick(start.Add(30 * time.Second))
+	s.tick(start.Add(90 * time.Second))
+	if want := []string{"clock", "clock", "clock"}; !slices.Equal(ran, want) {
+		t.Fatalf("ran %v while paused, want only %v", ran, want)
+	}
+
+	// The held job is overdue, so it runs on the first tick after resuming and
+	// then one interval after that
+	ran = nil
+	s.paused = false
+	resumed := start.Add(100 * time.Second)
+	s.tick(resumed)
+	s.tick(resumed.Add(29 * time.Second))
+	s.tick(resumed.Add(30 * time.Second))
+	if want := []string{"poll", "clock", "poll"}; !slices.Equal(ran, want) {
+		t.Errorf("ran %v after resuming, want %v", ran, want)
+	}
+}
+
+func TestScheduler_ResumeBeforeDueKeepsSchedule(t *testing.T) {
+	start := time.Now()
+	var ran []string
+	s := recordingScheduler(start, &ran,
+		periodicJob{name: "poll", interval: everyInterval(30 * time.Second), background: true, whileBlurred: true},
+	)
+
+	s.paused = true
+	s.tick(start.Add(10 * time.Second))
+	s.paused = false
+	s.tick(start.Add(20 * time.Second))
+	if len(ran) != 0 {
+		t.Fatalf("expected no run before the job is due, ran %v", ran)
+	}
+	s.tick(start.Add(30 * time.Second))
+	if len(ran) != 1 {
+		t.Errorf("expected the job to run when due, ran %v", ran)
+	}
+}
+
+func TestScheduler_BlurHoldsForegroundJobs(t *testing.T) {
+	start := time.Now()
+	var ran []string
+	s := recordingScheduler(start, &ran,
+		periodicJob{name: "live-diff", interval: everyInterval(2 * time.Second), background: true},
+		periodicJob{name: "autosave", interval: everyInterval(2 * time.Second), whileBlurred: true},
+	)
+	s.blurred = true
+
+	s.tick(start.Add(4 * time.Second))
+	if want := []string{"autosave"}; !slices.Equal(ran, want) {
+		t.Fatalf("ran %v while blurred, want %v", ran, want)
+	}
+
+	ran = nil
+	s.blurred = false
+	s.tick(start.Add(5 * time.Second))
+	if want := []string{"live-diff"}; !slices.Equal(ran, want) {
+		t.Errorf("ran %v on focus, want %v", ran, want)
+	}
+}
+
+func TestScheduler_RunBackgroundIgnoresPause(t *testing.T) {
+	start := time.Now()
+	var ran []string
+	s := recordingScheduler(start, &ran,
+		periodicJob{name: "poll", interval: everyInterval(30 * time.Second), background: true},
+		periodicJob{name: "clock", interval: everyInterval(15 * time.Second), whileBlurred: true},
+	)
+	s.paused = true
+
+	now := start.Add(5 * time.Second)
+	s.runBackground(now)
+	if want := []string{"poll"}; !slices.Equal(ran, want) {
+		t.Fatalf("ran %v, want only the background job", ran)
+	}
+	if next := s.job("poll").next; !next.Equal(now.Add(30 * time.Second)) {
+		t.Errorf("expected the next run one interval after the refresh, got %v", next.Sub(now))
+	}
+}
+
+func TestNewScheduler_RegistersJobs(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetMessageAutosaveSec(-1)
+	m := testModel(cfg)
+
+	for _, name := range []string{"pr-status", "changed-files", "live-diff", "clock-check", "missing-sessions", "autosave"} {
+		if m.scheduler.job(name) == nil {
+			t.Errorf("expected a %q job", name)
+		}
+	}
+	for _, name := range []string{"pr-status", "changed-files", "live-diff"} {
+		if !m.scheduler.job(name).background {
+			t.Errorf("expected %q paused with background activity", name)
+		}
+	}
+	if m.scheduler.job("clock-check").background || m.scheduler.job("autosave").background {
+		t.Error("expected sleep detection and autosave to keep running while paused")
+	}
+
+	// Autosave's interval is re-read from the config
+	if interval := m.scheduler.job("autosave").interval(); interval > 0 {
+		t.Errorf("expected autosave disabled, got an interval of %v", interval)
+	}
+	cfg.SetMessageAutosaveSec(30)
+	if interval := m.scheduler.job("autosave").interval(); interval != 30*time.Second {
+		t.Errorf("expected the autosave interval re-read from config, got %v", interval)
+	}
+}
+
+func TestBackgroundActivity_ToggleAndConfigDefault(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetPauseBackgroundActivity(true)
+	m := testModel(cfg)
+	m.sidebar.SetSessions(cfg.Sessions)
+	if !m.scheduler.paused {
+		t.Fatal("expected background activity to start paused per config")
+	}
+
+	m = sendKey(m, "G")
+	if m.scheduler.paused {
+		t.Error("expected G to resume background activity")
+	}
+	m = sendKey(m, "G")
+	if !m.scheduler.paused {
+		t.Error("expected G to pause background activity")
+	}
+
+	// The window losing focus does not resume it
+	result, _ := m.Update(tea.BlurMsg{})
+	m = result.(*Model)
+	result, _ = m.Update(tea.FocusMsg{})
+	m = result.(*Model)
+	if !m.scheduler.paused || m.scheduler.blurred {
+		t.Errorf("expected focus to be tracked apart from the pause, paused=%v blurred=%v", m.scheduler.paused, m.scheduler.blurred)
+	}
+}
+
+func TestRefreshNow_RunsWhilePaused(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].PRCreated = true
+	m := testModel(cfg)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.setBackgroundPaused(true)
+
+	_, cmd, handled := m.ExecuteShortcut("g")
+	if !handled || cmd == nil {
+		t.Fatal("expected g to refresh PR status even while paused")
+	}
+}
+
+func TestPausingCancelsPollsInFlight(t *testing.T) {
+	m := testModel(testConfigWithSessions())
+	ctx := m.scheduler.backgroundContext()
+
+	m.setBackgroundPaused(true)
+	if ctx.Err() == nil {
+		t.Error("expected pausing to cancel the polls in flight")
+	}
+	// Refreshing while paused polls in a new context
+	if m.scheduler.backgroundContext().Err() != nil {
+		t.Error("expected a fresh context for polls after pausing")
+	}
+}
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/session"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// SessionCreateProgressMsg reports on a session being created in the
+// background: a line of git's output, or, when Done, the result.
+type SessionCreateProgressMsg struct {
+	Line    string
+	Done    bool
+	Session *config.Session // Set when Done without error
+	Error   error
+}
+
+// pendingSessionCreate is a session whose worktree is being created in the background.
+type pendingSessionCreate struct {
+	ch            chan SessionCreateProgressMsg
+	cancel        context.CancelFunc
+	useContainers bool
+}
+
+// handleLFSPrompt handles keys while the New Session modal asks how to check
+// out the repo's LFS files. The choice is remembered for the repo.
+func (m *Model) handleLFSPrompt(key string, msg tea.KeyPressMsg, state *ui.NewSessionState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		state.LFSChoice()
+		return m, nil
+	case keys.Enter:
+		repoPath := state.GetSelectedRepo()
+		switch state.LFSChoice() {
+		case ui.LFSChoiceCancel:
+			return m, nil
+		case ui.LFSChoiceSkip:
+			m.config.SetLFSMode(repoPath, config.LFSModeSkip)
+		default:
+			m.config.SetLFSMode(repoPath, config.LFSModeFull)
+		}
+		saveCmd := m.saveConfigOrFlash()
+		model, cmd := m.submitNewSession(state)
+		return model, tea.Batch(saveCmd, cmd)
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// initialCommitMessage returns the message of the empty first commit offered
+// for repos without commits.
+func (m *Model) initialCommitMessage() string {
+	if message := strings.TrimSpace(m.config.GetInitialCommitMessage()); message != "" {
+		return message
+	}
+	return session.DefaultInitialCommitMessage
+}
+
+// handleInitialCommitPrompt handles keys while the New Session modal offers to
+// make the first commit of a repo without commits, then creates the session.
+func (m *Model) handleInitialCommitPrompt(key string, state *ui.NewSessionState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		state.HideInitialCommitPrompt()
+	case keys.Enter:
+		state.HideInitialCommitPrompt()
+		repoPath := state.GetSelectedRepo()
+		if err := m.sessionService.CreateInitialCommit(context.Background(), repoPath, state.InitialCommitMessage); err != nil {
+			logger.Get().Error("failed to create initial commit", "repo", repoPath, "error", err)
+			m.modal.SetError(err.Error())
+			return m, nil
+		}
+		return m.submitNewSession(state)
+	}
+	return m, nil
+}
+
+// createNewSessionAsync creates a session from an LFS repo in the background,
+// streaming git's output into the New Session modal, where Esc cancels.
+func (m *Model) createNewSessionAsync(repoPath, branchName, branchPrefix string, basePoint session.BasePoint, useContainers bool) (tea.Model, tea.Cmd) {
+	state, ok := m.modal.State.(*ui.NewSessionState)
+	if !ok {
+		// Container checks may have replaced the New Session modal; progress needs one
+		state = ui.NewNewSessionState(m.config.GetRepos(), false, false)
+		state.LockedRepo = repoPath
+		m.modal.Show(state)
+	}
+	skipLFS := m.config.GetLFSMode(repoPath) == config.LFSModeSkip
+	state.StartCreating(skipLFS)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan SessionCreateProgressMsg)
+	m.pendingCreate = &pendingSessionCreate{ch: ch, cancel: cancel, useContainers: useContainers}
+	sessionService := m.sessionService
+	go func() {
+		defer close(ch)
+		opts := session.WorktreeOptions{
+			SkipLFSSmudge: skipLFS,
+			Progress: func(line string) {
+				ch <- SessionCreateProgressMsg{Line: line}
+			},
+		}
+		sess, err := sessionService.CreateWithOptions(ctx, repoPath, branchName, branchPrefix, basePoint, opts)
+		ch <- SessionCreateProgressMsg{Done: true, Session: sess, Error: err}
+	}()
+	return m, listenForSessionCreate(ch)
+}
+
+// listenForSessionCreate creates a command waiting for the next update from a
+// session being created in the background.
+func listenForSessionCreate(ch <-chan SessionCreateProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return SessionCreateProgressMsg{Done: true, Error: errors.New("session creation ended without a result")}
+		}
+		return msg
+	}
+}
+
+// cancelSessionCreate stops the session being created in the background. Its
+// partial worktree is removed before the result arrives.
+func (m *Model) cancelSessionCreate(state *ui.NewSessionState) {
+	if m.pendingCreate == nil {
+		return
+	}
+	m.pendingCreate.cancel()
+	state.SetCreateProgress("Cancelling...", -1)
+}
+
+// handleSessionCreateProgressMsg shows progress from a session being created
+// in the background and, once it is done, adds the session or shows why not.
+func (m *Model) handleSessionCreateProgressMsg(msg SessionCreateProgressMsg) (tea.Model, tea.Cmd) {
+	pending := m.pendingCreate
+	if pending == nil {
+		return m, nil
+	}
+	state, _ := m.modal.State.(*ui.NewSessionState)
+
+	if !msg.Done {
+		if state != nil {
+			percent := -1
+			if p, _, _, ok := session.ParseLFSProgress(msg.Line); ok {
+				percent = p
+			}
+			state.SetCreateProgress(msg.Line, percent)
+		}
+		return m, listenForSessionCreate(pending.ch)
+	}
+
+	m.pendingCreate = nil
+	pending.cancel()
+	if state != nil {
+		state.StopCreating()
+	}
+	if msg.Error != nil {
+		if errors.Is(msg.Error, context.Canceled) {
+			return m, m.ShowFlashInfo("Session creation cancelled; the partial worktree was removed")
+		}
+		logger.Get().Error("failed to create session", "error", msg.Error)
+		if state == nil {
+			return m, m.ShowFlashError("Failed to create session: " + msg.Error.Error())
+		}
+		m.modal.SetError(msg.Error.Error())
+		return m, nil
+	}
+	return m.finishNewSession(msg.Session, pending.useContainers)
+}
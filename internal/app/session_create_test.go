@@ -0,0 +1,238 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	pexec "github.com/zhubert/plural/internal/exec"
+	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/session"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// lfsTestModel returns a model showing the New Session modal for a repo using
+// Git LFS, with git mocked by the returned executor.
+func lfsTestModel(t *testing.T) (*Model, *pexec.MockExecutor, string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	repo := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repo, ".gitattributes"), []byte("*.psd filter=lfs diff=lfs merge=lfs -text\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := testConfig()
+	cfg.Repos = []string{repo}
+	cfg.SetFilePath(filepath.Join(home, "config.json"))
+
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	mockExec := pexec.NewMockExecutor(nil)
+	m.SetSessionService(session.NewSessionServiceWithExecutor(mockExec))
+	m.modal.Show(ui.NewNewSessionState(cfg.Repos, false, false))
+	return m, mockExec, repo
+}
+
+// drainSessionCreate feeds the background session creation's updates to the
+// model until it is done, returning the percentages shown along the way.
+func drainSessionCreate(t *testing.T, m *Model) []int {
+	t.Helper()
+	var percents []int
+	for m.pendingCreate != nil {
+		msg := listenForSessionCreate(m.pendingCreate.ch)()
+		m.Update(msg)
+		if state, ok := m.modal.State.(*ui.NewSessionState); ok && state.Creating {
+			percents = append(percents, state.CreatePercent)
+		}
+	}
+	return percents
+}
+
+// worktreeAddCall returns the recorded command creating a worktree, if any.
+func worktreeAddCall(mockExec *pexec.MockExecutor) *pexec.MockCall {
+	for _, call := range mockExec.GetCalls() {
+		if slices.Contains(call.Args, "worktree") && slices.Contains(call.Args, "add") {
+			return &call
+		}
+	}
+	return nil
+}
+
+func TestNewSession_LFSPromptRemembersSkip(t *testing.T) {
+	m, mockExec, repo := lfsTestModel(t)
+	mockExec.AddPrefixMatch("env", []string{"GIT_LFS_SKIP_SMUDGE=1", "git", "worktree", "add"}, pexec.MockResponse{
+		Stderr: []byte("Preparing worktree (new branch 'feature')\n"),
+	})
+
+	m = sendKey(m, "enter")
+	state, ok := m.modal.State.(*ui.NewSessionState)
+	if !ok || !state.LFSPrompt {
+		t.Fatal("expected the LFS choice to be offered for an LFS repo")
+	}
+	if worktreeAddCall(mockExec) != nil {
+		t.Fatal("no worktree should be created before the choice is made")
+	}
+
+	m = sendKey(m, "down")
+	m = sendKey(m, "enter")
+	if mode := m.config.GetLFSMode(repo); mode != config.LFSModeSkip {
+		t.Errorf("expected the skip choice to be remembered, got %q", mode)
+	}
+	if !state.Creating || !state.CreateSkipsLFS {
+		t.Fatal("expected creation progress to be shown, skipping LFS files")
+	}
+
+	drainSessionCreate(t, m)
+	add := worktreeAddCall(mockExec)
+	if add == nil || add.Name != "env" || add.Args[0] != "GIT_LFS_SKIP_SMUDGE=1" {
+		t.Fatalf("expected the worktree to be created with GIT_LFS_SKIP_SMUDGE=1, got %+v", add)
+	}
+	if len(m.config.GetSessions()) != 1 {
+		t.Fatalf("expected the session to be added, got %d sessions", len(m.config.GetSessions()))
+	}
+	if m.modal.IsVisible() {
+		t.Errorf("expected the modal to close once the session is created, got %T", m.modal.State)
+	}
+}
+
+func TestNewSession_LFSModeRememberedSkipsPrompt(t *testing.T) {
+	m, mockExec, repo := lfsTestModel(t)
+	m.config.SetLFSMode(repo, config.LFSModeFull)
+	mockExec.AddPrefixMatch("env", []string{"GIT_LFS_FORCE_PROGRESS=1", "git", "worktree", "add"}, pexec.MockResponse{
+		Stderr: []byte("Filtering content:  50% (1/2)\rFiltering content: 100% (2/2), done.\n"),
+	})
+
+	m = sendKey(m, "enter")
+	state := m.modal.State.(*ui.NewSessionState)
+	if state.LFSPrompt {
+		t.Fatal("expected the remembered choice to be used without asking")
+	}
+
+	percents := drainSessionCreate(t, m)
+	if !slices.Equal(percents, []int{50, 100}) {
+		t.Errorf("expected LFS download progress 50%% then 100%%, got %v", percents)
+	}
+	if add := worktreeAddCall(mockExec); add == nil || !slices.Equal(add.Args[:2], []string{"GIT_LFS_FORCE_PROGRESS=1", "git"}) {
+		t.Fatalf("expected a full checkout with LFS progress forced, got %+v", add)
+	}
+	if len(m.config.GetSessions()) != 1 {
+		t.Errorf("expected the session to be added, got %d sessions", len(m.config.GetSessions()))
+	}
+}
+
+func TestNewSession_LFSPromptEscapeReturnsToForm(t *testing.T) {
+	m, mockExec, repo := lfsTestModel(t)
+
+	m = sendKey(m, "enter")
+	m = sendKey(m, "esc")
+	state, ok := m.modal.State.(*ui.NewSessionState)
+	if !ok || !m.modal.IsVisible() || state.LFSPrompt {
+		t.Fatal("expected Esc to return to the New Session form")
+	}
+	if mode := m.config.GetLFSMode(repo); mode != "" {
+		t.Errorf("expected no choice to be remembered, got %q", mode)
+	}
+	if worktreeAddCall(mockExec) != nil {
+		t.Error("expected no worktree to be created")
+	}
+}
+
+func TestNewSession_CancelLFSCreate(t *testing.T) {
+	m, mockExec, repo := lfsTestModel(t)
+	m.config.SetLFSMode(repo, config.LFSModeFull)
+	// A killed git reports an error; the mock can't observe the cancellation itself
+	mockExec.AddPrefixMatch("env", []string{"GIT_LFS_FORCE_PROGRESS=1", "git", "worktree", "add"}, pexec.MockResponse{
+		Stderr: []byte("Filtering content:  10% (1/10)\n"),
+		Err:    errors.New("signal: killed"),
+	})
+
+	m = sendKey(m, "enter")
+	state := m.modal.State.(*ui.NewSessionState)
+	if !state.Creating {
+		t.Fatal("expected creation to be in progress")
+	}
+	m = sendKey(m, "esc")
+	if !m.modal.IsVisible() {
+		t.Fatal("Esc during creation should cancel it, not close the modal")
+	}
+
+	drainSessionCreate(t, m)
+	if state.Creating {
+		t.Error("expected the form to be shown again after cancelling")
+	}
+	if len(m.config.GetSessions()) != 0 {
+		t.Errorf("expected no session after cancelling, got %d", len(m.config.GetSessions()))
+	}
+	if !m.footer.HasFlash() {
+		t.Error("expected a flash confirming the cancellation")
+	}
+}
+
+func TestNewSession_EmptyRepoOffersInitialCommit(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	cfg := testConfig()
+	cfg.Repos = []string{repo}
+	cfg.SetFilePath(filepath.Join(home, "config.json"))
+	cfg.SetInitialCommitMessage("Start the project")
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.modal.Show(ui.NewNewSessionState(cfg.Repos, false, false))
+
+	m = sendKey(m, "enter")
+	state, ok := m.modal.State.(*ui.NewSessionState)
+	if !ok || !state.InitialCommitPrompt {
+		t.Fatal("expected a first commit to be offered for a repo without commits")
+	}
+	if state.InitialCommitMessage != "Start the project" {
+		t.Errorf("expected the configured commit message, got %q", state.InitialCommitMessage)
+	}
+
+	// Esc goes back to the form without committing
+	m = sendKey(m, "esc")
+	if state.InitialCommitPrompt || !m.modal.IsVisible() {
+		t.Fatal("expected Esc to return to the form")
+	}
+	if !m.sessionService.IsEmptyRepo(context.Background(), repo) {
+		t.Fatal("expected no commit after going back")
+	}
+
+	m = sendKey(m, "enter")
+	m = sendKey(m, "enter")
+	if len(cfg.GetSessions()) != 1 {
+		t.Fatalf("expected the session created after the first commit, error %q", m.modal.GetError())
+	}
+	cmd := exec.Command("git", "log", "-1", "--format=%s")
+	cmd.Dir = repo
+	if output, err := cmd.Output(); err != nil || strings.TrimSpace(string(output)) != "Start the project" {
+		t.Errorf("expected the first commit made with the configured message, got %q (%v)", output, err)
+	}
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// sessionInfo returns what the chat shows about a session above its conversation.
+func sessionInfo(cfg *config.Config, sess *config.Session) *ui.SessionInfo {
+	return &ui.SessionInfo{
+		Repo:     filepath.Base(sess.RepoPath),
+		Branch:   sess.Branch,
+		Worktree: sess.WorkTree,
+		Base:     sess.BaseBranch,
+		Claude:   sess.CLIVersion,
+		Denied:   strings.Join(cfg.GetDeniedToolsForRepo(sess.RepoPath), ", "),
+	}
+}
+
+// shortcutCopyWorktreePath copies the selected session's worktree path, for
+// opening it in an editor.
+func shortcutCopyWorktreePath(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	return m, ui.CopyToClipboard(sess.WorkTree, "Copied worktree path")
+}
+
+// shortcutCopyBranch copies the selected session's branch name.
+func shortcutCopyBranch(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	return m, ui.CopyToClipboard(sess.Branch, "Copied branch name")
+}
@@ -0,0 +1,33 @@
+package app
+
+import "testing"
+
+func TestSessionInfo_FromSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].CLIVersion = "2.0.14"
+	info := sessionInfo(cfg, &cfg.Sessions[0])
+	if info.Repo != "repo1" || info.Branch != "feature-branch" || info.Worktree != "/test/worktree1" || info.Claude != "2.0.14" {
+		t.Errorf("unexpected session info %+v", info)
+	}
+	if info.Denied != "" {
+		t.Errorf("expected no denied tools, got %q", info.Denied)
+	}
+
+	cfg.SetRepoDeniedTools(cfg.Sessions[0].RepoPath, []string{"Bash(rm:*)", "WebFetch"})
+	if info := sessionInfo(cfg, &cfg.Sessions[0]); info.Denied != "Bash(rm:*), WebFetch" {
+		t.Errorf("expected the repo's denied tools, got %q", info.Denied)
+	}
+}
+
+func TestCopySessionShortcuts(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModel(cfg)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	for _, key := range []string{"y", "Y"} {
+		_, cmd, handled := m.ExecuteShortcut(key)
+		if !handled || cmd == nil {
+			t.Errorf("expected %s to copy from the selected session", key)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/session"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// sessionNameRepairs returns the sessions saved, before names were checked,
+// with a branch git refuses, as gitAccepts reports, or a name with control
+// characters, along with what to rename them to.
+func sessionNameRepairs(sessions []config.Session, gitAccepts func(branch string) bool) []ui.SessionNameRepair {
+	var repairs []ui.SessionNameRepair
+	for _, sess := range sessions {
+		newBranch := sess.Branch
+		if newBranch != "" && !gitAccepts(sess.Branch) {
+			normalized, err := session.NormalizeBranchName(sess.Branch)
+			if err != nil {
+				normalized = session.SanitizeBranchName(sess.Branch)
+			}
+			if normalized == "" {
+				normalized = "plural-" + sess.ID
+			}
+			newBranch = normalized
+		}
+		// Renamed sessions are named after their branch
+		newName := session.CleanSessionName(sess.Name)
+		if sess.Name == sess.Branch || newName == "" {
+			newName = newBranch
+		}
+		if newName != sess.Name || newBranch != sess.Branch {
+			repairs = append(repairs, ui.SessionNameRepair{
+				SessionID: sess.ID,
+				OldName:   sess.Name,
+				NewName:   newName,
+				OldBranch: sess.Branch,
+				NewBranch: newBranch,
+			})
+		}
+	}
+	return repairs
+}
+
+// pendingSessionNameRepairs returns the repairs to offer at startup, leaving
+// out sessions the user already chose not to rename.
+func (m *Model) pendingSessionNameRepairs() []ui.SessionNameRepair {
+	var sessions []config.Session
+	for _, sess := range m.config.GetSessions() {
+		if !m.config.IsNameRepairSkipped(sess.ID) {
+			sessions = append(sessions, sess)
+		}
+	}
+	ctx := context.Background()
+	return sessionNameRepairs(sessions, func(branch string) bool {
+		return m.sessionService.GitAcceptsBranchName(ctx, branch)
+	})
+}
+
+// handleRepairSessionNamesModal handles key events for the Repair Session Names modal.
+func (m *Model) handleRepairSessionNamesModal(key string, msg tea.KeyPressMsg, state *ui.RepairSessionNamesState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, m.skipSessionNameRepairs(state.Repairs)
+	case keys.Enter:
+		m.modal.Hide()
+		if !state.ShouldRename() {
+			return m, m.skipSessionNameRepairs(state.Repairs)
+		}
+		return m, m.repairSessionNames(state.Repairs)
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// skipSessionNameRepairs remembers that the user chose not to rename the
+// sessions, so they aren't offered again at every launch.
+func (m *Model) skipSessionNameRepairs(repairs []ui.SessionNameRepair) tea.Cmd {
+	ids := make([]string, len(repairs))
+	for i, r := range repairs {
+		ids[i] = r.SessionID
+	}
+	m.config.SkipNameRepairs(ids)
+	return m.saveConfigOrFlash()
+}
+
+// repairSessionNames renames the sessions and their branches as listed. A
+// branch that isn't there, as git refused to create it, is only renamed in the
+// config; one git fails to rename leaves its session as it was.
+func (m *Model) repairSessionNames(repairs []ui.SessionNameRepair) tea.Cmd {
+	ctx := context.Background()
+	var failed []string
+	renamed := 0
+	for _, r := range repairs {
+		sess := m.config.GetSession(r.SessionID)
+		if sess == nil {
+			continue
+		}
+		log := logger.WithSession(r.SessionID)
+		if r.NewBranch != r.OldBranch && m.sessionService.BranchExists(ctx, sess.RepoPath, r.OldBranch) {
+			if err := m.gitService.RenameBranch(ctx, sess.WorkTree, r.OldBranch, r.NewBranch); err != nil {
+				log.Warn("failed to rename branch of session with a bad name", "branch", r.OldBranch, "newBranch", r.NewBranch, "error", err)
+				failed = append(failed, r.NewBranch)
+				continue
+			}
+		}
+		m.config.RenameSession(r.SessionID, r.NewName, r.NewBranch)
+		log.Info("repaired session name", "name", r.NewName, "branch", r.NewBranch)
+		renamed++
+	}
+	m.sidebar.SetSessions(m.getFilteredSessions())
+
+	if cmd := m.saveConfigOrFlash(); cmd != nil {
+		return cmd
+	}
+	if len(failed) > 0 {
+		return m.ShowFlashWarning(fmt.Sprintf("Renamed %d session(s); couldn't rename the branch for %s", renamed, strings.Join(failed, ", ")))
+	}
+	return m.ShowFlashSuccess(fmt.Sprintf("Renamed %d session(s)", renamed))
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	pexec "github.com/zhubert/plural/internal/exec"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/session"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestSessionNameRepairs(t *testing.T) {
+	sessions := []config.Session{
+		{ID: "ok", Branch: "feature-branch", Name: "repo/feature"},
+		{ID: "escape", Branch: "fix\x1b[31m-colors", Name: "fix\x1b[31m-colors"},
+		{ID: "space", Branch: "my branch", Name: "repo/my branch"},
+		{ID: "dots", Branch: "../../etc", Name: "repo/etc"},
+		{ID: "empty", Branch: "\x07\x08", Name: "\x07\x08"},
+		// Stricter than new names are held to, but fine by git
+		{ID: "unicode", Branch: "feature/über-fix", Name: "feature/über-fix"},
+		{ID: "long", Branch: strings.Repeat("a", 120), Name: "repo/long"},
+	}
+	rejected := []string{"fix\x1b[31m-colors", "my branch", "../../etc", "\x07\x08"}
+	gitAccepts := func(branch string) bool { return !slices.Contains(rejected, branch) }
+
+	repairs := sessionNameRepairs(sessions, gitAccepts)
+
+	want := map[string]ui.SessionNameRepair{
+		"escape": {SessionID: "escape", OldName: "fix\x1b[31m-colors", NewName: "fix-colors", OldBranch: "fix\x1b[31m-colors", NewBranch: "fix-colors"},
+		"space":  {SessionID: "space", OldName: "repo/my branch", NewName: "repo/my branch", OldBranch: "my branch", NewBranch: "my-branch"},
+		"dots":   {SessionID: "dots", OldName: "repo/etc", NewName: "repo/etc", OldBranch: "../../etc", NewBranch: "etc"},
+		"empty":  {SessionID: "empty", OldName: "\x07\x08", NewName: "plural-empty", OldBranch: "\x07\x08", NewBranch: "plural-empty"},
+	}
+	if len(repairs) != len(want) {
+		t.Fatalf("expected %d repairs, got %d: %+v", len(want), len(repairs), repairs)
+	}
+	for _, r := range repairs {
+		if r != want[r.SessionID] {
+			t.Errorf("repair of %s = %+v, want %+v", r.SessionID, r, want[r.SessionID])
+		}
+	}
+}
+
+func TestStartupModal_RepairSessionNames(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].Branch = "feature branch"
+	cfg.Sessions[0].Name = "feature branch"
+	cfg.Sessions[2].Branch = "bug\x1b[0mfix"
+	cfg.SetFilePath(filepath.Join(home, "config.json"))
+	m, _ := testModelWithMocks(cfg, 120, 40)
+
+	mockExec := pexec.NewMockExecutor(nil)
+	// Only the first session's branch was ever created
+	mockExec.AddExactMatch("git", []string{"rev-parse", "--verify", "bug\x1b[0mfix"}, pexec.MockResponse{Err: errors.New("unknown revision")})
+	for _, branch := range []string{"feature branch", "bug\x1b[0mfix"} {
+		mockExec.AddExactMatch("git", []string{"check-ref-format", "--branch", branch}, pexec.MockResponse{Err: errors.New("exit status 128")})
+	}
+	m.SetSessionService(session.NewSessionServiceWithExecutor(mockExec))
+	m.SetGitService(git.NewGitServiceWithExecutor(mockExec))
+
+	m.showStartupModal()
+	state, ok := m.modal.State.(*ui.RepairSessionNamesState)
+	if !ok {
+		t.Fatalf("expected the Repair Session Names modal, got %T", m.modal.State)
+	}
+	if len(state.Repairs) != 2 {
+		t.Fatalf("expected 2 sessions to repair, got %+v", state.Repairs)
+	}
+
+	m.Update(keyPress("enter"))
+
+	if m.modal.IsVisible() {
+		t.Error("expected the modal hidden")
+	}
+	if sess := cfg.GetSession("session-1"); sess.Branch != "feature-branch" || sess.Name != "feature-branch" {
+		t.Errorf("expected session-1 renamed to feature-branch, got %q / %q", sess.Name, sess.Branch)
+	}
+	if sess := cfg.GetSession("session-3"); sess.Branch != "bugfix" || sess.Name != "repo2/bugfix" {
+		t.Errorf("expected session-3's branch renamed to bugfix, got %q / %q", sess.Name, sess.Branch)
+	}
+
+	var renamed [][]string
+	for _, call := range mockExec.GetCalls() {
+		if len(call.Args) > 1 && call.Args[0] == "branch" && call.Args[1] == "-m" {
+			renamed = append(renamed, call.Args[2:])
+		}
+	}
+	if len(renamed) != 1 || !slices.Equal(renamed[0], []string{"feature branch", "feature-branch"}) {
+		t.Errorf("expected only the existing branch renamed in git, got %v", renamed)
+	}
+	if !m.footer.HasFlash() {
+		t.Error("expected a flash message reporting the renames")
+	}
+}
+
+func TestStartupModal_RepairSessionNamesNotNow(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].Branch = "feature branch"
+	cfg.SetFilePath(filepath.Join(home, "config.json"))
+	m, _ := testModelWithMocks(cfg, 120, 40)
+
+	m.showStartupModal()
+	if _, ok := m.modal.State.(*ui.RepairSessionNamesState); !ok {
+		t.Fatalf("expected the Repair Session Names modal, got %T", m.modal.State)
+	}
+	m.Update(keyPress("down"))
+	m.Update(keyPress("enter"))
+
+	if m.modal.IsVisible() {
+		t.Error("expected the modal hidden")
+	}
+	if sess := cfg.GetSession("session-1"); sess.Branch != "feature branch" {
+		t.Errorf("expected session-1 left alone, got branch %q", sess.Branch)
+	}
+
+	// Not asked again on the next launch
+	m.showStartupModal()
+	if _, ok := m.modal.State.(*ui.RepairSessionNamesState); ok {
+		t.Error("expected sessions the user chose not to rename left out of the prompt")
+	}
+}
+
+func TestStartupModal_NoRepairForCleanNames(t *testing.T) {
+	m, _ := testModelWithMocks(testConfigWithSessions(), 120, 40)
+
+	m.showStartupModal()
+	if m.modal.IsVisible() {
+		t.Errorf("expected no startup modal, got %T", m.modal.State)
+	}
+}
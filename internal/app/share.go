@@ -0,0 +1,93 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/share"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// SetShareLAN lets shared sessions be watched from the local network instead of
+// only from this machine. Must be called before the program starts.
+func (m *Model) SetShareLAN(lan bool) {
+	m.shareLAN = lan
+}
+
+// isShared returns whether a session is being shared.
+func (m *Model) isShared(sessionID string) bool {
+	return m.shares[sessionID] != nil
+}
+
+// shortcutToggleShare starts or stops sharing the selected session read-only.
+func shortcutToggleShare(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	if m.isShared(sess.ID) {
+		m.stopShare(sess.ID)
+		return m, m.ShowFlashInfo("Stopped sharing " + ui.SessionDisplayName(sess.Branch, sess.Name))
+	}
+
+	server, err := share.Start(ui.SessionDisplayName(sess.Branch, sess.Name), share.Options{LAN: m.shareLAN})
+	if err != nil {
+		logger.WithSession(sess.ID).Error("failed to start share", "error", err)
+		return m, m.ShowFlashError(fmt.Sprintf("Failed to share session: %v", err))
+	}
+	m.shares[sess.ID] = server
+	m.publishShare(sess.ID)
+
+	watchCmd := "plural watch " + server.URL()
+	logger.WithSession(sess.ID).Info("sharing session", "url", server.URL(), "lan", m.shareLAN)
+	return m, tea.Batch(
+		m.ShowFlashSuccess("Sharing read-only: "+watchCmd),
+		ui.CopyToClipboard(watchCmd, "Sharing read-only (copied): "+watchCmd),
+	)
+}
+
+// publishShare sends a shared session's current transcript to its watchers.
+func (m *Model) publishShare(sessionID string) {
+	server := m.shares[sessionID]
+	if server == nil {
+		return
+	}
+	server.Publish(m.shareTranscript(sessionID))
+}
+
+// shareTranscript returns a session's transcript, including any response still streaming.
+func (m *Model) shareTranscript(sessionID string) []share.Message {
+	var messages []share.Message
+	if runner := m.sessionMgr.GetRunner(sessionID); runner != nil {
+		for _, msg := range runner.GetMessagesWithStreaming() {
+			messages = append(messages, share.Message{Role: msg.Role, Content: msg.Content})
+		}
+	} else {
+		saved, err := config.LoadSessionMessages(sessionID)
+		if err != nil {
+			logger.WithSession(sessionID).Warn("failed to load messages to share", "error", err)
+		}
+		for _, msg := range saved {
+			messages = append(messages, share.Message{Role: msg.Role, Content: msg.Content})
+		}
+	}
+	return messages
+}
+
+// stopShare stops sharing a session, telling its watchers the share ended.
+func (m *Model) stopShare(sessionID string) {
+	server := m.shares[sessionID]
+	if server == nil {
+		return
+	}
+	delete(m.shares, sessionID)
+	if err := server.Close(); err != nil {
+		logger.WithSession(sessionID).Warn("failed to stop share server", "error", err)
+	}
+}
+
+// stopAllShares stops every share.
+func (m *Model) stopAllShares() {
+	for id := range m.shares {
+		m.stopShare(id)
+	}
+}
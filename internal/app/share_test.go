@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zhubert/plural/internal/share"
+)
+
+// watchShare connects a watcher to a share, waits for its initial snapshot, and
+// returns the channel receiving the watch's final error.
+func watchShare(t *testing.T, url string) <-chan error {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	events := make(chan share.Event, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- share.Watch(ctx, url, func(e share.Event) { events <- e })
+	}()
+	select {
+	case e := <-events:
+		if e.Type != share.EventSnapshot {
+			t.Errorf("first event = %q, want snapshot", e.Type)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for snapshot")
+	}
+	return done
+}
+
+// waitForShareEnd waits for a watcher to be told the share ended.
+func waitForShareEnd(t *testing.T, done <-chan error) {
+	t.Helper()
+	select {
+	case err := <-done:
+		if !errors.Is(err, share.ErrShareEnded) {
+			t.Errorf("watch ended with %v, want ErrShareEnded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for share to end")
+	}
+}
+
+func TestShareShortcut_TogglesShare(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	t.Cleanup(m.stopAllShares)
+
+	sessionID := m.sidebar.SelectedSession().ID
+	m = sendKey(m, "S")
+	server := m.shares[sessionID]
+	if server == nil {
+		t.Fatal("Expected S to start sharing the selected session")
+	}
+	if m.shareLAN {
+		t.Error("Sharing should be localhost-only unless --share-lan is set")
+	}
+
+	done := watchShare(t, server.URL())
+
+	m = sendKey(m, "S")
+	if m.isShared(sessionID) {
+		t.Error("Expected S to stop sharing")
+	}
+	waitForShareEnd(t, done)
+}
+
+func TestShare_StopsWhenSessionDeleted(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	sessionID := m.sidebar.SelectedSession().ID
+	m = sendKey(m, "S")
+	if !m.isShared(sessionID) {
+		t.Fatal("Expected session to be shared")
+	}
+	done := watchShare(t, m.shares[sessionID].URL())
+
+	m = sendKey(m, "d")
+	m = sendKey(m, "enter")
+	if m.config.GetSession(sessionID) != nil {
+		t.Fatal("Expected session to be deleted")
+	}
+	if m.isShared(sessionID) {
+		t.Error("Expected share to stop when its session is deleted")
+	}
+	waitForShareEnd(t, done)
+}
+
+func TestShare_StopsOnClose(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "S")
+	if len(m.shares) != 1 {
+		t.Fatalf("Expected one share, got %d", len(m.shares))
+	}
+	m.Close()
+	if len(m.shares) != 0 {
+		t.Error("Expected Close to stop all shares")
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/keys"
 	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/manager"
 	"github.com/zhubert/plural/internal/process"
 	"github.com/zhubert/plural/internal/ui"
 )
@@ -67,6 +68,8 @@ var ShortcutRegistry = []Shortcut{
 		Description: "Switch between sidebar and chat",
 		Category:    CategoryNavigation,
 		Handler:     shortcutToggleFocus,
+		// The inline layout shows only the chat, so there is nothing to switch to
+		Condition: func(m *Model) bool { return !m.inline() },
 	},
 	{
 		Key:             "/",
@@ -74,7 +77,16 @@ var ShortcutRegistry = []Shortcut{
 		Category:        CategoryNavigation,
 		RequiresSidebar: true,
 		Handler:         shortcutSearch,
-		Condition:       func(m *Model) bool { return !m.sidebar.IsSearchMode() },
+		// The sidebar is hidden in the inline layout; the session switcher replaces its search
+		Condition: func(m *Model) bool { return !m.sidebar.IsSearchMode() && !m.inline() },
+	},
+	{
+		Key:         keys.CtrlG,
+		DisplayKey:  "ctrl-g",
+		Description: "Switch session",
+		Category:    CategoryNavigation,
+		Handler:     shortcutSessionSwitcher,
+		Condition:   func(m *Model) bool { return len(m.config.GetSessions()) > 0 },
 	},
 
 	// Sessions
@@ -93,6 +105,41 @@ var ShortcutRegistry = []Shortcut{
 		RequiresSession: true,
 		Handler:         shortcutDeleteSession,
 	},
+	{
+		Key:             "b",
+		Description:     "Pin/unpin session to the top",
+		Category:        CategorySessions,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutTogglePin,
+	},
+	{
+		Key:             "#",
+		Description:     "Edit tags of selected session",
+		Category:        CategorySessions,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutEditTags,
+	},
+	{
+		Key:             "=",
+		Description:     "Filter sessions by tag",
+		Category:        CategorySessions,
+		RequiresSidebar: true,
+		Handler:         shortcutFilterByTag,
+	},
+	{
+		Key:             "D",
+		Description:     "Mark todo items done or not done",
+		Category:        CategorySessions,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutTodoFocus,
+		Condition: func(m *Model) bool {
+			sess := m.sidebar.SelectedSession()
+			return m.activeSession != nil && m.activeSession.ID == sess.ID && m.chat.HasTodoList()
+		},
+	},
 	{
 		Key:             "f",
 		Description:     "Fork selected session",
@@ -101,6 +148,22 @@ var ShortcutRegistry = []Shortcut{
 		RequiresSession: true,
 		Handler:         shortcutForkSession,
 	},
+	{
+		Key:             "S",
+		Description:     "Share session read-only (toggle)",
+		Category:        CategorySessions,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutToggleShare,
+	},
+	{
+		Key:             "C",
+		Description:     "Edit context files (re-sent on fresh sessions)",
+		Category:        CategorySessions,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutContextFiles,
+	},
 	{
 		Key:             "i",
 		Description:     "Import GitHub issues",
@@ -136,13 +199,37 @@ var ShortcutRegistry = []Shortcut{
 		RequiresSession: true,
 		Handler:         shortcutRenameSession,
 	},
+	{
+		Key:             "y",
+		Description:     "Copy worktree path",
+		Category:        CategorySessions,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutCopyWorktreePath,
+	},
+	{
+		Key:             "Y",
+		Description:     "Copy branch name",
+		Category:        CategorySessions,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutCopyBranch,
+	},
+	{
+		Key:             "K",
+		Description:     "Switch model for the next messages",
+		Category:        CategorySessions,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutSwitchModel,
+	},
 	{
 		Key:             "s",
 		Description:     "Multi-select sessions",
 		Category:        CategorySessions,
 		RequiresSidebar: true,
 		Handler:         shortcutMultiSelect,
-		Condition:       func(m *Model) bool { return len(m.config.GetSessions()) > 0 },
+		Condition:       func(m *Model) bool { return len(m.config.GetSessions()) > 0 && !m.inline() },
 	},
 	// Git Operations
 	{
@@ -161,6 +248,65 @@ var ShortcutRegistry = []Shortcut{
 		RequiresSession: true,
 		Handler:         shortcutViewChanges,
 	},
+	{
+		Key:             "e",
+		Description:     "Files Claude touched (open in $EDITOR)",
+		Category:        CategoryGit,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutTouchedFiles,
+	},
+	{
+		Key:             "O",
+		Description:     "Open nearest file:line reference in $EDITOR",
+		Category:        CategoryGit,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutOpenFileRef,
+	},
+	{
+		Key:             keys.CtrlF,
+		DisplayKey:      "ctrl-f",
+		Description:     "Toggle between chat and live diff",
+		Category:        CategoryGit,
+		RequiresSession: true,
+		Handler:         shortcutToggleLiveDiff,
+	},
+	{
+		Key:             "g",
+		Description:     "Refresh git and PR status now",
+		Category:        CategoryGit,
+		RequiresSidebar: true,
+		Handler:         shortcutRefreshNow,
+	},
+	{
+		Key:             "t",
+		Description:     "Take a snapshot of the worktree",
+		Category:        CategoryGit,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutTakeSnapshot,
+	},
+	{
+		Key:             "T",
+		Description:     "Compare snapshots of the worktree",
+		Category:        CategoryGit,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutCompareSnapshots,
+	},
+	{
+		Key:             "o",
+		Description:     "Show files also changed by other sessions",
+		Category:        CategoryGit,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutShowOverlaps,
+		Condition: func(m *Model) bool {
+			sess := m.sidebar.SelectedSession()
+			return sess != nil && len(m.overlaps[sess.ID]) > 0
+		},
+	},
 	{
 		Key:             "m",
 		Description:     "Merge to main / Create PR",
@@ -169,6 +315,26 @@ var ShortcutRegistry = []Shortcut{
 		RequiresSession: true,
 		Handler:         shortcutMerge,
 	},
+	{
+		Key:             "M",
+		Description:     "Check if branch is merged upstream",
+		Category:        CategoryGit,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutCheckMerged,
+	},
+	{
+		Key:             "X",
+		Description:     "Clean up merged session",
+		Category:        CategoryGit,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutCleanupMerged,
+		Condition: func(m *Model) bool {
+			sess := m.sidebar.SelectedSession()
+			return sess != nil && sess.IsMerged()
+		},
+	},
 	{
 		Key:             "c",
 		Description:     "Commit resolved conflicts",
@@ -193,6 +359,18 @@ var ShortcutRegistry = []Shortcut{
 		RequiresSession: true,
 		Handler:         shortcutReviewComments,
 	},
+	{
+		Key:             "L",
+		Description:     "Send failing CI logs to Claude",
+		Category:        CategoryGit,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutFetchCILogs,
+		Condition: func(m *Model) bool {
+			sess := m.sidebar.SelectedSession()
+			return sess != nil && sess.PRCreated && !sess.PRClosed && !sess.IsMerged()
+		},
+	},
 
 	// Configuration
 	{
@@ -229,14 +407,73 @@ var ShortcutRegistry = []Shortcut{
 		Handler:         shortcutSearchMessages,
 		Condition:       func(m *Model) bool { return m.chat.IsFocused() },
 	},
+	{
+		Key:             keys.AltM,
+		DisplayKey:      "opt-m",
+		Description:     "Bookmark the message at the top of the chat",
+		Category:        CategoryChat,
+		RequiresSession: true,
+		Handler:         shortcutToggleBookmark,
+		Condition:       func(m *Model) bool { return m.chat.IsFocused() && m.activeSession != nil },
+	},
+	{
+		Key:             keys.AltN,
+		DisplayKey:      "opt-n",
+		Description:     "Jump to the next bookmarked message",
+		Category:        CategoryChat,
+		RequiresSession: true,
+		Handler:         shortcutNextBookmark,
+		Condition:       func(m *Model) bool { return m.chat.IsFocused() && m.activeSession != nil },
+	},
+	{
+		Key:             keys.AltP,
+		DisplayKey:      "opt-p",
+		Description:     "Fill in a prompt template",
+		Category:        CategoryChat,
+		RequiresSession: true,
+		Handler:         shortcutPromptTemplates,
+		Condition:       func(m *Model) bool { return m.chat.IsFocused() && m.activeSession != nil },
+	},
+	{
+		Key:             keys.AltY,
+		DisplayKey:      "opt-y",
+		Description:     "Copy the last code block (again for the one before)",
+		Category:        CategoryChat,
+		RequiresSession: true,
+		Handler:         shortcutCopyCodeBlock,
+		Condition:       func(m *Model) bool { return m.chat.IsFocused() && m.activeSession != nil },
+	},
 	{
 		Key:             keys.CtrlT,
 		DisplayKey:      "ctrl-t",
-		Description:     "Toggle tool use expansion",
+		Description:     "Toggle tool use, hook output and repeated error expansion",
 		Category:        CategoryChat,
 		RequiresSession: true,
 		Handler:         shortcutToggleToolUseRollup,
-		Condition:       func(m *Model) bool { return m.chat.IsFocused() && m.chat.HasActiveToolUseRollup() },
+		Condition: func(m *Model) bool {
+			return m.chat.IsFocused() && (m.chat.HasActiveToolUseRollup() || m.chat.HasCollapsedErrors() || m.chat.HasCompactToolGroups() || m.chat.HasHookOutput())
+		},
+	},
+	{
+		// Checked before paste undo: a pending auto-answer is the more time-sensitive undo
+		Key:             keys.CtrlZ,
+		DisplayKey:      "ctrl-z",
+		Description:     "Undo auto-answer and answer yourself",
+		Category:        CategoryPermissions,
+		RequiresSession: true,
+		Handler:         shortcutUndoAutoAnswer,
+		Condition:       func(m *Model) bool { return m.canUndoAutoAnswer() },
+	},
+	{
+		Key:             keys.CtrlZ,
+		DisplayKey:      "ctrl-z",
+		Description:     "Undo paste cleaning",
+		Category:        CategoryChat,
+		RequiresSession: true,
+		Handler:         shortcutUndoPasteCleaning,
+		Condition: func(m *Model) bool {
+			return m.chat.IsFocused() && m.canUndoPasteCleaning()
+		},
 	},
 
 	// General
@@ -248,6 +485,46 @@ var ShortcutRegistry = []Shortcut{
 		Category:    CategoryGeneral,
 		Handler:     shortcutToggleLogViewer,
 	},
+	{
+		Key:             "F",
+		Description:     "Activity feed (all sessions)",
+		Category:        CategoryGeneral,
+		RequiresSidebar: true,
+		Handler:         shortcutToggleActivityFeed,
+	},
+	{
+		Key:             "Z",
+		Description:     "Focus mode (show only this chat)",
+		Category:        CategoryGeneral,
+		RequiresSidebar: true,
+		RequiresSession: true,
+		Handler:         shortcutToggleFocusMode,
+		Condition:       func(m *Model) bool { return !m.inline() },
+	},
+	{
+		Key:         keys.CtrlEnter,
+		DisplayKey:  "ctrl-enter",
+		Description: "Toggle focus mode",
+		Category:    CategoryGeneral,
+		Handler:     shortcutToggleFocusMode,
+		Condition: func(m *Model) bool {
+			return !m.inline() && (m.focusMode != nil || m.sidebar.SelectedSession() != nil || m.focusedSessionID() != "")
+		},
+	},
+	{
+		Key:             "P",
+		Description:     "Pause/resume all sessions",
+		Category:        CategoryGeneral,
+		RequiresSidebar: true,
+		Handler:         shortcutTogglePauseAll,
+	},
+	{
+		Key:             "G",
+		Description:     "Pause/resume background polling",
+		Category:        CategoryGeneral,
+		RequiresSidebar: true,
+		Handler:         shortcutToggleBackgroundActivity,
+	},
 	{
 		Key:             "W",
 		Description:     "What's new (changelog)",
@@ -255,12 +532,27 @@ var ShortcutRegistry = []Shortcut{
 		RequiresSidebar: true,
 		Handler:         shortcutWhatsNew,
 	},
+	{
+		Key:             keys.CtrlU,
+		DisplayKey:      "ctrl-u",
+		Description:     "Release notes of the available update",
+		Category:        CategoryGeneral,
+		RequiresSidebar: true,
+		Handler:         shortcutReleaseNotes,
+		Condition: func(m *Model) bool {
+			return m.availableUpdate != nil
+		},
+	},
 	{
 		Key:             "q",
 		Description:     "Quit application",
 		Category:        CategoryGeneral,
 		RequiresSidebar: true,
 		Handler:         shortcutQuit,
+		Condition: func(m *Model) bool {
+			behavior := m.config.GetQuitKeyBehavior()
+			return behavior == config.QuitKeySidebarOnly || behavior == config.QuitKeyConfirm
+		},
 	},
 }
 
@@ -280,6 +572,8 @@ var DisplayOnlyShortcuts = []Shortcut{
 	{DisplayKey: "↑/↓ or j/k", Description: "Navigate session list", Category: CategoryNavigation},
 	{DisplayKey: "PgUp/PgDn", Description: "Scroll chat or session list", Category: CategoryNavigation},
 	{DisplayKey: "ctrl-u/ctrl-d", Description: "Scroll half page up/down", Category: CategoryNavigation},
+	{DisplayKey: "Home/End", Description: "Jump chat to top/bottom", Category: CategoryNavigation},
+	{DisplayKey: "←/→", Description: "Scroll wide chat lines (input empty)", Category: CategoryNavigation},
 	{DisplayKey: "Enter", Description: "Send message / New session action", Category: CategoryNavigation},
 	{DisplayKey: "Esc", Description: "Cancel search / Stop streaming", Category: CategoryNavigation},
 
@@ -287,6 +581,8 @@ var DisplayOnlyShortcuts = []Shortcut{
 	{DisplayKey: "Opt+Enter", Description: "Insert newline", Category: CategoryChat},
 	{DisplayKey: "ctrl-v", Description: "Paste image", Category: CategoryChat},
 	{DisplayKey: "ctrl-o", Description: "Fork detected options", Category: CategoryChat},
+	{DisplayKey: "ctrl-; or ;;", Description: "Insert snippet", Category: CategoryChat},
+	{DisplayKey: "opt-o", Description: "Insert worktree file paths", Category: CategoryChat},
 	{DisplayKey: "Mouse drag", Description: "Select text (auto-copies)", Category: CategoryChat},
 	{DisplayKey: "Esc", Description: "Clear input / selection", Category: CategoryChat},
 
@@ -360,6 +656,10 @@ func (m *Model) ExecuteShortcut(key string) (tea.Model, tea.Cmd, bool) {
 				log.Debug("guard failed - Condition returned false, trying next")
 				continue
 			}
+			// Git operations on a session whose repo or worktree was deleted would only fail cryptically
+			if s.Category == CategoryGit && s.RequiresSession && m.sidebar.IsMissing(selectedID) {
+				return m, m.ShowFlashError("This session's repo or worktree no longer exists"), true
+			}
 			log.Debug("all guards passed, executing handler", "key", key)
 			result, cmd := s.Handler(m)
 			return result, cmd, true
@@ -482,6 +782,10 @@ func (m *Model) getApplicableHelpSections(registry []Shortcut, displayOnly []Sho
 // =============================================================================
 
 func shortcutToggleFocus(m *Model) (tea.Model, tea.Cmd) {
+	// The sidebar is hidden in focus mode, so Tab leaves it instead
+	if m.focusMode != nil {
+		return m, m.exitFocusMode("")
+	}
 	cmd := m.toggleFocus()
 	return m, cmd
 }
@@ -492,10 +796,34 @@ func shortcutSearch(m *Model) (tea.Model, tea.Cmd) {
 }
 
 func shortcutNewSession(m *Model) (tea.Model, tea.Cmd) {
-	m.modal.Show(ui.NewNewSessionState(m.config.GetRepos(), process.ContainersSupported(), claude.ContainerAuthAvailable()))
+	if m.config.GetNewSessionFromIssue() {
+		if sess := m.sidebar.SelectedSession(); sess != nil {
+			if m.hasIssueSource(sess.RepoPath) {
+				return m.showIssueSourceOrFetch(sess.RepoPath)
+			}
+		} else if repos := m.config.GetRepos(); len(repos) > 0 && m.issueRegistry != nil {
+			m.modal.Show(ui.NewSelectRepoForIssuesState(repos))
+			return m, nil
+		}
+	}
+	m.showNewSessionForm("")
 	return m, nil
 }
 
+// showNewSessionForm opens the New Session modal's blank-branch form, with
+// repoPath selected if given.
+func (m *Model) showNewSessionForm(repoPath string) {
+	state := ui.NewNewSessionState(m.config.GetRepos(), process.ContainersSupported(), claude.ContainerAuthAvailable())
+	state.SelectRepo(repoPath)
+	m.modal.Show(state)
+}
+
+// hasIssueSource returns whether issues can be imported for repoPath from any
+// configured integration.
+func (m *Model) hasIssueSource(repoPath string) bool {
+	return m.issueRegistry != nil && len(m.issueRegistry.GetConfiguredProviders(repoPath)) > 0
+}
+
 func shortcutDeleteSession(m *Model) (tea.Model, tea.Cmd) {
 	sess := m.sidebar.SelectedSession()
 	displayName := ui.SessionDisplayName(sess.Branch, sess.Name)
@@ -523,10 +851,7 @@ func shortcutImportIssues(m *Model) (tea.Model, tea.Cmd) {
 
 func shortcutRenameSession(m *Model) (tea.Model, tea.Cmd) {
 	sess := m.sidebar.SelectedSession()
-	// Get current name without the branch prefix (so user edits just the base name)
-	branchPrefix := m.config.GetDefaultBranchPrefix()
-	currentName := strings.TrimPrefix(sess.Branch, branchPrefix)
-	m.modal.Show(ui.NewRenameSessionState(sess.ID, currentName))
+	m.modal.Show(ui.NewRenameSessionState(sess.ID, ui.SessionDisplayName(sess.Branch, sess.Name)))
 	return m, nil
 }
 
@@ -570,11 +895,39 @@ func shortcutViewChanges(m *Model) (tea.Model, tea.Cmd) {
 	} else {
 		files = status.FileDiffs
 	}
+	m.enterViewChanges(files)
+	return m, nil
+}
+
+// enterViewChanges shows files in the diff viewer and focuses the chat panel
+// so arrow keys and Escape work immediately.
+func (m *Model) enterViewChanges(files []git.FileDiff) {
 	m.chat.EnterViewChangesMode(files)
-	// Switch focus to chat so arrow keys and Escape work immediately
 	m.focus = FocusChat
 	m.sidebar.SetFocused(false)
 	m.chat.SetFocused(true)
+}
+
+func shortcutTakeSnapshot(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	return m, takeSnapshot(m.gitService, sess.ID, sess.WorkTree)
+}
+
+func shortcutCompareSnapshots(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	snapshots, err := m.gitService.ListSnapshots(context.Background(), sess.RepoPath, sess.ID)
+	if err != nil {
+		logger.WithSession(sess.ID).Warn("failed to list snapshots", "error", err)
+		return m, m.ShowFlashError("Failed to list snapshots")
+	}
+	if len(snapshots) == 0 {
+		return m, m.ShowFlashInfo("No snapshots yet - press t to take one")
+	}
+	items := make([]ui.SnapshotPickerItem, len(snapshots))
+	for i, snap := range snapshots {
+		items[i] = ui.SnapshotPickerItem{Number: snap.Number, Ref: snap.Ref, CreatedAt: snap.CreatedAt}
+	}
+	m.modal.Show(ui.NewSnapshotPickerState(ui.SessionDisplayName(sess.Branch, sess.Name), items))
 	return m, nil
 }
 
@@ -587,13 +940,19 @@ func shortcutMerge(m *Model) (tea.Model, tea.Cmd) {
 	}
 	ctx := context.Background()
 	hasRemote := m.gitService.HasRemoteOrigin(ctx, sess.RepoPath)
+	defaultBranch := m.gitService.GetDefaultBranch(ctx, sess.RepoPath)
 	// Get changes summary to display in modal
 	var changesSummary string
-	if status, err := m.gitService.GetWorktreeStatus(ctx, sess.WorkTree); err == nil && status.HasChanges {
-		changesSummary = status.Summary
-		// Add file list if not too many files
-		if len(status.Files) <= 5 {
-			changesSummary += ": " + strings.Join(status.Files, ", ")
+	if status, err := m.gitService.GetWorktreeStatus(ctx, sess.WorkTree); err == nil {
+		// Refresh this session's cached files so the overlap warning below is current
+		m.changedFiles[sess.ID] = status.Files
+		m.refreshOverlaps()
+		if status.HasChanges {
+			changesSummary = status.Summary
+			// Add file list if not too many files
+			if len(status.Files) <= 5 {
+				changesSummary += ": " + strings.Join(status.Files, ", ")
+			}
 		}
 	}
 	displayName := ui.SessionDisplayName(sess.Branch, sess.Name)
@@ -604,8 +963,46 @@ func shortcutMerge(m *Model) (tea.Model, tea.Cmd) {
 			parentName = ui.SessionDisplayName(parent.Branch, parent.Name)
 		}
 	}
-	m.modal.Show(ui.NewMergeState(displayName, hasRemote, changesSummary, parentName, sess.PRCreated))
-	return m, nil
+	mergeState := ui.NewMergeState(displayName, hasRemote, changesSummary, parentName, sess.PRCreated)
+	// Rebasing onto main is offered once the branch has commits of its own
+	if divergence, err := m.gitService.GetBranchDivergence(ctx, sess.RepoPath, sess.Branch, defaultBranch); err == nil {
+		mergeState.SetCommitsAhead(divergence.Ahead)
+	}
+	mergeState.SetOverlaps(m.overlapItems(sess.ID))
+	mergeState.SetExternalWrites(sess.ExternalWrites)
+	// Merging to main checks out the default branch in the main repo, over any changes there
+	if status, err := m.gitService.GetWorktreeStatus(ctx, sess.RepoPath); err == nil && status.HasChanges {
+		mergeState.SetMainRepoChanges(status.Summary)
+	}
+	hooks := m.config.GetRepoHooks(sess.RepoPath)
+	mergeState.SetHooks(len(hooks.PostMerge), len(hooks.PostPRCreate))
+	if hasRemote && !sess.PRCreated {
+		// Prefill the PR base with the branch the session came from, completing from origin's branches
+		baseBranch := sess.BaseBranch
+		// Read progress from config since the sidebar's copy may predate the last attempt
+		if fresh := m.config.GetSession(sess.ID); fresh != nil && fresh.PRProgress != nil && fresh.PRProgress.BaseBranch != "" {
+			// Resuming a failed attempt keeps its base so completed steps stay valid
+			baseBranch = fresh.PRProgress.BaseBranch
+			if fresh.PRProgress.FailedStep != "" {
+				mergeState.SetPRResume(prStepItems(fresh.PRProgress))
+			}
+		}
+		if baseBranch == "" {
+			baseBranch = defaultBranch
+		}
+		mergeState.SetPRBaseBranch(baseBranch, nil)
+	}
+	m.modal.Show(mergeState)
+	if !hasRemote {
+		return m, nil
+	}
+	// origin/HEAD goes stale when the default branch is renamed; re-resolve it in the background
+	checkCmd := checkDefaultBranch(m.gitService, sess.ID, sess.RepoPath, sess.BaseBranch, defaultBranch)
+	if sess.PRCreated {
+		return m, checkCmd
+	}
+	// Complete the PR base from origin's branches as last fetched, until the check refreshes them
+	return m, tea.Batch(listRemoteBranches(m.gitService, sess.ID, sess.RepoPath), checkCmd)
 }
 
 func shortcutCommitConflicts(m *Model) (tea.Model, tea.Cmd) {
@@ -658,10 +1055,36 @@ func shortcutHelp(m *Model) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func shortcutTogglePin(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	pinned := !sess.Pinned
+	m.config.SetSessionPinned(sess.ID, pinned)
+	m.sidebar.SetSessions(m.getFilteredSessions())
+	m.sidebar.SelectSession(sess.ID)
+
+	flash := m.ShowFlashInfo("Unpinned session")
+	if pinned {
+		flash = m.ShowFlashInfo("Pinned session to the top")
+	}
+	return m, tea.Batch(flash, m.saveConfigOrFlash())
+}
+
 func shortcutQuit(m *Model) (tea.Model, tea.Cmd) {
+	if m.config.GetQuitKeyBehavior() == config.QuitKeyConfirm {
+		m.modal.Show(ui.NewConfirmExitState(m.streamingSessionCount()))
+		return m, nil
+	}
 	return m, tea.Quit
 }
 
+func shortcutCopyCodeBlock(m *Model) (tea.Model, tea.Cmd) {
+	cmd := m.chat.CopyCodeBlock()
+	if cmd == nil {
+		return m, m.ShowFlashInfo("No code blocks in this session")
+	}
+	return m, cmd
+}
+
 func shortcutSearchMessages(m *Model) (tea.Model, tea.Cmd) {
 	// Get messages from the current session
 	messages := m.chat.GetMessages()
@@ -682,8 +1105,34 @@ func shortcutSearchMessages(m *Model) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func shortcutSessionSwitcher(m *Model) (tea.Model, tea.Cmd) {
+	var items []ui.SessionSwitcherItem
+	for _, sess := range m.config.GetSessions() {
+		items = append(items, ui.SessionSwitcherItem{
+			ID:   sess.ID,
+			Name: ui.SessionDisplayName(sess.Branch, sess.Name),
+			Repo: filepath.Base(sess.RepoPath),
+		})
+	}
+
+	var currentID string
+	if m.activeSession != nil {
+		currentID = m.activeSession.ID
+	}
+	m.modal.Show(ui.NewSessionSwitcherState(items, currentID))
+	return m, nil
+}
+
 func shortcutToggleToolUseRollup(m *Model) (tea.Model, tea.Cmd) {
-	m.chat.ToggleToolUseRollup()
+	// The live rollup takes priority, then repeated errors; otherwise expand/collapse
+	// compacted bursts and hook output in history
+	if m.chat.HasActiveToolUseRollup() {
+		m.chat.ToggleToolUseRollup()
+	} else if m.chat.HasCollapsedErrors() {
+		m.chat.ToggleErrorsExpanded()
+	} else {
+		m.chat.ToggleToolGroupsExpanded()
+	}
 	return m, nil
 }
 
@@ -700,6 +1149,8 @@ func shortcutGlobalSettings(m *Model) (tea.Model, tea.Cmd) {
 		m.config.GetDefaultBranchPrefix(),
 		m.config.GetNotificationsEnabled(),
 		m.config.GetAutoCleanupMerged(),
+		m.config.GetCompactToolUses(),
+		m.config.GetFocusInputOnNewSession(),
 	)
 	m.modal.Show(settingsState)
 	return m, nil
@@ -707,8 +1158,8 @@ func shortcutGlobalSettings(m *Model) (tea.Model, tea.Cmd) {
 
 // showSessionSettings opens the session-specific settings modal.
 func (m *Model) showSessionSettings(sess *config.Session) (tea.Model, tea.Cmd) {
-	// Strip branch prefix for display in the name input
-	name := sess.Name
+	// Strip branch prefix for display in the branch input
+	name := sess.Branch
 	branchPrefix := m.config.GetDefaultBranchPrefix()
 	if branchPrefix != "" {
 		name = strings.TrimPrefix(name, branchPrefix)
@@ -729,6 +1180,22 @@ func (m *Model) showSessionSettings(sess *config.Session) (tea.Model, tea.Cmd) {
 		linearAPIKeySet,
 		m.config.GetLinearTeam(sess.RepoPath),
 	)
+	if current := m.config.GetSession(sess.ID); current != nil && len(current.TurnStats) > 0 {
+		tokens := make([]int, len(current.TurnStats))
+		durations := make([]int, len(current.TurnStats))
+		for i, turn := range current.TurnStats {
+			tokens[i] = turn.OutputTokens
+			durations[i] = turn.DurationMs
+		}
+		state.SetTurnActivity(tokens, durations)
+	}
+	state.SetContextFiles(m.config.GetSessionContextFiles(sess.ID))
+	mirrorPath, err := manager.MirrorFilePath(sess.ID)
+	if err != nil {
+		logger.WithSession(sess.ID).Warn("failed to get mirror file path", "error", err)
+	}
+	state.SetMirrorOutput(sess.MirrorOutput, mirrorPath)
+	state.SetAutoApprovePlans(sess.AutoApprovePlans)
 	m.modal.Show(state)
 
 	// Kick off async fetches for configured providers
@@ -749,6 +1216,24 @@ func shortcutWhatsNew(m *Model) (tea.Model, tea.Cmd) {
 	return m, m.fetchChangelogAll()
 }
 
+func shortcutToggleActivityFeed(m *Model) (tea.Model, tea.Cmd) {
+	if m.chat.IsInActivityFeedMode() {
+		m.chat.ExitActivityFeedMode()
+		return m, nil
+	}
+
+	// Only one overlay at a time
+	m.chat.ExitLogViewerMode()
+	m.chat.EnterActivityFeedMode(m.activity.Events())
+
+	// Switch focus to chat so keys work immediately
+	m.focus = FocusChat
+	m.sidebar.SetFocused(false)
+	m.chat.SetFocused(true)
+
+	return m, nil
+}
+
 func shortcutToggleLogViewer(m *Model) (tea.Model, tea.Cmd) {
 	// If already in log viewer mode, exit it
 	if m.chat.IsInLogViewerMode() {
@@ -806,7 +1291,11 @@ func shortcutPreviewInMain(m *Model) (tea.Model, tea.Cmd) {
 		return m, m.ShowFlashWarning("Another session is being previewed. End that preview first (p).")
 	}
 
-	// Check if session worktree has uncommitted changes - commit them first
+	// Check if session worktree has uncommitted changes - commit them first.
+	// Plural's own scratch files are excluded first so they don't count.
+	if err := git.EnsureArtifactExcludes(sess.WorkTree); err != nil {
+		log.Warn("failed to update git excludes", "worktree", sess.WorkTree, "error", err)
+	}
 	sessionStatus, err := m.gitService.GetWorktreeStatus(ctx, sess.WorkTree)
 	if err != nil {
 		log.Error("failed to check session worktree status", "error", err)
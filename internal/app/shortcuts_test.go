@@ -1,9 +1,12 @@
 package app
 
 import (
+	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/changelog"
+	"github.com/zhubert/plural/internal/config"
 	pexec "github.com/zhubert/plural/internal/exec"
 	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/keys"
@@ -34,7 +37,8 @@ func TestShortcutRegistry_AllShortcutsHaveHandlers(t *testing.T) {
 func TestShortcutRegistry_NoDuplicateKeys(t *testing.T) {
 	// Keys that intentionally have multiple entries with different guards
 	allowedDuplicates := map[string]bool{
-		"d": true, // delete session (RequiresSession) vs delete repo (IsRepoSelected)
+		"d":      true, // delete session (RequiresSession) vs delete repo (IsRepoSelected)
+		"ctrl+z": true, // undo auto-answer (pending auto-answer) vs undo paste cleaning (cleaned paste)
 	}
 
 	seen := make(map[string]bool)
@@ -217,6 +221,95 @@ func TestExecuteShortcut_QuitReturnsQuitCmd(t *testing.T) {
 	}
 }
 
+func TestExecuteShortcut_QuitKeyBehavior(t *testing.T) {
+	cfg := testConfig()
+	m := testModelWithSize(cfg, 120, 40)
+
+	cfg.SetQuitKeyBehavior(config.QuitKeyConfirm)
+	_, cmd, handled := m.ExecuteShortcut("q")
+	if !handled || cmd != nil {
+		t.Fatalf("Expected 'q' to ask instead of quitting, handled=%v cmd=%v", handled, cmd != nil)
+	}
+	if _, ok := m.modal.State.(*ui.ConfirmExitState); !ok {
+		t.Fatalf("Expected the exit confirmation, got %T", m.modal.State)
+	}
+	m.modal.Hide()
+
+	for _, behavior := range []string{config.QuitKeyDisabled, config.QuitKeyCtrlCOnly} {
+		cfg.SetQuitKeyBehavior(behavior)
+		if _, _, handled := m.ExecuteShortcut("q"); handled {
+			t.Errorf("Expected 'q' not to quit with %s", behavior)
+		}
+	}
+
+	// The exit command only quits while it isn't limited to Ctrl+C
+	cfg.SetQuitKeyBehavior(config.QuitKeyDisabled)
+	if _, cmd := m.handleExitCommand(); cmd == nil {
+		t.Error("Expected the exit command to quit with disabled")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected the exit command to quit with disabled")
+	}
+	cfg.SetQuitKeyBehavior(config.QuitKeyCtrlCOnly)
+	m.handleExitCommand()
+	if !m.footer.HasFlash() {
+		t.Error("Expected the exit command to point at Ctrl+C with ctrl-c-only")
+	}
+}
+
+func TestExecuteShortcut_TogglePin(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m.sidebar.SelectSession("session-3")
+
+	if _, _, handled := m.ExecuteShortcut("b"); !handled {
+		t.Fatal("Expected 'b' to be handled")
+	}
+	if !cfg.GetSession("session-3").Pinned {
+		t.Fatal("Expected the session to be pinned")
+	}
+	// The pinned session moves to the top and stays selected
+	if sess := m.sidebar.SelectedSession(); sess == nil || sess.ID != "session-3" {
+		t.Errorf("Expected the pinned session to stay selected, got %v", sess)
+	}
+
+	m.ExecuteShortcut("b")
+	if cfg.GetSession("session-3").Pinned {
+		t.Error("Expected the session to be unpinned")
+	}
+}
+
+func TestExecuteShortcut_ReleaseNotes(t *testing.T) {
+	cfg := testConfig()
+	m := testModelWithSize(cfg, 120, 40)
+
+	if _, _, handled := m.ExecuteShortcut(keys.CtrlU); handled {
+		t.Fatal("Expected ctrl+u to be unavailable without an update")
+	}
+
+	result, _ := m.Update(UpdateAvailableMsg{Release: changelog.Release{Version: "0.9.0", Notes: "- Faster startup"}})
+	m = result.(*Model)
+	if !strings.Contains(m.footer.View(), "v0.9.0 available") {
+		t.Error("Expected the footer to offer the update")
+	}
+
+	if _, _, handled := m.ExecuteShortcut(keys.CtrlU); !handled {
+		t.Fatal("Expected ctrl+u to be handled")
+	}
+	state, ok := m.modal.State.(*ui.ReleaseNotesState)
+	if !ok {
+		t.Fatalf("Expected ReleaseNotesState, got %T", m.modal.State)
+	}
+	if state.Version != "0.9.0" || !strings.Contains(state.Render(), "Faster startup") {
+		t.Errorf("Expected the release notes of 0.9.0, got %q", state.Render())
+	}
+
+	m = sendKey(m, "esc")
+	if m.modal.IsVisible() {
+		t.Error("Expected esc to close the release notes")
+	}
+}
+
 // =============================================================================
 // Help Sections Generation Tests
 // =============================================================================
@@ -1197,3 +1290,66 @@ func TestDetectTerminalApp(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteShortcut_NewSessionFromIssue(t *testing.T) {
+	cfg := testConfig()
+	m := testModelWithSize(cfg, 120, 40)
+
+	m.ExecuteShortcut("n")
+	if _, ok := m.modal.State.(*ui.NewSessionState); !ok {
+		t.Fatalf("Expected the blank-branch form by default, got %T", m.modal.State)
+	}
+
+	// "i" switches from the form to the selected repo's issues
+	m = sendKey(m, "down")
+	m = sendKey(m, "i")
+	issuesState, ok := m.modal.State.(*ui.ImportIssuesState)
+	if !ok {
+		t.Fatalf("Expected ImportIssuesState after i, got %T", m.modal.State)
+	}
+	if issuesState.RepoPath != "/test/repo2" {
+		t.Errorf("Expected issues of the selected repo, got %q", issuesState.RepoPath)
+	}
+	m.modal.Hide()
+
+	cfg.SetNewSessionFromIssue(true)
+	m.ExecuteShortcut("n")
+	if _, ok := m.modal.State.(*ui.SelectRepoForIssuesState); !ok {
+		t.Fatalf("Expected the issue picker's repo selection, got %T", m.modal.State)
+	}
+
+	// "b" switches to the blank-branch form, keeping the repo
+	m = sendKey(m, "down")
+	m = sendKey(m, "b")
+	formState, ok := m.modal.State.(*ui.NewSessionState)
+	if !ok {
+		t.Fatalf("Expected NewSessionState after b, got %T", m.modal.State)
+	}
+	if formState.GetSelectedRepo() != "/test/repo2" {
+		t.Errorf("Expected the repo chosen in the picker to stay selected, got %q", formState.GetSelectedRepo())
+	}
+}
+
+func TestExecuteShortcut_NewSessionFromIssue_SelectedSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetNewSessionFromIssue(true)
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m.ExecuteShortcut("n")
+	state, ok := m.modal.State.(*ui.ImportIssuesState)
+	if !ok {
+		t.Fatalf("Expected the selected session's repo issues, got %T", m.modal.State)
+	}
+	if state.RepoPath != cfg.Sessions[0].RepoPath {
+		t.Errorf("Expected issues of %q, got %q", cfg.Sessions[0].RepoPath, state.RepoPath)
+	}
+
+	// Without any integration, new sessions fall back to the blank-branch form
+	m.modal.Hide()
+	m.issueRegistry = nil
+	m.ExecuteShortcut("n")
+	if _, ok := m.modal.State.(*ui.NewSessionState); !ok {
+		t.Errorf("Expected the blank-branch form without integrations, got %T", m.modal.State)
+	}
+}
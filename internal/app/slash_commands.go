@@ -14,9 +14,12 @@ import (
 type SlashCommandAction int
 
 const (
-	ActionNone        SlashCommandAction = iota
-	ActionOpenMCP                        // Open MCP servers modal
-	ActionOpenPlugins                    // Open plugins modal
+	ActionNone           SlashCommandAction = iota
+	ActionOpenMCP                           // Open MCP servers modal
+	ActionOpenPlugins                       // Open plugins modal
+	ActionOpenSnippets                      // Open snippets modal
+	ActionRunCommand                        // Insert a command's output into the input
+	ActionOpenFilePicker                    // Open the file picker
 )
 
 // SlashCommandResult represents the result of handling a slash command.
@@ -24,6 +27,7 @@ type SlashCommandResult struct {
 	Handled  bool               // Whether the command was recognized and handled
 	Response string             // The response to display to the user
 	Action   SlashCommandAction // Optional UI action to trigger
+	Command  string             // Command to run for ActionRunCommand ("" for the scrollback file)
 }
 
 // slashCommandDef defines a slash command with its handler and help text.
@@ -40,6 +44,10 @@ func getSlashCommands() []slashCommandDef {
 			name:        "cost",
 			description: "Show token usage and cost for the current session",
 		},
+		{
+			name:        "file",
+			description: "Insert worktree file paths into the input (also opt+o)",
+		},
 		{
 			name:        "help",
 			description: "Show available slash commands",
@@ -52,6 +60,14 @@ func getSlashCommands() []slashCommandDef {
 			name:        "plugins",
 			description: "Manage plugin directories",
 		},
+		{
+			name:        "run",
+			description: "Run a command in the worktree and insert its output into the input",
+		},
+		{
+			name:        "snippets",
+			description: "Manage prompt snippets (insert with ctrl+; or ;;name)",
+		},
 	}
 }
 
@@ -76,12 +92,18 @@ func (m *Model) handleSlashCommand(input string) SlashCommandResult {
 	switch cmdName {
 	case "cost":
 		return handleCostCommand(m, args)
+	case "file", "files":
+		return handleFileCommand(m, args)
 	case "help":
 		return handleHelpCommand(m, args)
 	case "mcp":
 		return handleMCPCommand(m, args)
 	case "plugin", "plugins":
 		return handlePluginsCommand(m, args)
+	case "run":
+		return handleRunCommand(m, args)
+	case "snippet", "snippets":
+		return handleSnippetsCommand(m, args)
 	default:
 		// Unknown slash command - let Claude handle it (might be a custom command)
 		logger.Get().Debug("unknown slash command, passing to Claude", "command", cmdName)
@@ -97,6 +119,14 @@ func handleMCPCommand(_ *Model, _ string) SlashCommandResult {
 	}
 }
 
+// handleFileCommand opens the file picker.
+func handleFileCommand(_ *Model, _ string) SlashCommandResult {
+	return SlashCommandResult{
+		Handled: true,
+		Action:  ActionOpenFilePicker,
+	}
+}
+
 // handlePluginsCommand opens the plugins configuration modal.
 func handlePluginsCommand(_ *Model, _ string) SlashCommandResult {
 	return SlashCommandResult{
@@ -105,6 +135,32 @@ func handlePluginsCommand(_ *Model, _ string) SlashCommandResult {
 	}
 }
 
+// handleRunCommand runs a command in the session's worktree and inserts its
+// output into the input. With no command, the end of the command_output_file
+// scrollback file is inserted instead.
+func handleRunCommand(m *Model, args string) SlashCommandResult {
+	command := strings.TrimSpace(args)
+	if command == "" && m.config.GetCommandOutputFile() == "" {
+		return SlashCommandResult{
+			Handled:  true,
+			Response: "Usage: /run <command>\nRuns the command in the session's worktree and inserts its output into the input. Set command_output_file in the config file to insert the end of a scrollback file with a bare /run.",
+		}
+	}
+	return SlashCommandResult{
+		Handled: true,
+		Action:  ActionRunCommand,
+		Command: command,
+	}
+}
+
+// handleSnippetsCommand opens the snippets management modal.
+func handleSnippetsCommand(_ *Model, _ string) SlashCommandResult {
+	return SlashCommandResult{
+		Handled: true,
+		Action:  ActionOpenSnippets,
+	}
+}
+
 // handleHelpCommand shows available slash commands.
 func handleHelpCommand(_ *Model, _ string) SlashCommandResult {
 	var sb strings.Builder
@@ -322,6 +322,18 @@ func TestHandleSlashCommand_Dispatcher(t *testing.T) {
 			wantHandled: true,
 			wantAction:  ActionOpenPlugins,
 		},
+		{
+			name:        "run with a command",
+			input:       "/run go test ./...",
+			wantHandled: true,
+			wantAction:  ActionRunCommand,
+		},
+		{
+			name:         "bare run without a scrollback file shows usage",
+			input:        "/run",
+			wantHandled:  true,
+			wantResponse: "Usage: /run <command>",
+		},
 		{
 			name:        "plugin alias opens modal",
 			input:       "/plugin",
@@ -0,0 +1,181 @@
+package app
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+const (
+	clockCheckInterval = 15 * time.Second
+
+	// sleepThreshold is how far the wall clock must run ahead of the monotonic
+	// clock between two checks before it is treated as a system sleep.
+	sleepThreshold = time.Minute
+
+	sleepInterruptedNote = "[Response interrupted by system sleep]"
+)
+
+// SleepResumeMsg reports the health of every runner after the system woke from sleep
+type SleepResumeMsg struct {
+	Slept  time.Duration
+	Health map[string]claude.RunnerHealth // By session ID
+}
+
+// sleptFor returns how long the system slept given the wall clock and monotonic
+// clock time elapsed over the same interval, or 0 if it did not. The monotonic
+// clock stops during sleep on most platforms while the wall clock keeps going,
+// so the difference between the two is the time spent asleep.
+func sleptFor(wall, monotonic time.Duration) time.Duration {
+	if slept := wall - monotonic; slept >= sleepThreshold {
+		return slept
+	}
+	return 0
+}
+
+// sleepAction is what to do with a session after the system wakes from sleep.
+type sleepAction int
+
+const (
+	sleepKeep          sleepAction = iota // Nothing was lost; only timers need correcting
+	sleepAwaitRecovery                    // The CLI died mid-response; process-exit recovery restarts it
+	sleepInterrupt                        // The response was lost; stop waiting and say so
+	sleepReset                            // The MCP socket is dead; stop the runner so the next message starts afresh
+)
+
+// reconcileAfterSleep decides what to do with a session from whether its UI is
+// waiting for a response and the health of its runner.
+func reconcileAfterSleep(waiting bool, health claude.RunnerHealth) sleepAction {
+	switch {
+	case !health.SocketHealthy:
+		return sleepReset
+	case !waiting:
+		return sleepKeep
+	case !health.Streaming:
+		return sleepInterrupt
+	case !health.ProcessRunning:
+		return sleepAwaitRecovery
+	default:
+		return sleepKeep
+	}
+}
+
+// checkRunnerHealth returns a command that checks every runner in the background,
+// since probing MCP sockets can block.
+func checkRunnerHealth(runners map[string]claude.RunnerInterface, slept time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		health := make(map[string]claude.RunnerHealth, len(runners))
+		for sessionID, runner := range runners {
+			health[sessionID] = runner.Health()
+		}
+		return SleepResumeMsg{Slept: slept, Health: health}
+	}
+}
+
+// checkClock checks for a system sleep since the last check. Returns a command
+// checking the health of every runner if the system slept, or nil.
+func (m *Model) checkClock() tea.Cmd {
+	now := time.Now()
+	prev := m.lastClockCheck
+	m.lastClockCheck = now
+	if prev.IsZero() {
+		return nil
+	}
+	// Round(0) strips the monotonic reading, so Sub measures wall clock time
+	slept := sleptFor(now.Round(0).Sub(prev.Round(0)), now.Sub(prev))
+	if slept == 0 {
+		return nil
+	}
+
+	logger.Get().Info("system resumed from sleep", "slept", slept.Round(time.Second))
+	for sessionID := range m.sessionMgr.GetRunners() {
+		m.sessionState().ShiftTimers(sessionID, slept)
+	}
+	m.chat.ShiftTimers(slept)
+	return checkRunnerHealth(m.sessionMgr.GetRunners(), slept)
+}
+
+// handleSleepResumeMsg reconciles each session with the health of its runner
+// after the system woke from sleep.
+func (m *Model) handleSleepResumeMsg(msg SleepResumeMsg) (tea.Model, tea.Cmd) {
+	var saveFailed bool
+	for sessionID, health := range msg.Health {
+		log := logger.WithSession(sessionID)
+		waiting := false
+		if state := m.sessionState().GetIfExists(sessionID); state != nil {
+			waiting = state.GetIsWaiting()
+		}
+
+		switch reconcileAfterSleep(waiting, health) {
+		case sleepKeep:
+			continue
+		case sleepAwaitRecovery:
+			log.Info("claude process died during sleep, awaiting restart")
+		case sleepInterrupt:
+			log.Warn("response lost during sleep")
+			if !m.interruptAfterSleep(sessionID) {
+				saveFailed = true
+			}
+		case sleepReset:
+			log.Warn("MCP socket unhealthy after sleep, resetting runner")
+			if waiting && !m.interruptAfterSleep(sessionID) {
+				saveFailed = true
+			}
+			m.sessionMgr.ResetRunner(sessionID)
+			if m.activeSession != nil && m.activeSession.ID == sessionID {
+				m.claudeRunner = m.sessionMgr.GetOrCreateRunner(m.activeSession)
+				m.addClaudeCodeMCPApprovals(m.claudeRunner, m.activeSession)
+			}
+		}
+	}
+
+	if !m.hasAnyStreamingSessions() {
+		m.setState(StateIdle)
+	}
+	if saveFailed {
+		return m, m.ShowFlashError("Failed to save session messages")
+	}
+	return m, nil
+}
+
+// interruptAfterSleep stops waiting on a session whose response was lost while the
+// system slept, keeping any partial response with a note saying what happened.
+// Returns false if the messages could not be saved.
+func (m *Model) interruptAfterSleep(sessionID string) bool {
+	runner := m.sessionMgr.GetRunner(sessionID)
+	state := m.sessionState().GetIfExists(sessionID)
+	if runner == nil || state == nil {
+		return true
+	}
+	if cancel := state.GetStreamCancel(); cancel != nil {
+		cancel()
+	}
+	m.sessionState().StopWaiting(sessionID)
+	m.sidebar.SetStreaming(sessionID, false)
+
+	isActive := m.activeSession != nil && m.activeSession.ID == sessionID
+	content := state.GetStreamingContent()
+	if isActive {
+		content = m.chat.GetStreaming()
+	}
+	if content != "" {
+		content += "\n"
+	}
+	runner.AddAssistantMessage(content + sleepInterruptedNote)
+
+	if isActive {
+		m.chat.SetWaiting(false)
+		m.chat.AppendStreaming("\n" + sleepInterruptedNote + "\n")
+		m.chat.FinishStreaming()
+	} else {
+		state.SetStreamingContent("")
+	}
+
+	if err := m.sessionMgr.SaveRunnerMessages(sessionID, runner); err != nil {
+		logger.WithSession(sessionID).Error("failed to save messages after sleep", "error", err)
+		return false
+	}
+	return true
+}
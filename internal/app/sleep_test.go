@@ -0,0 +1,97 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhubert/plural/internal/claude"
+)
+
+func TestSleptFor(t *testing.T) {
+	tests := []struct {
+		name            string
+		wall, monotonic time.Duration
+		want            time.Duration
+	}{
+		{"no jump", 15 * time.Second, 15 * time.Second, 0},
+		{"clock drift", 45 * time.Second, 15 * time.Second, 0},
+		{"wall clock set back", 15 * time.Second, time.Hour, 0},
+		{"slept", 2*time.Hour + 15*time.Second, 15 * time.Second, 2 * time.Hour},
+		{"slept just long enough", time.Minute + 15*time.Second, 15 * time.Second, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sleptFor(tt.wall, tt.monotonic); got != tt.want {
+				t.Errorf("sleptFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileAfterSleep(t *testing.T) {
+	healthy := claude.RunnerHealth{ProcessRunning: true, Streaming: true, SocketHealthy: true}
+
+	tests := []struct {
+		name    string
+		waiting bool
+		health  claude.RunnerHealth
+		want    sleepAction
+	}{
+		{"idle session", false, claude.RunnerHealth{SocketHealthy: true}, sleepKeep},
+		{"response still streaming", true, healthy, sleepKeep},
+		{"response lost", true, claude.RunnerHealth{ProcessRunning: true, SocketHealthy: true}, sleepInterrupt},
+		{"process died mid-response", true, claude.RunnerHealth{Streaming: true, SocketHealthy: true}, sleepAwaitRecovery},
+		{"dead socket while idle", false, claude.RunnerHealth{}, sleepReset},
+		{"dead socket while waiting", true, claude.RunnerHealth{ProcessRunning: true, Streaming: true}, sleepReset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reconcileAfterSleep(tt.waiting, tt.health); got != tt.want {
+				t.Errorf("reconcileAfterSleep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleSleepResumeMsg(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, factory := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	sessionID := m.activeSession.ID
+	m.sessionState().StartWaiting(sessionID, func() {})
+	m.chat.SetWaiting(true)
+	m.chat.AppendStreaming("Partial answer")
+
+	// The response was lost while asleep
+	mock := factory.GetMock(sessionID)
+	mock.SetHealth(claude.RunnerHealth{ProcessRunning: true, SocketHealthy: true})
+	m.Update(SleepResumeMsg{Slept: time.Hour, Health: map[string]claude.RunnerHealth{sessionID: mock.Health()}})
+
+	if state := m.sessionState().GetIfExists(sessionID); state.GetIsWaiting() {
+		t.Error("expected the session to stop waiting")
+	}
+	if m.chat.IsWaiting() {
+		t.Error("expected the chat to stop waiting")
+	}
+	msgs := mock.GetMessages()
+	if len(msgs) == 0 || msgs[len(msgs)-1].Content != "Partial answer\n"+sleepInterruptedNote {
+		t.Errorf("expected the partial answer kept with a note, got %+v", msgs)
+	}
+	if !strings.Contains(m.chat.View(), sleepInterruptedNote) {
+		t.Error("expected the note in the chat")
+	}
+
+	// A dead socket gets the session a fresh runner
+	mock.SetHealth(claude.RunnerHealth{})
+	m.Update(SleepResumeMsg{Slept: time.Hour, Health: map[string]claude.RunnerHealth{sessionID: mock.Health()}})
+
+	if m.claudeRunner == claude.RunnerInterface(mock) {
+		t.Error("expected the runner to be replaced")
+	}
+	if m.sessionMgr.GetRunner(sessionID) != m.claudeRunner {
+		t.Error("expected the active session to use the new runner")
+	}
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// SnapshotTakenMsg is sent when a snapshot taken in the background is recorded
+type SnapshotTakenMsg struct {
+	SessionID string
+	Snapshot  *git.Snapshot
+	Err       error
+}
+
+// SnapshotDiffMsg is sent when a comparison of two snapshot points is ready
+type SnapshotDiffMsg struct {
+	SessionID string
+	From, To  ui.SnapshotPickerItem
+	Diff      *git.SnapshotDiff
+	Err       error
+}
+
+// takeSnapshot returns a command that records the worktree as the session's next snapshot.
+func takeSnapshot(gitService *git.GitService, sessionID, worktree string) tea.Cmd {
+	return func() tea.Msg {
+		snap, err := gitService.CreateSnapshot(context.Background(), worktree, sessionID)
+		return SnapshotTakenMsg{SessionID: sessionID, Snapshot: snap, Err: err}
+	}
+}
+
+// diffSnapshots returns a command that compares two snapshot points of a session.
+func diffSnapshots(gitService *git.GitService, sessionID, worktree string, from, to ui.SnapshotPickerItem) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := gitService.DiffSnapshots(context.Background(), worktree, from.Ref, to.Ref)
+		return SnapshotDiffMsg{SessionID: sessionID, From: from, To: to, Diff: diff, Err: err}
+	}
+}
+
+// handleSnapshotTakenMsg reports a snapshot taken in the background.
+func (m *Model) handleSnapshotTakenMsg(msg SnapshotTakenMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		logger.WithSession(msg.SessionID).Warn("failed to take snapshot", "error", msg.Err)
+		return m, m.ShowFlashError("Failed to take snapshot")
+	}
+	return m, m.ShowFlashSuccess(fmt.Sprintf("Took snapshot %d", msg.Snapshot.Number))
+}
+
+// handleSnapshotDiffMsg shows a snapshot comparison in the diff viewer, selecting
+// its session if the user hasn't since deleted it.
+func (m *Model) handleSnapshotDiffMsg(msg SnapshotDiffMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		logger.WithSession(msg.SessionID).Warn("failed to diff snapshots", "from", msg.From.Ref, "to", msg.To.Ref, "error", msg.Err)
+		return m, m.ShowFlashError("Failed to compare snapshots")
+	}
+	sess := m.config.GetSession(msg.SessionID)
+	if sess == nil {
+		return m, nil
+	}
+	if m.activeSession == nil || m.activeSession.ID != sess.ID {
+		m.selectSession(sess)
+	}
+
+	files := msg.Diff.Files
+	if len(files) == 0 {
+		files = []git.FileDiff{{
+			Filename: "No changes",
+			Status:   " ",
+			Diff:     "Nothing changed between these points.",
+		}}
+	}
+	m.enterViewChanges(files)
+	m.chat.SetViewChangesHeader(snapshotDiffHeader(msg.From, msg.To, msg.Diff.Stats))
+	return m, nil
+}
+
+// snapshotDiffHeader summarizes a snapshot comparison, e.g.
+// "Snapshot 1 → Now: 3 files changed, +10 -2".
+func snapshotDiffHeader(from, to ui.SnapshotPickerItem, stats git.DiffStats) string {
+	files := "1 file changed"
+	if stats.FilesChanged != 1 {
+		files = fmt.Sprintf("%d files changed", stats.FilesChanged)
+	}
+	toLabel := "Now"
+	if to.Ref != "" {
+		toLabel = to.Label()
+	}
+	return fmt.Sprintf("%s → %s: %s, +%d -%d", from.Label(), toLabel, files, stats.Additions, stats.Deletions)
+}
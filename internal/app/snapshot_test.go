@@ -0,0 +1,112 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// initSnapshotRepo creates a git repo with one commit for snapshot tests.
+func initSnapshotRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+		{"add", "."},
+		{"commit", "-m", "Initial commit"},
+	} {
+		if output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, output)
+		}
+	}
+	return dir
+}
+
+func TestSnapshots_TakeCompareAndDelete(t *testing.T) {
+	repo := initSnapshotRepo(t)
+	cfg := testConfigWithSessions()
+	cfg.Sessions[0].RepoPath = repo
+	cfg.Sessions[0].WorkTree = repo
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	sessionID := cfg.Sessions[0].ID
+
+	m = sendKey(m, "T")
+	if m.modal.IsVisible() {
+		t.Fatal("expected no picker before any snapshot is taken")
+	}
+
+	// Snapshots are taken in the background
+	_, cmd := m.Update(keyPress("t"))
+	if cmd == nil {
+		t.Fatal("expected a command taking the snapshot")
+	}
+	if msg, ok := cmd().(SnapshotTakenMsg); !ok || msg.Err != nil || msg.Snapshot.Number != 1 {
+		t.Fatalf("expected snapshot 1 taken, got %+v", msg)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m = sendKey(m, "T")
+	state, ok := m.modal.State.(*ui.SnapshotPickerState)
+	if !ok {
+		t.Fatalf("expected SnapshotPickerState, got %T", m.modal.State)
+	}
+	if len(state.Items) != 2 || state.Items[0].Number != 1 || state.Items[1].Ref != "" {
+		t.Fatalf("expected snapshot 1 and Now, got %+v", state.Items)
+	}
+
+	// Mark snapshot 1, then compare it with now
+	m = sendKey(m, "enter")
+	m = sendKey(m, "down")
+	_, cmd = m.Update(keyPress("enter"))
+	if m.modal.IsVisible() {
+		t.Fatal("expected the picker to close after choosing two points")
+	}
+	if cmd == nil {
+		t.Fatal("expected a command comparing the snapshots")
+	}
+	m.Update(cmd())
+	if !m.chat.IsInViewChangesMode() {
+		t.Fatal("expected the diff viewer to open")
+	}
+	view := m.chat.View()
+	if !strings.Contains(view, "Snapshot 1 → Now: 1 file changed, +2 -0") || !strings.Contains(view, "main.go") {
+		t.Errorf("expected a summary header and the changed file, got:\n%s", view)
+	}
+
+	// Deleting the session removes its snapshot refs
+	m.chat.ExitViewChangesMode()
+	m.focus = FocusSidebar
+	m.sidebar.SetFocused(true)
+	m.chat.SetFocused(false)
+	m = sendKey(m, "d")
+	if _, ok := m.modal.State.(*ui.ConfirmDeleteState); !ok {
+		t.Fatalf("expected ConfirmDeleteState, got %T", m.modal.State)
+	}
+	m = sendKey(m, "enter")
+	if snapshots, err := m.gitService.ListSnapshots(t.Context(), repo, sessionID); err != nil || len(snapshots) != 0 {
+		t.Errorf("expected snapshots deleted, got %+v (err %v)", snapshots, err)
+	}
+}
+
+func TestSnapshotDiffHeader(t *testing.T) {
+	from := ui.SnapshotPickerItem{Number: 2, Ref: "refs/plural/snapshot/s/2"}
+	to := ui.SnapshotPickerItem{Number: 5, Ref: "refs/plural/snapshot/s/5"}
+
+	got := snapshotDiffHeader(from, to, git.DiffStats{FilesChanged: 3, Additions: 10, Deletions: 2})
+	if want := "Snapshot 2 → Snapshot 5: 3 files changed, +10 -2"; got != want {
+		t.Errorf("snapshotDiffHeader() = %q, want %q", got, want)
+	}
+}
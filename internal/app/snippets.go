@@ -0,0 +1,156 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// snippetTrigger typed in the chat input opens the snippet picker
+const snippetTrigger = ";;"
+
+// snippetItems converts the saved snippets for the snippet modals.
+func snippetItems(snippets []config.Snippet) []ui.SnippetItem {
+	items := make([]ui.SnippetItem, len(snippets))
+	for i, s := range snippets {
+		items[i] = ui.SnippetItem{Name: s.Name, Text: s.Text}
+	}
+	return items
+}
+
+// showSnippetPicker opens the snippet picker for the chat input, remembering the
+// conversation text selected now for the {selection} placeholder.
+func (m *Model) showSnippetPicker() (tea.Model, tea.Cmd) {
+	m.modal.Show(ui.NewSnippetPickerState(snippetItems(m.config.GetSnippets()), m.chat.GetSelectedText()))
+	return m, nil
+}
+
+// showSnippets opens the snippet management overlay.
+func (m *Model) showSnippets() (tea.Model, tea.Cmd) {
+	m.modal.Show(ui.NewSnippetsState(snippetItems(m.config.GetSnippets())))
+	return m, nil
+}
+
+// insertSnippet expands a snippet and inserts it at the chat input cursor.
+func (m *Model) insertSnippet(item ui.SnippetItem, selection, file string) (tea.Model, tea.Cmd) {
+	m.modal.Hide()
+	snippet := config.Snippet{Name: item.Name, Text: item.Text}
+	m.chat.InsertInput(snippet.Expand(selection, file))
+	logger.Get().Debug("inserted snippet", "name", item.Name)
+	return m, nil
+}
+
+// handleSnippetPickerModal handles key events in the snippet picker.
+func (m *Model) handleSnippetPickerModal(key string, msg tea.KeyPressMsg, state *ui.SnippetPickerState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.CtrlE:
+		return m.showSnippets()
+	case keys.Enter:
+		item := state.GetSelected()
+		if item == nil {
+			return m, nil
+		}
+		if (config.Snippet{Text: item.Text}).NeedsFile() {
+			m.modal.Show(ui.NewSnippetFileState(*item, state.Selection))
+			return m, nil
+		}
+		return m.insertSnippet(*item, state.Selection, "")
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// handleSnippetFileModal handles key events in the {file} prompt of a snippet.
+func (m *Model) handleSnippetFileModal(key string, msg tea.KeyPressMsg, state *ui.SnippetFileState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		if state.GetPath() == "" {
+			return m, nil
+		}
+		return m.insertSnippet(state.Snippet, state.Selection, state.GetPath())
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// handleSnippetsModal handles key events in the snippet management overlay.
+func (m *Model) handleSnippetsModal(key string, msg tea.KeyPressMsg, state *ui.SnippetsState) (tea.Model, tea.Cmd) {
+	if state.Editing {
+		switch key {
+		case keys.Escape:
+			state.CancelEdit()
+			return m, nil
+		case keys.CtrlS:
+			return m, m.saveEditedSnippet(state)
+		}
+	} else {
+		switch key {
+		case keys.Escape:
+			m.modal.Hide()
+			return m, nil
+		case keys.CtrlD:
+			item := state.SelectedSnippet()
+			if item == nil {
+				return m, nil
+			}
+			snippets := slices.DeleteFunc(m.config.GetSnippets(), func(s config.Snippet) bool { return s.Name == item.Name })
+			logger.Get().Info("deleted snippet", "name", item.Name)
+			return m, m.setSnippets(state, snippets, state.SelectedIndex)
+		}
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// saveEditedSnippet validates the snippet in the editor and saves it, replacing
+// the snippet being edited or adding a new one.
+func (m *Model) saveEditedSnippet(state *ui.SnippetsState) tea.Cmd {
+	edited := state.GetEdited()
+	if err := config.ValidateSnippetName(edited.Name); err != nil {
+		state.Error = err.Error()
+		return nil
+	}
+	if edited.Text == "" {
+		state.Error = "Snippet text is required"
+		return nil
+	}
+
+	snippets := m.config.GetSnippets()
+	for i, s := range snippets {
+		if s.Name == edited.Name && i != state.EditingIndex {
+			state.Error = fmt.Sprintf("A snippet named %q already exists", edited.Name)
+			return nil
+		}
+	}
+	snippet := config.Snippet{Name: edited.Name, Text: edited.Text}
+	index := state.EditingIndex
+	if index >= 0 && index < len(snippets) {
+		snippets[index] = snippet
+	} else {
+		snippets = append(snippets, snippet)
+		index = len(snippets) - 1
+	}
+	logger.Get().Info("saved snippet", "name", snippet.Name)
+	return m.setSnippets(state, snippets, index)
+}
+
+// setSnippets saves the snippets and refreshes the overlay.
+func (m *Model) setSnippets(state *ui.SnippetsState, snippets []config.Snippet, selected int) tea.Cmd {
+	m.config.SetSnippets(snippets)
+	state.SetItems(snippetItems(snippets), selected)
+	return m.saveConfigOrFlash()
+}
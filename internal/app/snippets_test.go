@@ -0,0 +1,108 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestSnippets_InsertMidDraft(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetSnippets([]config.Snippet{
+		{Name: "tradeoffs", Text: "explain the tradeoffs"},
+		{Name: "file", Text: "look at {file}"},
+	})
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	// Typing ";;" opens the picker and leaves the draft as it was
+	m = typeText(m, "First, ;;")
+	picker, ok := m.modal.State.(*ui.SnippetPickerState)
+	if !ok {
+		t.Fatalf("expected SnippetPickerState, got %T", m.modal.State)
+	}
+	if got := m.chat.GetInput(); got != "First," {
+		t.Errorf("expected the trigger removed from the draft, got %q", got)
+	}
+
+	m = typeText(m, "trade")
+	if len(picker.Matches) != 1 {
+		t.Fatalf("expected one match for \"trade\", got %+v", picker.Matches)
+	}
+	m = sendKey(m, "enter")
+	if m.modal.IsVisible() {
+		t.Fatal("expected the picker to close")
+	}
+	m = typeText(m, ". Then")
+	if got := m.chat.GetInput(); got != "First, explain the tradeoffs. Then" {
+		t.Errorf("expected the snippet inserted at the cursor, got %q", got)
+	}
+
+	// ctrl+; opens the picker too; {file} prompts for a path
+	m = sendKey(m, " ")
+	m = sendKey(m, "ctrl+;")
+	m = typeText(m, "file")
+	m = sendKey(m, "enter")
+	if _, ok := m.modal.State.(*ui.SnippetFileState); !ok {
+		t.Fatalf("expected SnippetFileState, got %T", m.modal.State)
+	}
+	m = typeText(m, "main.go")
+	m = sendKey(m, "enter")
+	if got := m.chat.GetInput(); got != "First, explain the tradeoffs. Then look at main.go" {
+		t.Errorf("expected the expanded snippet appended, got %q", got)
+	}
+}
+
+func TestSnippets_Manage(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+
+	m = typeText(m, "/snippets")
+	m = sendKey(m, "enter")
+	state, ok := m.modal.State.(*ui.SnippetsState)
+	if !ok {
+		t.Fatalf("expected SnippetsState, got %T", m.modal.State)
+	}
+
+	// Add a snippet
+	m = sendKey(m, "n")
+	m = typeText(m, "bad name")
+	m = sendKey(m, "ctrl+s")
+	if state.Error == "" {
+		t.Error("expected an error for a name with a space")
+	}
+	for range len(" name") {
+		m = sendKey(m, "backspace")
+	}
+	m = sendKey(m, "tab")
+	m = typeText(m, "Write tests")
+	m = sendKey(m, "ctrl+s")
+	if state.Editing || state.Error != "" {
+		t.Fatalf("expected the snippet saved, got editing=%v error=%q", state.Editing, state.Error)
+	}
+	if got := cfg.GetSnippets(); len(got) != 1 || got[0] != (config.Snippet{Name: "bad", Text: "Write tests"}) {
+		t.Fatalf("expected the snippet in the config, got %+v", got)
+	}
+
+	// Rename it
+	m = sendKey(m, "enter")
+	m = typeText(m, "-tests")
+	m = sendKey(m, "ctrl+s")
+	if got := cfg.GetSnippets(); len(got) != 1 || got[0].Name != "bad-tests" {
+		t.Fatalf("expected the snippet renamed in place, got %+v", got)
+	}
+
+	// Delete it
+	m = sendKey(m, "ctrl+d")
+	if got := cfg.GetSnippets(); len(got) != 0 {
+		t.Errorf("expected the snippet deleted, got %+v", got)
+	}
+	m = sendKey(m, "esc")
+	if m.modal.IsVisible() {
+		t.Error("expected Esc to close the overlay")
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func shortcutEditTags(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	displayName := ui.SessionDisplayName(sess.Branch, sess.Name)
+	m.modal.Show(ui.NewEditTagsState(sess.ID, displayName, sess.Tags, m.config.GetAllTags()))
+	return m, nil
+}
+
+func shortcutFilterByTag(m *Model) (tea.Model, tea.Cmd) {
+	tags := m.config.GetAllTags()
+	if len(tags) == 0 && m.sidebar.TagFilter() == "" {
+		return m, m.ShowFlashInfo("No sessions are tagged; press # to tag one")
+	}
+	m.modal.Show(ui.NewTagFilterState(tags, m.sidebar.TagFilter()))
+	return m, nil
+}
+
+func (m *Model) handleEditTagsModal(key string, msg tea.KeyPressMsg, state *ui.EditTagsState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		tags := config.NormalizeTags(state.GetTags())
+		if !m.config.SetSessionTags(state.SessionID, tags) {
+			m.modal.SetError("Session not found")
+			return m, nil
+		}
+		m.modal.Hide()
+		if m.activeSession != nil && m.activeSession.ID == state.SessionID {
+			m.activeSession.Tags = tags
+		}
+		m.sidebar.SetSessions(m.getFilteredSessions())
+		m.sidebar.SelectSession(state.SessionID)
+		return m, m.saveConfigOrFlash()
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+func (m *Model) handleTagFilterModal(key string, msg tea.KeyPressMsg, state *ui.TagFilterState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		m.modal.Hide()
+		return m, m.setTagFilter(state.SelectedTag())
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// setTagFilter shows only the sessions with tag in the sidebar, or all of
+// them for "", keeping the selected session selected if it is still listed.
+func (m *Model) setTagFilter(tag string) tea.Cmd {
+	if tag == m.sidebar.TagFilter() {
+		return nil
+	}
+	var selectedID string
+	if sess := m.sidebar.SelectedSession(); sess != nil {
+		selectedID = sess.ID
+	}
+	m.sidebar.SetTagFilter(tag)
+	m.sidebar.SetSessions(m.getFilteredSessions())
+	if selectedID != "" {
+		m.sidebar.SelectSession(selectedID)
+	}
+	if tag == "" {
+		return m.ShowFlashInfo("Showing all sessions")
+	}
+	return m.ShowFlashInfo(fmt.Sprintf("Showing sessions tagged #%s", tag))
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestEditTagsAndFilterByTag(t *testing.T) {
+	cfg := testConfigWithSessions()
+	cfg.SetFilePath(filepath.Join(t.TempDir(), "config.json"))
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(m.getFilteredSessions())
+	m.sidebar.SelectSession("session-2")
+
+	if _, _, handled := m.ExecuteShortcut("#"); !handled {
+		t.Fatal("Expected '#' to be handled")
+	}
+	state, ok := m.modal.State.(*ui.EditTagsState)
+	if !ok {
+		t.Fatalf("expected the tag editor, got %T", m.modal.State)
+	}
+	state.SetTags("#Bug, spike")
+	m = sendKey(m, "enter")
+
+	if got := cfg.GetSession("session-2").Tags; !slices.Equal(got, []string{"bug", "spike"}) {
+		t.Fatalf("saved tags = %v, want [bug spike]", got)
+	}
+
+	m.ExecuteShortcut("=")
+	filter, ok := m.modal.State.(*ui.TagFilterState)
+	if !ok {
+		t.Fatalf("expected the tag filter, got %T", m.modal.State)
+	}
+	filter.SelectedIndex = 1 // #bug
+	m = sendKey(m, "enter")
+
+	if m.sidebar.TagFilter() != "bug" {
+		t.Fatalf("expected the sidebar filtered by bug, got %q", m.sidebar.TagFilter())
+	}
+	if got := m.getFilteredSessions(); len(got) != 1 || got[0].ID != "session-2" {
+		t.Errorf("expected only the tagged session listed, got %v", got)
+	}
+	if sess := m.sidebar.SelectedSession(); sess == nil || sess.ID != "session-2" {
+		t.Errorf("expected the tagged session to stay selected, got %v", sess)
+	}
+
+	m.setTagFilter("")
+	if got := m.getFilteredSessions(); len(got) != len(cfg.Sessions) {
+		t.Errorf("expected all sessions listed again, got %d", len(got))
+	}
+}
+
+func TestFilterByTag_NoTags(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m := testModelWithSize(cfg, 120, 40)
+	m.sidebar.SetSessions(m.getFilteredSessions())
+
+	m.ExecuteShortcut("=")
+	if m.modal.IsVisible() || !m.footer.HasFlash() {
+		t.Error("expected a hint instead of an empty tag filter")
+	}
+}
@@ -12,6 +12,11 @@ func TestMain(m *testing.M) {
 	logger.Reset()
 	logger.Init(os.DevNull)
 
+	// Test sessions point at placeholder repo and worktree paths; treat them as present
+	statPath = func(string) (os.FileInfo, error) { return nil, nil }
+	// Tests don't depend on the Claude CLI installed; ones about its version stub it
+	detectClaudeVersion = func() string { return "" }
+
 	code := m.Run()
 
 	logger.Reset()
@@ -107,10 +107,28 @@ func keyPress(key string) tea.KeyPressMsg {
 		return tea.KeyPressMsg{Code: 'v', Mod: tea.ModCtrl}
 	case keys.CtrlS:
 		return tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl}
+	case keys.CtrlT:
+		return tea.KeyPressMsg{Code: 't', Mod: tea.ModCtrl}
+	case keys.CtrlZ:
+		return tea.KeyPressMsg{Code: 'z', Mod: tea.ModCtrl}
+	case keys.CtrlG:
+		return tea.KeyPressMsg{Code: 'g', Mod: tea.ModCtrl}
+	case keys.CtrlF:
+		return tea.KeyPressMsg{Code: 'f', Mod: tea.ModCtrl}
+	case keys.CtrlE:
+		return tea.KeyPressMsg{Code: 'e', Mod: tea.ModCtrl}
+	case keys.CtrlD:
+		return tea.KeyPressMsg{Code: 'd', Mod: tea.ModCtrl}
+	case keys.CtrlSemi:
+		return tea.KeyPressMsg{Code: ';', Mod: tea.ModCtrl}
 	case keys.ShiftTab:
 		return tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}
 	case keys.AltComma:
 		return tea.KeyPressMsg{Code: ',', Mod: tea.ModAlt}
+	case keys.AltP:
+		return tea.KeyPressMsg{Code: 'p', Mod: tea.ModAlt}
+	case keys.AltO:
+		return tea.KeyPressMsg{Code: 'o', Mod: tea.ModAlt}
 	default:
 		// Regular character - for single characters, set both Code and Text
 		if len(key) == 1 {
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// shortcutTodoFocus enters todo focus mode, for marking items of the active
+// session's todo list done (or not done) when Claude's list has drifted.
+func shortcutTodoFocus(m *Model) (tea.Model, tea.Cmd) {
+	m.chat.EnterTodoFocusMode()
+	return m, nil
+}
+
+// handleTodoMarksChangedMsg stores the active session's todo list after the
+// user marked an item, so the marks survive switching sessions.
+func (m *Model) handleTodoMarksChangedMsg(msg ui.TodoMarksChangedMsg) {
+	if m.activeSession == nil {
+		return
+	}
+	m.sessionState().GetOrCreate(m.activeSession.ID).SetCurrentTodoList(msg.List)
+}
+
+// reportTodoMarks returns the note telling Claude which todo items the user
+// marked since the last message to sessionID, or "" if there are none. The
+// marks are recorded as reported so each is only mentioned once.
+func (m *Model) reportTodoMarks(sessionID string) string {
+	list := m.chat.GetTodoList()
+	report := list.UserMarksReport()
+	if report == "" {
+		return ""
+	}
+	logger.WithSession(sessionID).Info("reporting user todo marks", "report", report)
+	m.sessionState().GetOrCreate(sessionID).SetCurrentTodoList(&claude.TodoList{Items: slices.Clone(list.Items)})
+	return report
+}
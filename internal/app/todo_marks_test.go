@@ -0,0 +1,92 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func todoChunk(items ...claude.TodoItem) claude.ResponseChunk {
+	return claude.ResponseChunk{Type: claude.ChunkTypeTodoUpdate, TodoList: &claude.TodoList{Items: items}}
+}
+
+func TestTodoMarks_MarkAndReport(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, factory := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m = sendKey(m, "enter")
+	sessionID := m.activeSession.ID
+
+	items := []claude.TodoItem{
+		{Content: "Write code", Status: claude.TodoStatusInProgress},
+		{Content: "Update docs", Status: claude.TodoStatusPending},
+	}
+	m = simulateClaudeResponse(m, sessionID, todoChunk(items...))
+	m = simulateClaudeResponse(m, sessionID, doneChunk())
+
+	// D needs the sidebar focused
+	m = sendKey(m, "tab")
+	m = sendKey(m, "D")
+	if !m.chat.IsInTodoFocusMode() {
+		t.Fatal("Expected D to enter todo focus mode")
+	}
+
+	m = sendKey(m, "j")
+	result, cmd := m.Update(keyPress("space"))
+	m = result.(*Model)
+	if cmd == nil {
+		t.Fatal("Expected a command reporting the changed marks")
+	}
+	msg, ok := cmd().(ui.TodoMarksChangedMsg)
+	if !ok {
+		t.Fatal("Expected TodoMarksChangedMsg")
+	}
+	result, _ = m.Update(msg)
+	m = result.(*Model)
+
+	stored := m.sessionState().GetOrCreate(sessionID).GetCurrentTodoList()
+	if !stored.Items[1].UserMarked || stored.Items[1].Status != claude.TodoStatusCompleted {
+		t.Errorf("Expected the mark to be stored in the session state, got %+v", stored.Items[1])
+	}
+
+	m = sendKey(m, "esc")
+	if m.chat.IsInTodoFocusMode() {
+		t.Error("Expected esc to leave todo focus mode")
+	}
+
+	// Claude republishing the list unchanged keeps the mark
+	m = simulateClaudeResponse(m, sessionID, todoChunk(items...))
+	m = simulateClaudeResponse(m, sessionID, doneChunk())
+	if item := m.chat.GetTodoList().Items[1]; !item.UserMarked {
+		t.Errorf("Expected the mark to survive Claude's update, got %+v", item)
+	}
+
+	// The next message tells Claude, once
+	var sent []claude.ContentBlock
+	factory.GetMock(sessionID).OnSend = func(content []claude.ContentBlock) { sent = content }
+	m.chat.SetInput("carry on")
+	result, _ = m.sendMessage()
+	m = result.(*Model)
+
+	if len(sent) != 2 || !strings.Contains(sent[0].Text, `item 2 ("Update docs") is already done`) || sent[1].Text != "carry on" {
+		t.Errorf("Expected the todo report before the message, got %+v", sent)
+	}
+	if !m.chat.GetTodoList().Items[1].MarkReported {
+		t.Error("Expected the mark to be recorded as reported")
+	}
+}
+
+func TestTodoMarks_ShortcutRequiresTodoList(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	m = sendKey(m, "enter")
+	m = sendKey(m, "tab")
+
+	if _, _, handled := m.ExecuteShortcut("D"); handled {
+		t.Error("Expected D to be unavailable without a todo list")
+	}
+}
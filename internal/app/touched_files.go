@@ -0,0 +1,141 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// containerWorkspace is where containerized sessions see their worktree.
+const containerWorkspace = "/workspace"
+
+// touchedFileGroups is the order the touched files overlay lists its groups in.
+var touchedFileGroups = []manager.FileTouch{manager.FileTouchEdited, manager.FileTouchCreated, manager.FileTouchRead}
+
+// trackTouchedFiles records the file a completed Read, Edit, or Write names in
+// the session's touched files.
+func (m *Model) trackTouchedFiles(sessionID string, chunk claude.ResponseChunk) {
+	if chunk.Type != claude.ChunkTypeToolResult || chunk.ToolError {
+		return
+	}
+	path, touch := manager.TouchFromResult(chunk.ResultInfo)
+	if touch == 0 {
+		return
+	}
+	if sess := m.config.GetSession(sessionID); sess != nil {
+		path = touchedFilePath(sess, path)
+	}
+	m.sessionState().GetOrCreate(sessionID).RecordTouchedFile(path, touch)
+}
+
+// touchedFilePath resolves a path from a tool result to the file on this machine:
+// relative paths are in the worktree, and a container's workspace is the worktree.
+func touchedFilePath(sess *config.Session, path string) string {
+	if sess.WorkTree == "" {
+		return filepath.Clean(path)
+	}
+	if sess.Containerized {
+		if rest, ok := strings.CutPrefix(path, containerWorkspace); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+			path = strings.TrimPrefix(rest, "/")
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(sess.WorkTree, path)
+	}
+	return filepath.Clean(path)
+}
+
+// touchedFileItems lists a session's touched files for the overlay, grouped as
+// touchedFileGroups orders them and sorted by path within each group.
+func touchedFileItems(worktree string, files map[string]manager.FileTouch) []ui.TouchedFileItem {
+	var items []ui.TouchedFileItem
+	for _, group := range touchedFileGroups {
+		start := len(items)
+		for path, touch := range files {
+			if touch != group {
+				continue
+			}
+			shown := path
+			if rel, err := filepath.Rel(worktree, path); worktree != "" && err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				shown = rel
+			}
+			items = append(items, ui.TouchedFileItem{Path: shown, FullPath: path, Touch: touch.String()})
+		}
+		slices.SortFunc(items[start:], func(a, b ui.TouchedFileItem) int { return cmp.Compare(a.Path, b.Path) })
+	}
+	return items
+}
+
+// shortcutTouchedFiles opens the overlay listing the files Claude touched in the
+// selected session.
+func shortcutTouchedFiles(m *Model) (tea.Model, tea.Cmd) {
+	sess := m.sidebar.SelectedSession()
+	var files map[string]manager.FileTouch
+	if state := m.sessionState().GetIfExists(sess.ID); state != nil {
+		files = state.GetTouchedFiles()
+	}
+	m.modal.Show(ui.NewTouchedFilesState(ui.SessionDisplayName(sess.Branch, sess.Name), touchedFileItems(sess.WorkTree, files)))
+	return m, nil
+}
+
+// handleTouchedFilesModal handles key events for the touched files overlay.
+func (m *Model) handleTouchedFilesModal(key string, msg tea.KeyPressMsg, state *ui.TouchedFilesState) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Escape, "q":
+		m.modal.Hide()
+		return m, nil
+	case keys.Enter:
+		item := state.SelectedItem()
+		if item == nil {
+			return m, nil
+		}
+		m.modal.Hide()
+		return m, openInEditor(item.FullPath)
+	}
+	modal, cmd := m.modal.Update(msg)
+	m.modal = modal
+	return m, cmd
+}
+
+// editorFields returns the user's editor and its arguments: $VISUAL, then
+// $EDITOR, then vi, skipping a variable set only to whitespace. The variable
+// may carry arguments ("code --wait").
+func editorFields() []string {
+	editor := cmp.Or(strings.TrimSpace(os.Getenv("VISUAL")), strings.TrimSpace(os.Getenv("EDITOR")), "vi")
+	return strings.Fields(editor)
+}
+
+// editorCommand returns the command opening path in the user's editor.
+func editorCommand(path string) *exec.Cmd {
+	fields := editorFields()
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}
+
+// openInEditor suspends the TUI and opens path in the user's editor, returning
+// to Plural when it exits.
+func openInEditor(path string) tea.Cmd {
+	return execEditor(editorCommand(path), path)
+}
+
+// execEditor suspends the TUI and runs an editor command opening path.
+func execEditor(cmd *exec.Cmd, path string) tea.Cmd {
+	logger.Get().Debug("opening file in editor", "editor", cmd.Path, "path", path)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return TerminalErrorMsg{Error: fmt.Sprintf("Failed to open editor: %v", err)}
+		}
+		return nil
+	})
+}
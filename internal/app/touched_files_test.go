@@ -0,0 +1,91 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+func TestTouchedFiles_TrackedAndListed(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	results := []claude.ResponseChunk{
+		{Type: claude.ChunkTypeToolResult, ResultInfo: &claude.ToolResultInfo{FilePath: "/test/worktree1/main.go", NumLines: 10, TotalLines: 10}},
+		{Type: claude.ChunkTypeToolResult, ResultInfo: &claude.ToolResultInfo{FilePath: "/test/worktree1/main.go", Edited: true}},
+		{Type: claude.ChunkTypeToolResult, ResultInfo: &claude.ToolResultInfo{FilePath: "/test/worktree1/new.go", Edited: true, Created: true}},
+		{Type: claude.ChunkTypeToolResult, ResultInfo: &claude.ToolResultInfo{FilePath: "/etc/hosts", NumLines: 3, TotalLines: 3}},
+		{Type: claude.ChunkTypeToolResult, ResultInfo: &claude.ToolResultInfo{FilePath: "README.md", NumLines: 3, TotalLines: 3}},
+		// Failed tools and results naming no file are not recorded
+		{Type: claude.ChunkTypeToolResult, ToolError: true, ResultInfo: &claude.ToolResultInfo{FilePath: "/test/worktree1/broken.go", Edited: true}},
+		{Type: claude.ChunkTypeToolResult, ResultInfo: &claude.ToolResultInfo{NumFiles: 4}},
+	}
+	for _, chunk := range results {
+		m.trackTouchedFiles("session-1", chunk)
+	}
+
+	m.ExecuteShortcut("e")
+	state, ok := m.modal.State.(*ui.TouchedFilesState)
+	if !ok {
+		t.Fatalf("expected the touched files overlay, got %T", m.modal.State)
+	}
+	want := []ui.TouchedFileItem{
+		{Path: "main.go", FullPath: "/test/worktree1/main.go", Touch: "edited"},
+		{Path: "new.go", FullPath: "/test/worktree1/new.go", Touch: "created"},
+		{Path: "/etc/hosts", FullPath: "/etc/hosts", Touch: "read"},
+		{Path: "README.md", FullPath: "/test/worktree1/README.md", Touch: "read"},
+	}
+	if !slices.Equal(state.Items, want) {
+		t.Errorf("unexpected items:\n got %+v\nwant %+v", state.Items, want)
+	}
+}
+
+func TestTouchedFilePath_Container(t *testing.T) {
+	sess := &config.Session{WorkTree: "/test/worktree1", Containerized: true}
+	for path, want := range map[string]string{
+		"/workspace/src/a.go":  "/test/worktree1/src/a.go",
+		"/workspace":           "/test/worktree1",
+		"/workspaces/other.go": "/workspaces/other.go",
+		"src/b.go":             "/test/worktree1/src/b.go",
+	} {
+		if got := touchedFilePath(sess, path); got != want {
+			t.Errorf("touchedFilePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	cmd := editorCommand("/tmp/a.go")
+	if !slices.Equal(cmd.Args, []string{"code", "--wait", "/tmp/a.go"}) {
+		t.Errorf("unexpected args: %v", cmd.Args)
+	}
+
+	t.Setenv("EDITOR", "")
+	if cmd := editorCommand("/tmp/a.go"); !slices.Equal(cmd.Args, []string{"vi", "/tmp/a.go"}) {
+		t.Errorf("expected vi without an editor set, got %v", cmd.Args)
+	}
+
+	t.Setenv("VISUAL", "  ")
+	t.Setenv("EDITOR", " \t")
+	if cmd := editorCommand("/tmp/a.go"); !slices.Equal(cmd.Args, []string{"vi", "/tmp/a.go"}) {
+		t.Errorf("expected vi with only whitespace set, got %v", cmd.Args)
+	}
+}
+
+func TestTouchedFiles_EmptyWithoutState(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+
+	m.ExecuteShortcut("e")
+	state, ok := m.modal.State.(*ui.TouchedFilesState)
+	if !ok || len(state.Items) != 0 || state.SelectedItem() != nil {
+		t.Fatalf("expected an empty overlay, got %#v", m.modal.State)
+	}
+}
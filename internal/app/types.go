@@ -1,6 +1,9 @@
 package app
 
-import "github.com/zhubert/plural/internal/manager"
+import (
+	"github.com/zhubert/plural/internal/manager"
+	"github.com/zhubert/plural/internal/ui"
+)
 
 // PendingCommit tracks state for commit message editing.
 // Non-nil when a commit message is being edited.
@@ -8,6 +11,24 @@ type PendingCommit struct {
 	SessionID       string           // Session ID waiting for commit message confirmation
 	Type            manager.MergeType // What operation follows after commit
 	ParentSessionID string           // Parent session ID for merge-to-parent operations
+	BaseBranch      string           // PR base branch chosen in the merge modal (empty means repo default)
+	AutoStash       bool             // Stash uncommitted main repo changes around a merge to main
+	MergeState      *ui.MergeState   // Merge modal whose preview confirms a merge to main
+}
+
+// PasteUndo remembers a cleaned paste so the raw text can be restored.
+// Non-nil after a paste was cleaned, until it is undone or replaced.
+type PasteUndo struct {
+	SessionID string // Session whose input received the paste
+	Raw       string // Paste as received from the terminal
+	Cleaned   string // Text that was inserted instead
+}
+
+// PendingAutoAnswer holds a question response produced by auto-answer rules while
+// its grace period runs, during which the user can undo it.
+type PendingAutoAnswer struct {
+	Seq     int               // Identifies the grace period timer for this auto-answer
+	Answers map[string]string // Question text -> option label
 }
 
 // PendingConflict tracks state for conflict resolution.
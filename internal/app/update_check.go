@@ -0,0 +1,72 @@
+package app
+
+import (
+	"path/filepath"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/changelog"
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// UpdateAvailableMsg is sent when a release newer than the running version is found.
+type UpdateAvailableMsg struct {
+	Release changelog.Release
+}
+
+// checkForUpdate creates a command that looks for a newer release in the
+// background, asking GitHub at most once a day. Failures are only logged.
+// Returns nil when update checks are disabled.
+func (m *Model) checkForUpdate() tea.Cmd {
+	if !m.config.GetUpdateCheck() {
+		return nil
+	}
+	version := m.version
+	return func() tea.Msg {
+		stateDir, err := paths.StateDir()
+		if err != nil {
+			return nil
+		}
+		release, err := changelog.CheckForUpdate(version, filepath.Join(stateDir, changelog.UpdateCacheFile), time.Now())
+		if err != nil {
+			logger.Get().Debug("update check failed", "error", err)
+			return nil
+		}
+		if release == nil {
+			return nil
+		}
+		return UpdateAvailableMsg{Release: *release}
+	}
+}
+
+// handleUpdateAvailableMsg offers the newer release's notes from the footer.
+func (m *Model) handleUpdateAvailableMsg(msg UpdateAvailableMsg) (tea.Model, tea.Cmd) {
+	logger.Get().Info("newer release available", "version", msg.Release.Version, "running", m.version)
+	m.availableUpdate = &msg.Release
+	m.footer.SetUpdateHint("v" + msg.Release.Version)
+	return m, nil
+}
+
+// shortcutReleaseNotes shows the release notes of the available update.
+func shortcutReleaseNotes(m *Model) (tea.Model, tea.Cmd) {
+	notes := ui.RenderMarkdown(m.availableUpdate.Notes, ui.ReleaseNotesWidth())
+	m.modal.Show(ui.NewReleaseNotesState(m.availableUpdate.Version, notes))
+	return m, nil
+}
+
+// handleReleaseNotesModal handles key events for the release notes modal.
+func (m *Model) handleReleaseNotesModal(key string, msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch key {
+	case keys.Enter, keys.Escape:
+		m.modal.Hide()
+		return m, nil
+	case keys.Up, "k", keys.Down, "j":
+		modal, cmd := m.modal.Update(msg)
+		m.modal = modal
+		return m, cmd
+	}
+	return m, nil
+}
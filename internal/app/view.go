@@ -9,19 +9,29 @@ import (
 // updateSizes recalculates and applies dimensions to all UI components
 func (m *Model) updateSizes() {
 	ctx := ui.GetViewContext()
+	ctx.SetMode(m.viewMode())
 	ctx.UpdateTerminalSize(m.width, m.height)
 
 	m.header.SetWidth(ctx.TerminalWidth)
 	m.footer.SetWidth(ctx.TerminalWidth)
 	m.sidebar.SetSize(ctx.SidebarWidth, ctx.ContentHeight)
+	if m.inline() {
+		// No sidebar is shown, so the chat takes the full width
+		m.chat.SetSize(ctx.TerminalWidth, ctx.ContentHeight)
+		return
+	}
 	m.chat.SetSize(ctx.ChatWidth, ctx.ContentHeight)
 }
 
 // View renders the app
 func (m *Model) View() tea.View {
 	var v tea.View
-	v.AltScreen = true
-	v.MouseMode = tea.MouseModeCellMotion
+	// Inline mode stays on the normal screen so output lands in the scrollback,
+	// and leaves the mouse to the terminal for native selection
+	v.AltScreen = !m.inline()
+	if !m.inline() {
+		v.MouseMode = tea.MouseModeCellMotion
+	}
 	v.ReportFocus = true
 
 	if m.width == 0 || m.height == 0 {
@@ -45,26 +55,20 @@ func (m *Model) View() tea.View {
 	searchMode := m.sidebar.IsSearchMode()
 	multiSelectMode := m.sidebar.IsMultiSelectMode()
 	m.footer.SetContext(hasSession, sidebarFocused, hasPendingPermission, hasPendingQuestion, isStreaming, viewChangesMode, searchMode, multiSelectMode, hasDetectedOptions, m.kittyKeyboard)
-
-	header := m.header.View()
-	footer := m.footer.View()
-
-	// Render panels side by side
-	sidebarView := m.sidebar.View()
-	chatView := m.chat.View()
-
-	panels := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		sidebarView,
-		chatView,
-	)
-
-	view := lipgloss.JoinVertical(
-		lipgloss.Left,
-		header,
-		panels,
-		footer,
-	)
+	m.footer.SetTodoFocusMode(m.chat.IsInTodoFocusMode())
+	m.footer.SetDestructivePermission(m.chat.HasDestructivePermission())
+
+	var view string
+	if m.focusMode != nil {
+		view = m.renderFocusMode(isStreaming)
+	} else {
+		view = lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.header.View(),
+			m.renderPanels(),
+			m.footer.View(),
+		)
+	}
 
 	// Overlay modal if visible
 	if m.modal.IsVisible() {
@@ -105,26 +109,20 @@ func (m *Model) RenderToString() string {
 	searchMode := m.sidebar.IsSearchMode()
 	multiSelectMode := m.sidebar.IsMultiSelectMode()
 	m.footer.SetContext(hasSession, sidebarFocused, hasPendingPermission, hasPendingQuestion, isStreaming, viewChangesMode, searchMode, multiSelectMode, hasDetectedOptions, m.kittyKeyboard)
-
-	header := m.header.View()
-	footer := m.footer.View()
-
-	// Render panels side by side
-	sidebarView := m.sidebar.View()
-	chatView := m.chat.View()
-
-	panels := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		sidebarView,
-		chatView,
-	)
-
-	view := lipgloss.JoinVertical(
-		lipgloss.Left,
-		header,
-		panels,
-		footer,
-	)
+	m.footer.SetTodoFocusMode(m.chat.IsInTodoFocusMode())
+	m.footer.SetDestructivePermission(m.chat.HasDestructivePermission())
+
+	var view string
+	if m.focusMode != nil {
+		view = m.renderFocusMode(isStreaming)
+	} else {
+		view = lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.header.View(),
+			m.renderPanels(),
+			m.footer.View(),
+		)
+	}
 
 	// Overlay modal if visible
 	if m.modal.IsVisible() {
@@ -139,6 +137,19 @@ func (m *Model) RenderToString() string {
 	return view
 }
 
+// renderPanels renders the sidebar and chat side by side, or only the chat in the
+// inline layout.
+func (m *Model) renderPanels() string {
+	if m.inline() {
+		return m.chat.View()
+	}
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		m.sidebar.View(),
+		m.chat.View(),
+	)
+}
+
 // adjustMouseForChat checks if a mouse event is in the chat panel area and adjusts
 // coordinates relative to the chat panel. Returns the adjusted message and true if
 // the event should be routed to chat, or nil and false otherwise.
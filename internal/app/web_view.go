@@ -0,0 +1,38 @@
+package app
+
+import (
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/ui"
+	"github.com/zhubert/plural/internal/web"
+)
+
+// SetWebView mirrors the active session's conversation to a web view server,
+// which the model stops on Close. Must be called before the program starts.
+func (m *Model) SetWebView(server *web.Server) {
+	m.webView = server
+	m.publishWebView()
+}
+
+// publishWebView shows the active session's current transcript in the web view, if serving one.
+func (m *Model) publishWebView() {
+	if m.webView == nil {
+		return
+	}
+	if m.activeSession == nil {
+		m.webView.Publish("", nil)
+		return
+	}
+	sess := m.activeSession
+	m.webView.Publish(ui.SessionDisplayName(sess.Branch, sess.Name), m.shareTranscript(sess.ID))
+}
+
+// stopWebView stops the web view server, telling open pages it stopped.
+func (m *Model) stopWebView() {
+	if m.webView == nil {
+		return
+	}
+	if err := m.webView.Close(); err != nil {
+		logger.Get().Warn("failed to stop web view server", "error", err)
+	}
+	m.webView = nil
+}
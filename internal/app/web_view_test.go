@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zhubert/plural/internal/share"
+	"github.com/zhubert/plural/internal/web"
+)
+
+func TestWebView_FollowsActiveSession(t *testing.T) {
+	cfg := testConfigWithSessions()
+	m, _ := testModelWithMocks(cfg, 120, 40)
+	m.sidebar.SetSessions(cfg.Sessions)
+	server, err := web.Start(":0")
+	if err != nil {
+		t.Fatalf("web.Start: %v", err)
+	}
+	m.SetWebView(server)
+	t.Cleanup(m.stopWebView)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	events := make(chan share.Event, 16)
+	go share.Watch(ctx, server.URL()+"events", func(e share.Event) { events <- e })
+	next := func() share.Event {
+		t.Helper()
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for web view event")
+			return share.Event{}
+		}
+	}
+
+	if e := next(); e.Type != share.EventSnapshot || e.SessionName != "" {
+		t.Fatalf("first event = %+v, want an empty snapshot with no session selected", e)
+	}
+	for server.Viewers() != 1 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	m.selectSession(m.config.GetSession("session-1"))
+	if e := next(); e.Type != share.EventSnapshot || e.SessionName != "session1" {
+		t.Errorf("event = %+v, want a snapshot of the selected session", e)
+	}
+
+	m.deleteSession(m.config.GetSession("session-1"), false)
+	if e := next(); e.Type != share.EventSnapshot || e.SessionName != "" {
+		t.Errorf("event = %+v, want the view cleared when the session is deleted", e)
+	}
+
+	m.Close()
+	if e := next(); e.Type != share.EventEnd {
+		t.Errorf("event = %+v, want end when plural closes", e)
+	}
+}
@@ -151,9 +151,16 @@ func GetChangesSince(lastSeen string, entries []Entry) []Entry {
 	return result
 }
 
-// CompareVersions compares two semantic versions.
+// CompareVersions compares two semantic versions, pre-releases included (see Version).
 // Returns -1 if a < b, 0 if a == b, 1 if a > b.
 func CompareVersions(a, b string) int {
+	if av, ok := ParseVersion(a); ok {
+		if bv, ok := ParseVersion(b); ok {
+			return av.Compare(bv)
+		}
+	}
+
+	// Lenient fallback for malformed versions: compare the numbers found
 	aParts := parseVersion(a)
 	bParts := parseVersion(b)
 
@@ -0,0 +1,110 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// latestReleaseURL is the GitHub API endpoint for the latest release
+var latestReleaseURL = releasesURL + "/latest"
+
+// UpdateCheckInterval is how often CheckForUpdate asks GitHub for the latest release.
+const UpdateCheckInterval = 24 * time.Hour
+
+// UpdateCacheFile is the name of the file in the state directory caching the
+// latest release between update checks.
+const UpdateCacheFile = "update-check.json"
+
+// Release is a published release, for the update notice.
+type Release struct {
+	Version string `json:"version"` // Without the leading "v"
+	Notes   string `json:"notes"`   // Release notes, as markdown
+}
+
+// updateCache is the cached result of the last update check.
+type updateCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    *Release  `json:"latest,omitempty"` // nil if the last check failed
+}
+
+// FetchLatestRelease fetches the latest release from GitHub.
+func FetchLatestRelease() (Release, error) {
+	client := &http.Client{Timeout: timeout}
+
+	req, err := http.NewRequest("GET", latestReleaseURL, nil)
+	if err != nil {
+		return Release{}, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "plural")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Release{}, fmt.Errorf("fetching latest release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Release{}, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+	}
+
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return Release{}, fmt.Errorf("decoding response: %w", err)
+	}
+	return Release{Version: strings.TrimPrefix(release.TagName, "v"), Notes: release.Body}, nil
+}
+
+// CheckForUpdate returns the latest release when it is newer than current, or
+// nil. GitHub is asked at most once per UpdateCheckInterval; in between, the
+// release cached in cachePath is used. Dev builds are never checked.
+func CheckForUpdate(current, cachePath string, now time.Time) (*Release, error) {
+	return checkForUpdate(current, cachePath, now, FetchLatestRelease)
+}
+
+func checkForUpdate(current, cachePath string, now time.Time, fetch func() (Release, error)) (*Release, error) {
+	if _, ok := ParseVersion(current); !ok {
+		return nil, nil
+	}
+
+	var cache updateCache
+	if data, err := os.ReadFile(cachePath); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+
+	if cache.CheckedAt.IsZero() || now.Sub(cache.CheckedAt) >= UpdateCheckInterval || now.Before(cache.CheckedAt) {
+		release, err := fetch()
+		// A failed check waits for the next interval too, keeping the release found before
+		cache.CheckedAt = now
+		if err == nil {
+			cache.Latest = &release
+		}
+		// Best effort: without the cache, the next start checks again
+		_ = writeUpdateCache(cachePath, cache)
+		if err != nil && cache.Latest == nil {
+			return nil, err
+		}
+	}
+
+	if cache.Latest == nil || !IsNewer(cache.Latest.Version, current) {
+		return nil, nil
+	}
+	return cache.Latest, nil
+}
+
+// writeUpdateCache writes the update check cache, creating its directory.
+func writeUpdateCache(path string, cache updateCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
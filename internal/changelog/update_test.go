@@ -0,0 +1,134 @@
+package changelog
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckForUpdate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	release := Release{Version: "0.9.0", Notes: "## Changes\n- New things"}
+
+	fetcher := func(r Release, err error, calls *int) func() (Release, error) {
+		return func() (Release, error) {
+			*calls++
+			return r, err
+		}
+	}
+
+	t.Run("newer release is returned and cached", func(t *testing.T) {
+		cachePath := filepath.Join(t.TempDir(), "state", UpdateCacheFile)
+		calls := 0
+
+		got, err := checkForUpdate("0.8.0", cachePath, now, fetcher(release, nil, &calls))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == nil || got.Version != "0.9.0" || got.Notes != release.Notes {
+			t.Fatalf("got %+v, want %+v", got, release)
+		}
+
+		data, err := os.ReadFile(cachePath)
+		if err != nil {
+			t.Fatalf("cache not written: %v", err)
+		}
+		var cache updateCache
+		if err := json.Unmarshal(data, &cache); err != nil {
+			t.Fatalf("invalid cache: %v", err)
+		}
+		if !cache.CheckedAt.Equal(now) || cache.Latest == nil || cache.Latest.Version != "0.9.0" {
+			t.Errorf("unexpected cache %+v", cache)
+		}
+	})
+
+	t.Run("fresh cache is used without fetching", func(t *testing.T) {
+		cachePath := filepath.Join(t.TempDir(), UpdateCacheFile)
+		calls := 0
+		checkForUpdate("0.8.0", cachePath, now, fetcher(release, nil, &calls))
+
+		got, err := checkForUpdate("0.8.0", cachePath, now.Add(time.Hour), fetcher(Release{}, errors.New("unused"), &calls))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("fetched %d times, want 1", calls)
+		}
+		if got == nil || got.Version != "0.9.0" {
+			t.Errorf("expected cached release, got %+v", got)
+		}
+	})
+
+	t.Run("stale cache is refreshed", func(t *testing.T) {
+		cachePath := filepath.Join(t.TempDir(), UpdateCacheFile)
+		calls := 0
+		checkForUpdate("0.8.0", cachePath, now, fetcher(release, nil, &calls))
+
+		got, _ := checkForUpdate("0.8.0", cachePath, now.Add(UpdateCheckInterval), fetcher(Release{Version: "0.10.0"}, nil, &calls))
+		if calls != 2 {
+			t.Errorf("fetched %d times, want 2", calls)
+		}
+		if got == nil || got.Version != "0.10.0" {
+			t.Errorf("expected refreshed release, got %+v", got)
+		}
+	})
+
+	t.Run("failure keeps cached release and waits an interval", func(t *testing.T) {
+		cachePath := filepath.Join(t.TempDir(), UpdateCacheFile)
+		calls := 0
+		checkForUpdate("0.8.0", cachePath, now, fetcher(release, nil, &calls))
+
+		later := now.Add(2 * UpdateCheckInterval)
+		got, err := checkForUpdate("0.8.0", cachePath, later, fetcher(Release{}, errors.New("offline"), &calls))
+		if err != nil {
+			t.Fatalf("unexpected error with cached release: %v", err)
+		}
+		if got == nil || got.Version != "0.9.0" {
+			t.Errorf("expected cached release, got %+v", got)
+		}
+
+		checkForUpdate("0.8.0", cachePath, later.Add(time.Hour), fetcher(release, nil, &calls))
+		if calls != 2 {
+			t.Errorf("fetched %d times, want 2 (failed check should be rate-limited)", calls)
+		}
+	})
+
+	t.Run("failure without cache returns error", func(t *testing.T) {
+		cachePath := filepath.Join(t.TempDir(), UpdateCacheFile)
+		calls := 0
+		got, err := checkForUpdate("0.8.0", cachePath, now, fetcher(Release{}, errors.New("offline"), &calls))
+		if err == nil {
+			t.Error("expected error")
+		}
+		if got != nil {
+			t.Errorf("expected no release, got %+v", got)
+		}
+	})
+
+	t.Run("current version is not reported", func(t *testing.T) {
+		cachePath := filepath.Join(t.TempDir(), UpdateCacheFile)
+		calls := 0
+		got, err := checkForUpdate("v0.9.0", cachePath, now, fetcher(release, nil, &calls))
+		if err != nil || got != nil {
+			t.Errorf("got %+v, %v; want nil, nil", got, err)
+		}
+	})
+
+	t.Run("dev build is never checked", func(t *testing.T) {
+		cachePath := filepath.Join(t.TempDir(), UpdateCacheFile)
+		calls := 0
+		got, err := checkForUpdate("dev", cachePath, now, fetcher(release, nil, &calls))
+		if err != nil || got != nil {
+			t.Errorf("got %+v, %v; want nil, nil", got, err)
+		}
+		if calls != 0 {
+			t.Errorf("fetched %d times for a dev build, want 0", calls)
+		}
+		if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+			t.Error("dev build should not write the cache")
+		}
+	})
+}
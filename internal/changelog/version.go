@@ -0,0 +1,132 @@
+package changelog
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed release version: major.minor.patch with an optional
+// pre-release (1.2.0-rc.1) or, for a build made from a commit after a release,
+// the number of commits since it as git describe names them (1.2.0-3-gabc1234).
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 []string // Pre-release identifiers; a pre-release sorts before its release
+	Commits             int      // Commits after the release; such a build sorts after it
+}
+
+// describeSuffix matches the part of a git describe version after the release tag.
+var describeSuffix = regexp.MustCompile(`^(\d+)-g[0-9a-f]+(-dirty)?$`)
+
+// ParseVersion parses a version such as "v1.2.3", "1.2", "1.2.3-rc.1", or
+// "1.2.3-4-gabc1234". Build metadata ("+...") is ignored. Returns false for
+// anything else, including unversioned dev builds ("dev").
+func ParseVersion(s string) (Version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, suffix, hasSuffix := strings.Cut(s, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Version{}, false
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, false
+		}
+		numbers[i] = n
+	}
+	v := Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}
+
+	if hasSuffix {
+		if m := describeSuffix.FindStringSubmatch(suffix); m != nil {
+			v.Commits, _ = strconv.Atoi(m[1])
+			return v, true
+		}
+		v.Pre = strings.Split(suffix, ".")
+		for _, id := range v.Pre {
+			if id == "" {
+				return Version{}, false
+			}
+		}
+	}
+	return v, true
+}
+
+// Compare returns -1 if v sorts before w, 0 if they are equal, and 1 if v sorts after w.
+func (v Version) Compare(w Version) int {
+	for _, d := range [][2]int{{v.Major, w.Major}, {v.Minor, w.Minor}, {v.Patch, w.Patch}} {
+		if c := compareInts(d[0], d[1]); c != 0 {
+			return c
+		}
+	}
+	if c := compareInts(v.rank(), w.rank()); c != 0 {
+		return c
+	}
+	if len(v.Pre) > 0 {
+		return comparePre(v.Pre, w.Pre)
+	}
+	return compareInts(v.Commits, w.Commits)
+}
+
+// rank orders a pre-release before its release, and a later commit after it.
+func (v Version) rank() int {
+	switch {
+	case len(v.Pre) > 0:
+		return -1
+	case v.Commits > 0:
+		return 1
+	}
+	return 0
+}
+
+// comparePre compares pre-release identifiers as semver does: numeric ones
+// numerically and before alphanumeric ones, and a shorter list first when one
+// is a prefix of the other.
+func comparePre(a, b []string) int {
+	for i := range min(len(a), len(b)) {
+		an, aErr := strconv.Atoi(a[i])
+		bn, bErr := strconv.Atoi(b[i])
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInts(an, bn)
+		case aErr == nil:
+			c = -1
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(a), len(b))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// IsNewer returns whether latest is a newer version than current. It is false
+// when either doesn't parse, so dev builds are never told to update.
+func IsNewer(latest, current string) bool {
+	l, ok := ParseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := ParseVersion(current)
+	if !ok {
+		return false
+	}
+	return l.Compare(c) > 0
+}
@@ -0,0 +1,94 @@
+package changelog
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseVersion_Semver(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    Version
+		wantOK  bool
+	}{
+		{name: "release", version: "v1.2.3", want: Version{Major: 1, Minor: 2, Patch: 3}, wantOK: true},
+		{name: "partial", version: "0.9", want: Version{Minor: 9}, wantOK: true},
+		{name: "pre-release", version: "1.2.0-rc.1", want: Version{Major: 1, Minor: 2, Pre: []string{"rc", "1"}}, wantOK: true},
+		{name: "build metadata ignored", version: "1.2.0+darwin", want: Version{Major: 1, Minor: 2}, wantOK: true},
+		{name: "git describe", version: "v1.2.0-3-gabc1234", want: Version{Major: 1, Minor: 2, Commits: 3}, wantOK: true},
+		{name: "git describe dirty", version: "1.2.0-3-gabc1234-dirty", want: Version{Major: 1, Minor: 2, Commits: 3}, wantOK: true},
+		{name: "dev build", version: "dev", wantOK: false},
+		{name: "empty", version: "", wantOK: false},
+		{name: "too many parts", version: "1.2.3.4", wantOK: false},
+		{name: "empty pre-release identifier", version: "1.2.3-rc..1", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseVersion(tt.version)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseVersion(%q) ok = %v, want %v", tt.version, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.Major != tt.want.Major || got.Minor != tt.want.Minor || got.Patch != tt.want.Patch ||
+				got.Commits != tt.want.Commits || !slices.Equal(got.Pre, tt.want.Pre) {
+				t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.2.0", b: "1.2.0", want: 0},
+		{a: "1.2.0-rc.1", b: "1.2.0", want: -1},
+		{a: "1.2.0-rc.1", b: "1.1.9", want: 1},
+		{a: "1.2.0-alpha", b: "1.2.0-beta", want: -1},
+		{a: "1.2.0-rc.2", b: "1.2.0-rc.10", want: -1},
+		{a: "1.2.0-1", b: "1.2.0-rc", want: -1},
+		{a: "1.2.0-rc", b: "1.2.0-rc.1", want: -1},
+		{a: "1.2.0-3-gabc1234", b: "1.2.0", want: 1},
+		{a: "1.2.0-3-gabc1234", b: "1.2.1", want: -1},
+		{a: "1.2.0-3-gabc1234", b: "1.2.0-12-gdef5678", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, _ := ParseVersion(tt.a)
+			b, _ := ParseVersion(tt.b)
+			if got := a.Compare(b); got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := b.Compare(a); got != -tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{latest: "0.9.0", current: "0.8.2", want: true},
+		{latest: "0.9.0", current: "v0.9.0", want: false},
+		{latest: "0.9.0", current: "0.9.0-rc.2", want: true},
+		{latest: "0.9.0", current: "0.9.0-4-gabc1234", want: false},
+		{latest: "0.8.0", current: "0.9.0", want: false},
+		{latest: "0.9.0", current: "dev", want: false},
+		{latest: "nightly", current: "0.9.0", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNewer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
@@ -71,10 +71,44 @@ var DefaultAllowedTools = ComposeTools(ToolSetBase, ToolSetSafeShell)
 // Composed from ToolSetBase + ToolSetContainerShell + ToolSetWeb + ToolSetProductivity (see tools.go).
 var containerAllowedTools = ComposeTools(ToolSetBase, ToolSetContainerShell, ToolSetWeb, ToolSetProductivity)
 
+// RoleSystem is the role of notices plural adds to a session's transcript, such
+// as merge output. They are shown and saved with the conversation but are not
+// Claude's words and are never sent to it.
+const RoleSystem = "system"
+
+// Kinds of system message
+const (
+	KindCommand     = "command"      // Response to a slash command handled locally
+	KindMerge       = "merge"        // Output of a merge or PR and the hooks run after it
+	KindModelSwitch = "model-switch" // Marks where the session switched models
+)
+
 // Message represents a chat message
 type Message struct {
-	Role    string // "user" or "assistant"
+	Role    string // "user", "assistant", or RoleSystem
+	Kind    string // What a system message is about, e.g. KindMerge ("" otherwise)
 	Content string
+
+	// ToolUseGroups records where bursts of tool-use lines sit in Content.
+	// Recorded by whoever builds Content (the runner, or the chat view while
+	// streaming) and saved with the message so compact rendering survives reloads.
+	ToolUseGroups []ToolUseGroup
+}
+
+// ToolUseGroup is a run of consecutive tool-use lines within a message.
+// Start and End are byte offsets into Message.Content covering the lines
+// (End excludes the trailing newline of the last line).
+type ToolUseGroup struct {
+	Start int
+	End   int
+	Tools []ToolUseSummary
+}
+
+// ToolUseSummary is the structured outcome of a single tool use in a group.
+type ToolUseSummary struct {
+	Name     string // e.g., "Read", "Bash"
+	Complete bool   // Whether a result was received
+	Failed   bool   // Whether the tool reported failure (e.g., non-zero Bash exit code)
 }
 
 // ContentType represents the type of content in a message block
@@ -152,6 +186,7 @@ type Runner struct {
 	sessionStarted bool // tracks if session has been created
 	mu             sync.RWMutex
 	allowedTools   []string          // Pre-allowed tools for this session
+	deniedTools    []string          // Tools denied for this session, over any allowed ones
 	socketServer   *mcp.SocketServer // Socket server for MCP communication (persistent)
 	mcpConfigPath  string            // Path to MCP config file (persistent)
 	serverRunning  bool              // Whether the socket server is running
@@ -205,10 +240,28 @@ type Runner struct {
 	// System prompt: passed to Claude CLI via --append-system-prompt
 	systemPrompt string
 
+	// Model: passed to Claude CLI via --model ("" for the CLI's default)
+	model string
+	// Set when the model changed since the CLI process started
+	modelChanged bool
+
 	// Container ready callback: invoked when containerized session receives init message
 	onContainerReady func()
+
+	// Context provider: supplies text prepended to the first message of a fresh CLI session
+	contextProvider ContextProvider
+	// Set when the CLI process started without prior conversation (not a resume or fork)
+	freshSession bool
+
+	// Mirror of the streaming response for external tools (nil when disabled)
+	mirror *responseMirror
 }
 
+// ContextProvider returns text to prepend to the first message sent to a fresh CLI
+// session, such as the contents of files that must stay in Claude's context, along
+// with warnings to show the user (e.g. about truncated files).
+type ContextProvider func() (text string, warnings []string)
+
 // New creates a new Claude runner for a session
 func New(sessionID, workingDir, repoPath string, sessionStarted bool, initialMessages []Message) *Runner {
 	log := logger.WithSession(sessionID)
@@ -277,6 +330,16 @@ func (r *Runner) AddAllowedTool(tool string) {
 	r.allowedTools = append(r.allowedTools, tool)
 }
 
+// SetDeniedTools replaces the denied tools list. Denied tools are passed to the
+// CLI as --disallowedTools and hard-blocked by the MCP permission handler, so
+// they stay denied even where allowed tools or approvals would allow them.
+func (r *Runner) SetDeniedTools(tools []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deniedTools = make([]string, len(tools))
+	copy(r.deniedTools, tools)
+}
+
 // SetForkFromSession sets the parent session ID to fork from.
 // When set and the session hasn't started yet, the CLI will use
 // --resume <parentID> --fork-session to inherit the parent's conversation history.
@@ -322,6 +385,47 @@ func (r *Runner) SetSystemPrompt(prompt string) {
 	r.systemPrompt = prompt
 }
 
+// SetModel sets the model passed to Claude CLI via --model, "" for the CLI's
+// default. A running process is restarted with the new model before the next
+// message, resuming the conversation.
+func (r *Runner) SetModel(model string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if model == r.model {
+		return
+	}
+	r.model = model
+	r.modelChanged = r.processManager != nil
+	r.log.Debug("set model", "model", model)
+}
+
+// SetContextProvider sets the provider of context prepended to the first message
+// whenever a fresh CLI session starts (not when resuming or forking a conversation).
+func (r *Runner) SetContextProvider(provider ContextProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contextProvider = provider
+}
+
+// SetMirrorFile starts mirroring the streaming response to the file at path, or
+// stops mirroring when path is empty. Text chunks are appended as they arrive and
+// tool uses as single-line JSON records (see MirrorToolRecord).
+func (r *Runner) SetMirrorFile(path string) {
+	r.mu.Lock()
+	old := r.mirror
+	r.mirror = nil
+	if path != "" {
+		r.mirror = newResponseMirror(path, r.log)
+	}
+	r.mu.Unlock()
+
+	// Close outside the lock: it waits for pending writes to finish
+	if old != nil {
+		old.Close()
+	}
+	r.log.Debug("set mirror file", "path", path)
+}
+
 // PermissionRequestChan returns the channel for receiving permission requests.
 // Returns nil if the runner has been stopped to prevent reading from closed channel.
 func (r *Runner) PermissionRequestChan() <-chan mcp.PermissionRequest {
@@ -678,8 +782,9 @@ type ToolResultInfo struct {
 	StartLine  int    // Starting line number (1-indexed)
 	TotalLines int    // Total lines in the file
 
-	// For Edit tool results
-	Edited bool // Whether an edit was applied
+	// For Edit and Write tool results
+	Edited  bool // Whether an edit was applied
+	Created bool // Whether a Write created the file
 
 	// For Glob tool results
 	NumFiles int // Number of files matched
@@ -733,7 +838,9 @@ type ResponseChunk struct {
 	ToolName          string             // Tool being used (for tool_use chunks)
 	ToolInput         string             // Brief description of tool input
 	ToolUseID         string             // Unique ID for tool use (for matching tool_use to tool_result)
+	WriteTargets      []string           // Files the tool use may write, as given (for tool_use chunks; see extractWriteTargets)
 	ResultInfo        *ToolResultInfo    // Details about tool result (for tool_result chunks)
+	ToolError         bool               // Whether the tool failed (for tool_result chunks)
 	TodoList          *TodoList          // Todo list (for ChunkTypeTodoUpdate)
 	Stats             *StreamStats       // Streaming statistics (for ChunkTypeStreamStats)
 	SubagentModel     string             // Model name when this is from a subagent (e.g., "claude-haiku-4-5-20251001")
@@ -774,6 +881,7 @@ func (r *Runner) ensureProcessRunning() error {
 		RepoPath:               r.repoPath,
 		SessionStarted:         r.sessionStarted,
 		AllowedTools:           make([]string, len(r.allowedTools)),
+		DeniedTools:            slices.Clone(r.deniedTools),
 		MCPConfigPath:          r.mcpConfigPath,
 		ForkFromSessionID:      r.forkFromSessionID,
 		Containerized:          r.containerized,
@@ -782,8 +890,10 @@ func (r *Runner) ensureProcessRunning() error {
 		Supervisor:             r.supervisor,
 		DisableStreamingChunks: r.disableStreamingChunks,
 		SystemPrompt:           r.systemPrompt,
+		Model:                  r.model,
 	}
 	copy(config.AllowedTools, r.allowedTools)
+	r.modelChanged = false
 
 	r.processManager = NewProcessManager(config, r.createProcessCallbacks(), r.log)
 
@@ -802,6 +912,7 @@ func (r *Runner) ensureProcessRunning() error {
 	} else if err != nil {
 		return err
 	}
+	r.freshSession = StartsFreshSession(config)
 
 	// For container sessions, launch a goroutine that discovers the published
 	// MCP port and dials into the container to establish the IPC connection.
@@ -812,6 +923,23 @@ func (r *Runner) ensureProcessRunning() error {
 	return nil
 }
 
+// stopProcessForModelChange stops the CLI process if the model changed since
+// it started, so ensureProcessRunning starts one with the new model. Called
+// between responses, when the process exiting is expected.
+func (r *Runner) stopProcessForModelChange() {
+	r.mu.Lock()
+	pm := r.processManager
+	changed := r.modelChanged
+	model := r.model
+	r.modelChanged = false
+	r.mu.Unlock()
+
+	if changed && pm != nil && pm.IsRunning() {
+		r.log.Info("restarting process for model change", "model", model)
+		pm.Stop()
+	}
+}
+
 // createProcessCallbacks creates the callbacks for ProcessManager events.
 func (r *Runner) createProcessCallbacks() ProcessCallbacks {
 	return ProcessCallbacks{
@@ -898,11 +1026,15 @@ func (r *Runner) handleProcessLine(line string) {
 				r.streaming.EndsWithDoubleNL = len(chunk.Content) >= 2 && chunk.Content[len(chunk.Content)-2:] == "\n\n"
 			}
 			r.streaming.LastWasToolUse = false
+			if r.mirror != nil {
+				r.mirror.Text(chunk.Content)
+			}
 		case ChunkTypeToolUse:
 			// Format tool use line - add newline if needed
 			if r.streaming.Response.Len() > 0 && !r.streaming.EndsWithNewline {
 				r.streaming.Response.WriteString("\n")
 			}
+			toolUseStart := r.streaming.Response.Len()
 			r.streaming.Response.WriteString("● ")
 			r.streaming.Response.WriteString(formatToolIcon(chunk.ToolName))
 			r.streaming.Response.WriteString("(")
@@ -912,9 +1044,16 @@ func (r *Runner) handleProcessLine(line string) {
 				r.streaming.Response.WriteString(chunk.ToolInput)
 			}
 			r.streaming.Response.WriteString(")\n")
+			r.streaming.RecordToolUse(toolUseStart, r.streaming.Response.Len()-1, chunk.ToolName, chunk.ToolUseID)
 			r.streaming.EndsWithNewline = true
 			r.streaming.EndsWithDoubleNL = false
 			r.streaming.LastWasToolUse = true
+			if r.mirror != nil {
+				r.mirror.ToolUse(chunk.ToolName, chunk.ToolInput)
+			}
+		case ChunkTypeToolResult:
+			failed := chunk.ResultInfo != nil && chunk.ResultInfo.ExitCode != nil && *chunk.ResultInfo.ExitCode != 0
+			r.streaming.CompleteToolUse(chunk.ToolUseID, failed)
 		}
 
 		if r.streaming.FirstChunk {
@@ -1091,7 +1230,14 @@ func (r *Runner) handleProcessLine(line string) {
 				}
 			}
 
-			r.messages = append(r.messages, Message{Role: "assistant", Content: r.streaming.Response.String()})
+			r.messages = append(r.messages, Message{
+				Role:          "assistant",
+				Content:       r.streaming.Response.String(),
+				ToolUseGroups: r.streaming.ToolUseGroups,
+			})
+			if r.mirror != nil {
+				r.mirror.Complete()
+			}
 
 			// Emit stream stats chunk before Done if we have usage data
 			// Prefer modelUsage (which includes sub-agent tokens) over the streaming accumulator
@@ -1287,6 +1433,11 @@ func (r *Runner) handleRestartAttempt(attemptNum int) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	// A restart only resumes the conversation if the session had started outside a container
+	if r.containerized || !r.sessionStarted {
+		r.freshSession = true
+	}
+
 	ch := r.responseChan.Channel
 	chClosed := r.responseChan.Closed
 
@@ -1504,6 +1655,51 @@ func (r *Runner) Send(cmdCtx context.Context, prompt string) <-chan ResponseChun
 	return r.SendContent(cmdCtx, TextContent(prompt))
 }
 
+// SendSync sends a message to Claude and waits for the whole response, for
+// scripts and tests that don't need to follow it as it streams. It returns
+// the response's text and the final stream stats. If ctx ends first, the
+// response is interrupted and ctx's error returned.
+func (r *Runner) SendSync(ctx context.Context, prompt string) (string, *StreamStats, error) {
+	text, stats, err := collectResponse(ctx, r.Send(ctx, prompt))
+	if err != nil && err == ctx.Err() {
+		if ierr := r.Interrupt(); ierr != nil {
+			r.log.Warn("failed to interrupt after context ended", "error", ierr)
+		}
+	}
+	return text, stats, err
+}
+
+// collectResponse drains ch until the response is done or ctx ends, joining
+// the text chunks and keeping the latest stats.
+func collectResponse(ctx context.Context, ch <-chan ResponseChunk) (string, *StreamStats, error) {
+	var text strings.Builder
+	var stats *StreamStats
+	for {
+		select {
+		case <-ctx.Done():
+			return text.String(), stats, ctx.Err()
+		case chunk, ok := <-ch:
+			if !ok {
+				return text.String(), stats, nil
+			}
+			switch chunk.Type {
+			case ChunkTypeText:
+				text.WriteString(chunk.Content)
+			case ChunkTypeStreamStats:
+				if chunk.Stats != nil {
+					stats = chunk.Stats
+				}
+			}
+			if chunk.Error != nil {
+				return text.String(), stats, chunk.Error
+			}
+			if chunk.Done {
+				return text.String(), stats, nil
+			}
+		}
+	}
+}
+
 // SendContent sends structured content to Claude and streams the response
 func (r *Runner) SendContent(cmdCtx context.Context, content []ContentBlock) <-chan ResponseChunk {
 	ch := make(chan ResponseChunk, 100) // Buffered to avoid blocking response reader
@@ -1534,6 +1730,9 @@ func (r *Runner) SendContent(cmdCtx context.Context, content []ContentBlock) <-c
 			return
 		}
 
+		// A process started before the model changed keeps using the old one
+		r.stopProcessForModelChange()
+
 		// Set up the response channel for routing BEFORE starting the process.
 		// This is critical because the process might crash immediately after starting,
 		// and handleFatalError needs the channel to report the error to the user.
@@ -1544,6 +1743,9 @@ func (r *Runner) SendContent(cmdCtx context.Context, content []ContentBlock) <-c
 		r.streaming.Complete = false // Reset for new message - we haven't received result yet
 		r.responseChan.Setup(ch)
 		r.tokens.Reset() // Reset token accumulator for new request
+		if r.mirror != nil {
+			r.mirror.Begin()
+		}
 		if r.processManager != nil {
 			r.processManager.SetInterrupted(false) // Reset interrupt flag for new message
 		}
@@ -1562,6 +1764,8 @@ func (r *Runner) SendContent(cmdCtx context.Context, content []ContentBlock) <-c
 			return
 		}
 
+		content := r.withSessionContext(content, ch)
+
 		// Build the input message
 		inputMsg := StreamInputMessage{
 			Type: "user",
@@ -1618,6 +1822,31 @@ func (r *Runner) SendContent(cmdCtx context.Context, content []ContentBlock) <-c
 	return ch
 }
 
+// withSessionContext prepends the context provider's text to content when this is
+// the first message of a fresh CLI session, so context survives restarts that lose
+// the conversation. Warnings from the provider are streamed to ch.
+func (r *Runner) withSessionContext(content []ContentBlock, ch chan ResponseChunk) []ContentBlock {
+	r.mu.Lock()
+	provider := r.contextProvider
+	fresh := r.freshSession
+	r.freshSession = false
+	r.mu.Unlock()
+
+	if !fresh || provider == nil {
+		return content
+	}
+	text, warnings := provider()
+	for _, warning := range warnings {
+		r.log.Warn("session context warning", "warning", warning)
+		ch <- ResponseChunk{Type: ChunkTypeText, Content: "[" + warning + "]\n"}
+	}
+	if text == "" {
+		return content
+	}
+	r.log.Info("prepending session context to fresh session", "bytes", len(text))
+	return append([]ContentBlock{{Type: ContentTypeText, Text: text}}, content...)
+}
+
 // GetMessages returns a copy of the message history.
 // Thread-safe: takes a snapshot of messages under lock to prevent
 // race conditions with concurrent appends from readPersistentResponses
@@ -1663,6 +1892,13 @@ func (r *Runner) AddAssistantMessage(content string) {
 	r.messages = append(r.messages, Message{Role: "assistant", Content: content})
 }
 
+// AddSystemMessage adds a system message of the given kind to the history
+func (r *Runner) AddSystemMessage(kind, content string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, Message{Role: RoleSystem, Kind: kind, Content: content})
+}
+
 // Stop cleanly stops the runner and releases resources.
 // This method is idempotent - multiple calls are safe.
 func (r *Runner) Stop() {
@@ -1708,6 +1944,12 @@ func (r *Runner) Stop() {
 			r.mcp.Close()
 		}
 
+		// Flush and close the mirror file
+		if r.mirror != nil {
+			r.mirror.Close()
+			r.mirror = nil
+		}
+
 		// Close stream log file
 		if r.streamLogFile != nil {
 			r.streamLogFile.Close()
@@ -3,10 +3,12 @@ package claude
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -566,6 +568,33 @@ func TestParseStreamMessage_UserToolResultWithResultInfo_Edit(t *testing.T) {
 	}
 }
 
+func TestParseStreamMessage_UserToolResultWithResultInfo_WriteCreate(t *testing.T) {
+	log := testLogger()
+	// Test Write tool result for a new file
+	msg := `{
+		"type": "user",
+		"tool_use_result": {
+			"type": "create",
+			"filePath": "/path/to/new.go",
+			"content": "package main",
+			"structuredPatch": []
+		},
+		"message": {"content": [{"type": "tool_result", "tool_use_id": "123", "content": "..."}]}
+	}`
+	chunks := parseStreamMessage(msg, false, log)
+
+	if len(chunks) != 1 {
+		t.Fatalf("Expected 1 chunk, got %d", len(chunks))
+	}
+	info := chunks[0].ResultInfo
+	if info == nil {
+		t.Fatal("Expected ResultInfo to be populated")
+	}
+	if !info.Created || info.FilePath != "/path/to/new.go" {
+		t.Errorf("Expected a created /path/to/new.go, got %+v", info)
+	}
+}
+
 func TestParseStreamMessage_UserToolResultWithResultInfo_Glob(t *testing.T) {
 	log := testLogger()
 	// Test Glob tool result with numFiles
@@ -3594,9 +3623,191 @@ func TestRunner_SetSystemPrompt(t *testing.T) {
 	runner.mu.RUnlock()
 }
 
+func TestRunner_SetModel(t *testing.T) {
+	runner := New("session-1", "/tmp", "", true, nil)
+
+	// Before a process starts, the model is simply used for it
+	runner.SetModel("haiku")
+	runner.mu.RLock()
+	if runner.model != "haiku" || runner.modelChanged {
+		t.Errorf("model = %q, changed = %v; want haiku, unchanged", runner.model, runner.modelChanged)
+	}
+	runner.mu.RUnlock()
+
+	// Once one has, switching marks it for a restart before the next message
+	runner.mu.Lock()
+	runner.processManager = NewProcessManager(ProcessConfig{SessionID: "session-1", Model: "haiku"}, ProcessCallbacks{}, runner.log)
+	runner.mu.Unlock()
+	runner.SetModel("opus")
+	runner.mu.RLock()
+	if runner.model != "opus" || !runner.modelChanged {
+		t.Errorf("model = %q, changed = %v; want opus, changed", runner.model, runner.modelChanged)
+	}
+	runner.mu.RUnlock()
+
+	runner.stopProcessForModelChange()
+	runner.mu.RLock()
+	if runner.modelChanged {
+		t.Error("expected the model change handled")
+	}
+	runner.mu.RUnlock()
+}
+
+func TestRunner_WithSessionContext(t *testing.T) {
+	runner := New("session-1", "/tmp", "", false, nil)
+	content := TextContent("what does this do?")
+	ch := make(chan ResponseChunk, 10)
+
+	// No provider: content unchanged even for a fresh session
+	runner.freshSession = true
+	if got := runner.withSessionContext(content, ch); len(got) != 1 {
+		t.Fatalf("expected content unchanged without a provider, got %d blocks", len(got))
+	}
+
+	calls := 0
+	runner.SetContextProvider(func() (string, []string) {
+		calls++
+		return "<context_files>notes</context_files>", []string{"notes.md truncated"}
+	})
+
+	// Resumed session: provider not consulted
+	if got := runner.withSessionContext(content, ch); len(got) != 1 || calls != 0 {
+		t.Fatalf("expected no context for a resumed session, got %d blocks, %d calls", len(got), calls)
+	}
+
+	// Fresh session: context prepended once, warnings streamed
+	runner.freshSession = true
+	got := runner.withSessionContext(content, ch)
+	if len(got) != 2 || got[0].Text != "<context_files>notes</context_files>" || got[1].Text != "what does this do?" {
+		t.Fatalf("expected context block before the message, got %+v", got)
+	}
+	select {
+	case chunk := <-ch:
+		if !strings.Contains(chunk.Content, "notes.md truncated") {
+			t.Errorf("expected truncation warning chunk, got %q", chunk.Content)
+		}
+	default:
+		t.Error("expected a warning chunk")
+	}
+	if got := runner.withSessionContext(content, ch); len(got) != 1 {
+		t.Error("context should only be prepended to the first message of a fresh session")
+	}
+}
+
+func TestRunner_RestartMarksFreshSession(t *testing.T) {
+	runner := New("session-1", "/tmp", "", true, nil)
+	runner.handleRestartAttempt(1)
+	if runner.freshSession {
+		t.Error("restarting a started session resumes it, so it is not fresh")
+	}
+
+	runner.SetContainerized(true, "image")
+	runner.handleRestartAttempt(1)
+	if !runner.freshSession {
+		t.Error("restarting a container session starts a fresh conversation")
+	}
+}
+
 func TestMockRunner_SetSystemPrompt(t *testing.T) {
 	runner := NewMockRunner("session-1", false, nil)
 
 	// Should not panic
 	runner.SetSystemPrompt("test prompt")
 }
+
+func TestCollectResponse(t *testing.T) {
+	runner := New("test-session", "/tmp/test", "", false, nil)
+	defer runner.Stop()
+
+	runner.mu.Lock()
+	runner.streaming.Active = true
+	runner.tokens.Reset()
+	ch := make(chan ResponseChunk, 100)
+	runner.responseChan.Setup(ch)
+	runner.mu.Unlock()
+
+	runner.handleProcessLine(`{"type":"stream_event","event":{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello, "}}}`)
+	runner.handleProcessLine(`{"type":"stream_event","event":{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"world"}}}`)
+	runner.handleProcessLine(`{"type":"assistant","message":{"id":"msg_1","content":[{"type":"text","text":"Hello, world"}],"usage":{"output_tokens":5}}}`)
+	runner.handleProcessLine(`{"type":"result","subtype":"success","result":"Hello, world","total_cost_usd":0.25,"usage":{"output_tokens":5}}`)
+
+	text, stats, err := collectResponse(context.Background(), ch)
+	if err != nil {
+		t.Fatalf("collectResponse error: %v", err)
+	}
+	if text != "Hello, world" {
+		t.Errorf("text = %q, want %q", text, "Hello, world")
+	}
+	if stats == nil || stats.TotalCostUSD != 0.25 {
+		t.Errorf("stats = %+v, want the final stats with cost 0.25", stats)
+	}
+}
+
+func TestCollectResponse_Error(t *testing.T) {
+	ch := make(chan ResponseChunk, 2)
+	ch <- ResponseChunk{Type: ChunkTypeText, Content: "partial"}
+	ch <- ResponseChunk{Error: errors.New("process crashed"), Done: true}
+
+	text, _, err := collectResponse(context.Background(), ch)
+	if err == nil || err.Error() != "process crashed" {
+		t.Errorf("err = %v, want process crashed", err)
+	}
+	if text != "partial" {
+		t.Errorf("text = %q, want %q", text, "partial")
+	}
+}
+
+func TestCollectResponse_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := collectResponse(ctx, make(chan ResponseChunk))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestHandleProcessLine_RecordsToolUseGroups(t *testing.T) {
+	runner := New("test-session", "/tmp/test", "", false, nil)
+	defer runner.Stop()
+
+	runner.mu.Lock()
+	runner.disableStreamingChunks = true
+	runner.streaming.Active = true
+	ch := make(chan ResponseChunk, 100)
+	runner.responseChan.Setup(ch)
+	runner.mu.Unlock()
+
+	for _, line := range []string{
+		`{"type":"assistant","message":{"id":"msg_1","content":[{"type":"text","text":"Looking.\n"},{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"main.go"}},{"type":"tool_use","id":"t2","name":"Grep","input":{"pattern":"TODO"}}]}}`,
+		`{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t1"}]}}`,
+		`{"type":"assistant","message":{"id":"msg_2","content":[{"type":"text","text":"Done."},{"type":"tool_use","id":"t3","name":"Bash","input":{"command":"ls"}}]}}`,
+		`{"type":"result","subtype":"success","result":"Done."}`,
+	} {
+		runner.handleProcessLine(line)
+	}
+
+	messages := runner.GetMessages()
+	if len(messages) != 1 {
+		t.Fatalf("expected one assistant message, got %d", len(messages))
+	}
+	msg := messages[0]
+	if len(msg.ToolUseGroups) != 2 {
+		t.Fatalf("expected two bursts, got %+v", msg.ToolUseGroups)
+	}
+
+	burst := msg.ToolUseGroups[0]
+	lines := strings.Split(msg.Content[burst.Start:burst.End], "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "Read") || !strings.Contains(lines[1], "Grep") {
+		t.Errorf("expected the burst to cover the Read and Grep lines, got %q", msg.Content[burst.Start:burst.End])
+	}
+	want := []ToolUseSummary{{Name: "Read", Complete: true}, {Name: "Grep"}}
+	if !reflect.DeepEqual(burst.Tools, want) {
+		t.Errorf("burst tools = %+v, want %+v", burst.Tools, want)
+	}
+
+	// Text between tool uses starts a new burst
+	if single := msg.ToolUseGroups[1]; len(single.Tools) != 1 || single.Tools[0].Name != "Bash" {
+		t.Errorf("expected a separate Bash burst, got %+v", single)
+	}
+}
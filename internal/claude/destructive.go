@@ -0,0 +1,211 @@
+package claude
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/zhubert/plural/internal/mcp"
+)
+
+// DestructiveMatch is a part of a shell command that does damage that's hard to
+// undo, as byte offsets into the command and the reason it's destructive.
+type DestructiveMatch struct {
+	Start, End int
+	Reason     string
+}
+
+// DestructiveRules adjusts ClassifyDestructiveCommand for a repo: Extra flags
+// whatever its patterns match, and a match whose text an Allow pattern matches
+// isn't reported, for commands classified as destructive that aren't.
+type DestructiveRules struct {
+	Extra []*regexp.Regexp
+	Allow []*regexp.Regexp
+}
+
+// sqlDestructivePattern matches SQL statements that drop or empty tables,
+// wherever they appear in the command (psql -c, heredocs, echo | mysql).
+var sqlDestructivePattern = regexp.MustCompile(`(?i)\b(drop\s+(table|database|schema)|truncate\s+table)\b`)
+
+// ClassifyDestructiveCommand returns the parts of a Bash command that do
+// damage that's hard to undo: recursive rm of paths outside worktree (the
+// directory the command runs in), force pushes, SQL that drops or truncates
+// tables, and recursive chmod to world-writable, along with what rules.Extra
+// matches. Matches are ordered by where they start. With no worktree, only
+// absolute, home, and variable rm targets count as outside it.
+func ClassifyDestructiveCommand(command, worktree string, rules DestructiveRules) []DestructiveMatch {
+	var matches []DestructiveMatch
+	add := func(start, end int, reason string) {
+		for _, allow := range rules.Allow {
+			if allow.MatchString(command[start:end]) {
+				return
+			}
+		}
+		matches = append(matches, DestructiveMatch{Start: start, End: end, Reason: reason})
+	}
+
+	cwd := worktree
+	for _, words := range mcp.SplitShellCommands(command) {
+		words = mcp.SkipCommandPrefixes(words)
+		if len(words) == 0 {
+			continue
+		}
+		start, end := words[0].Start, words[len(words)-1].End
+		args := words[1:]
+		switch filepath.Base(words[0].Text) {
+		case "cd", "pushd":
+			cwd = classifyChangeDir(cwd, args)
+		case "rm":
+			if target := rmOutsideTarget(cwd, worktree, args); target != "" {
+				add(start, end, "recursively deletes "+target+", which isn't inside the worktree")
+			}
+		case "git":
+			if isForcePush(args) {
+				add(start, end, "force-pushes, overwriting the remote branch")
+			}
+		case "chmod":
+			if isRecursiveWorldWritable(args) {
+				add(start, end, "makes files world-writable recursively")
+			}
+		}
+	}
+
+	for _, loc := range sqlDestructivePattern.FindAllStringIndex(command, -1) {
+		add(loc[0], loc[1], "drops or empties database tables")
+	}
+	for _, re := range rules.Extra {
+		for _, loc := range re.FindAllStringIndex(command, -1) {
+			if loc[1] > loc[0] {
+				add(loc[0], loc[1], "matches the configured pattern "+re.String())
+			}
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b DestructiveMatch) int { return a.Start - b.Start })
+	return matches
+}
+
+// classifyChangeDir returns the directory a cd with args moves to from cwd, or
+// "" when it can't be known.
+func classifyChangeDir(cwd string, args []mcp.ShellWord) string {
+	for len(args) > 0 && strings.HasPrefix(args[0].Text, "-") && args[0].Text != "-" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	dir := args[0].Text
+	switch {
+	case dir == "-" || dir == "~" || strings.HasPrefix(dir, "~/") || strings.ContainsAny(dir, "$`"):
+		return ""
+	case filepath.IsAbs(dir):
+		return filepath.Clean(dir)
+	case cwd == "":
+		return ""
+	}
+	return filepath.Join(cwd, dir)
+}
+
+// rmOutsideTarget returns the first target of a recursive rm that is, or may
+// be, outside worktree when run from cwd, or "" if it isn't recursive or all
+// its targets are inside.
+func rmOutsideTarget(cwd, worktree string, args []mcp.ShellWord) string {
+	recursive := false
+	var targets []string
+	flagsDone := false
+	for _, arg := range args {
+		text := arg.Text
+		switch {
+		case flagsDone || !strings.HasPrefix(text, "-") || text == "-":
+			targets = append(targets, text)
+		case text == "--":
+			flagsDone = true
+		case text == "--recursive":
+			recursive = true
+		case !strings.HasPrefix(text, "--") && strings.ContainsAny(text, "rR"):
+			recursive = true
+		}
+	}
+	if !recursive {
+		return ""
+	}
+	for _, target := range targets {
+		if !insideWorktree(cwd, worktree, target) {
+			return target
+		}
+	}
+	return ""
+}
+
+// insideWorktree returns whether target, relative to cwd unless absolute,
+// stays inside worktree. Targets built from variables or in the home
+// directory can't be known to, nor can relative ones after a cd to somewhere
+// unknown. The worktree itself isn't inside it.
+func insideWorktree(cwd, worktree, target string) bool {
+	if target == "" || strings.ContainsAny(target, "$`") || target == "~" || strings.HasPrefix(target, "~") {
+		return false
+	}
+	if worktree == "" {
+		return !filepath.IsAbs(target) && !strings.HasPrefix(filepath.Clean(target), "..")
+	}
+	if !filepath.IsAbs(target) {
+		if cwd == "" {
+			return false
+		}
+		target = filepath.Join(cwd, target)
+	}
+	target = filepath.Clean(target)
+	root := filepath.Clean(worktree)
+	return target != root && strings.HasPrefix(target, root+string(filepath.Separator))
+}
+
+// isForcePush returns whether git args are a push that forces: --force,
+// --force-with-lease, -f among short flags, or a refspec starting with "+".
+func isForcePush(args []mcp.ShellWord) bool {
+	// Skip git's own options (-C dir, -c key=value) to reach the subcommand
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i].Text, "-") {
+		if args[i].Text == "-C" || args[i].Text == "-c" {
+			i++
+		}
+		i++
+	}
+	if i >= len(args) || args[i].Text != "push" {
+		return false
+	}
+	for _, arg := range args[i+1:] {
+		text := arg.Text
+		switch {
+		case strings.HasPrefix(text, "--force"):
+			return true
+		case strings.HasPrefix(text, "--"):
+		case strings.HasPrefix(text, "-") && strings.Contains(text, "f"):
+			return true
+		case strings.HasPrefix(text, "+") && len(text) > 1:
+			return true
+		}
+	}
+	return false
+}
+
+// isRecursiveWorldWritable returns whether chmod args recursively give
+// everyone write access: -R with 777, 0777, or a+rwx style modes.
+func isRecursiveWorldWritable(args []mcp.ShellWord) bool {
+	recursive, worldWritable := false, false
+	for _, arg := range args {
+		text := arg.Text
+		switch {
+		case text == "--recursive":
+			recursive = true
+		case strings.HasPrefix(text, "--"):
+		case strings.HasPrefix(text, "-") && strings.Contains(text, "R"):
+			recursive = true
+		case text == "777" || text == "0777" || text == "1777":
+			worldWritable = true
+		case text == "a+rwx" || text == "ugo+rwx" || text == "a=rwx" || text == "o+w" || text == "a+w":
+			worldWritable = true
+		}
+	}
+	return recursive && worldWritable
+}
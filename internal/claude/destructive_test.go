@@ -0,0 +1,180 @@
+package claude
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestClassifyDestructiveCommand(t *testing.T) {
+	const worktree = "/repos/app/.plural-worktrees/s1"
+	tests := []struct {
+		name    string
+		command string
+		want    []string // matched text of each match, in order
+	}{
+		// rm
+		{"plain rm", "rm build.log", nil},
+		{"recursive rm inside", "rm -rf build", nil},
+		{"recursive rm absolute inside", "rm -rf " + worktree + "/node_modules", nil},
+		{"recursive rm root", "rm -rf /", []string{"rm -rf /"}},
+		{"recursive rm absolute outside", "rm -rf /etc/nginx", []string{"rm -rf /etc/nginx"}},
+		{"recursive rm parent", "rm -rf ../other", []string{"rm -rf ../other"}},
+		{"recursive rm worktree itself", "rm -rf .", []string{"rm -rf ."}},
+		{"recursive rm home", "rm -rf ~/projects", []string{"rm -rf ~/projects"}},
+		{"recursive rm bare home", "rm -r ~", []string{"rm -r ~"}},
+		{"recursive rm variable", "rm -rf $BUILD_DIR/", []string{"rm -rf $BUILD_DIR/"}},
+		{"recursive rm substitution", "rm -rf `pwd`", []string{"rm -rf `pwd`"}},
+		{"non-recursive rm outside", "rm -f /tmp/lock", nil},
+		{"split flags", "rm -r -f /tmp/x", []string{"rm -r -f /tmp/x"}},
+		{"capital R", "rm -Rf /opt/app", []string{"rm -Rf /opt/app"}},
+		{"long flag", "rm --recursive --force /var/data", []string{"rm --recursive --force /var/data"}},
+		{"flags after double dash are targets", "rm -f -- -r", nil},
+		{"one target outside", "rm -rf build /usr/local", []string{"rm -rf build /usr/local"}},
+		{"absolute rm path", "/bin/rm -rf /srv", []string{"/bin/rm -rf /srv"}},
+		{"sudo", "sudo rm -rf /var/lib", []string{"rm -rf /var/lib"}},
+		{"sudo with flags", "sudo -E rm -rf /var/lib", []string{"rm -rf /var/lib"}},
+		{"assignment prefix", "FOO=1 rm -rf /tmp/x", []string{"rm -rf /tmp/x"}},
+		{"chained", "make clean && rm -rf /tmp/cache", []string{"rm -rf /tmp/cache"}},
+		{"in subshell", "echo $(rm -rf /data)", []string{"rm -rf /data"}},
+		{"redirect not a target", "rm -rf build > /tmp/log", nil},
+		{"quoted target", `rm -rf "/Users/me/My Files"`, []string{`rm -rf "/Users/me/My Files"`}},
+		{"quoted operator", `echo "rm -rf /"`, nil},
+		{"comment", "ls # rm -rf /", nil},
+		{"cd into subdir", "cd src && rm -rf gen", nil},
+		{"cd out of worktree", "cd /tmp && rm -rf gen", []string{"rm -rf gen"}},
+		{"cd up then relative", "cd .. && rm -rf s2", []string{"rm -rf s2"}},
+		{"cd unknown then relative", "cd $DIR && rm -rf gen", []string{"rm -rf gen"}},
+
+		// git push
+		{"plain push", "git push origin main", nil},
+		{"push set upstream", "git push -u origin feature", nil},
+		{"force push", "git push --force origin main", []string{"git push --force origin main"}},
+		{"force short", "git push -f", []string{"git push -f"}},
+		{"force combined short", "git push -uf origin main", []string{"git push -uf origin main"}},
+		{"force with lease", "git push --force-with-lease", []string{"git push --force-with-lease"}},
+		{"plus refspec", "git push origin +main", []string{"git push origin +main"}},
+		{"git -C push force", "git -C ../repo push -f", []string{"git -C ../repo push -f"}},
+		{"force flag on other subcommand", "git checkout -f main", nil},
+		{"force fetch", "git fetch --force", nil},
+		{"push then status", "git push --force; git status", []string{"git push --force"}},
+
+		// SQL
+		{"drop table", `psql -c "DROP TABLE users"`, []string{"DROP TABLE"}},
+		{"drop table lowercase", `sqlite3 app.db 'drop table sessions;'`, []string{"drop table"}},
+		{"drop database", `mysql -e "DROP DATABASE prod"`, []string{"DROP DATABASE"}},
+		{"drop schema", `psql -c "drop   schema public cascade"`, []string{"drop   schema"}},
+		{"truncate table", `psql -c "TRUNCATE TABLE events"`, []string{"TRUNCATE TABLE"}},
+		{"select", `psql -c "SELECT * FROM drop_tables"`, nil},
+		{"drop index", `psql -c "DROP INDEX idx"`, nil},
+		{"word boundary", `echo backdrop table`, nil},
+
+		// chmod
+		{"chmod recursive 777", "chmod -R 777 /var/www", []string{"chmod -R 777 /var/www"}},
+		{"chmod recursive 0777", "chmod -R 0777 .", []string{"chmod -R 0777 ."}},
+		{"chmod recursive a+rwx", "chmod --recursive a+rwx dist", []string{"chmod --recursive a+rwx dist"}},
+		{"chmod recursive combined flags", "chmod -vR 777 .", []string{"chmod -vR 777 ."}},
+		{"chmod 777 not recursive", "chmod 777 script.sh", nil},
+		{"chmod recursive 755", "chmod -R 755 bin", nil},
+		{"chmod recursive u+x", "chmod -R u+x bin", nil},
+
+		// Several
+		{"several in order", `git push -f && rm -rf /tmp/x && psql -c "drop table t"`, []string{"git push -f", "rm -rf /tmp/x", "drop table"}},
+		{"empty", "", nil},
+		{"harmless", "go test ./... && git status", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := ClassifyDestructiveCommand(tt.command, worktree, DestructiveRules{})
+			var got []string
+			for _, m := range matches {
+				got = append(got, tt.command[m.Start:m.End])
+				if m.Reason == "" {
+					t.Errorf("match %q has no reason", tt.command[m.Start:m.End])
+				}
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("ClassifyDestructiveCommand(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyDestructiveCommand_NoWorktree(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"rm -rf build", false},
+		{"rm -rf ../other", true},
+		{"rm -rf /tmp/x", true},
+		{"rm -rf ~/x", true},
+		{"rm -rf $X", true},
+	}
+	for _, tt := range tests {
+		if got := len(ClassifyDestructiveCommand(tt.command, "", DestructiveRules{})) > 0; got != tt.want {
+			t.Errorf("ClassifyDestructiveCommand(%q, no worktree) destructive = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyDestructiveCommand_Rules(t *testing.T) {
+	const worktree = "/repo"
+	tests := []struct {
+		name    string
+		command string
+		rules   DestructiveRules
+		want    []string
+	}{
+		{
+			name:    "extra pattern",
+			command: "kubectl delete namespace prod",
+			rules:   DestructiveRules{Extra: []*regexp.Regexp{regexp.MustCompile(`kubectl delete \S+`)}},
+			want:    []string{"kubectl delete namespace"},
+		},
+		{
+			name:    "extra pattern matching everywhere",
+			command: "terraform destroy; terraform destroy -auto-approve",
+			rules:   DestructiveRules{Extra: []*regexp.Regexp{regexp.MustCompile(`terraform destroy`)}},
+			want:    []string{"terraform destroy", "terraform destroy"},
+		},
+		{
+			name:    "extra pattern matching nothing",
+			command: "ls",
+			rules:   DestructiveRules{Extra: []*regexp.Regexp{regexp.MustCompile(`x*`)}},
+			want:    nil,
+		},
+		{
+			name:    "allow overrides a built-in match",
+			command: "rm -rf /tmp/plural-cache && rm -rf /etc",
+			rules:   DestructiveRules{Allow: []*regexp.Regexp{regexp.MustCompile(`^rm -rf /tmp/plural-`)}},
+			want:    []string{"rm -rf /etc"},
+		},
+		{
+			name:    "allow overrides force push to a personal branch",
+			command: "git push --force origin zhubert/wip",
+			rules:   DestructiveRules{Allow: []*regexp.Regexp{regexp.MustCompile(`origin zhubert/`)}},
+			want:    nil,
+		},
+		{
+			name:    "allow overrides an extra pattern",
+			command: "kubectl delete pod web-1",
+			rules: DestructiveRules{
+				Extra: []*regexp.Regexp{regexp.MustCompile(`kubectl delete \S+ \S+`)},
+				Allow: []*regexp.Regexp{regexp.MustCompile(`delete pod`)},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, m := range ClassifyDestructiveCommand(tt.command, worktree, tt.rules) {
+				got = append(got, tt.command[m.Start:m.End])
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -41,7 +41,9 @@
 //	}
 //
 // The Send() method returns immediately with a channel. Content chunks are
-// sent as they arrive, with a final chunk having Done=true.
+// sent as they arrive, with a final chunk having Done=true. SendSync waits for
+// the whole response instead, returning its text and final stats, for scripts
+// and tests.
 //
 // # Permission System
 //
@@ -0,0 +1,32 @@
+package claude
+
+import (
+	"github.com/zhubert/plural/internal/mcp"
+)
+
+// RunnerHealth is a point-in-time check of a runner's CLI process and MCP socket,
+// used to reconcile session state after the system wakes from sleep.
+type RunnerHealth struct {
+	ProcessRunning bool // The Claude CLI process is alive
+	Streaming      bool // A response is in progress
+	SocketHealthy  bool // The MCP socket answers a ping (true when there is no socket to probe)
+}
+
+// Health checks the runner's CLI process and MCP socket. Container sessions have
+// no host socket to probe, so their socket is always reported healthy.
+func (r *Runner) Health() RunnerHealth {
+	r.mu.RLock()
+	pm := r.processManager
+	health := RunnerHealth{Streaming: r.streaming.Active, SocketHealthy: true}
+	var socketPath string
+	if r.socketServer != nil && !r.containerized {
+		socketPath = r.socketServer.SocketPath()
+	}
+	r.mu.RUnlock()
+
+	health.ProcessRunning = pm != nil && pm.IsRunning()
+	if socketPath != "" {
+		health.SocketHealthy = mcp.ProbeSocket(socketPath)
+	}
+	return health
+}
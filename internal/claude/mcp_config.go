@@ -132,6 +132,9 @@ func (r *Runner) createMCPConfigLocked(socketPath string) (string, error) {
 	if r.hostTools {
 		mcpArgs = append(mcpArgs, "--host-tools")
 	}
+	for _, tool := range r.deniedTools {
+		mcpArgs = append(mcpArgs, "--deny-tool", tool)
+	}
 	mcpServers := map[string]any{
 		"plural": map[string]any{
 			"command": execPath,
@@ -179,6 +182,13 @@ func (r *Runner) createContainerMCPConfigLocked(containerPort int) (string, erro
 	if r.hostTools {
 		args = append(args, "--host-tools")
 	}
+	for _, tool := range r.deniedTools {
+		args = append(args, "--deny-tool", tool)
+	}
+	// The container can't see the host's wire trace toggle, so it's fixed at start
+	if mcp.WireTraceEnabled() {
+		args = append(args, "--debug-mcp")
+	}
 	mcpServers := map[string]any{
 		"plural": map[string]any{
 			"command": "/usr/local/bin/plural",
@@ -177,3 +177,37 @@ func TestCreateContainerMCPConfig_NoExternalServers(t *testing.T) {
 		t.Error("container MCP config should not include external MCP servers")
 	}
 }
+
+func TestCreateMCPConfigLocked_DeniedTools(t *testing.T) {
+	r := &Runner{
+		sessionID: "test-denied-mcp",
+		log:       pmTestLogger(),
+	}
+	r.SetDeniedTools([]string{"Bash(rm:*)"})
+
+	for name, create := range map[string]func() (string, error){
+		"host":      func() (string, error) { return r.createMCPConfigLocked("/tmp/plural-test-denied.sock") },
+		"container": func() (string, error) { return r.createContainerMCPConfigLocked(21120) },
+	} {
+		configPath, err := create()
+		if err != nil {
+			t.Fatalf("%s: creating MCP config: %v", name, err)
+		}
+		data, err := os.ReadFile(configPath)
+		os.Remove(configPath)
+		if err != nil {
+			t.Fatalf("%s: reading MCP config: %v", name, err)
+		}
+		var config struct {
+			MCPServers map[string]struct {
+				Args []string `json:"args"`
+			} `json:"mcpServers"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			t.Fatalf("%s: parsing MCP config: %v", name, err)
+		}
+		if got := getArgValue(config.MCPServers["plural"].Args, "--deny-tool"); got != "Bash(rm:*)" {
+			t.Errorf("%s: --deny-tool = %q, want %q", name, got, "Bash(rm:*)")
+		}
+	}
+}
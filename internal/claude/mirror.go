@@ -0,0 +1,198 @@
+package claude
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// MirrorBufferSize is the number of writes that can be queued for a mirror file
+// before further writes are dropped. Writes happen on a separate goroutine so a
+// slow disk never delays the response stream.
+const MirrorBufferSize = 256
+
+type mirrorOpKind int
+
+const (
+	mirrorBegin    mirrorOpKind = iota // Truncate the file for a new response
+	mirrorWrite                        // Append data
+	mirrorComplete                     // Flush and fsync
+)
+
+type mirrorOp struct {
+	kind   mirrorOpKind
+	data   []byte
+	record bool // Data is a JSON record that must start on a new line
+}
+
+// MirrorToolRecord is the line written to a mirror file when Claude calls a tool.
+// Records always start on their own line so consumers can tell them apart from text.
+type MirrorToolRecord struct {
+	Plural string `json:"plural"` // Always "tool_use"
+	Tool   string `json:"tool"`
+	Input  string `json:"input,omitempty"`
+}
+
+// responseMirror appends the streaming response of a session to a file that
+// external tools can watch. The file is truncated at the start of each response
+// and fsynced when it completes. Failures are logged once and then ignored, so
+// the mirror can never affect the conversation.
+type responseMirror struct {
+	path string
+	log  *slog.Logger
+	ops  chan mirrorOp
+	done chan struct{}
+
+	mu       sync.Mutex // Guards closed so sends never race with Close
+	closed   bool
+	warnOnce sync.Once
+}
+
+// newResponseMirror starts a mirror writing to path.
+func newResponseMirror(path string, log *slog.Logger) *responseMirror {
+	m := &responseMirror{
+		path: path,
+		log:  log,
+		ops:  make(chan mirrorOp, MirrorBufferSize),
+		done: make(chan struct{}),
+	}
+	go m.run()
+	return m
+}
+
+// Begin truncates the file for a new response.
+func (m *responseMirror) Begin() {
+	m.send(mirrorOp{kind: mirrorBegin})
+}
+
+// Text appends a chunk of response text.
+func (m *responseMirror) Text(text string) {
+	if text != "" {
+		m.send(mirrorOp{kind: mirrorWrite, data: []byte(text)})
+	}
+}
+
+// ToolUse appends a tool use record on its own line.
+func (m *responseMirror) ToolUse(tool, input string) {
+	data, err := json.Marshal(MirrorToolRecord{Plural: "tool_use", Tool: tool, Input: input})
+	if err != nil {
+		return
+	}
+	m.send(mirrorOp{kind: mirrorWrite, data: append(data, '\n'), record: true})
+}
+
+// Complete flushes the response and syncs it to disk.
+func (m *responseMirror) Complete() {
+	m.send(mirrorOp{kind: mirrorComplete})
+}
+
+// Close flushes pending writes and closes the file, waiting for the writer to finish.
+func (m *responseMirror) Close() {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.ops)
+	}
+	m.mu.Unlock()
+	<-m.done
+}
+
+// send queues an op without blocking, dropping it if the writer has fallen behind.
+func (m *responseMirror) send(op mirrorOp) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return
+	}
+	select {
+	case m.ops <- op:
+	default:
+		m.warn("mirror file writer fell behind, dropping output", nil)
+	}
+}
+
+// run applies queued ops until Close. The file is opened lazily and buffered
+// writes are flushed whenever the queue drains, so watchers see output promptly.
+// After the first failure the mirror stops writing but keeps draining the queue.
+func (m *responseMirror) run() {
+	defer close(m.done)
+
+	var file *os.File
+	var w *bufio.Writer
+	failed := false
+	atLineStart := true
+
+	fail := func(msg string, err error) {
+		m.warn(msg, err)
+		failed = true
+	}
+
+	for op := range m.ops {
+		if failed {
+			continue
+		}
+		if file == nil {
+			var err error
+			if err = os.MkdirAll(filepath.Dir(m.path), 0755); err == nil {
+				file, err = os.OpenFile(m.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			}
+			if err != nil {
+				fail("failed to open mirror file", err)
+				continue
+			}
+			w = bufio.NewWriter(file)
+		}
+
+		switch op.kind {
+		case mirrorBegin:
+			w.Reset(file)
+			if err := file.Truncate(0); err != nil {
+				fail("failed to truncate mirror file", err)
+				continue
+			}
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				fail("failed to truncate mirror file", err)
+				continue
+			}
+			atLineStart = true
+		case mirrorWrite:
+			if op.record && !atLineStart {
+				w.WriteByte('\n')
+			}
+			w.Write(op.data)
+			atLineStart = op.data[len(op.data)-1] == '\n'
+		case mirrorComplete:
+			if err := w.Flush(); err != nil {
+				fail("failed to write mirror file", err)
+				continue
+			}
+			if err := file.Sync(); err != nil {
+				fail("failed to sync mirror file", err)
+				continue
+			}
+		}
+
+		if len(m.ops) == 0 {
+			if err := w.Flush(); err != nil {
+				fail("failed to write mirror file", err)
+			}
+		}
+	}
+
+	if file != nil {
+		if !failed {
+			w.Flush()
+		}
+		file.Close()
+	}
+}
+
+func (m *responseMirror) warn(msg string, err error) {
+	m.warnOnce.Do(func() {
+		m.log.Warn(msg, "path", m.path, "error", err)
+	})
+}
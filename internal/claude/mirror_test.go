@@ -0,0 +1,88 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResponseMirror_WritesTextAndToolRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mirrors", "session.txt")
+	m := newResponseMirror(path, testLogger())
+
+	m.Begin()
+	m.Text("Let me check.")
+	m.ToolUse("Read", "main.go")
+	m.Text("Looks good.\n")
+	m.Complete()
+	m.Close()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read mirror file: %v", err)
+	}
+	want := "Let me check.\n" +
+		`{"plural":"tool_use","tool":"Read","input":"main.go"}` + "\n" +
+		"Looks good.\n"
+	if string(got) != want {
+		t.Errorf("mirror file = %q, want %q", got, want)
+	}
+}
+
+func TestResponseMirror_TruncatesOnNewResponse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.txt")
+	m := newResponseMirror(path, testLogger())
+
+	m.Begin()
+	m.Text("first response that is long")
+	m.Complete()
+	m.Begin()
+	m.Text("second")
+	m.Complete()
+	m.Close()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read mirror file: %v", err)
+	}
+	if string(got) != "second" {
+		t.Errorf("mirror file = %q, want only the latest response", got)
+	}
+}
+
+func TestResponseMirror_FailureDoesNotBlock(t *testing.T) {
+	// A regular file where the mirror's directory should be makes every open fail
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := newResponseMirror(filepath.Join(blocker, "session.txt"), testLogger())
+
+	for range MirrorBufferSize * 2 {
+		m.Text("chunk")
+	}
+	m.Complete()
+	m.Close()
+	m.Text("after close") // Must not panic
+}
+
+func TestRunner_MirrorsStreamingResponse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.txt")
+	runner := New("session-mirror", "/tmp", "", true, nil)
+	defer runner.Stop()
+	runner.SetMirrorFile(path)
+
+	runner.handleProcessLine(`{"type":"stream_event","event":{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Reading"}}}`)
+	runner.handleProcessLine(`{"type":"assistant","message":{"content":[{"type":"tool_use","id":"toolu_1","name":"Read","input":{"file_path":"main.go"}}]}}`)
+	runner.handleProcessLine(`{"type":"result","subtype":"success","result":"done"}`)
+	runner.SetMirrorFile("") // Closes the mirror, flushing pending writes
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read mirror file: %v", err)
+	}
+	want := "Reading\n" + `{"plural":"tool_use","tool":"Read","input":"main.go"}` + "\n"
+	if string(got) != want {
+		t.Errorf("mirror file = %q, want %q", got, want)
+	}
+}
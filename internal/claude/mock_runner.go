@@ -23,6 +23,7 @@ type MockRunner struct {
 	isStreaming    bool
 	messages       []Message
 	allowedTools   []string
+	deniedTools    []string
 	mcpServers     []MCPServer
 
 	// Response queue - chunks queued by tests to be returned by Send/SendContent
@@ -56,6 +57,18 @@ type MockRunner struct {
 	// System prompt
 	systemPrompt string
 
+	// Model
+	model string
+
+	// Context provider
+	contextProvider ContextProvider
+
+	// Health reported by Health; nil reports a healthy runner matching isStreaming
+	health *RunnerHealth
+
+	// Mirror file path
+	mirrorFile string
+
 	// Simulated streaming content for GetMessagesWithStreaming
 	streamingContent string
 
@@ -215,6 +228,23 @@ func (m *MockRunner) IsStreaming() bool {
 	return m.isStreaming
 }
 
+// Health implements RunnerInterface.
+func (m *MockRunner) Health() RunnerHealth {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.health != nil {
+		return *m.health
+	}
+	return RunnerHealth{ProcessRunning: m.isStreaming, Streaming: m.isStreaming, SocketHealthy: true}
+}
+
+// SetHealth sets the health the mock runner reports.
+func (m *MockRunner) SetHealth(health RunnerHealth) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.health = &health
+}
+
 // Send implements RunnerInterface.
 func (m *MockRunner) Send(ctx context.Context, prompt string) <-chan ResponseChunk {
 	return m.SendContent(ctx, TextContent(prompt))
@@ -321,6 +351,13 @@ func (m *MockRunner) AddAssistantMessage(content string) {
 	m.messages = append(m.messages, Message{Role: "assistant", Content: content})
 }
 
+// AddSystemMessage implements RunnerInterface.
+func (m *MockRunner) AddSystemMessage(kind, content string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, Message{Role: RoleSystem, Kind: kind, Content: content})
+}
+
 // GetResponseChan implements RunnerInterface.
 func (m *MockRunner) GetResponseChan() <-chan ResponseChunk {
 	m.mu.RLock()
@@ -345,6 +382,13 @@ func (m *MockRunner) AddAllowedTool(tool string) {
 	}
 }
 
+// SetDeniedTools implements RunnerInterface.
+func (m *MockRunner) SetDeniedTools(tools []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deniedTools = slices.Clone(tools)
+}
+
 // SetMCPServers implements RunnerInterface.
 func (m *MockRunner) SetMCPServers(servers []MCPServer) {
 	m.mu.Lock()
@@ -399,6 +443,48 @@ func (m *MockRunner) GetSystemPrompt() string {
 	return m.systemPrompt
 }
 
+// SetModel implements RunnerInterface.
+func (m *MockRunner) SetModel(model string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.model = model
+}
+
+// GetModel returns the model set on this mock runner.
+func (m *MockRunner) GetModel() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.model
+}
+
+// SetContextProvider implements RunnerInterface.
+func (m *MockRunner) SetContextProvider(provider ContextProvider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.contextProvider = provider
+}
+
+// GetContextProvider returns the context provider set on this mock runner.
+func (m *MockRunner) GetContextProvider() ContextProvider {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.contextProvider
+}
+
+// SetMirrorFile implements RunnerInterface.
+func (m *MockRunner) SetMirrorFile(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mirrorFile = path
+}
+
+// GetMirrorFile returns the mirror file path set on this mock runner.
+func (m *MockRunner) GetMirrorFile() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.mirrorFile
+}
+
 // PermissionRequestChan implements RunnerInterface.
 func (m *MockRunner) PermissionRequestChan() <-chan mcp.PermissionRequest {
 	m.mu.RLock()
@@ -689,6 +775,13 @@ func (m *MockRunner) GetAllowedTools() []string {
 	return tools
 }
 
+// GetDeniedTools returns the current denied tools list (for test assertions).
+func (m *MockRunner) GetDeniedTools() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.deniedTools)
+}
+
 // SetStreaming allows tests to manually set the streaming state.
 func (m *MockRunner) SetStreaming(streaming bool) {
 	m.mu.Lock()
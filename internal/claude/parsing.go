@@ -31,6 +31,7 @@ type streamMessage struct {
 			ToolUseID string          `json:"tool_use_id,omitempty"` // tool use ID reference (for tool_result)
 			ToolUseId string          `json:"toolUseId,omitempty"`   // camelCase variant from Claude CLI
 			Content   json.RawMessage `json:"content,omitempty"`     // tool result content (can be string or array)
+			IsError   bool            `json:"is_error,omitempty"`    // tool result is an error (for tool_result)
 		} `json:"content"`
 		Usage *StreamUsage `json:"usage,omitempty"` // Token usage (for assistant messages)
 	} `json:"message"`
@@ -57,7 +58,7 @@ type streamMessage struct {
 // Different tool types populate different fields.
 type toolUseResultData struct {
 	// Common fields
-	Type string `json:"type,omitempty"` // e.g., "text"
+	Type string `json:"type,omitempty"` // e.g., "text", or "create"/"update" for Write
 
 	// Read tool results
 	File *toolUseResultFile `json:"file,omitempty"`
@@ -225,10 +226,11 @@ func parseStreamMessage(line string, hasStreamEvents bool, log *slog.Logger) []R
 				// Extract a brief description from the tool input
 				inputDesc := extractToolInputDescription(content.Name, content.Input)
 				chunks = append(chunks, ResponseChunk{
-					Type:      ChunkTypeToolUse,
-					ToolName:  content.Name,
-					ToolInput: inputDesc,
-					ToolUseID: content.ID,
+					Type:         ChunkTypeToolUse,
+					ToolName:     content.Name,
+					ToolInput:    inputDesc,
+					ToolUseID:    content.ID,
+					WriteTargets: extractWriteTargets(content.Name, content.Input),
 				})
 				log.Debug("tool use", "tool", content.Name, "id", content.ID, "input", inputDesc)
 			}
@@ -258,6 +260,7 @@ func parseStreamMessage(line string, hasStreamEvents bool, log *slog.Logger) []R
 					Type:       ChunkTypeToolResult,
 					ToolUseID:  toolUseID,
 					ResultInfo: resultInfo,
+					ToolError:  content.IsError,
 				})
 			}
 		}
@@ -494,6 +497,13 @@ func extractToolResultInfo(field *toolUseResultField) *ToolResultInfo {
 		hasData = true
 	}
 
+	// Write tool results - a new file is reported as "create"
+	if data.Type == "create" && data.FilePath != "" {
+		info.Created = true
+		info.FilePath = data.FilePath
+		hasData = true
+	}
+
 	// Glob tool results - file count
 	if data.NumFiles > 0 {
 		info.NumFiles = data.NumFiles
@@ -71,6 +71,7 @@ type ProcessConfig struct {
 	RepoPath               string // Main repository path (for containerized worktree support)
 	SessionStarted         bool
 	AllowedTools           []string
+	DeniedTools            []string // Passed as --disallowedTools, which the CLI applies over allowed tools
 	MCPConfigPath          string
 	ForkFromSessionID      string // When set, uses --resume <parentID> --fork-session to inherit parent conversation
 	Containerized          bool   // When true, wraps Claude CLI in a container
@@ -79,6 +80,7 @@ type ProcessConfig struct {
 	Supervisor               bool          // When true, adds --supervisor flag to Claude CLI args
 	DisableStreamingChunks   bool          // When true, omits --include-partial-messages for less verbose output (useful for agent mode)
 	SystemPrompt             string        // When set, passed to Claude CLI via --append-system-prompt
+	Model                    string        // When set, passed to Claude CLI via --model
 	ContainerStartupTimeout  time.Duration // Override container startup watchdog timeout (0 = use default)
 }
 
@@ -213,6 +215,12 @@ func (pm *ProcessManager) containerStartupTimeoutDuration() time.Duration {
 	return ContainerStartupTimeout
 }
 
+// StartsFreshSession returns whether a process started with config begins a new
+// conversation rather than resuming or forking one (container runs never resume).
+func StartsFreshSession(config ProcessConfig) bool {
+	return config.Containerized || (!config.SessionStarted && config.ForkFromSessionID == "")
+}
+
 // BuildCommandArgs builds the command line arguments for the Claude CLI based on the config.
 // This is exported for testing purposes to verify correct argument construction.
 func BuildCommandArgs(config ProcessConfig) []string {
@@ -266,6 +274,10 @@ func BuildCommandArgs(config ProcessConfig) []string {
 		}
 	}
 
+	if config.Model != "" {
+		args = append(args, "--model", config.Model)
+	}
+
 	if config.Containerized {
 		// Container IS the sandbox. When MCP config is available, use --permission-prompt-tool
 		// with a wildcard MCP server (--auto-approve) that auto-approves all regular permissions
@@ -289,6 +301,9 @@ func BuildCommandArgs(config ProcessConfig) []string {
 		for _, tool := range config.AllowedTools {
 			args = append(args, "--allowedTools", tool)
 		}
+		for _, tool := range config.DeniedTools {
+			args = append(args, "--disallowedTools", tool)
+		}
 	} else {
 		// Add MCP config and permission prompt tool
 		args = append(args,
@@ -303,6 +318,9 @@ func BuildCommandArgs(config ProcessConfig) []string {
 		for _, tool := range config.AllowedTools {
 			args = append(args, "--allowedTools", tool)
 		}
+		for _, tool := range config.DeniedTools {
+			args = append(args, "--disallowedTools", tool)
+		}
 	}
 
 	return args
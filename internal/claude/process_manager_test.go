@@ -645,6 +645,25 @@ func TestBuildCommandArgs_ResumedSession(t *testing.T) {
 	}
 }
 
+func TestStartsFreshSession(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ProcessConfig
+		want   bool
+	}{
+		{"new session", ProcessConfig{}, true},
+		{"resumed session", ProcessConfig{SessionStarted: true}, false},
+		{"forked session", ProcessConfig{ForkFromSessionID: "parent"}, false},
+		{"container never resumes", ProcessConfig{SessionStarted: true, Containerized: true}, true},
+		{"container never forks", ProcessConfig{ForkFromSessionID: "parent", Containerized: true}, true},
+	}
+	for _, tt := range tests {
+		if got := StartsFreshSession(tt.config); got != tt.want {
+			t.Errorf("%s: StartsFreshSession = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
 func TestBuildCommandArgs_ForkedSession(t *testing.T) {
 	config := ProcessConfig{
 		SessionID:         "child-session-uuid",
@@ -1195,6 +1214,28 @@ func TestBuildCommandArgs_NonContainerized_Unchanged(t *testing.T) {
 	}
 }
 
+func TestBuildCommandArgs_DeniedTools(t *testing.T) {
+	for _, containerized := range []bool{false, true} {
+		config := ProcessConfig{
+			SessionID:     "test-session",
+			MCPConfigPath: "/tmp/mcp.json",
+			AllowedTools:  []string{"Bash"},
+			DeniedTools:   []string{"Bash(rm:*)", "WebFetch"},
+			Containerized: containerized,
+		}
+		args := BuildCommandArgs(config)
+		var denied []string
+		for i, arg := range args {
+			if arg == "--disallowedTools" && i+1 < len(args) {
+				denied = append(denied, args[i+1])
+			}
+		}
+		if len(denied) != 2 || denied[0] != "Bash(rm:*)" || denied[1] != "WebFetch" {
+			t.Errorf("containerized=%v: expected each denied tool passed as --disallowedTools, got %v", containerized, denied)
+		}
+	}
+}
+
 func TestBuildContainerRunArgs(t *testing.T) {
 	config := ProcessConfig{
 		SessionID:      "test-session-123",
@@ -2163,6 +2204,30 @@ func TestBuildCommandArgs_SystemPrompt(t *testing.T) {
 	}
 }
 
+func TestBuildCommandArgs_Model(t *testing.T) {
+	config := ProcessConfig{
+		SessionID:      "resumed-session",
+		WorkingDir:     "/tmp",
+		SessionStarted: true,
+		MCPConfigPath:  "/tmp/mcp.json",
+		Model:          "opus",
+	}
+
+	args := BuildCommandArgs(config)
+
+	if model := getArgValue(args, "--model"); model != "opus" {
+		t.Errorf("expected --model opus, got %q", model)
+	}
+	if resume := getArgValue(args, "--resume"); resume != "resumed-session" {
+		t.Errorf("expected the conversation resumed with the model, got --resume %q", resume)
+	}
+
+	config.Model = ""
+	if model := getArgValue(BuildCommandArgs(config), "--model"); model != "" {
+		t.Errorf("session with no model should NOT have --model, got %q", model)
+	}
+}
+
 func TestBuildCommandArgs_NoSystemPrompt(t *testing.T) {
 	config := ProcessConfig{
 		SessionID:      "normal-session",
@@ -13,6 +13,7 @@ type RunnerInterface interface {
 	// Session state
 	SessionStarted() bool
 	IsStreaming() bool
+	Health() RunnerHealth
 
 	// Message handling
 	Send(ctx context.Context, prompt string) <-chan ResponseChunk
@@ -20,17 +21,22 @@ type RunnerInterface interface {
 	GetMessages() []Message
 	GetMessagesWithStreaming() []Message
 	AddAssistantMessage(content string)
+	AddSystemMessage(kind, content string)
 	GetResponseChan() <-chan ResponseChunk
 
 	// Configuration
 	SetAllowedTools(tools []string)
 	AddAllowedTool(tool string)
+	SetDeniedTools(tools []string)
 	SetMCPServers(servers []MCPServer)
 	SetForkFromSession(parentSessionID string)
 	SetContainerized(containerized bool, image string)
 	SetOnContainerReady(callback func())
 	SetDisableStreamingChunks(disable bool)
 	SetSystemPrompt(prompt string)
+	SetModel(model string)
+	SetContextProvider(provider ContextProvider)
+	SetMirrorFile(path string)
 
 	// Permission/Question/Plan channels
 	PermissionRequestChan() <-chan mcp.PermissionRequest
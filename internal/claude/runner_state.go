@@ -83,6 +83,10 @@ type StreamingState struct {
 	EndsWithDoubleNL bool            // Track if response ends with \n\n
 	FirstChunk       bool            // Track if this is first chunk
 
+	// Tool-use bursts in Response, stored with the finished message
+	ToolUseGroups []ToolUseGroup
+	toolUseSlots  map[string][2]int // Tool use ID -> group and tool index, for marking results
+
 	// Subagent tracking
 	CurrentSubagentModel string // Model of active subagent (empty when no subagent)
 }
@@ -109,9 +113,42 @@ func (s *StreamingState) Reset() {
 	s.EndsWithNewline = false
 	s.EndsWithDoubleNL = false
 	s.FirstChunk = true
+	s.ToolUseGroups = nil
+	s.toolUseSlots = nil
 	s.CurrentSubagentModel = ""
 }
 
+// RecordToolUse notes a tool-use line written to Response between start and end
+// (excluding its trailing newline), extending the current burst when the line
+// directly follows the previous tool use.
+func (s *StreamingState) RecordToolUse(start, end int, toolName, toolUseID string) {
+	n := len(s.ToolUseGroups)
+	if n == 0 || !s.LastWasToolUse || s.ToolUseGroups[n-1].End+1 != start {
+		s.ToolUseGroups = append(s.ToolUseGroups, ToolUseGroup{Start: start})
+		n++
+	}
+	group := &s.ToolUseGroups[n-1]
+	group.End = end
+	group.Tools = append(group.Tools, ToolUseSummary{Name: toolName})
+	if toolUseID != "" {
+		if s.toolUseSlots == nil {
+			s.toolUseSlots = make(map[string][2]int)
+		}
+		s.toolUseSlots[toolUseID] = [2]int{n - 1, len(group.Tools) - 1}
+	}
+}
+
+// CompleteToolUse marks the recorded tool use with the given ID as complete.
+func (s *StreamingState) CompleteToolUse(toolUseID string, failed bool) {
+	slot, ok := s.toolUseSlots[toolUseID]
+	if !ok {
+		return
+	}
+	tool := &s.ToolUseGroups[slot[0]].Tools[slot[1]]
+	tool.Complete = true
+	tool.Failed = failed
+}
+
 // TokenTracking accumulates token usage across API calls within a request.
 // Claude CLI sends cumulative output_tokens within each API call, but resets on new API calls.
 // We track message IDs to detect new API calls and accumulate across them.
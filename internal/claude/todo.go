@@ -3,6 +3,8 @@ package claude
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // TodoStatus represents the status of a todo item
@@ -26,6 +28,14 @@ type TodoItem struct {
 	// ActiveForm is the present participle form shown during execution
 	// e.g., "Running tests" for a task with content "Run tests"
 	ActiveForm string `json:"activeForm"`
+
+	// UserMarked is set when the user changed Status in the todo sidebar because
+	// Claude's list drifted from reality; ClaudeStatus then holds the status
+	// Claude gave the item. See ToggleUserMark and MergeUserMarks.
+	UserMarked   bool       `json:"-"`
+	ClaudeStatus TodoStatus `json:"-"`
+	// MarkReported is set once Claude has been told about the user's mark
+	MarkReported bool `json:"-"`
 }
 
 // TodoList represents a complete todo list from TodoWrite
@@ -92,3 +102,131 @@ func (t *TodoList) IsComplete() bool {
 	}
 	return true
 }
+
+// ToggleUserMark toggles the user's mark on the item at index i. An unmarked
+// item is marked completed, or pending if Claude already completed it; a marked
+// item goes back to the status Claude gave it.
+func (t *TodoList) ToggleUserMark(i int) {
+	if t == nil || i < 0 || i >= len(t.Items) {
+		return
+	}
+	item := &t.Items[i]
+	if item.UserMarked {
+		item.Status = item.ClaudeStatus
+		item.UserMarked, item.ClaudeStatus, item.MarkReported = false, "", false
+		return
+	}
+	item.UserMarked = true
+	item.ClaudeStatus = item.Status
+	if item.Status == TodoStatusCompleted {
+		item.Status = TodoStatusPending
+	} else {
+		item.Status = TodoStatusCompleted
+	}
+}
+
+// HasUserMarks returns true if the user has marked any item.
+func (t *TodoList) HasUserMarks() bool {
+	if t == nil {
+		return false
+	}
+	for _, item := range t.Items {
+		if item.UserMarked {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeUserMarks returns incoming, a list Claude just published, with the user's
+// marks from previous carried over. Items are matched by content, since Claude
+// may reorder, add, or drop items. A mark is kept while Claude publishes the
+// item with the status it had when the user marked it, and dropped once Claude
+// changes that status itself: the user's mark wins until Claude has caught up.
+func MergeUserMarks(previous, incoming *TodoList) *TodoList {
+	if incoming == nil || !previous.HasUserMarks() {
+		return incoming
+	}
+
+	marked := make(map[string][]TodoItem)
+	for _, item := range previous.Items {
+		if item.UserMarked {
+			key := todoKey(item.Content)
+			marked[key] = append(marked[key], item)
+		}
+	}
+
+	merged := &TodoList{Items: make([]TodoItem, len(incoming.Items))}
+	for i, item := range incoming.Items {
+		merged.Items[i] = item
+		key := todoKey(item.Content)
+		candidates := marked[key]
+		if len(candidates) == 0 {
+			continue
+		}
+		// Each previous mark applies to at most one incoming item
+		mark := candidates[0]
+		marked[key] = candidates[1:]
+		if item.Status != mark.ClaudeStatus {
+			continue
+		}
+		merged.Items[i].Status = mark.Status
+		merged.Items[i].UserMarked = true
+		merged.Items[i].ClaudeStatus = mark.ClaudeStatus
+		merged.Items[i].MarkReported = mark.MarkReported
+	}
+	return merged
+}
+
+// todoKey normalizes an item's content for matching across updates.
+func todoKey(content string) string {
+	return strings.ToLower(strings.Join(strings.Fields(content), " "))
+}
+
+// UserMarksReport returns a note telling Claude which items the user has marked
+// done or not done since Claude was last told, numbered as in the list, and
+// marks them reported. Returns "" if there is nothing new to report.
+func (t *TodoList) UserMarksReport() string {
+	if t == nil {
+		return ""
+	}
+	var done, notDone []int
+	for i := range t.Items {
+		item := &t.Items[i]
+		if !item.UserMarked || item.MarkReported {
+			continue
+		}
+		item.MarkReported = true
+		if item.Status == TodoStatusCompleted {
+			done = append(done, i)
+		} else {
+			notDone = append(notDone, i)
+		}
+	}
+	if len(done) == 0 && len(notDone) == 0 {
+		return ""
+	}
+
+	var reports []string
+	if len(done) > 0 {
+		reports = append(reports, t.describeItems(done)+" already done")
+	}
+	if len(notDone) > 0 {
+		reports = append(reports, t.describeItems(notDone)+" not done yet")
+	}
+	return "[The user reports that in your todo list, " + strings.Join(reports, ", and ") +
+		". Update your todo list to reflect this.]"
+}
+
+// describeItems describes the items at the given indexes, e.g.
+// `items 2 ("Run tests") and 4 ("Update docs") are`.
+func (t *TodoList) describeItems(indexes []int) string {
+	parts := make([]string, len(indexes))
+	for i, idx := range indexes {
+		parts[i] = strconv.Itoa(idx+1) + " (" + strconv.Quote(t.Items[idx].Content) + ")"
+	}
+	if len(parts) == 1 {
+		return "item " + parts[0] + " is"
+	}
+	return "items " + strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1] + " are"
+}
@@ -2,6 +2,7 @@ package claude
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -288,3 +289,152 @@ func TestTodoList_IsComplete(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoList_ToggleUserMark(t *testing.T) {
+	list := &TodoList{Items: []TodoItem{
+		{Content: "Write code", Status: TodoStatusInProgress},
+		{Content: "Run tests", Status: TodoStatusCompleted},
+	}}
+
+	list.ToggleUserMark(0)
+	item := list.Items[0]
+	if !item.UserMarked || item.Status != TodoStatusCompleted || item.ClaudeStatus != TodoStatusInProgress {
+		t.Errorf("expected in-progress item marked completed, got %+v", item)
+	}
+
+	list.ToggleUserMark(1)
+	if item := list.Items[1]; !item.UserMarked || item.Status != TodoStatusPending {
+		t.Errorf("expected completed item marked pending, got %+v", item)
+	}
+
+	list.ToggleUserMark(0)
+	if item := list.Items[0]; item.UserMarked || item.Status != TodoStatusInProgress {
+		t.Errorf("expected unmarking to restore Claude's status, got %+v", item)
+	}
+
+	// Out of range is ignored
+	list.ToggleUserMark(5)
+	var nilList *TodoList
+	nilList.ToggleUserMark(0)
+}
+
+func TestMergeUserMarks(t *testing.T) {
+	marked := func() *TodoList {
+		list := &TodoList{Items: []TodoItem{
+			{Content: "Write code", Status: TodoStatusInProgress},
+			{Content: "Update docs", Status: TodoStatusPending},
+			{Content: "Run tests", Status: TodoStatusPending},
+		}}
+		list.ToggleUserMark(1)
+		return list
+	}
+
+	t.Run("mark kept while Claude leaves the item unchanged", func(t *testing.T) {
+		incoming := &TodoList{Items: []TodoItem{
+			{Content: "Write code", Status: TodoStatusCompleted},
+			{Content: "Run tests", Status: TodoStatusInProgress},
+			{Content: "update  docs", Status: TodoStatusPending},
+		}}
+		merged := MergeUserMarks(marked(), incoming)
+
+		if merged.Items[0].UserMarked || merged.Items[0].Status != TodoStatusCompleted {
+			t.Errorf("unmarked item should take Claude's status, got %+v", merged.Items[0])
+		}
+		// Matched by content despite the reorder and different spacing and case
+		if item := merged.Items[2]; !item.UserMarked || item.Status != TodoStatusCompleted || item.ClaudeStatus != TodoStatusPending {
+			t.Errorf("expected user mark carried over, got %+v", item)
+		}
+		if incoming.Items[2].UserMarked {
+			t.Error("incoming list should not be modified")
+		}
+	})
+
+	t.Run("Claude republishing the item drops the mark", func(t *testing.T) {
+		incoming := &TodoList{Items: []TodoItem{
+			{Content: "Update docs", Status: TodoStatusInProgress},
+		}}
+		merged := MergeUserMarks(marked(), incoming)
+		if item := merged.Items[0]; item.UserMarked || item.Status != TodoStatusInProgress {
+			t.Errorf("expected Claude's new status to win, got %+v", item)
+		}
+	})
+
+	t.Run("Claude agreeing drops the mark", func(t *testing.T) {
+		incoming := &TodoList{Items: []TodoItem{
+			{Content: "Update docs", Status: TodoStatusCompleted},
+		}}
+		merged := MergeUserMarks(marked(), incoming)
+		if item := merged.Items[0]; item.UserMarked || item.Status != TodoStatusCompleted {
+			t.Errorf("expected Claude's completed status, got %+v", item)
+		}
+	})
+
+	t.Run("each mark applies to one item", func(t *testing.T) {
+		previous := &TodoList{Items: []TodoItem{{Content: "Fix lint", Status: TodoStatusPending}}}
+		previous.ToggleUserMark(0)
+		incoming := &TodoList{Items: []TodoItem{
+			{Content: "Fix lint", Status: TodoStatusPending},
+			{Content: "Fix lint", Status: TodoStatusPending},
+		}}
+		merged := MergeUserMarks(previous, incoming)
+		if !merged.Items[0].UserMarked || merged.Items[1].UserMarked {
+			t.Errorf("expected only the first duplicate marked, got %+v", merged.Items)
+		}
+	})
+
+	t.Run("no marks returns incoming", func(t *testing.T) {
+		incoming := &TodoList{Items: []TodoItem{{Content: "Write code", Status: TodoStatusPending}}}
+		if got := MergeUserMarks(nil, incoming); got != incoming {
+			t.Error("expected incoming list unchanged without previous marks")
+		}
+		if got := MergeUserMarks(marked(), nil); got != nil {
+			t.Error("expected nil for nil incoming list")
+		}
+	})
+
+	t.Run("reported state survives the merge", func(t *testing.T) {
+		previous := marked()
+		previous.UserMarksReport()
+		incoming := &TodoList{Items: []TodoItem{{Content: "Update docs", Status: TodoStatusPending}}}
+		merged := MergeUserMarks(previous, incoming)
+		if !merged.Items[0].MarkReported {
+			t.Error("expected the mark to stay reported")
+		}
+		if report := merged.UserMarksReport(); report != "" {
+			t.Errorf("expected no repeated report, got %q", report)
+		}
+	})
+}
+
+func TestTodoList_UserMarksReport(t *testing.T) {
+	list := &TodoList{Items: []TodoItem{
+		{Content: "Write code", Status: TodoStatusInProgress},
+		{Content: "Update docs", Status: TodoStatusPending},
+		{Content: "Run tests", Status: TodoStatusCompleted},
+		{Content: "Tag release", Status: TodoStatusPending},
+	}}
+	if report := list.UserMarksReport(); report != "" {
+		t.Errorf("expected no report without marks, got %q", report)
+	}
+
+	list.ToggleUserMark(1)
+	list.ToggleUserMark(3)
+	list.ToggleUserMark(2)
+
+	report := list.UserMarksReport()
+	want := `[The user reports that in your todo list, items 2 ("Update docs") and 4 ("Tag release") are already done, ` +
+		`and item 3 ("Run tests") is not done yet. Update your todo list to reflect this.]`
+	if report != want {
+		t.Errorf("report = %q\nwant %q", report, want)
+	}
+
+	if again := list.UserMarksReport(); again != "" {
+		t.Errorf("expected marks to be reported once, got %q", again)
+	}
+
+	// A new mark is reported on its own
+	list.ToggleUserMark(0)
+	if report := list.UserMarksReport(); !strings.Contains(report, `item 1 ("Write code") is already done`) {
+		t.Errorf("expected only the new mark, got %q", report)
+	}
+}
@@ -0,0 +1,119 @@
+package claude
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/zhubert/plural/internal/mcp"
+)
+
+// writeTargetFields maps the tools that write files to the input field naming the file.
+var writeTargetFields = map[string]string{
+	"Write":        "file_path",
+	"Edit":         "file_path",
+	"MultiEdit":    "file_path",
+	"NotebookEdit": "notebook_path",
+}
+
+// extractWriteTargets returns the paths a tool use may write: the file of a
+// Write/Edit tool, or the redirect and tee targets of a Bash command. Paths are
+// as given, relative to the session's working directory unless absolute, and
+// may start with "~" for the home directory.
+func extractWriteTargets(toolName string, input json.RawMessage) []string {
+	if len(input) == 0 {
+		return nil
+	}
+	var inputMap map[string]any
+	if err := json.Unmarshal(input, &inputMap); err != nil {
+		return nil
+	}
+	if toolName == "Bash" {
+		command, _ := inputMap["command"].(string)
+		return BashWriteTargets(command)
+	}
+	if field, ok := writeTargetFields[toolName]; ok {
+		if path, _ := inputMap[field].(string); path != "" {
+			return []string{path}
+		}
+	}
+	return nil
+}
+
+// BashWriteTargets returns the files a shell command writes through output
+// redirects and tee. A cd earlier in the command moves the directory later
+// relative targets are resolved from, so "cd .. && echo x > f" writes "../f".
+// Targets that can't be known without running the command, such as those built
+// from variables, are skipped, as are devices under /dev.
+func BashWriteTargets(command string) []string {
+	var targets []string
+	add := func(cwd, target string) {
+		if target == "" || strings.ContainsAny(target, "$`") || strings.HasPrefix(target, "/dev/") {
+			return
+		}
+		if !filepath.IsAbs(target) && target != "~" && !strings.HasPrefix(target, "~/") {
+			target = filepath.Join(cwd, target)
+		}
+		targets = append(targets, target)
+	}
+
+	cwd := ""
+	for _, words := range mcp.SplitShellCommandsWithRedirects(command) {
+		var args []mcp.ShellWord
+		for _, word := range words {
+			switch {
+			case word.Redirect == "":
+				args = append(args, word)
+			case strings.HasPrefix(word.Redirect, "<"):
+				// Input, a here-document's delimiter, or a here-string
+			case strings.HasSuffix(word.Redirect, "&") && strings.Trim(word.Text, "0123456789-") == "":
+				// Duplicating a descriptor (2>&1, >&-) writes no file
+			default:
+				add(cwd, word.Text)
+			}
+		}
+		args = mcp.SkipCommandPrefixes(args)
+		if len(args) == 0 {
+			continue
+		}
+		switch args[0].Text {
+		case "cd", "pushd":
+			cwd = changeDir(cwd, wordTexts(args[1:]))
+		case "tee":
+			for _, arg := range args[1:] {
+				if !strings.HasPrefix(arg.Text, "-") {
+					add(cwd, arg.Text)
+				}
+			}
+		}
+	}
+	return targets
+}
+
+// wordTexts returns the text of each word.
+func wordTexts(words []mcp.ShellWord) []string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.Text
+	}
+	return texts
+}
+
+// changeDir returns the directory a cd with args moves to from cwd. Where it
+// can't be known (cd -, variables) cwd is kept.
+func changeDir(cwd string, args []string) string {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") && args[0] != "-" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return "~"
+	}
+	dir := args[0]
+	switch {
+	case dir == "-" || strings.ContainsAny(dir, "$`"):
+		return cwd
+	case filepath.IsAbs(dir) || dir == "~" || strings.HasPrefix(dir, "~/"):
+		return filepath.Clean(dir)
+	}
+	return filepath.Join(cwd, dir)
+}
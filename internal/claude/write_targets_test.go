@@ -0,0 +1,91 @@
+package claude
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestBashWriteTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+	}{
+		{"no writes", "go test ./...", nil},
+		{"redirect", "echo hi > out.txt", []string{"out.txt"}},
+		{"append", "echo hi >> log.txt", []string{"log.txt"}},
+		{"no space", "echo hi >out.txt", []string{"out.txt"}},
+		{"clobber", "echo hi >| out.txt", []string{"out.txt"}},
+		{"fd redirect", "make 2> err.log", []string{"err.log"}},
+		{"stdout and stderr", "make &> all.log", []string{"all.log"}},
+		{"fd duplication", "make 2>&1 | less", nil},
+		{"dev null", "make > /dev/null 2>&1", nil},
+		{"input redirect", "sort < in.txt > out.txt", []string{"out.txt"}},
+		{"absolute", "echo x > /Users/me/repo/main.go", []string{"/Users/me/repo/main.go"}},
+		{"parent escape", "echo x > ../main/main.go", []string{"../main/main.go"}},
+		{"home", "echo x >> ~/.zshrc", []string{"~/.zshrc"}},
+		{"quoted", `echo x > "my file.txt"`, []string{"my file.txt"}},
+		{"quoted operator", `echo "a > b" 'c > d'`, nil},
+		{"escaped operator", `echo a \> b`, nil},
+		{"tee", "echo x | tee -a a.txt ../b.txt", []string{"a.txt", "../b.txt"}},
+		{"cd then redirect", "cd .. && echo x > f.go", []string{"../f.go"}},
+		{"cd chain", "cd sub; cd ../../other && echo x > f.go", []string{"../other/f.go"}},
+		{"cd absolute", "cd /tmp && echo x > f", []string{"/tmp/f"}},
+		{"cd home", "cd && echo x > f", []string{"~/f"}},
+		{"cd dash keeps directory", "cd - && echo x > f", []string{"f"}},
+		{"variable target skipped", "echo x > $OUT", nil},
+		{"variable cd keeps directory", "cd $DIR && echo x > f", []string{"f"}},
+		{"assignment prefix", "FOO=1 tee out.txt", []string{"out.txt"}},
+		{"comment", "echo x # > not-a-file", nil},
+		{"multiline", "cd ..\necho x > f", []string{"../f"}},
+		{"heredoc", "cat > ../notes.md <<'EOF'\nline > with redirect\nEOF\necho done > done.txt", []string{"../notes.md", "done.txt"}},
+		{"here-string", "cat <<< word > out", []string{"out"}},
+		{"heredoc with tabs stripped", "cat <<-EOF > out\n\tx > y\n\tEOF", []string{"out"}},
+		{"redirect to file with >&", "make >& build.log", []string{"build.log"}},
+		{"subshell", "(cd sub && echo x > f)", []string{"sub/f"}},
+		{"substitution", "echo $(cat a > b)", []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BashWriteTargets(tt.command); !slices.Equal(got, tt.want) {
+				t.Errorf("BashWriteTargets(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractWriteTargets(t *testing.T) {
+	tests := []struct {
+		tool  string
+		input map[string]any
+		want  []string
+	}{
+		{"Write", map[string]any{"file_path": "/repo/main.go", "content": "x"}, []string{"/repo/main.go"}},
+		{"Edit", map[string]any{"file_path": "../main.go"}, []string{"../main.go"}},
+		{"MultiEdit", map[string]any{"file_path": "a.go"}, []string{"a.go"}},
+		{"NotebookEdit", map[string]any{"notebook_path": "n.ipynb"}, []string{"n.ipynb"}},
+		{"Bash", map[string]any{"command": "echo x > /tmp/f"}, []string{"/tmp/f"}},
+		{"Read", map[string]any{"file_path": "/etc/passwd"}, nil},
+	}
+	for _, tt := range tests {
+		input, _ := json.Marshal(tt.input)
+		if got := extractWriteTargets(tt.tool, input); !slices.Equal(got, tt.want) {
+			t.Errorf("extractWriteTargets(%s) = %q, want %q", tt.tool, got, tt.want)
+		}
+	}
+}
+
+func TestParseStreamMessage_WriteTargets(t *testing.T) {
+	line := `{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t1","name":"Write","input":{"file_path":"/elsewhere/main.go","content":"x"}}]}}`
+	chunks := parseStreamMessage(line, false, testLogger())
+	if len(chunks) != 1 || !slices.Equal(chunks[0].WriteTargets, []string{"/elsewhere/main.go"}) {
+		t.Fatalf("expected write target in tool use chunk, got %+v", chunks)
+	}
+
+	line = `{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t1","content":"denied","is_error":true}]}}`
+	chunks = parseStreamMessage(line, false, testLogger())
+	if len(chunks) != 1 || !chunks[0].ToolError {
+		t.Fatalf("expected failed tool result chunk, got %+v", chunks)
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// claudeVersionTimeout bounds claude --version, so a hung binary can't stall its caller.
+const claudeVersionTimeout = 5 * time.Second
+
+// versionPattern matches a dotted version number such as "2.0.14"
+var versionPattern = regexp.MustCompile(`\d+(?:\.\d+)+`)
+
+// ParseVersion returns the version number in a tool's --version output, e.g.
+// "2.0.14" from "2.0.14 (Claude Code)", or "" if it has none.
+func ParseVersion(output string) string {
+	return versionPattern.FindString(output)
+}
+
+// VersionChanged returns true if two versions are known and differ.
+func VersionChanged(recorded, current string) bool {
+	return recorded != "" && current != "" && recorded != current
+}
+
+// claudeVersionCache remembers the version of the claude binary last asked,
+// keyed by its path, size and modification time so that an upgrade is noticed.
+var claudeVersionCache struct {
+	mu      sync.Mutex
+	path    string
+	size    int64
+	modTime time.Time
+	version string
+}
+
+// ClaudeVersion returns the version of the claude binary in PATH, as reported by
+// claude --version, or "" if it can't be found, run, or answer in time. The
+// answer is cached until the binary changes.
+func ClaudeVersion() string {
+	path, err := exec.LookPath("claude")
+	if err != nil {
+		return ""
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+
+	c := &claudeVersionCache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.version != "" && c.path == path && c.size == info.Size() && c.modTime.Equal(info.ModTime()) {
+		return c.version
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), claudeVersionTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	c.path, c.size, c.modTime = path, info.Size(), info.ModTime()
+	c.version = ParseVersion(string(output))
+	return c.version
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"2.0.14 (Claude Code)\n", "2.0.14"},
+		{"claude version 1.2", "1.2"},
+		{"v10.0.1-beta", "10.0.1"},
+		{"unknown", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ParseVersion(tt.output); got != tt.want {
+			t.Errorf("ParseVersion(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestVersionChanged(t *testing.T) {
+	tests := []struct {
+		recorded, current string
+		want              bool
+	}{
+		{"2.0.14", "2.0.15", true},
+		{"2.0.14", "2.0.14", false},
+		{"", "2.0.14", false},
+		{"2.0.14", "", false},
+	}
+	for _, tt := range tests {
+		if got := VersionChanged(tt.recorded, tt.current); got != tt.want {
+			t.Errorf("VersionChanged(%q, %q) = %v, want %v", tt.recorded, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestClaudeVersion_NoticesUpgrade(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	script := filepath.Join(dir, "claude")
+	write := func(version string, modTime time.Time) {
+		if err := os.WriteFile(script, []byte("#!/bin/sh\necho '"+version+" (Claude Code)'\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(script, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	write("2.0.14", start)
+	if got := ClaudeVersion(); got != "2.0.14" {
+		t.Fatalf("ClaudeVersion() = %q, want 2.0.14", got)
+	}
+	write("2.0.15", start.Add(time.Minute))
+	if got := ClaudeVersion(); got != "2.0.15" {
+		t.Errorf("ClaudeVersion() = %q after an upgrade, want 2.0.15", got)
+	}
+}
+
+func TestClaudeVersion_NotInstalled(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	if got := ClaudeVersion(); got != "" {
+		t.Errorf("ClaudeVersion() = %q without claude in PATH, want empty", got)
+	}
+}
@@ -244,3 +244,9 @@ func WriteText(text string) error {
 	log.Debug("wrote text to clipboard", "bytes", len(text))
 	return nil
 }
+
+// nativeAvailable reports whether this machine has a clipboard to write to,
+// which on macOS it always does.
+func nativeAvailable() bool {
+	return true
+}
@@ -100,3 +100,9 @@ func WriteText(text string) error {
 	log.Debug("wrote text", "bytes", len(text))
 	return nil
 }
+
+// nativeAvailable reports whether this machine has a clipboard to write to,
+// which on Linux needs a running X11 or Wayland session.
+func nativeAvailable() bool {
+	return Init() == nil
+}
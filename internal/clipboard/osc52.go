@@ -0,0 +1,112 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Mechanism names how copied text reaches the clipboard.
+type Mechanism string
+
+const (
+	MechanismNative Mechanism = "native clipboard" // The clipboard of the machine plural runs on
+	MechanismOSC52  Mechanism = "OSC 52"           // An escape sequence asking the terminal to set its clipboard
+)
+
+// OSC52MaxPayload is the largest base64 payload many terminals accept in one
+// OSC 52 sequence (hterm and several xterm builds cap it at about 100KB).
+const OSC52MaxPayload = 100_000
+
+// screenChunkSize is the most of a sequence GNU screen passes through in one
+// DCS string; it drops longer ones.
+const screenChunkSize = 76
+
+var (
+	preferenceMu sync.RWMutex
+	preference   string
+)
+
+// SetPreference sets the mechanism copies use: "native", "osc52", or "auto"
+// (or "") to pick one per copy.
+func SetPreference(p string) {
+	preferenceMu.Lock()
+	defer preferenceMu.Unlock()
+	preference = p
+}
+
+// Choose returns the mechanism to copy with: the configured preference if there
+// is one, then the native clipboard if this machine has one, then OSC 52. Over
+// SSH the native clipboard belongs to the remote machine, so OSC 52 is used.
+func Choose() Mechanism {
+	preferenceMu.RLock()
+	p := preference
+	preferenceMu.RUnlock()
+	remote := os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != ""
+	return chooseMechanism(p, remote, remote || nativeAvailable())
+}
+
+// chooseMechanism picks a mechanism from the preference, whether the session is
+// remote, and whether the native clipboard is available. nativeOK is ignored for
+// remote sessions, so callers need not probe the native clipboard for them.
+func chooseMechanism(preference string, remote, nativeOK bool) Mechanism {
+	switch {
+	case preference == "native":
+		return MechanismNative
+	case preference == "osc52":
+		return MechanismOSC52
+	case !remote && nativeOK:
+		return MechanismNative
+	default:
+		return MechanismOSC52
+	}
+}
+
+// OSC52Sequence returns the escape sequence that sets the system clipboard to
+// text. The text is base64-encoded as UTF-8, so multibyte characters survive
+// intact. With chunkSize > 0 the sequence is split into DCS passthrough strings
+// of at most chunkSize payload bytes each, for terminal multiplexers that cap the
+// strings they pass on; the outer terminal receives the whole sequence.
+func OSC52Sequence(text string, chunkSize int) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	if chunkSize <= 0 {
+		return seq
+	}
+
+	var sb strings.Builder
+	for len(seq) > 0 {
+		n := min(chunkSize, len(seq))
+		sb.WriteString("\x1bP")
+		sb.WriteString(seq[:n])
+		sb.WriteString("\x1b\\")
+		seq = seq[n:]
+	}
+	return sb.String()
+}
+
+// OSC52TooLarge reports whether text is too large for some terminals to accept over OSC 52.
+func OSC52TooLarge(text string) bool {
+	return base64.StdEncoding.EncodedLen(len(text)) > OSC52MaxPayload
+}
+
+// OSC52 returns the escape sequence that sets the system clipboard to text in
+// the current terminal, chunked when running inside GNU screen and passed
+// through to the outer terminal when running inside tmux.
+func OSC52(text string) string {
+	if os.Getenv("TMUX") != "" {
+		return TmuxPassthrough(OSC52Sequence(text, 0))
+	}
+	chunkSize := 0
+	if os.Getenv("STY") != "" {
+		chunkSize = screenChunkSize
+	}
+	return OSC52Sequence(text, chunkSize)
+}
+
+// TmuxPassthrough wraps seq in a DCS string tmux passes on to the terminal it
+// runs in, instead of interpreting it. The escapes inside are doubled, as tmux
+// requires.
+func TmuxPassthrough(seq string) string {
+	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
@@ -0,0 +1,123 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// decodeOSC52 returns the text an OSC 52 sequence sets the clipboard to.
+func decodeOSC52(t *testing.T, seq string) string {
+	t.Helper()
+	if !strings.HasPrefix(seq, "\x1b]52;c;") || !strings.HasSuffix(seq, "\x07") {
+		t.Fatalf("not an OSC 52 sequence: %q", seq[:min(len(seq), 40)])
+	}
+	payload := strings.TrimSuffix(strings.TrimPrefix(seq, "\x1b]52;c;"), "\x07")
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		t.Fatalf("invalid base64 payload: %v", err)
+	}
+	return string(data)
+}
+
+func TestOSC52Sequence(t *testing.T) {
+	large := strings.Repeat("héllo wörld ✓ ", 10_000) // >100KB, with multibyte characters
+	if len(large) <= 100_000 {
+		t.Fatalf("test text is only %d bytes", len(large))
+	}
+
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"empty", ""},
+		{"small", "git push -u origin feature"},
+		{"multibyte", "日本語 🎉 naïve"},
+		{"large", large},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq := OSC52Sequence(tt.text, 0)
+			if got := decodeOSC52(t, seq); got != tt.text {
+				t.Errorf("sequence decodes to %d bytes, want %d", len(got), len(tt.text))
+			}
+		})
+	}
+
+	if got := OSC52Sequence("", 0); got != "\x1b]52;c;\x07" {
+		t.Errorf("OSC52Sequence(empty) = %q", got)
+	}
+	if got := OSC52Sequence("hi", 0); got != "\x1b]52;c;aGk=\x07" {
+		t.Errorf("OSC52Sequence(hi) = %q", got)
+	}
+}
+
+func TestOSC52Sequence_Chunked(t *testing.T) {
+	large := strings.Repeat("日本語 🎉 ", 10_000)
+	for _, text := range []string{"", "small", large} {
+		chunked := OSC52Sequence(text, screenChunkSize)
+
+		var whole strings.Builder
+		for piece := range strings.SplitSeq(chunked, "\x1b\\") {
+			if piece == "" {
+				continue
+			}
+			if !strings.HasPrefix(piece, "\x1bP") {
+				t.Fatalf("chunk is not a DCS string: %q", piece)
+			}
+			piece = strings.TrimPrefix(piece, "\x1bP")
+			if len(piece) > screenChunkSize {
+				t.Fatalf("chunk of %d bytes exceeds %d", len(piece), screenChunkSize)
+			}
+			whole.WriteString(piece)
+		}
+		if whole.String() != OSC52Sequence(text, 0) {
+			t.Errorf("chunks of %d-byte text do not reassemble into the sequence", len(text))
+		}
+	}
+}
+
+func TestChooseMechanism(t *testing.T) {
+	tests := []struct {
+		name       string
+		preference string
+		remote     bool
+		nativeOK   bool
+		want       Mechanism
+	}{
+		{"auto with native clipboard", "auto", false, true, MechanismNative},
+		{"unset with native clipboard", "", false, true, MechanismNative},
+		{"auto without native clipboard", "auto", false, false, MechanismOSC52},
+		{"auto over SSH", "auto", true, true, MechanismOSC52},
+		{"prefer OSC 52", "osc52", false, true, MechanismOSC52},
+		{"prefer native over SSH", "native", true, false, MechanismNative},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chooseMechanism(tt.preference, tt.remote, tt.nativeOK); got != tt.want {
+				t.Errorf("chooseMechanism() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSC52TooLarge(t *testing.T) {
+	if OSC52TooLarge("small") {
+		t.Error("expected small text to fit")
+	}
+	if !OSC52TooLarge(strings.Repeat("x", 80_000)) {
+		t.Error("expected text encoding to over 100KB not to fit")
+	}
+}
+
+func TestTmuxPassthrough(t *testing.T) {
+	if got := TmuxPassthrough(OSC52Sequence("hi", 0)); got != "\x1bPtmux;\x1b\x1b]52;c;aGk=\x07\x1b\\" {
+		t.Errorf("TmuxPassthrough() = %q", got)
+	}
+
+	t.Setenv("STY", "")
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	if got := OSC52("hi"); !strings.HasPrefix(got, "\x1bPtmux;") {
+		t.Errorf("expected the sequence passed through tmux, got %q", got)
+	}
+}
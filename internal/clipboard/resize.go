@@ -0,0 +1,136 @@
+package clipboard
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+)
+
+// fitJPEGQuality is the JPEG quality used when re-encoding to fit a size limit.
+const fitJPEGQuality = 85
+
+// fitMinDimension is the smallest width or height Fit downscales to.
+const fitMinDimension = 64
+
+// fitMaxAttempts bounds how many downscaling steps Fit tries.
+const fitMaxAttempts = 8
+
+// Fit returns the image re-encoded, and downscaled preserving aspect ratio if
+// needed, so its data is at most maxBytes. PNG is preferred since it keeps text
+// in screenshots sharp; JPEG is tried at each scale when PNG is too large.
+// ok is false when no attempt fits, in which case the smallest attempt is returned.
+// An image already within the limit is returned unchanged.
+func (img *ImageData) Fit(maxBytes int) (fitted *ImageData, ok bool, err error) {
+	if len(img.Data) <= maxBytes {
+		return img, true, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	best := img
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	for attempt := 0; attempt < fitMaxAttempts; attempt++ {
+		scaled := src
+		if attempt > 0 {
+			// Size scales roughly with pixel count, so shrink each side by the square
+			// root of how far over the limit the last attempt was, with some headroom.
+			factor := math.Sqrt(float64(maxBytes)/float64(len(best.Data))) * 0.9
+			factor = min(factor, 0.9)
+			width = max(int(float64(width)*factor), min(fitMinDimension, width))
+			height = max(int(float64(height)*factor), min(fitMinDimension, height))
+			scaled = scale(src, width, height)
+		}
+
+		encoders := []func(image.Image) (*ImageData, error){encodePNG, encodeJPEG}
+		if attempt == 0 && img.MediaType == "image/png" {
+			encoders = encoders[1:] // Re-encoding the original PNG would not shrink it
+		}
+		for _, encode := range encoders {
+			candidate, err := encode(scaled)
+			if err != nil {
+				return nil, false, err
+			}
+			if len(candidate.Data) < len(best.Data) {
+				best = candidate
+			}
+			if len(candidate.Data) <= maxBytes {
+				return candidate, true, nil
+			}
+		}
+
+		if width <= fitMinDimension || height <= fitMinDimension {
+			break
+		}
+	}
+	return best, false, nil
+}
+
+// encodePNG encodes an image as PNG.
+func encodePNG(src image.Image) (*ImageData, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		return nil, fmt.Errorf("failed to encode image as PNG: %w", err)
+	}
+	b := src.Bounds()
+	return &ImageData{Data: buf.Bytes(), MediaType: "image/png", Width: b.Dx(), Height: b.Dy()}, nil
+}
+
+// encodeJPEG encodes an image as JPEG, flattening transparency onto white.
+func encodeJPEG(src image.Image) (*ImageData, error) {
+	b := src.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, b.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: fitJPEGQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image as JPEG: %w", err)
+	}
+	return &ImageData{Data: buf.Bytes(), MediaType: "image/jpeg", Width: b.Dx(), Height: b.Dy()}, nil
+}
+
+// scale resizes an image to width x height by averaging the source pixels that
+// fall in each destination pixel (a box filter), which suits downscaling.
+func scale(src image.Image, width, height int) *image.RGBA {
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcW, srcH := b.Dx(), b.Dy()
+	for y := range height {
+		y0 := y * srcH / height
+		y1 := max((y+1)*srcH/height, y0+1)
+		for x := range width {
+			x0 := x * srcW / width
+			x1 := max((x+1)*srcW/width, x0+1)
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					bl += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(bl / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,101 @@
+package clipboard
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+)
+
+// noisyPNG returns a PNG of random pixels, which compresses poorly.
+func noisyPNG(t *testing.T, width, height int) *ImageData {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(rng.Intn(256))
+	}
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 255
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return &ImageData{Data: buf.Bytes(), MediaType: "image/png", Width: width, Height: height}
+}
+
+func TestFit_WithinLimitUnchanged(t *testing.T) {
+	img := noisyPNG(t, 32, 32)
+	fitted, ok, err := img.Fit(len(img.Data))
+	if err != nil || !ok {
+		t.Fatalf("Fit = ok %v, err %v", ok, err)
+	}
+	if fitted != img {
+		t.Error("an image within the limit should be returned unchanged")
+	}
+}
+
+func TestFit_DownscalesPreservingAspectRatio(t *testing.T) {
+	img := noisyPNG(t, 800, 400)
+	limit := len(img.Data) / 10
+
+	fitted, ok, err := img.Fit(limit)
+	if err != nil {
+		t.Fatalf("Fit: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected image to fit in %d bytes, smallest was %d", limit, len(fitted.Data))
+	}
+	if len(fitted.Data) > limit {
+		t.Errorf("fitted image is %d bytes, limit %d", len(fitted.Data), limit)
+	}
+	if fitted.Width >= 800 && fitted.MediaType == "image/png" {
+		t.Error("expected the image to be downscaled or re-encoded")
+	}
+	if ratio := float64(fitted.Width) / float64(fitted.Height); ratio < 1.9 || ratio > 2.1 {
+		t.Errorf("aspect ratio %dx%d not preserved", fitted.Width, fitted.Height)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(fitted.Data))
+	if err != nil {
+		t.Fatalf("fitted data does not decode: %v", err)
+	}
+	if b := decoded.Bounds(); b.Dx() != fitted.Width || b.Dy() != fitted.Height {
+		t.Errorf("decoded %dx%d, ImageData says %dx%d", b.Dx(), b.Dy(), fitted.Width, fitted.Height)
+	}
+}
+
+func TestFit_ImpossibleLimit(t *testing.T) {
+	img := noisyPNG(t, 200, 200)
+	fitted, ok, err := img.Fit(10)
+	if err != nil {
+		t.Fatalf("Fit: %v", err)
+	}
+	if ok {
+		t.Error("a 10 byte limit should not be reachable")
+	}
+	if fitted == nil || len(fitted.Data) >= len(img.Data) {
+		t.Error("expected the smallest attempt to be returned")
+	}
+}
+
+func TestFit_InvalidData(t *testing.T) {
+	img := &ImageData{Data: []byte("not an image"), MediaType: "image/png"}
+	if _, _, err := img.Fit(1); err == nil {
+		t.Error("expected error for undecodable data")
+	}
+}
+
+func TestScale_AveragesPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, color.RGBA{R: 200, A: 255})
+	src.Set(1, 0, color.RGBA{R: 100, A: 255})
+
+	dst := scale(src, 1, 1)
+	if got := dst.RGBAAt(0, 0); got.R != 150 || got.A != 255 {
+		t.Errorf("scaled pixel = %+v, want R=150", got)
+	}
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// BundleSchemaVersion is the version of the export bundle format. It changes
+// when a field is removed or changes meaning; new fields may appear within a
+// version.
+const BundleSchemaVersion = 1
+
+// bundleExcludedKeys are config keys left out of bundles and ignored in them:
+// they record this machine's state, a branch checked out for preview, rather
+// than settings.
+var bundleExcludedKeys = []string{"preview_session_id", "preview_previous_branch", "preview_repo_path"}
+
+// Bundle is a portable backup of the config: repos, their settings, global
+// settings, and sessions, along with each session's message history if asked
+// for. No credentials are in it, as none are kept in the config: API keys and
+// tokens stay in the environment, the keychain, and the gh CLI.
+type Bundle struct {
+	SchemaVersion int                  `json:"schema_version"`
+	PluralVersion string               `json:"plural_version,omitempty"`
+	ExportedAt    time.Time            `json:"exported_at"`
+	Config        json.RawMessage      `json:"config"`             // The config file's contents, less bundleExcludedKeys
+	Messages      map[string][]Message `json:"messages,omitempty"` // Message history by session ID (only when exported with history)
+}
+
+// Export returns a bundle of the config. With includeHistory, it has the
+// message history of every session as well.
+func (c *Config) Export(includeHistory bool) (*Bundle, error) {
+	c.mu.RLock()
+	data, err := json.Marshal(c)
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range bundleExcludedKeys {
+		delete(fields, key)
+	}
+	if data, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		SchemaVersion: BundleSchemaVersion,
+		ExportedAt:    time.Now().UTC(),
+		Config:        data,
+	}
+	if includeHistory {
+		bundle.Messages = make(map[string][]Message)
+		for _, sess := range c.GetSessions() {
+			messages, err := LoadSessionMessages(sess.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load messages of session %s: %w", sess.ID, err)
+			}
+			if len(messages) > 0 {
+				bundle.Messages[sess.ID] = messages
+			}
+		}
+	}
+	return bundle, nil
+}
+
+// ParseBundle reads a bundle written by Export, checking that it is one this
+// version of Plural understands.
+func ParseBundle(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("not a Plural export: %w", err)
+	}
+	switch {
+	case bundle.SchemaVersion == 0 || bundle.Config == nil:
+		return nil, errors.New("not a Plural export: no schema_version or config")
+	case bundle.SchemaVersion > BundleSchemaVersion:
+		return nil, fmt.Errorf("export has schema version %d, newer than this version of Plural reads (%d); upgrade Plural to import it", bundle.SchemaVersion, BundleSchemaVersion)
+	}
+	if errs, _ := checkStructure(bundle.Config); len(errs) > 0 {
+		return nil, &ValidationError{File: "exported config", Problems: errs}
+	}
+	return &bundle, nil
+}
+
+// ImportResult lists what MergeBundle changed.
+type ImportResult struct {
+	ReposAdded  []string // Repos registered that weren't before
+	Added       []string // IDs of sessions added
+	Overwritten []string // IDs of sessions already here, replaced by the bundle's
+	Skipped     []string // IDs of sessions already here, left as they were
+}
+
+// Imported returns the IDs of the sessions taken from the bundle.
+func (r ImportResult) Imported() []string {
+	return slices.Concat(r.Added, r.Overwritten)
+}
+
+// MergeBundle returns a copy of c with the bundle's repos, settings, and
+// sessions added, saving to the same file. Repos are registered if they
+// aren't already. A setting or per-repo setting already set in c, or a
+// session with the same ID, is kept unless overwrite is true, in which case
+// the bundle's replaces it. The result is checked as Load checks a config
+// file; c is left as it was.
+func (c *Config) MergeBundle(bundle *Bundle, overwrite bool) (*Config, ImportResult, error) {
+	var imported Config
+	if err := json.Unmarshal(bundle.Config, &imported); err != nil {
+		return nil, ImportResult{}, err
+	}
+	imported.ensureInitialized()
+	if err := imported.Validate(); err != nil {
+		return nil, ImportResult{}, err
+	}
+	// Session IDs name files and directories, so a crafted bundle could
+	// otherwise write outside them
+	for _, sess := range imported.Sessions {
+		if !IsSessionID(sess.ID) {
+			return nil, ImportResult{}, fmt.Errorf("session %q has an invalid ID", sess.ID)
+		}
+	}
+
+	// Settings are merged as JSON, so that every setting is covered without
+	// listing them
+	c.mu.RLock()
+	data, err := json.Marshal(c)
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, ImportResult{}, err
+	}
+	var fields, importedFields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, ImportResult{}, err
+	}
+	if err := json.Unmarshal(bundle.Config, &importedFields); err != nil {
+		return nil, ImportResult{}, err
+	}
+	for key, value := range importedFields {
+		if key == "repos" || key == "sessions" || slices.Contains(bundleExcludedKeys, key) {
+			continue
+		}
+		fields[key] = mergeSetting(fields[key], value, overwrite)
+	}
+	if data, err = json.Marshal(fields); err != nil {
+		return nil, ImportResult{}, err
+	}
+	merged := &Config{filePath: c.FilePath()}
+	if err := json.Unmarshal(data, merged); err != nil {
+		return nil, ImportResult{}, err
+	}
+	merged.ensureInitialized()
+
+	var result ImportResult
+	for _, repo := range imported.Repos {
+		if merged.AddRepo(repo) {
+			result.ReposAdded = append(result.ReposAdded, repo)
+		}
+	}
+	for _, sess := range imported.Sessions {
+		i := slices.IndexFunc(merged.Sessions, func(s Session) bool { return s.ID == sess.ID })
+		switch {
+		case i < 0:
+			merged.Sessions = append(merged.Sessions, sess)
+			result.Added = append(result.Added, sess.ID)
+		case overwrite:
+			merged.Sessions[i] = sess
+			result.Overwritten = append(result.Overwritten, sess.ID)
+		default:
+			result.Skipped = append(result.Skipped, sess.ID)
+		}
+	}
+
+	if err := merged.Validate(); err != nil {
+		return nil, ImportResult{}, err
+	}
+	return merged, result, nil
+}
+
+// mergeSetting returns the value of a setting after importing one: per-repo
+// settings (JSON objects) are merged repo by repo, and any other setting is
+// replaced. What is already set is kept unless overwrite is true.
+func mergeSetting(current, imported json.RawMessage, overwrite bool) json.RawMessage {
+	if current == nil {
+		return imported
+	}
+	var currentMap, importedMap map[string]json.RawMessage
+	if json.Unmarshal(current, &currentMap) == nil && json.Unmarshal(imported, &importedMap) == nil && currentMap != nil && importedMap != nil {
+		for key, value := range importedMap {
+			if _, ok := currentMap[key]; !ok || overwrite {
+				currentMap[key] = value
+			}
+		}
+		if data, err := json.Marshal(currentMap); err == nil {
+			return data
+		}
+	}
+	if overwrite {
+		return imported
+	}
+	return current
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhubert/plural/internal/paths"
+)
+
+// setTestHome points the data and config directories at a temporary home.
+func setTestHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+}
+
+// bundleSessionID is the ID of bundleTestConfig's session.
+const bundleSessionID = "6ba7b810-9dad-41d1-80b4-00c04fd430c8"
+
+func bundleTestConfig() *Config {
+	cfg := &Config{
+		Repos: []string{"/src/api"},
+		Sessions: []Session{
+			{ID: bundleSessionID, RepoPath: "/src/api", WorkTree: "/wt/" + bundleSessionID, Branch: "fix-login", Name: "api/fix-login", CreatedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)},
+		},
+		RepoLinearTeam:   map[string]string{"/src/api": "team-1"},
+		Theme:            "nord",
+		PreviewSessionID: bundleSessionID,
+		PreviewRepoPath:  "/src/api",
+	}
+	cfg.ensureInitialized()
+	return cfg
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	setTestHome(t)
+	src := bundleTestConfig()
+	if err := SaveSessionMessages(bundleSessionID, []Message{{Role: "user", Content: "hi"}}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := src.Export(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.Messages != nil {
+		t.Errorf("expected no history without includeHistory, got %v", bundle.Messages)
+	}
+	if strings.Contains(string(bundle.Config), "preview") {
+		t.Errorf("expected the preview state left out, got %s", bundle.Config)
+	}
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseBundle(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := &Config{}
+	dst.ensureInitialized()
+	merged, result, err := dst.MergeBundle(parsed, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(merged.Repos, []string{"/src/api"}) || !slices.Equal(result.ReposAdded, []string{"/src/api"}) {
+		t.Errorf("expected the repo registered, got %v (added %v)", merged.Repos, result.ReposAdded)
+	}
+	if sess := merged.GetSession(bundleSessionID); sess == nil || sess.Branch != "fix-login" || !sess.CreatedAt.Equal(src.Sessions[0].CreatedAt) {
+		t.Errorf("expected session s1 imported, got %+v", sess)
+	}
+	if merged.GetTheme() != "nord" || merged.GetLinearTeam("/src/api") != "team-1" {
+		t.Errorf("expected settings imported, got theme %q and team %q", merged.GetTheme(), merged.GetLinearTeam("/src/api"))
+	}
+	if merged.IsPreviewActive() {
+		t.Error("expected no preview imported")
+	}
+}
+
+func TestExport_IncludeHistory(t *testing.T) {
+	setTestHome(t)
+	src := bundleTestConfig()
+	messages := []Message{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}}
+	if err := SaveSessionMessages(bundleSessionID, messages, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := src.Export(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bundle.Messages[bundleSessionID], messages) {
+		t.Errorf("expected the history of s1, got %v", bundle.Messages)
+	}
+}
+
+func TestMergeBundle_Conflicts(t *testing.T) {
+	bundle, err := bundleTestConfig().Export(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := &Config{
+		Repos: []string{"/src/api", "/src/web"},
+		Sessions: []Session{
+			{ID: bundleSessionID, RepoPath: "/src/api", WorkTree: "/wt/" + bundleSessionID, Branch: "local-work", Name: "api/local-work"},
+		},
+		RepoLinearTeam: map[string]string{"/src/api": "team-local", "/src/web": "team-web"},
+		Theme:          "dracula",
+	}
+	dst.ensureInitialized()
+
+	t.Run("skip", func(t *testing.T) {
+		merged, result, err := dst.MergeBundle(bundle, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(result.Skipped, []string{bundleSessionID}) || len(result.Imported()) != 0 || len(result.ReposAdded) != 0 {
+			t.Errorf("expected s1 skipped and nothing added, got %+v", result)
+		}
+		if merged.GetSession(bundleSessionID).Branch != "local-work" || merged.GetTheme() != "dracula" || merged.GetLinearTeam("/src/api") != "team-local" {
+			t.Error("expected what is already here kept")
+		}
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		merged, result, err := dst.MergeBundle(bundle, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(result.Overwritten, []string{bundleSessionID}) {
+			t.Errorf("expected s1 overwritten, got %+v", result)
+		}
+		if merged.GetSession(bundleSessionID).Branch != "fix-login" || merged.GetTheme() != "nord" || merged.GetLinearTeam("/src/api") != "team-1" {
+			t.Error("expected the bundle's session and settings")
+		}
+		if merged.GetLinearTeam("/src/web") != "team-web" || len(merged.Repos) != 2 {
+			t.Error("expected settings of repos not in the bundle kept")
+		}
+	})
+
+	if dst.GetSession(bundleSessionID).Branch != "local-work" || dst.GetTheme() != "dracula" {
+		t.Error("expected the config merged into left as it was")
+	}
+}
+
+func TestParseBundle_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not JSON", "nope", "not a Plural export"},
+		{"no schema version", `{"config": {}}`, "not a Plural export"},
+		{"newer schema", `{"schema_version": 99, "config": {}}`, "upgrade Plural"},
+		{"bad config", `{"schema_version": 1, "config": {"repos": "x"}}`, "repos"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBundle([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseBundle(%q) error = %v, want one mentioning %q", tt.data, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeBundle_RejectsInvalidSessionIDs(t *testing.T) {
+	setTestHome(t)
+	src := bundleTestConfig()
+	src.Sessions[0].ID = "../../.ssh/authorized_keys"
+	bundle, err := src.Export(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := &Config{}
+	dst.ensureInitialized()
+	if _, _, err := dst.MergeBundle(bundle, false); err == nil || !strings.Contains(err.Error(), "invalid ID") {
+		t.Errorf("MergeBundle() error = %v, want one rejecting the session ID", err)
+	}
+}
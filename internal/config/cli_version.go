@@ -0,0 +1,42 @@
+package config
+
+// What to do when a session last run with another Claude CLI version resumes
+const (
+	CLIVersionChangeAsk    = "ask"    // Ask before resuming, offering to continue anyway
+	CLIVersionChangeWarn   = "warn"   // Resume with a warning
+	CLIVersionChangeIgnore = "ignore" // Resume without a word
+)
+
+// GetCLIVersionChange returns what to do when a session resumes with another
+// Claude CLI version than it last ran with, defaulting to "ask".
+func (c *Config) GetCLIVersionChange() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.CLIVersionChange == "" {
+		return CLIVersionChangeAsk
+	}
+	return c.CLIVersionChange
+}
+
+// SetCLIVersionChange sets what to do when a session resumes with another Claude
+// CLI version (ask, warn, or ignore).
+func (c *Config) SetCLIVersionChange(behavior string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CLIVersionChange = behavior
+}
+
+// SetSessionCLIVersion records the Claude CLI version a session's conversation
+// runs with.
+func (c *Config) SetSessionCLIVersion(sessionID, version string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].CLIVersion = version
+			return true
+		}
+	}
+	return false
+}
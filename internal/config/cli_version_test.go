@@ -0,0 +1,33 @@
+package config
+
+import "testing"
+
+func TestConfig_CLIVersionChange(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetCLIVersionChange(); got != CLIVersionChangeAsk {
+		t.Errorf("GetCLIVersionChange() = %q, want %q by default", got, CLIVersionChangeAsk)
+	}
+	cfg.SetCLIVersionChange(CLIVersionChangeIgnore)
+	if got := cfg.GetCLIVersionChange(); got != CLIVersionChangeIgnore {
+		t.Errorf("GetCLIVersionChange() = %q, want %q", got, CLIVersionChangeIgnore)
+	}
+
+	cfg.CLIVersionChange = "sometimes"
+	problems := problemStrings(cfg.semanticProblems())
+	if len(problems) != 1 || problems[0] != `cli_version_change: unknown value "sometimes"; expected one of ask, warn, ignore` {
+		t.Errorf("expected an unknown behavior to be reported, got %q", problems)
+	}
+}
+
+func TestConfig_SetSessionCLIVersion(t *testing.T) {
+	cfg := &Config{Sessions: []Session{{ID: "s1"}}}
+	if !cfg.SetSessionCLIVersion("s1", "2.0.14") {
+		t.Fatal("expected the session found")
+	}
+	if got := cfg.GetSession("s1").CLIVersion; got != "2.0.14" {
+		t.Errorf("CLIVersion = %q, want 2.0.14", got)
+	}
+	if cfg.SetSessionCLIVersion("missing", "2.0.14") {
+		t.Error("expected no session found for an unknown ID")
+	}
+}
@@ -5,29 +5,73 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/workpool"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Repos             []string               `json:"repos"`
-	Sessions          []Session              `json:"sessions"`
-	MCPServers        []MCPServer            `json:"mcp_servers,omitempty"`          // Global MCP servers
-	RepoMCP           map[string][]MCPServer `json:"repo_mcp,omitempty"`             // Per-repo MCP servers
-	AllowedTools      []string               `json:"allowed_tools,omitempty"`        // Global allowed tools
-	RepoAllowedTools  map[string][]string    `json:"repo_allowed_tools,omitempty"`   // Per-repo allowed tools
-	RepoSquashOnMerge map[string]bool        `json:"repo_squash_on_merge,omitempty"` // Per-repo squash-on-merge setting
-	RepoAsanaProject  map[string]string      `json:"repo_asana_project,omitempty"`   // Per-repo Asana project GID mapping
-	RepoLinearTeam      map[string]string `json:"repo_linear_team,omitempty"`       // Per-repo Linear team ID mapping
-	RepoContainerImage map[string]string `json:"repo_container_image,omitempty"`   // Per-repo container image mapping
-
-	WelcomeShown         bool   `json:"welcome_shown,omitempty"`         // Whether welcome modal has been shown
-	LastSeenVersion      string `json:"last_seen_version,omitempty"`     // Last version user has seen changelog for
-	Theme                string `json:"theme,omitempty"`                 // UI theme name (e.g., "dark-purple", "nord")
-	DefaultBranchPrefix  string `json:"default_branch_prefix,omitempty"` // Prefix for auto-generated branch names (e.g., "zhubert/")
-	NotificationsEnabled bool   `json:"notifications_enabled,omitempty"` // Desktop notifications when Claude completes
+	Repos              []string                  `json:"repos"`
+	Sessions           []Session                 `json:"sessions"`
+	MCPServers         []MCPServer               `json:"mcp_servers,omitempty"`          // Global MCP servers
+	RepoMCP            map[string][]MCPServer    `json:"repo_mcp,omitempty"`             // Per-repo MCP servers
+	AllowedTools       []string                  `json:"allowed_tools,omitempty"`        // Global allowed tools
+	RepoAllowedTools   map[string][]string       `json:"repo_allowed_tools,omitempty"`   // Per-repo allowed tools
+	RepoDeniedTools    map[string][]string       `json:"repo_denied_tools,omitempty"`    // Per-repo denied tools, which win over allowed ones
+	RepoSquashOnMerge  map[string]bool           `json:"repo_squash_on_merge,omitempty"` // Per-repo squash-on-merge setting
+	RepoAsanaProject   map[string]string         `json:"repo_asana_project,omitempty"`   // Per-repo Asana project GID mapping
+	RepoLinearTeam     map[string]string         `json:"repo_linear_team,omitempty"`     // Per-repo Linear team ID mapping
+	RepoContainerImage map[string]string         `json:"repo_container_image,omitempty"` // Per-repo container image mapping
+	RepoQuestionRules  map[string][]QuestionRule `json:"repo_question_rules,omitempty"`  // Per-repo question auto-answer rules
+	RepoPlanApproval   map[string]PlanApprovalCriteria `json:"repo_plan_approval,omitempty"` // Per-repo criteria for auto-approving plans
+	RepoPRTemplate     map[string]PRTemplate           `json:"repo_pr_template,omitempty"`   // Per-repo PR template settings for generated PR descriptions
+	RepoLFSMode        map[string]string               `json:"repo_lfs_mode,omitempty"`      // Per-repo LFS checkout mode for new worktrees: "full" or "skip"
+	RepoWorktreeDir    map[string]string               `json:"repo_worktree_dir,omitempty"`  // Per-repo directory new worktrees are created in, overriding worktree_dir
+	RepoHooks          map[string]RepoHooks            `json:"repo_hooks,omitempty"`         // Per-repo commands run after merging or opening a PR
+	RepoProvenance     map[string]RepoProvenance       `json:"repo_provenance,omitempty"`    // Per-repo trailers and git notes recording which session produced a merge or PR
+	RepoDestructiveCommands map[string]DestructiveCommandRules `json:"repo_destructive_commands,omitempty"` // Per-repo patterns adding to or overriding which Bash commands need typing "allow" to run
+
+	WelcomeShown           bool   `json:"welcome_shown,omitempty"`              // Whether welcome modal has been shown
+	LastSeenVersion        string `json:"last_seen_version,omitempty"`          // Last version user has seen changelog for
+	NameRepairsSkipped     []string `json:"name_repairs_skipped,omitempty"`   // IDs of sessions the user chose not to rename when offered at startup
+	Theme                  string `json:"theme,omitempty"`                      // UI theme name (e.g., "dark-purple", "nord")
+	ThemeDark              string `json:"theme_dark,omitempty"`                 // Theme used while the terminal's background is dark (switches with it when this or theme_light is set and theme is not)
+	ThemeLight             string `json:"theme_light,omitempty"`                // Theme used while the terminal's background is light
+	SyntaxStyle            string `json:"syntax_style,omitempty"`               // Chroma style for code blocks instead of the theme's (e.g., a custom style's name)
+	CustomSyntaxStyle      string `json:"custom_syntax_style,omitempty"`        // Path to a chroma XML style file to load at startup
+	DefaultBranchPrefix    string `json:"default_branch_prefix,omitempty"`      // Prefix for auto-generated branch names (e.g., "zhubert/")
+	WorktreeDir            string `json:"worktree_dir,omitempty"`               // Directory new session worktrees are created in, e.g. "~/worktrees/{repo}/{session}" (default the data directory's worktrees)
+	NotificationsEnabled   bool   `json:"notifications_enabled,omitempty"`      // Desktop notifications when Claude completes
+	CompactToolUses        bool   `json:"compact_tool_uses,omitempty"`          // Collapse bursts of tool-use lines into one summary line
+	ToolCategoryColors     *bool  `json:"tool_category_colors,omitempty"`       // Color tool-use markers by kind of tool: reading, changing files, or running commands (default true)
+	MaxChatWidth           int    `json:"max_chat_width,omitempty"`             // Columns chat messages are wrapped to at most, centered in wider panels (default 120, negative disables)
+	PasteCleaning          string `json:"paste_cleaning,omitempty"`             // Clean pasted terminal output: "ask", "always", or "never" (default "ask")
+	PasteSanitize          *bool  `json:"paste_sanitize,omitempty"`             // Normalize line endings and trim trailing whitespace on paste (default true)
+	Clipboard              string `json:"clipboard,omitempty"`                  // How copies reach the clipboard: "auto", "native", or "osc52" (default "auto")
+	FocusInputOnNewSession *bool  `json:"focus_input_on_new_session,omitempty"` // Focus the chat input after creating a session (default true)
+	MessageAutosaveSec     int    `json:"message_autosave_sec,omitempty"`       // Seconds between message history autosaves (default 30, negative disables)
+	MaxImageKB             int    `json:"max_image_kb,omitempty"`               // Downscale attached images larger than this many KB (0 = no limit)
+	ResumeLastSession      bool   `json:"resume_last_session,omitempty"`        // Open the most recently active session on startup
+	NewSessionFromIssue    bool   `json:"new_session_from_issue,omitempty"`     // Start new sessions (n) in the issue picker instead of the blank-branch form
+	PauseBackgroundActivity bool `json:"pause_background_activity,omitempty"` // Start with periodic git status and PR polling paused (toggled with G)
+	FocusMinutes           int    `json:"focus_minutes,omitempty"`              // Leave focus mode automatically after this many minutes (0 = stay until left)
+	CILogLines             int    `json:"ci_log_lines,omitempty"`               // Lines kept from the end of each failed CI job's log (default 150)
+	CommandOutputLines     int    `json:"command_output_lines,omitempty"`       // Lines kept from the end of /run output (default 200)
+	CommandOutputFile      string `json:"command_output_file,omitempty"`        // Scrollback file /run inserts the end of when given no command
+	UpdateCheck            *bool  `json:"update_check,omitempty"`               // Check GitHub for a newer release once a day (default true)
+	QuitKeyBehavior        string `json:"quit_key_behavior,omitempty"`          // What "q" does: "sidebar-only", "confirm", "disabled", or "ctrl-c-only" (default "sidebar-only")
+	Snippets               []Snippet `json:"snippets,omitempty"`                // Named prompt fragments for quick insertion into the chat input
+	PromptTemplates        []PromptTemplate `json:"prompt_templates,omitempty"` // Named reusable prompts whose {variables} are asked for on use
+	CompletionFlashMs      int    `json:"completion_flash_ms,omitempty"`        // Milliseconds the "Done" flash shows after a response (default 480, negative disables)
+	CompletionFlashStats   *bool  `json:"completion_flash_stats,omitempty"`     // Show token and timing stats in the "Done" flash (default true)
+	CompletionSound        string `json:"completion_sound,omitempty"`           // On a response in the open session: "bell" rings the terminal bell, anything else is a shell command to run (default none)
+	ThinkingEscalation     ThinkingEscalation `json:"thinking_escalation,omitzero"` // When the status line escalates during a long turn
+	InitialCommitMessage   string `json:"initial_commit_message,omitempty"`     // Message of the empty first commit offered for repos without commits (default "Initial commit")
+	CLIVersionChange       string `json:"cli_version_change,omitempty"`         // On resuming a session last run with another Claude CLI version: "ask", "warn", or "ignore" (default "ask")
 
 	// Automation settings
 	AutoMaxTurns          int    `json:"auto_max_turns,omitempty"`           // Max autonomous turns before stopping (default 50)
@@ -37,6 +81,7 @@ type Config struct {
 	AutoBroadcastPR       bool   `json:"auto_broadcast_pr,omitempty"`        // Auto-create PRs when all broadcast sessions complete
 	AutoMergeMethod       string `json:"auto_merge_method,omitempty"`        // Merge method: "rebase", "squash", or "merge" (default "rebase")
 	IssueMaxConcurrent    int    `json:"issue_max_concurrent,omitempty"`     // Max concurrent auto-sessions from issues (default 3)
+	MaxConcurrentJobs     int    `json:"max_concurrent_jobs,omitempty"`      // Git and gh commands run at once by operations over many sessions or repos (default 8)
 
 	// Preview state - tracks when a session's branch is checked out in the main repo
 	PreviewSessionID      string `json:"preview_session_id,omitempty"`      // Session ID currently being previewed (empty if none)
@@ -45,15 +90,22 @@ type Config struct {
 
 	mu       sync.RWMutex
 	filePath string
+	problems []Problem // Non-fatal problems found in the file when it was loaded
 }
 
-// Load reads the config from disk, or creates a new one if it doesn't exist
+// Load reads the config from disk, or creates a new one if it doesn't exist.
+// A file that cannot be loaded returns a *ValidationError listing every problem;
+// problems that still let it load are available from Problems.
 func Load() (*Config, error) {
 	path, err := paths.ConfigFilePath()
 	if err != nil {
 		return nil, err
 	}
+	return loadFrom(path)
+}
 
+// loadFrom reads the config from path, or creates a new one if it doesn't exist.
+func loadFrom(path string) (*Config, error) {
 	cfg := &Config{
 		Repos:            []string{},
 		Sessions:         []Session{},
@@ -72,8 +124,12 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	errs, warnings := checkStructure(data)
+	if len(errs) > 0 {
+		return nil, &ValidationError{File: path, Problems: errs}
+	}
 	if err := json.Unmarshal(data, cfg); err != nil {
-		return nil, err
+		return nil, &ValidationError{File: path, Problems: []Problem{{Message: err.Error()}}}
 	}
 
 	// Ensure slices and maps are initialized (not nil) after unmarshaling
@@ -85,6 +141,7 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	cfg.problems = append(warnings, cfg.semanticProblems()...)
 	return cfg, nil
 }
 
@@ -115,6 +172,9 @@ func (c *Config) ensureInitialized() {
 	if c.RepoAllowedTools == nil {
 		c.RepoAllowedTools = make(map[string][]string)
 	}
+	if c.RepoDeniedTools == nil {
+		c.RepoDeniedTools = make(map[string][]string)
+	}
 	if c.RepoSquashOnMerge == nil {
 		c.RepoSquashOnMerge = make(map[string]bool)
 	}
@@ -127,50 +187,89 @@ func (c *Config) ensureInitialized() {
 	if c.RepoContainerImage == nil {
 		c.RepoContainerImage = make(map[string]string)
 	}
+	if c.RepoQuestionRules == nil {
+		c.RepoQuestionRules = make(map[string][]QuestionRule)
+	}
+	if c.RepoPlanApproval == nil {
+		c.RepoPlanApproval = make(map[string]PlanApprovalCriteria)
+	}
+	if c.RepoPRTemplate == nil {
+		c.RepoPRTemplate = make(map[string]PRTemplate)
+	}
+	if c.RepoLFSMode == nil {
+		c.RepoLFSMode = make(map[string]string)
+	}
+	if c.RepoHooks == nil {
+		c.RepoHooks = make(map[string]RepoHooks)
+	}
+	if c.RepoProvenance == nil {
+		c.RepoProvenance = make(map[string]RepoProvenance)
+	}
+	if c.RepoDestructiveCommands == nil {
+		c.RepoDestructiveCommands = make(map[string]DestructiveCommandRules)
+	}
 }
 
 // Validate checks that the config is internally consistent.
 // This is a read-only operation - call ensureInitialized() first if needed.
+// Returns a *ValidationError listing every inconsistency found.
 func (c *Config) Validate() error {
+	if problems := c.consistencyProblems(); len(problems) > 0 {
+		return &ValidationError{File: c.FilePath(), Problems: problems}
+	}
+	return nil
+}
+
+// consistencyProblems returns the inconsistencies that prevent the config from
+// being used: sessions missing required fields or sharing an ID, and empty or
+// duplicate repos.
+func (c *Config) consistencyProblems() []Problem {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	var problems []Problem
+	add := func(path, format string, args ...any) {
+		problems = append(problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
 	// Check for duplicate session IDs
 	seenIDs := make(map[string]bool)
-	for _, sess := range c.Sessions {
+	for i, sess := range c.Sessions {
+		path := fmt.Sprintf("sessions[%d]", i)
 		if sess.ID == "" {
-			return fmt.Errorf("session with empty ID found")
-		}
-		if seenIDs[sess.ID] {
-			return fmt.Errorf("duplicate session ID: %s", sess.ID)
+			add(path+".id", "session with empty ID found")
+		} else if seenIDs[sess.ID] {
+			add(path+".id", "duplicate session ID: %s", sess.ID)
 		}
 		seenIDs[sess.ID] = true
 
 		// Validate session fields
 		if sess.RepoPath == "" {
-			return fmt.Errorf("session %s has empty repo path", sess.ID)
+			add(path+".repo_path", "session %s has empty repo path", sess.ID)
 		}
 		if sess.WorkTree == "" {
-			return fmt.Errorf("session %s has empty worktree path", sess.ID)
+			add(path+".worktree", "session %s has empty worktree path", sess.ID)
 		}
 		if sess.Branch == "" {
-			return fmt.Errorf("session %s has empty branch", sess.ID)
+			add(path+".branch", "session %s has empty branch", sess.ID)
 		}
 	}
 
 	// Check for duplicate repos (filesystem-aware: handles case, symlinks)
 	for i, repo := range c.Repos {
+		path := fmt.Sprintf("repos[%d]", i)
 		if repo == "" {
-			return fmt.Errorf("empty repo path found")
+			add(path, "empty repo path found")
+			continue
 		}
 		for j := i + 1; j < len(c.Repos); j++ {
 			if SamePath(repo, c.Repos[j]) {
-				return fmt.Errorf("duplicate repo: %s", repo)
+				add(fmt.Sprintf("repos[%d]", j), "duplicate repo: %s", repo)
 			}
 		}
 	}
 
-	return nil
+	return problems
 }
 
 // Save writes the config to disk
@@ -263,6 +362,26 @@ func (c *Config) MarkWelcomeShown() {
 	c.WelcomeShown = true
 }
 
+// IsNameRepairSkipped returns whether the user chose not to rename the session
+// when offered to repair its name
+func (c *Config) IsNameRepairSkipped(sessionID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Contains(c.NameRepairsSkipped, sessionID)
+}
+
+// SkipNameRepairs records that the user chose not to rename the sessions, so
+// they aren't offered again
+func (c *Config) SkipNameRepairs(sessionIDs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range sessionIDs {
+		if !slices.Contains(c.NameRepairsSkipped, id) {
+			c.NameRepairsSkipped = append(c.NameRepairsSkipped, id)
+		}
+	}
+}
+
 // GetLastSeenVersion returns the last version the user has seen
 func (c *Config) GetLastSeenVersion() string {
 	c.mu.RLock()
@@ -291,6 +410,35 @@ func (c *Config) SetTheme(theme string) {
 	c.Theme = theme
 }
 
+// GetBackgroundThemes returns the themes preferred while the terminal's
+// background is dark and light, "" for those not set.
+func (c *Config) GetBackgroundThemes() (dark, light string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ThemeDark, c.ThemeLight
+}
+
+// GetSyntaxStyle returns the chroma style for code blocks, or "" to use the theme's
+func (c *Config) GetSyntaxStyle() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.SyntaxStyle
+}
+
+// SetSyntaxStyle sets the chroma style for code blocks ("" for the theme's)
+func (c *Config) SetSyntaxStyle(style string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.SyntaxStyle = style
+}
+
+// GetCustomSyntaxStyle returns the path of the chroma XML style file to load, or ""
+func (c *Config) GetCustomSyntaxStyle() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CustomSyntaxStyle
+}
+
 // GetDefaultBranchPrefix returns the default branch prefix
 func (c *Config) GetDefaultBranchPrefix() string {
 	c.mu.RLock()
@@ -319,6 +467,311 @@ func (c *Config) SetNotificationsEnabled(enabled bool) {
 	c.NotificationsEnabled = enabled
 }
 
+// GetResumeLastSession returns whether the most recently active session is opened on startup
+func (c *Config) GetResumeLastSession() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ResumeLastSession
+}
+
+// SetResumeLastSession sets whether the most recently active session is opened on startup
+func (c *Config) SetResumeLastSession(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ResumeLastSession = enabled
+}
+
+// GetCompactToolUses returns whether tool-use bursts are rendered as summary lines
+func (c *Config) GetCompactToolUses() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CompactToolUses
+}
+
+// SetCompactToolUses sets whether tool-use bursts are rendered as summary lines
+func (c *Config) SetCompactToolUses(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CompactToolUses = enabled
+}
+
+// GetToolCategoryColors returns whether tool-use markers are colored by the
+// kind of tool. Defaults to true when unset.
+func (c *Config) GetToolCategoryColors() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ToolCategoryColors == nil || *c.ToolCategoryColors
+}
+
+// SetToolCategoryColors sets whether tool-use markers are colored by the kind of tool
+func (c *Config) SetToolCategoryColors(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ToolCategoryColors = &enabled
+}
+
+// GetMaxChatWidth returns how many columns chat messages are wrapped to at
+// most, defaulting to 120. Returns 0 when there is no cap (negative setting).
+func (c *Config) GetMaxChatWidth() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.MaxChatWidth < 0 {
+		return 0
+	}
+	if c.MaxChatWidth == 0 {
+		return 120
+	}
+	return c.MaxChatWidth
+}
+
+// SetMaxChatWidth sets how many columns chat messages are wrapped to at most
+func (c *Config) SetMaxChatWidth(width int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MaxChatWidth = width
+}
+
+// GetPasteSanitize returns whether pasted text has its line endings normalized and
+// trailing whitespace trimmed. Defaults to true when unset.
+func (c *Config) GetPasteSanitize() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PasteSanitize == nil || *c.PasteSanitize
+}
+
+// SetPasteSanitize sets whether pasted text is sanitized
+func (c *Config) SetPasteSanitize(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PasteSanitize = &enabled
+}
+
+// GetUpdateCheck returns whether Plural checks GitHub for a newer release.
+// Defaults to true when unset.
+func (c *Config) GetUpdateCheck() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.UpdateCheck == nil || *c.UpdateCheck
+}
+
+// GetCompletionFlashMs returns how many milliseconds the "Done" flash shows after
+// a response, defaulting to 480. Returns 0 when the flash is disabled (negative setting).
+func (c *Config) GetCompletionFlashMs() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.CompletionFlashMs < 0 {
+		return 0
+	}
+	if c.CompletionFlashMs == 0 {
+		return 480
+	}
+	return c.CompletionFlashMs
+}
+
+// SetCompletionFlashMs sets how many milliseconds the "Done" flash shows
+func (c *Config) SetCompletionFlashMs(ms int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CompletionFlashMs = ms
+}
+
+// GetCompletionFlashStats returns whether the "Done" flash shows token and
+// timing stats. Defaults to true when unset.
+func (c *Config) GetCompletionFlashStats() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CompletionFlashStats == nil || *c.CompletionFlashStats
+}
+
+// SetCompletionFlashStats sets whether the "Done" flash shows stats
+func (c *Config) SetCompletionFlashStats(show bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CompletionFlashStats = &show
+}
+
+// ThinkingEscalation holds how many seconds into a turn the status line
+// escalates: showing the elapsed time prominently, turning amber with a
+// "still working" note, then suggesting an interrupt. 0 uses the default;
+// negative skips that stage.
+type ThinkingEscalation struct {
+	EmphasizeSecs     int `json:"emphasize_secs,omitempty"`      // Elapsed time shown prominently (default 30)
+	WarnSecs          int `json:"warn_secs,omitempty"`           // Amber with a "still working" note (default 120)
+	SuggestCancelSecs int `json:"suggest_cancel_secs,omitempty"` // Suggests interrupting the turn (default 300)
+}
+
+// GetThinkingEscalation returns how long into a turn the status line
+// escalates at each stage, with defaults of 30s, 2m and 5m. A skipped stage
+// is 0.
+func (c *Config) GetThinkingEscalation() (emphasize, warn, suggestCancel time.Duration) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	threshold := func(secs, def int) time.Duration {
+		if secs < 0 {
+			return 0
+		}
+		if secs == 0 {
+			secs = def
+		}
+		return time.Duration(secs) * time.Second
+	}
+	e := c.ThinkingEscalation
+	return threshold(e.EmphasizeSecs, 30), threshold(e.WarnSecs, 120), threshold(e.SuggestCancelSecs, 300)
+}
+
+// SetThinkingEscalation sets when the status line escalates during a long turn
+func (c *Config) SetThinkingEscalation(e ThinkingEscalation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ThinkingEscalation = e
+}
+
+// GetCompletionSound returns what plays when a response completes in the open
+// session: "bell", a shell command, or "" for nothing.
+func (c *Config) GetCompletionSound() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CompletionSound
+}
+
+// SetCompletionSound sets what plays when a response completes in the open session
+func (c *Config) SetCompletionSound(sound string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CompletionSound = sound
+}
+
+// GetInitialCommitMessage returns the message configured for the empty first
+// commit of a repo without commits, or "" for the default
+func (c *Config) GetInitialCommitMessage() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.InitialCommitMessage
+}
+
+// SetInitialCommitMessage sets the message of the empty first commit of a repo without commits
+func (c *Config) SetInitialCommitMessage(message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.InitialCommitMessage = message
+}
+
+// GetNewSessionFromIssue returns whether new sessions start in the issue picker
+func (c *Config) GetNewSessionFromIssue() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.NewSessionFromIssue
+}
+
+// SetNewSessionFromIssue sets whether new sessions start in the issue picker
+func (c *Config) SetNewSessionFromIssue(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.NewSessionFromIssue = enabled
+}
+
+// GetPauseBackgroundActivity returns whether background activity starts paused
+func (c *Config) GetPauseBackgroundActivity() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PauseBackgroundActivity
+}
+
+// SetPauseBackgroundActivity sets whether background activity starts paused
+func (c *Config) SetPauseBackgroundActivity(paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PauseBackgroundActivity = paused
+}
+
+// GetFocusInputOnNewSession returns whether creating a session focuses its chat input.
+// Defaults to true when unset.
+func (c *Config) GetFocusInputOnNewSession() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.FocusInputOnNewSession == nil || *c.FocusInputOnNewSession
+}
+
+// SetFocusInputOnNewSession sets whether creating a session focuses its chat input
+func (c *Config) SetFocusInputOnNewSession(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.FocusInputOnNewSession = &enabled
+}
+
+// Paste cleaning modes for multi-line pastes into the chat input
+const (
+	PasteCleaningAsk    = "ask"    // Ask once per session whether to clean
+	PasteCleaningAlways = "always" // Always clean without asking
+	PasteCleaningNever  = "never"  // Never clean
+)
+
+// GetPasteCleaning returns the paste cleaning mode, defaulting to "ask"
+func (c *Config) GetPasteCleaning() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.PasteCleaning == "" {
+		return PasteCleaningAsk
+	}
+	return c.PasteCleaning
+}
+
+// SetPasteCleaning sets the paste cleaning mode (ask, always, or never)
+func (c *Config) SetPasteCleaning(mode string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PasteCleaning = mode
+}
+
+// Clipboard mechanisms for copying text
+const (
+	ClipboardAuto   = "auto"   // The native clipboard when available, otherwise OSC 52
+	ClipboardNative = "native" // Always the native clipboard
+	ClipboardOSC52  = "osc52"  // Always OSC 52, through the terminal (works over SSH)
+)
+
+// GetClipboard returns the clipboard mechanism for copies, defaulting to "auto"
+func (c *Config) GetClipboard() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.Clipboard == "" {
+		return ClipboardAuto
+	}
+	return c.Clipboard
+}
+
+// SetClipboard sets the clipboard mechanism for copies (auto, native, or osc52)
+func (c *Config) SetClipboard(mechanism string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Clipboard = mechanism
+}
+
+// Behaviors of the "q" quit key
+const (
+	QuitKeySidebarOnly = "sidebar-only" // "q" quits when the sidebar is focused
+	QuitKeyConfirm     = "confirm"      // "q" asks before quitting, when the sidebar is focused
+	QuitKeyDisabled    = "disabled"     // "q" doesn't quit; Ctrl+C or the "exit" command do
+	QuitKeyCtrlCOnly   = "ctrl-c-only"  // Only Ctrl+C quits; "q" and the "exit" command don't
+)
+
+// GetQuitKeyBehavior returns what the "q" key does, defaulting to "sidebar-only"
+func (c *Config) GetQuitKeyBehavior() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.QuitKeyBehavior == "" {
+		return QuitKeySidebarOnly
+	}
+	return c.QuitKeyBehavior
+}
+
+// SetQuitKeyBehavior sets what the "q" key does (sidebar-only, confirm, disabled, or ctrl-c-only)
+func (c *Config) SetQuitKeyBehavior(behavior string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.QuitKeyBehavior = behavior
+}
+
 // GetPreviewState returns the current preview state (session ID, previous branch, repo path).
 // Returns empty strings if no preview is active.
 func (c *Config) GetPreviewState() (sessionID, previousBranch, repoPath string) {
@@ -475,6 +928,94 @@ func (c *Config) SetContainerImage(repoPath, image string) {
 	}
 }
 
+// GetMessageAutosaveSec returns the seconds between message history autosaves,
+// defaulting to 30. Returns 0 when autosave is disabled (negative setting).
+func (c *Config) GetMessageAutosaveSec() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.MessageAutosaveSec < 0 {
+		return 0
+	}
+	if c.MessageAutosaveSec == 0 {
+		return 30
+	}
+	return c.MessageAutosaveSec
+}
+
+// SetMessageAutosaveSec sets the seconds between message history autosaves
+func (c *Config) SetMessageAutosaveSec(sec int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MessageAutosaveSec = sec
+}
+
+// GetMaxImageKB returns the size attached images are downscaled to fit, in KB.
+// Returns 0 when there is no limit.
+func (c *Config) GetMaxImageKB() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return max(c.MaxImageKB, 0)
+}
+
+// SetMaxImageKB sets the size attached images are downscaled to fit, in KB (0 = no limit)
+func (c *Config) SetMaxImageKB(kb int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MaxImageKB = kb
+}
+
+// GetFocusMinutes returns how many minutes focus mode lasts before leaving by itself.
+// Returns 0 when it lasts until left.
+func (c *Config) GetFocusMinutes() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return max(c.FocusMinutes, 0)
+}
+
+// SetFocusMinutes sets how many minutes focus mode lasts (0 = until left)
+func (c *Config) SetFocusMinutes(minutes int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.FocusMinutes = minutes
+}
+
+// GetCILogLines returns how many lines are kept from the end of each failed
+// CI job's log, defaulting to 150
+func (c *Config) GetCILogLines() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.CILogLines <= 0 {
+		return 150
+	}
+	return c.CILogLines
+}
+
+// SetCILogLines sets how many lines are kept from the end of each failed CI job's log
+func (c *Config) SetCILogLines(lines int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CILogLines = lines
+}
+
+// GetCommandOutputLines returns how many lines are kept from the end of a
+// command's output inserted with /run, defaulting to 200
+func (c *Config) GetCommandOutputLines() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.CommandOutputLines <= 0 {
+		return 200
+	}
+	return c.CommandOutputLines
+}
+
+// GetCommandOutputFile returns the scrollback file /run inserts the end of when
+// given no command, or "" if none is set
+func (c *Config) GetCommandOutputFile() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CommandOutputFile
+}
+
 // GetAutoMaxTurns returns the max autonomous turns, defaulting to 50
 func (c *Config) GetAutoMaxTurns() int {
 	c.mu.RLock()
@@ -568,6 +1109,25 @@ func (c *Config) SetIssueMaxConcurrent(n int) {
 	c.IssueMaxConcurrent = n
 }
 
+// GetMaxConcurrentJobs returns how many git and gh commands operations over
+// many sessions or repos run at once, defaulting to workpool.DefaultLimit
+func (c *Config) GetMaxConcurrentJobs() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.MaxConcurrentJobs <= 0 {
+		return workpool.DefaultLimit
+	}
+	return c.MaxConcurrentJobs
+}
+
+// SetMaxConcurrentJobs sets how many git and gh commands operations over many
+// sessions or repos run at once
+func (c *Config) SetMaxConcurrentJobs(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MaxConcurrentJobs = n
+}
+
 // GetAutoMergeMethod returns the auto-merge method, defaulting to "rebase"
 func (c *Config) GetAutoMergeMethod() string {
 	c.mu.RLock()
@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/workpool"
 )
 
 func TestConfig_AddRepo(t *testing.T) {
@@ -257,6 +260,32 @@ func TestConfig_AllowedTools(t *testing.T) {
 	}
 }
 
+func TestConfig_DeniedTools(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{"/path/to/repo"},
+		Sessions: []Session{},
+	}
+
+	if tools := cfg.GetDeniedToolsForRepo("/path/to/repo"); len(tools) != 0 {
+		t.Errorf("Expected no denied tools by default, got %v", tools)
+	}
+
+	cfg.SetRepoDeniedTools("/path/to/repo", []string{"Bash(rm:*)"})
+	tools := cfg.GetDeniedToolsForRepo("/path/to/repo")
+	if len(tools) != 1 || tools[0] != "Bash(rm:*)" {
+		t.Errorf("Expected [Bash(rm:*)], got %v", tools)
+	}
+	tools[0] = "changed"
+	if cfg.GetDeniedToolsForRepo("/path/to/repo")[0] != "Bash(rm:*)" {
+		t.Error("GetDeniedToolsForRepo should return a copy")
+	}
+
+	cfg.SetRepoDeniedTools("/path/to/repo", nil)
+	if _, exists := cfg.RepoDeniedTools["/path/to/repo"]; exists {
+		t.Error("Clearing denied tools should remove the repo entry")
+	}
+}
+
 func TestConfig_MarkSessionStarted(t *testing.T) {
 	cfg := &Config{
 		Repos: []string{},
@@ -281,6 +310,33 @@ func TestConfig_MarkSessionStarted(t *testing.T) {
 	}
 }
 
+func TestConfig_MostRecentSession(t *testing.T) {
+	cfg := &Config{}
+	if cfg.MostRecentSession() != nil {
+		t.Error("MostRecentSession should return nil with no sessions")
+	}
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cfg.Sessions = []Session{
+		{ID: "old-active", CreatedAt: base, LastActivity: base.Add(time.Hour)},
+		{ID: "new-idle", CreatedAt: base.Add(30 * time.Minute)},
+		{ID: "recent", CreatedAt: base.Add(-time.Hour)},
+	}
+	if got := cfg.MostRecentSession(); got == nil || got.ID != "old-active" {
+		t.Errorf("MostRecentSession() = %v, want old-active", got)
+	}
+
+	if !cfg.SetSessionLastActivity("recent", base.Add(2*time.Hour)) {
+		t.Error("SetSessionLastActivity should return true for existing session")
+	}
+	if got := cfg.MostRecentSession(); got == nil || got.ID != "recent" {
+		t.Errorf("MostRecentSession() = %v, want recent", got)
+	}
+	if cfg.SetSessionLastActivity("nonexistent", base) {
+		t.Error("SetSessionLastActivity should return false for non-existent session")
+	}
+}
+
 func TestConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -456,6 +512,174 @@ func TestConfig_ClearSessions(t *testing.T) {
 	}
 }
 
+func TestSaveSessionMessages_Concurrent(t *testing.T) {
+	sessionID := "test-session-concurrent"
+	t.Cleanup(func() { DeleteSessionMessages(sessionID) })
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			messages := []Message{
+				{Role: "user", Content: strings.Repeat("x", n*1000)},
+				{Role: "assistant", Content: "done"},
+			}
+			if err := SaveSessionMessages(sessionID, messages, 100000); err != nil {
+				t.Errorf("SaveSessionMessages failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// The file must hold one complete write, not interleaved ones
+	loaded, err := LoadSessionMessages(sessionID)
+	if err != nil {
+		t.Fatalf("LoadSessionMessages failed after concurrent saves: %v", err)
+	}
+	if len(loaded) != 2 || loaded[1].Content != "done" {
+		t.Errorf("Expected one complete history, got %+v", loaded)
+	}
+
+	// No temporary files should be left behind
+	dir, err := paths.SessionsDir()
+	if err != nil {
+		t.Fatalf("SessionsDir failed: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("Leftover temporary file %s", e.Name())
+		}
+	}
+}
+
+func TestMessageAutosaveSec(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetMessageAutosaveSec(); got != 30 {
+		t.Errorf("Expected default 30, got %d", got)
+	}
+
+	cfg.SetMessageAutosaveSec(10)
+	if got := cfg.GetMessageAutosaveSec(); got != 10 {
+		t.Errorf("Expected 10, got %d", got)
+	}
+
+	cfg.SetMessageAutosaveSec(-1)
+	if got := cfg.GetMessageAutosaveSec(); got != 0 {
+		t.Errorf("Expected 0 (disabled) for negative setting, got %d", got)
+	}
+}
+
+func TestMaxImageKB(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetMaxImageKB(); got != 0 {
+		t.Errorf("Expected default 0 (no limit), got %d", got)
+	}
+
+	cfg.SetMaxImageKB(500)
+	if got := cfg.GetMaxImageKB(); got != 500 {
+		t.Errorf("Expected 500, got %d", got)
+	}
+
+	cfg.SetMaxImageKB(-5)
+	if got := cfg.GetMaxImageKB(); got != 0 {
+		t.Errorf("Expected 0 (no limit) for negative setting, got %d", got)
+	}
+}
+
+func TestFocusMinutes(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetFocusMinutes(); got != 0 {
+		t.Errorf("Expected default 0 (until left), got %d", got)
+	}
+
+	cfg.SetFocusMinutes(25)
+	if got := cfg.GetFocusMinutes(); got != 25 {
+		t.Errorf("Expected 25, got %d", got)
+	}
+
+	cfg.SetFocusMinutes(-1)
+	if got := cfg.GetFocusMinutes(); got != 0 {
+		t.Errorf("Expected 0 (until left) for negative setting, got %d", got)
+	}
+}
+
+func TestCILogLines(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetCILogLines(); got != 150 {
+		t.Errorf("Expected default 150, got %d", got)
+	}
+
+	cfg.SetCILogLines(40)
+	if got := cfg.GetCILogLines(); got != 40 {
+		t.Errorf("Expected 40, got %d", got)
+	}
+}
+
+func TestMaxConcurrentJobs(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetMaxConcurrentJobs(); got != workpool.DefaultLimit {
+		t.Errorf("Expected default %d, got %d", workpool.DefaultLimit, got)
+	}
+
+	cfg.SetMaxConcurrentJobs(2)
+	if got := cfg.GetMaxConcurrentJobs(); got != 2 {
+		t.Errorf("Expected 2, got %d", got)
+	}
+}
+
+func TestCommandOutputSettings(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetCommandOutputLines(); got != 200 {
+		t.Errorf("Expected default 200, got %d", got)
+	}
+	if got := cfg.GetCommandOutputFile(); got != "" {
+		t.Errorf("Expected no scrollback file by default, got %q", got)
+	}
+
+	cfg.CommandOutputLines = 50
+	cfg.CommandOutputFile = "~/.scrollback"
+	if got := cfg.GetCommandOutputLines(); got != 50 {
+		t.Errorf("Expected 50, got %d", got)
+	}
+	if got := cfg.GetCommandOutputFile(); got != "~/.scrollback" {
+		t.Errorf("Expected ~/.scrollback, got %q", got)
+	}
+}
+
+func TestSessionMessages_SystemKind(t *testing.T) {
+	sessionID := "test-session-system"
+	t.Cleanup(func() { DeleteSessionMessages(sessionID) })
+
+	messages := []Message{
+		{Role: "assistant", Content: "Done"},
+		{Role: "system", Kind: "merge", Content: "Merged into main"},
+	}
+	if err := SaveSessionMessages(sessionID, messages, 100); err != nil {
+		t.Fatalf("SaveSessionMessages failed: %v", err)
+	}
+	loaded, err := LoadSessionMessages(sessionID)
+	if err != nil {
+		t.Fatalf("LoadSessionMessages failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, messages) {
+		t.Errorf("loaded %+v, want %+v", loaded, messages)
+	}
+
+	// Messages without a kind are saved as before
+	data, err := json.Marshal(messages[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"role":"assistant","content":"Done"}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
+
 func TestSessionMessages(t *testing.T) {
 	sessionID := "test-session-123"
 
@@ -775,6 +999,36 @@ func TestConfig_MarkSessionPRCreated(t *testing.T) {
 	}
 }
 
+func TestConfig_SetSessionPRProgress(t *testing.T) {
+	cfg := &Config{
+		Repos: []string{},
+		Sessions: []Session{
+			{ID: "session-1", RepoPath: "/path", WorkTree: "/wt", Branch: "b1"},
+		},
+	}
+
+	progress := &PRProgress{BaseBranch: "main", PushedSHA: "abc123", FailedStep: "create", Error: "auth"}
+	if !cfg.SetSessionPRProgress("session-1", progress) {
+		t.Error("SetSessionPRProgress should return true for existing session")
+	}
+
+	// The stored value is a copy, not the caller's pointer
+	progress.PushedSHA = "changed"
+	sess := cfg.GetSession("session-1")
+	if sess.PRProgress == nil || sess.PRProgress.PushedSHA != "abc123" {
+		t.Errorf("expected stored progress to be unaffected by caller changes, got %+v", sess.PRProgress)
+	}
+
+	cfg.SetSessionPRProgress("session-1", nil)
+	if cfg.GetSession("session-1").PRProgress != nil {
+		t.Error("expected nil to clear PR progress")
+	}
+
+	if cfg.SetSessionPRProgress("nonexistent", progress) {
+		t.Error("SetSessionPRProgress should return false for non-existent session")
+	}
+}
+
 func TestConfig_MarkSessionMergedToParent(t *testing.T) {
 	cfg := &Config{
 		Repos: []string{},
@@ -1555,6 +1809,35 @@ func TestConfig_SetSessionBroadcastGroup(t *testing.T) {
 	}
 }
 
+func TestConfig_MigrateSessionsBaseBranch(t *testing.T) {
+	cfg := &Config{
+		Repos: []string{},
+		Sessions: []Session{
+			{ID: "session-1", RepoPath: "/repo", Branch: "b1", BaseBranch: "master"},
+			{ID: "session-2", RepoPath: "/repo", Branch: "b2", BaseBranch: "master"},
+			{ID: "session-3", RepoPath: "/repo", Branch: "b3", BaseBranch: "b1"},
+			{ID: "session-4", RepoPath: "/other", Branch: "b4", BaseBranch: "master"},
+		},
+	}
+
+	if got := cfg.CountSessionsWithBaseBranch("/repo", "master"); got != 2 {
+		t.Errorf("CountSessionsWithBaseBranch = %d, want 2", got)
+	}
+
+	if got := cfg.MigrateSessionsBaseBranch("/repo", "master", "main"); got != 2 {
+		t.Errorf("MigrateSessionsBaseBranch = %d, want 2", got)
+	}
+	for id, want := range map[string]string{"session-1": "main", "session-2": "main", "session-3": "b1", "session-4": "master"} {
+		if got := cfg.GetSession(id).BaseBranch; got != want {
+			t.Errorf("%s BaseBranch = %q, want %q", id, got, want)
+		}
+	}
+
+	if got := cfg.MigrateSessionsBaseBranch("/repo", "master", "main"); got != 0 {
+		t.Errorf("Second MigrateSessionsBaseBranch = %d, want 0", got)
+	}
+}
+
 func TestConfig_BroadcastGroupID_Persistence(t *testing.T) {
 	// Create a temp directory for test config
 	tmpDir, err := os.MkdirTemp("", "plural-broadcast-test-*")
@@ -1636,6 +1919,36 @@ func TestConfig_AutoMergeMethod(t *testing.T) {
 	}
 }
 
+func TestConfig_FocusInputOnNewSession(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{},
+		Sessions: []Session{},
+	}
+
+	// Default should be on
+	if !cfg.GetFocusInputOnNewSession() {
+		t.Error("GetFocusInputOnNewSession default = false, want true")
+	}
+
+	cfg.SetFocusInputOnNewSession(false)
+	if cfg.GetFocusInputOnNewSession() {
+		t.Error("GetFocusInputOnNewSession = true after disabling, want false")
+	}
+
+	// An explicit false must survive a JSON round trip rather than reverting to the default
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var loaded Config
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if loaded.GetFocusInputOnNewSession() {
+		t.Error("GetFocusInputOnNewSession = true after reload, want false")
+	}
+}
+
 func TestConfig_RemoveSessions(t *testing.T) {
 	cfg := &Config{
 		Repos: []string{},
@@ -1954,6 +2267,28 @@ func TestConfig_MarkSessionPRMerged(t *testing.T) {
 	}
 }
 
+func TestConfig_MarkSessionMergedUpstream(t *testing.T) {
+	cfg := &Config{
+		Repos: []string{},
+		Sessions: []Session{
+			{ID: "session-1", RepoPath: "/path", WorkTree: "/wt", Branch: "b1"},
+		},
+	}
+
+	if cfg.GetSession("session-1").IsMerged() {
+		t.Error("Session should not be merged yet")
+	}
+	if !cfg.MarkSessionMergedUpstream("session-1") {
+		t.Error("MarkSessionMergedUpstream should return true for existing session")
+	}
+	if sess := cfg.GetSession("session-1"); !sess.MergedUpstream || !sess.IsMerged() {
+		t.Error("Session should be marked as merged upstream")
+	}
+	if cfg.MarkSessionMergedUpstream("nonexistent") {
+		t.Error("MarkSessionMergedUpstream should return false for non-existent session")
+	}
+}
+
 func TestConfig_MarkSessionPRClosed(t *testing.T) {
 	cfg := &Config{
 		Repos: []string{},
@@ -2123,6 +2458,196 @@ func TestConfig_UpdateSessionPRCommentCount(t *testing.T) {
 	}
 }
 
+func TestConfig_AddSessionTurnStats(t *testing.T) {
+	cfg := &Config{
+		Sessions: []Session{
+			{ID: "s1", RepoPath: "/repo", Branch: "b1"},
+		},
+	}
+
+	if !cfg.AddSessionTurnStats("s1", TurnStats{OutputTokens: 100, DurationMs: 2000}) {
+		t.Error("AddSessionTurnStats should return true for existing session")
+	}
+	if cfg.AddSessionTurnStats("nonexistent", TurnStats{OutputTokens: 1}) {
+		t.Error("AddSessionTurnStats should return false for non-existent session")
+	}
+
+	sess := cfg.GetSession("s1")
+	if len(sess.TurnStats) != 1 || sess.TurnStats[0].OutputTokens != 100 {
+		t.Fatalf("unexpected turn stats: %+v", sess.TurnStats)
+	}
+
+	// Exceeding the bound keeps only the most recent entries
+	for i := range MaxSessionTurnStats + 5 {
+		cfg.AddSessionTurnStats("s1", TurnStats{OutputTokens: i})
+	}
+	sess = cfg.GetSession("s1")
+	if len(sess.TurnStats) != MaxSessionTurnStats {
+		t.Fatalf("expected %d turn stats, got %d", MaxSessionTurnStats, len(sess.TurnStats))
+	}
+	if first := sess.TurnStats[0].OutputTokens; first != 5 {
+		t.Errorf("expected oldest retained OutputTokens 5, got %d", first)
+	}
+	if last := sess.TurnStats[len(sess.TurnStats)-1].OutputTokens; last != MaxSessionTurnStats+4 {
+		t.Errorf("expected newest OutputTokens %d, got %d", MaxSessionTurnStats+4, last)
+	}
+}
+
+func TestConfig_AddSessionTurnStats_DoesNotMutateCopies(t *testing.T) {
+	cfg := &Config{
+		Sessions: []Session{
+			{ID: "s1", RepoPath: "/repo", Branch: "b1", TurnStats: make([]TurnStats, 1, 10)},
+		},
+	}
+
+	snapshot := cfg.GetSession("s1")
+	cfg.AddSessionTurnStats("s1", TurnStats{OutputTokens: 42})
+
+	if len(snapshot.TurnStats) != 1 {
+		t.Errorf("snapshot should be unaffected, got %d entries", len(snapshot.TurnStats))
+	}
+	if extended := snapshot.TurnStats[:2]; extended[1].OutputTokens == 42 {
+		t.Error("append should not write into the snapshot's backing array")
+	}
+}
+
+func TestConfig_SessionContextFiles(t *testing.T) {
+	cfg := &Config{
+		Sessions: []Session{
+			{ID: "s1", RepoPath: "/repo", Branch: "b1"},
+		},
+	}
+
+	if got := cfg.GetSessionContextFiles("s1"); len(got) != 0 {
+		t.Errorf("expected no context files, got %v", got)
+	}
+	paths := []string{"docs/ARCHITECTURE.md", "api/openapi.yaml"}
+	if !cfg.SetSessionContextFiles("s1", paths) {
+		t.Error("SetSessionContextFiles should return true for existing session")
+	}
+	if cfg.SetSessionContextFiles("nonexistent", paths) {
+		t.Error("SetSessionContextFiles should return false for non-existent session")
+	}
+
+	paths[0] = "mutated"
+	got := cfg.GetSessionContextFiles("s1")
+	if len(got) != 2 || got[0] != "docs/ARCHITECTURE.md" {
+		t.Fatalf("expected stored copy of context files, got %v", got)
+	}
+	got[1] = "mutated"
+	if cfg.GetSessionContextFiles("s1")[1] != "api/openapi.yaml" {
+		t.Error("GetSessionContextFiles should return a copy")
+	}
+}
+
+func TestConfig_SessionBookmarks(t *testing.T) {
+	cfg := &Config{
+		Sessions: []Session{
+			{ID: "s1", RepoPath: "/repo", Branch: "b1"},
+		},
+	}
+
+	bookmarks := []MessageBookmark{{Index: 3, Hash: MessageHash("assistant", "the answer")}}
+	if !cfg.SetSessionBookmarks("s1", bookmarks) {
+		t.Error("SetSessionBookmarks should return true for existing session")
+	}
+	if cfg.SetSessionBookmarks("nonexistent", bookmarks) {
+		t.Error("SetSessionBookmarks should return false for non-existent session")
+	}
+	bookmarks[0].Index = 99
+	if got := cfg.GetSession("s1").Bookmarks; len(got) != 1 || got[0].Index != 3 {
+		t.Errorf("expected stored copy of bookmarks, got %v", got)
+	}
+
+	if MessageHash("assistant", "a") == MessageHash("user", "a") {
+		t.Error("MessageHash should tell roles apart")
+	}
+}
+
+func TestConfig_AddSessionExternalWrite(t *testing.T) {
+	cfg := &Config{
+		Sessions: []Session{
+			{ID: "s1", RepoPath: "/repo", Branch: "b1"},
+		},
+	}
+
+	if !cfg.AddSessionExternalWrite("s1", "/repo/main.go") {
+		t.Error("AddSessionExternalWrite should return true for a new path")
+	}
+	if cfg.AddSessionExternalWrite("s1", "/repo/main.go") {
+		t.Error("AddSessionExternalWrite should return false for a path already recorded")
+	}
+	if cfg.AddSessionExternalWrite("nonexistent", "/repo/main.go") {
+		t.Error("AddSessionExternalWrite should return false for non-existent session")
+	}
+	if got := cfg.GetSession("s1").ExternalWrites; len(got) != 1 || got[0] != "/repo/main.go" {
+		t.Errorf("expected one external write, got %v", got)
+	}
+}
+
+func TestConfig_SetSessionPinned(t *testing.T) {
+	cfg := &Config{
+		Sessions: []Session{
+			{ID: "s1", RepoPath: "/repo", Branch: "b1"},
+		},
+	}
+
+	if !cfg.SetSessionPinned("s1", true) {
+		t.Error("SetSessionPinned should return true for existing session")
+	}
+	if !cfg.GetSession("s1").Pinned {
+		t.Error("expected session to be pinned")
+	}
+	cfg.SetSessionPinned("s1", false)
+	if cfg.GetSession("s1").Pinned {
+		t.Error("expected session to be unpinned")
+	}
+	if cfg.SetSessionPinned("nonexistent", true) {
+		t.Error("SetSessionPinned should return false for non-existent session")
+	}
+}
+
+func TestConfig_SessionNameTaken(t *testing.T) {
+	cfg := &Config{
+		Sessions: []Session{
+			{ID: "s1", RepoPath: "/repo", Branch: "b1", Name: "b1"},
+			{ID: "s2", RepoPath: "/repo", Branch: "b2", Name: "repo/two"},
+			{ID: "s3", RepoPath: "/other", Branch: "b3", Name: "b3"},
+		},
+	}
+
+	if !cfg.SessionNameTaken("/repo", "b1", "s2") {
+		t.Error("expected another session's name taken")
+	}
+	if !cfg.SessionNameTaken("/repo", "b2", "s1") {
+		t.Error("expected another session's branch taken")
+	}
+	if cfg.SessionNameTaken("/repo", "b1", "s1") {
+		t.Error("expected the session's own name not to count")
+	}
+	if cfg.SessionNameTaken("/repo", "b3", "s1") {
+		t.Error("expected names in other repos not to count")
+	}
+}
+
+func TestConfig_SetSessionModel(t *testing.T) {
+	cfg := &Config{
+		Sessions: []Session{
+			{ID: "s1", RepoPath: "/repo", Branch: "b1"},
+		},
+	}
+
+	if !cfg.SetSessionModel("s1", "opus") {
+		t.Error("SetSessionModel should return true for existing session")
+	}
+	if got := cfg.GetSession("s1").Model; got != "opus" {
+		t.Errorf("Model = %q, want opus", got)
+	}
+	if cfg.SetSessionModel("nonexistent", "opus") {
+		t.Error("SetSessionModel should return false for non-existent session")
+	}
+}
+
 func TestConfig_UpdateSessionPRCommentCount_ThreadSafe(t *testing.T) {
 	cfg := &Config{
 		Sessions: []Session{
@@ -2308,6 +2833,18 @@ func TestFormatTranscript_MultipleMessages(t *testing.T) {
 	}
 }
 
+func TestFormatTranscript_SystemMessages(t *testing.T) {
+	messages := []Message{
+		{Role: "assistant", Content: "Done"},
+		{Role: "system", Kind: "merge", Content: "Merged into main"},
+		{Role: "system", Content: "Note"},
+	}
+	want := "Assistant:\nDone\n\nPlural (merge):\nMerged into main\n\nPlural:\nNote"
+	if got := FormatTranscript(messages); got != want {
+		t.Errorf("FormatTranscript() = %q, want %q", got, want)
+	}
+}
+
 func TestFormatTranscript_Order(t *testing.T) {
 	messages := []Message{
 		{Role: "user", Content: "first"},
@@ -2325,6 +2862,41 @@ func TestFormatTranscript_Order(t *testing.T) {
 	}
 }
 
+func TestArchiveSessionMessages(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	messages := []Message{
+		{Role: "user", Content: "fix the bug"},
+		{Role: "assistant", Content: "fixed"},
+	}
+	if err := SaveSessionMessages("archived-session", messages, 100); err != nil {
+		t.Fatalf("SaveSessionMessages failed: %v", err)
+	}
+
+	path, err := ArchiveSessionMessages("archived-session", "my-feature")
+	if err != nil {
+		t.Fatalf("ArchiveSessionMessages failed: %v", err)
+	}
+	archiveDir, _ := paths.ArchiveDir()
+	if filepath.Dir(path) != archiveDir {
+		t.Errorf("expected the transcript in %s, got %s", archiveDir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read transcript: %v", err)
+	}
+	content := string(data)
+	if !strings.HasPrefix(content, "my-feature\n\n") {
+		t.Errorf("expected the transcript to start with the title, got %q", content)
+	}
+	if !strings.Contains(content, "fix the bug") || !strings.Contains(content, "fixed") {
+		t.Errorf("expected both messages in the transcript, got %q", content)
+	}
+}
+
 func TestConfig_AddRepo_SameFilesystemPath(t *testing.T) {
 	dir := t.TempDir()
 	target := filepath.Join(dir, "repo")
@@ -2489,3 +3061,141 @@ func TestConfig_Validate_DuplicateRepoFilesystem(t *testing.T) {
 		t.Error("Validate should detect filesystem-level duplicate repos")
 	}
 }
+
+func TestConfig_PasteSanitize(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{},
+		Sessions: []Session{},
+	}
+
+	// Default should be on
+	if !cfg.GetPasteSanitize() {
+		t.Error("GetPasteSanitize default = false, want true")
+	}
+
+	cfg.SetPasteSanitize(false)
+	if cfg.GetPasteSanitize() {
+		t.Error("GetPasteSanitize = true after disabling, want false")
+	}
+}
+
+func TestConfig_UpdateCheck(t *testing.T) {
+	cfg := &Config{}
+	if !cfg.GetUpdateCheck() {
+		t.Error("GetUpdateCheck default = false, want true")
+	}
+
+	disabled := false
+	cfg.UpdateCheck = &disabled
+	if cfg.GetUpdateCheck() {
+		t.Error("GetUpdateCheck = true with update_check false, want false")
+	}
+}
+
+func TestConfig_CompletionFlash(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetCompletionFlashMs(); got != 480 {
+		t.Errorf("GetCompletionFlashMs default = %d, want 480", got)
+	}
+	if !cfg.GetCompletionFlashStats() {
+		t.Error("GetCompletionFlashStats default = false, want true")
+	}
+	if got := cfg.GetCompletionSound(); got != "" {
+		t.Errorf("GetCompletionSound default = %q, want none", got)
+	}
+
+	cfg.SetCompletionFlashMs(1000)
+	cfg.SetCompletionFlashStats(false)
+	cfg.SetCompletionSound("bell")
+	if got := cfg.GetCompletionFlashMs(); got != 1000 {
+		t.Errorf("GetCompletionFlashMs = %d, want 1000", got)
+	}
+	if cfg.GetCompletionFlashStats() {
+		t.Error("GetCompletionFlashStats = true, want false")
+	}
+	if got := cfg.GetCompletionSound(); got != "bell" {
+		t.Errorf("GetCompletionSound = %q, want bell", got)
+	}
+
+	cfg.SetCompletionFlashMs(-1)
+	if got := cfg.GetCompletionFlashMs(); got != 0 {
+		t.Errorf("GetCompletionFlashMs = %d with a negative setting, want 0 (disabled)", got)
+	}
+}
+
+func TestConfig_MaxChatWidth(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetMaxChatWidth(); got != 120 {
+		t.Errorf("GetMaxChatWidth default = %d, want 120", got)
+	}
+	cfg.SetMaxChatWidth(100)
+	if got := cfg.GetMaxChatWidth(); got != 100 {
+		t.Errorf("GetMaxChatWidth = %d, want 100", got)
+	}
+	cfg.SetMaxChatWidth(-1)
+	if got := cfg.GetMaxChatWidth(); got != 0 {
+		t.Errorf("GetMaxChatWidth = %d with a negative setting, want 0 (no cap)", got)
+	}
+}
+
+func TestSortedSessions(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sessions := []Session{
+		{ID: "c", RepoPath: "/b", CreatedAt: t0},
+		{ID: "b", RepoPath: "/a", CreatedAt: t0.Add(time.Hour)},
+		{ID: "z", RepoPath: "/a", CreatedAt: t0},
+		{ID: "a", RepoPath: "/a", CreatedAt: t0},
+	}
+	var got []string
+	for _, sess := range SortedSessions(sessions) {
+		got = append(got, sess.ID)
+	}
+	if want := []string{"a", "z", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("SortedSessions order = %v, want %v", got, want)
+	}
+	if sessions[0].ID != "c" {
+		t.Error("SortedSessions should not reorder its argument")
+	}
+}
+
+func TestIsSessionID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"0b7e6c1a-4f2d-4c9b-9a51-2f3c4d5e6f70", true},
+		{"hotfix", false},
+		{"", false},
+		{"../0b7e6c1a-4f2d-4c9b-9a51-2f3c4d5e6f70", false},
+		{"0b7e6c1a4f2d4c9b9a512f3c4d5e6f70", false},
+		{"{0b7e6c1a-4f2d-4c9b-9a51-2f3c4d5e6f70}", false},
+	}
+	for _, tt := range tests {
+		if got := IsSessionID(tt.id); got != tt.want {
+			t.Errorf("IsSessionID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestShortID(t *testing.T) {
+	if got := ShortID("0123456789abcdef"); got != "01234567" {
+		t.Errorf("ShortID() = %q, want %q", got, "01234567")
+	}
+	if got := ShortID("abc"); got != "abc" {
+		t.Errorf("ShortID() of a short ID = %q, want it unchanged", got)
+	}
+}
+
+func TestConfig_ThinkingEscalation(t *testing.T) {
+	cfg := &Config{}
+	emphasize, warn, suggestCancel := cfg.GetThinkingEscalation()
+	if emphasize != 30*time.Second || warn != 2*time.Minute || suggestCancel != 5*time.Minute {
+		t.Errorf("GetThinkingEscalation defaults = %v, %v, %v, want 30s, 2m, 5m", emphasize, warn, suggestCancel)
+	}
+
+	cfg.SetThinkingEscalation(ThinkingEscalation{EmphasizeSecs: 10, WarnSecs: -1})
+	emphasize, warn, suggestCancel = cfg.GetThinkingEscalation()
+	if emphasize != 10*time.Second || warn != 0 || suggestCancel != 5*time.Minute {
+		t.Errorf("GetThinkingEscalation = %v, %v, %v, want 10s, 0 (skipped), 5m", emphasize, warn, suggestCancel)
+	}
+}
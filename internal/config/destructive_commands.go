@@ -0,0 +1,33 @@
+package config
+
+// DestructiveCommandRules adjusts which Bash commands in a repo get the
+// high-friction permission prompt that asks for typing "allow". Patterns are
+// regular expressions flagged on top of the built-in checks; a flagged part of
+// a command that an Allow pattern matches gets the usual prompt instead.
+type DestructiveCommandRules struct {
+	Patterns []string `json:"patterns,omitempty"` // Extra regexes for commands to treat as destructive
+	Allow    []string `json:"allow,omitempty"`    // Regexes for flagged commands that aren't destructive in this repo
+}
+
+// GetRepoDestructiveCommands returns the destructive command rules for a repo.
+func (c *Config) GetRepoDestructiveCommands(repoPath string) DestructiveCommandRules {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.RepoDestructiveCommands[resolveRepoPath(c.Repos, repoPath)]
+}
+
+// SetRepoDestructiveCommands sets the destructive command rules for a repo.
+// Empty rules remove the repo's entry.
+func (c *Config) SetRepoDestructiveCommands(repoPath string, rules DestructiveCommandRules) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RepoDestructiveCommands == nil {
+		c.RepoDestructiveCommands = make(map[string]DestructiveCommandRules)
+	}
+	resolved := resolveRepoPath(c.Repos, repoPath)
+	if len(rules.Patterns) == 0 && len(rules.Allow) == 0 {
+		delete(c.RepoDestructiveCommands, resolved)
+		return
+	}
+	c.RepoDestructiveCommands[resolved] = rules
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConfig_RepoDestructiveCommands(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{"/path/to/repo"},
+		Sessions: []Session{},
+	}
+
+	if got := cfg.GetRepoDestructiveCommands("/path/to/repo"); len(got.Patterns) != 0 || len(got.Allow) != 0 {
+		t.Errorf("expected no rules by default, got %+v", got)
+	}
+
+	cfg.SetRepoDestructiveCommands("/path/to/repo", DestructiveCommandRules{Patterns: []string{`terraform destroy`}, Allow: []string{`rm -rf /tmp/`}})
+	got := cfg.GetRepoDestructiveCommands("/path/to/repo")
+	if !slices.Equal(got.Patterns, []string{`terraform destroy`}) || !slices.Equal(got.Allow, []string{`rm -rf /tmp/`}) {
+		t.Errorf("GetRepoDestructiveCommands = %+v", got)
+	}
+
+	cfg.SetRepoDestructiveCommands("/path/to/repo", DestructiveCommandRules{})
+	if _, exists := cfg.RepoDestructiveCommands["/path/to/repo"]; exists {
+		t.Error("empty rules should remove the repo entry")
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+// RepoHooks are shell commands run in a repo's main checkout after Plural
+// merges or opens a PR for one of its sessions, such as a deploy script or a
+// chat notification. Each list runs in order and stops at the first failure.
+type RepoHooks struct {
+	PostMerge    []string `json:"post_merge,omitempty"`     // Run after a session is merged to main
+	PostPRCreate []string `json:"post_pr_create,omitempty"` // Run after a PR is created for a session
+}
+
+// GetRepoHooks returns the hooks configured for a repo.
+func (c *Config) GetRepoHooks(repoPath string) RepoHooks {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.RepoHooks[resolveRepoPath(c.Repos, repoPath)]
+}
+
+// SetRepoHooks sets the hooks of a repo. Passing no commands removes them.
+func (c *Config) SetRepoHooks(repoPath string, hooks RepoHooks) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RepoHooks == nil {
+		c.RepoHooks = make(map[string]RepoHooks)
+	}
+	resolved := resolveRepoPath(c.Repos, repoPath)
+	if len(hooks.PostMerge) == 0 && len(hooks.PostPRCreate) == 0 {
+		delete(c.RepoHooks, resolved)
+		return
+	}
+	c.RepoHooks[resolved] = hooks
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConfig_RepoHooks(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{"/path/to/repo"},
+		Sessions: []Session{},
+	}
+
+	if hooks := cfg.GetRepoHooks("/path/to/repo"); len(hooks.PostMerge) != 0 || len(hooks.PostPRCreate) != 0 {
+		t.Errorf("expected no hooks by default, got %+v", hooks)
+	}
+
+	cfg.SetRepoHooks("/path/to/repo", RepoHooks{PostMerge: []string{"./deploy.sh", "notify"}})
+	hooks := cfg.GetRepoHooks("/path/to/repo")
+	if !slices.Equal(hooks.PostMerge, []string{"./deploy.sh", "notify"}) || len(hooks.PostPRCreate) != 0 {
+		t.Fatalf("GetRepoHooks = %+v, want the post-merge commands", hooks)
+	}
+
+	cfg.SetRepoHooks("/path/to/repo", RepoHooks{})
+	if _, exists := cfg.RepoHooks["/path/to/repo"]; exists {
+		t.Error("clearing the hooks should remove the repo entry")
+	}
+}
+
+func TestConfig_RepoHooksValidation(t *testing.T) {
+	cfg := &Config{
+		Repos:     []string{"/path/to/repo"},
+		Sessions:  []Session{},
+		RepoHooks: map[string]RepoHooks{"/path/to/repo": {PostMerge: []string{"make deploy", "  "}}},
+	}
+
+	problems := problemStrings(cfg.semanticProblems())
+	if len(problems) != 1 || !strings.Contains(problems[0], `repo_hooks["/path/to/repo"].post_merge[1]: command is empty`) {
+		t.Errorf("expected an empty hook command to be reported, got %q", problems)
+	}
+
+	cfg.RepoHooks["/path/to/repo"] = RepoHooks{PostMerge: []string{"make deploy"}}
+	if problems := cfg.semanticProblems(); len(problems) != 0 {
+		t.Errorf("expected valid hooks to pass, got %v", problems)
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+// LFS checkout modes: how new session worktrees of a repo using Git LFS check
+// out LFS files.
+const (
+	LFSModeFull = "full" // Download LFS files while checking out
+	LFSModeSkip = "skip" // Leave LFS files as pointers (GIT_LFS_SKIP_SMUDGE=1)
+)
+
+// GetLFSMode returns the remembered LFS checkout mode for a repo, or "" if the
+// user hasn't chosen one yet.
+func (c *Config) GetLFSMode(repoPath string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.RepoLFSMode == nil {
+		return ""
+	}
+	return c.RepoLFSMode[resolveRepoPath(c.Repos, repoPath)]
+}
+
+// SetLFSMode remembers the LFS checkout mode for a repo. Passing "" forgets it,
+// so the next session asks again.
+func (c *Config) SetLFSMode(repoPath, mode string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RepoLFSMode == nil {
+		c.RepoLFSMode = make(map[string]string)
+	}
+	resolved := resolveRepoPath(c.Repos, repoPath)
+	if mode == "" {
+		delete(c.RepoLFSMode, resolved)
+		return
+	}
+	c.RepoLFSMode[resolved] = mode
+}
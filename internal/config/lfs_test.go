@@ -0,0 +1,45 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfig_LFSMode(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{"/path/to/repo"},
+		Sessions: []Session{},
+	}
+
+	if mode := cfg.GetLFSMode("/path/to/repo"); mode != "" {
+		t.Errorf("expected no LFS mode by default, got %q", mode)
+	}
+
+	cfg.SetLFSMode("/path/to/repo", LFSModeSkip)
+	if mode := cfg.GetLFSMode("/path/to/repo"); mode != LFSModeSkip {
+		t.Errorf("GetLFSMode = %q, want %q", mode, LFSModeSkip)
+	}
+
+	cfg.SetLFSMode("/path/to/repo", "")
+	if _, exists := cfg.RepoLFSMode["/path/to/repo"]; exists {
+		t.Error("clearing the mode should remove the repo entry")
+	}
+}
+
+func TestConfig_LFSModeValidation(t *testing.T) {
+	cfg := &Config{
+		Repos:       []string{"/path/to/repo"},
+		Sessions:    []Session{},
+		RepoLFSMode: map[string]string{"/path/to/repo": "partial"},
+	}
+
+	problems := problemStrings(cfg.semanticProblems())
+	if len(problems) != 1 || !strings.Contains(problems[0], `repo_lfs_mode["/path/to/repo"]: unknown value "partial"`) {
+		t.Errorf("expected an unknown LFS mode to be reported, got %q", problems)
+	}
+
+	cfg.RepoLFSMode["/path/to/repo"] = LFSModeFull
+	if problems := cfg.semanticProblems(); len(problems) != 0 {
+		t.Errorf("expected a valid LFS mode to pass, got %v", problems)
+	}
+}
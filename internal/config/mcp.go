@@ -182,3 +182,28 @@ func (c *Config) GetAllowedToolsForRepo(repoPath string) []string {
 	}
 	return result
 }
+
+// GetDeniedToolsForRepo returns a copy of a repository's denied tools
+func (c *Config) GetDeniedToolsForRepo(repoPath string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.RepoDeniedTools[resolveRepoPath(c.Repos, repoPath)])
+}
+
+// SetRepoDeniedTools replaces a repository's denied tools. An empty list
+// removes the repo's entry.
+func (c *Config) SetRepoDeniedTools(repoPath string, tools []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.RepoDeniedTools == nil {
+		c.RepoDeniedTools = make(map[string][]string)
+	}
+	resolved := resolveRepoPath(c.Repos, repoPath)
+	if len(tools) == 0 {
+		delete(c.RepoDeniedTools, resolved)
+		return
+	}
+	c.RepoDeniedTools[resolved] = slices.Clone(tools)
+}
@@ -18,10 +18,31 @@ const (
 // Message represents a chat message for persistence
 type Message struct {
 	Role    string `json:"role"`
+	Kind    string `json:"kind,omitempty"` // What a system message is about (empty for user and assistant messages)
 	Content string `json:"content"`
+
+	// Where bursts of tool uses sit in Content, for compact rendering
+	ToolUseGroups []ToolUseGroup `json:"tool_use_groups,omitempty"`
+}
+
+// ToolUseGroup is a run of consecutive tool-use lines in a message's content,
+// given as byte offsets, with the outcome of each tool use in it.
+type ToolUseGroup struct {
+	Start int              `json:"start"`
+	End   int              `json:"end"`
+	Tools []ToolUseSummary `json:"tools"`
+}
+
+// ToolUseSummary is the persisted outcome of a single tool use in a group.
+type ToolUseSummary struct {
+	Name     string `json:"name"`
+	Complete bool   `json:"complete,omitempty"`
+	Failed   bool   `json:"failed,omitempty"`
 }
 
-// SaveSessionMessages saves messages for a session (keeps last maxLines lines)
+// SaveSessionMessages saves messages for a session (keeps last maxLines lines).
+// The file is replaced atomically, so concurrent saves never interleave and a
+// crash mid-write leaves the previous history intact.
 func SaveSessionMessages(sessionID string, messages []Message, maxLines int) error {
 	dir, err := paths.SessionsDir()
 	if err != nil {
@@ -53,8 +74,34 @@ func SaveSessionMessages(sessionID string, messages []Message, maxLines int) err
 		return err
 	}
 
-	path := filepath.Join(dir, sessionID+".json")
-	return os.WriteFile(path, data, 0644)
+	return writeFileAtomic(filepath.Join(dir, sessionID+".json"), data, 0644)
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over path.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
 }
 
 // LoadSessionMessages loads messages for a session
@@ -81,6 +128,57 @@ func LoadSessionMessages(sessionID string) ([]Message, error) {
 	return messages, nil
 }
 
+// SessionMessagesSize returns the size in bytes of a session's saved messages,
+// or 0 if it has none.
+func SessionMessagesSize(sessionID string) (int64, error) {
+	dir, err := paths.SessionsDir()
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := os.Stat(filepath.Join(dir, sessionID+".json"))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// SetTurnInProgress records whether a session's messages were last saved while
+// Claude was still responding, so a crash before the turn's final save can be
+// told apart from a history that is complete.
+func SetTurnInProgress(sessionID string, inProgress bool) error {
+	dir, err := paths.SessionsDir()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, sessionID+".turn")
+	if inProgress {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, nil, 0644)
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// TurnInProgress reports whether a session's messages were last saved mid-turn.
+func TurnInProgress(sessionID string) bool {
+	dir, err := paths.SessionsDir()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, sessionID+".turn"))
+	return err == nil
+}
+
 // DeleteSessionMessages deletes the messages file for a session
 func DeleteSessionMessages(sessionID string) error {
 	dir, err := paths.SessionsDir()
@@ -88,6 +186,7 @@ func DeleteSessionMessages(sessionID string) error {
 		return err
 	}
 
+	os.Remove(filepath.Join(dir, sessionID+".turn"))
 	path := filepath.Join(dir, sessionID+".json")
 	err = os.Remove(path)
 	if os.IsNotExist(err) {
@@ -96,6 +195,29 @@ func DeleteSessionMessages(sessionID string) error {
 	return err
 }
 
+// ArchiveSessionMessages writes a session's message history as a plain text
+// transcript to the archive directory, under title, and returns its path. The
+// saved history itself is left for the caller to delete.
+func ArchiveSessionMessages(sessionID, title string) (string, error) {
+	messages, err := LoadSessionMessages(sessionID)
+	if err != nil {
+		return "", err
+	}
+	dir, err := paths.ArchiveDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	content := title + "\n\n" + FormatTranscript(messages) + "\n"
+	path := filepath.Join(dir, sessionID+".txt")
+	if err := writeFileAtomic(path, []byte(content), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
 // ClearAllSessionMessages deletes all session message files.
 // Returns the number of files deleted.
 func ClearAllSessionMessages() (int, error) {
@@ -114,6 +236,10 @@ func ClearAllSessionMessages() (int, error) {
 
 	deleted := 0
 	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".turn") {
+			os.Remove(filepath.Join(dir, entry.Name())) // Markers go with their history
+			continue
+		}
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
 			continue
 		}
@@ -183,7 +309,8 @@ func PruneOrphanedSessionMessages(cfg *Config) (int, error) {
 }
 
 // FormatTranscript formats session messages as a human-readable plain text transcript.
-// Each message is prefixed with "User:" or "Assistant:" and separated by blank lines.
+// Each message is prefixed with "User:", "Assistant:" or, for notices plural added,
+// "Plural:" with their kind, and separated by blank lines.
 func FormatTranscript(messages []Message) string {
 	if len(messages) == 0 {
 		return ""
@@ -195,6 +322,12 @@ func FormatTranscript(messages []Message) string {
 			sb.WriteString("User:\n")
 		case "assistant":
 			sb.WriteString("Assistant:\n")
+		case "system":
+			if msg.Kind != "" {
+				sb.WriteString("Plural (" + msg.Kind + "):\n")
+			} else {
+				sb.WriteString("Plural:\n")
+			}
 		default:
 			sb.WriteString(msg.Role + ":\n")
 		}
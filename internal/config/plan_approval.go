@@ -0,0 +1,41 @@
+package config
+
+// PlanApprovalCriteria describes plans considered safe enough to approve without
+// asking. A plan must meet every criterion; sessions opt in with AutoApprovePlans.
+type PlanApprovalCriteria struct {
+	PathPrefixes []string `json:"path_prefixes,omitempty"`  // Worktree-relative paths the plan may touch; every file it names must be under one (empty = any)
+	AllowShell   bool     `json:"allow_shell,omitempty"`    // Allow plans that request Bash permissions or contain shell commands
+	MaxPlanChars int      `json:"max_plan_chars,omitempty"` // Longest plan to approve, in characters (0 = no limit)
+}
+
+// GetPlanApprovalCriteria returns the plan auto-approval criteria for a repo,
+// or nil if none are configured.
+func (c *Config) GetPlanApprovalCriteria(repoPath string) *PlanApprovalCriteria {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.RepoPlanApproval == nil {
+		return nil
+	}
+	criteria, ok := c.RepoPlanApproval[resolveRepoPath(c.Repos, repoPath)]
+	if !ok {
+		return nil
+	}
+	criteria.PathPrefixes = append([]string(nil), criteria.PathPrefixes...)
+	return &criteria
+}
+
+// SetPlanApprovalCriteria sets the plan auto-approval criteria for a repo.
+// Passing nil removes them.
+func (c *Config) SetPlanApprovalCriteria(repoPath string, criteria *PlanApprovalCriteria) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RepoPlanApproval == nil {
+		c.RepoPlanApproval = make(map[string]PlanApprovalCriteria)
+	}
+	resolved := resolveRepoPath(c.Repos, repoPath)
+	if criteria == nil {
+		delete(c.RepoPlanApproval, resolved)
+		return
+	}
+	c.RepoPlanApproval[resolved] = *criteria
+}
@@ -0,0 +1,31 @@
+package config
+
+import "testing"
+
+func TestConfig_PlanApprovalCriteria(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{"/path/to/repo"},
+		Sessions: []Session{},
+	}
+
+	if criteria := cfg.GetPlanApprovalCriteria("/path/to/repo"); criteria != nil {
+		t.Errorf("expected no criteria by default, got %+v", criteria)
+	}
+
+	cfg.SetPlanApprovalCriteria("/path/to/repo", &PlanApprovalCriteria{PathPrefixes: []string{"docs/"}, MaxPlanChars: 2000})
+	criteria := cfg.GetPlanApprovalCriteria("/path/to/repo")
+	if criteria == nil || len(criteria.PathPrefixes) != 1 || criteria.MaxPlanChars != 2000 {
+		t.Fatalf("GetPlanApprovalCriteria = %+v, want docs/ with a 2000 character limit", criteria)
+	}
+
+	// Returned criteria are a copy
+	criteria.PathPrefixes[0] = "src/"
+	if cfg.GetPlanApprovalCriteria("/path/to/repo").PathPrefixes[0] != "docs/" {
+		t.Error("modifying returned criteria should not change the config")
+	}
+
+	cfg.SetPlanApprovalCriteria("/path/to/repo", nil)
+	if _, exists := cfg.RepoPlanApproval["/path/to/repo"]; exists {
+		t.Error("clearing criteria should remove the repo entry")
+	}
+}
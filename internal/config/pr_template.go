@@ -0,0 +1,44 @@
+package config
+
+// PR template modes: how a repo's pull request template combines with the
+// description Claude generates.
+const (
+	PRTemplateMerge   = "merge"   // Claude fills in the template's sections (default)
+	PRTemplateReplace = "replace" // The template is used as the PR body unchanged
+)
+
+// PRTemplate configures the pull request template used for generated PR
+// descriptions. Without one, GitHub's standard template locations are used.
+type PRTemplate struct {
+	Path string `json:"path,omitempty"` // Template file, relative to the repo root or absolute (empty = GitHub's standard locations)
+	Mode string `json:"mode,omitempty"` // PRTemplateMerge or PRTemplateReplace (empty = merge)
+}
+
+// GetPRTemplate returns the PR template settings for a repo, or nil if none are configured.
+func (c *Config) GetPRTemplate(repoPath string) *PRTemplate {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.RepoPRTemplate == nil {
+		return nil
+	}
+	tmpl, ok := c.RepoPRTemplate[resolveRepoPath(c.Repos, repoPath)]
+	if !ok {
+		return nil
+	}
+	return &tmpl
+}
+
+// SetPRTemplate sets the PR template settings for a repo. Passing nil removes them.
+func (c *Config) SetPRTemplate(repoPath string, tmpl *PRTemplate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RepoPRTemplate == nil {
+		c.RepoPRTemplate = make(map[string]PRTemplate)
+	}
+	resolved := resolveRepoPath(c.Repos, repoPath)
+	if tmpl == nil {
+		delete(c.RepoPRTemplate, resolved)
+		return
+	}
+	c.RepoPRTemplate[resolved] = *tmpl
+}
@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestConfig_PRTemplate(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{"/path/to/repo"},
+		Sessions: []Session{},
+	}
+
+	if tmpl := cfg.GetPRTemplate("/path/to/repo"); tmpl != nil {
+		t.Errorf("expected no template settings by default, got %+v", tmpl)
+	}
+
+	cfg.SetPRTemplate("/path/to/repo", &PRTemplate{Path: "docs/pr.md", Mode: PRTemplateReplace})
+	tmpl := cfg.GetPRTemplate("/path/to/repo")
+	if tmpl == nil || tmpl.Path != "docs/pr.md" || tmpl.Mode != PRTemplateReplace {
+		t.Fatalf("GetPRTemplate = %+v, want docs/pr.md in replace mode", tmpl)
+	}
+
+	cfg.SetPRTemplate("/path/to/repo", nil)
+	if _, exists := cfg.RepoPRTemplate["/path/to/repo"]; exists {
+		t.Error("clearing the settings should remove the repo entry")
+	}
+}
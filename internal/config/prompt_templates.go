@@ -0,0 +1,60 @@
+package config
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// templateVariablePattern matches a {name} placeholder in a prompt template
+var templateVariablePattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_-]*)\}`)
+
+// PromptTemplate is a named, reusable prompt. Each {name} placeholder in its text
+// is a variable the user is asked for when the template is used, e.g.
+// "Review {file} for {concern}".
+type PromptTemplate struct {
+	Name string `json:"name"` // Shown in the template picker
+	Text string `json:"text"` // Prompt body, which may contain {variables}
+}
+
+// Variables returns the names of the template's variables in the order they
+// first appear, each once.
+func (t PromptTemplate) Variables() []string {
+	var names []string
+	for _, match := range templateVariablePattern.FindAllStringSubmatch(t.Text, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// Fill returns the template text with each variable replaced by its value.
+// Variables without a value are left as they are.
+func (t PromptTemplate) Fill(values map[string]string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(t.Text, func(placeholder string) string {
+		if value, ok := values[strings.Trim(placeholder, "{}")]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// GetPromptTemplates returns the saved prompt templates
+func (c *Config) GetPromptTemplates() []PromptTemplate {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.PromptTemplates) == 0 {
+		return nil
+	}
+	result := make([]PromptTemplate, len(c.PromptTemplates))
+	copy(result, c.PromptTemplates)
+	return result
+}
+
+// SetPromptTemplates replaces the saved prompt templates
+func (c *Config) SetPromptTemplates(templates []PromptTemplate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PromptTemplates = templates
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPromptTemplate_VariablesAndFill(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		variables []string
+		values    map[string]string
+		want      string
+	}{
+		{"no variables", "Summarize the diff", nil, nil, "Summarize the diff"},
+		{"two variables", "Review {file} for {concern}", []string{"file", "concern"}, map[string]string{"file": "app.go", "concern": "races"}, "Review app.go for races"},
+		{"repeated variable asked once", "{pkg}: test {pkg}", []string{"pkg"}, map[string]string{"pkg": "git"}, "git: test git"},
+		{"missing value kept", "Port {file} to {lang}", []string{"file", "lang"}, map[string]string{"file": "a.py"}, "Port a.py to {lang}"},
+		{"values are not filled again", "Quote {a} and {b}", []string{"a", "b"}, map[string]string{"a": "{b}", "b": "x"}, "Quote {b} and x"},
+		{"non-identifiers are not variables", "Return {} or { spaced } or {1st}", nil, nil, "Return {} or { spaced } or {1st}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := PromptTemplate{Name: "t", Text: tt.text}
+			if got := tmpl.Variables(); !slices.Equal(got, tt.variables) {
+				t.Errorf("Variables() = %q, want %q", got, tt.variables)
+			}
+			if got := tmpl.Fill(tt.values); got != tt.want {
+				t.Errorf("Fill() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_PromptTemplatesValidation(t *testing.T) {
+	cfg := &Config{PromptTemplates: []PromptTemplate{
+		{Name: "review", Text: "Review {file}"},
+		{Name: "review", Text: "Again"},
+		{Name: "", Text: " "},
+	}}
+	problems := problemStrings(cfg.semanticProblems())
+	want := []string{
+		`prompt_templates[1].name: duplicate prompt template name "review"; only the first is used`,
+		`prompt_templates[2].name: prompt template has no name`,
+		`prompt_templates[2].text: prompt template "" has no text`,
+	}
+	if !slices.Equal(problems, want) {
+		t.Errorf("problems = %q, want %q", problems, want)
+	}
+}
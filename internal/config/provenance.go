@@ -0,0 +1,32 @@
+package config
+
+// RepoProvenance selects how merges and PRs Plural makes in a repo record the
+// session that produced them, for tracing a commit back to it (see plural
+// blame). Both are off unless set.
+type RepoProvenance struct {
+	Trailers bool `json:"trailers,omitempty"` // Append Plural-Session and Plural-Prompted-By trailers to the commit messages Plural writes
+	Notes    bool `json:"notes,omitempty"`    // Write a git note (refs/notes/plural) with the session's name, usage, and prompt hashes on the resulting commit
+}
+
+// GetRepoProvenance returns how merges and PRs in a repo record their session.
+func (c *Config) GetRepoProvenance(repoPath string) RepoProvenance {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.RepoProvenance[resolveRepoPath(c.Repos, repoPath)]
+}
+
+// SetRepoProvenance sets how merges and PRs in a repo record their session.
+// Turning both off removes the repo's entry.
+func (c *Config) SetRepoProvenance(repoPath string, provenance RepoProvenance) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RepoProvenance == nil {
+		c.RepoProvenance = make(map[string]RepoProvenance)
+	}
+	resolved := resolveRepoPath(c.Repos, repoPath)
+	if provenance == (RepoProvenance{}) {
+		delete(c.RepoProvenance, resolved)
+		return
+	}
+	c.RepoProvenance[resolved] = provenance
+}
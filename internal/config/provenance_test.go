@@ -0,0 +1,24 @@
+package config
+
+import "testing"
+
+func TestConfig_RepoProvenance(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{"/path/to/repo"},
+		Sessions: []Session{},
+	}
+
+	if got := cfg.GetRepoProvenance("/path/to/repo"); got.Trailers || got.Notes {
+		t.Errorf("expected provenance off by default, got %+v", got)
+	}
+
+	cfg.SetRepoProvenance("/path/to/repo", RepoProvenance{Trailers: true})
+	if got := cfg.GetRepoProvenance("/path/to/repo"); !got.Trailers || got.Notes {
+		t.Errorf("GetRepoProvenance = %+v, want trailers only", got)
+	}
+
+	cfg.SetRepoProvenance("/path/to/repo", RepoProvenance{})
+	if _, exists := cfg.RepoProvenance["/path/to/repo"]; exists {
+		t.Error("turning provenance off should remove the repo entry")
+	}
+}
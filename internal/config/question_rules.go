@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// QuestionRule answers a question prompt (AskUserQuestion) automatically when
+// the question text matches.
+type QuestionRule struct {
+	Match  string `json:"match"`           // Text the question must contain (case-insensitive), or a regular expression when Regex is set
+	Regex  bool   `json:"regex,omitempty"` // Treat Match as a regular expression
+	Answer string `json:"answer"`          // Label of the option to select (case-insensitive)
+}
+
+// Matches reports whether the rule applies to the given question text.
+// Returns an error if the rule's regular expression does not compile.
+func (r QuestionRule) Matches(question string) (bool, error) {
+	if r.Match == "" {
+		return false, nil
+	}
+	if !r.Regex {
+		return strings.Contains(strings.ToLower(question), strings.ToLower(r.Match)), nil
+	}
+	re, err := regexp.Compile(r.Match)
+	if err != nil {
+		return false, fmt.Errorf("invalid question rule regex %q: %w", r.Match, err)
+	}
+	return re.MatchString(question), nil
+}
+
+// GetQuestionRules returns the question auto-answer rules for a repo
+func (c *Config) GetQuestionRules(repoPath string) []QuestionRule {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.RepoQuestionRules == nil {
+		return nil
+	}
+	resolved := resolveRepoPath(c.Repos, repoPath)
+	rules := c.RepoQuestionRules[resolved]
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]QuestionRule, len(rules))
+	copy(result, rules)
+	return result
+}
+
+// SetQuestionRules sets the question auto-answer rules for a repo
+func (c *Config) SetQuestionRules(repoPath string, rules []QuestionRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RepoQuestionRules == nil {
+		c.RepoQuestionRules = make(map[string][]QuestionRule)
+	}
+	resolved := resolveRepoPath(c.Repos, repoPath)
+	if len(rules) == 0 {
+		delete(c.RepoQuestionRules, resolved)
+		return
+	}
+	c.RepoQuestionRules[resolved] = rules
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQuestionRule_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     QuestionRule
+		question string
+		want     bool
+		wantErr  bool
+	}{
+		{"substring", QuestionRule{Match: "add tests"}, "Should I add tests?", true, false},
+		{"substring is case-insensitive", QuestionRule{Match: "ADD TESTS"}, "Should I add tests?", true, false},
+		{"substring no match", QuestionRule{Match: "add docs"}, "Should I add tests?", false, false},
+		{"regex", QuestionRule{Match: `^Should I (add|write) tests\?$`, Regex: true}, "Should I write tests?", true, false},
+		{"regex is case-sensitive", QuestionRule{Match: `^should`, Regex: true}, "Should I add tests?", false, false},
+		{"regex metacharacters are literal in substring rules", QuestionRule{Match: "tests?"}, "Should I add tests?", true, false},
+		{"invalid regex", QuestionRule{Match: `(unclosed`, Regex: true}, "anything", false, true},
+		{"empty match never matches", QuestionRule{Match: ""}, "Should I add tests?", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.Matches(tt.question)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Matches() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_QuestionRules(t *testing.T) {
+	cfg := &Config{
+		Repos:    []string{"/path/to/repo"},
+		Sessions: []Session{},
+	}
+
+	if rules := cfg.GetQuestionRules("/path/to/repo"); rules != nil {
+		t.Errorf("expected no rules by default, got %v", rules)
+	}
+
+	cfg.SetQuestionRules("/path/to/repo", []QuestionRule{{Match: "add tests", Answer: "Yes"}})
+	rules := cfg.GetQuestionRules("/path/to/repo")
+	if len(rules) != 1 || rules[0].Answer != "Yes" {
+		t.Fatalf("GetQuestionRules = %v, want one rule answering Yes", rules)
+	}
+
+	// Returned slice is a copy
+	rules[0].Answer = "No"
+	if cfg.GetQuestionRules("/path/to/repo")[0].Answer != "Yes" {
+		t.Error("modifying returned rules should not change the config")
+	}
+
+	if rules := cfg.GetQuestionRules("/other/repo"); rules != nil {
+		t.Errorf("expected no rules for other repo, got %v", rules)
+	}
+
+	cfg.SetQuestionRules("/path/to/repo", nil)
+	if _, exists := cfg.RepoQuestionRules["/path/to/repo"]; exists {
+		t.Error("clearing rules should remove the repo entry")
+	}
+}
+
+func TestConfig_QuestionRules_InvalidRegexLoads(t *testing.T) {
+	// A bad regex in a hand-edited config must not stop the config from loading;
+	// the rule is reported when it is evaluated instead.
+	data := []byte(`{
+		"repos": ["/path/to/repo"],
+		"sessions": [],
+		"repo_question_rules": {
+			"/path/to/repo": [
+				{"match": "(unclosed", "regex": true, "answer": "Yes"},
+				{"match": "add tests", "answer": "Yes"}
+			]
+		}
+	}`)
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	cfg.ensureInitialized()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	rules := cfg.GetQuestionRules("/path/to/repo")
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if _, err := rules[0].Matches("Should I add tests?"); err == nil {
+		t.Error("expected error for invalid regex rule")
+	}
+	if ok, err := rules[1].Matches("Should I add tests?"); err != nil || !ok {
+		t.Errorf("expected valid rule to match, got %v, %v", ok, err)
+	}
+}
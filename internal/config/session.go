@@ -1,10 +1,26 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+// MaxSessionTurnStats is the maximum number of per-turn stats retained per session.
+const MaxSessionTurnStats = 50
+
+// TurnStats records token and timing statistics for a single completed Claude turn.
+type TurnStats struct {
+	CompletedAt  time.Time `json:"completed_at"`
+	OutputTokens int       `json:"output_tokens"`
+	DurationMs   int       `json:"duration_ms,omitempty"`
+}
+
 // IssueRef represents a reference to an issue/task from any supported source.
 // This is the generic replacement for the deprecated IssueNumber field.
 type IssueRef struct {
@@ -14,6 +30,17 @@ type IssueRef struct {
 	URL    string `json:"url"`    // Link to the issue/task
 }
 
+// PRProgress records the artifacts of a PR creation attempt that stopped partway, so a
+// retry resumes from the failed step instead of re-pushing and re-generating.
+type PRProgress struct {
+	BaseBranch string `json:"base_branch,omitempty"` // Branch the PR targets
+	PushedSHA  string `json:"pushed_sha,omitempty"`  // Branch commit pushed to origin
+	Title      string `json:"title,omitempty"`       // Generated PR title
+	Body       string `json:"body,omitempty"`        // Generated PR body
+	FailedStep string `json:"failed_step,omitempty"` // Step that failed (empty while running)
+	Error      string `json:"error,omitempty"`       // Error message from the failed step
+}
+
 // Session represents a Claude Code conversation session with its own worktree
 type Session struct {
 	ID         string    `json:"id"`
@@ -42,6 +69,75 @@ type Session struct {
 	DaemonManaged    bool      `json:"daemon_managed,omitempty"`     // Whether this session is managed by the daemon (suppresses host tools and supervisor prompt)
 	SupervisorID     string    `json:"supervisor_id,omitempty"`      // ID of supervisor session (for child sessions)
 	ChildSessionIDs  []string  `json:"child_session_ids,omitempty"`  // IDs of child sessions (for supervisor sessions)
+	TurnStats        []TurnStats `json:"turn_stats,omitempty"`       // Most recent per-turn stats (bounded by MaxSessionTurnStats)
+	PRProgress       *PRProgress `json:"pr_progress,omitempty"`      // Artifacts of an unfinished PR creation (nil when none)
+	ContextFiles     []string    `json:"context_files,omitempty"`    // Worktree-relative paths re-sent to Claude whenever a fresh CLI session starts
+	MirrorOutput     bool        `json:"mirror_output,omitempty"`    // Whether the streaming response is mirrored to a file for external tools
+	AutoApprovePlans bool        `json:"auto_approve_plans,omitempty"` // Whether plans meeting the repo's plan approval criteria are approved automatically
+	LastActivity     time.Time   `json:"last_activity,omitzero"`       // When Claude last finished responding in this session (zero if never)
+	MergedUpstream   bool        `json:"merged_upstream,omitempty"`    // Whether the branch was found merged into the default branch outside Plural
+	Bookmarks        []MessageBookmark `json:"bookmarks,omitempty"`   // Messages flagged for later review
+	ExternalWrites   []string          `json:"external_writes,omitempty"` // Files Claude wrote outside the worktree, to review before merging
+	Pinned           bool              `json:"pinned,omitempty"`          // Shown in the sidebar's Pinned group, above all repo groups
+	Model            string            `json:"model,omitempty"`           // Model Claude runs as, passed to the CLI ("" for its default)
+	CLIVersion       string            `json:"cli_version,omitempty"`     // Claude CLI version the session's conversation last ran with ("" if unknown)
+	Tags             []string          `json:"tags,omitempty"`            // Free-form labels ("bug", "spike") for organizing sessions across repos
+}
+
+// MessageBookmark flags a message of a session's conversation for later review.
+type MessageBookmark struct {
+	Index int    `json:"index"` // Position of the message in the conversation when bookmarked
+	Hash  string `json:"hash"`  // MessageHash of the message, to find it again once older messages are trimmed from the saved history
+}
+
+// MessageHash returns a short hash identifying a message by its role and content.
+func MessageHash(role, content string) string {
+	sum := sha256.Sum256([]byte(role + "\x00" + content))
+	return hex.EncodeToString(sum[:8])
+}
+
+// IsSessionID returns whether id has the form of a session ID: a UUID in its
+// canonical, hyphenated form. Session IDs name worktree directories and message
+// files, so only these are safe to join onto a path.
+func IsSessionID(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil && len(id) == 36
+}
+
+// ShortIDLen is the length of the short form of a session ID.
+const ShortIDLen = 8
+
+// ShortID returns the short form of a session ID: its first ShortIDLen characters.
+func ShortID(id string) string {
+	if len(id) > ShortIDLen {
+		return id[:ShortIDLen]
+	}
+	return id
+}
+
+// CompareSessions orders sessions for programmatic output: by repo path, then
+// creation time, then ID, so every output lists them the same way on every run.
+func CompareSessions(a, b Session) int {
+	if c := strings.Compare(a.RepoPath, b.RepoPath); c != 0 {
+		return c
+	}
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// SortedSessions returns a copy of sessions in CompareSessions order.
+func SortedSessions(sessions []Session) []Session {
+	sorted := slices.Clone(sessions)
+	slices.SortFunc(sorted, CompareSessions)
+	return sorted
+}
+
+// IsMerged returns whether the session's work has landed on the default branch,
+// whether merged by Plural, through its PR, or found merged upstream.
+func (s *Session) IsMerged() bool {
+	return s.Merged || s.PRMerged || s.MergedUpstream
 }
 
 // GetIssueRef returns the IssueRef for this session, converting from legacy IssueNumber if needed.
@@ -158,6 +254,45 @@ func (c *Config) MarkSessionStarted(sessionID string) bool {
 	return false
 }
 
+// SetSessionLastActivity records when a session was last active
+func (c *Config) SetSessionLastActivity(sessionID string, t time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].LastActivity = t
+			return true
+		}
+	}
+	return false
+}
+
+// MostRecentSession returns a copy of the most recently active session, falling
+// back to creation time for sessions that have never been active. Returns nil if
+// there are no sessions.
+func (c *Config) MostRecentSession() *Session {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var latest *Session
+	var latestAt time.Time
+	for i := range c.Sessions {
+		at := c.Sessions[i].LastActivity
+		if at.IsZero() {
+			at = c.Sessions[i].CreatedAt
+		}
+		if latest == nil || at.After(latestAt) {
+			latest, latestAt = &c.Sessions[i], at
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	sess := *latest // copy
+	return &sess
+}
+
 // MarkSessionMerged marks a session as merged to main
 func (c *Config) MarkSessionMerged(sessionID string) bool {
 	c.mu.Lock()
@@ -186,6 +321,25 @@ func (c *Config) MarkSessionPRCreated(sessionID string) bool {
 	return false
 }
 
+// SetSessionPRProgress records the progress of an unfinished PR creation.
+// Pass nil to clear it once the PR is created.
+func (c *Config) SetSessionPRProgress(sessionID string, progress *PRProgress) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			if progress != nil {
+				p := *progress // copy so session copies never share a mutable value
+				progress = &p
+			}
+			c.Sessions[i].PRProgress = progress
+			return true
+		}
+	}
+	return false
+}
+
 // MarkSessionPRMerged marks a session's PR as merged on GitHub
 func (c *Config) MarkSessionPRMerged(sessionID string) bool {
 	c.mu.Lock()
@@ -200,6 +354,20 @@ func (c *Config) MarkSessionPRMerged(sessionID string) bool {
 	return false
 }
 
+// MarkSessionMergedUpstream marks a session's branch as found merged into the default branch
+func (c *Config) MarkSessionMergedUpstream(sessionID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].MergedUpstream = true
+			return true
+		}
+	}
+	return false
+}
+
 // MarkSessionPRClosed marks a session's PR as closed without merging on GitHub
 func (c *Config) MarkSessionPRClosed(sessionID string) bool {
 	c.mu.Lock()
@@ -243,6 +411,20 @@ func (c *Config) RenameSession(sessionID, newName, newBranch string) bool {
 	return false
 }
 
+// SessionNameTaken returns whether a session of the repo other than exceptID
+// is named name or is on a branch of that name
+func (c *Config) SessionNameTaken(repoPath, name, exceptID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, s := range c.Sessions {
+		if s.ID != exceptID && s.RepoPath == repoPath && (s.Name == name || s.Branch == name) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetSessionsByBroadcastGroup returns all sessions that belong to the given broadcast group
 func (c *Config) GetSessionsByBroadcastGroup(groupID string) []Session {
 	c.mu.RLock()
@@ -275,6 +457,40 @@ func (c *Config) SetSessionBroadcastGroup(sessionID, groupID string) bool {
 	return false
 }
 
+// CountSessionsWithBaseBranch returns how many sessions of a repo record baseBranch as their base
+func (c *Config) CountSessionsWithBaseBranch(repoPath, baseBranch string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := 0
+	for _, s := range c.Sessions {
+		if s.RepoPath == repoPath && s.BaseBranch == baseBranch {
+			count++
+		}
+	}
+	return count
+}
+
+// MigrateSessionsBaseBranch changes the base branch of every session of a repo from
+// oldBranch to newBranch, e.g. after the repo's default branch was renamed.
+// Returns the number of sessions updated.
+func (c *Config) MigrateSessionsBaseBranch(repoPath, oldBranch, newBranch string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if oldBranch == "" || oldBranch == newBranch {
+		return 0
+	}
+	count := 0
+	for i := range c.Sessions {
+		if c.Sessions[i].RepoPath == repoPath && c.Sessions[i].BaseBranch == oldBranch {
+			c.Sessions[i].BaseBranch = newBranch
+			count++
+		}
+	}
+	return count
+}
+
 // SetSessionAutonomous sets the autonomous mode for a session.
 func (c *Config) SetSessionAutonomous(sessionID string, autonomous bool) bool {
 	c.mu.Lock()
@@ -346,6 +562,142 @@ func (c *Config) UpdateSessionPRCommentCount(sessionID string, count int) bool {
 	return false
 }
 
+// AddSessionTurnStats appends stats for a completed turn, keeping only the most
+// recent MaxSessionTurnStats entries.
+func (c *Config) AddSessionTurnStats(sessionID string, stats TurnStats) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			// Clone so copies handed out by GetSession never observe the append
+			turns := append(slices.Clone(c.Sessions[i].TurnStats), stats)
+			if len(turns) > MaxSessionTurnStats {
+				turns = turns[len(turns)-MaxSessionTurnStats:]
+			}
+			c.Sessions[i].TurnStats = turns
+			return true
+		}
+	}
+	return false
+}
+
+// GetSessionContextFiles returns the context file paths of a session, relative to its worktree.
+func (c *Config) GetSessionContextFiles(sessionID string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, s := range c.Sessions {
+		if s.ID == sessionID {
+			return slices.Clone(s.ContextFiles)
+		}
+	}
+	return nil
+}
+
+// SetSessionContextFiles replaces the context file paths of a session.
+func (c *Config) SetSessionContextFiles(sessionID string, paths []string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].ContextFiles = slices.Clone(paths)
+			return true
+		}
+	}
+	return false
+}
+
+// SetSessionBookmarks replaces the bookmarked messages of a session.
+func (c *Config) SetSessionBookmarks(sessionID string, bookmarks []MessageBookmark) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].Bookmarks = slices.Clone(bookmarks)
+			return true
+		}
+	}
+	return false
+}
+
+// AddSessionExternalWrite records that Claude wrote path, outside the session's
+// worktree. Returns whether the path was newly recorded.
+func (c *Config) AddSessionExternalWrite(sessionID, path string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			if slices.Contains(c.Sessions[i].ExternalWrites, path) {
+				return false
+			}
+			c.Sessions[i].ExternalWrites = append(c.Sessions[i].ExternalWrites, path)
+			return true
+		}
+	}
+	return false
+}
+
+// SetSessionPinned sets whether a session is pinned above the sidebar's repo groups.
+func (c *Config) SetSessionPinned(sessionID string, pinned bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].Pinned = pinned
+			return true
+		}
+	}
+	return false
+}
+
+// SetSessionModel sets the model a session's Claude runs as, "" for the CLI's default.
+func (c *Config) SetSessionModel(sessionID, model string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].Model = model
+			return true
+		}
+	}
+	return false
+}
+
+// SetSessionMirrorOutput sets whether a session's streaming response is mirrored to a file.
+func (c *Config) SetSessionMirrorOutput(sessionID string, enabled bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].MirrorOutput = enabled
+			return true
+		}
+	}
+	return false
+}
+
+// SetSessionAutoApprovePlans sets whether a session's plans are approved automatically
+// when they meet the repo's plan approval criteria.
+func (c *Config) SetSessionAutoApprovePlans(sessionID string, enabled bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].AutoApprovePlans = enabled
+			return true
+		}
+	}
+	return false
+}
+
 // UpdateSessionPRCommentsAddressedCount updates the addressed PR comment count for a session.
 // This tracks the comment count at the time comments were last sent to Claude for addressing.
 func (c *Config) UpdateSessionPRCommentsAddressedCount(sessionID string, count int) bool {
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Snippet placeholders, expanded when a snippet is inserted
+const (
+	SnippetSelectionPlaceholder = "{selection}" // Text selected in the conversation
+	SnippetFilePlaceholder      = "{file}"      // A path the user is prompted for
+)
+
+// Snippet is a named prompt fragment that can be inserted into the chat input.
+type Snippet struct {
+	Name string `json:"name"` // Short name, typed after ";;" to find the snippet
+	Text string `json:"text"` // Text to insert, which may contain placeholders
+}
+
+// NeedsFile reports whether the snippet has a {file} placeholder to prompt for.
+func (s Snippet) NeedsFile() bool {
+	return strings.Contains(s.Text, SnippetFilePlaceholder)
+}
+
+// Expand returns the snippet text with {selection} and {file} replaced.
+func (s Snippet) Expand(selection, file string) string {
+	return strings.NewReplacer(
+		SnippetSelectionPlaceholder, selection,
+		SnippetFilePlaceholder, file,
+	).Replace(s.Text)
+}
+
+// ValidateSnippetName checks that name can be typed after ";;" to find a snippet.
+func ValidateSnippetName(name string) error {
+	if name == "" {
+		return fmt.Errorf("snippet name is required")
+	}
+	if strings.ContainsFunc(name, func(r rune) bool { return r == ' ' || r == '\t' || r == '\n' }) {
+		return fmt.Errorf("snippet name %q cannot contain spaces", name)
+	}
+	return nil
+}
+
+// GetSnippets returns the saved snippets
+func (c *Config) GetSnippets() []Snippet {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.Snippets) == 0 {
+		return nil
+	}
+	result := make([]Snippet, len(c.Snippets))
+	copy(result, c.Snippets)
+	return result
+}
+
+// SetSnippets replaces the saved snippets
+func (c *Config) SetSnippets(snippets []Snippet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Snippets = snippets
+}
@@ -0,0 +1,66 @@
+package config
+
+import "testing"
+
+func TestSnippet_Expand(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		selection string
+		file      string
+		want      string
+		needsFile bool
+	}{
+		{"no placeholders", "Explain the tradeoffs before coding", "ignored", "", "Explain the tradeoffs before coding", false},
+		{"selection", "Write table-driven tests for:\n{selection}", "func Add(a, b int) int", "", "Write table-driven tests for:\nfunc Add(a, b int) int", false},
+		{"empty selection", "Review {selection}", "", "", "Review ", false},
+		{"file", "Refactor {file} to use errgroup", "", "internal/app/app.go", "Refactor internal/app/app.go to use errgroup", true},
+		{"both, repeated", "{file}: {selection} ({file})", "sel", "a.go", "a.go: sel (a.go)", true},
+		{"placeholders in the selection are not expanded again", "Quote: {selection}", "{file}", "a.go", "Quote: {file}", false},
+		{"unknown placeholders are kept", "Use {lang}", "", "", "Use {lang}", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Snippet{Name: "s", Text: tt.text}
+			if got := s.Expand(tt.selection, tt.file); got != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+			if got := s.NeedsFile(); got != tt.needsFile {
+				t.Errorf("NeedsFile() = %v, want %v", got, tt.needsFile)
+			}
+		})
+	}
+}
+
+func TestValidateSnippetName(t *testing.T) {
+	for _, name := range []string{"tests", "explain-tradeoffs", "go.tests"} {
+		if err := ValidateSnippetName(name); err != nil {
+			t.Errorf("ValidateSnippetName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"", "two words", "tab\tname"} {
+		if err := ValidateSnippetName(name); err == nil {
+			t.Errorf("ValidateSnippetName(%q) = nil, want error", name)
+		}
+	}
+}
+
+func TestConfig_Snippets(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetSnippets(); got != nil {
+		t.Errorf("expected no snippets, got %v", got)
+	}
+
+	cfg.SetSnippets([]Snippet{{Name: "tests", Text: "Write tests"}})
+	got := cfg.GetSnippets()
+	if len(got) != 1 || got[0].Name != "tests" {
+		t.Fatalf("GetSnippets() = %v", got)
+	}
+
+	// The returned slice is a copy
+	got[0].Name = "changed"
+	if cfg.GetSnippets()[0].Name != "tests" {
+		t.Error("modifying the returned snippets should not change the config")
+	}
+}
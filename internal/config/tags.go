@@ -0,0 +1,60 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// NormalizeTags parses tags separated by commas or whitespace, as typed into
+// the tag editor. Tags are lowercased, stripped of a leading "#", and
+// deduplicated in the order given.
+func NormalizeTags(input string) []string {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	var tags []string
+	for _, f := range fields {
+		tag := strings.ToLower(strings.TrimLeft(f, "#"))
+		if tag == "" || slices.Contains(tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// HasTag returns whether the session is tagged with tag.
+func (s *Session) HasTag(tag string) bool {
+	return slices.Contains(s.Tags, tag)
+}
+
+// SetSessionTags replaces the tags of a session.
+func (c *Config) SetSessionTags(sessionID string, tags []string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == sessionID {
+			c.Sessions[i].Tags = slices.Clone(tags)
+			return true
+		}
+	}
+	return false
+}
+
+// GetAllTags returns every tag used by any session, sorted.
+func (c *Config) GetAllTags() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var tags []string
+	for _, sess := range c.Sessions {
+		for _, tag := range sess.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"bug", []string{"bug"}},
+		{"bug, feature spike", []string{"bug", "feature", "spike"}},
+		{"#Bug ,, #bug  BUG", []string{"bug"}},
+		{"  # ,", nil},
+	}
+	for _, tt := range tests {
+		if got := NormalizeTags(tt.input); !slices.Equal(got, tt.want) {
+			t.Errorf("NormalizeTags(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_SessionTags(t *testing.T) {
+	cfg := &Config{
+		Sessions: []Session{
+			{ID: "s1", Tags: []string{"spike"}},
+			{ID: "s2"},
+		},
+	}
+
+	if !cfg.SetSessionTags("s2", []string{"bug", "feature"}) {
+		t.Fatal("SetSessionTags returned false for an existing session")
+	}
+	if cfg.SetSessionTags("missing", []string{"bug"}) {
+		t.Error("SetSessionTags returned true for a missing session")
+	}
+	if sess := cfg.GetSession("s2"); !sess.HasTag("bug") || sess.HasTag("spike") {
+		t.Errorf("unexpected tags %v", sess.Tags)
+	}
+	if got := cfg.GetAllTags(); !slices.Equal(got, []string{"bug", "feature", "spike"}) {
+		t.Errorf("GetAllTags() = %v", got)
+	}
+
+	cfg.SetSessionTags("s1", nil)
+	if got := cfg.GetAllTags(); !slices.Equal(got, []string{"bug", "feature"}) {
+		t.Errorf("GetAllTags() after clearing = %v", got)
+	}
+}
@@ -0,0 +1,481 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Problem is one issue found while validating the config file.
+type Problem struct {
+	Path    string // JSON path of the offending value, e.g. sessions[2].started (empty for the whole file)
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+	return p.Path + ": " + p.Message
+}
+
+// ValidationError reports problems that prevent the config file from loading.
+type ValidationError struct {
+	File     string
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	file := e.File
+	if file == "" {
+		file = "config"
+	}
+	var sb strings.Builder
+	if len(e.Problems) == 1 {
+		fmt.Fprintf(&sb, "%s has a problem:", file)
+	} else {
+		fmt.Fprintf(&sb, "%s has %d problems:", file, len(e.Problems))
+	}
+	for _, p := range e.Problems {
+		sb.WriteString("\n  - " + p.String())
+	}
+	return sb.String()
+}
+
+// checkStructure checks raw config JSON against the shape of Config, collecting
+// every problem rather than stopping at the first. Syntax errors and type
+// mismatches are errors, since the file cannot be loaded; unknown top-level keys
+// are warnings, with the nearest valid key suggested.
+func checkStructure(data []byte) (errs, warnings []Problem) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, col := lineAndColumn(data, syntaxErr.Offset)
+			return []Problem{{Message: fmt.Sprintf("invalid JSON at line %d, column %d: %v", line, col, err)}}, nil
+		}
+		return []Problem{{Message: "invalid JSON: " + err.Error()}}, nil
+	}
+	if _, ok := raw.(map[string]any); !ok {
+		return []Problem{{Message: "expected an object at the top level, got " + describeJSON(raw)}}, nil
+	}
+
+	c := &checker{}
+	c.check("", raw, reflect.TypeFor[Config](), true)
+	return c.errs, c.warnings
+}
+
+// checker walks decoded JSON alongside the Go type it will be unmarshaled into.
+type checker struct {
+	errs     []Problem
+	warnings []Problem
+}
+
+var unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+func (c *checker) check(path string, v any, t reflect.Type, topLevel bool) {
+	if v == nil {
+		return // encoding/json leaves the zero value for null
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return // Types like time.Time validate their own encoding
+	}
+
+	mismatch := func(expected string) {
+		c.errs = append(c.errs, Problem{Path: path, Message: fmt.Sprintf("expected %s, got %s", expected, describeJSON(v))})
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			mismatch("text")
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			mismatch("true or false")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := v.(float64); !ok || n != math.Trunc(n) {
+			mismatch("a whole number")
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := v.(float64); !ok {
+			mismatch("a number")
+		}
+	case reflect.Slice:
+		items, ok := v.([]any)
+		if !ok {
+			mismatch("a list")
+			return
+		}
+		for i, item := range items {
+			c.check(fmt.Sprintf("%s[%d]", path, i), item, t.Elem(), false)
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			mismatch("an object")
+			return
+		}
+		for _, key := range sortedKeys(obj) {
+			c.check(fmt.Sprintf("%s[%q]", path, key), obj[key], t.Elem(), false)
+		}
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			mismatch("an object")
+			return
+		}
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(obj) {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			field, ok := lookupField(fields, key)
+			if !ok {
+				// Only top-level keys are hand-edited; older nested fields are left alone
+				if topLevel {
+					c.warnings = append(c.warnings, Problem{Path: fieldPath, Message: unknownKeyMessage(key, fields)})
+				}
+				continue
+			}
+			c.check(fieldPath, obj[key], field.Type, false)
+		}
+	}
+}
+
+// jsonFields returns a struct's exported fields by JSON name.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// lookupField finds the field for a JSON key, preferring an exact match and
+// falling back to a case-insensitive one like encoding/json does.
+func lookupField(fields map[string]reflect.StructField, key string) (reflect.StructField, bool) {
+	if f, ok := fields[key]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// unknownKeyMessage describes an unknown key, suggesting the nearest valid one
+// when it looks like a typo.
+func unknownKeyMessage(key string, fields map[string]reflect.StructField) string {
+	best, bestDist := "", math.MaxInt
+	for _, name := range sortedKeys(fields) {
+		if d := editDistance(key, name); d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+	if best != "" && bestDist <= max(2, len(key)/3) {
+		return fmt.Sprintf("unknown key (did you mean %q?)", best)
+	}
+	return "unknown key (ignored)"
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// describeJSON names the type of a decoded JSON value in plain language.
+func describeJSON(v any) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("text %q", v)
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case float64:
+		return fmt.Sprintf("the number %v", v)
+	case []any:
+		return "a list"
+	case map[string]any:
+		return "an object"
+	default:
+		return "null"
+	}
+}
+
+// lineAndColumn converts a byte offset in data to a 1-based line and column.
+func lineAndColumn(data []byte, offset int64) (line, col int) {
+	offset = min(offset, int64(len(data)))
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = int(offset) - (bytes.LastIndexByte(before, '\n') + 1)
+	return line, max(col, 1)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// semanticProblems checks settings that parse but would not work as intended,
+// such as MCP servers without a command or question rules whose regex does not
+// compile. These are warnings: the config still loads.
+func (c *Config) semanticProblems() []Problem {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var problems []Problem
+	add := func(path, format string, args ...any) {
+		problems = append(problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	checkMCP := func(path string, servers []MCPServer) {
+		seen := make(map[string]bool)
+		for i, s := range servers {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case s.Name == "":
+				add(p+".name", "MCP server has no name")
+			case seen[s.Name]:
+				add(p+".name", "duplicate MCP server name %q; only the first is used", s.Name)
+			}
+			seen[s.Name] = true
+			if strings.TrimSpace(s.Command) == "" {
+				add(p+".command", "MCP server %q has no command to run", s.Name)
+			}
+		}
+	}
+	checkMCP("mcp_servers", c.MCPServers)
+
+	isRepo := func(path string) bool {
+		return slices.ContainsFunc(c.Repos, func(r string) bool { return SamePath(r, path) })
+	}
+	checkRepoKeys := func(section string, keys []string) {
+		for _, repo := range keys {
+			if !isRepo(repo) {
+				add(fmt.Sprintf("%s[%q]", section, repo), "not a registered repo; these settings are unused")
+			}
+		}
+	}
+	checkRepoKeys("repo_mcp", sortedKeys(c.RepoMCP))
+	for _, repo := range sortedKeys(c.RepoMCP) {
+		checkMCP(fmt.Sprintf("repo_mcp[%q]", repo), c.RepoMCP[repo])
+	}
+	checkRepoKeys("repo_allowed_tools", sortedKeys(c.RepoAllowedTools))
+	checkRepoKeys("repo_denied_tools", sortedKeys(c.RepoDeniedTools))
+	checkRepoKeys("repo_squash_on_merge", sortedKeys(c.RepoSquashOnMerge))
+	checkRepoKeys("repo_asana_project", sortedKeys(c.RepoAsanaProject))
+	checkRepoKeys("repo_linear_team", sortedKeys(c.RepoLinearTeam))
+	checkRepoKeys("repo_container_image", sortedKeys(c.RepoContainerImage))
+	checkRepoKeys("repo_question_rules", sortedKeys(c.RepoQuestionRules))
+	checkRepoKeys("repo_plan_approval", sortedKeys(c.RepoPlanApproval))
+	checkRepoKeys("repo_pr_template", sortedKeys(c.RepoPRTemplate))
+	checkRepoKeys("repo_lfs_mode", sortedKeys(c.RepoLFSMode))
+	checkRepoKeys("repo_worktree_dir", sortedKeys(c.RepoWorktreeDir))
+	checkRepoKeys("repo_hooks", sortedKeys(c.RepoHooks))
+	checkRepoKeys("repo_provenance", sortedKeys(c.RepoProvenance))
+	checkRepoKeys("repo_destructive_commands", sortedKeys(c.RepoDestructiveCommands))
+
+	for _, repo := range sortedKeys(c.RepoQuestionRules) {
+		for i, rule := range c.RepoQuestionRules[repo] {
+			p := fmt.Sprintf("repo_question_rules[%q][%d]", repo, i)
+			if rule.Match == "" {
+				add(p+".match", "rule has no text to match, so it never applies")
+			} else if rule.Regex {
+				if _, err := regexp.Compile(rule.Match); err != nil {
+					add(p+".match", "invalid regular expression: %v", err)
+				}
+			}
+			if rule.Answer == "" {
+				add(p+".answer", "rule has no answer to select")
+			}
+		}
+	}
+
+	for _, repo := range sortedKeys(c.RepoDestructiveCommands) {
+		rules := c.RepoDestructiveCommands[repo]
+		checkPatterns := func(field string, patterns []string) {
+			for i, pattern := range patterns {
+				if _, err := regexp.Compile(pattern); err != nil {
+					add(fmt.Sprintf("repo_destructive_commands[%q].%s[%d]", repo, field, i), "invalid regular expression: %v", err)
+				}
+			}
+		}
+		checkPatterns("patterns", rules.Patterns)
+		checkPatterns("allow", rules.Allow)
+	}
+
+	oneOf := func(path, value string, allowed ...string) {
+		if value != "" && !slices.Contains(allowed, value) {
+			add(path, "unknown value %q; expected one of %s", value, strings.Join(allowed, ", "))
+		}
+	}
+	oneOf("paste_cleaning", c.PasteCleaning, PasteCleaningAsk, PasteCleaningAlways, PasteCleaningNever)
+	oneOf("cli_version_change", c.CLIVersionChange, CLIVersionChangeAsk, CLIVersionChangeWarn, CLIVersionChangeIgnore)
+	oneOf("clipboard", c.Clipboard, ClipboardAuto, ClipboardNative, ClipboardOSC52)
+	oneOf("quit_key_behavior", c.QuitKeyBehavior, QuitKeySidebarOnly, QuitKeyConfirm, QuitKeyDisabled, QuitKeyCtrlCOnly)
+	oneOf("auto_merge_method", c.AutoMergeMethod, "rebase", "squash", "merge")
+
+	nonNegative := func(path string, n int) {
+		if n < 0 {
+			add(path, "must not be negative (got %d)", n)
+		}
+	}
+	nonNegative("max_image_kb", c.MaxImageKB)
+	nonNegative("focus_minutes", c.FocusMinutes)
+	nonNegative("ci_log_lines", c.CILogLines)
+	nonNegative("command_output_lines", c.CommandOutputLines)
+	nonNegative("auto_max_turns", c.AutoMaxTurns)
+	nonNegative("auto_max_duration_min", c.AutoMaxDurationMin)
+	nonNegative("issue_max_concurrent", c.IssueMaxConcurrent)
+	nonNegative("max_concurrent_jobs", c.MaxConcurrentJobs)
+
+	for _, repo := range sortedKeys(c.RepoPlanApproval) {
+		criteria := c.RepoPlanApproval[repo]
+		p := fmt.Sprintf("repo_plan_approval[%q]", repo)
+		for i, prefix := range criteria.PathPrefixes {
+			if strings.Trim(prefix, " ./") == "" {
+				add(fmt.Sprintf("%s.path_prefixes[%d]", p, i), "prefix %q matches every file", prefix)
+			}
+		}
+		nonNegative(p+".max_plan_chars", criteria.MaxPlanChars)
+	}
+
+	for _, repo := range sortedKeys(c.RepoPRTemplate) {
+		oneOf(fmt.Sprintf("repo_pr_template[%q].mode", repo), c.RepoPRTemplate[repo].Mode, PRTemplateMerge, PRTemplateReplace)
+	}
+	for _, repo := range sortedKeys(c.RepoLFSMode) {
+		oneOf(fmt.Sprintf("repo_lfs_mode[%q]", repo), c.RepoLFSMode[repo], LFSModeFull, LFSModeSkip)
+	}
+	checkWorktreeDir := func(path, template string) {
+		if template == "" {
+			return
+		}
+		if _, err := ExpandWorktreeDir(template, "repo"); err != nil {
+			add(path, "%v; new sessions can't be created", err)
+		}
+	}
+	checkWorktreeDir("worktree_dir", c.WorktreeDir)
+	for _, repo := range sortedKeys(c.RepoWorktreeDir) {
+		checkWorktreeDir(fmt.Sprintf("repo_worktree_dir[%q]", repo), c.RepoWorktreeDir[repo])
+	}
+	for _, repo := range sortedKeys(c.RepoHooks) {
+		hooks := c.RepoHooks[repo]
+		checkCommands := func(name string, commands []string) {
+			for i, command := range commands {
+				if strings.TrimSpace(command) == "" {
+					add(fmt.Sprintf("repo_hooks[%q].%s[%d]", repo, name, i), "command is empty")
+				}
+			}
+		}
+		checkCommands("post_merge", hooks.PostMerge)
+		checkCommands("post_pr_create", hooks.PostPRCreate)
+	}
+
+	seenTemplates := make(map[string]bool)
+	for i, t := range c.PromptTemplates {
+		p := fmt.Sprintf("prompt_templates[%d]", i)
+		switch {
+		case t.Name == "":
+			add(p+".name", "prompt template has no name")
+		case seenTemplates[t.Name]:
+			add(p+".name", "duplicate prompt template name %q; only the first is used", t.Name)
+		}
+		seenTemplates[t.Name] = true
+		if strings.TrimSpace(t.Text) == "" {
+			add(p+".text", "prompt template %q has no text", t.Name)
+		}
+	}
+
+	return problems
+}
+
+// CheckTheme records a problem for each configured theme that is not one of
+// known. The UI owns the theme list, so it is checked after loading.
+func (c *Config) CheckTheme(known []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, setting := range []struct{ path, theme string }{
+		{"theme", c.Theme},
+		{"theme_dark", c.ThemeDark},
+		{"theme_light", c.ThemeLight},
+	} {
+		if setting.theme != "" && !slices.Contains(known, setting.theme) {
+			c.problems = append(c.problems, Problem{
+				Path:    setting.path,
+				Message: fmt.Sprintf("unknown theme %q; expected one of %s (using the default)", setting.theme, strings.Join(known, ", ")),
+			})
+		}
+	}
+}
+
+// CheckCustomSyntaxStyle records a problem if the custom syntax style file
+// failed to load; loadErr is the error from loading it, or nil.
+func (c *Config) CheckCustomSyntaxStyle(loadErr error) {
+	if loadErr == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.problems = append(c.problems, Problem{
+		Path:    "custom_syntax_style",
+		Message: fmt.Sprintf("%v (using monokai)", loadErr),
+	})
+}
+
+// Problems returns the non-fatal problems found in the config file when it was loaded.
+func (c *Config) Problems() []Problem {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.problems)
+}
+
+// FilePath returns the path the config is loaded from and saved to.
+func (c *Config) FilePath() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.filePath
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func problemStrings(problems []Problem) []string {
+	out := make([]string, len(problems))
+	for i, p := range problems {
+		out[i] = p.String()
+	}
+	return out
+}
+
+func TestLoad_ValidationFixtures(t *testing.T) {
+	tests := []struct {
+		file         string
+		wantErrs     []string // Substrings of each fatal problem, in order
+		wantWarnings []string // Substrings of each warning, in order
+	}{
+		{file: "valid.json"},
+		{
+			file:     "syntax.json",
+			wantErrs: []string{"invalid JSON at line 3, column 3"},
+		},
+		{
+			file:     "not_object.json",
+			wantErrs: []string{"expected an object at the top level, got a list"},
+		},
+		{
+			file: "types.json",
+			wantErrs: []string{
+				`max_image_kb: expected a whole number, got the number 1.5`,
+				`repo_squash_on_merge["/path/to/repo"]: expected true or false, got text "true"`,
+				`repos: expected a list, got text "/path/to/repo"`,
+				`sessions[0].issue_ref.id: expected text, got the number 42`,
+				`sessions[0].started: expected true or false, got text "yes"`,
+			},
+		},
+		{
+			file: "unknown_keys.json",
+			wantWarnings: []string{
+				`frobnicate: unknown key (ignored)`,
+				`notifications_enable: unknown key (did you mean "notifications_enabled"?)`,
+				`thme: unknown key (did you mean "theme"?)`,
+			},
+		},
+		{
+			file: "semantic.json",
+			wantWarnings: []string{
+				`mcp_servers[1].name: duplicate MCP server name "github"`,
+				`mcp_servers[2].name: MCP server has no name`,
+				`mcp_servers[2].command: MCP server "" has no command to run`,
+				`repo_linear_team["/path/to/removed"]: not a registered repo`,
+				`repo_question_rules["/path/to/repo"][0].match: invalid regular expression`,
+				`repo_question_rules["/path/to/repo"][1].match: rule has no text to match`,
+				`repo_question_rules["/path/to/repo"][1].answer: rule has no answer`,
+				`repo_destructive_commands["/path/to/repo"].patterns[1]: invalid regular expression`,
+				`repo_destructive_commands["/path/to/repo"].allow[0]: invalid regular expression`,
+				`paste_cleaning: unknown value "sometimes"; expected one of ask, always, never`,
+				`clipboard: unknown value "xclip"; expected one of auto, native, osc52`,
+				`quit_key_behavior: unknown value "never"; expected one of sidebar-only, confirm, disabled, ctrl-c-only`,
+				`auto_merge_method: unknown value "fast-forward"`,
+				`auto_max_turns: must not be negative (got -5)`,
+				`repo_plan_approval["/path/to/repo"].path_prefixes[1]: prefix "./" matches every file`,
+				`repo_plan_approval["/path/to/repo"].max_plan_chars: must not be negative (got -1)`,
+				`repo_pr_template["/path/to/repo"].mode: unknown value "append"; expected one of merge, replace`,
+			},
+		},
+		{
+			file: "inconsistent.json",
+			wantErrs: []string{
+				`sessions[1].id: duplicate session ID: dup`,
+				`sessions[1].branch: session dup has empty branch`,
+				`repos[1]: empty repo path found`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join("testdata", "validation", tt.file)
+			cfg, err := loadFrom(path)
+
+			if len(tt.wantErrs) > 0 {
+				var verr *ValidationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("expected *ValidationError, got %v", err)
+				}
+				if verr.File != path {
+					t.Errorf("File = %q, want %q", verr.File, path)
+				}
+				assertProblems(t, verr.Problems, tt.wantErrs)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("loadFrom() failed: %v", err)
+			}
+			assertProblems(t, cfg.Problems(), tt.wantWarnings)
+		})
+	}
+}
+
+func assertProblems(t *testing.T, problems []Problem, want []string) {
+	t.Helper()
+	got := problemStrings(problems)
+	if len(got) != len(want) {
+		t.Fatalf("got %d problems, want %d:\n  %s", len(got), len(want), strings.Join(got, "\n  "))
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Errorf("problem %d = %q, want it to contain %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := &ValidationError{File: "/home/me/.plural/config.json", Problems: []Problem{
+		{Path: "repos", Message: "expected a list, got text \"x\""},
+		{Message: "something else"},
+	}}
+	want := "/home/me/.plural/config.json has 2 problems:\n  - repos: expected a list, got text \"x\"\n  - something else"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestConfig_CheckTheme(t *testing.T) {
+	cfg := &Config{Theme: "nrod"}
+	cfg.CheckTheme([]string{"nord", "dracula"})
+	got := problemStrings(cfg.Problems())
+	if len(got) != 1 || !strings.Contains(got[0], `theme: unknown theme "nrod"`) {
+		t.Errorf("Problems() = %q, want an unknown theme problem", got)
+	}
+
+	cfg = &Config{Theme: "nord"}
+	cfg.CheckTheme([]string{"nord", "dracula"})
+	if len(cfg.Problems()) != 0 {
+		t.Errorf("expected no problems for a known theme, got %v", cfg.Problems())
+	}
+
+	cfg = &Config{Theme: "nord", ThemeDark: "dracula", ThemeLight: "sunny"}
+	cfg.CheckTheme([]string{"nord", "dracula"})
+	got = problemStrings(cfg.Problems())
+	if len(got) != 1 || !strings.Contains(got[0], `theme_light: unknown theme "sunny"`) {
+		t.Errorf("Problems() = %q, want an unknown theme_light problem", got)
+	}
+}
+
+func TestConfig_CheckCustomSyntaxStyle(t *testing.T) {
+	cfg := &Config{CustomSyntaxStyle: "/styles/mine.xml"}
+	cfg.CheckCustomSyntaxStyle(nil)
+	if len(cfg.Problems()) != 0 {
+		t.Errorf("expected no problems for a style that loaded, got %v", cfg.Problems())
+	}
+
+	cfg.CheckCustomSyntaxStyle(errors.New("invalid chroma style in /styles/mine.xml: missing style name attribute"))
+	got := problemStrings(cfg.Problems())
+	if len(got) != 1 || !strings.Contains(got[0], "custom_syntax_style: invalid chroma style") || !strings.Contains(got[0], "(using monokai)") {
+		t.Errorf("Problems() = %q, want a custom syntax style problem", got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"theme", "theme", 0},
+		{"thme", "theme", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Placeholders in a worktree_dir template.
+const (
+	WorktreeDirRepo    = "{repo}"    // Base name of the repo
+	WorktreeDirSession = "{session}" // Session ID, which names the worktree; may only end the template
+)
+
+// GetWorktreeDir returns the template of the directory new session worktrees
+// of a repo are created in: the repo's own, else the global one, or "" for
+// the default worktrees directory.
+func (c *Config) GetWorktreeDir(repoPath string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if dir := c.RepoWorktreeDir[resolveRepoPath(c.Repos, repoPath)]; dir != "" {
+		return dir
+	}
+	return c.WorktreeDir
+}
+
+// ExpandWorktreeDir returns the directory a worktree_dir template puts the
+// worktrees of a repo's sessions in, each in a directory named after the
+// session: "~" is expanded and {repo} replaced, and a {session} ending the
+// template dropped. The result must be an absolute path.
+func ExpandWorktreeDir(template, repoPath string) (string, error) {
+	dir := strings.TrimSuffix(filepath.Clean(template), string(filepath.Separator)+WorktreeDirSession)
+	if strings.Contains(dir, WorktreeDirSession) {
+		return "", fmt.Errorf("%s may only end the worktree directory %q", WorktreeDirSession, template)
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expanding ~ in the worktree directory: %w", err)
+		}
+		dir = filepath.Join(home, dir[1:])
+	}
+	dir = strings.ReplaceAll(dir, WorktreeDirRepo, filepath.Base(repoPath))
+	if !filepath.IsAbs(dir) {
+		return "", fmt.Errorf("the worktree directory %q must be an absolute path or start with ~", template)
+	}
+	return dir, nil
+}
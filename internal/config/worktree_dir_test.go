@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfig_GetWorktreeDir(t *testing.T) {
+	cfg := &Config{
+		Repos:           []string{"/path/to/repo", "/path/to/other"},
+		Sessions:        []Session{},
+		WorktreeDir:     "~/worktrees/{repo}/{session}",
+		RepoWorktreeDir: map[string]string{"/path/to/other": "/mnt/fast/{repo}"},
+	}
+
+	if got := cfg.GetWorktreeDir("/path/to/repo"); got != "~/worktrees/{repo}/{session}" {
+		t.Errorf("expected the global worktree_dir, got %q", got)
+	}
+	if got := cfg.GetWorktreeDir("/path/to/other"); got != "/mnt/fast/{repo}" {
+		t.Errorf("expected the repo's own worktree dir, got %q", got)
+	}
+	cfg.WorktreeDir = ""
+	if got := cfg.GetWorktreeDir("/path/to/repo"); got != "" {
+		t.Errorf("expected no worktree dir by default, got %q", got)
+	}
+}
+
+func TestExpandWorktreeDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	tests := []struct {
+		template string
+		want     string
+		wantErr  string
+	}{
+		{template: "~/.local/share/plural/worktrees/{repo}/{session}", want: filepath.Join(home, ".local/share/plural/worktrees/api")},
+		{template: "/mnt/fast/{repo}", want: "/mnt/fast/api"},
+		{template: "/mnt/fast/", want: "/mnt/fast"},
+		{template: "/mnt/{session}/work", wantErr: "may only end"},
+		{template: "worktrees/{repo}", wantErr: "absolute path"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandWorktreeDir(tt.template, "/code/api")
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ExpandWorktreeDir(%q) error = %v, want one containing %q", tt.template, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ExpandWorktreeDir(%q) = %q, %v; want %q", tt.template, got, err, tt.want)
+		}
+	}
+}
+
+func TestConfig_WorktreeDirValidation(t *testing.T) {
+	cfg := &Config{
+		Repos:           []string{"/path/to/repo"},
+		Sessions:        []Session{},
+		WorktreeDir:     "relative/{session}",
+		RepoWorktreeDir: map[string]string{"/path/to/repo": "/ok/{repo}/{session}"},
+	}
+
+	problems := problemStrings(cfg.semanticProblems())
+	if len(problems) != 1 || !strings.Contains(problems[0], "worktree_dir: ") || !strings.Contains(problems[0], "absolute path") {
+		t.Errorf("expected a relative worktree_dir to be reported, got %q", problems)
+	}
+}
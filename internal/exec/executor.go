@@ -6,6 +6,7 @@ package exec
 import (
 	"bytes"
 	"context"
+	"io"
 	"os/exec"
 	"sync"
 )
@@ -22,6 +23,11 @@ type CommandExecutor interface {
 	// CombinedOutput executes a command and returns combined stdout+stderr.
 	CombinedOutput(ctx context.Context, dir string, name string, args ...string) ([]byte, error)
 
+	// StreamCombinedOutput executes a command like CombinedOutput, also calling
+	// onLine with each line of output as it is written. Progress meters that
+	// redraw a line with "\r" report each redraw as a line.
+	StreamCombinedOutput(ctx context.Context, dir string, onLine func(string), name string, args ...string) ([]byte, error)
+
 	// Start starts a command without waiting for it to complete.
 	// Returns a CommandHandle that can be used to wait for completion.
 	Start(ctx context.Context, dir string, name string, args ...string) (CommandHandle, error)
@@ -51,6 +57,7 @@ func NewRealExecutor() *RealExecutor {
 func (e *RealExecutor) Run(ctx context.Context, dir string, name string, args ...string) (stdout, stderr []byte, err error) {
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Dir = dir
+	killGroupOnCancel(cmd)
 
 	var stdoutBuf, stderrBuf bytes.Buffer
 	cmd.Stdout = &stdoutBuf
@@ -64,6 +71,7 @@ func (e *RealExecutor) Run(ctx context.Context, dir string, name string, args ..
 func (e *RealExecutor) Output(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Dir = dir
+	killGroupOnCancel(cmd)
 	return cmd.Output()
 }
 
@@ -71,9 +79,55 @@ func (e *RealExecutor) Output(ctx context.Context, dir string, name string, args
 func (e *RealExecutor) CombinedOutput(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Dir = dir
+	killGroupOnCancel(cmd)
 	return cmd.CombinedOutput()
 }
 
+// StreamCombinedOutput executes a command and returns combined stdout+stderr,
+// calling onLine with each line as it is written.
+func (e *RealExecutor) StreamCombinedOutput(ctx context.Context, dir string, onLine func(string), name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	killGroupOnCancel(cmd)
+
+	var buf bytes.Buffer
+	lines := &lineWriter{onLine: onLine}
+	// The same writer for both makes exec write to it from one goroutine at a time
+	out := io.MultiWriter(&buf, lines)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	err := cmd.Run()
+	lines.flush()
+	return buf.Bytes(), err
+}
+
+// lineWriter calls onLine with each non-empty line written to it, splitting on
+// both "\n" and "\r".
+type lineWriter struct {
+	onLine  func(string)
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b == '\n' || b == '\r' {
+			w.flush()
+			continue
+		}
+		w.partial = append(w.partial, b)
+	}
+	return len(p), nil
+}
+
+// flush reports the unterminated line written so far, if any.
+func (w *lineWriter) flush() {
+	if len(w.partial) > 0 && w.onLine != nil {
+		w.onLine(string(w.partial))
+	}
+	w.partial = w.partial[:0]
+}
+
 // Start starts a command without waiting for it to complete.
 func (e *RealExecutor) Start(ctx context.Context, dir string, name string, args ...string) (CommandHandle, error) {
 	cmd := exec.CommandContext(ctx, name, args...)
@@ -289,6 +343,26 @@ func (e *MockExecutor) CombinedOutput(ctx context.Context, dir string, name stri
 	return nil, nil
 }
 
+// StreamCombinedOutput executes a mocked command, reporting each line of the
+// mocked combined output to onLine.
+func (e *MockExecutor) StreamCombinedOutput(ctx context.Context, dir string, onLine func(string), name string, args ...string) ([]byte, error) {
+	e.recordCall(dir, name, args)
+
+	if resp := e.findMatch(dir, name, args); resp != nil {
+		combined := append(append([]byte(nil), resp.Stdout...), resp.Stderr...)
+		lines := &lineWriter{onLine: onLine}
+		lines.Write(combined)
+		lines.flush()
+		return combined, resp.Err
+	}
+
+	if e.fallback != nil {
+		return e.fallback.StreamCombinedOutput(ctx, dir, onLine, name, args...)
+	}
+
+	return nil, nil
+}
+
 // Start starts a mocked command (returns immediately with buffered response).
 func (e *MockExecutor) Start(ctx context.Context, dir string, name string, args ...string) (CommandHandle, error) {
 	e.recordCall(dir, name, args)
@@ -3,6 +3,7 @@ package exec
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 	"testing"
 )
@@ -50,6 +51,26 @@ func TestRealExecutor_CombinedOutput(t *testing.T) {
 	}
 }
 
+func TestRealExecutor_StreamCombinedOutput(t *testing.T) {
+	executor := NewRealExecutor()
+	ctx := context.Background()
+
+	var lines []string
+	output, err := executor.StreamCombinedOutput(ctx, "", func(line string) {
+		lines = append(lines, line)
+	}, "sh", "-c", `printf 'one\n 50%%\r100%%\n' && printf 'two' >&2`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(output) != "one\n 50%\r100%\ntwo" {
+		t.Errorf("unexpected output %q", string(output))
+	}
+	want := []string{"one", " 50%", "100%", "two"}
+	if !slices.Equal(lines, want) {
+		t.Errorf("expected lines %q, got %q", want, lines)
+	}
+}
+
 func TestMockExecutor_Run(t *testing.T) {
 	mock := NewMockExecutor(nil)
 
@@ -184,6 +205,35 @@ func TestMockExecutor_CombinedOutput(t *testing.T) {
 	}
 }
 
+func TestMockExecutor_StreamCombinedOutput(t *testing.T) {
+	mock := NewMockExecutor(nil)
+
+	mock.AddExactMatch("cmd", []string{"test"}, MockResponse{
+		Stdout: []byte("first\r\nsecond\n"),
+		Stderr: []byte("third"),
+		Err:    errors.New("failed"),
+	})
+
+	var lines []string
+	output, err := mock.StreamCombinedOutput(context.Background(), "", func(line string) {
+		lines = append(lines, line)
+	}, "cmd", "test")
+
+	if err == nil || err.Error() != "failed" {
+		t.Errorf("expected the mocked error, got %v", err)
+	}
+	if string(output) != "first\r\nsecond\nthird" {
+		t.Errorf("unexpected output %q", string(output))
+	}
+	want := []string{"first", "second", "third"}
+	if !slices.Equal(lines, want) {
+		t.Errorf("expected lines %q, got %q", want, lines)
+	}
+	if calls := mock.GetCalls(); len(calls) != 1 || calls[0].Name != "cmd" {
+		t.Errorf("expected the call to be recorded, got %+v", calls)
+	}
+}
+
 func TestMockExecutor_Start(t *testing.T) {
 	mock := NewMockExecutor(nil)
 
@@ -0,0 +1,18 @@
+//go:build !unix
+
+package exec
+
+import (
+	"os/exec"
+	"time"
+)
+
+// waitDelay bounds how long Wait waits for output after a cancelled command is
+// killed, in case its children still hold the pipes.
+const waitDelay = 2 * time.Second
+
+// killGroupOnCancel bounds the wait for a cancelled cmd. There are no process
+// groups to kill here, so only cmd itself is killed.
+func killGroupOnCancel(cmd *exec.Cmd) {
+	cmd.WaitDelay = waitDelay
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package exec
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// waitDelay bounds how long Wait waits for output after a cancelled command is
+// killed, in case something outside its process group still holds the pipes.
+const waitDelay = 2 * time.Second
+
+// killGroupOnCancel runs cmd in its own process group and, when its context is
+// done, kills the whole group rather than just cmd. A shell's children (sleep
+// in "sleep 60 | cat") would otherwise outlive it and keep the output pipes
+// open, so Wait would block until they exited anyway.
+func killGroupOnCancel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = waitDelay
+}
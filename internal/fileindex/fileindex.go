@@ -0,0 +1,126 @@
+// Package fileindex lists the files of a session's worktree for the file picker,
+// skipping what .gitignore files ignore, and fuzzy-searches their paths.
+package fileindex
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// MaxFiles bounds how many files Build lists, so a huge checkout can't stall
+// the picker or hold on to unbounded memory.
+const MaxFiles = 200_000
+
+// Index is the file list of a worktree. It is not modified after Build, so it
+// can be searched from any goroutine.
+type Index struct {
+	Root      string
+	Paths     []string // Slash-separated paths relative to Root, sorted
+	Truncated bool     // The walk stopped at the file limit
+}
+
+// Build walks root and lists its files, up to maxFiles. Directories and files
+// ignored by the .gitignore files found on the way are skipped, as is .git.
+func Build(root string, maxFiles int) (*Index, error) {
+	if _, err := os.Stat(root); err != nil {
+		return nil, err
+	}
+	ix := &Index{Root: root}
+	ix.walk("", nil, maxFiles)
+	slices.Sort(ix.Paths)
+	return ix, nil
+}
+
+// walk lists the files under dir, a slash-separated path relative to the root,
+// with the ignore rules of its parents. Returns false once the limit is reached.
+// Unreadable directories are skipped.
+func (ix *Index) walk(dir string, rules []ignoreRule, maxFiles int) bool {
+	entries, err := os.ReadDir(filepath.Join(ix.Root, filepath.FromSlash(dir)))
+	if err != nil {
+		return true
+	}
+	if data, err := os.ReadFile(filepath.Join(ix.Root, filepath.FromSlash(dir), ".gitignore")); err == nil {
+		// Clipped so appending doesn't write into the parent's rules
+		rules = append(slices.Clip(rules), parseIgnore(string(data), dir)...)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		rel := path.Join(dir, name)
+		isDir := entry.IsDir()
+		if (isDir && name == ".git") || ignored(rules, rel, isDir) {
+			continue
+		}
+		if isDir {
+			if !ix.walk(rel, rules, maxFiles) {
+				return false
+			}
+			continue
+		}
+		if len(ix.Paths) >= maxFiles {
+			ix.Truncated = true
+			return false
+		}
+		ix.Paths = append(ix.Paths, rel)
+	}
+	return true
+}
+
+// Search returns up to limit paths matching query, best first. Characters of
+// the query must appear in the path in order; matches at the start of a path
+// segment or of the file name, and consecutive matches, rank higher. An empty
+// query returns the first paths in order.
+func (ix *Index) Search(query string, limit int) []string {
+	if query == "" {
+		return slices.Clone(ix.Paths[:min(limit, len(ix.Paths))])
+	}
+	// Scoring is much slower than checking for the characters in order, which
+	// rules out most paths. The check only folds ASCII case.
+	candidates := ix.Paths
+	if isASCII(query) {
+		candidates = nil
+		lowerQuery := strings.ToLower(query)
+		for _, p := range ix.Paths {
+			if containsInOrder(p, lowerQuery) {
+				candidates = append(candidates, p)
+			}
+		}
+	}
+	matches := fuzzy.Find(query, candidates)
+	results := make([]string, 0, min(limit, len(matches)))
+	for _, match := range matches[:min(limit, len(matches))] {
+		results = append(results, match.Str)
+	}
+	return results
+}
+
+// isASCII returns whether s is all ASCII.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// containsInOrder returns whether the bytes of lowerQuery appear in s in order,
+// ignoring ASCII case.
+func containsInOrder(s, lowerQuery string) bool {
+	j := 0
+	for i := 0; i < len(s) && j < len(lowerQuery); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c == lowerQuery[j] {
+			j++
+		}
+	}
+	return j == len(lowerQuery)
+}
@@ -0,0 +1,174 @@
+package fileindex
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeTree creates files (slash-separated paths to contents) under a temp dir.
+func writeTree(t testing.TB, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestBuild_RespectsGitignore(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".gitignore":             "# build output\n/dist\n*.log\nnode_modules/\n!keep.log\n",
+		".git/HEAD":              "ref: refs/heads/main\n",
+		"main.go":                "",
+		"debug.log":              "",
+		"keep.log":               "",
+		"dist/app.js":            "",
+		"web/dist/index.html":    "",
+		"web/node_modules/x.js":  "",
+		"web/.gitignore":         "generated/\n",
+		"web/generated/api.ts":   "",
+		"web/src/app.ts":         "",
+		"docs/generated/page.md": "",
+	})
+
+	ix, err := Build(root, MaxFiles)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	want := []string{
+		".gitignore",
+		"docs/generated/page.md", // web/.gitignore only applies under web
+		"keep.log",
+		"main.go",
+		"web/.gitignore",
+		"web/dist/index.html", // /dist is anchored to the root
+		"web/src/app.ts",
+	}
+	if !slices.Equal(ix.Paths, want) {
+		t.Errorf("Paths = %v, want %v", ix.Paths, want)
+	}
+	if ix.Truncated {
+		t.Error("expected the index not truncated")
+	}
+}
+
+func TestBuild_StopsAtLimit(t *testing.T) {
+	files := make(map[string]string)
+	for i := range 10 {
+		files[fmt.Sprintf("pkg/file%d.go", i)] = ""
+	}
+	ix, err := Build(writeTree(t, files), 4)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(ix.Paths) != 4 || !ix.Truncated {
+		t.Errorf("got %d paths, truncated=%v; want 4, truncated", len(ix.Paths), ix.Truncated)
+	}
+
+	if _, err := Build(filepath.Join(t.TempDir(), "gone"), MaxFiles); err == nil {
+		t.Error("expected an error for a missing root")
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"docs/*.md", "docs/a.md", true},
+		{"docs/*.md", "docs/sub/a.md", false},
+		{"**/testdata", "a/b/testdata", true},
+		{"**/testdata", "testdata", true},
+		{"vendor/**", "vendor/x/y.go", true},
+		{"a/**/z", "a/z", true},
+		{"a/**/z", "a/b/c/z", true},
+		{"a/**/z", "a/b/c/y", false},
+	}
+	for _, tt := range tests {
+		rules := parseIgnore(tt.pattern, "")
+		if got := ignored(rules, tt.path, false); got != tt.want {
+			t.Errorf("%q matching %q = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSearch_RanksFileNameMatches(t *testing.T) {
+	ix := &Index{Paths: []string{
+		"internal/app/app.go",
+		"internal/app/modal_handlers.go",
+		"internal/ui/modal.go",
+		"README.md",
+	}}
+	got := ix.Search("modal", 10)
+	if len(got) != 2 || got[0] != "internal/ui/modal.go" {
+		t.Errorf("Search(modal) = %v, want modal.go first", got)
+	}
+	if got := ix.Search("", 2); !slices.Equal(got, ix.Paths[:2]) {
+		t.Errorf("Search(\"\") = %v, want the first paths", got)
+	}
+	if got := ix.Search("readme", 10); !slices.Equal(got, []string{"README.md"}) {
+		t.Errorf("Search(readme) = %v, want a case-insensitive match", got)
+	}
+	if got := ix.Search("zzz", 10); len(got) != 0 {
+		t.Errorf("Search(zzz) = %v, want no matches", got)
+	}
+}
+
+// syntheticTree creates a repo-like tree of about n files in nested packages,
+// with a .gitignore excluding a build directory.
+func syntheticTree(b *testing.B, n int) string {
+	b.Helper()
+	root := b.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("build/\n*.tmp\n"), 0644); err != nil {
+		b.Fatal(err)
+	}
+	const perDir = 50
+	for i := range n / perDir {
+		dir := filepath.Join(root, fmt.Sprintf("module%02d", i%20), fmt.Sprintf("pkg%03d", i))
+		if i%10 == 0 {
+			dir = filepath.Join(root, "build", fmt.Sprintf("out%03d", i))
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.Fatal(err)
+		}
+		for j := range perDir {
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("handler_%03d.go", j)), nil, 0644); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	return root
+}
+
+// BenchmarkBuild_50k indexes a synthetic 50k-file tree.
+func BenchmarkBuild_50k(b *testing.B) {
+	root := syntheticTree(b, 50_000)
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := Build(root, MaxFiles); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSearch_50k searches the index of a synthetic 50k-file tree, as on
+// each keystroke in the picker.
+func BenchmarkSearch_50k(b *testing.B) {
+	ix, err := Build(syntheticTree(b, 50_000), MaxFiles)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for b.Loop() {
+		ix.Search("mod07hand42", 50)
+	}
+}
@@ -0,0 +1,106 @@
+package fileindex
+
+import (
+	"path"
+	"strings"
+)
+
+// ignoreRule is one pattern of a .gitignore file.
+type ignoreRule struct {
+	base     string // Directory of the .gitignore, relative to the root ("" for the root)
+	pattern  string
+	negate   bool // "!pattern" re-includes what an earlier rule ignored
+	dirOnly  bool // "pattern/" only matches directories
+	anchored bool // Contains a slash, so matches the path from base rather than any name
+}
+
+// parseIgnore parses the contents of the .gitignore in directory base.
+func parseIgnore(data, base string) []ignoreRule {
+	var rules []ignoreRule
+	for line := range strings.SplitSeq(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		// Trailing spaces are ignored unless escaped
+		if trimmed := strings.TrimRight(line, " "); !strings.HasSuffix(trimmed, "\\") {
+			line = trimmed
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, "\\") // Escaped leading "#" or "!"
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// ignored returns whether the rules ignore relPath, a slash-separated path from
+// the root. As in git, the last matching rule wins.
+func ignored(rules []ignoreRule, relPath string, isDir bool) bool {
+	result := false
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].matches(relPath, isDir) {
+			result = !rules[i].negate
+			break
+		}
+	}
+	return result
+}
+
+// matches returns whether the rule matches relPath, a slash-separated path from the root.
+func (r ignoreRule) matches(relPath string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	rel := relPath
+	if r.base != "" {
+		var ok bool
+		if rel, ok = strings.CutPrefix(relPath, r.base+"/"); !ok {
+			return false
+		}
+	}
+	if !r.anchored {
+		ok, _ := path.Match(r.pattern, path.Base(rel))
+		return ok
+	}
+	return globMatch(strings.Split(r.pattern, "/"), strings.Split(rel, "/"))
+}
+
+// globMatch matches path segments against pattern segments, where a "**"
+// segment matches any number of segments.
+func globMatch(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(segments); i++ {
+				if globMatch(rest, segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
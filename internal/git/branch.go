@@ -104,15 +104,75 @@ func (s *GitService) GetDefaultBranch(ctx context.Context, repoPath string) stri
 		}
 	}
 
-	// Fallback: check if main exists, otherwise use master
+	// Fallback: check if main or master exists
 	_, _, err = s.executor.Run(ctx, repoPath, "git", "rev-parse", "--verify", "main")
 	if err == nil {
 		return "main"
 	}
 
+	_, _, err = s.executor.Run(ctx, repoPath, "git", "rev-parse", "--verify", "master")
+	if err == nil {
+		return "master"
+	}
+
+	// Neither exists, as in a repo started on another branch, which may have no
+	// commits yet: use the branch checked out in the repo
+	if output, err := s.executor.Output(ctx, repoPath, "git", "symbolic-ref", "--short", "HEAD"); err == nil {
+		if branch := strings.TrimSpace(string(output)); branch != "" {
+			return branch
+		}
+	}
+
 	return "master"
 }
 
+// hasCommits returns whether the repo's HEAD has any commits. A freshly
+// initialized repo has none until its first commit.
+func (s *GitService) hasCommits(ctx context.Context, repoPath string) bool {
+	_, _, err := s.executor.Run(ctx, repoPath, "git", "rev-parse", "--verify", "--quiet", "HEAD")
+	return err == nil
+}
+
+// unbornBranch returns the branch HEAD points at if it has no commits yet, as
+// in a freshly initialized repo, or "" otherwise.
+func (s *GitService) unbornBranch(ctx context.Context, repoPath string) string {
+	if s.hasCommits(ctx, repoPath) {
+		return ""
+	}
+	output, err := s.executor.Output(ctx, repoPath, "git", "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// RefreshDefaultBranch re-resolves the default branch from origin, so a default branch
+// renamed upstream (e.g. master -> main) is picked up instead of the stale origin/HEAD
+// recorded at clone time. It prunes deleted remote branches and updates origin/HEAD,
+// then returns GetDefaultBranch. Without an origin, or if origin is unreachable, the
+// locally known default is returned.
+func (s *GitService) RefreshDefaultBranch(ctx context.Context, repoPath string) string {
+	if s.HasRemoteOrigin(ctx, repoPath) {
+		log := logger.WithComponent("git")
+		if output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "fetch", "--prune", "origin"); err != nil {
+			log.Warn("failed to fetch origin while resolving default branch", "repoPath", repoPath, "error", err, "output", strings.TrimSpace(string(output)))
+		} else if output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "remote", "set-head", "origin", "--auto"); err != nil {
+			log.Warn("failed to update origin/HEAD", "repoPath", repoPath, "error", err, "output", strings.TrimSpace(string(output)))
+		}
+	}
+	return s.GetDefaultBranch(ctx, repoPath)
+}
+
+// BaseBranchGone returns whether baseBranch no longer exists on origin, e.g. because
+// the repo's default branch was renamed. Returns false for repos without an origin.
+// Reflects the last fetch; call RefreshDefaultBranch first for an up-to-date answer.
+func (s *GitService) BaseBranchGone(ctx context.Context, repoPath, baseBranch string) bool {
+	if baseBranch == "" || !s.HasRemoteOrigin(ctx, repoPath) {
+		return false
+	}
+	return !s.RemoteBranchExists(ctx, repoPath, "refs/remotes/origin/"+baseBranch)
+}
+
 // GetBranchDivergence returns how many commits the local branch is behind and ahead
 // of the remote branch. Uses git rev-list --count --left-right which outputs "behind\tahead".
 // Returns an error if either branch doesn't exist or comparison fails.
@@ -158,6 +218,28 @@ func (s *GitService) RemoteBranchExists(ctx context.Context, repoPath, remoteBra
 	return err == nil
 }
 
+// ListRemoteBranches returns the branch names on origin (without the "origin/" prefix),
+// as listed by git branch -r. The symbolic origin/HEAD entry is skipped.
+func (s *GitService) ListRemoteBranches(ctx context.Context, repoPath string) ([]string, error) {
+	output, err := s.executor.Output(ctx, repoPath, "git", "branch", "-r")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote branches: %w", err)
+	}
+
+	var branches []string
+	for line := range strings.SplitSeq(string(output), "\n") {
+		// Lines look like "  origin/main" or "  origin/HEAD -> origin/main"
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Contains(line, " -> ") {
+			continue
+		}
+		if name, ok := strings.CutPrefix(line, "origin/"); ok && name != "" {
+			branches = append(branches, name)
+		}
+	}
+	return branches, nil
+}
+
 // RenameBranch renames a git branch in the given worktree.
 // The worktree must have the branch checked out.
 func (s *GitService) RenameBranch(ctx context.Context, worktreePath, oldName, newName string) error {
@@ -170,11 +252,14 @@ func (s *GitService) RenameBranch(ctx context.Context, worktreePath, oldName, ne
 	return nil
 }
 
-// GetCurrentBranch returns the name of the currently checked out branch in the given repo/worktree.
-// Returns an error if HEAD is detached or the command fails.
+// GetCurrentBranch returns the name of the currently checked out branch in the given repo/worktree,
+// including a branch that has no commits yet. Returns an error if HEAD is detached or the command fails.
 func (s *GitService) GetCurrentBranch(ctx context.Context, repoPath string) (string, error) {
 	output, err := s.executor.Output(ctx, repoPath, "git", "rev-parse", "--abbrev-ref", "HEAD")
 	if err != nil {
+		if branch := s.unbornBranch(ctx, repoPath); branch != "" {
+			return branch, nil
+		}
 		return "", fmt.Errorf("failed to get current branch: %w", err)
 	}
 
@@ -0,0 +1,157 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CIRun is a GitHub Actions workflow run with failed checks.
+type CIRun struct {
+	ID      string
+	Attempt int      // Which attempt of the run, counting re-runs from 1 (0 if unknown)
+	Checks  []string // Names of the run's failed checks
+}
+
+// CIJobLog is the tail of a failed job's log.
+type CIJobLog struct {
+	RunID   string
+	Job     string
+	Lines   []string
+	Omitted int // Lines cut from the start of the log
+}
+
+// actionsRunPattern extracts the run ID from a GitHub Actions check link, e.g.
+// https://github.com/owner/repo/actions/runs/123/job/456.
+var actionsRunPattern = regexp.MustCompile(`/actions/runs/(\d+)`)
+
+// logTimestampPattern matches the timestamp GitHub prefixes to each log line.
+var logTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+
+// ghError turns a failed gh call into an error saying what to do about it.
+func ghError(action string, stderr []byte, err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%s: gh CLI not found; install it from https://cli.github.com", action)
+	}
+	msg := strings.TrimSpace(string(stderr))
+	switch {
+	case strings.Contains(msg, "gh auth login"), strings.Contains(msg, "HTTP 401"):
+		return fmt.Errorf("%s: gh is not logged in; run gh auth login", action)
+	case strings.Contains(msg, "HTTP 403"), strings.Contains(msg, "Resource not accessible"):
+		return fmt.Errorf("%s: no permission to read this repo's Actions runs; check gh auth status", action)
+	case strings.Contains(msg, "HTTP 404"), strings.Contains(msg, "HTTP 410"):
+		return fmt.Errorf("%s: not found; the run's logs may have expired", action)
+	case msg == "":
+		return fmt.Errorf("%s: %w", action, err)
+	}
+	return fmt.Errorf("%s: %s", action, msg)
+}
+
+// FailingCIRuns returns the GitHub Actions runs with failed checks on branch's PR,
+// and the names of failed checks from other CI systems, whose logs gh cannot fetch.
+func (s *GitService) FailingCIRuns(ctx context.Context, repoPath, branch string) ([]CIRun, []string, error) {
+	// gh pr checks exits non-zero when checks fail, with the JSON still on stdout
+	stdout, stderr, err := s.executor.Run(ctx, repoPath, "gh", "pr", "checks", branch, "--json", "name,bucket,link")
+	if err != nil && len(bytes.TrimSpace(stdout)) == 0 {
+		return nil, nil, ghError("failed to list PR checks", stderr, err)
+	}
+
+	var checks []struct {
+		Name   string `json:"name"`
+		Bucket string `json:"bucket"`
+		Link   string `json:"link"`
+	}
+	if err := json.Unmarshal(stdout, &checks); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse PR checks: %w", err)
+	}
+
+	var runs []CIRun
+	var external []string
+	for _, check := range checks {
+		if check.Bucket != "fail" {
+			continue
+		}
+		match := actionsRunPattern.FindStringSubmatch(check.Link)
+		if match == nil {
+			external = append(external, check.Name)
+			continue
+		}
+		i := slices.IndexFunc(runs, func(r CIRun) bool { return r.ID == match[1] })
+		if i < 0 {
+			runs = append(runs, CIRun{ID: match[1]})
+			i = len(runs) - 1
+		}
+		runs[i].Checks = append(runs[i].Checks, check.Name)
+	}
+	for i := range runs {
+		runs[i].Attempt = s.ciRunAttempt(ctx, repoPath, runs[i].ID)
+	}
+	return runs, external, nil
+}
+
+// ciRunAttempt returns which attempt of a GitHub Actions run is its latest, so a
+// re-run can be told from the run it repeats, or 0 if gh can't say.
+func (s *GitService) ciRunAttempt(ctx context.Context, repoPath, runID string) int {
+	stdout, _, err := s.executor.Run(ctx, repoPath, "gh", "run", "view", runID, "--json", "attempt")
+	if err != nil {
+		return 0
+	}
+	var run struct {
+		Attempt int `json:"attempt"`
+	}
+	if json.Unmarshal(stdout, &run) != nil {
+		return 0
+	}
+	return run.Attempt
+}
+
+// FetchFailedCILogs fetches the logs of the failed jobs of each run, keeping the
+// last maxLines lines of each job.
+func (s *GitService) FetchFailedCILogs(ctx context.Context, repoPath string, runIDs []string, maxLines int) ([]CIJobLog, error) {
+	var logs []CIJobLog
+	for _, runID := range runIDs {
+		stdout, stderr, err := s.executor.Run(ctx, repoPath, "gh", "run", "view", runID, "--log-failed")
+		if err != nil {
+			return nil, ghError("failed to fetch logs of run "+runID, stderr, err)
+		}
+		logs = append(logs, parseFailedLog(stdout, runID, maxLines)...)
+	}
+	return logs, nil
+}
+
+// parseFailedLog splits the output of gh run view --log-failed, whose lines are
+// "job<TAB>step<TAB>timestamp message", into per-job logs trimmed to their last
+// maxLines lines. Jobs keep the order they appear in.
+func parseFailedLog(output []byte, runID string, maxLines int) []CIJobLog {
+	var logs []CIJobLog
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		job, rest, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			continue
+		}
+		_, line, _ := strings.Cut(rest, "\t")
+		line = logTimestampPattern.ReplaceAllString(line, "")
+
+		if len(logs) == 0 || logs[len(logs)-1].Job != job {
+			logs = append(logs, CIJobLog{RunID: runID, Job: job})
+		}
+		logs[len(logs)-1].Lines = append(logs[len(logs)-1].Lines, line)
+	}
+
+	for i := range logs {
+		if maxLines > 0 && len(logs[i].Lines) > maxLines {
+			logs[i].Omitted = len(logs[i].Lines) - maxLines
+			logs[i].Lines = logs[i].Lines[logs[i].Omitted:]
+		}
+	}
+	return logs
+}
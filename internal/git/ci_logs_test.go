@@ -0,0 +1,126 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	pexec "github.com/zhubert/plural/internal/exec"
+)
+
+func TestFailingCIRuns(t *testing.T) {
+	mock := pexec.NewMockExecutor(nil)
+	// gh pr checks exits 1 when checks fail
+	mock.AddExactMatch("gh", []string{"pr", "checks", "feature", "--json", "name,bucket,link"}, pexec.MockResponse{
+		Stdout: []byte(`[
+			{"name": "test (ubuntu)", "bucket": "fail", "link": "https://github.com/o/r/actions/runs/111/job/1"},
+			{"name": "test (macos)", "bucket": "fail", "link": "https://github.com/o/r/actions/runs/111/job/2"},
+			{"name": "lint", "bucket": "pass", "link": "https://github.com/o/r/actions/runs/222/job/3"},
+			{"name": "build", "bucket": "fail", "link": "https://github.com/o/r/actions/runs/333/job/4"},
+			{"name": "buildkite", "bucket": "fail", "link": "https://buildkite.com/o/r/builds/5"}
+		]`),
+		Err: errors.New("exit status 1"),
+	})
+	mock.AddExactMatch("gh", []string{"run", "view", "111", "--json", "attempt"}, pexec.MockResponse{
+		Stdout: []byte(`{"attempt": 2}`),
+	})
+	mock.AddExactMatch("gh", []string{"run", "view", "333", "--json", "attempt"}, pexec.MockResponse{
+		Err: errors.New("exit status 1"),
+	})
+	svc := NewGitServiceWithExecutor(mock)
+
+	runs, external, err := svc.FailingCIRuns(context.Background(), "/repo", "feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []CIRun{
+		{ID: "111", Attempt: 2, Checks: []string{"test (ubuntu)", "test (macos)"}},
+		{ID: "333", Checks: []string{"build"}},
+	}
+	if !reflect.DeepEqual(runs, want) {
+		t.Errorf("runs = %+v, want %+v", runs, want)
+	}
+	if !reflect.DeepEqual(external, []string{"buildkite"}) {
+		t.Errorf("external = %v, want [buildkite]", external)
+	}
+}
+
+func TestFailingCIRuns_ReadableErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		err    error
+		want   string
+	}{
+		{"not logged in", "To get started with GitHub CLI, please run:  gh auth login", errors.New("exit status 4"), "gh is not logged in"},
+		{"no permission", "HTTP 403: Resource not accessible by integration", errors.New("exit status 1"), "no permission"},
+		{"expired", "HTTP 404: Not Found", errors.New("exit status 1"), "may have expired"},
+		{"no gh", "", &exec.Error{Name: "gh", Err: exec.ErrNotFound}, "gh CLI not found"},
+		{"other", "no pull requests found for branch \"feature\"", errors.New("exit status 1"), "no pull requests found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := pexec.NewMockExecutor(nil)
+			mock.AddExactMatch("gh", []string{"pr", "checks", "feature", "--json", "name,bucket,link"}, pexec.MockResponse{
+				Stderr: []byte(tt.stderr),
+				Err:    tt.err,
+			})
+			svc := NewGitServiceWithExecutor(mock)
+
+			_, _, err := svc.FailingCIRuns(context.Background(), "/repo", "feature")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchFailedCILogs(t *testing.T) {
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddExactMatch("gh", []string{"run", "view", "111", "--log-failed"}, pexec.MockResponse{
+		Stdout: []byte("test\tRun go test\t2026-01-02T03:04:05.1234567Z === RUN TestFoo\n" +
+			"test\tRun go test\t2026-01-02T03:04:05.2234567Z     foo_test.go:12: got 1, want 2\n" +
+			"test\tRun go test\t2026-01-02T03:04:05.3234567Z --- FAIL: TestFoo\n" +
+			"lint\tgolangci-lint\t2026-01-02T03:04:06.0000000Z main.go:3: unused import\n"),
+	})
+	svc := NewGitServiceWithExecutor(mock)
+
+	logs, err := svc.FetchFailedCILogs(context.Background(), "/repo", []string{"111"}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []CIJobLog{
+		{RunID: "111", Job: "test", Lines: []string{"    foo_test.go:12: got 1, want 2", "--- FAIL: TestFoo"}, Omitted: 1},
+		{RunID: "111", Job: "lint", Lines: []string{"main.go:3: unused import"}},
+	}
+	if !reflect.DeepEqual(logs, want) {
+		t.Errorf("logs = %+v\nwant %+v", logs, want)
+	}
+}
+
+func TestCIStatusFromRollup(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []rollupCheck
+		want   CIStatus
+	}{
+		{"no checks", nil, CIStatusNone},
+		{"passing", []rollupCheck{{Status: "COMPLETED", Conclusion: "SUCCESS"}, {State: "SUCCESS"}}, CIStatusPassing},
+		{"skipped counts as passing", []rollupCheck{{Status: "COMPLETED", Conclusion: "SKIPPED"}}, CIStatusPassing},
+		{"running", []rollupCheck{{Status: "COMPLETED", Conclusion: "SUCCESS"}, {Status: "IN_PROGRESS"}}, CIStatusPending},
+		{"status pending", []rollupCheck{{State: "PENDING"}}, CIStatusPending},
+		{"failed run wins over running", []rollupCheck{{Status: "IN_PROGRESS"}, {Status: "COMPLETED", Conclusion: "FAILURE"}}, CIStatusFailing},
+		{"timed out", []rollupCheck{{Status: "COMPLETED", Conclusion: "TIMED_OUT"}}, CIStatusFailing},
+		{"status error", []rollupCheck{{State: "ERROR"}}, CIStatusFailing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ciStatusFromRollup(tt.checks); got != tt.want {
+				t.Errorf("ciStatusFromRollup() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
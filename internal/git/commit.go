@@ -18,17 +18,31 @@ const (
 	MaxDiffSize = 50000
 )
 
+// ShortSHA abbreviates a commit SHA to seven characters for display.
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
 // CommitAll stages all changes and commits them with the given message
 func (s *GitService) CommitAll(ctx context.Context, worktreePath, message string) error {
-	logger.WithComponent("git").Info("committing all changes", "worktree", worktreePath)
+	log := logger.WithComponent("git")
+	log.Info("committing all changes", "worktree", worktreePath)
+
+	// Keep Plural's own scratch files out of the commit
+	if err := EnsureArtifactExcludes(worktreePath); err != nil {
+		log.Warn("failed to update git excludes", "worktree", worktreePath, "error", err)
+	}
 
 	// Stage all changes
-	if output, err := s.executor.CombinedOutput(ctx, worktreePath, "git", "add", "-A"); err != nil {
+	if output, err := s.run(ctx, addAllCommand(worktreePath)); err != nil {
 		return fmt.Errorf("git add failed: %s - %w", string(output), err)
 	}
 
 	// Commit
-	if output, err := s.executor.CombinedOutput(ctx, worktreePath, "git", "commit", "-m", message); err != nil {
+	if output, err := s.run(ctx, commitCommand(worktreePath, message)); err != nil {
 		return fmt.Errorf("git commit failed: %s - %w", string(output), err)
 	}
 
@@ -138,7 +152,13 @@ Diff:
 // CommitConflictResolution stages all changes and commits with the given message.
 // This is used after resolving merge conflicts to complete the merge.
 func (s *GitService) CommitConflictResolution(ctx context.Context, repoPath, message string) error {
-	logger.WithComponent("git").Info("committing conflict resolution", "repoPath", repoPath)
+	log := logger.WithComponent("git")
+	log.Info("committing conflict resolution", "repoPath", repoPath)
+
+	// Keep Plural's own scratch files out of the commit
+	if err := EnsureArtifactExcludes(repoPath); err != nil {
+		log.Warn("failed to update git excludes", "repoPath", repoPath, "error", err)
+	}
 
 	// Stage all changes
 	if output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "add", "-A"); err != nil {
@@ -158,18 +178,33 @@ func (s *GitService) CommitConflictResolution(ctx context.Context, repoPath, mes
 // Returns true if the operation succeeded (either committed or no changes needed),
 // false if there was an error (error is sent to the result channel).
 func (s *GitService) EnsureCommitted(ctx context.Context, ch chan<- Result, worktreePath, commitMsg string) bool {
+	if err := s.CommitPendingChanges(ctx, ch, worktreePath, commitMsg); err != nil {
+		ch <- Result{Error: err, Done: true}
+		return false
+	}
+	return true
+}
+
+// CommitPendingChanges commits any uncommitted changes in the worktree, reporting
+// progress on ch. If commitMsg is empty, it generates one using Claude (with fallback).
+// Unlike EnsureCommitted, errors are returned rather than sent to the channel.
+func (s *GitService) CommitPendingChanges(ctx context.Context, ch chan<- Result, worktreePath, commitMsg string) error {
 	log := logger.WithComponent("git")
 
+	// Exclude Plural's own scratch files first, so they don't count as changes
+	if err := EnsureArtifactExcludes(worktreePath); err != nil {
+		log.Warn("failed to update git excludes", "worktree", worktreePath, "error", err)
+	}
+
 	status, err := s.GetWorktreeStatus(ctx, worktreePath)
 	if err != nil {
-		ch <- Result{Error: fmt.Errorf("failed to check worktree status: %w", err), Done: true}
-		return false
+		return fmt.Errorf("failed to check worktree status: %w", err)
 	}
 
 	if !status.HasChanges {
 		log.Debug("no uncommitted changes in worktree", "worktree", worktreePath)
 		ch <- Result{Output: "No uncommitted changes in worktree\n\n"}
-		return true
+		return nil
 	}
 
 	// Report that we found uncommitted changes
@@ -183,19 +218,17 @@ func (s *GitService) EnsureCommitted(ctx context.Context, ch chan<- Result, work
 			log.Warn("Claude commit message generation failed, using fallback", "error", err)
 			commitMsg, err = s.GenerateCommitMessage(ctx, worktreePath)
 			if err != nil {
-				ch <- Result{Error: fmt.Errorf("failed to generate commit message: %w", err), Done: true}
-				return false
+				return fmt.Errorf("failed to generate commit message: %w", err)
 			}
 		}
 	}
 
 	// Commit the changes
 	ch <- Result{Output: fmt.Sprintf("Committing changes in worktree...\n")}
-	if err := s.CommitAll(ctx, worktreePath, commitMsg); err != nil {
-		ch <- Result{Error: fmt.Errorf("failed to commit changes: %w", err), Done: true}
-		return false
+	if err := s.CommitAll(ctx, worktreePath, s.withTrailers(commitMsg)); err != nil {
+		return fmt.Errorf("failed to commit changes: %w", err)
 	}
 	ch <- Result{Output: "Changes committed.\n"}
 
-	return true
+	return nil
 }
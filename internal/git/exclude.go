@@ -0,0 +1,130 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Markers delimiting the block of patterns Plural manages in info/exclude
+const (
+	excludeBlockStart = "# BEGIN plural (managed automatically, do not edit)"
+	excludeBlockEnd   = "# END plural"
+)
+
+// ArtifactPatterns are gitignore patterns for the scratch files Plural creates.
+// They normally live outside the worktree, but a session can end up with a copy
+// inside it (e.g. when the temp dir is the repo), and auto-commits must never
+// stage them.
+var ArtifactPatterns = []string{
+	"plural-mcp-*.json", // MCP config passed to the Claude CLI
+	"plural-auth-*",     // Credentials for containerized sessions
+	"pl-*.sock",         // Permission prompt socket
+}
+
+// EnsureArtifactExcludes adds ArtifactPatterns to the repository's info/exclude
+// file, replacing any block written by an earlier version. Linked worktrees
+// share the exclude file of their main repository. This reads the .git entry
+// directly rather than shelling out, so it is cheap enough to call before every
+// auto-commit.
+func EnsureArtifactExcludes(worktreePath string) error {
+	gitDir, err := commonGitDir(worktreePath)
+	if err != nil {
+		return err
+	}
+
+	excludePath := filepath.Join(gitDir, "info", "exclude")
+	existing, err := os.ReadFile(excludePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", excludePath, err)
+	}
+
+	updated := withExcludeBlock(string(existing), ArtifactPatterns)
+	if updated == string(existing) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(excludePath), err)
+	}
+	if err := os.WriteFile(excludePath, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", excludePath, err)
+	}
+	return nil
+}
+
+// withExcludeBlock returns content with the managed block set to patterns,
+// leaving every line outside the block untouched.
+func withExcludeBlock(content string, patterns []string) string {
+	var kept []string
+	inBlock := false
+	for line := range strings.Lines(content) {
+		trimmed := strings.TrimRight(line, "\r\n")
+		switch {
+		case trimmed == excludeBlockStart:
+			inBlock = true
+		case trimmed == excludeBlockEnd && inBlock:
+			inBlock = false
+		case !inBlock:
+			kept = append(kept, trimmed)
+		}
+	}
+	for len(kept) > 0 && kept[len(kept)-1] == "" {
+		kept = kept[:len(kept)-1]
+	}
+
+	var sb strings.Builder
+	for _, line := range kept {
+		sb.WriteString(line + "\n")
+	}
+	if len(kept) > 0 {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(excludeBlockStart + "\n")
+	for _, p := range patterns {
+		sb.WriteString(p + "\n")
+	}
+	sb.WriteString(excludeBlockEnd + "\n")
+	return sb.String()
+}
+
+// commonGitDir returns the git directory shared by all worktrees of the repo
+// containing worktreePath. For a linked worktree, .git is a file pointing at
+// .git/worktrees/<name>, which in turn names the common dir in its commondir file.
+func commonGitDir(worktreePath string) (string, error) {
+	dotGit := filepath.Join(worktreePath, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", fmt.Errorf("not a git worktree: %w", err)
+	}
+	if info.IsDir() {
+		return dotGit, nil
+	}
+
+	content, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", fmt.Errorf("failed to read .git file: %w", err)
+	}
+	line := strings.TrimSpace(string(content))
+	gitDir, ok := strings.CutPrefix(line, "gitdir: ")
+	if !ok {
+		return "", fmt.Errorf("invalid .git file format: %s", line)
+	}
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(worktreePath, gitDir)
+	}
+
+	commonDir, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return gitDir, nil // Not a linked worktree (e.g. a submodule)
+		}
+		return "", fmt.Errorf("failed to read commondir: %w", err)
+	}
+	common := strings.TrimSpace(string(commonDir))
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(gitDir, common)
+	}
+	return filepath.Clean(common), nil
+}
@@ -0,0 +1,124 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommitAll_ExcludesPluralArtifacts(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	files := map[string]string{
+		"feature.go":          "package main\n",
+		"plural-mcp-abc.json": "{}",
+		"plural-auth-abc":     "ANTHROPIC_API_KEY=secret",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	if err := svc.CommitAll(ctx, repoPath, "Add feature"); err != nil {
+		t.Fatalf("CommitAll failed: %v", err)
+	}
+
+	cmd := exec.Command("git", "show", "--name-only", "--format=", "HEAD")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git show failed: %v", err)
+	}
+	committed := strings.Fields(string(output))
+	if len(committed) != 1 || committed[0] != "feature.go" {
+		t.Errorf("committed files = %v, want only feature.go", committed)
+	}
+}
+
+func TestCommitPendingChanges_IgnoresPluralArtifacts(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	if err := os.WriteFile(filepath.Join(repoPath, "plural-mcp-abc.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write MCP config: %v", err)
+	}
+
+	ch := make(chan Result, 10)
+	if err := svc.CommitPendingChanges(ctx, ch, repoPath, "Commit"); err != nil {
+		t.Fatalf("CommitPendingChanges failed: %v", err)
+	}
+	close(ch)
+	var output strings.Builder
+	for result := range ch {
+		output.WriteString(result.Output)
+	}
+	if !strings.Contains(output.String(), "No uncommitted changes") {
+		t.Errorf("expected an artifact alone not to count as a change, got %q", output.String())
+	}
+}
+
+func TestEnsureArtifactExcludes_LinkedWorktree(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	worktreePath := filepath.Join(t.TempDir(), "wt")
+	cmd := exec.Command("git", "worktree", "add", "-b", "feature", worktreePath)
+	cmd.Dir = repoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add failed: %s: %v", output, err)
+	}
+
+	if err := EnsureArtifactExcludes(worktreePath); err != nil {
+		t.Fatalf("EnsureArtifactExcludes failed: %v", err)
+	}
+
+	// Linked worktrees read the main repo's exclude file
+	content, err := os.ReadFile(filepath.Join(repoPath, ".git", "info", "exclude"))
+	if err != nil {
+		t.Fatalf("Failed to read exclude file: %v", err)
+	}
+	if !strings.Contains(string(content), "plural-mcp-*.json") {
+		t.Errorf("expected artifact patterns in main repo exclude file, got:\n%s", content)
+	}
+}
+
+func TestEnsureArtifactExcludes_Idempotent(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	excludePath := filepath.Join(repoPath, ".git", "info", "exclude")
+	if err := os.WriteFile(excludePath, []byte("# user entry\n*.log\n"), 0644); err != nil {
+		t.Fatalf("Failed to write exclude file: %v", err)
+	}
+
+	for range 2 {
+		if err := EnsureArtifactExcludes(repoPath); err != nil {
+			t.Fatalf("EnsureArtifactExcludes failed: %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(excludePath)
+	if err != nil {
+		t.Fatalf("Failed to read exclude file: %v", err)
+	}
+	s := string(content)
+	if !strings.HasPrefix(s, "# user entry\n*.log\n\n") {
+		t.Errorf("expected existing entries to be preserved, got:\n%s", s)
+	}
+	if n := strings.Count(s, excludeBlockStart); n != 1 {
+		t.Errorf("expected one managed block, got %d:\n%s", n, s)
+	}
+}
+
+func TestWithExcludeBlock_ReplacesOldBlock(t *testing.T) {
+	old := "*.log\n\n" + excludeBlockStart + "\nold-pattern\n" + excludeBlockEnd + "\n"
+	got := withExcludeBlock(old, []string{"new-pattern"})
+	want := "*.log\n\n" + excludeBlockStart + "\nnew-pattern\n" + excludeBlockEnd + "\n"
+	if got != want {
+		t.Errorf("withExcludeBlock() = %q, want %q", got, want)
+	}
+}
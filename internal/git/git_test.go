@@ -3,10 +3,12 @@ package git
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -282,6 +284,114 @@ func TestMergeToMain_Conflict(t *testing.T) {
 	}
 }
 
+func TestAbortConflictedMerge(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		cmd.Run()
+	}
+	testFile := filepath.Join(repoPath, "test.txt")
+
+	// Conflicting changes on a feature branch and on main
+	run("checkout", "-b", "conflict-branch")
+	os.WriteFile(testFile, []byte("feature version"), 0644)
+	run("commit", "-am", "Feature change")
+	run("checkout", "-")
+	os.WriteFile(testFile, []byte("main version"), 0644)
+	run("commit", "-am", "Main change")
+
+	// The repo is on another branch when the merge starts
+	run("checkout", "-b", "other")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var conflict Result
+	for result := range svc.MergeToMain(ctx, repoPath, repoPath, "conflict-branch", "") {
+		if len(result.ConflictedFiles) > 0 {
+			conflict = result
+		}
+	}
+	if conflict.OriginalBranch != "other" {
+		t.Fatalf("OriginalBranch = %q, want %q", conflict.OriginalBranch, "other")
+	}
+
+	var final Result
+	var output strings.Builder
+	for result := range svc.AbortConflictedMerge(ctx, repoPath, conflict.OriginalBranch) {
+		output.WriteString(result.Output)
+		final = result
+	}
+	if final.Error != nil || !final.Done {
+		t.Fatalf("AbortConflictedMerge final result = %+v, want done without error", final)
+	}
+	if !strings.Contains(output.String(), "Merge aborted") {
+		t.Errorf("Expected the output to report the abort, got %q", output.String())
+	}
+	if inProgress, _ := svc.IsMergeInProgress(ctx, repoPath); inProgress {
+		t.Error("Expected no merge in progress after aborting")
+	}
+	if branch, _ := svc.GetCurrentBranch(ctx, repoPath); branch != "other" {
+		t.Errorf("Expected the original branch checked out again, got %q", branch)
+	}
+
+	// With nothing left to abort, it says so
+	for result := range svc.AbortConflictedMerge(ctx, repoPath, "") {
+		final = result
+	}
+	if final.Error == nil || !strings.Contains(final.Error.Error(), "no merge in progress") {
+		t.Errorf("Expected a no merge in progress error, got %v", final.Error)
+	}
+}
+
+func TestAbortConflictedMerge_Squash(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	testFile := filepath.Join(repoPath, "test.txt")
+	gitIn(t, repoPath, "checkout", "-b", "conflict-branch")
+	os.WriteFile(testFile, []byte("feature version"), 0644)
+	gitIn(t, repoPath, "commit", "-am", "Feature change")
+	gitIn(t, repoPath, "checkout", "-")
+	os.WriteFile(testFile, []byte("main version"), 0644)
+	gitIn(t, repoPath, "commit", "-am", "Main change")
+	gitIn(t, repoPath, "checkout", "-b", "other")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var conflict Result
+	for result := range svc.SquashMergeToMain(ctx, repoPath, repoPath, "conflict-branch", "") {
+		if len(result.ConflictedFiles) > 0 {
+			conflict = result
+		}
+	}
+	if conflict.OriginalBranch != "other" {
+		t.Fatalf("OriginalBranch = %q, want %q", conflict.OriginalBranch, "other")
+	}
+	// A conflicted squash merge writes no MERGE_HEAD
+	if inProgress, _ := svc.IsMergeInProgress(ctx, repoPath); inProgress {
+		t.Fatal("Expected no MERGE_HEAD after a squash conflict")
+	}
+
+	_, final := drainMerge(svc.AbortConflictedMerge(ctx, repoPath, conflict.OriginalBranch))
+	if final.Error != nil || !final.Done {
+		t.Fatalf("AbortConflictedMerge final result = %+v, want done without error", final)
+	}
+	if conflicted, _ := svc.GetConflictedFiles(ctx, repoPath); len(conflicted) > 0 {
+		t.Errorf("Expected no conflicted files after aborting, got %v", conflicted)
+	}
+	if status := gitIn(t, repoPath, "status", "--porcelain"); status != "" {
+		t.Errorf("Expected a clean repo after aborting, got %q", status)
+	}
+	if branch, _ := svc.GetCurrentBranch(ctx, repoPath); branch != "other" {
+		t.Errorf("Expected the original branch checked out again, got %q", branch)
+	}
+}
+
 func TestMergeToMain_Cancelled(t *testing.T) {
 	repoPath := createTestRepo(t)
 	defer os.RemoveAll(repoPath)
@@ -315,7 +425,7 @@ func TestCreatePR_NoGh(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	ch := svc.CreatePR(ctx, repoPath, repoPath, "test-branch", "", "", nil, "")
+	ch := svc.CreatePR(ctx, repoPath, repoPath, "test-branch", "", "", nil, nil, "")
 
 	var hadError bool
 	for result := range ch {
@@ -602,7 +712,7 @@ func TestCreatePR_WithProvidedCommitMessage(t *testing.T) {
 	defer cancel()
 
 	// CreatePR will fail without a real remote, but we can verify it tries
-	ch := svc.CreatePR(ctx, repoPath, repoPath, "feature-pr-msg", "", "Custom PR commit", nil, "")
+	ch := svc.CreatePR(ctx, repoPath, repoPath, "feature-pr-msg", "", "Custom PR commit", nil, nil, "")
 
 	// Drain channel - expect an error since no remote
 	for range ch {
@@ -744,6 +854,67 @@ func TestMergeToMain_NoChangesToCommit(t *testing.T) {
 	}
 }
 
+func TestEmptyRepo_BranchesAndFirstMerge(t *testing.T) {
+	repoPath := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	git("init")
+	git("symbolic-ref", "HEAD", "refs/heads/trunk")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test User")
+
+	if branch := svc.GetDefaultBranch(ctx, repoPath); branch != "trunk" {
+		t.Errorf("default branch = %q, want the unborn trunk", branch)
+	}
+	if branch, err := svc.GetCurrentBranch(ctx, repoPath); err != nil || branch != "trunk" {
+		t.Errorf("current branch = %q (%v), want the unborn trunk", branch, err)
+	}
+	if err := svc.CheckSomethingToMerge(ctx, repoPath, "feature", "trunk"); !errors.Is(err, ErrNothingToMerge) {
+		t.Errorf("expected nothing to merge into a branch without commits, got %v", err)
+	}
+
+	// After the first commit, a branch with no commits of its own has nothing to merge
+	git("commit", "--allow-empty", "-m", "Initial commit")
+	worktree := filepath.Join(t.TempDir(), "feature")
+	git("worktree", "add", "-b", "feature", worktree)
+	if err := svc.CheckSomethingToMerge(ctx, repoPath, "feature", "trunk"); !errors.Is(err, ErrNothingToMerge) {
+		t.Errorf("expected nothing to merge from a branch without new commits, got %v", err)
+	}
+	var mergeErr error
+	for result := range svc.MergeToMain(ctx, repoPath, worktree, "feature", "") {
+		if result.Error != nil {
+			mergeErr = result.Error
+		}
+	}
+	if !errors.Is(mergeErr, ErrNothingToMerge) {
+		t.Errorf("expected the merge to stop with nothing to merge, got %v", mergeErr)
+	}
+
+	// The first real commit merges
+	if err := os.WriteFile(filepath.Join(worktree, "README.md"), []byte("# Project\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("-C", worktree, "add", ".")
+	git("-C", worktree, "commit", "-m", "Add README")
+	if err := svc.CheckSomethingToMerge(ctx, repoPath, "feature", "trunk"); err != nil {
+		t.Errorf("expected the new commit to be mergeable, got %v", err)
+	}
+	for result := range svc.MergeToMain(ctx, repoPath, worktree, "feature", "") {
+		if result.Error != nil {
+			t.Fatalf("first merge failed: %v\n%s", result.Error, result.Output)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "README.md")); err != nil {
+		t.Errorf("expected the commit merged into trunk: %v", err)
+	}
+}
+
 func TestCreatePR_Cancelled(t *testing.T) {
 	repoPath := createTestRepo(t)
 	defer os.RemoveAll(repoPath)
@@ -757,7 +928,7 @@ func TestCreatePR_Cancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	ch := svc.CreatePR(ctx, repoPath, repoPath, "pr-cancel-test", "", "", nil, "")
+	ch := svc.CreatePR(ctx, repoPath, repoPath, "pr-cancel-test", "", "", nil, nil, "")
 
 	// Drain channel - should not hang
 	for range ch {
@@ -831,7 +1002,7 @@ func TestCreatePR_UsesBaseBranchNotDefaultBranch(t *testing.T) {
 	defer cancel()
 
 	// Call CreatePR with baseBranch="parent-branch"
-	ch := svc.CreatePR(ctx, repoPath, worktreePath, branch, baseBranch, "", nil, "")
+	ch := svc.CreatePR(ctx, repoPath, worktreePath, branch, baseBranch, "", nil, nil, "")
 
 	// Drain the channel
 	for range ch {
@@ -1356,6 +1527,102 @@ func createTestRepoWithRemote(t *testing.T) (repoPath, remotePath string, cleanu
 	return repoPath, remotePath, cleanup
 }
 
+// renameRemoteDefaultBranch renames the bare remote's default branch from main to newName,
+// as a hosting provider does when a repo's default branch is renamed.
+func renameRemoteDefaultBranch(t *testing.T, remotePath, newName string) {
+	t.Helper()
+	for _, args := range [][]string{
+		{"branch", "-m", "main", newName},
+		{"symbolic-ref", "HEAD", "refs/heads/" + newName},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = remotePath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v in remote failed: %v\n%s", args, err, output)
+		}
+	}
+}
+
+func TestRefreshDefaultBranch_DetectsRename(t *testing.T) {
+	repoPath, remotePath, cleanup := createTestRepoWithRemote(t)
+	defer cleanup()
+
+	renameRemoteDefaultBranch(t, remotePath, "trunk")
+
+	// origin/HEAD recorded locally is stale until refreshed
+	if got := svc.GetDefaultBranch(ctx, repoPath); got != "main" {
+		t.Fatalf("GetDefaultBranch before refresh = %q, want stale 'main'", got)
+	}
+
+	if got := svc.RefreshDefaultBranch(ctx, repoPath); got != "trunk" {
+		t.Errorf("RefreshDefaultBranch = %q, want 'trunk'", got)
+	}
+	if got := svc.GetDefaultBranch(ctx, repoPath); got != "trunk" {
+		t.Errorf("GetDefaultBranch after refresh = %q, want 'trunk'", got)
+	}
+	if !svc.BaseBranchGone(ctx, repoPath, "main") {
+		t.Error("BaseBranchGone(main) = false after rename, want true")
+	}
+	if svc.BaseBranchGone(ctx, repoPath, "trunk") {
+		t.Error("BaseBranchGone(trunk) = true, want false")
+	}
+}
+
+func TestBaseBranchGone_NoRemote(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	if svc.BaseBranchGone(ctx, repoPath, "main") {
+		t.Error("BaseBranchGone should be false for a repo without origin")
+	}
+}
+
+func TestMergeToMain_AfterDefaultBranchRename(t *testing.T) {
+	repoPath, remotePath, cleanup := createTestRepoWithRemote(t)
+	defer cleanup()
+
+	cmd := exec.Command("git", "checkout", "-b", "feature-rename")
+	cmd.Dir = repoPath
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to create feature branch: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, "feature.txt"), []byte("feature content"), 0644); err != nil {
+		t.Fatalf("Failed to create feature file: %v", err)
+	}
+	cmd = exec.Command("git", "add", ".")
+	cmd.Dir = repoPath
+	cmd.Run()
+	cmd = exec.Command("git", "commit", "-m", "Feature commit")
+	cmd.Dir = repoPath
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to commit feature: %v", err)
+	}
+
+	renameRemoteDefaultBranch(t, remotePath, "trunk")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for result := range svc.MergeToMain(ctx, repoPath, repoPath, "feature-rename", "") {
+		if result.Error != nil {
+			t.Fatalf("Merge error: %v\n%s", result.Error, result.Output)
+		}
+	}
+
+	// The merge must land on the renamed default branch, not the stale one
+	cmd = exec.Command("git", "branch", "--show-current")
+	cmd.Dir = repoPath
+	output, _ := cmd.Output()
+	if got := strings.TrimSpace(string(output)); got != "trunk" {
+		t.Errorf("Merged into %q, want 'trunk'", got)
+	}
+	cmd = exec.Command("git", "log", "--oneline", "trunk")
+	cmd.Dir = repoPath
+	output, _ = cmd.Output()
+	if !strings.Contains(string(output), "Feature commit") {
+		t.Errorf("Expected feature commit on trunk, got log:\n%s", output)
+	}
+}
+
 func TestMergeToMain_PullFailsDiverged(t *testing.T) {
 	repoPath, remotePath, cleanup := createTestRepoWithRemote(t)
 	defer cleanup()
@@ -2022,6 +2289,35 @@ func TestRemoteBranchExists_InvalidPath(t *testing.T) {
 	}
 }
 
+func TestListRemoteBranches(t *testing.T) {
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddExactMatch("git", []string{"branch", "-r"}, pexec.MockResponse{
+		Stdout: []byte("  origin/HEAD -> origin/main\n  origin/main\n  origin/release/1.2\n  upstream/main\n"),
+	})
+	s := NewGitServiceWithExecutor(mock)
+
+	branches, err := s.ListRemoteBranches(ctx, "/repo")
+	if err != nil {
+		t.Fatalf("ListRemoteBranches error: %v", err)
+	}
+	want := []string{"main", "release/1.2"}
+	if !slices.Equal(branches, want) {
+		t.Errorf("ListRemoteBranches = %v, want %v", branches, want)
+	}
+}
+
+func TestListRemoteBranches_Error(t *testing.T) {
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddExactMatch("git", []string{"branch", "-r"}, pexec.MockResponse{
+		Err: fmt.Errorf("fatal: not a git repository"),
+	})
+	s := NewGitServiceWithExecutor(mock)
+
+	if _, err := s.ListRemoteBranches(ctx, "/repo"); err == nil {
+		t.Error("ListRemoteBranches should return an error when git fails")
+	}
+}
+
 func TestGetCurrentBranch_Success(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
 	mock.AddExactMatch("git", []string{"rev-parse", "--abbrev-ref", "HEAD"}, pexec.MockResponse{
@@ -2419,6 +2715,113 @@ func TestSquashMergeToMain_Cancelled(t *testing.T) {
 	}
 }
 
+// rebaseTestRepo returns a repo whose default branch has moved on since branch
+// was created in a worktree of its own, with branch's change to file and the
+// default branch's change to test.txt. The default branch is checked out.
+func rebaseTestRepo(t *testing.T, branch, file string) (repoPath, worktreePath, defaultBranch string) {
+	t.Helper()
+	repoPath = createTestRepo(t)
+	t.Cleanup(func() { os.RemoveAll(repoPath) })
+	defaultBranch = svc.GetDefaultBranch(ctx, repoPath)
+
+	worktreePath = filepath.Join(t.TempDir(), "wt")
+	gitIn(t, repoPath, "worktree", "add", "-b", branch, worktreePath)
+	if err := os.WriteFile(filepath.Join(worktreePath, file), []byte("feature version"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, worktreePath, "add", ".")
+	gitIn(t, worktreePath, "commit", "-m", "Feature change")
+
+	if err := os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("main version"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, repoPath, "commit", "-am", "Main change")
+	return repoPath, worktreePath, defaultBranch
+}
+
+func TestRebaseOntoMain(t *testing.T) {
+	repoPath, worktreePath, defaultBranch := rebaseTestRepo(t, "rebase-branch", "feature.txt")
+	mainChange := gitIn(t, repoPath, "rev-parse", "HEAD")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	output, final := drainMerge(svc.RebaseOntoMain(ctx, repoPath, worktreePath, "rebase-branch", ""))
+	if final.Error != nil || !final.Done {
+		t.Fatalf("RebaseOntoMain final result = %+v\n%s", final, output)
+	}
+
+	// The default branch is fast-forwarded to the rebased branch, without a merge commit
+	if head, branchHead := gitIn(t, repoPath, "rev-parse", defaultBranch), gitIn(t, repoPath, "rev-parse", "rebase-branch"); head != branchHead {
+		t.Errorf("expected %s at rebase-branch (%s), got %s", defaultBranch, branchHead, head)
+	}
+	if parent := gitIn(t, repoPath, "rev-parse", defaultBranch+"^"); parent != mainChange {
+		t.Errorf("expected the feature commit on top of %s's own, got parent %s", defaultBranch, parent)
+	}
+	if merges := gitIn(t, repoPath, "rev-list", "--merges", defaultBranch); merges != "" {
+		t.Errorf("expected linear history, got merge commits %s", merges)
+	}
+}
+
+func TestRebaseOntoMain_Conflict(t *testing.T) {
+	repoPath, worktreePath, defaultBranch := rebaseTestRepo(t, "rebase-conflict", "test.txt")
+	mainChange := gitIn(t, repoPath, "rev-parse", "HEAD")
+
+	// The main repo is on another branch when the rebase starts
+	gitIn(t, repoPath, "checkout", "-b", "other")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	output, final := drainMerge(svc.RebaseOntoMain(ctx, repoPath, worktreePath, "rebase-conflict", ""))
+	if final.Error == nil {
+		t.Fatalf("expected the rebase to stop on conflicts\n%s", output)
+	}
+	if len(final.ConflictedFiles) != 1 || final.ConflictedFiles[0] != "test.txt" || final.RepoPath != worktreePath {
+		t.Errorf("expected test.txt conflicted in the worktree, got %v in %s", final.ConflictedFiles, final.RepoPath)
+	}
+	if !strings.Contains(output, "git rebase --abort") {
+		t.Errorf("expected instructions to abort the rebase, got %q", output)
+	}
+	if head := gitIn(t, repoPath, "rev-parse", defaultBranch); head != mainChange {
+		t.Errorf("expected %s untouched, got %s", defaultBranch, head)
+	}
+
+	if final.OriginalBranch != "other" {
+		t.Errorf("OriginalBranch = %q, want %q", final.OriginalBranch, "other")
+	}
+
+	var abort Result
+	output = ""
+	for result := range svc.AbortRebase(ctx, worktreePath, repoPath, final.OriginalBranch) {
+		output += result.Output
+		abort = result
+	}
+	if abort.Error != nil || !abort.Done {
+		t.Fatalf("AbortRebase final result = %+v, want done without error\n%s", abort, output)
+	}
+	if !strings.Contains(output, "Rebase aborted") {
+		t.Errorf("Expected the output to report the abort, got %q", output)
+	}
+	if inProgress, _ := svc.IsRebaseInProgress(ctx, worktreePath); inProgress {
+		t.Error("Expected no rebase in progress after aborting")
+	}
+	if branch, _ := svc.GetCurrentBranch(ctx, worktreePath); branch != "rebase-conflict" {
+		t.Errorf("expected rebase-conflict checked out again after aborting, got %q", branch)
+	}
+	if branch, _ := svc.GetCurrentBranch(ctx, repoPath); branch != "other" {
+		t.Errorf("Expected the original branch checked out in the repo again, got %q", branch)
+	}
+
+	// With nothing left to abort, it says so
+	for result := range svc.AbortRebase(ctx, worktreePath, repoPath, "") {
+		abort = result
+	}
+	if abort.Error == nil || !strings.Contains(abort.Error.Error(), "no rebase in progress") {
+		t.Errorf("Expected a no rebase in progress error, got %v", abort.Error)
+	}
+}
+
 func TestIsMergeInProgress_NoMerge(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
 	mock.AddExactMatch("git", []string{"rev-parse", "--verify", "MERGE_HEAD"}, pexec.MockResponse{
@@ -2572,7 +2975,7 @@ This PR adds feature Y to the codebase.
 	})
 
 	ctx := context.Background()
-	title, body, err := svc.GeneratePRTitleAndBodyWithIssueRef(ctx, "/test/repo", "feature-branch", "feature-base", nil)
+	title, body, err := svc.GeneratePRTitleAndBodyWithIssueRef(ctx, "/test/repo", "feature-branch", "feature-base", nil, nil)
 
 	if err != nil {
 		t.Fatalf("GeneratePRTitleAndBodyWithIssueRef failed: %v", err)
@@ -2647,7 +3050,7 @@ func TestGeneratePRTitleAndBodyWithEmptyBaseBranch(t *testing.T) {
 
 	ctx := context.Background()
 	// Pass empty string for baseBranch - should fall back to default branch
-	title, _, err := svc.GeneratePRTitleAndBodyWithIssueRef(ctx, "/test/repo", "feature-branch", "", nil)
+	title, _, err := svc.GeneratePRTitleAndBodyWithIssueRef(ctx, "/test/repo", "feature-branch", "", nil, nil)
 
 	if err != nil {
 		t.Fatalf("GeneratePRTitleAndBodyWithIssueRef failed: %v", err)
@@ -2701,7 +3104,7 @@ func TestGeneratePRTitleAndBodyFallsBackToLocalWhenFetchFails(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	title, _, err := svc.GeneratePRTitleAndBodyWithIssueRef(ctx, "/test/repo", "feature-branch", "main", nil)
+	title, _, err := svc.GeneratePRTitleAndBodyWithIssueRef(ctx, "/test/repo", "feature-branch", "main", nil, nil)
 
 	if err != nil {
 		t.Fatalf("GeneratePRTitleAndBodyWithIssueRef failed: %v", err)
@@ -2906,3 +3309,414 @@ func TestLoadTranscript_EmptyMessages(t *testing.T) {
 		t.Errorf("expected empty string for session with no messages, got %q", result)
 	}
 }
+
+func TestOpenPR_Args(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		body     string
+		wantTail []string
+	}{
+		{name: "generated description", title: "Add feature", body: "Details", wantTail: []string{"--title", "Add feature", "--body", "Details"}},
+		{name: "fill fallback", title: "", body: "", wantTail: []string{"--fill"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExec := pexec.NewMockExecutor(nil)
+			mockExec.AddPrefixMatch("gh", []string{"pr", "create"}, pexec.MockResponse{
+				Stdout: []byte("https://github.com/owner/repo/pull/7\n"),
+			})
+			s := NewGitServiceWithExecutor(mockExec)
+
+			output, err := s.OpenPR(context.Background(), "/repo", "feature", "develop", tt.title, tt.body)
+			if err != nil {
+				t.Fatalf("OpenPR failed: %v", err)
+			}
+			if !strings.Contains(output, "/pull/7") {
+				t.Errorf("expected PR URL in output, got %q", output)
+			}
+
+			calls := mockExec.GetCalls()
+			if len(calls) != 1 {
+				t.Fatalf("expected 1 call, got %d", len(calls))
+			}
+			want := append([]string{"pr", "create", "--base", "develop", "--head", "feature"}, tt.wantTail...)
+			if !slices.Equal(calls[0].Args, want) {
+				t.Errorf("gh args = %v, want %v", calls[0].Args, want)
+			}
+		})
+	}
+}
+
+func TestOpenPR_ErrorUsesStderr(t *testing.T) {
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddPrefixMatch("gh", []string{"pr", "create"}, pexec.MockResponse{
+		Stderr: []byte("gh: To use GitHub CLI, please run: gh auth login"),
+		Err:    fmt.Errorf("exit status 4"),
+	})
+	s := NewGitServiceWithExecutor(mockExec)
+
+	_, err := s.OpenPR(context.Background(), "/repo", "feature", "main", "", "")
+	if err == nil {
+		t.Fatal("expected error from failed gh pr create")
+	}
+	if !strings.Contains(err.Error(), "PR creation failed: gh: To use GitHub CLI") {
+		t.Errorf("expected stderr in error, got %v", err)
+	}
+}
+
+func TestPushBranch(t *testing.T) {
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddExactMatch("git", []string{"push", "-u", "origin", "feature"}, pexec.MockResponse{
+		Stdout: []byte("branch 'feature' set up to track 'origin/feature'.\n"),
+	})
+	mockExec.AddExactMatch("git", []string{"rev-parse", "feature"}, pexec.MockResponse{
+		Stdout: []byte("0123456789abcdef\n"),
+	})
+	s := NewGitServiceWithExecutor(mockExec)
+
+	output, err := s.PushBranch(context.Background(), "/repo", "feature")
+	if err != nil {
+		t.Fatalf("PushBranch failed: %v", err)
+	}
+	if !strings.Contains(output, "set up to track") {
+		t.Errorf("expected push output, got %q", output)
+	}
+
+	sha, err := s.BranchHeadSHA(context.Background(), "/repo", "feature")
+	if err != nil {
+		t.Fatalf("BranchHeadSHA failed: %v", err)
+	}
+	if sha != "0123456789abcdef" {
+		t.Errorf("BranchHeadSHA = %q, want %q", sha, "0123456789abcdef")
+	}
+}
+
+func TestResumePR_SkipsCompletedSteps(t *testing.T) {
+	// Skip this test if gh CLI is not available
+	if _, err := exec.LookPath("gh"); err != nil {
+		t.Skip("gh CLI not available, skipping test")
+	}
+
+	const headSHA = "0123456789abcdef"
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddExactMatch("git", []string{"status", "--porcelain"}, pexec.MockResponse{})
+	mockExec.AddExactMatch("git", []string{"rev-parse", "feature"}, pexec.MockResponse{
+		Stdout: []byte(headSHA + "\n"),
+	})
+	mockExec.AddPrefixMatch("gh", []string{"pr", "create"}, pexec.MockResponse{
+		Stdout: []byte("https://github.com/owner/repo/pull/7\n"),
+	})
+	s := NewGitServiceWithExecutor(mockExec)
+
+	// A previous attempt pushed and generated a description, then failed creating the PR
+	progress := &config.PRProgress{
+		BaseBranch: "main",
+		PushedSHA:  headSHA,
+		Title:      "Add feature",
+		Body:       "Details",
+		FailedStep: string(PRStepCreate),
+		Error:      "PR creation failed: auth",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var reused []PRStep
+	var finalErr error
+	var prURL string
+	for result := range s.ResumePR(ctx, "/repo", "/worktree", "feature", "main", "", nil, nil, "", progress) {
+		if result.PRStep != nil && result.PRStep.Reused {
+			reused = append(reused, result.PRStep.Step)
+		}
+		if result.Error != nil {
+			finalErr = result.Error
+		}
+		if result.Done {
+			prURL = result.PRURL
+		}
+	}
+	if finalErr != nil {
+		t.Fatalf("ResumePR failed: %v", finalErr)
+	}
+	if prURL != "https://github.com/owner/repo/pull/7" {
+		t.Errorf("PRURL = %q, want the URL gh printed", prURL)
+	}
+	if !slices.Equal(reused, []PRStep{PRStepPush, PRStepGenerate}) {
+		t.Errorf("reused steps = %v, want push and generate", reused)
+	}
+
+	for _, call := range mockExec.GetCalls() {
+		if call.Name == "git" && len(call.Args) > 0 && call.Args[0] == "push" {
+			t.Errorf("expected push to be skipped, got git %v", call.Args)
+		}
+		if call.Name == "gh" && !slices.Contains(call.Args, "Add feature") {
+			t.Errorf("expected saved title to be reused, got gh %v", call.Args)
+		}
+	}
+}
+
+// gitOutput runs a git command in repoPath and returns its trimmed output
+func gitOutput(t *testing.T, repoPath string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestStashChanges_RoundTrip(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	testFile := filepath.Join(repoPath, "test.txt")
+	untracked := filepath.Join(repoPath, "notes.txt")
+	os.WriteFile(testFile, []byte("work in progress"), 0644)
+	os.WriteFile(untracked, []byte("scratch"), 0644)
+
+	label := StashLabel("merge", "fix-login")
+	ref, err := svc.StashChanges(ctx, repoPath, label)
+	if err != nil {
+		t.Fatalf("StashChanges failed: %v", err)
+	}
+	if ref == "" {
+		t.Fatal("Expected a stash ref")
+	}
+	if status := gitOutput(t, repoPath, "status", "--porcelain"); status != "" {
+		t.Errorf("Expected a clean worktree after stashing, got %q", status)
+	}
+	if list := gitOutput(t, repoPath, "stash", "list"); !strings.Contains(list, "plural: before merge of fix-login") {
+		t.Errorf("Expected labeled stash, got %q", list)
+	}
+
+	if err := svc.RestoreStash(ctx, repoPath, ref, label); err != nil {
+		t.Fatalf("RestoreStash failed: %v", err)
+	}
+	if content, _ := os.ReadFile(testFile); string(content) != "work in progress" {
+		t.Errorf("Expected modified file restored, got %q", content)
+	}
+	if content, _ := os.ReadFile(untracked); string(content) != "scratch" {
+		t.Errorf("Expected untracked file restored, got %q", content)
+	}
+	if list := gitOutput(t, repoPath, "stash", "list"); list != "" {
+		t.Errorf("Expected the stash to be dropped, got %q", list)
+	}
+}
+
+func TestStashChanges_NothingToStash(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	ref, err := svc.StashChanges(ctx, repoPath, "plural: test")
+	if err != nil {
+		t.Fatalf("StashChanges failed: %v", err)
+	}
+	if ref != "" {
+		t.Errorf("Expected no stash for a clean worktree, got %q", ref)
+	}
+}
+
+func TestRestoreStash_ConflictKeepsStash(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	testFile := filepath.Join(repoPath, "test.txt")
+	os.WriteFile(testFile, []byte("stashed version"), 0644)
+	ref, err := svc.StashChanges(ctx, repoPath, "plural: test")
+	if err != nil || ref == "" {
+		t.Fatalf("StashChanges failed: ref=%q err=%v", ref, err)
+	}
+
+	// Commit a conflicting change while the stash is held
+	os.WriteFile(testFile, []byte("committed version"), 0644)
+	gitOutput(t, repoPath, "commit", "-am", "Conflicting change")
+
+	err = svc.RestoreStash(ctx, repoPath, ref, "plural: test")
+	var applyErr *StashApplyError
+	if !errors.As(err, &applyErr) {
+		t.Fatalf("Expected StashApplyError, got %v", err)
+	}
+	if applyErr.Ref != ref {
+		t.Errorf("Expected error to carry ref %q, got %q", ref, applyErr.Ref)
+	}
+	if !strings.Contains(applyErr.Hint(), "git stash apply "+ref) {
+		t.Errorf("Expected hint to include the apply command, got %q", applyErr.Hint())
+	}
+	if list := gitOutput(t, repoPath, "stash", "list", "--format=%H"); list != ref {
+		t.Errorf("Expected the stash to be kept, got %q", list)
+	}
+}
+
+func TestWithAutoStash_MergeToMain(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	gitOutput(t, repoPath, "checkout", "-b", "feature-branch")
+	os.WriteFile(filepath.Join(repoPath, "feature.txt"), []byte("feature content"), 0644)
+	gitOutput(t, repoPath, "add", ".")
+	gitOutput(t, repoPath, "commit", "-m", "Feature commit")
+	gitOutput(t, repoPath, "checkout", "-")
+
+	// Unrelated uncommitted work in the main repo
+	testFile := filepath.Join(repoPath, "test.txt")
+	os.WriteFile(testFile, []byte("local edits"), 0644)
+
+	// Use a separate worktree for the session so the merge does not commit the main repo's edits
+	worktreePath := filepath.Join(t.TempDir(), "wt")
+	gitOutput(t, repoPath, "worktree", "add", worktreePath, "feature-branch")
+
+	ch := svc.WithAutoStash(ctx, repoPath, StashLabel("merge", "feature-branch"), func() <-chan Result {
+		return svc.MergeToMain(ctx, repoPath, worktreePath, "feature-branch", "")
+	})
+
+	var last Result
+	for result := range ch {
+		if result.Error != nil {
+			t.Fatalf("Unexpected error: %v\n%s", result.Error, result.Output)
+		}
+		if result.KeptStash != "" {
+			t.Errorf("Expected the stash to be re-applied, got kept stash %s", result.KeptStash)
+		}
+		last = result
+	}
+	if !last.Done {
+		t.Error("Expected a final Done result")
+	}
+
+	if _, err := os.Stat(filepath.Join(repoPath, "feature.txt")); err != nil {
+		t.Error("Expected feature branch to be merged")
+	}
+	if content, _ := os.ReadFile(testFile); string(content) != "local edits" {
+		t.Errorf("Expected local edits to be restored, got %q", content)
+	}
+	if list := gitOutput(t, repoPath, "stash", "list"); list != "" {
+		t.Errorf("Expected no stash left behind, got %q", list)
+	}
+}
+
+func TestWithAutoStash_RebaseOntoMain(t *testing.T) {
+	repoPath, worktreePath, defaultBranch := rebaseTestRepo(t, "rebase-branch", "feature.txt")
+
+	// Unrelated uncommitted work in the main repo, which the rebase checks out over
+	untracked := filepath.Join(repoPath, "notes.txt")
+	if err := os.WriteFile(untracked, []byte("local notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	output, final := drainMerge(svc.WithAutoStash(ctx, repoPath, StashLabel("merge", "rebase-branch"), func() <-chan Result {
+		return svc.RebaseOntoMain(ctx, repoPath, worktreePath, "rebase-branch", "")
+	}))
+	if final.Error != nil || !final.Done || final.KeptStash != "" {
+		t.Fatalf("final result = %+v\n%s", final, output)
+	}
+
+	if head, branchHead := gitIn(t, repoPath, "rev-parse", defaultBranch), gitIn(t, repoPath, "rev-parse", "rebase-branch"); head != branchHead {
+		t.Errorf("expected %s fast-forwarded to rebase-branch, got %s", defaultBranch, head)
+	}
+	if content, _ := os.ReadFile(untracked); string(content) != "local notes" {
+		t.Errorf("Expected local notes to be restored, got %q", content)
+	}
+	if list := gitIn(t, repoPath, "stash", "list"); list != "" {
+		t.Errorf("Expected no stash left behind, got %q", list)
+	}
+}
+
+func TestWithAutoStash_ReportsKeptStashOnConflict(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	testFile := filepath.Join(repoPath, "test.txt")
+	os.WriteFile(testFile, []byte("local edits"), 0644)
+
+	// The operation commits a change that the stashed edits conflict with
+	ch := svc.WithAutoStash(ctx, repoPath, "plural: test", func() <-chan Result {
+		out := make(chan Result)
+		go func() {
+			defer close(out)
+			os.WriteFile(testFile, []byte("merged content"), 0644)
+			cmd := exec.Command("git", "commit", "-am", "Merged change")
+			cmd.Dir = repoPath
+			if output, err := cmd.CombinedOutput(); err != nil {
+				out <- Result{Output: string(output), Error: err, Done: true}
+				return
+			}
+			out <- Result{Output: "Merged\n", Done: true}
+		}()
+		return out
+	})
+
+	var keptStash, output string
+	var last Result
+	for result := range ch {
+		if result.KeptStash != "" {
+			keptStash = result.KeptStash
+		}
+		output += result.Output
+		last = result
+	}
+
+	if keptStash == "" {
+		t.Fatal("Expected the kept stash to be reported")
+	}
+	if !strings.Contains(output, "git stash apply "+keptStash) {
+		t.Errorf("Expected output to print the stash ref, got %q", output)
+	}
+	if !last.Done || last.Error != nil {
+		t.Errorf("Expected the operation's own result to finish the stream, got %+v", last)
+	}
+	if list := gitOutput(t, repoPath, "stash", "list", "--format=%H"); list != keptStash {
+		t.Errorf("Expected the stash to be kept, got %q", list)
+	}
+}
+
+func TestWithAutoStash_ConflictLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		inRepo   bool // Whether the conflict is in the main repo rather than a worktree
+		wantKept bool
+	}{
+		{name: "conflict in the main repo keeps the stash", inRepo: true, wantKept: true},
+		{name: "conflict in a worktree re-applies the stash", inRepo: false, wantKept: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoPath := createTestRepo(t)
+			defer os.RemoveAll(repoPath)
+			testFile := filepath.Join(repoPath, "test.txt")
+			os.WriteFile(testFile, []byte("local edits"), 0644)
+
+			conflictPath := filepath.Join(t.TempDir(), "wt")
+			if tt.inRepo {
+				conflictPath = repoPath
+			}
+			ch := svc.WithAutoStash(ctx, repoPath, "plural: test", func() <-chan Result {
+				out := make(chan Result, 1)
+				out <- Result{Error: errors.New("conflict"), Done: true, ConflictedFiles: []string{"a.go"}, RepoPath: conflictPath}
+				close(out)
+				return out
+			})
+
+			var keptStash string
+			for result := range ch {
+				if result.KeptStash != "" {
+					keptStash = result.KeptStash
+				}
+			}
+			if kept := keptStash != ""; kept != tt.wantKept {
+				t.Errorf("kept stash = %v, want %v", kept, tt.wantKept)
+			}
+			content, _ := os.ReadFile(testFile)
+			if restored := string(content) == "local edits"; restored == tt.wantKept {
+				t.Errorf("local edits restored = %v, want %v", restored, !tt.wantKept)
+			}
+		})
+	}
+}
@@ -98,19 +98,55 @@ func (s *GitService) GetBatchPRStates(ctx context.Context, repoPath string, bran
 	return result, nil
 }
 
-// PRBatchResult holds the state and comment count for a PR from a batch query.
+// PRBatchResult holds the state, comment count and CI status for a PR from a batch query.
 type PRBatchResult struct {
 	State        PRState
 	CommentCount int // len(comments) + len(actionable reviews) — excludes APPROVED/DISMISSED reviews
+	CI           CIStatus
 }
 
-// GetBatchPRStatesWithComments returns PR states and comment counts for multiple branches.
+// rollupCheck is an entry of a PR's statusCheckRollup: a check run (Status and
+// Conclusion) or a commit status context (State).
+type rollupCheck struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	State      string `json:"state"`
+}
+
+// ciStatusFromRollup combines a PR's status checks into one status.
+func ciStatusFromRollup(checks []rollupCheck) CIStatus {
+	if len(checks) == 0 {
+		return CIStatusNone
+	}
+	pending := false
+	for _, check := range checks {
+		switch check.Conclusion {
+		case "FAILURE", "TIMED_OUT", "CANCELLED", "ACTION_REQUIRED", "STARTUP_FAILURE":
+			return CIStatusFailing
+		}
+		switch check.State {
+		case "FAILURE", "ERROR":
+			return CIStatusFailing
+		case "PENDING", "EXPECTED":
+			pending = true
+		}
+		if check.Status != "" && check.Status != "COMPLETED" {
+			pending = true
+		}
+	}
+	if pending {
+		return CIStatusPending
+	}
+	return CIStatusPassing
+}
+
+// GetBatchPRStatesWithComments returns PR states, comment counts and CI statuses for multiple branches.
 // Uses a single `gh pr list` call per repo. The comment count is len(comments) + len(reviews),
 // which captures top-level PR comments and review submissions.
 func (s *GitService) GetBatchPRStatesWithComments(ctx context.Context, repoPath string, branches []string) (map[string]PRBatchResult, error) {
 	output, err := s.executor.Output(ctx, repoPath, "gh", "pr", "list",
 		"--state", "all",
-		"--json", "state,headRefName,comments,reviews",
+		"--json", "state,headRefName,comments,reviews,statusCheckRollup",
 		"--limit", "200",
 	)
 	if err != nil {
@@ -118,10 +154,11 @@ func (s *GitService) GetBatchPRStatesWithComments(ctx context.Context, repoPath
 	}
 
 	var prs []struct {
-		State       string            `json:"state"`
-		HeadRefName string            `json:"headRefName"`
-		Comments    []json.RawMessage `json:"comments"`
-		Reviews     []json.RawMessage `json:"reviews"`
+		State             string            `json:"state"`
+		HeadRefName       string            `json:"headRefName"`
+		Comments          []json.RawMessage `json:"comments"`
+		Reviews           []json.RawMessage `json:"reviews"`
+		StatusCheckRollup []rollupCheck     `json:"statusCheckRollup"`
 	}
 	if err := json.Unmarshal(output, &prs); err != nil {
 		return nil, fmt.Errorf("failed to parse PR list: %w", err)
@@ -164,6 +201,7 @@ func (s *GitService) GetBatchPRStatesWithComments(ctx context.Context, repoPath
 		result[pr.HeadRefName] = PRBatchResult{
 			State:        state,
 			CommentCount: len(pr.Comments) + actionableReviewCount,
+			CI:           ciStatusFromRollup(pr.StatusCheckRollup),
 		}
 	}
 
@@ -510,7 +548,11 @@ func (s *GitService) MergePR(ctx context.Context, repoPath, branch string, delet
 //   - Asana: no auto-close support (Asana doesn't use commit message keywords)
 //
 // baseBranch is the branch this PR will be compared against (typically the session's BaseBranch or main).
-func (s *GitService) GeneratePRTitleAndBodyWithIssueRef(ctx context.Context, repoPath, branch, baseBranch string, issueRef *config.IssueRef) (title, body string, err error) {
+//
+// If the repo has a pull request template (see findPRTemplate), Claude fills in its
+// sections instead of using a fixed structure, or in PRTemplateReplace mode the
+// template becomes the body as-is. prTemplate may be nil.
+func (s *GitService) GeneratePRTitleAndBodyWithIssueRef(ctx context.Context, repoPath, branch, baseBranch string, issueRef *config.IssueRef, prTemplate *config.PRTemplate) (title, body string, err error) {
 	log := logger.WithComponent("git")
 	log.Info("generating PR title and body with Claude", "branch", branch, "baseBranch", baseBranch, "issueRef", issueRef)
 
@@ -558,42 +600,13 @@ func (s *GitService) GeneratePRTitleAndBodyWithIssueRef(ctx context.Context, rep
 		fullDiff = fullDiff[:maxDiffSize] + "\n... (diff truncated)"
 	}
 
-	// Build the prompt for Claude
-	prompt := fmt.Sprintf(`Generate a GitHub pull request title and body for the following changes.
-
-Output format (use exactly this format with the markers):
----TITLE---
-Your PR title here in conventional commit format
----BODY---
-## Summary
-Brief description of what this PR does
-
-## Changes
-- Bullet points of key changes
-
-## Test plan
-- How to test these changes
-
-Rules:
-1. Title MUST follow conventional commit format: <type>[optional scope]: <description>
-   - type: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert
-   - scope: optional component/module name in parentheses
-   - description: concise summary in imperative mood, lowercase, no period at end
-   - Example: "feat(auth): add OAuth2 login support"
-   - Example: "fix: prevent race condition in request handling"
-   - Keep total title length under 72 characters
-2. Body should explain the purpose and changes clearly
-3. Include a test plan section
-4. Do NOT include any preamble - start directly with ---TITLE---
-
-Commits in this branch:
-%s
-
-Diff:
-%s`, string(commitLog), fullDiff)
+	template := s.findPRTemplate(ctx, repoPath, branch, prTemplate)
+	if template != "" {
+		log.Debug("using repo PR template", "branch", branch)
+	}
 
 	// Call Claude CLI
-	output, err := s.executor.Output(ctx, repoPath, "claude", "--print", "-p", prompt)
+	output, err := s.executor.Output(ctx, repoPath, "claude", "--print", "-p", prPrompt(string(commitLog), fullDiff, template))
 	if err != nil {
 		log.Error("Claude PR generation failed", "error", err)
 		return "", "", fmt.Errorf("failed to generate PR with Claude: %w", err)
@@ -624,6 +637,10 @@ Diff:
 		return "", "", fmt.Errorf("Claude returned empty PR title")
 	}
 
+	if template != "" && prTemplate != nil && prTemplate.Mode == config.PRTemplateReplace {
+		body = template
+	}
+
 	// Add issue reference to the body based on source
 	if issueRef != nil {
 		linkText := GetPRLinkText(issueRef)
@@ -637,6 +654,55 @@ Diff:
 	return title, body, nil
 }
 
+// prPrompt builds the prompt asking Claude for a PR title and body. The body
+// follows template if given, otherwise a fixed summary/changes/test plan structure.
+func prPrompt(commitLog, diff, template string) string {
+	bodyFormat := `## Summary
+Brief description of what this PR does
+
+## Changes
+- Bullet points of key changes
+
+## Test plan
+- How to test these changes`
+	bodyRules := `2. Body should explain the purpose and changes clearly
+3. Include a test plan section`
+	if template != "" {
+		bodyFormat = "The repository's pull request template below, with every section filled in"
+		bodyRules = `2. Body MUST follow the repository's pull request template: keep its headings, order and checklists, and fill each section in from the changes
+3. Drop the template's instructional comments, and write "N/A" under sections that do not apply`
+	}
+
+	prompt := fmt.Sprintf(`Generate a GitHub pull request title and body for the following changes.
+
+Output format (use exactly this format with the markers):
+---TITLE---
+Your PR title here in conventional commit format
+---BODY---
+%s
+
+Rules:
+1. Title MUST follow conventional commit format: <type>[optional scope]: <description>
+   - type: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert
+   - scope: optional component/module name in parentheses
+   - description: concise summary in imperative mood, lowercase, no period at end
+   - Example: "feat(auth): add OAuth2 login support"
+   - Example: "fix: prevent race condition in request handling"
+   - Keep total title length under 72 characters
+%s
+4. Do NOT include any preamble - start directly with ---TITLE---
+`, bodyFormat, bodyRules)
+	if template != "" {
+		prompt += fmt.Sprintf("\nPull request template:\n%s\n", template)
+	}
+	return prompt + fmt.Sprintf(`
+Commits in this branch:
+%s
+
+Diff:
+%s`, commitLog, diff)
+}
+
 // GetPRLinkText returns the appropriate text to add to a PR body based on the issue source.
 // For GitHub issues: returns "\n\nFixes #123"
 // For Asana tasks: returns "" (no auto-close support)
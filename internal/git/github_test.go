@@ -525,7 +525,7 @@ func TestFetchPRReviewComments_ReviewBodyOnly(t *testing.T) {
 
 func TestGetBatchPRStatesWithComments_Success(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
-	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews", "--limit", "200"}, pexec.MockResponse{
+	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews,statusCheckRollup", "--limit", "200"}, pexec.MockResponse{
 		Stdout: []byte(`[
 			{
 				"state": "OPEN",
@@ -570,7 +570,7 @@ func TestGetBatchPRStatesWithComments_Success(t *testing.T) {
 
 func TestGetBatchPRStatesWithComments_NoComments(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
-	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews", "--limit", "200"}, pexec.MockResponse{
+	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews,statusCheckRollup", "--limit", "200"}, pexec.MockResponse{
 		Stdout: []byte(`[{"state": "OPEN", "headRefName": "branch-a", "comments": [], "reviews": []}]`),
 	})
 
@@ -586,7 +586,7 @@ func TestGetBatchPRStatesWithComments_NoComments(t *testing.T) {
 
 func TestGetBatchPRStatesWithComments_CLIError(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
-	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews", "--limit", "200"}, pexec.MockResponse{
+	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews,statusCheckRollup", "--limit", "200"}, pexec.MockResponse{
 		Err: fmt.Errorf("not a git repository"),
 	})
 
@@ -602,7 +602,7 @@ func TestGetBatchPRStatesWithComments_CLIError(t *testing.T) {
 
 func TestGetBatchPRStatesWithComments_InvalidJSON(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
-	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews", "--limit", "200"}, pexec.MockResponse{
+	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews,statusCheckRollup", "--limit", "200"}, pexec.MockResponse{
 		Stdout: []byte(`not valid json`),
 	})
 
@@ -618,7 +618,7 @@ func TestGetBatchPRStatesWithComments_InvalidJSON(t *testing.T) {
 
 func TestGetBatchPRStatesWithComments_MissingBranch(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
-	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews", "--limit", "200"}, pexec.MockResponse{
+	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews,statusCheckRollup", "--limit", "200"}, pexec.MockResponse{
 		Stdout: []byte(`[{"state": "OPEN", "headRefName": "other-branch", "comments": [{"body": "c"}], "reviews": []}]`),
 	})
 
@@ -634,7 +634,7 @@ func TestGetBatchPRStatesWithComments_MissingBranch(t *testing.T) {
 
 func TestGetBatchPRStatesWithComments_ApprovedReviewsExcludedFromCount(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
-	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews", "--limit", "200"}, pexec.MockResponse{
+	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews,statusCheckRollup", "--limit", "200"}, pexec.MockResponse{
 		Stdout: []byte(`[
 			{
 				"state": "OPEN",
@@ -664,7 +664,7 @@ func TestGetBatchPRStatesWithComments_ApprovedReviewsExcludedFromCount(t *testin
 
 func TestGetBatchPRStatesWithComments_AllApprovedReviewsExcluded(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
-	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews", "--limit", "200"}, pexec.MockResponse{
+	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews,statusCheckRollup", "--limit", "200"}, pexec.MockResponse{
 		Stdout: []byte(`[
 			{
 				"state": "OPEN",
@@ -692,7 +692,7 @@ func TestGetBatchPRStatesWithComments_AllApprovedReviewsExcluded(t *testing.T) {
 
 func TestGetBatchPRStatesWithComments_DraftTreatedAsOpen(t *testing.T) {
 	mock := pexec.NewMockExecutor(nil)
-	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews", "--limit", "200"}, pexec.MockResponse{
+	mock.AddExactMatch("gh", []string{"pr", "list", "--state", "all", "--json", "state,headRefName,comments,reviews,statusCheckRollup", "--limit", "200"}, pexec.MockResponse{
 		Stdout: []byte(`[{"state": "DRAFT", "headRefName": "draft-branch", "comments": [{"body": "c"}], "reviews": []}]`),
 	})
 
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// HookEnv is what a repo hook is told about the merge or PR it follows, as
+// PLURAL_* environment variables.
+type HookEnv struct {
+	SessionID    string
+	Branch       string // Session's branch
+	TargetBranch string // Branch merged into, or the PR's base
+	PRURL        string // URL of the PR created (empty after a merge)
+}
+
+// Vars returns the environment variables for a hook, as NAME=value. PLURAL_PR_URL
+// is only set when there is a PR.
+func (e HookEnv) Vars() []string {
+	vars := []string{
+		"PLURAL_SESSION_ID=" + e.SessionID,
+		"PLURAL_BRANCH=" + e.Branch,
+		"PLURAL_TARGET_BRANCH=" + e.TargetBranch,
+	}
+	if e.PRURL != "" {
+		vars = append(vars, "PLURAL_PR_URL="+e.PRURL)
+	}
+	return vars
+}
+
+// HookResult reports progress of the hooks run by RunHooks: a result with
+// neither Line nor Done as a hook starts, one per line of its output, and one
+// with Done when it exits.
+type HookResult struct {
+	Index   int    // Position of the hook in the list run
+	Command string // The hook's command
+	Line    string // A line of the hook's combined output, without ANSI escapes
+	Done    bool   // The hook exited
+	Error   error  // Why the hook failed (only set with Done)
+}
+
+// RunHooks runs commands one after another with sh -c in dir, with env's
+// variables added to the environment, streaming their output. It stops at the
+// first command that fails; the channel closes once no more will run.
+func (s *GitService) RunHooks(ctx context.Context, dir string, commands []string, env HookEnv) <-chan HookResult {
+	ch := make(chan HookResult)
+
+	go func() {
+		defer close(ch)
+		log := logger.WithComponent("git")
+		for i, command := range commands {
+			ch <- HookResult{Index: i, Command: command}
+			args := append(env.Vars(), "sh", "-c", command)
+			_, err := s.executor.StreamCombinedOutput(ctx, dir, func(line string) {
+				ch <- HookResult{Index: i, Command: command, Line: ansi.Strip(line)}
+			}, "env", args...)
+			ch <- HookResult{Index: i, Command: command, Done: true, Error: err}
+			if err != nil {
+				log.Warn("hook failed", "command", command, "dir", dir, "error", err)
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// prURLFromOutput returns the PR URL gh pr create printed, its last line that
+// is a URL, or "" if there is none.
+func prURLFromOutput(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); strings.HasPrefix(line, "https://") || strings.HasPrefix(line, "http://") {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+	"time"
+
+	pexec "github.com/zhubert/plural/internal/exec"
+)
+
+// collectHooks runs commands with RunHooks and returns every result.
+func collectHooks(t *testing.T, svc *GitService, commands []string, env HookEnv) []HookResult {
+	t.Helper()
+	var results []HookResult
+	for result := range svc.RunHooks(context.Background(), "/repo", commands, env) {
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestHookEnv_Vars(t *testing.T) {
+	env := HookEnv{SessionID: "abc", Branch: "fix-login", TargetBranch: "main"}
+	want := []string{"PLURAL_SESSION_ID=abc", "PLURAL_BRANCH=fix-login", "PLURAL_TARGET_BRANCH=main"}
+	if got := env.Vars(); !slices.Equal(got, want) {
+		t.Errorf("Vars() = %q, want %q", got, want)
+	}
+
+	env.PRURL = "https://github.com/owner/repo/pull/7"
+	if got := env.Vars(); !slices.Contains(got, "PLURAL_PR_URL=https://github.com/owner/repo/pull/7") {
+		t.Errorf("expected the PR URL set when there is a PR, got %q", got)
+	}
+}
+
+func TestRunHooks_InjectsEnvAndStreamsOutput(t *testing.T) {
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddPrefixMatch("env", nil, pexec.MockResponse{Stdout: []byte("deploying\n\x1b[32mdone\x1b[0m\n")})
+	svc := NewGitServiceWithExecutor(mock)
+
+	env := HookEnv{SessionID: "abc", Branch: "fix-login", TargetBranch: "main", PRURL: "https://github.com/owner/repo/pull/7"}
+	results := collectHooks(t, svc, []string{"./deploy.sh", "notify"}, env)
+
+	calls := mock.GetCalls()
+	if len(calls) != 2 {
+		t.Fatalf("expected both hooks run, got %d calls", len(calls))
+	}
+	for i, command := range []string{"./deploy.sh", "notify"} {
+		want := append(env.Vars(), "sh", "-c", command)
+		if calls[i].Name != "env" || !slices.Equal(calls[i].Args, want) || calls[i].Dir != "/repo" {
+			t.Errorf("call %d = %s %q in %s, want env %q in /repo", i, calls[i].Name, calls[i].Args, calls[i].Dir, want)
+		}
+	}
+
+	var lines []string
+	for _, result := range results {
+		if result.Index == 0 && result.Line != "" {
+			lines = append(lines, result.Line)
+		}
+	}
+	if !slices.Equal(lines, []string{"deploying", "done"}) {
+		t.Errorf("first hook's output = %q, want its lines without escapes", lines)
+	}
+	if last := results[len(results)-1]; !last.Done || last.Index != 1 || last.Error != nil {
+		t.Errorf("expected the last result to report the second hook succeeded, got %+v", last)
+	}
+}
+
+func TestRunHooks_StopsAtFirstFailure(t *testing.T) {
+	failure := errors.New("exit status 1")
+	mock := pexec.NewMockExecutor(nil)
+	mock.AddRule(func(dir, name string, args []string) bool {
+		return slices.Contains(args, "false")
+	}, pexec.MockResponse{Stderr: []byte("boom\n"), Err: failure})
+	svc := NewGitServiceWithExecutor(mock)
+
+	results := collectHooks(t, svc, []string{"true", "false", "never"}, HookEnv{SessionID: "abc"})
+
+	for _, call := range mock.GetCalls() {
+		if slices.Contains(call.Args, "never") {
+			t.Error("expected no hook to run after one fails")
+		}
+	}
+	last := results[len(results)-1]
+	if !last.Done || last.Index != 1 || !errors.Is(last.Error, failure) {
+		t.Errorf("expected the last result to report the failed hook, got %+v", last)
+	}
+}
+
+func TestRunHooks_CancelStopsPipeline(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	svc := NewGitServiceWithExecutor(pexec.NewRealExecutor())
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	var last HookResult
+	for result := range svc.RunHooks(ctx, t.TempDir(), []string{"sleep 10 | cat"}, HookEnv{SessionID: "abc"}) {
+		last = result
+	}
+	if !last.Done || last.Error == nil {
+		t.Errorf("expected the cancelled hook to fail, got %+v", last)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the hook's pipeline to be stopped on cancel, took %v", elapsed)
+	}
+}
+
+func TestPRURLFromOutput(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"https://github.com/owner/repo/pull/7\n", "https://github.com/owner/repo/pull/7"},
+		{"Creating pull request for feature into main\n\nhttps://github.com/owner/repo/pull/8\n", "https://github.com/owner/repo/pull/8"},
+		{"", ""},
+		{"no url here\n", ""},
+	}
+	for _, tt := range tests {
+		if got := prURLFromOutput(tt.output); got != tt.want {
+			t.Errorf("prURLFromOutput(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
@@ -2,8 +2,8 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os/exec"
 
 	"github.com/zhubert/plural/internal/config"
 	"github.com/zhubert/plural/internal/logger"
@@ -27,8 +27,12 @@ type Result struct {
 	Output          string
 	Error           error
 	Done            bool
-	ConflictedFiles []string // Files with merge conflicts (only set on conflict)
-	RepoPath        string   // Path to the repo where conflict occurred
+	ConflictedFiles []string      // Files with merge conflicts (only set on conflict)
+	RepoPath        string        // Path to the repo where conflict occurred
+	OriginalBranch  string        // Branch the repo had checked out before a merge to main (only set on conflict)
+	PRStep          *PRStepUpdate // PR pipeline step progress (only set by CreatePR/ResumePR)
+	KeptStash       string        // Automatic stash left in place instead of being re-applied (only set by WithAutoStash)
+	PRURL           string        // URL of the PR created (only set on CreatePR/ResumePR's final result)
 }
 
 // syncWithRemote checks if the local default branch needs syncing with its remote
@@ -43,7 +47,7 @@ func (s *GitService) syncWithRemote(ctx context.Context, ch chan Result, repoPat
 
 		// Fetch to update remote refs
 		ch <- Result{Output: "Fetching from origin...\n"}
-		output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "fetch", "origin", defaultBranch)
+		output, err := s.run(ctx, fetchCommand(repoPath, defaultBranch))
 		if err != nil {
 			// Fetch failed - check if remote branch exists
 			if !s.RemoteBranchExists(ctx, repoPath, remoteBranch) {
@@ -56,7 +60,7 @@ func (s *GitService) syncWithRemote(ctx context.Context, ch chan Result, repoPat
 			ch <- Result{Output: string(output)}
 
 			// Check for divergence using programmatic git commands
-			divergence, divErr := s.GetBranchDivergence(ctx, repoPath, defaultBranch, remoteBranch)
+			divergence, divErr := s.defaultBranchDivergence(ctx, repoPath, defaultBranch)
 			if divErr != nil {
 				log.Warn("could not check divergence", "error", divErr)
 			} else if divergence.IsDiverged() {
@@ -75,14 +79,14 @@ Then try merging again.
 `, defaultBranch, defaultBranch, divergence.Ahead, divergence.Behind, defaultBranch, repoPath, defaultBranch, defaultBranch)
 				ch <- Result{
 					Output: hint,
-					Error:  fmt.Errorf("local %s has diverged from origin (%d ahead, %d behind) - sync required before merge", defaultBranch, divergence.Ahead, divergence.Behind),
+					Error:  divergedError(defaultBranch, divergence),
 					Done:   true,
 				}
 				return false
 			} else if divergence.Behind > 0 {
 				// Local is behind, can fast-forward - pull the changes
 				ch <- Result{Output: fmt.Sprintf("Pulling %d commit(s) from origin...\n", divergence.Behind)}
-				output, err = s.executor.CombinedOutput(ctx, repoPath, "git", "pull", "--ff-only")
+				output, err = s.run(ctx, pullFastForwardCommand(repoPath))
 				if err != nil {
 					ch <- Result{Output: string(output), Error: fmt.Errorf("failed to pull: %w", err), Done: true}
 					return false
@@ -99,6 +103,40 @@ Then try merging again.
 	return true
 }
 
+// defaultBranchDivergence compares the local default branch with origin's, as of
+// the last fetch. The merge and its preview (FillMergePlan) both judge it here.
+func (s *GitService) defaultBranchDivergence(ctx context.Context, repoPath, defaultBranch string) (*BranchDivergence, error) {
+	return s.GetBranchDivergence(ctx, repoPath, defaultBranch, "origin/"+defaultBranch)
+}
+
+// divergedError is the error a merge stops with when the local default branch has
+// diverged from origin's.
+func divergedError(defaultBranch string, divergence *BranchDivergence) error {
+	return fmt.Errorf("local %s has diverged from origin (%d ahead, %d behind) - sync required before merge", defaultBranch, divergence.Ahead, divergence.Behind)
+}
+
+// ErrNothingToMerge means a branch has nothing to merge yet: no commits beyond
+// the branch it would merge into, or that branch has no commits at all.
+var ErrNothingToMerge = errors.New("nothing to merge yet")
+
+// CheckSomethingToMerge returns an error wrapping ErrNothingToMerge if merging
+// branch into target would bring in nothing: target is the branch of a repo
+// that has no commits yet, or branch has no commits target lacks. If the
+// branches can't be compared otherwise it returns nil, leaving git to report why.
+func (s *GitService) CheckSomethingToMerge(ctx context.Context, repoPath, branch, target string) error {
+	if unborn := s.unbornBranch(ctx, repoPath); unborn != "" && unborn == target {
+		return fmt.Errorf("%w: %s has no commits, make a first commit in %s", ErrNothingToMerge, target, repoPath)
+	}
+	divergence, err := s.GetBranchDivergence(ctx, repoPath, branch, target)
+	if err != nil {
+		return nil
+	}
+	if divergence.Ahead == 0 {
+		return fmt.Errorf("%w: %s has no commits that %s doesn't already have", ErrNothingToMerge, branch, target)
+	}
+	return nil
+}
+
 // MergeToMain merges a branch into the default branch
 // worktreePath is where Claude made changes - we commit any uncommitted changes first
 // If commitMsg is provided and non-empty, it will be used directly instead of generating one
@@ -109,17 +147,23 @@ func (s *GitService) MergeToMain(ctx context.Context, repoPath, worktreePath, br
 		defer close(ch)
 
 		log := logger.WithComponent("git")
-		defaultBranch := s.GetDefaultBranch(ctx, repoPath)
+		defaultBranch := s.RefreshDefaultBranch(ctx, repoPath)
 		log.Info("merging branch into default", "branch", branch, "defaultBranch", defaultBranch, "repoPath", repoPath, "worktree", worktreePath)
 
 		// First, check for uncommitted changes in the worktree and commit them
 		if !s.EnsureCommitted(ctx, ch, worktreePath, commitMsg) {
 			return
 		}
+		if err := s.CheckSomethingToMerge(ctx, repoPath, branch, defaultBranch); err != nil {
+			ch <- Result{Error: err, Done: true}
+			return
+		}
 
-		// Checkout the default branch
+		// Checkout the default branch, noting the branch to return to if the
+		// merge is aborted
+		originalBranch, _ := s.GetCurrentBranch(ctx, repoPath)
 		ch <- Result{Output: fmt.Sprintf("Checking out %s...\n", defaultBranch)}
-		output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "checkout", defaultBranch)
+		output, err := s.run(ctx, checkoutCommand(repoPath, defaultBranch))
 		if err != nil {
 			ch <- Result{Output: string(output), Error: fmt.Errorf("failed to checkout %s: %w", defaultBranch, err), Done: true}
 			return
@@ -133,7 +177,7 @@ func (s *GitService) MergeToMain(ctx context.Context, repoPath, worktreePath, br
 
 		// Merge the branch
 		ch <- Result{Output: fmt.Sprintf("Merging %s...\n", branch)}
-		output, err = s.executor.CombinedOutput(ctx, repoPath, "git", "merge", branch, "--no-edit")
+		output, err = s.run(ctx, s.mergeToMainCommand(repoPath, branch))
 		if err != nil {
 			// Check if this is a merge conflict
 			conflictedFiles, conflictErr := s.GetConflictedFiles(ctx, repoPath)
@@ -145,6 +189,7 @@ func (s *GitService) MergeToMain(ctx context.Context, repoPath, worktreePath, br
 					Done:            true,
 					ConflictedFiles: conflictedFiles,
 					RepoPath:        repoPath,
+					OriginalBranch:  originalBranch,
 				}
 				return
 			}
@@ -163,6 +208,7 @@ Or abort the merge with: git merge --abort
 			return
 		}
 		ch <- Result{Output: string(output)}
+		s.recordProvenanceNote(ctx, ch, repoPath, "HEAD")
 
 		ch <- Result{Output: fmt.Sprintf("\nSuccessfully merged %s into %s\n", branch, defaultBranch), Done: true}
 	}()
@@ -195,7 +241,7 @@ func (s *GitService) MergeToParent(ctx context.Context, childWorktreePath, child
 		// Now merge the child branch into the parent worktree
 		// The parent worktree should already be on the parent branch
 		ch <- Result{Output: fmt.Sprintf("Merging %s into parent...\n", childBranch)}
-		output, err := s.executor.CombinedOutput(ctx, parentWorktreePath, "git", "merge", childBranch, "--no-edit")
+		output, err := s.run(ctx, mergeCommand(parentWorktreePath, childBranch))
 		if err != nil {
 			// Check if this is a merge conflict
 			conflictedFiles, conflictErr := s.GetConflictedFiles(ctx, parentWorktreePath)
@@ -241,97 +287,84 @@ func (s *GitService) AbortMerge(ctx context.Context, repoPath string) error {
 	return nil
 }
 
-// CreatePR pushes the branch and creates a pull request using gh CLI
-// worktreePath is where Claude made changes - we commit any uncommitted changes first
-// If commitMsg is provided and non-empty, it will be used directly instead of generating one
-// If issueRef is provided, appropriate link text will be added to the PR body based on the source.
-// baseBranch is the branch this PR should be compared against (typically the session's BaseBranch).
-// sessionID is used to load and upload the session transcript as a PR comment; pass "" to skip.
-func (s *GitService) CreatePR(ctx context.Context, repoPath, worktreePath, branch, baseBranch, commitMsg string, issueRef *config.IssueRef, sessionID string) <-chan Result {
+// AbortConflictedMerge aborts a merge, or squash merge, stopped on conflicts in
+// repoPath, making sure one is in progress before and none is after, then checks out
+// originalBranch again if set and the repo isn't already on it.
+func (s *GitService) AbortConflictedMerge(ctx context.Context, repoPath, originalBranch string) <-chan Result {
 	ch := make(chan Result)
 
 	go func() {
 		defer close(ch)
 
-		log := logger.WithComponent("git")
-		defaultBranch := s.GetDefaultBranch(ctx, repoPath)
-		log.Info("creating PR", "branch", branch, "defaultBranch", defaultBranch, "repoPath", repoPath, "worktree", worktreePath)
-
-		// Check if gh CLI is available
-		if _, err := exec.LookPath("gh"); err != nil {
-			ch <- Result{Error: fmt.Errorf("gh CLI not found - install from https://cli.github.com"), Done: true}
-			return
-		}
-
-		// First, check for uncommitted changes in the worktree and commit them
-		if !s.EnsureCommitted(ctx, ch, worktreePath, commitMsg) {
-			return
-		}
-
-		// Push the branch
-		ch <- Result{Output: fmt.Sprintf("Pushing %s to origin...\n", branch)}
-		output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "push", "-u", "origin", branch)
+		inProgress, err := s.IsMergeInProgress(ctx, repoPath)
 		if err != nil {
-			ch <- Result{Output: string(output), Error: fmt.Errorf("failed to push: %w", err), Done: true}
+			ch <- Result{Error: err, Done: true}
 			return
 		}
-		ch <- Result{Output: string(output)}
-
-		// Generate PR title and body with Claude
-		ch <- Result{Output: "\nGenerating PR description with Claude...\n"}
-		prTitle, prBody, err := s.GeneratePRTitleAndBodyWithIssueRef(ctx, repoPath, branch, baseBranch, issueRef)
-		var ghArgs []string
-		if err != nil {
-			log.Warn("Claude PR generation failed, using --fill", "error", err)
-			ch <- Result{Output: "Claude unavailable, using commit info for PR...\n"}
-			// Fall back to --fill which uses commit info
-			ghArgs = []string{"pr", "create", "--base", baseBranch, "--head", branch, "--fill"}
-		} else {
-			ch <- Result{Output: fmt.Sprintf("PR title: %s\n", prTitle)}
-			// Create PR with Claude-generated title and body
-			ghArgs = []string{"pr", "create", "--base", baseBranch, "--head", branch, "--title", prTitle, "--body", prBody}
+		// A squash merge stopped on conflicts leaves unmerged paths but no MERGE_HEAD,
+		// so git merge --abort refuses it
+		squash := false
+		if !inProgress {
+			conflicted, err := s.GetConflictedFiles(ctx, repoPath)
+			if err != nil {
+				ch <- Result{Error: err, Done: true}
+				return
+			}
+			if len(conflicted) == 0 {
+				ch <- Result{Error: fmt.Errorf("no merge in progress in %s", repoPath), Done: true}
+				return
+			}
+			squash = true
 		}
 
-		// Run gh pr create using the executor
-		handle, err := s.executor.Start(ctx, repoPath, "gh", ghArgs...)
-		if err != nil {
-			ch <- Result{Error: fmt.Errorf("failed to start gh: %w", err), Done: true}
+		ch <- Result{Output: "Aborting merge...\n"}
+		if squash {
+			if output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "reset", "--merge"); err != nil {
+				ch <- Result{Error: fmt.Errorf("failed to abort squash merge: %s - %w", string(output), err), Done: true}
+				return
+			}
+		} else if err := s.AbortMerge(ctx, repoPath); err != nil {
+			ch <- Result{Error: err, Done: true}
 			return
 		}
-
-		stdout, stderr, err := handle.Wait()
-		if len(stdout) > 0 {
-			ch <- Result{Output: string(stdout)}
-		}
-		if err != nil {
-			errMsg := string(stderr)
-			if errMsg == "" {
-				errMsg = err.Error()
-			}
-			ch <- Result{Error: fmt.Errorf("PR creation failed: %s", errMsg), Done: true}
+		inProgress, err = s.IsMergeInProgress(ctx, repoPath)
+		conflicted, conflictErr := s.GetConflictedFiles(ctx, repoPath)
+		if err != nil || conflictErr != nil || inProgress || len(conflicted) > 0 {
+			ch <- Result{Error: fmt.Errorf("merge still in progress in %s after aborting", repoPath), Done: true}
 			return
 		}
+		logger.WithComponent("git").Info("aborted merge", "repoPath", repoPath, "squash", squash)
 
-		// Upload session transcript as a PR comment (best-effort)
-		// Done before the final success message so the output sequence reflects completion order.
-		if sessionID != "" {
-			if transcript := loadTranscript(sessionID); transcript != "" {
-				ch <- Result{Output: "Uploading session transcript to PR...\n"}
-				if err := s.UploadTranscriptToPR(ctx, repoPath, branch, transcript); err != nil {
-					log.Warn("failed to upload transcript to PR", "error", err)
-					ch <- Result{Output: "Warning: could not upload session transcript: " + err.Error() + "\n"}
-				} else {
-					ch <- Result{Output: "Session transcript uploaded to PR.\n"}
+		if originalBranch != "" {
+			if current, err := s.GetCurrentBranch(ctx, repoPath); err != nil || current != originalBranch {
+				ch <- Result{Output: fmt.Sprintf("Checking out %s...\n", originalBranch)}
+				output, err := s.run(ctx, checkoutCommand(repoPath, originalBranch))
+				if err != nil {
+					ch <- Result{Output: string(output), Error: fmt.Errorf("merge aborted, but failed to checkout %s: %w", originalBranch, err), Done: true}
+					return
 				}
+				ch <- Result{Output: string(output)}
 			}
 		}
 
-		ch <- Result{Output: "\nPull request created successfully!\n", Done: true}
+		ch <- Result{Output: "\nMerge aborted\n", Done: true}
 	}()
 
 	return ch
 }
 
+// CreatePR pushes the branch and creates a pull request using gh CLI
+// worktreePath is where Claude made changes - we commit any uncommitted changes first
+// If commitMsg is provided and non-empty, it will be used directly instead of generating one
+// If issueRef is provided, appropriate link text will be added to the PR body based on the source.
+// prTemplate configures the repo's PR template (nil for GitHub's standard locations).
+// baseBranch is the branch this PR should be compared against (typically the session's BaseBranch).
+// sessionID is used to load and upload the session transcript as a PR comment; pass "" to skip.
+// It runs every step of the PR pipeline; use ResumePR to continue a failed attempt.
+func (s *GitService) CreatePR(ctx context.Context, repoPath, worktreePath, branch, baseBranch, commitMsg string, issueRef *config.IssueRef, prTemplate *config.PRTemplate, sessionID string) <-chan Result {
+	return s.ResumePR(ctx, repoPath, worktreePath, branch, baseBranch, commitMsg, issueRef, prTemplate, sessionID, nil)
+}
+
 // SquashMergeToMain squashes all commits from a branch into a single commit when merging to main.
 // worktreePath is where Claude made changes - we commit any uncommitted changes first.
 // commitMsg is required and will be used as the commit message for the squashed commit.
@@ -342,17 +375,23 @@ func (s *GitService) SquashMergeToMain(ctx context.Context, repoPath, worktreePa
 		defer close(ch)
 
 		log := logger.WithComponent("git")
-		defaultBranch := s.GetDefaultBranch(ctx, repoPath)
+		defaultBranch := s.RefreshDefaultBranch(ctx, repoPath)
 		log.Info("squash merging branch into default", "branch", branch, "defaultBranch", defaultBranch, "repoPath", repoPath, "worktree", worktreePath)
 
 		// First, check for uncommitted changes in the worktree and commit them
 		if !s.EnsureCommitted(ctx, ch, worktreePath, commitMsg) {
 			return
 		}
+		if err := s.CheckSomethingToMerge(ctx, repoPath, branch, defaultBranch); err != nil {
+			ch <- Result{Error: err, Done: true}
+			return
+		}
 
-		// Checkout the default branch
+		// Checkout the default branch, noting the branch to return to if the
+		// merge is aborted
+		originalBranch, _ := s.GetCurrentBranch(ctx, repoPath)
 		ch <- Result{Output: fmt.Sprintf("Checking out %s...\n", defaultBranch)}
-		output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "checkout", defaultBranch)
+		output, err := s.run(ctx, checkoutCommand(repoPath, defaultBranch))
 		if err != nil {
 			ch <- Result{Output: string(output), Error: fmt.Errorf("failed to checkout %s: %w", defaultBranch, err), Done: true}
 			return
@@ -366,7 +405,7 @@ func (s *GitService) SquashMergeToMain(ctx context.Context, repoPath, worktreePa
 
 		// Squash merge the branch (stages all changes but doesn't commit)
 		ch <- Result{Output: fmt.Sprintf("Squash merging %s...\n", branch)}
-		output, err = s.executor.CombinedOutput(ctx, repoPath, "git", "merge", "--squash", branch)
+		output, err = s.run(ctx, squashMergeCommand(repoPath, branch))
 		if err != nil {
 			// Check if this is a merge conflict
 			conflictedFiles, conflictErr := s.GetConflictedFiles(ctx, repoPath)
@@ -378,6 +417,7 @@ func (s *GitService) SquashMergeToMain(ctx context.Context, repoPath, worktreePa
 					Done:            true,
 					ConflictedFiles: conflictedFiles,
 					RepoPath:        repoPath,
+					OriginalBranch:  originalBranch,
 				}
 				return
 			}
@@ -399,12 +439,13 @@ Or abort the merge with: git merge --abort
 
 		// Commit the squashed changes with the provided message
 		ch <- Result{Output: "Committing squashed changes...\n"}
-		output, err = s.executor.CombinedOutput(ctx, repoPath, "git", "commit", "-m", commitMsg)
+		output, err = s.run(ctx, commitCommand(repoPath, s.withTrailers(commitMsg)))
 		if err != nil {
 			ch <- Result{Output: string(output), Error: fmt.Errorf("failed to commit squashed changes: %w", err), Done: true}
 			return
 		}
 		ch <- Result{Output: string(output)}
+		s.recordProvenanceNote(ctx, ch, repoPath, "HEAD")
 
 		ch <- Result{Output: fmt.Sprintf("\nSuccessfully squash merged %s into %s\n", branch, defaultBranch), Done: true}
 	}()
@@ -412,6 +453,145 @@ Or abort the merge with: git merge --abort
 	return ch
 }
 
+// RebaseOntoMain rebases a branch onto the default branch and fast-forwards the
+// default branch to it, keeping history linear. worktreePath is where the branch
+// is checked out and Claude made changes - we commit any uncommitted changes
+// first. The default branch is synced with origin first, as for MergeToMain.
+// If commitMsg is provided and non-empty, it will be used directly instead of generating one
+func (s *GitService) RebaseOntoMain(ctx context.Context, repoPath, worktreePath, branch, commitMsg string) <-chan Result {
+	ch := make(chan Result)
+
+	go func() {
+		defer close(ch)
+
+		log := logger.WithComponent("git")
+		defaultBranch := s.RefreshDefaultBranch(ctx, repoPath)
+		log.Info("rebasing branch onto default", "branch", branch, "defaultBranch", defaultBranch, "repoPath", repoPath, "worktree", worktreePath)
+
+		// First, check for uncommitted changes in the worktree and commit them
+		if !s.EnsureCommitted(ctx, ch, worktreePath, commitMsg) {
+			return
+		}
+		if err := s.CheckSomethingToMerge(ctx, repoPath, branch, defaultBranch); err != nil {
+			ch <- Result{Error: err, Done: true}
+			return
+		}
+
+		// Checkout the default branch, which is fast-forwarded after the rebase,
+		// noting the branch to return to if the rebase is aborted
+		originalBranch, _ := s.GetCurrentBranch(ctx, repoPath)
+		ch <- Result{Output: fmt.Sprintf("Checking out %s...\n", defaultBranch)}
+		output, err := s.run(ctx, checkoutCommand(repoPath, defaultBranch))
+		if err != nil {
+			ch <- Result{Output: string(output), Error: fmt.Errorf("failed to checkout %s: %w", defaultBranch, err), Done: true}
+			return
+		}
+		ch <- Result{Output: string(output)}
+
+		// Sync with remote before rebasing (fetch, divergence check, fast-forward)
+		if !s.syncWithRemote(ctx, ch, repoPath, defaultBranch) {
+			return
+		}
+
+		// Rebase the branch in its worktree
+		ch <- Result{Output: fmt.Sprintf("Rebasing %s onto %s...\n", branch, defaultBranch)}
+		output, err = s.run(ctx, rebaseCommand(worktreePath, defaultBranch))
+		if err != nil {
+			hint := fmt.Sprintf(`
+
+The rebase stopped in %s. To resolve it:
+  1. Fix the conflicts and stage them with git add
+  2. git rebase --continue
+  3. Rebase onto main again to fast-forward %s
+
+Or abort the rebase with: git rebase --abort
+`, worktreePath, defaultBranch)
+
+			// Check if this is a rebase conflict
+			conflictedFiles, conflictErr := s.GetConflictedFiles(ctx, worktreePath)
+			if conflictErr == nil && len(conflictedFiles) > 0 {
+				// This is a conflict - include the conflicted files in the result
+				ch <- Result{
+					Output:          string(output) + hint,
+					Error:           fmt.Errorf("rebase conflict"),
+					Done:            true,
+					ConflictedFiles: conflictedFiles,
+					RepoPath:        worktreePath,
+					OriginalBranch:  originalBranch,
+				}
+				return
+			}
+			ch <- Result{Output: string(output) + hint, Error: fmt.Errorf("rebase failed: %w", err), Done: true}
+			return
+		}
+		ch <- Result{Output: string(output)}
+
+		// Fast-forward the default branch to the rebased branch
+		ch <- Result{Output: fmt.Sprintf("Fast-forwarding %s to %s...\n", defaultBranch, branch)}
+		output, err = s.run(ctx, fastForwardCommand(repoPath, branch))
+		if err != nil {
+			ch <- Result{Output: string(output), Error: fmt.Errorf("failed to fast-forward %s: %w", defaultBranch, err), Done: true}
+			return
+		}
+		ch <- Result{Output: string(output)}
+		s.recordProvenanceNote(ctx, ch, repoPath, "HEAD")
+
+		ch <- Result{Output: fmt.Sprintf("\nSuccessfully rebased %s onto %s\n", branch, defaultBranch), Done: true}
+	}()
+
+	return ch
+}
+
+// AbortRebase aborts a rebase stopped on conflicts in worktreePath, making sure
+// one is in progress before and none is after, then checks out originalBranch
+// in repoPath again if set and the repo isn't already on it.
+func (s *GitService) AbortRebase(ctx context.Context, worktreePath, repoPath, originalBranch string) <-chan Result {
+	ch := make(chan Result)
+
+	go func() {
+		defer close(ch)
+
+		inProgress, err := s.IsRebaseInProgress(ctx, worktreePath)
+		if err != nil {
+			ch <- Result{Error: err, Done: true}
+			return
+		}
+		if !inProgress {
+			ch <- Result{Error: fmt.Errorf("no rebase in progress in %s", worktreePath), Done: true}
+			return
+		}
+
+		ch <- Result{Output: "Aborting rebase...\n"}
+		output, err := s.run(ctx, gitCommand(worktreePath, "rebase", "--abort"))
+		if err != nil {
+			ch <- Result{Output: string(output), Error: fmt.Errorf("failed to abort rebase: %w", err), Done: true}
+			return
+		}
+		ch <- Result{Output: string(output)}
+		if inProgress, err := s.IsRebaseInProgress(ctx, worktreePath); err != nil || inProgress {
+			ch <- Result{Error: fmt.Errorf("rebase still in progress in %s after aborting", worktreePath), Done: true}
+			return
+		}
+		logger.WithComponent("git").Info("aborted rebase", "worktree", worktreePath)
+
+		if originalBranch != "" {
+			if current, err := s.GetCurrentBranch(ctx, repoPath); err != nil || current != originalBranch {
+				ch <- Result{Output: fmt.Sprintf("Checking out %s...\n", originalBranch)}
+				output, err := s.run(ctx, checkoutCommand(repoPath, originalBranch))
+				if err != nil {
+					ch <- Result{Output: string(output), Error: fmt.Errorf("rebase aborted, but failed to checkout %s: %w", originalBranch, err), Done: true}
+					return
+				}
+				ch <- Result{Output: string(output)}
+			}
+		}
+
+		ch <- Result{Output: "\nRebase aborted\n", Done: true}
+	}()
+
+	return ch
+}
+
 // PushUpdates commits any uncommitted changes and pushes to the remote branch.
 // This is used after a PR has been created to push additional commits based on feedback.
 // If commitMsg is provided and non-empty, it will be used directly instead of generating one.
@@ -430,7 +610,7 @@ func (s *GitService) PushUpdates(ctx context.Context, repoPath, worktreePath, br
 
 		// Push the updates to the existing remote branch
 		ch <- Result{Output: fmt.Sprintf("Pushing updates to %s...\n", branch)}
-		output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "push", "origin", branch)
+		output, err := s.run(ctx, pushCommand(repoPath, branch))
 		if err != nil {
 			ch <- Result{Output: string(output), Error: fmt.Errorf("failed to push: %w", err), Done: true}
 			return
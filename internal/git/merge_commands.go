@@ -0,0 +1,261 @@
+package git
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// Command is one command of a merge and the directory it runs in. The merge
+// operations run them, and MergePlan lists them so they can be shown or run by hand.
+type Command struct {
+	Dir  string
+	Name string // Program to run ("git" or "gh")
+	Args []string
+	Note string // Explains how Plural's run differs from the command as written, if it does
+}
+
+// String returns the command as it would be typed in a shell.
+func (c Command) String() string {
+	words := make([]string, 0, len(c.Args)+1)
+	words = append(words, c.Name)
+	for _, arg := range c.Args {
+		words = append(words, shellQuote(arg))
+	}
+	return strings.Join(words, " ")
+}
+
+// safeShellWord matches words that need no quoting in a POSIX shell.
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes s for a POSIX shell if needed.
+func shellQuote(s string) string {
+	if safeShellWord.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// FormatCommands renders commands as a shell script, changing directory whenever
+// the next command runs somewhere else.
+func FormatCommands(cmds []Command) string {
+	var sb strings.Builder
+	dir := ""
+	for _, cmd := range cmds {
+		if cmd.Dir != dir {
+			if sb.Len() > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("cd " + shellQuote(cmd.Dir) + "\n")
+			dir = cmd.Dir
+		}
+		if cmd.Note != "" {
+			sb.WriteString("# " + cmd.Note + "\n")
+		}
+		sb.WriteString(cmd.String() + "\n")
+	}
+	return sb.String()
+}
+
+// run runs a command and returns its combined output.
+func (s *GitService) run(ctx context.Context, cmd Command) ([]byte, error) {
+	return s.executor.CombinedOutput(ctx, cmd.Dir, cmd.Name, cmd.Args...)
+}
+
+func gitCommand(dir string, args ...string) Command {
+	return Command{Dir: dir, Name: "git", Args: args}
+}
+
+func addAllCommand(worktreePath string) Command {
+	return gitCommand(worktreePath, "add", "-A")
+}
+
+func commitCommand(worktreePath, message string) Command {
+	return gitCommand(worktreePath, "commit", "-m", message)
+}
+
+func checkoutCommand(repoPath, branch string) Command {
+	return gitCommand(repoPath, "checkout", branch)
+}
+
+func fetchCommand(repoPath, branch string) Command {
+	return gitCommand(repoPath, "fetch", "origin", branch)
+}
+
+func pullFastForwardCommand(repoPath string) Command {
+	return gitCommand(repoPath, "pull", "--ff-only")
+}
+
+func mergeCommand(dir, branch string) Command {
+	return gitCommand(dir, "merge", branch, "--no-edit")
+}
+
+func squashMergeCommand(repoPath, branch string) Command {
+	return gitCommand(repoPath, "merge", "--squash", branch)
+}
+
+func rebaseCommand(worktreePath, onto string) Command {
+	return gitCommand(worktreePath, "rebase", onto)
+}
+
+func fastForwardCommand(repoPath, branch string) Command {
+	return gitCommand(repoPath, "merge", "--ff-only", branch)
+}
+
+func pushCommand(repoPath, branch string) Command {
+	return gitCommand(repoPath, "push", "origin", branch)
+}
+
+func pushUpstreamCommand(repoPath, branch string) Command {
+	return gitCommand(repoPath, "push", "-u", "origin", branch)
+}
+
+func stashPushCommand(repoPath, label string) Command {
+	return gitCommand(repoPath, "stash", "push", "--include-untracked", "-m", label)
+}
+
+// openPRArgs returns the gh arguments that open a PR for branch. An empty title
+// falls back to --fill, which uses the commit info.
+func openPRArgs(branch, baseBranch, title, body string) []string {
+	args := []string{"pr", "create", "--base", baseBranch, "--head", branch}
+	if title == "" {
+		return append(args, "--fill")
+	}
+	return append(args, "--title", title, "--body", body)
+}
+
+// MergeKind is the operation a MergePlan describes.
+type MergeKind int
+
+const (
+	MergeKindMerge  MergeKind = iota // Merge the branch into the default branch
+	MergeKindSquash                  // Squash the branch into one commit on the default branch
+	MergeKindParent                  // Merge the branch into its parent session's branch
+	MergeKindPR                      // Push the branch and open a PR
+	MergeKindPush                    // Push new commits to the branch of an open PR
+	MergeKindRebase                  // Rebase the branch onto the default branch and fast-forward it
+)
+
+// MergePlan describes a merge so its commands can be listed without running it.
+type MergePlan struct {
+	Kind          MergeKind
+	RepoPath      string
+	WorktreePath  string
+	Branch        string
+	DefaultBranch string // Branch merges land on, and PRs target unless BaseBranch is set
+	HasRemote     bool   // Whether the repo has an origin to sync with
+	HasChanges    bool   // Whether the worktree has uncommitted changes to commit first
+	CommitMsg     string // Message for those changes and for a squash commit; empty when not yet written
+	AutoStash     bool   // Whether the main repo's uncommitted changes are stashed around the merge
+
+	Files      []string          // Uncommitted files in the worktree, which are committed first
+	Divergence *BranchDivergence // Default branch against origin's as of the last fetch (nil if not compared)
+
+	ParentWorktreePath string // Parent session's worktree (MergeKindParent)
+	BaseBranch         string // Branch a PR targets (MergeKindPR)
+}
+
+// commit returns the command committing with the plan's message, or opening the
+// editor for one when it is not yet written.
+func (p MergePlan) commit(dir string) Command {
+	if p.CommitMsg == "" {
+		cmd := gitCommand(dir, "commit")
+		cmd.Note = "Plural writes the commit message with Claude"
+		return cmd
+	}
+	return commitCommand(dir, p.CommitMsg)
+}
+
+// Commands returns the commands the merge runs, in order. Steps Plural only takes
+// when needed are listed unconditionally where they are harmless otherwise: the
+// pull only fast-forwards, and fails like Plural does if the branches diverged.
+func (p MergePlan) Commands() []Command {
+	var cmds []Command
+	if p.HasChanges {
+		cmds = append(cmds, addAllCommand(p.WorktreePath), p.commit(p.WorktreePath))
+	}
+
+	switch p.Kind {
+	case MergeKindParent:
+		cmds = append(cmds, mergeCommand(p.ParentWorktreePath, p.Branch))
+	case MergeKindPush:
+		cmds = append(cmds, pushCommand(p.RepoPath, p.Branch))
+	case MergeKindPR:
+		base := p.BaseBranch
+		if base == "" {
+			base = p.DefaultBranch
+		}
+		openPR := Command{Dir: p.RepoPath, Name: "gh", Args: openPRArgs(p.Branch, base, "", "")}
+		openPR.Note = "Plural writes the title and body with Claude; --fill uses the commit messages"
+		cmds = append(cmds, pushUpstreamCommand(p.RepoPath, p.Branch), openPR)
+	default:
+		if p.AutoStash {
+			cmds = append(cmds, stashPushCommand(p.RepoPath, StashLabel("merge", p.Branch)))
+		}
+		cmds = append(cmds, checkoutCommand(p.RepoPath, p.DefaultBranch))
+		if p.HasRemote {
+			cmds = append(cmds, fetchCommand(p.RepoPath, p.DefaultBranch), pullFastForwardCommand(p.RepoPath))
+		}
+		switch p.Kind {
+		case MergeKindSquash:
+			cmds = append(cmds, squashMergeCommand(p.RepoPath, p.Branch), p.commit(p.RepoPath))
+		case MergeKindRebase:
+			cmds = append(cmds, rebaseCommand(p.WorktreePath, p.DefaultBranch), fastForwardCommand(p.RepoPath, p.Branch))
+		default:
+			cmds = append(cmds, mergeCommand(p.RepoPath, p.Branch))
+		}
+		if p.AutoStash {
+			pop := gitCommand(p.RepoPath, "stash", "pop")
+			pop.Note = "Plural re-applies the stash by its commit, and keeps it if that conflicts"
+			cmds = append(cmds, pop)
+		}
+	}
+	return cmds
+}
+
+// PullCommits returns how many commits a merge into the default branch pulls
+// from origin before merging: 0 unless the default branch is only behind.
+func (p MergePlan) PullCommits() int {
+	if p.Divergence == nil || p.Divergence.IsDiverged() {
+		return 0
+	}
+	return p.Divergence.Behind
+}
+
+// SyncError returns the error a merge into the default branch stops with because
+// the default branch has diverged from origin's, or nil if it won't.
+func (p MergePlan) SyncError() error {
+	if p.Divergence == nil || !p.Divergence.IsDiverged() {
+		return nil
+	}
+	return divergedError(p.DefaultBranch, p.Divergence)
+}
+
+// Pushes returns whether the operation pushes to origin. Merges stay local.
+func (p MergePlan) Pushes() bool {
+	return p.Kind == MergeKindPR || p.Kind == MergeKindPush
+}
+
+// FillMergePlan completes a plan with what it needs to know about the repo: its
+// default branch, unless already set from one re-resolved from origin, whether it has an origin, the worktree's uncommitted files, and
+// for a merge into the default branch, how that branch compares with origin's.
+// It changes nothing and does not fetch, so the comparison is as of the last
+// fetch; the merge itself fetches first.
+func (s *GitService) FillMergePlan(ctx context.Context, plan *MergePlan) error {
+	if plan.DefaultBranch == "" {
+		plan.DefaultBranch = s.GetDefaultBranch(ctx, plan.RepoPath)
+	}
+	plan.HasRemote = s.HasRemoteOrigin(ctx, plan.RepoPath)
+	status, err := s.GetWorktreeStatus(ctx, plan.WorktreePath)
+	if err != nil {
+		return err
+	}
+	plan.HasChanges = status.HasChanges
+	plan.Files = status.Files
+	if plan.HasRemote && (plan.Kind == MergeKindMerge || plan.Kind == MergeKindSquash || plan.Kind == MergeKindRebase) {
+		if divergence, err := s.defaultBranchDivergence(ctx, plan.RepoPath, plan.DefaultBranch); err == nil {
+			plan.Divergence = divergence
+		}
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func commandStrings(cmds []Command) []string {
+	var lines []string
+	for _, cmd := range cmds {
+		lines = append(lines, cmd.Dir+": "+cmd.String())
+	}
+	return lines
+}
+
+func TestMergePlan_Commands(t *testing.T) {
+	base := MergePlan{
+		RepoPath:      "/repo",
+		WorktreePath:  "/wt",
+		Branch:        "feature",
+		DefaultBranch: "main",
+		HasRemote:     true,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *MergePlan)
+		want   []string
+	}{
+		{
+			name:   "merge",
+			modify: func(p *MergePlan) {},
+			want: []string{
+				"/repo: git checkout main",
+				"/repo: git fetch origin main",
+				"/repo: git pull --ff-only",
+				"/repo: git merge feature --no-edit",
+			},
+		},
+		{
+			name: "squash with changes and auto-stash, no remote",
+			modify: func(p *MergePlan) {
+				p.Kind = MergeKindSquash
+				p.HasRemote = false
+				p.HasChanges = true
+				p.AutoStash = true
+				p.CommitMsg = "Add login"
+			},
+			want: []string{
+				"/wt: git add -A",
+				"/wt: git commit -m 'Add login'",
+				"/repo: git stash push --include-untracked -m 'plural: before merge of feature'",
+				"/repo: git checkout main",
+				"/repo: git merge --squash feature",
+				"/repo: git commit -m 'Add login'",
+				"/repo: git stash pop",
+			},
+		},
+		{
+			name: "rebase",
+			modify: func(p *MergePlan) {
+				p.Kind = MergeKindRebase
+			},
+			want: []string{
+				"/repo: git checkout main",
+				"/repo: git fetch origin main",
+				"/repo: git pull --ff-only",
+				"/wt: git rebase main",
+				"/repo: git merge --ff-only feature",
+			},
+		},
+		{
+			name: "parent",
+			modify: func(p *MergePlan) {
+				p.Kind = MergeKindParent
+				p.ParentWorktreePath = "/parent"
+			},
+			want: []string{"/parent: git merge feature --no-edit"},
+		},
+		{
+			name: "PR against the default branch",
+			modify: func(p *MergePlan) {
+				p.Kind = MergeKindPR
+			},
+			want: []string{
+				"/repo: git push -u origin feature",
+				"/repo: gh pr create --base main --head feature --fill",
+			},
+		},
+		{
+			name: "push with changes",
+			modify: func(p *MergePlan) {
+				p.Kind = MergeKindPush
+				p.HasChanges = true
+			},
+			want: []string{
+				"/wt: git add -A",
+				"/wt: git commit",
+				"/repo: git push origin feature",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := base
+			tt.modify(&plan)
+			if got := commandStrings(plan.Commands()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Commands() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCommands(t *testing.T) {
+	plan := MergePlan{
+		Kind:          MergeKindMerge,
+		RepoPath:      "/my repo",
+		WorktreePath:  "/wt",
+		Branch:        "feature",
+		DefaultBranch: "main",
+		HasChanges:    true,
+	}
+	want := `cd /wt
+git add -A
+# Plural writes the commit message with Claude
+git commit
+
+cd '/my repo'
+git checkout main
+git merge feature --no-edit
+`
+	if got := FormatCommands(plan.Commands()); got != want {
+		t.Errorf("FormatCommands() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"feature/login": "feature/login",
+		"two words":     "'two words'",
+		"it's":          `'it'\''s'`,
+		"$HOME":         "'$HOME'",
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// The copied commands must do what Plural's merge does when run by hand
+func TestMergePlan_ScriptMerges(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	defaultBranch := svc.GetDefaultBranch(ctx, repoPath)
+
+	createBranchWithCommit(t, repoPath, "feature")
+	gitIn(t, repoPath, "checkout", "feature")
+	if err := os.WriteFile(filepath.Join(repoPath, "pending.txt"), []byte("uncommitted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := MergePlan{Kind: MergeKindMerge, RepoPath: repoPath, WorktreePath: repoPath, Branch: "feature", CommitMsg: "Pending work"}
+	if err := svc.FillMergePlan(ctx, &plan); err != nil {
+		t.Fatalf("FillMergePlan: %v", err)
+	}
+	if !plan.HasChanges || plan.HasRemote || plan.DefaultBranch != defaultBranch {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	script := "set -e\n" + FormatCommands(plan.Commands())
+	if output, err := exec.Command("sh", "-c", script).CombinedOutput(); err != nil {
+		t.Fatalf("script failed: %v\n%s\n%s", err, script, output)
+	}
+
+	if branch := gitIn(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD"); branch != defaultBranch {
+		t.Errorf("expected to end on %s, got %s", defaultBranch, branch)
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "pending.txt")); err != nil {
+		t.Errorf("expected the pending change to be merged: %v", err)
+	}
+	if contained, err := svc.IsAncestor(ctx, repoPath, "feature", defaultBranch); err != nil || !contained {
+		t.Errorf("expected feature to be merged into %s (err %v)", defaultBranch, err)
+	}
+}
+
+// repoBehindOrigin returns a repo whose main is one commit behind origin/main, with
+// a feature branch checked out that has an uncommitted pending.txt.
+func repoBehindOrigin(t *testing.T) string {
+	t.Helper()
+	repoPath, _, cleanup := createTestRepoWithRemote(t)
+	t.Cleanup(cleanup)
+
+	if err := os.WriteFile(filepath.Join(repoPath, "upstream.txt"), []byte("upstream"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, repoPath, "add", ".")
+	gitIn(t, repoPath, "commit", "-m", "Upstream commit")
+	gitIn(t, repoPath, "push", "origin", "main")
+	gitIn(t, repoPath, "reset", "--hard", "HEAD~1")
+
+	createBranchWithCommit(t, repoPath, "feature")
+	gitIn(t, repoPath, "checkout", "feature")
+	if err := os.WriteFile(filepath.Join(repoPath, "pending.txt"), []byte("uncommitted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return repoPath
+}
+
+// drainMerge collects a merge's output and its final result.
+func drainMerge(ch <-chan Result) (string, Result) {
+	var output string
+	var last Result
+	for result := range ch {
+		output += result.Output
+		last = result
+	}
+	return output, last
+}
+
+func TestFillMergePlan_MatchesMerge(t *testing.T) {
+	repoPath := repoBehindOrigin(t)
+
+	plan := MergePlan{Kind: MergeKindMerge, RepoPath: repoPath, WorktreePath: repoPath, Branch: "feature", CommitMsg: "Pending work"}
+	if err := svc.FillMergePlan(ctx, &plan); err != nil {
+		t.Fatalf("FillMergePlan: %v", err)
+	}
+	if !reflect.DeepEqual(plan.Files, []string{"pending.txt"}) {
+		t.Errorf("expected pending.txt to be committed, got %v", plan.Files)
+	}
+	if plan.PullCommits() != 1 || plan.SyncError() != nil || plan.Pushes() {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if status := gitIn(t, repoPath, "status", "--porcelain"); status != "?? pending.txt" {
+		t.Errorf("expected the plan to change nothing, got status %q", status)
+	}
+
+	output, last := drainMerge(svc.MergeToMain(ctx, repoPath, repoPath, plan.Branch, plan.CommitMsg))
+	if last.Error != nil {
+		t.Fatalf("merge failed: %v\n%s", last.Error, output)
+	}
+	if !strings.Contains(output, "Pulling 1 commit(s)") {
+		t.Errorf("expected the merge to pull the commit the plan reported, got:\n%s", output)
+	}
+	if branch := gitIn(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD"); branch != plan.DefaultBranch {
+		t.Errorf("expected the merge to land on %s, got %s", plan.DefaultBranch, branch)
+	}
+	if msg := gitIn(t, repoPath, "log", "-1", "--format=%s", "feature"); msg != plan.CommitMsg {
+		t.Errorf("expected the commit message %q, got %q", plan.CommitMsg, msg)
+	}
+	if files := gitIn(t, repoPath, "show", "--name-only", "--format=", "feature"); files != strings.Join(plan.Files, "\n") {
+		t.Errorf("expected the commit to hold %v, got %q", plan.Files, files)
+	}
+}
+
+func TestFillMergePlan_MatchesDivergedMerge(t *testing.T) {
+	repoPath := repoBehindOrigin(t)
+	gitIn(t, repoPath, "checkout", "main")
+	if err := os.WriteFile(filepath.Join(repoPath, "local.txt"), []byte("local"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, repoPath, "add", "local.txt")
+	gitIn(t, repoPath, "commit", "-m", "Local commit")
+	gitIn(t, repoPath, "checkout", "feature")
+
+	plan := MergePlan{Kind: MergeKindMerge, RepoPath: repoPath, WorktreePath: repoPath, Branch: "feature", CommitMsg: "Pending work"}
+	if err := svc.FillMergePlan(ctx, &plan); err != nil {
+		t.Fatalf("FillMergePlan: %v", err)
+	}
+	syncErr := plan.SyncError()
+	if syncErr == nil || plan.PullCommits() != 0 {
+		t.Fatalf("expected the plan to report the divergence, got %+v", plan)
+	}
+
+	_, last := drainMerge(svc.MergeToMain(ctx, repoPath, repoPath, plan.Branch, plan.CommitMsg))
+	if last.Error == nil || last.Error.Error() != syncErr.Error() {
+		t.Errorf("expected the merge to stop with %q, got %v", syncErr, last.Error)
+	}
+}
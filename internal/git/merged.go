@@ -0,0 +1,88 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// IsAncestor reports whether commit is an ancestor of ref, or the same commit.
+// Wraps git merge-base --is-ancestor, which exits 1 for "no" and otherwise fails on errors.
+func (s *GitService) IsAncestor(ctx context.Context, repoPath, commit, ref string) (bool, error) {
+	_, stderr, err := s.executor.Run(ctx, repoPath, "git", "merge-base", "--is-ancestor", commit, ref)
+	if err == nil {
+		return true, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("git merge-base --is-ancestor failed: %s: %w", strings.TrimSpace(string(stderr)), err)
+}
+
+// branchStartSHA returns the commit a branch was created at, from the oldest
+// entry in its reflog.
+func (s *GitService) branchStartSHA(ctx context.Context, repoPath, branch string) (string, error) {
+	output, err := s.executor.Output(ctx, repoPath, "git", "reflog", "show", "--format=%H", "refs/heads/"+branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to read reflog of %s: %w", branch, err)
+	}
+	lines := strings.Fields(string(output))
+	if len(lines) == 0 {
+		return "", fmt.Errorf("no reflog for %s", branch)
+	}
+	return lines[len(lines)-1], nil
+}
+
+// MergeTarget returns the ref that merged work lands on: origin's default branch,
+// or the local default branch for repos without an origin.
+func (s *GitService) MergeTarget(ctx context.Context, repoPath string) string {
+	target := s.GetDefaultBranch(ctx, repoPath)
+	if s.HasRemoteOrigin(ctx, repoPath) {
+		target = "origin/" + target
+	}
+	return target
+}
+
+// IsBranchContained reports whether branch has commits of its own and all of them
+// are contained in target, i.e. its tip is an ancestor of target. A freshly created
+// branch has no commits of its own, so it is not mistaken for a merged one.
+// Reflects the last fetch.
+func (s *GitService) IsBranchContained(ctx context.Context, repoPath, branch, target string) (bool, error) {
+	tip, err := s.BranchHeadSHA(ctx, repoPath, branch)
+	if err != nil {
+		return false, err
+	}
+	ancestor, err := s.IsAncestor(ctx, repoPath, tip, target)
+	if err != nil || !ancestor {
+		return false, err
+	}
+	start, err := s.branchStartSHA(ctx, repoPath, branch)
+	if err != nil {
+		// Without a reflog there is no telling merged work from a new branch
+		return false, nil
+	}
+	return start != tip, nil
+}
+
+// IsBranchMerged reports whether branch has landed on the repo's MergeTarget.
+// Squash and rebase merges rewrite the commits, so when the branch is not
+// contained in it the state of the branch's PR decides, if gh knows of one.
+func (s *GitService) IsBranchMerged(ctx context.Context, repoPath, branch string) (bool, error) {
+	contained, err := s.IsBranchContained(ctx, repoPath, branch, s.MergeTarget(ctx, repoPath))
+	if err != nil {
+		return false, err
+	}
+	if contained {
+		return true, nil
+	}
+
+	// No PR, or no gh: nothing more to go on
+	state, err := s.GetPRState(ctx, repoPath, branch)
+	if err != nil {
+		return false, nil
+	}
+	return state == PRStateMerged, nil
+}
@@ -0,0 +1,140 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pexec "github.com/zhubert/plural/internal/exec"
+)
+
+// gitIn runs a git command in repoPath, failing the test on error.
+func gitIn(t *testing.T, repoPath string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// createBranchWithCommit creates branch from the current HEAD with one commit of
+// its own, leaving the default branch checked out.
+func createBranchWithCommit(t *testing.T, repoPath, branch string) {
+	t.Helper()
+	defaultBranch := gitIn(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	gitIn(t, repoPath, "checkout", "-b", branch)
+	if err := os.WriteFile(filepath.Join(repoPath, branch+".txt"), []byte("feature\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, repoPath, "add", ".")
+	gitIn(t, repoPath, "commit", "-m", "Add "+branch)
+	gitIn(t, repoPath, "checkout", defaultBranch)
+}
+
+// mergeCheckService returns a GitService running real git, with gh reporting
+// the given PR state for branch, or failing when prState is empty.
+func mergeCheckService(branch string, prState PRState) *GitService {
+	mock := pexec.NewMockExecutor(pexec.NewRealExecutor())
+	response := pexec.MockResponse{Err: fmt.Errorf("no pull requests found")}
+	if prState != PRStateUnknown {
+		response = pexec.MockResponse{Stdout: fmt.Appendf(nil, `{"state":%q}`, prState)}
+	}
+	mock.AddExactMatch("gh", []string{"pr", "view", branch, "--json", "state"}, response)
+	return NewGitServiceWithExecutor(mock)
+}
+
+func TestIsAncestor(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	createBranchWithCommit(t, repoPath, "feature")
+
+	if ok, err := svc.IsAncestor(ctx, repoPath, "HEAD", "feature"); err != nil || !ok {
+		t.Errorf("IsAncestor(HEAD, feature) = %v, %v; want true", ok, err)
+	}
+	if ok, err := svc.IsAncestor(ctx, repoPath, "feature", "HEAD"); err != nil || ok {
+		t.Errorf("IsAncestor(feature, HEAD) = %v, %v; want false", ok, err)
+	}
+	if _, err := svc.IsAncestor(ctx, repoPath, "no-such-branch", "HEAD"); err == nil {
+		t.Error("expected an error for an unknown commit")
+	}
+}
+
+func TestIsBranchMerged(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, repoPath string)
+		prState PRState
+		want    bool
+	}{
+		{
+			name: "merged",
+			setup: func(t *testing.T, repoPath string) {
+				createBranchWithCommit(t, repoPath, "feature")
+				gitIn(t, repoPath, "merge", "--no-ff", "-m", "Merge feature", "feature")
+			},
+			want: true,
+		},
+		{
+			name: "unmerged",
+			setup: func(t *testing.T, repoPath string) {
+				createBranchWithCommit(t, repoPath, "feature")
+			},
+			want: false,
+		},
+		{
+			name: "unmerged with open PR",
+			setup: func(t *testing.T, repoPath string) {
+				createBranchWithCommit(t, repoPath, "feature")
+			},
+			prState: PRStateOpen,
+			want:    false,
+		},
+		{
+			name: "new branch without commits",
+			setup: func(t *testing.T, repoPath string) {
+				gitIn(t, repoPath, "branch", "feature")
+			},
+			want: false,
+		},
+		{
+			name: "squash merged with merged PR",
+			setup: func(t *testing.T, repoPath string) {
+				createBranchWithCommit(t, repoPath, "feature")
+				gitIn(t, repoPath, "merge", "--squash", "feature")
+				gitIn(t, repoPath, "commit", "-m", "Squashed feature")
+			},
+			prState: PRStateMerged,
+			want:    true,
+		},
+		{
+			name: "squash merged without gh",
+			setup: func(t *testing.T, repoPath string) {
+				createBranchWithCommit(t, repoPath, "feature")
+				gitIn(t, repoPath, "merge", "--squash", "feature")
+				gitIn(t, repoPath, "commit", "-m", "Squashed feature")
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoPath := createTestRepo(t)
+			defer os.RemoveAll(repoPath)
+			tt.setup(t, repoPath)
+
+			got, err := mergeCheckService("feature", tt.prState).IsBranchMerged(ctx, repoPath, "feature")
+			if err != nil {
+				t.Fatalf("IsBranchMerged failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsBranchMerged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
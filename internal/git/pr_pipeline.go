@@ -0,0 +1,231 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// PRStep identifies one step of the PR creation pipeline.
+// The string values are persisted in config.PRProgress.FailedStep.
+type PRStep string
+
+const (
+	PRStepCommit   PRStep = "commit"
+	PRStepPush     PRStep = "push"
+	PRStepGenerate PRStep = "generate"
+	PRStepCreate   PRStep = "create"
+)
+
+// PRSteps lists the PR pipeline steps in the order they run.
+var PRSteps = []PRStep{PRStepCommit, PRStepPush, PRStepGenerate, PRStepCreate}
+
+// Label returns the display name of the step.
+func (s PRStep) Label() string {
+	switch s {
+	case PRStepCommit:
+		return "Commit pending changes"
+	case PRStepPush:
+		return "Push branch"
+	case PRStepGenerate:
+		return "Generate title/body"
+	case PRStepCreate:
+		return "Create PR"
+	default:
+		return string(s)
+	}
+}
+
+// PRStepStatus is the state of a PR pipeline step.
+type PRStepStatus int
+
+const (
+	PRStepRunning PRStepStatus = iota
+	PRStepDone
+	PRStepFailed
+)
+
+// PRStepUpdate reports a PR pipeline step changing state. Completed steps carry the
+// artifacts a retry needs in order to skip them.
+type PRStepUpdate struct {
+	Step       PRStep
+	Status     PRStepStatus
+	BaseBranch string // Branch the PR targets, after defaulting
+	Reused     bool   // Step was skipped because a previous attempt already completed it
+	PushedSHA  string // Commit pushed to origin (push step)
+	Title      string // Generated PR title, empty when falling back to --fill (generate step)
+	Body       string // Generated PR body (generate step)
+	Error      string // Failure message (failed steps)
+}
+
+// BranchHeadSHA returns the commit SHA that branch points to.
+func (s *GitService) BranchHeadSHA(ctx context.Context, repoPath, branch string) (string, error) {
+	output, err := s.executor.Output(ctx, repoPath, "git", "rev-parse", branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", branch, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// PushBranch pushes branch to origin, setting it as the upstream.
+// Returns the combined output of git push.
+func (s *GitService) PushBranch(ctx context.Context, repoPath, branch string) (string, error) {
+	output, err := s.run(ctx, pushUpstreamCommand(repoPath, branch))
+	if err != nil {
+		return string(output), fmt.Errorf("failed to push: %w", err)
+	}
+	return string(output), nil
+}
+
+// OpenPR runs gh pr create for branch against baseBranch. An empty title falls back to
+// --fill, which uses the commit info. Returns gh's stdout (the PR URL on success).
+func (s *GitService) OpenPR(ctx context.Context, repoPath, branch, baseBranch, title, body string) (string, error) {
+	handle, err := s.executor.Start(ctx, repoPath, "gh", openPRArgs(branch, baseBranch, title, body)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to start gh: %w", err)
+	}
+
+	stdout, stderr, err := handle.Wait()
+	if err != nil {
+		errMsg := string(stderr)
+		if errMsg == "" {
+			errMsg = err.Error()
+		}
+		return string(stdout), fmt.Errorf("PR creation failed: %s", errMsg)
+	}
+	return string(stdout), nil
+}
+
+// ResumePR runs the PR pipeline (commit pending changes, push branch, generate
+// title/body, create PR), skipping steps that progress shows a previous attempt
+// already completed. A recorded push is reused only if the branch still points at
+// the pushed commit, and a generated title/body only if nothing new was pushed.
+// Every step reports its state through Result.PRStep so callers can persist the
+// artifacts and show per-step status. Pass a nil progress to run every step.
+func (s *GitService) ResumePR(ctx context.Context, repoPath, worktreePath, branch, baseBranch, commitMsg string, issueRef *config.IssueRef, prTemplate *config.PRTemplate, sessionID string, progress *config.PRProgress) <-chan Result {
+	ch := make(chan Result)
+
+	go func() {
+		defer close(ch)
+
+		log := logger.WithComponent("git")
+		// Re-resolve rather than trust origin/HEAD, which goes stale when the default branch is renamed
+		defaultBranch := s.RefreshDefaultBranch(ctx, repoPath)
+		if baseBranch == "" {
+			baseBranch = defaultBranch
+		} else if baseBranch != defaultBranch && s.BaseBranchGone(ctx, repoPath, baseBranch) {
+			log.Warn("PR base branch no longer exists on origin, using default branch", "baseBranch", baseBranch, "defaultBranch", defaultBranch)
+			ch <- Result{Output: fmt.Sprintf("Base branch %s no longer exists on origin; targeting %s instead.\n", baseBranch, defaultBranch)}
+			baseBranch = defaultBranch
+		}
+
+		var prior config.PRProgress
+		if progress != nil {
+			prior = *progress
+		}
+		if prior.BaseBranch != baseBranch {
+			// A description generated against another base describes a different diff
+			prior = config.PRProgress{}
+		}
+		log.Info("creating PR", "branch", branch, "baseBranch", baseBranch, "defaultBranch", defaultBranch, "repoPath", repoPath, "worktree", worktreePath, "resumeFrom", prior.FailedStep)
+
+		// Check if gh CLI is available
+		if _, err := exec.LookPath("gh"); err != nil {
+			ch <- Result{Error: fmt.Errorf("gh CLI not found - install from https://cli.github.com"), Done: true}
+			return
+		}
+
+		// send reports a result carrying a step update, stamping it with the base branch
+		send := func(r Result) {
+			r.PRStep.BaseBranch = baseBranch
+			ch <- r
+		}
+		fail := func(step PRStep, output string, err error) {
+			send(Result{Output: output, Error: err, Done: true, PRStep: &PRStepUpdate{Step: step, Status: PRStepFailed, Error: err.Error()}})
+		}
+
+		// Step 1: commit any uncommitted changes in the worktree. Always runs since
+		// it is a no-op when the worktree is clean.
+		send(Result{PRStep: &PRStepUpdate{Step: PRStepCommit, Status: PRStepRunning}})
+		if err := s.CommitPendingChanges(ctx, ch, worktreePath, commitMsg); err != nil {
+			fail(PRStepCommit, "", err)
+			return
+		}
+		send(Result{PRStep: &PRStepUpdate{Step: PRStepCommit, Status: PRStepDone}})
+
+		// Step 2: push the branch, unless the same commit was already pushed
+		headSHA, err := s.BranchHeadSHA(ctx, repoPath, branch)
+		if err != nil {
+			log.Warn("could not resolve branch head, pushing anyway", "error", err)
+		}
+		if headSHA != "" && headSHA == prior.PushedSHA {
+			send(Result{
+				Output: fmt.Sprintf("%s already pushed at %s\n", branch, ShortSHA(headSHA)),
+				PRStep: &PRStepUpdate{Step: PRStepPush, Status: PRStepDone, Reused: true, PushedSHA: headSHA},
+			})
+		} else {
+			// New commits make a previously generated description stale
+			prior.Title, prior.Body = "", ""
+			send(Result{Output: fmt.Sprintf("Pushing %s to origin...\n", branch), PRStep: &PRStepUpdate{Step: PRStepPush, Status: PRStepRunning}})
+			output, err := s.PushBranch(ctx, repoPath, branch)
+			if err != nil {
+				fail(PRStepPush, output, err)
+				return
+			}
+			send(Result{Output: output, PRStep: &PRStepUpdate{Step: PRStepPush, Status: PRStepDone, PushedSHA: headSHA}})
+		}
+
+		// Step 3: generate PR title and body with Claude, reusing a previous description
+		if prior.Title != "" {
+			send(Result{
+				Output: fmt.Sprintf("\nReusing generated PR title: %s\n", prior.Title),
+				PRStep: &PRStepUpdate{Step: PRStepGenerate, Status: PRStepDone, Reused: true, Title: prior.Title, Body: prior.Body},
+			})
+		} else {
+			send(Result{Output: "\nGenerating PR description with Claude...\n", PRStep: &PRStepUpdate{Step: PRStepGenerate, Status: PRStepRunning}})
+			prior.Title, prior.Body, err = s.GeneratePRTitleAndBodyWithIssueRef(ctx, repoPath, branch, baseBranch, issueRef, prTemplate)
+			var output string
+			if err != nil {
+				log.Warn("Claude PR generation failed, using --fill", "error", err)
+				output = "Claude unavailable, using commit info for PR...\n"
+				prior.Title, prior.Body = "", ""
+			} else {
+				output = fmt.Sprintf("PR title: %s\n", prior.Title)
+			}
+			send(Result{Output: output, PRStep: &PRStepUpdate{Step: PRStepGenerate, Status: PRStepDone, Title: prior.Title, Body: prior.Body}})
+		}
+
+		// Step 4: create the PR
+		send(Result{PRStep: &PRStepUpdate{Step: PRStepCreate, Status: PRStepRunning}})
+		output, err := s.OpenPR(ctx, repoPath, branch, baseBranch, prior.Title, prior.Body)
+		if err != nil {
+			fail(PRStepCreate, output, err)
+			return
+		}
+		send(Result{Output: output, PRStep: &PRStepUpdate{Step: PRStepCreate, Status: PRStepDone}})
+		prURL := prURLFromOutput(output)
+		s.recordProvenanceNote(ctx, ch, repoPath, branch)
+
+		// Upload session transcript as a PR comment (best-effort)
+		// Done before the final success message so the output sequence reflects completion order.
+		if sessionID != "" {
+			if transcript := loadTranscript(sessionID); transcript != "" {
+				ch <- Result{Output: "Uploading session transcript to PR...\n"}
+				if err := s.UploadTranscriptToPR(ctx, repoPath, branch, transcript); err != nil {
+					log.Warn("failed to upload transcript to PR", "error", err)
+					ch <- Result{Output: "Warning: could not upload session transcript: " + err.Error() + "\n"}
+				} else {
+					ch <- Result{Output: "Session transcript uploaded to PR.\n"}
+				}
+			}
+		}
+
+		ch <- Result{Output: "\nPull request created successfully!\n", Done: true, PRURL: prURL}
+	}()
+
+	return ch
+}
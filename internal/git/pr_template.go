@@ -0,0 +1,56 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// prTemplatePaths are where GitHub looks for a repo's pull request template, in order.
+var prTemplatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// findPRTemplate returns the pull request template to use for branch: the file
+// configured in tmpl if set, otherwise the first of GitHub's standard locations
+// present on the branch. Relative paths are read from the branch, so a template
+// added or changed by the branch itself is used. Returns "" if there is none.
+func (s *GitService) findPRTemplate(ctx context.Context, repoPath, branch string, tmpl *config.PRTemplate) string {
+	if tmpl != nil && tmpl.Path != "" {
+		content, err := s.readPRTemplate(ctx, repoPath, branch, tmpl.Path)
+		if err != nil {
+			logger.WithComponent("git").Warn("failed to read configured PR template", "path", tmpl.Path, "error", err)
+		}
+		return content
+	}
+	for _, path := range prTemplatePaths {
+		if content, err := s.readPRTemplate(ctx, repoPath, branch, path); err == nil && content != "" {
+			return content
+		}
+	}
+	return ""
+}
+
+// readPRTemplate reads a template file: absolute paths from disk, relative ones
+// from branch.
+func (s *GitService) readPRTemplate(ctx context.Context, repoPath, branch, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		data, err := os.ReadFile(path)
+		return strings.TrimSpace(string(data)), err
+	}
+	output, err := s.executor.Output(ctx, repoPath, "git", "show", fmt.Sprintf("%s:%s", branch, filepath.ToSlash(path)))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,122 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/config"
+	pexec "github.com/zhubert/plural/internal/exec"
+)
+
+const testPRTemplate = `## What
+<!-- Describe the change -->
+
+## Testing
+- [ ] Unit tests`
+
+// prTemplateService mocks PR generation for feature-branch, with the given
+// template committed at .github/pull_request_template.md if not empty.
+func prTemplateService(template string) *pexec.MockExecutor {
+	mockExec := pexec.NewMockExecutor(nil)
+	if template != "" {
+		mockExec.AddExactMatch("git", []string{"show", "feature-branch:.github/pull_request_template.md"}, pexec.MockResponse{
+			Stdout: []byte(template + "\n"),
+		})
+	}
+	mockExec.AddPrefixMatch("claude", []string{"--print", "-p"}, pexec.MockResponse{
+		Stdout: []byte("---TITLE---\nfeat: add feature\n---BODY---\n## What\nAdds the feature\n\n## Testing\n- [x] Unit tests\n"),
+	})
+	return mockExec
+}
+
+// claudePrompt returns the prompt of the first claude call.
+func claudePrompt(t *testing.T, mockExec *pexec.MockExecutor) string {
+	t.Helper()
+	for _, call := range mockExec.GetCalls() {
+		if call.Name == "claude" {
+			return call.Args[len(call.Args)-1]
+		}
+	}
+	t.Fatal("claude was not called")
+	return ""
+}
+
+func TestPRPrompt(t *testing.T) {
+	prompt := prPrompt("abc123 Add feature", "+new line", "")
+	if !strings.Contains(prompt, "## Changes") || strings.Contains(prompt, "template") {
+		t.Errorf("expected the fixed body structure without a template, got:\n%s", prompt)
+	}
+
+	prompt = prPrompt("abc123 Add feature", "+new line", testPRTemplate)
+	if !strings.Contains(prompt, testPRTemplate) || !strings.Contains(prompt, "MUST follow the repository's pull request template") {
+		t.Errorf("expected the template and instructions to follow it, got:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "## Changes") {
+		t.Error("expected no fixed body structure with a template")
+	}
+}
+
+func TestGeneratePRTitleAndBody_RepoTemplate(t *testing.T) {
+	mockExec := prTemplateService(testPRTemplate)
+	svc := NewGitServiceWithExecutor(mockExec)
+
+	title, body, err := svc.GeneratePRTitleAndBodyWithIssueRef(context.Background(), "/test/repo", "feature-branch", "main", nil, nil)
+	if err != nil {
+		t.Fatalf("GeneratePRTitleAndBodyWithIssueRef failed: %v", err)
+	}
+	if title != "feat: add feature" || !strings.Contains(body, "Adds the feature") {
+		t.Errorf("unexpected title %q and body %q", title, body)
+	}
+	if !strings.Contains(claudePrompt(t, mockExec), testPRTemplate) {
+		t.Error("expected the repo's template in the prompt")
+	}
+}
+
+func TestGeneratePRTitleAndBody_ReplaceWithTemplate(t *testing.T) {
+	mockExec := prTemplateService(testPRTemplate)
+	svc := NewGitServiceWithExecutor(mockExec)
+
+	_, body, err := svc.GeneratePRTitleAndBodyWithIssueRef(context.Background(), "/test/repo", "feature-branch", "main",
+		&config.IssueRef{Source: "github", ID: "42"}, &config.PRTemplate{Mode: config.PRTemplateReplace})
+	if err != nil {
+		t.Fatalf("GeneratePRTitleAndBodyWithIssueRef failed: %v", err)
+	}
+	if !strings.HasPrefix(body, testPRTemplate) || strings.Contains(body, "Adds the feature") {
+		t.Errorf("expected the template as the body, got %q", body)
+	}
+	if !strings.Contains(body, "Fixes #42") {
+		t.Errorf("expected the issue link kept, got %q", body)
+	}
+}
+
+func TestGeneratePRTitleAndBody_ConfiguredTemplatePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pr.md")
+	if err := os.WriteFile(path, []byte("## Custom template\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mockExec := prTemplateService(testPRTemplate)
+	svc := NewGitServiceWithExecutor(mockExec)
+
+	if _, _, err := svc.GeneratePRTitleAndBodyWithIssueRef(context.Background(), "/test/repo", "feature-branch", "main", nil, &config.PRTemplate{Path: path}); err != nil {
+		t.Fatalf("GeneratePRTitleAndBodyWithIssueRef failed: %v", err)
+	}
+	prompt := claudePrompt(t, mockExec)
+	if !strings.Contains(prompt, "## Custom template") || strings.Contains(prompt, testPRTemplate) {
+		t.Errorf("expected the configured template in place of the repo's, got:\n%s", prompt)
+	}
+}
+
+func TestGeneratePRTitleAndBody_NoTemplate(t *testing.T) {
+	mockExec := prTemplateService("")
+	svc := NewGitServiceWithExecutor(mockExec)
+
+	if _, _, err := svc.GeneratePRTitleAndBodyWithIssueRef(context.Background(), "/test/repo", "feature-branch", "main", nil, &config.PRTemplate{Mode: config.PRTemplateReplace}); err != nil {
+		t.Fatalf("GeneratePRTitleAndBodyWithIssueRef failed: %v", err)
+	}
+	if !strings.Contains(claudePrompt(t, mockExec), "## Changes") {
+		t.Error("expected the fixed body structure without a template")
+	}
+}
@@ -0,0 +1,218 @@
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// ProvenanceNotesRef is the notes ref provenance notes are written to.
+const ProvenanceNotesRef = "refs/notes/plural"
+
+// Trailer and note keys recording the session a commit came from.
+const (
+	TrailerSession    = "Plural-Session"     // ID of the session
+	TrailerPromptedBy = "Plural-Prompted-By" // Hash of the session's first prompt
+	noteSessionName   = "Plural-Session-Name"
+	noteTokens        = "Plural-Tokens"
+	noteCostUSD       = "Plural-Cost-USD"
+	notePrompt        = "Plural-Prompt"
+)
+
+// Provenance describes the session a merge or PR comes from, for recording in
+// its commits. Prompts are recorded as hashes only, never their text.
+type Provenance struct {
+	SessionID   string
+	SessionName string
+	Prompts     []string // Hashes of the session's prompts, in order (see HashPrompt)
+	TotalTokens int64    // Tokens the session used (0 if unknown)
+	CostUSD     float64  // Estimated cost of the session (0 if unknown)
+	Trailers    bool     // Append trailers to the commit messages Plural writes
+	Note        bool     // Write a note to ProvenanceNotesRef on the resulting commit
+}
+
+// HashPrompt returns the short hash a prompt is recorded by.
+func HashPrompt(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// trailers returns the trailers to append to commit messages, or nil if none are.
+func (p *Provenance) trailers() []string {
+	if p == nil || !p.Trailers {
+		return nil
+	}
+	trailers := []string{TrailerSession + ": " + p.SessionID}
+	if len(p.Prompts) > 0 {
+		trailers = append(trailers, TrailerPromptedBy+": "+p.Prompts[0])
+	}
+	return trailers
+}
+
+// note returns the text of the provenance note, in the same "Key: value" form
+// as trailers.
+func (p *Provenance) note() string {
+	lines := []string{
+		TrailerSession + ": " + p.SessionID,
+		noteSessionName + ": " + p.SessionName,
+	}
+	if p.TotalTokens > 0 {
+		lines = append(lines, noteTokens+": "+strconv.FormatInt(p.TotalTokens, 10))
+	}
+	if p.CostUSD > 0 {
+		lines = append(lines, fmt.Sprintf("%s: %.4f", noteCostUSD, p.CostUSD))
+	}
+	for _, hash := range p.Prompts {
+		lines = append(lines, notePrompt+": "+hash)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// WithProvenance returns a copy of the service whose merges, squash merges, and
+// PRs record p in their commits: trailers on the commit messages Plural writes,
+// and a note on the resulting commit, as p selects.
+func (s *GitService) WithProvenance(p *Provenance) *GitService {
+	scoped := *s
+	scoped.provenance = p
+	return &scoped
+}
+
+// trailerLine matches a "Key: value" trailer line.
+var trailerLine = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*: \S`)
+
+// isTrailerBlock returns whether every line of a paragraph is a trailer.
+func isTrailerBlock(paragraph string) bool {
+	for line := range strings.SplitSeq(paragraph, "\n") {
+		if !trailerLine.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
+
+// AppendTrailers appends trailers to a commit message, leaving out those it
+// already has. They join a trailer block already ending the message, such as a
+// template's Signed-off-by or Co-authored-by lines, or start one after a blank
+// line; a subject alone is never taken for a trailer block.
+func AppendTrailers(message string, trailers []string) string {
+	message = strings.TrimRight(message, " \t\r\n")
+	lines := strings.Split(message, "\n")
+	var missing []string
+	for _, trailer := range trailers {
+		if !slices.ContainsFunc(lines, func(line string) bool { return strings.TrimSpace(line) == trailer }) {
+			missing = append(missing, trailer)
+		}
+	}
+	if len(missing) == 0 {
+		return message
+	}
+	if message == "" {
+		return strings.Join(missing, "\n")
+	}
+	separator := "\n\n"
+	if i := strings.LastIndex(message, "\n\n"); i >= 0 && isTrailerBlock(strings.TrimSpace(message[i:])) {
+		separator = "\n"
+	}
+	return message + separator + strings.Join(missing, "\n")
+}
+
+// withTrailers returns a commit message with the service's provenance trailers.
+func (s *GitService) withTrailers(message string) string {
+	if trailers := s.provenance.trailers(); len(trailers) > 0 {
+		return AppendTrailers(message, trailers)
+	}
+	return message
+}
+
+// mergeToMainCommand returns the command merging branch into the checked out
+// default branch. With provenance trailers it always makes a merge commit, so
+// that there is a commit message to carry them.
+func (s *GitService) mergeToMainCommand(repoPath, branch string) Command {
+	trailers := s.provenance.trailers()
+	if len(trailers) == 0 {
+		return mergeCommand(repoPath, branch)
+	}
+	message := AppendTrailers(fmt.Sprintf("Merge branch '%s'", branch), trailers)
+	return gitCommand(repoPath, "merge", "--no-ff", "-m", message, branch)
+}
+
+// recordProvenanceNote writes the service's provenance note on commit, if it
+// writes one, reporting progress on ch. Failing to is only a warning: the
+// merge or PR it records is already done.
+func (s *GitService) recordProvenanceNote(ctx context.Context, ch chan<- Result, dir, commit string) {
+	p := s.provenance
+	if p == nil || !p.Note {
+		return
+	}
+	output, err := s.executor.CombinedOutput(ctx, dir, "git", "notes", "--ref="+ProvenanceNotesRef, "add", "-f", "-m", p.note(), commit)
+	if err != nil {
+		logger.WithComponent("git").Warn("failed to write provenance note", "commit", commit, "error", err, "output", string(output))
+		ch <- Result{Output: fmt.Sprintf("Warning: could not record the session in %s: %s\n", ProvenanceNotesRef, strings.TrimSpace(string(output)))}
+		return
+	}
+	ch <- Result{Output: fmt.Sprintf("Recorded the session in %s.\n", ProvenanceNotesRef)}
+}
+
+// CommitProvenance is what a commit records of the session it came from.
+type CommitProvenance struct {
+	Commit     string // Full SHA of the commit
+	SessionID  string // From the Plural-Session trailer, or the note without one
+	PromptedBy string // Hash of the session's first prompt, if recorded
+	Note       string // The provenance note, or "" if there is none
+}
+
+// parseKeyValues returns the "Key: value" lines of text, the first value of
+// each key.
+func parseKeyValues(text string) map[string]string {
+	values := make(map[string]string)
+	for line := range strings.SplitSeq(text, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok || !trailerLine.MatchString(strings.TrimSpace(line)) {
+			continue
+		}
+		if _, seen := values[key]; !seen {
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+	return values
+}
+
+// ReadProvenance returns what a commit records of the session it came from:
+// its Plural trailers and provenance note. SessionID is empty if it records
+// neither.
+func (s *GitService) ReadProvenance(ctx context.Context, repoPath, commit string) (*CommitProvenance, error) {
+	output, err := s.executor.Output(ctx, repoPath, "git", "log", "-1", "--format=%H%n%B", commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s: %w", commit, err)
+	}
+	sha, message, _ := strings.Cut(string(output), "\n")
+	result := &CommitProvenance{Commit: strings.TrimSpace(sha)}
+
+	// Only the last paragraph holds trailers
+	message = strings.TrimSpace(message)
+	if i := strings.LastIndex(message, "\n\n"); i >= 0 && isTrailerBlock(strings.TrimSpace(message[i:])) {
+		trailers := parseKeyValues(message[i:])
+		result.SessionID = trailers[TrailerSession]
+		result.PromptedBy = trailers[TrailerPromptedBy]
+	}
+
+	// A commit without a note makes git notes show fail
+	if note, err := s.executor.Output(ctx, repoPath, "git", "notes", "--ref="+ProvenanceNotesRef, "show", result.Commit); err == nil {
+		result.Note = strings.TrimSpace(string(note))
+		values := parseKeyValues(result.Note)
+		if result.SessionID == "" {
+			result.SessionID = values[TrailerSession]
+		}
+		if result.PromptedBy == "" {
+			result.PromptedBy = values[notePrompt]
+		}
+	}
+	return result, nil
+}
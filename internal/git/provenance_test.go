@@ -0,0 +1,109 @@
+package git
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAppendTrailers(t *testing.T) {
+	trailers := []string{"Plural-Session: s1", "Plural-Prompted-By: abc123"}
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"subject only", "Fix login", "Fix login\n\nPlural-Session: s1\nPlural-Prompted-By: abc123"},
+		{"subject that looks like a trailer", "Fix: login", "Fix: login\n\nPlural-Session: s1\nPlural-Prompted-By: abc123"},
+		{"body", "Fix login\n\nThe token expired early.\n", "Fix login\n\nThe token expired early.\n\nPlural-Session: s1\nPlural-Prompted-By: abc123"},
+		{"existing trailer block", "Fix login\n\nFixes #12\n\nSigned-off-by: A <a@example.com>", "Fix login\n\nFixes #12\n\nSigned-off-by: A <a@example.com>\nPlural-Session: s1\nPlural-Prompted-By: abc123"},
+		{"trailers already there", "Fix login\n\nPlural-Session: s1\nPlural-Prompted-By: abc123", "Fix login\n\nPlural-Session: s1\nPlural-Prompted-By: abc123"},
+		{"one already there", "Fix login\n\nPlural-Session: s1", "Fix login\n\nPlural-Session: s1\nPlural-Prompted-By: abc123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AppendTrailers(tt.message, trailers); got != tt.want {
+				t.Errorf("AppendTrailers(%q) =\n%q\nwant\n%q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeToMain_Provenance(t *testing.T) {
+	p := &Provenance{
+		SessionID:   "session-1",
+		SessionName: "repo/fix-login",
+		Prompts:     []string{HashPrompt("fix the login"), HashPrompt("add a test")},
+		TotalTokens: 1200,
+		CostUSD:     0.05,
+		Trailers:    true,
+		Note:        true,
+	}
+	for _, squash := range []bool{false, true} {
+		name := "merge"
+		if squash {
+			name = "squash"
+		}
+		t.Run(name, func(t *testing.T) {
+			repoPath := createTestRepo(t)
+			defer os.RemoveAll(repoPath)
+			createBranchWithCommit(t, repoPath, "feature")
+
+			scoped := svc.WithProvenance(p)
+			var ch <-chan Result
+			if squash {
+				ch = scoped.SquashMergeToMain(ctx, repoPath, repoPath, "feature", "Add the feature")
+			} else {
+				ch = scoped.MergeToMain(ctx, repoPath, repoPath, "feature", "")
+			}
+			for result := range ch {
+				if result.Error != nil {
+					t.Fatalf("merge failed: %v\n%s", result.Error, result.Output)
+				}
+			}
+
+			message := gitIn(t, repoPath, "log", "-1", "--format=%B")
+			if !strings.HasSuffix(message, "\n\nPlural-Session: session-1\nPlural-Prompted-By: "+HashPrompt("fix the login")) {
+				t.Errorf("expected the trailers on the new commit, got:\n%s", message)
+			}
+			got, err := svc.ReadProvenance(ctx, repoPath, "HEAD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.SessionID != "session-1" || got.PromptedBy != HashPrompt("fix the login") {
+				t.Errorf("ReadProvenance = %+v, want session-1 prompted by the first prompt", got)
+			}
+			for _, want := range []string{"Plural-Session-Name: repo/fix-login", "Plural-Tokens: 1200", "Plural-Cost-USD: 0.0500", "Plural-Prompt: " + HashPrompt("add a test")} {
+				if !strings.Contains(got.Note, want) {
+					t.Errorf("expected %q in the note, got:\n%s", want, got.Note)
+				}
+			}
+			if strings.Contains(got.Note, "fix the login") {
+				t.Error("expected prompts recorded by hash only")
+			}
+		})
+	}
+}
+
+func TestReadProvenance_NoneRecorded(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	createBranchWithCommit(t, repoPath, "feature")
+
+	// Merging without provenance records nothing
+	for result := range svc.MergeToMain(ctx, repoPath, repoPath, "feature", "") {
+		if result.Error != nil {
+			t.Fatal(result.Error)
+		}
+	}
+	got, err := svc.ReadProvenance(ctx, repoPath, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SessionID != "" || got.Note != "" {
+		t.Errorf("expected no provenance, got %+v", got)
+	}
+	if _, err := svc.ReadProvenance(ctx, repoPath, "no-such-commit"); err == nil {
+		t.Error("expected an error for an unknown commit")
+	}
+}
@@ -0,0 +1,66 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ShellOutput is the output of a command run by RunShellCommand.
+type ShellOutput struct {
+	Lines    []string // End of the combined stdout and stderr, without ANSI escapes
+	Omitted  int      // Lines cut from the start of the output
+	ExitCode int      // The command's exit status (-1 if it timed out)
+	TimedOut bool
+}
+
+// RunShellCommand runs command with sh -c in dir, stopping it after timeout,
+// and returns the last maxLines lines of its combined output (all if maxLines
+// is 0). A command that fails or times out is not an error: its exit code and
+// output are returned as usual. The error is for a command that can't be run.
+func (s *GitService) RunShellCommand(ctx context.Context, dir, command string, timeout time.Duration, maxLines int) (ShellOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output, err := s.executor.CombinedOutput(ctx, dir, "sh", "-c", command)
+	result := ShellOutput{}
+	result.Lines, result.Omitted = TailLines(string(output), maxLines)
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.ExitCode = -1
+		result.TimedOut = true
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		return ShellOutput{}, err
+	}
+	return result, nil
+}
+
+// TailLines splits output into lines, without ANSI escapes or carriage
+// returns, and keeps the last maxLines (all if maxLines is 0). Returns the lines
+// kept and how many were cut from the start.
+func TailLines(output string, maxLines int) ([]string, int) {
+	output = strings.TrimRight(ansi.Strip(strings.ReplaceAll(output, "\r\n", "\n")), "\n")
+	if output == "" {
+		return nil, 0
+	}
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		// A carriage return redraws the line (progress bars); keep what was drawn last
+		if j := strings.LastIndex(line, "\r"); j >= 0 {
+			lines[i] = line[j+1:]
+		}
+	}
+	if maxLines > 0 && len(lines) > maxLines {
+		omitted := len(lines) - maxLines
+		return lines[omitted:], omitted
+	}
+	return lines, 0
+}
@@ -0,0 +1,111 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pexec "github.com/zhubert/plural/internal/exec"
+)
+
+func TestTailLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		maxLines    int
+		wantLines   []string
+		wantOmitted int
+	}{
+		{name: "empty", output: "", wantLines: nil},
+		{name: "all lines", output: "a\nb\nc\n", wantLines: []string{"a", "b", "c"}},
+		{name: "tail", output: "a\nb\nc\nd", maxLines: 2, wantLines: []string{"c", "d"}, wantOmitted: 2},
+		{name: "ansi stripped", output: "\x1b[31mFAIL\x1b[0m pkg\n", wantLines: []string{"FAIL pkg"}},
+		{name: "crlf", output: "a\r\nb\r\n", wantLines: []string{"a", "b"}},
+		{name: "progress redraws", output: "10%\r50%\r100%\ndone", wantLines: []string{"100%", "done"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, omitted := TailLines(tt.output, tt.maxLines)
+			if !slices.Equal(lines, tt.wantLines) || omitted != tt.wantOmitted {
+				t.Errorf("TailLines() = %q, %d; want %q, %d", lines, omitted, tt.wantLines, tt.wantOmitted)
+			}
+		})
+	}
+}
+
+func TestRunShellCommand(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("runs with sh in dir", func(t *testing.T) {
+		mock := pexec.NewMockExecutor(nil)
+		mock.AddExactMatch("sh", []string{"-c", "make test"}, pexec.MockResponse{Stdout: []byte("ok\n")})
+		svc := NewGitServiceWithExecutor(mock)
+
+		output, err := svc.RunShellCommand(ctx, "/repo/worktree", "make test", time.Minute, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(output.Lines, []string{"ok"}) || output.ExitCode != 0 {
+			t.Errorf("unexpected output %+v", output)
+		}
+		if calls := mock.GetCalls(); len(calls) != 1 || calls[0].Dir != "/repo/worktree" {
+			t.Errorf("expected one call in the worktree, got %+v", calls)
+		}
+	})
+
+	t.Run("cannot run", func(t *testing.T) {
+		mock := pexec.NewMockExecutor(nil)
+		mock.AddPrefixMatch("sh", nil, pexec.MockResponse{Err: errors.New("exec: \"sh\": executable file not found")})
+		svc := NewGitServiceWithExecutor(mock)
+
+		if _, err := svc.RunShellCommand(ctx, t.TempDir(), "true", time.Minute, 0); err == nil {
+			t.Error("expected an error when the shell can't be run")
+		}
+	})
+}
+
+func TestRunShellCommand_Real(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	svc := NewGitServiceWithExecutor(pexec.NewRealExecutor())
+	ctx := context.Background()
+
+	output, err := svc.RunShellCommand(ctx, t.TempDir(), "echo out; echo err >&2; exit 3", time.Minute, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.ExitCode != 3 || output.TimedOut {
+		t.Errorf("expected exit status 3, got %+v", output)
+	}
+	if joined := strings.Join(output.Lines, "\n"); !strings.Contains(joined, "out") || !strings.Contains(joined, "err") {
+		t.Errorf("expected combined output, got %q", output.Lines)
+	}
+
+	output, err = svc.RunShellCommand(ctx, t.TempDir(), "echo started; exec sleep 10", 200*time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !output.TimedOut || output.ExitCode != -1 {
+		t.Errorf("expected the command to time out, got %+v", output)
+	}
+
+	// The shell's children are killed too, so a pipeline doesn't hold the
+	// output open until it finishes on its own
+	start := time.Now()
+	output, err = svc.RunShellCommand(ctx, t.TempDir(), "sleep 10 | cat", 200*time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !output.TimedOut {
+		t.Errorf("expected the pipeline to time out, got %+v", output)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the pipeline to be stopped at the timeout, took %v", elapsed)
+	}
+}
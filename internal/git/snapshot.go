@@ -0,0 +1,219 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// SnapshotRefPrefix is the namespace snapshot refs are stored under. Each session
+// numbers its snapshots from 1: refs/plural/snapshot/<session-id>/<n>. Worktrees
+// share refs with their repo, so the session ID keeps sessions apart.
+const SnapshotRefPrefix = "refs/plural/snapshot/"
+
+// Snapshot is a recorded state of a session's worktree, including uncommitted
+// and untracked files.
+type Snapshot struct {
+	Ref       string    // Full ref name
+	Number    int       // Position in the session's sequence, starting at 1
+	Commit    string    // SHA of the snapshot commit
+	CreatedAt time.Time // When the snapshot was taken
+}
+
+// SnapshotDiff is the difference between two snapshots, or a snapshot and the
+// current worktree.
+type SnapshotDiff struct {
+	Files []FileDiff
+	Stats DiffStats
+}
+
+// snapshotRefs returns the ref namespace for a session's snapshots.
+func snapshotRefs(sessionID string) string {
+	return SnapshotRefPrefix + sessionID + "/"
+}
+
+// CreateSnapshot records the current state of worktreePath as the next snapshot
+// of sessionID. The worktree, index and branch are left untouched.
+func (s *GitService) CreateSnapshot(ctx context.Context, worktreePath, sessionID string) (*Snapshot, error) {
+	existing, err := s.ListSnapshots(ctx, worktreePath, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	number := 1
+	if len(existing) > 0 {
+		number = existing[len(existing)-1].Number + 1
+	}
+
+	commit, err := s.snapshotCommit(ctx, worktreePath, fmt.Sprintf("plural snapshot %d", number))
+	if err != nil {
+		return nil, err
+	}
+
+	ref := snapshotRefs(sessionID) + strconv.Itoa(number)
+	if output, err := s.executor.CombinedOutput(ctx, worktreePath, "git", "update-ref", ref, commit); err != nil {
+		return nil, fmt.Errorf("failed to record snapshot: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	logger.WithSession(sessionID).Info("created snapshot", "ref", ref, "commit", commit)
+	return &Snapshot{Ref: ref, Number: number, Commit: commit, CreatedAt: time.Now()}, nil
+}
+
+// ListSnapshots returns the snapshots of sessionID, oldest first.
+func (s *GitService) ListSnapshots(ctx context.Context, repoPath, sessionID string) ([]Snapshot, error) {
+	output, err := s.executor.Output(ctx, repoPath, "git", "for-each-ref",
+		"--format=%(refname)%09%(objectname)%09%(committerdate:iso-strict)", snapshotRefs(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	var snapshots []Snapshot
+	for line := range strings.SplitSeq(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimPrefix(fields[0], snapshotRefs(sessionID)))
+		if err != nil {
+			continue // Not one of ours
+		}
+		createdAt, _ := time.Parse(time.RFC3339, fields[2])
+		snapshots = append(snapshots, Snapshot{Ref: fields[0], Number: number, Commit: fields[1], CreatedAt: createdAt})
+	}
+	// for-each-ref sorts refs as strings, which puts 10 before 2
+	slices.SortFunc(snapshots, func(a, b Snapshot) int { return a.Number - b.Number })
+	return snapshots, nil
+}
+
+// DiffSnapshots returns the changes from snapshot ref from to snapshot ref to.
+// An empty to compares against the current state of worktreePath instead.
+func (s *GitService) DiffSnapshots(ctx context.Context, worktreePath, from, to string) (*SnapshotDiff, error) {
+	if to == "" {
+		commit, err := s.snapshotCommit(ctx, worktreePath, "plural snapshot (now)")
+		if err != nil {
+			return nil, err
+		}
+		to = commit
+	}
+
+	nameStatus, err := s.executor.Output(ctx, worktreePath, "git", "diff", "--no-ext-diff", "--no-renames", "--name-status", from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff snapshots: %w", err)
+	}
+	var files []string
+	fileStatuses := make(map[string]string)
+	for line := range strings.SplitSeq(strings.TrimSpace(string(nameStatus)), "\n") {
+		status, file, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		files = append(files, file)
+		fileStatuses[file] = status
+	}
+
+	result := &SnapshotDiff{Stats: DiffStats{FilesChanged: len(files)}}
+	if len(files) == 0 {
+		return result, nil
+	}
+
+	diff, err := s.executor.Output(ctx, worktreePath, "git", "diff", "--no-ext-diff", "--no-renames", from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff snapshots: %w", err)
+	}
+	result.Files = s.parseFileDiffs(ctx, worktreePath, string(diff), files, fileStatuses)
+
+	numstat, err := s.executor.Output(ctx, worktreePath, "git", "diff", "--no-ext-diff", "--no-renames", "--numstat", from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff snapshots: %w", err)
+	}
+	for line := range strings.SplitSeq(strings.TrimSpace(string(numstat)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		// Binary files report "-" for both counts
+		added, _ := strconv.Atoi(fields[0])
+		deleted, _ := strconv.Atoi(fields[1])
+		result.Stats.Additions += added
+		result.Stats.Deletions += deleted
+	}
+	return result, nil
+}
+
+// DeleteSnapshots removes every snapshot ref of sessionID. The snapshot commits
+// become unreachable and are collected by git gc.
+func (s *GitService) DeleteSnapshots(ctx context.Context, repoPath, sessionID string) error {
+	snapshots, err := s.ListSnapshots(ctx, repoPath, sessionID)
+	if err != nil {
+		return err
+	}
+	for _, snap := range snapshots {
+		if output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "update-ref", "-d", snap.Ref); err != nil {
+			return fmt.Errorf("failed to delete snapshot %s: %s: %w", snap.Ref, strings.TrimSpace(string(output)), err)
+		}
+	}
+	if len(snapshots) > 0 {
+		logger.WithSession(sessionID).Info("deleted snapshots", "count", len(snapshots))
+	}
+	return nil
+}
+
+// snapshotCommit writes the current worktree (tracked and untracked files, minus
+// ignored ones) as a commit whose parent is HEAD, without touching the real index.
+// The commit is not referenced by anything; callers decide whether to keep it.
+func (s *GitService) snapshotCommit(ctx context.Context, worktreePath, message string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "plural-snapshot-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary index: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	indexPath := filepath.Join(tmpDir, "index")
+	indexEnv := "GIT_INDEX_FILE=" + indexPath
+
+	// Start from the worktree's own index, which tracks HEAD and caches file stats,
+	// so adding the worktree re-hashes only what changed rather than every file.
+	// Without one, the whole worktree is added from scratch.
+	s.copyIndex(ctx, worktreePath, indexPath)
+
+	// The executor has no way to set the environment, so go through env(1)
+	if output, err := s.executor.CombinedOutput(ctx, worktreePath, "env", indexEnv, "git", "add", "--all"); err != nil {
+		return "", fmt.Errorf("failed to stage snapshot: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	tree, err := s.executor.Output(ctx, worktreePath, "env", indexEnv, "git", "write-tree")
+	if err != nil {
+		return "", fmt.Errorf("failed to write snapshot tree: %w", err)
+	}
+
+	args := []string{"commit-tree", strings.TrimSpace(string(tree)), "-m", message}
+	if head, err := s.executor.Output(ctx, worktreePath, "git", "rev-parse", "--verify", "--quiet", "HEAD"); err == nil {
+		args = append(args, "-p", strings.TrimSpace(string(head)))
+	}
+	commit, err := s.executor.Output(ctx, worktreePath, "git", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to write snapshot commit: %w", err)
+	}
+	return strings.TrimSpace(string(commit)), nil
+}
+
+// copyIndex copies the index of worktreePath to dst, if it has one.
+func (s *GitService) copyIndex(ctx context.Context, worktreePath, dst string) {
+	output, err := s.executor.Output(ctx, worktreePath, "git", "rev-parse", "--git-path", "index")
+	if err != nil {
+		return
+	}
+	src := strings.TrimSpace(string(output))
+	if src == "" {
+		return
+	}
+	if !filepath.IsAbs(src) {
+		src = filepath.Join(worktreePath, src)
+	}
+	if data, err := os.ReadFile(src); err == nil {
+		os.WriteFile(dst, data, 0644)
+	}
+}
@@ -0,0 +1,154 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSnapshots_ScriptedSequence(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 1: untouched checkout
+	snap1, err := svc.CreateSnapshot(ctx, repoPath, "session-a")
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+
+	// 2: a tracked file modified and an untracked file added
+	write("test.txt", "test content\nmore\n")
+	write("new.go", "package main\n")
+	snap2, err := svc.CreateSnapshot(ctx, repoPath, "session-a")
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+
+	// Current state: the untracked file removed again
+	if err := os.Remove(filepath.Join(repoPath, "new.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := svc.ListSnapshots(ctx, repoPath, "session-a")
+	if err != nil {
+		t.Fatalf("ListSnapshots failed: %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Number != 1 || snapshots[1].Number != 2 {
+		t.Fatalf("ListSnapshots() = %+v, want snapshots 1 and 2", snapshots)
+	}
+	if snapshots[0].Ref != "refs/plural/snapshot/session-a/1" || snapshots[0].Commit != snap1.Commit {
+		t.Errorf("snapshot 1 = %+v, want %+v", snapshots[0], snap1)
+	}
+	if snapshots[1].CreatedAt.IsZero() {
+		t.Error("expected snapshot timestamp")
+	}
+
+	diff, err := svc.DiffSnapshots(ctx, repoPath, snap1.Ref, snap2.Ref)
+	if err != nil {
+		t.Fatalf("DiffSnapshots failed: %v", err)
+	}
+	want := map[string]string{"new.go": "A", "test.txt": "M"}
+	if len(diff.Files) != len(want) {
+		t.Fatalf("diff files = %+v, want %v", diff.Files, want)
+	}
+	for _, f := range diff.Files {
+		if want[f.Filename] != f.Status {
+			t.Errorf("file %s has status %q, want %q", f.Filename, f.Status, want[f.Filename])
+		}
+		if !strings.HasPrefix(f.Diff, "diff --git a/"+f.Filename) {
+			t.Errorf("file %s has diff %q", f.Filename, f.Diff)
+		}
+	}
+	if diff.Stats != (DiffStats{FilesChanged: 2, Additions: 3, Deletions: 1}) {
+		t.Errorf("diff stats = %+v", diff.Stats)
+	}
+
+	// Against now, only the removed file differs
+	diff, err = svc.DiffSnapshots(ctx, repoPath, snap2.Ref, "")
+	if err != nil {
+		t.Fatalf("DiffSnapshots against now failed: %v", err)
+	}
+	if len(diff.Files) != 1 || diff.Files[0].Filename != "new.go" || diff.Files[0].Status != "D" {
+		t.Errorf("diff against now = %+v, want new.go deleted", diff.Files)
+	}
+
+	// Snapshots never touch the index or the branch
+	output, err := exec.Command("git", "-C", repoPath, "status", "--porcelain").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(output)) != "M test.txt" {
+		t.Errorf("git status = %q, want only the unstaged modification", output)
+	}
+}
+
+func TestSnapshots_NumberingAndCleanupPerSession(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	for range 11 {
+		if _, err := svc.CreateSnapshot(ctx, repoPath, "session-a"); err != nil {
+			t.Fatalf("CreateSnapshot failed: %v", err)
+		}
+	}
+	if _, err := svc.CreateSnapshot(ctx, repoPath, "session-b"); err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+
+	snapshots, err := svc.ListSnapshots(ctx, repoPath, "session-a")
+	if err != nil {
+		t.Fatalf("ListSnapshots failed: %v", err)
+	}
+	if len(snapshots) != 11 || snapshots[9].Number != 10 || snapshots[10].Number != 11 {
+		t.Fatalf("expected snapshots numbered 1-11 in order, got %+v", snapshots)
+	}
+
+	if err := svc.DeleteSnapshots(ctx, repoPath, "session-a"); err != nil {
+		t.Fatalf("DeleteSnapshots failed: %v", err)
+	}
+	if snapshots, _ := svc.ListSnapshots(ctx, repoPath, "session-a"); len(snapshots) != 0 {
+		t.Errorf("expected session-a snapshots deleted, got %+v", snapshots)
+	}
+	if snapshots, _ := svc.ListSnapshots(ctx, repoPath, "session-b"); len(snapshots) != 1 {
+		t.Errorf("expected session-b snapshot kept, got %+v", snapshots)
+	}
+}
+
+func TestSnapshots_StagedChangesInWorktree(t *testing.T) {
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	worktreePath := filepath.Join(t.TempDir(), "wt")
+	gitIn(t, repoPath, "worktree", "add", "-b", "snap-branch", worktreePath)
+
+	// The worktree's index seeds the snapshot, but the worktree itself wins
+	gitIn(t, worktreePath, "rm", "-q", "test.txt")
+	if err := os.WriteFile(filepath.Join(worktreePath, "staged.go"), []byte("package a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, worktreePath, "add", "staged.go")
+	if err := os.WriteFile(filepath.Join(worktreePath, "staged.go"), []byte("package b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := svc.CreateSnapshot(ctx, worktreePath, "session-wt")
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+	if files := gitIn(t, worktreePath, "ls-tree", "--name-only", snap.Commit); files != "staged.go" {
+		t.Errorf("snapshot files = %q, want only staged.go", files)
+	}
+	if content := gitIn(t, worktreePath, "show", snap.Commit+":staged.go"); content != "package b" {
+		t.Errorf("snapshot staged.go = %q, want the worktree's content", content)
+	}
+	if status := gitIn(t, worktreePath, "status", "--porcelain"); status != "AM staged.go\nD  test.txt" {
+		t.Errorf("git status = %q, want the staged changes untouched", status)
+	}
+}
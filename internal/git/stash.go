@@ -0,0 +1,142 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// StashLabel returns the message an automatic stash is saved under,
+// e.g. "plural: before merge of fix-login".
+func StashLabel(operation, branch string) string {
+	return fmt.Sprintf("plural: before %s of %s", operation, branch)
+}
+
+// StashApplyError reports that stashed changes could not be re-applied (usually
+// because they conflict with the operation's result). The stash is left in place.
+type StashApplyError struct {
+	Ref    string // Stash commit SHA; "git stash apply <Ref>" restores the changes
+	Label  string // Message the stash was saved under
+	Output string // Output of git stash apply
+}
+
+func (e *StashApplyError) Error() string {
+	return fmt.Sprintf("could not re-apply stashed changes; they are kept in stash %s (%s)", ShortSHA(e.Ref), e.Label)
+}
+
+// Hint returns instructions for recovering the stashed changes by hand.
+func (e *StashApplyError) Hint() string {
+	return fmt.Sprintf("Your uncommitted changes are kept in stash %s (%q).\nResolve the conflicts, or reset, then re-apply them with:\n  git stash apply %s\n", e.Ref, e.Label, e.Ref)
+}
+
+// StashChanges stashes uncommitted changes in repoPath, including untracked files,
+// under label. Returns the stash commit SHA, which keeps identifying the stash as
+// other stashes are pushed. Returns "" if there was nothing to stash.
+func (s *GitService) StashChanges(ctx context.Context, repoPath, label string) (string, error) {
+	output, err := s.run(ctx, stashPushCommand(repoPath, label))
+	if err != nil {
+		return "", fmt.Errorf("git stash failed: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	if strings.Contains(string(output), "No local changes to save") {
+		return "", nil
+	}
+
+	ref, err := s.executor.Output(ctx, repoPath, "git", "rev-parse", "--verify", "refs/stash")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve stash: %w", err)
+	}
+	logger.WithComponent("git").Info("stashed changes", "repoPath", repoPath, "label", label, "ref", strings.TrimSpace(string(ref)))
+	return strings.TrimSpace(string(ref)), nil
+}
+
+// RestoreStash applies the stash identified by ref (as returned by StashChanges) to
+// repoPath and drops it. If applying fails, the stash is kept and a *StashApplyError
+// carrying the ref is returned.
+func (s *GitService) RestoreStash(ctx context.Context, repoPath, ref, label string) error {
+	log := logger.WithComponent("git")
+
+	output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "stash", "apply", ref)
+	if err != nil {
+		log.Warn("failed to re-apply stash", "repoPath", repoPath, "ref", ref, "error", err)
+		return &StashApplyError{Ref: ref, Label: label, Output: string(output)}
+	}
+
+	// Stash entries are addressed by position, so find where ref currently sits
+	list, err := s.executor.Output(ctx, repoPath, "git", "stash", "list", "--format=%H")
+	if err != nil {
+		log.Warn("applied stash but could not list stashes to drop it", "ref", ref, "error", err)
+		return nil
+	}
+	index := slices.Index(strings.Split(strings.TrimSpace(string(list)), "\n"), ref)
+	if index < 0 {
+		return nil
+	}
+	if output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "stash", "drop", fmt.Sprintf("stash@{%d}", index)); err != nil {
+		log.Warn("applied stash but could not drop it", "ref", ref, "output", string(output), "error", err)
+	}
+	return nil
+}
+
+// WithAutoStash stashes uncommitted changes in repoPath under label, then runs the
+// operation started by run and re-applies the changes once it finishes. The stash is
+// kept, with instructions for restoring it, if the operation stops on a merge conflict
+// in repoPath or the changes no longer apply cleanly. Conflicts elsewhere, as in a
+// session's worktree, leave repoPath clean, so the changes are re-applied.
+//
+// Merge, squash merge and rebase onto main all check out the default branch in
+// repoPath and run through here. Preview checks out a session's branch for an open
+// ended period instead, so it refuses to start or end over uncommitted changes.
+func (s *GitService) WithAutoStash(ctx context.Context, repoPath, label string, run func() <-chan Result) <-chan Result {
+	ch := make(chan Result)
+
+	go func() {
+		defer close(ch)
+
+		ch <- Result{Output: "Stashing uncommitted changes in main repo...\n"}
+		ref, err := s.StashChanges(ctx, repoPath, label)
+		if err != nil {
+			ch <- Result{Error: err, Done: true}
+			return
+		}
+
+		// Hold back the final result so the changes are restored before callers see Done
+		final := Result{Done: true}
+		for result := range run() {
+			if result.Done {
+				final = result
+				continue
+			}
+			ch <- result
+		}
+
+		if ref == "" {
+			ch <- final
+			return
+		}
+		if len(final.ConflictedFiles) > 0 && filepath.Clean(final.RepoPath) == filepath.Clean(repoPath) {
+			// Applying on top of an unresolved merge would mix the changes into the resolution
+			ch <- Result{Output: "\n" + (&StashApplyError{Ref: ref, Label: label}).Hint(), KeptStash: ref}
+			ch <- final
+			return
+		}
+
+		ch <- Result{Output: "Re-applying stashed changes...\n"}
+		// Restore even if the operation was cancelled so the changes are not left stashed
+		if err := s.RestoreStash(context.WithoutCancel(ctx), repoPath, ref, label); err != nil {
+			output := "\nWarning: " + err.Error() + "\n"
+			var applyErr *StashApplyError
+			if errors.As(err, &applyErr) {
+				output += applyErr.Output + "\n" + applyErr.Hint()
+			}
+			ch <- Result{Output: output, KeptStash: ref}
+		}
+		ch <- final
+	}()
+
+	return ch
+}
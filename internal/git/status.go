@@ -3,6 +3,8 @@ package git
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/zhubert/plural/internal/logger"
@@ -344,6 +346,36 @@ func (s *GitService) GetConflictedFiles(ctx context.Context, repoPath string) ([
 	return files, nil
 }
 
+// GetConflictDiff returns the diff of the files with merge conflicts in a repo,
+// showing the conflict markers against both sides.
+func (s *GitService) GetConflictDiff(ctx context.Context, repoPath string) (string, error) {
+	output, err := s.executor.Output(ctx, repoPath, "git", "diff", "--diff-filter=U")
+	if err != nil {
+		return "", fmt.Errorf("failed to get conflict diff: %w", err)
+	}
+	return string(output), nil
+}
+
+// IsRebaseInProgress checks if a rebase is currently in progress in the worktree,
+// as when one stopped on conflicts. It returns true if git's rebase state
+// directory (rebase-merge, or rebase-apply for older rebases) exists.
+func (s *GitService) IsRebaseInProgress(ctx context.Context, worktreePath string) (bool, error) {
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		output, err := s.executor.Output(ctx, worktreePath, "git", "rev-parse", "--git-path", dir)
+		if err != nil {
+			return false, fmt.Errorf("failed to check for a rebase: %w", err)
+		}
+		path := strings.TrimSpace(string(output))
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(worktreePath, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // IsMergeInProgress checks if a merge is currently in progress in the repo.
 // It returns true if MERGE_HEAD exists (meaning there's an ongoing merge).
 func (s *GitService) IsMergeInProgress(ctx context.Context, repoPath string) (bool, error) {
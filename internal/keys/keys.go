@@ -27,6 +27,7 @@ var (
 	Enter      = tea.KeyPressMsg{Code: tea.KeyEnter}.String()                      // "enter"
 	ShiftEnter = (tea.KeyPressMsg{Code: tea.KeyEnter, Mod: tea.ModShift}).String() // "shift+enter"
 	AltEnter   = (tea.KeyPressMsg{Code: tea.KeyEnter, Mod: tea.ModAlt}).String()   // "alt+enter"
+	CtrlEnter  = (tea.KeyPressMsg{Code: tea.KeyEnter, Mod: tea.ModCtrl}).String()  // "ctrl+enter"
 	Tab        = tea.KeyPressMsg{Code: tea.KeyTab}.String()                        // "tab"
 	ShiftTab   = (tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}).String()   // "shift+tab"
 	Space      = tea.KeyPressMsg{Code: tea.KeySpace}.String()                      // "space"
@@ -49,8 +50,12 @@ var (
 	CtrlN      = (tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl}).String()                // "ctrl+n"
 	CtrlP      = (tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl}).String()                // "ctrl+p"
 	CtrlE      = (tea.KeyPressMsg{Code: 'e', Mod: tea.ModCtrl}).String()                // "ctrl+e"
+	CtrlF      = (tea.KeyPressMsg{Code: 'f', Mod: tea.ModCtrl}).String()                // "ctrl+f"
 	CtrlR      = (tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl}).String()                // "ctrl+r"
+	CtrlZ      = (tea.KeyPressMsg{Code: 'z', Mod: tea.ModCtrl}).String()                // "ctrl+z"
+	CtrlG      = (tea.KeyPressMsg{Code: 'g', Mod: tea.ModCtrl}).String()                // "ctrl+g"
 	CtrlSlash  = (tea.KeyPressMsg{Code: '/', Mod: tea.ModCtrl}).String()                // "ctrl+/"
+	CtrlSemi   = (tea.KeyPressMsg{Code: ';', Mod: tea.ModCtrl}).String()                // "ctrl+;"
 	CtrlShiftB = (tea.KeyPressMsg{Code: 'b', Mod: tea.ModCtrl | tea.ModShift}).String() // "ctrl+shift+b"
 	CtrlUp     = (tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModCtrl}).String()          // "ctrl+up"
 	CtrlDown   = (tea.KeyPressMsg{Code: tea.KeyDown, Mod: tea.ModCtrl}).String()        // "ctrl+down"
@@ -59,4 +64,11 @@ var (
 // Alt combinations
 var (
 	AltComma = (tea.KeyPressMsg{Code: ',', Mod: tea.ModAlt}).String() // "alt+,"
+	AltJ     = (tea.KeyPressMsg{Code: 'j', Mod: tea.ModAlt}).String() // "alt+j"
+	AltK     = (tea.KeyPressMsg{Code: 'k', Mod: tea.ModAlt}).String() // "alt+k"
+	AltM     = (tea.KeyPressMsg{Code: 'm', Mod: tea.ModAlt}).String() // "alt+m"
+	AltN     = (tea.KeyPressMsg{Code: 'n', Mod: tea.ModAlt}).String() // "alt+n"
+	AltO     = (tea.KeyPressMsg{Code: 'o', Mod: tea.ModAlt}).String() // "alt+o"
+	AltP     = (tea.KeyPressMsg{Code: 'p', Mod: tea.ModAlt}).String() // "alt+p"
+	AltY     = (tea.KeyPressMsg{Code: 'y', Mod: tea.ModAlt}).String() // "alt+y"
 )
@@ -24,6 +24,7 @@ func TestKeyStringValues(t *testing.T) {
 		// Actions
 		{"Enter", Enter, "enter"},
 		{"ShiftEnter", ShiftEnter, "shift+enter"},
+		{"CtrlEnter", CtrlEnter, "ctrl+enter"},
 		{"Tab", Tab, "tab"},
 		{"ShiftTab", ShiftTab, "shift+tab"},
 		{"Space", Space, "space"},
@@ -44,10 +45,15 @@ func TestKeyStringValues(t *testing.T) {
 		{"CtrlN", CtrlN, "ctrl+n"},
 		{"CtrlP", CtrlP, "ctrl+p"},
 		{"CtrlE", CtrlE, "ctrl+e"},
+		{"CtrlF", CtrlF, "ctrl+f"},
+		{"CtrlSemi", CtrlSemi, "ctrl+;"},
 		{"CtrlSlash", CtrlSlash, "ctrl+/"},
 		{"CtrlShiftB", CtrlShiftB, "ctrl+shift+b"},
 		{"CtrlUp", CtrlUp, "ctrl+up"},
 		{"CtrlDown", CtrlDown, "ctrl+down"},
+		{"AltM", AltM, "alt+m"},
+		{"AltN", AltN, "alt+n"},
+		{"AltP", AltP, "alt+p"},
 	}
 
 	for _, tt := range tests {
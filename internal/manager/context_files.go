@@ -0,0 +1,98 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// ContextFilesMaxBytes caps the combined size of context files sent to a fresh
+// CLI session. Files past the cap are truncated with a warning.
+const ContextFilesMaxBytes = 64 * 1024
+
+// ValidateContextFile checks that path names a regular file inside worktree and
+// returns it relative to the worktree, as stored in the session's context files.
+func ValidateContextFile(worktree, path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", fmt.Errorf("path is empty")
+	}
+	abs := path
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(worktree, path)
+	}
+	rel, err := filepath.Rel(worktree, filepath.Clean(abs))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not inside the session worktree", path)
+	}
+
+	info, err := os.Stat(filepath.Join(worktree, rel))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%s does not exist in the session worktree", rel)
+		}
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%s is not a regular file", rel)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// BuildContextFiles reads a session's context files from its worktree and formats
+// them as a message block, capping the combined size at ContextFilesMaxBytes.
+// Returns warnings for files that were truncated, skipped, or could not be read.
+func BuildContextFiles(worktree string, paths []string) (string, []string) {
+	if len(paths) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	var warnings []string
+	remaining := ContextFilesMaxBytes
+	included := 0
+	for _, path := range paths {
+		if remaining <= 0 {
+			warnings = append(warnings, fmt.Sprintf("Context file %s skipped: context files are limited to %dKB", path, ContextFilesMaxBytes/1024))
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(worktree, filepath.FromSlash(path)))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Context file %s could not be read: %v", path, err))
+			continue
+		}
+
+		content := string(data)
+		if len(content) > remaining {
+			content = truncateUTF8(content, remaining)
+			remaining = 0 // Later files are skipped rather than cut to a few bytes
+			if content == "" {
+				warnings = append(warnings, fmt.Sprintf("Context file %s skipped: context files are limited to %dKB", path, ContextFilesMaxBytes/1024))
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("Context file %s truncated to %dKB of %dKB", path, len(content)/1024, len(data)/1024))
+		} else {
+			remaining -= len(content)
+		}
+		included++
+
+		fmt.Fprintf(&sb, "<context_file path=%q>\n%s\n</context_file>\n", path, strings.TrimRight(content, "\n"))
+	}
+	if included == 0 {
+		return "", warnings
+	}
+	return "These context files are attached to this session. Their current contents follow; keep them in mind for the rest of the conversation.\n\n" + sb.String(), warnings
+}
+
+// truncateUTF8 shortens s to at most n bytes without splitting a UTF-8 sequence.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,97 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeWorktreeFile(t *testing.T, worktree, rel, content string) {
+	t.Helper()
+	path := filepath.Join(worktree, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateContextFile(t *testing.T) {
+	worktree := t.TempDir()
+	writeWorktreeFile(t, worktree, "docs/ARCHITECTURE.md", "# Architecture")
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	os.WriteFile(outside, []byte("x"), 0644)
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{path: "docs/ARCHITECTURE.md", want: "docs/ARCHITECTURE.md"},
+		{path: "  ./docs/../docs/ARCHITECTURE.md ", want: "docs/ARCHITECTURE.md"},
+		{path: filepath.Join(worktree, "docs", "ARCHITECTURE.md"), want: "docs/ARCHITECTURE.md"},
+		{path: "docs/missing.md", wantErr: "does not exist"},
+		{path: "docs", wantErr: "not a regular file"},
+		{path: "../secret.txt", wantErr: "not inside"},
+		{path: outside, wantErr: "not inside"},
+		{path: ".", wantErr: "not inside"},
+		{path: " ", wantErr: "empty"},
+	}
+	for _, tt := range tests {
+		got, err := ValidateContextFile(worktree, tt.path)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateContextFile(%q) error = %v, want %q", tt.path, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ValidateContextFile(%q) = %q, %v; want %q", tt.path, got, err, tt.want)
+		}
+	}
+}
+
+func TestBuildContextFiles(t *testing.T) {
+	worktree := t.TempDir()
+	writeWorktreeFile(t, worktree, "docs/ARCHITECTURE.md", "# Architecture\nLayers.\n")
+	writeWorktreeFile(t, worktree, "api.yaml", "openapi: 3.0")
+
+	text, warnings := BuildContextFiles(worktree, []string{"docs/ARCHITECTURE.md", "api.yaml", "gone.md"})
+	for _, want := range []string{
+		"<context_file path=\"docs/ARCHITECTURE.md\">\n# Architecture\nLayers.\n</context_file>",
+		"<context_file path=\"api.yaml\">\nopenapi: 3.0\n</context_file>",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in:\n%s", want, text)
+		}
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "gone.md could not be read") {
+		t.Errorf("expected a warning for the missing file, got %v", warnings)
+	}
+
+	if text, warnings := BuildContextFiles(worktree, nil); text != "" || warnings != nil {
+		t.Errorf("expected nothing for no context files, got %q %v", text, warnings)
+	}
+	if text, _ := BuildContextFiles(worktree, []string{"gone.md"}); text != "" {
+		t.Errorf("expected no message when no file could be read, got %q", text)
+	}
+}
+
+func TestBuildContextFiles_CapsSize(t *testing.T) {
+	worktree := t.TempDir()
+	writeWorktreeFile(t, worktree, "big.txt", "a"+strings.Repeat("é", ContextFilesMaxBytes)) // Cap falls mid-rune
+	writeWorktreeFile(t, worktree, "small.txt", "tiny")
+
+	text, warnings := BuildContextFiles(worktree, []string{"big.txt", "small.txt"})
+	if len(text) > ContextFilesMaxBytes+1024 {
+		t.Errorf("context is %d bytes, expected about %d", len(text), ContextFilesMaxBytes)
+	}
+	if !strings.Contains(text, "é\n</context_file>") || strings.Contains(text, "�") {
+		t.Error("truncation should not split a UTF-8 sequence")
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "big.txt truncated") || !strings.Contains(warnings[1], "small.txt skipped") {
+		t.Errorf("expected truncation and skip warnings, got %v", warnings)
+	}
+}
@@ -3,6 +3,7 @@ package manager
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
@@ -17,6 +18,7 @@ import (
 	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/logger"
 	"github.com/zhubert/plural/internal/mcp"
+	"github.com/zhubert/plural/internal/paths"
 )
 
 // Compile-time interface satisfaction check.
@@ -74,9 +76,12 @@ type SessionManagerConfig interface {
 	GetSession(id string) *config.Session
 	GetSessions() []config.Session
 	GetAllowedToolsForRepo(repoPath string) []string
+	GetDeniedToolsForRepo(repoPath string) []string
 	GetMCPServersForRepo(repoPath string) []config.MCPServer
 	GetContainerImage(repoPath string) string
 	AddRepoAllowedTool(repoPath, tool string) bool
+	SetSessionMirrorOutput(sessionID string, enabled bool) bool
+	SetSessionModel(sessionID, model string) bool
 	Save() error
 }
 
@@ -90,7 +95,14 @@ type SessionManager struct {
 	runnerFactory   RunnerFactory
 	skipMessageLoad bool // Skip loading messages from disk (for demos/tests)
 	gitService      *git.GitService
-	mu              sync.RWMutex // Protects runners map
+	mu              sync.RWMutex // Protects runners map and preloaded
+
+	// Message histories loaded ahead of runner creation, by session ID
+	preloaded map[string][]claude.Message
+
+	saveLocks sync.Map                // Session ID -> *sync.Mutex serializing message saves
+	saved     map[string]messagesMark // Last history written per session
+	savedMu   sync.Mutex              // Protects saved
 }
 
 // NewSessionManager creates a new session manager.
@@ -99,8 +111,10 @@ func NewSessionManager(cfg SessionManagerConfig, gitSvc *git.GitService) *Sessio
 		config:        cfg,
 		stateManager:  NewSessionStateManager(),
 		runners:       make(map[string]claude.RunnerInterface),
+		preloaded:     make(map[string][]claude.Message),
 		runnerFactory: defaultRunnerFactory,
 		gitService:    gitSvc,
+		saved:         make(map[string]messagesMark),
 	}
 }
 
@@ -177,18 +191,7 @@ func (sm *SessionManager) Select(sess *config.Session, previousSessionID string,
 
 	// Save previous session's state if provided
 	if previousSessionID != "" {
-		if previousInput != "" || previousStreaming != "" {
-			prevState := sm.stateManager.GetOrCreate(previousSessionID)
-			prevLog := logger.WithSession(previousSessionID)
-			if previousInput != "" {
-				prevState.SetInputText(previousInput)
-				prevLog.Debug("saved input for session")
-			}
-			if previousStreaming != "" {
-				prevState.SetStreamingContent(previousStreaming)
-				prevLog.Debug("saved streaming content for session")
-			}
-		}
+		sm.StashUIState(previousSessionID, previousInput, previousStreaming)
 	}
 
 	log := logger.WithSession(sess.ID)
@@ -271,6 +274,81 @@ func (sm *SessionManager) Select(sess *config.Session, previousSessionID string,
 	return result
 }
 
+// StashUIState keeps the draft input and any streaming content shown for a
+// session that is being switched away from, for when it is selected again.
+func (sm *SessionManager) StashUIState(sessionID, input, streaming string) {
+	if input == "" && streaming == "" {
+		return
+	}
+	state := sm.stateManager.GetOrCreate(sessionID)
+	log := logger.WithSession(sessionID)
+	if input != "" {
+		state.SetInputText(input)
+		log.Debug("saved input for session")
+	}
+	if streaming != "" {
+		state.SetStreamingContent(streaming)
+		log.Debug("saved streaming content for session")
+	}
+}
+
+// LargeHistoryBytes is the size of saved message history at or above which the
+// TUI loads it in the background on first selection, instead of blocking.
+const LargeHistoryBytes = 256 << 10
+
+// NeedsHistoryLoad reports whether a session's message history still has to be
+// read from disk and is large enough, or ends in a turn cut off by a crash, to
+// be worth loading with PreloadHistory.
+func (sm *SessionManager) NeedsHistoryLoad(sessionID string) bool {
+	if sm.skipMessageLoad {
+		return false
+	}
+	sm.mu.RLock()
+	_, hasRunner := sm.runners[sessionID]
+	_, loaded := sm.preloaded[sessionID]
+	sm.mu.RUnlock()
+	if hasRunner || loaded {
+		return false
+	}
+	if config.TurnInProgress(sessionID) {
+		return true // Recovering the cut-off turn reads Claude's transcript
+	}
+	size, err := config.SessionMessagesSize(sessionID)
+	return err == nil && size >= LargeHistoryBytes
+}
+
+// PreloadHistory reads a session's message history from disk so that creating its
+// runner later does not. Safe to call from a background goroutine.
+func (sm *SessionManager) PreloadHistory(sess *config.Session) {
+	msgs := sm.loadInitialMessages(sess)
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if _, exists := sm.runners[sess.ID]; !exists {
+		sm.preloaded[sess.ID] = msgs
+	}
+}
+
+// loadInitialMessages reads a session's saved messages for a new runner,
+// completing any response cut off by a crash.
+func (sm *SessionManager) loadInitialMessages(sess *config.Session) []claude.Message {
+	log := logger.WithSession(sess.ID)
+	if sm.skipMessageLoad {
+		log.Debug("skipping message load (demo/test mode)")
+		return nil
+	}
+	savedMsgs, err := config.LoadSessionMessages(sess.ID)
+	if err != nil {
+		log.Warn("failed to load session messages", "error", err)
+		return nil
+	}
+	log.Debug("loaded saved messages", "count", len(savedMsgs))
+	var initialMsgs []claude.Message
+	for _, msg := range savedMsgs {
+		initialMsgs = append(initialMsgs, FromConfigMessage(msg))
+	}
+	return recoverFromClaudeSession(sess, initialMsgs)
+}
+
 // GetOrCreateRunner returns an existing runner or creates a new one for the session.
 // Uses double-checked locking to prevent race conditions where multiple goroutines
 // could create duplicate runners for the same session.
@@ -288,23 +366,13 @@ func (sm *SessionManager) GetOrCreateRunner(sess *config.Session) claude.RunnerI
 	sm.mu.RUnlock()
 
 	// Load messages from disk BEFORE acquiring write lock to avoid blocking
-	// all runner lookups during disk I/O.
-	var initialMsgs []claude.Message
-	if !sm.skipMessageLoad {
-		savedMsgs, err := config.LoadSessionMessages(sess.ID)
-		if err != nil {
-			log.Warn("failed to load session messages", "error", err)
-		} else {
-			log.Debug("loaded saved messages", "count", len(savedMsgs))
-			for _, msg := range savedMsgs {
-				initialMsgs = append(initialMsgs, claude.Message{
-					Role:    msg.Role,
-					Content: msg.Content,
-				})
-			}
-		}
-	} else {
-		log.Debug("skipping message load (demo/test mode)")
+	// all runner lookups during disk I/O, unless they were preloaded.
+	sm.mu.Lock()
+	initialMsgs, preloaded := sm.preloaded[sess.ID]
+	delete(sm.preloaded, sess.ID)
+	sm.mu.Unlock()
+	if !preloaded {
+		initialMsgs = sm.loadInitialMessages(sess)
 	}
 
 	// Slow path: acquire write lock and double-check before creating
@@ -392,6 +460,12 @@ func (sm *SessionManager) ConfigureRunnerDefaults(runner claude.RunnerInterface,
 	}
 	runner.SetAllowedTools(tools)
 
+	// Denied tools win over allowed ones, in the CLI and the permission handler
+	if denied := sm.config.GetDeniedToolsForRepo(sess.RepoPath); len(denied) > 0 {
+		log.Debug("loaded denied tools", "count", len(denied), "repo", sess.RepoPath)
+		runner.SetDeniedTools(denied)
+	}
+
 	// Configure supervisor mode if this is a supervisor session
 	if sess.IsSupervisor {
 		runner.SetSupervisor(true)
@@ -433,6 +507,30 @@ func (sm *SessionManager) ConfigureRunnerDefaults(runner claude.RunnerInterface,
 		}
 	}
 
+	// Re-send the session's context files whenever a fresh CLI session starts.
+	// Read from config on each start so edits made after the runner was created apply.
+	sessionID := sess.ID
+	runner.SetContextProvider(func() (string, []string) {
+		current := sm.config.GetSession(sessionID)
+		if current == nil {
+			return "", nil
+		}
+		return BuildContextFiles(current.WorkTree, current.ContextFiles)
+	})
+
+	if sess.Model != "" {
+		runner.SetModel(sess.Model)
+	}
+
+	// Mirror the streaming response to a file for external tools if enabled
+	if sess.MirrorOutput {
+		if path, err := MirrorFilePath(sess.ID); err != nil {
+			log.Warn("failed to get mirror file path", "error", err)
+		} else {
+			runner.SetMirrorFile(path)
+		}
+	}
+
 	// Disable streaming chunks for autonomous sessions (agent mode)
 	// This reduces logging verbosity since real-time streaming is not needed for headless operation
 	if sess.Autonomous {
@@ -441,29 +539,100 @@ func (sm *SessionManager) ConfigureRunnerDefaults(runner claude.RunnerInterface,
 	}
 }
 
-// SaveMessages saves the current messages from a runner to disk.
-func (sm *SessionManager) SaveMessages(sessionID string) error {
-	sm.mu.RLock()
-	runner, exists := sm.runners[sessionID]
-	sm.mu.RUnlock()
-	if !exists || runner == nil {
-		return nil
+// messagesMark records what was last written for a session, so autosave can skip
+// histories that have not changed since.
+type messagesMark struct {
+	count   int // Number of messages
+	lastLen int // Length of the last message's content (grows while streaming)
+}
+
+func markOf(msgs []claude.Message) messagesMark {
+	if len(msgs) == 0 {
+		return messagesMark{}
+	}
+	return messagesMark{count: len(msgs), lastLen: len(msgs[len(msgs)-1].Content)}
+}
+
+// lockSave serializes message saves for a session, so a snapshot taken by one save
+// is never written after a newer snapshot taken by another.
+func (sm *SessionManager) lockSave(sessionID string) func() {
+	mu, _ := sm.saveLocks.LoadOrStore(sessionID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// ToConfigMessage converts a runner message, with its tool-use groups, for saving.
+func ToConfigMessage(msg claude.Message) config.Message {
+	saved := config.Message{Role: msg.Role, Kind: msg.Kind, Content: msg.Content}
+	for _, group := range msg.ToolUseGroups {
+		savedGroup := config.ToolUseGroup{Start: group.Start, End: group.End}
+		for _, tool := range group.Tools {
+			savedGroup.Tools = append(savedGroup.Tools, config.ToolUseSummary(tool))
+		}
+		saved.ToolUseGroups = append(saved.ToolUseGroups, savedGroup)
+	}
+	return saved
+}
+
+// FromConfigMessage converts a saved message back into a runner message.
+func FromConfigMessage(saved config.Message) claude.Message {
+	msg := claude.Message{Role: saved.Role, Kind: saved.Kind, Content: saved.Content}
+	for _, savedGroup := range saved.ToolUseGroups {
+		group := claude.ToolUseGroup{Start: savedGroup.Start, End: savedGroup.End}
+		for _, tool := range savedGroup.Tools {
+			group.Tools = append(group.Tools, claude.ToolUseSummary(tool))
+		}
+		msg.ToolUseGroups = append(msg.ToolUseGroups, group)
+	}
+	return msg
+}
+
+// writeMessages snapshots messages and writes them to disk under the session's save lock,
+// noting whether they were saved mid-turn (inTurn) so a crash can be recovered from.
+// When onlyIfChanged is set, the write is skipped if nothing changed since the last save.
+// Returns whether the history was written.
+func (sm *SessionManager) writeMessages(sessionID string, inTurn bool, snapshot func() []claude.Message, onlyIfChanged bool) (bool, error) {
+	defer sm.lockSave(sessionID)()
+
+	msgs := snapshot()
+	mark := markOf(msgs)
+	sm.savedMu.Lock()
+	prev, saved := sm.saved[sessionID]
+	sm.savedMu.Unlock()
+	if onlyIfChanged && (len(msgs) == 0 || (saved && prev == mark)) {
+		return false, nil
 	}
 
-	msgs := runner.GetMessages()
 	var configMsgs []config.Message
 	for _, msg := range msgs {
-		configMsgs = append(configMsgs, config.Message{
-			Role:    msg.Role,
-			Content: msg.Content,
-		})
+		configMsgs = append(configMsgs, ToConfigMessage(msg))
 	}
 
 	if err := config.SaveSessionMessages(sessionID, configMsgs, config.MaxSessionMessageLines); err != nil {
 		logger.WithSession(sessionID).Error("failed to save session messages", "error", err)
-		return err
+		return false, err
 	}
-	return nil
+	if err := config.SetTurnInProgress(sessionID, inTurn); err != nil {
+		logger.WithSession(sessionID).Warn("failed to record turn state", "error", err)
+	}
+
+	sm.savedMu.Lock()
+	sm.saved[sessionID] = mark
+	sm.savedMu.Unlock()
+	return true, nil
+}
+
+// SaveMessages saves the current messages from a runner to disk.
+func (sm *SessionManager) SaveMessages(sessionID string) error {
+	sm.mu.RLock()
+	runner, exists := sm.runners[sessionID]
+	sm.mu.RUnlock()
+	if !exists || runner == nil {
+		return nil
+	}
+
+	_, err := sm.writeMessages(sessionID, runner.IsStreaming(), runner.GetMessages, false)
+	return err
 }
 
 // SaveRunnerMessages saves messages for a specific runner (used when runner reference is already available).
@@ -472,20 +641,31 @@ func (sm *SessionManager) SaveRunnerMessages(sessionID string, runner claude.Run
 		return nil
 	}
 
-	msgs := runner.GetMessages()
-	var configMsgs []config.Message
-	for _, msg := range msgs {
-		configMsgs = append(configMsgs, config.Message{
-			Role:    msg.Role,
-			Content: msg.Content,
-		})
-	}
+	_, err := sm.writeMessages(sessionID, runner.IsStreaming(), runner.GetMessages, false)
+	return err
+}
 
-	if err := config.SaveSessionMessages(sessionID, configMsgs, config.MaxSessionMessageLines); err != nil {
-		logger.WithSession(sessionID).Error("failed to save session messages", "error", err)
-		return err
+// AutosaveMessages saves the message history of every session with a runner,
+// including any response still streaming, so little is lost if plural exits
+// mid-turn. Sessions whose history has not changed since the last save are skipped.
+// Returns the number of sessions saved; errors from individual sessions are joined.
+func (sm *SessionManager) AutosaveMessages() (int, error) {
+	var saved int
+	var errs []error
+	for sessionID, runner := range sm.GetRunners() {
+		if runner == nil {
+			continue
+		}
+		wrote, err := sm.writeMessages(sessionID, runner.IsStreaming(), runner.GetMessagesWithStreaming, true)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("session %s: %w", sessionID, err))
+			continue
+		}
+		if wrote {
+			saved++
+		}
 	}
-	return nil
+	return saved, errors.Join(errs...)
 }
 
 // DeleteSession cleans up all resources for a deleted session.
@@ -501,14 +681,36 @@ func (sm *SessionManager) DeleteSession(sessionID string) claude.RunnerInterface
 		runner = r
 		delete(sm.runners, sessionID)
 	}
+	delete(sm.preloaded, sessionID)
 	sm.mu.Unlock()
 
 	// Clean up all per-session state (this also cancels in-progress operations)
 	sm.stateManager.Delete(sessionID)
 
+	sm.savedMu.Lock()
+	delete(sm.saved, sessionID)
+	sm.savedMu.Unlock()
+
 	return runner
 }
 
+// ResetRunner stops a session's runner and forgets it, keeping the rest of the
+// session's state. The next message starts a fresh CLI process and MCP socket,
+// resuming the conversation. Returns false if the session had no runner.
+func (sm *SessionManager) ResetRunner(sessionID string) bool {
+	sm.mu.Lock()
+	runner, exists := sm.runners[sessionID]
+	delete(sm.runners, sessionID)
+	sm.mu.Unlock()
+
+	if !exists {
+		return false
+	}
+	logger.WithSession(sessionID).Info("resetting runner")
+	runner.Stop()
+	return true
+}
+
 // AddAllowedTool adds a tool to the allowed list for a session's repo and updates the runner.
 func (sm *SessionManager) AddAllowedTool(sessionID string, tool string) {
 	sess := sm.GetSession(sessionID)
@@ -531,6 +733,61 @@ func (sm *SessionManager) AddAllowedTool(sessionID string, tool string) {
 	logger.WithSession(sessionID).Debug("added tool to allowed list", "tool", tool, "repo", sess.RepoPath)
 }
 
+// MirrorFilePath returns the file a session's streaming response is mirrored to.
+func MirrorFilePath(sessionID string) (string, error) {
+	dir, err := paths.MirrorsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, sessionID+".txt"), nil
+}
+
+// SetMirrorOutput enables or disables mirroring a session's streaming response to
+// its mirror file, applying the change to a running session immediately. The
+// caller is responsible for saving the config.
+func (sm *SessionManager) SetMirrorOutput(sessionID string, enabled bool) error {
+	if !sm.config.SetSessionMirrorOutput(sessionID, enabled) {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	path := ""
+	if enabled {
+		var err error
+		if path, err = MirrorFilePath(sessionID); err != nil {
+			return err
+		}
+	}
+
+	sm.mu.RLock()
+	runner, exists := sm.runners[sessionID]
+	sm.mu.RUnlock()
+	if exists {
+		runner.SetMirrorFile(path)
+	}
+
+	logger.WithSession(sessionID).Debug("set mirror output", "enabled", enabled, "path", path)
+	return nil
+}
+
+// SetModel sets the model a session's Claude runs as, "" for the CLI's default.
+// A running session switches from its next message on, keeping the
+// conversation. The caller is responsible for saving the config.
+func (sm *SessionManager) SetModel(sessionID, model string) error {
+	if !sm.config.SetSessionModel(sessionID, model) {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	sm.mu.RLock()
+	runner, exists := sm.runners[sessionID]
+	sm.mu.RUnlock()
+	if exists {
+		runner.SetModel(model)
+	}
+
+	logger.WithSession(sessionID).Info("set model", "model", model)
+	return nil
+}
+
 // SetRunner sets a runner for a session (used when manually creating runners).
 func (sm *SessionManager) SetRunner(sessionID string, runner claude.RunnerInterface) {
 	sm.mu.Lock()
@@ -3,6 +3,7 @@ package manager
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"slices"
 	"strings"
 	"testing"
@@ -356,6 +357,76 @@ func TestSessionManager_DeleteSession_NoRunner(t *testing.T) {
 	}
 }
 
+func TestSessionManager_ResetRunner(t *testing.T) {
+	cfg := createTestConfig()
+	sm := NewSessionManager(cfg, git.NewGitService())
+
+	if sm.ResetRunner("session-1") {
+		t.Error("ResetRunner should return false when no runner exists")
+	}
+
+	runner := claude.NewMockRunner("session-1", true, nil)
+	sm.runners["session-1"] = runner
+	sm.stateManager.GetOrCreate("session-1").InputText = "test input"
+
+	if !sm.ResetRunner("session-1") {
+		t.Error("ResetRunner should return true when a runner exists")
+	}
+	if sm.GetRunner("session-1") != nil {
+		t.Error("Runner should be removed after reset")
+	}
+	if state := sm.stateManager.GetIfExists("session-1"); state == nil || state.InputText != "test input" {
+		t.Error("State should be kept after reset")
+	}
+}
+
+func TestSessionManager_PreloadHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	cfg := createTestConfig()
+	sm := NewSessionManager(cfg, git.NewGitService())
+	sm.SetRunnerFactory(func(sessionID, workingDir, repoPath string, sessionStarted bool, initialMessages []claude.Message) claude.RunnerInterface {
+		return claude.NewMockRunner(sessionID, sessionStarted, initialMessages)
+	})
+
+	large := strings.Repeat("x", LargeHistoryBytes)
+	if err := config.SaveSessionMessages("session-1", []config.Message{{Role: "assistant", Content: large}}, 0); err != nil {
+		t.Fatalf("SaveSessionMessages: %v", err)
+	}
+	if err := config.SaveSessionMessages("session-2", []config.Message{{Role: "user", Content: "small"}}, 0); err != nil {
+		t.Fatalf("SaveSessionMessages: %v", err)
+	}
+
+	if !sm.NeedsHistoryLoad("session-1") {
+		t.Error("a large history should need loading")
+	}
+	if sm.NeedsHistoryLoad("session-2") {
+		t.Error("a small history should load on selection")
+	}
+
+	sess := sm.GetSession("session-1")
+	sm.PreloadHistory(sess)
+	if sm.NeedsHistoryLoad("session-1") {
+		t.Error("a preloaded history should not need loading again")
+	}
+
+	runner := sm.GetOrCreateRunner(sess)
+	if msgs := runner.GetMessages(); len(msgs) != 1 || msgs[0].Content != large {
+		t.Errorf("expected the runner to start with the preloaded history, got %d messages", len(msgs))
+	}
+	if _, ok := sm.preloaded["session-1"]; ok {
+		t.Error("the preloaded history should be consumed by the runner")
+	}
+	if sm.NeedsHistoryLoad("session-1") {
+		t.Error("a session with a runner should not need loading")
+	}
+}
+
 func TestSessionManager_SetRunner(t *testing.T) {
 	cfg := createTestConfig()
 	sm := NewSessionManager(cfg, git.NewGitService())
@@ -1029,6 +1100,32 @@ func TestSessionManager_SaveRunnerMessages_Success(t *testing.T) {
 	}
 }
 
+func TestSessionManager_SaveRunnerMessages_KeepsToolUseGroups(t *testing.T) {
+	cfg := createTestConfig()
+	sm := NewSessionManager(cfg, git.NewGitService())
+
+	groups := []claude.ToolUseGroup{{Start: 6, End: 30, Tools: []claude.ToolUseSummary{
+		{Name: "Read", Complete: true},
+		{Name: "Bash", Complete: true, Failed: true},
+	}}}
+	runner := claude.NewMockRunner("session-1", true, []claude.Message{
+		{Role: "user", Content: "Test message"},
+		{Role: "assistant", Content: "Sure.\n\n● Read(a.go)\n● Bash(ls)\n", ToolUseGroups: groups},
+	})
+	if err := sm.SaveRunnerMessages("session-1", runner); err != nil {
+		t.Fatalf("SaveRunnerMessages should succeed, got %v", err)
+	}
+
+	// A new runner for the session starts with the groups restored
+	msgs := sm.loadInitialMessages(cfg.GetSession("session-1"))
+	if len(msgs) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(msgs))
+	}
+	if !reflect.DeepEqual(msgs[1].ToolUseGroups, groups) {
+		t.Errorf("Expected tool use groups %+v after loading, got %+v", groups, msgs[1].ToolUseGroups)
+	}
+}
+
 func TestSessionManager_SaveRunnerMessages_Error(t *testing.T) {
 	// Set HOME to a read-only path to trigger a write error
 	tempDir := t.TempDir()
@@ -1056,6 +1153,78 @@ func TestSessionManager_SaveRunnerMessages_Error(t *testing.T) {
 	}
 }
 
+func TestSessionManager_SaveMessages_TracksTurnInProgress(t *testing.T) {
+	cfg := createTestConfig()
+	sm := NewSessionManager(cfg, git.NewGitService())
+
+	runner := claude.NewMockRunner("session-1", true, []claude.Message{
+		{Role: "user", Content: "Hello"},
+	})
+	sm.SetRunner("session-1", runner)
+	t.Cleanup(func() { config.DeleteSessionMessages("session-1") })
+
+	// An autosave mid-turn marks the history as possibly cut off
+	runner.SetStreaming(true)
+	runner.SetStreamingContent("Hi th")
+	if _, err := sm.AutosaveMessages(); err != nil {
+		t.Fatalf("AutosaveMessages should succeed, got %v", err)
+	}
+	if !config.TurnInProgress("session-1") {
+		t.Error("Expected the turn marked in progress after a mid-turn save")
+	}
+
+	// The save after the turn completes clears it
+	runner.SetStreaming(false)
+	runner.SetStreamingContent("")
+	runner.AddAssistantMessage("Hi there")
+	if err := sm.SaveRunnerMessages("session-1", runner); err != nil {
+		t.Fatalf("SaveRunnerMessages should succeed, got %v", err)
+	}
+	if config.TurnInProgress("session-1") {
+		t.Error("Expected no turn in progress after the turn's final save")
+	}
+}
+
+func TestSessionManager_AutosaveMessages(t *testing.T) {
+	cfg := createTestConfig()
+	sm := NewSessionManager(cfg, git.NewGitService())
+
+	runner := claude.NewMockRunner("session-1", true, []claude.Message{
+		{Role: "user", Content: "Hello"},
+	})
+	sm.SetRunner("session-1", runner)
+	// Sessions without messages are not written
+	sm.SetRunner("session-2", claude.NewMockRunner("session-2", true, nil))
+
+	saved, err := sm.AutosaveMessages()
+	if err != nil {
+		t.Fatalf("AutosaveMessages should succeed, got %v", err)
+	}
+	if saved != 1 {
+		t.Errorf("Expected 1 session saved, got %d", saved)
+	}
+
+	// Unchanged history is skipped
+	saved, _ = sm.AutosaveMessages()
+	if saved != 0 {
+		t.Errorf("Expected unchanged history to be skipped, got %d saved", saved)
+	}
+
+	// In-progress streaming content is included
+	runner.SetStreamingContent("Partial response")
+	saved, _ = sm.AutosaveMessages()
+	if saved != 1 {
+		t.Errorf("Expected streaming content to trigger a save, got %d saved", saved)
+	}
+	msgs, loadErr := config.LoadSessionMessages("session-1")
+	if loadErr != nil {
+		t.Fatalf("Failed to load saved messages: %v", loadErr)
+	}
+	if len(msgs) != 2 || msgs[1].Content != "Partial response" {
+		t.Errorf("Expected streaming content to be saved, got %+v", msgs)
+	}
+}
+
 func TestGetOrCreateRunner_BareRunner(t *testing.T) {
 	// GetOrCreateRunner should return a bare runner with no tools or modes set.
 	// Policy configuration is the consumer's responsibility (via ConfigureRunnerDefaults or explicit calls).
@@ -1123,6 +1292,116 @@ func TestConfigureRunnerDefaults_SetsTools(t *testing.T) {
 	}
 }
 
+func TestConfigureRunnerDefaults_SetsDeniedTools(t *testing.T) {
+	cfg := &config.Config{
+		Repos: []string{"/test/repo", "/test/other"},
+		Sessions: []config.Session{
+			{ID: "session-1", RepoPath: "/test/repo", WorkTree: "/test/worktree"},
+			{ID: "session-2", RepoPath: "/test/other", WorkTree: "/test/worktree2"},
+		},
+		RepoDeniedTools: map[string][]string{"/test/repo": {"Bash(rm:*)"}},
+	}
+	sm := NewSessionManager(cfg, git.NewGitService())
+
+	runner := claude.NewMockRunner("session-1", false, nil)
+	sm.ConfigureRunnerDefaults(runner, sm.GetSession("session-1"))
+	if got := runner.GetDeniedTools(); !slices.Equal(got, []string{"Bash(rm:*)"}) {
+		t.Errorf("expected the repo's denied tools, got %v", got)
+	}
+
+	other := claude.NewMockRunner("session-2", false, nil)
+	sm.ConfigureRunnerDefaults(other, sm.GetSession("session-2"))
+	if got := other.GetDeniedTools(); len(got) != 0 {
+		t.Errorf("expected no denied tools for another repo, got %v", got)
+	}
+}
+
+func TestConfigureRunnerDefaults_SetsMirrorFile(t *testing.T) {
+	cfg := &config.Config{
+		Repos: []string{"/test/repo"},
+		Sessions: []config.Session{
+			{ID: "session-1", RepoPath: "/test/repo", WorkTree: "/test/worktree1", MirrorOutput: true},
+			{ID: "session-2", RepoPath: "/test/repo", WorkTree: "/test/worktree2"},
+		},
+	}
+	sm := NewSessionManager(cfg, git.NewGitService())
+
+	runner := claude.NewMockRunner("session-1", false, nil)
+	sm.ConfigureRunnerDefaults(runner, sm.GetSession("session-1"))
+	want, err := MirrorFilePath("session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := runner.GetMirrorFile(); got != want {
+		t.Errorf("mirror file = %q, want %q", got, want)
+	}
+
+	runner = claude.NewMockRunner("session-2", false, nil)
+	sm.ConfigureRunnerDefaults(runner, sm.GetSession("session-2"))
+	if got := runner.GetMirrorFile(); got != "" {
+		t.Errorf("expected no mirror file when disabled, got %q", got)
+	}
+}
+
+func TestSessionManager_SetModel(t *testing.T) {
+	cfg := &config.Config{
+		Repos:    []string{"/test/repo"},
+		Sessions: []config.Session{{ID: "session-1", RepoPath: "/test/repo", WorkTree: "/test/worktree", Model: "haiku"}},
+	}
+	sm := NewSessionManager(cfg, git.NewGitService())
+
+	// The saved model is used for a new runner
+	runner := claude.NewMockRunner("session-1", true, nil)
+	sm.ConfigureRunnerDefaults(runner, sm.GetSession("session-1"))
+	if got := runner.GetModel(); got != "haiku" {
+		t.Errorf("runner model = %q, want haiku", got)
+	}
+
+	// Switching applies to the running session and is saved for resuming
+	sm.SetRunner("session-1", runner)
+	if err := sm.SetModel("session-1", "opus"); err != nil {
+		t.Fatal(err)
+	}
+	if got := runner.GetModel(); got != "opus" {
+		t.Errorf("runner model = %q, want opus", got)
+	}
+	if got := cfg.GetSession("session-1").Model; got != "opus" {
+		t.Errorf("saved model = %q, want opus", got)
+	}
+
+	if err := sm.SetModel("missing", "opus"); err == nil {
+		t.Error("expected an error for a missing session")
+	}
+}
+
+func TestConfigureRunnerDefaults_SetsContextProvider(t *testing.T) {
+	worktree := t.TempDir()
+	if err := os.WriteFile(filepath.Join(worktree, "NOTES.md"), []byte("use the v2 API"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{
+		Repos:    []string{"/test/repo"},
+		Sessions: []config.Session{{ID: "session-1", RepoPath: "/test/repo", WorkTree: worktree}},
+	}
+	sm := NewSessionManager(cfg, git.NewGitService())
+
+	runner := claude.NewMockRunner("session-1", false, nil)
+	sm.ConfigureRunnerDefaults(runner, sm.GetSession("session-1"))
+	provider := runner.GetContextProvider()
+	if provider == nil {
+		t.Fatal("expected a context provider")
+	}
+	if text, _ := provider(); text != "" {
+		t.Errorf("expected no context without context files, got %q", text)
+	}
+
+	// Files added after the runner was configured are picked up
+	cfg.SetSessionContextFiles("session-1", []string{"NOTES.md"})
+	if text, _ := provider(); !strings.Contains(text, "use the v2 API") {
+		t.Errorf("expected context file contents, got %q", text)
+	}
+}
+
 func TestConfigureRunnerDefaults_DaemonManaged_SkipsHostTools(t *testing.T) {
 	// A daemon-managed autonomous supervisor session should NOT get host tools
 	cfg := &config.Config{
@@ -46,6 +46,8 @@ type SessionState struct {
 	StreamingContent   string    // In-progress streaming content
 	StreamingStartTime time.Time // When streaming started (for elapsed time display)
 	ToolUsePos         int       // Position of tool use marker for replacement
+	ShowLiveDiff       bool      // Chat panel shows the live worktree diff instead of the conversation
+	LiveDiffSeen       *string   // Diff last shown in the live diff (nil until shown)
 
 	// Tool use rollup for non-active sessions
 	ToolUseRollup *ToolUseRollupState // Current rollup group (nil when no tool uses yet)
@@ -62,6 +64,9 @@ type SessionState struct {
 	// Current todo list from TodoWrite tool
 	CurrentTodoList *claude.TodoList
 
+	// Files Claude read, edited, or created since Plural started (see RecordTouchedFile)
+	TouchedFiles map[string]FileTouch
+
 	// Subagent indicator - model name when subagent is active (empty when none)
 	SubagentModel string
 
@@ -584,6 +589,44 @@ func (s *SessionState) SetInputText(text string) {
 	s.InputText = text
 }
 
+// --- Thread-safe accessors for ShowLiveDiff ---
+
+// GetShowLiveDiff returns whether the chat panel shows the live diff for this session.
+// Thread-safe.
+func (s *SessionState) GetShowLiveDiff() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ShowLiveDiff
+}
+
+// SetShowLiveDiff sets whether the chat panel shows the live diff for this session.
+// Thread-safe.
+func (s *SessionState) SetShowLiveDiff(show bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ShowLiveDiff = show
+}
+
+// GetLiveDiffSeen returns the diff last shown in the live diff for this
+// session, and false if it never was.
+// Thread-safe.
+func (s *SessionState) GetLiveDiffSeen() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.LiveDiffSeen == nil {
+		return "", false
+	}
+	return *s.LiveDiffSeen, true
+}
+
+// SetLiveDiffSeen records the diff shown in the live diff for this session.
+// Thread-safe.
+func (s *SessionState) SetLiveDiffSeen(diff string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.LiveDiffSeen = &diff
+}
+
 // --- Thread-safe accessors for StreamCancel ---
 
 // GetStreamCancel returns the stream cancel function.
@@ -894,6 +937,27 @@ func (m *SessionStateManager) StopContainerInit(sessionID string) {
 	}
 }
 
+// ShiftTimers moves a session's wait, streaming and container init start times
+// forward by d, so elapsed-time displays leave out time the system spent asleep.
+// The shifted times carry no monotonic reading: elapsed time is then measured on
+// the wall clock, which (unlike the monotonic clock on most platforms) includes sleep.
+func (m *SessionStateManager) ShiftTimers(sessionID string, d time.Duration) {
+	m.mu.RLock()
+	state, exists := m.states[sessionID]
+	m.mu.RUnlock()
+
+	if !exists {
+		return
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	for _, t := range []*time.Time{&state.WaitStart, &state.StreamingStartTime, &state.ContainerInitStart} {
+		if !t.IsZero() {
+			*t = t.Round(0).Add(d)
+		}
+	}
+}
+
 // GetStreamingStartTimeOrNow returns the streaming start time for a session,
 // or time.Now() if the session doesn't exist or hasn't started streaming.
 func (m *SessionStateManager) GetStreamingStartTimeOrNow(sessionID string) time.Time {
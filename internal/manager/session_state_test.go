@@ -716,6 +716,32 @@ func TestSessionStateManager_ContainerInitialization(t *testing.T) {
 	}
 }
 
+func TestSessionStateManager_ShiftTimers(t *testing.T) {
+	m := NewSessionStateManager()
+	m.ShiftTimers("missing", time.Hour) // No-op for unknown sessions
+
+	m.StartWaiting("session-1", func() {})
+	m.StartContainerInit("session-1")
+	waitStart, _ := m.GetWaitStart("session-1")
+	initStart, _ := m.GetContainerInitStart("session-1")
+
+	m.ShiftTimers("session-1", time.Hour)
+
+	if got, _ := m.GetWaitStart("session-1"); !got.Equal(waitStart.Add(time.Hour)) {
+		t.Errorf("wait start = %v, want %v", got, waitStart.Add(time.Hour))
+	}
+	if got, _ := m.GetContainerInitStart("session-1"); !got.Equal(initStart.Add(time.Hour)) {
+		t.Errorf("container init start = %v, want %v", got, initStart.Add(time.Hour))
+	}
+
+	// Unset timers stay unset
+	m.StopWaiting("session-1")
+	m.ShiftTimers("session-1", time.Hour)
+	if got := m.GetIfExists("session-1").GetWaitStartTime(); !got.IsZero() {
+		t.Errorf("expected wait start to stay zero, got %v", got)
+	}
+}
+
 func TestSessionState_ContainerInitializingAccessors(t *testing.T) {
 	state := &SessionState{}
 
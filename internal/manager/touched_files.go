@@ -0,0 +1,67 @@
+package manager
+
+import (
+	"maps"
+
+	"github.com/zhubert/plural/internal/claude"
+)
+
+// FileTouch is how Claude touched a file during a session. A file touched more
+// than once keeps its strongest touch: created over edited over read.
+type FileTouch int
+
+const (
+	FileTouchRead    FileTouch = iota + 1 // Read with the Read tool
+	FileTouchEdited                       // Changed with the Edit or Write tool
+	FileTouchCreated                      // Created with the Write tool
+)
+
+// String returns the touch as a past-tense verb ("read", "edited", "created").
+func (t FileTouch) String() string {
+	switch t {
+	case FileTouchRead:
+		return "read"
+	case FileTouchEdited:
+		return "edited"
+	case FileTouchCreated:
+		return "created"
+	}
+	return ""
+}
+
+// TouchFromResult returns the file a tool result names and how the tool touched
+// it. Returns 0 for results that name no file, such as Bash or Glob results.
+func TouchFromResult(info *claude.ToolResultInfo) (string, FileTouch) {
+	if info == nil || info.FilePath == "" {
+		return "", 0
+	}
+	switch {
+	case info.Created:
+		return info.FilePath, FileTouchCreated
+	case info.Edited:
+		return info.FilePath, FileTouchEdited
+	}
+	return info.FilePath, FileTouchRead
+}
+
+// RecordTouchedFile records that Claude touched path, unless it already touched
+// it more strongly.
+// Thread-safe.
+func (s *SessionState) RecordTouchedFile(path string, touch FileTouch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.TouchedFiles == nil {
+		s.TouchedFiles = make(map[string]FileTouch)
+	}
+	if touch > s.TouchedFiles[path] {
+		s.TouchedFiles[path] = touch
+	}
+}
+
+// GetTouchedFiles returns a copy of the files Claude touched, by path.
+// Thread-safe.
+func (s *SessionState) GetTouchedFiles() map[string]FileTouch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.TouchedFiles)
+}
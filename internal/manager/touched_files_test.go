@@ -0,0 +1,50 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/zhubert/plural/internal/claude"
+)
+
+func TestTouchFromResult(t *testing.T) {
+	tests := []struct {
+		name  string
+		info  *claude.ToolResultInfo
+		path  string
+		touch FileTouch
+	}{
+		{"nil", nil, "", 0},
+		{"bash", &claude.ToolResultInfo{ExitCode: new(int)}, "", 0},
+		{"read", &claude.ToolResultInfo{FilePath: "/wt/a.go", NumLines: 3, TotalLines: 3}, "/wt/a.go", FileTouchRead},
+		{"edit", &claude.ToolResultInfo{FilePath: "/wt/a.go", Edited: true}, "/wt/a.go", FileTouchEdited},
+		{"create", &claude.ToolResultInfo{FilePath: "/wt/b.go", Edited: true, Created: true}, "/wt/b.go", FileTouchCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, touch := TouchFromResult(tt.info)
+			if path != tt.path || touch != tt.touch {
+				t.Errorf("got (%q, %v), want (%q, %v)", path, touch, tt.path, tt.touch)
+			}
+		})
+	}
+}
+
+func TestSessionState_RecordTouchedFile_KeepsStrongest(t *testing.T) {
+	state := &SessionState{}
+	state.RecordTouchedFile("/wt/new.go", FileTouchCreated)
+	state.RecordTouchedFile("/wt/new.go", FileTouchEdited)
+	state.RecordTouchedFile("/wt/a.go", FileTouchRead)
+	state.RecordTouchedFile("/wt/a.go", FileTouchEdited)
+	state.RecordTouchedFile("/wt/a.go", FileTouchRead)
+
+	files := state.GetTouchedFiles()
+	if files["/wt/new.go"] != FileTouchCreated || files["/wt/a.go"] != FileTouchEdited || len(files) != 2 {
+		t.Errorf("unexpected touched files: %v", files)
+	}
+
+	// The copy is the caller's
+	files["/wt/c.go"] = FileTouchRead
+	if len(state.GetTouchedFiles()) != 2 {
+		t.Error("expected GetTouchedFiles to return a copy")
+	}
+}
@@ -0,0 +1,239 @@
+package manager
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// transcriptEntry is the subset of a Claude session JSONL line needed to recover
+// the last response.
+type transcriptEntry struct {
+	Type        string `json:"type"`
+	IsSidechain bool   `json:"isSidechain"` // Sub-agent traffic, not part of the main conversation
+	IsMeta      bool   `json:"isMeta"`      // CLI bookkeeping, not typed by the user
+	Message     struct {
+		Content json.RawMessage `json:"content"` // A string, or an array of content blocks
+	} `json:"message"`
+}
+
+// transcriptBlock is a content block in a transcript entry.
+type transcriptBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// claudeSessionPath returns where the Claude CLI stores the transcript of a session
+// run in workTree.
+func claudeSessionPath(sessionID, workTree string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".claude", "projects", escapeClaudePath(workTree), sessionID+".jsonl"), nil
+}
+
+// transcriptTailBytes is how much of the end of a Claude session transcript is
+// read to recover the last turn. Transcripts of long sessions run to many
+// megabytes, and only the last turn is needed.
+const transcriptTailBytes = 8 << 20
+
+// readLastClaudeTurn reads the tail of a Claude session transcript and returns the
+// last prompt the user sent, formatted like claude.GetDisplayContent, and the text
+// blocks of the assistant's response to it in order. Tool results do not start a
+// new turn. A turn that starts before the tail is not found.
+func readLastClaudeTurn(path string) (prompt string, texts []string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", nil, err
+	}
+	offset := max(info.Size()-transcriptTailBytes, 0)
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return "", nil, err
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024) // Lines carry whole tool results
+	if offset > 0 {
+		scanner.Scan() // Skip the line the tail starts partway through
+	}
+	for scanner.Scan() {
+		var entry transcriptEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue // Skip malformed lines, including one cut off by a crash
+		}
+		if entry.IsSidechain || entry.IsMeta {
+			continue
+		}
+
+		var blocks []transcriptBlock
+		var text string
+		if err := json.Unmarshal(entry.Message.Content, &text); err == nil {
+			blocks = []transcriptBlock{{Type: "text", Text: text}}
+		} else if err := json.Unmarshal(entry.Message.Content, &blocks); err != nil {
+			continue
+		}
+
+		switch entry.Type {
+		case "user":
+			var parts []string
+			for _, block := range blocks {
+				switch block.Type {
+				case "text":
+					parts = append(parts, block.Text)
+				case "image":
+					parts = append(parts, "[Image]")
+				}
+			}
+			if len(parts) == 0 {
+				continue // Tool results belong to the current turn
+			}
+			prompt, texts = strings.Join(parts, "\n"), nil
+		case "assistant":
+			for _, block := range blocks {
+				if block.Type == "text" && strings.TrimSpace(block.Text) != "" {
+					texts = append(texts, block.Text)
+				}
+			}
+		}
+	}
+	return prompt, texts, scanner.Err()
+}
+
+// recoverLastResponse fills in the response to the last saved prompt from the text
+// Claude recorded for that turn, for when plural exited before saving all of it.
+// Text already in the saved response is kept as is; a block cut off mid-stream is
+// completed and missing blocks are appended. System messages plural added after
+// the prompt are not part of the turn and stay where they are. Returns the
+// messages and whether any text was recovered.
+func recoverLastResponse(msgs []claude.Message, prompt string, texts []string) ([]claude.Message, bool) {
+	lastUser := -1
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role == "user" {
+			lastUser = i
+			break
+		}
+	}
+	if lastUser < 0 || len(texts) == 0 {
+		return msgs, false
+	}
+	responseIndex := -1
+	for i := lastUser + 1; i < len(msgs); i++ {
+		if msgs[i].Role == claude.RoleSystem {
+			continue
+		}
+		if responseIndex >= 0 {
+			return msgs, false // More than one response to the prompt
+		}
+		responseIndex = i
+	}
+	// The transcript's prompt may carry context sent ahead of what was displayed
+	saved := strings.TrimSpace(msgs[lastUser].Content)
+	if saved == "" || !strings.HasSuffix(strings.TrimSpace(prompt), saved) {
+		return msgs, false
+	}
+
+	var response string
+	if responseIndex >= 0 {
+		response = msgs[responseIndex].Content
+	}
+	merged := response
+	for _, text := range texts {
+		text = strings.TrimSpace(text)
+		if strings.Contains(merged, text) {
+			continue
+		}
+		if k := overlap(merged, text); k > 0 {
+			merged += text[k:]
+			continue
+		}
+		if merged != "" {
+			merged = strings.TrimRight(merged, "\n") + "\n\n"
+		}
+		merged += text
+	}
+	if merged == response {
+		return msgs, false
+	}
+
+	recovered := slices.Clone(msgs)
+	if responseIndex >= 0 {
+		recovered[responseIndex] = claude.Message{Role: "assistant", Content: merged}
+	} else {
+		recovered = slices.Insert(recovered, lastUser+1, claude.Message{Role: "assistant", Content: merged})
+	}
+	return recovered, true
+}
+
+// overlap returns the length of the longest suffix of s that is a prefix of t,
+// in linear time using the KMP failure function of t.
+func overlap(s, t string) int {
+	if len(s) == 0 || len(t) == 0 {
+		return 0
+	}
+	fail := make([]int, len(t))
+	for i, k := 1, 0; i < len(t); i++ {
+		for k > 0 && t[i] != t[k] {
+			k = fail[k-1]
+		}
+		if t[i] == t[k] {
+			k++
+		}
+		fail[i] = k
+	}
+
+	// Match t against the part of s that could overlap it
+	k := 0
+	for i := max(len(s)-len(t), 0); i < len(s); i++ {
+		for k > 0 && (k == len(t) || s[i] != t[k]) {
+			k = fail[k-1]
+		}
+		if s[i] == t[k] {
+			k++
+		}
+	}
+	return k
+}
+
+// recoverFromClaudeSession completes the last saved response of a started session
+// from Claude's own transcript, which keeps being written after plural crashes
+// mid-stream. Only histories last saved mid-turn are checked; messages are
+// returned unchanged when there is nothing to recover.
+func recoverFromClaudeSession(sess *config.Session, msgs []claude.Message) []claude.Message {
+	if !sess.Started || sess.WorkTree == "" || len(msgs) == 0 || !config.TurnInProgress(sess.ID) {
+		return msgs
+	}
+	log := logger.WithSession(sess.ID)
+
+	path, err := claudeSessionPath(sess.ID, sess.WorkTree)
+	if err != nil {
+		return msgs
+	}
+	prompt, texts, err := readLastClaudeTurn(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn("failed to read Claude session transcript", "path", path, "error", err)
+		}
+		return msgs
+	}
+
+	recovered, ok := recoverLastResponse(msgs, prompt, texts)
+	if ok {
+		log.Info("recovered last response from Claude session transcript", "messages", len(recovered))
+	}
+	return recovered
+}
@@ -0,0 +1,309 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/config"
+	"github.com/zhubert/plural/internal/git"
+	"github.com/zhubert/plural/internal/paths"
+)
+
+// testTranscript is a Claude session transcript whose last turn used a tool and
+// was cut off while the final line was being written.
+const testTranscript = `{"type":"user","message":{"role":"user","content":"first question"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"First answer."}]}}
+{"type":"user","message":{"role":"user","content":[{"type":"text","text":"fix the bug"},{"type":"image","source":{}}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"thinking","thinking":"hmm"}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Let me look at the code."}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{}}]}}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"file"}]}}
+{"type":"assistant","isSidechain":true,"message":{"role":"assistant","content":[{"type":"text","text":"sub-agent chatter"}]}}
+{"type":"user","isMeta":true,"message":{"role":"user","content":"caveat"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"The bug is fixed."}]}}
+{"type":"assistant","message":{"role":"assis`
+
+func writeTranscript(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadLastClaudeTurn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	writeTranscript(t, path, testTranscript)
+
+	prompt, texts, err := readLastClaudeTurn(path)
+	if err != nil {
+		t.Fatalf("readLastClaudeTurn: %v", err)
+	}
+	if prompt != "fix the bug\n[Image]" {
+		t.Errorf("prompt = %q, want %q", prompt, "fix the bug\n[Image]")
+	}
+	want := []string{"Let me look at the code.", "The bug is fixed."}
+	if !slices.Equal(texts, want) {
+		t.Errorf("texts = %q, want %q", texts, want)
+	}
+}
+
+func TestReadLastClaudeTurn_ReadsTail(t *testing.T) {
+	// Earlier turns fill more than the tail, ending in a line it starts partway through
+	filler := `{"type":"user","message":{"role":"user","content":"` + strings.Repeat("x", transcriptTailBytes) + `"}}` + "\n"
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	writeTranscript(t, path, filler+testTranscript)
+
+	prompt, texts, err := readLastClaudeTurn(path)
+	if err != nil {
+		t.Fatalf("readLastClaudeTurn: %v", err)
+	}
+	if prompt != "fix the bug\n[Image]" || len(texts) != 2 {
+		t.Errorf("expected the last turn from the tail, got %q %q", prompt, texts)
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	tests := []struct {
+		s, t string
+		want int
+	}{
+		{"Let me look", "Let me look at the code.", 11},
+		{"abcabc", "abcabd", 3},
+		{"aaaa", "aab", 2},
+		{"done", "other", 0},
+		{"", "text", 0},
+	}
+	for _, tt := range tests {
+		if got := overlap(tt.s, tt.t); got != tt.want {
+			t.Errorf("overlap(%q, %q) = %d, want %d", tt.s, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestReadLastClaudeTurn_Missing(t *testing.T) {
+	_, _, err := readLastClaudeTurn(filepath.Join(t.TempDir(), "missing.jsonl"))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected not-exist error, got %v", err)
+	}
+}
+
+func TestRecoverLastResponse(t *testing.T) {
+	texts := []string{"Let me look at the code.", "The bug is fixed."}
+	prompt := "fix the bug"
+
+	tests := []struct {
+		name   string
+		msgs   []claude.Message
+		prompt string
+		want   string // Content of the last message; "" means unchanged
+	}{
+		{
+			name: "nothing saved after the prompt",
+			msgs: []claude.Message{{Role: "user", Content: "fix the bug"}},
+			want: "Let me look at the code.\n\nThe bug is fixed.",
+		},
+		{
+			name: "block cut off mid-stream",
+			msgs: []claude.Message{
+				{Role: "user", Content: "fix the bug"},
+				{Role: "assistant", Content: "Let me look at the code.\n[Read] main.go\nThe bug is"},
+			},
+			want: "Let me look at the code.\n[Read] main.go\nThe bug is fixed.",
+		},
+		{
+			name: "missing block appended",
+			msgs: []claude.Message{
+				{Role: "user", Content: "fix the bug"},
+				{Role: "assistant", Content: "Let me look at the code.\n"},
+			},
+			want: "Let me look at the code.\n\nThe bug is fixed.",
+		},
+		{
+			name: "already complete",
+			msgs: []claude.Message{
+				{Role: "user", Content: "fix the bug"},
+				{Role: "assistant", Content: "Let me look at the code.\n[Read] main.go\nThe bug is fixed."},
+			},
+		},
+		{
+			name:   "prompt with context sent ahead",
+			msgs:   []claude.Message{{Role: "user", Content: "fix the bug"}},
+			prompt: "<context_file path=\"a.md\">\nnotes\n</context_file>\n\nfix the bug",
+			want:   "Let me look at the code.\n\nThe bug is fixed.",
+		},
+		{
+			name: "different prompt",
+			msgs: []claude.Message{{Role: "user", Content: "something else"}},
+		},
+		{
+			name: "turn followed by more messages",
+			msgs: []claude.Message{
+				{Role: "user", Content: "fix the bug"},
+				{Role: "assistant", Content: "partial"},
+				{Role: "assistant", Content: "another"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := prompt
+			if tt.prompt != "" {
+				p = tt.prompt
+			}
+			got, ok := recoverLastResponse(slices.Clone(tt.msgs), p, texts)
+			if tt.want == "" {
+				if ok || !reflect.DeepEqual(got, tt.msgs) {
+					t.Errorf("expected no recovery, got %v %+v", ok, got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("expected recovery")
+			}
+			last := got[len(got)-1]
+			if last.Role != "assistant" || last.Content != tt.want {
+				t.Errorf("last message = %s %q, want assistant %q", last.Role, last.Content, tt.want)
+			}
+			if got[0].Content != tt.msgs[0].Content {
+				t.Errorf("prompt changed: %q", got[0].Content)
+			}
+		})
+	}
+}
+
+func TestRecoverLastResponse_SkipsSystemMessages(t *testing.T) {
+	texts := []string{"Let me look at the code.", "The bug is fixed."}
+	msgs := []claude.Message{
+		{Role: "user", Content: "fix the bug"},
+		{Role: claude.RoleSystem, Kind: claude.KindModelSwitch, Content: "— switched to opus —"},
+		{Role: "assistant", Content: "Let me look at the code.\n"},
+		{Role: claude.RoleSystem, Kind: claude.KindMerge, Content: "Merged into main"},
+	}
+
+	got, ok := recoverLastResponse(slices.Clone(msgs), "fix the bug", texts)
+	if !ok {
+		t.Fatal("expected recovery past the system messages")
+	}
+	want := slices.Clone(msgs)
+	want[2].Content = "Let me look at the code.\n\nThe bug is fixed."
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// With no response saved, it goes right after the prompt
+	got, ok = recoverLastResponse([]claude.Message{msgs[0], msgs[3]}, "fix the bug", texts)
+	if !ok || len(got) != 3 || got[1].Role != "assistant" || got[2].Kind != claude.KindMerge {
+		t.Errorf("expected the response inserted before the merge notice, got %v %+v", ok, got)
+	}
+}
+
+func TestGetOrCreateRunner_RecoversFromClaudeSession(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	sess := config.Session{
+		ID:       "crashed-session",
+		RepoPath: "/test/repo",
+		WorkTree: "/test/worktrees/crashed",
+		Started:  true,
+	}
+	saved := []config.Message{
+		{Role: "user", Content: "first question"},
+		{Role: "assistant", Content: "First answer."},
+		{Role: "user", Content: "fix the bug\n[Image]"},
+		{Role: "assistant", Content: "Let me look"},
+	}
+	if err := config.SaveSessionMessages(sess.ID, saved, config.MaxSessionMessageLines); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SetTurnInProgress(sess.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	path, err := claudeSessionPath(sess.ID, sess.WorkTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(path, tempHome) {
+		t.Fatalf("transcript path %q is not under the test home", path)
+	}
+	writeTranscript(t, path, testTranscript)
+
+	cfg := &config.Config{Repos: []string{"/test/repo"}, Sessions: []config.Session{sess}}
+	sm := NewSessionManager(cfg, git.NewGitService())
+	var initial []claude.Message
+	sm.SetRunnerFactory(func(sessionID, workingDir, repoPath string, sessionStarted bool, initialMessages []claude.Message) claude.RunnerInterface {
+		initial = initialMessages
+		return claude.NewMockRunner(sessionID, sessionStarted, initialMessages)
+	})
+
+	if !sm.NeedsHistoryLoad(sess.ID) {
+		t.Error("expected a history saved mid-turn to load in the background")
+	}
+	sm.GetOrCreateRunner(sm.GetSession(sess.ID))
+
+	if len(initial) != 4 {
+		t.Fatalf("expected 4 messages, got %d", len(initial))
+	}
+	want := "Let me look at the code.\n\nThe bug is fixed."
+	if initial[3].Content != want {
+		t.Errorf("recovered response = %q, want %q", initial[3].Content, want)
+	}
+}
+
+func TestGetOrCreateRunner_SkipsRecoveryForCompleteTurn(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	paths.Reset()
+	t.Cleanup(paths.Reset)
+
+	sess := config.Session{
+		ID:       "finished-session",
+		RepoPath: "/test/repo",
+		WorkTree: "/test/worktrees/finished",
+		Started:  true,
+	}
+	saved := []config.Message{
+		{Role: "user", Content: "fix the bug\n[Image]"},
+		{Role: "assistant", Content: "Let me look"},
+	}
+	if err := config.SaveSessionMessages(sess.ID, saved, config.MaxSessionMessageLines); err != nil {
+		t.Fatal(err)
+	}
+	path, err := claudeSessionPath(sess.ID, sess.WorkTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTranscript(t, path, testTranscript)
+
+	cfg := &config.Config{Repos: []string{"/test/repo"}, Sessions: []config.Session{sess}}
+	sm := NewSessionManager(cfg, git.NewGitService())
+	var initial []claude.Message
+	sm.SetRunnerFactory(func(sessionID, workingDir, repoPath string, sessionStarted bool, initialMessages []claude.Message) claude.RunnerInterface {
+		initial = initialMessages
+		return claude.NewMockRunner(sessionID, sessionStarted, initialMessages)
+	})
+
+	// Without a mid-turn save, the transcript isn't read
+	if sm.NeedsHistoryLoad(sess.ID) {
+		t.Error("expected a small, complete history to load directly")
+	}
+	sm.GetOrCreateRunner(sm.GetSession(sess.ID))
+	if len(initial) != 2 || initial[1].Content != "Let me look" {
+		t.Errorf("expected the saved history unchanged, got %+v", initial)
+	}
+}
@@ -20,6 +20,12 @@ const (
 
 	// MergeTypePush indicates pushing updates to an existing PR.
 	MergeTypePush
+
+	// MergeTypeAbort indicates aborting a merge or rebase stopped on conflicts.
+	MergeTypeAbort
+
+	// MergeTypeRebase indicates rebasing onto the main branch and fast-forwarding it.
+	MergeTypeRebase
 )
 
 // String returns a human-readable name for the merge type.
@@ -35,6 +41,10 @@ func (t MergeType) String() string {
 		return "parent"
 	case MergeTypePush:
 		return "push"
+	case MergeTypeAbort:
+		return "abort"
+	case MergeTypeRebase:
+		return "rebase"
 	default:
 		return "unknown"
 	}
@@ -3,7 +3,6 @@ package mcp
 import (
 	"encoding/json"
 	"fmt"
-	"log/slog"
 	"net"
 	"sync"
 	"time"
@@ -46,7 +45,7 @@ func (cp *ChannelPair[Req, Resp]) IsInitialized() bool {
 // handleChannelMessage is the generic handler for SocketServer channel-based messages.
 // It replaces the 6 identical handler methods (handleCreateChildMessage, etc.).
 func handleChannelMessage[Req, Resp any](
-	log *slog.Logger,
+	s *SocketServer,
 	conn net.Conn,
 	req *Req,
 	reqCh chan<- Req,
@@ -59,9 +58,10 @@ func handleChannelMessage[Req, Resp any](
 	setResp func(*SocketMessage, *Resp),
 	label string,
 ) {
+	log := s.log
 	if req == nil || reqCh == nil {
 		log.Warn(label + " request ignored (nil request or no channel)")
-		sendResponse(log, conn, msgType, nilResp, setResp)
+		sendResponse(s, conn, msgType, nilResp, setResp)
 		return
 	}
 
@@ -72,25 +72,25 @@ func handleChannelMessage[Req, Resp any](
 	case <-time.After(SocketReadTimeout):
 		log.Warn("timeout sending " + label + " request to TUI")
 		resp := timeoutResp(getID(req))
-		sendResponse(log, conn, msgType, resp, setResp)
+		sendResponse(s, conn, msgType, resp, setResp)
 		return
 	}
 
 	select {
 	case resp := <-respCh:
-		sendResponse(log, conn, msgType, resp, setResp)
+		sendResponse(s, conn, msgType, resp, setResp)
 		log.Info("sent " + label + " response")
 	case <-time.After(responseTimeout):
 		log.Warn("timeout waiting for " + label + " response")
 		resp := timeoutResp(getID(req))
-		sendResponse(log, conn, msgType, resp, setResp)
+		sendResponse(s, conn, msgType, resp, setResp)
 	}
 }
 
 // sendResponse is the generic response sender for SocketServer.
 // It replaces the 6 identical sendXxxResponse methods.
 func sendResponse[Resp any](
-	log *slog.Logger,
+	s *SocketServer,
 	conn net.Conn,
 	msgType MessageType,
 	resp Resp,
@@ -102,13 +102,12 @@ func sendResponse[Resp any](
 
 	respJSON, err := json.Marshal(msg)
 	if err != nil {
-		log.Error("failed to marshal response", "error", err)
+		s.log.Error("failed to marshal response", "error", err)
 		return
 	}
 
-	conn.SetWriteDeadline(time.Now().Add(SocketWriteTimeout))
-	if _, err := conn.Write(append(respJSON, '\n')); err != nil {
-		log.Error("write error", "error", err)
+	if err := s.writeFrame(conn, respJSON); err != nil {
+		s.log.Error("write error", "error", err)
 	}
 }
 
@@ -133,6 +132,7 @@ func sendSocketRequest[Req, Resp any](
 		return zero, err
 	}
 
+	c.tracer.trace(WireDirSend, reqJSON)
 	c.conn.SetWriteDeadline(time.Now().Add(SocketWriteTimeout))
 	if _, err = c.conn.Write(append(reqJSON, '\n')); err != nil {
 		var zero Resp
@@ -150,6 +150,7 @@ func sendSocketRequest[Req, Resp any](
 		var zero Resp
 		return zero, fmt.Errorf("read %s response: %w", label, err)
 	}
+	c.tracer.trace(WireDirRecv, []byte(line))
 
 	var respMsg SocketMessage
 	if err := json.Unmarshal([]byte(line), &respMsg); err != nil {
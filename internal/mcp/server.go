@@ -50,6 +50,7 @@ type Server struct {
 	planApprovalChan      chan<- PlanApprovalRequest       // Send plan approval requests to TUI
 	planResponseChan      <-chan PlanApprovalResponse      // Receive plan approval responses from TUI
 	allowedTools          []string                         // Pre-allowed tools for this session
+	deniedTools           []string                         // Tools denied regardless of allowed tools or approval
 	isSupervisor          bool                             // Whether to expose supervisor tools
 	createChildChan       chan<- CreateChildRequest        // Send create child requests to TUI
 	createChildResp       <-chan CreateChildResponse       // Receive create child responses from TUI
@@ -105,6 +106,15 @@ func WithHostTools(
 	}
 }
 
+// WithDeniedTools hard-blocks permission requests matching any of tools, in
+// the Claude CLI's permission rule syntax, ahead of allowed tools and without
+// asking the TUI.
+func WithDeniedTools(tools []string) ServerOption {
+	return func(s *Server) {
+		s.deniedTools = tools
+	}
+}
+
 // NewServer creates a new MCP server
 func NewServer(r io.Reader, w io.Writer, reqChan chan<- PermissionRequest, respChan <-chan PermissionResponse, questionChan chan<- QuestionRequest, answerChan <-chan QuestionResponse, planApprovalChan chan<- PlanApprovalRequest, planResponseChan <-chan PlanApprovalResponse, allowedTools []string, sessionID string, opts ...ServerOption) *Server {
 	s := &Server{
@@ -377,6 +387,13 @@ func (s *Server) handlePermissionToolCall(req *JSONRPCRequest, params ToolCallPa
 		return
 	}
 
+	// Denied tools are blocked even when allowed, including by "*" in container mode
+	if pattern, denied := s.deniedBy(tool, arguments); denied {
+		s.log.Info("tool is denied", "tool", tool, "pattern", pattern)
+		s.sendPermissionResult(req.ID, false, arguments, "Denied for this repository by "+pattern)
+		return
+	}
+
 	// Auto-approve our own MCP supervisor/host tools — they already have their own
 	// access checks (isSupervisor/hasHostTools guards) so the permission prompt is redundant.
 	if s.isOwnMCPTool(tool) {
@@ -743,6 +760,99 @@ func (s *Server) isToolAllowed(tool string) bool {
 	return false
 }
 
+// deniedBy returns the denied tool pattern a tool use matches, if any.
+func (s *Server) deniedBy(tool string, arguments map[string]any) (string, bool) {
+	for _, pattern := range s.deniedTools {
+		if ToolMatchesPattern(pattern, tool, arguments) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// ToolMatchesPattern returns whether a tool use matches a pattern in the Claude
+// CLI's permission rule syntax. A bare tool name matches every use of the tool;
+// Bash(prefix:*) matches commands starting with the prefix as a whole word, and
+// Bash(command) matches the command exactly. Compound commands match if any of
+// their commands does, so "cd x && rm -rf y" matches Bash(rm:*); prefixes that
+// run a command are skipped, so "sudo rm -rf y" does too, as does a command
+// run by its path (/bin/rm) or in a shell's -c string (sh -c "rm -rf y").
+// Specifiers of other tools (Edit(docs/**)) are left to the CLI and never
+// match here.
+func ToolMatchesPattern(pattern, tool string, arguments map[string]any) bool {
+	name, specifier, hasSpecifier := strings.Cut(pattern, "(")
+	if name != tool {
+		return false
+	}
+	if !hasSpecifier {
+		return true
+	}
+	specifier = strings.TrimSuffix(specifier, ")")
+	if tool != "Bash" {
+		return false
+	}
+	if specifier == "*" {
+		return true
+	}
+	command, _ := arguments["command"].(string)
+	return bashCommandMatches(command, specifier)
+}
+
+// shells are the shells whose -c strings are checked as commands of their own.
+var shells = []string{"sh", "bash", "zsh", "dash", "ksh"}
+
+// bashCommandMatches returns whether any command in command matches a Bash
+// permission rule's specifier (see ToolMatchesPattern).
+func bashCommandMatches(command, specifier string) bool {
+	prefix, isPrefix := strings.CutSuffix(specifier, ":*")
+	if !isPrefix {
+		prefix, isPrefix = strings.CutSuffix(specifier, " *")
+	}
+	matches := func(texts []string) bool {
+		part := strings.Join(texts, " ")
+		return part == specifier || (isPrefix && (part == prefix || strings.HasPrefix(part, prefix+" ")))
+	}
+	for _, words := range SplitShellCommands(command) {
+		words = SkipCommandPrefixes(words)
+		if len(words) == 0 {
+			continue
+		}
+		texts := make([]string, len(words))
+		for i, word := range words {
+			texts[i] = word.Text
+		}
+		if matches(texts) {
+			return true
+		}
+		// The command run by its path, as /bin/rm
+		base := filepath.Base(texts[0])
+		if base != texts[0] && matches(append([]string{base}, texts[1:]...)) {
+			return true
+		}
+		if slices.Contains(shells, base) {
+			if script, ok := shellScript(texts[1:]); ok && bashCommandMatches(script, specifier) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shellScript returns the command string a shell runs given args, the string
+// after its -c flag (also combined, as in -ec), if there is one.
+func shellScript(args []string) (string, bool) {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "--") {
+			// Options come first; after them are the script's own arguments
+			return "", false
+		}
+		if strings.Contains(arg, "c") && i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
+
 // supervisorMCPTools are the Claude CLI tool names for supervisor MCP tools.
 // Claude CLI prefixes MCP tools with "mcp__<server>__", so tools on the "plural"
 // server become "mcp__plural__<tool>".
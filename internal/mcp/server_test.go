@@ -1583,3 +1583,90 @@ func TestServer_sendToolResult(t *testing.T) {
 		}
 	})
 }
+
+func TestToolMatchesPattern(t *testing.T) {
+	bash := func(command string) map[string]any { return map[string]any{"command": command} }
+	tests := []struct {
+		pattern   string
+		tool      string
+		arguments map[string]any
+		want      bool
+	}{
+		{"WebFetch", "WebFetch", nil, true},
+		{"WebFetch", "WebSearch", nil, false},
+		{"Bash", "Bash", bash("ls"), true},
+		{"Bash(rm:*)", "Bash", bash("rm -rf build"), true},
+		{"Bash(rm:*)", "Bash", bash("rm"), true},
+		{"Bash(rm:*)", "Bash", bash("rmdir build"), false},
+		{"Bash(rm:*)", "Bash", bash("ls && rm -rf build"), true},
+		{"Bash(rm:*)", "Bash", bash("cat x | rm -f y"), true},
+		{"Bash(rm:*)", "Bash", bash("echo $(rm -rf y)"), true},
+		{"Bash(rm:*)", "Bash", bash("ls\nrm y"), true},
+		{"Bash(rm:*)", "Bash", bash("echo rm"), false},
+		{"Bash(rm:*)", "Bash", bash("sudo rm -rf build"), true},
+		{"Bash(rm:*)", "Bash", bash("sudo -E rm -rf build"), true},
+		{"Bash(rm:*)", "Bash", bash("env FOO=1 rm -rf build"), true},
+		{"Bash(rm:*)", "Bash", bash("FOO=1 rm -rf build"), true},
+		{"Bash(rm:*)", "Bash", bash("find . -name '*.o' | xargs rm"), true},
+		{"Bash(rm:*)", "Bash", bash("nohup rm -rf build &"), true},
+		{"Bash(rm:*)", "Bash", bash("if true; then rm -rf build; fi"), true},
+		{"Bash(rm:*)", "Bash", bash(`echo "$(rm -rf y)"`), true},
+		{"Bash(rm:*)", "Bash", bash("echo `rm -rf y`"), true},
+		{"Bash(rm:*)", "Bash", bash("echo 'rm -rf y'"), false},
+		{"Bash(git push:*)", "Bash", bash("GIT_TRACE=1 git push origin main"), true},
+		{"Bash(make deploy)", "Bash", bash("sudo make deploy"), true},
+		{"Bash(rm *)", "Bash", bash("rm -rf build"), true},
+		{"Bash(git push:*)", "Bash", bash("git push --force"), true},
+		{"Bash(git push:*)", "Bash", bash("git pull"), false},
+		{"Bash(make deploy)", "Bash", bash("make deploy"), true},
+		{"Bash(make deploy)", "Bash", bash("make deploy-docs"), false},
+		{"Bash(*)", "Bash", bash("anything"), true},
+		{"Bash(rm:*)", "Edit", map[string]any{"file_path": "rm"}, false},
+		{"Edit(docs/**)", "Edit", map[string]any{"file_path": "docs/a.md"}, false},
+		{"Bash(rm:*)", "Bash", nil, false},
+		{"Bash(rm:*)", "Bash", bash("/bin/rm -rf build"), true},
+		{"Bash(/bin/rm:*)", "Bash", bash("/bin/rm -rf build"), true},
+		{"Bash(rm:*)", "Bash", bash("/bin/rmdir build"), false},
+		{"Bash(rm:*)", "Bash", bash(`sh -c "rm -rf build"`), true},
+		{"Bash(rm:*)", "Bash", bash(`bash -ec 'cd x && rm -rf build'`), true},
+		{"Bash(rm:*)", "Bash", bash(`/bin/bash -c "echo hi"`), false},
+		{"Bash(rm:*)", "Bash", bash(`sh script.sh -c "rm -rf build"`), false},
+	}
+	for _, tt := range tests {
+		if got := ToolMatchesPattern(tt.pattern, tt.tool, tt.arguments); got != tt.want {
+			t.Errorf("ToolMatchesPattern(%q, %q, %v) = %v, want %v", tt.pattern, tt.tool, tt.arguments, got, tt.want)
+		}
+	}
+}
+
+func TestServer_DeniedToolBlocksEvenWhenAllowed(t *testing.T) {
+	var buf strings.Builder
+	reqChan := make(chan PermissionRequest, 1)
+	s := &Server{
+		requestChan:  reqChan,
+		allowedTools: []string{"*"},
+		writer:       &buf,
+		log:          logger.WithSession("test"),
+	}
+	WithDeniedTools([]string{"Bash(rm:*)"})(s)
+
+	s.handlePermissionToolCall(&JSONRPCRequest{ID: 1}, ToolCallParams{Arguments: map[string]any{
+		"tool_name": "Bash",
+		"input":     map[string]any{"command": "rm -rf /"},
+	}})
+	if !strings.Contains(buf.String(), `\"behavior\":\"deny\"`) || !strings.Contains(buf.String(), "Bash(rm:*)") {
+		t.Errorf("expected a denial naming the pattern, got %s", buf.String())
+	}
+	if len(reqChan) != 0 {
+		t.Error("expected a denied tool not to reach the TUI")
+	}
+
+	buf.Reset()
+	s.handlePermissionToolCall(&JSONRPCRequest{ID: 2}, ToolCallParams{Arguments: map[string]any{
+		"tool_name": "Bash",
+		"input":     map[string]any{"command": "ls"},
+	}})
+	if !strings.Contains(buf.String(), `\"behavior\":\"allow\"`) {
+		t.Errorf("expected other commands still allowed, got %s", buf.String())
+	}
+}
@@ -0,0 +1,235 @@
+package mcp
+
+import (
+	"slices"
+	"strings"
+)
+
+// commandPrefixes are words that run the command after them, skipped to find
+// the command that runs.
+var commandPrefixes = []string{"sudo", "doas", "env", "command", "exec", "nohup", "time", "xargs", "then", "do", "else", "!", "{"}
+
+// ShellWord is a word of a shell command, unquoted, with its byte offsets in
+// the command.
+type ShellWord struct {
+	Text       string
+	Start, End int
+	// Redirect is the operator ("<", ">>", "2>&", ...) of the redirect this word
+	// is the target of, or "" for the command's own words. Only
+	// SplitShellCommandsWithRedirects returns redirect targets.
+	Redirect string
+}
+
+// SplitShellCommands splits a shell command into the words of each simple
+// command, keeping where each word is in the command. Quotes and backslash
+// escapes are removed from words; redirect targets, here-document bodies, and
+// comments are dropped. Command substitutions ($(...) and backticks, even
+// inside double quotes) stay in their word and are also split into commands of
+// their own.
+func SplitShellCommands(command string) [][]ShellWord {
+	return splitShell(command, false)
+}
+
+// SplitShellCommandsWithRedirects is SplitShellCommands keeping the targets of
+// redirects among each command's words, marked with their operator. The
+// delimiter of a here-document is the target of "<<" or "<<-".
+func SplitShellCommandsWithRedirects(command string) [][]ShellWord {
+	return splitShell(command, true)
+}
+
+// splitShell implements SplitShellCommands, keeping redirect targets if asked.
+func splitShell(command string, keepRedirects bool) [][]ShellWord {
+	var commands [][]ShellWord
+	var words []ShellWord
+	var word strings.Builder
+	wordStart := -1
+	redirect := ""
+	var heredocs []string
+	flush := func(end int) {
+		if wordStart < 0 {
+			return
+		}
+		switch {
+		case redirect == "":
+			words = append(words, ShellWord{Text: word.String(), Start: wordStart, End: end})
+		case keepRedirects:
+			words = append(words, ShellWord{Text: word.String(), Start: wordStart, End: end, Redirect: redirect})
+		}
+		if redirect == "<<" || redirect == "<<-" {
+			heredocs = append(heredocs, word.String())
+		}
+		redirect = ""
+		word.Reset()
+		wordStart = -1
+	}
+	endCommand := func(end int) {
+		flush(end)
+		if len(words) > 0 {
+			commands = append(commands, words)
+		}
+		words = nil
+	}
+	startWord := func(i int) {
+		if wordStart < 0 {
+			wordStart = i
+		}
+	}
+	// substitute adds the command substitution starting at i to the word and its
+	// commands to commands, returning the index of its closing character
+	substitute := func(i int) int {
+		startWord(i)
+		innerStart, end := i+1, substitutionEnd(command, i)
+		if command[i] == '$' {
+			innerStart++
+		}
+		for _, inner := range splitShell(command[innerStart:min(end, len(command))], keepRedirects) {
+			for j := range inner {
+				inner[j].Start += innerStart
+				inner[j].End += innerStart
+			}
+			commands = append(commands, inner)
+		}
+		word.WriteString(command[i:min(end+1, len(command))])
+		return end
+	}
+
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case c == '\'':
+			startWord(i)
+			for i++; i < len(command) && command[i] != '\''; i++ {
+				word.WriteByte(command[i])
+			}
+		case c == '"':
+			startWord(i)
+			for i++; i < len(command) && command[i] != '"'; i++ {
+				if isSubstitution(command, i) {
+					i = substitute(i)
+					continue
+				}
+				if command[i] == '\\' && i+1 < len(command) {
+					i++
+				}
+				word.WriteByte(command[i])
+			}
+		case c == '\\':
+			startWord(i)
+			if i+1 < len(command) {
+				i++
+				if command[i] != '\n' {
+					word.WriteByte(command[i])
+				}
+			}
+		case c == '#' && wordStart < 0:
+			for i+1 < len(command) && command[i+1] != '\n' {
+				i++
+			}
+		case c == ' ' || c == '\t':
+			flush(i)
+		case c == '>' || c == '<' || (c == '&' && i+1 < len(command) && command[i+1] == '>'):
+			// A word of digits right before the operator is its file descriptor
+			if wordStart >= 0 && strings.Trim(word.String(), "0123456789") == "" {
+				word.Reset()
+				wordStart = -1
+			}
+			flush(i)
+			opStart := i
+			for i+1 < len(command) && strings.IndexByte("><&|", command[i+1]) >= 0 {
+				i++
+			}
+			if command[opStart:i+1] == "<<" && i+1 < len(command) && command[i+1] == '-' {
+				i++
+			}
+			redirect = command[opStart : i+1]
+		case isSubstitution(command, i):
+			i = substitute(i)
+		case c == '\n' || c == ';' || c == '|' || c == '&' || c == '(' || c == ')':
+			endCommand(i)
+			redirect = ""
+			if c == '\n' && len(heredocs) > 0 {
+				i = skipHeredocs(command, i+1, heredocs) - 1
+				heredocs = nil
+			}
+		default:
+			startWord(i)
+			word.WriteByte(c)
+		}
+	}
+	endCommand(len(command))
+	return commands
+}
+
+// skipHeredocs returns the index after the bodies of heredocs, which start at
+// index start of command, each ending with a line holding only its delimiter.
+func skipHeredocs(command string, start int, heredocs []string) int {
+	i := start
+	for _, delim := range heredocs {
+		for i < len(command) {
+			end := strings.IndexByte(command[i:], '\n')
+			if end < 0 {
+				end = len(command) - i
+			}
+			line := strings.TrimLeft(command[i:i+end], "\t")
+			i += end + 1
+			if line == delim {
+				break
+			}
+		}
+	}
+	return min(i, len(command))
+}
+
+// isSubstitution returns whether a command substitution, $(...) or `...`,
+// starts at index i of command.
+func isSubstitution(command string, i int) bool {
+	return command[i] == '`' || (command[i] == '$' && i+1 < len(command) && command[i+1] == '(')
+}
+
+// substitutionEnd returns the index of the character closing the command
+// substitution starting at index i of command, or len(command) if it's unclosed.
+func substitutionEnd(command string, i int) int {
+	if command[i] == '`' {
+		if end := strings.IndexByte(command[i+1:], '`'); end >= 0 {
+			return i + 1 + end
+		}
+		return len(command)
+	}
+	depth := 0
+	for j := i + 1; j < len(command); j++ {
+		switch command[j] {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return j
+			}
+		}
+	}
+	return len(command)
+}
+
+// SkipCommandPrefixes drops the variable assignments and the words that run the
+// command after them (sudo -E, env, xargs) from the start of a simple command,
+// leaving the command that runs and its arguments.
+func SkipCommandPrefixes(words []ShellWord) []ShellWord {
+	for len(words) > 0 {
+		if isAssignment(words[0].Text) {
+			words = words[1:]
+			continue
+		}
+		if !slices.Contains(commandPrefixes, words[0].Text) {
+			break
+		}
+		// Skip the prefix and its own flags (sudo -E rm ...)
+		for words = words[1:]; len(words) > 1 && strings.HasPrefix(words[0].Text, "-"); words = words[1:] {
+		}
+	}
+	return words
+}
+
+// isAssignment returns whether a word is a variable assignment (FOO=bar).
+func isAssignment(word string) bool {
+	name, _, ok := strings.Cut(word, "=")
+	return ok && name != "" && !strings.HasPrefix(name, "-")
+}
@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"slices"
+	"testing"
+)
+
+func shellWordTexts(words []ShellWord) []string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.Text
+	}
+	return texts
+}
+
+func TestSplitShellCommands(t *testing.T) {
+	tests := []struct {
+		command string
+		want    [][]string
+	}{
+		{"ls -la", [][]string{{"ls", "-la"}}},
+		{"cd x && rm -rf 'my dir'", [][]string{{"cd", "x"}, {"rm", "-rf", "my dir"}}},
+		{"cat a | grep b > out.txt 2>&1", [][]string{{"cat", "a"}, {"grep", "b"}}},
+		{"echo hi # rm -rf /", [][]string{{"echo", "hi"}}},
+		{"(cd x; make)", [][]string{{"cd", "x"}, {"make"}}},
+		{"echo $(rm -rf y) done", [][]string{{"rm", "-rf", "y"}, {"echo", "$(rm -rf y)", "done"}}},
+		{"echo `rm -rf y`", [][]string{{"rm", "-rf", "y"}, {"echo", "`rm -rf y`"}}},
+		{`echo "a $(rm -rf y)"`, [][]string{{"rm", "-rf", "y"}, {"echo", "a $(rm -rf y)"}}},
+	}
+	for _, tt := range tests {
+		var got [][]string
+		for _, words := range SplitShellCommands(tt.command) {
+			got = append(got, shellWordTexts(words))
+		}
+		if !slices.EqualFunc(got, tt.want, slices.Equal) {
+			t.Errorf("SplitShellCommands(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestSplitShellCommandsWithRedirects(t *testing.T) {
+	tests := []struct {
+		command string
+		want    [][]string // Redirect targets as "operator target"
+	}{
+		{"make > out.log 2>&1", [][]string{{"make", "> out.log", ">& 1"}}},
+		{"sort < in >> out", [][]string{{"sort", "< in", ">> out"}}},
+		{"cat <<'EOF' > f\nrm -rf /\nEOF\nls", [][]string{{"cat", "<< EOF", "> f"}, {"ls"}}},
+		{"cat <<-EOF\n\tbody\n\tEOF", [][]string{{"cat", "<<- EOF"}}},
+		{"cat <<< word", [][]string{{"cat", "<<< word"}}},
+	}
+	for _, tt := range tests {
+		var got [][]string
+		for _, words := range SplitShellCommandsWithRedirects(tt.command) {
+			var texts []string
+			for _, word := range words {
+				if word.Redirect != "" {
+					texts = append(texts, word.Redirect+" "+word.Text)
+				} else {
+					texts = append(texts, word.Text)
+				}
+			}
+			got = append(got, texts)
+		}
+		if !slices.EqualFunc(got, tt.want, slices.Equal) {
+			t.Errorf("SplitShellCommandsWithRedirects(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+
+	// Without redirects, here-document bodies are skipped all the same
+	got := SplitShellCommands("cat <<EOF\nrm -rf /\nEOF")
+	if len(got) != 1 || !slices.Equal(shellWordTexts(got[0]), []string{"cat"}) {
+		t.Errorf("expected the here-document body skipped, got %v", got)
+	}
+}
+
+func TestSplitShellCommands_Offsets(t *testing.T) {
+	command := `sudo rm -rf "build dir"; echo "$(rm -rf y)"`
+	commands := SplitShellCommands(command)
+	words := commands[0]
+	if got := command[words[len(words)-1].Start:words[len(words)-1].End]; got != `"build dir"` {
+		t.Errorf("last word spans %q, want the quoted word", got)
+	}
+	inner := commands[1]
+	if got := command[inner[0].Start:inner[len(inner)-1].End]; got != "rm -rf y" {
+		t.Errorf("substituted command spans %q, want %q", got, "rm -rf y")
+	}
+}
+
+func TestSkipCommandPrefixes(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"rm -rf x", []string{"rm", "-rf", "x"}},
+		{"sudo rm -rf x", []string{"rm", "-rf", "x"}},
+		{"sudo -E -u root rm x", []string{"root", "rm", "x"}},
+		{"env FOO=1 BAR=2 rm x", []string{"rm", "x"}},
+		{"FOO=1 rm x", []string{"rm", "x"}},
+		{"xargs -0 rm", []string{"rm"}},
+		{"nohup time rm x", []string{"rm", "x"}},
+		{"sudo", nil},
+	}
+	for _, tt := range tests {
+		got := shellWordTexts(SkipCommandPrefixes(SplitShellCommands(tt.command)[0]))
+		if !slices.Equal(got, tt.want) && !(len(got) == 0 && len(tt.want) == 0) {
+			t.Errorf("SkipCommandPrefixes(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
@@ -3,12 +3,14 @@ package mcp
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"os"
 	"path/filepath"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/zhubert/plural/internal/logger"
@@ -32,6 +34,10 @@ const (
 	// than interactive prompts. Must be >= the 2-minute context timeout in TUI handlers.
 	HostToolResponseTimeout = 5 * time.Minute
 
+	// SocketProbeTimeout bounds how long a liveness probe waits for an existing
+	// socket to answer a ping before the socket is considered stale.
+	SocketProbeTimeout = 500 * time.Millisecond
+
 	// ContainerMCPPort is the fixed port the MCP subprocess listens on inside the
 	// container. Docker publishes this port to an ephemeral host port via -p 0:21120.
 	// The host then dials into the container, reversing the TCP direction so that
@@ -52,6 +58,7 @@ const (
 	MessageTypeCreatePR          MessageType = "createPR"
 	MessageTypePushBranch        MessageType = "pushBranch"
 	MessageTypeGetReviewComments MessageType = "getReviewComments"
+	MessageTypePing              MessageType = "ping"
 )
 
 // SocketMessage wraps permission, question, plan approval, or supervisor requests/responses
@@ -107,6 +114,7 @@ type SocketServer struct {
 	log                   *slog.Logger   // Logger with session context
 	activeConn            net.Conn       // Active connection (for dialing servers that receive a conn via HandleConn)
 	activeConnMu          sync.Mutex     // Guards activeConn
+	tracer                wireTracer     // Traces frames while wire tracing is on
 }
 
 // NewSocketServer creates a new socket server for the given session
@@ -121,8 +129,11 @@ func NewSocketServer(sessionID string, reqCh chan<- PermissionRequest, respCh <-
 	socketPath := filepath.Join(os.TempDir(), "pl-"+shortID+".sock")
 	log := logger.WithSession(sessionID).With("component", "mcp-socket")
 
-	// Remove existing socket if present
-	os.Remove(socketPath)
+	// A socket left behind by a crashed session would make Listen fail with
+	// "address already in use", so remove it unless a live server still owns it
+	if err := removeStaleSocket(socketPath, log); err != nil {
+		return nil, err
+	}
 
 	listener, err := net.Listen("unix", socketPath)
 	if err != nil {
@@ -142,6 +153,7 @@ func NewSocketServer(sessionID string, reqCh chan<- PermissionRequest, respCh <-
 		planRespCh: planRespCh,
 		readyCh:    make(chan struct{}),
 		log:        log,
+		tracer:     wireTracer{sessionID: sessionID, side: WireSideTUI},
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -184,6 +196,66 @@ func WithHostToolChannels(
 	}
 }
 
+// removeStaleSocket removes an existing socket file at socketPath if no server listens on it.
+// Returns an error if the socket is still live, since binding would steal it from its owner.
+func removeStaleSocket(socketPath string, log *slog.Logger) error {
+	if _, err := os.Lstat(socketPath); os.IsNotExist(err) {
+		return nil
+	}
+	if !socketRefused(socketPath) {
+		return fmt.Errorf("socket %s is in use by another plural instance", socketPath)
+	}
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket %s: %w", socketPath, err)
+	}
+	log.Info("removed stale socket", "socketPath", socketPath)
+	return nil
+}
+
+// socketRefused reports whether dialing socketPath is refused, as it is for a
+// socket file whose owner has exited. A server that accepts the connection is
+// live even if it is too busy to answer a ping, so only a refusal makes the
+// socket safe to remove.
+func socketRefused(socketPath string) bool {
+	conn, err := net.DialTimeout("unix", socketPath, SocketProbeTimeout)
+	if err != nil {
+		return errors.Is(err, syscall.ECONNREFUSED)
+	}
+	conn.Close()
+	return false
+}
+
+// ProbeSocket reports whether a socket server is healthy at socketPath by dialing
+// it and waiting for a ping response. Socket files whose owner has exited refuse
+// the dial, and servers that accept but don't answer in time are reported as not
+// healthy.
+func ProbeSocket(socketPath string) bool {
+	conn, err := net.DialTimeout("unix", socketPath, SocketProbeTimeout)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	pingJSON, err := json.Marshal(SocketMessage{Type: MessageTypePing})
+	if err != nil {
+		return false
+	}
+	conn.SetDeadline(time.Now().Add(SocketProbeTimeout))
+	if _, err := conn.Write(append(pingJSON, '\n')); err != nil {
+		return false
+	}
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	var resp SocketMessage
+	if err := json.Unmarshal([]byte(line), &resp); err != nil {
+		return false
+	}
+	return resp.Type == MessageTypePing
+}
+
 // NewTCPSocketServer creates a socket server that listens on TCP instead of a
 // Unix socket. Used for container sessions where Unix sockets can't cross the
 // Docker container boundary.
@@ -215,6 +287,7 @@ func NewTCPSocketServer(sessionID string, reqCh chan<- PermissionRequest, respCh
 		planRespCh: planRespCh,
 		readyCh:    make(chan struct{}),
 		log:        log,
+		tracer:     wireTracer{sessionID: sessionID, side: WireSideTUI},
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -243,6 +316,7 @@ func NewDialingSocketServer(sessionID string, reqCh chan<- PermissionRequest, re
 		planRespCh: planRespCh,
 		readyCh:    readyCh,
 		log:        log,
+		tracer:     wireTracer{sessionID: sessionID, side: WireSideTUI},
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -381,6 +455,8 @@ func (s *SocketServer) handleConnection(conn net.Conn) {
 			return
 		}
 
+		s.tracer.trace(WireDirRecv, []byte(line))
+
 		var msg SocketMessage
 		if err := json.Unmarshal([]byte(line), &msg); err != nil {
 			s.log.Error("JSON parse error", "error", err)
@@ -388,6 +464,8 @@ func (s *SocketServer) handleConnection(conn net.Conn) {
 		}
 
 		switch msg.Type {
+		case MessageTypePing:
+			s.handlePingMessage(conn)
 		case MessageTypePermission:
 			s.handlePermissionMessage(conn, msg.PermReq)
 		case MessageTypeQuestion:
@@ -395,7 +473,7 @@ func (s *SocketServer) handleConnection(conn net.Conn) {
 		case MessageTypePlanApproval:
 			s.handlePlanApprovalMessage(conn, msg.PlanReq)
 		case MessageTypeCreateChild:
-			handleChannelMessage(s.log, conn, msg.CreateChildReq,
+			handleChannelMessage(s, conn, msg.CreateChildReq,
 				s.createChildReq, s.createChildResp,
 				PermissionResponseTimeout,
 				CreateChildResponse{Success: false, Error: "Supervisor tools not available"},
@@ -407,7 +485,7 @@ func (s *SocketServer) handleConnection(conn net.Conn) {
 				func(m *SocketMessage, r *CreateChildResponse) { m.CreateChildResp = r },
 				"create child")
 		case MessageTypeListChildren:
-			handleChannelMessage(s.log, conn, msg.ListChildrenReq,
+			handleChannelMessage(s, conn, msg.ListChildrenReq,
 				s.listChildrenReq, s.listChildrenResp,
 				PermissionResponseTimeout,
 				ListChildrenResponse{Children: []ChildSessionInfo{}},
@@ -419,7 +497,7 @@ func (s *SocketServer) handleConnection(conn net.Conn) {
 				func(m *SocketMessage, r *ListChildrenResponse) { m.ListChildrenResp = r },
 				"list children")
 		case MessageTypeMergeChild:
-			handleChannelMessage(s.log, conn, msg.MergeChildReq,
+			handleChannelMessage(s, conn, msg.MergeChildReq,
 				s.mergeChildReq, s.mergeChildResp,
 				PermissionResponseTimeout,
 				MergeChildResponse{Success: false, Error: "Supervisor tools not available"},
@@ -431,7 +509,7 @@ func (s *SocketServer) handleConnection(conn net.Conn) {
 				func(m *SocketMessage, r *MergeChildResponse) { m.MergeChildResp = r },
 				"merge child")
 		case MessageTypeCreatePR:
-			handleChannelMessage(s.log, conn, msg.CreatePRReq,
+			handleChannelMessage(s, conn, msg.CreatePRReq,
 				s.createPRReq, s.createPRResp,
 				HostToolResponseTimeout,
 				CreatePRResponse{Success: false, Error: "Host tools not available"},
@@ -443,7 +521,7 @@ func (s *SocketServer) handleConnection(conn net.Conn) {
 				func(m *SocketMessage, r *CreatePRResponse) { m.CreatePRResp = r },
 				"create PR")
 		case MessageTypePushBranch:
-			handleChannelMessage(s.log, conn, msg.PushBranchReq,
+			handleChannelMessage(s, conn, msg.PushBranchReq,
 				s.pushBranchReq, s.pushBranchResp,
 				HostToolResponseTimeout,
 				PushBranchResponse{Success: false, Error: "Host tools not available"},
@@ -455,7 +533,7 @@ func (s *SocketServer) handleConnection(conn net.Conn) {
 				func(m *SocketMessage, r *PushBranchResponse) { m.PushBranchResp = r },
 				"push branch")
 		case MessageTypeGetReviewComments:
-			handleChannelMessage(s.log, conn, msg.GetReviewCommentsReq,
+			handleChannelMessage(s, conn, msg.GetReviewCommentsReq,
 				s.getReviewCommentsReq, s.getReviewCommentsResp,
 				HostToolResponseTimeout,
 				GetReviewCommentsResponse{Success: false, Error: "Host tools not available"},
@@ -566,8 +644,7 @@ func (s *SocketServer) sendPermissionResponse(conn net.Conn, resp PermissionResp
 		return
 	}
 
-	conn.SetWriteDeadline(time.Now().Add(SocketWriteTimeout))
-	if _, err := conn.Write(append(respJSON, '\n')); err != nil {
+	if err := s.writeFrame(conn, respJSON); err != nil {
 		s.log.Error("write error", "error", err)
 	}
 }
@@ -583,8 +660,7 @@ func (s *SocketServer) sendQuestionResponse(conn net.Conn, resp QuestionResponse
 		return
 	}
 
-	conn.SetWriteDeadline(time.Now().Add(SocketWriteTimeout))
-	if _, err := conn.Write(append(respJSON, '\n')); err != nil {
+	if err := s.writeFrame(conn, respJSON); err != nil {
 		s.log.Error("write error", "error", err)
 	}
 }
@@ -639,12 +715,32 @@ func (s *SocketServer) sendPlanApprovalResponse(conn net.Conn, resp PlanApproval
 		return
 	}
 
-	conn.SetWriteDeadline(time.Now().Add(SocketWriteTimeout))
-	if _, err := conn.Write(append(respJSON, '\n')); err != nil {
+	if err := s.writeFrame(conn, respJSON); err != nil {
 		s.log.Error("write error", "error", err)
 	}
 }
 
+// handlePingMessage answers a liveness probe from ProbeSocket.
+func (s *SocketServer) handlePingMessage(conn net.Conn) {
+	respJSON, err := json.Marshal(SocketMessage{Type: MessageTypePing})
+	if err != nil {
+		s.log.Error("failed to marshal ping response", "error", err)
+		return
+	}
+
+	if err := s.writeFrame(conn, respJSON); err != nil {
+		s.log.Error("write error", "error", err)
+	}
+}
+
+// writeFrame traces and writes frame, a marshaled SocketMessage, to conn.
+func (s *SocketServer) writeFrame(conn net.Conn, frame []byte) error {
+	s.tracer.trace(WireDirSend, frame)
+	conn.SetWriteDeadline(time.Now().Add(SocketWriteTimeout))
+	_, err := conn.Write(append(frame, '\n'))
+	return err
+}
+
 // Close shuts down the socket server and waits for the Run() goroutine to exit.
 func (s *SocketServer) Close() error {
 	s.log.Info("closing socket server")
@@ -686,6 +782,7 @@ type SocketClient struct {
 	socketPath string
 	conn       net.Conn
 	reader     *bufio.Reader
+	tracer     wireTracer
 }
 
 // NewSocketClient creates a client connected to the TUI socket via Unix socket
@@ -738,6 +835,12 @@ func NewListeningSocketClient(listenAddr string) (*SocketClient, error) {
 	}, nil
 }
 
+// SetSessionID names the session whose MCP log the client's frames are traced
+// in while wire tracing is on.
+func (c *SocketClient) SetSessionID(sessionID string) {
+	c.tracer = wireTracer{sessionID: sessionID, side: WireSideMCP}
+}
+
 // SendPermissionRequest sends a permission request and waits for response
 func (c *SocketClient) SendPermissionRequest(req PermissionRequest) (PermissionResponse, error) {
 	return sendSocketRequest(c, req, MessageTypePermission,
@@ -4,6 +4,9 @@ import (
 	"bufio"
 	"encoding/json"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -201,6 +204,112 @@ func TestSocketServer_Close(t *testing.T) {
 	server.Close()
 }
 
+func TestNewSocketServer_RemovesStaleSocket(t *testing.T) {
+	// Simulate a crashed session: a socket file whose listener is gone
+	socketPath := filepath.Join(os.TempDir(), "pl-test-stale-s.sock")
+	os.Remove(socketPath)
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	if err != nil {
+		t.Fatalf("failed to create stale socket: %v", err)
+	}
+	listener.SetUnlinkOnClose(false)
+	listener.Close()
+	if _, err := os.Stat(socketPath); err != nil {
+		t.Fatalf("expected stale socket file to remain: %v", err)
+	}
+
+	server, err := NewSocketServer("test-stale-session", make(chan PermissionRequest, 1), make(chan PermissionResponse, 1),
+		make(chan QuestionRequest, 1), make(chan QuestionResponse, 1), make(chan PlanApprovalRequest, 1), make(chan PlanApprovalResponse, 1))
+	if err != nil {
+		t.Fatalf("NewSocketServer should replace a stale socket, got: %v", err)
+	}
+	defer server.Close()
+
+	if server.SocketPath() != socketPath {
+		t.Errorf("SocketPath = %q, want %q", server.SocketPath(), socketPath)
+	}
+}
+
+func TestNewSocketServer_KeepsBusySocket(t *testing.T) {
+	// A live server that accepts connections but is too busy to answer pings
+	socketPath := filepath.Join(os.TempDir(), "pl-test-busy-se.sock")
+	os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to create busy socket: %v", err)
+	}
+	defer listener.Close()
+
+	_, err = NewSocketServer("test-busy-session", make(chan PermissionRequest, 1), make(chan PermissionResponse, 1),
+		make(chan QuestionRequest, 1), make(chan QuestionResponse, 1), make(chan PlanApprovalRequest, 1), make(chan PlanApprovalResponse, 1))
+	if err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Fatalf("expected 'in use' error for a server that doesn't answer pings, got: %v", err)
+	}
+	if _, err := os.Stat(socketPath); err != nil {
+		t.Errorf("expected the busy server's socket kept: %v", err)
+	}
+}
+
+func TestNewSocketServer_RefusesLiveSocket(t *testing.T) {
+	first, err := NewSocketServer("test-live-session", make(chan PermissionRequest, 1), make(chan PermissionResponse, 1),
+		make(chan QuestionRequest, 1), make(chan QuestionResponse, 1), make(chan PlanApprovalRequest, 1), make(chan PlanApprovalResponse, 1))
+	if err != nil {
+		t.Fatalf("NewSocketServer failed: %v", err)
+	}
+	first.Start()
+	first.WaitReady()
+	defer first.Close()
+
+	_, err = NewSocketServer("test-live-session", make(chan PermissionRequest, 1), make(chan PermissionResponse, 1),
+		make(chan QuestionRequest, 1), make(chan QuestionResponse, 1), make(chan PlanApprovalRequest, 1), make(chan PlanApprovalResponse, 1))
+	if err == nil {
+		t.Fatal("expected error when a live server owns the socket")
+	}
+	if !strings.Contains(err.Error(), "in use") {
+		t.Errorf("expected 'in use' error, got: %v", err)
+	}
+
+	// The live server's socket must survive the failed attempt
+	if !ProbeSocket(first.SocketPath()) {
+		t.Error("expected the first server to still answer pings")
+	}
+}
+
+func TestProbeSocket(t *testing.T) {
+	if ProbeSocket(filepath.Join(t.TempDir(), "missing.sock")) {
+		t.Error("expected missing socket to be reported as not live")
+	}
+
+	// A regular file named like a socket is never live
+	regular := filepath.Join(t.TempDir(), "pl-regular.sock")
+	if err := os.WriteFile(regular, []byte("test"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if ProbeSocket(regular) {
+		t.Error("expected regular file to be reported as not live")
+	}
+
+	server, err := NewSocketServer("test-probe-session", make(chan PermissionRequest, 1), make(chan PermissionResponse, 1),
+		make(chan QuestionRequest, 1), make(chan QuestionResponse, 1), make(chan PlanApprovalRequest, 1), make(chan PlanApprovalResponse, 1))
+	if err != nil {
+		t.Fatalf("NewSocketServer failed: %v", err)
+	}
+	server.Start()
+	server.WaitReady()
+
+	if !ProbeSocket(server.SocketPath()) {
+		t.Error("expected running server to answer ping")
+	}
+
+	server.Close()
+	if ProbeSocket(server.SocketPath()) {
+		t.Error("expected closed server to be reported as not live")
+	}
+	if _, err := os.Stat(server.SocketPath()); !os.IsNotExist(err) {
+		t.Errorf("expected socket file to be removed on Close, got: %v", err)
+	}
+}
+
 func TestSocketClientServer_Integration(t *testing.T) {
 	permReqCh := make(chan PermissionRequest, 1)
 	permRespCh := make(chan PermissionResponse, 1)
@@ -0,0 +1,318 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/paths"
+)
+
+// Wire trace sides: the TUI's socket server and the MCP server subprocess's
+// socket client.
+const (
+	WireSideTUI = "tui"
+	WireSideMCP = "mcp"
+)
+
+// Wire trace directions, from the side doing the tracing.
+const (
+	WireDirSend = "send"
+	WireDirRecv = "recv"
+)
+
+// wireTracePrefix starts each wire trace line in the per-session MCP log,
+// setting them apart from the regular log lines around them.
+const wireTracePrefix = "mcp-wire "
+
+// wireTraceMarkerName is the file in the logs directory whose existence turns
+// wire tracing on, so MCP server subprocesses follow the TUI's runtime toggle.
+const wireTraceMarkerName = "mcp-wire-trace"
+
+// maxWireTracePayload caps how much of each frame a trace record keeps.
+const maxWireTracePayload = 2048
+
+// wireTraceMarkerRecheck is how long WireTraceEnabled trusts its last look at
+// the marker file.
+const wireTraceMarkerRecheck = time.Second
+
+var (
+	wireTraceOn          atomic.Bool
+	wireTraceMarkerOn    atomic.Bool
+	wireTraceMarkerCheck atomic.Int64 // Unix nanoseconds of the last marker check
+)
+
+// SetWireTrace turns wire tracing of the TUI↔MCP server socket on or off, for
+// this process and for MCP server subprocesses on this machine. Subprocesses
+// notice within a second.
+func SetWireTrace(enabled bool) error {
+	wireTraceOn.Store(enabled)
+	wireTraceMarkerOn.Store(enabled)
+	wireTraceMarkerCheck.Store(time.Now().UnixNano())
+
+	dir, err := paths.LogsDir()
+	if err != nil {
+		return err
+	}
+	marker := filepath.Join(dir, wireTraceMarkerName)
+	if !enabled {
+		if err := os.Remove(marker); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(marker, nil, 0644)
+}
+
+// WireTraceEnabled returns whether socket frames are being traced, because
+// SetWireTrace turned it on here or in the TUI.
+func WireTraceEnabled() bool {
+	if wireTraceOn.Load() {
+		return true
+	}
+	now := time.Now().UnixNano()
+	if last := wireTraceMarkerCheck.Load(); now-last < int64(wireTraceMarkerRecheck) {
+		return wireTraceMarkerOn.Load()
+	}
+	wireTraceMarkerCheck.Store(now)
+	on := false
+	if dir, err := paths.LogsDir(); err == nil {
+		_, err := os.Stat(filepath.Join(dir, wireTraceMarkerName))
+		on = err == nil
+	}
+	wireTraceMarkerOn.Store(on)
+	return on
+}
+
+// WireRecord is one socket frame as traced.
+type WireRecord struct {
+	Time      time.Time `json:"time"`
+	Side      string    `json:"side"`
+	Dir       string    `json:"dir"`
+	Type      string    `json:"type"`
+	Kind      string    `json:"kind"` // "request" or "response"
+	ID        string    `json:"id,omitempty"`
+	Size      int       `json:"size"`
+	Payload   string    `json:"payload"`
+	Truncated bool      `json:"truncated,omitempty"`
+}
+
+// wireTracer records the frames one side of a session's socket sends and
+// receives in the session's MCP log while wire tracing is on.
+type wireTracer struct {
+	sessionID string
+	side      string
+}
+
+// trace records frame, a newline-terminated socket message, sent or received
+// per dir. It does nothing, not even parse the frame, unless tracing is on.
+func (t wireTracer) trace(dir string, frame []byte) {
+	if t.sessionID == "" || !WireTraceEnabled() {
+		return
+	}
+	path, err := logger.MCPLogPath(t.sessionID)
+	if err != nil {
+		return
+	}
+	line, err := json.Marshal(t.record(time.Now(), dir, frame))
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(append([]byte(wireTracePrefix), append(line, '\n')...))
+}
+
+// record describes frame as a trace record.
+func (t wireTracer) record(now time.Time, dir string, frame []byte) WireRecord {
+	frame = []byte(strings.TrimRight(string(frame), "\n"))
+	rec := WireRecord{
+		Time:    now.UTC(),
+		Side:    t.side,
+		Dir:     dir,
+		Size:    len(frame),
+		Payload: string(frame),
+	}
+	if len(frame) > maxWireTracePayload {
+		rec.Payload = string(frame[:maxWireTracePayload])
+		rec.Truncated = true
+	}
+
+	// The request or response rides in the one field besides "type", named
+	// after it with a Req or Resp suffix, and carries the ID
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(frame, &fields) == nil {
+		json.Unmarshal(fields["type"], &rec.Type)
+		for name, raw := range fields {
+			switch {
+			case strings.HasSuffix(name, "Req"):
+				rec.Kind = "request"
+			case strings.HasSuffix(name, "Resp"):
+				rec.Kind = "response"
+			default:
+				continue
+			}
+			var body struct {
+				ID json.RawMessage `json:"id"`
+			}
+			if json.Unmarshal(raw, &body) == nil && len(body.ID) > 0 && string(body.ID) != "null" {
+				rec.ID = strings.Trim(string(body.ID), `"`)
+			}
+		}
+	}
+	if rec.Kind == "" {
+		// Pings carry nothing; the MCP server sends requests, the TUI answers
+		if (t.side == WireSideMCP) == (dir == WireDirSend) {
+			rec.Kind = "request"
+		} else {
+			rec.Kind = "response"
+		}
+	}
+	return rec
+}
+
+// ParseWireTrace reads the wire trace records from an MCP log, skipping its
+// other lines.
+func ParseWireTrace(r io.Reader) ([]WireRecord, error) {
+	var records []WireRecord
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line, ok := strings.CutPrefix(scanner.Text(), wireTracePrefix)
+		if !ok {
+			continue
+		}
+		var rec WireRecord
+		if json.Unmarshal([]byte(line), &rec) == nil {
+			records = append(records, rec)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// Wire exchange statuses, from how far an exchange got.
+const (
+	WireAnswered        = "answered"
+	WireNotReceived     = "never reached the TUI"
+	WireUnanswered      = "unanswered by the TUI"
+	WireResponseLost    = "response never reached the MCP server"
+	WireUnmatchedAnswer = "response without a request"
+)
+
+// WireExchange is a request and its response, paired by type and ID.
+type WireExchange struct {
+	Type string
+	ID   string
+
+	// When each side sent or received the request and response; zero if
+	// the trace didn't record it.
+	MCPSent, TUIReceived, TUIResponded, MCPReceived time.Time
+
+	Status string
+}
+
+// Start returns when the exchange was first seen.
+func (e *WireExchange) Start() time.Time {
+	for _, t := range []time.Time{e.MCPSent, e.TUIReceived, e.TUIResponded, e.MCPReceived} {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Latency returns how long the exchange took, from the request being sent to
+// the response being received, as far as the trace shows; zero if it wasn't
+// answered.
+func (e *WireExchange) Latency() time.Duration {
+	end := e.MCPReceived
+	if end.IsZero() {
+		end = e.TUIResponded
+	}
+	if end.IsZero() {
+		return 0
+	}
+	return end.Sub(e.Start())
+}
+
+// PairWireTrace pairs the requests in records with their responses, in the
+// order the requests were first seen. Pings are left out. The MCP server
+// side counts as traced only if records include any of its frames, so a trace
+// of the TUI side alone doesn't report every response as lost.
+func PairWireTrace(records []WireRecord) []*WireExchange {
+	// The two processes append to the log on their own, so lines can land
+	// slightly out of order
+	records = slices.Clone(records)
+	slices.SortStableFunc(records, func(a, b WireRecord) int { return a.Time.Compare(b.Time) })
+	mcpTraced := false
+	for _, rec := range records {
+		if rec.Side == WireSideMCP {
+			mcpTraced = true
+			break
+		}
+	}
+
+	var exchanges []*WireExchange
+	open := make(map[string]*WireExchange)
+	for _, rec := range records {
+		if rec.Type == string(MessageTypePing) || (rec.Kind != "request" && rec.Kind != "response") {
+			continue
+		}
+		// A frame for a step the open exchange already took starts a new one
+		key := rec.Type + "\x00" + rec.ID
+		ex := open[key]
+		if ex == nil || !wireStage(ex, rec).IsZero() {
+			ex = &WireExchange{Type: rec.Type, ID: rec.ID}
+			exchanges = append(exchanges, ex)
+			open[key] = ex
+		}
+		*wireStage(ex, rec) = rec.Time
+		if rec.Side == WireSideMCP && rec.Kind == "response" {
+			delete(open, key)
+		}
+	}
+
+	for _, ex := range exchanges {
+		switch {
+		case ex.MCPSent.IsZero() && ex.TUIReceived.IsZero():
+			ex.Status = WireUnmatchedAnswer
+		case !ex.MCPReceived.IsZero():
+			ex.Status = WireAnswered
+		case !ex.TUIResponded.IsZero() && mcpTraced:
+			ex.Status = WireResponseLost
+		case !ex.TUIResponded.IsZero():
+			ex.Status = WireAnswered
+		case !ex.TUIReceived.IsZero():
+			ex.Status = WireUnanswered
+		default:
+			ex.Status = WireNotReceived
+		}
+	}
+	return exchanges
+}
+
+// wireStage returns the field of ex that rec, a request or response, fills in.
+func wireStage(ex *WireExchange, rec WireRecord) *time.Time {
+	switch {
+	case rec.Side == WireSideMCP && rec.Kind == "request":
+		return &ex.MCPSent
+	case rec.Side == WireSideTUI && rec.Kind == "request":
+		return &ex.TUIReceived
+	case rec.Side == WireSideTUI:
+		return &ex.TUIResponded
+	}
+	return &ex.MCPReceived
+}
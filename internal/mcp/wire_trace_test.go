@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhubert/plural/internal/logger"
+)
+
+func TestWireTracer_Record(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tracer := wireTracer{sessionID: "s1", side: WireSideMCP}
+
+	rec := tracer.record(now, WireDirSend, []byte(`{"type":"permission","permReq":{"id":7,"tool":"Bash"}}`+"\n"))
+	if rec.Type != "permission" || rec.Kind != "request" || rec.ID != "7" || rec.Side != WireSideMCP || rec.Dir != WireDirSend {
+		t.Errorf("unexpected record %+v", rec)
+	}
+	if rec.Truncated || strings.HasSuffix(rec.Payload, "\n") {
+		t.Errorf("payload = %q, truncated = %v", rec.Payload, rec.Truncated)
+	}
+
+	rec = tracer.record(now, WireDirRecv, []byte(`{"type":"question","questResp":{"id":"abc","answers":{}}}`))
+	if rec.Kind != "response" || rec.ID != "abc" {
+		t.Errorf("string ID response: got kind %q, ID %q", rec.Kind, rec.ID)
+	}
+
+	// Pings have no body; their kind comes from who sent them
+	rec = tracer.record(now, WireDirSend, []byte(`{"type":"ping"}`))
+	if rec.Kind != "request" || rec.ID != "" {
+		t.Errorf("ping: got kind %q, ID %q", rec.Kind, rec.ID)
+	}
+	rec = wireTracer{side: WireSideTUI}.record(now, WireDirSend, []byte(`{"type":"ping"}`))
+	if rec.Kind != "response" {
+		t.Errorf("TUI ping: got kind %q, want response", rec.Kind)
+	}
+
+	large := `{"type":"planApproval","planReq":{"id":1,"plan":"` + strings.Repeat("x", 3*maxWireTracePayload) + `"}}`
+	rec = tracer.record(now, WireDirSend, []byte(large))
+	if !rec.Truncated || len(rec.Payload) != maxWireTracePayload || rec.Size != len(large) {
+		t.Errorf("large frame: truncated %v, payload %d bytes, size %d", rec.Truncated, len(rec.Payload), rec.Size)
+	}
+	if rec.ID != "1" {
+		t.Errorf("large frame ID = %q, want 1", rec.ID)
+	}
+}
+
+func TestPairWireTrace(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+	rec := func(ms int, side, kind, typ, id string) WireRecord {
+		return WireRecord{Time: at(ms), Side: side, Kind: kind, Type: typ, ID: id}
+	}
+	records := []WireRecord{
+		// Answered
+		rec(0, WireSideMCP, "request", "permission", "1"),
+		rec(1, WireSideTUI, "request", "permission", "1"),
+		rec(40, WireSideTUI, "response", "permission", "1"),
+		rec(41, WireSideMCP, "response", "permission", "1"),
+		// Pings are left out
+		rec(50, WireSideMCP, "request", "ping", ""),
+		rec(51, WireSideTUI, "response", "ping", ""),
+		// Never answered by the TUI
+		rec(60, WireSideMCP, "request", "question", "2"),
+		rec(61, WireSideTUI, "request", "question", "2"),
+		// Answered, but the answer never arrived
+		rec(70, WireSideMCP, "request", "createPR", "3"),
+		rec(72, WireSideTUI, "request", "createPR", "3"),
+		rec(90, WireSideTUI, "response", "createPR", "3"),
+		// Never reached the TUI
+		rec(100, WireSideMCP, "request", "permission", "4"),
+		// The same ID again later is a new exchange; its lines landed out of order
+		rec(111, WireSideMCP, "response", "permission", "1"),
+		rec(110, WireSideTUI, "response", "permission", "1"),
+		rec(105, WireSideMCP, "request", "permission", "1"),
+		rec(106, WireSideTUI, "request", "permission", "1"),
+	}
+
+	got := PairWireTrace(records)
+	want := []struct {
+		typ, id, status string
+		latency         time.Duration
+	}{
+		{"permission", "1", WireAnswered, 41 * time.Millisecond},
+		{"question", "2", WireUnanswered, 0},
+		{"createPR", "3", WireResponseLost, 20 * time.Millisecond},
+		{"permission", "4", WireNotReceived, 0},
+		{"permission", "1", WireAnswered, 6 * time.Millisecond},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d exchanges, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		ex := got[i]
+		if ex.Type != w.typ || ex.ID != w.id || ex.Status != w.status || ex.Latency() != w.latency {
+			t.Errorf("exchange %d = %s %s %q %v, want %s %s %q %v", i, ex.Type, ex.ID, ex.Status, ex.Latency(), w.typ, w.id, w.status, w.latency)
+		}
+	}
+}
+
+func TestPairWireTrace_TUISideOnly(t *testing.T) {
+	// A container session's MCP server logs inside the container, so only
+	// the TUI's side is in the host's log
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	got := PairWireTrace([]WireRecord{
+		{Time: base, Side: WireSideTUI, Kind: "request", Type: "question", ID: "1"},
+		{Time: base.Add(time.Second), Side: WireSideTUI, Kind: "response", Type: "question", ID: "1"},
+		{Time: base.Add(2 * time.Second), Side: WireSideTUI, Kind: "response", Type: "question", ID: "9"},
+	})
+	if len(got) != 2 {
+		t.Fatalf("got %d exchanges, want 2", len(got))
+	}
+	if got[0].Status != WireAnswered {
+		t.Errorf("status = %q, want %q", got[0].Status, WireAnswered)
+	}
+	if got[1].Status != WireUnmatchedAnswer {
+		t.Errorf("status = %q, want %q", got[1].Status, WireUnmatchedAnswer)
+	}
+}
+
+func TestWireTrace_SocketRoundTrip(t *testing.T) {
+	const sessionID = "test-wire-trace"
+	logPath, err := logger.MCPLogPath(sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(logPath)
+	t.Cleanup(func() {
+		SetWireTrace(false)
+		os.Remove(logPath)
+	})
+
+	permReqCh := make(chan PermissionRequest, 1)
+	permRespCh := make(chan PermissionResponse, 1)
+	server := NewDialingSocketServer(sessionID, permReqCh, permRespCh, make(chan QuestionRequest), make(chan QuestionResponse), make(chan PlanApprovalRequest), make(chan PlanApprovalResponse))
+	defer server.Close()
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	go server.HandleConn(serverConn)
+	go func() {
+		for req := range permReqCh {
+			permRespCh <- PermissionResponse{ID: req.ID, Allowed: true}
+		}
+	}()
+	defer close(permReqCh)
+
+	client := &SocketClient{conn: clientConn, reader: bufio.NewReader(clientConn)}
+	client.SetSessionID(sessionID)
+
+	// Nothing is written while tracing is off
+	if err := SetWireTrace(false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SendPermissionRequest(PermissionRequest{ID: 1, Tool: "Read"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Fatalf("expected no MCP log with tracing off, got %v", err)
+	}
+
+	if err := SetWireTrace(true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SendPermissionRequest(PermissionRequest{ID: 2, Tool: "Bash"}); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := ParseWireTrace(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("got %d records, want 4: %+v", len(records), records)
+	}
+	exchanges := PairWireTrace(records)
+	if len(exchanges) != 1 || exchanges[0].ID != "2" || exchanges[0].Status != WireAnswered {
+		t.Errorf("unexpected exchanges %+v", exchanges)
+	}
+}
+
+func TestWireTraceEnabled_FollowsMarker(t *testing.T) {
+	t.Cleanup(func() { SetWireTrace(false) })
+
+	if err := SetWireTrace(true); err != nil {
+		t.Fatal(err)
+	}
+	// Another process sees only the marker
+	wireTraceOn.Store(false)
+	wireTraceMarkerCheck.Store(0)
+	if !WireTraceEnabled() {
+		t.Error("expected tracing on while the marker exists")
+	}
+
+	if err := SetWireTrace(false); err != nil {
+		t.Fatal(err)
+	}
+	wireTraceMarkerCheck.Store(0)
+	if WireTraceEnabled() {
+		t.Error("expected tracing off once the marker is removed")
+	}
+}
@@ -0,0 +1,298 @@
+// Package paste cleans terminal output pasted into the chat input.
+//
+// Copying from a terminal tends to drag along noise that costs tokens without
+// adding meaning: ANSI escape sequences, shell prompts, CI log timestamps,
+// blank-padded columns and long runs of empty lines. All functions here are
+// pure so the cleaning rules can be tested against fixture pastes.
+package paste
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// minPrefixRepeats is how many lines must share a prompt or timestamp prefix
+// before it is treated as noise rather than content.
+const minPrefixRepeats = 2
+
+// prefixPatterns match shell prompts and log timestamps at the start of a line.
+// A pattern only applies when it matches at least minPrefixRepeats lines.
+var prefixPatterns = []*regexp.Regexp{
+	// GitHub Actions log timestamps: "2024-05-01T12:34:56.1234567Z "
+	regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?Z ?`),
+	// bash (Fedora/RHEL): "[user@host dir]$ "
+	regexp.MustCompile(`^\[[^\]]+\][$#](?: |$)`),
+	// bash (Debian/Ubuntu): "user@host:~/src$ "
+	regexp.MustCompile(`^[\w.-]+@[\w.-]+:\S*[$#](?: |$)`),
+	// zsh default: "user@host dir % "
+	regexp.MustCompile(`^[\w.-]+@[\w.-]+ \S+ [%#](?: |$)`),
+	// oh-my-zsh: "➜  src git:(main) ✗ "
+	regexp.MustCompile(`^➜ +\S+(?: git:\([^)]*\))?(?: ✗)?(?: |$)`),
+	// powerlevel10k status line above the input line: "╭─  ~/src  main ✔ ... at 10:22:01"
+	regexp.MustCompile(`^╭─.*$`),
+	// powerlevel10k / starship input line: "╰─❯ " or "❯ "
+	regexp.MustCompile(`^(?:╰─)?❯(?: |$)`),
+	// Bare prompt: "$ "
+	regexp.MustCompile(`^\$(?: |$)`),
+}
+
+// ciMarker matches GitHub Actions workflow commands that wrap log output.
+var ciMarker = regexp.MustCompile(`^##\[(?:group|endgroup|section|command)\]`)
+
+// Result is the outcome of cleaning a paste.
+type Result struct {
+	Text         string // Cleaned text
+	RemovedBytes int    // Number of bytes removed from the raw paste
+}
+
+// Changed returns whether cleaning removed anything.
+func (r Result) Changed() bool {
+	return r.RemovedBytes > 0
+}
+
+// Summary returns a one-line description of what was removed,
+// e.g. "cleaned paste: removed 2.1KB of noise".
+func (r Result) Summary() string {
+	return "cleaned paste: removed " + FormatBytes(r.RemovedBytes) + " of noise"
+}
+
+// IsMultiline returns whether the paste spans more than one line.
+// Single-line pastes are left alone since they rarely carry terminal noise.
+func IsMultiline(raw string) bool {
+	return strings.Contains(strings.TrimRight(raw, "\r\n"), "\n")
+}
+
+// Clean applies every cleaning step to a raw paste. Like Sanitize, it leaves the
+// contents of fenced code blocks as pasted, apart from ANSI escapes and line endings.
+func Clean(raw string) Result {
+	text := StripANSI(NormalizeLineEndings(raw))
+
+	segments := splitFences(strings.Split(text, "\n"))
+	var lines []string
+	for i, segment := range segments {
+		if segment.fenced {
+			lines = append(lines, segment.lines...)
+			continue
+		}
+		lines = append(lines, cleanLines(segment.lines, i == 0, i == len(segments)-1)...)
+	}
+
+	cleaned := strings.Join(lines, "\n")
+	return Result{
+		Text:         cleaned,
+		RemovedBytes: max(len(raw)-len(cleaned), 0),
+	}
+}
+
+// cleanLines applies the line-based cleaning steps to lines outside fenced code
+// blocks. Blank lines are dropped at the start and end of the paste, but kept next
+// to a fence.
+func cleanLines(lines []string, first, last bool) []string {
+	lines = StripRepeatedPrefixes(lines)
+	lines = StripCIMarkers(lines) // After prefixes, since CI markers follow the timestamp
+	lines = TrimTrailingWhitespace(lines)
+	return collapseBlankRuns(lines, first, last)
+}
+
+// fenceSegment is a run of lines either inside or outside a fenced code block.
+type fenceSegment struct {
+	lines  []string
+	fenced bool // Lines form a fenced code block, including its delimiters
+}
+
+// splitFences splits lines into alternating runs outside and inside fenced code
+// blocks, starting and ending with a (possibly empty) run outside. A fence left
+// open runs to the end of the paste. Fence delimiters lose trailing whitespace.
+func splitFences(lines []string) []fenceSegment {
+	var segments []fenceSegment
+	current := fenceSegment{}
+	for _, line := range lines {
+		if !isFence(line) {
+			current.lines = append(current.lines, line)
+			continue
+		}
+		line = strings.TrimRight(line, " \t")
+		if current.fenced {
+			current.lines = append(current.lines, line)
+			segments = append(segments, current)
+			current = fenceSegment{}
+			continue
+		}
+		segments = append(segments, current)
+		current = fenceSegment{lines: []string{line}, fenced: true}
+	}
+	return append(segments, current)
+}
+
+// Sanitize normalizes line endings to LF, resolves carriage-return overwrites as
+// NormalizeLineEndings does, and trims trailing whitespace from each line of a
+// paste. Unlike Clean it removes nothing but invisible characters, so it
+// is safe to apply to every paste. Lines inside fenced code blocks keep their
+// trailing whitespace; inFence reports whether the paste lands inside a fence
+// that was opened before the cursor.
+func Sanitize(text string, inFence bool) string {
+	text = NormalizeLineEndings(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if isFence(line) {
+			inFence = !inFence
+			lines[i] = strings.TrimRight(line, " \t")
+			continue
+		}
+		if !inFence {
+			lines[i] = strings.TrimRight(line, " \t")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// EndsInFence returns whether text leaves a fenced code block open, i.e. whether
+// text typed after it would be inside the code block.
+func EndsInFence(text string) bool {
+	open := false
+	for line := range strings.SplitSeq(text, "\n") {
+		if isFence(line) {
+			open = !open
+		}
+	}
+	return open
+}
+
+// isFence returns whether a line opens or closes a markdown fenced code block.
+func isFence(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// NormalizeLineEndings converts CRLF to LF and resolves carriage-return overwrites
+// (progress bars) to the text that was last visible on each line. A carriage
+// return ending a line overwrites nothing.
+func NormalizeLineEndings(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	if !strings.Contains(s, "\r") {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		lines[i] = line
+		if idx := strings.LastIndex(line, "\r"); idx >= 0 {
+			lines[i] = line[idx+1:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StripANSI removes ANSI escape sequences (colors, cursor movement, hyperlinks).
+func StripANSI(s string) string {
+	return ansi.Strip(s)
+}
+
+// StripCIMarkers removes GitHub Actions group markers, dropping lines that held nothing else.
+func StripCIMarkers(lines []string) []string {
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		loc := ciMarker.FindStringIndex(line)
+		if loc == nil {
+			result = append(result, line)
+			continue
+		}
+		if rest := line[loc[1]:]; strings.TrimSpace(rest) != "" {
+			result = append(result, rest)
+		}
+	}
+	return result
+}
+
+// StripRepeatedPrefixes removes shell prompts and log timestamps that appear at the
+// start of at least minPrefixRepeats lines. Lines that held only a prompt are dropped;
+// the command after a prompt is kept.
+func StripRepeatedPrefixes(lines []string) []string {
+	for _, pattern := range prefixPatterns {
+		matches := 0
+		for _, line := range lines {
+			if pattern.MatchString(line) {
+				matches++
+			}
+		}
+		if matches < minPrefixRepeats {
+			continue
+		}
+
+		result := make([]string, 0, len(lines))
+		for _, line := range lines {
+			loc := pattern.FindStringIndex(line)
+			if loc == nil {
+				result = append(result, line)
+				continue
+			}
+			if rest := line[loc[1]:]; strings.TrimSpace(rest) != "" {
+				result = append(result, rest)
+			}
+		}
+		lines = result
+	}
+	return lines
+}
+
+// TrimTrailingWhitespace removes trailing spaces and tabs from every line.
+func TrimTrailingWhitespace(lines []string) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = strings.TrimRight(line, " \t")
+	}
+	return result
+}
+
+// CollapseBlankRuns replaces runs of three or more blank lines with a single blank
+// line and drops blank lines at the start and end of the paste.
+func CollapseBlankRuns(lines []string) []string {
+	return collapseBlankRuns(lines, true, true)
+}
+
+// collapseBlankRuns collapses runs of blank lines like CollapseBlankRuns, dropping
+// leading blank lines only with trimStart and trailing ones only with trimEnd.
+func collapseBlankRuns(lines []string, trimStart, trimEnd bool) []string {
+	result := make([]string, 0, len(lines))
+	blanks := 0
+	flush := func() {
+		if blanks >= 3 {
+			blanks = 1
+		}
+		for range blanks {
+			result = append(result, "")
+		}
+		blanks = 0
+	}
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			blanks++
+			continue
+		}
+		if len(result) == 0 && trimStart {
+			blanks = 0 // Drop leading blank lines
+		}
+		flush()
+		result = append(result, line)
+	}
+	if !trimEnd && (len(result) > 0 || !trimStart) {
+		flush()
+	}
+	return result
+}
+
+// FormatBytes formats a byte count for display, e.g. "512B" or "2.1KB".
+func FormatBytes(n int) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%dB", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1fKB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1024*1024))
+	}
+}
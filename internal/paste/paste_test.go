@@ -0,0 +1,254 @@
+package paste
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Fixture pastes as copied from real terminals.
+const (
+	bashFixture = "zach@devbox:~/src/plural$ go test ./internal/git/   \n" +
+		"--- FAIL: TestMergeToMain (0.01s)                                             \n" +
+		"    merge_test.go:42: expected clean merge\n" +
+		"\n" +
+		"\n" +
+		"\n" +
+		"\n" +
+		"FAIL\n" +
+		"FAIL\tgithub.com/zhubert/plural/internal/git\t0.215s\n" +
+		"zach@devbox:~/src/plural$ \n"
+
+	zshP10kFixture = "\x1b[1;34m╭─\x1b[0m  ~/src/plural   main ✔                                        at 10:22:01\n" +
+		"\x1b[1;34m╰─❯\x1b[0m go vet ./...\n" +
+		"\x1b[31m# github.com/zhubert/plural/cmd\x1b[0m\n" +
+		"cmd/root_test.go:33:17: self-assignment of origQuiet\n" +
+		"╭─  ~/src/plural   main ✔                                   1 ✘  took 2s  at 10:22:04\n" +
+		"╰─❯\n"
+
+	ciFixture = "2025-03-01T12:00:01.1234567Z ##[group]Run go test ./...\r\n" +
+		"2025-03-01T12:00:01.1234567Z go test ./...\r\n" +
+		"2025-03-01T12:00:01.1234567Z ##[endgroup]\r\n" +
+		"2025-03-01T12:00:09.7654321Z \x1b[31m--- FAIL: TestParse (0.00s)\x1b[0m\r\n" +
+		"2025-03-01T12:00:09.7654321Z     parse_test.go:10: got 1, want 2\r\n" +
+		"2025-03-01T12:00:09.8000000Z FAIL\r\n"
+)
+
+func TestClean_Fixtures(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "bash",
+			raw:  bashFixture,
+			want: "go test ./internal/git/\n" +
+				"--- FAIL: TestMergeToMain (0.01s)\n" +
+				"    merge_test.go:42: expected clean merge\n" +
+				"\n" +
+				"FAIL\n" +
+				"FAIL\tgithub.com/zhubert/plural/internal/git\t0.215s",
+		},
+		{
+			name: "zsh powerlevel10k",
+			raw:  zshP10kFixture,
+			want: "go vet ./...\n" +
+				"# github.com/zhubert/plural/cmd\n" +
+				"cmd/root_test.go:33:17: self-assignment of origQuiet",
+		},
+		{
+			name: "CI log",
+			raw:  ciFixture,
+			want: "Run go test ./...\n" +
+				"go test ./...\n" +
+				"--- FAIL: TestParse (0.00s)\n" +
+				"    parse_test.go:10: got 1, want 2\n" +
+				"FAIL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Clean(tt.raw)
+			if result.Text != tt.want {
+				t.Errorf("Clean() text =\n%q\nwant\n%q", result.Text, tt.want)
+			}
+			if result.RemovedBytes != len(tt.raw)-len(tt.want) {
+				t.Errorf("RemovedBytes = %d, want %d", result.RemovedBytes, len(tt.raw)-len(tt.want))
+			}
+			if !result.Changed() {
+				t.Error("expected fixture paste to be changed")
+			}
+		})
+	}
+}
+
+func TestClean_LeavesCleanTextAlone(t *testing.T) {
+	raw := "func main() {\n\tfmt.Println(\"hi\")\n}"
+	result := Clean(raw)
+	if result.Text != raw || result.Changed() {
+		t.Errorf("expected clean text to be untouched, got %q (removed %d)", result.Text, result.RemovedBytes)
+	}
+}
+
+func TestClean_SkipsFencedCodeBlocks(t *testing.T) {
+	raw := "$ cat Makefile   \n" +
+		"$ make   \n" +
+		"\n" +
+		"```make   \n" +
+		"build:  \n" +
+		"\tgo build ./...\t\n" +
+		"\n" +
+		"\n" +
+		"\n" +
+		"```\n" +
+		"\n" +
+		"done   \n"
+	want := "cat Makefile\n" +
+		"make\n" +
+		"\n" +
+		"```make\n" +
+		"build:  \n" +
+		"\tgo build ./...\t\n" +
+		"\n" +
+		"\n" +
+		"\n" +
+		"```\n" +
+		"\n" +
+		"done"
+	if got := Clean(raw).Text; got != want {
+		t.Errorf("Clean() text =\n%q\nwant\n%q", got, want)
+	}
+
+	// A fence left open keeps everything after it
+	raw = "```\n  indented  \n\n\n\n"
+	if got := Clean(raw).Text; got != raw {
+		t.Errorf("Clean() text = %q, want unclosed fence kept as pasted", got)
+	}
+}
+
+func TestStripRepeatedPrefixes_RequiresRepeats(t *testing.T) {
+	// A single "$ " line is likely content (e.g., docs), not a prompt
+	lines := []string{"$ make install", "Installs the binary"}
+	got := StripRepeatedPrefixes(lines)
+	if !slices.Equal(got, lines) {
+		t.Errorf("single prompt-like line should be kept, got %q", got)
+	}
+
+	lines = []string{"$ make install", "ok", "$ make test", "PASS"}
+	got = StripRepeatedPrefixes(lines)
+	want := []string{"make install", "ok", "make test", "PASS"}
+	if !slices.Equal(got, want) {
+		t.Errorf("StripRepeatedPrefixes = %q, want %q", got, want)
+	}
+}
+
+func TestCollapseBlankRuns(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []string
+	}{
+		{name: "keeps single blank", lines: []string{"a", "", "b"}, want: []string{"a", "", "b"}},
+		{name: "keeps double blank", lines: []string{"a", "", "", "b"}, want: []string{"a", "", "", "b"}},
+		{name: "collapses three", lines: []string{"a", "", "", "", "b"}, want: []string{"a", "", "b"}},
+		{name: "whitespace-only counts as blank", lines: []string{"a", " ", "\t", "  ", "b"}, want: []string{"a", "", "b"}},
+		{name: "drops leading and trailing", lines: []string{"", "", "a", "", ""}, want: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CollapseBlankRuns(tt.lines); !slices.Equal(got, tt.want) {
+				t.Errorf("CollapseBlankRuns = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeLineEndings(t *testing.T) {
+	got := NormalizeLineEndings("a\r\nDownloading 10%\rDownloading 100%\r\nb\r")
+	if want := "a\nDownloading 100%\nb"; got != want {
+		t.Errorf("NormalizeLineEndings = %q, want %q", got, want)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		inFence bool
+		want    string
+	}{
+		{"crlf", "a\r\nb\r\n", false, "a\nb\n"},
+		{"carriage return overwrites", "fetching 10%\rfetching 100%\ndone", false, "fetching 100%\ndone"},
+		{"trailing whitespace", "a  \nb\t\nc", false, "a\nb\nc"},
+		{"keeps leading indentation", "  a  \n\tb ", false, "  a\n\tb"},
+		{"fenced code kept", "x \n```go  \nfoo  \n```\ny ", false, "x\n```go\nfoo  \n```\ny"},
+		{"tilde fence", "~~~\nfoo \n~~~", false, "~~~\nfoo \n~~~"},
+		{"pasted inside open fence", "foo  \nbar  ", true, "foo  \nbar  "},
+		{"closes open fence", "foo  \n```\nbar  ", true, "foo  \n```\nbar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.text, tt.inFence); got != tt.want {
+				t.Errorf("Sanitize(%q, %v) = %q, want %q", tt.text, tt.inFence, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndsInFence(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"", false},
+		{"plain text", false},
+		{"look:\n```go\nfunc main() {", true},
+		{"```\ncode\n```\n", false},
+		{"  ~~~\n", true},
+	}
+	for _, tt := range tests {
+		if got := EndsInFence(tt.text); got != tt.want {
+			t.Errorf("EndsInFence(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestIsMultiline(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{"single line", false},
+		{"single line\n", false},
+		{"two\nlines", true},
+		{"two\r\nlines\r\n", true},
+	}
+	for _, tt := range tests {
+		if got := IsMultiline(tt.raw); got != tt.want {
+			t.Errorf("IsMultiline(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestResult_Summary(t *testing.T) {
+	tests := []struct {
+		removed int
+		want    string
+	}{
+		{512, "cleaned paste: removed 512B of noise"},
+		{2150, "cleaned paste: removed 2.1KB of noise"},
+		{3 * 1024 * 1024, "cleaned paste: removed 3.0MB of noise"},
+	}
+	for _, tt := range tests {
+		got := Result{RemovedBytes: tt.removed}.Summary()
+		if got != tt.want {
+			t.Errorf("Summary() = %q, want %q", got, tt.want)
+		}
+		if !strings.HasPrefix(got, "cleaned paste:") {
+			t.Errorf("summary should start with 'cleaned paste:', got %q", got)
+		}
+	}
+}
@@ -3,8 +3,8 @@
 // Plural supports the XDG Base Directory Specification for organizing files:
 //
 //   - Config (XDG_CONFIG_HOME): config.json — user settings worth syncing
-//   - Data (XDG_DATA_HOME): sessions/*.json — local session history
-//   - State (XDG_STATE_HOME): logs/ — transient log files
+//   - Data (XDG_DATA_HOME): sessions/*.json, archive/ — local session history
+//   - State (XDG_STATE_HOME): logs/, activity/, mirrors/ — transient log files
 //
 // Resolution order:
 //  1. If ~/.plural/ exists → use legacy flat layout (all paths under ~/.plural/)
@@ -198,6 +198,33 @@ func LogsDir() (string, error) {
 	return filepath.Join(dir, "logs"), nil
 }
 
+// ActivityDir returns the directory for per-day activity feed logs.
+func ActivityDir() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "activity"), nil
+}
+
+// MirrorsDir returns the directory for per-session response mirror files.
+func MirrorsDir() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mirrors"), nil
+}
+
+// ArchiveDir returns the directory for transcripts of archived sessions.
+func ArchiveDir() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "archive"), nil
+}
+
 // WorktreesDir returns the directory for centralized git worktrees.
 func WorktreesDir() (string, error) {
 	dir, err := DataDir()
@@ -228,6 +228,22 @@ func TestDerivedPaths(t *testing.T) {
 		if want := filepath.Join(legacyDir, "logs"); logsDir != want {
 			t.Errorf("LogsDir = %q, want %q", logsDir, want)
 		}
+
+		activityDir, err := ActivityDir()
+		if err != nil {
+			t.Fatalf("ActivityDir: %v", err)
+		}
+		if want := filepath.Join(legacyDir, "activity"); activityDir != want {
+			t.Errorf("ActivityDir = %q, want %q", activityDir, want)
+		}
+
+		mirrorsDir, err := MirrorsDir()
+		if err != nil {
+			t.Fatalf("MirrorsDir: %v", err)
+		}
+		if want := filepath.Join(legacyDir, "mirrors"); mirrorsDir != want {
+			t.Errorf("MirrorsDir = %q, want %q", mirrorsDir, want)
+		}
 	})
 
 	t.Run("XDG layout", func(t *testing.T) {
@@ -264,6 +280,22 @@ func TestDerivedPaths(t *testing.T) {
 		if want := filepath.Join(xdgState, "plural", "logs"); logsDir != want {
 			t.Errorf("LogsDir = %q, want %q", logsDir, want)
 		}
+
+		activityDir, err := ActivityDir()
+		if err != nil {
+			t.Fatalf("ActivityDir: %v", err)
+		}
+		if want := filepath.Join(xdgState, "plural", "activity"); activityDir != want {
+			t.Errorf("ActivityDir = %q, want %q", activityDir, want)
+		}
+
+		mirrorsDir, err := MirrorsDir()
+		if err != nil {
+			t.Fatalf("MirrorsDir: %v", err)
+		}
+		if want := filepath.Join(xdgState, "plural", "mirrors"); mirrorsDir != want {
+			t.Errorf("MirrorsDir = %q, want %q", mirrorsDir, want)
+		}
 	})
 }
 
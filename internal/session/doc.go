@@ -38,6 +38,9 @@
 // across the filesystem. Legacy worktrees from the old sibling-directory layout
 // are automatically migrated on startup.
 //
+// The worktree_dir config setting, or repo_worktree_dir for a repo, puts them
+// elsewhere instead; see config.ExpandWorktreeDir.
+//
 // # Git Operations
 //
 // The package uses git commands for:
@@ -0,0 +1,54 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OutsideWorktree resolves target, a path a tool wrote relative to the worktree
+// unless absolute (see claude.ResponseChunk.WriteTargets), and returns it with
+// whether it lies outside the worktree. Symlinks are followed wherever the path
+// exists, so a link inside the worktree pointing out of it counts as outside.
+// Scratch files in /tmp or $TMPDIR don't count, unless the worktree itself is
+// there, where they can't be told from its neighbors.
+func OutsideWorktree(worktree, target string) (string, bool) {
+	if target == "~" || strings.HasPrefix(target, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return target, false
+		}
+		target = filepath.Join(home, strings.TrimPrefix(target, "~"))
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(worktree, target)
+	}
+	resolved := resolveExisting(target)
+	resolvedWorktree := resolvePath(worktree)
+	if isWithin(resolved, resolvedWorktree) {
+		return resolved, false
+	}
+	for _, tmp := range []string{"/tmp", os.TempDir()} {
+		tmp = resolvePath(tmp)
+		if isWithin(resolved, tmp) && !isWithin(resolvedWorktree, tmp) {
+			return resolved, false
+		}
+	}
+	return resolved, true
+}
+
+// resolveExisting is resolvePath for a path that may not exist (yet): symlinks
+// are resolved in its longest existing ancestor.
+func resolveExisting(path string) string {
+	path = filepath.Clean(path)
+	rest := ""
+	for dir := path; ; dir = filepath.Dir(dir) {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(resolved, rest)
+		}
+		if filepath.Dir(dir) == dir {
+			return path
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+	}
+}
@@ -0,0 +1,91 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOutsideWorktree(t *testing.T) {
+	root := t.TempDir()
+	worktree := filepath.Join(root, "worktree")
+	mainRepo := filepath.Join(root, "repo")
+	for _, dir := range []string{filepath.Join(worktree, "sub"), mainRepo} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A symlink inside the worktree pointing at the main checkout
+	if err := os.Symlink(mainRepo, filepath.Join(worktree, "link")); err != nil {
+		t.Fatal(err)
+	}
+	// The worktree reached through a symlink
+	worktreeLink := filepath.Join(root, "worktree-link")
+	if err := os.Symlink(worktree, worktreeLink); err != nil {
+		t.Fatal(err)
+	}
+	resolvedRepo, _ := filepath.EvalSymlinks(mainRepo)
+
+	tests := []struct {
+		name     string
+		worktree string
+		target   string
+		outside  bool
+		path     string // Expected resolved path when outside
+	}{
+		{"relative inside", worktree, "main.go", false, ""},
+		{"nested inside", worktree, "sub/new/file.go", false, ""},
+		{"dot dot staying inside", worktree, "sub/../main.go", false, ""},
+		{"absolute inside", worktree, filepath.Join(worktree, "main.go"), false, ""},
+		{"parent escape", worktree, "../repo/main.go", true, filepath.Join(resolvedRepo, "main.go")},
+		{"absolute outside", worktree, filepath.Join(mainRepo, "main.go"), true, filepath.Join(resolvedRepo, "main.go")},
+		{"symlink out of worktree", worktree, "link/main.go", true, filepath.Join(resolvedRepo, "main.go")},
+		{"worktree through symlink", worktreeLink, filepath.Join(worktree, "main.go"), false, ""},
+		{"target through worktree symlink", worktree, filepath.Join(worktreeLink, "main.go"), false, ""},
+		{"worktree root itself", worktree, ".", false, ""},
+		{"sibling with shared prefix", worktree, "../worktree-other/f", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, outside := OutsideWorktree(tt.worktree, tt.target)
+			if outside != tt.outside {
+				t.Fatalf("OutsideWorktree(%q, %q) outside = %v, want %v (path %s)", tt.worktree, tt.target, outside, tt.outside, path)
+			}
+			if tt.path != "" && path != tt.path {
+				t.Errorf("OutsideWorktree(%q, %q) path = %q, want %q", tt.worktree, tt.target, path, tt.path)
+			}
+		})
+	}
+}
+
+func TestOutsideWorktree_Home(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	worktree := filepath.Join(home, "worktree")
+	if _, outside := OutsideWorktree(worktree, "~/.zshrc"); !outside {
+		t.Error("expected a file in the home directory to be outside the worktree")
+	}
+	if _, outside := OutsideWorktree(worktree, "~/worktree/main.go"); outside {
+		t.Error("expected a home-relative path into the worktree to be inside")
+	}
+}
+
+func TestOutsideWorktree_TempDir(t *testing.T) {
+	root := t.TempDir()
+	worktree := filepath.Join(root, "worktree")
+	scratch := filepath.Join(root, "scratch")
+	for _, dir := range []string{worktree, scratch} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("TMPDIR", scratch)
+
+	if path, outside := OutsideWorktree(worktree, filepath.Join(scratch, "out.log")); outside {
+		t.Errorf("expected a scratch file in $TMPDIR not to count, got %s", path)
+	}
+	// The worktree is under /tmp here, so /tmp can't be told from its neighbors
+	if _, outside := OutsideWorktree(worktree, filepath.Join(root, "repo", "main.go")); !outside {
+		t.Error("expected a neighbor of a worktree in the temp directory to count")
+	}
+}
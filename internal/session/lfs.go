@@ -0,0 +1,115 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// lfsSkipSmudgeEnv makes git-lfs leave LFS files as pointers on checkout.
+const lfsSkipSmudgeEnv = "GIT_LFS_SKIP_SMUDGE=1"
+
+// lfsForceProgressEnv makes git-lfs report download progress even when its
+// stderr isn't a terminal.
+const lfsForceProgressEnv = "GIT_LFS_FORCE_PROGRESS=1"
+
+// WorktreeOptions controls how a new session's worktree is checked out.
+type WorktreeOptions struct {
+	SkipLFSSmudge bool              // Leave LFS files as pointers instead of downloading them
+	Progress      func(line string) // Called with each line of git's output during checkout (may be nil)
+}
+
+// lfsProgressPattern matches the progress git-lfs reports while downloading,
+// e.g. "Filtering content: 45% (9/20), 1.20 GiB | 3.2 MiB/s".
+var lfsProgressPattern = regexp.MustCompile(`(\d{1,3})% \((\d+)/(\d+)\)`)
+
+// UsesLFS returns whether the repo tracks files with Git LFS: whether its
+// top-level .gitattributes, or .git/info/attributes, assigns the lfs filter.
+// Attributes files in subdirectories aren't checked.
+func UsesLFS(repoPath string) bool {
+	for _, path := range []string{
+		filepath.Join(repoPath, ".gitattributes"),
+		filepath.Join(repoPath, ".git", "info", "attributes"),
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if attributesUseLFS(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// attributesUseLFS returns whether a gitattributes file assigns filter=lfs to
+// any pattern, ignoring comments.
+func attributesUseLFS(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, attr := range strings.Fields(line)[1:] {
+			if attr == "filter=lfs" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseLFSProgress extracts the percentage and file counts from a line of
+// git-lfs download progress. Returns false for any other line.
+func ParseLFSProgress(line string) (percent, done, total int, ok bool) {
+	m := lfsProgressPattern.FindStringSubmatch(line)
+	if m == nil {
+		return 0, 0, 0, false
+	}
+	percent, _ = strconv.Atoi(m[1])
+	done, _ = strconv.Atoi(m[2])
+	total, _ = strconv.Atoi(m[3])
+	return min(percent, 100), done, total, true
+}
+
+// worktreeAddCommand returns the command creating a worktree with a new branch
+// from startPoint. git runs under env, as the executor has no way to pass an
+// environment: with GIT_LFS_SKIP_SMUDGE set when skipping the LFS smudge, and
+// otherwise with GIT_LFS_FORCE_PROGRESS set, since git-lfs only reports download
+// progress to a terminal and the checkout's output is piped.
+func worktreeAddCommand(branch, worktreePath, startPoint string, opts WorktreeOptions) (string, []string) {
+	args := []string{"worktree", "add", "-b", branch, worktreePath, startPoint}
+	if opts.SkipLFSSmudge {
+		return "env", append([]string{lfsSkipSmudgeEnv, "git"}, args...)
+	}
+	return "env", append([]string{lfsForceProgressEnv, "git"}, args...)
+}
+
+// removePartialWorktree removes what a cancelled worktree creation left behind:
+// the worktree, its directory, and the new branch. Best effort; it runs after
+// the creation's context is done, so it uses its own.
+func (s *SessionService) removePartialWorktree(repoPath, worktreePath, branch string) {
+	log := logger.WithComponent("session")
+	ctx := context.Background()
+	if output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "worktree", "remove", worktreePath, "--force"); err != nil {
+		log.Debug("no partial worktree to remove", "output", string(output), "error", err)
+	}
+	if err := os.RemoveAll(worktreePath); err != nil {
+		log.Warn("failed to remove partial worktree directory", "path", worktreePath, "error", err)
+	}
+	if output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "worktree", "prune"); err != nil {
+		log.Warn("worktree prune failed (best-effort)", "output", string(output), "error", err)
+	}
+	if output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "branch", "-D", branch); err != nil {
+		log.Debug("no partial branch to delete", "output", string(output), "error", err)
+	}
+	log.Info("removed partial worktree after cancellation", "worktreePath", worktreePath, "branch", branch)
+}
@@ -0,0 +1,180 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	pexec "github.com/zhubert/plural/internal/exec"
+)
+
+func TestUsesLFS(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes string
+		want       bool
+	}{
+		{"no attributes", "", false},
+		{"lfs pattern", "*.psd filter=lfs diff=lfs merge=lfs -text\n", true},
+		{"other filters only", "*.go text eol=lf\n*.sh filter=crlf\n", false},
+		{"commented out", "# *.psd filter=lfs diff=lfs merge=lfs -text\n", false},
+		{"pattern named like the filter", "filter=lfs text\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := t.TempDir()
+			if tt.attributes != "" {
+				if err := os.WriteFile(filepath.Join(repo, ".gitattributes"), []byte(tt.attributes), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := UsesLFS(repo); got != tt.want {
+				t.Errorf("UsesLFS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsesLFS_InfoAttributes(t *testing.T) {
+	repo := t.TempDir()
+	infoDir := filepath.Join(repo, ".git", "info")
+	if err := os.MkdirAll(infoDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(infoDir, "attributes"), []byte("*.bin filter=lfs -text\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !UsesLFS(repo) {
+		t.Error("expected LFS filters in .git/info/attributes to be detected")
+	}
+}
+
+func TestParseLFSProgress(t *testing.T) {
+	percent, done, total, ok := ParseLFSProgress("Filtering content:  45% (9/20), 1.20 GiB | 3.2 MiB/s")
+	if !ok || percent != 45 || done != 9 || total != 20 {
+		t.Errorf("got %d%% (%d/%d) ok=%v, want 45%% (9/20)", percent, done, total, ok)
+	}
+	if _, _, _, ok := ParseLFSProgress("Preparing worktree (new branch 'feature')"); ok {
+		t.Error("expected a non-progress line not to parse")
+	}
+}
+
+func TestCreateWithOptions_WorktreeCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     WorktreeOptions
+		wantName string
+		wantArgs []string // Before the worktree add arguments
+	}{
+		{"full checkout", WorktreeOptions{}, "env", []string{"GIT_LFS_FORCE_PROGRESS=1", "git"}},
+		{"skip smudge", WorktreeOptions{SkipLFSSmudge: true}, "env", []string{"GIT_LFS_SKIP_SMUDGE=1", "git"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestPaths(t)
+			repo := t.TempDir()
+			mockExec := pexec.NewMockExecutor(nil)
+			mockSvc := NewSessionServiceWithExecutor(mockExec)
+
+			sess, err := mockSvc.CreateWithOptions(context.Background(), repo, "feature", "", BasePointHead, tt.opts)
+			if err != nil {
+				t.Fatalf("CreateWithOptions failed: %v", err)
+			}
+
+			var add *pexec.MockCall
+			for _, call := range mockExec.GetCalls() {
+				if slices.Contains(call.Args, "worktree") && slices.Contains(call.Args, "add") {
+					add = &call
+				}
+			}
+			if add == nil {
+				t.Fatal("expected a worktree add command")
+			}
+			wantArgs := append(tt.wantArgs, "worktree", "add", "-b", "feature", sess.WorkTree, "HEAD")
+			if add.Name != tt.wantName || !slices.Equal(add.Args, wantArgs) {
+				t.Errorf("got %s %q, want %s %q", add.Name, add.Args, tt.wantName, wantArgs)
+			}
+			if add.Dir != repo {
+				t.Errorf("expected the command to run in the repo, got %q", add.Dir)
+			}
+		})
+	}
+}
+
+func TestCreateWithOptions_StreamsProgress(t *testing.T) {
+	setupTestPaths(t)
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddPrefixMatch("env", []string{"GIT_LFS_FORCE_PROGRESS=1", "git", "worktree", "add"}, pexec.MockResponse{
+		Stderr: []byte("Preparing worktree (new branch 'feature')\nFiltering content:  50% (1/2)\rFiltering content: 100% (2/2), done.\n"),
+	})
+	mockSvc := NewSessionServiceWithExecutor(mockExec)
+
+	var lines []string
+	opts := WorktreeOptions{Progress: func(line string) { lines = append(lines, line) }}
+	if _, err := mockSvc.CreateWithOptions(context.Background(), t.TempDir(), "feature", "", BasePointHead, opts); err != nil {
+		t.Fatalf("CreateWithOptions failed: %v", err)
+	}
+
+	want := []string{"Preparing worktree (new branch 'feature')", "Filtering content:  50% (1/2)", "Filtering content: 100% (2/2), done."}
+	if !slices.Equal(lines, want) {
+		t.Errorf("progress lines = %q, want %q", lines, want)
+	}
+}
+
+func TestCreateWithOptions_CancelRemovesPartialWorktree(t *testing.T) {
+	setupTestPaths(t)
+	repo := t.TempDir()
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddPrefixMatch("env", []string{"GIT_LFS_SKIP_SMUDGE=1", "git", "worktree", "add"}, pexec.MockResponse{
+		Stderr: []byte("Updating files:  10% (1/10)\n"),
+		Err:    errors.New("signal: killed"),
+	})
+	mockSvc := NewSessionServiceWithExecutor(mockExec)
+
+	// Cancel while git reports progress, as the user pressing Esc would
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opts := WorktreeOptions{SkipLFSSmudge: true, Progress: func(string) { cancel() }}
+	_, err := mockSvc.CreateWithOptions(ctx, repo, "feature", "", BasePointHead, opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancellation error, got %v", err)
+	}
+
+	var cleanup []string
+	for _, call := range mockExec.GetCalls() {
+		if call.Name == "git" && (slices.Contains(call.Args, "remove") || slices.Contains(call.Args, "prune") || slices.Contains(call.Args, "-D")) {
+			cleanup = append(cleanup, strings.Join(call.Args[:2], " "))
+			if call.Dir != repo {
+				t.Errorf("expected cleanup to run in the repo, got %q", call.Dir)
+			}
+		}
+	}
+	want := []string{"worktree remove", "worktree prune", "branch -D"}
+	if !slices.Equal(cleanup, want) {
+		t.Errorf("cleanup commands = %q, want %q", cleanup, want)
+	}
+}
+
+func TestCreateWithOptions_FailureKeepsBranch(t *testing.T) {
+	setupTestPaths(t)
+	mockExec := pexec.NewMockExecutor(nil)
+	mockExec.AddPrefixMatch("env", []string{"GIT_LFS_FORCE_PROGRESS=1", "git", "worktree", "add"}, pexec.MockResponse{
+		Stderr: []byte("fatal: a branch named 'feature' already exists\n"),
+		Err:    errors.New("exit status 128"),
+	})
+	mockSvc := NewSessionServiceWithExecutor(mockExec)
+
+	if _, err := mockSvc.CreateWithOptions(context.Background(), t.TempDir(), "feature", "", BasePointHead, WorktreeOptions{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	// The branch may be the user's own; only a cancelled creation is cleaned up
+	for _, call := range mockExec.GetCalls() {
+		if slices.Contains(call.Args, "-D") {
+			t.Errorf("unexpected branch delete: %q", call.Args)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package session
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// CleanSessionName returns a session name as typed, pasted, or generated with
+// terminal escape sequences, control characters, and invisible formatting
+// characters (such as zero-width spaces) removed and surrounding whitespace
+// trimmed.
+func CleanSessionName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, ansi.Strip(name))
+	return strings.TrimSpace(name)
+}
+
+// NormalizeBranchName cleans up a session's branch name as typed, pasted, or
+// generated, then checks it with ValidateBranchName. Besides what
+// CleanSessionName removes, backslashes become "/", and empty path components
+// and dots starting a component are dropped, as git refuses them. Anything
+// else git can't use is an error rather than guessed at. An empty name stays
+// empty, to be generated.
+func NormalizeBranchName(name string) (string, error) {
+	branch := cleanBranchName(name)
+	if branch == "" && strings.TrimSpace(name) != "" {
+		return "", errors.New("branch name has no usable characters")
+	}
+	if err := ValidateBranchName(branch); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
+
+// SanitizeBranchName returns a branch name git accepts made from name, for
+// names that can't be asked about, such as generated ones or those of sessions
+// saved before names were checked. What NormalizeBranchName would reject is
+// replaced: unusable characters become "-", ".." becomes ".", ".lock" ending a
+// component is dropped, and a name too long is cut short. Returns "" if
+// nothing is left.
+func SanitizeBranchName(name string) string {
+	branch := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("/_.-", r)) {
+			return r
+		}
+		return '-'
+	}, cleanBranchName(name))
+
+	for _, repeated := range []string{"..", "--"} {
+		for strings.Contains(branch, repeated) {
+			branch = strings.ReplaceAll(branch, repeated, repeated[:1])
+		}
+	}
+	branch = cleanBranchName(strings.ReplaceAll(branch, ".lock/", "/"))
+	if len(branch) > MaxBranchNameValidation {
+		branch = branch[:MaxBranchNameValidation]
+	}
+	for {
+		trimmed := strings.TrimLeft(strings.TrimRight(strings.TrimSuffix(branch, ".lock"), "-./"), "-./_")
+		if trimmed == branch {
+			break
+		}
+		branch = trimmed
+	}
+
+	if ValidateBranchName(branch) != nil {
+		return ""
+	}
+	return branch
+}
+
+// cleanBranchName applies the fixes NormalizeBranchName makes without asking.
+func cleanBranchName(name string) string {
+	name = strings.ReplaceAll(CleanSessionName(name), `\`, "/")
+	var parts []string
+	for part := range strings.SplitSeq(name, "/") {
+		if part = strings.TrimLeft(part, "."); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,103 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCleanSessionName(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"plain", "repo/feature", "repo/feature"},
+		{"surrounding whitespace", "  feature \n", "feature"},
+		{"pasted newline", "fea\nture", "feature"},
+		{"ansi escape", "\x1b[31mfeature\x1b[0m", "feature"},
+		{"bell and nul", "feat\a\x00ure", "feature"},
+		{"zero-width space", "fea\u200bture", "feature"},
+		{"bidi override", "\u202efeature", "feature"},
+		{"unicode kept", "café ☕", "café ☕"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanSessionName(tt.input); got != tt.want {
+				t.Errorf("CleanSessionName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeBranchName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"empty stays empty", "", "", false},
+		{"valid kept", "feature/my-branch", "feature/my-branch", false},
+		{"pasted with newline", "feature\n", "feature", false},
+		{"control characters", "fea\x1b[1mture\x1b[0m", "feature", false},
+		{"zero-width space", "feat\u200bure", "feature", false},
+		{"backslash", `feature\fix`, "feature/fix", false},
+		{"leading slash", "/feature", "feature", false},
+		{"double slash", "feature//fix", "feature/fix", false},
+		{"trailing slash", "feature/", "feature", false},
+		{"leading dot", ".hidden", "hidden", false},
+		{"component leading dot", "feature/.fix", "feature/fix", false},
+		{"traversal", "../../etc/passwd", "etc/passwd", false},
+		{"only separators", "/ / /", "", true},
+		{"only control characters", "\x00\x01", "", true},
+		{"space", "my branch", "", true},
+		{"tilde", "branch~1", "", true},
+		{"lock suffix", "feature.lock", "", true},
+		{"dot dot inside", "a..b", "", true},
+		{"leading dash", "-feature", "", true},
+		{"non-ascii", "café", "", true},
+		{"too long", strings.Repeat("a", MaxBranchNameValidation+1), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeBranchName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeBranchName(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeBranchName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeBranchName(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"valid kept", "feature/my-branch", "feature/my-branch"},
+		{"space", "my branch", "my-branch"},
+		{"git specials", "a~b^c:d?e*f[g", "a-b-c-d-e-f-g"},
+		{"dot dot", "a..b", "a.b"},
+		{"lock suffix", "feature.lock", "feature"},
+		{"lock component", "a.lock/b", "a/b"},
+		{"leading dash", "--feature", "feature"},
+		{"trailing dot", "feature.", "feature"},
+		{"control characters", "fix\ttabs\x7f", "fixtabs"},
+		{"non-ascii", "café au lait", "caf-au-lait"},
+		{"traversal", `..\..\windows`, "windows"},
+		{"nothing usable", "☕☕", ""},
+		{"too long", strings.Repeat("ab-", 50), strings.TrimRight(strings.Repeat("ab-", 50)[:MaxBranchNameValidation], "-")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeBranchName(tt.input)
+			if got != tt.want {
+				t.Errorf("SanitizeBranchName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if got != "" {
+				if err := ValidateBranchName(got); err != nil {
+					t.Errorf("SanitizeBranchName(%q) = %q, which isn't valid: %v", tt.input, got, err)
+				}
+			}
+		})
+	}
+}
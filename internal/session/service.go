@@ -9,6 +9,10 @@ import (
 // holds its own executor, enabling proper testing and avoiding global state.
 type SessionService struct {
 	executor pexec.CommandExecutor
+
+	// worktreeDir returns the worktree_dir template for a repo's new worktrees,
+	// or "" for the default worktrees directory (nil for always the default)
+	worktreeDir func(repoPath string) string
 }
 
 // NewSessionService creates a new SessionService with the default real executor.
@@ -21,3 +25,10 @@ func NewSessionService() *SessionService {
 func NewSessionServiceWithExecutor(exec pexec.CommandExecutor) *SessionService {
 	return &SessionService{executor: exec}
 }
+
+// SetWorktreeDirs sets where the worktrees of new sessions go: dirFor returns
+// the worktree_dir template for a repo, as config.Config.GetWorktreeDir does,
+// or "" for the default worktrees directory.
+func (s *SessionService) SetWorktreeDirs(dirFor func(repoPath string) string) {
+	s.worktreeDir = dirFor
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 	"github.com/zhubert/plural/internal/config"
 	"github.com/zhubert/plural/internal/logger"
 	"github.com/zhubert/plural/internal/paths"
+	"github.com/zhubert/plural/internal/workpool"
 )
 
 // BasePoint specifies where to branch from when creating a new session
@@ -28,6 +30,14 @@ const (
 	BasePointLocalDefault BasePoint = "local-default"
 )
 
+// ErrNoCommits is returned when creating a session from a repo that has no
+// commits yet, which has nothing to create a worktree from. See CreateInitialCommit.
+var ErrNoCommits = errors.New("repository has no commits yet")
+
+// DefaultInitialCommitMessage is the message of the empty first commit made in
+// a repo without commits, unless the config sets another.
+const DefaultInitialCommitMessage = "Initial commit"
+
 // MaxBranchNameValidation is the maximum length for user-provided branch names.
 // This is more permissive than git.MaxBranchNameLength which is for auto-generated names.
 const MaxBranchNameValidation = 100
@@ -72,7 +82,56 @@ func (s *SessionService) BranchExists(ctx context.Context, repoPath, branch stri
 	return err == nil
 }
 
-// getCurrentBranchName returns the current branch name for the repo
+// GitAcceptsBranchName returns whether git check-ref-format accepts branch as
+// a branch name. Git allows more than ValidateBranchName, which new names are
+// held to, so this is for names already in use.
+func (s *SessionService) GitAcceptsBranchName(ctx context.Context, branch string) bool {
+	_, _, err := s.executor.Run(ctx, "", "git", "check-ref-format", "--branch", branch)
+	return err == nil
+}
+
+// IsEmptyRepo returns whether repoPath is a git repository without commits
+// yet, as it is from git init until the first commit.
+func (s *SessionService) IsEmptyRepo(ctx context.Context, repoPath string) bool {
+	return s.unbornBranch(ctx, repoPath) != ""
+}
+
+// hasCommits returns whether the repo's HEAD has any commits.
+func (s *SessionService) hasCommits(ctx context.Context, repoPath string) bool {
+	_, _, err := s.executor.Run(ctx, repoPath, "git", "rev-parse", "--verify", "--quiet", "HEAD")
+	return err == nil
+}
+
+// CreateInitialCommit makes an empty first commit with the given message on the
+// branch HEAD points at, so a repo without commits can have sessions. Changes
+// already staged stay staged rather than going into the commit.
+func (s *SessionService) CreateInitialCommit(ctx context.Context, repoPath, message string) error {
+	if message == "" {
+		message = DefaultInitialCommitMessage
+	}
+	output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "commit", "--allow-empty", "--only", "-m", message)
+	if err != nil {
+		return fmt.Errorf("failed to create initial commit: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	logger.WithComponent("session").Info("created initial commit", "repoPath", repoPath)
+	return nil
+}
+
+// unbornBranch returns the branch HEAD points at if it has no commits yet, as
+// in a freshly initialized repo, or "" otherwise.
+func (s *SessionService) unbornBranch(ctx context.Context, repoPath string) string {
+	if s.hasCommits(ctx, repoPath) {
+		return ""
+	}
+	output, err := s.executor.Output(ctx, repoPath, "git", "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// getCurrentBranchName returns the current branch name for the repo, including
+// a branch that has no commits yet.
 // Returns "HEAD" as fallback if it cannot be determined
 func (s *SessionService) getCurrentBranchName(ctx context.Context, repoPath string) string {
 	output, err := s.executor.Output(ctx, repoPath, "git", "rev-parse", "--abbrev-ref", "HEAD")
@@ -81,6 +140,8 @@ func (s *SessionService) getCurrentBranchName(ctx context.Context, repoPath stri
 		if branch != "" && branch != "HEAD" {
 			return branch
 		}
+	} else if branch := s.unbornBranch(ctx, repoPath); branch != "" {
+		return branch
 	}
 	return "HEAD"
 }
@@ -110,6 +171,11 @@ func (s *SessionService) GetDefaultBranch(ctx context.Context, repoPath string)
 		return "master"
 	}
 
+	// A repo without commits has no branches yet, only the one HEAD will start
+	if branch := s.unbornBranch(ctx, repoPath); branch != "" {
+		return branch
+	}
+
 	// Last resort fallback
 	return "main"
 }
@@ -147,6 +213,14 @@ func (s *SessionService) FetchOrigin(ctx context.Context, repoPath string) error
 //   - BasePointOrigin: fetches from origin and branches from origin's default branch
 //   - BasePointHead: branches from the current local HEAD
 func (s *SessionService) Create(ctx context.Context, repoPath string, customBranch string, branchPrefix string, basePoint BasePoint) (*config.Session, error) {
+	return s.CreateWithOptions(ctx, repoPath, customBranch, branchPrefix, basePoint, WorktreeOptions{})
+}
+
+// CreateWithOptions is Create with control over the worktree checkout: whether
+// LFS files are downloaded, and a callback receiving git's output as it runs.
+// Cancelling ctx while the worktree is checked out stops git and removes the
+// partial worktree and branch.
+func (s *SessionService) CreateWithOptions(ctx context.Context, repoPath string, customBranch string, branchPrefix string, basePoint BasePoint, opts WorktreeOptions) (*config.Session, error) {
 	log := logger.WithComponent("session")
 	startTime := time.Now()
 	log.Info("creating new session",
@@ -155,6 +229,17 @@ func (s *SessionService) Create(ctx context.Context, repoPath string, customBran
 		"branchPrefix", branchPrefix,
 		"basePoint", string(basePoint))
 
+	// A session made from a worktree would nest worktrees inside each other
+	if err := CheckNotInWorktree(repoPath, nil); err != nil {
+		return nil, fmt.Errorf("cannot create a session here: %w", err)
+	}
+
+	// git worktree add needs a commit to start the branch from
+	if s.IsEmptyRepo(ctx, repoPath) {
+		log.Info("repo has no commits, not creating session", "repoPath", repoPath)
+		return nil, fmt.Errorf("%w: make a first commit in %s to create sessions from it", ErrNoCommits, repoPath)
+	}
+
 	// Generate UUID for this session
 	id := uuid.New().String()
 	shortID := id[:8]
@@ -171,12 +256,11 @@ func (s *SessionService) Create(ctx context.Context, repoPath string, customBran
 		branch = branchPrefix + fmt.Sprintf("plural-%s", id)
 	}
 
-	// Worktree path: centralized under data directory
-	worktreesDir, err := paths.WorktreesDir()
+	// Worktree path: under the configured worktree directory, or centralized under data directory
+	worktreePath, err := s.worktreePath(repoPath, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get worktrees directory: %w", err)
+		return nil, err
 	}
-	worktreePath := filepath.Join(worktreesDir, id)
 
 	// Determine the starting point for the new branch
 	var startPoint string
@@ -228,10 +312,20 @@ func (s *SessionService) Create(ctx context.Context, repoPath string, customBran
 	log.Info("creating git worktree",
 		"branch", branch,
 		"worktreePath", worktreePath,
-		"startPoint", startPoint)
+		"startPoint", startPoint,
+		"skipLFSSmudge", opts.SkipLFSSmudge)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("session creation cancelled: %w", err)
+	}
 	worktreeStart := time.Now()
-	output, err := s.executor.CombinedOutput(ctx, repoPath, "git", "worktree", "add", "-b", branch, worktreePath, startPoint)
+	name, args := worktreeAddCommand(branch, worktreePath, startPoint, opts)
+	output, err := s.executor.StreamCombinedOutput(ctx, repoPath, opts.Progress, name, args...)
 	if err != nil {
+		if ctx.Err() != nil {
+			log.Info("worktree creation cancelled", "duration", time.Since(worktreeStart))
+			s.removePartialWorktree(repoPath, worktreePath, branch)
+			return nil, fmt.Errorf("session creation cancelled: %w", ctx.Err())
+		}
 		log.Error("failed to create worktree",
 			"duration", time.Since(worktreeStart),
 			"output", string(output),
@@ -285,6 +379,10 @@ func (s *SessionService) CreateFromBranch(ctx context.Context, repoPath string,
 		"customBranch", customBranch,
 		"branchPrefix", branchPrefix)
 
+	if err := CheckNotInWorktree(repoPath, nil); err != nil {
+		return nil, fmt.Errorf("cannot create a session here: %w", err)
+	}
+
 	// Generate UUID for this session
 	id := uuid.New().String()
 	shortID := id[:8]
@@ -300,12 +398,11 @@ func (s *SessionService) CreateFromBranch(ctx context.Context, repoPath string,
 		branch = branchPrefix + fmt.Sprintf("plural-%s", id)
 	}
 
-	// Worktree path: centralized under data directory
-	worktreesDir, err := paths.WorktreesDir()
+	// Worktree path: under the configured worktree directory, or centralized under data directory
+	worktreePath, err := s.worktreePath(repoPath, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get worktrees directory: %w", err)
+		return nil, err
 	}
-	worktreePath := filepath.Join(worktreesDir, id)
 
 	// Create the worktree with a new branch based on the source branch
 	log.Info("creating git worktree",
@@ -353,7 +450,8 @@ func (s *SessionService) CreateFromBranch(ctx context.Context, repoPath string,
 	return session, nil
 }
 
-// ValidateRepo checks if a path is a valid git repository
+// ValidateRepo checks if a path is a valid git repository, and not one of
+// Plural's own worktrees
 func (s *SessionService) ValidateRepo(ctx context.Context, path string) error {
 	log := logger.WithComponent("session")
 	log.Info("validating repo", "path", path)
@@ -365,6 +463,11 @@ func (s *SessionService) ValidateRepo(ctx context.Context, path string) error {
 		return fmt.Errorf("please use absolute path instead of ~")
 	}
 
+	if err := CheckNotInWorktree(path, nil); err != nil {
+		log.Info("validation failed - path is inside a worktree", "path", path, "error", err)
+		return err
+	}
+
 	// Check if it's a git repo by running git rev-parse
 	output, err := s.executor.CombinedOutput(ctx, path, "git", "rev-parse", "--git-dir")
 	if err != nil {
@@ -379,6 +482,91 @@ func (s *SessionService) ValidateRepo(ctx context.Context, path string) error {
 	return nil
 }
 
+// worktreePath returns where the worktree of a repo's session goes: in the
+// repo's worktree_dir, checked to be writable, or the default worktrees
+// directory.
+func (s *SessionService) worktreePath(repoPath, id string) (string, error) {
+	var template string
+	if s.worktreeDir != nil {
+		template = s.worktreeDir(repoPath)
+	}
+	if template == "" {
+		worktreesDir, err := paths.WorktreesDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get worktrees directory: %w", err)
+		}
+		return filepath.Join(worktreesDir, id), nil
+	}
+	dir, err := config.ExpandWorktreeDir(template, repoPath)
+	if err != nil {
+		return "", err
+	}
+	if err := checkWritable(dir); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, id), nil
+}
+
+// checkWritable creates dir if needed and checks that files can be created in
+// it, so a worktree_dir that can't be used fails before git runs.
+func checkWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("can't create the worktree directory %s: %w", dir, err)
+	}
+	f, err := os.CreateTemp(dir, ".plural-write-check-*")
+	if err != nil {
+		return fmt.Errorf("the worktree directory %s isn't writable: %w", dir, err)
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return nil
+}
+
+// CheckNotInWorktree returns an error if path lies within a Plural worktree: under
+// the worktrees directory, under a legacy .plural-worktrees directory, or within
+// one of knownWorktrees. Registering such a path as a repo, or creating a session
+// from it, nests worktrees inside each other.
+func CheckNotInWorktree(path string, knownWorktrees []string) error {
+	path = resolvePath(path)
+
+	for dir := path; ; dir = filepath.Dir(dir) {
+		if filepath.Base(dir) == ".plural-worktrees" {
+			return fmt.Errorf("%s is inside Plural's worktree directory %s; add the original repository instead", path, dir)
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	if worktreesDir, err := paths.WorktreesDir(); err == nil && isWithin(path, resolvePath(worktreesDir)) {
+		return fmt.Errorf("%s is inside Plural's worktree directory %s; add the original repository instead", path, worktreesDir)
+	}
+	for _, worktree := range knownWorktrees {
+		if worktree != "" && isWithin(path, resolvePath(worktree)) {
+			return fmt.Errorf("%s is inside the session worktree %s; add the original repository instead", path, worktree)
+		}
+	}
+	return nil
+}
+
+// resolvePath cleans path and resolves its symlinks where it exists, so paths
+// compare equal however they were reached (e.g., /tmp vs /private/tmp on macOS).
+func resolvePath(path string) string {
+	path = filepath.Clean(path)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// isWithin returns whether path is dir or lies below it.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
 // GetGitRoot returns the git root directory for a path, or empty string if not a git repo
 func (s *SessionService) GetGitRoot(ctx context.Context, path string) string {
 	output, err := s.executor.Output(ctx, path, "git", "rev-parse", "--show-toplevel")
@@ -434,8 +622,9 @@ type OrphanedWorktree struct {
 	ID       string // Session ID (directory name)
 }
 
-// FindOrphanedWorktrees finds all worktrees in .plural-worktrees directories
-// that don't have a matching session in config.
+// FindOrphanedWorktrees finds all worktrees in the worktrees directory, the
+// repos' configured worktree directories, and legacy .plural-worktrees
+// directories that don't have a matching session in config.
 // Directory scans are parallelized for better performance with many repos.
 func FindOrphanedWorktrees(cfg *config.Config) ([]OrphanedWorktree, error) {
 	log := logger.WithComponent("session")
@@ -476,6 +665,20 @@ func FindOrphanedWorktrees(cfg *config.Config) ([]OrphanedWorktree, error) {
 		dirsToCheck = append(dirsToCheck, centralDir)
 	}
 
+	// Configured worktree directories, where the repos' sessions are created now
+	for _, repoPath := range repoPaths {
+		template := cfg.GetWorktreeDir(repoPath)
+		if template == "" {
+			continue
+		}
+		dir, err := config.ExpandWorktreeDir(template, repoPath)
+		if err != nil || checkedDirs[dir] {
+			continue
+		}
+		checkedDirs[dir] = true
+		dirsToCheck = append(dirsToCheck, dir)
+	}
+
 	// Legacy .plural-worktrees sibling directories (transition period)
 	for _, repoPath := range repoPaths {
 		repoParent := filepath.Dir(repoPath)
@@ -493,27 +696,20 @@ func FindOrphanedWorktrees(cfg *config.Config) ([]OrphanedWorktree, error) {
 	}
 
 	// Scan directories in parallel
-	var mu sync.Mutex
-	var orphans []OrphanedWorktree
-
-	var wg sync.WaitGroup
-	for _, worktreesDir := range dirsToCheck {
-		wg.Add(1)
-		go func(worktreesDir string) {
-			defer wg.Done()
-
-			orphansInDir, err := findOrphansInDir(worktreesDir, knownSessions, repoPathsSet)
-			if err != nil {
-				return // Skip if directory doesn't exist or can't be read
-			}
+	found := make([][]OrphanedWorktree, len(dirsToCheck))
+	workpool.Run(context.Background(), len(dirsToCheck), workpool.Options{Limit: cfg.GetMaxConcurrentJobs()}, func(ctx context.Context, i int) error {
+		orphansInDir, err := findOrphansInDir(dirsToCheck[i], knownSessions, repoPathsSet)
+		if err != nil {
+			return err // Skip if directory doesn't exist or can't be read
+		}
+		found[i] = orphansInDir
+		return nil
+	})
 
-			mu.Lock()
-			orphans = append(orphans, orphansInDir...)
-			mu.Unlock()
-		}(worktreesDir)
+	var orphans []OrphanedWorktree
+	for _, orphansInDir := range found {
+		orphans = append(orphans, orphansInDir...)
 	}
-
-	wg.Wait()
 	log.Info("orphaned worktree search complete", "count", len(orphans))
 	return orphans, nil
 }
@@ -575,7 +771,12 @@ func findOrphansInDir(worktreesDir string, knownSessions map[string]bool, repoPa
 			continue
 		}
 
+		// Only directories named by a session ID are sessions' worktrees; others
+		// sharing a configured worktree directory belong to the user
 		sessionID := entry.Name()
+		if !config.IsSessionID(sessionID) {
+			continue
+		}
 		if !knownSessions[sessionID] {
 			worktreePath := filepath.Join(worktreesDir, sessionID)
 
@@ -620,10 +821,10 @@ func detectWorktreeBranch(ctx context.Context, s *SessionService, orphan Orphane
 
 // PruneOrphanedWorktrees removes all orphaned worktrees and their branches.
 // Pruning operations are parallelized across repos, but serialized within each repo
-// to avoid concurrent git operations on the same repository.
+// to avoid concurrent git operations on the same repository. Once ctx is
+// canceled no further orphans are pruned, and its error is returned with the
+// count of those that were.
 func (s *SessionService) PruneOrphanedWorktrees(ctx context.Context, cfg *config.Config) (int, error) {
-	log := logger.WithComponent("session")
-
 	orphans, err := FindOrphanedWorktrees(cfg)
 	if err != nil {
 		return 0, err
@@ -634,8 +835,12 @@ func (s *SessionService) PruneOrphanedWorktrees(ctx context.Context, cfg *config
 	}
 
 	// Group orphans by repo to avoid concurrent git operations on the same repo
+	var repos []string
 	orphansByRepo := make(map[string][]OrphanedWorktree)
 	for _, orphan := range orphans {
+		if _, ok := orphansByRepo[orphan.RepoPath]; !ok {
+			repos = append(repos, orphan.RepoPath)
+		}
 		orphansByRepo[orphan.RepoPath] = append(orphansByRepo[orphan.RepoPath], orphan)
 	}
 
@@ -643,61 +848,65 @@ func (s *SessionService) PruneOrphanedWorktrees(ctx context.Context, cfg *config
 	pruned := 0
 
 	// Process repos in parallel, but orphans within each repo sequentially
-	var wg sync.WaitGroup
-	for repoPath, repoOrphans := range orphansByRepo {
-		wg.Add(1)
-		go func(repoPath string, repoOrphans []OrphanedWorktree) {
-			defer wg.Done()
-
-			for _, orphan := range repoOrphans {
-				log.Info("pruning orphaned worktree", "path", orphan.Path)
-
-				// Detect the actual branch name before removing the worktree.
-				// Sessions can have prefixed branches (e.g., "zhubert/plural-<UUID>")
-				// or custom names after rename, so we can't assume "plural-<UUID>".
-				branchName := detectWorktreeBranch(ctx, s, orphan)
-
-				// Try to remove via git worktree remove first
-				_, _, err := s.executor.Run(ctx, orphan.RepoPath, "git", "worktree", "remove", orphan.Path, "--force")
-				if err != nil {
-					// If git command fails, try direct removal
-					log.Warn("git worktree remove failed, trying direct removal", "path", orphan.Path)
-					if err := os.RemoveAll(orphan.Path); err != nil {
-						log.Error("failed to remove orphan", "path", orphan.Path, "error", err)
-					}
-				}
-
-				// Prune worktree references (best-effort cleanup)
-				if _, _, pruneErr := s.executor.Run(ctx, orphan.RepoPath, "git", "worktree", "prune"); pruneErr != nil {
-					log.Warn("worktree prune failed (best-effort)", "repoPath", orphan.RepoPath, "error", pruneErr)
-				}
-
-				// Try to delete the branch
-				if branchName != "" {
-					if _, _, branchErr := s.executor.Run(ctx, orphan.RepoPath, "git", "branch", "-D", branchName); branchErr != nil {
-						log.Warn("failed to delete branch (may already be deleted)", "branch", branchName, "error", branchErr)
-					}
-				} else {
-					log.Warn("could not detect branch name for orphan, skipping branch deletion", "sessionID", orphan.ID)
-				}
-
-				// Delete session messages file
-				if err := config.DeleteSessionMessages(orphan.ID); err != nil {
-					log.Warn("failed to delete session messages", "sessionID", orphan.ID, "error", err)
-				} else {
-					log.Info("deleted session messages", "sessionID", orphan.ID)
-				}
-
-				mu.Lock()
-				pruned++
-				mu.Unlock()
-				log.Info("pruned orphan", "path", orphan.Path)
+	workpool.Run(ctx, len(repos), workpool.Options{Limit: cfg.GetMaxConcurrentJobs()}, func(ctx context.Context, i int) error {
+		for _, orphan := range orphansByRepo[repos[i]] {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
-		}(repoPath, repoOrphans)
+			s.pruneOrphan(ctx, orphan)
+
+			mu.Lock()
+			pruned++
+			mu.Unlock()
+		}
+		return nil
+	})
+
+	return pruned, ctx.Err()
+}
+
+// pruneOrphan removes an orphaned worktree, its branch, and its messages.
+func (s *SessionService) pruneOrphan(ctx context.Context, orphan OrphanedWorktree) {
+	log := logger.WithComponent("session")
+	log.Info("pruning orphaned worktree", "path", orphan.Path)
+
+	// Detect the actual branch name before removing the worktree.
+	// Sessions can have prefixed branches (e.g., "zhubert/plural-<UUID>")
+	// or custom names after rename, so we can't assume "plural-<UUID>".
+	branchName := detectWorktreeBranch(ctx, s, orphan)
+
+	// Try to remove via git worktree remove first
+	_, _, err := s.executor.Run(ctx, orphan.RepoPath, "git", "worktree", "remove", orphan.Path, "--force")
+	if err != nil {
+		// If git command fails, try direct removal
+		log.Warn("git worktree remove failed, trying direct removal", "path", orphan.Path)
+		if err := os.RemoveAll(orphan.Path); err != nil {
+			log.Error("failed to remove orphan", "path", orphan.Path, "error", err)
+		}
+	}
+
+	// Prune worktree references (best-effort cleanup)
+	if _, _, pruneErr := s.executor.Run(ctx, orphan.RepoPath, "git", "worktree", "prune"); pruneErr != nil {
+		log.Warn("worktree prune failed (best-effort)", "repoPath", orphan.RepoPath, "error", pruneErr)
 	}
 
-	wg.Wait()
-	return pruned, nil
+	// Try to delete the branch
+	if branchName != "" {
+		if _, _, branchErr := s.executor.Run(ctx, orphan.RepoPath, "git", "branch", "-D", branchName); branchErr != nil {
+			log.Warn("failed to delete branch (may already be deleted)", "branch", branchName, "error", branchErr)
+		}
+	} else {
+		log.Warn("could not detect branch name for orphan, skipping branch deletion", "sessionID", orphan.ID)
+	}
+
+	// Delete session messages file
+	if err := config.DeleteSessionMessages(orphan.ID); err != nil {
+		log.Warn("failed to delete session messages", "sessionID", orphan.ID, "error", err)
+	} else {
+		log.Info("deleted session messages", "sessionID", orphan.ID)
+	}
+
+	log.Info("pruned orphan", "path", orphan.Path)
 }
 
 // MigrateWorktrees moves worktrees from old .plural-worktrees sibling directories
@@ -804,3 +1013,40 @@ func (s *SessionService) MigrateWorktrees(ctx context.Context, cfg *config.Confi
 
 	return nil
 }
+
+// RestoreWorktree recreates the worktree of a session imported from an export,
+// in this machine's worktrees directory, checking out the session's branch, or
+// the branch on origin if it only exists there. A worktree already at the
+// session's path is kept. Returns the path of the worktree.
+func (s *SessionService) RestoreWorktree(ctx context.Context, sess config.Session) (string, error) {
+	log := logger.WithSession(sess.ID)
+
+	if _, err := os.Stat(sess.WorkTree); err == nil {
+		return sess.WorkTree, nil
+	}
+	worktreePath, err := s.worktreePath(sess.RepoPath, sess.ID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(worktreePath); err == nil {
+		return worktreePath, nil
+	}
+	if _, err := os.Stat(sess.RepoPath); err != nil {
+		return "", fmt.Errorf("repo %s not found", sess.RepoPath)
+	}
+
+	var args []string
+	switch {
+	case s.BranchExists(ctx, sess.RepoPath, sess.Branch):
+		args = []string{"worktree", "add", worktreePath, sess.Branch}
+	case s.BranchExists(ctx, sess.RepoPath, "origin/"+sess.Branch):
+		args = []string{"worktree", "add", "--track", "-b", sess.Branch, worktreePath, "origin/" + sess.Branch}
+	default:
+		return "", fmt.Errorf("branch %s not found in %s or on origin", sess.Branch, sess.RepoPath)
+	}
+	if output, err := s.executor.CombinedOutput(ctx, sess.RepoPath, "git", args...); err != nil {
+		return "", fmt.Errorf("failed to create worktree: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	log.Info("restored worktree", "path", worktreePath, "branch", sess.Branch)
+	return worktreePath, nil
+}
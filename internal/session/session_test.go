@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -197,6 +198,87 @@ func TestCreate_InvalidRepo(t *testing.T) {
 	}
 }
 
+// createEmptyTestRepo creates a git repository without commits, on a branch named trunk
+func createEmptyTestRepo(t *testing.T) string {
+	t.Helper()
+	repoPath := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"symbolic-ref", "HEAD", "refs/heads/trunk"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	return repoPath
+}
+
+func TestCreate_EmptyRepo(t *testing.T) {
+	setupTestPaths(t)
+	repoPath := createEmptyTestRepo(t)
+	defer cleanupWorktrees(t, repoPath)
+
+	if !svc.IsEmptyRepo(ctx, repoPath) {
+		t.Fatal("expected a repo without commits to be empty")
+	}
+	if branch := svc.getCurrentBranchName(ctx, repoPath); branch != "trunk" {
+		t.Errorf("current branch = %q, want the unborn trunk", branch)
+	}
+	if branch := svc.GetDefaultBranch(ctx, repoPath); branch != "trunk" {
+		t.Errorf("default branch = %q, want the unborn trunk", branch)
+	}
+
+	_, err := svc.Create(ctx, repoPath, "", "", BasePointLocalDefault)
+	if !errors.Is(err, ErrNoCommits) {
+		t.Fatalf("expected ErrNoCommits, got %v", err)
+	}
+	if worktreesDir, _ := paths.WorktreesDir(); worktreesDir != "" {
+		if entries, _ := os.ReadDir(worktreesDir); len(entries) > 0 {
+			t.Errorf("expected no worktree left behind, found %d", len(entries))
+		}
+	}
+
+	// Work the user already staged stays out of the first commit
+	if err := os.WriteFile(filepath.Join(repoPath, "draft.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := exec.Command("git", "-C", repoPath, "add", "draft.txt").CombinedOutput(); err != nil {
+		t.Fatalf("git add failed: %v\n%s", err, output)
+	}
+	if err := svc.CreateInitialCommit(ctx, repoPath, "Start the project"); err != nil {
+		t.Fatalf("CreateInitialCommit failed: %v", err)
+	}
+	if output, err := exec.Command("git", "-C", repoPath, "status", "--porcelain").Output(); err != nil || strings.TrimSpace(string(output)) != "A  draft.txt" {
+		t.Errorf("expected draft.txt still staged and uncommitted, got %q (%v)", output, err)
+	}
+	if svc.IsEmptyRepo(ctx, repoPath) {
+		t.Error("expected the repo not to be empty after its first commit")
+	}
+	cmd := exec.Command("git", "log", "-1", "--format=%s")
+	cmd.Dir = repoPath
+	if output, err := cmd.Output(); err != nil || strings.TrimSpace(string(output)) != "Start the project" {
+		t.Errorf("expected the first commit to use the given message, got %q (%v)", output, err)
+	}
+
+	sess, err := svc.Create(ctx, repoPath, "", "", BasePointLocalDefault)
+	if err != nil {
+		t.Fatalf("Create after the first commit failed: %v", err)
+	}
+	if sess.BaseBranch != "trunk" {
+		t.Errorf("BaseBranch = %q, want trunk", sess.BaseBranch)
+	}
+}
+
+func TestIsEmptyRepo_NotARepo(t *testing.T) {
+	if svc.IsEmptyRepo(ctx, t.TempDir()) {
+		t.Error("a directory that isn't a repo should not count as an empty repo")
+	}
+}
+
 func TestValidateRepo_Valid(t *testing.T) {
 	repoPath := createTestRepo(t)
 	defer os.RemoveAll(repoPath)
@@ -237,6 +319,66 @@ func TestValidateRepo_NonexistentPath(t *testing.T) {
 	}
 }
 
+func TestValidateRepo_NestedWorktree(t *testing.T) {
+	setupTestPaths(t)
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	defer cleanupWorktrees(t, repoPath)
+
+	sess, err := svc.Create(ctx, repoPath, "", "", BasePointHead)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// Neither the worktree nor a directory inside it can be registered as a repo
+	subdir := filepath.Join(sess.WorkTree, "pkg")
+	if err := os.MkdirAll(subdir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{sess.WorkTree, subdir} {
+		err := svc.ValidateRepo(ctx, path)
+		if err == nil || !strings.Contains(err.Error(), "worktree directory") {
+			t.Errorf("ValidateRepo(%s) = %v, want a worktree error", path, err)
+		}
+	}
+
+	// Nor can a session be created inside another session's worktree
+	if _, err := svc.Create(ctx, sess.WorkTree, "", "", BasePointHead); err == nil {
+		t.Error("Create should refuse a repo path inside a worktree")
+	}
+	if _, err := svc.CreateFromBranch(ctx, sess.WorkTree, sess.Branch, "", ""); err == nil {
+		t.Error("CreateFromBranch should refuse a repo path inside a worktree")
+	}
+
+	if err := svc.ValidateRepo(ctx, repoPath); err != nil {
+		t.Errorf("ValidateRepo failed for the original repo: %v", err)
+	}
+}
+
+func TestCheckNotInWorktree(t *testing.T) {
+	tmpDir := t.TempDir()
+	known := filepath.Join(tmpDir, "elsewhere", "session-1")
+
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{filepath.Join(tmpDir, "repo"), false},
+		{filepath.Join(tmpDir, ".plural-worktrees", "abc"), true},
+		{filepath.Join(tmpDir, ".plural-worktrees", "abc", "sub"), true},
+		{known, true},
+		{filepath.Join(known, "sub"), true},
+		{known + "-other", false},
+		{filepath.Join(tmpDir, "elsewhere"), false},
+	}
+	for _, tt := range tests {
+		err := CheckNotInWorktree(tt.path, []string{known})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckNotInWorktree(%s) = %v, wantErr %v", tt.path, err, tt.wantErr)
+		}
+	}
+}
+
 func TestGetGitRoot_Valid(t *testing.T) {
 	repoPath := createTestRepo(t)
 	defer os.RemoveAll(repoPath)
@@ -603,6 +745,31 @@ func TestFindOrphanedWorktrees(t *testing.T) {
 	}
 }
 
+func TestFindOrphanedWorktrees_SkipsOtherWorktrees(t *testing.T) {
+	setupTestPaths(t)
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	defer cleanupWorktrees(t, repoPath)
+
+	session, err := svc.Create(ctx, repoPath, "", "", BasePointHead)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	// A worktree of the user's own beside the session's
+	own := filepath.Join(filepath.Dir(session.WorkTree), "hotfix")
+	if out, err := exec.Command("git", "-C", repoPath, "worktree", "add", "-b", "hotfix", own).CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add failed: %v: %s", err, out)
+	}
+
+	orphans, err := FindOrphanedWorktrees(&config.Config{Repos: []string{repoPath}})
+	if err != nil {
+		t.Fatalf("FindOrphanedWorktrees failed: %v", err)
+	}
+	if len(orphans) != 1 || orphans[0].ID != session.ID {
+		t.Errorf("expected only the session's worktree as an orphan, got %+v", orphans)
+	}
+}
+
 func TestFindOrphanedWorktrees_NoWorktrees(t *testing.T) {
 	setupTestPaths(t)
 	repoPath := createTestRepo(t)
@@ -663,6 +830,35 @@ func TestPruneOrphanedWorktrees(t *testing.T) {
 	}
 }
 
+func TestPruneOrphanedWorktrees_Canceled(t *testing.T) {
+	setupTestPaths(t)
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	defer cleanupWorktrees(t, repoPath)
+
+	session, err := svc.Create(ctx, repoPath, "", "", BasePointHead)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	cfg := &config.Config{
+		Repos:    []string{repoPath},
+		Sessions: []config.Session{},
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	pruned, err := svc.PruneOrphanedWorktrees(canceled, cfg)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if pruned != 0 {
+		t.Errorf("Expected nothing pruned after cancellation, got %d", pruned)
+	}
+	if _, err := os.Stat(session.WorkTree); err != nil {
+		t.Errorf("Worktree should be left after a canceled prune: %v", err)
+	}
+}
+
 func TestPruneOrphanedWorktrees_PrefixedBranch(t *testing.T) {
 	setupTestPaths(t)
 	repoPath := createTestRepo(t)
@@ -1814,7 +2010,7 @@ func TestPruneOrphanedWorktrees_LogsGitErrors(t *testing.T) {
 	defer os.RemoveAll(repoPath)
 	defer cleanupWorktrees(t, repoPath)
 
-	orphanID := "fake-orphan-session-id"
+	orphanID := "5d2c8e0a-6b1f-4e3a-9c7d-1a2b3c4d5e6f"
 	worktreesDir, err := paths.WorktreesDir()
 	if err != nil {
 		t.Fatalf("WorktreesDir failed: %v", err)
@@ -2044,7 +2240,7 @@ func TestFindOrphanedWorktrees_LegacyLocation(t *testing.T) {
 	defer os.RemoveAll(repoPath)
 
 	// Create a worktree in the old .plural-worktrees location manually
-	sessionID := "legacy-orphan-id"
+	sessionID := "8f3a1b2c-7d4e-4f5a-8b6c-9d0e1f2a3b4c"
 	oldWorktreesDir := filepath.Join(filepath.Dir(repoPath), ".plural-worktrees")
 	oldWorktreePath := filepath.Join(oldWorktreesDir, sessionID)
 
@@ -2084,3 +2280,90 @@ func TestFindOrphanedWorktrees_LegacyLocation(t *testing.T) {
 		t.Errorf("Orphan ID = %q, want %q", orphans[0].ID, sessionID)
 	}
 }
+
+func TestCreate_ConfiguredWorktreeDir(t *testing.T) {
+	setupTestPaths(t)
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	worktreesRoot := t.TempDir()
+	cfg := &config.Config{
+		Repos:       []string{repoPath},
+		Sessions:    []config.Session{},
+		WorktreeDir: filepath.Join(worktreesRoot, "{repo}", "{session}"),
+	}
+	configured := NewSessionService()
+	configured.SetWorktreeDirs(cfg.GetWorktreeDir)
+
+	sess, err := configured.Create(ctx, repoPath, "", "", BasePointHead)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	defer func() {
+		cmd := exec.Command("git", "worktree", "remove", "--force", sess.WorkTree)
+		cmd.Dir = repoPath
+		cmd.Run()
+	}()
+	if want := filepath.Join(worktreesRoot, filepath.Base(repoPath), sess.ID); sess.WorkTree != want {
+		t.Errorf("WorkTree = %q, want %q", sess.WorkTree, want)
+	}
+
+	// Without its session, the worktree is found as an orphan there
+	orphans, err := FindOrphanedWorktrees(cfg)
+	if err != nil {
+		t.Fatalf("FindOrphanedWorktrees failed: %v", err)
+	}
+	if len(orphans) != 1 || orphans[0].ID != sess.ID {
+		t.Errorf("expected the session's worktree found as an orphan, got %+v", orphans)
+	}
+
+	// A directory that can't be created fails before git runs
+	blocker := filepath.Join(worktreesRoot, "file")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.WorktreeDir = filepath.Join(blocker, "{repo}")
+	if _, err := configured.Create(ctx, repoPath, "", "", BasePointHead); err == nil || !strings.Contains(err.Error(), "worktree directory") {
+		t.Errorf("expected an unusable worktree directory reported, got %v", err)
+	}
+}
+
+func TestRestoreWorktree(t *testing.T) {
+	setupTestPaths(t)
+	repoPath := createTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	defer cleanupWorktrees(t, repoPath)
+
+	cmd := exec.Command("git", "branch", "imported-work")
+	cmd.Dir = repoPath
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to create branch: %s: %v", string(out), err)
+	}
+
+	sess := config.Session{ID: "restored-session-id", RepoPath: repoPath, WorkTree: "/elsewhere/worktrees/restored-session-id", Branch: "imported-work"}
+	path, err := svc.RestoreWorktree(ctx, sess)
+	if err != nil {
+		t.Fatalf("RestoreWorktree failed: %v", err)
+	}
+	worktreesDir, _ := paths.WorktreesDir()
+	if path != filepath.Join(worktreesDir, sess.ID) {
+		t.Errorf("worktree path = %q, want it in %s", path, worktreesDir)
+	}
+	cmd = exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = path
+	out, err := cmd.Output()
+	if err != nil || strings.TrimSpace(string(out)) != "imported-work" {
+		t.Errorf("expected imported-work checked out in the worktree, got %q, %v", out, err)
+	}
+
+	// Restoring again keeps the worktree
+	sess.WorkTree = path
+	if again, err := svc.RestoreWorktree(ctx, sess); err != nil || again != path {
+		t.Errorf("expected the existing worktree kept, got %q, %v", again, err)
+	}
+
+	sess = config.Session{ID: "missing-branch-id", RepoPath: repoPath, WorkTree: "/elsewhere/missing", Branch: "never-pushed"}
+	if _, err := svc.RestoreWorktree(ctx, sess); err == nil || !strings.Contains(err.Error(), "never-pushed") {
+		t.Errorf("expected an error naming the missing branch, got %v", err)
+	}
+}
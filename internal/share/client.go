@@ -0,0 +1,72 @@
+package share
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// maxEventSize bounds a single event, which for snapshots holds the whole transcript.
+const maxEventSize = 64 * 1024 * 1024
+
+// ErrShareEnded is returned by Watch when the host stops sharing.
+var ErrShareEnded = errors.New("the host stopped sharing this session")
+
+// Watch connects to a share URL and calls onEvent for each event until the host
+// stops sharing (ErrShareEnded), the connection drops, or ctx is cancelled.
+func Watch(ctx context.Context, url string, onEvent func(Event)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid share URL: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to share: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("share unavailable: %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+	var data []byte
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			// A blank line ends the event
+			if len(data) == 0 {
+				continue
+			}
+			e, err := decodeEvent(data)
+			if err != nil {
+				return err
+			}
+			data = data[:0]
+			onEvent(e)
+			if e.Type == EventEnd {
+				return ErrShareEnded
+			}
+			continue
+		}
+		if payload, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, bytes.TrimPrefix(payload, []byte(" "))...)
+		}
+		// Other fields (event:, id:, comments) carry nothing the JSON doesn't
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("share connection lost: %w", err)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return errors.New("share connection closed")
+}
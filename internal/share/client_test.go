@@ -0,0 +1,64 @@
+package share
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serveFrames returns a test server that writes body as an event stream.
+func serveFrames(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestWatch_ParsesEventStream(t *testing.T) {
+	ts := serveFrames(t, ": keepalive comment\n\n"+
+		"event: snapshot\ndata: {\"version\":1,\"type\":\"snapshot\",\"session_name\":\"s\",\"messages\":[{\"role\":\"user\",\"content\":\"hi\"}]}\n\n"+
+		"event: append\ndata: {\"version\":1,\"type\":\"append\",\"index\":1,\"role\":\"assistant\",\"content\":\"yo\"}\n\n"+
+		"event: end\ndata: {\"version\":1,\"type\":\"end\"}\n\n")
+
+	var got []Event
+	err := Watch(context.Background(), ts.URL, func(e Event) { got = append(got, e) })
+	if err != ErrShareEnded {
+		t.Fatalf("Watch returned %v, want ErrShareEnded", err)
+	}
+	if len(got) != 3 || got[0].Type != EventSnapshot || got[1].Type != EventAppend || got[2].Type != EventEnd {
+		t.Fatalf("events = %+v", got)
+	}
+}
+
+func TestWatch_RejectsNewerProtocol(t *testing.T) {
+	ts := serveFrames(t, "event: snapshot\ndata: {\"version\":2,\"type\":\"snapshot\"}\n\n")
+	err := Watch(context.Background(), ts.URL, func(Event) { t.Error("no event should be delivered") })
+	if err == nil || !strings.Contains(err.Error(), "newer than supported") {
+		t.Errorf("Watch returned %v, want protocol version error", err)
+	}
+}
+
+func TestWatch_ConnectionClosed(t *testing.T) {
+	ts := serveFrames(t, "event: snapshot\ndata: {\"version\":1,\"type\":\"snapshot\"}\n\n")
+	err := Watch(context.Background(), ts.URL, func(Event) {})
+	if err == nil || err == ErrShareEnded {
+		t.Errorf("Watch returned %v, want a connection closed error", err)
+	}
+}
+
+func TestWatch_HTTPError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "share ended", http.StatusGone)
+	}))
+	defer ts.Close()
+	err := Watch(context.Background(), ts.URL, func(Event) {})
+	if err == nil || !strings.Contains(err.Error(), "410") {
+		t.Errorf("Watch returned %v, want 410 error", err)
+	}
+}
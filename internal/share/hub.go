@@ -0,0 +1,207 @@
+package share
+
+import (
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// subscriberBuffer is how many events a viewer may lag behind before it is
+// disconnected. A disconnected viewer gets a fresh snapshot when it reconnects.
+const subscriberBuffer = 256
+
+// Hub holds a transcript and streams it as server-sent events to every viewer
+// connected, sending each a snapshot and then what changes. It is shared by
+// the share server and the web view.
+type Hub struct {
+	component string // Logger component, naming the server in logs
+
+	mu          sync.Mutex
+	sessionName string
+	transcript  []Message
+	subscribers map[chan []byte]struct{}
+	closed      bool
+}
+
+// NewHub returns a hub for the named session's transcript, logging as component.
+func NewHub(component, sessionName string) *Hub {
+	return &Hub{
+		component:   component,
+		sessionName: sessionName,
+		subscribers: make(map[chan []byte]struct{}),
+	}
+}
+
+// Publish sets the transcript shown. Only what changed since the last call is
+// sent when the same session's transcript grew by appending; otherwise viewers
+// get a new snapshot.
+func (h *Hub) Publish(sessionName string, messages []Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	var events []Event
+	if sessionName != h.sessionName {
+		events = []Event{Snapshot(sessionName, messages)}
+	} else {
+		events = Diff(sessionName, h.transcript, messages)
+	}
+	h.sessionName = sessionName
+	h.transcript = slices.Clone(messages)
+	for _, e := range events {
+		h.broadcast(e)
+	}
+}
+
+// Transcript returns the session's name and transcript as last published.
+func (h *Hub) Transcript() (string, []Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sessionName, slices.Clone(h.transcript)
+}
+
+// broadcast sends an event to every viewer, disconnecting viewers that fell too far behind.
+// Must be called with h.mu held.
+func (h *Hub) broadcast(e Event) {
+	frame, err := EncodeEvent(e)
+	if err != nil {
+		logger.WithComponent(h.component).Error("failed to encode event", "error", err)
+		return
+	}
+	for ch := range h.subscribers {
+		select {
+		case ch <- frame:
+		default:
+			logger.WithComponent(h.component).Warn("disconnecting slow viewer")
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Viewers returns the number of connected viewers.
+func (h *Hub) Viewers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
+
+// ServeEvents streams events to a viewer, starting with a snapshot of the transcript.
+func (h *Hub) ServeEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		// Viewers are read-only: they can never send anything to the session
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		http.Error(w, "stream ended", http.StatusGone)
+		return
+	}
+	snapshot, err := EncodeEvent(Snapshot(h.sessionName, slices.Clone(h.transcript)))
+	if err != nil {
+		h.mu.Unlock()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ch := make(chan []byte, subscriberBuffer)
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		if _, ok := h.subscribers[ch]; ok {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+		h.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if _, err := w.Write(snapshot); err != nil {
+		return
+	}
+	flusher.Flush()
+	logger.WithComponent(h.component).Info("viewer connected", "session", h.sessionName, "remote", r.RemoteAddr)
+
+	for {
+		select {
+		case frame, ok := <-ch:
+			if !ok {
+				return
+			}
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// Close tells viewers the stream ended and disconnects them.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	h.broadcast(Event{Version: ProtocolVersion, Type: EventEnd})
+	for ch := range h.subscribers {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// RequireHost wraps next to refuse requests whose Host header doesn't name the
+// address the server listens on, so a web page whose DNS name is rebound to
+// this machine can't read from it. For a server listening on all interfaces,
+// any IP address is accepted, as is localhost for a server on the loopback.
+func RequireHost(addr net.Addr, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hostAllowed(r.Host, addr) {
+			http.Error(w, "invalid host", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hostAllowed returns whether a request's Host names addr: its port with its
+// IP address, any IP address if it listens on all interfaces, or localhost if
+// it listens on the loopback.
+func hostAllowed(host string, addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil || port != strconv.Itoa(tcpAddr.Port) {
+		return false
+	}
+	if hostname == "localhost" {
+		return tcpAddr.IP.IsLoopback() || tcpAddr.IP.IsUnspecified()
+	}
+	ip := net.ParseIP(hostname)
+	if ip == nil {
+		return false
+	}
+	return tcpAddr.IP.IsUnspecified() || ip.Equal(tcpAddr.IP)
+}
@@ -0,0 +1,36 @@
+package share
+
+import (
+	"net"
+	"testing"
+)
+
+func TestHostAllowed(t *testing.T) {
+	loopback := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8099}
+	all := &net.TCPAddr{IP: net.IPv4zero, Port: 8099}
+	lan := &net.TCPAddr{IP: net.ParseIP("192.168.1.5"), Port: 8099}
+
+	tests := []struct {
+		host string
+		addr net.Addr
+		want bool
+	}{
+		{"127.0.0.1:8099", loopback, true},
+		{"localhost:8099", loopback, true},
+		{"127.0.0.1:9000", loopback, false},
+		{"127.0.0.1", loopback, false},
+		// A site whose DNS name was rebound to this machine
+		{"attacker.example:8099", loopback, false},
+		{"192.168.1.5:8099", all, true},
+		{"localhost:8099", all, true},
+		{"attacker.example:8099", all, false},
+		{"192.168.1.5:8099", lan, true},
+		{"192.168.1.6:8099", lan, false},
+		{"localhost:8099", lan, false},
+	}
+	for _, tt := range tests {
+		if got := hostAllowed(tt.host, tt.addr); got != tt.want {
+			t.Errorf("hostAllowed(%q, %v) = %v, want %v", tt.host, tt.addr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// shutdownTimeout bounds how long Close waits for watcher connections to finish.
+const shutdownTimeout = 2 * time.Second
+
+// Options configures a share server.
+type Options struct {
+	// LAN listens on all interfaces so watchers on the local network can connect.
+	// By default the server only listens on localhost.
+	LAN bool
+	// Port to listen on; 0 picks a free port.
+	Port int
+}
+
+// Server streams one session's transcript to watchers.
+type Server struct {
+	sessionName string
+	token       string
+	lan         bool
+	listener    net.Listener
+	http        *http.Server
+	hub         *Hub
+}
+
+// Start starts a share server for a session. The event stream is served at a path
+// containing a random token, so only someone given the URL can watch.
+func Start(sessionName string, opts Options) (*Server, error) {
+	host := "127.0.0.1"
+	if opts.LAN {
+		host = "0.0.0.0"
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(opts.Port)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for share: %w", err)
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	s := &Server{
+		sessionName: sessionName,
+		token:       hex.EncodeToString(token),
+		lan:         opts.LAN,
+		listener:    listener,
+		hub:         NewHub("share", sessionName),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/share/"+s.token+"/events", s.hub.ServeEvents)
+	s.http = &http.Server{Handler: RequireHost(listener.Addr(), mux), ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := s.http.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.WithComponent("share").Error("share server failed", "error", err)
+		}
+	}()
+	logger.WithComponent("share").Info("share server started", "session", sessionName, "addr", listener.Addr().String(), "lan", opts.LAN)
+	return s, nil
+}
+
+// URL returns the URL watchers connect to. For LAN shares it uses the machine's
+// local network address.
+func (s *Server) URL() string {
+	host := "127.0.0.1"
+	if s.lan {
+		if ip := localIP(); ip != "" {
+			host = ip
+		}
+	}
+	port := s.listener.Addr().(*net.TCPAddr).Port
+	return fmt.Sprintf("http://%s/share/%s/events", net.JoinHostPort(host, strconv.Itoa(port)), s.token)
+}
+
+// localIP returns the first non-loopback IPv4 address of this machine, or "" if none.
+func localIP() string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return ""
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+			return ipNet.IP.String()
+		}
+	}
+	return ""
+}
+
+// Publish sends the session's current transcript to watchers. Only what changed
+// since the last call is sent when the transcript grew by appending; otherwise
+// watchers get a new snapshot.
+func (s *Server) Publish(messages []Message) {
+	s.hub.Publish(s.sessionName, messages)
+}
+
+// Watchers returns the number of connected watchers.
+func (s *Server) Watchers() int {
+	return s.hub.Viewers()
+}
+
+// Close tells watchers the share ended and stops the server.
+func (s *Server) Close() error {
+	s.hub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	logger.WithComponent("share").Info("share server stopped", "session", s.sessionName)
+	return s.http.Shutdown(ctx)
+}
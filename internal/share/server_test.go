@@ -0,0 +1,155 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startTestServer starts a localhost share server that is closed when the test ends.
+func startTestServer(t *testing.T) *Server {
+	t.Helper()
+	s, err := Start("fix-login", Options{})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// watchEvents runs Watch in the background, forwarding events and the final error.
+func watchEvents(t *testing.T, url string) (<-chan Event, <-chan error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	events := make(chan Event, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, url, func(e Event) { events <- e })
+	}()
+	return events, done
+}
+
+func nextEvent(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for share event")
+		return Event{}
+	}
+}
+
+// waitForWatchers waits until n watchers are connected.
+func waitForWatchers(t *testing.T, s *Server, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for s.Watchers() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d watchers, have %d", n, s.Watchers())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServer_StartListensOnLocalhost(t *testing.T) {
+	s := startTestServer(t)
+	url := s.URL()
+	if !strings.HasPrefix(url, "http://127.0.0.1:") || !strings.Contains(url, "/share/"+s.token+"/events") {
+		t.Errorf("URL() = %q, want a localhost URL containing the token", url)
+	}
+	if len(s.token) != 32 {
+		t.Errorf("token %q should be 32 hex characters", s.token)
+	}
+
+	other := startTestServer(t)
+	if other.token == s.token {
+		t.Error("each share should get its own token")
+	}
+}
+
+func TestServer_WatchReceivesSnapshotThenAppends(t *testing.T) {
+	s := startTestServer(t)
+	s.Publish([]Message{{Role: "user", Content: "fix the login bug"}})
+
+	events, _ := watchEvents(t, s.URL())
+	first := nextEvent(t, events)
+	if first.Type != EventSnapshot || first.SessionName != "fix-login" || len(first.Messages) != 1 {
+		t.Fatalf("first event = %+v, want snapshot of one message", first)
+	}
+	waitForWatchers(t, s, 1)
+
+	s.Publish([]Message{{Role: "user", Content: "fix the login bug"}, {Role: "assistant", Content: "Looking"}})
+	s.Publish([]Message{{Role: "user", Content: "fix the login bug"}, {Role: "assistant", Content: "Looking at it"}})
+
+	transcript := first.Messages
+	for range 2 {
+		e := nextEvent(t, events)
+		if e.Type != EventAppend || e.Version != ProtocolVersion {
+			t.Fatalf("event = %+v, want append", e)
+		}
+		transcript = Apply(transcript, e)
+	}
+	if len(transcript) != 2 || transcript[1].Content != "Looking at it" {
+		t.Errorf("watcher transcript = %+v", transcript)
+	}
+}
+
+func TestServer_CloseEndsWatch(t *testing.T) {
+	s := startTestServer(t)
+	events, done := watchEvents(t, s.URL())
+	nextEvent(t, events)
+	waitForWatchers(t, s, 1)
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if e := nextEvent(t, events); e.Type != EventEnd {
+		t.Errorf("event = %+v, want end", e)
+	}
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrShareEnded) {
+			t.Errorf("Watch returned %v, want ErrShareEnded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch did not return after Close")
+	}
+
+	// Publishing after close is a no-op, and the server no longer accepts watchers
+	s.Publish([]Message{{Role: "user", Content: "late"}})
+	if err := Watch(context.Background(), s.URL(), func(Event) {}); err == nil {
+		t.Error("expected an error watching a closed share")
+	}
+}
+
+func TestServer_IsReadOnly(t *testing.T) {
+	s := startTestServer(t)
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		req, err := http.NewRequest(method, s.URL(), strings.NewReader(`{"content":"rm -rf"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("%s returned %d, want 405", method, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_RejectsWrongToken(t *testing.T) {
+	s := startTestServer(t)
+	wrong := strings.Replace(s.URL(), s.token, strings.Repeat("0", 32), 1)
+	err := Watch(context.Background(), wrong, func(Event) {})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Watch with wrong token = %v, want 404 error", err)
+	}
+}
@@ -0,0 +1,112 @@
+// Package share streams a session's transcript read-only to watchers over HTTP.
+//
+// The host runs a Server per shared session and publishes the transcript to it
+// as it changes; watchers (plural watch) subscribe to a server-sent events stream
+// of versioned JSON events. The server only serves GET requests for the event
+// stream, so nothing a watcher sends can reach the host session.
+package share
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ProtocolVersion is the version of the event protocol. Watchers reject events
+// with a newer version than they understand.
+const ProtocolVersion = 1
+
+// Event types
+const (
+	// EventSnapshot replaces the watcher's transcript with Messages.
+	EventSnapshot = "snapshot"
+	// EventAppend appends Content to message Index, creating it with Role when
+	// Index is one past the last message.
+	EventAppend = "append"
+	// EventEnd reports that the host stopped sharing.
+	EventEnd = "end"
+)
+
+// Message is a transcript message.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Event is one server-sent event of the share protocol.
+type Event struct {
+	Version     int       `json:"version"`
+	Type        string    `json:"type"`
+	SessionName string    `json:"session_name,omitempty"` // Set on snapshots
+	Messages    []Message `json:"messages,omitempty"`     // EventSnapshot
+	Index       int       `json:"index,omitempty"`        // EventAppend
+	Role        string    `json:"role,omitempty"`         // EventAppend
+	Content     string    `json:"content,omitempty"`      // EventAppend
+}
+
+// Snapshot returns a snapshot event of a session's transcript.
+func Snapshot(sessionName string, messages []Message) Event {
+	return Event{Version: ProtocolVersion, Type: EventSnapshot, SessionName: sessionName, Messages: messages}
+}
+
+// Diff returns the events that turn transcript old into updated: appends when
+// updated extends old (the last message may have grown while streaming), a
+// snapshot otherwise.
+func Diff(sessionName string, old, updated []Message) []Event {
+	if len(old) == 0 || len(updated) < len(old) {
+		return []Event{Snapshot(sessionName, updated)}
+	}
+	last := len(old) - 1
+	if !slices.Equal(old[:last], updated[:last]) ||
+		old[last].Role != updated[last].Role ||
+		!strings.HasPrefix(updated[last].Content, old[last].Content) {
+		return []Event{Snapshot(sessionName, updated)}
+	}
+
+	var events []Event
+	if delta := updated[last].Content[len(old[last].Content):]; delta != "" {
+		events = append(events, Event{Version: ProtocolVersion, Type: EventAppend, Index: last, Content: delta})
+	}
+	for i := len(old); i < len(updated); i++ {
+		events = append(events, Event{Version: ProtocolVersion, Type: EventAppend, Index: i, Role: updated[i].Role, Content: updated[i].Content})
+	}
+	return events
+}
+
+// EncodeEvent formats an event as a server-sent event frame.
+func EncodeEvent(e Event) ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Appendf(nil, "event: %s\ndata: %s\n\n", e.Type, data), nil
+}
+
+// decodeEvent parses the data of a server-sent event frame.
+func decodeEvent(data []byte) (Event, error) {
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		return Event{}, fmt.Errorf("invalid share event: %w", err)
+	}
+	if e.Version > ProtocolVersion {
+		return Event{}, fmt.Errorf("share protocol version %d is newer than supported version %d; upgrade plural", e.Version, ProtocolVersion)
+	}
+	return e, nil
+}
+
+// Apply updates a transcript with an event and returns the result.
+func Apply(messages []Message, e Event) []Message {
+	switch e.Type {
+	case EventSnapshot:
+		return append([]Message(nil), e.Messages...)
+	case EventAppend:
+		switch {
+		case e.Index == len(messages):
+			return append(messages, Message{Role: e.Role, Content: e.Content})
+		case e.Index >= 0 && e.Index < len(messages):
+			messages[e.Index].Content += e.Content
+		}
+	}
+	return messages
+}
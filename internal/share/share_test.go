@@ -0,0 +1,100 @@
+package share
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeEvent(t *testing.T) {
+	frame, err := EncodeEvent(Event{Version: ProtocolVersion, Type: EventAppend, Index: 2, Content: "hi"})
+	if err != nil {
+		t.Fatalf("EncodeEvent: %v", err)
+	}
+	s := string(frame)
+	if !strings.HasPrefix(s, "event: append\ndata: ") || !strings.HasSuffix(s, "\n\n") {
+		t.Fatalf("unexpected frame %q", s)
+	}
+
+	data := strings.TrimSuffix(strings.TrimPrefix(s, "event: append\ndata: "), "\n\n")
+	e, err := decodeEvent([]byte(data))
+	if err != nil {
+		t.Fatalf("decodeEvent: %v", err)
+	}
+	if e.Type != EventAppend || e.Index != 2 || e.Content != "hi" {
+		t.Errorf("decoded %+v", e)
+	}
+}
+
+func TestDecodeEvent_RejectsNewerVersion(t *testing.T) {
+	_, err := decodeEvent([]byte(`{"version":99,"type":"snapshot"}`))
+	if err == nil || !strings.Contains(err.Error(), "upgrade plural") {
+		t.Errorf("expected version error, got %v", err)
+	}
+}
+
+func TestDecodeEvent_InvalidJSON(t *testing.T) {
+	if _, err := decodeEvent([]byte("{")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestApply(t *testing.T) {
+	var messages []Message
+	messages = Apply(messages, Event{Type: EventSnapshot, Messages: []Message{{Role: "user", Content: "hi"}}})
+	messages = Apply(messages, Event{Type: EventAppend, Index: 1, Role: "assistant", Content: "Hel"})
+	messages = Apply(messages, Event{Type: EventAppend, Index: 1, Content: "lo"})
+	messages = Apply(messages, Event{Type: EventAppend, Index: 7, Content: "ignored"})
+
+	want := []Message{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "Hello"}}
+	if len(messages) != len(want) {
+		t.Fatalf("got %d messages, want %d: %+v", len(messages), len(want), messages)
+	}
+	for i := range want {
+		if messages[i] != want[i] {
+			t.Errorf("message %d = %+v, want %+v", i, messages[i], want[i])
+		}
+	}
+}
+
+func TestDiffTranscript(t *testing.T) {
+	user := Message{Role: "user", Content: "hi"}
+
+	tests := []struct {
+		name      string
+		old, next []Message
+		wantTypes []string
+	}{
+		{"first publish", nil, []Message{user}, []string{EventSnapshot}},
+		{"streaming grows last message", []Message{user, {Role: "assistant", Content: "He"}}, []Message{user, {Role: "assistant", Content: "Hello"}}, []string{EventAppend}},
+		{"new message", []Message{user}, []Message{user, {Role: "assistant", Content: "Hi"}}, []string{EventAppend}},
+		{"unchanged", []Message{user}, []Message{user}, nil},
+		{"rewritten", []Message{user, {Role: "assistant", Content: "abc"}}, []Message{user, {Role: "assistant", Content: "xyz"}}, []string{EventSnapshot}},
+		{"shrunk", []Message{user, user}, []Message{user}, []string{EventSnapshot}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := Diff("s", tt.old, tt.next)
+			var types []string
+			for _, e := range events {
+				types = append(types, e.Type)
+			}
+			if strings.Join(types, ",") != strings.Join(tt.wantTypes, ",") {
+				t.Fatalf("event types = %v, want %v", types, tt.wantTypes)
+			}
+
+			// Applying the events to the old transcript must give the new one
+			got := Apply(append([]Message(nil), tt.old...), Event{Type: EventSnapshot, Messages: tt.old})
+			for _, e := range events {
+				got = Apply(got, e)
+			}
+			if len(got) != len(tt.next) {
+				t.Fatalf("applied transcript has %d messages, want %d", len(got), len(tt.next))
+			}
+			for i := range got {
+				if got[i] != tt.next[i] {
+					t.Errorf("message %d = %+v, want %+v", i, got[i], tt.next[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/zhubert/plural/internal/keys"
+	"github.com/zhubert/plural/internal/ui"
+)
+
+// eventMsg delivers a share event to the viewer.
+type eventMsg Event
+
+// watchDoneMsg reports that the share stream ended.
+type watchDoneMsg struct{ err error }
+
+// Viewer is a read-only view of a shared session, used by plural watch.
+// Keys only scroll or quit; nothing is sent to the host.
+type Viewer struct {
+	url         string
+	viewport    viewport.Model
+	messages    []Message
+	sessionName string
+	status      string // Connection status shown in the footer
+	width       int
+	height      int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	msgs   chan tea.Msg
+}
+
+// NewViewer creates a viewer for the share at url.
+func NewViewer(url string) *Viewer {
+	ctx, cancel := context.WithCancel(context.Background())
+	vp := viewport.New()
+	vp.MouseWheelEnabled = true
+	vp.MouseWheelDelta = 3
+	vp.SoftWrap = false
+	return &Viewer{
+		url:      url,
+		viewport: vp,
+		status:   "Connecting...",
+		ctx:      ctx,
+		cancel:   cancel,
+		msgs:     make(chan tea.Msg, subscriberBuffer),
+	}
+}
+
+func (v *Viewer) Init() tea.Cmd {
+	go func() {
+		err := Watch(v.ctx, v.url, func(e Event) {
+			v.msgs <- eventMsg(e)
+		})
+		v.msgs <- watchDoneMsg{err: err}
+	}()
+	return v.next()
+}
+
+// next returns a command that waits for the next message from the stream.
+func (v *Viewer) next() tea.Cmd {
+	return func() tea.Msg {
+		return <-v.msgs
+	}
+}
+
+func (v *Viewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.width, v.height = msg.Width, msg.Height
+		v.viewport.SetWidth(msg.Width)
+		v.viewport.SetHeight(max(msg.Height-2, 1)) // Header and footer lines
+		v.render()
+		return v, nil
+
+	case eventMsg:
+		e := Event(msg)
+		if e.SessionName != "" {
+			v.sessionName = e.SessionName
+		}
+		v.messages = Apply(v.messages, e)
+		if e.Type != EventEnd {
+			v.status = "Live (read-only)"
+		}
+		v.render()
+		return v, v.next()
+
+	case watchDoneMsg:
+		switch {
+		case errors.Is(msg.err, ErrShareEnded):
+			v.status = "The host stopped sharing. Press q to quit."
+		case msg.err != nil && !errors.Is(msg.err, context.Canceled):
+			v.status = msg.err.Error() + ". Press q to quit."
+		default:
+			v.status = "Disconnected. Press q to quit."
+		}
+		return v, nil
+
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "q", keys.CtrlC, keys.Escape:
+			v.cancel()
+			return v, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+// render re-renders the transcript, following the bottom if it was already there.
+func (v *Viewer) render() {
+	if v.width == 0 {
+		return
+	}
+	follow := v.viewport.AtBottom()
+	var sb strings.Builder
+	for _, m := range v.messages {
+		sb.WriteString(ui.RenderScrollbackMessage(m.Role, m.Content, v.width-ui.ContentPadding))
+		sb.WriteString("\n")
+	}
+	v.viewport.SetContent(sb.String())
+	if follow {
+		v.viewport.GotoBottom()
+	}
+}
+
+func (v *Viewer) View() tea.View {
+	var view tea.View
+	view.AltScreen = true
+	view.MouseMode = tea.MouseModeCellMotion
+	if v.width == 0 {
+		view.SetContent("Connecting...")
+		return view
+	}
+
+	name := v.sessionName
+	if name == "" {
+		name = "shared session"
+	}
+	header := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorPrimary).Render("Watching " + name)
+	footer := lipgloss.NewStyle().Foreground(ui.ColorTextMuted).Render(v.status + "  up/down/pgup/pgdn: scroll  q: quit")
+	view.SetContent(lipgloss.JoinVertical(lipgloss.Left, header, v.viewport.View(), footer))
+	return view
+}
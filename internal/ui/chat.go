@@ -2,8 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"charm.land/bubbles/v2/progress"
 	"charm.land/bubbles/v2/spinner"
@@ -11,10 +13,12 @@ import (
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	pclaude "github.com/zhubert/plural/internal/claude"
 	"github.com/zhubert/plural/internal/keys"
 	"github.com/zhubert/plural/internal/logger"
 	"github.com/zhubert/plural/internal/mcp"
+	"github.com/zhubert/plural/internal/paste"
 )
 
 // ToolUseInProgress is the empty circle marker for tool use in progress
@@ -30,6 +34,7 @@ type ToolUseItem struct {
 	ToolUseID  string                  // Unique ID for matching tool_use to tool_result
 	Complete   bool                    // Whether the tool has completed
 	ResultInfo *pclaude.ToolResultInfo // Rich details about the result (populated on completion)
+	StartedAt  time.Time               // When the tool use was received
 }
 
 // ToolUseRollup tracks consecutive tool uses for collapsible display
@@ -56,13 +61,39 @@ type Chat struct {
 	streaming   string // Current streaming response
 	sessionName string
 	hasSession  bool
-	waiting     bool // Waiting for Claude's response
+	loadingName string // Session whose history is loading while there is no session, or ""
+	waiting     bool   // Waiting for Claude's response
 
 	// Spinner and completion animation state
 	spinner *SpinnerState
 
 	// Message rendering cache - avoids re-rendering unchanged messages
-	messageCache []messageCache // Cache of rendered messages, indexed by message position
+	messageCache   []messageCache // Cache of rendered messages, indexed by message position
+	streamingCache messageCache   // Rendered streaming content, reused while only what follows it changes
+
+	// Session info shown above the conversation (nil when not set), and the lines its values take
+	sessionInfo       *SessionInfo
+	sessionInfoValues []sessionInfoValue
+
+	// Columns left of the content that center it, when its width is capped
+	contentMargin int
+
+	// Offset each session's conversation was left scrolled up to, by session
+	// ID; sessions left at the bottom have none
+	scrollOffsets map[string]int
+
+	// Code blocks copied by pressing the copy shortcut repeatedly
+	codeBlockCopy codeBlockCopy
+
+	// Columns the conversation is scrolled right, to read lines wider than
+	// the viewport, and the width of each of its lines to clamp that to the
+	// lines in view
+	hOffset    int
+	lineWidths []int
+
+	// Bookmarks - messages flagged for later review, by index, and the line each message starts on
+	bookmarks         map[int]bool
+	messageStartLines []int
 
 	// Track last tool use position for marking as complete
 	lastToolUsePos int // Position in streaming content where last tool use marker starts
@@ -70,6 +101,21 @@ type Chat struct {
 	// Tool use rollup - tracks consecutive tool uses for collapsible display
 	toolUseRollup *ToolUseRollup // Current rollup group (nil when no tool uses yet)
 
+	// Region below the conversation the expanded rollup scrolls in (see chat_tool_region.go)
+	toolUseViewport viewport.Model // Scrollable list of the rollup's tool uses
+	toolUseRegion   int            // Height of the region, 0 when the rollup is shown inline
+
+	// Compact tool-use rendering - collapses flushed tool-use bursts into one summary line
+	compactToolUses     bool                   // Whether completed messages show bursts as summaries
+	toolGroupsExpanded  bool                   // Whether compacted bursts are temporarily expanded
+	streamingToolGroups []pclaude.ToolUseGroup // Bursts flushed into the current streaming content
+
+	// Repeated errors - consecutive errors of the same class collapse into one line with a counter
+	errorRun       *errorRun // Errors at the end of the streaming response (nil when the last thing appended was not an error)
+	errorsExpanded bool      // Whether the current run shows every error
+	// Paste sanitizing - normalizes line endings and trims trailing whitespace on paste
+	sanitizePaste bool
+
 	// Pending prompts (nil when not active)
 	permission   *PendingPermission   // Permission prompt state
 	question     *PendingQuestion     // Question prompt state
@@ -77,10 +123,17 @@ type Chat struct {
 
 	// View changes mode - temporary overlay showing git diff (nil when not active)
 	viewChanges *ViewChangesState
+	splitDiff   bool // Whether view changes mode last showed diffs side by side
 
 	// Log viewer mode - temporary overlay showing log files (nil when not active)
 	logViewer *LogViewerState
 
+	// Live diff mode - the session's worktree diff shown instead of the conversation (nil when not active)
+	liveDiff *LiveDiffState
+
+	// Activity feed mode - temporary overlay showing events across sessions (nil when not active)
+	activityFeed *ActivityFeedState
+
 	// Pending image attachment (nil when no image attached)
 	pendingImage *PendingImage
 
@@ -91,6 +144,9 @@ type Chat struct {
 	currentTodoList *pclaude.TodoList
 	todoWidth       int            // Width of todo sidebar when visible (0 when hidden)
 	todoViewport    viewport.Model // Viewport for scrollable todo list
+	todoHovered     bool           // The mouse wheel last scrolled the todo list, so Home/End jump in it
+	todoFocus       bool           // Whether keys move among and mark todo items (see EnterTodoFocusMode)
+	todoCursor      int            // Highlighted todo item in todo focus mode
 
 	// Text selection state
 	selection *TextSelection
@@ -136,14 +192,20 @@ func NewChat() *Chat {
 	todoVp.MouseWheelDelta = 3
 	todoVp.SoftWrap = false
 
+	// Create viewport for the expanded tool-use rollup
+	toolUseVp := viewport.New()
+	toolUseVp.SoftWrap = false
+
 	c := &Chat{
-		viewport:       vp,
-		todoViewport:   todoVp,
-		input:          ti,
-		messages:       []pclaude.Message{},
-		lastToolUsePos: -1,
-		spinner:        NewSpinnerState(),
-		selection:      NewTextSelection(),
+		viewport:        vp,
+		todoViewport:    todoVp,
+		toolUseViewport: toolUseVp,
+		input:           ti,
+		messages:        []pclaude.Message{},
+		lastToolUsePos:  -1,
+		spinner:         NewSpinnerState(),
+		selection:       NewTextSelection(),
+		scrollOffsets:   make(map[string]int),
 	}
 	c.updateContent()
 	return c
@@ -190,8 +252,12 @@ func (c *Chat) SetSize(width, height int) {
 	c.width = width
 	c.height = height
 
-	// Get dynamic input height (accounts for image indicator when attached)
+	// Get dynamic input height (accounts for image indicator when attached),
+	// and that of the expanded tool-use region above it
 	inputTotalHeight := c.getInputTotalHeight()
+	c.toolUseRegion = c.toolUseRegionHeight()
+	c.toolUseViewport.SetWidth(max(width-ContentPadding, 1))
+	c.toolUseViewport.SetHeight(max(c.toolUseRegion-1, 0))
 
 	// Calculate todo sidebar width if we have a todo list
 	var mainPanelWidth int
@@ -200,8 +266,8 @@ func (c *Chat) SetSize(width, height int) {
 		c.todoWidth = max(width/TodoSidebarWidthRatio, TodoListMinWrapWidth+BorderSize)
 		mainPanelWidth = width - c.todoWidth
 
-		// Chat panel height (excluding input area which is separate)
-		chatPanelHeight := height - inputTotalHeight
+		// Chat panel height (excluding input area and tool-use region which are separate)
+		chatPanelHeight := height - inputTotalHeight - c.toolUseRegion
 		// Set todo viewport dimensions (accounting for border)
 		todoInnerWidth := c.todoWidth - BorderSize
 		todoInnerHeight := ctx.InnerHeight(chatPanelHeight)
@@ -229,8 +295,8 @@ func (c *Chat) SetSize(width, height int) {
 		c.messageCache = nil // Clear cache to force re-render at new width
 	}
 
-	// Chat panel height (excluding input area which is separate)
-	chatPanelHeight := height - inputTotalHeight
+	// Chat panel height (excluding input area and tool-use region which are separate)
+	chatPanelHeight := height - inputTotalHeight - c.toolUseRegion
 
 	// Calculate inner dimensions for the chat panel (accounting for borders)
 	innerWidth := newInnerWidth
@@ -256,11 +322,86 @@ func (c *Chat) SetSize(width, height int) {
 		"todoWidth", c.todoWidth,
 		"chatPanelHeight", chatPanelHeight,
 		"inputTotalHeight", inputTotalHeight,
+		"toolUseRegion", c.toolUseRegion,
 		"viewportWidth", c.viewport.Width(),
 		"viewportHeight", c.viewport.Height(),
 	)
 }
 
+// ScrollPosition returns how far the conversation is scrolled, from 0 (top) to 1 (bottom)
+func (c *Chat) ScrollPosition() float64 {
+	return c.viewport.ScrollPercent()
+}
+
+// SetScrollPosition scrolls the conversation to a position from ScrollPosition,
+// e.g. to keep the reader's place across a resize that re-wraps it.
+func (c *Chat) SetScrollPosition(pos float64) {
+	maxOffset := max(c.viewport.TotalLineCount()-c.viewport.Height(), 0)
+	c.viewport.SetYOffset(int(math.Round(pos * float64(maxOffset))))
+}
+
+// SaveScroll remembers where the conversation shown, that of the given
+// session, is scrolled to, for RestoreScroll when the session is shown again.
+func (c *Chat) SaveScroll(sessionID string) {
+	if c.viewport.AtBottom() {
+		delete(c.scrollOffsets, sessionID)
+		return
+	}
+	c.scrollOffsets[sessionID] = c.viewport.YOffset()
+}
+
+// RestoreScroll scrolls the conversation shown, that of the given session,
+// back to where SaveScroll last left it, as far as it now reaches. Sessions
+// left at the bottom stay there, following new content.
+func (c *Chat) RestoreScroll(sessionID string) {
+	if offset, ok := c.scrollOffsets[sessionID]; ok {
+		c.viewport.SetYOffset(offset)
+	}
+}
+
+// ForgetScroll drops where a deleted session's conversation was scrolled to.
+func (c *Chat) ForgetScroll(sessionID string) {
+	delete(c.scrollOffsets, sessionID)
+}
+
+// jumpToEdge scrolls the conversation to its top or bottom, or the todo list
+// instead if the mouse wheel last scrolled it.
+func (c *Chat) jumpToEdge(top bool) {
+	vp := &c.viewport
+	if c.todoHovered && c.HasTodoList() && c.todoWidth > 0 {
+		vp = &c.todoViewport
+	}
+	if top {
+		vp.GotoTop()
+	} else {
+		vp.GotoBottom()
+	}
+}
+
+// scrollHorizontally shifts the conversation a step left or right, as far as
+// the widest line in view allows.
+func (c *Chat) scrollHorizontally(right bool) {
+	if right {
+		c.hOffset += HorizontalScrollStep
+	} else {
+		c.hOffset -= HorizontalScrollStep
+	}
+	c.syncHOffset()
+}
+
+// syncHOffset clamps hOffset to the lines in view, which change as the chat
+// scrolls, and applies it to the viewport.
+func (c *Chat) syncHOffset() {
+	start := min(c.viewport.YOffset(), len(c.lineWidths))
+	end := min(start+c.viewport.Height(), len(c.lineWidths))
+	widest := 0
+	for _, w := range c.lineWidths[start:end] {
+		widest = max(widest, w)
+	}
+	c.hOffset = max(min(c.hOffset, widest-c.viewport.Width()), 0)
+	c.viewport.SetXOffset(c.hOffset)
+}
+
 // SetFocused sets the focus state
 func (c *Chat) SetFocused(focused bool) {
 	c.focused = focused
@@ -280,6 +421,7 @@ func (c *Chat) IsFocused() bool {
 func (c *Chat) RefreshStyles() {
 	applyTextareaStyles(&c.input)
 	c.messageCache = nil // Clear cache so messages re-render with new theme
+	c.streamingCache = messageCache{}
 	c.updateContent()
 }
 
@@ -288,9 +430,15 @@ func (c *Chat) SetSession(name string, messages []pclaude.Message) {
 	c.sessionName = name
 	c.messages = messages
 	c.hasSession = true
+	c.loadingName = ""
 	c.streaming = ""
+	c.errorRun = nil
 	c.toolUseRollup = nil // Clear rollup from any previous session
-	c.messageCache = nil  // Clear cache on session change
+	c.streamingToolGroups = nil
+	c.messageCache = nil // Clear cache on session change
+	c.bookmarks = nil
+	c.codeBlockCopy = codeBlockCopy{}
+	c.sessionInfo = nil // Set by SetSessionInfo for the new session
 	c.updateContent()
 }
 
@@ -300,23 +448,49 @@ func (c *Chat) ClearSession() {
 	c.messages = nil
 	c.hasSession = false
 	c.streaming = ""
+	c.errorRun = nil
 	c.lastToolUsePos = -1
 	c.toolUseRollup = nil // Clear tool use rollup
-	c.messageCache = nil  // Clear cache on session clear
+	c.streamingToolGroups = nil
+	c.messageCache = nil // Clear cache on session clear
+	c.bookmarks = nil
 	c.permission = nil
 	c.question = nil
 	c.waiting = false
 	c.spinner.FlashFrame = -1
 	c.queuedMessage = ""
 	c.currentTodoList = nil
+	c.liveDiff = nil
+	c.loadingName = ""
+	c.sessionInfo = nil
 	c.updateContent()
 }
 
+// SetLoadingSession clears the current session and shows that the named session's
+// history is loading. The next SetSession replaces it.
+func (c *Chat) SetLoadingSession(name string) {
+	c.ClearSession()
+	c.loadingName = name
+	c.updateContent()
+}
+
+// IsLoadingSession returns whether a session's history is shown as loading
+func (c *Chat) IsLoadingSession() bool {
+	return c.loadingName != ""
+}
+
 // AppendStreaming appends content to the current streaming response
 func (c *Chat) AppendStreaming(content string) {
+	// Error lines go through the error path so that repeats collapse
+	if match := errorLinePattern.FindStringSubmatch(content); match != nil {
+		c.AppendError(match[1], match[1])
+		return
+	}
+
 	// When text content arrives, flush any pending tool uses to streaming first
 	// (flushToolUseRollup adds a trailing newline for visual separation)
 	c.flushToolUseRollup()
+	c.commitErrorRun()
 
 	c.streaming += content
 	c.updateContent()
@@ -330,6 +504,7 @@ func (c *Chat) AppendPermissionDenials(denials []pclaude.PermissionDenial) {
 
 	// Flush any pending tool uses first
 	c.flushToolUseRollup()
+	c.commitErrorRun()
 
 	// Format denials as a summary block
 	var sb strings.Builder
@@ -369,6 +544,7 @@ func (c *Chat) AppendToolUse(toolName, toolInput, toolUseID string) {
 		ToolInput: toolInput,
 		ToolUseID: toolUseID,
 		Complete:  false,
+		StartedAt: time.Now(),
 	})
 
 	c.updateContent()
@@ -412,6 +588,9 @@ func (c *Chat) flushToolUseRollup() {
 		return
 	}
 
+	// Errors before the tool uses stay before them
+	c.commitErrorRun()
+
 	// Add blank line before tool uses for visual separation from preceding text
 	// This creates a paragraph break between text content and tool use indicators
 	if c.streaming != "" {
@@ -419,11 +598,16 @@ func (c *Chat) flushToolUseRollup() {
 		c.streaming = strings.TrimRight(c.streaming, "\n") + "\n\n"
 	}
 
-	// Render all tool uses in the rollup to streaming content
+	// Render all tool uses in the rollup to streaming content, remembering where
+	// the burst lands so compact rendering can summarize it without re-parsing
+	group := pclaude.ToolUseGroup{Start: len(c.streaming)}
 	for _, item := range c.toolUseRollup.Items {
 		line := formatToolUseLine(item)
 		c.streaming += line + "\n"
+		group.Tools = append(group.Tools, toolUseSummary(item))
 	}
+	group.End = len(c.streaming) - 1
+	c.streamingToolGroups = append(c.streamingToolGroups, group)
 
 	// Add extra newline after tool uses for visual separation from following text
 	// This is called from AppendStreaming, so there will be text content after
@@ -461,15 +645,30 @@ func formatToolUseLine(item ToolUseItem) string {
 
 // FinishStreaming completes the streaming and adds to messages
 func (c *Chat) FinishStreaming() {
-	// Flush any remaining tool uses before finishing
+	c.finishStreamingAs("assistant", "")
+}
+
+// FinishSystemOutput completes streamed output that plural produced rather
+// than Claude, such as a merge's, adding it as a system message of the given kind.
+func (c *Chat) FinishSystemOutput(kind string) {
+	c.finishStreamingAs(pclaude.RoleSystem, kind)
+}
+
+// finishStreamingAs completes the streaming, adding it to messages with role and kind.
+func (c *Chat) finishStreamingAs(role, kind string) {
+	// Flush any remaining tool uses and errors before finishing
 	c.flushToolUseRollup()
+	c.commitErrorRun()
 
 	if c.streaming != "" {
 		c.messages = append(c.messages, pclaude.Message{
-			Role:    "assistant",
-			Content: c.streaming,
+			Role:          role,
+			Kind:          kind,
+			Content:       c.streaming,
+			ToolUseGroups: c.streamingToolGroups,
 		})
 		c.streaming = ""
+		c.streamingToolGroups = nil
 		c.lastToolUsePos = -1 // Reset tool tracking to prevent stale state affecting future streaming
 		c.toolUseRollup = nil // Ensure rollup is cleared
 		// Preserve final stats for display after streaming ends
@@ -493,6 +692,55 @@ func (c *Chat) HasActiveToolUseRollup() bool {
 	return c.toolUseRollup != nil && len(c.toolUseRollup.Items) > 1
 }
 
+// SetCompactToolUses enables or disables summarizing tool-use bursts in completed messages
+func (c *Chat) SetCompactToolUses(enabled bool) {
+	if c.compactToolUses == enabled {
+		return
+	}
+	c.compactToolUses = enabled
+	c.toolGroupsExpanded = false
+	c.updateContent()
+}
+
+// SetSanitizePaste enables or disables sanitizing pasted text
+func (c *Chat) SetSanitizePaste(enabled bool) {
+	c.sanitizePaste = enabled
+}
+
+// SanitizePaste returns text as it will be inserted when pasted at the cursor: with
+// line endings normalized to LF and trailing whitespace trimmed, except inside fenced
+// code blocks (including one left open above the cursor). Returns text unchanged
+// when sanitizing is disabled.
+func (c *Chat) SanitizePaste(text string) string {
+	if !c.sanitizePaste {
+		return text
+	}
+	above := strings.Split(c.input.Value(), "\n")[:c.input.Line()]
+	return paste.Sanitize(text, paste.EndsInFence(strings.Join(above, "\n")))
+}
+
+// ToggleToolGroupsExpanded toggles between compacted and full tool-use bursts, and
+// collapsed and full hook output, in completed messages
+func (c *Chat) ToggleToolGroupsExpanded() {
+	c.toolGroupsExpanded = !c.toolGroupsExpanded
+	c.updateContent()
+}
+
+// HasCompactToolGroups returns true if compact mode is on and any message has a burst to summarize
+func (c *Chat) HasCompactToolGroups() bool {
+	if !c.compactToolUses {
+		return false
+	}
+	for _, msg := range c.messages {
+		for _, group := range msg.ToolUseGroups {
+			if len(group.Tools) > 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetToolUseRollup returns the current tool use rollup (for rendering)
 func (c *Chat) GetToolUseRollup() *ToolUseRollup {
 	return c.toolUseRollup
@@ -507,10 +755,12 @@ func (c *Chat) AddUserMessage(content string) {
 	c.updateContent()
 }
 
-// AddSystemMessage adds a system/assistant message (for local command responses)
-func (c *Chat) AddSystemMessage(content string) {
+// AddSystemMessage adds a system message of the given kind, such as a local
+// command's response
+func (c *Chat) AddSystemMessage(kind, content string) {
 	c.messages = append(c.messages, pclaude.Message{
-		Role:    "assistant",
+		Role:    pclaude.RoleSystem,
+		Kind:    kind,
 		Content: content,
 	})
 	c.updateContent()
@@ -533,6 +783,63 @@ func (c *Chat) SetInput(value string) {
 	c.input.SetValue(value)
 }
 
+// InsertInput inserts text at the cursor, keeping the rest of the input.
+func (c *Chat) InsertInput(text string) {
+	c.input.InsertString(text)
+}
+
+// TrimInputTrigger removes trigger from the input if it is what was typed just
+// before the cursor. Returns whether it was removed.
+func (c *Chat) TrimInputTrigger(trigger string) bool {
+	lines := strings.Split(c.input.Value(), "\n")
+	if c.input.Line() >= len(lines) {
+		return false
+	}
+	line := []rune(lines[c.input.Line()])
+	col := min(c.input.Column(), len(line))
+	if !strings.HasSuffix(string(line[:col]), trigger) {
+		return false
+	}
+	for range []rune(trigger) {
+		c.input, _ = c.input.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	}
+	return true
+}
+
+// InsertPaste inserts text at the cursor as if it were pasted and returns the
+// text as stored in the input, which may differ after the textarea sanitizes it
+// (e.g., tabs expanded to spaces).
+func (c *Chat) InsertPaste(text string) (string, tea.Cmd) {
+	before := c.input.Value()
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(tea.PasteMsg{Content: c.SanitizePaste(text)})
+	after := c.input.Value()
+
+	// The inserted text is whatever lies between the unchanged prefix and suffix
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	return after[prefix : len(after)-suffix], cmd
+}
+
+// ReplaceInInput replaces the last occurrence of old in the input with replacement.
+// Returns false if old is no longer present (e.g., the user edited or sent it).
+func (c *Chat) ReplaceInInput(old, replacement string) bool {
+	value := c.input.Value()
+	idx := strings.LastIndex(value, old)
+	if old == "" || idx < 0 {
+		return false
+	}
+	c.input.SetValue(value[:idx] + replacement + value[idx+len(old):])
+	return true
+}
+
 // SetQueuedMessage sets a message that is queued to be sent after streaming completes
 func (c *Chat) SetQueuedMessage(msg string) {
 	c.queuedMessage = msg
@@ -548,12 +855,12 @@ func (c *Chat) ClearQueuedMessage() {
 // IsStreaming returns whether we're currently streaming a response
 // This includes both text streaming and tool use operations
 func (c *Chat) IsStreaming() bool {
-	return c.streaming != "" || c.toolUseRollup != nil
+	return c.streaming != "" || c.toolUseRollup != nil || c.errorRun != nil
 }
 
-// GetStreaming returns the current streaming content
+// GetStreaming returns the current streaming content, with any repeated errors collapsed
 func (c *Chat) GetStreaming() string {
-	return c.streaming
+	return c.streaming + c.errorRunContent(false)
 }
 
 // GetMessages returns the conversation messages
@@ -564,6 +871,8 @@ func (c *Chat) GetMessages() []pclaude.Message {
 // SetStreaming sets the streaming content (used when restoring session state)
 func (c *Chat) SetStreaming(content string) {
 	c.streaming = content
+	c.errorRun = nil
+	c.streamingToolGroups = nil // Restored content has no recorded tool-use bursts
 	c.updateContent()
 }
 
@@ -576,6 +885,56 @@ func (c *Chat) SetPendingPermission(tool, description string) {
 	c.updateContent()
 }
 
+// SetPendingDestructivePermission sets a permission prompt for a Bash command
+// with destructive parts, which the user allows by typing
+// DestructiveConfirmPhrase rather than with a single key.
+func (c *Chat) SetPendingDestructivePermission(tool, description, command string, matches []pclaude.DestructiveMatch) {
+	c.permission = &PendingPermission{
+		Tool:        tool,
+		Description: description,
+		Command:     command,
+		Destructive: matches,
+	}
+	c.updateContent()
+}
+
+// HasDestructivePermission returns whether the pending permission prompt is for
+// a destructive command.
+func (c *Chat) HasDestructivePermission() bool {
+	return c.permission != nil && len(c.permission.Destructive) > 0
+}
+
+// TypePermissionConfirmation adds a typed key to the confirmation of a
+// destructive permission prompt, or removes the last character on backspace.
+// Other keys are ignored.
+func (c *Chat) TypePermissionConfirmation(key string) {
+	if !c.HasDestructivePermission() {
+		return
+	}
+	typed := c.permission.Confirmation
+	switch {
+	case key == keys.Backspace:
+		if typed != "" {
+			_, size := utf8.DecodeLastRuneInString(typed)
+			typed = typed[:len(typed)-size]
+		}
+	case key == keys.Space:
+		typed += " "
+	case utf8.RuneCountInString(key) == 1 && len(typed) < maxConfirmationLen:
+		typed += key
+	default:
+		return
+	}
+	c.permission.Confirmation = typed
+	c.updateContent()
+}
+
+// PermissionConfirmed returns whether DestructiveConfirmPhrase has been typed
+// into a destructive permission prompt.
+func (c *Chat) PermissionConfirmed() bool {
+	return c.HasDestructivePermission() && strings.EqualFold(strings.TrimSpace(c.permission.Confirmation), DestructiveConfirmPhrase)
+}
+
 // ClearPendingPermission clears the pending permission prompt
 func (c *Chat) ClearPendingPermission() {
 	c.permission = nil
@@ -593,6 +952,17 @@ func (c *Chat) SetPendingQuestion(questions []mcp.Question) {
 	c.updateContent()
 }
 
+// PreselectQuestionAnswers highlights the given answers (question text -> option
+// label) when their questions are shown, so they can be confirmed with Enter.
+func (c *Chat) PreselectQuestionAnswers(answers map[string]string) {
+	if c.question == nil {
+		return
+	}
+	c.question.Preselected = answers
+	c.question.SelectedOption = c.question.defaultOption()
+	c.updateContent()
+}
+
 // ClearPendingQuestion clears the pending question prompt
 func (c *Chat) ClearPendingQuestion() {
 	c.question = nil
@@ -678,7 +1048,7 @@ func (c *Chat) SelectCurrentOption() bool {
 
 	// Move to next question or complete
 	c.question.CurrentIdx++
-	c.question.SelectedOption = 0
+	c.question.SelectedOption = c.question.defaultOption()
 
 	if c.question.CurrentIdx >= len(c.question.Questions) {
 		// All questions answered
@@ -791,6 +1161,12 @@ func (c *Chat) SetTodoList(list *pclaude.TodoList) {
 		c.currentTodoList = nil
 	}
 
+	if c.HasTodoList() {
+		c.todoCursor = min(c.todoCursor, len(c.currentTodoList.Items)-1)
+	} else {
+		c.todoFocus = false
+	}
+
 	// If todo list visibility changed, recalculate layout
 	hasTodoList := c.HasTodoList()
 	if hadTodoList != hasTodoList && c.width > 0 && c.height > 0 {
@@ -807,6 +1183,8 @@ func (c *Chat) SetTodoList(list *pclaude.TodoList) {
 func (c *Chat) ClearTodoList() {
 	hadTodoList := c.HasTodoList()
 	c.currentTodoList = nil
+	c.todoFocus = false
+	c.todoHovered = false
 
 	// If we had a todo list, recalculate layout to reclaim the sidebar space
 	if hadTodoList && c.width > 0 && c.height > 0 {
@@ -840,10 +1218,24 @@ func (c *Chat) updateTodoViewportContent() {
 	// Get inner width for content wrapping
 	width := max(c.todoViewport.Width(), TodoListMinWrapWidth)
 
+	cursor := -1
+	if c.todoFocus {
+		cursor = c.todoCursor
+	}
+
 	// Use renderTodoListForSidebar which renders without the box border
 	// since the sidebar panel already has borders
-	content := renderTodoListForSidebar(c.currentTodoList, width)
+	content, cursorLine := renderTodoListForSidebar(c.currentTodoList, width, cursor)
 	c.todoViewport.SetContent(content)
+
+	// Keep the highlighted item in view
+	if c.todoFocus {
+		if cursorLine < c.todoViewport.YOffset() {
+			c.todoViewport.SetYOffset(cursorLine)
+		} else if last := c.todoViewport.YOffset() + c.todoViewport.Height() - 1; cursorLine > last {
+			c.todoViewport.SetYOffset(c.todoViewport.YOffset() + cursorLine - last)
+		}
+	}
 }
 
 // GetToolIcon returns an appropriate icon for the tool type
@@ -887,22 +1279,24 @@ func (c *Chat) renderToolUseRollup() string {
 	line := formatToolUseLine(lastItem)
 
 	// Apply styling to tool use markers in the line
-	line = strings.ReplaceAll(line, ToolUseInProgress, ToolUseInProgressStyle.Render(ToolUseInProgress))
-	line = strings.ReplaceAll(line, ToolUseComplete, ToolUseCompleteStyle.Render(ToolUseComplete))
+	line = styleToolUseMarkers(line)
 
 	sb.WriteString(line)
 	sb.WriteString("\n")
 
 	// If there are multiple items and not expanded, show the rollup summary
 	if len(c.toolUseRollup.Items) > 1 {
-		if c.toolUseRollup.Expanded {
+		if c.toolUseRegion > 0 {
+			// Expanded into the region below the conversation
+			sb.WriteString(c.renderToolUseRegionNote())
+			sb.WriteString("\n")
+		} else if c.toolUseRollup.Expanded {
 			// Show all previous tool uses (oldest first, excluding the last one already shown)
 			for i := 0; i < len(c.toolUseRollup.Items)-1; i++ {
 				item := c.toolUseRollup.Items[i]
 				itemLine := "  " + formatToolUseLine(item)
 				// Apply styling
-				itemLine = strings.ReplaceAll(itemLine, ToolUseInProgress, ToolUseInProgressStyle.Render(ToolUseInProgress))
-				itemLine = strings.ReplaceAll(itemLine, ToolUseComplete, ToolUseCompleteStyle.Render(ToolUseComplete))
+				itemLine = styleToolUseMarkers(itemLine)
 				sb.WriteString(itemLine)
 				sb.WriteString("\n")
 			}
@@ -926,9 +1320,41 @@ func (c *Chat) renderToolUseRollup() string {
 		}
 	}
 
+	if hint := c.waitingBashHint(); hint != "" {
+		sb.WriteString(hint)
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
 
+// bashInputWaitThreshold is how long a Bash command runs without a result
+// before the chat suggests it may be waiting for input.
+const bashInputWaitThreshold = 30 * time.Second
+
+// waitingBashHint returns a hint under the tool uses when a Bash command has
+// run for bashInputWaitThreshold without a result. Claude runs commands with
+// nothing to answer a prompt, so one asking "continue? [y/N]" hangs until the
+// tool times out; only Claude's process can reach the command, so the way out
+// is to interrupt and have it run the command non-interactively.
+func (c *Chat) waitingBashHint() string {
+	for _, item := range c.toolUseRollup.Items {
+		if item.ToolName != "Bash" || item.Complete || item.StartedAt.IsZero() {
+			continue
+		}
+		elapsed := time.Since(item.StartedAt)
+		if elapsed < bashInputWaitThreshold {
+			continue
+		}
+		hintStyle := lipgloss.NewStyle().
+			Foreground(ColorWarning).
+			Italic(true)
+		return hintStyle.Render(fmt.Sprintf("  No result from Bash for %s; if it's waiting for input, Esc interrupts it so Claude can run it non-interactively",
+			elapsed.Truncate(time.Second)))
+	}
+	return ""
+}
+
 // renderQuestionPrompt renders the inline question prompt
 func (c *Chat) renderQuestionPrompt(wrapWidth int) string {
 	if c.question == nil || c.question.CurrentIdx >= len(c.question.Questions) {
@@ -946,7 +1372,7 @@ func (c *Chat) renderQuestionPrompt(wrapWidth int) string {
 	}
 
 	// Header/label
-	headerStyle := lipgloss.NewStyle().Foreground(ColorInfo).Bold(true)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorInfoText).Bold(true)
 	sb.WriteString(headerStyle.Render("? " + q.Header + ":"))
 	sb.WriteString(" ")
 
@@ -957,13 +1383,14 @@ func (c *Chat) renderQuestionPrompt(wrapWidth int) string {
 
 	// Calculate box width (capped at max width for readability)
 	boxWidth := min(wrapWidth, OverlayBoxMaxWidth)
+	preselected := c.question.preselectedOption()
 
 	// Render options
 	for i, opt := range q.Options {
 		isSelected := i == c.question.SelectedOption
 
 		// Number indicator
-		numStyle := lipgloss.NewStyle().Foreground(ColorInfo).Bold(true)
+		numStyle := lipgloss.NewStyle().Foreground(ColorInfoText).Bold(true)
 		if isSelected {
 			sb.WriteString(numStyle.Render(fmt.Sprintf("[%d]", i+1)))
 		} else {
@@ -974,9 +1401,12 @@ func (c *Chat) renderQuestionPrompt(wrapWidth int) string {
 		// Option label
 		labelStyle := lipgloss.NewStyle().Foreground(ColorText)
 		if isSelected {
-			labelStyle = labelStyle.Bold(true).Background(ColorPrimary).Foreground(ColorTextInverse)
+			labelStyle = labelStyle.Bold(true).Background(ColorOverlaySelectedBg).Foreground(ColorOverlaySelectedFg)
 		}
 		sb.WriteString(labelStyle.Render(opt.Label))
+		if i == preselected {
+			sb.WriteString(lipgloss.NewStyle().Foreground(ColorTextMuted).Italic(true).Render(" (auto-answer rule)"))
+		}
 
 		// Description if present
 		if opt.Description != "" {
@@ -1033,7 +1463,7 @@ func (c *Chat) renderQuestionPrompt(wrapWidth int) string {
 	// "Other" option (always last)
 	otherIdx := len(q.Options)
 	isOtherSelected := c.question.SelectedOption == otherIdx
-	numStyle := lipgloss.NewStyle().Foreground(ColorInfo).Bold(true)
+	numStyle := lipgloss.NewStyle().Foreground(ColorInfoText).Bold(true)
 	if isOtherSelected {
 		sb.WriteString(numStyle.Render(fmt.Sprintf("[%d]", otherIdx+1)))
 	} else {
@@ -1042,14 +1472,14 @@ func (c *Chat) renderQuestionPrompt(wrapWidth int) string {
 	sb.WriteString(" ")
 	labelStyle := lipgloss.NewStyle().Foreground(ColorText)
 	if isOtherSelected {
-		labelStyle = labelStyle.Bold(true).Background(ColorPrimary).Foreground(ColorTextInverse)
+		labelStyle = labelStyle.Bold(true).Background(ColorOverlaySelectedBg).Foreground(ColorOverlaySelectedFg)
 	}
 	sb.WriteString(labelStyle.Render("Other"))
 	sb.WriteString("\n\n")
 
 	// Keyboard hints
 	hintStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
-	keyStyle := lipgloss.NewStyle().Foreground(ColorInfo).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(ColorInfoText).Bold(true)
 	sb.WriteString(hintStyle.Render("Press "))
 	sb.WriteString(keyStyle.Render("1-" + fmt.Sprintf("%d", len(q.Options)+1)))
 	sb.WriteString(hintStyle.Render(" to select, or "))
@@ -1073,7 +1503,7 @@ func (c *Chat) renderPlanApprovalPrompt(wrapWidth int) string {
 	boxWidth := min(wrapWidth, PlanBoxMaxWidth)
 
 	// Title
-	titleStyle := lipgloss.NewStyle().Foreground(ColorInfo).Bold(true)
+	titleStyle := lipgloss.NewStyle().Foreground(ColorInfoText).Bold(true)
 	sb.WriteString(titleStyle.Render("Plan Approval Required"))
 	sb.WriteString("\n\n")
 
@@ -1111,7 +1541,7 @@ func (c *Chat) renderPlanApprovalPrompt(wrapWidth int) string {
 	// Show allowed prompts if any
 	if len(c.planApproval.AllowedPrompts) > 0 {
 		sb.WriteString("\n")
-		promptsHeader := lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
+		promptsHeader := lipgloss.NewStyle().Foreground(ColorWarningText).Bold(true)
 		sb.WriteString(promptsHeader.Render("Requested permissions:"))
 		sb.WriteString("\n")
 
@@ -1125,7 +1555,7 @@ func (c *Chat) renderPlanApprovalPrompt(wrapWidth int) string {
 	sb.WriteString("\n")
 
 	// Keyboard hints
-	keyStyle := lipgloss.NewStyle().Foreground(ColorInfo).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(ColorInfoText).Bold(true)
 	hintStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
 
 	sb.WriteString(keyStyle.Render("[y]"))
@@ -1147,17 +1577,38 @@ func (c *Chat) updateContent() {
 	if c.viewport.Width() <= 0 {
 		return
 	}
+	c.syncToolUseRegion()
 
 	var sb strings.Builder
-
-	// Get wrap width (use viewport width, fallback to reasonable default)
-	// Subtract ContentPadding for the horizontal padding applied via Padding(0, 1)
-	wrapWidth := c.viewport.Width() - ContentPadding
+	c.messageStartLines = c.messageStartLines[:0]
+	c.sessionInfoValues = nil
+
+	// Get wrap width (use viewport width, capped on wide terminals, fallback to
+	// reasonable default). Subtract ContentPadding for the horizontal padding
+	// applied below
+	contentWidth, margin := GetViewContext().ChatContentWidth(c.viewport.Width())
+	c.contentMargin = margin
+	wrapWidth := contentWidth - ContentPadding
 	if wrapWidth < MinWrapWidth {
 		wrapWidth = DefaultWrapWidth
 	}
 
-	if !c.hasSession {
+	line := 0 // Line the next message starts on, for jumping to bookmarks
+	if c.hasSession && c.sessionInfo != nil {
+		// Sized to the viewport rather than the wrap width, which may exceed it when narrow
+		info, values := renderSessionInfo(c.sessionInfo, contentWidth-ContentPadding)
+		c.sessionInfoValues = values
+		sb.WriteString(info)
+		sb.WriteString("\n\n")
+		line = strings.Count(info, "\n") + 2
+	}
+
+	if !c.hasSession && c.loadingName != "" {
+		sb.WriteString(lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			Italic(true).
+			Render("Loading " + c.loadingName + "..."))
+	} else if !c.hasSession {
 		sb.WriteString(renderNoSessionMessage())
 	} else if len(c.messages) == 0 && c.streaming == "" {
 		sb.WriteString(lipgloss.NewStyle().
@@ -1174,24 +1625,38 @@ func (c *Chat) updateContent() {
 		for i, msg := range c.messages {
 			if i > 0 {
 				sb.WriteString("\n\n")
+				line += 2
 			}
+			c.messageStartLines = append(c.messageStartLines, line)
 
-			var roleStyle lipgloss.Style
-			var roleName string
-			if msg.Role == "user" {
-				roleStyle = ChatUserStyle
-				roleName = "You"
-			} else {
-				roleStyle = ChatAssistantStyle
-				roleName = "Claude"
+			// Model switch markers are dividers, without a role label
+			if !isModelSwitchMarker(msg) {
+				if msg.Role == pclaude.RoleSystem {
+					sb.WriteString(renderSystemLabel(msg.Kind))
+				} else {
+					sb.WriteString(renderRoleLabel(msg.Role))
+				}
+				if c.bookmarks[i] {
+					sb.WriteString(" " + renderBookmarkMarker())
+				}
+				sb.WriteString("\n")
+				line++
 			}
 
-			sb.WriteString(roleStyle.Render(roleName + ":"))
-			sb.WriteString("\n")
-
 			// Check cache for this message
-			content := strings.TrimSpace(msg.Content)
+			rawContent := msg.Content
+			if c.compactToolUses && !c.toolGroupsExpanded {
+				rawContent = compactToolUseGroups(rawContent, msg.ToolUseGroups)
+			}
+			if !c.toolGroupsExpanded {
+				rawContent = collapseHookOutput(rawContent)
+			}
+			content := strings.TrimSpace(rawContent)
 			var renderedContent string
+			render := renderMarkdown
+			if msg.Role == pclaude.RoleSystem && !isModelSwitchMarker(msg) {
+				render = renderSystemContent
+			}
 
 			if i < len(c.messageCache) {
 				cached := c.messageCache[i]
@@ -1200,7 +1665,7 @@ func (c *Chat) updateContent() {
 					renderedContent = cached.rendered
 				} else {
 					// Cache miss - content or width changed, re-render
-					renderedContent = renderMarkdown(content, wrapWidth)
+					renderedContent = render(content, wrapWidth)
 					c.messageCache[i] = messageCache{
 						content:   content,
 						rendered:  renderedContent,
@@ -1209,7 +1674,7 @@ func (c *Chat) updateContent() {
 				}
 			} else {
 				// New message - render and add to cache
-				renderedContent = renderMarkdown(content, wrapWidth)
+				renderedContent = render(content, wrapWidth)
 				c.messageCache = append(c.messageCache, messageCache{
 					content:   content,
 					rendered:  renderedContent,
@@ -1218,10 +1683,11 @@ func (c *Chat) updateContent() {
 			}
 
 			sb.WriteString(renderedContent)
+			line += strings.Count(renderedContent, "\n")
 		}
 
 		// Show streaming content or waiting indicator with stopwatch
-		if c.streaming != "" || c.toolUseRollup != nil {
+		if c.streaming != "" || c.toolUseRollup != nil || c.errorRun != nil {
 			if len(c.messages) > 0 {
 				sb.WriteString("\n\n")
 			}
@@ -1231,12 +1697,27 @@ func (c *Chat) updateContent() {
 			// Tool use lines are already included in streaming content with circle markers
 			if c.streaming != "" {
 				streamContent := strings.TrimSpace(c.streaming)
-				sb.WriteString(renderMarkdown(streamContent, wrapWidth))
+				if c.streamingCache.content != streamContent || c.streamingCache.wrapWidth != wrapWidth {
+					c.streamingCache = messageCache{
+						content:   streamContent,
+						rendered:  renderMarkdown(streamContent, wrapWidth),
+						wrapWidth: wrapWidth,
+					}
+				}
+				sb.WriteString(c.streamingCache.rendered)
+			}
+			// Render the current error run on its own, so its counter updates
+			// without re-rendering the streaming content above it
+			if c.errorRun != nil {
+				if c.streaming != "" {
+					sb.WriteString("\n")
+				}
+				sb.WriteString(renderMarkdown(strings.TrimSpace(c.errorRunContent(c.errorsExpanded)), wrapWidth))
 			}
 			// Render active tool use rollup
 			if c.toolUseRollup != nil && len(c.toolUseRollup.Items) > 0 {
 				// Add newline separator if there's streaming content before the rollup
-				if c.streaming != "" {
+				if c.streaming != "" || c.errorRun != nil {
 					sb.WriteString("\n")
 				}
 				sb.WriteString(c.renderToolUseRollup())
@@ -1273,7 +1754,11 @@ func (c *Chat) updateContent() {
 			}
 			sb.WriteString(ChatAssistantStyle.Render("Claude:"))
 			sb.WriteString("\n")
-			sb.WriteString(renderCompletionFlash(c.spinner.FlashFrame, c.finalStats))
+			stats := c.finalStats
+			if !c.spinner.FlashStats {
+				stats = nil
+			}
+			sb.WriteString(renderCompletionFlash(c.spinner.flashPhase(), stats))
 		}
 
 		// Show queued message waiting to be sent
@@ -1294,7 +1779,11 @@ func (c *Chat) updateContent() {
 			if len(c.messages) > 0 || c.streaming != "" || c.waiting {
 				sb.WriteString("\n\n")
 			}
-			sb.WriteString(renderPermissionPrompt(c.permission.Tool, c.permission.Description, wrapWidth))
+			if len(c.permission.Destructive) > 0 {
+				sb.WriteString(renderDestructivePermissionPrompt(c.permission, wrapWidth))
+			} else {
+				sb.WriteString(renderPermissionPrompt(c.permission.Tool, c.permission.Description, wrapWidth))
+			}
 		}
 
 		// Show pending question prompt
@@ -1314,10 +1803,17 @@ func (c *Chat) updateContent() {
 		}
 	}
 
-	// Add horizontal padding to content for visual breathing room
-	paddedContent := lipgloss.NewStyle().Padding(0, 1).Render(sb.String())
+	// Add horizontal padding to content for visual breathing room, and the
+	// margin centering it when its width is capped
+	paddedContent := lipgloss.NewStyle().Padding(0, 1, 0, 1+margin).Render(sb.String())
 	c.viewport.SetContent(paddedContent)
+	c.lineWidths = c.lineWidths[:0]
+	for l := range strings.SplitSeq(paddedContent, "\n") {
+		// Padding fills every line out to the widest, so only count up to its text
+		c.lineWidths = append(c.lineWidths, ansi.StringWidth(strings.TrimRight(ansi.Strip(l), " ")))
+	}
 	c.viewport.GotoBottom()
+	c.syncHOffset()
 }
 
 // Update handles messages
@@ -1347,6 +1843,10 @@ func (c *Chat) Update(msg tea.Msg) (*Chat, tea.Cmd) {
 					c.updateViewChangesDiff()
 				}
 				return c, nil
+			case "s":
+				// Toggle between unified and split diffs
+				c.ToggleViewChangesSplit()
+				return c, nil
 			case keys.Up, "k", keys.Down, "j", keys.PgUp, keys.PgDown, keys.CtrlUp, keys.CtrlDown,
 				keys.Home, keys.End, keys.CtrlU, keys.CtrlD:
 				// Scroll diff viewport
@@ -1365,6 +1865,13 @@ func (c *Chat) Update(msg tea.Msg) (*Chat, tea.Cmd) {
 		return c, tea.Batch(cmds...)
 	}
 
+	// Handle todo focus mode - it intercepts all keys
+	if c.todoFocus {
+		if keyMsg, isKey := msg.(tea.KeyPressMsg); isKey {
+			return c, c.updateTodoFocus(keyMsg)
+		}
+	}
+
 	// Handle log viewer mode - it intercepts all input
 	if c.logViewer != nil {
 		if keyMsg, isKey := msg.(tea.KeyPressMsg); isKey {
@@ -1396,6 +1903,10 @@ func (c *Chat) Update(msg tea.Msg) (*Chat, tea.Cmd) {
 				// Refresh log content
 				c.RefreshLogViewer()
 				return c, nil
+			case "m":
+				// Toggle MCP wire tracing
+				c.ToggleLogViewerWireTrace()
+				return c, nil
 			case keys.Up, "k", keys.Down, "j", keys.PgUp, keys.PgDown, keys.CtrlUp, keys.CtrlDown,
 				keys.Home, keys.End, keys.CtrlU, keys.CtrlD:
 				// Scroll log viewport - disable follow mode when manually scrolling
@@ -1417,6 +1928,65 @@ func (c *Chat) Update(msg tea.Msg) (*Chat, tea.Cmd) {
 		return c, tea.Batch(cmds...)
 	}
 
+	// Handle live diff mode - it intercepts keys and the mouse; other messages (like
+	// spinner ticks) still reach the conversation, which keeps streaming underneath
+	if c.liveDiff != nil {
+		switch msg := msg.(type) {
+		case tea.KeyPressMsg:
+			switch msg.String() {
+			case keys.Escape:
+				c.ExitLiveDiffMode()
+				return c, nil
+			case keys.Up, "k", keys.Down, "j", keys.PgUp, keys.PgDown, keys.CtrlUp, keys.CtrlDown,
+				keys.Home, keys.End, keys.CtrlU, keys.CtrlD:
+				var cmd tea.Cmd
+				c.liveDiff.Viewport, cmd = c.liveDiff.Viewport.Update(msg)
+				return c, cmd
+			}
+			// Ignore other keys in live diff mode
+			return c, nil
+		case tea.MouseMsg:
+			var cmd tea.Cmd
+			c.liveDiff.Viewport, cmd = c.liveDiff.Viewport.Update(msg)
+			return c, cmd
+		}
+	}
+
+	// Handle activity feed mode - it intercepts all input
+	if c.activityFeed != nil {
+		if keyMsg, isKey := msg.(tea.KeyPressMsg); isKey {
+			switch keyMsg.String() {
+			case keys.Escape, "q", "F":
+				c.ExitActivityFeedMode()
+				return c, nil
+			case keys.Up, "k":
+				c.MoveActivityFeedSelection(-1)
+				return c, nil
+			case keys.Down, "j":
+				c.MoveActivityFeedSelection(1)
+				return c, nil
+			case keys.Home:
+				c.MoveActivityFeedSelection(-len(c.activityFeed.Events))
+				return c, nil
+			case keys.End:
+				c.MoveActivityFeedSelection(len(c.activityFeed.Events))
+				return c, nil
+			case keys.PgUp, keys.PgDown, keys.CtrlU, keys.CtrlD:
+				var cmd tea.Cmd
+				c.activityFeed.Viewport, cmd = c.activityFeed.Viewport.Update(msg)
+				cmds = append(cmds, cmd)
+				return c, tea.Batch(cmds...)
+			}
+			// Ignore other keys in activity feed mode
+			return c, nil
+		}
+		// Pass non-key events (like mouse wheel) to viewport
+		var cmd tea.Cmd
+		c.activityFeed.Viewport, cmd = c.activityFeed.Viewport.Update(msg)
+		cmds = append(cmds, cmd)
+		return c, tea.Batch(cmds...)
+	}
+
 	// Handle mouse events for text selection
 	switch msg := msg.(type) {
 	case tea.MouseClickMsg:
@@ -1508,13 +2078,35 @@ func (c *Chat) Update(msg tea.Msg) (*Chat, tea.Cmd) {
 		return c, tea.Batch(cmds...)
 	}
 
+	// Home and End jump to the top and bottom, focused or not
+	if keyMsg, isKey := msg.(tea.KeyPressMsg); isKey && c.hasSession {
+		switch keyMsg.String() {
+		case keys.Home:
+			c.jumpToEdge(true)
+			return c, tea.Batch(cmds...)
+		case keys.End:
+			c.jumpToEdge(false)
+			return c, tea.Batch(cmds...)
+		case keys.Left, keys.Right:
+			// While typing, they move the input's cursor instead
+			if !c.focused || c.input.Value() == "" {
+				c.scrollHorizontally(keyMsg.String() == keys.Right)
+				return c, tea.Batch(cmds...)
+			}
+		}
+	}
+
 	if c.focused && c.hasSession {
 		// Check if this is a scroll key before sending to input
 		if keyMsg, isKey := msg.(tea.KeyPressMsg); isKey {
 			key := keyMsg.String()
+			// While the tool-use region is shown, page keys scroll it
+			if c.toolUseRegion > 0 && c.scrollToolUseRegion(key) {
+				return c, tea.Batch(cmds...)
+			}
 			// Allow scroll keys to pass through to viewport
 			switch key {
-			case keys.PgUp, keys.PgDown, keys.CtrlUp, keys.CtrlDown, keys.Home, keys.End,
+			case keys.PgUp, keys.PgDown, keys.CtrlUp, keys.CtrlDown,
 				keys.CtrlU, keys.CtrlD:
 				// Pass to viewport for scrolling
 				var cmd tea.Cmd
@@ -1545,6 +2137,10 @@ func (c *Chat) Update(msg tea.Msg) (*Chat, tea.Cmd) {
 			}
 		}
 
+		if pasteMsg, ok := msg.(tea.PasteMsg); ok {
+			msg = tea.PasteMsg{Content: c.SanitizePaste(pasteMsg.Content)}
+		}
+
 		var cmd tea.Cmd
 		c.input, cmd = c.input.Update(msg)
 		cmds = append(cmds, cmd)
@@ -1557,11 +2153,14 @@ func (c *Chat) Update(msg tea.Msg) (*Chat, tea.Cmd) {
 	}
 
 	// Update viewport for scrolling (non-key events, or when not focused)
-	// Route mouse wheel events to the appropriate viewport based on X coordinate
-	if mouseMsg, isMouse := msg.(tea.MouseWheelMsg); isMouse && c.HasTodoList() && c.todoWidth > 0 {
+	// Route mouse wheel events to the appropriate viewport based on X coordinate.
+	// Every wheel event sets todoHovered anew, so one over the conversation, or
+	// after the todo list went away, sends Home/End back to the conversation.
+	if mouseMsg, isMouse := msg.(tea.MouseWheelMsg); isMouse {
 		// Calculate the boundary between chat and todo sidebar
 		mainWidth := c.width - c.todoWidth
-		if mouseMsg.X >= mainWidth {
+		c.todoHovered = c.HasTodoList() && c.todoWidth > 0 && mouseMsg.X >= mainWidth
+		if c.todoHovered {
 			// Mouse is over the todo sidebar - route to todo viewport
 			var cmd tea.Cmd
 			c.todoViewport, cmd = c.todoViewport.Update(msg)
@@ -1594,11 +2193,22 @@ func (c *Chat) View() string {
 		return c.renderLogViewerMode(panelStyle)
 	}
 
+	// Live diff mode: show the worktree diff instead of the conversation
+	if c.liveDiff != nil {
+		return c.renderLiveDiffMode(panelStyle)
+	}
+
+	// Activity feed mode: show events across all sessions instead of chat
+	if c.activityFeed != nil {
+		return c.renderActivityFeedMode(panelStyle)
+	}
+
 	// Viewport content - render placeholder directly if no session
 	var viewportContent string
-	if !c.hasSession {
+	if !c.hasSession && c.loadingName == "" {
 		viewportContent = lipgloss.NewStyle().Padding(0, 1).Render(renderNoSessionMessage())
 	} else {
+		c.syncHOffset()
 		viewportContent = c.viewport.View()
 		// Apply selection highlighting if there's an active selection
 		if c.HasTextSelection() {
@@ -1614,7 +2224,7 @@ func (c *Chat) View() string {
 	// With session: chat history panel + input area below it
 	// Calculate heights: chat panel gets remaining space after input
 	// Use dynamic height to account for image indicator when attached
-	chatPanelHeight := c.height - c.getInputTotalHeight()
+	chatPanelHeight := c.height - c.getInputTotalHeight() - c.toolUseRegion
 
 	// Input area with its own border
 	inputStyle := ChatInputStyle
@@ -1650,15 +2260,15 @@ func (c *Chat) View() string {
 		// Join horizontally
 		chatPanel := lipgloss.JoinHorizontal(lipgloss.Top, mainPanel, todoPanel)
 
-		// Input spans full width below both panels
+		// Input spans full width below both panels, as does the tool-use region
 		inputArea := inputStyle.Width(c.width).Render(inputContent)
 
-		return lipgloss.JoinVertical(lipgloss.Left, chatPanel, inputArea)
+		return c.joinToolUseRegion(chatPanel, inputArea)
 	}
 
 	// No todo list: full-width chat (original behavior)
 	chatPanel := panelStyle.Width(c.width).Height(chatPanelHeight).Render(viewportContent)
 	inputArea := inputStyle.Width(c.width).Render(inputContent)
 
-	return lipgloss.JoinVertical(lipgloss.Left, chatPanel, inputArea)
+	return c.joinToolUseRegion(chatPanel, inputArea)
 }
@@ -48,9 +48,12 @@ func randomThinkingVerb() string {
 	return thinkingVerbs[rand.Intn(len(thinkingVerbs))]
 }
 
+// completionFlashFrame is how long each frame of the completion flash shows
+const completionFlashFrame = 160 * time.Millisecond
+
 // CompletionFlashTick returns a command that sends a completion flash tick
 func CompletionFlashTick() tea.Cmd {
-	return tea.Tick(160*time.Millisecond, func(t time.Time) tea.Msg {
+	return tea.Tick(completionFlashFrame, func(t time.Time) tea.Msg {
 		return CompletionFlashTickMsg(t)
 	})
 }
@@ -62,8 +65,23 @@ func SelectionFlashTick() tea.Cmd {
 	})
 }
 
-// StartCompletionFlash starts the completion checkmark flash animation
+// SetCompletionFlash sets how many milliseconds the completion flash shows, in
+// whole frames (0 or less disables it), and whether it shows the final stats.
+func (c *Chat) SetCompletionFlash(ms int, showStats bool) {
+	c.spinner.FlashFrames = 0
+	if ms > 0 {
+		frame := int(completionFlashFrame / time.Millisecond)
+		c.spinner.FlashFrames = (ms + frame - 1) / frame
+	}
+	c.spinner.FlashStats = showStats
+}
+
+// StartCompletionFlash starts the completion checkmark flash animation, unless
+// the flash is disabled.
 func (c *Chat) StartCompletionFlash() tea.Cmd {
+	if c.spinner.FlashFrames == 0 {
+		return nil
+	}
 	c.spinner.FlashFrame = 0
 	c.updateContent()
 	return CompletionFlashTick()
@@ -90,13 +108,65 @@ func renderSpinner(verb string, sp spinner.Model) string {
 	return sp.View() + " " + verbStyle.Render(verb+"...")
 }
 
+// ThinkingEscalation holds how long into a turn the streaming status line
+// escalates at each stage; 0 skips a stage.
+type ThinkingEscalation struct {
+	Emphasize     time.Duration // Elapsed time shown prominently after the verb
+	Warn          time.Duration // Amber, with a "still working" note
+	SuggestCancel time.Duration // The note suggests interrupting
+}
+
+// Escalation stages of the streaming status line, in order.
+const (
+	escalationNone = iota
+	escalationEmphasize
+	escalationWarn
+	escalationSuggestCancel
+)
+
+// thinkingEscalation is when the streaming status line escalates; see
+// SetThinkingEscalation.
+var thinkingEscalation = ThinkingEscalation{
+	Emphasize:     30 * time.Second,
+	Warn:          2 * time.Minute,
+	SuggestCancel: 5 * time.Minute,
+}
+
+// SetThinkingEscalation sets how long into a turn the streaming status line
+// escalates at each stage.
+func SetThinkingEscalation(e ThinkingEscalation) {
+	thinkingEscalation = e
+}
+
+// stage returns the furthest escalation stage reached after elapsed.
+func (e ThinkingEscalation) stage(elapsed time.Duration) int {
+	stage := escalationNone
+	for i, threshold := range []time.Duration{e.Emphasize, e.Warn, e.SuggestCancel} {
+		if threshold > 0 && elapsed >= threshold {
+			stage = i + 1
+		}
+	}
+	return stage
+}
+
 // renderStreamingStatus renders the full status line during streaming.
 // Format: ⠋ Thinking... (esc to interrupt • 12s • ↓ 342 tokens • cache: 138k)
 // Or with subagent: ⠋ Thinking... [haiku working] (esc to interrupt • 12s • ↓ 342 tokens • cache: 138k)
+//
+// As the turn runs long (see ThinkingEscalation) the elapsed time moves up
+// next to the verb, then turns amber with a note that Claude is still
+// working, then suggests interrupting: ⠋ Thinking... 5m12s still working… esc to interrupt if it's stuck (↓ 342 tokens)
 func renderStreamingStatus(verb string, sp spinner.Model, elapsed time.Duration, stats *pclaude.StreamStats, subagentModel string) string {
-	// Style for the verb text - uses theme's primary color, italic
+	stage := thinkingEscalation.stage(elapsed)
+
+	// Style for the verb text - uses theme's primary color, italic, or
+	// amber once the turn has run long enough to warn
+	verbColor := ColorPrimary
+	if stage >= escalationWarn {
+		verbColor = ColorWarning
+	}
 	verbStyle := lipgloss.NewStyle().
-		Foreground(ColorPrimary).
+		Foreground(verbColor).
 		Italic(true)
 
 	// Style for the metadata - muted color
@@ -115,8 +185,32 @@ func renderStreamingStatus(verb string, sp spinner.Model, elapsed time.Duration,
 
 	// Build metadata parts: (esc to interrupt • 12s • ↓ 342 tokens • cache: 138k)
 	var parts []string
-	parts = append(parts, "esc to interrupt")
-	parts = append(parts, formatElapsed(elapsed))
+	if stage < escalationSuggestCancel {
+		parts = append(parts, "esc to interrupt")
+	}
+
+	// Past the first threshold, the elapsed time stands out next to the verb
+	if stage == escalationNone {
+		parts = append(parts, formatElapsed(elapsed))
+	} else {
+		elapsedStyle := lipgloss.NewStyle().
+			Foreground(ColorText).
+			Bold(true)
+		if stage >= escalationWarn {
+			elapsedStyle = elapsedStyle.Foreground(ColorWarning)
+		}
+		verbPart += " " + elapsedStyle.Render(formatElapsed(elapsed))
+	}
+
+	noteStyle := lipgloss.NewStyle().
+		Foreground(ColorWarning).
+		Italic(true)
+	switch stage {
+	case escalationWarn:
+		verbPart += " " + noteStyle.Render("still working…")
+	case escalationSuggestCancel:
+		verbPart += " " + noteStyle.Render("still working… esc to interrupt if it's stuck")
+	}
 
 	if stats != nil && stats.OutputTokens > 0 {
 		parts = append(parts, fmt.Sprintf("↓ %s tokens", formatTokenCount(stats.OutputTokens)))
@@ -127,6 +221,9 @@ func renderStreamingStatus(verb string, sp spinner.Model, elapsed time.Duration,
 		parts = append(parts, fmt.Sprintf("cache: %s", formatTokenCount(stats.CacheReadTokens)))
 	}
 
+	if len(parts) == 0 {
+		return sp.View() + " " + verbPart
+	}
 	meta := metaStyle.Render("(" + strings.Join(parts, " • ") + ")")
 	return sp.View() + " " + verbPart + " " + meta
 }
@@ -340,6 +437,18 @@ func (c *Chat) SetWaitingWithStart(waiting bool, startTime time.Time) {
 	c.updateContent()
 }
 
+// ShiftTimers moves the waiting and container init start times forward by d, so
+// elapsed times leave out time the system spent asleep.
+func (c *Chat) ShiftTimers(d time.Duration) {
+	if !c.streamStartTime.IsZero() {
+		c.streamStartTime = c.streamStartTime.Round(0).Add(d)
+	}
+	if !c.containerInitStart.IsZero() {
+		c.containerInitStart = c.containerInitStart.Round(0).Add(d)
+	}
+	c.updateContent()
+}
+
 // IsWaiting returns whether we're waiting for a response
 func (c *Chat) IsWaiting() bool {
 	return c.waiting
@@ -386,7 +495,7 @@ func (c *Chat) handleCompletionFlashTick() tea.Cmd {
 	}
 
 	c.spinner.FlashFrame++
-	if c.spinner.FlashFrame >= 3 {
+	if c.spinner.FlashFrame >= c.spinner.FlashFrames {
 		// Animation complete
 		c.spinner.FlashFrame = -1
 	}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
+
+// BookmarkMarker is shown after the role label of a bookmarked message
+const BookmarkMarker = "⚑"
+
+// renderBookmarkMarker renders the marker of a bookmarked message's role label.
+func renderBookmarkMarker() string {
+	return lipgloss.NewStyle().Foreground(ColorWarning).Render(BookmarkMarker)
+}
+
+// SetBookmarks replaces the bookmarked messages, by index. Indices outside the
+// conversation are ignored.
+func (c *Chat) SetBookmarks(indices []int) {
+	c.bookmarks = make(map[int]bool, len(indices))
+	for _, i := range indices {
+		if i >= 0 && i < len(c.messages) {
+			c.bookmarks[i] = true
+		}
+	}
+	c.updateContentKeepingScroll()
+}
+
+// Bookmarks returns the indices of the bookmarked messages, in order.
+func (c *Chat) Bookmarks() []int {
+	indices := make([]int, 0, len(c.bookmarks))
+	for i := range c.bookmarks {
+		indices = append(indices, i)
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+// IsBookmarked returns whether the message at index is bookmarked.
+func (c *Chat) IsBookmarked(index int) bool {
+	return c.bookmarks[index]
+}
+
+// MessageAtViewportTop returns the index of the message shown at the top of the
+// viewport: the last one starting at or above it. Returns -1 if there are no messages.
+func (c *Chat) MessageAtViewportTop() int {
+	top := c.viewport.YOffset()
+	index := -1
+	for i, line := range c.messageStartLines {
+		if line > top {
+			break
+		}
+		index = i
+	}
+	if index < 0 && len(c.messageStartLines) > 0 {
+		index = 0
+	}
+	return index
+}
+
+// ToggleBookmark bookmarks the message at the top of the viewport, or removes its
+// bookmark. Returns the message's index and whether it is now bookmarked; the
+// index is -1 if there are no messages.
+func (c *Chat) ToggleBookmark() (int, bool) {
+	index := c.MessageAtViewportTop()
+	if index < 0 {
+		return -1, false
+	}
+	if c.bookmarks == nil {
+		c.bookmarks = make(map[int]bool)
+	}
+	if c.bookmarks[index] {
+		delete(c.bookmarks, index)
+	} else {
+		c.bookmarks[index] = true
+	}
+	c.updateContentKeepingScroll()
+	return index, c.bookmarks[index]
+}
+
+// JumpToNextBookmark scrolls the next bookmarked message below the top of the
+// viewport to the top, wrapping around to the first. Returns its index, or -1
+// if there are no bookmarks.
+func (c *Chat) JumpToNextBookmark() int {
+	bookmarks := c.Bookmarks()
+	if len(bookmarks) == 0 {
+		return -1
+	}
+	current := c.MessageAtViewportTop()
+	next := bookmarks[0]
+	for _, i := range bookmarks {
+		if i > current {
+			next = i
+			break
+		}
+	}
+	if next < len(c.messageStartLines) {
+		c.viewport.SetYOffset(c.messageStartLines[next])
+	}
+	return next
+}
+
+// updateContentKeepingScroll re-renders the conversation without scrolling to
+// the bottom, as updateContent does for new content.
+func (c *Chat) updateContentKeepingScroll() {
+	offset := c.viewport.YOffset()
+	atBottom := c.viewport.AtBottom()
+	c.updateContent()
+	if !atBottom {
+		c.viewport.SetYOffset(offset)
+	}
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// codeBlock is a fenced code block in one of Claude's messages, as written
+// rather than highlighted.
+type codeBlock struct {
+	message int // Index of the message it is in
+	code    string
+}
+
+// codeBlockCopy tracks repeated copying of code blocks, which steps back one
+// block per press.
+type codeBlockCopy struct {
+	count int // Code blocks there were at the last copy
+	back  int // How many blocks before the last one the last copy was
+}
+
+// extractCodeBlocks returns the contents of the fenced code blocks in
+// markdown, in order, split the way renderMarkdown splits them. A block left
+// open runs to the end.
+func extractCodeBlocks(markdown string) []string {
+	var blocks []string
+	var code []string
+	inBlock := false
+	for line := range strings.SplitSeq(markdown, "\n") {
+		if strings.HasPrefix(line, "```") {
+			if inBlock {
+				blocks = append(blocks, strings.Join(code, "\n"))
+			}
+			inBlock = !inBlock
+			code = nil
+			continue
+		}
+		if inBlock {
+			code = append(code, line)
+		}
+	}
+	if inBlock {
+		blocks = append(blocks, strings.Join(code, "\n"))
+	}
+	return blocks
+}
+
+// codeBlocks returns the code blocks in Claude's messages, oldest first.
+func (c *Chat) codeBlocks() []codeBlock {
+	var blocks []codeBlock
+	for i, msg := range c.messages {
+		if msg.Role != "assistant" {
+			continue
+		}
+		for _, code := range extractCodeBlocks(strings.TrimSpace(msg.Content)) {
+			blocks = append(blocks, codeBlock{message: i, code: code})
+		}
+	}
+	return blocks
+}
+
+// CopyCodeBlock copies the most recent code block in Claude's messages to the
+// clipboard, or the one before the block copied last when pressed again, wrapping
+// around to the most recent. The block flashes like a copied selection when it
+// can be found on screen. Returns nil if there are no code blocks.
+func (c *Chat) CopyCodeBlock() tea.Cmd {
+	blocks := c.codeBlocks()
+	if len(blocks) == 0 {
+		return nil
+	}
+	// New blocks start over from the most recent
+	if len(blocks) == c.codeBlockCopy.count {
+		c.codeBlockCopy.back = (c.codeBlockCopy.back + 1) % len(blocks)
+	} else {
+		c.codeBlockCopy = codeBlockCopy{count: len(blocks)}
+	}
+	index := len(blocks) - 1 - c.codeBlockCopy.back
+	block := blocks[index]
+
+	confirm := "Copied code block"
+	if len(blocks) > 1 {
+		confirm = fmt.Sprintf("Copied code block %d of %d", index+1, len(blocks))
+	}
+	cmds := []tea.Cmd{CopyToClipboard(block.code, confirm)}
+	if c.selectCodeBlock(block) {
+		c.selection.FlashFrame = 0
+		cmds = append(cmds, SelectionFlashTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+// selectCodeBlock scrolls the block into view and selects its rows in the
+// viewport, for the copy flash. Returns false if its rows can't be found, as
+// they are matched by text from the start of its message.
+func (c *Chat) selectCodeBlock(block codeBlock) bool {
+	if block.message >= len(c.messageStartLines) {
+		return false
+	}
+	lines := strings.Split(c.viewport.GetContent(), "\n")
+	code := strings.Split(block.code, "\n")
+	first := -1
+	for row := c.messageStartLines[block.message]; row+len(code) <= len(lines); row++ {
+		if codeBlockAt(lines[row:row+len(code)], code) {
+			first = row
+			break
+		}
+	}
+	if first < 0 {
+		return false
+	}
+	last := first + len(code) - 1
+
+	// Scroll only if the block doesn't start in view
+	top := c.viewport.YOffset()
+	if first < top || first >= top+c.viewport.Height() {
+		c.viewport.SetYOffset(first)
+		top = c.viewport.YOffset()
+	}
+	c.selection.StartCol = 0
+	c.selection.StartLine = first - top
+	c.selection.EndCol = c.viewport.Width()
+	c.selection.EndLine = min(last-top, c.viewport.Height()-1)
+	c.selection.Active = false
+	return true
+}
+
+// codeBlockAt returns whether the rendered rows show the code's lines, ignoring
+// styling and the padding around them.
+func codeBlockAt(rows, code []string) bool {
+	for i, line := range code {
+		if strings.TrimSpace(ansi.Strip(rows[i])) != strings.TrimSpace(line) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zhubert/plural/internal/claude"
+)
+
+func TestExtractCodeBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     []string
+	}{
+		{"none", "just text", nil},
+		{"one", "Try:\n```go\nfmt.Println(1)\n\treturn\n```\nDone", []string{"fmt.Println(1)\n\treturn"}},
+		{"two", "```\na\n```\ntext\n```sh\nb\nc\n```", []string{"a", "b\nc"}},
+		{"left open", "```py\nprint(1)", []string{"print(1)"}},
+		{"empty", "```\n```", []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractCodeBlocks(tt.markdown); !slices.Equal(got, tt.want) {
+				t.Errorf("extractCodeBlocks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChat_CopyCodeBlock(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(120, 40)
+	if chat.CopyCodeBlock() != nil {
+		t.Error("expected nothing to copy without a session")
+	}
+
+	chat.SetSession("test", []claude.Message{
+		{Role: "user", Content: "```\nuser code\n```"},
+		{Role: "assistant", Content: "First:\n```go\nfirst()\n```\nSecond:\n```go\nsecond()\n```"},
+		{Role: "assistant", Content: "Last:\n```sh\nthird one\nmore\n```"},
+	})
+
+	// Most recent first, then stepping back, wrapping around
+	for i, want := range []string{"third one\nmore", "second()", "first()", "third one\nmore"} {
+		if chat.CopyCodeBlock() == nil {
+			t.Fatalf("press %d: expected a copy command", i+1)
+		}
+		if chat.selection.FlashFrame != 0 {
+			t.Errorf("press %d: expected the copied block to flash", i+1)
+		}
+		if got := chat.GetSelectedText(); !strings.Contains(got, strings.Split(want, "\n")[0]) {
+			t.Errorf("press %d: expected %q selected for the flash, got %q", i+1, want, got)
+		}
+		blocks := chat.codeBlocks()
+		if got := blocks[len(blocks)-1-chat.codeBlockCopy.back].code; got != want {
+			t.Errorf("press %d: copied %q, want %q", i+1, got, want)
+		}
+	}
+
+	// A new block starts over from it
+	chat.AppendStreaming("```\nnewest\n```")
+	chat.FinishStreaming()
+	chat.CopyCodeBlock()
+	if chat.codeBlockCopy.back != 0 || chat.codeBlockCopy.count != 4 {
+		t.Errorf("expected copying to start over at the new block, got %+v", chat.codeBlockCopy)
+	}
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// errorLinePattern matches content that is a single streamed error line, such as
+// "\n[Error: broken pipe]\n", capturing the error text.
+var errorLinePattern = regexp.MustCompile(`^\s*\[Error: ([^\n]*)\]\s*$`)
+
+// errorCountPattern matches the counter errorLine puts after a repeated error,
+// capturing the count.
+var errorCountPattern = regexp.MustCompile(`^ × (\d+), last at \d{2}:\d{2}$`)
+
+// typedErrorClasses maps errors recognized by errors.Is to the class they collapse under,
+// so that e.g. every broken pipe counts as the same error whatever path it was on.
+var typedErrorClasses = []struct {
+	target error
+	class  string
+}{
+	{syscall.EPIPE, "broken pipe"},
+	{syscall.ECONNRESET, "connection reset"},
+	{syscall.ECONNREFUSED, "connection refused"},
+	{net.ErrClosed, "connection closed"},
+	{context.DeadlineExceeded, "timeout"},
+	{os.ErrDeadlineExceeded, "timeout"},
+}
+
+// ErrorClass returns the class an error is collapsed under in the chat: a shared
+// class for well-known typed errors, otherwise its exact text.
+func ErrorClass(err error) string {
+	for _, tc := range typedErrorClasses {
+		if errors.Is(err, tc.target) {
+			return tc.class
+		}
+	}
+	return err.Error()
+}
+
+// errorOccurrence is one error appended to the chat.
+type errorOccurrence struct {
+	text string
+	at   time.Time
+}
+
+// errorRun is a run of consecutive errors of the same class at the end of the
+// streaming response. It is shown as one line with a counter until something
+// else is appended, when the collapsed line is written to the streaming content.
+type errorRun struct {
+	class       string
+	occurrences []errorOccurrence
+}
+
+// errorLine returns an error as a chat line, with a counter and the time of the
+// latest occurrence if it occurred more than once.
+func errorLine(text string, count int, at time.Time) string {
+	line := "[Error: " + text + "]"
+	if count > 1 {
+		line += fmt.Sprintf(" × %d, last at %s", count, at.Format("15:04"))
+	}
+	return line
+}
+
+// collapsed returns the run as a single line: the latest error, with a counter
+// and the time of the latest occurrence if it repeated.
+func (r *errorRun) collapsed() string {
+	last := r.occurrences[len(r.occurrences)-1]
+	return errorLine(last.text, len(r.occurrences), last.at)
+}
+
+// AppendErrorLine appends an error line to the streaming content of a session
+// that isn't showing. An error the same as the one content ends with is merged
+// into it, counting the repeats as the chat does for the session showing.
+func AppendErrorLine(content, text string, at time.Time) string {
+	prefix := "\n[Error: " + text + "]"
+	if i := strings.LastIndex(content, prefix); i >= 0 {
+		count, rest := 1, content[i+len(prefix):]
+		if match := errorCountPattern.FindStringSubmatch(rest); match != nil {
+			count, _ = strconv.Atoi(match[1])
+			rest = ""
+		}
+		if rest == "" {
+			return content[:i] + "\n" + errorLine(text, count+1, at)
+		}
+	}
+	return content + prefix
+}
+
+// expanded returns every error in the run on its own line, with its time.
+func (r *errorRun) expanded() string {
+	lines := make([]string, len(r.occurrences))
+	for i, occ := range r.occurrences {
+		lines[i] = fmt.Sprintf("[Error: %s] at %s", occ.text, occ.at.Format("15:04:05"))
+	}
+	return strings.Join(lines, "\n\n")
+}
+
+// AppendError appends an error line to the streaming response. Consecutive errors
+// of the same class collapse into one line with a counter; every occurrence is
+// still written to the debug log.
+func (c *Chat) AppendError(class, text string) {
+	// Tool uses since the last error go before this one
+	c.flushToolUseRollup()
+
+	if c.errorRun == nil || c.errorRun.class != class {
+		c.commitErrorRun()
+		c.errorRun = &errorRun{class: class}
+	}
+	c.errorRun.occurrences = append(c.errorRun.occurrences, errorOccurrence{text: text, at: time.Now()})
+	logger.Get().Debug("chat error", "class", class, "count", len(c.errorRun.occurrences), "error", text)
+	c.updateContent()
+}
+
+// commitErrorRun writes the current error run to the streaming content as its
+// collapsed line and ends it.
+func (c *Chat) commitErrorRun() {
+	if c.errorRun == nil {
+		return
+	}
+	c.streaming += c.errorRunContent(false)
+	c.errorRun = nil
+	c.errorsExpanded = false
+}
+
+// errorRunContent returns the current error run as streaming content, or "" if there is none.
+func (c *Chat) errorRunContent(expanded bool) string {
+	if c.errorRun == nil {
+		return ""
+	}
+	if expanded {
+		return "\n" + c.errorRun.expanded() + "\n"
+	}
+	return "\n" + c.errorRun.collapsed() + "\n"
+}
+
+// ToggleErrorsExpanded toggles between the collapsed line and every error of the current run
+func (c *Chat) ToggleErrorsExpanded() {
+	if c.errorRun != nil {
+		c.errorsExpanded = !c.errorsExpanded
+		c.updateContent()
+	}
+}
+
+// HasCollapsedErrors returns true if the current error run has repeated errors to expand
+func (c *Chat) HasCollapsedErrors() bool {
+	return c.errorRun != nil && len(c.errorRun.occurrences) > 1
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// fileRefPattern matches path:line and path:line:col references such as
+// "internal/ui/chat.go:412". The path must contain a slash or end in an
+// extension, so times and host:port pairs aren't taken for references, and it
+// must start the text or follow a space, bracket, or quote, so URLs aren't either.
+var fileRefPattern = regexp.MustCompile(`(?:^|[\s(\[` + "`" + `'"])((?:[\w.~-]*/)+[\w.-]+|[\w-]+(?:\.[\w-]+)*\.[A-Za-z]\w*):(\d+)(?::(\d+))?\b`)
+
+// FileRef is a reference to a line of a file in the chat, as Claude writes when
+// pointing at code.
+type FileRef struct {
+	Path   string // As written, so relative to the worktree unless absolute
+	Line   int
+	Column int // 0 if not given
+}
+
+// fileRefMatches returns the submatch indices of the file references in text,
+// as FindAllStringSubmatchIndex does.
+func fileRefMatches(text string) [][]int {
+	return fileRefPattern.FindAllStringSubmatchIndex(text, -1)
+}
+
+// fileRefAt returns the file reference of a match of fileRefMatches in text.
+func fileRefAt(text string, match []int) FileRef {
+	ref := FileRef{Path: text[match[2]:match[3]]}
+	ref.Line, _ = strconv.Atoi(text[match[4]:match[5]])
+	if match[6] >= 0 {
+		ref.Column, _ = strconv.Atoi(text[match[6]:match[7]])
+	}
+	return ref
+}
+
+// isFileRef returns whether text is a file reference and nothing else.
+func isFileRef(text string) bool {
+	match := fileRefPattern.FindStringIndex(text)
+	return match != nil && match[0] == 0 && match[1] == len(text)
+}
+
+// replaceFileRefs returns line with each file reference in it replaced by what
+// replace returns for it.
+func replaceFileRefs(line string, replace func(ref string) string) string {
+	matches := fileRefMatches(line)
+	if len(matches) == 0 {
+		return line
+	}
+	var sb strings.Builder
+	prev := 0
+	for _, match := range matches {
+		sb.WriteString(line[prev:match[2]])
+		sb.WriteString(replace(line[match[2]:match[1]]))
+		prev = match[1]
+	}
+	sb.WriteString(line[prev:])
+	return sb.String()
+}
+
+// NearestFileRef returns the file reference nearest the bottom of the viewport:
+// the last one shown in it, or if it shows none, the last one above it.
+// Returns false if there is none.
+func (c *Chat) NearestFileRef() (FileRef, bool) {
+	lines := strings.Split(c.viewport.GetContent(), "\n")
+	bottom := min(c.viewport.YOffset()+c.viewport.Height(), len(lines)) - 1
+	for i := bottom; i >= 0; i-- {
+		line := ansi.Strip(lines[i])
+		if matches := fileRefMatches(line); len(matches) > 0 {
+			return fileRefAt(line, matches[len(matches)-1]), true
+		}
+	}
+	return FileRef{}, false
+}
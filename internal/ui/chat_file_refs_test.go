@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	pclaude "github.com/zhubert/plural/internal/claude"
+)
+
+func TestFileRefPattern(t *testing.T) {
+	tests := []struct {
+		text string
+		want []FileRef
+	}{
+		{"see internal/ui/chat.go:412", []FileRef{{Path: "internal/ui/chat.go", Line: 412}}},
+		{"at chat.go:12:5.", []FileRef{{Path: "chat.go", Line: 12, Column: 5}}},
+		{"(/abs/path/main.go:3) and `cmd/Makefile:7`", []FileRef{{Path: "/abs/path/main.go", Line: 3}, {Path: "cmd/Makefile", Line: 7}}},
+		{"meet at 10:30", nil},
+		{"listening on localhost:8080", nil},
+		{"https://github.com/zhubert/plural/blob/main/a.go:12", nil},
+		{"version v1.2:3", nil},
+	}
+	for _, tt := range tests {
+		var got []FileRef
+		for _, match := range fileRefMatches(tt.text) {
+			got = append(got, fileRefAt(tt.text, match))
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.text, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: got %v, want %v", tt.text, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestRenderInlineMarkdown_FileRefs(t *testing.T) {
+	// Underscores in the path are not italics
+	line := "fixed in internal/my_pkg_name/a_b.go:9"
+	if got := ansi.Strip(renderInlineMarkdown(line)); got != line {
+		t.Errorf("got %q, want %q", got, line)
+	}
+	// A reference in backticks is rendered as a reference, without the backticks
+	if got := ansi.Strip(renderInlineMarkdown("`chat.go:4`")); got != "chat.go:4" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestChat_NearestFileRef(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(120, 10)
+	if _, ok := chat.NearestFileRef(); ok {
+		t.Fatal("expected no reference without a session")
+	}
+
+	var content []string
+	content = append(content, "The bug is in internal/ui/chat.go:412.")
+	for range 30 {
+		content = append(content, "filler")
+	}
+	content = append(content, "Also see `ui/sidebar.go:7:3` and a.go:1 here.")
+	chat.SetSession("test", []pclaude.Message{{Role: "assistant", Content: strings.Join(content, "\n\n")}})
+
+	// The last reference shown in the viewport
+	ref, ok := chat.NearestFileRef()
+	if !ok || ref != (FileRef{Path: "a.go", Line: 1}) {
+		t.Errorf("got %v, %v; want a.go:1", ref, ok)
+	}
+
+	// Scrolled past the lower references, the last one above the viewport
+	chat.viewport.SetYOffset(10)
+	ref, ok = chat.NearestFileRef()
+	if !ok || ref != (FileRef{Path: "internal/ui/chat.go", Line: 412}) {
+		t.Errorf("got %v, %v; want internal/ui/chat.go:412", ref, ok)
+	}
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HookOutputPrefix starts the line naming a repo hook whose output follows in a
+// fenced block. Such lines are rendered muted, and in completed messages the
+// block is collapsed into the line until expanded.
+const HookOutputPrefix = "⚙ hook"
+
+// hookOutputFence opens the block of a hook's output.
+const hookOutputFence = "```text"
+
+// HookOutputStart returns the streamed content naming a hook run after event,
+// e.g. "post-merge", and opening the block its output goes in.
+func HookOutputStart(event, command string) string {
+	return "\n" + HookOutputPrefix + " " + event + ": " + command + "\n" + hookOutputFence + "\n"
+}
+
+// HookOutputLine returns the streamed content for a line of a hook's output. A
+// line starting with a fence is indented so that it can't close the block.
+func HookOutputLine(line string) string {
+	if strings.HasPrefix(line, "```") {
+		line = " " + line
+	}
+	return line + "\n"
+}
+
+// HookOutputEnd returns the streamed content closing a hook's output block.
+func HookOutputEnd() string {
+	return "```\n"
+}
+
+// collapseHookOutput replaces each hook's output block in content with a count
+// of its lines on the line naming the hook. A block left open, as when the app
+// quit while the hook ran, is collapsed to the end of content.
+func collapseHookOutput(content string) string {
+	if !strings.Contains(content, HookOutputPrefix) {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if !strings.HasPrefix(line, HookOutputPrefix) || i+1 >= len(lines) || lines[i+1] != hookOutputFence {
+			out = append(out, line)
+			continue
+		}
+		count := 0
+		i += 2
+		for ; i < len(lines) && lines[i] != "```"; i++ {
+			count++
+		}
+		switch count {
+		case 0:
+			out = append(out, line+" (no output)")
+		case 1:
+			out = append(out, line+" (1 line of output)")
+		default:
+			out = append(out, line+fmt.Sprintf(" (%d lines of output)", count))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// AppendOutput appends command output, such as a hook's, to the streaming
+// content as is. Unlike AppendStreaming, lines that look like errors are kept
+// in place rather than collapsed with other errors.
+func (c *Chat) AppendOutput(content string) {
+	c.flushToolUseRollup()
+	c.commitErrorRun()
+	c.streaming += content
+	c.updateContent()
+}
+
+// HasHookOutput returns true if any completed message has a hook's output,
+// which ToggleToolGroupsExpanded expands and collapses.
+func (c *Chat) HasHookOutput() bool {
+	for _, msg := range c.messages {
+		if collapseHookOutput(msg.Content) != msg.Content {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	pclaude "github.com/zhubert/plural/internal/claude"
+)
+
+func TestCollapseHookOutput(t *testing.T) {
+	content := "Merged.\n" +
+		HookOutputStart("post-merge", "./deploy.sh") +
+		HookOutputLine("deploying") +
+		HookOutputLine("```not a fence") +
+		HookOutputEnd() +
+		HookOutputStart("post-merge", "true") +
+		HookOutputEnd() +
+		"after"
+
+	want := "Merged.\n\n" +
+		HookOutputPrefix + " post-merge: ./deploy.sh (2 lines of output)\n\n" +
+		HookOutputPrefix + " post-merge: true (no output)\n" +
+		"after"
+	if got := collapseHookOutput(content); got != want {
+		t.Errorf("collapseHookOutput() =\n%q\nwant\n%q", got, want)
+	}
+
+	if got := collapseHookOutput("no hooks\n```text\nx\n```"); got != "no hooks\n```text\nx\n```" {
+		t.Errorf("expected content without hooks unchanged, got %q", got)
+	}
+}
+
+func TestChat_HookOutputCollapsedUntilExpanded(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(120, 20)
+	content := "Merged.\n" + HookOutputStart("post-merge", "./deploy.sh") + HookOutputLine("deploying to staging") + HookOutputEnd()
+	chat.SetSession("test", []pclaude.Message{{Role: "assistant", Content: content}})
+
+	if !chat.HasHookOutput() {
+		t.Fatal("expected the message's hook output noticed")
+	}
+	view := ansi.Strip(chat.viewport.View())
+	if strings.Contains(view, "deploying to staging") || !strings.Contains(view, "(1 line of output)") {
+		t.Errorf("expected the output collapsed into a count, got:\n%s", view)
+	}
+
+	chat.ToggleToolGroupsExpanded()
+	if view := ansi.Strip(chat.viewport.View()); !strings.Contains(view, "deploying to staging") {
+		t.Errorf("expected the output shown once expanded, got:\n%s", view)
+	}
+}
@@ -3,14 +3,18 @@ package ui
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"charm.land/lipgloss/v2"
 	"github.com/alecthomas/chroma/v2"
 	"github.com/alecthomas/chroma/v2/formatters"
 	"github.com/alecthomas/chroma/v2/lexers"
-	"github.com/alecthomas/chroma/v2/styles"
 	pclaude "github.com/zhubert/plural/internal/claude"
 )
 
@@ -22,10 +26,45 @@ var (
 	linkPattern       = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
 	// Table separator pattern matches lines like |---|---|---| or |:---|:---:|---:|
 	tableSeparatorPattern = regexp.MustCompile(`^\s*\|[\s\-:]+\|[\s\-:|]*$`)
+	// Strikethrough and highlight spans must not start or end with a space so that
+	// comparisons like "a == b == c" are left alone; see also renderHighlights
+	strikethroughPattern = regexp.MustCompile(`~~([^~\s](?:[^~]*[^~\s])?)~~`)
+	highlightPattern     = regexp.MustCompile(`==([^=\s](?:[^=]*[^=\s])?)==`)
 )
 
+// renderHighlights renders ==text== spans that stand apart from the words around
+// them, so unspaced comparisons like "x==1 && y==2" are left alone.
+func renderHighlights(line string) string {
+	var b strings.Builder
+	written, pos := 0, 0
+	for pos < len(line) {
+		loc := highlightPattern.FindStringSubmatchIndex(line[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		before, _ := utf8.DecodeLastRuneInString(line[:start])
+		after, _ := utf8.DecodeRuneInString(line[end:])
+		if !isHighlightBoundary(before) || !isHighlightBoundary(after) {
+			pos = start + 1
+			continue
+		}
+		b.WriteString(line[written:start])
+		b.WriteString(MarkdownHighlightStyle.Render(line[pos+loc[2] : pos+loc[3]]))
+		written, pos = end, end
+	}
+	b.WriteString(line[written:])
+	return b.String()
+}
+
+// isHighlightBoundary returns whether r, the rune next to a ==text== span
+// (utf8.RuneError at either end of the line), may border a highlight.
+func isHighlightBoundary(r rune) bool {
+	return r != '=' && r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
 // highlightCode applies syntax highlighting to code using chroma.
-// The syntax style is determined by the current theme's SyntaxStyle field.
+// The syntax style is the one selected in settings, or the current theme's SyntaxStyle field.
 func highlightCode(code, language string) string {
 	lexer := lexers.Get(language)
 	if lexer == nil {
@@ -33,12 +72,7 @@ func highlightCode(code, language string) string {
 	}
 	lexer = chroma.Coalesce(lexer)
 
-	// Use the current theme's syntax style
-	syntaxStyle := CurrentTheme().GetSyntaxStyle()
-	style := styles.Get(syntaxStyle)
-	if style == nil {
-		style = styles.Fallback
-	}
+	style := currentSyntaxStyle()
 
 	formatter := formatters.Get(DefaultTerminalFormatter)
 	if formatter == nil {
@@ -60,53 +94,89 @@ func highlightCode(code, language string) string {
 
 // HighlightDiff applies coloring to git diff output
 func HighlightDiff(diff string) string {
+	return highlightDiffLines(diff, nil)
+}
+
+// highlightDiffLines colorizes a diff like HighlightDiff, also marking the
+// lines whose indexes are in changed with LiveDiffChangedStyle.
+func highlightDiffLines(diff string, changed map[int]bool) string {
 	if diff == "" {
 		return diff
 	}
 
 	var result strings.Builder
-	lines := strings.SplitSeq(diff, "\n")
-
-	for line := range lines {
+	i := 0
+	for line := range strings.SplitSeq(diff, "\n") {
+		style, styled := diffLineStyle(line)
 		switch {
-		case strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---"):
-			// File headers
-			result.WriteString(DiffHeaderStyle.Render(line))
-		case strings.HasPrefix(line, "@@"):
-			// Hunk markers
-			result.WriteString(DiffHunkStyle.Render(line))
-		case strings.HasPrefix(line, "+"):
-			// Added lines
-			result.WriteString(DiffAddedStyle.Render(line))
-		case strings.HasPrefix(line, "-"):
-			// Removed lines
-			result.WriteString(DiffRemovedStyle.Render(line))
-		case strings.HasPrefix(line, "diff --git"):
-			// Diff command header
-			result.WriteString(DiffHeaderStyle.Render(line))
-		case strings.HasPrefix(line, "index "):
-			// Index line
-			result.WriteString(DiffHeaderStyle.Render(line))
-		case strings.HasPrefix(line, "new file mode") || strings.HasPrefix(line, "deleted file mode"):
-			// File mode changes
-			result.WriteString(DiffHeaderStyle.Render(line))
+		case changed[i]:
+			result.WriteString(style.Inherit(LiveDiffChangedStyle).Render(line))
+		case styled:
+			result.WriteString(style.Render(line))
 		default:
 			// Context lines (unchanged)
 			result.WriteString(line)
 		}
 		result.WriteString("\n")
+		i++
 	}
 
 	return strings.TrimRight(result.String(), "\n")
 }
 
-// renderInlineMarkdown applies inline formatting (bold, italic, code, links) to a line
+// diffLineStyle returns the style of a line of a unified diff, and false for
+// context lines, which are left as they are.
+func diffLineStyle(line string) (lipgloss.Style, bool) {
+	switch {
+	case strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---"):
+		// File headers
+		return DiffHeaderStyle, true
+	case strings.HasPrefix(line, "@@"):
+		// Hunk markers
+		return DiffHunkStyle, true
+	case strings.HasPrefix(line, "+"):
+		// Added lines
+		return DiffAddedStyle, true
+	case strings.HasPrefix(line, "-"):
+		// Removed lines
+		return DiffRemovedStyle, true
+	case strings.HasPrefix(line, "diff --git"):
+		// Diff command header
+		return DiffHeaderStyle, true
+	case strings.HasPrefix(line, "index "):
+		// Index line
+		return DiffHeaderStyle, true
+	case strings.HasPrefix(line, "new file mode") || strings.HasPrefix(line, "deleted file mode"):
+		// File mode changes
+		return DiffHeaderStyle, true
+	}
+	return lipgloss.NewStyle(), false
+}
+
+// strikethroughSupported reports whether the terminal renders the strikethrough
+// attribute. The Linux console and dumb terminals silently drop it.
+var strikethroughSupported = terminalSupportsStrikethrough(os.Getenv("TERM"))
+
+// terminalSupportsStrikethrough returns whether a terminal of the given TERM type
+// renders the strikethrough attribute.
+func terminalSupportsStrikethrough(term string) bool {
+	return term != "linux" && term != "dumb" && !strings.HasPrefix(term, "vt")
+}
+
+// renderStrikethrough renders struck-through text, falling back to word-diff style
+// brackets ([-text-]) where the terminal cannot draw the attribute.
+func renderStrikethrough(text string) string {
+	if !strikethroughSupported {
+		return "[-" + text + "-]"
+	}
+	return MarkdownStrikethroughStyle.Render(text)
+}
+
+// renderInlineMarkdown applies inline formatting (bold, italic, strikethrough,
+// highlight, code, links, file references) to a line
 func renderInlineMarkdown(line string) string {
 	// Apply tool use marker coloring first
-	// White circle for in-progress tools
-	line = strings.ReplaceAll(line, ToolUseInProgress, ToolUseInProgressStyle.Render(ToolUseInProgress))
-	// Green circle for completed tools
-	line = strings.ReplaceAll(line, ToolUseComplete, ToolUseCompleteStyle.Render(ToolUseComplete))
+	line = styleToolUseMarkers(line)
 
 	// Process inline code first (to avoid formatting inside code)
 	// We need to protect code spans from other formatting
@@ -125,11 +195,28 @@ func renderInlineMarkdown(line string) string {
 			return match
 		}
 		code := submatch[1]
+		rendered := MarkdownInlineCodeStyle.Render(code)
+		if isFileRef(code) {
+			rendered = MarkdownFileRefStyle.Render(code)
+		}
 		placeholder := fmt.Sprintf("\x00CODE%d\x00", codeIdx)
 		codeSpans = append(codeSpans, codeSpan{
 			placeholder: placeholder,
 			original:    match,
-			rendered:    MarkdownInlineCodeStyle.Render(code),
+			rendered:    rendered,
+		})
+		codeIdx++
+		return placeholder
+	})
+
+	// Protect file references (path:line) the same way, styled as references,
+	// so paths with underscores aren't taken for italics
+	line = replaceFileRefs(line, func(ref string) string {
+		placeholder := fmt.Sprintf("\x00CODE%d\x00", codeIdx)
+		codeSpans = append(codeSpans, codeSpan{
+			placeholder: placeholder,
+			original:    ref,
+			rendered:    MarkdownFileRefStyle.Render(ref),
 		})
 		codeIdx++
 		return placeholder
@@ -144,6 +231,18 @@ func renderInlineMarkdown(line string) string {
 		return MarkdownBoldStyle.Render(submatch[1])
 	})
 
+	// Process strikethrough (~~text~~)
+	line = strikethroughPattern.ReplaceAllStringFunc(line, func(match string) string {
+		submatch := strikethroughPattern.FindStringSubmatch(match)
+		if len(submatch) < 2 {
+			return match
+		}
+		return renderStrikethrough(submatch[1])
+	})
+
+	// Process highlight (==text==)
+	line = renderHighlights(line)
+
 	// Process italic with underscores (_text_)
 	// Only match underscores at word boundaries (not in identifiers like foo_bar_baz)
 	line = underscoreItalic.ReplaceAllStringFunc(line, func(match string) string {
@@ -427,10 +526,55 @@ func calculateTableColumnWidths(naturalWidths []int, availableWidth int) []int {
 	return colWidths
 }
 
-// renderMarkdownLine renders a single line with markdown formatting
-func renderMarkdownLine(line string, width int) string {
+// AutoAnsweredPrefix starts the transcript line recording a question answered by
+// an auto-answer rule. Such lines are rendered muted.
+const AutoAnsweredPrefix = "[Auto-answered]"
+
+// AutoApprovedPrefix starts the transcript line recording a plan approved because
+// it met the repo's plan approval criteria. Such lines are rendered muted.
+const AutoApprovedPrefix = "[Auto-approved]"
+
+// ExternalWritePrefix starts the line warning that a tool wrote a file outside
+// the session's worktree. Such lines are rendered as warnings.
+const ExternalWritePrefix = "⚠ wrote outside worktree:"
+
+// modelSwitchPrefix starts the transcript message marking where a session
+// switched models (see ModelSwitchMarker).
+const modelSwitchPrefix = "— switched to "
+
+// ModelSwitchMarker returns the transcript message marking where a session
+// switched to model, "" being the CLI's default. It is shown as a muted divider
+// without a role label.
+func ModelSwitchMarker(model string) string {
+	if model == "" {
+		model = "default model"
+	}
+	return modelSwitchPrefix + model + " —"
+}
+
+// isModelSwitchMarker returns whether a message marks a model switch. Markers
+// saved before system messages existed are assistant messages.
+func isModelSwitchMarker(msg pclaude.Message) bool {
+	if msg.Kind == pclaude.KindModelSwitch {
+		return true
+	}
+	return msg.Role == "assistant" && strings.HasPrefix(msg.Content, modelSwitchPrefix) &&
+		strings.HasSuffix(msg.Content, " —") && !strings.Contains(msg.Content, "\n")
+}
+
+// renderMarkdownLine renders a single line with markdown formatting. listDepth
+// is how deeply a list item line is nested in other items, 0 for the top level.
+func renderMarkdownLine(line string, width, listDepth int) string {
 	trimmed := strings.TrimSpace(line)
 
+	if strings.HasPrefix(trimmed, AutoAnsweredPrefix) || strings.HasPrefix(trimmed, AutoApprovedPrefix) || strings.HasPrefix(trimmed, modelSwitchPrefix) ||
+		strings.HasPrefix(trimmed, HookOutputPrefix) {
+		return lipgloss.NewStyle().Foreground(ColorTextMuted).Italic(true).Render(wrapText(trimmed, width))
+	}
+	if strings.HasPrefix(trimmed, ExternalWritePrefix) {
+		return lipgloss.NewStyle().Foreground(ColorWarning).Bold(true).Render(wrapText(trimmed, width))
+	}
+
 	// Headers - don't wrap, they should be concise
 	if after, ok := strings.CutPrefix(trimmed, "#### "); ok {
 		return MarkdownH4Style.Render(after)
@@ -460,18 +604,19 @@ func renderMarkdownLine(line string, width int) string {
 	if strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") {
 		content := trimmed[2:]
 		bullet := MarkdownListBulletStyle.Render("•")
+		nesting := listDepth * ListNestingIndent
 		// Wrap list item content, accounting for the prefix width "  • " = 4 chars
-		wrapped := wrapText(renderInlineMarkdown(content), width-ListItemPrefixWidth)
+		wrapped := wrapText(renderInlineMarkdown(content), width-nesting-ListItemPrefixWidth)
 		// Indent continuation lines to align with first line content
 		lines := strings.Split(wrapped, "\n")
 		if len(lines) > 1 {
-			indent := strings.Repeat(" ", ListItemContinuationIndent)
+			indent := strings.Repeat(" ", nesting+ListItemContinuationIndent)
 			for i := 1; i < len(lines); i++ {
 				lines[i] = indent + lines[i]
 			}
 			wrapped = strings.Join(lines, "\n")
 		}
-		return "  " + bullet + " " + wrapped
+		return strings.Repeat(" ", nesting) + "  " + bullet + " " + wrapped
 	}
 
 	// Numbered list items
@@ -486,18 +631,19 @@ func renderMarkdownLine(line string, width int) string {
 			if i >= 10 {
 				prefixWidth = NumberedListPrefixWidth + 1
 			}
+			nesting := listDepth * ListNestingIndent
 			// Wrap list item content, accounting for the prefix width
-			wrapped := wrapText(renderInlineMarkdown(content), width-prefixWidth)
+			wrapped := wrapText(renderInlineMarkdown(content), width-nesting-prefixWidth)
 			// Indent continuation lines to align with first line content
 			lines := strings.Split(wrapped, "\n")
 			if len(lines) > 1 {
-				indent := strings.Repeat(" ", prefixWidth)
+				indent := strings.Repeat(" ", nesting+prefixWidth)
 				for j := 1; j < len(lines); j++ {
 					lines[j] = indent + lines[j]
 				}
 				wrapped = strings.Join(lines, "\n")
 			}
-			return "  " + number + " " + wrapped
+			return strings.Repeat(" ", nesting) + "  " + number + " " + wrapped
 		}
 	}
 
@@ -505,6 +651,46 @@ func renderMarkdownLine(line string, width int) string {
 	return wrapText(renderInlineMarkdown(line), width)
 }
 
+// listItemIndent returns the width of the whitespace before a list item's
+// marker, tabs counting as 4 spaces, and whether the line is a list item.
+func listItemIndent(line string) (int, bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(trimmed, "- ") && !strings.HasPrefix(trimmed, "* ") && !isNumberedListItem(trimmed) {
+		return 0, false
+	}
+	indent := 0
+	for _, r := range line[:len(line)-len(trimmed)] {
+		if r == '\t' {
+			indent += 4
+		} else {
+			indent++
+		}
+	}
+	return indent, true
+}
+
+// isNumberedListItem returns whether a trimmed line starts with a numbered list
+// marker renderMarkdownLine renders, "1. " to "99. ".
+func isNumberedListItem(trimmed string) bool {
+	number, _, ok := strings.Cut(trimmed, ". ")
+	if !ok || len(number) == 0 || len(number) > 2 {
+		return false
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	n, _ := strconv.Atoi(number)
+	return n >= 1 && n <= 99 && strconv.Itoa(n) == number
+}
+
+// RenderMarkdown renders markdown as the chat renders Claude's responses, for
+// showing markdown elsewhere (e.g., release notes).
+func RenderMarkdown(content string, width int) string {
+	return renderMarkdown(content, width)
+}
+
 // renderMarkdown renders markdown content with syntax-highlighted code blocks
 func renderMarkdown(content string, width int) string {
 	if width <= 0 {
@@ -522,6 +708,10 @@ func renderMarkdown(content string, width int) string {
 	var tableRows [][]string
 	tableHasHeader := false
 
+	// Leading whitespace of the list items enclosing the current line, outermost
+	// first, so nesting is found whether lists indent 2 or 4 spaces per level
+	var listIndents []int
+
 	// Helper function to flush table
 	flushTable := func() {
 		if len(tableRows) > 0 {
@@ -600,8 +790,20 @@ func renderMarkdown(content string, width int) string {
 			flushTable()
 		}
 
+		// Track list nesting; unindented text ends the list
+		listDepth := 0
+		if indent, ok := listItemIndent(line); ok {
+			for len(listIndents) > 0 && listIndents[len(listIndents)-1] >= indent {
+				listIndents = listIndents[:len(listIndents)-1]
+			}
+			listDepth = len(listIndents)
+			listIndents = append(listIndents, indent)
+		} else if line != "" && !unicode.IsSpace(rune(line[0])) {
+			listIndents = nil
+		}
+
 		// Render markdown line with wrapping
-		result.WriteString(renderMarkdownLine(line, width))
+		result.WriteString(renderMarkdownLine(line, width, listDepth))
 		result.WriteString("\n")
 	}
 
@@ -659,7 +861,7 @@ func renderPermissionPrompt(tool, description string, wrapWidth int) string {
 	sb.WriteString("\n\n")
 
 	// Keyboard hints - compact horizontal layout
-	keyStyle := lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(ColorWarningText).Bold(true)
 	hintStyle := PermissionHintStyle
 
 	sb.WriteString(keyStyle.Render("[y]"))
@@ -672,6 +874,67 @@ func renderPermissionPrompt(tool, description string, wrapWidth int) string {
 	return PermissionBoxStyle.Width(boxWidth).Render(sb.String())
 }
 
+// renderDestructivePermissionPrompt renders the permission prompt for a Bash
+// command with destructive parts: red, with the full command and its
+// destructive parts highlighted, and allowed only by typing
+// DestructiveConfirmPhrase.
+func renderDestructivePermissionPrompt(p *PendingPermission, wrapWidth int) string {
+	var sb strings.Builder
+	boxWidth := min(wrapWidth, OverlayBoxMaxWidth)
+	contentWidth := boxWidth - OverlayBoxPadding
+
+	sb.WriteString(DestructiveTitleStyle.Render("⚠ Destructive Command: "))
+	sb.WriteString(PermissionToolStyle.Render(p.Tool))
+	sb.WriteString("\n")
+
+	// Why it's destructive, once per reason
+	var reasons []string
+	for _, m := range p.Destructive {
+		if !slices.Contains(reasons, m.Reason) {
+			reasons = append(reasons, m.Reason)
+		}
+	}
+	for _, reason := range reasons {
+		sb.WriteString(DestructiveTitleStyle.Render(wrapText("• "+reason, contentWidth)))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(wrapText(highlightDestructive(p.Command, p.Destructive), contentWidth))
+	sb.WriteString("\n\n")
+
+	keyStyle := lipgloss.NewStyle().Foreground(ColorError).Bold(true)
+	sb.WriteString(PermissionHintStyle.Render("Type "))
+	sb.WriteString(keyStyle.Render(DestructiveConfirmPhrase))
+	sb.WriteString(PermissionHintStyle.Render(" and press enter to run it  "))
+	sb.WriteString(keyStyle.Render("[n]"))
+	sb.WriteString(PermissionHintStyle.Render(" Deny"))
+	sb.WriteString("\n")
+	sb.WriteString(keyStyle.Render("> "))
+	sb.WriteString(PermissionToolStyle.Render(p.Confirmation))
+	sb.WriteString(PermissionHintStyle.Render("█"))
+
+	return DestructiveBoxStyle.Width(boxWidth).Render(sb.String())
+}
+
+// highlightDestructive returns command with the parts matches cover
+// highlighted, merging matches that overlap.
+func highlightDestructive(command string, matches []pclaude.DestructiveMatch) string {
+	var sb strings.Builder
+	pos := 0
+	for _, m := range matches {
+		start, end := max(m.Start, pos), min(m.End, len(command))
+		if start >= end {
+			continue
+		}
+		sb.WriteString(PermissionDescStyle.Render(command[pos:start]))
+		sb.WriteString(DestructiveHighlightStyle.Render(command[start:end]))
+		pos = end
+	}
+	sb.WriteString(PermissionDescStyle.Render(command[pos:]))
+	return sb.String()
+}
+
 // renderTodoList renders the todo list from a TodoWrite tool call
 func renderTodoList(list *pclaude.TodoList, wrapWidth int) string {
 	if list == nil || len(list.Items) == 0 {
@@ -684,7 +947,7 @@ func renderTodoList(list *pclaude.TodoList, wrapWidth int) string {
 	_, _, completed := list.CountByStatus()
 	total := len(list.Items)
 
-	titleStyle := lipgloss.NewStyle().Foreground(ColorInfo).Bold(true)
+	titleStyle := lipgloss.NewStyle().Foreground(ColorInfoText).Bold(true)
 	sb.WriteString(titleStyle.Render("Task Progress"))
 
 	// Progress indicator
@@ -747,9 +1010,11 @@ func renderTodoList(list *pclaude.TodoList, wrapWidth int) string {
 
 // renderTodoListForSidebar renders the todo list without a box border.
 // Used when the todo list is displayed in a sidebar panel that already has borders.
-func renderTodoListForSidebar(list *pclaude.TodoList, wrapWidth int) string {
+// The item at cursor is highlighted (-1 for none); the line it starts on is
+// returned with the content, for scrolling it into view.
+func renderTodoListForSidebar(list *pclaude.TodoList, wrapWidth, cursor int) (string, int) {
 	if list == nil || len(list.Items) == 0 {
-		return ""
+		return "", 0
 	}
 
 	var sb strings.Builder
@@ -758,7 +1023,7 @@ func renderTodoListForSidebar(list *pclaude.TodoList, wrapWidth int) string {
 	_, _, completed := list.CountByStatus()
 	total := len(list.Items)
 
-	titleStyle := lipgloss.NewStyle().Foreground(ColorInfo).Bold(true)
+	titleStyle := lipgloss.NewStyle().Foreground(ColorInfoText).Bold(true)
 	sb.WriteString(titleStyle.Render("Tasks"))
 
 	// Progress indicator
@@ -767,21 +1032,33 @@ func renderTodoListForSidebar(list *pclaude.TodoList, wrapWidth int) string {
 	sb.WriteString("\n\n")
 
 	// Render each todo item
-	for _, item := range list.Items {
+	cursorLine := 0
+	for idx, item := range list.Items {
 		var marker string
 		var contentStyle lipgloss.Style
 
-		switch item.Status {
-		case pclaude.TodoStatusCompleted:
+		switch {
+		case item.UserMarked && item.Status == pclaude.TodoStatusCompleted:
+			// Marked done by the user rather than Claude
+			marker = TodoUserMarkedMarkerStyle.Render("✓")
+			contentStyle = TodoUserMarkedContentStyle.Strikethrough(true)
+		case item.UserMarked:
+			marker = TodoUserMarkedMarkerStyle.Render("○")
+			contentStyle = TodoUserMarkedContentStyle
+		case item.Status == pclaude.TodoStatusCompleted:
 			marker = TodoCompletedMarkerStyle.Render("✓")
 			contentStyle = TodoCompletedContentStyle
-		case pclaude.TodoStatusInProgress:
+		case item.Status == pclaude.TodoStatusInProgress:
 			marker = TodoInProgressMarkerStyle.Render("▸")
 			contentStyle = TodoInProgressContentStyle
 		default: // pending
 			marker = TodoPendingMarkerStyle.Render("○")
 			contentStyle = TodoPendingContentStyle
 		}
+		if idx == cursor {
+			contentStyle = contentStyle.Reverse(true)
+			cursorLine = strings.Count(sb.String(), "\n")
+		}
 
 		sb.WriteString(marker)
 		sb.WriteString(" ")
@@ -812,5 +1089,52 @@ func renderTodoListForSidebar(list *pclaude.TodoList, wrapWidth int) string {
 	}
 
 	// Apply padding but no border (sidebar panel has its own border)
-	return lipgloss.NewStyle().Padding(0, 1).Render(sb.String())
+	return lipgloss.NewStyle().Padding(0, 1).Render(sb.String()), cursorLine
+}
+
+// renderRoleLabel renders the "You:"/"Claude:" label shown above a message, or
+// the label of a system message.
+func renderRoleLabel(role string) string {
+	switch role {
+	case "user":
+		return ChatUserStyle.Render("You:")
+	case pclaude.RoleSystem:
+		return renderSystemLabel("")
+	}
+	return ChatAssistantStyle.Render("Claude:")
+}
+
+// systemKindIcons are the icons labelling system messages, by kind.
+var systemKindIcons = map[string]string{
+	pclaude.KindCommand: "›",
+	pclaude.KindMerge:   "⎇",
+}
+
+// renderSystemLabel renders the muted, icon-prefixed label shown above a system
+// message of the given kind.
+func renderSystemLabel(kind string) string {
+	icon, ok := systemKindIcons[kind]
+	if !ok {
+		icon = "ⓘ"
+	}
+	return lipgloss.NewStyle().Foreground(ColorTextMuted).Bold(true).Render(icon + " Plural:")
+}
+
+// renderSystemContent renders the content of a system message muted. Lines
+// markdown styled, such as code, keep their own colors.
+func renderSystemContent(content string, width int) string {
+	muted := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	lines := strings.Split(renderMarkdown(content, width), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = muted.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RenderScrollbackMessage renders a message the way the chat panel does, for printing
+// to the terminal's scrollback in the inline layout.
+func RenderScrollbackMessage(role, content string, width int) string {
+	return renderRoleLabel(role) + "\n" + renderMarkdown(strings.TrimSpace(content), width) + "\n"
 }
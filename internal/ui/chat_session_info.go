@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// SessionInfo is what the chat shows about the open session above its
+// conversation. It comes from the session's config rather than its message
+// history, so it shows current values even if the worktree is recreated.
+type SessionInfo struct {
+	Repo     string // Repo name
+	Branch   string
+	Worktree string // Path of the worktree
+	Base     string // Branch the session was created from, "" if unknown
+	Claude   string // Claude CLI version the session last ran with, "" if unknown
+	Denied   string // Tools the repo denies, comma-separated, "" if none
+}
+
+// sessionInfoField is a labelled value of the session info block.
+type sessionInfoField struct {
+	label string
+	value string
+}
+
+// fields returns the fields of the session info block in order, leaving out empty ones.
+func (i *SessionInfo) fields() []sessionInfoField {
+	var fields []sessionInfoField
+	for _, f := range []sessionInfoField{
+		{"Repo", i.Repo},
+		{"Branch", i.Branch},
+		{"Worktree", i.Worktree},
+		{"Base", i.Base},
+		{"Claude", i.Claude},
+		{"Denied", i.Denied},
+	} {
+		if f.value != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// sessionInfoLabelWidth is the width labels are padded to, so values line up.
+const sessionInfoLabelWidth = len("Worktree") + 2
+
+// sessionInfoValue is where a value of the session info block was rendered:
+// its first and last line, from the top of the block, and the byte columns it
+// starts on the first line and ends on the last.
+type sessionInfoValue struct {
+	sessionInfoField
+	first, last      int
+	startCol, endCol int
+}
+
+// renderSessionInfo renders the session info block to fit width. Each field is
+// its label and value on one line, separated only by spaces, so selecting the
+// value picks up nothing else. A value too long for that goes on the lines
+// below its label, broken at width without splitting characters.
+func renderSessionInfo(info *SessionInfo, width int) (string, []sessionInfoValue) {
+	labelStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	valueStyle := lipgloss.NewStyle().Foreground(ColorText)
+	width = max(width, 1)
+
+	var lines []string
+	var values []sessionInfoValue
+	for _, f := range info.fields() {
+		if sessionInfoLabelWidth+ansi.StringWidth(f.value) <= width {
+			label := f.label + strings.Repeat(" ", sessionInfoLabelWidth-len(f.label))
+			values = append(values, sessionInfoValue{
+				sessionInfoField: f,
+				first:            len(lines),
+				last:             len(lines),
+				startCol:         sessionInfoLabelWidth,
+				endCol:           sessionInfoLabelWidth + len(f.value),
+			})
+			lines = append(lines, labelStyle.Render(label)+valueStyle.Render(f.value))
+			continue
+		}
+		lines = append(lines, labelStyle.Render(f.label))
+		parts := strings.Split(ansi.Hardwrap(f.value, width, true), "\n")
+		values = append(values, sessionInfoValue{
+			sessionInfoField: f,
+			first:            len(lines),
+			last:             len(lines) + len(parts) - 1,
+			endCol:           len(parts[len(parts)-1]),
+		})
+		for _, part := range parts {
+			lines = append(lines, valueStyle.Render(part))
+		}
+	}
+	return strings.Join(lines, "\n"), values
+}
+
+// SetSessionInfo sets what the chat shows about the open session above its
+// conversation, or nil to show nothing.
+func (c *Chat) SetSessionInfo(info *SessionInfo) {
+	c.sessionInfo = info
+	c.updateContentKeepingScroll()
+}
+
+// copySessionInfoValue copies the session info value shown on a line of the
+// viewport, exactly as it is rather than as wrapped, and highlights it.
+// Returns nil if the line shows none.
+func (c *Chat) copySessionInfoValue(line int) tea.Cmd {
+	offset := c.viewport.YOffset()
+	for _, v := range c.sessionInfoValues {
+		if line+offset < v.first || line+offset > v.last {
+			continue
+		}
+		// Columns are offset by the content's left padding and margin
+		left := c.contentMargin + ContentPadding/2
+		c.selection.StartLine = v.first - offset
+		c.selection.StartCol = v.startCol + left
+		c.selection.EndLine = v.last - offset
+		c.selection.EndCol = v.endCol + left
+		c.selection.Active = false
+		c.selection.FlashFrame = 0
+		return tea.Batch(
+			CopyToClipboard(v.value, "Copied "+strings.ToLower(v.label)),
+			SelectionFlashTick(),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func testSessionInfo() *SessionInfo {
+	return &SessionInfo{
+		Repo:     "plural",
+		Branch:   "zhubert/fix-login",
+		Worktree: "/Users/zhubert/.local/share/plural/worktrees/0f4c9a5e-6a1d-4c33-9d2b-1f7c2b8e4a10",
+		Base:     "main",
+	}
+}
+
+// contentLines returns the chat's rendered content without styling or the
+// left padding, line by line.
+func contentLines(chat *Chat) []string {
+	var lines []string
+	for line := range strings.SplitSeq(ansi.Strip(chat.viewport.GetContent()), "\n") {
+		lines = append(lines, strings.TrimRight(strings.TrimPrefix(line, " "), " "))
+	}
+	return lines
+}
+
+func TestChat_SessionInfoAboveConversation(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(160, 30)
+	chat.SetSession("test", nil)
+	chat.SetSessionInfo(testSessionInfo())
+
+	lines := contentLines(chat)
+	want := []string{
+		"Repo      plural",
+		"Branch    zhubert/fix-login",
+		"Worktree  /Users/zhubert/.local/share/plural/worktrees/0f4c9a5e-6a1d-4c33-9d2b-1f7c2b8e4a10",
+		"Base      main",
+		"",
+	}
+	for i, w := range want {
+		if i >= len(lines) || lines[i] != w {
+			t.Fatalf("line %d = %q, want %q\n%s", i, lines[i], w, strings.Join(lines, "\n"))
+		}
+	}
+	if !strings.Contains(lines[5], "Start a conversation") {
+		t.Errorf("expected the placeholder below the info, got %q", lines[5])
+	}
+
+	// Messages, and the lines bookmarks jump to, start below it
+	chat.AddUserMessage("fix the login bug")
+	if chat.messageStartLines[0] != 5 || !strings.HasPrefix(contentLines(chat)[5], "You") {
+		t.Errorf("expected the first message on line 5, got %d", chat.messageStartLines[0])
+	}
+
+	// Another session's info is not carried over
+	chat.SetSession("other", nil)
+	if strings.Contains(ansi.Strip(chat.viewport.GetContent()), "fix-login") {
+		t.Error("expected the info cleared with the session")
+	}
+}
+
+func TestChat_SessionInfoNarrowWidth(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(32, 30)
+	chat.SetSession("test", nil)
+	info := testSessionInfo()
+	info.Worktree = "/tmp/wörktrees/日本語/0f4c9a5e-6a1d-4c33-9d2b-1f7c2b8e4a10"
+	chat.SetSessionInfo(info)
+
+	lines := contentLines(chat)
+	width := chat.viewport.Width() - ContentPadding
+	start := -1
+	for i, line := range lines {
+		if line == "" {
+			break // End of the info
+		}
+		if ansi.StringWidth(line) > width {
+			t.Errorf("line %q is wider than %d", line, width)
+		}
+		if line == "Worktree" {
+			start = i + 1
+		}
+	}
+	if start < 0 {
+		t.Fatalf("expected the worktree label on its own line:\n%s", strings.Join(lines, "\n"))
+	}
+	// The path's pieces join back into it, with no character split
+	var path string
+	for _, line := range lines[start:] {
+		if line == "Base      main" {
+			break
+		}
+		path += line
+	}
+	if path != info.Worktree {
+		t.Errorf("wrapped path = %q, want %q", path, info.Worktree)
+	}
+
+	// Triple-clicking any of its lines copies the whole path and highlights it
+	if chat.copySessionInfoValue(start+1) == nil {
+		t.Fatal("expected the worktree path copied")
+	}
+	selStart, selLine, _, selEndLine := chat.selectionArea()
+	if selLine != start || selEndLine <= start || selStart != 1 {
+		t.Errorf("expected the wrapped path highlighted from line %d, got lines %d-%d from column %d", start, selLine, selEndLine, selStart)
+	}
+	if chat.copySessionInfoValue(len(lines)+5) != nil {
+		t.Error("expected nothing copied below the info")
+	}
+}
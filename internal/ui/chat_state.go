@@ -6,15 +6,31 @@ import (
 	"charm.land/bubbles/v2/spinner"
 	"charm.land/bubbles/v2/viewport"
 	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/activity"
+	pclaude "github.com/zhubert/plural/internal/claude"
 	"github.com/zhubert/plural/internal/git"
 	"github.com/zhubert/plural/internal/mcp"
 )
 
+// DestructiveConfirmPhrase is what the user types to allow a destructive
+// command, so it can't be allowed by a stray keystroke.
+const DestructiveConfirmPhrase = "allow"
+
+// maxConfirmationLen caps what can be typed into a destructive permission
+// prompt, well past anything but a mistyped phrase.
+const maxConfirmationLen = 20
+
 // PendingPermission tracks an awaited permission response from the user.
 // Non-nil when a permission prompt is displayed.
 type PendingPermission struct {
 	Tool        string // Tool name requesting permission (e.g., "Bash")
 	Description string // Description of what the tool wants to do
+
+	// Set for Bash commands classified as destructive, which are allowed only
+	// by typing DestructiveConfirmPhrase
+	Command      string                     // The full command
+	Destructive  []pclaude.DestructiveMatch // The command's destructive parts
+	Confirmation string                     // What the user has typed so far
 }
 
 // PendingQuestion tracks an awaited question response from the user.
@@ -24,6 +40,7 @@ type PendingQuestion struct {
 	CurrentIdx     int               // Index of question currently being answered
 	SelectedOption int               // Currently highlighted option (0-indexed)
 	Answers        map[string]string // Collected answers (question text -> selected label)
+	Preselected    map[string]string // Answers suggested by auto-answer rules (question text -> label)
 }
 
 // NewPendingQuestion creates a new PendingQuestion for the given questions.
@@ -44,6 +61,30 @@ func (p *PendingQuestion) CurrentQuestion() *mcp.Question {
 	return &p.Questions[p.CurrentIdx]
 }
 
+// preselectedOption returns the index of the option pre-selected for the current
+// question, or -1 if none is.
+func (p *PendingQuestion) preselectedOption() int {
+	q := p.CurrentQuestion()
+	if q == nil {
+		return -1
+	}
+	label, ok := p.Preselected[q.Question]
+	if !ok {
+		return -1
+	}
+	for i, opt := range q.Options {
+		if opt.Label == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// defaultOption returns the option to highlight when a question is shown.
+func (p *PendingQuestion) defaultOption() int {
+	return max(p.preselectedOption(), 0)
+}
+
 // IsComplete returns true if all questions have been answered.
 func (p *PendingQuestion) IsComplete() bool {
 	return p.CurrentIdx >= len(p.Questions)
@@ -103,6 +144,10 @@ type ViewChangesState struct {
 	Viewport  viewport.Model // Viewport for diff scrolling
 	Files     []git.FileDiff // List of files with diffs
 	FileIndex int            // Currently selected file index
+	Header    string         // Optional summary line shown above the file navigation bar
+	Split     bool           // Whether diffs are shown side by side (unified when too narrow)
+	// Viewport width the diff was last rendered at, to re-render a split diff on resize
+	RenderedWidth int
 }
 
 // LogFile represents a log file for display in the log viewer.
@@ -121,6 +166,29 @@ type LogViewerState struct {
 	FollowTail bool           // Whether to auto-scroll to bottom on updates
 }
 
+// LiveDiffState tracks the live worktree diff view.
+// Non-nil when the chat panel shows the diff instead of the conversation.
+type LiveDiffState struct {
+	Viewport  viewport.Model // Viewport for diff scrolling
+	Diff      string         // Raw diff last shown, to skip re-rendering when unchanged
+	Error     string         // Error reading the worktree, shown instead of the diff
+	UpdatedAt time.Time      // When the diff was last refreshed (zero until the first refresh)
+
+	// Hunks changed since the previous refresh, highlighted for a moment
+	Baseline       *string      // Diff to compare the first refresh with (nil for none)
+	Changed        map[int]bool // Indexes of the lines of the changed hunks
+	ChangedHunks   int
+	HighlightUntil time.Time
+}
+
+// ActivityFeedState tracks the global activity feed overlay state.
+// Non-nil when the activity feed is displayed.
+type ActivityFeedState struct {
+	Viewport      viewport.Model   // Viewport for feed scrolling
+	Events        []activity.Event // Events to display, newest first
+	SelectedIndex int              // Currently highlighted event
+}
+
 // PendingImage tracks an attached image waiting to be sent.
 // Non-nil when an image is attached.
 type PendingImage struct {
@@ -135,10 +203,25 @@ func (p *PendingImage) SizeKB() int {
 
 // SpinnerState tracks the waiting/streaming spinner animation.
 type SpinnerState struct {
-	Model      spinner.Model // Bubbles spinner for frame animation
-	Verb       string        // Random verb to display while waiting (e.g., "Thinking")
-	StartTime  time.Time
-	FlashFrame int // Completion flash animation: -1 = inactive, 0-2 = animation frames
+	Model       spinner.Model // Bubbles spinner for frame animation
+	Verb        string        // Random verb to display while waiting (e.g., "Thinking")
+	StartTime   time.Time
+	FlashFrame  int  // Completion flash animation: -1 = inactive, otherwise the frame showing
+	FlashFrames int  // Frames the completion flash lasts, the last one blank; 0 disables it
+	FlashStats  bool // Show the final stats in the completion flash
+}
+
+// flashPhase returns what renderCompletionFlash draws for the current frame: 0
+// (bright) for the first, 1 (normal) until the last, and 2 (blank) for the last.
+func (s *SpinnerState) flashPhase() int {
+	switch {
+	case s.FlashFrame == 0:
+		return 0
+	case s.FlashFrame < s.FlashFrames-1:
+		return 1
+	default:
+		return 2
+	}
 }
 
 // NewSpinnerState creates a new SpinnerState.
@@ -148,7 +231,9 @@ func NewSpinnerState() *SpinnerState {
 		spinner.WithStyle(lipgloss.NewStyle().Foreground(ColorUser).Bold(true)),
 	)
 	return &SpinnerState{
-		Model:      sp,
-		FlashFrame: -1,
+		Model:       sp,
+		FlashFrame:  -1,
+		FlashFrames: 3,
+		FlashStats:  true,
 	}
 }
@@ -1,9 +1,13 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"slices"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -11,6 +15,7 @@ import (
 	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/zhubert/plural/internal/claude"
 	"github.com/zhubert/plural/internal/mcp"
 )
@@ -495,7 +500,7 @@ func TestRenderMarkdownLine(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := renderMarkdownLine(tt.line, tt.width)
+			result := renderMarkdownLine(tt.line, tt.width, 0)
 			if !tt.check(result) {
 				t.Errorf("renderMarkdownLine(%q, %d) = %q, check failed", tt.line, tt.width, result)
 			}
@@ -541,6 +546,41 @@ func TestRenderInlineMarkdown(t *testing.T) {
 			line:  "Just plain text",
 			check: func(s string) bool { return strings.Contains(s, "Just plain text") },
 		},
+		{
+			name:  "strikethrough",
+			line:  "This is ~~wrong~~ right",
+			check: func(s string) bool { return !strings.Contains(s, "~~") && strings.Contains(ansi.Strip(s), "wrong") },
+		},
+		{
+			name:  "highlight",
+			line:  "This is ==important== text",
+			check: func(s string) bool { return !strings.Contains(s, "==") && ansi.Strip(s) == "This is important text" },
+		},
+		{
+			name:  "comparison operators are not highlighted",
+			line:  "if a == b == c",
+			check: func(s string) bool { return s == "if a == b == c" },
+		},
+		{
+			name:  "unspaced comparison is not highlighted",
+			line:  "a==b",
+			check: func(s string) bool { return s == "a==b" },
+		},
+		{
+			name:  "unspaced comparisons are not highlighted",
+			line:  "x==1 && y==2",
+			check: func(s string) bool { return s == "x==1 && y==2" },
+		},
+		{
+			name:  "highlight after a rejected span",
+			line:  "x==1 and ==this==",
+			check: func(s string) bool { return ansi.Strip(s) == "x==1 and this" && s != ansi.Strip(s) },
+		},
+		{
+			name:  "strikethrough inside code is literal",
+			line:  "Run `echo ~~x~~`",
+			check: func(s string) bool { return strings.Contains(ansi.Strip(s), "echo ~~x~~") },
+		},
 	}
 
 	for _, tt := range tests {
@@ -553,6 +593,60 @@ func TestRenderInlineMarkdown(t *testing.T) {
 	}
 }
 
+func TestRenderInlineMarkdown_StrikethroughFallback(t *testing.T) {
+	orig := strikethroughSupported
+	defer func() { strikethroughSupported = orig }()
+
+	strikethroughSupported = true
+	if got := renderInlineMarkdown("~~old~~ new"); !strings.Contains(got, "\x1b[") || ansi.Strip(got) != "old new" {
+		t.Errorf("Expected strikethrough attribute, got %q", got)
+	}
+
+	strikethroughSupported = false
+	if got := renderInlineMarkdown("~~old~~ new"); got != "[-old-] new" {
+		t.Errorf("Expected bracketed fallback, got %q", got)
+	}
+}
+
+func TestTerminalSupportsStrikethrough(t *testing.T) {
+	tests := []struct {
+		term string
+		want bool
+	}{
+		{"xterm-256color", true},
+		{"screen-256color", true},
+		{"", true},
+		{"linux", false},
+		{"dumb", false},
+		{"vt100", false},
+	}
+	for _, tt := range tests {
+		if got := terminalSupportsStrikethrough(tt.term); got != tt.want {
+			t.Errorf("terminalSupportsStrikethrough(%q) = %v, want %v", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestRenderMarkdownLine_WrapsStyledInlineSpans(t *testing.T) {
+	orig := strikethroughSupported
+	defer func() { strikethroughSupported = orig }()
+	strikethroughSupported = true
+
+	line := "Keep ==this highlighted phrase== and drop ~~that struck phrase~~ before wrapping"
+	width := 20
+	result := renderMarkdownLine(line, width, 0)
+
+	for _, l := range strings.Split(result, "\n") {
+		if w := ansi.StringWidth(l); w > width {
+			t.Errorf("Line %q has visible width %d, want <= %d", ansi.Strip(l), w, width)
+		}
+	}
+	// Wrapping only moves words between lines; the visible text is unchanged
+	if got := strings.Join(strings.Fields(ansi.Strip(result)), " "); got != "Keep this highlighted phrase and drop that struck phrase before wrapping" {
+		t.Errorf("Unexpected wrapped text %q", got)
+	}
+}
+
 func TestRenderInlineMarkdown_NoPanicOnEdgeCases(t *testing.T) {
 	// Regression tests: renderInlineMarkdown must not panic on edge-case inputs.
 	// These exercise the bounds checks added to FindStringSubmatch results.
@@ -575,6 +669,14 @@ func TestRenderInlineMarkdown_NoPanicOnEdgeCases(t *testing.T) {
 		"**bold without close",
 		"_italic without close",
 		"[link without close",
+		"~~",
+		"~~~~",
+		"~~ ~~",
+		"==",
+		"====",
+		"== ==",
+		"~~strike without close",
+		"==highlight without close",
 	}
 	for _, input := range edgeCases {
 		t.Run(input, func(t *testing.T) {
@@ -1210,6 +1312,36 @@ func TestChat_ToolUseMarkers(t *testing.T) {
 	}
 }
 
+func TestChat_WaitingBashHint(t *testing.T) {
+	chat := NewChat()
+	chat.SetSession("test", nil)
+	chat.AppendToolUse("Read", "file.go", "tool-1")
+	chat.AppendToolUse("Bash", "npm run migrate", "tool-2")
+
+	if strings.Contains(ansi.Strip(chat.renderToolUseRollup()), "waiting for input") {
+		t.Error("expected no hint for a command just started")
+	}
+
+	// A Bash command long without a result may be waiting for input
+	chat.GetToolUseRollup().Items[0].StartedAt = time.Now().Add(-time.Minute)
+	chat.GetToolUseRollup().Items[1].StartedAt = time.Now().Add(-45 * time.Second)
+	rendered := ansi.Strip(chat.renderToolUseRollup())
+	if !strings.Contains(rendered, "No result from Bash for 45s") || !strings.Contains(rendered, "Esc interrupts") {
+		t.Errorf("expected the waiting-for-input hint, got:\n%s", rendered)
+	}
+
+	chat.MarkToolUseComplete("tool-2", nil)
+	if strings.Contains(ansi.Strip(chat.renderToolUseRollup()), "waiting for input") {
+		t.Error("expected no hint once the command finished")
+	}
+
+	// The hint is only shown live, not kept in the response
+	chat.AppendStreaming("Done")
+	if strings.Contains(chat.GetStreaming(), "waiting for input") {
+		t.Error("expected the hint left out of the streamed response")
+	}
+}
+
 func TestChat_ToolUseRollupResetOnFinishStreaming(t *testing.T) {
 	// This tests that tool use rollup is flushed and reset when FinishStreaming is called,
 	// preventing stale tool use state from affecting subsequent streaming content.
@@ -1354,8 +1486,9 @@ func TestChat_ToolUseRollupRenderExpanded(t *testing.T) {
 	// Expand the rollup
 	chat.ToggleToolUseRollup()
 
-	// Render the rollup
-	rendered := chat.renderToolUseRollup()
+	// Render the chat: the newest tool use stays inline, and all of them are
+	// listed in the region below the conversation
+	rendered := ansi.Strip(chat.View())
 
 	// Should show all tool uses when expanded
 	if !strings.Contains(rendered, "main.go") {
@@ -2063,6 +2196,58 @@ func TestChat_PendingPermission(t *testing.T) {
 	}
 }
 
+func TestChat_PendingDestructivePermission(t *testing.T) {
+	chat := NewChat()
+	chat.SetSession("test", nil)
+
+	command := "cd /tmp && rm -rf build"
+	matches := []claude.DestructiveMatch{{Start: 11, End: 23, Reason: "recursively deletes build, which isn't inside the worktree"}}
+	chat.SetPendingDestructivePermission("Bash", "Run: "+command, command, matches)
+	if !chat.HasPendingPermission() || !chat.HasDestructivePermission() {
+		t.Fatal("expected a pending destructive permission")
+	}
+
+	for _, key := range []string{"a", "l", "x", "backspace", "l", "o", "w"} {
+		chat.TypePermissionConfirmation(key)
+	}
+	chat.TypePermissionConfirmation("ctrl+a") // Not a character
+	if chat.permission.Confirmation != "allow" || !chat.PermissionConfirmed() {
+		t.Errorf("expected the typed phrase to confirm, got %q", chat.permission.Confirmation)
+	}
+	chat.TypePermissionConfirmation("s")
+	if chat.PermissionConfirmed() {
+		t.Error("expected only the exact phrase to confirm")
+	}
+
+	view := ansi.Strip(renderDestructivePermissionPrompt(chat.permission, 80))
+	for _, want := range []string{"Destructive Command: Bash", "recursively deletes build", command, "Type allow and press enter", "> allows"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the destructive prompt, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "[a]") || strings.Contains(view, "[y]") {
+		t.Error("expected no single-key allow in the destructive prompt")
+	}
+
+	chat.SetPendingPermission("Bash", "Run: ls")
+	if chat.HasDestructivePermission() || chat.PermissionConfirmed() {
+		t.Error("expected an ordinary prompt to replace the destructive one")
+	}
+	chat.TypePermissionConfirmation("a") // Ignored without a destructive prompt
+}
+
+func TestHighlightDestructive(t *testing.T) {
+	command := "git push -f && rm -rf /"
+	matches := []claude.DestructiveMatch{{Start: 0, End: 11}, {Start: 5, End: 9}, {Start: 15, End: 23}}
+	got := highlightDestructive(command, matches)
+	if ansi.Strip(got) != command {
+		t.Errorf("expected the whole command kept, got %q", ansi.Strip(got))
+	}
+	if !strings.Contains(got, DestructiveHighlightStyle.Render("git push -f")) || !strings.Contains(got, DestructiveHighlightStyle.Render("rm -rf /")) {
+		t.Errorf("expected the destructive parts highlighted, got %q", got)
+	}
+}
+
 func TestChat_PendingQuestion(t *testing.T) {
 	chat := NewChat()
 	chat.SetSession("test", nil)
@@ -3524,6 +3709,38 @@ func TestChat_ClearTodoList(t *testing.T) {
 // Todo Sidebar Tests
 // =============================================================================
 
+func TestChat_MaxChatWidthCentersContent(t *testing.T) {
+	ctx := GetViewContext()
+	ctx.SetMaxChatWidth(40)
+	t.Cleanup(func() { ctx.SetMaxChatWidth(0) })
+
+	chat := NewChat()
+	chat.SetSize(200, 40)
+	chat.SetSession("test", nil)
+	chat.AddUserMessage(strings.Repeat("word ", 30))
+
+	_, margin := ctx.ChatContentWidth(chat.viewport.Width())
+	if margin == 0 {
+		t.Fatal("expected a margin in a panel wider than the cap")
+	}
+	content := ansi.Strip(chat.viewport.GetContent())
+	if strings.Count(content, "word") != 30 {
+		t.Fatalf("expected the message rendered, got:\n%s", content)
+	}
+	for line := range strings.SplitSeq(content, "\n") {
+		text := strings.TrimSpace(line)
+		if text == "" {
+			continue
+		}
+		if indent := len(line) - len(strings.TrimLeft(line, " ")); indent < margin {
+			t.Errorf("expected content indented by the margin (%d), got %d in %q", margin, indent, line)
+		}
+		if ansi.StringWidth(text) > 40 {
+			t.Errorf("expected content wrapped to the cap, got %d columns in %q", ansi.StringWidth(text), text)
+		}
+	}
+}
+
 func TestChat_TodoSidebar_WidthCalculation(t *testing.T) {
 	chat := NewChat()
 	chat.SetSize(120, 40)
@@ -3913,7 +4130,7 @@ func TestListItemWrapping(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := renderMarkdownLine(tt.line, tt.width)
+			result := renderMarkdownLine(tt.line, tt.width, 0)
 			if err := tt.checkFn(result); err != nil {
 				t.Error(err)
 			}
@@ -3921,6 +4138,67 @@ func TestListItemWrapping(t *testing.T) {
 	}
 }
 
+// TestNestedListRendering verifies nested list items are indented by level,
+// whether the markdown indents 2 or 4 spaces per level, and that wrapped lines
+// align under the content of their own item.
+func TestNestedListRendering(t *testing.T) {
+	long := "with enough words in it that it has to wrap onto another line"
+	tests := []struct {
+		name     string
+		markdown string
+		prefixes []string // Expected start of each item's first line
+	}{
+		{
+			name:     "two levels unordered, 2 spaces",
+			markdown: "- Parent " + long + "\n  - Child " + long + "\n- Sibling " + long,
+			prefixes: []string{"  • ", "    • ", "  • "},
+		},
+		{
+			name:     "three levels unordered, 4 spaces",
+			markdown: "- Parent " + long + "\n    - Child " + long + "\n        - Grandchild " + long + "\n    - Child " + long,
+			prefixes: []string{"  • ", "    • ", "      • ", "    • "},
+		},
+		{
+			name:     "two levels ordered",
+			markdown: "1. First " + long + "\n   1. Nested " + long + "\n   2. Nested " + long + "\n2. Second " + long,
+			prefixes: []string{"  1. ", "    1. ", "    2. ", "  2. "},
+		},
+		{
+			name:     "three levels mixed",
+			markdown: "1. First " + long + "\n  - Child " + long + "\n    1. Grandchild " + long + "\n- Back out " + long,
+			prefixes: []string{"  1. ", "    • ", "      1. ", "  • "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := ansi.Strip(renderMarkdown(tt.markdown, 40))
+			item := -1
+			contentCol := 0
+			for line := range strings.SplitSeq(rendered, "\n") {
+				content := strings.TrimLeft(line, " ")
+				if strings.HasPrefix(content, "• ") || (len(content) > 2 && content[0] >= '1' && content[0] <= '9' && content[1] == '.') {
+					item++
+					if item >= len(tt.prefixes) {
+						t.Fatalf("more items than expected in:\n%s", rendered)
+					}
+					if !strings.HasPrefix(line, tt.prefixes[item]) {
+						t.Errorf("item %d = %q, want prefix %q", item, line, tt.prefixes[item])
+					}
+					contentCol = lipgloss.Width(tt.prefixes[item])
+					continue
+				}
+				if indent := len(line) - len(content); indent != contentCol {
+					t.Errorf("continuation of item %d indented %d, want %d: %q", item, indent, contentCol, line)
+				}
+			}
+			if item != len(tt.prefixes)-1 {
+				t.Errorf("rendered %d items, want %d:\n%s", item+1, len(tt.prefixes), rendered)
+			}
+		})
+	}
+}
+
 // errorf is a helper that returns an error with formatting
 func errorf(format string, args ...any) error {
 	return &testError{msg: sprintf(format, args...)}
@@ -3967,7 +4245,7 @@ func itoa(i int) string {
 // TestBlockquoteWrapping verifies blockquote content wraps correctly
 func TestBlockquoteWrapping(t *testing.T) {
 	line := "> This is a long blockquote that should be wrapped to fit within the available width minus the blockquote prefix"
-	result := renderMarkdownLine(line, 50)
+	result := renderMarkdownLine(line, 50, 0)
 
 	// Result should contain the content
 	if !strings.Contains(result, "blockquote") {
@@ -4786,6 +5064,36 @@ func TestRenderCompletionFlash_WithStats(t *testing.T) {
 	}
 }
 
+func TestChat_CompletionFlashSettings(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 24)
+	chat.SetSession("test", nil)
+	chat.AddUserMessage("fix the bug")
+	stats := &claude.StreamStats{OutputTokens: 231}
+
+	// 1s is 7 frames of 160ms: bright, five normal, then a blank one
+	chat.SetCompletionFlash(1000, false)
+	chat.finalStats = stats
+	if chat.StartCompletionFlash() == nil {
+		t.Fatal("expected the flash to start")
+	}
+	frames := 1
+	for chat.handleCompletionFlashTick() != nil {
+		if chat.spinner.flashPhase() == 1 && strings.Contains(chat.View(), "231") {
+			t.Error("expected the stats hidden")
+		}
+		frames++
+	}
+	if frames != 7 || chat.IsCompletionFlashing() {
+		t.Errorf("flash lasted %d frames, want 7", frames)
+	}
+
+	chat.SetCompletionFlash(0, true)
+	if chat.StartCompletionFlash() != nil || chat.IsCompletionFlashing() {
+		t.Error("expected no flash when disabled")
+	}
+}
+
 func TestFormatDuration(t *testing.T) {
 	tests := []struct {
 		ms       int
@@ -5213,6 +5521,45 @@ func TestChat_RenderStreamingStatus_WithSubagent(t *testing.T) {
 	}
 }
 
+func TestRenderStreamingStatus_Escalation(t *testing.T) {
+	orig := thinkingEscalation
+	defer func() { thinkingEscalation = orig }()
+	SetThinkingEscalation(ThinkingEscalation{Emphasize: 30 * time.Second, Warn: 2 * time.Minute, SuggestCancel: 5 * time.Minute})
+
+	sp := spinner.New(spinner.WithSpinner(spinner.MiniDot))
+	stats := &claude.StreamStats{OutputTokens: 100}
+	status := func(elapsed time.Duration) string {
+		return ansi.Strip(renderStreamingStatus("Thinking", sp, elapsed, stats, ""))
+	}
+
+	if got := status(10 * time.Second); !strings.Contains(got, "(esc to interrupt • 10s • ↓ 100 tokens)") {
+		t.Errorf("expected the elapsed time among the metadata early on, got %q", got)
+	}
+	if got := status(45 * time.Second); !strings.Contains(got, "Thinking... 45s (esc to interrupt • ↓ 100 tokens)") || strings.Contains(got, "still working") {
+		t.Errorf("expected the elapsed time next to the verb after 30s, got %q", got)
+	}
+	if got := status(3 * time.Minute); !strings.Contains(got, "Thinking... 3m0s still working… (esc to interrupt") {
+		t.Errorf("expected a still-working note after 2m, got %q", got)
+	}
+	if got := status(6 * time.Minute); !strings.Contains(got, "still working… esc to interrupt if it's stuck (↓ 100 tokens)") {
+		t.Errorf("expected an interrupt suggestion after 5m, got %q", got)
+	}
+
+	// Escalating turns amber
+	plain := renderStreamingStatus("Thinking", sp, 45*time.Second, nil, "")
+	warned := renderStreamingStatus("Thinking", sp, 3*time.Minute, nil, "")
+	if strings.Contains(plain, lipgloss.NewStyle().Foreground(ColorWarning).Italic(true).Render("Thinking...")) ||
+		!strings.Contains(warned, lipgloss.NewStyle().Foreground(ColorWarning).Italic(true).Render("Thinking...")) {
+		t.Error("expected the verb amber only once the turn warns")
+	}
+
+	// Skipped stages don't escalate
+	SetThinkingEscalation(ThinkingEscalation{})
+	if got := status(time.Hour); strings.Contains(got, "still working") || !strings.Contains(got, "(esc to interrupt • 60m0s") {
+		t.Errorf("expected no escalation with every stage skipped, got %q", got)
+	}
+}
+
 // TestQuestionPrompt_TextWrapping verifies that long option descriptions wrap correctly
 func TestQuestionPrompt_TextWrapping(t *testing.T) {
 	chat := NewChat()
@@ -5288,3 +5635,563 @@ func TestQuestionPrompt_TextWrapping(t *testing.T) {
 		}
 	}
 }
+
+// =============================================================================
+// Compact Tool-Use Rendering Tests
+// =============================================================================
+
+func TestFormatToolUseGroupSummary(t *testing.T) {
+	tests := []struct {
+		name  string
+		tools []claude.ToolUseSummary
+		want  string
+	}{
+		{
+			name: "all succeeded ordered by count",
+			tools: []claude.ToolUseSummary{
+				{Name: "Bash", Complete: true},
+				{Name: "Read", Complete: true},
+				{Name: "Grep", Complete: true},
+				{Name: "Read", Complete: true},
+				{Name: "Read", Complete: true},
+				{Name: "Bash", Complete: true},
+			},
+			want: "● 6 tool uses: 3 reads, 2 bash, 1 grep — all succeeded",
+		},
+		{
+			name: "failures and incomplete",
+			tools: []claude.ToolUseSummary{
+				{Name: "Bash", Complete: true, Failed: true},
+				{Name: "Edit", Complete: false},
+				{Name: "Edit", Complete: false},
+			},
+			want: "○ 3 tool uses: 2 edits, 1 bash — 1 failed, 2 incomplete",
+		},
+		{
+			name: "only failures",
+			tools: []claude.ToolUseSummary{
+				{Name: "Bash", Complete: true, Failed: true},
+				{Name: "WebFetch", Complete: true},
+			},
+			want: "● 2 tool uses: 1 bash, 1 fetch — 1 failed",
+		},
+		{
+			name: "unknown tool uses lowercase name",
+			tools: []claude.ToolUseSummary{
+				{Name: "mcp__github__search", Complete: true},
+				{Name: "TodoWrite", Complete: true},
+				{Name: "TodoWrite", Complete: true},
+			},
+			want: "● 3 tool uses: 2 todo updates, 1 mcp__github__search — all succeeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatToolUseGroupSummary(tt.tools); got != tt.want {
+				t.Errorf("formatToolUseGroupSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompactToolUseGroups(t *testing.T) {
+	two := []claude.ToolUseSummary{{Name: "Read", Complete: true}, {Name: "Read", Complete: true}}
+	content := "Intro\n\nA\nB\n\nOutro"
+
+	tests := []struct {
+		name   string
+		groups []claude.ToolUseGroup
+		want   string
+	}{
+		{name: "no groups", groups: nil, want: content},
+		{
+			name:   "replaces burst",
+			groups: []claude.ToolUseGroup{{Start: 7, End: 10, Tools: two}},
+			want:   "Intro\n\n● 2 tool uses: 2 reads — all succeeded\n\nOutro",
+		},
+		{
+			name:   "single tool left alone",
+			groups: []claude.ToolUseGroup{{Start: 7, End: 8, Tools: two[:1]}},
+			want:   content,
+		},
+		{
+			name:   "out of range skipped",
+			groups: []claude.ToolUseGroup{{Start: 7, End: 100, Tools: two}},
+			want:   content,
+		},
+		{
+			name: "overlapping group skipped",
+			groups: []claude.ToolUseGroup{
+				{Start: 7, End: 10, Tools: two},
+				{Start: 9, End: 12, Tools: two},
+			},
+			want: "Intro\n\n● 2 tool uses: 2 reads — all succeeded\n\nOutro",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compactToolUseGroups(content, tt.groups); got != tt.want {
+				t.Errorf("compactToolUseGroups() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChat_FinishStreamingCarriesToolUseGroups(t *testing.T) {
+	chat := NewChat()
+	chat.SetSession("test", nil)
+
+	chat.AppendStreaming("Looking around.")
+	chat.AppendToolUse("Read", "a.go", "tool-1")
+	chat.AppendToolUse("Bash", "go test", "tool-2")
+	exitCode := 2
+	chat.MarkToolUseComplete("tool-1", nil)
+	chat.MarkToolUseComplete("tool-2", &claude.ToolResultInfo{ExitCode: &exitCode})
+	chat.AppendStreaming("Done.")
+	chat.FinishStreaming()
+
+	msgs := chat.GetMessages()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	groups := msgs[0].ToolUseGroups
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 tool use group, got %d", len(groups))
+	}
+
+	burst := msgs[0].Content[groups[0].Start:groups[0].End]
+	if !strings.HasPrefix(burst, ToolUseComplete+" Reading(Read: a.go)") || !strings.Contains(burst, "Running(Bash: go test)") {
+		t.Errorf("group offsets don't cover the tool-use lines: %q", burst)
+	}
+	if strings.HasSuffix(burst, "\n") {
+		t.Errorf("group should exclude the trailing newline: %q", burst)
+	}
+	if len(groups[0].Tools) != 2 || !groups[0].Tools[1].Failed || groups[0].Tools[0].Failed {
+		t.Errorf("unexpected tool summaries: %+v", groups[0].Tools)
+	}
+	if chat.streamingToolGroups != nil {
+		t.Error("streaming tool groups should be reset after FinishStreaming")
+	}
+}
+
+func TestChat_CompactToolUsesRendering(t *testing.T) {
+	chat := NewChat()
+	chat.SetSession("test", nil)
+	chat.SetSize(100, 40)
+
+	chat.AppendToolUse("Read", "a.go", "tool-1")
+	chat.AppendToolUse("Read", "b.go", "tool-2")
+	chat.MarkToolUseComplete("tool-1", nil)
+	chat.MarkToolUseComplete("tool-2", nil)
+	chat.AppendStreaming("Summary")
+	chat.FinishStreaming()
+
+	if chat.HasCompactToolGroups() {
+		t.Error("should not report compact groups while compact mode is off")
+	}
+	if !strings.Contains(chat.messageCache[0].content, "a.go") {
+		t.Errorf("expected full tool-use lines by default, got %q", chat.messageCache[0].content)
+	}
+
+	chat.SetCompactToolUses(true)
+	if !chat.HasCompactToolGroups() {
+		t.Error("expected compact groups once compact mode is on")
+	}
+	cached := chat.messageCache[0].content
+	if strings.Contains(cached, "a.go") || !strings.Contains(cached, "2 tool uses: 2 reads — all succeeded") {
+		t.Errorf("expected burst to be summarized, got %q", cached)
+	}
+
+	chat.ToggleToolGroupsExpanded()
+	if !strings.Contains(chat.messageCache[0].content, "b.go") {
+		t.Errorf("expected expanded burst to show tool-use lines, got %q", chat.messageCache[0].content)
+	}
+}
+
+func TestChat_SetLoadingSession(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 24)
+	chat.SetSession("old-session", []claude.Message{{Role: "user", Content: "Hello"}})
+
+	chat.SetLoadingSession("big-session")
+	if !chat.IsLoadingSession() {
+		t.Error("expected the chat to be loading")
+	}
+	if chat.hasSession || len(chat.messages) != 0 {
+		t.Error("expected the previous session to be cleared")
+	}
+	if !strings.Contains(chat.View(), "Loading big-session...") {
+		t.Error("expected the loading indicator in the view")
+	}
+
+	chat.SetSession("big-session", nil)
+	if chat.IsLoadingSession() {
+		t.Error("expected SetSession to end loading")
+	}
+}
+
+func TestChat_AppendError_CollapsesRepeats(t *testing.T) {
+	chat := NewChat()
+	chat.SetSession("test", nil)
+	chat.SetSize(80, 40)
+
+	chat.AppendStreaming("Working on it.")
+	for range 3 {
+		chat.AppendStreaming("\n[Error: MCP socket write failed: broken pipe]\n")
+	}
+
+	if !chat.HasCollapsedErrors() {
+		t.Fatal("Expected repeated errors to be collapsed")
+	}
+	streaming := chat.GetStreaming()
+	if got := strings.Count(streaming, "[Error: MCP socket write failed: broken pipe]"); got != 1 {
+		t.Errorf("Expected the error once in streaming content, got %d times:\n%s", got, streaming)
+	}
+	if !strings.Contains(streaming, "× 3, last at ") {
+		t.Errorf("Expected a counter in streaming content, got:\n%s", streaming)
+	}
+
+	chat.ToggleErrorsExpanded()
+	if got := strings.Count(chat.View(), "broken pipe"); got != 3 {
+		t.Errorf("Expected every error when expanded, got %d", got)
+	}
+
+	// Anything else ends the run; the next error starts a new one
+	chat.AppendStreaming("Retrying.")
+	chat.AppendStreaming("\n[Error: MCP socket write failed: broken pipe]\n")
+	if chat.HasCollapsedErrors() {
+		t.Error("Expected an error after other content to start a new run")
+	}
+
+	chat.FinishStreaming()
+	content := chat.GetMessages()[0].Content
+	if !strings.Contains(content, "× 3, last at ") || strings.Count(content, "broken pipe") != 2 {
+		t.Errorf("Expected the collapsed run and the later error in the message, got:\n%s", content)
+	}
+}
+
+func TestChat_AppendError_SameClass(t *testing.T) {
+	chat := NewChat()
+	chat.SetSession("test", nil)
+
+	chat.AppendError("broken pipe", "write unix /tmp/a.sock: broken pipe")
+	chat.AppendError("broken pipe", "write unix /tmp/b.sock: broken pipe")
+	chat.AppendError("timeout", "context deadline exceeded")
+
+	streaming := chat.GetStreaming()
+	if strings.Contains(streaming, "a.sock") {
+		t.Errorf("Expected same-class errors to collapse to the latest, got:\n%s", streaming)
+	}
+	if !strings.Contains(streaming, "b.sock: broken pipe] × 2") {
+		t.Errorf("Expected the collapsed run with a counter, got:\n%s", streaming)
+	}
+	if !strings.HasSuffix(streaming, "[Error: context deadline exceeded]\n") {
+		t.Errorf("Expected a different class to start a new line, got:\n%s", streaming)
+	}
+}
+
+func TestAppendErrorLine(t *testing.T) {
+	at := time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)
+
+	content := AppendErrorLine("Working on it.", "broken pipe", at)
+	if content != "Working on it.\n[Error: broken pipe]" {
+		t.Fatalf("Expected the first error appended, got %q", content)
+	}
+	content = AppendErrorLine(content, "broken pipe", at)
+	if content != "Working on it.\n[Error: broken pipe] × 2, last at 15:04" {
+		t.Fatalf("Expected a repeat to be counted, got %q", content)
+	}
+	content = AppendErrorLine(content, "broken pipe", at)
+	if content != "Working on it.\n[Error: broken pipe] × 3, last at 15:04" {
+		t.Fatalf("Expected the counter to grow, got %q", content)
+	}
+
+	if got := AppendErrorLine(content, "timeout", at); !strings.HasSuffix(got, "× 3, last at 15:04\n[Error: timeout]") {
+		t.Errorf("Expected a different error on a new line, got %q", got)
+	}
+	if got := AppendErrorLine(content+"Retrying.", "broken pipe", at); !strings.HasSuffix(got, "Retrying.\n[Error: broken pipe]") {
+		t.Errorf("Expected an error after other content on a new line, got %q", got)
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	pipeErr := fmt.Errorf("MCP socket write failed: %w", &net.OpError{Op: "write", Net: "unix", Err: syscall.EPIPE})
+	if got := ErrorClass(pipeErr); got != "broken pipe" {
+		t.Errorf("ErrorClass(EPIPE) = %q, want %q", got, "broken pipe")
+	}
+	if got := ErrorClass(fmt.Errorf("request: %w", context.DeadlineExceeded)); got != "timeout" {
+		t.Errorf("ErrorClass(DeadlineExceeded) = %q, want %q", got, "timeout")
+	}
+	if got := ErrorClass(errors.New("unknown model")); got != "unknown model" {
+		t.Errorf("ErrorClass(untyped) = %q, want the error text", got)
+	}
+}
+
+func TestChat_Bookmarks(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 10)
+	var messages []claude.Message
+	for i := range 6 {
+		role := "user"
+		if i%2 == 1 {
+			role = "assistant"
+		}
+		messages = append(messages, claude.Message{Role: role, Content: strings.Repeat(fmt.Sprintf("line of message %d\n", i), 5)})
+	}
+	chat.SetSession("test", messages)
+
+	if got := chat.MessageAtViewportTop(); got != 5 {
+		t.Fatalf("expected the last message at the top when scrolled to the bottom, got %d", got)
+	}
+	if chat.JumpToNextBookmark() != -1 {
+		t.Error("expected no bookmark to jump to")
+	}
+
+	chat.viewport.SetYOffset(chat.messageStartLines[1])
+	if index, on := chat.ToggleBookmark(); index != 1 || !on {
+		t.Fatalf("ToggleBookmark() = %d, %v; want 1, true", index, on)
+	}
+	if chat.viewport.YOffset() != chat.messageStartLines[1] {
+		t.Error("expected bookmarking to keep the scroll position")
+	}
+	if !strings.Contains(chat.View(), BookmarkMarker) {
+		t.Error("expected the bookmark marker in the view")
+	}
+
+	chat.SetBookmarks([]int{1, 3, 99})
+	if got := chat.Bookmarks(); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Bookmarks() = %v, want [1 3]", got)
+	}
+
+	// Jumping moves through the bookmarks in order and wraps around
+	for _, want := range []int{3, 1, 3} {
+		if got := chat.JumpToNextBookmark(); got != want {
+			t.Errorf("JumpToNextBookmark() = %d, want %d", got, want)
+		}
+		if chat.MessageAtViewportTop() != want {
+			t.Errorf("expected message %d at the top, got %d", want, chat.MessageAtViewportTop())
+		}
+	}
+
+	if index, on := chat.ToggleBookmark(); index != 3 || on {
+		t.Errorf("ToggleBookmark() = %d, %v; want 3, false", index, on)
+	}
+
+	chat.SetSession("other", messages)
+	if len(chat.Bookmarks()) != 0 {
+		t.Error("expected bookmarks to be cleared with the session")
+	}
+}
+
+func TestChat_ModelSwitchMarker(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 20)
+	chat.SetSession("test", []claude.Message{
+		{Role: "user", Content: "start cheap"},
+		{Role: "assistant", Content: "ok"},
+		{Role: "assistant", Content: ModelSwitchMarker("opus")},
+		{Role: "user", Content: "now the hard part"},
+	})
+
+	content := ansi.Strip(chat.viewport.GetContent())
+	if !strings.Contains(content, "— switched to opus —") {
+		t.Fatalf("expected the marker in:\n%s", content)
+	}
+	// The marker has no role label of its own
+	if got := strings.Count(content, "Claude:"); got != 1 {
+		t.Errorf("expected one Claude label, got %d in:\n%s", got, content)
+	}
+	if ModelSwitchMarker("") != "— switched to default model —" {
+		t.Errorf("unexpected marker for the default model: %q", ModelSwitchMarker(""))
+	}
+
+	// Markers saved as system messages are dividers too
+	chat.SetSession("test", []claude.Message{
+		{Role: claude.RoleSystem, Kind: claude.KindModelSwitch, Content: ModelSwitchMarker("opus")},
+	})
+	if content := ansi.Strip(chat.viewport.GetContent()); strings.Contains(content, "Plural:") || !strings.Contains(content, "— switched to opus —") {
+		t.Errorf("expected the marker without a label in:\n%s", content)
+	}
+}
+
+func TestChat_SystemMessages(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 20)
+	chat.SetSession("test", []claude.Message{{Role: "user", Content: "/cost"}})
+	chat.AddSystemMessage(claude.KindCommand, "Session cost: $0.12")
+	chat.AppendOutput("Merged feature into main\n")
+	chat.FinishSystemOutput(claude.KindMerge)
+
+	messages := chat.GetMessages()
+	if len(messages) != 3 || messages[1].Role != claude.RoleSystem || messages[1].Kind != claude.KindCommand ||
+		messages[2].Role != claude.RoleSystem || messages[2].Kind != claude.KindMerge {
+		t.Fatalf("expected a command response and merge output as system messages, got %+v", messages)
+	}
+
+	content := ansi.Strip(chat.viewport.GetContent())
+	for _, want := range []string{"› Plural:", "⎇ Plural:", "Session cost: $0.12", "Merged feature into main"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "Claude:") {
+		t.Errorf("expected no Claude label for system messages in:\n%s", content)
+	}
+}
+
+func TestChat_ScrollPerSession(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 10)
+	conversation := func(n int) []claude.Message {
+		var messages []claude.Message
+		for i := range n {
+			messages = append(messages, claude.Message{Role: "assistant", Content: strings.Repeat(fmt.Sprintf("line of message %d\n", i), 5)})
+		}
+		return messages
+	}
+
+	chat.SetSession("a", conversation(6))
+	chat.viewport.SetYOffset(3)
+	chat.SaveScroll("a")
+
+	// A session never scrolled up shows its end
+	chat.SetSession("b", conversation(6))
+	chat.RestoreScroll("b")
+	if !chat.viewport.AtBottom() {
+		t.Error("expected a session without a saved offset at the bottom")
+	}
+	chat.SaveScroll("b")
+
+	chat.SetSession("a", conversation(6))
+	chat.RestoreScroll("a")
+	if got := chat.viewport.YOffset(); got != 3 {
+		t.Errorf("expected the saved offset restored, got %d", got)
+	}
+
+	// An offset past the end of a conversation that shrank is clamped
+	chat.viewport.SetYOffset(20)
+	chat.SaveScroll("a")
+	chat.SetSession("a", conversation(2))
+	chat.RestoreScroll("a")
+	if !chat.viewport.AtBottom() {
+		t.Errorf("expected the offset clamped to the shorter conversation, got %d", chat.viewport.YOffset())
+	}
+
+	// Leaving a session at the bottom forgets its offset
+	chat.SaveScroll("a")
+	chat.SetSession("a", conversation(6))
+	chat.RestoreScroll("a")
+	if !chat.viewport.AtBottom() {
+		t.Error("expected a session left at the bottom to follow its end")
+	}
+
+	// A deleted session's offset is dropped
+	chat.viewport.SetYOffset(3)
+	chat.SaveScroll("a")
+	chat.ForgetScroll("a")
+	if _, ok := chat.scrollOffsets["a"]; ok {
+		t.Error("expected the deleted session's offset to be dropped")
+	}
+}
+
+func TestChat_HomeEndJump(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(120, 20)
+	var messages []claude.Message
+	for i := range 10 {
+		messages = append(messages, claude.Message{Role: "assistant", Content: strings.Repeat(fmt.Sprintf("line of message %d\n", i), 5)})
+	}
+	chat.SetSession("test", messages)
+	chat.SetFocused(true)
+
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	if !chat.viewport.AtTop() {
+		t.Errorf("expected Home to jump to the top, got offset %d", chat.viewport.YOffset())
+	}
+	if chat.input.Value() != "" {
+		t.Errorf("expected Home not to reach the input, got %q", chat.input.Value())
+	}
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyEnd})
+	if !chat.viewport.AtBottom() {
+		t.Error("expected End to jump to the bottom")
+	}
+
+	// Unfocused, as when the sidebar routes them here
+	chat.SetFocused(false)
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	if !chat.viewport.AtTop() {
+		t.Error("expected Home to jump to the top while unfocused")
+	}
+
+	// After the mouse wheel scrolls the todo list, they jump in it instead
+	items := make([]claude.TodoItem, 40)
+	for i := range items {
+		items[i] = claude.TodoItem{Content: fmt.Sprintf("Task %d", i+1), Status: claude.TodoStatusPending}
+	}
+	chat.SetTodoList(&claude.TodoList{Items: items})
+	chat.viewport.GotoBottom()
+	chat.Update(tea.MouseWheelMsg{X: chat.width - chat.todoWidth + 5, Y: 10, Button: tea.MouseWheelDown})
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyEnd})
+	if !chat.todoViewport.AtBottom() || chat.todoViewport.YOffset() == 0 {
+		t.Errorf("expected End to jump to the bottom of the todo list, got offset %d", chat.todoViewport.YOffset())
+	}
+	if !chat.viewport.AtBottom() {
+		t.Error("expected the conversation left alone")
+	}
+
+	// Once the todo list is gone, the wheel no longer points at it
+	chat.ClearTodoList()
+	chat.Update(tea.MouseWheelMsg{X: chat.width - 5, Y: 10, Button: tea.MouseWheelUp})
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	if chat.todoHovered || chat.viewport.YOffset() != 0 {
+		t.Errorf("expected Home to jump to the top of the conversation, got offset %d", chat.viewport.YOffset())
+	}
+}
+
+func TestChat_HorizontalScroll(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 20)
+	wide := strings.Repeat("abcdefghij", 30)
+	content := "```js\n" + wide + "\n```\n" + strings.Repeat("short line\n\n", 30)
+	chat.SetSession("test", []claude.Message{{Role: "assistant", Content: content}})
+	chat.SetFocused(true)
+	chat.viewport.GotoTop()
+
+	// Code block lines are kept whole for scrolling to reveal
+	if !strings.Contains(ansi.Strip(chat.viewport.GetContent()), wide) {
+		t.Fatal("expected the wide code line to be rendered unwrapped")
+	}
+
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if chat.hOffset != HorizontalScrollStep {
+		t.Errorf("expected Right to scroll %d columns, got %d", HorizontalScrollStep, chat.hOffset)
+	}
+	for range 100 {
+		chat.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	}
+	if maxOffset := slices.Max(chat.lineWidths) - chat.viewport.Width(); chat.hOffset != maxOffset {
+		t.Errorf("expected the offset clamped to %d, got %d", maxOffset, chat.hOffset)
+	}
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	if chat.viewport.XOffset() != chat.hOffset {
+		t.Errorf("expected the viewport at offset %d, got %d", chat.hOffset, chat.viewport.XOffset())
+	}
+
+	// While typing, the arrows move the input's cursor
+	chat.input.SetValue("draft")
+	before := chat.hOffset
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	if chat.hOffset != before {
+		t.Error("expected Left not to scroll while typing")
+	}
+	chat.input.SetValue("")
+
+	// Scrolled to where every line fits, the chat isn't shifted
+	chat.viewport.GotoBottom()
+	chat.View()
+	if chat.hOffset != 0 {
+		t.Errorf("expected no offset with only short lines in view, got %d", chat.hOffset)
+	}
+}
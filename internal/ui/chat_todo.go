@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	pclaude "github.com/zhubert/plural/internal/claude"
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// TodoMarksChangedMsg is sent when the user marks or unmarks a todo item, so
+// the session's stored todo list can be updated to match.
+type TodoMarksChangedMsg struct {
+	List *pclaude.TodoList
+}
+
+// EnterTodoFocusMode highlights a todo item so j/k move among the items and
+// Space toggles the user's mark on one (see pclaude.TodoList.ToggleUserMark).
+func (c *Chat) EnterTodoFocusMode() {
+	if !c.HasTodoList() {
+		return
+	}
+	c.todoFocus = true
+	c.todoCursor = min(c.todoCursor, len(c.currentTodoList.Items)-1)
+	c.updateTodoViewportContent()
+}
+
+// ExitTodoFocusMode leaves todo focus mode.
+func (c *Chat) ExitTodoFocusMode() {
+	c.todoFocus = false
+	c.updateTodoViewportContent()
+}
+
+// IsInTodoFocusMode returns whether keys move among and mark todo items.
+func (c *Chat) IsInTodoFocusMode() bool {
+	return c.todoFocus
+}
+
+// TodoCursor returns the highlighted todo item in todo focus mode.
+func (c *Chat) TodoCursor() int {
+	return c.todoCursor
+}
+
+// updateTodoFocus handles a key in todo focus mode.
+func (c *Chat) updateTodoFocus(msg tea.KeyPressMsg) tea.Cmd {
+	switch msg.String() {
+	case keys.Escape, keys.Enter, "q":
+		c.ExitTodoFocusMode()
+	case keys.Up, "k":
+		if c.todoCursor > 0 {
+			c.todoCursor--
+			c.updateTodoViewportContent()
+		}
+	case keys.Down, "j":
+		if c.todoCursor < len(c.currentTodoList.Items)-1 {
+			c.todoCursor++
+			c.updateTodoViewportContent()
+		}
+	case keys.Space:
+		// Mark a copy, since the list may be shared with the session's state
+		list := &pclaude.TodoList{Items: slices.Clone(c.currentTodoList.Items)}
+		list.ToggleUserMark(c.todoCursor)
+		c.currentTodoList = list
+		c.updateTodoViewportContent()
+		return func() tea.Msg {
+			return TodoMarksChangedMsg{List: &pclaude.TodoList{Items: slices.Clone(list.Items)}}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	pclaude "github.com/zhubert/plural/internal/claude"
+)
+
+// toolUseNouns maps tool names to the singular and plural nouns used in burst summaries
+var toolUseNouns = map[string][2]string{
+	"Read":      {"read", "reads"},
+	"Edit":      {"edit", "edits"},
+	"Write":     {"write", "writes"},
+	"Glob":      {"glob", "globs"},
+	"Grep":      {"grep", "greps"},
+	"Bash":      {"bash", "bash"},
+	"Task":      {"task", "tasks"},
+	"WebFetch":  {"fetch", "fetches"},
+	"WebSearch": {"search", "searches"},
+	"TodoWrite": {"todo update", "todo updates"},
+}
+
+// toolUseSummary captures the structured outcome of a rollup item for burst grouping
+func toolUseSummary(item ToolUseItem) pclaude.ToolUseSummary {
+	failed := item.ResultInfo != nil && item.ResultInfo.ExitCode != nil && *item.ResultInfo.ExitCode != 0
+	return pclaude.ToolUseSummary{
+		Name:     item.ToolName,
+		Complete: item.Complete,
+		Failed:   failed,
+	}
+}
+
+// toolUseNoun returns the summary noun for a tool, pluralized by count
+func toolUseNoun(toolName string, count int) string {
+	nouns, ok := toolUseNouns[toolName]
+	if !ok {
+		return strings.ToLower(toolName)
+	}
+	if count == 1 {
+		return nouns[0]
+	}
+	return nouns[1]
+}
+
+// formatToolUseGroupSummary renders a burst as a single line, e.g.
+// "● 14 tool uses: 9 reads, 3 greps, 2 bash — all succeeded".
+// Counts are ordered by frequency, ties keeping first-seen order.
+func formatToolUseGroupSummary(tools []pclaude.ToolUseSummary) string {
+	var order []string
+	counts := make(map[string]int)
+	failed, incomplete := 0, 0
+	for _, tool := range tools {
+		if counts[tool.Name] == 0 {
+			order = append(order, tool.Name)
+		}
+		counts[tool.Name]++
+		if !tool.Complete {
+			incomplete++
+		} else if tool.Failed {
+			failed++
+		}
+	}
+
+	// Stable insertion sort by count keeps first-seen order for ties
+	for i := 1; i < len(order); i++ {
+		for j := i; j > 0 && counts[order[j]] > counts[order[j-1]]; j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+		}
+	}
+
+	parts := make([]string, len(order))
+	for i, name := range order {
+		parts[i] = fmt.Sprintf("%d %s", counts[name], toolUseNoun(name, counts[name]))
+	}
+
+	marker := ToolUseComplete
+	if incomplete > 0 {
+		marker = ToolUseInProgress
+	}
+
+	var outcome string
+	switch {
+	case failed == 0 && incomplete == 0:
+		outcome = "all succeeded"
+	case incomplete == 0:
+		outcome = fmt.Sprintf("%d failed", failed)
+	case failed == 0:
+		outcome = fmt.Sprintf("%d incomplete", incomplete)
+	default:
+		outcome = fmt.Sprintf("%d failed, %d incomplete", failed, incomplete)
+	}
+
+	return fmt.Sprintf("%s %d tool uses: %s — %s", marker, len(tools), strings.Join(parts, ", "), outcome)
+}
+
+// compactToolUseGroups replaces each recorded burst of two or more tool uses in
+// content with its summary line. Groups whose offsets don't fit the content are
+// left untouched so a stale or corrupt group can never mangle the message.
+func compactToolUseGroups(content string, groups []pclaude.ToolUseGroup) string {
+	if len(groups) == 0 {
+		return content
+	}
+
+	var sb strings.Builder
+	pos := 0
+	for _, group := range groups {
+		if len(group.Tools) < 2 || group.Start < pos || group.End < group.Start || group.End > len(content) {
+			continue
+		}
+		sb.WriteString(content[pos:group.Start])
+		sb.WriteString(formatToolUseGroupSummary(group.Tools))
+		pos = group.End
+	}
+	sb.WriteString(content[pos:])
+	return sb.String()
+}
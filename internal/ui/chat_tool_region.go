@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// The expanded tool-use rollup lists every tool use of the current burst,
+// which after a long burst is far more than fits above the input. Rather than
+// pushing the conversation out of view, it scrolls in a region of its own
+// between the conversation and the input, sized by the ViewContext along with
+// the input area. A chat too short for the region keeps the rollup inline.
+
+// toolUseRegionHeight returns the height the tool-use region should have: its
+// position line and one line per tool use, within what the ViewContext allows;
+// 0 unless a rollup of several tool uses is expanded.
+func (c *Chat) toolUseRegionHeight() int {
+	if c.toolUseRollup == nil || !c.toolUseRollup.Expanded || len(c.toolUseRollup.Items) < 2 {
+		return 0
+	}
+	return GetViewContext().ToolUseRegionHeight(c.height, c.getInputTotalHeight(), len(c.toolUseRollup.Items)+1)
+}
+
+// syncToolUseRegion resizes the chat when the tool-use region's height
+// changes, and refreshes the tool uses it lists, following the newest unless
+// scrolled up from them.
+func (c *Chat) syncToolUseRegion() {
+	if c.toolUseRegionHeight() != c.toolUseRegion && c.width > 0 && c.height > 0 {
+		c.SetSize(c.width, c.height)
+	}
+	if c.toolUseRegion == 0 {
+		c.toolUseViewport.SetContent("")
+		return
+	}
+
+	width := c.toolUseViewport.Width()
+	lines := make([]string, len(c.toolUseRollup.Items))
+	for i, item := range c.toolUseRollup.Items {
+		lines[i] = ansi.Truncate(styleToolUseMarkers(formatToolUseLine(item)), width, "…")
+	}
+	atBottom := c.toolUseViewport.AtBottom()
+	c.toolUseViewport.SetContent(strings.Join(lines, "\n"))
+	if atBottom {
+		c.toolUseViewport.GotoBottom()
+	}
+}
+
+// scrollToolUseRegion scrolls the tool-use region for a page key, or a line
+// for alt+k and alt+j, returning whether key was one of those.
+func (c *Chat) scrollToolUseRegion(key string) bool {
+	switch key {
+	case keys.PgUp:
+		c.toolUseViewport.PageUp()
+	case keys.PgDown:
+		c.toolUseViewport.PageDown()
+	case keys.AltK:
+		c.toolUseViewport.ScrollUp(1)
+	case keys.AltJ:
+		c.toolUseViewport.ScrollDown(1)
+	default:
+		return false
+	}
+	return true
+}
+
+// toolUseRegionPosition returns which of the rollup's tool uses the region
+// shows, as "items 12–26 of 80".
+func (c *Chat) toolUseRegionPosition() string {
+	total := c.toolUseViewport.TotalLineCount()
+	first := c.toolUseViewport.YOffset() + 1
+	last := min(c.toolUseViewport.YOffset()+c.toolUseViewport.Height(), total)
+	return fmt.Sprintf("items %d–%d of %d", first, last, total)
+}
+
+// renderToolUseRegionNote renders the line that stands in for the expanded
+// rollup in the conversation while it is shown in the region.
+func (c *Chat) renderToolUseRegionNote() string {
+	rollupStyle := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Italic(true)
+	keyStyle := lipgloss.NewStyle().
+		Foreground(ColorInfo)
+	return rollupStyle.Render(fmt.Sprintf("  %d tool uses listed below (", len(c.toolUseRollup.Items))) +
+		keyStyle.Render("ctrl-t") +
+		rollupStyle.Render(" to collapse)")
+}
+
+// joinToolUseRegion stacks the chat panel, the tool-use region if shown, and
+// the input area.
+func (c *Chat) joinToolUseRegion(chatPanel, inputArea string) string {
+	if c.toolUseRegion == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, chatPanel, inputArea)
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	keyStyle := lipgloss.NewStyle().Foreground(ColorInfo)
+	position := mutedStyle.Render(c.toolUseRegionPosition()+" · ") +
+		keyStyle.Render("pgup/pgdn") + mutedStyle.Render(" or ") +
+		keyStyle.Render("opt-k/j") + mutedStyle.Render(" to scroll")
+	position = ansi.Truncate(position, c.toolUseViewport.Width(), "…")
+
+	region := lipgloss.NewStyle().
+		Width(c.width).
+		Height(c.toolUseRegion).
+		MaxHeight(c.toolUseRegion).
+		Padding(0, 1).
+		Render(position + "\n" + c.toolUseViewport.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left, chatPanel, region, inputArea)
+}
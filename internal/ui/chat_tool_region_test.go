@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/claude"
+)
+
+// toolUseBurst returns a focused chat of the given size streaming n tool uses.
+func toolUseBurst(width, height, n int) *Chat {
+	chat := NewChat()
+	chat.SetSession("test", []claude.Message{{Role: "user", Content: "Read every file"}})
+	chat.SetSize(width, height)
+	chat.SetFocused(true)
+	chat.SetWaiting(true)
+	for i := 1; i <= n; i++ {
+		chat.AppendToolUse("Read", fmt.Sprintf("file%02d.go", i), fmt.Sprintf("tool-%d", i))
+	}
+	return chat
+}
+
+// assertChatLayout checks the chat renders exactly its height with the input
+// area intact and some conversation left above it.
+func assertChatLayout(t *testing.T, chat *Chat) {
+	t.Helper()
+	if got := lipgloss.Height(chat.View()); got != chat.height {
+		t.Errorf("expected the chat to render %d lines, got %d", chat.height, got)
+	}
+	if chat.viewport.Height() < 1 {
+		t.Errorf("expected conversation left visible, got a viewport %d high", chat.viewport.Height())
+	}
+	if got := chat.input.Height(); got != TextareaHeight {
+		t.Errorf("expected the textarea kept %d lines high, got %d", TextareaHeight, got)
+	}
+}
+
+func TestChat_ToolUseRegion(t *testing.T) {
+	chat := toolUseBurst(100, 40, 80)
+	viewportHeight := chat.viewport.Height()
+
+	chat.ToggleToolUseRollup()
+	regionHeight := 40 / ToolUseRegionHeightRatio
+	if chat.toolUseRegion != regionHeight {
+		t.Fatalf("expected a region %d high, got %d", regionHeight, chat.toolUseRegion)
+	}
+	if chat.viewport.Height() != viewportHeight-regionHeight {
+		t.Errorf("expected the conversation to give the region its room, got a viewport %d high", chat.viewport.Height())
+	}
+	assertChatLayout(t, chat)
+
+	// The region follows the newest tool uses
+	view := ansi.Strip(chat.View())
+	shown := regionHeight - 1
+	if want := fmt.Sprintf("items %d–80 of 80", 80-shown+1); !strings.Contains(view, want) {
+		t.Errorf("expected %q in:\n%s", want, view)
+	}
+	if !strings.Contains(view, "file80.go") || strings.Contains(view, "file01.go") {
+		t.Errorf("expected only the newest tool uses listed, got:\n%s", view)
+	}
+	if !strings.Contains(view, "80 tool uses listed below") {
+		t.Errorf("expected the conversation to point to the region, got:\n%s", view)
+	}
+
+	// Page keys scroll it while expanded, and it stays put as tool uses arrive
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyPgUp})
+	chat.Update(tea.KeyPressMsg{Code: 'k', Mod: tea.ModAlt})
+	first := 80 - 2*shown
+	if want := fmt.Sprintf("items %d–%d of 80", first, first+shown-1); !strings.Contains(ansi.Strip(chat.View()), want) {
+		t.Errorf("expected %q after scrolling up, got:\n%s", want, ansi.Strip(chat.View()))
+	}
+	chat.AppendToolUse("Edit", "main.go", "tool-81")
+	if want := fmt.Sprintf("items %d–%d of 81", first, first+shown-1); !strings.Contains(ansi.Strip(chat.View()), want) {
+		t.Errorf("expected the position kept as tool uses arrive, got:\n%s", ansi.Strip(chat.View()))
+	}
+
+	// Collapsing returns to the one-line summary and gives the room back
+	chat.ToggleToolUseRollup()
+	if chat.toolUseRegion != 0 || chat.viewport.Height() != viewportHeight {
+		t.Errorf("expected the region gone after collapsing, got %d high with a viewport %d high", chat.toolUseRegion, chat.viewport.Height())
+	}
+	if view := ansi.Strip(chat.View()); !strings.Contains(view, "+80 more tool uses") || strings.Contains(view, "items ") {
+		t.Errorf("expected the collapsed summary, got:\n%s", view)
+	}
+	assertChatLayout(t, chat)
+}
+
+func TestChat_ToolUseRegion_SmallHeights(t *testing.T) {
+	for height := MinTerminalHeight; height <= 20; height++ {
+		t.Run(fmt.Sprintf("height %d", height), func(t *testing.T) {
+			chat := toolUseBurst(80, height, 80)
+			chat.ToggleToolUseRollup()
+			assertChatLayout(t, chat)
+
+			chat.AttachImage([]byte("png"), "image/png")
+			assertChatLayout(t, chat)
+
+			chat.SetTodoList(&claude.TodoList{Items: []claude.TodoItem{
+				{Content: "Task", Status: claude.TodoStatusInProgress, ActiveForm: "Working"},
+			}})
+			assertChatLayout(t, chat)
+			if chat.todoViewport.Height() != chat.viewport.Height() {
+				t.Errorf("expected the todo list as high as the conversation, got %d and %d", chat.todoViewport.Height(), chat.viewport.Height())
+			}
+
+			// Too short for a region, the expanded rollup stays inline
+			if chat.toolUseRegion == 0 && !strings.Contains(ansi.Strip(chat.renderToolUseRollup()), "file01.go") {
+				t.Error("expected the expanded rollup inline without a region")
+			}
+		})
+	}
+}
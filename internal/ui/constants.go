@@ -85,6 +85,20 @@ const (
 	// The indicator shows "[Image attached: NKB] (backspace to remove)".
 	ImageIndicatorHeight = 1
 
+	// ToolUseRegionHeightRatio caps the region the expanded tool-use rollup
+	// scrolls in at 1/ToolUseRegionHeightRatio of the chat panel's height, so
+	// a long burst of tool uses leaves most of the room to the conversation.
+	ToolUseRegionHeightRatio = 3
+
+	// MinToolUseRegionHeight is the smallest useful tool-use region: its
+	// position line and two tool uses. A chat too short for it keeps the
+	// expanded rollup inline in the conversation.
+	MinToolUseRegionHeight = 3
+
+	// MinChatViewportHeight is the fewest lines of conversation the tool-use
+	// region leaves visible above it.
+	MinChatViewportHeight = 3
+
 	// TitleHeight is the height of panel title bars (currently unused but reserved).
 	TitleHeight = 1
 
@@ -207,6 +221,13 @@ const (
 	DefaultTerminalFormatter = "terminal256"
 )
 
+// Horizontal scrolling of the chat
+const (
+	// HorizontalScrollStep is how many columns Left and Right shift the chat's
+	// lines wider than the viewport, such as long lines in code blocks
+	HorizontalScrollStep = 8
+)
+
 // Todo list rendering
 const (
 	// TodoListMinWrapWidth is the minimum wrap width for todo lists
@@ -234,6 +255,9 @@ const (
 //	Numbered:  "  1. content here..."    (2 spaces + digit + dot + space = 5 chars for 1-9)
 //	           "     continuation..."    (5 spaces for continuation)
 //
+//	Nested:    "    • content here..."   (2 spaces per level before the prefix)
+//	           "      continuation..."   (nesting + the prefix width)
+//
 //	Blockquote: "▎ content here..."      (bar + space = 2 chars visible, but styled)
 const (
 	// ContentPadding is the horizontal padding applied to viewport content.
@@ -249,6 +273,10 @@ const (
 	// Must match ListItemPrefixWidth so text aligns vertically.
 	ListItemContinuationIndent = 4
 
+	// ListNestingIndent is the extra indentation per level of a nested list
+	// item, putting a nested bullet under its parent bullet's content.
+	ListNestingIndent = 2
+
 	// NumberedListPrefixWidth is the width of numbered list prefixes "  N. ".
 	// Breakdown: 2 leading spaces + 1-2 digit chars + 1 dot + 1 space = 5-6 chars.
 	// We use 5 for single-digit numbers (1-9) as the common case.
@@ -6,6 +6,24 @@ import (
 	"github.com/zhubert/plural/internal/logger"
 )
 
+// ViewMode selects which panels the layout makes room for.
+type ViewMode int
+
+const (
+	// ViewModeNormal shows the header, sidebar, chat, and footer.
+	ViewModeNormal ViewMode = iota
+	// ViewModeFocus shows only the chat at full width below a one-line status,
+	// with no sidebar or footer.
+	ViewModeFocus
+)
+
+func (v ViewMode) String() string {
+	if v == ViewModeFocus {
+		return "focus"
+	}
+	return "normal"
+}
+
 // ViewContext holds centralized layout calculations and provides debug logging.
 // All size calculations should go through this to avoid duplication.
 type ViewContext struct {
@@ -20,6 +38,13 @@ type ViewContext struct {
 	SidebarWidth  int
 	ChatWidth     int
 
+	// Mode selects which panels get space; see SetMode
+	Mode ViewMode
+
+	// MaxChatWidth caps the width chat messages are wrapped to (0 = no cap);
+	// see SetMaxChatWidth
+	MaxChatWidth int
+
 	mu sync.Mutex
 }
 
@@ -67,12 +92,19 @@ func (v *ViewContext) UpdateTerminalSize(width, height int) {
 	// The styles add padding but lipgloss Width() handles the total
 	v.HeaderHeight = HeaderHeight
 	v.FooterHeight = FooterHeight
+	if v.Mode == ViewModeFocus {
+		// The one-line focus status takes the header's place; there is no footer
+		v.FooterHeight = 0
+	}
 
 	// Content area is everything between header and footer
 	v.ContentHeight = height - v.HeaderHeight - v.FooterHeight
 
 	// Sidebar is 1/5 of width, chat gets the rest
 	v.SidebarWidth = width / SidebarWidthRatio
+	if v.Mode == ViewModeFocus {
+		v.SidebarWidth = 0
+	}
 	v.ChatWidth = width - v.SidebarWidth
 
 	log := logger.WithComponent("ui")
@@ -84,9 +116,55 @@ func (v *ViewContext) UpdateTerminalSize(width, height int) {
 		"contentHeight", v.ContentHeight,
 		"sidebarWidth", v.SidebarWidth,
 		"chatWidth", v.ChatWidth,
+		"mode", v.Mode,
 	)
 }
 
+// SetMode sets which panels the layout makes room for. It takes effect at the
+// next UpdateTerminalSize.
+func (v *ViewContext) SetMode(mode ViewMode) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.Mode = mode
+}
+
+// SetMaxChatWidth sets the most columns chat messages are wrapped to, however
+// wide the chat panel; 0 removes the cap. Chats pick it up when they next
+// render their content.
+func (v *ViewContext) SetMaxChatWidth(width int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.MaxChatWidth = max(width, 0)
+}
+
+// ChatContentWidth returns the width of the chat's content, padding included,
+// in a viewport innerWidth wide, and the margin left of it that centers it.
+// Content fills the viewport unless MaxChatWidth caps it.
+func (v *ViewContext) ChatContentWidth(innerWidth int) (width, margin int) {
+	v.mu.Lock()
+	maxWidth := v.MaxChatWidth
+	v.mu.Unlock()
+	if maxWidth <= 0 || innerWidth <= maxWidth+ContentPadding {
+		return innerWidth, 0
+	}
+	width = maxWidth + ContentPadding
+	return width, (innerWidth - width) / 2
+}
+
+// ToolUseRegionHeight returns the height of the region the expanded tool-use
+// rollup scrolls in, for a chat panel chatHeight tall whose input area takes
+// inputHeight and a rollup wanting lines. The region is capped at a fraction of
+// the chat height and leaves MinChatViewportHeight lines of conversation; it is
+// 0 when that leaves less than MinToolUseRegionHeight.
+func (v *ViewContext) ToolUseRegionHeight(chatHeight, inputHeight, lines int) int {
+	available := chatHeight - inputHeight - BorderSize - MinChatViewportHeight
+	height := min(lines, chatHeight/ToolUseRegionHeightRatio, available)
+	if height < MinToolUseRegionHeight {
+		return 0
+	}
+	return height
+}
+
 // InnerWidth returns the usable width inside a panel with borders
 func (v *ViewContext) InnerWidth(panelWidth int) int {
 	return panelWidth - BorderSize
@@ -53,6 +53,81 @@ func TestViewContext_UpdateTerminalSize(t *testing.T) {
 	}
 }
 
+func TestViewContext_FocusMode(t *testing.T) {
+	ctx := GetViewContext()
+	ctx.SetMode(ViewModeFocus)
+	t.Cleanup(func() {
+		ctx.SetMode(ViewModeNormal)
+		ctx.UpdateTerminalSize(120, 40)
+	})
+
+	ctx.UpdateTerminalSize(120, 40)
+
+	if ctx.SidebarWidth != 0 {
+		t.Errorf("Expected no sidebar in focus mode, got width %d", ctx.SidebarWidth)
+	}
+	if ctx.ChatWidth != 120 {
+		t.Errorf("Expected full-width chat in focus mode, got %d", ctx.ChatWidth)
+	}
+	if ctx.FooterHeight != 0 {
+		t.Errorf("Expected no footer in focus mode, got height %d", ctx.FooterHeight)
+	}
+	if expected := 40 - HeaderHeight; ctx.ContentHeight != expected {
+		t.Errorf("Expected ContentHeight %d, got %d", expected, ctx.ContentHeight)
+	}
+}
+
+func TestViewContext_ChatContentWidth(t *testing.T) {
+	ctx := GetViewContext()
+	t.Cleanup(func() { ctx.SetMaxChatWidth(0) })
+
+	if width, margin := ctx.ChatContentWidth(300); width != 300 || margin != 0 {
+		t.Errorf("ChatContentWidth(300) with no cap = %d, %d, want 300, 0", width, margin)
+	}
+
+	ctx.SetMaxChatWidth(120)
+	tests := []struct {
+		innerWidth int
+		width      int
+		margin     int
+	}{
+		{80, 80, 0},
+		{120 + ContentPadding, 120 + ContentPadding, 0},
+		{300, 120 + ContentPadding, (300 - 120 - ContentPadding) / 2},
+	}
+	for _, tt := range tests {
+		width, margin := ctx.ChatContentWidth(tt.innerWidth)
+		if width != tt.width || margin != tt.margin {
+			t.Errorf("ChatContentWidth(%d) = %d, %d, want %d, %d", tt.innerWidth, width, margin, tt.width, tt.margin)
+		}
+	}
+}
+
+func TestViewContext_ToolUseRegionHeight(t *testing.T) {
+	ctx := GetViewContext()
+	tests := []struct {
+		name        string
+		chatHeight  int
+		inputHeight int
+		lines       int
+		want        int
+	}{
+		{"fits", 40, InputTotalHeight, 5, 5},
+		{"capped at a fraction of the chat", 40, InputTotalHeight, 81, 40 / ToolUseRegionHeightRatio},
+		{"leaves the conversation its minimum", 14, InputTotalHeight, 81, 14 - InputTotalHeight - BorderSize - MinChatViewportHeight},
+		{"image indicator taken into account", 14, InputTotalHeight + ImageIndicatorHeight, 81, MinToolUseRegionHeight},
+		{"too short for a region", 12, InputTotalHeight, 81, 0},
+		{"too few lines for a region", 40, InputTotalHeight, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ctx.ToolUseRegionHeight(tt.chatHeight, tt.inputHeight, tt.lines); got != tt.want {
+				t.Errorf("ToolUseRegionHeight(%d, %d, %d) = %d, want %d", tt.chatHeight, tt.inputHeight, tt.lines, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestViewContext_InnerWidth(t *testing.T) {
 	ctx := GetViewContext()
 
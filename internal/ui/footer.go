@@ -64,6 +64,9 @@ type Footer struct {
 	hasDetectedOptions bool          // Whether chat has detected options for parallel exploration
 	kittyKeyboard      bool          // Terminal supports Kitty keyboard protocol
 	flashMessage       *FlashMessage // Current flash message, if any
+	updateVersion      string        // Newer release to offer the notes of (empty if none)
+	todoFocusMode      bool          // Whether keys mark todo items
+	destructivePrompt  bool          // Whether the pending permission is for a destructive command
 
 	// Dynamic bindings generator (injected from app)
 	getApplicableBindings func() []KeyBinding
@@ -88,6 +91,23 @@ func (f *Footer) SetContext(hasSession, sidebarFocused, pendingPermission, pendi
 	f.kittyKeyboard = kittyKeyboard
 }
 
+// SetUpdateHint offers the release notes of a newer release, named by version
+// (e.g., "v0.9.0"), ahead of the sidebar's bindings. Empty removes the hint.
+func (f *Footer) SetUpdateHint(version string) {
+	f.updateVersion = version
+}
+
+// SetTodoFocusMode sets whether keys move among and mark todo items.
+func (f *Footer) SetTodoFocusMode(active bool) {
+	f.todoFocusMode = active
+}
+
+// SetDestructivePermission sets whether the pending permission prompt is for
+// a destructive command, allowed by typing a phrase rather than y or a.
+func (f *Footer) SetDestructivePermission(destructive bool) {
+	f.destructivePrompt = destructive
+}
+
 // SetWidth sets the footer width
 func (f *Footer) SetWidth(width int) {
 	f.width = width
@@ -214,6 +234,7 @@ func (f *Footer) View() string {
 			{Key: "←/→", Desc: "switch pane"},
 			{Key: "↑/↓", Desc: "select file"},
 			{Key: "j/k", Desc: "scroll diff"},
+			{Key: "s", Desc: "split/unified"},
 			{Key: "esc/q", Desc: "close"},
 		}
 		for _, b := range viewChangesBindings {
@@ -225,6 +246,22 @@ func (f *Footer) View() string {
 		return FooterStyle.Width(f.width).MaxHeight(1).Render(content)
 	}
 
+	// Show todo marking shortcuts when in todo focus mode
+	if f.todoFocusMode {
+		todoBindings := []KeyBinding{
+			{Key: "↑/↓", Desc: "select item"},
+			{Key: "space", Desc: "mark done/not done"},
+			{Key: "esc", Desc: "finish"},
+		}
+		for _, b := range todoBindings {
+			key := FooterKeyStyle.Render(b.Key)
+			desc := FooterDescStyle.Render(": " + b.Desc)
+			parts = append(parts, key+desc)
+		}
+		content := strings.Join(parts, footerSeparator())
+		return FooterStyle.Width(f.width).MaxHeight(1).Render(content)
+	}
+
 	// Show search-specific shortcuts when in search mode
 	if f.searchMode {
 		searchBindings := []KeyBinding{
@@ -269,6 +306,13 @@ func (f *Footer) View() string {
 			{Key: "a", Desc: "always allow"},
 			{Key: "tab", Desc: "switch pane"},
 		}
+		if f.destructivePrompt {
+			permBindings = []KeyBinding{
+				{Key: DestructiveConfirmPhrase + " ⏎", Desc: "allow"},
+				{Key: "n", Desc: "deny"},
+				{Key: "tab", Desc: "switch pane"},
+			}
+		}
 		for _, b := range permBindings {
 			key := FooterKeyStyle.Render(b.Key)
 			desc := FooterDescStyle.Render(": " + b.Desc)
@@ -324,6 +368,11 @@ func (f *Footer) View() string {
 			parts = append(parts, key+desc)
 		}
 	} else {
+		if f.updateVersion != "" {
+			parts = append(parts, FooterDescStyle.Render(f.updateVersion+" available — press ")+
+				FooterKeyStyle.Render("ctrl+u")+FooterDescStyle.Render(" for changes"))
+		}
+
 		// Get applicable bindings from the dynamic generator (uses shortcut registry)
 		var bindings []KeyBinding
 		if f.getApplicableBindings != nil {
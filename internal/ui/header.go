@@ -2,7 +2,9 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"charm.land/lipgloss/v2"
@@ -24,6 +26,7 @@ type Header struct {
 	diffStats       *DiffStats
 	previewActive   bool
 	containerActive bool
+	paused          bool
 }
 
 // NewHeader creates a new header
@@ -61,11 +64,16 @@ func (h *Header) SetContainerActive(active bool) {
 	h.containerActive = active
 }
 
+// SetPaused sets whether all sessions are paused
+func (h *Header) SetPaused(paused bool) {
+	h.paused = paused
+}
+
 // headerRegion represents a styled region in the header
 type headerRegion struct {
 	start int
 	end   int
-	style string // "normal", "muted", "added", "deleted", "preview", "container"
+	style string // "normal", "muted", "added", "deleted", "preview", "container", "paused"
 }
 
 // View renders the header
@@ -77,6 +85,12 @@ func (h *Header) View() string {
 	var rightText string
 	var regions []headerRegion
 
+	// Pausing applies to every session, so it shows with or without one selected
+	if h.paused {
+		rightText += "[PAUSED] "
+		regions = append(regions, headerRegion{start: 0, end: utf8.RuneCountInString(rightText), style: "paused"})
+	}
+
 	if h.sessionName != "" {
 		// Add container indicator if active
 		if h.containerActive {
@@ -175,6 +189,7 @@ func (h *Header) renderGradient(content string, regions []headerRegion) string {
 	deletedColor := lipgloss.Color(theme.DiffRemoved)
 	previewColor := lipgloss.Color(theme.Warning)   // Use warning color (amber/yellow) for preview indicator
 	containerColor := lipgloss.Color(theme.Success) // Use success color (green) for container indicator
+	pausedColor := lipgloss.Color(theme.Error)      // Use error color (red) for paused indicator
 
 	// Helper to get the style for a given position
 	getStyleForPos := func(pos int) string {
@@ -222,6 +237,8 @@ func (h *Header) renderGradient(content string, regions []headerRegion) string {
 			style = style.Foreground(previewColor).Bold(true)
 		case "container":
 			style = style.Foreground(containerColor).Bold(true)
+		case "paused":
+			style = style.Foreground(pausedColor).Bold(true)
 		default:
 			style = style.Foreground(textColor)
 		}
@@ -232,3 +249,24 @@ func (h *Header) renderGradient(content string, regions []headerRegion) string {
 
 	return result.String()
 }
+
+// FocusView renders the one-line status shown in place of the header in focus
+// mode: the session name, whether it is streaming, and the time left if focus
+// mode is time-boxed (remaining > 0).
+func (h *Header) FocusView(streaming bool, remaining time.Duration) string {
+	status := "idle"
+	if streaming {
+		status = "streaming"
+	}
+	left := " " + h.sessionName + " · " + status
+	if remaining > 0 {
+		left += fmt.Sprintf(" · %dm left", int(math.Ceil(remaining.Minutes())))
+	}
+	right := "tab to leave focus "
+
+	paddingLen := max(h.width-lipgloss.Width(left)-lipgloss.Width(right), 1)
+	return lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		MaxWidth(h.width).
+		Render(left + strings.Repeat(" ", paddingLen) + right)
+}
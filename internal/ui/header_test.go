@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"charm.land/lipgloss/v2"
 )
@@ -376,3 +377,47 @@ func TestHeader_View_MixedASCIIAndUnicode(t *testing.T) {
 		t.Errorf("Header display width should be 100, got %d", displayWidth)
 	}
 }
+
+func TestHeader_View_Paused(t *testing.T) {
+	header := NewHeader()
+	header.SetWidth(120)
+	header.SetPaused(true)
+
+	// Shown without a session too
+	if view := stripANSI(header.View()); !strings.Contains(view, "[PAUSED]") {
+		t.Errorf("Header should show the paused indicator, got: %q", view)
+	}
+
+	header.SetSessionName("feature-branch")
+	view := stripANSI(header.View())
+	if !strings.Contains(view, "[PAUSED] feature-branch") {
+		t.Errorf("Header should show the paused indicator before the session, got: %q", view)
+	}
+
+	header.SetPaused(false)
+	if view := stripANSI(header.View()); strings.Contains(view, "[PAUSED]") {
+		t.Errorf("Header should not show the paused indicator once resumed, got: %q", view)
+	}
+}
+
+func TestHeader_FocusView(t *testing.T) {
+	header := NewHeader()
+	header.SetWidth(80)
+	header.SetSessionName("feature-branch")
+
+	view := stripANSI(header.FocusView(true, 0))
+	if !strings.Contains(view, "feature-branch · streaming") {
+		t.Errorf("Focus status should show the session and streaming state, got: %q", view)
+	}
+	if strings.Contains(view, "left") {
+		t.Errorf("Focus status should not show a time limit when there is none, got: %q", view)
+	}
+	if lipgloss.Width(view) != 80 {
+		t.Errorf("Focus status should fill the width, got %d", lipgloss.Width(view))
+	}
+
+	view = stripANSI(header.FocusView(false, 90*time.Second))
+	if !strings.Contains(view, "feature-branch · idle · 2m left") {
+		t.Errorf("Focus status should round the time left up to minutes, got: %q", view)
+	}
+}
@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/viewport"
+	"charm.land/lipgloss/v2"
+)
+
+// liveDiffHighlightDuration is how long the hunks changed at a refresh of the
+// live diff stay highlighted.
+const liveDiffHighlightDuration = 2 * time.Second
+
+// EnterLiveDiffMode switches the chat panel from the conversation to the live
+// worktree diff. The diff is empty until the first SetLiveDiff.
+func (c *Chat) EnterLiveDiffMode() {
+	c.liveDiff = &LiveDiffState{
+		Viewport: viewport.New(),
+	}
+
+	// Configure viewport
+	c.liveDiff.Viewport.MouseWheelEnabled = true
+	c.liveDiff.Viewport.MouseWheelDelta = 3
+	c.liveDiff.Viewport.SoftWrap = true
+
+	// Size it - will be adjusted in render, but set initial size
+	c.liveDiff.Viewport.SetWidth(c.viewport.Width())
+	c.liveDiff.Viewport.SetHeight(c.viewport.Height())
+	c.liveDiff.Viewport.SetContent(lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Loading diff..."))
+}
+
+// ExitLiveDiffMode switches the chat panel back to the conversation.
+func (c *Chat) ExitLiveDiffMode() {
+	c.liveDiff = nil
+}
+
+// IsInLiveDiffMode returns whether the chat panel is showing the live diff.
+func (c *Chat) IsInLiveDiffMode() bool {
+	return c.liveDiff != nil
+}
+
+// SetLiveDiffBaseline sets the diff the first refresh of the live diff is
+// compared with, as last seen for the session, so that what changed since is
+// highlighted.
+func (c *Chat) SetLiveDiffBaseline(diff string) {
+	if c.liveDiff != nil {
+		c.liveDiff.Baseline = &diff
+	}
+}
+
+// SetLiveDiff updates the live diff with the worktree's current diff, or with
+// the error reading it. The scroll position is kept across refreshes. Hunks
+// not in the previous diff are highlighted for liveDiffHighlightDuration.
+func (c *Chat) SetLiveDiff(diff string, err error) {
+	if c.liveDiff == nil {
+		return
+	}
+	first := c.liveDiff.UpdatedAt.IsZero()
+	now := time.Now()
+	c.liveDiff.UpdatedAt = now
+
+	errText := ""
+	if err != nil {
+		errText = err.Error()
+	}
+	expired := c.liveDiff.Changed != nil && !now.Before(c.liveDiff.HighlightUntil)
+	if !first && diff == c.liveDiff.Diff && errText == c.liveDiff.Error && !expired {
+		return
+	}
+
+	previous, compare := c.liveDiff.Diff, !first && c.liveDiff.Error == ""
+	if first && c.liveDiff.Baseline != nil {
+		previous, compare = *c.liveDiff.Baseline, true
+	}
+	if compare && errText == "" && diff != previous {
+		c.liveDiff.Changed, c.liveDiff.ChangedHunks = changedDiffLines(previous, diff)
+		c.liveDiff.HighlightUntil = now.Add(liveDiffHighlightDuration)
+	} else if expired || errText != "" {
+		c.liveDiff.Changed, c.liveDiff.ChangedHunks = nil, 0
+	}
+	c.liveDiff.Diff = diff
+	c.liveDiff.Error = errText
+
+	var content string
+	switch {
+	case errText != "":
+		content = lipgloss.NewStyle().Foreground(ColorError).Render("Error reading worktree: " + errText)
+	case diff == "":
+		content = lipgloss.NewStyle().Foreground(ColorTextMuted).Render("No uncommitted changes in this session.")
+	default:
+		content = highlightDiffLines(diff, c.liveDiff.Changed)
+	}
+	c.liveDiff.Viewport.SetContent(content)
+	if first {
+		c.liveDiff.Viewport.GotoTop()
+	}
+}
+
+// renderLiveDiffMode renders the live diff with a one-line title bar.
+func (c *Chat) renderLiveDiffMode(panelStyle lipgloss.Style) string {
+	if c.liveDiff == nil {
+		return ""
+	}
+
+	// Calculate dimensions
+	innerWidth := c.width - 2 // Account for panel border
+	innerHeight := c.height - 2
+	diffHeight := innerHeight - 1 // Title bar
+
+	c.liveDiff.Viewport.SetWidth(innerWidth)
+	c.liveDiff.Viewport.SetHeight(diffHeight)
+
+	title := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true).Render("Live diff")
+	status := "loading"
+	if !c.liveDiff.UpdatedAt.IsZero() {
+		status = "updated " + c.liveDiff.UpdatedAt.Format("15:04:05")
+	}
+	if n := c.liveDiff.ChangedHunks; n > 0 {
+		changed := "1 hunk changed"
+		if n > 1 {
+			changed = fmt.Sprintf("%d hunks changed", n)
+		}
+		title += lipgloss.NewStyle().Foreground(ColorWarning).Render("  " + changed)
+	}
+	titleBar := lipgloss.NewStyle().Width(innerWidth).MaxHeight(1).Render(
+		title + lipgloss.NewStyle().Foreground(ColorTextMuted).Render(fmt.Sprintf("  %s · ctrl-f: back to chat", status)))
+
+	// Get viewport content and constrain to max height to prevent layout overflow
+	diffContent := lipgloss.NewStyle().
+		MaxHeight(diffHeight).
+		Render(c.liveDiff.Viewport.View())
+
+	content := lipgloss.JoinVertical(lipgloss.Left, titleBar, diffContent)
+	return panelStyle.Width(c.width).Height(c.height).Render(content)
+}
+
+// changedDiffLines compares two unified diffs hunk by hunk, returning the
+// indexes of the lines of diff in hunks that prev doesn't have, with how many
+// such hunks there are. Hunks are matched by file and content, not by their
+// line numbers, so that a hunk moved by edits above it isn't counted.
+func changedDiffLines(prev, diff string) (map[int]bool, int) {
+	seen := make(map[string]bool)
+	for _, h := range diffHunks(strings.Split(strings.TrimRight(prev, "\n"), "\n")) {
+		seen[h.key] = true
+	}
+
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	changed := make(map[int]bool)
+	count := 0
+	for _, h := range diffHunks(lines) {
+		if seen[h.key] {
+			continue
+		}
+		count++
+		for i := h.start; i < h.end; i++ {
+			changed[i] = true
+		}
+	}
+	return changed, count
+}
+
+// diffHunk is a hunk of a unified diff: the indexes of its lines, from its
+// "@@" line, and its file and content to tell it apart from other hunks.
+type diffHunk struct {
+	start, end int
+	key        string
+}
+
+// diffHunks splits the lines of a unified diff into hunks.
+func diffHunks(lines []string) []diffHunk {
+	var hunks []diffHunk
+	file := ""
+	start := -1
+	closeHunk := func(end int) {
+		if start >= 0 {
+			key := file + "\x00" + strings.Join(lines[start+1:end], "\n")
+			hunks = append(hunks, diffHunk{start: start, end: end, key: key})
+			start = -1
+		}
+	}
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git"):
+			closeHunk(i)
+			file = line
+		case strings.HasPrefix(line, "@@"):
+			closeHunk(i)
+			start = i
+		}
+	}
+	closeHunk(len(lines))
+	return hunks
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestLiveDiff_KeepsScrollAcrossRefreshes(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 20)
+	chat.EnterLiveDiffMode()
+
+	var diff strings.Builder
+	diff.WriteString("diff --git a/main.go b/main.go\n")
+	for i := range 100 {
+		fmt.Fprintf(&diff, "+line %d\n", i)
+	}
+	chat.SetLiveDiff(diff.String(), nil)
+	chat.View()
+
+	chat, _ = chat.Update(tea.KeyPressMsg{Code: tea.KeyPgDown})
+	offset := chat.liveDiff.Viewport.YOffset()
+	if offset == 0 {
+		t.Fatal("expected page down to scroll the diff")
+	}
+
+	chat.SetLiveDiff(diff.String()+"+line 100\n", nil)
+	if got := chat.liveDiff.Viewport.YOffset(); got != offset {
+		t.Errorf("refresh moved the scroll position from %d to %d", offset, got)
+	}
+}
+
+func TestLiveDiff_EmptyAndError(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 20)
+	chat.EnterLiveDiffMode()
+
+	chat.SetLiveDiff("", nil)
+	if view := stripANSI(chat.View()); !strings.Contains(view, "No uncommitted changes") {
+		t.Errorf("expected an empty-diff message, got:\n%s", view)
+	}
+
+	chat.SetLiveDiff("", errors.New("not a git repository"))
+	if view := stripANSI(chat.View()); !strings.Contains(view, "not a git repository") {
+		t.Errorf("expected the error shown, got:\n%s", view)
+	}
+
+	chat, _ = chat.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if chat.IsInLiveDiffMode() {
+		t.Error("expected Escape to return to the conversation")
+	}
+}
+
+func TestChangedDiffLines(t *testing.T) {
+	prev := "diff --git a/a.go b/a.go\n@@ -1,2 +1,2 @@\n-old\n+new\ndiff --git a/b.go b/b.go\n@@ -5 +5 @@\n+kept\n"
+
+	// A hunk moved by an edit above it still matches; the new one doesn't
+	diff := "diff --git a/a.go b/a.go\n@@ -1,2 +1,2 @@\n-old\n+new\ndiff --git a/b.go b/b.go\n@@ -1 +1 @@\n+added\n@@ -5 +6 @@\n+kept\n"
+	changed, hunks := changedDiffLines(prev, diff)
+	if hunks != 1 {
+		t.Fatalf("expected 1 changed hunk, got %d", hunks)
+	}
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		if want := line == "@@ -1 +1 @@" || line == "+added"; changed[i] != want {
+			t.Errorf("line %d %q: changed = %v, want %v", i, line, changed[i], want)
+		}
+	}
+
+	// The same content in another file is a change
+	if _, hunks := changedDiffLines(prev, strings.ReplaceAll(prev, "b.go", "c.go")); hunks != 1 {
+		t.Errorf("expected a hunk moved to another file counted, got %d", hunks)
+	}
+}
+
+func TestLiveDiff_HighlightsChangedHunks(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 20)
+	chat.EnterLiveDiffMode()
+
+	diff := "diff --git a/main.go b/main.go\n@@ -1 +1 @@\n+first\n"
+	chat.SetLiveDiff(diff, nil)
+	if chat.liveDiff.ChangedHunks != 0 {
+		t.Error("expected nothing highlighted at the first refresh")
+	}
+
+	diff += "@@ -9 +9 @@\n+second\n"
+	chat.SetLiveDiff(diff, nil)
+	if chat.liveDiff.ChangedHunks != 1 || !chat.liveDiff.Changed[4] || chat.liveDiff.Changed[2] {
+		t.Fatalf("expected only the new hunk highlighted, got %v", chat.liveDiff.Changed)
+	}
+	if view := stripANSI(chat.View()); !strings.Contains(view, "1 hunk changed") {
+		t.Errorf("expected the title to count the changed hunks, got:\n%s", view)
+	}
+
+	// The highlight stays while it lasts, and is cleared by a refresh after
+	chat.SetLiveDiff(diff, nil)
+	if chat.liveDiff.ChangedHunks != 1 {
+		t.Error("expected the highlight kept by a refresh while it lasts")
+	}
+	chat.liveDiff.HighlightUntil = time.Now().Add(-time.Second)
+	chat.SetLiveDiff(diff, nil)
+	if chat.liveDiff.ChangedHunks != 0 || chat.liveDiff.Changed != nil {
+		t.Error("expected the highlight cleared once it has lasted")
+	}
+	if view := stripANSI(chat.View()); strings.Contains(view, "changed") {
+		t.Errorf("expected the count gone from the title, got:\n%s", view)
+	}
+}
+
+func TestLiveDiff_BaselineHighlightsFirstRefresh(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(80, 20)
+	chat.EnterLiveDiffMode()
+	chat.SetLiveDiffBaseline("")
+
+	chat.SetLiveDiff("diff --git a/main.go b/main.go\n@@ -1 +1 @@\n+first\n", nil)
+	if chat.liveDiff.ChangedHunks != 1 {
+		t.Errorf("expected the hunk new since the baseline highlighted, got %d", chat.liveDiff.ChangedHunks)
+	}
+}
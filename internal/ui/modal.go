@@ -3,6 +3,8 @@
 package ui
 
 import (
+	"context"
+
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
@@ -31,11 +33,22 @@ type (
 	ForkSessionState         = modals.ForkSessionState
 	RenameSessionState       = modals.RenameSessionState
 	MergeState               = modals.MergeState
+	MergePreview             = modals.MergePreview
+	FileOverlapState         = modals.FileOverlapState
+	FileOverlapItem          = modals.FileOverlapItem
 	LoadingCommitState       = modals.LoadingCommitState
 	EditCommitState          = modals.EditCommitState
 	MergeConflictState       = modals.MergeConflictState
 	ConfirmDeleteState       = modals.ConfirmDeleteState
 	ConfirmDeleteRepoState   = modals.ConfirmDeleteRepoState
+	MissingSessionState      = modals.MissingSessionState
+	CLIVersionChangedState   = modals.CLIVersionChangedState
+	RepairSessionNamesState  = modals.RepairSessionNamesState
+	SessionNameRepair        = modals.SessionNameRepair
+	ModelPickerState         = modals.ModelPickerState
+	EditTagsState            = modals.EditTagsState
+	TagFilterState           = modals.TagFilterState
+	FilePickerState          = modals.FilePickerState
 	ConfirmExitState         = modals.ConfirmExitState
 	MCPServersState          = modals.MCPServersState
 	AddMCPServerState        = modals.AddMCPServerState
@@ -43,6 +56,7 @@ type (
 	AddMarketplaceState      = modals.AddMarketplaceState
 	WelcomeState             = modals.WelcomeState
 	ChangelogState           = modals.ChangelogState
+	ReleaseNotesState        = modals.ReleaseNotesState
 	SettingsState            = modals.SettingsState
 	ImportIssuesState        = modals.ImportIssuesState
 	SelectIssueSourceState   = modals.SelectIssueSourceState
@@ -50,7 +64,27 @@ type (
 	HelpState                = modals.HelpState
 	ExploreOptionsState      = modals.ExploreOptionsState
 	SearchMessagesState      = modals.SearchMessagesState
+	SessionSwitcherState     = modals.SessionSwitcherState
+	SessionSwitcherItem      = modals.SessionSwitcherItem
+	SnapshotPickerState      = modals.SnapshotPickerState
+	SnapshotPickerItem       = modals.SnapshotPickerItem
+	SnippetItem              = modals.SnippetItem
+	SnippetPickerState       = modals.SnippetPickerState
+	SnippetFileState         = modals.SnippetFileState
+	SnippetsState            = modals.SnippetsState
+	PromptTemplateItem       = modals.PromptTemplateItem
+	TemplatePickerState      = modals.TemplatePickerState
+	TemplateVariablesState   = modals.TemplateVariablesState
 	PreviewActiveState       = modals.PreviewActiveState
+	PasteCleanState          = modals.PasteCleanState
+	PasteCleanChoice         = modals.PasteCleanChoice
+	ImageTooLargeState       = modals.ImageTooLargeState
+	ContextFilesState        = modals.ContextFilesState
+	TouchedFilesState        = modals.TouchedFilesState
+	TouchedFileItem          = modals.TouchedFileItem
+	PRProgressState          = modals.PRProgressState
+	PRStepItem               = modals.PRStepItem
+	PRStepState              = modals.PRStepState
 	BroadcastState           = modals.BroadcastState
 	BroadcastGroupState      = modals.BroadcastGroupState
 	BroadcastGroupAction     = modals.BroadcastGroupAction
@@ -81,6 +115,29 @@ const (
 	BulkActionSendPrompt = modals.BulkActionSendPrompt
 )
 
+// Re-export paste cleaning choices
+const (
+	PasteCleanSession = modals.PasteCleanSession
+	PasteRawSession   = modals.PasteRawSession
+	PasteCleanAlways  = modals.PasteCleanAlways
+	PasteCleanNever   = modals.PasteCleanNever
+)
+
+// Re-export PR pipeline step states
+const (
+	PRStepPending = modals.PRStepPending
+	PRStepRunning = modals.PRStepRunning
+	PRStepDone    = modals.PRStepDone
+	PRStepFailed  = modals.PRStepFailed
+)
+
+// Re-export LFS checkout choices
+const (
+	LFSChoiceFull   = modals.LFSChoiceFull
+	LFSChoiceSkip   = modals.LFSChoiceSkip
+	LFSChoiceCancel = modals.LFSChoiceCancel
+)
+
 // Re-export constructor functions
 var (
 	NewAddRepoState                   = modals.NewAddRepoState
@@ -90,11 +147,19 @@ var (
 	NewRenameSessionState             = modals.NewRenameSessionState
 	NewSessionSettingsState           = modals.NewSessionSettingsState
 	NewMergeState                     = modals.NewMergeState
+	NewFileOverlapState               = modals.NewFileOverlapState
 	NewLoadingCommitState             = modals.NewLoadingCommitState
 	NewEditCommitState                = modals.NewEditCommitState
 	NewMergeConflictState             = modals.NewMergeConflictState
 	NewConfirmDeleteState             = modals.NewConfirmDeleteState
 	NewConfirmDeleteRepoState         = modals.NewConfirmDeleteRepoState
+	NewMissingSessionState            = modals.NewMissingSessionState
+	NewCLIVersionChangedState         = modals.NewCLIVersionChangedState
+	NewRepairSessionNamesState        = modals.NewRepairSessionNamesState
+	NewModelPickerState               = modals.NewModelPickerState
+	NewEditTagsState                  = modals.NewEditTagsState
+	NewTagFilterState                 = modals.NewTagFilterState
+	NewFilePickerState                = modals.NewFilePickerState
 	NewConfirmExitState               = modals.NewConfirmExitState
 	NewMCPServersState                = modals.NewMCPServersState
 	NewAddMCPServerState              = modals.NewAddMCPServerState
@@ -103,13 +168,27 @@ var (
 	NewAddMarketplaceState            = modals.NewAddMarketplaceState
 	NewWelcomeState                   = modals.NewWelcomeState
 	NewChangelogState                 = modals.NewChangelogState
+	NewReleaseNotesState              = modals.NewReleaseNotesState
+	ReleaseNotesWidth                 = modals.ReleaseNotesWidth
 	NewImportIssuesState              = modals.NewImportIssuesState
 	NewImportIssuesStateWithSource    = modals.NewImportIssuesStateWithSource
 	NewSelectIssueSourceState         = modals.NewSelectIssueSourceState
 	NewHelpStateFromSections          = modals.NewHelpStateFromSections
 	NewExploreOptionsState            = modals.NewExploreOptionsState
 	NewSearchMessagesState            = modals.NewSearchMessagesState
+	NewSessionSwitcherState           = modals.NewSessionSwitcherState
+	NewSnapshotPickerState            = modals.NewSnapshotPickerState
+	NewSnippetPickerState             = modals.NewSnippetPickerState
+	NewSnippetFileState               = modals.NewSnippetFileState
+	NewSnippetsState                  = modals.NewSnippetsState
+	NewTemplatePickerState            = modals.NewTemplatePickerState
+	NewTemplateVariablesState         = modals.NewTemplateVariablesState
 	NewPreviewActiveState             = modals.NewPreviewActiveState
+	NewPasteCleanState                = modals.NewPasteCleanState
+	NewImageTooLargeState             = modals.NewImageTooLargeState
+	NewContextFilesState              = modals.NewContextFilesState
+	NewTouchedFilesState              = modals.NewTouchedFilesState
+	NewPRProgressState                = modals.NewPRProgressState
 	NewBroadcastState                 = modals.NewBroadcastState
 	NewBroadcastGroupState            = modals.NewBroadcastGroupState
 	NewReviewCommentsState            = modals.NewReviewCommentsState
@@ -139,14 +218,15 @@ func themeKeysAndNames() ([]string, []string) {
 	return themeKeys, themeDisplayNames
 }
 
-// NewSettingsState creates a new SettingsState with theme data injected automatically.
+// NewSettingsState creates a new SettingsState with theme and syntax style data injected automatically.
 func NewSettingsState(currentBranchPrefix string, notificationsEnabled bool,
-	autoCleanupMerged bool) *SettingsState {
+	autoCleanupMerged bool, compactToolUses bool, focusInputOnNew bool) *SettingsState {
 	themeKeys, themeDisplayNames := themeKeysAndNames()
 	currentTheme := string(CurrentThemeName())
 	return modals.NewSettingsState(themeKeys, themeDisplayNames, currentTheme,
+		CustomSyntaxStyles(), SyntaxStyle(),
 		currentBranchPrefix, notificationsEnabled,
-		autoCleanupMerged)
+		autoCleanupMerged, compactToolUses, focusInputOnNew)
 }
 
 // GetSelectedSettingsTheme returns the selected theme from a SettingsState as a ThemeName.
@@ -157,8 +237,10 @@ func GetSelectedSettingsTheme(s *SettingsState) ThemeName {
 // Modal represents a popup dialog with type-safe state management.
 // The State field is nil when no modal is visible.
 type Modal struct {
-	State ModalState
-	error string
+	State  ModalState
+	error  string
+	ctx    context.Context // Work started from the shown modal, see Context
+	cancel context.CancelFunc
 }
 
 // NewModal creates a new modal
@@ -168,16 +250,38 @@ func NewModal() *Modal {
 
 // Show displays a modal with the given state
 func (m *Modal) Show(state ModalState) {
+	if state != m.State {
+		m.cancelWork()
+	}
 	m.State = state
 	m.error = ""
 }
 
 // Hide hides the modal
 func (m *Modal) Hide() {
+	m.cancelWork()
 	m.State = nil
 	m.error = ""
 }
 
+// Context returns a context for work started from the shown modal, canceled
+// when the modal is hidden or replaced by another, so closing it stops work
+// whose results it was waiting to show.
+func (m *Modal) Context() context.Context {
+	if m.ctx == nil {
+		m.ctx, m.cancel = context.WithCancel(context.Background())
+	}
+	return m.ctx
+}
+
+// cancelWork cancels the context of work started from the shown modal.
+func (m *Modal) cancelWork() {
+	if m.cancel != nil {
+		m.cancel()
+		m.ctx, m.cancel = nil, nil
+	}
+}
+
 // IsVisible returns whether the modal is visible
 func (m *Modal) IsVisible() bool {
 	return m.State != nil
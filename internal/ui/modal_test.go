@@ -50,6 +50,35 @@ func TestModal_ShowHide(t *testing.T) {
 	}
 }
 
+func TestModal_ContextCanceledOnClose(t *testing.T) {
+	modal := NewModal()
+	state := NewAddRepoState("")
+	modal.Show(state)
+
+	ctx := modal.Context()
+	if modal.Context() != ctx {
+		t.Error("expected the same context for the shown modal")
+	}
+	modal.Show(state)
+	if ctx.Err() != nil {
+		t.Error("expected showing the same modal again to keep its context")
+	}
+
+	modal.Show(NewAddRepoState(""))
+	if ctx.Err() == nil {
+		t.Error("expected replacing the modal to cancel its context")
+	}
+
+	ctx = modal.Context()
+	modal.Hide()
+	if ctx.Err() == nil {
+		t.Error("expected hiding the modal to cancel its context")
+	}
+	if modal.Context().Err() != nil {
+		t.Error("expected a fresh context after hiding")
+	}
+}
+
 func TestModal_Error(t *testing.T) {
 	modal := NewModal()
 
@@ -317,7 +346,7 @@ func TestNewSessionState_Help(t *testing.T) {
 	state := NewNewSessionState([]string{"/repo1", "/repo2"}, false, false)
 	state.Focus = 0
 	help := state.Help()
-	if help != "up/down: select  Tab: next field  a: add repo  d: delete repo  Enter: create" {
+	if help != "up/down: select  Tab: next field  a: add repo  d: delete repo  i: from issue  Enter: create" {
 		t.Errorf("Expected help with add/delete hints when focused on repos, got %q", help)
 	}
 
@@ -333,7 +362,7 @@ func TestNewSessionState_Help(t *testing.T) {
 	state = NewNewSessionState([]string{"/repo1"}, false, false)
 	state.Focus = 1
 	help = state.Help()
-	if help != "up/down: select  Tab: next field  Enter: create" {
+	if help != "up/down: select  Tab: next field  i: from issue  Enter: create" {
 		t.Errorf("Expected help without delete hint when focused on base, got %q", help)
 	}
 
@@ -26,6 +26,10 @@ type BulkActionState struct {
 	SessionCount int
 	Action       BulkAction
 	PromptInput  textarea.Model
+
+	// Progress of deleting the sessions, once confirmed
+	Deleting bool
+	Deleted  int // Sessions whose worktrees are deleted so far
 }
 
 func (*BulkActionState) modalState() {}
@@ -35,6 +39,9 @@ func (s *BulkActionState) Title() string {
 }
 
 func (s *BulkActionState) Help() string {
+	if s.Deleting {
+		return "Esc: stop, leaving sessions not yet started"
+	}
 	if s.Action == BulkActionSendPrompt {
 		return "tab/shift+tab: switch action  Enter: send  Esc: cancel"
 	}
@@ -82,6 +89,9 @@ func (s *BulkActionState) Render() string {
 	switch s.Action {
 	case BulkActionDelete:
 		confirmMsg = fmt.Sprintf("This will delete %d session(s) and their worktrees.", s.SessionCount)
+		if s.Deleting {
+			confirmMsg = fmt.Sprintf("Deleting worktrees... %d/%d", s.Deleted, s.SessionCount)
+		}
 	case BulkActionCreatePRs:
 		confirmMsg = fmt.Sprintf("Create PRs for %d session(s). Sessions with existing PRs or that are already merged will be skipped.", s.SessionCount)
 	case BulkActionSendPrompt:
@@ -102,6 +112,9 @@ func (s *BulkActionState) Render() string {
 }
 
 func (s *BulkActionState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if s.Deleting {
+		return s, nil
+	}
 	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
 		key := keyMsg.String()
 
@@ -181,6 +194,18 @@ func (s *BulkActionState) GetAction() BulkAction {
 	return s.Action
 }
 
+// StartDeleting shows that the sessions are being deleted, and stops the
+// action from being changed.
+func (s *BulkActionState) StartDeleting() {
+	s.Deleting = true
+	s.Deleted = 0
+}
+
+// SetDeleteProgress sets how many of the sessions' worktrees are deleted so far.
+func (s *BulkActionState) SetDeleteProgress(deleted int) {
+	s.Deleted = deleted
+}
+
 // GetPrompt returns the prompt text for send prompt action
 func (s *BulkActionState) GetPrompt() string {
 	return strings.TrimSpace(s.PromptInput.Value())
@@ -97,6 +97,25 @@ func TestBulkActionState_Render_Delete(t *testing.T) {
 
 
 
+func TestBulkActionState_DeleteProgress(t *testing.T) {
+	state := NewBulkActionState([]string{"s1", "s2", "s3"})
+	state.StartDeleting()
+	state.SetDeleteProgress(2)
+
+	rendered := state.Render()
+	if !strings.Contains(rendered, "2/3") {
+		t.Errorf("should show deletion progress, got:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "Esc: stop") {
+		t.Errorf("should offer to stop, got:\n%s", rendered)
+	}
+
+	state.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if state.Action != BulkActionDelete {
+		t.Errorf("should not switch action while deleting, got %d", state.Action)
+	}
+}
+
 func TestBulkActionState_Render_CreatePRs(t *testing.T) {
 	state := NewBulkActionState([]string{"s1", "s2", "s3"})
 	state.Action = BulkActionCreatePRs
@@ -0,0 +1,90 @@
+package modals
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// =============================================================================
+// CLIVersionChangedState - State for the CLI Version Changed modal
+// =============================================================================
+
+// CLIVersionChangedState is shown before resuming a session last run with
+// another Claude CLI version, offering to continue anyway.
+type CLIVersionChangedState struct {
+	SessionID       string
+	SessionName     string
+	PreviousVersion string // Version the session last ran with
+	CurrentVersion  string // Version of the claude binary now installed
+	Options         []string
+	SelectedIndex   int
+}
+
+func (*CLIVersionChangedState) modalState() {}
+
+func (s *CLIVersionChangedState) Title() string { return "Claude CLI Updated" }
+
+func (s *CLIVersionChangedState) Help() string {
+	return "up/down to select, Enter to confirm, Esc to cancel"
+}
+
+func (s *CLIVersionChangedState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	sessionLabel := lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		Bold(true).
+		Render(s.SessionName)
+
+	versions := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		MarginBottom(1).
+		Render(fmt.Sprintf("%s → %s", s.PreviousVersion, s.CurrentVersion))
+
+	message := lipgloss.NewStyle().
+		Foreground(ColorText).
+		Width(ModalWidth - 4).
+		MarginBottom(1).
+		Render("This session last ran with another version of the Claude CLI. Resuming its conversation may not work the same. Your message stays in the input if you cancel.")
+
+	optionList := RenderSelectableList(s.Options, s.SelectedIndex)
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, sessionLabel, versions, message, optionList, help)
+}
+
+func (s *CLIVersionChangedState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case keys.Down, "j":
+			if s.SelectedIndex < len(s.Options)-1 {
+				s.SelectedIndex++
+			}
+		}
+	}
+	return s, nil
+}
+
+// ShouldContinue returns true if the user chose to resume anyway
+func (s *CLIVersionChangedState) ShouldContinue() bool {
+	return s.SelectedIndex == 0
+}
+
+// NewCLIVersionChangedState creates a new CLIVersionChangedState
+func NewCLIVersionChangedState(sessionID, sessionName, previousVersion, currentVersion string) *CLIVersionChangedState {
+	return &CLIVersionChangedState{
+		SessionID:       sessionID,
+		SessionName:     sessionName,
+		PreviousVersion: previousVersion,
+		CurrentVersion:  currentVersion,
+		Options:         []string{"Continue anyway", "Cancel"},
+	}
+}
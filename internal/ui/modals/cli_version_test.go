@@ -0,0 +1,28 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestCLIVersionChangedState_Options(t *testing.T) {
+	state := NewCLIVersionChangedState("session-1", "my-feature", "2.0.14", "2.0.15")
+
+	rendered := ansi.Strip(state.Render())
+	for _, want := range []string{"Claude CLI Updated", "my-feature", "2.0.14 → 2.0.15", "Continue anyway"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %q in:\n%s", want, rendered)
+		}
+	}
+
+	if !state.ShouldContinue() {
+		t.Error("expected continuing selected by default")
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.ShouldContinue() {
+		t.Error("expected cancel selected after down")
+	}
+}
@@ -218,6 +218,94 @@ func NewChangelogState(entries []ChangelogEntry) *ChangelogState {
 	}
 }
 
+// =============================================================================
+// ReleaseNotesState - State for the release notes of an available update
+// =============================================================================
+
+// ReleaseNotesState shows the release notes of a newer release than the one running.
+type ReleaseNotesState struct {
+	Version         string   // Version of the release, without the leading "v"
+	Lines           []string // Notes, already rendered to fit the modal
+	ScrollOffset    int
+	maxVisibleLines int
+}
+
+func (*ReleaseNotesState) modalState() {}
+
+func (s *ReleaseNotesState) Title() string { return "Plural v" + s.Version + " available" }
+
+func (s *ReleaseNotesState) Help() string {
+	if len(s.Lines) > s.maxVisibleLines {
+		return "up/down scroll  Enter/Esc: dismiss"
+	}
+	return "Press Enter or Esc to dismiss"
+}
+
+func (s *ReleaseNotesState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	var content string
+	if len(s.Lines) == 0 {
+		content = lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			Italic(true).
+			Render("This release has no notes.")
+	} else {
+		end := min(s.ScrollOffset+s.maxVisibleLines, len(s.Lines))
+		content = strings.Join(s.Lines[s.ScrollOffset:end], "\n")
+		if len(s.Lines) > s.maxVisibleLines {
+			content += "\n" + lipgloss.NewStyle().
+				Foreground(ColorTextMuted).
+				Italic(true).
+				MarginTop(1).
+				Render("(scroll for more)")
+		}
+	}
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, content, help)
+}
+
+func (s *ReleaseNotesState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	maxOffset := max(0, len(s.Lines)-s.maxVisibleLines)
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case keys.Up, "k":
+			s.ScrollOffset = max(s.ScrollOffset-1, 0)
+		case keys.Down, "j":
+			s.ScrollOffset = min(s.ScrollOffset+1, maxOffset)
+		}
+	case tea.MouseWheelMsg:
+		if msg.Y < 0 {
+			s.ScrollOffset = max(s.ScrollOffset-1, 0)
+		} else if msg.Y > 0 {
+			s.ScrollOffset = min(s.ScrollOffset+1, maxOffset)
+		}
+	}
+	return s, nil
+}
+
+// NewReleaseNotesState creates a new ReleaseNotesState. notes is the rendered
+// release notes; render them at ReleaseNotesWidth.
+func NewReleaseNotesState(version, notes string) *ReleaseNotesState {
+	var lines []string
+	if strings.TrimSpace(notes) != "" {
+		lines = strings.Split(strings.TrimRight(notes, "\n"), "\n")
+	}
+	return &ReleaseNotesState{
+		Version:         version,
+		Lines:           lines,
+		maxVisibleLines: ChangelogModalMaxVisible,
+	}
+}
+
+// ReleaseNotesWidth returns the width to render release notes at for the modal.
+func ReleaseNotesWidth() int {
+	return ModalWidth - 4
+}
+
 // =============================================================================
 // SettingsState - State for the Settings modal
 // =============================================================================
@@ -235,9 +323,13 @@ type SettingsState struct {
 	// Bound form values
 	selectedTheme        string
 	OriginalTheme        string // To detect if theme changed
+	selectedSyntaxStyle  string // Chroma style for code blocks, or "" for the theme's
+	originalSyntaxStyle  string
 	branchPrefix         string
 	NotificationsEnabled bool
 	AutoCleanupMerged    bool // Auto-cleanup sessions when PR merged/closed
+	CompactToolUses      bool // Collapse tool-use bursts into summary lines
+	FocusInputOnNew      bool // Focus the chat input after creating a session
 
 	// MultiSelect bindings
 	generalOptions []string
@@ -249,8 +341,10 @@ type SettingsState struct {
 }
 
 const (
-	optionNotifications = "notifications"
-	optionAutoCleanup   = "auto-cleanup"
+	optionNotifications   = "notifications"
+	optionAutoCleanup     = "auto-cleanup"
+	optionCompactToolUses = "compact-tool-uses"
+	optionFocusInputOnNew = "focus-input-on-new"
 )
 
 func (*SettingsState) modalState() {}
@@ -293,6 +387,8 @@ func (s *SettingsState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
 func (s *SettingsState) syncFromMultiSelect() {
 	s.NotificationsEnabled = slices.Contains(s.generalOptions, optionNotifications)
 	s.AutoCleanupMerged = slices.Contains(s.generalOptions, optionAutoCleanup)
+	s.CompactToolUses = slices.Contains(s.generalOptions, optionCompactToolUses)
+	s.FocusInputOnNew = slices.Contains(s.generalOptions, optionFocusInputOnNew)
 }
 
 // GetBranchPrefix returns the branch prefix value
@@ -315,6 +411,16 @@ func (s *SettingsState) ThemeChanged() bool {
 	return s.selectedTheme != s.OriginalTheme
 }
 
+// GetSelectedSyntaxStyle returns the selected code highlighting style, or "" for the theme's.
+func (s *SettingsState) GetSelectedSyntaxStyle() string {
+	return s.selectedSyntaxStyle
+}
+
+// SyntaxStyleChanged returns true if the selected code highlighting style differs from the original.
+func (s *SettingsState) SyntaxStyleChanged() bool {
+	return s.selectedSyntaxStyle != s.originalSyntaxStyle
+}
+
 // SetBranchPrefix sets the branch prefix value.
 // Must be called before the form is displayed to the user. Works because
 // huh binds via pointer, so mutations to the struct field reflect in the form.
@@ -339,16 +445,23 @@ func renderSectionHeader(title string) string {
 }
 
 // NewSettingsState creates a new SettingsState with the current settings values.
+// syntaxStyles are the custom code highlighting styles to offer besides the
+// theme's; the choice is only shown when there are any.
 func NewSettingsState(themes []string, themeDisplayNames []string, currentTheme string,
+	syntaxStyles []string, currentSyntaxStyle string,
 	currentBranchPrefix string, notificationsEnabled bool,
-	autoCleanupMerged bool) *SettingsState {
+	autoCleanupMerged bool, compactToolUses bool, focusInputOnNew bool) *SettingsState {
 
 	s := &SettingsState{
 		selectedTheme:        currentTheme,
 		OriginalTheme:        currentTheme,
+		selectedSyntaxStyle:  currentSyntaxStyle,
+		originalSyntaxStyle:  currentSyntaxStyle,
 		branchPrefix:         currentBranchPrefix,
 		NotificationsEnabled: notificationsEnabled,
 		AutoCleanupMerged:    autoCleanupMerged,
+		CompactToolUses:      compactToolUses,
+		FocusInputOnNew:      focusInputOnNew,
 		availableWidth:       ModalWidthWide,
 	}
 
@@ -364,6 +477,10 @@ func NewSettingsState(themes []string, themeDisplayNames []string, currentTheme
 			Selected(notificationsEnabled),
 		huh.NewOption("Auto-cleanup merged sessions", optionAutoCleanup).
 			Selected(autoCleanupMerged),
+		huh.NewOption("Compact tool-use lines", optionCompactToolUses).
+			Selected(compactToolUses),
+		huh.NewOption("Focus chat input on new session", optionFocusInputOnNew).
+			Selected(focusInputOnNew),
 	}
 	// Initialize the enabledOptions slice to match
 	if notificationsEnabled {
@@ -372,13 +489,31 @@ func NewSettingsState(themes []string, themeDisplayNames []string, currentTheme
 	if autoCleanupMerged {
 		s.generalOptions = append(s.generalOptions, optionAutoCleanup)
 	}
+	if compactToolUses {
+		s.generalOptions = append(s.generalOptions, optionCompactToolUses)
+	}
+	if focusInputOnNew {
+		s.generalOptions = append(s.generalOptions, optionFocusInputOnNew)
+	}
 
 	// General settings group
-	generalGroup := huh.NewGroup(
+	fields := []huh.Field{
 		huh.NewSelect[string]().
 			Title("Theme").
 			Options(themeOptions...).
 			Value(&s.selectedTheme),
+	}
+	if len(syntaxStyles) > 0 {
+		syntaxOptions := []huh.Option[string]{huh.NewOption("Theme default", "")}
+		for _, name := range syntaxStyles {
+			syntaxOptions = append(syntaxOptions, huh.NewOption(name, name))
+		}
+		fields = append(fields, huh.NewSelect[string]().
+			Title("Code highlighting").
+			Options(syntaxOptions...).
+			Value(&s.selectedSyntaxStyle))
+	}
+	fields = append(fields,
 		huh.NewInput().
 			Title("Default branch prefix").
 			Description("Applied to all new branches").
@@ -391,6 +526,7 @@ func NewSettingsState(themes []string, themeDisplayNames []string, currentTheme
 			Height(len(generalOpts)).
 			Value(&s.generalOptions),
 	)
+	generalGroup := huh.NewGroup(fields...)
 
 	s.form = huh.NewForm(generalGroup).
 		WithTheme(ModalTheme()).
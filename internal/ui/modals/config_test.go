@@ -1,6 +1,7 @@
 package modals
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -16,8 +17,8 @@ var (
 
 // newTestSettingsState is a helper that prepends theme data to NewSettingsState calls.
 func newTestSettingsState(branchPrefix string, notifs bool) *SettingsState {
-	return NewSettingsState(testThemes, testThemeNames, testCurrentTheme,
-		branchPrefix, notifs, false)
+	return NewSettingsState(testThemes, testThemeNames, testCurrentTheme, nil, "",
+		branchPrefix, notifs, false, false, true)
 }
 
 // =============================================================================
@@ -100,6 +101,42 @@ func TestSettingsState_GetNotificationsEnabled(t *testing.T) {
 	}
 }
 
+func TestSettingsState_SyntaxStyle(t *testing.T) {
+	s := newTestSettingsState("", false)
+	if strings.Contains(s.Render(), "Code highlighting") {
+		t.Error("Expected no code highlighting choice without custom styles")
+	}
+
+	s = NewSettingsState(testThemes, testThemeNames, testCurrentTheme, []string{"my-style"}, "", "", false, false, false, true)
+	if !strings.Contains(s.Render(), "Code highlighting") {
+		t.Error("Expected a code highlighting choice with custom styles")
+	}
+	if s.GetSelectedSyntaxStyle() != "" || s.SyntaxStyleChanged() {
+		t.Error("Expected the theme default to start selected")
+	}
+	s.selectedSyntaxStyle = "my-style"
+	if !s.SyntaxStyleChanged() {
+		t.Error("Expected selecting the custom style to count as a change")
+	}
+}
+
+func TestSettingsState_CompactToolUses(t *testing.T) {
+	s := NewSettingsState(testThemes, testThemeNames, testCurrentTheme, nil, "", "", false, false, true, true)
+	if !s.CompactToolUses {
+		t.Error("Expected compact tool uses to be enabled")
+	}
+	if !slices.Contains(s.generalOptions, optionCompactToolUses) {
+		t.Error("Expected compact tool uses option to be preselected")
+	}
+
+	// Deselecting the option should clear the flag on sync
+	s.generalOptions = nil
+	s.syncFromMultiSelect()
+	if s.CompactToolUses {
+		t.Error("Expected compact tool uses to be disabled after deselecting")
+	}
+}
+
 func TestSettingsState_HelpText(t *testing.T) {
 	s := newTestSettingsState("", false)
 	help := s.Help()
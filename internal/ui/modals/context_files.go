@@ -0,0 +1,131 @@
+package modals
+
+import (
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// =============================================================================
+// ContextFilesState - State for editing a session's context files
+// =============================================================================
+
+// ContextFilesState lists a session's context files: files whose current contents
+// are re-sent to Claude whenever a fresh CLI session starts. Typed paths are added
+// with Enter after the app validates them; the selected file is removed with ctrl-d.
+type ContextFilesState struct {
+	SessionID     string
+	SessionName   string
+	Files         []string // Paths relative to the session worktree
+	Input         textinput.Model
+	SelectedIndex int
+	Error         string // Validation error for the last path entered
+}
+
+func (*ContextFilesState) modalState() {}
+
+func (s *ContextFilesState) Title() string { return "Context Files" }
+
+func (s *ContextFilesState) Help() string {
+	if len(s.Files) == 0 {
+		return "Enter: add path  Esc: close"
+	}
+	return "Enter: add path  up/down: select  ctrl-d: remove  Esc: close"
+}
+
+func (s *ContextFilesState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	description := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Width(ModalWidth - 4).
+		MarginBottom(1).
+		Render("Re-sent to Claude whenever " + s.SessionName + " starts a fresh conversation.")
+
+	var list string
+	if len(s.Files) == 0 {
+		list = lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			Italic(true).
+			Render("No context files yet")
+	} else {
+		list = RenderSelectableList(s.Files, s.SelectedIndex)
+	}
+
+	inputLabel := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		MarginTop(1).
+		Render("Add path (relative to the worktree):")
+
+	inputStyle := lipgloss.NewStyle().
+		BorderLeft(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(ColorPrimary).
+		PaddingLeft(1)
+	inputView := inputStyle.Render(s.Input.View())
+
+	parts := []string{title, description, list, inputLabel, inputView}
+	if s.Error != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(ColorWarning).Render(s.Error))
+	}
+	parts = append(parts, ModalHelpStyle.Render(s.Help()))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+func (s *ContextFilesState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up:
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+			return s, nil
+		case keys.Down:
+			if s.SelectedIndex < len(s.Files)-1 {
+				s.SelectedIndex++
+			}
+			return s, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	s.Input, cmd = s.Input.Update(msg)
+	return s, cmd
+}
+
+// GetInput returns the path typed into the input.
+func (s *ContextFilesState) GetInput() string {
+	return s.Input.Value()
+}
+
+// SelectedFile returns the selected context file, or "" when there are none.
+func (s *ContextFilesState) SelectedFile() string {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Files) {
+		return ""
+	}
+	return s.Files[s.SelectedIndex]
+}
+
+// SetFiles replaces the listed files after an add or remove, keeping the selection in range.
+func (s *ContextFilesState) SetFiles(files []string) {
+	s.Files = files
+	s.SelectedIndex = max(min(s.SelectedIndex, len(files)-1), 0)
+}
+
+// NewContextFilesState creates a new ContextFilesState for a session's context files.
+func NewContextFilesState(sessionID, sessionName string, files []string) *ContextFilesState {
+	input := textinput.New()
+	input.Placeholder = "docs/ARCHITECTURE.md"
+	input.CharLimit = ModalInputCharLimit
+	input.SetWidth(ModalInputWidth)
+	input.Focus()
+
+	return &ContextFilesState{
+		SessionID:   sessionID,
+		SessionName: sessionName,
+		Files:       files,
+		Input:       input,
+	}
+}
@@ -0,0 +1,68 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestContextFilesState_Render(t *testing.T) {
+	state := NewContextFilesState("s1", "fix-login", nil)
+	rendered := state.Render()
+	for _, want := range []string{"Context Files", "fix-login", "No context files yet", "Add path"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected render to contain %q\nFull render:\n%s", want, rendered)
+		}
+	}
+	if strings.Contains(state.Help(), "ctrl-d") {
+		t.Error("remove hint should only show when there are files")
+	}
+
+	state.SetFiles([]string{"docs/ARCHITECTURE.md"})
+	state.Error = "docs/x.md does not exist in the session worktree"
+	rendered = state.Render()
+	for _, want := range []string{"docs/ARCHITECTURE.md", "does not exist"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected render to contain %q\nFull render:\n%s", want, rendered)
+		}
+	}
+}
+
+func TestContextFilesState_Update(t *testing.T) {
+	state := NewContextFilesState("s1", "fix-login", []string{"a.md", "b.md"})
+
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.SelectedFile() != "b.md" {
+		t.Errorf("expected b.md selected, got %q", state.SelectedFile())
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.SelectedIndex != 1 {
+		t.Errorf("selection should stop at the last file, got %d", state.SelectedIndex)
+	}
+
+	// Letters go to the input, including j/k
+	for _, r := range "docs/jk.md" {
+		state.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	if state.GetInput() != "docs/jk.md" {
+		t.Errorf("expected typed path, got %q", state.GetInput())
+	}
+	if state.SelectedIndex != 1 {
+		t.Error("typing should not move the selection")
+	}
+}
+
+func TestContextFilesState_SetFilesClampsSelection(t *testing.T) {
+	state := NewContextFilesState("s1", "fix-login", []string{"a.md", "b.md"})
+	state.SelectedIndex = 1
+
+	state.SetFiles([]string{"a.md"})
+	if state.SelectedIndex != 0 {
+		t.Errorf("expected selection clamped to 0, got %d", state.SelectedIndex)
+	}
+	state.SetFiles(nil)
+	if state.SelectedIndex != 0 || state.SelectedFile() != "" {
+		t.Errorf("expected no selection with no files, got %d %q", state.SelectedIndex, state.SelectedFile())
+	}
+}
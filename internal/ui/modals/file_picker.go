@@ -0,0 +1,212 @@
+package modals
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// FilePickerMaxVisible is the maximum number of paths visible before scrolling
+const FilePickerMaxVisible = 10
+
+// FilePickerMaxMatches bounds how many matches are ranked and listed per query
+const FilePickerMaxMatches = 200
+
+// =============================================================================
+// FilePickerState - Choose worktree files to insert into the chat input
+// =============================================================================
+
+// FilePickerState fuzzy-searches the files of a session's worktree as the user
+// types. Tab marks several files; Enter inserts the marked files, or else the
+// selected one, as relative paths.
+type FilePickerState struct {
+	SessionName   string
+	Input         textinput.Model
+	Matches       []string // Paths matching the query, best first
+	Marked        []string // Paths marked with Tab, in the order marked
+	SelectedIndex int
+	ScrollOffset  int
+	Truncated     bool   // The worktree has more files than were indexed
+	Error         string // Set when the worktree could not be read
+
+	search func(query string, limit int) []string // nil while the index is built
+}
+
+func (*FilePickerState) modalState() {}
+
+func (s *FilePickerState) Title() string { return "Insert File Path" }
+
+func (s *FilePickerState) Help() string {
+	return "Type to filter  up/down: navigate  Tab: mark  Enter: insert  Esc: cancel"
+}
+
+func (s *FilePickerState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	inputStyle := lipgloss.NewStyle().
+		BorderLeft(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(ColorPrimary).
+		PaddingLeft(1).
+		MarginBottom(1)
+	inputView := inputStyle.Render(s.Input.View())
+
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	var list string
+	switch {
+	case s.Error != "":
+		list = StatusErrorStyle.Render(s.Error)
+	case s.search == nil:
+		list = mutedStyle.Italic(true).Render("Indexing files in " + s.SessionName + "...")
+	case len(s.Matches) == 0:
+		list = mutedStyle.Italic(true).Render("No matching files")
+	default:
+		visibleEnd := min(s.ScrollOffset+FilePickerMaxVisible, len(s.Matches))
+		var labels []string
+		for _, path := range s.Matches[s.ScrollOffset:visibleEnd] {
+			mark := "  "
+			if s.IsMarked(path) {
+				mark = "+ "
+			}
+			labels = append(labels, mark+TruncatePath(path, ModalWidth-10))
+		}
+		list = strings.TrimSuffix(RenderSelectableList(labels, s.SelectedIndex-s.ScrollOffset), "\n")
+		if s.ScrollOffset > 0 {
+			list = mutedStyle.Render("  ↑ more above") + "\n" + list
+		}
+		if visibleEnd < len(s.Matches) {
+			list += "\n" + mutedStyle.Render("  ↓ more below")
+		}
+	}
+
+	parts := []string{title, inputView, list}
+	var notes []string
+	if len(s.Marked) > 0 {
+		notes = append(notes, fmt.Sprintf("%d marked", len(s.Marked)))
+	}
+	if s.Truncated {
+		notes = append(notes, "large worktree: not every file is listed")
+	}
+	if len(notes) > 0 {
+		parts = append(parts, mutedStyle.MarginTop(1).Render(strings.Join(notes, "  ·  ")))
+	}
+	parts = append(parts, ModalHelpStyle.Render(s.Help()))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+func (s *FilePickerState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, keys.CtrlP:
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+				if s.SelectedIndex < s.ScrollOffset {
+					s.ScrollOffset = s.SelectedIndex
+				}
+			}
+			return s, nil
+		case keys.Down, keys.CtrlN:
+			if s.SelectedIndex < len(s.Matches)-1 {
+				s.SelectedIndex++
+				if s.SelectedIndex >= s.ScrollOffset+FilePickerMaxVisible {
+					s.ScrollOffset = s.SelectedIndex - FilePickerMaxVisible + 1
+				}
+			}
+			return s, nil
+		case keys.Tab:
+			s.toggleMark()
+			return s, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	oldQuery := s.Input.Value()
+	s.Input, cmd = s.Input.Update(msg)
+	if s.Input.Value() != oldQuery {
+		s.filter()
+	}
+	return s, cmd
+}
+
+// toggleMark marks or unmarks the selected path and moves to the next one.
+func (s *FilePickerState) toggleMark() {
+	path := s.GetSelected()
+	if path == "" {
+		return
+	}
+	if i := slices.Index(s.Marked, path); i >= 0 {
+		s.Marked = slices.Delete(s.Marked, i, i+1)
+	} else {
+		s.Marked = append(s.Marked, path)
+	}
+	if s.SelectedIndex < len(s.Matches)-1 {
+		s.SelectedIndex++
+		if s.SelectedIndex >= s.ScrollOffset+FilePickerMaxVisible {
+			s.ScrollOffset = s.SelectedIndex - FilePickerMaxVisible + 1
+		}
+	}
+}
+
+// IsMarked returns whether path is marked.
+func (s *FilePickerState) IsMarked(path string) bool {
+	return slices.Contains(s.Marked, path)
+}
+
+// filter re-runs the search for the query and selects the best match.
+func (s *FilePickerState) filter() {
+	s.SelectedIndex = 0
+	s.ScrollOffset = 0
+	if s.search == nil {
+		s.Matches = nil
+		return
+	}
+	s.Matches = s.search(strings.TrimSpace(s.Input.Value()), FilePickerMaxMatches)
+}
+
+// SetSearch sets how the worktree's files are searched once they are indexed,
+// and lists the matches for what has been typed so far.
+func (s *FilePickerState) SetSearch(search func(query string, limit int) []string, truncated bool) {
+	s.search = search
+	s.Truncated = truncated
+	s.filter()
+}
+
+// GetSelected returns the selected path, or "" if nothing matches.
+func (s *FilePickerState) GetSelected() string {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Matches) {
+		return ""
+	}
+	return s.Matches[s.SelectedIndex]
+}
+
+// GetPaths returns the paths to insert: the marked ones, or else the selected one.
+func (s *FilePickerState) GetPaths() []string {
+	if len(s.Marked) > 0 {
+		return slices.Clone(s.Marked)
+	}
+	if path := s.GetSelected(); path != "" {
+		return []string{path}
+	}
+	return nil
+}
+
+// NewFilePickerState creates a new FilePickerState for a session's worktree.
+// It shows that files are being indexed until SetSearch is called.
+func NewFilePickerState(sessionName string) *FilePickerState {
+	input := textinput.New()
+	input.Placeholder = "Type to search files..."
+	input.CharLimit = SearchInputCharLimit
+	input.SetWidth(ModalInputWidth)
+	input.Focus()
+
+	return &FilePickerState{
+		SessionName: sessionName,
+		Input:       input,
+	}
+}
@@ -0,0 +1,67 @@
+package modals
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// prefixSearch is a stand-in for the file index search: paths containing the query.
+func prefixSearch(paths []string) func(string, int) []string {
+	return func(query string, limit int) []string {
+		var matches []string
+		for _, p := range paths {
+			if strings.Contains(p, query) && len(matches) < limit {
+				matches = append(matches, p)
+			}
+		}
+		return matches
+	}
+}
+
+func TestFilePickerState_IndexingThenSearch(t *testing.T) {
+	state := NewFilePickerState("fix-login")
+	if rendered := ansi.Strip(state.Render()); !strings.Contains(rendered, "Indexing files in fix-login") {
+		t.Errorf("expected an indexing notice, got:\n%s", rendered)
+	}
+
+	// What was typed while indexing is searched once the index arrives
+	state.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	state.Update(tea.KeyPressMsg{Code: 'p', Text: "p"})
+	state.SetSearch(prefixSearch([]string{"main.go", "internal/app/app.go", "internal/ui/app_view.go"}), true)
+	if !slices.Equal(state.Matches, []string{"internal/app/app.go", "internal/ui/app_view.go"}) {
+		t.Fatalf("Matches = %v", state.Matches)
+	}
+	rendered := ansi.Strip(state.Render())
+	if !strings.Contains(rendered, ">   internal/app/app.go") || !strings.Contains(rendered, "not every file is listed") {
+		t.Errorf("unexpected render:\n%s", rendered)
+	}
+}
+
+func TestFilePickerState_MarkSeveral(t *testing.T) {
+	state := NewFilePickerState("fix-login")
+	state.SetSearch(prefixSearch([]string{"a.go", "b.go", "c.go"}), false)
+	if !slices.Equal(state.GetPaths(), []string{"a.go"}) {
+		t.Fatalf("GetPaths() = %v, want the selected path", state.GetPaths())
+	}
+
+	// Tab marks and moves down; marking again unmarks
+	state.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	state.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if !slices.Equal(state.GetPaths(), []string{"a.go", "c.go"}) {
+		t.Errorf("GetPaths() = %v, want the marked paths in order", state.GetPaths())
+	}
+	if rendered := ansi.Strip(state.Render()); !strings.Contains(rendered, "+ a.go") || !strings.Contains(rendered, "2 marked") {
+		t.Errorf("expected marks shown, got:\n%s", rendered)
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	state.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	state.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if !slices.Equal(state.GetPaths(), []string{"c.go"}) {
+		t.Errorf("GetPaths() = %v, want a.go unmarked", state.GetPaths())
+	}
+}
@@ -70,3 +70,33 @@ func SessionDisplayName(branch, name string) string {
 	// Simple name - return as-is
 	return name
 }
+
+// sparklineGlyphs are the block characters used by RenderSparkline, lowest to highest.
+var sparklineGlyphs = []rune("▁▂▃▄▅▆▇█")
+
+// RenderSparkline renders values as a single line of block glyphs scaled to the maximum value.
+// When there are more values than width, only the most recent width values are shown.
+// Returns an empty string if there are no values.
+func RenderSparkline(values []int, width int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	maxVal := 0
+	for _, v := range values {
+		maxVal = max(maxVal, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		idx := 0
+		if maxVal > 0 && v > 0 {
+			idx = v * (len(sparklineGlyphs) - 1) / maxVal
+		}
+		sb.WriteRune(sparklineGlyphs[idx])
+	}
+	return sb.String()
+}
@@ -0,0 +1,28 @@
+package modals
+
+import "testing"
+
+func TestRenderSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		width  int
+		want   string
+	}{
+		{name: "empty", values: nil, width: 10, want: ""},
+		{name: "all zero", values: []int{0, 0, 0}, width: 10, want: "▁▁▁"},
+		{name: "single value", values: []int{5}, width: 10, want: "█"},
+		{name: "ascending", values: []int{0, 1, 2, 3, 4, 5, 6, 7}, width: 10, want: "▁▂▃▄▅▆▇█"},
+		{name: "scaled to max", values: []int{1000, 500, 250}, width: 10, want: "█▄▂"},
+		{name: "keeps most recent when truncated", values: []int{7, 0, 7, 0, 7}, width: 3, want: "█▁█"},
+		{name: "zero width shows all", values: []int{1, 2}, width: 0, want: "▄█"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderSparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("RenderSparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+			}
+		})
+	}
+}
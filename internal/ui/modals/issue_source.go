@@ -34,7 +34,7 @@ func (*SelectIssueSourceState) modalState() {}
 func (s *SelectIssueSourceState) Title() string { return "Select Issue Source" }
 
 func (s *SelectIssueSourceState) Help() string {
-	return "up/down: navigate  Enter: select  Esc: cancel"
+	return "up/down: navigate  Enter: select  b: blank branch  Esc: cancel"
 }
 
 func (s *SelectIssueSourceState) Render() string {
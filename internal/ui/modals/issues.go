@@ -70,7 +70,7 @@ func (s *ImportIssuesState) Help() string {
 	if s.LoadError != "" {
 		return "Esc: close"
 	}
-	return "up/down navigate  Space: toggle  Tab: next field  Enter: import  Esc: cancel"
+	return "up/down navigate  Space: toggle  Tab: next field  Enter: import  b: blank branch  Esc: cancel"
 }
 
 func (s *ImportIssuesState) Render() string {
@@ -2,10 +2,12 @@ package modals
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/spinner"
 	"charm.land/bubbles/v2/textarea"
+	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
@@ -25,17 +27,159 @@ type MergeState struct {
 	ParentName     string // Name of parent session (for display)
 	ChangesSummary string
 	PRCreated      bool // Whether a PR has already been created for this session
+
+	// PR base branch (only shown while "Create PR" is selected)
+	BaseBranchInput   textinput.Model // Branch the PR targets, completed from remote branches
+	BaseBranchFocused bool            // Whether the base branch input has focus
+
+	// Steps of an unfinished PR attempt; Create PR resumes from the failed step
+	PRSteps []PRStepItem
+
+	// Uncommitted changes in the main repo, which merging to main checks out over
+	MainRepoChanges string // Summary of the changes (empty if the main repo is clean)
+	AutoStash       bool   // Stash the changes before the merge and re-apply them after
+
+	// Base branch that no longer exists on origin (e.g. after the default branch was renamed)
+	StaleBaseBranch    string // Session's recorded base branch (empty if it still exists)
+	DefaultBranch      string // Repo's re-resolved default branch
+	MigratableSessions int    // Sessions of the repo still based on StaleBaseBranch (0 once migrated)
+
+	// Other sessions of the repo with changes to the same files
+	Overlaps []FileOverlapItem
+
+	// Files Claude wrote outside the session's worktree, which merging won't include
+	ExternalWrites []string
+
+	// Repo hooks configured to run after a merge to main and after creating a PR
+	MergeHooks int
+	PRHooks    int
+	RunHooks   bool // Run them this time; unchecked skips them
+
+	// Archive the session's transcript and remove it and its worktree once a merge succeeds
+	ArchiveAfterMerge bool
+
+	// Option whose git commands were copied to the clipboard (empty if none)
+	CommandsCopied string
+
+	// What a merge to main will do, while its confirmation screen is shown (nil otherwise)
+	Preview *MergePreview
 }
 
+const (
+	mergeOptionMergeToParent = "Merge to parent"
+	mergeOptionMergeToMain   = "Merge to main"
+	mergeOptionRebaseOnMain  = "Rebase onto main"
+	mergeOptionCreatePR      = "Create PR" // Opens a new PR against the base branch
+)
+
 func (*MergeState) modalState() {}
 
 func (s *MergeState) Title() string { return "Merge/PR" }
 
 func (s *MergeState) Help() string {
-	return "up/down to select, Enter to confirm, Esc to cancel"
+	if s.Preview != nil {
+		if s.MergeHooks > 0 {
+			return "h: toggle hooks  a: archive after  Enter: merge  Esc: back"
+		}
+		return "a: archive after  Enter: merge  Esc: back"
+	}
+	if s.BaseBranchFocused {
+		return "Tab: complete  up/down: cycle matches  Shift+Tab: options  Enter: create PR  Esc: cancel"
+	}
+	help := s.optionsHelp()
+	if s.canArchiveAfter() {
+		help = strings.Replace(help, "c: copy commands", "a: archive after, c: copy commands", 1)
+	}
+	if s.hooksForSelected() > 0 {
+		help = strings.Replace(help, "c: copy commands", "h: toggle hooks, c: copy commands", 1)
+	}
+	return help
+}
+
+// optionsHelp returns the help for the merge options, before any hooks' toggle.
+func (s *MergeState) optionsHelp() string {
+	if s.showBaseBranch() {
+		return "up/down to select, Tab: edit base branch, c: copy commands, Enter to confirm, Esc to cancel"
+	}
+	if s.showMainRepoChanges() {
+		return "up/down to select, s: toggle auto-stash, c: copy commands, Enter to confirm, Esc to cancel"
+	}
+	if s.CanMigrateBaseBranch() {
+		return "up/down to select, u: update base branch, c: copy commands, Enter to confirm, Esc to cancel"
+	}
+	return "up/down to select, c: copy commands, Enter to confirm, Esc to cancel"
+}
+
+// showBaseBranch returns whether the base branch field applies to the selected option.
+func (s *MergeState) showBaseBranch() bool {
+	return s.GetSelectedOption() == mergeOptionCreatePR
+}
+
+// hooksForSelected returns how many repo hooks run after the selected option.
+func (s *MergeState) hooksForSelected() int {
+	switch s.GetSelectedOption() {
+	case mergeOptionMergeToMain, mergeOptionRebaseOnMain:
+		return s.MergeHooks
+	case mergeOptionCreatePR:
+		return s.PRHooks
+	}
+	return 0
+}
+
+// canArchiveAfter returns whether the selected option is a merge, after which
+// the session can be archived.
+func (s *MergeState) canArchiveAfter() bool {
+	option := s.GetSelectedOption()
+	return option == mergeOptionMergeToMain || option == mergeOptionRebaseOnMain || option == mergeOptionMergeToParent
+}
+
+// ShouldArchiveAfterMerge returns whether the session is to be archived once the
+// selected merge succeeds.
+func (s *MergeState) ShouldArchiveAfterMerge() bool {
+	return s.ArchiveAfterMerge && s.canArchiveAfter()
+}
+
+// renderArchiveCheckbox renders the checkbox for archiving the session after the merge.
+func (s *MergeState) renderArchiveCheckbox() string {
+	checkbox := "[ ]"
+	if s.ArchiveAfterMerge {
+		checkbox = "[x]"
+	}
+	desc := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Italic(true).
+		Render("Archive the session and remove its worktree after a successful merge")
+	return lipgloss.NewStyle().PaddingLeft(2).Render(checkbox + " " + desc)
+}
+
+// renderHooksCheckbox renders the checkbox for running count hooks after event.
+func (s *MergeState) renderHooksCheckbox(count int, event string) string {
+	checkbox := "[ ]"
+	if s.RunHooks {
+		checkbox = "[x]"
+	}
+	hooks := "hook"
+	if count != 1 {
+		hooks = "hooks"
+	}
+	desc := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Italic(true).
+		Render(fmt.Sprintf("Run %d %s %s", count, event, hooks))
+	return lipgloss.NewStyle().PaddingLeft(2).Render(checkbox + " " + desc)
+}
+
+// showMainRepoChanges returns whether the selected option checks out over uncommitted
+// changes in the main repo.
+func (s *MergeState) showMainRepoChanges() bool {
+	option := s.GetSelectedOption()
+	return s.MainRepoChanges != "" && (option == mergeOptionMergeToMain || option == mergeOptionRebaseOnMain)
 }
 
 func (s *MergeState) Render() string {
+	if s.Preview != nil {
+		return s.renderPreview()
+	}
 	title := ModalTitleStyle.Render(s.Title())
 
 	// Content width for text wrapping (modal width minus padding)
@@ -77,27 +221,277 @@ func (s *MergeState) Render() string {
 		optionList += "\n" + note
 	}
 
+	parts := []string{title, sessionLabel, summarySection}
+
+	if len(s.Overlaps) > 0 {
+		warningStyle := lipgloss.NewStyle().
+			Foreground(ColorWarning).
+			Width(contentWidth)
+		parts = append(parts, warningStyle.Bold(true).Render("Other sessions changed the same files; expect conflicts:"))
+		for _, line := range overlapSummary(s.Overlaps, 3) {
+			parts = append(parts, warningStyle.PaddingLeft(2).Render(line))
+		}
+		parts = append(parts, "")
+	}
+
+	if len(s.ExternalWrites) > 0 {
+		warningStyle := lipgloss.NewStyle().
+			Foreground(ColorWarning).
+			Width(contentWidth)
+		parts = append(parts, warningStyle.Bold(true).Render("Claude wrote files outside the worktree; review them before merging:"))
+		for _, line := range externalWritesSummary(s.ExternalWrites, 5) {
+			parts = append(parts, warningStyle.PaddingLeft(2).Render(line))
+		}
+		parts = append(parts, "")
+	}
+
+	parts = append(parts, optionList)
+
+	if s.StaleBaseBranch != "" {
+		warning := lipgloss.NewStyle().
+			Foreground(ColorWarning).
+			MarginTop(1).
+			Width(contentWidth).
+			Render(fmt.Sprintf("Base branch %s no longer exists on origin; the default branch is now %s.", s.StaleBaseBranch, s.DefaultBranch))
+		parts = append(parts, warning)
+		if s.CanMigrateBaseBranch() {
+			sessions := "1 session"
+			if s.MigratableSessions != 1 {
+				sessions = fmt.Sprintf("%d sessions", s.MigratableSessions)
+			}
+			hint := lipgloss.NewStyle().
+				Foreground(ColorTextMuted).
+				Italic(true).
+				PaddingLeft(2).
+				Width(contentWidth).
+				Render(fmt.Sprintf("u: update %s of this repo to %s", sessions, s.DefaultBranch))
+			parts = append(parts, hint)
+		}
+	}
+
+	if s.showMainRepoChanges() {
+		warning := lipgloss.NewStyle().
+			Foreground(ColorWarning).
+			MarginTop(1).
+			Width(contentWidth).
+			Render("Main repo has uncommitted changes: " + s.MainRepoChanges)
+
+		checkbox := "[ ]"
+		if s.AutoStash {
+			checkbox = "[x]"
+		}
+		stashDesc := lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			Italic(true).
+			Render("Auto-stash them and re-apply after the merge")
+		parts = append(parts, warning, lipgloss.NewStyle().PaddingLeft(2).Render(checkbox+" "+stashDesc))
+	}
+
+	if s.showBaseBranch() {
+		baseLabel := lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			MarginTop(1).
+			Render("PR base branch:")
+
+		baseStyle := lipgloss.NewStyle()
+		if s.BaseBranchFocused {
+			baseStyle = baseStyle.BorderLeft(true).BorderStyle(lipgloss.NormalBorder()).BorderForeground(ColorPrimary).PaddingLeft(1)
+		} else {
+			baseStyle = baseStyle.PaddingLeft(2)
+		}
+		parts = append(parts, baseLabel, baseStyle.Render(s.BaseBranchInput.View()))
+
+		if len(s.PRSteps) > 0 {
+			resumeLabel := lipgloss.NewStyle().
+				Foreground(ColorTextMuted).
+				MarginTop(1).
+				Render("Previous attempt (Create PR resumes from the failed step):")
+			parts = append(parts, resumeLabel, renderPRSteps(s.PRSteps, "", contentWidth))
+			if failed := failedPRStep(s.PRSteps); failed != nil && failed.Detail != "" {
+				parts = append(parts, StatusErrorStyle.Width(contentWidth).Render(failed.Detail))
+			}
+		}
+	}
+
+	if count := s.hooksForSelected(); count > 0 {
+		event := "post-merge"
+		if s.GetSelectedOption() == mergeOptionCreatePR {
+			event = "post-PR-create"
+		}
+		parts = append(parts, lipgloss.NewStyle().MarginTop(1).Render(s.renderHooksCheckbox(count, event)))
+	}
+
+	if s.canArchiveAfter() {
+		parts = append(parts, lipgloss.NewStyle().MarginTop(1).Render(s.renderArchiveCheckbox()))
+	}
+
+	if s.CommandsCopied != "" && s.CommandsCopied == s.GetSelectedOption() {
+		copied := lipgloss.NewStyle().
+			Foreground(ColorPrimary).
+			Bold(true).
+			MarginTop(1).
+			Render("Copied the git commands to the clipboard!")
+		parts = append(parts, copied)
+	}
+
 	help := ModalHelpStyle.Render(s.Help())
+	parts = append(parts, help)
 
-	return lipgloss.JoinVertical(lipgloss.Left, title, sessionLabel, summarySection, optionList, help)
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
 
 func (s *MergeState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
-	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return s, nil
+	}
+	if s.Preview != nil {
 		switch keyMsg.String() {
-		case keys.Up, "k":
-			if s.SelectedIndex > 0 {
-				s.SelectedIndex--
-			}
-		case keys.Down, "j":
-			if s.SelectedIndex < len(s.Options)-1 {
-				s.SelectedIndex++
+		case "h":
+			if s.MergeHooks > 0 {
+				s.RunHooks = !s.RunHooks
 			}
+		case "a":
+			s.ArchiveAfterMerge = !s.ArchiveAfterMerge
+		}
+		return s, nil
+	}
+
+	if s.BaseBranchFocused {
+		if keyMsg.String() == keys.ShiftTab {
+			s.BaseBranchFocused = false
+			s.BaseBranchInput.Blur()
+			return s, nil
+		}
+		// Tab accepts the current completion; up/down cycle through matches
+		var cmd tea.Cmd
+		s.BaseBranchInput, cmd = s.BaseBranchInput.Update(msg)
+		return s, cmd
+	}
+
+	switch keyMsg.String() {
+	case keys.Up, "k":
+		if s.SelectedIndex > 0 {
+			s.SelectedIndex--
+		}
+	case keys.Down, "j":
+		if s.SelectedIndex < len(s.Options)-1 {
+			s.SelectedIndex++
+		}
+	case keys.Tab:
+		if s.showBaseBranch() {
+			s.BaseBranchFocused = true
+			return s, s.BaseBranchInput.Focus()
+		}
+	case "s":
+		if s.showMainRepoChanges() {
+			s.AutoStash = !s.AutoStash
+		}
+	case "h":
+		if s.hooksForSelected() > 0 {
+			s.RunHooks = !s.RunHooks
+		}
+	case "a":
+		if s.canArchiveAfter() {
+			s.ArchiveAfterMerge = !s.ArchiveAfterMerge
 		}
 	}
 	return s, nil
 }
 
+// SetHooks shows how many repo hooks run after a merge to main and after creating
+// a PR, offering to skip them (they run by default).
+func (s *MergeState) SetHooks(postMerge, postPRCreate int) {
+	s.MergeHooks = postMerge
+	s.PRHooks = postPRCreate
+	s.RunHooks = true
+}
+
+// SetCommitsAhead offers rebasing onto main, after merging to main, when the
+// branch has commits the default branch lacks.
+func (s *MergeState) SetCommitsAhead(ahead int) {
+	if ahead == 0 || slices.Contains(s.Options, mergeOptionRebaseOnMain) {
+		return
+	}
+	i := slices.Index(s.Options, mergeOptionMergeToMain) + 1
+	s.Options = slices.Insert(s.Options, i, mergeOptionRebaseOnMain)
+}
+
+// SetMainRepoChanges warns that the main repo has uncommitted changes and offers to
+// auto-stash them around a merge to main (on by default).
+func (s *MergeState) SetMainRepoChanges(summary string) {
+	s.MainRepoChanges = summary
+	s.AutoStash = summary != ""
+}
+
+// NeedsAutoStash returns whether a merge to main must stash the main repo's changes first.
+func (s *MergeState) NeedsAutoStash() bool {
+	return s.MainRepoChanges != "" && s.AutoStash
+}
+
+// SetPRBaseBranch prefills the PR base branch and offers remote branches as completions.
+func (s *MergeState) SetPRBaseBranch(baseBranch string, remoteBranches []string) {
+	s.BaseBranchInput.SetValue(baseBranch)
+	s.BaseBranchInput.SetSuggestions(remoteBranches)
+}
+
+// UpdateDefaultBranch applies a re-resolved default branch: a PR base still set to
+// previousDefault (the stale value it was prefilled with) or to a stale base branch
+// moves to defaultBranch, and the completions are replaced with remoteBranches.
+func (s *MergeState) UpdateDefaultBranch(previousDefault, defaultBranch string, remoteBranches []string) {
+	s.DefaultBranch = defaultBranch
+	if base := s.GetPRBaseBranch(); base == previousDefault || (base != "" && base == s.StaleBaseBranch) {
+		s.BaseBranchInput.SetValue(defaultBranch)
+	}
+	if remoteBranches != nil {
+		s.BaseBranchInput.SetSuggestions(remoteBranches)
+	}
+}
+
+// SetStaleBaseBranch warns that the session's base branch no longer exists on origin
+// and, when sessions is non-zero, offers to move that many sessions to defaultBranch.
+// Call before UpdateDefaultBranch so a PR base prefilled with the stale branch moves too.
+func (s *MergeState) SetStaleBaseBranch(staleBranch, defaultBranch string, sessions int) {
+	s.StaleBaseBranch = staleBranch
+	s.DefaultBranch = defaultBranch
+	s.MigratableSessions = sessions
+}
+
+// CanMigrateBaseBranch returns whether the "u" key migrates sessions off the stale base branch.
+func (s *MergeState) CanMigrateBaseBranch() bool {
+	return s.StaleBaseBranch != "" && s.MigratableSessions > 0 && !s.BaseBranchFocused
+}
+
+// SetOverlaps warns that other sessions of the repo changed some of the same files.
+func (s *MergeState) SetOverlaps(overlaps []FileOverlapItem) {
+	s.Overlaps = overlaps
+}
+
+// SetExternalWrites warns about files Claude wrote outside the session's worktree.
+func (s *MergeState) SetExternalWrites(paths []string) {
+	s.ExternalWrites = paths
+}
+
+// externalWritesSummary lists at most maxPaths of paths, one per line, noting how
+// many more there are.
+func externalWritesSummary(paths []string, maxPaths int) []string {
+	if len(paths) <= maxPaths {
+		return paths
+	}
+	lines := append([]string(nil), paths[:maxPaths]...)
+	return append(lines, fmt.Sprintf("+%d more", len(paths)-maxPaths))
+}
+
+// SetPRResume shows the steps of an unfinished PR attempt under the Create PR option.
+func (s *MergeState) SetPRResume(steps []PRStepItem) {
+	s.PRSteps = steps
+}
+
+// GetPRBaseBranch returns the base branch entered for a new PR (empty means the repo default).
+func (s *MergeState) GetPRBaseBranch() string {
+	return strings.TrimSpace(s.BaseBranchInput.Value())
+}
+
 // GetSelectedOption returns the selected merge option
 func (s *MergeState) GetSelectedOption() string {
 	if len(s.Options) == 0 || s.SelectedIndex >= len(s.Options) {
@@ -115,28 +509,35 @@ func NewMergeState(sessionName string, hasRemote bool, changesSummary string, pa
 	// If session has a parent, offer merge to parent first
 	hasParent := parentName != ""
 	if hasParent {
-		options = append(options, "Merge to parent")
+		options = append(options, mergeOptionMergeToParent)
 	}
 
-	options = append(options, "Merge to main")
+	options = append(options, mergeOptionMergeToMain)
 	if hasRemote {
 		if prCreated {
 			// PR already exists - offer to push updates instead
 			options = append(options, "Push updates to PR")
 		} else {
-			options = append(options, "Create PR")
+			options = append(options, mergeOptionCreatePR)
 		}
 	}
 
+	baseInput := textinput.New()
+	baseInput.Placeholder = "default branch"
+	baseInput.CharLimit = BranchNameCharLimit
+	baseInput.SetWidth(ModalInputWidth)
+	baseInput.ShowSuggestions = true
+
 	return &MergeState{
-		SessionName:    sessionName,
-		Options:        options,
-		SelectedIndex:  0,
-		HasRemote:      hasRemote,
-		HasParent:      hasParent,
-		ParentName:     parentName,
-		ChangesSummary: changesSummary,
-		PRCreated:      prCreated,
+		SessionName:     sessionName,
+		Options:         options,
+		SelectedIndex:   0,
+		HasRemote:       hasRemote,
+		HasParent:       hasParent,
+		ParentName:      parentName,
+		ChangesSummary:  changesSummary,
+		PRCreated:       prCreated,
+		BaseBranchInput: baseInput,
 	}
 }
 
@@ -145,7 +546,7 @@ func NewMergeState(sessionName string, hasRemote bool, changesSummary string, pa
 // =============================================================================
 
 type LoadingCommitState struct {
-	MergeType string        // "merge", "pr", "push", or "parent"
+	MergeType string        // "merge", "rebase", "pr", "push", or "parent"
 	Spinner   spinner.Model // Bubbles spinner for animation
 }
 
@@ -172,6 +573,8 @@ func (s *LoadingCommitState) Render() string {
 		operationLabel = "Push updates to PR"
 	case "parent":
 		operationLabel = "Merge to parent"
+	case "rebase":
+		operationLabel = "Rebase onto main"
 	default:
 		operationLabel = "Merge to main"
 	}
@@ -222,7 +625,7 @@ func NewLoadingCommitState(mergeType string) *LoadingCommitState {
 
 type EditCommitState struct {
 	Textarea  textarea.Model
-	MergeType string // "merge" or "pr"
+	MergeType string // "merge", "rebase", or "pr"
 }
 
 func (*EditCommitState) modalState() {}
@@ -245,8 +648,11 @@ func (s *EditCommitState) Render() string {
 		operationSection = operationStyle.Render("Committing resolved merge conflicts")
 	} else {
 		operationLabel := "Merge to main"
-		if s.MergeType == "pr" {
+		switch s.MergeType {
+		case "pr":
 			operationLabel = "Create PR"
+		case "rebase":
+			operationLabel = "Rebase onto main"
 		}
 		operationStyle := lipgloss.NewStyle().
 			Foreground(ColorSecondary).
@@ -355,13 +761,20 @@ type MergeConflictState struct {
 	SessionName     string
 	ConflictedFiles []string
 	RepoPath        string
+	OriginalBranch  string // Branch to check out again if the merge is aborted
+	Rebase          bool   // Whether a rebase stopped on the conflicts, rather than a merge
 	Options         []string
 	SelectedIndex   int
 }
 
 func (*MergeConflictState) modalState() {}
 
-func (s *MergeConflictState) Title() string { return "Merge Conflict" }
+func (s *MergeConflictState) Title() string {
+	if s.Rebase {
+		return "Rebase Conflict"
+	}
+	return "Merge Conflict"
+}
 
 func (s *MergeConflictState) Help() string {
 	return "up/down to select, Enter to confirm, Esc to cancel"
@@ -433,8 +846,15 @@ func (s *MergeConflictState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
 	return s, nil
 }
 
+// SetRebase marks the conflicts as stopping a rebase, which is aborted instead
+// of a merge.
+func (s *MergeConflictState) SetRebase() {
+	s.Rebase = true
+	s.Options[1] = "Abort rebase"
+}
+
 // GetSelectedOption returns the index of the selected option
-// 0 = Have Claude resolve, 1 = Abort merge, 2 = Resolve manually
+// 0 = Have Claude resolve, 1 = Abort merge (or rebase), 2 = Resolve manually
 func (s *MergeConflictState) GetSelectedOption() int {
 	return s.SelectedIndex
 }
@@ -597,9 +1017,12 @@ func (s *ConfirmExitState) Render() string {
 
 	// Show warning about active sessions
 	var message string
-	if s.ActiveSessionCount == 1 {
+	switch s.ActiveSessionCount {
+	case 0:
+		message = "No sessions are waiting on Claude."
+	case 1:
 		message = "There is 1 active session running."
-	} else {
+	default:
 		message = fmt.Sprintf("There are %d active sessions running.", s.ActiveSessionCount)
 	}
 
@@ -0,0 +1,126 @@
+package modals
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// MergePreview is what a merge to main will do, gathered without changing anything
+// and shown for confirmation before the merge runs.
+type MergePreview struct {
+	Files         []string // Uncommitted files committed first
+	CommitMessage string   // Message for those files, or for the squash commit
+	TargetBranch  string   // Branch merged into
+	Squash        bool     // Whether the branch is squashed into one commit
+	Rebase        bool     // Whether the branch is rebased onto the target, which is fast-forwarded
+	HasRemote     bool     // Whether the repo has an origin to sync with first
+	Compared      bool     // Whether the target branch was compared with origin's
+	Ahead, Behind int      // Commits the target branch is ahead of and behind origin's
+	AutoStash     bool     // Whether the main repo's changes are stashed around the merge
+	Push          bool     // Whether a push follows the merge
+}
+
+// maxPreviewFiles is how many files the merge preview lists before summarizing the rest.
+const maxPreviewFiles = 8
+
+// ShowPreview switches the modal to the confirmation screen for preview.
+func (s *MergeState) ShowPreview(preview MergePreview) {
+	s.Preview = &preview
+	s.BaseBranchFocused = false
+	s.BaseBranchInput.Blur()
+}
+
+// HidePreview returns from the confirmation screen to the merge options.
+func (s *MergeState) HidePreview() {
+	s.Preview = nil
+}
+
+// syncLine describes how the target branch is synced with origin before merging.
+func (p *MergePreview) syncLine() string {
+	switch {
+	case !p.HasRemote:
+		return "No remote; merges locally"
+	case !p.Compared:
+		return fmt.Sprintf("Fetches origin; %s isn't on origin yet", p.TargetBranch)
+	case p.Ahead > 0 && p.Behind > 0:
+		return fmt.Sprintf("Diverged from origin/%s (%d ahead, %d behind); the merge will stop until it is synced", p.TargetBranch, p.Ahead, p.Behind)
+	case p.Behind > 0:
+		return fmt.Sprintf("Fetches origin, then pulls %d commit(s) from origin/%s", p.Behind, p.TargetBranch)
+	case p.Ahead > 0:
+		return fmt.Sprintf("Fetches origin; %d local commit(s) not on origin/%s", p.Ahead, p.TargetBranch)
+	}
+	return fmt.Sprintf("Fetches origin; up to date with origin/%s", p.TargetBranch)
+}
+
+// diverged returns whether the merge will stop at the divergence check.
+func (p *MergePreview) diverged() bool {
+	return p.Compared && p.Ahead > 0 && p.Behind > 0
+}
+
+func (s *MergeState) renderPreview() string {
+	p := s.Preview
+	contentWidth := ModalWidth - 4
+	label := lipgloss.NewStyle().Foreground(ColorTextMuted).Width(contentWidth)
+	value := lipgloss.NewStyle().PaddingLeft(2).Width(contentWidth)
+	muted := value.Foreground(ColorTextMuted).Italic(true)
+
+	title := ModalTitleStyle.Render("Merge Preview")
+	sessionLabel := lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		Bold(true).
+		MarginBottom(1).
+		Width(contentWidth).
+		Render(s.SessionName)
+	parts := []string{title, sessionLabel}
+
+	parts = append(parts, label.Render("Commits:"))
+	if len(p.Files) == 0 {
+		parts = append(parts, muted.Render("No uncommitted changes"))
+	} else {
+		for _, line := range externalWritesSummary(p.Files, maxPreviewFiles) {
+			parts = append(parts, value.Render(line))
+		}
+	}
+
+	if len(p.Files) > 0 || p.Squash {
+		parts = append(parts, label.MarginTop(1).Render("Commit message:"))
+		if p.CommitMessage == "" {
+			parts = append(parts, muted.Render("(none)"))
+		} else {
+			parts = append(parts, value.Render(strings.TrimSpace(p.CommitMessage)))
+		}
+	}
+
+	action := "Merges into "
+	switch {
+	case p.Squash:
+		action = "Squashes into one commit on "
+	case p.Rebase:
+		action = "Rebases onto, then fast-forwards "
+	}
+	parts = append(parts, label.MarginTop(1).Render("Target:"), value.Render(action+p.TargetBranch))
+	if p.AutoStash {
+		parts = append(parts, value.Render("Stashes the main repo's changes and re-applies them after"))
+	}
+
+	syncStyle := value
+	if p.diverged() {
+		syncStyle = syncStyle.Foreground(ColorWarning)
+	}
+	parts = append(parts, label.MarginTop(1).Render("Sync:"), syncStyle.Render(p.syncLine()))
+
+	push := "No push; the merge stays local"
+	if p.Push {
+		push = "Pushes to origin after"
+	}
+	parts = append(parts, label.MarginTop(1).Render("Push:"), value.Render(push))
+	if s.MergeHooks > 0 {
+		parts = append(parts, label.MarginTop(1).Render("Hooks:"), s.renderHooksCheckbox(s.MergeHooks, "post-merge"))
+	}
+	parts = append(parts, label.MarginTop(1).Render("After:"), s.renderArchiveCheckbox())
+
+	parts = append(parts, ModalHelpStyle.Render(s.Help()))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
@@ -2,12 +2,15 @@ package modals
 
 import (
 	"image/color"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestUnwrapCommitMessage(t *testing.T) {
@@ -318,3 +321,272 @@ func TestMergeConflictState(t *testing.T) {
 		}
 	})
 }
+
+func TestMergeState_MainRepoChanges(t *testing.T) {
+	s := NewMergeState("session", true, "", "", false)
+	s.SetMainRepoChanges("1 file changed")
+
+	if !s.NeedsAutoStash() {
+		t.Fatal("Expected auto-stash to default on when the main repo has changes")
+	}
+	if !strings.Contains(s.Render(), "Main repo has uncommitted changes: 1 file changed") {
+		t.Error("Expected the warning while Merge to main is selected")
+	}
+	if !strings.Contains(s.Help(), "auto-stash") {
+		t.Errorf("Expected help to mention auto-stash, got %q", s.Help())
+	}
+
+	// s toggles auto-stash
+	s.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	if s.NeedsAutoStash() {
+		t.Error("Expected s to turn auto-stash off")
+	}
+
+	// Creating a PR does not touch the main repo, so the warning is hidden and s is ignored
+	s.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if s.GetSelectedOption() != mergeOptionCreatePR {
+		t.Fatalf("Expected Create PR to be selected, got %q", s.GetSelectedOption())
+	}
+	if strings.Contains(s.Render(), "Main repo has uncommitted changes") {
+		t.Error("Warning should only show while Merge to main is selected")
+	}
+	s.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	if s.AutoStash {
+		t.Error("s should not toggle auto-stash while Create PR is selected")
+	}
+
+	clean := NewMergeState("session", true, "", "", false)
+	clean.SetMainRepoChanges("")
+	if clean.NeedsAutoStash() {
+		t.Error("A clean main repo should not need auto-stash")
+	}
+}
+
+func TestMergeState_CommitsAhead(t *testing.T) {
+	s := NewMergeState("session", true, "", "", false)
+	s.SetCommitsAhead(0)
+	if slices.Contains(s.Options, mergeOptionRebaseOnMain) {
+		t.Fatal("Expected no rebase option without commits ahead of main")
+	}
+
+	s.SetCommitsAhead(2)
+	s.SetCommitsAhead(2)
+	want := []string{mergeOptionMergeToMain, mergeOptionRebaseOnMain, mergeOptionCreatePR}
+	if !slices.Equal(s.Options, want) {
+		t.Fatalf("Options = %v, want %v", s.Options, want)
+	}
+
+	// Rebasing checks out main like a merge, runs the post-merge hooks, and can archive after
+	s.SetMainRepoChanges("1 file changed")
+	s.SetHooks(1, 0)
+	s.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	rendered := ansi.Strip(s.Render())
+	for _, want := range []string{"Main repo has uncommitted changes", "Run 1 post-merge hook"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Expected %q with the rebase selected, got:\n%s", want, rendered)
+		}
+	}
+	if !strings.Contains(s.Help(), "a: archive after") {
+		t.Errorf("Expected archiving after a rebase, got help %q", s.Help())
+	}
+
+	s.ShowPreview(MergePreview{TargetBranch: "main", Rebase: true})
+	if !strings.Contains(ansi.Strip(s.Render()), "Rebases onto, then fast-forwards main") {
+		t.Errorf("Expected the rebase in the preview, got:\n%s", ansi.Strip(s.Render()))
+	}
+}
+
+func TestMergeConflictState_Rebase(t *testing.T) {
+	s := NewMergeConflictState("id", "session", []string{"main.go"}, "/wt")
+	s.SetRebase()
+	if s.Title() != "Rebase Conflict" || s.Options[1] != "Abort rebase" {
+		t.Errorf("Expected a rebase conflict, got title %q and options %v", s.Title(), s.Options)
+	}
+}
+
+func TestMergeState_Hooks(t *testing.T) {
+	s := NewMergeState("session", true, "", "", false)
+	if strings.Contains(ansi.Strip(s.Render()), "hook") || strings.Contains(s.Help(), "hooks") {
+		t.Error("Expected no hooks checkbox without hooks configured")
+	}
+
+	s.SetHooks(2, 1)
+	if !s.RunHooks {
+		t.Fatal("Expected hooks to run by default")
+	}
+	if !strings.Contains(ansi.Strip(s.Render()), "[x] Run 2 post-merge hooks") {
+		t.Errorf("Expected the post-merge hooks checkbox, got:\n%s", ansi.Strip(s.Render()))
+	}
+	if !strings.Contains(s.Help(), "h: toggle hooks") {
+		t.Errorf("Expected help to mention toggling hooks, got %q", s.Help())
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	if s.RunHooks {
+		t.Error("Expected h to skip the hooks")
+	}
+
+	// Create PR has its own hooks; the choice carries over
+	s.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if !strings.Contains(ansi.Strip(s.Render()), "[ ] Run 1 post-PR-create hook") {
+		t.Errorf("Expected the post-PR hook checkbox, got:\n%s", ansi.Strip(s.Render()))
+	}
+
+	// The preview offers the post-merge hooks too
+	s.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	s.ShowPreview(MergePreview{TargetBranch: "main"})
+	s.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	if !s.RunHooks {
+		t.Error("Expected h to toggle the hooks on the preview")
+	}
+	if !strings.Contains(ansi.Strip(s.Render()), "[x] Run 2 post-merge hooks") || !strings.HasPrefix(s.Help(), "h: toggle hooks") {
+		t.Errorf("Expected the preview to show the hooks checkbox, got:\n%s", ansi.Strip(s.Render()))
+	}
+}
+
+func TestMergeState_ArchiveAfterMerge(t *testing.T) {
+	s := NewMergeState("session", true, "", "parent", false)
+	if !strings.Contains(ansi.Strip(s.Render()), "[ ] Archive the session") || !strings.Contains(s.Help(), "a: archive after") {
+		t.Errorf("Expected an unchecked archive checkbox for merge to parent, got:\n%s", ansi.Strip(s.Render()))
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	if !s.ShouldArchiveAfterMerge() {
+		t.Error("Expected a to choose archiving after the merge")
+	}
+
+	// Merge to main offers it too, keeping the choice
+	s.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if !strings.Contains(ansi.Strip(s.Render()), "[x] Archive the session") {
+		t.Errorf("Expected the checked archive checkbox for merge to main, got:\n%s", ansi.Strip(s.Render()))
+	}
+
+	// Creating a PR doesn't finish the session, so archiving doesn't apply
+	s.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if strings.Contains(ansi.Strip(s.Render()), "Archive the session") || strings.Contains(s.Help(), "archive") {
+		t.Error("Expected no archive checkbox for Create PR")
+	}
+	if s.ShouldArchiveAfterMerge() {
+		t.Error("Expected no archiving after creating a PR")
+	}
+	s.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	if !s.ArchiveAfterMerge {
+		t.Error("a should not toggle archiving while Create PR is selected")
+	}
+
+	// The preview toggles it as well
+	s.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	s.ShowPreview(MergePreview{TargetBranch: "main"})
+	s.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	if s.ShouldArchiveAfterMerge() {
+		t.Error("Expected a to toggle archiving on the preview")
+	}
+	if !strings.Contains(ansi.Strip(s.Render()), "[ ] Archive the session") || !strings.Contains(s.Help(), "a: archive after") {
+		t.Errorf("Expected the preview to show the archive checkbox, got:\n%s", ansi.Strip(s.Render()))
+	}
+}
+
+func TestMergeState_UpdateDefaultBranch(t *testing.T) {
+	// A prefilled stale default moves to the re-resolved default
+	state := NewMergeState("session", true, "", "", false)
+	state.SetPRBaseBranch("master", nil)
+	state.UpdateDefaultBranch("master", "main", []string{"main"})
+	if got := state.GetPRBaseBranch(); got != "main" {
+		t.Errorf("Expected PR base 'main', got %q", got)
+	}
+
+	// A base the user chose is kept
+	state = NewMergeState("session", true, "", "", false)
+	state.SetPRBaseBranch("release", nil)
+	state.UpdateDefaultBranch("master", "main", nil)
+	if got := state.GetPRBaseBranch(); got != "release" {
+		t.Errorf("Expected PR base 'release' to be kept, got %q", got)
+	}
+	if state.StaleBaseBranch != "" || state.CanMigrateBaseBranch() {
+		t.Error("Expected no stale base branch warning")
+	}
+}
+
+func TestMergeState_Render_Overlaps(t *testing.T) {
+	state := NewMergeState("session", true, "", "", false)
+	if strings.Contains(state.Render(), "expect conflicts") {
+		t.Error("Expected no overlap warning without overlaps")
+	}
+
+	state.SetOverlaps([]FileOverlapItem{{SessionName: "other", Files: []string{"a.go", "b.go", "c.go", "d.go"}}})
+	rendered := state.Render()
+	if !strings.Contains(rendered, "expect conflicts") {
+		t.Error("Expected overlap warning")
+	}
+	if !strings.Contains(rendered, "other: a.go, b.go, c.go +1 more") {
+		t.Errorf("Expected overlapping files of the other session, got:\n%s", rendered)
+	}
+}
+
+func TestMergeState_Render_ExternalWrites(t *testing.T) {
+	state := NewMergeState("session", true, "", "", false)
+	if strings.Contains(state.Render(), "outside the worktree") {
+		t.Error("Expected no external writes warning without external writes")
+	}
+
+	state.SetExternalWrites([]string{"/a", "/b", "/c", "/d", "/e", "/f"})
+	rendered := state.Render()
+	if !strings.Contains(rendered, "outside the worktree") {
+		t.Error("Expected external writes warning")
+	}
+	if !strings.Contains(rendered, "/e") || strings.Contains(rendered, "/f") || !strings.Contains(rendered, "+1 more") {
+		t.Errorf("Expected the first five external writes and a count of the rest, got:\n%s", rendered)
+	}
+}
+
+func TestMergeState_Render_CommandsCopied(t *testing.T) {
+	state := NewMergeState("session", true, "", "", false)
+	if !strings.Contains(state.Help(), "c: copy commands") {
+		t.Errorf("Expected the copy commands hint, got %q", state.Help())
+	}
+
+	state.CommandsCopied = state.GetSelectedOption()
+	if !strings.Contains(state.Render(), "Copied the git commands") {
+		t.Error("Expected confirmation for the copied option")
+	}
+
+	// The confirmation belongs to the option that was copied
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if strings.Contains(state.Render(), "Copied the git commands") {
+		t.Error("Expected no confirmation after selecting another option")
+	}
+}
+
+func TestMergeState_Preview(t *testing.T) {
+	state := NewMergeState("session", true, "", "", false)
+	state.ShowPreview(MergePreview{
+		Files:         []string{"main.go"},
+		CommitMessage: "Add feature",
+		TargetBranch:  "main",
+		HasRemote:     true,
+		Compared:      true,
+		Ahead:         1,
+		Behind:        2,
+	})
+
+	rendered := ansi.Strip(state.Render())
+	for _, want := range []string{"Merge Preview", "main.go", "Add feature", "Merges into main", "Diverged from origin/main (1 ahead, 2 behind)", "No push"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Expected %q in the preview, got:\n%s", want, rendered)
+		}
+	}
+	if state.Help() != "a: archive after  Enter: merge  Esc: back" {
+		t.Errorf("Expected the confirmation help, got %q", state.Help())
+	}
+
+	// Option navigation is off while previewing
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.SelectedIndex != 0 {
+		t.Error("Expected the selection to stay put while previewing")
+	}
+
+	state.HidePreview()
+	if strings.Contains(state.Render(), "Merge Preview") {
+		t.Error("Expected the merge options after hiding the preview")
+	}
+}
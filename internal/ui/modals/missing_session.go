@@ -0,0 +1,98 @@
+package modals
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// =============================================================================
+// MissingSessionState - State for the Missing Session modal
+// =============================================================================
+
+// Options of the Missing Session modal, by index
+const (
+	missingSessionArchive = iota // Save the transcript, then remove the session
+	missingSessionDelete         // Remove the session and its history
+	missingSessionKeep           // Leave it in the list, e.g. while a drive is remounted
+)
+
+// MissingSessionState is shown on selecting a session whose repo or worktree
+// no longer exists, offering to archive or delete it.
+type MissingSessionState struct {
+	SessionID     string
+	SessionName   string
+	MissingPath   string // The repo or worktree that is gone
+	Options       []string
+	SelectedIndex int
+}
+
+func (*MissingSessionState) modalState() {}
+
+func (s *MissingSessionState) Title() string { return "Session Missing" }
+
+func (s *MissingSessionState) Help() string {
+	return "up/down to select, Enter to confirm, Esc to cancel"
+}
+
+func (s *MissingSessionState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	sessionLabel := lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		Bold(true).
+		Render(s.SessionName)
+
+	pathLabel := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		MarginBottom(1).
+		Render(TruncateString(s.MissingPath, ModalWidth-6))
+
+	message := lipgloss.NewStyle().
+		Foreground(ColorText).
+		Width(ModalWidth - 4).
+		MarginBottom(1).
+		Render("This path no longer exists, so the session can't run Claude or git. Archiving saves its conversation as a transcript before removing it.")
+
+	optionList := RenderSelectableList(s.Options, s.SelectedIndex)
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, sessionLabel, pathLabel, message, optionList, help)
+}
+
+func (s *MissingSessionState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case keys.Down, "j":
+			if s.SelectedIndex < len(s.Options)-1 {
+				s.SelectedIndex++
+			}
+		}
+	}
+	return s, nil
+}
+
+// ShouldArchive returns true if the user chose to archive the transcript and remove the session
+func (s *MissingSessionState) ShouldArchive() bool {
+	return s.SelectedIndex == missingSessionArchive
+}
+
+// ShouldDelete returns true if the user chose to delete the session outright
+func (s *MissingSessionState) ShouldDelete() bool {
+	return s.SelectedIndex == missingSessionDelete
+}
+
+// NewMissingSessionState creates a new MissingSessionState
+func NewMissingSessionState(sessionID, sessionName, missingPath string) *MissingSessionState {
+	return &MissingSessionState{
+		SessionID:   sessionID,
+		SessionName: sessionName,
+		MissingPath: missingPath,
+		Options:     []string{"Archive transcript and remove", "Delete session", "Keep for now"},
+	}
+}
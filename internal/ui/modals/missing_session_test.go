@@ -0,0 +1,33 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestMissingSessionState_Options(t *testing.T) {
+	state := NewMissingSessionState("session-1", "my-feature", "/gone/worktree")
+
+	rendered := ansi.Strip(state.Render())
+	for _, want := range []string{"Session Missing", "my-feature", "/gone/worktree", "Archive transcript and remove"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %q in:\n%s", want, rendered)
+		}
+	}
+
+	if !state.ShouldArchive() || state.ShouldDelete() {
+		t.Error("expected archiving selected by default")
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.ShouldArchive() || !state.ShouldDelete() {
+		t.Error("expected delete selected after down")
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.ShouldArchive() || state.ShouldDelete() || state.SelectedIndex != missingSessionKeep {
+		t.Errorf("expected keep selected at the bottom, got index %d", state.SelectedIndex)
+	}
+}
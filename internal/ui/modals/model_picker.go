@@ -0,0 +1,113 @@
+package modals
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// =============================================================================
+// ModelPickerState - State for the Switch Model modal
+// =============================================================================
+
+// ModelChoices are the models offered for switching, as Claude CLI aliases for
+// the latest of each. "" is the CLI's default.
+var ModelChoices = []string{"", "haiku", "sonnet", "opus"}
+
+// ModelPickerState picks the model a session's Claude runs as from its next
+// message on.
+type ModelPickerState struct {
+	SessionID     string
+	SessionName   string
+	Current       string   // Model the session runs as, "" for the CLI's default
+	Models        []string // Models offered, in order
+	SelectedIndex int
+}
+
+func (*ModelPickerState) modalState() {}
+
+func (s *ModelPickerState) Title() string { return "Switch Model" }
+
+func (s *ModelPickerState) Help() string {
+	return "up/down to select, Enter to switch, Esc to cancel"
+}
+
+// modelLabel returns how a model is listed.
+func (s *ModelPickerState) modelLabel(model string) string {
+	label := model
+	if model == "" {
+		label = "default"
+	}
+	if model == s.Current {
+		label += " (current)"
+	}
+	return label
+}
+
+func (s *ModelPickerState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	sessionLabel := lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		Bold(true).
+		Render(s.SessionName)
+
+	message := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Width(ModalWidth - 4).
+		MarginBottom(1).
+		Render("Applies from the next message on; the conversation continues.")
+
+	labels := make([]string, len(s.Models))
+	for i, model := range s.Models {
+		labels[i] = s.modelLabel(model)
+	}
+	optionList := RenderSelectableList(labels, s.SelectedIndex)
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, sessionLabel, message, optionList, help)
+}
+
+func (s *ModelPickerState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case keys.Down, "j":
+			if s.SelectedIndex < len(s.Models)-1 {
+				s.SelectedIndex++
+			}
+		}
+	}
+	return s, nil
+}
+
+// SelectedModel returns the model selected, "" for the CLI's default.
+func (s *ModelPickerState) SelectedModel() string {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Models) {
+		return s.Current
+	}
+	return s.Models[s.SelectedIndex]
+}
+
+// NewModelPickerState creates a new ModelPickerState with the current model
+// selected. A current model not among ModelChoices, such as a full model name
+// set in the config file, is listed after them.
+func NewModelPickerState(sessionID, sessionName, current string) *ModelPickerState {
+	models := slices.Clone(ModelChoices)
+	if !slices.Contains(models, current) {
+		models = append(models, current)
+	}
+	return &ModelPickerState{
+		SessionID:     sessionID,
+		SessionName:   sessionName,
+		Current:       current,
+		Models:        models,
+		SelectedIndex: slices.Index(models, current),
+	}
+}
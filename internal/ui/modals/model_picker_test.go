@@ -0,0 +1,41 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestModelPickerState_SelectsCurrent(t *testing.T) {
+	state := NewModelPickerState("session-1", "my-feature", "haiku")
+
+	rendered := ansi.Strip(state.Render())
+	for _, want := range []string{"Switch Model", "my-feature", "default", "haiku (current)", "opus"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %q in:\n%s", want, rendered)
+		}
+	}
+
+	if state.SelectedModel() != "haiku" {
+		t.Errorf("expected the current model selected, got %q", state.SelectedModel())
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.SelectedModel() != "opus" {
+		t.Errorf("expected opus after down twice, got %q", state.SelectedModel())
+	}
+}
+
+func TestModelPickerState_CustomModel(t *testing.T) {
+	state := NewModelPickerState("session-1", "my-feature", "claude-opus-4-1")
+	if len(state.Models) != len(ModelChoices)+1 || state.SelectedModel() != "claude-opus-4-1" {
+		t.Errorf("expected a custom current model listed and selected, got %v at %d", state.Models, state.SelectedIndex)
+	}
+
+	// The default is listed first
+	if state := NewModelPickerState("session-1", "my-feature", ""); state.SelectedIndex != 0 || len(state.Models) != len(ModelChoices) {
+		t.Errorf("expected the default selected, got %v at %d", state.Models, state.SelectedIndex)
+	}
+}
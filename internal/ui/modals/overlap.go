@@ -0,0 +1,84 @@
+package modals
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// FileOverlapItem is another session of the same repo that changed some of the
+// same files, which will likely conflict when both are merged.
+type FileOverlapItem struct {
+	SessionName string
+	Files       []string // Paths changed by both sessions
+}
+
+// overlapSummary describes overlaps in one line per session, listing at most
+// maxFiles paths for each.
+func overlapSummary(overlaps []FileOverlapItem, maxFiles int) []string {
+	lines := make([]string, 0, len(overlaps))
+	for _, o := range overlaps {
+		files := o.Files
+		more := ""
+		if len(files) > maxFiles {
+			files = files[:maxFiles]
+			more = fmt.Sprintf(" +%d more", len(o.Files)-maxFiles)
+		}
+		lines = append(lines, o.SessionName+": "+strings.Join(files, ", ")+more)
+	}
+	return lines
+}
+
+// =============================================================================
+// FileOverlapState - Files a session changed that other sessions changed too
+// =============================================================================
+
+// FileOverlapState lists, for each other session of the same repo, the files it
+// changed that the selected session changed too.
+type FileOverlapState struct {
+	SessionName string
+	Overlaps    []FileOverlapItem
+}
+
+func (*FileOverlapState) modalState() {}
+
+func (s *FileOverlapState) Title() string { return "Overlapping Changes" }
+
+func (s *FileOverlapState) Help() string { return "Esc: close" }
+
+func (s *FileOverlapState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+	contentWidth := ModalWidth - 4
+
+	description := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Width(contentWidth).
+		MarginBottom(1).
+		Render(s.SessionName + " has uncommitted changes to files other sessions changed too. Merging both will likely conflict.")
+
+	parts := []string{title, description}
+	sessionStyle := lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
+	fileStyle := lipgloss.NewStyle().Foreground(ColorText).PaddingLeft(2).Width(contentWidth)
+	for _, o := range s.Overlaps {
+		parts = append(parts, sessionStyle.Render(o.SessionName))
+		for _, file := range o.Files {
+			parts = append(parts, fileStyle.Render(file))
+		}
+	}
+	parts = append(parts, ModalHelpStyle.Render(s.Help()))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+func (s *FileOverlapState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	return s, nil
+}
+
+// NewFileOverlapState creates a new FileOverlapState for a session's overlaps.
+func NewFileOverlapState(sessionName string, overlaps []FileOverlapItem) *FileOverlapState {
+	return &FileOverlapState{
+		SessionName: sessionName,
+		Overlaps:    overlaps,
+	}
+}
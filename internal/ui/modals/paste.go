@@ -0,0 +1,177 @@
+package modals
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// =============================================================================
+// PasteCleanState - State for the paste cleaning prompt
+// =============================================================================
+
+// PasteCleanChoice is the user's answer to the paste cleaning prompt.
+type PasteCleanChoice int
+
+const (
+	PasteCleanSession PasteCleanChoice = iota // Clean pastes for the rest of this session
+	PasteRawSession                           // Keep raw pastes for the rest of this session
+	PasteCleanAlways                          // Always clean pastes (saved to config)
+	PasteCleanNever                           // Never clean pastes (saved to config)
+)
+
+// PasteCleanState asks whether a noisy multi-line paste should be cleaned.
+// It holds both versions of the paste so the chosen one can be inserted.
+type PasteCleanState struct {
+	SessionID     string
+	Raw           string // Paste as received from the terminal
+	Cleaned       string // Paste after cleaning
+	Summary       string // One-line description of what cleaning removes
+	Options       []string
+	SelectedIndex int
+}
+
+func (*PasteCleanState) modalState() {}
+
+func (s *PasteCleanState) Title() string { return "Clean Pasted Output?" }
+
+func (s *PasteCleanState) Help() string {
+	return "up/down to select, Enter to confirm, Esc to paste raw"
+}
+
+func (s *PasteCleanState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	contentWidth := ModalWidth - 4
+
+	message := lipgloss.NewStyle().
+		Foreground(ColorText).
+		Width(contentWidth).
+		Render("This paste looks like terminal output with prompts, escape codes, or padding.")
+
+	summary := lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		MarginTop(1).
+		MarginBottom(1).
+		Width(contentWidth).
+		Render(s.Summary)
+
+	optionList := RenderSelectableList(s.Options, s.SelectedIndex)
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, message, summary, optionList, help)
+}
+
+func (s *PasteCleanState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case keys.Down, "j":
+			if s.SelectedIndex < len(s.Options)-1 {
+				s.SelectedIndex++
+			}
+		}
+	}
+	return s, nil
+}
+
+// GetChoice returns the selected answer.
+func (s *PasteCleanState) GetChoice() PasteCleanChoice {
+	return PasteCleanChoice(s.SelectedIndex)
+}
+
+// NewPasteCleanState creates a new PasteCleanState for a paste in the given session.
+func NewPasteCleanState(sessionID, raw, cleaned, summary string) *PasteCleanState {
+	return &PasteCleanState{
+		SessionID: sessionID,
+		Raw:       raw,
+		Cleaned:   cleaned,
+		Summary:   summary,
+		Options: []string{
+			"Clean pastes in this session",
+			"Keep raw pastes in this session",
+			"Always clean pastes",
+			"Never clean pastes",
+		},
+		SelectedIndex: 0,
+	}
+}
+
+// =============================================================================
+// ImageTooLargeState - State for the oversized image prompt
+// =============================================================================
+
+// ImageTooLargeState asks whether to attach an image that is still larger than
+// the configured limit after downscaling. It holds the smallest version produced.
+type ImageTooLargeState struct {
+	Data          []byte
+	MediaType     string
+	SizeKB        int // Size of Data, rounded up so it always reads as over the limit
+	LimitKB       int // Configured max_image_kb
+	Options       []string
+	SelectedIndex int
+}
+
+func (*ImageTooLargeState) modalState() {}
+
+func (s *ImageTooLargeState) Title() string { return "Image Too Large" }
+
+func (s *ImageTooLargeState) Help() string {
+	return "up/down to select, Enter to confirm, Esc to cancel"
+}
+
+func (s *ImageTooLargeState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	message := lipgloss.NewStyle().
+		Foreground(ColorText).
+		MarginBottom(1).
+		Width(ModalWidth - 4).
+		Render(fmt.Sprintf("Even downscaled, this image is %dKB, over the %dKB limit (max_image_kb).", s.SizeKB, s.LimitKB))
+
+	optionList := RenderSelectableList(s.Options, s.SelectedIndex)
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, message, optionList, help)
+}
+
+func (s *ImageTooLargeState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case keys.Down, "j":
+			if s.SelectedIndex < len(s.Options)-1 {
+				s.SelectedIndex++
+			}
+		}
+	}
+	return s, nil
+}
+
+// ShouldAttach returns true if the user chose to attach the image anyway.
+func (s *ImageTooLargeState) ShouldAttach() bool {
+	return s.SelectedIndex == 0 // "Attach anyway" is index 0
+}
+
+// NewImageTooLargeState creates a new ImageTooLargeState for the downscaled image data.
+func NewImageTooLargeState(data []byte, mediaType string, limitKB int) *ImageTooLargeState {
+	return &ImageTooLargeState{
+		Data:          data,
+		MediaType:     mediaType,
+		SizeKB:        (len(data) + 1023) / 1024,
+		LimitKB:       limitKB,
+		Options:       []string{"Attach anyway", "Cancel"},
+		SelectedIndex: 0,
+	}
+}
@@ -0,0 +1,101 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// =============================================================================
+// PasteCleanState Tests
+// =============================================================================
+
+func TestNewPasteCleanState(t *testing.T) {
+	state := NewPasteCleanState("session-1", "$ ls  \n$ pwd\n", "ls\npwd", "cleaned paste: removed 8B of noise")
+
+	if state.SessionID != "session-1" {
+		t.Errorf("expected session ID 'session-1', got '%s'", state.SessionID)
+	}
+	if state.Cleaned != "ls\npwd" {
+		t.Errorf("expected cleaned text to be kept, got %q", state.Cleaned)
+	}
+	if len(state.Options) != 4 {
+		t.Fatalf("expected 4 options, got %d", len(state.Options))
+	}
+	if state.GetChoice() != PasteCleanSession {
+		t.Errorf("expected default choice to clean for this session, got %v", state.GetChoice())
+	}
+}
+
+func TestPasteCleanState_Render(t *testing.T) {
+	state := NewPasteCleanState("session-1", "raw", "clean", "cleaned paste: removed 2.1KB of noise")
+
+	rendered := state.Render()
+	if !strings.Contains(rendered, "Clean Pasted Output?") {
+		t.Error("expected rendered output to contain title")
+	}
+	if !strings.Contains(rendered, "removed 2.1KB of noise") {
+		t.Error("expected rendered output to contain the summary")
+	}
+	if !strings.Contains(rendered, "Never clean pastes") {
+		t.Error("expected rendered output to list options")
+	}
+}
+
+func TestPasteCleanState_Update(t *testing.T) {
+	state := NewPasteCleanState("session-1", "raw", "clean", "summary")
+
+	choices := []PasteCleanChoice{PasteRawSession, PasteCleanAlways, PasteCleanNever, PasteCleanNever}
+	for _, want := range choices {
+		state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+		if got := state.GetChoice(); got != want {
+			t.Errorf("after down, expected choice %v, got %v", want, got)
+		}
+	}
+
+	state.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	if got := state.GetChoice(); got != PasteCleanAlways {
+		t.Errorf("after k, expected choice %v, got %v", PasteCleanAlways, got)
+	}
+}
+
+func TestPasteCleanState_ModalStateInterface(t *testing.T) {
+	// Compile-time check that PasteCleanState implements ModalState
+	var _ ModalState = (*PasteCleanState)(nil)
+}
+
+// =============================================================================
+// ImageTooLargeState Tests
+// =============================================================================
+
+func TestImageTooLargeState(t *testing.T) {
+	state := NewImageTooLargeState(make([]byte, 300*1024), "image/jpeg", 200)
+
+	if state.SizeKB != 300 || state.LimitKB != 200 {
+		t.Errorf("expected 300KB over a 200KB limit, got %dKB over %dKB", state.SizeKB, state.LimitKB)
+	}
+	if !state.ShouldAttach() {
+		t.Error("expected attach anyway to be the default")
+	}
+
+	rendered := state.Render()
+	for _, want := range []string{"Image Too Large", "300KB", "200KB", "Attach anyway", "Cancel"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected rendered output to contain %q", want)
+		}
+	}
+
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.ShouldAttach() {
+		t.Error("expected cancel after moving down")
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.SelectedIndex != 1 {
+		t.Errorf("selection should stop at the last option, got %d", state.SelectedIndex)
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	if !state.ShouldAttach() {
+		t.Error("expected attach anyway after moving up")
+	}
+}
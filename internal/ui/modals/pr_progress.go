@@ -0,0 +1,165 @@
+package modals
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// PRStepState is the display state of a PR pipeline step.
+type PRStepState int
+
+const (
+	PRStepPending PRStepState = iota
+	PRStepRunning
+	PRStepDone
+	PRStepFailed
+)
+
+// PRStepItem is one step of the PR pipeline as shown in the merge and PR progress modals.
+type PRStepItem struct {
+	Label  string
+	State  PRStepState
+	Detail string // Short note shown after the label (pushed SHA, generated title, error)
+}
+
+// renderPRSteps renders one line per step with a ✓/✗/spinner marker.
+func renderPRSteps(steps []PRStepItem, spinnerView string, width int) string {
+	doneStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+	pendingStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	detailStyle := lipgloss.NewStyle().Foreground(ColorTextMuted).Italic(true)
+
+	var lines []string
+	for _, step := range steps {
+		var marker, label string
+		switch step.State {
+		case PRStepDone:
+			marker, label = doneStyle.Render("✓"), lipgloss.NewStyle().Foreground(ColorText).Render(step.Label)
+		case PRStepFailed:
+			marker, label = StatusErrorStyle.Render("✗"), StatusErrorStyle.Render(step.Label)
+		case PRStepRunning:
+			marker, label = spinnerView, lipgloss.NewStyle().Foreground(ColorPrimary).Render(step.Label)
+		default:
+			marker, label = pendingStyle.Render("·"), pendingStyle.Render(step.Label)
+		}
+		line := marker + " " + label
+		if step.Detail != "" && step.State != PRStepFailed {
+			line += " " + detailStyle.Render(TruncateString(step.Detail, max(width-len(step.Label)-4, 10)))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// failedPRStep returns the failed step, or nil if none failed.
+func failedPRStep(steps []PRStepItem) *PRStepItem {
+	for i := range steps {
+		if steps[i].State == PRStepFailed {
+			return &steps[i]
+		}
+	}
+	return nil
+}
+
+// =============================================================================
+// PRProgressState - State for the PR creation progress modal
+// =============================================================================
+
+// PRProgressState shows per-step progress while a PR is created. When a step fails
+// the modal stays open with the error so the user can retry from that step.
+type PRProgressState struct {
+	SessionID   string
+	SessionName string
+	Steps       []PRStepItem
+	Spinner     spinner.Model
+}
+
+func (*PRProgressState) modalState() {}
+
+func (s *PRProgressState) Title() string {
+	if s.Failed() {
+		return "PR Creation Failed"
+	}
+	return "Creating PR"
+}
+
+func (s *PRProgressState) Help() string {
+	if s.Failed() {
+		return "Enter: retry from failed step  Esc: close"
+	}
+	return "Esc: hide (continues in background)"
+}
+
+func (s *PRProgressState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	// Content width for text wrapping (modal width minus padding)
+	contentWidth := ModalWidth - 4
+
+	sessionLabel := lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		Bold(true).
+		MarginBottom(1).
+		Width(contentWidth).
+		Render(s.SessionName)
+
+	parts := []string{title, sessionLabel, renderPRSteps(s.Steps, s.Spinner.View(), contentWidth)}
+
+	if failed := failedPRStep(s.Steps); failed != nil && failed.Detail != "" {
+		errText := StatusErrorStyle.
+			MarginTop(1).
+			Width(contentWidth).
+			Render(failed.Detail)
+		parts = append(parts, errText)
+	}
+
+	help := ModalHelpStyle.Render(s.Help())
+	parts = append(parts, help)
+
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+func (s *PRProgressState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	// Keys are handled by the app (retry/close); nothing to navigate
+	return s, nil
+}
+
+// SetStep updates the state and detail of the step at index.
+func (s *PRProgressState) SetStep(index int, state PRStepState, detail string) {
+	if index < 0 || index >= len(s.Steps) {
+		return
+	}
+	s.Steps[index].State = state
+	if detail != "" {
+		s.Steps[index].Detail = detail
+	}
+}
+
+// Failed returns whether a step has failed.
+func (s *PRProgressState) Failed() bool {
+	return failedPRStep(s.Steps) != nil
+}
+
+// AdvanceSpinner updates the spinner by forwarding a tick message
+func (s *PRProgressState) AdvanceSpinner(msg spinner.TickMsg) tea.Cmd {
+	var cmd tea.Cmd
+	s.Spinner, cmd = s.Spinner.Update(msg)
+	return cmd
+}
+
+// NewPRProgressState creates a new PRProgressState. steps carries the labels in
+// pipeline order along with any state carried over from a previous attempt.
+func NewPRProgressState(sessionID, sessionName string, steps []PRStepItem) *PRProgressState {
+	sp := spinner.New(
+		spinner.WithSpinner(spinner.MiniDot),
+		spinner.WithStyle(lipgloss.NewStyle().Foreground(ColorUser).Bold(true)),
+	)
+	return &PRProgressState{
+		SessionID:   sessionID,
+		SessionName: sessionName,
+		Steps:       steps,
+		Spinner:     sp,
+	}
+}
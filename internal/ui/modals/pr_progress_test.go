@@ -0,0 +1,81 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// PRProgressState Tests
+// =============================================================================
+
+func testPRSteps() []PRStepItem {
+	return []PRStepItem{
+		{Label: "Commit pending changes"},
+		{Label: "Push branch"},
+		{Label: "Generate title/body"},
+		{Label: "Create PR"},
+	}
+}
+
+func TestPRProgressState_StepsAndFailure(t *testing.T) {
+	state := NewPRProgressState("session-1", "my-session", testPRSteps())
+
+	if state.Failed() {
+		t.Error("expected new state to not be failed")
+	}
+	if state.Title() != "Creating PR" {
+		t.Errorf("expected title 'Creating PR', got %q", state.Title())
+	}
+
+	state.SetStep(0, PRStepDone, "")
+	state.SetStep(1, PRStepDone, "at 0123456")
+	state.SetStep(2, PRStepRunning, "")
+	state.SetStep(9, PRStepDone, "") // out of range is ignored
+
+	rendered := state.Render()
+	if !strings.Contains(rendered, "✓") || !strings.Contains(rendered, "at 0123456") {
+		t.Errorf("expected done marker and detail in render, got:\n%s", rendered)
+	}
+
+	state.SetStep(2, PRStepFailed, "PR creation failed: auth")
+	if !state.Failed() {
+		t.Fatal("expected state to be failed")
+	}
+	if state.Title() != "PR Creation Failed" {
+		t.Errorf("expected failed title, got %q", state.Title())
+	}
+	if !strings.Contains(state.Help(), "retry") {
+		t.Errorf("expected retry in help, got %q", state.Help())
+	}
+	rendered = state.Render()
+	if !strings.Contains(rendered, "✗") || !strings.Contains(rendered, "PR creation failed: auth") {
+		t.Errorf("expected failure marker and error in render, got:\n%s", rendered)
+	}
+}
+
+func TestMergeState_RendersPRResume(t *testing.T) {
+	state := NewMergeState("session", true, "", "", false)
+	for state.GetSelectedOption() != mergeOptionCreatePR {
+		state.SelectedIndex++
+	}
+
+	steps := testPRSteps()
+	steps[0].State = PRStepDone
+	steps[1].State = PRStepFailed
+	steps[1].Detail = "failed to push: rejected"
+	state.SetPRResume(steps)
+
+	rendered := state.Render()
+	if !strings.Contains(rendered, "resumes from the failed step") {
+		t.Errorf("expected resume note in render, got:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "failed to push: rejected") {
+		t.Errorf("expected failed step error in render, got:\n%s", rendered)
+	}
+}
+
+func TestPRProgressState_ModalStateInterface(t *testing.T) {
+	// Compile-time check that PRProgressState implements ModalState
+	var _ ModalState = (*PRProgressState)(nil)
+}
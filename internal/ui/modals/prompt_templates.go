@@ -0,0 +1,259 @@
+package modals
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// PromptTemplateItem is a saved prompt template.
+type PromptTemplateItem struct {
+	Name      string
+	Text      string
+	Variables []string // Names of the {variables} in Text, in order
+}
+
+// =============================================================================
+// TemplatePickerState - Choose a prompt template to fill in
+// =============================================================================
+
+// TemplatePickerState lists the saved prompt templates, filtered by a fuzzy
+// match on their names as the user types.
+type TemplatePickerState struct {
+	Items         []PromptTemplateItem
+	Input         textinput.Model
+	Matches       []PromptTemplateItem // Items matching the filter, in order
+	SelectedIndex int
+	ScrollOffset  int
+}
+
+func (*TemplatePickerState) modalState() {}
+
+func (s *TemplatePickerState) Title() string { return "Prompt Templates" }
+
+func (s *TemplatePickerState) Help() string {
+	return "Type to filter  up/down: navigate  Enter: use  Esc: cancel"
+}
+
+func (s *TemplatePickerState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	inputStyle := lipgloss.NewStyle().
+		BorderLeft(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(ColorPrimary).
+		PaddingLeft(1).
+		MarginBottom(1)
+	inputView := inputStyle.Render(s.Input.View())
+
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	var list string
+	switch {
+	case len(s.Items) == 0:
+		list = mutedStyle.Italic(true).Width(ModalWidth - 4).Render("No prompt templates yet. Add them under prompt_templates in the config file.")
+	case len(s.Matches) == 0:
+		list = mutedStyle.Italic(true).Render("No matching templates")
+	default:
+		visibleEnd := min(s.ScrollOffset+SnippetPickerMaxVisible, len(s.Matches))
+		var labels []string
+		for _, item := range s.Matches[s.ScrollOffset:visibleEnd] {
+			labels = append(labels, item.Name+"  "+mutedStyle.Render(SnippetItem{Name: item.Name, Text: item.Text}.preview()))
+		}
+		list = strings.TrimSuffix(RenderSelectableList(labels, s.SelectedIndex-s.ScrollOffset), "\n")
+		if s.ScrollOffset > 0 {
+			list = mutedStyle.Render("  ↑ more above") + "\n" + list
+		}
+		if visibleEnd < len(s.Matches) {
+			list += "\n" + mutedStyle.Render("  ↓ more below")
+		}
+	}
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, inputView, list, help)
+}
+
+func (s *TemplatePickerState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, keys.CtrlP:
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+				if s.SelectedIndex < s.ScrollOffset {
+					s.ScrollOffset = s.SelectedIndex
+				}
+			}
+			return s, nil
+		case keys.Down, keys.CtrlN:
+			if s.SelectedIndex < len(s.Matches)-1 {
+				s.SelectedIndex++
+				if s.SelectedIndex >= s.ScrollOffset+SnippetPickerMaxVisible {
+					s.ScrollOffset = s.SelectedIndex - SnippetPickerMaxVisible + 1
+				}
+			}
+			return s, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	oldQuery := s.Input.Value()
+	s.Input, cmd = s.Input.Update(msg)
+	if s.Input.Value() != oldQuery {
+		s.filter()
+	}
+	return s, cmd
+}
+
+// filter narrows the list to templates whose name fuzzy-matches the query and
+// selects the first match.
+func (s *TemplatePickerState) filter() {
+	query := strings.TrimSpace(s.Input.Value())
+	s.Matches = nil
+	for _, item := range s.Items {
+		if fuzzyMatch(query, item.Name) {
+			s.Matches = append(s.Matches, item)
+		}
+	}
+	s.SelectedIndex = 0
+	s.ScrollOffset = 0
+}
+
+// GetSelected returns the selected template, or nil if nothing matches.
+func (s *TemplatePickerState) GetSelected() *PromptTemplateItem {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Matches) {
+		return nil
+	}
+	return &s.Matches[s.SelectedIndex]
+}
+
+// NewTemplatePickerState creates a new TemplatePickerState listing items.
+func NewTemplatePickerState(items []PromptTemplateItem) *TemplatePickerState {
+	input := textinput.New()
+	input.Placeholder = "Type to filter templates..."
+	input.CharLimit = SearchInputCharLimit
+	input.SetWidth(ModalInputWidth)
+	input.Focus()
+
+	s := &TemplatePickerState{
+		Items: items,
+		Input: input,
+	}
+	s.filter()
+	return s
+}
+
+// =============================================================================
+// TemplateVariablesState - Ask for each variable of a prompt template in turn
+// =============================================================================
+
+// TemplateVariablesState asks for the value of each of a template's variables
+// in turn, showing the prompt filled in so far.
+type TemplateVariablesState struct {
+	Template PromptTemplateItem
+	Values   map[string]string // Values entered so far, by variable name
+	Index    int               // Variable being asked for
+	Input    textinput.Model
+}
+
+func (*TemplateVariablesState) modalState() {}
+
+func (s *TemplateVariablesState) Title() string { return s.Template.Name }
+
+func (s *TemplateVariablesState) Help() string {
+	if s.Index < len(s.Template.Variables)-1 {
+		return "Enter: next  shift-tab: back  Esc: cancel"
+	}
+	return "Enter: insert  shift-tab: back  Esc: cancel"
+}
+
+func (s *TemplateVariablesState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	// The prompt as filled so far, with the variable asked for highlighted
+	current := "{" + s.Template.Variables[s.Index] + "}"
+	var prompt strings.Builder
+	for i, part := range strings.Split(s.Template.Text, current) {
+		if i > 0 {
+			prompt.WriteString(lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true).Render(current))
+		}
+		prompt.WriteString(s.fillKnown(part))
+	}
+	preview := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Width(ModalWidth - 4).
+		MaxHeight(8).
+		MarginBottom(1).
+		Render(prompt.String())
+
+	label := lipgloss.NewStyle().
+		Foreground(ColorText).
+		Render(fmt.Sprintf("%s (%d of %d):", s.Template.Variables[s.Index], s.Index+1, len(s.Template.Variables)))
+
+	inputStyle := lipgloss.NewStyle().
+		BorderLeft(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(ColorPrimary).
+		PaddingLeft(1)
+	inputView := inputStyle.Render(s.Input.View())
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, preview, label, inputView, help)
+}
+
+// fillKnown replaces the variables entered so far in text.
+func (s *TemplateVariablesState) fillKnown(text string) string {
+	var pairs []string
+	for name, value := range s.Values {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+func (s *TemplateVariablesState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok && keyMsg.String() == keys.ShiftTab {
+		if s.Index > 0 {
+			s.Values[s.Template.Variables[s.Index]] = s.Input.Value()
+			s.Index--
+			s.Input.SetValue(s.Values[s.Template.Variables[s.Index]])
+			s.Input.CursorEnd()
+		}
+		return s, nil
+	}
+	var cmd tea.Cmd
+	s.Input, cmd = s.Input.Update(msg)
+	return s, cmd
+}
+
+// Next records the value typed for the current variable and moves on to the
+// next one. Returns true once every variable has a value.
+func (s *TemplateVariablesState) Next() bool {
+	s.Values[s.Template.Variables[s.Index]] = s.Input.Value()
+	if s.Index == len(s.Template.Variables)-1 {
+		return true
+	}
+	s.Index++
+	s.Input.SetValue(s.Values[s.Template.Variables[s.Index]])
+	s.Input.CursorEnd()
+	return false
+}
+
+// NewTemplateVariablesState creates a new TemplateVariablesState for a template
+// with at least one variable.
+func NewTemplateVariablesState(template PromptTemplateItem) *TemplateVariablesState {
+	input := textinput.New()
+	input.CharLimit = ModalInputCharLimit
+	input.SetWidth(ModalInputWidth)
+	input.Focus()
+
+	return &TemplateVariablesState{
+		Template: template,
+		Values:   make(map[string]string),
+		Input:    input,
+	}
+}
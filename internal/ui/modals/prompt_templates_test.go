@@ -0,0 +1,60 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTemplatePickerState_Filter(t *testing.T) {
+	state := NewTemplatePickerState([]PromptTemplateItem{
+		{Name: "review", Text: "Review {file}"},
+		{Name: "refactor", Text: "Refactor {file}"},
+	})
+	typeInto(state, "rev")
+	if len(state.Matches) != 1 || state.GetSelected().Name != "review" {
+		t.Errorf("expected only review to match, got %+v", state.Matches)
+	}
+
+	empty := NewTemplatePickerState(nil)
+	if rendered := ansi.Strip(empty.Render()); !strings.Contains(rendered, "prompt_templates") {
+		t.Errorf("expected the empty picker to point at the config, got:\n%s", rendered)
+	}
+}
+
+func TestTemplateVariablesState_Steps(t *testing.T) {
+	state := NewTemplateVariablesState(PromptTemplateItem{
+		Name:      "review",
+		Text:      "Review {file} for {concern}",
+		Variables: []string{"file", "concern"},
+	})
+
+	rendered := ansi.Strip(state.Render())
+	if !strings.Contains(rendered, "file (1 of 2)") || !strings.Contains(rendered, "Review {file} for {concern}") {
+		t.Errorf("expected the first variable asked for, got:\n%s", rendered)
+	}
+
+	typeInto(state, "a.go")
+	if state.Next() {
+		t.Fatal("expected another variable to ask for")
+	}
+	if rendered := ansi.Strip(state.Render()); !strings.Contains(rendered, "Review a.go for {concern}") {
+		t.Errorf("expected the preview filled so far, got:\n%s", rendered)
+	}
+
+	// Going back shows the value entered before
+	state.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	if state.Index != 0 || state.Input.Value() != "a.go" {
+		t.Errorf("expected back on file with its value, at %d with %q", state.Index, state.Input.Value())
+	}
+	state.Next()
+	typeInto(state, "leaks")
+	if !state.Next() {
+		t.Fatal("expected every variable entered")
+	}
+	if state.Values["file"] != "a.go" || state.Values["concern"] != "leaks" {
+		t.Errorf("unexpected values %v", state.Values)
+	}
+}
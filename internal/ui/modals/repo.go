@@ -316,7 +316,7 @@ func (*SelectRepoForIssuesState) modalState() {}
 func (s *SelectRepoForIssuesState) Title() string { return "Select Repository" }
 
 func (s *SelectRepoForIssuesState) Help() string {
-	return "up/down select repo  Enter: import issues  Esc: cancel"
+	return "up/down select repo  Enter: import issues  b: blank branch  Esc: cancel"
 }
 
 func (s *SelectRepoForIssuesState) Render() string {
@@ -87,12 +87,14 @@ func (s *SearchMessagesState) Render() string {
 
 			// Role indicator
 			roleStyle := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
-			if result.Role == "user" {
-				roleStyle = lipgloss.NewStyle().Foreground(ColorUser).Bold(true)
-			}
 			roleText := "Claude"
-			if result.Role == "user" {
+			switch result.Role {
+			case "user":
+				roleStyle = lipgloss.NewStyle().Foreground(ColorUser).Bold(true)
 				roleText = "You"
+			case "system":
+				roleStyle = lipgloss.NewStyle().Foreground(ColorTextMuted).Bold(true)
+				roleText = "Plural"
 			}
 
 			// Message number
@@ -1,6 +1,7 @@
 package modals
 
 import (
+	"fmt"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -15,6 +16,9 @@ import (
 // NewSessionMaxVisibleRepos is the maximum number of repos visible before scrolling
 const NewSessionMaxVisibleRepos = 10
 
+// SessionActivitySparklineWidth is the number of most recent turns shown in the session activity sparklines
+const SessionActivitySparklineWidth = 40
+
 // ContainerAuthHelp is the user-facing message explaining how to set up auth for container mode.
 const ContainerAuthHelp = "Set ANTHROPIC_API_KEY env var, run 'claude login', or add 'anthropic_api_key' to macOS keychain"
 
@@ -34,6 +38,18 @@ type NewSessionState struct {
 	ContainersSupported    bool // Whether Docker is available for container mode
 	ContainerAuthAvailable bool // Whether API key credentials are available for container mode
 	Focus                  int  // 0=repo list, 1=base selection, 2=branch input, 3=containers (if supported)
+
+	// Git LFS: the checkout choice offered for repos using LFS, then creation progress
+	LFSPrompt      bool   // Asking how to check out the repo's LFS files
+	LFSIndex       int    // Selected LFSChoice while LFSPrompt is set
+	Creating       bool   // The worktree is being created in the background
+	CreateSkipsLFS bool   // The worktree being created leaves LFS files as pointers
+	CreateProgress string // Last line of git's output while Creating
+	CreatePercent  int    // LFS download percentage while Creating, -1 if not reported
+
+	// Repos without commits: the offer to make an empty first commit
+	InitialCommitPrompt  bool   // Asking to make the first commit of the selected repo
+	InitialCommitMessage string // Message of that commit
 }
 
 func (*NewSessionState) modalState() {}
@@ -46,14 +62,26 @@ func (s *NewSessionState) Title() string {
 }
 
 func (s *NewSessionState) Help() string {
+	if s.Creating {
+		return "Esc: cancel and remove the partial worktree"
+	}
+	if s.LFSPrompt {
+		return "up/down: select  Enter: confirm  Esc: back"
+	}
+	if s.InitialCommitPrompt {
+		return "Enter: commit and create session  Esc: back"
+	}
 	if s.LockedRepo == "" {
 		if s.Focus == 0 && len(s.RepoOptions) == 0 {
 			return "a: add repo  Esc: cancel"
 		}
 		if s.Focus == 0 && len(s.RepoOptions) > 0 {
-			return "up/down: select  Tab: next field  a: add repo  d: delete repo  Enter: create"
+			return "up/down: select  Tab: next field  a: add repo  d: delete repo  i: from issue  Enter: create"
 		}
 	}
+	if s.Focus == 1 {
+		return "up/down: select  Tab: next field  i: from issue  Enter: create"
+	}
 	if s.ContainersSupported && s.Focus == 3 {
 		return "Space: toggle  Tab: next field  Enter: create"
 	}
@@ -66,6 +94,15 @@ func (s *NewSessionState) Render() string {
 	var parts []string
 	parts = append(parts, title)
 
+	if s.LFSPrompt || s.Creating {
+		parts = append(parts, s.renderLFS(), ModalHelpStyle.Render(s.Help()))
+		return lipgloss.JoinVertical(lipgloss.Left, parts...)
+	}
+	if s.InitialCommitPrompt {
+		parts = append(parts, s.renderInitialCommit(), ModalHelpStyle.Render(s.Help()))
+		return lipgloss.JoinVertical(lipgloss.Left, parts...)
+	}
+
 	// Repository selection section (hidden when repo is locked)
 	if s.LockedRepo == "" {
 		repoLabel := lipgloss.NewStyle().
@@ -242,6 +279,13 @@ func (s *NewSessionState) advanceFocus(delta int) {
 }
 
 func (s *NewSessionState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if s.LFSPrompt || s.Creating {
+		return s, s.updateLFS(msg)
+	}
+	if s.InitialCommitPrompt {
+		// Confirming and going back are handled by the app
+		return s, nil
+	}
 	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
 		switch keyMsg.String() {
 		case keys.Up, "k":
@@ -321,6 +365,17 @@ func (s *NewSessionState) GetSelectedRepo() string {
 	return s.RepoOptions[s.RepoIndex]
 }
 
+// SelectRepo selects repoPath in the repo list, scrolling it into view. Does
+// nothing if it isn't listed.
+func (s *NewSessionState) SelectRepo(repoPath string) {
+	i := slices.Index(s.RepoOptions, repoPath)
+	if i < 0 {
+		return
+	}
+	s.RepoIndex = i
+	s.ScrollOffset = max(i-NewSessionMaxVisibleRepos+1, 0)
+}
+
 // GetBranchName returns the custom branch name
 func (s *NewSessionState) GetBranchName() string {
 	return s.BranchInput.Value()
@@ -622,6 +677,21 @@ type SessionSettingsState struct {
 	// Read-only info
 	Containerized bool
 
+	// Context files re-sent when a fresh CLI session starts (worktree-relative)
+	ContextFiles []string
+
+	// Where the streaming response is mirrored for external tools
+	MirrorPath string
+
+	// Per-session options (bound to the form's MultiSelect)
+	MirrorOutput     bool
+	AutoApprovePlans bool
+	sessionOptions   []string
+
+	// Per-turn activity history, oldest first (for the activity sparkline)
+	TurnOutputTokens []int
+	TurnDurationsMs  []int
+
 	// Bound form values
 	name string
 
@@ -650,6 +720,11 @@ type SessionSettingsState struct {
 	cachedLinearOptions []LinearTeamOption
 }
 
+const (
+	optionMirrorOutput     = "mirror-output"
+	optionAutoApprovePlans = "auto-approve-plans"
+)
+
 func (*SessionSettingsState) modalState() {}
 
 func (s *SessionSettingsState) PreferredWidth() int {
@@ -690,6 +765,22 @@ func (s *SessionSettingsState) Render() string {
 		containerLabel + lipgloss.NewStyle().Foreground(ColorSecondary).Render(containerValue),
 	)
 
+	contextLabel := lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Context files: ")
+	contextValue := lipgloss.NewStyle().Foreground(ColorTextMuted).Italic(true).Render("none (C to add)")
+	if len(s.ContextFiles) > 0 {
+		contextValue = lipgloss.NewStyle().Foreground(ColorSecondary).Render(strings.Join(s.ContextFiles, ", "))
+	}
+	contextLine := lipgloss.NewStyle().PaddingLeft(2).Width(ModalWidth - 4).Render(contextLabel + contextValue)
+
+	mirrorLabel := lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Mirror file: ")
+	mirrorValue := lipgloss.NewStyle().Foreground(ColorTextMuted).Italic(true).Render("off")
+	if s.MirrorOutput && s.MirrorPath != "" {
+		mirrorValue = lipgloss.NewStyle().Foreground(ColorSecondary).Render(s.MirrorPath)
+	}
+	mirrorLine := lipgloss.NewStyle().PaddingLeft(2).Width(ModalWidth - 4).Render(mirrorLabel + mirrorValue)
+
+	activityLines := s.renderActivity()
+
 	// Editable fields via huh form
 	editHeader := renderSectionHeader("Settings:")
 
@@ -701,9 +792,11 @@ func (s *SessionSettingsState) Render() string {
 		branchLine,
 		baseLine,
 		containerLine,
-		editHeader,
-		s.form.View(),
+		contextLine,
+		mirrorLine,
 	}
+	parts = append(parts, activityLines...)
+	parts = append(parts, editHeader, s.form.View())
 
 	// Repo settings section
 	repoHeader := renderSectionHeader("Repo Settings (" + s.RepoName + "):")
@@ -765,6 +858,8 @@ func (s *SessionSettingsState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 	s.form, cmd = huhFormUpdate(s.form, msg)
+	s.MirrorOutput = slices.Contains(s.sessionOptions, optionMirrorOutput)
+	s.AutoApprovePlans = slices.Contains(s.sessionOptions, optionAutoApprovePlans)
 	cmds = append(cmds, cmd)
 	if s.repoForm != nil {
 		s.repoForm, cmd = huhFormUpdate(s.repoForm, msg)
@@ -788,6 +883,94 @@ func (s *SessionSettingsState) GetLinearTeam() string {
 	return s.LinearSelectedTeamID
 }
 
+// SetMirrorOutput sets whether the response is mirrored to a file and where.
+// Must be called before the form is displayed, since it rebuilds the form.
+func (s *SessionSettingsState) SetMirrorOutput(enabled bool, path string) {
+	s.MirrorOutput = enabled
+	s.MirrorPath = path
+	s.buildForm()
+}
+
+// GetMirrorOutput returns whether mirroring the response to a file is selected.
+func (s *SessionSettingsState) GetMirrorOutput() bool {
+	return s.MirrorOutput
+}
+
+// SetAutoApprovePlans sets whether plans meeting the repo's criteria are approved
+// automatically. Must be called before the form is displayed, since it rebuilds the form.
+func (s *SessionSettingsState) SetAutoApprovePlans(enabled bool) {
+	s.AutoApprovePlans = enabled
+	s.buildForm()
+}
+
+// GetAutoApprovePlans returns whether plan auto-approval is selected.
+func (s *SessionSettingsState) GetAutoApprovePlans() bool {
+	return s.AutoApprovePlans
+}
+
+// SetContextFiles sets the context files shown in the info section.
+func (s *SessionSettingsState) SetContextFiles(files []string) {
+	s.ContextFiles = files
+}
+
+// SetTurnActivity sets the per-turn output tokens and durations (oldest first) shown as sparklines.
+func (s *SessionSettingsState) SetTurnActivity(outputTokens, durationsMs []int) {
+	s.TurnOutputTokens = outputTokens
+	s.TurnDurationsMs = durationsMs
+}
+
+// renderActivity renders the token and duration sparklines for recent turns.
+func (s *SessionSettingsState) renderActivity() []string {
+	label := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	spark := lipgloss.NewStyle().Foreground(ColorSecondary)
+	line := lipgloss.NewStyle().PaddingLeft(2)
+
+	if len(s.TurnOutputTokens) == 0 {
+		return []string{line.Render(label.Render("Activity: ") + label.Italic(true).Render("no completed turns yet"))}
+	}
+
+	width := SessionActivitySparklineWidth
+	shown := min(len(s.TurnOutputTokens), width)
+	lines := []string{
+		line.Render(label.Render("Tokens:   ") + spark.Render(RenderSparkline(s.TurnOutputTokens, width)) +
+			label.Render(fmt.Sprintf("  last %d turns, peak %s", shown, formatSparklineTokens(maxOf(s.TurnOutputTokens, width))))),
+	}
+	if len(s.TurnDurationsMs) > 0 {
+		lines = append(lines, line.Render(label.Render("Duration: ")+spark.Render(RenderSparkline(s.TurnDurationsMs, width))+
+			label.Render(fmt.Sprintf("  peak %s", formatSparklineDuration(maxOf(s.TurnDurationsMs, width))))))
+	}
+	return lines
+}
+
+// maxOf returns the largest of the last n values.
+func maxOf(values []int, n int) int {
+	if len(values) > n {
+		values = values[len(values)-n:]
+	}
+	result := 0
+	for _, v := range values {
+		result = max(result, v)
+	}
+	return result
+}
+
+// formatSparklineTokens formats a token count compactly (e.g., "342", "1.4k").
+func formatSparklineTokens(n int) string {
+	if n >= 1000 {
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// formatSparklineDuration formats milliseconds compactly (e.g., "12s", "1m30s").
+func formatSparklineDuration(ms int) string {
+	secs := ms / 1000
+	if secs < 60 {
+		return fmt.Sprintf("%ds", secs)
+	}
+	return fmt.Sprintf("%dm%ds", secs/60, secs%60)
+}
+
 // SetAsanaProjects populates the Asana project options and rebuilds the repo form.
 func (s *SessionSettingsState) SetAsanaProjects(options []AsanaProjectOption) {
 	s.AsanaLoading = false
@@ -896,19 +1079,42 @@ func NewSessionSettingsState(
 		LinearSelectedTeamID: linearTeamID,
 		LinearLoading:        linearAPIKeySet,
 	}
+	s.buildForm()
+	return s
+}
+
+// buildForm constructs the session form from the current field values.
+func (s *SessionSettingsState) buildForm() {
+	s.sessionOptions = nil
+	if s.MirrorOutput {
+		s.sessionOptions = append(s.sessionOptions, optionMirrorOutput)
+	}
+	if s.AutoApprovePlans {
+		s.sessionOptions = append(s.sessionOptions, optionAutoApprovePlans)
+	}
+	options := []huh.Option[string]{
+		huh.NewOption("Mirror response to a file", optionMirrorOutput).
+			Selected(s.MirrorOutput),
+		huh.NewOption("Auto-approve plans meeting repo criteria", optionAutoApprovePlans).
+			Selected(s.AutoApprovePlans),
+	}
 
 	s.form = huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
-				Title("Name").
-				Placeholder("enter session name").
+				Title("Branch").
+				Placeholder("enter branch name").
 				CharLimit(SessionNameCharLimit).
 				Value(&s.name),
+			huh.NewMultiSelect[string]().
+				Title("Options").
+				Options(options...).
+				Height(len(options)).
+				Value(&s.sessionOptions),
 		),
 	).WithTheme(ModalTheme()).
 		WithShowHelp(false).
 		WithWidth(ModalInputWidth)
 
 	initHuhForm(s.form)
-	return s
 }
@@ -0,0 +1,33 @@
+package modals
+
+import (
+	"path/filepath"
+
+	"charm.land/lipgloss/v2"
+)
+
+// ShowInitialCommitPrompt offers to make an empty first commit with the given
+// message in the selected repo, which has no commits to start a branch from.
+func (s *NewSessionState) ShowInitialCommitPrompt(message string) {
+	s.InitialCommitPrompt = true
+	s.InitialCommitMessage = message
+}
+
+// HideInitialCommitPrompt returns the modal to the form.
+func (s *NewSessionState) HideInitialCommitPrompt() {
+	s.InitialCommitPrompt = false
+}
+
+// renderInitialCommit renders the initial commit prompt in place of the form.
+func (s *NewSessionState) renderInitialCommit() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	messageStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+	repoName := filepath.Base(s.GetSelectedRepo())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Width(ModalInputWidth).Render(repoName+" has no commits yet, so there is nothing to branch a session from."),
+		mutedStyle.MarginTop(1).Render("Make an empty first commit on its current branch?"),
+		messageStyle.Render("  "+TruncateString(s.InitialCommitMessage, ModalInputWidth-2)),
+		mutedStyle.Italic(true).MarginTop(1).Render("Set initial_commit_message in the config to change the message."),
+	)
+}
@@ -0,0 +1,125 @@
+package modals
+
+import (
+	"path/filepath"
+
+	"charm.land/bubbles/v2/progress"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// LFS checkout choices offered in the New Session modal for repos using Git LFS.
+const (
+	LFSChoiceFull   = iota // Download LFS files while checking out
+	LFSChoiceSkip          // Leave LFS files as pointers
+	LFSChoiceCancel        // Go back to the form
+)
+
+// lfsChoiceOptions are the labels of the LFS choices, in LFSChoice order.
+var lfsChoiceOptions = []string{
+	"Full checkout (download LFS files)",
+	"Skip LFS files (GIT_LFS_SKIP_SMUDGE=1)",
+	"Cancel",
+}
+
+// lfsPointerNote explains what skipping the LFS smudge leaves in the worktree.
+const lfsPointerNote = "Binary assets will be LFS pointer files. Run 'git lfs pull' in the worktree to download them."
+
+// createProgressWidth is the width of the LFS download progress bar.
+const createProgressWidth = 40
+
+// ShowLFSPrompt asks how to check out the selected repo's LFS files.
+func (s *NewSessionState) ShowLFSPrompt() {
+	s.LFSPrompt = true
+	s.LFSIndex = LFSChoiceFull
+}
+
+// LFSChoice returns the selected LFS choice and hides the prompt.
+func (s *NewSessionState) LFSChoice() int {
+	s.LFSPrompt = false
+	return s.LFSIndex
+}
+
+// StartCreating switches the modal to showing creation progress.
+func (s *NewSessionState) StartCreating(skipLFS bool) {
+	s.Creating = true
+	s.CreateSkipsLFS = skipLFS
+	s.CreateProgress = ""
+	s.CreatePercent = -1
+}
+
+// SetCreateProgress shows a line of git's output. A percent of -1 keeps the
+// last reported download percentage.
+func (s *NewSessionState) SetCreateProgress(line string, percent int) {
+	s.CreateProgress = line
+	if percent >= 0 {
+		s.CreatePercent = percent
+	}
+}
+
+// StopCreating returns the modal to the form after creation fails or is cancelled.
+func (s *NewSessionState) StopCreating() {
+	s.Creating = false
+	s.CreateProgress = ""
+}
+
+// updateLFS handles keys while the LFS prompt or creation progress is shown.
+// Confirming and cancelling are handled by the app.
+func (s *NewSessionState) updateLFS(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok || !s.LFSPrompt {
+		return nil
+	}
+	switch keyMsg.String() {
+	case keys.Up, "k":
+		if s.LFSIndex > 0 {
+			s.LFSIndex--
+		}
+	case keys.Down, "j":
+		if s.LFSIndex < len(lfsChoiceOptions)-1 {
+			s.LFSIndex++
+		}
+	}
+	return nil
+}
+
+// renderLFS renders the LFS prompt or the creation progress in place of the form.
+func (s *NewSessionState) renderLFS() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	noteStyle := lipgloss.NewStyle().Foreground(ColorWarning).Italic(true).Width(ModalInputWidth)
+	repoName := filepath.Base(s.GetSelectedRepo())
+
+	if s.LFSPrompt {
+		parts := []string{
+			lipgloss.NewStyle().Width(ModalInputWidth).Render(repoName + " uses Git LFS. Checking out its LFS files may download a lot of data."),
+			mutedStyle.MarginTop(1).Render("LFS files:"),
+			RenderSelectableListWithFocus(lfsChoiceOptions, s.LFSIndex, true, "> "),
+		}
+		if s.LFSIndex == LFSChoiceSkip {
+			parts = append(parts, noteStyle.MarginTop(1).Render(lfsPointerNote))
+		}
+		parts = append(parts, mutedStyle.Italic(true).MarginTop(1).Render("Your choice is remembered for this repo."))
+		return lipgloss.JoinVertical(lipgloss.Left, parts...)
+	}
+
+	status := "Creating worktree for " + repoName + "..."
+	if s.CreateSkipsLFS {
+		status = "Creating worktree for " + repoName + " (skipping LFS files)..."
+	}
+	parts := []string{status}
+	if s.CreatePercent >= 0 {
+		bar := progress.New(
+			progress.WithColors(ColorPrimary, ColorSecondary),
+			progress.WithWidth(createProgressWidth),
+		)
+		parts = append(parts, bar.ViewAs(float64(s.CreatePercent)/100))
+	}
+	if s.CreateProgress != "" {
+		parts = append(parts, mutedStyle.Render(TruncateString(s.CreateProgress, ModalInputWidth)))
+	}
+	if s.CreateSkipsLFS {
+		parts = append(parts, noteStyle.MarginTop(1).Render(lfsPointerNote))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
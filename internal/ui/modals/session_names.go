@@ -0,0 +1,102 @@
+package modals
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// =============================================================================
+// RepairSessionNamesState - State for the Repair Session Names modal
+// =============================================================================
+
+// SessionNameRepair is a session whose name or branch has characters git or
+// the terminal can't cope with, and what they would be renamed to.
+type SessionNameRepair struct {
+	SessionID string
+	OldName   string
+	NewName   string
+	OldBranch string
+	NewBranch string
+}
+
+// RepairSessionNamesState is shown at startup when sessions saved earlier have
+// names with control characters or branches git refuses, offering to rename
+// them.
+type RepairSessionNamesState struct {
+	Repairs       []SessionNameRepair
+	Options       []string
+	SelectedIndex int
+}
+
+// maxRepairsShown is how many of the sessions to rename the modal lists.
+const maxRepairsShown = 8
+
+func (*RepairSessionNamesState) modalState() {}
+
+func (s *RepairSessionNamesState) Title() string { return "Repair Session Names" }
+
+func (s *RepairSessionNamesState) Help() string {
+	return "up/down to select, Enter to confirm, Esc to skip"
+}
+
+func (s *RepairSessionNamesState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	message := lipgloss.NewStyle().
+		Foreground(ColorText).
+		Width(ModalWidth - 4).
+		MarginBottom(1).
+		Render(fmt.Sprintf("%d session(s) have names git or the terminal can't use, so their branches may not push or the sessions may not delete cleanly. Rename them as below?", len(s.Repairs)))
+
+	oldStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	newStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+	var lines []string
+	for i, r := range s.Repairs {
+		if i == maxRepairsShown {
+			lines = append(lines, oldStyle.Render(fmt.Sprintf("…and %d more", len(s.Repairs)-i)))
+			break
+		}
+		// Quoted, so that control characters show rather than act
+		lines = append(lines, oldStyle.Render(strconv.Quote(r.OldBranch))+" → "+newStyle.Render(r.NewBranch))
+	}
+	list := lipgloss.NewStyle().MarginBottom(1).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	optionList := RenderSelectableList(s.Options, s.SelectedIndex)
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, message, list, optionList, help)
+}
+
+func (s *RepairSessionNamesState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case keys.Down, "j":
+			if s.SelectedIndex < len(s.Options)-1 {
+				s.SelectedIndex++
+			}
+		}
+	}
+	return s, nil
+}
+
+// ShouldRename returns true if the user chose to rename the sessions
+func (s *RepairSessionNamesState) ShouldRename() bool {
+	return s.SelectedIndex == 0
+}
+
+// NewRepairSessionNamesState creates a new RepairSessionNamesState
+func NewRepairSessionNamesState(repairs []SessionNameRepair) *RepairSessionNamesState {
+	return &RepairSessionNamesState{
+		Repairs: repairs,
+		Options: []string{"Rename them", "Not now"},
+	}
+}
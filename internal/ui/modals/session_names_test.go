@@ -0,0 +1,41 @@
+package modals
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRepairSessionNamesState(t *testing.T) {
+	var repairs []SessionNameRepair
+	for i := range maxRepairsShown + 2 {
+		repairs = append(repairs, SessionNameRepair{
+			SessionID: fmt.Sprintf("s%d", i),
+			OldBranch: fmt.Sprintf("fix\x1b[31m%d", i),
+			NewBranch: fmt.Sprintf("fix-%d", i),
+		})
+	}
+	state := NewRepairSessionNamesState(repairs)
+
+	rendered := state.Render()
+	if strings.Contains(rendered, "\x1b[31m") {
+		t.Error("expected the old names' control characters quoted, not printed")
+	}
+	rendered = ansi.Strip(rendered)
+	for _, want := range []string{"Repair Session Names", `"fix\x1b[31m0" → fix-0`, "…and 2 more", "Rename them"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %q in:\n%s", want, rendered)
+		}
+	}
+
+	if !state.ShouldRename() {
+		t.Error("expected renaming selected by default")
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.ShouldRename() {
+		t.Error("expected skipping selected after down")
+	}
+}
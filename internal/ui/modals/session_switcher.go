@@ -0,0 +1,164 @@
+package modals
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// =============================================================================
+// SessionSwitcherState - State for the quick session switcher
+// =============================================================================
+
+// SessionSwitcherMaxVisible is the maximum number of sessions visible before scrolling
+const SessionSwitcherMaxVisible = 8
+
+// SessionSwitcherItem is a session listed in the session switcher.
+type SessionSwitcherItem struct {
+	ID   string
+	Name string // Display name (branch or custom name)
+	Repo string // Repository name, shown after the session name
+}
+
+// SessionSwitcherState lets the user jump to a session by typing part of its name.
+// It replaces the sidebar for switching sessions in the inline layout.
+type SessionSwitcherState struct {
+	Items         []SessionSwitcherItem
+	Input         textinput.Model
+	Matches       []SessionSwitcherItem // Items matching the filter, in order
+	SelectedIndex int
+	ScrollOffset  int
+	maxVisible    int
+}
+
+func (*SessionSwitcherState) modalState() {}
+
+func (s *SessionSwitcherState) Title() string { return "Switch Session" }
+
+func (s *SessionSwitcherState) Help() string {
+	return "Type to filter  up/down: navigate  Enter: switch  Esc: cancel"
+}
+
+func (s *SessionSwitcherState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	inputStyle := lipgloss.NewStyle().
+		BorderLeft(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(ColorPrimary).
+		PaddingLeft(1).
+		MarginBottom(1)
+	inputView := inputStyle.Render(s.Input.View())
+
+	var list string
+	if len(s.Matches) == 0 {
+		list = lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			Italic(true).
+			Render("No matching sessions")
+	} else {
+		visibleEnd := min(s.ScrollOffset+s.maxVisible, len(s.Matches))
+		repoStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+		var labels []string
+		for _, item := range s.Matches[s.ScrollOffset:visibleEnd] {
+			label := item.Name
+			if item.Repo != "" {
+				label += " " + repoStyle.Render("("+item.Repo+")")
+			}
+			labels = append(labels, label)
+		}
+		list = strings.TrimSuffix(RenderSelectableList(labels, s.SelectedIndex-s.ScrollOffset), "\n")
+		if s.ScrollOffset > 0 {
+			list = repoStyle.Render("  ↑ more above") + "\n" + list
+		}
+		if visibleEnd < len(s.Matches) {
+			list += "\n" + repoStyle.Render("  ↓ more below")
+		}
+	}
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, inputView, list, help)
+}
+
+func (s *SessionSwitcherState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, keys.CtrlP:
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+				if s.SelectedIndex < s.ScrollOffset {
+					s.ScrollOffset = s.SelectedIndex
+				}
+			}
+			return s, nil
+		case keys.Down, keys.CtrlN:
+			if s.SelectedIndex < len(s.Matches)-1 {
+				s.SelectedIndex++
+				if s.SelectedIndex >= s.ScrollOffset+s.maxVisible {
+					s.ScrollOffset = s.SelectedIndex - s.maxVisible + 1
+				}
+			}
+			return s, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	oldQuery := s.Input.Value()
+	s.Input, cmd = s.Input.Update(msg)
+	if s.Input.Value() != oldQuery {
+		s.filter()
+	}
+	return s, cmd
+}
+
+// filter narrows the list to sessions whose name or repo contains the query
+// (case-insensitive) and selects the first match.
+func (s *SessionSwitcherState) filter() {
+	query := strings.ToLower(strings.TrimSpace(s.Input.Value()))
+	s.Matches = nil
+	for _, item := range s.Items {
+		if query == "" || strings.Contains(strings.ToLower(item.Name), query) || strings.Contains(strings.ToLower(item.Repo), query) {
+			s.Matches = append(s.Matches, item)
+		}
+	}
+	s.SelectedIndex = 0
+	s.ScrollOffset = 0
+}
+
+// GetSelected returns the selected session, or nil if nothing matches.
+func (s *SessionSwitcherState) GetSelected() *SessionSwitcherItem {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Matches) {
+		return nil
+	}
+	return &s.Matches[s.SelectedIndex]
+}
+
+// NewSessionSwitcherState creates a new SessionSwitcherState listing items, with
+// the session currentID (if listed) selected.
+func NewSessionSwitcherState(items []SessionSwitcherItem, currentID string) *SessionSwitcherState {
+	input := textinput.New()
+	input.Placeholder = "Type to filter sessions..."
+	input.CharLimit = SearchInputCharLimit
+	input.SetWidth(ModalInputWidth)
+	input.Focus()
+
+	s := &SessionSwitcherState{
+		Items:      items,
+		Input:      input,
+		maxVisible: SessionSwitcherMaxVisible,
+	}
+	s.filter()
+	for i, item := range s.Matches {
+		if item.ID == currentID {
+			s.SelectedIndex = i
+			s.ScrollOffset = max(i-s.maxVisible+1, 0)
+			break
+		}
+	}
+	return s
+}
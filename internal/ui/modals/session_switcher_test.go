@@ -0,0 +1,65 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// =============================================================================
+// SessionSwitcherState Tests
+// =============================================================================
+
+func testSwitcherItems() []SessionSwitcherItem {
+	return []SessionSwitcherItem{
+		{ID: "s1", Name: "feature-login", Repo: "api"},
+		{ID: "s2", Name: "fix-crash", Repo: "api"},
+		{ID: "s3", Name: "docs", Repo: "website"},
+	}
+}
+
+func TestSessionSwitcherState_SelectsCurrentSession(t *testing.T) {
+	state := NewSessionSwitcherState(testSwitcherItems(), "s2")
+
+	if len(state.Matches) != 3 {
+		t.Fatalf("expected all sessions listed, got %d", len(state.Matches))
+	}
+	if sel := state.GetSelected(); sel == nil || sel.ID != "s2" {
+		t.Errorf("expected current session selected, got %v", sel)
+	}
+
+	rendered := state.Render()
+	if !strings.Contains(rendered, "feature-login") || !strings.Contains(rendered, "(website)") {
+		t.Errorf("expected sessions and repos in render, got:\n%s", rendered)
+	}
+}
+
+func TestSessionSwitcherState_Filter(t *testing.T) {
+	state := NewSessionSwitcherState(testSwitcherItems(), "")
+
+	for _, ch := range "WEB" {
+		state.Update(tea.KeyPressMsg{Code: ch, Text: string(ch)})
+	}
+	if len(state.Matches) != 1 || state.Matches[0].ID != "s3" {
+		t.Fatalf("expected repo match s3 (case-insensitive), got %v", state.Matches)
+	}
+
+	state.Input.SetValue("")
+	state.Update(tea.KeyPressMsg{Code: 'f', Text: "f"})
+	if len(state.Matches) != 2 {
+		t.Fatalf("expected 2 matches for 'f', got %v", state.Matches)
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if sel := state.GetSelected(); sel == nil || sel.ID != "s2" {
+		t.Errorf("expected down to select s2, got %v", sel)
+	}
+
+	state.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if state.GetSelected() != nil {
+		t.Error("expected no selection when nothing matches")
+	}
+	if !strings.Contains(state.Render(), "No matching sessions") {
+		t.Error("expected empty-state message")
+	}
+}
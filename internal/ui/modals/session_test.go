@@ -359,7 +359,7 @@ func TestSessionSettingsState_Render(t *testing.T) {
 	rendered := state.Render()
 
 	// Check info section and form structure
-	checks := []string{"Session Settings", "feature-branch", "main", "yes", "Name"}
+	checks := []string{"Session Settings", "feature-branch", "main", "yes", "Branch"}
 	for _, check := range checks {
 		if !strings.Contains(rendered, check) {
 			t.Errorf("expected render to contain %q\nFull render:\n%s", check, rendered)
@@ -367,6 +367,36 @@ func TestSessionSettingsState_Render(t *testing.T) {
 	}
 }
 
+func TestSessionSettingsState_Render_Activity(t *testing.T) {
+	state := NewSessionSettingsState("s1", "my-session", "feature-branch", "main", false, "/repo", false, "", false, "")
+
+	if rendered := state.Render(); !strings.Contains(rendered, "no completed turns yet") {
+		t.Errorf("expected empty activity hint\nFull render:\n%s", rendered)
+	}
+
+	state.SetTurnActivity([]int{100, 1500, 800}, []int{2000, 95000, 10000})
+	rendered := state.Render()
+	checks := []string{"Tokens:", "▁█▄", "peak 1.5k", "Duration:", "peak 1m35s", "last 3 turns"}
+	for _, check := range checks {
+		if !strings.Contains(rendered, check) {
+			t.Errorf("expected render to contain %q\nFull render:\n%s", check, rendered)
+		}
+	}
+}
+
+func TestSessionSettingsState_Render_ContextFiles(t *testing.T) {
+	state := NewSessionSettingsState("s1", "my-session", "feature-branch", "main", false, "/repo", false, "", false, "")
+
+	if rendered := state.Render(); !strings.Contains(rendered, "none (C to add)") {
+		t.Errorf("expected hint to add context files\nFull render:\n%s", rendered)
+	}
+
+	state.SetContextFiles([]string{"docs/ARCHITECTURE.md", "api.yaml"})
+	if rendered := state.Render(); !strings.Contains(rendered, "docs/ARCHITECTURE.md, api.yaml") {
+		t.Errorf("expected context files listed\nFull render:\n%s", rendered)
+	}
+}
+
 func TestSessionSettingsState_Help(t *testing.T) {
 	state := NewSessionSettingsState("s1", "my-session", "feature-branch", "main", false, "/repo", false, "", false, "")
 
@@ -542,3 +572,56 @@ func TestSessionSettingsState_Render_BothProviders(t *testing.T) {
 		t.Error("should show Linear team section")
 	}
 }
+
+func TestNewSessionState_LFSPrompt(t *testing.T) {
+	state := NewNewSessionState([]string{"/path/to/assets"}, false, false)
+	state.ShowLFSPrompt()
+
+	render := state.Render()
+	if !strings.Contains(render, "assets uses Git LFS") || strings.Contains(render, "Branch name:") {
+		t.Errorf("expected the LFS choice in place of the form, got:\n%s", render)
+	}
+	if strings.Contains(render, "pointer files") {
+		t.Error("the pointer note should only show for the skip choice")
+	}
+
+	state.Update(tea.KeyPressMsg{Code: -1, Text: keys.Down})
+	if !strings.Contains(state.Render(), "pointer files") {
+		t.Error("expected the pointer note when skipping LFS files")
+	}
+	for range 3 {
+		state.Update(tea.KeyPressMsg{Code: -1, Text: keys.Down})
+	}
+	if choice := state.LFSChoice(); choice != LFSChoiceCancel {
+		t.Errorf("expected navigation to stop at Cancel, got %d", choice)
+	}
+	if state.LFSPrompt {
+		t.Error("expected LFSChoice to hide the prompt")
+	}
+}
+
+func TestNewSessionState_CreateProgress(t *testing.T) {
+	state := NewNewSessionState([]string{"/path/to/assets"}, false, false)
+	state.StartCreating(false)
+
+	state.SetCreateProgress("Filtering content:  45% (9/20)", 45)
+	state.SetCreateProgress("Updating files: done", -1)
+	if state.CreatePercent != 45 {
+		t.Errorf("expected a line without progress to keep 45%%, got %d", state.CreatePercent)
+	}
+	render := state.Render()
+	if !strings.Contains(render, "Updating files: done") || !strings.Contains(render, "Esc: cancel") {
+		t.Errorf("expected the latest output and the cancel key, got:\n%s", render)
+	}
+
+	// Keys other than those the app handles do nothing while creating
+	state.Update(tea.KeyPressMsg{Code: -1, Text: keys.Tab})
+	if state.Focus != 0 {
+		t.Errorf("expected focus to stay put while creating, got %d", state.Focus)
+	}
+
+	state.StopCreating()
+	if !strings.Contains(state.Render(), "Branch name:") {
+		t.Error("expected the form after creation stops")
+	}
+}
@@ -0,0 +1,133 @@
+package modals
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// =============================================================================
+// SnapshotPickerState - Choose two snapshots of a session to diff
+// =============================================================================
+
+// SnapshotPickerItem is a snapshot listed in the snapshot picker. The picker adds
+// a final "Now" item with an empty Ref for the current worktree.
+type SnapshotPickerItem struct {
+	Number    int
+	Ref       string
+	CreatedAt time.Time
+}
+
+// Label returns the item as shown in the picker.
+func (i SnapshotPickerItem) Label() string {
+	if i.Ref == "" {
+		return "Now (current worktree)"
+	}
+	return fmt.Sprintf("Snapshot %d", i.Number)
+}
+
+// SnapshotPickerState lists a session's snapshots. The user marks one with Enter,
+// then picks a second (or "Now") to compare it with.
+type SnapshotPickerState struct {
+	SessionName   string
+	Items         []SnapshotPickerItem // Snapshots oldest first, then "Now"
+	SelectedIndex int
+	MarkedIndex   int // First choice, or -1
+}
+
+func (*SnapshotPickerState) modalState() {}
+
+func (s *SnapshotPickerState) Title() string { return "Compare Snapshots" }
+
+func (s *SnapshotPickerState) Help() string {
+	if s.MarkedIndex < 0 {
+		return "up/down: navigate  Enter: choose first  Esc: cancel"
+	}
+	return "up/down: navigate  Enter: compare with marked  Esc: cancel"
+}
+
+func (s *SnapshotPickerState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	description := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Width(ModalWidth - 4).
+		MarginBottom(1).
+		Render("Choose two points in " + s.SessionName + " to see what changed between them.")
+
+	timeStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	markStyle := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+	labels := make([]string, len(s.Items))
+	for i, item := range s.Items {
+		mark := "  "
+		if i == s.MarkedIndex {
+			mark = markStyle.Render("* ")
+		}
+		label := mark + item.Label()
+		if item.Ref != "" {
+			label += "  " + timeStyle.Render(item.CreatedAt.Format("Jan 02 15:04:05"))
+		}
+		labels[i] = label
+	}
+	list := strings.TrimSuffix(RenderSelectableList(labels, s.SelectedIndex), "\n")
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, description, list, help)
+}
+
+func (s *SnapshotPickerState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case keys.Down, "j":
+			if s.SelectedIndex < len(s.Items)-1 {
+				s.SelectedIndex++
+			}
+		}
+	}
+	return s, nil
+}
+
+// Choose marks the selected item, or unmarks it if it is already marked.
+// Returns true once two different items are chosen; GetRange then returns them.
+func (s *SnapshotPickerState) Choose() bool {
+	switch s.MarkedIndex {
+	case -1:
+		s.MarkedIndex = s.SelectedIndex
+		return false
+	case s.SelectedIndex:
+		s.MarkedIndex = -1
+		return false
+	}
+	return true
+}
+
+// GetRange returns the chosen items, older first. "Now" is always last.
+func (s *SnapshotPickerState) GetRange() (from, to SnapshotPickerItem) {
+	a, b := s.MarkedIndex, s.SelectedIndex
+	if a > b {
+		a, b = b, a
+	}
+	return s.Items[a], s.Items[b]
+}
+
+// NewSnapshotPickerState creates a new SnapshotPickerState for a session's
+// snapshots (oldest first), with the latest snapshot selected.
+func NewSnapshotPickerState(sessionName string, snapshots []SnapshotPickerItem) *SnapshotPickerState {
+	items := append(append([]SnapshotPickerItem(nil), snapshots...), SnapshotPickerItem{})
+	return &SnapshotPickerState{
+		SessionName:   sessionName,
+		Items:         items,
+		SelectedIndex: max(len(snapshots)-1, 0),
+		MarkedIndex:   -1,
+	}
+}
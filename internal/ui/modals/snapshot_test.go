@@ -0,0 +1,43 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotPickerState_ChooseRange(t *testing.T) {
+	created := time.Date(2026, 3, 4, 15, 4, 5, 0, time.UTC)
+	state := NewSnapshotPickerState("feature-login", []SnapshotPickerItem{
+		{Number: 1, Ref: "refs/plural/snapshot/s1/1", CreatedAt: created},
+		{Number: 2, Ref: "refs/plural/snapshot/s1/2", CreatedAt: created},
+	})
+
+	if len(state.Items) != 3 || state.SelectedIndex != 1 {
+		t.Fatalf("expected two snapshots plus Now with the latest selected, got %+v (selected %d)", state.Items, state.SelectedIndex)
+	}
+	rendered := state.Render()
+	if !strings.Contains(rendered, "Snapshot 2") || !strings.Contains(rendered, "Mar 04 15:04:05") || !strings.Contains(rendered, "Now") {
+		t.Errorf("expected snapshots, timestamps and Now in render, got:\n%s", rendered)
+	}
+
+	// Choosing the same item twice unmarks it
+	if state.Choose() || state.MarkedIndex != 1 {
+		t.Fatal("expected the first choice to mark the item")
+	}
+	if state.Choose() || state.MarkedIndex != -1 {
+		t.Fatal("expected choosing the marked item to unmark it")
+	}
+
+	// Marking a later item first still yields an older-first range
+	state.SelectedIndex = 2
+	state.Choose()
+	state.SelectedIndex = 0
+	if !state.Choose() {
+		t.Fatal("expected the second choice to complete the range")
+	}
+	from, to := state.GetRange()
+	if from.Number != 1 || to.Ref != "" {
+		t.Errorf("GetRange() = %+v, %+v; want snapshot 1 to Now", from, to)
+	}
+}
@@ -0,0 +1,448 @@
+package modals
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/textarea"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// SnippetPickerMaxVisible is the maximum number of snippets visible before scrolling
+const SnippetPickerMaxVisible = 8
+
+// SnippetItem is a saved prompt snippet.
+type SnippetItem struct {
+	Name string
+	Text string
+}
+
+// preview returns the first line of the snippet text, shortened to fit the modal.
+func (i SnippetItem) preview() string {
+	line, _, more := strings.Cut(i.Text, "\n")
+	limit := max(ModalWidth-len(i.Name)-10, 10)
+	if r := []rune(line); len(r) > limit {
+		return string(r[:limit-1]) + "…"
+	}
+	if more {
+		return line + " …"
+	}
+	return line
+}
+
+// fuzzyMatch reports whether the runes of query appear in s in order (case-insensitive).
+func fuzzyMatch(query, s string) bool {
+	s = strings.ToLower(s)
+	for _, r := range strings.ToLower(query) {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+// =============================================================================
+// SnippetPickerState - Choose a snippet to insert into the chat input
+// =============================================================================
+
+// SnippetPickerState lists the saved snippets, filtered by a fuzzy match on their
+// names as the user types. Selection holds the conversation text that was selected
+// when the picker opened, for the {selection} placeholder.
+type SnippetPickerState struct {
+	Items         []SnippetItem
+	Input         textinput.Model
+	Matches       []SnippetItem // Items matching the filter, in order
+	SelectedIndex int
+	ScrollOffset  int
+	Selection     string
+}
+
+func (*SnippetPickerState) modalState() {}
+
+func (s *SnippetPickerState) Title() string { return "Insert Snippet" }
+
+func (s *SnippetPickerState) Help() string {
+	return "Type to filter  up/down: navigate  Enter: insert  ctrl-e: manage  Esc: cancel"
+}
+
+func (s *SnippetPickerState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	inputStyle := lipgloss.NewStyle().
+		BorderLeft(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(ColorPrimary).
+		PaddingLeft(1).
+		MarginBottom(1)
+	inputView := inputStyle.Render(s.Input.View())
+
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	var list string
+	switch {
+	case len(s.Items) == 0:
+		list = mutedStyle.Italic(true).Render("No snippets yet. Press ctrl-e to add one.")
+	case len(s.Matches) == 0:
+		list = mutedStyle.Italic(true).Render("No matching snippets")
+	default:
+		visibleEnd := min(s.ScrollOffset+SnippetPickerMaxVisible, len(s.Matches))
+		var labels []string
+		for _, item := range s.Matches[s.ScrollOffset:visibleEnd] {
+			labels = append(labels, item.Name+"  "+mutedStyle.Render(item.preview()))
+		}
+		list = strings.TrimSuffix(RenderSelectableList(labels, s.SelectedIndex-s.ScrollOffset), "\n")
+		if s.ScrollOffset > 0 {
+			list = mutedStyle.Render("  ↑ more above") + "\n" + list
+		}
+		if visibleEnd < len(s.Matches) {
+			list += "\n" + mutedStyle.Render("  ↓ more below")
+		}
+	}
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, inputView, list, help)
+}
+
+func (s *SnippetPickerState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, keys.CtrlP:
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+				if s.SelectedIndex < s.ScrollOffset {
+					s.ScrollOffset = s.SelectedIndex
+				}
+			}
+			return s, nil
+		case keys.Down, keys.CtrlN:
+			if s.SelectedIndex < len(s.Matches)-1 {
+				s.SelectedIndex++
+				if s.SelectedIndex >= s.ScrollOffset+SnippetPickerMaxVisible {
+					s.ScrollOffset = s.SelectedIndex - SnippetPickerMaxVisible + 1
+				}
+			}
+			return s, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	oldQuery := s.Input.Value()
+	s.Input, cmd = s.Input.Update(msg)
+	if s.Input.Value() != oldQuery {
+		s.filter()
+	}
+	return s, cmd
+}
+
+// filter narrows the list to snippets whose name fuzzy-matches the query and
+// selects the first match.
+func (s *SnippetPickerState) filter() {
+	query := strings.TrimSpace(s.Input.Value())
+	s.Matches = nil
+	for _, item := range s.Items {
+		if fuzzyMatch(query, item.Name) {
+			s.Matches = append(s.Matches, item)
+		}
+	}
+	s.SelectedIndex = 0
+	s.ScrollOffset = 0
+}
+
+// GetSelected returns the selected snippet, or nil if nothing matches.
+func (s *SnippetPickerState) GetSelected() *SnippetItem {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Matches) {
+		return nil
+	}
+	return &s.Matches[s.SelectedIndex]
+}
+
+// NewSnippetPickerState creates a new SnippetPickerState listing items.
+// selection is the conversation text selected when the picker was opened.
+func NewSnippetPickerState(items []SnippetItem, selection string) *SnippetPickerState {
+	input := textinput.New()
+	input.Placeholder = "Type to filter snippets..."
+	input.CharLimit = SearchInputCharLimit
+	input.SetWidth(ModalInputWidth)
+	input.Focus()
+
+	s := &SnippetPickerState{
+		Items:     items,
+		Input:     input,
+		Selection: selection,
+	}
+	s.filter()
+	return s
+}
+
+// =============================================================================
+// SnippetFileState - Prompt for the path a snippet's {file} placeholder expands to
+// =============================================================================
+
+// SnippetFileState asks for the path to insert in place of {file} in a snippet.
+type SnippetFileState struct {
+	Snippet   SnippetItem
+	Selection string // Conversation text selected when the picker was opened
+	Input     textinput.Model
+}
+
+func (*SnippetFileState) modalState() {}
+
+func (s *SnippetFileState) Title() string { return "Insert Snippet" }
+
+func (s *SnippetFileState) Help() string {
+	return "Enter: insert  Esc: cancel"
+}
+
+func (s *SnippetFileState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	description := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Width(ModalWidth - 4).
+		MarginBottom(1).
+		Render("File for {file} in " + s.Snippet.Name + ":")
+
+	inputStyle := lipgloss.NewStyle().
+		BorderLeft(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(ColorPrimary).
+		PaddingLeft(1)
+	inputView := inputStyle.Render(s.Input.View())
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, description, inputView, help)
+}
+
+func (s *SnippetFileState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	var cmd tea.Cmd
+	s.Input, cmd = s.Input.Update(msg)
+	return s, cmd
+}
+
+// GetPath returns the path typed into the input.
+func (s *SnippetFileState) GetPath() string {
+	return strings.TrimSpace(s.Input.Value())
+}
+
+// NewSnippetFileState creates a new SnippetFileState for a snippet with a {file} placeholder.
+func NewSnippetFileState(snippet SnippetItem, selection string) *SnippetFileState {
+	input := textinput.New()
+	input.Placeholder = "internal/app/app.go"
+	input.CharLimit = ModalInputCharLimit
+	input.SetWidth(ModalInputWidth)
+	input.Focus()
+
+	return &SnippetFileState{
+		Snippet:   snippet,
+		Selection: selection,
+		Input:     input,
+	}
+}
+
+// =============================================================================
+// SnippetsState - List, add, edit and delete snippets
+// =============================================================================
+
+// SnippetsState manages the saved snippets. The list is browsed first; a snippet
+// opens in an editor with a name input and a text area. The app saves edits with
+// ctrl-s and deletes the selected snippet with ctrl-d.
+type SnippetsState struct {
+	Items         []SnippetItem
+	SelectedIndex int
+
+	Editing      bool
+	EditingIndex int // Index of the snippet being edited, or -1 for a new one
+	NameInput    textinput.Model
+	TextInput    textarea.Model
+	Focus        int    // 0=name, 1=text
+	Error        string // Validation error for the last save
+}
+
+func (*SnippetsState) modalState() {}
+
+func (s *SnippetsState) Title() string {
+	switch {
+	case !s.Editing:
+		return "Snippets"
+	case s.EditingIndex < 0:
+		return "New Snippet"
+	default:
+		return "Edit Snippet"
+	}
+}
+
+func (s *SnippetsState) Help() string {
+	if s.Editing {
+		return "Tab: switch field  ctrl-s: save  Esc: back"
+	}
+	if len(s.Items) == 0 {
+		return "n: new  Esc: close"
+	}
+	return "up/down: select  Enter: edit  n: new  ctrl-d: delete  Esc: close"
+}
+
+func (s *SnippetsState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+
+	var parts []string
+	if s.Editing {
+		focused := lipgloss.NewStyle().BorderLeft(true).BorderStyle(lipgloss.NormalBorder()).BorderForeground(ColorPrimary).PaddingLeft(1)
+		unfocused := lipgloss.NewStyle().PaddingLeft(2)
+		nameStyle, textStyle := focused, unfocused
+		if s.Focus == 1 {
+			nameStyle, textStyle = unfocused, focused
+		}
+		parts = []string{
+			title,
+			mutedStyle.Render("Name (type ;;name in the chat input to find it):"),
+			nameStyle.Render(s.NameInput.View()),
+			mutedStyle.MarginTop(1).Render("Text ({selection} and {file} are filled in on insert):"),
+			textStyle.Render(s.TextInput.View()),
+		}
+	} else {
+		var list string
+		if len(s.Items) == 0 {
+			list = mutedStyle.Italic(true).Render("No snippets yet")
+		} else {
+			labels := make([]string, len(s.Items))
+			for i, item := range s.Items {
+				labels[i] = item.Name + "  " + mutedStyle.Render(item.preview())
+			}
+			list = strings.TrimSuffix(RenderSelectableList(labels, s.SelectedIndex), "\n")
+		}
+		parts = []string{title, list}
+	}
+
+	if s.Error != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(ColorWarning).Render(s.Error))
+	}
+	parts = append(parts, ModalHelpStyle.Render(s.Help()))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+func (s *SnippetsState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	keyMsg, isKey := msg.(tea.KeyPressMsg)
+
+	if !s.Editing {
+		if !isKey {
+			return s, nil
+		}
+		switch keyMsg.String() {
+		case keys.Up, "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case keys.Down, "j":
+			if s.SelectedIndex < len(s.Items)-1 {
+				s.SelectedIndex++
+			}
+		case keys.Enter, "e":
+			if s.SelectedIndex < len(s.Items) {
+				s.startEdit(s.SelectedIndex)
+			}
+		case "n":
+			s.startEdit(-1)
+		}
+		return s, nil
+	}
+
+	if isKey {
+		switch keyMsg.String() {
+		case keys.Tab, keys.ShiftTab:
+			if s.Focus == 0 {
+				s.Focus = 1
+				s.NameInput.Blur()
+				return s, s.TextInput.Focus()
+			}
+			s.Focus = 0
+			s.TextInput.Blur()
+			return s, s.NameInput.Focus()
+		}
+	}
+
+	var cmd tea.Cmd
+	if s.Focus == 0 {
+		s.NameInput, cmd = s.NameInput.Update(msg)
+	} else {
+		s.TextInput, cmd = s.TextInput.Update(msg)
+	}
+	return s, cmd
+}
+
+// startEdit opens the editor on the snippet at index, or on a new snippet if index is -1.
+func (s *SnippetsState) startEdit(index int) {
+	s.Editing = true
+	s.EditingIndex = index
+	s.Focus = 0
+	s.Error = ""
+	s.NameInput.SetValue("")
+	s.TextInput.SetValue("")
+	if index >= 0 {
+		s.NameInput.SetValue(s.Items[index].Name)
+		s.TextInput.SetValue(s.Items[index].Text)
+	}
+	s.TextInput.Blur()
+	s.NameInput.Focus()
+}
+
+// CancelEdit closes the editor without saving and returns to the list.
+func (s *SnippetsState) CancelEdit() {
+	s.Editing = false
+	s.Error = ""
+}
+
+// GetEdited returns the snippet as entered in the editor.
+func (s *SnippetsState) GetEdited() SnippetItem {
+	return SnippetItem{
+		Name: strings.TrimSpace(s.NameInput.Value()),
+		Text: s.TextInput.Value(),
+	}
+}
+
+// SelectedSnippet returns the selected snippet, or nil when there are none.
+func (s *SnippetsState) SelectedSnippet() *SnippetItem {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Items) {
+		return nil
+	}
+	return &s.Items[s.SelectedIndex]
+}
+
+// SetItems replaces the listed snippets after a save or delete, closing the
+// editor and keeping the selection in range.
+func (s *SnippetsState) SetItems(items []SnippetItem, selected int) {
+	s.Items = items
+	s.SelectedIndex = max(min(selected, len(items)-1), 0)
+	s.Editing = false
+	s.Error = ""
+}
+
+// NewSnippetsState creates a new SnippetsState listing items.
+func NewSnippetsState(items []SnippetItem) *SnippetsState {
+	nameInput := textinput.New()
+	nameInput.Placeholder = "tests"
+	nameInput.CharLimit = 40
+	nameInput.SetWidth(ModalInputWidth)
+
+	textInput := textarea.New()
+	textInput.Placeholder = "Write table-driven tests for {selection}"
+	textInput.CharLimit = 10000
+	textInput.ShowLineNumbers = false
+	textInput.SetWidth(ModalWidth - 6)
+	textInput.SetHeight(6)
+	textInput.Prompt = ""
+	ApplyTextareaStyles(&textInput)
+
+	return &SnippetsState{
+		Items:        items,
+		EditingIndex: -1,
+		NameInput:    nameInput,
+		TextInput:    textInput,
+	}
+}
@@ -0,0 +1,105 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func typeInto(state ModalState, text string) {
+	for _, r := range text {
+		state.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, s string
+		want     bool
+	}{
+		{"", "tests", true},
+		{"tst", "tests", true},
+		{"TDT", "table-driven-tests", true},
+		{"tts", "tests", true},
+		{"sett", "tests", false},
+		{"testsx", "tests", false},
+	}
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.query, tt.s); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.query, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSnippetPickerState_Filter(t *testing.T) {
+	items := []SnippetItem{
+		{Name: "table-tests", Text: "Write table-driven tests for this"},
+		{Name: "tradeoffs", Text: "Explain the tradeoffs before coding\nThen wait"},
+	}
+	state := NewSnippetPickerState(items, "selected text")
+	if len(state.Matches) != 2 || state.Selection != "selected text" {
+		t.Fatalf("expected all snippets listed, got %+v", state.Matches)
+	}
+	rendered := state.Render()
+	for _, want := range []string{"Insert Snippet", "table-tests", "Write table-driven tests", "Explain the tradeoffs before coding …"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected render to contain %q\nFull render:\n%s", want, rendered)
+		}
+	}
+
+	state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if state.GetSelected().Name != "tradeoffs" {
+		t.Errorf("expected tradeoffs selected, got %+v", state.GetSelected())
+	}
+
+	typeInto(state, "tt")
+	if len(state.Matches) != 1 || state.GetSelected().Name != "table-tests" {
+		t.Errorf("expected only table-tests to match \"tt\", got %+v", state.Matches)
+	}
+
+	typeInto(state, "zz")
+	if state.GetSelected() != nil || !strings.Contains(state.Render(), "No matching snippets") {
+		t.Error("expected no matches")
+	}
+
+	if empty := NewSnippetPickerState(nil, ""); !strings.Contains(empty.Render(), "ctrl-e to add one") {
+		t.Error("expected a hint when there are no snippets")
+	}
+}
+
+func TestSnippetsState_Edit(t *testing.T) {
+	state := NewSnippetsState([]SnippetItem{{Name: "tests", Text: "Write tests"}})
+	if state.Title() != "Snippets" || !strings.Contains(state.Render(), "Write tests") {
+		t.Fatalf("expected the snippet list, got:\n%s", state.Render())
+	}
+
+	// Enter edits the selected snippet
+	state.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !state.Editing || state.EditingIndex != 0 || state.Title() != "Edit Snippet" {
+		t.Fatalf("expected to edit snippet 0, got editing=%v index=%d", state.Editing, state.EditingIndex)
+	}
+	typeInto(state, "-go")
+	state.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	typeInto(state, " for {selection}")
+	if got := state.GetEdited(); got.Name != "tests-go" || got.Text != "Write tests for {selection}" {
+		t.Errorf("GetEdited() = %+v", got)
+	}
+
+	// Cancelling returns to the list unchanged
+	state.CancelEdit()
+	if state.Editing || state.Items[0].Name != "tests" {
+		t.Error("expected to return to the unchanged list")
+	}
+
+	// n starts a new, empty snippet
+	state.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if !state.Editing || state.EditingIndex != -1 || state.Title() != "New Snippet" || state.GetEdited().Name != "" {
+		t.Errorf("expected a new empty snippet, got editing=%v index=%d edited=%+v", state.Editing, state.EditingIndex, state.GetEdited())
+	}
+
+	state.SetItems([]SnippetItem{{Name: "a", Text: "A"}, {Name: "b", Text: "B"}}, 5)
+	if state.Editing || state.SelectedSnippet().Name != "b" {
+		t.Errorf("expected the list with the selection clamped, got %+v", state.SelectedSnippet())
+	}
+}
@@ -99,7 +99,7 @@ type HelpSection struct {
 // SearchResult represents a single search match with context
 type SearchResult struct {
 	MessageIndex int    // Index in the messages array
-	Role         string // "user" or "assistant"
+	Role         string // "user", "assistant", or "system"
 	Content      string // The full message content
 	MatchStart   int    // Start position of match in content
 	MatchEnd     int    // End position of match in content
@@ -0,0 +1,186 @@
+package modals
+
+import (
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	huh "charm.land/huh/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// TagsCharLimit is the maximum length of a session's tags as typed.
+const TagsCharLimit = 200
+
+// =============================================================================
+// EditTagsState - State for the Edit Tags modal
+// =============================================================================
+
+// EditTagsState edits the free-form tags of a session, typed as words
+// separated by commas or spaces.
+type EditTagsState struct {
+	SessionID   string
+	SessionName string
+	KnownTags   []string // Tags used by any session, offered as a reminder
+
+	form *huh.Form
+	tags string
+}
+
+func (*EditTagsState) modalState() {}
+
+func (s *EditTagsState) Title() string { return "Edit Tags" }
+
+func (s *EditTagsState) Help() string {
+	return "Enter: save  Esc: cancel"
+}
+
+func (s *EditTagsState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	sessionLabel := lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		Bold(true).
+		MarginBottom(1).
+		Render(s.SessionName)
+
+	parts := []string{title, sessionLabel, s.form.View()}
+
+	if len(s.KnownTags) > 0 {
+		known := make([]string, len(s.KnownTags))
+		for i, tag := range s.KnownTags {
+			known[i] = "#" + tag
+		}
+		parts = append(parts, lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			Width(ModalWidth-4).
+			MarginTop(1).
+			Render("In use: "+strings.Join(known, " ")))
+	}
+
+	parts = append(parts, ModalHelpStyle.Render(s.Help()))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+func (s *EditTagsState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	var cmd tea.Cmd
+	s.form, cmd = huhFormUpdate(s.form, msg)
+	return s, cmd
+}
+
+// GetTags returns the tags as typed.
+func (s *EditTagsState) GetTags() string {
+	return s.tags
+}
+
+// SetTags sets the tags as typed (for testing).
+func (s *EditTagsState) SetTags(tags string) {
+	s.tags = tags
+}
+
+// NewEditTagsState creates a new EditTagsState prefilled with the session's tags.
+func NewEditTagsState(sessionID, sessionName string, tags, knownTags []string) *EditTagsState {
+	s := &EditTagsState{
+		SessionID:   sessionID,
+		SessionName: sessionName,
+		KnownTags:   knownTags,
+		tags:        strings.Join(tags, ", "),
+	}
+
+	s.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Tags").
+				Placeholder("bug, feature, spike").
+				CharLimit(TagsCharLimit).
+				Value(&s.tags),
+		),
+	).WithTheme(ModalTheme()).
+		WithShowHelp(false).
+		WithWidth(ModalInputWidth)
+
+	initHuhForm(s.form)
+	return s
+}
+
+// =============================================================================
+// TagFilterState - State for the Filter by Tag modal
+// =============================================================================
+
+// TagFilterState picks the tag the sidebar shows only the sessions of, or
+// none to show them all.
+type TagFilterState struct {
+	Tags          []string // Tags offered, after "All sessions"
+	Current       string   // Tag filtered by now, "" for none
+	SelectedIndex int      // 0 is "All sessions"; i is Tags[i-1]
+}
+
+func (*TagFilterState) modalState() {}
+
+func (s *TagFilterState) Title() string { return "Filter by Tag" }
+
+func (s *TagFilterState) Help() string {
+	return "up/down to select, Enter to filter, Esc to cancel"
+}
+
+func (s *TagFilterState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+
+	labels := make([]string, 0, len(s.Tags)+1)
+	labels = append(labels, "All sessions")
+	for _, tag := range s.Tags {
+		labels = append(labels, "#"+tag)
+	}
+	labels[s.indexOf(s.Current)] += " (current)"
+	optionList := RenderSelectableList(labels, s.SelectedIndex)
+
+	help := ModalHelpStyle.Render(s.Help())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, optionList, help)
+}
+
+func (s *TagFilterState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case keys.Up, "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case keys.Down, "j":
+			if s.SelectedIndex < len(s.Tags) {
+				s.SelectedIndex++
+			}
+		}
+	}
+	return s, nil
+}
+
+// indexOf returns the list index of a tag, 0 for "" (all sessions).
+func (s *TagFilterState) indexOf(tag string) int {
+	if tag == "" {
+		return 0
+	}
+	return slices.Index(s.Tags, tag) + 1
+}
+
+// SelectedTag returns the tag selected, "" for all sessions.
+func (s *TagFilterState) SelectedTag() string {
+	if s.SelectedIndex <= 0 || s.SelectedIndex > len(s.Tags) {
+		return ""
+	}
+	return s.Tags[s.SelectedIndex-1]
+}
+
+// NewTagFilterState creates a new TagFilterState with the current tag selected.
+// A current tag no session has any longer is listed after the others, so it
+// can still be seen as current.
+func NewTagFilterState(tags []string, current string) *TagFilterState {
+	tags = slices.Clone(tags)
+	if current != "" && !slices.Contains(tags, current) {
+		tags = append(tags, current)
+	}
+	s := &TagFilterState{Tags: tags, Current: current}
+	s.SelectedIndex = s.indexOf(current)
+	return s
+}
@@ -0,0 +1,47 @@
+package modals
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestEditTagsState(t *testing.T) {
+	state := NewEditTagsState("session-1", "my-feature", []string{"bug", "spike"}, []string{"bug", "feature", "spike"})
+
+	if state.GetTags() != "bug, spike" {
+		t.Errorf("expected the session's tags prefilled, got %q", state.GetTags())
+	}
+	rendered := ansi.Strip(state.Render())
+	for _, want := range []string{"Edit Tags", "my-feature", "In use: #bug #feature #spike"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %q in:\n%s", want, rendered)
+		}
+	}
+}
+
+func TestTagFilterState(t *testing.T) {
+	state := NewTagFilterState([]string{"bug", "spike"}, "spike")
+
+	rendered := ansi.Strip(state.Render())
+	for _, want := range []string{"Filter by Tag", "All sessions", "#bug", "#spike (current)"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %q in:\n%s", want, rendered)
+		}
+	}
+	if state.SelectedTag() != "spike" {
+		t.Errorf("expected the current tag selected, got %q", state.SelectedTag())
+	}
+	state.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	state.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	if state.SelectedTag() != "" {
+		t.Errorf("expected all sessions after up twice, got %q", state.SelectedTag())
+	}
+
+	// A tag no session has any longer is still listed as current
+	if state := NewTagFilterState([]string{"bug"}, "gone"); state.SelectedTag() != "gone" || len(state.Tags) != 2 {
+		t.Errorf("expected a stale current tag listed and selected, got %v at %d", state.Tags, state.SelectedIndex)
+	}
+}
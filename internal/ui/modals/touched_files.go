@@ -0,0 +1,158 @@
+package modals
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/zhubert/plural/internal/keys"
+)
+
+// =============================================================================
+// TouchedFilesState - Files Claude touched during a session
+// =============================================================================
+
+// TouchedFilesMaxVisible is the maximum number of files visible before scrolling.
+const TouchedFilesMaxVisible = 12
+
+// TouchedFileItem is a file Claude read, edited, or created during a session.
+type TouchedFileItem struct {
+	Path     string // As shown: relative to the worktree when inside it
+	FullPath string // Absolute path, for opening
+	Touch    string // "edited", "created", or "read"
+}
+
+// TouchedFilesState lists the files Claude touched during a session, grouped by
+// how it touched them, for opening in $EDITOR.
+type TouchedFilesState struct {
+	SessionName   string
+	Items         []TouchedFileItem // Ordered by group: edited, created, then read
+	SelectedIndex int
+	ScrollOffset  int
+}
+
+func (*TouchedFilesState) modalState() {}
+
+func (s *TouchedFilesState) Title() string { return "Touched Files" }
+
+func (s *TouchedFilesState) Help() string {
+	if len(s.Items) == 0 {
+		return "Esc: close"
+	}
+	return "up/down: select  Enter: open in $EDITOR  Esc: close"
+}
+
+func (s *TouchedFilesState) Render() string {
+	title := ModalTitleStyle.Render(s.Title())
+	contentWidth := ModalWidth - 4
+
+	description := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Width(contentWidth).
+		MarginBottom(1).
+		Render("Files Claude has worked with in " + s.SessionName + ".")
+
+	parts := []string{title, description}
+	if len(s.Items) == 0 {
+		parts = append(parts, lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			Italic(true).
+			Render("Claude hasn't touched any files yet"))
+	} else {
+		parts = append(parts, s.renderList(contentWidth))
+	}
+	parts = append(parts, ModalHelpStyle.Render(s.Help()))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+// renderList renders the visible files, with a header wherever a group starts.
+func (s *TouchedFilesState) renderList(width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	startIdx := s.ScrollOffset
+	endIdx := min(startIdx+TouchedFilesMaxVisible, len(s.Items))
+
+	var lines []string
+	if startIdx > 0 {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("  ... %d more above", startIdx)))
+	}
+	for i := startIdx; i < endIdx; i++ {
+		item := s.Items[i]
+		if i == startIdx || s.Items[i-1].Touch != item.Touch {
+			lines = append(lines, headerStyle.Render(fmt.Sprintf("%s (%d)", capitalize(item.Touch), s.groupSize(item.Touch))))
+		}
+		style := SidebarItemStyle
+		prefix := "  "
+		if i == s.SelectedIndex {
+			style = SidebarSelectedStyle
+			prefix = "> "
+		}
+		lines = append(lines, style.Render(prefix+TruncatePath(item.Path, width-2)))
+	}
+	if endIdx < len(s.Items) {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("  ... %d more below", len(s.Items)-endIdx)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// groupSize returns how many files were touched the given way.
+func (s *TouchedFilesState) groupSize(touch string) int {
+	n := 0
+	for _, item := range s.Items {
+		if item.Touch == touch {
+			n++
+		}
+	}
+	return n
+}
+
+// capitalize upper-cases the first letter of an ASCII word.
+func capitalize(word string) string {
+	if word == "" {
+		return ""
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
+
+func (s *TouchedFilesState) Update(msg tea.Msg) (ModalState, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return s, nil
+	}
+	switch keyMsg.String() {
+	case keys.Up, "k":
+		if s.SelectedIndex > 0 {
+			s.SelectedIndex--
+			if s.SelectedIndex < s.ScrollOffset {
+				s.ScrollOffset = s.SelectedIndex
+			}
+		}
+	case keys.Down, "j":
+		if s.SelectedIndex < len(s.Items)-1 {
+			s.SelectedIndex++
+			if s.SelectedIndex >= s.ScrollOffset+TouchedFilesMaxVisible {
+				s.ScrollOffset = s.SelectedIndex - TouchedFilesMaxVisible + 1
+			}
+		}
+	}
+	return s, nil
+}
+
+// SelectedItem returns the selected file, or nil when there are none.
+func (s *TouchedFilesState) SelectedItem() *TouchedFileItem {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Items) {
+		return nil
+	}
+	return &s.Items[s.SelectedIndex]
+}
+
+// NewTouchedFilesState creates a new TouchedFilesState for a session's touched files.
+func NewTouchedFilesState(sessionName string, items []TouchedFileItem) *TouchedFilesState {
+	return &TouchedFilesState{
+		SessionName: sessionName,
+		Items:       items,
+	}
+}
@@ -0,0 +1,38 @@
+package modals
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTouchedFilesState_GroupsAndScrolls(t *testing.T) {
+	var items []TouchedFileItem
+	items = append(items, TouchedFileItem{Path: "main.go", Touch: "edited"})
+	for i := range TouchedFilesMaxVisible + 2 {
+		items = append(items, TouchedFileItem{Path: fmt.Sprintf("read%02d.go", i), Touch: "read"})
+	}
+	state := NewTouchedFilesState("session", items)
+
+	rendered := ansi.Strip(state.Render())
+	for _, want := range []string{"Edited (1)", "> main.go", "Read (14)", "more below"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %q in:\n%s", want, rendered)
+		}
+	}
+
+	for range len(items) - 1 {
+		state.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	}
+	if item := state.SelectedItem(); item == nil || item.Path != "read13.go" {
+		t.Fatalf("expected the last file selected, got %+v", item)
+	}
+	rendered = ansi.Strip(state.Render())
+	// The group of the first visible file is named even when its header scrolled away
+	if !strings.Contains(rendered, "> read13.go") || !strings.Contains(rendered, "more above") || strings.Contains(rendered, "Edited") || !strings.Contains(rendered, "Read (14)") {
+		t.Errorf("unexpected scrolled list:\n%s", rendered)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"hash/fnv"
 	"image/color"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -26,6 +27,8 @@ type sessionNode struct {
 type repoGroup struct {
 	RepoPath string
 	RepoName string
+	Label    string // Header label: RepoName, or enough of the path to tell apart repos sharing it
+	Pinned   bool   // The Pinned pseudo-group of pinned sessions from any repo
 	Sessions []config.Session
 	// Tree structure for hierarchical display
 	RootNodes []sessionNode
@@ -35,19 +38,26 @@ type repoGroup struct {
 type Sidebar struct {
 	groups             []repoGroup
 	sessions           []config.Session // flat list for index tracking
-	filteredSessions   []config.Session // sessions matching current search filter
+	filteredSessions   []config.Session // sessions matching filterQuery, in list order
+	filterQuery        string           // Search query the list is filtered by, kept after leaving search mode ("" for none)
 	selectedIdx        int
 	width              int
 	height             int
 	focused            bool
 	scrollOffset       int
-	streamingSessions  map[string]bool // Map of session IDs that are currently streaming
-	pendingPermissions map[string]bool // Map of session IDs that have pending permission requests
-	pendingQuestions   map[string]bool // Map of session IDs that have pending questions
-	idleWithResponse   map[string]bool // Map of session IDs that finished streaming (user hasn't responded)
-	uncommittedChanges map[string]bool // Map of session IDs that have uncommitted changes
-	hasNewComments     map[string]bool // Map of session IDs that have new PR review comments
-	spinner            spinner.Model   // Spinner for streaming sessions
+	streamingSessions  map[string]bool   // Map of session IDs that are currently streaming
+	pendingPermissions map[string]bool   // Map of session IDs that have pending permission requests
+	pendingQuestions   map[string]bool   // Map of session IDs that have pending questions
+	idleWithResponse   map[string]bool   // Map of session IDs that finished streaming (user hasn't responded)
+	uncommittedChanges map[string]bool   // Map of session IDs that have uncommitted changes
+	hasNewComments     map[string]bool   // Map of session IDs that have new PR review comments
+	fileOverlaps       map[string]bool   // Map of session IDs whose changes overlap another session's
+	missing            map[string]bool   // Map of session IDs whose repo or worktree no longer exists
+	backgroundPaused   bool              // Polling is paused, so the polled badges may be stale
+	pinnedRepos        map[string]string // Repo label of each pinned session, shown after its name
+	repos              []string          // Configured repo paths, told apart in the labels even without sessions
+	tagFilter          string            // Tag the listed sessions were filtered by, shown above them ("" for none)
+	spinner            spinner.Model     // Spinner for streaming sessions
 
 	// Multi-select mode
 	multiSelectMode  bool
@@ -57,6 +67,10 @@ type Sidebar struct {
 	lastHash     uint64 // Hash of last session list for change detection
 	lastAttnHash uint64 // Hash of attention state for re-ordering detection
 
+	// Rows rendered unselected, by session ID, so View renders only those changed
+	rowCache    map[string]cachedRow
+	onRowRender func(sessionID string) // Called as a row is rendered; for tests
+
 	// Search mode
 	searchMode  bool
 	searchInput textinput.Model
@@ -80,7 +94,10 @@ func NewSidebar() *Sidebar {
 		idleWithResponse:   make(map[string]bool),
 		uncommittedChanges: make(map[string]bool),
 		hasNewComments:     make(map[string]bool),
+		fileOverlaps:       make(map[string]bool),
+		missing:            make(map[string]bool),
 		selectedSessions:   make(map[string]bool),
+		rowCache:           make(map[string]cachedRow),
 		searchInput:        ti,
 		spinner:            sp,
 	}
@@ -155,6 +172,20 @@ func hashSessions(sessions []config.Session) uint64 {
 		} else {
 			h.Write([]byte{0})
 		}
+		if sess.MergedUpstream {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+		if sess.Pinned {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+		for _, tag := range sess.Tags {
+			h.Write([]byte(tag))
+			h.Write([]byte{0})
+		}
 	}
 	return h.Sum64()
 }
@@ -182,9 +213,21 @@ func (s *Sidebar) hashAttention() uint64 {
 	hashMap('I', s.idleWithResponse)
 	hashMap('U', s.uncommittedChanges)
 	hashMap('C', s.hasNewComments)
+	hashMap('O', s.fileOverlaps)
 	return h.Sum64()
 }
 
+// SetRepos sets the configured repo paths. A repo's header label names enough
+// of its path to tell it apart from every one of them, not only from the repos
+// with sessions listed.
+func (s *Sidebar) SetRepos(repos []string) {
+	if slices.Equal(repos, s.repos) {
+		return
+	}
+	s.repos = slices.Clone(repos)
+	s.lastHash = 0 // Relabel on the next SetSessions
+}
+
 // SetSessions updates the session list, grouping by repo
 func (s *Sidebar) SetSessions(sessions []config.Session) {
 	// Fast path: check if sessions or attention state have changed
@@ -197,11 +240,20 @@ func (s *Sidebar) SetSessions(sessions []config.Session) {
 	s.lastHash = newHash
 	s.lastAttnHash = newAttnHash
 
-	// Group sessions by repo path
+	// Group sessions by repo path, except pinned ones
 	groupMap := make(map[string]*repoGroup)
 	var groupOrder []string
+	repoPaths := slices.Clone(s.repos)
+	var pinned []config.Session
 
 	for _, sess := range sessions {
+		if !slices.Contains(repoPaths, sess.RepoPath) {
+			repoPaths = append(repoPaths, sess.RepoPath)
+		}
+		if sess.Pinned {
+			pinned = append(pinned, sess)
+			continue
+		}
 		if _, exists := groupMap[sess.RepoPath]; !exists {
 			groupMap[sess.RepoPath] = &repoGroup{
 				RepoPath: sess.RepoPath,
@@ -213,10 +265,23 @@ func (s *Sidebar) SetSessions(sessions []config.Session) {
 		groupMap[sess.RepoPath].Sessions = append(groupMap[sess.RepoPath].Sessions, sess)
 	}
 
-	// Build ordered groups with tree structure and priority sorting
-	s.groups = make([]repoGroup, 0, len(groupOrder))
+	// Build ordered groups with tree structure and priority sorting,
+	// starting with the pinned sessions
+	labels := repoLabels(repoPaths)
+	s.groups = make([]repoGroup, 0, len(groupOrder)+1)
+	s.pinnedRepos = make(map[string]string, len(pinned))
+	if len(pinned) > 0 {
+		group := repoGroup{Label: "Pinned", Pinned: true, Sessions: pinned}
+		group.RootNodes = buildSessionTree(pinned)
+		s.sortNodesByPriority(group.RootNodes)
+		s.groups = append(s.groups, group)
+		for _, sess := range pinned {
+			s.pinnedRepos[sess.ID] = labels[sess.RepoPath]
+		}
+	}
 	for _, path := range groupOrder {
 		group := groupMap[path]
+		group.Label = labels[path]
 		group.RootNodes = buildSessionTree(group.Sessions)
 		s.sortNodesByPriority(group.RootNodes)
 		s.groups = append(s.groups, *group)
@@ -227,6 +292,7 @@ func (s *Sidebar) SetSessions(sessions []config.Session) {
 	for _, group := range s.groups {
 		flattenSessionTree(group.RootNodes, &s.sessions)
 	}
+	s.pruneRowCache()
 
 	// Adjust selection if needed
 	if s.selectedIdx >= len(s.sessions) {
@@ -235,6 +301,11 @@ func (s *Sidebar) SetSessions(sessions []config.Session) {
 	if s.selectedIdx < 0 {
 		s.selectedIdx = 0
 	}
+
+	// Match the filter against the new sessions
+	if s.filterQuery != "" {
+		s.SetFilter(s.filterQuery)
+	}
 }
 
 // buildSessionTree builds a tree structure from a flat list of sessions
@@ -389,6 +460,41 @@ func (s *Sidebar) HasNewComments(sessionID string) bool {
 	return s.hasNewComments[sessionID]
 }
 
+// SetFileOverlap sets whether a session changed files another session of its repo changed too
+func (s *Sidebar) SetFileOverlap(sessionID string, overlaps bool) {
+	if overlaps {
+		s.fileOverlaps[sessionID] = true
+	} else {
+		delete(s.fileOverlaps, sessionID)
+	}
+}
+
+// SetMissing sets whether a session's repo or worktree no longer exists
+func (s *Sidebar) SetMissing(sessionID string, missing bool) {
+	if missing {
+		s.missing[sessionID] = true
+	} else {
+		delete(s.missing, sessionID)
+	}
+}
+
+// IsMissing returns whether a session's repo or worktree no longer exists
+func (s *Sidebar) IsMissing(sessionID string) bool {
+	return s.missing[sessionID]
+}
+
+// SetBackgroundPaused sets whether background polling is paused. The badges it
+// keeps up to date (new comments, overlapping changes) are then dimmed and
+// marked stale.
+func (s *Sidebar) SetBackgroundPaused(paused bool) {
+	s.backgroundPaused = paused
+}
+
+// HasFileOverlap returns whether a session changed files another session changed too
+func (s *Sidebar) HasFileOverlap(sessionID string) bool {
+	return s.fileOverlaps[sessionID]
+}
+
 // Attention priority levels (lower = higher priority, needs attention sooner)
 const (
 	priorityPermission  = 0 // Pending permission/question/plan approval
@@ -419,9 +525,19 @@ func (s *Sidebar) sessionPriority(sessionID string) int {
 	return priorityNormal
 }
 
+// nodePriority returns the attention priority of a session in the tree. Merged
+// sessions are done, so they only need attention while Claude is working or asking.
+func (s *Sidebar) nodePriority(sess config.Session) int {
+	priority := s.sessionPriority(sess.ID)
+	if sess.IsMerged() && priority > priorityStreaming {
+		return priorityNormal
+	}
+	return priority
+}
+
 // effectivePriority returns the best (lowest) priority across a node and all its descendants.
 func (s *Sidebar) effectivePriority(node sessionNode) int {
-	best := s.sessionPriority(node.Session.ID)
+	best := s.nodePriority(node.Session)
 	for _, child := range node.Children {
 		childPriority := s.effectivePriority(child)
 		if childPriority < best {
@@ -513,10 +629,7 @@ func (s *Sidebar) SelectedCount() int {
 
 // visibleSessions returns the sessions currently visible (filtered or all)
 func (s *Sidebar) visibleSessions() []config.Session {
-	if s.searchMode {
-		return s.filteredSessions
-	}
-	return s.sessions
+	return s.getDisplaySessions()
 }
 
 // SidebarTick returns a command that starts the sidebar spinner animation.
@@ -525,12 +638,13 @@ func (s *Sidebar) SidebarTick() tea.Cmd {
 	return s.spinner.Tick
 }
 
-// EnterSearchMode activates search mode
+// EnterSearchMode activates search mode, editing the filter already applied
+// if there is one
 func (s *Sidebar) EnterSearchMode() tea.Cmd {
 	s.searchMode = true
-	s.searchInput.SetValue("")
+	s.searchInput.SetValue(s.filterQuery)
+	s.searchInput.CursorEnd()
 	s.searchInput.Focus()
-	s.applyFilter("")
 	return nil
 }
 
@@ -539,16 +653,33 @@ func (s *Sidebar) ExitSearchMode() {
 	s.searchMode = false
 	s.searchInput.Blur()
 	s.searchInput.SetValue("")
-	s.filteredSessions = nil
-	// Reset selection to stay within bounds
-	if s.selectedIdx >= len(s.sessions) {
-		s.selectedIdx = len(s.sessions) - 1
+	s.ClearFilter()
+}
+
+// SetFilter filters the list to the sessions matching query, keeping the
+// selected session selected if it still matches. An empty query lists every
+// session.
+func (s *Sidebar) SetFilter(query string) {
+	var selectedID string
+	if sess := s.SelectedSession(); sess != nil {
+		selectedID = sess.ID
 	}
-	if s.selectedIdx < 0 {
-		s.selectedIdx = 0
+	s.applyFilter(query)
+	if selectedID != "" {
+		s.SelectSession(selectedID)
 	}
 }
 
+// ClearFilter lists every session again, keeping the selected session.
+func (s *Sidebar) ClearFilter() {
+	s.SetFilter("")
+}
+
+// HasFilter returns whether the list is filtered by a search query.
+func (s *Sidebar) HasFilter() bool {
+	return s.filterQuery != ""
+}
+
 // IsSearchMode returns whether search mode is active
 func (s *Sidebar) IsSearchMode() bool {
 	return s.searchMode
@@ -559,41 +690,87 @@ func (s *Sidebar) GetSearchQuery() string {
 	return s.searchInput.Value()
 }
 
-// applyFilter filters sessions based on the search query
-func (s *Sidebar) applyFilter(query string) {
-	if query == "" {
-		s.filteredSessions = nil
-		return
-	}
+// SetTagFilter sets the tag the sessions passed to SetSessions were filtered
+// by, shown above the list; "" for none.
+func (s *Sidebar) SetTagFilter(tag string) {
+	s.tagFilter = tag
+}
 
-	query = strings.ToLower(query)
-	s.filteredSessions = nil
+// TagFilter returns the tag the listed sessions were filtered by, or "".
+func (s *Sidebar) TagFilter() string {
+	return s.tagFilter
+}
 
-	for _, sess := range s.sessions {
-		// Search in branch name
-		if sess.Branch != "" && strings.Contains(strings.ToLower(sess.Branch), query) {
-			s.filteredSessions = append(s.filteredSessions, sess)
+// parseSearchQuery splits a search query into the tags its "#tag" words
+// require and the remaining text to match.
+func parseSearchQuery(query string) (tags []string, text string) {
+	var words []string
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if tag := strings.TrimPrefix(word, "#"); tag != word {
+			if tag != "" {
+				tags = append(tags, tag)
+			}
 			continue
 		}
-		// Search in session name
-		if strings.Contains(strings.ToLower(sess.Name), query) {
-			s.filteredSessions = append(s.filteredSessions, sess)
-			continue
+		words = append(words, word)
+	}
+	return tags, strings.Join(words, " ")
+}
+
+// fuzzyMatch reports whether the runes of query appear in s in order, not
+// necessarily together, ignoring case.
+func fuzzyMatch(query, s string) bool {
+	s = strings.ToLower(s)
+	for _, r := range strings.ToLower(query) {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
 		}
-		// Search in repo path (just the base name)
-		repoName := filepath.Base(sess.RepoPath)
-		if strings.Contains(strings.ToLower(repoName), query) {
-			s.filteredSessions = append(s.filteredSessions, sess)
-			continue
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+// sessionMatches reports whether sess has every tag in tags and each word of
+// text fuzzy-matches its branch, name, repo or one of its tags.
+func sessionMatches(sess config.Session, tags []string, text string) bool {
+	// Every "#tag" is required
+	if slices.ContainsFunc(tags, func(tag string) bool { return !sess.HasTag(tag) }) {
+		return false
+	}
+	fields := append([]string{sess.Branch, sess.Name, filepath.Base(sess.RepoPath)}, sess.Tags...)
+	for _, word := range strings.Fields(text) {
+		if !slices.ContainsFunc(fields, func(field string) bool { return fuzzyMatch(word, field) }) {
+			return false
 		}
 	}
+	return true
+}
 
-	// Reset selection to stay within bounds of filtered list
-	if len(s.filteredSessions) > 0 {
-		if s.selectedIdx >= len(s.filteredSessions) {
-			s.selectedIdx = len(s.filteredSessions) - 1
+// applyFilter filters sessions based on the search query. Words starting
+// with "#" require the session to have that tag; the other words are each
+// fuzzy-matched against the branch, name, repo and tags. The matches keep the
+// order of the full list.
+func (s *Sidebar) applyFilter(query string) {
+	s.filterQuery = strings.TrimSpace(query)
+	s.filteredSessions = nil
+	if s.filterQuery != "" {
+		tags, text := parseSearchQuery(s.filterQuery)
+		// Non-nil even when nothing matches, so the list shows as empty
+		s.filteredSessions = []config.Session{}
+		for _, sess := range s.sessions {
+			if sessionMatches(sess, tags, text) {
+				s.filteredSessions = append(s.filteredSessions, sess)
+			}
 		}
-	} else {
+	}
+
+	// Reset selection to stay within bounds of the list shown
+	displaySessions := s.getDisplaySessions()
+	if s.selectedIdx >= len(displaySessions) {
+		s.selectedIdx = len(displaySessions) - 1
+	}
+	if s.selectedIdx < 0 {
 		s.selectedIdx = 0
 	}
 	s.scrollOffset = 0
@@ -601,7 +778,7 @@ func (s *Sidebar) applyFilter(query string) {
 
 // getDisplaySessions returns the sessions to display (filtered or all)
 func (s *Sidebar) getDisplaySessions() []config.Session {
-	if s.searchMode && s.filteredSessions != nil {
+	if s.filterQuery != "" {
 		return s.filteredSessions
 	}
 	return s.sessions
@@ -630,7 +807,8 @@ func (s *Sidebar) Update(msg tea.Msg) (*Sidebar, tea.Cmd) {
 				s.ExitSearchMode()
 				return s, nil
 			case keys.Enter:
-				// Exit search mode but keep filter applied (user selected)
+				// Exit search mode but keep the filter applied (user selected);
+				// Escape clears it afterwards
 				s.searchMode = false
 				s.searchInput.Blur()
 				return s, nil
@@ -653,7 +831,7 @@ func (s *Sidebar) Update(msg tea.Msg) (*Sidebar, tea.Cmd) {
 				var cmd tea.Cmd
 				s.searchInput, cmd = s.searchInput.Update(msg)
 				// Apply filter based on new query
-				s.applyFilter(s.searchInput.Value())
+				s.SetFilter(s.searchInput.Value())
 				return s, cmd
 			}
 		}
@@ -666,7 +844,7 @@ func (s *Sidebar) Update(msg tea.Msg) (*Sidebar, tea.Cmd) {
 				s.ensureVisible()
 			}
 		case keys.Down, "j":
-			if s.selectedIdx < len(s.sessions)-1 {
+			if s.selectedIdx < len(s.getDisplaySessions())-1 {
 				s.selectedIdx++
 				s.ensureVisible()
 			}
@@ -701,9 +879,9 @@ func (s *Sidebar) View() string {
 
 	var content string
 
-	// Render search input if in search mode
+	// Render search input if in search mode, or the query while filtered
 	var searchLine string
-	if s.searchMode {
+	if s.searchMode || s.filterQuery != "" {
 		// Style the search input
 		searchStyle := lipgloss.NewStyle().
 			Foreground(ColorSecondary).
@@ -713,15 +891,37 @@ func (s *Sidebar) View() string {
 		innerHeight-- // Reserve one line for search
 	}
 
+	// Name the tag the sessions are filtered by
+	var tagLine string
+	if s.tagFilter != "" {
+		tagLine = lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Tagged ") +
+			lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true).Render("#"+s.tagFilter)
+		innerHeight-- // Reserve one line for the tag
+	}
+
 	displaySessions := s.getDisplaySessions()
 
+	// Full path of the selected session's repo when its header is shortened
+	var pathHint string
+	if !s.searchMode && s.filterQuery == "" && len(displaySessions) > 0 {
+		pathHint = s.repoPathHint(ctx.InnerWidth(s.width))
+		if pathHint != "" {
+			innerHeight-- // Reserve one line for the path
+		}
+	}
+
 	if len(displaySessions) == 0 {
 		var emptyMsg string
-		if s.searchMode && s.searchInput.Value() != "" {
+		if s.filterQuery != "" {
 			emptyMsg = lipgloss.NewStyle().
 				Foreground(ColorTextMuted).
 				Italic(true).
 				Render("No matches.")
+		} else if s.tagFilter != "" {
+			emptyMsg = lipgloss.NewStyle().
+				Foreground(ColorTextMuted).
+				Italic(true).
+				Render("No sessions with this tag.")
 		} else {
 			emptyMsg = lipgloss.NewStyle().
 				Foreground(ColorTextMuted).
@@ -729,135 +929,8 @@ func (s *Sidebar) View() string {
 				Render("No sessions.")
 		}
 		content = emptyMsg
-	} else if s.searchMode && s.filteredSessions != nil {
-		// Render flat filtered list (no repo grouping)
-		// Use actual lines to handle text wrapping correctly
-		var allLines []string
-		selectedStartLine := 0
-		innerWidth := ctx.InnerWidth(s.width)
-
-		for idx, sess := range s.filteredSessions {
-			displayName := s.renderSessionName(sess, idx)
-			itemStyle := SidebarItemStyle.Width(innerWidth)
-			if idx == s.selectedIdx {
-				itemStyle = SidebarSelectedStyle.Width(innerWidth)
-				displayName = "> " + strings.TrimPrefix(displayName, "  ")
-				selectedStartLine = len(allLines)
-			}
-			// Render and split into actual lines
-			rendered := itemStyle.Render(displayName)
-			for line := range strings.SplitSeq(rendered, "\n") {
-				allLines = append(allLines, line)
-			}
-		}
-
-		// Adjust scroll to keep selected session visible
-		visibleHeight := innerHeight
-		if selectedStartLine < s.scrollOffset {
-			s.scrollOffset = selectedStartLine
-		} else if selectedStartLine >= s.scrollOffset+visibleHeight {
-			s.scrollOffset = selectedStartLine - visibleHeight + 1
-		}
-
-		// Ensure scrollOffset is valid
-		if s.scrollOffset < 0 {
-			s.scrollOffset = 0
-		}
-		maxScroll := max(len(allLines)-visibleHeight, 0)
-		if s.scrollOffset > maxScroll {
-			s.scrollOffset = maxScroll
-		}
-
-		// Apply scrolling and truncate
-		if s.scrollOffset > 0 && s.scrollOffset < len(allLines) {
-			allLines = allLines[s.scrollOffset:]
-		}
-		if len(allLines) > visibleHeight {
-			allLines = allLines[:visibleHeight]
-		}
-		content = strings.Join(allLines, "\n")
 	} else {
-		// Build the grouped list (normal mode) with tree structure
-		// Use actual lines (not items) to handle text wrapping correctly
-		var allLines []string
-		selectedStartLine := 0 // Line where selected session starts
-
-		sessionIdx := 0
-		innerWidth := ctx.InnerWidth(s.width)
-
-		for i, group := range s.groups {
-			// Add blank line between repos (not before first one)
-			if i > 0 {
-				allLines = append(allLines, "")
-			}
-
-			// Repo header
-			repoStyle := lipgloss.NewStyle().
-				Foreground(ColorTextMuted).
-				Bold(true)
-			allLines = append(allLines, repoStyle.Render(group.RepoName))
-
-			// Render sessions in tree order with indentation
-			var renderNode func(node sessionNode, depth int, isLastChild bool)
-			renderNode = func(node sessionNode, depth int, isLastChild bool) {
-				isSelected := sessionIdx == s.selectedIdx
-				hasChildren := len(node.Children) > 0
-				displayName := s.renderSessionNode(node.Session, depth, isSelected, hasChildren, isLastChild)
-
-				itemStyle := SidebarItemStyle.Width(innerWidth)
-				if isSelected {
-					itemStyle = SidebarSelectedStyle.Width(innerWidth)
-					selectedStartLine = len(allLines) // Record where selected session starts
-				}
-
-				// Render and split into actual lines (handles text wrapping)
-				rendered := itemStyle.Render(displayName)
-				for line := range strings.SplitSeq(rendered, "\n") {
-					allLines = append(allLines, line)
-				}
-				sessionIdx++
-
-				// Render children with increased depth
-				for i, child := range node.Children {
-					childIsLast := i == len(node.Children)-1
-					renderNode(child, depth+1, childIsLast)
-				}
-			}
-
-			for i, node := range group.RootNodes {
-				isLast := i == len(group.RootNodes)-1
-				renderNode(node, 0, isLast)
-			}
-		}
-
-		// Adjust scroll to keep selected session visible
-		visibleHeight := innerHeight
-		if selectedStartLine < s.scrollOffset {
-			// Selected is above visible area - scroll up
-			s.scrollOffset = selectedStartLine
-		} else if selectedStartLine >= s.scrollOffset+visibleHeight {
-			// Selected is below visible area - scroll down
-			s.scrollOffset = selectedStartLine - visibleHeight + 1
-		}
-
-		// Ensure scrollOffset is valid
-		if s.scrollOffset < 0 {
-			s.scrollOffset = 0
-		}
-		maxScroll := max(len(allLines)-visibleHeight, 0)
-		if s.scrollOffset > maxScroll {
-			s.scrollOffset = maxScroll
-		}
-
-		// Apply scrolling and truncate
-		if s.scrollOffset > 0 && s.scrollOffset < len(allLines) {
-			allLines = allLines[s.scrollOffset:]
-		}
-		if len(allLines) > visibleHeight {
-			allLines = allLines[:visibleHeight]
-		}
-
-		content = strings.Join(allLines, "\n")
+		content = s.renderList(innerHeight, ctx.InnerWidth(s.width))
 	}
 
 	// Ensure content fits
@@ -867,8 +940,20 @@ func (s *Sidebar) View() string {
 		content = strings.Join(lines, "\n")
 	}
 
-	// Prepend search line if in search mode
-	if s.searchMode {
+	// Append the repo path below the sessions, padding up to the bottom line
+	if pathHint != "" {
+		lines := strings.Split(content, "\n")
+		for len(lines) < innerHeight {
+			lines = append(lines, "")
+		}
+		content = strings.Join(append(lines, pathHint), "\n")
+	}
+
+	// Prepend the tag line, then the search line if in search mode
+	if tagLine != "" {
+		content = tagLine + "\n" + content
+	}
+	if searchLine != "" {
 		if content != "" {
 			content = searchLine + "\n" + content
 		} else {
@@ -897,15 +982,19 @@ func (s *Sidebar) renderSessionNameWithDepth(sess config.Session, depth int, isS
 // isLastChild: whether this is the last child of its parent (for connector style)
 func (s *Sidebar) renderSessionNode(sess config.Session, depth int, isSelected bool, hasChildren bool, isLastChild bool) string {
 	// Determine the node symbol based on state priority:
-	// 1. Pending permission (⚠) - highest priority, needs attention
-	// 2. Streaming (spinner) - active work happening
-	// 3. Merged status (✓) - completed state
-	// 4. PR created - has PR but not merged
-	// 5. Default node type (◆/◇) - base state
+	// 1. Missing repo or worktree (⊘) - nothing else can happen until it is dealt with
+	// 2. Pending permission (⚠) - needs attention
+	// 3. Streaming (spinner) - active work happening
+	// 4. Merged status (✓) - completed state
+	// 5. PR created - has PR but not merged
+	// 6. Default node type (◆/◇) - base state
 	var nodeSymbol string
 	var symbolColor color.Color
 
-	if s.HasPendingPermission(sess.ID) {
+	if s.missing[sess.ID] {
+		nodeSymbol = "⊘"
+		symbolColor = ColorError
+	} else if s.HasPendingPermission(sess.ID) {
 		// Pending permission - needs attention
 		nodeSymbol = "⚠"
 		symbolColor = ColorWarning
@@ -917,8 +1006,8 @@ func (s *Sidebar) renderSessionNode(sess config.Session, depth int, isSelected b
 		// Merged to parent or main branch
 		nodeSymbol = "✓"
 		symbolColor = ColorSecondary
-	} else if sess.PRMerged {
-		// PR merged on GitHub
+	} else if sess.PRMerged || sess.MergedUpstream {
+		// PR merged on GitHub, or branch found merged upstream
 		nodeSymbol = "✓"
 		symbolColor = ColorSuccess
 	} else if sess.PRClosed {
@@ -990,8 +1079,38 @@ func (s *Sidebar) renderSessionNode(sess config.Session, depth int, isSelected b
 		name = sess.Name
 	}
 
+	if s.missing[sess.ID] && !isSelected {
+		name = lipgloss.NewStyle().Foreground(ColorTextMuted).Strikethrough(true).Render(name)
+	}
 	displayName := styledPrefix + name
 
+	// Show missing badge
+	if s.missing[sess.ID] {
+		if isSelected {
+			displayName += " missing"
+		} else {
+			displayName += lipgloss.NewStyle().Foreground(ColorError).Render(" missing")
+		}
+	}
+
+	// Pinned sessions are grouped apart from their repo, so name it
+	if repo := s.pinnedRepos[sess.ID]; repo != "" {
+		if isSelected {
+			displayName += " · " + repo
+		} else {
+			displayName += lipgloss.NewStyle().Foreground(ColorTextMuted).Render(" · " + repo)
+		}
+	}
+
+	// Show tag chips
+	for _, tag := range sess.Tags {
+		if isSelected {
+			displayName += " #" + tag
+		} else {
+			displayName += lipgloss.NewStyle().Foreground(ColorSecondary).Render(" #" + tag)
+		}
+	}
+
 	// Show autonomous mode indicator
 	if sess.Autonomous {
 		if isSelected {
@@ -1002,16 +1121,47 @@ func (s *Sidebar) renderSessionNode(sess config.Session, depth int, isSelected b
 		}
 	}
 
+	// Show merged badge
+	if sess.IsMerged() {
+		if isSelected {
+			displayName += " merged"
+		} else {
+			mergedStyle := lipgloss.NewStyle().Foreground(ColorSuccess)
+			displayName += mergedStyle.Render(" merged")
+		}
+	}
+
+	polled := s.hasNewComments[sess.ID] || s.fileOverlaps[sess.ID]
+
 	// Show new comments indicator
 	if s.hasNewComments[sess.ID] {
 		if isSelected {
 			displayName += " *"
 		} else {
-			commentStyle := lipgloss.NewStyle().Foreground(ColorInfo)
+			commentStyle := lipgloss.NewStyle().Foreground(s.polledBadgeColor(ColorInfo))
 			displayName += commentStyle.Render(" *")
 		}
 	}
 
+	// Show overlapping changes indicator
+	if s.fileOverlaps[sess.ID] {
+		if isSelected {
+			displayName += " !"
+		} else {
+			overlapStyle := lipgloss.NewStyle().Foreground(s.polledBadgeColor(ColorWarning))
+			displayName += overlapStyle.Render(" !")
+		}
+	}
+
+	// Polled badges are frozen while background activity is paused
+	if polled && s.backgroundPaused {
+		if isSelected {
+			displayName += " ⏸"
+		} else {
+			displayName += lipgloss.NewStyle().Foreground(ColorTextMuted).Render(" ⏸")
+		}
+	}
+
 	// In multi-select mode, prepend a checkbox
 	if s.multiSelectMode {
 		checkbox := "[ ] "
@@ -1031,3 +1181,12 @@ func (s *Sidebar) renderSessionNode(sess config.Session, depth int, isSelected b
 
 	return displayName
 }
+
+// polledBadgeColor returns the color of a badge kept up to date by background
+// polling: its own color, or muted while polling is paused.
+func (s *Sidebar) polledBadgeColor(c color.Color) color.Color {
+	if s.backgroundPaused {
+		return ColorTextMuted
+	}
+	return c
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// repoLabels returns the group header label of each repo path: its basename, or
+// as many trailing path segments as it takes to tell it apart from the other
+// paths (work/api and personal/api).
+func repoLabels(repoPaths []string) map[string]string {
+	segments := make(map[string][]string, len(repoPaths))
+	for _, path := range repoPaths {
+		segments[path] = pathSegments(path)
+	}
+
+	labels := make(map[string]string, len(repoPaths))
+	for _, path := range repoPaths {
+		own := segments[path]
+		n := 1
+		for n < len(own) && suffixShared(path, own[len(own)-n:], segments) {
+			n++
+		}
+		labels[path] = strings.Join(own[max(len(own)-n, 0):], "/")
+	}
+	return labels
+}
+
+// pathSegments splits a path into its non-empty segments.
+func pathSegments(path string) []string {
+	var segments []string
+	for segment := range strings.SplitSeq(filepath.ToSlash(filepath.Clean(path)), "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// suffixShared returns whether a path other than path ends with suffix.
+func suffixShared(path string, suffix []string, segments map[string][]string) bool {
+	for other, otherSegments := range segments {
+		if other == path || len(otherSegments) < len(suffix) {
+			continue
+		}
+		if slices.Equal(otherSegments[len(otherSegments)-len(suffix):], suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// fitRepoLabel shortens label to at most width cells so a header never wraps.
+// The middle is elided first, keeping the basename (work/or…/api), then the
+// basename is cut (servi…).
+func fitRepoLabel(label string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if ansi.StringWidth(label) <= width {
+		return label
+	}
+	head, base := "", label
+	if i := strings.LastIndex(label, "/"); i >= 0 {
+		head, base = label[:i], label[i+1:]
+	}
+	// Room for the head after the basename and "…/"
+	if avail := width - ansi.StringWidth(base) - 2; head != "" && avail >= 0 {
+		return ansi.Truncate(head, avail, "") + "…/" + base
+	}
+	return ansi.Truncate(base, width, "…")
+}
+
+// abbreviateHome replaces the home directory at the start of path with "~".
+func abbreviateHome(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return "~/" + rest
+	}
+	return path
+}
+
+// repoPathHint returns the footer line showing the full path of the selected
+// session's repo, when its group header shows anything other than the plain
+// basename. Returns "" otherwise.
+func (s *Sidebar) repoPathHint(width int) string {
+	if s.selectedIdx < 0 || s.selectedIdx >= len(s.sessions) {
+		return ""
+	}
+	repoPath := s.sessions[s.selectedIdx].RepoPath
+	for _, group := range s.groups {
+		if group.Pinned || group.RepoPath != repoPath {
+			continue
+		}
+		if fitRepoLabel(group.Label, width) == filepath.Base(repoPath) {
+			return ""
+		}
+		return lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			Italic(true).
+			Render(fitRepoLabel(abbreviateHome(repoPath), width))
+	}
+	return ""
+}
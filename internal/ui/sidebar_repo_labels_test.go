@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/config"
+)
+
+func TestRepoLabels(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  map[string]string
+	}{
+		{
+			name:  "unique basenames",
+			paths: []string{"/code/api", "/code/web"},
+			want:  map[string]string{"/code/api": "api", "/code/web": "web"},
+		},
+		{
+			name:  "colliding basenames",
+			paths: []string{"/code/work/api", "/code/personal/api", "/code/web"},
+			want:  map[string]string{"/code/work/api": "work/api", "/code/personal/api": "personal/api", "/code/web": "web"},
+		},
+		{
+			name:  "colliding parents",
+			paths: []string{"/a/org/team/service", "/b/org/team/service"},
+			want:  map[string]string{"/a/org/team/service": "a/org/team/service", "/b/org/team/service": "b/org/team/service"},
+		},
+		{
+			name:  "only some collide further up",
+			paths: []string{"/x/team/svc", "/y/team/svc", "/z/other/svc"},
+			want:  map[string]string{"/x/team/svc": "x/team/svc", "/y/team/svc": "y/team/svc", "/z/other/svc": "other/svc"},
+		},
+		{
+			name:  "one path is a suffix of another",
+			paths: []string{"/team/svc", "/org/team/svc"},
+			want:  map[string]string{"/team/svc": "team/svc", "/org/team/svc": "org/team/svc"},
+		},
+		{
+			name:  "trailing slash",
+			paths: []string{"/code/work/api/", "/code/home/api"},
+			want:  map[string]string{"/code/work/api/": "work/api", "/code/home/api": "home/api"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := repoLabels(tt.paths)
+			for path, want := range tt.want {
+				if got[path] != want {
+					t.Errorf("label of %s = %q, want %q", path, got[path], want)
+				}
+			}
+		})
+	}
+}
+
+func TestFitRepoLabel(t *testing.T) {
+	tests := []struct {
+		label string
+		width int
+		want  string
+	}{
+		{"api", 10, "api"},
+		{"work/api", 8, "work/api"},
+		{"work/org/team/api", 12, "work/or…/api"},
+		{"work/api", 6, "w…/api"},
+		{"work/api", 5, "…/api"},
+		{"work/api", 4, "api"},
+		{"service-name", 6, "servi…"},
+		{"team/service-name", 8, "service…"},
+		{"api", 0, ""},
+	}
+	for _, tt := range tests {
+		got := fitRepoLabel(tt.label, tt.width)
+		if got != tt.want {
+			t.Errorf("fitRepoLabel(%q, %d) = %q, want %q", tt.label, tt.width, got, tt.want)
+		}
+		if ansi.StringWidth(got) > max(tt.width, 0) {
+			t.Errorf("fitRepoLabel(%q, %d) = %q is wider than %d", tt.label, tt.width, got, tt.width)
+		}
+	}
+}
+
+func TestSidebar_RepoHeadersNeverWrap(t *testing.T) {
+	innerWidth := func(width int) int { return GetViewContext().InnerWidth(width) }
+	sessions := []config.Session{
+		{ID: "s1", RepoPath: "/home/me/code/work/org/team/service-name", Branch: "b1", Name: "service-name/one"},
+		{ID: "s2", RepoPath: "/home/me/code/personal/service-name", Branch: "b2", Name: "service-name/two"},
+	}
+	for _, width := range []int{8, 12, 20, 30, 60} {
+		sidebar := NewSidebar()
+		sidebar.SetSize(width, 20)
+		sidebar.SetSessions(sessions)
+		view := sidebar.View()
+		if got := lipgloss.Height(view); got != 20 {
+			t.Errorf("width %d: sidebar is %d lines tall, want 20", width, got)
+		}
+		for _, group := range sidebar.groups {
+			if got := ansi.StringWidth(fitRepoLabel(group.Label, innerWidth(width))); got > innerWidth(width) {
+				t.Errorf("width %d: header %q is %d cells wide", width, group.Label, got)
+			}
+		}
+		if width >= 30 {
+			stripped := ansi.Strip(view)
+			if !strings.Contains(stripped, "team/service-name") || !strings.Contains(stripped, "personal/service-name") {
+				t.Errorf("width %d: expected disambiguated headers, got:\n%s", width, stripped)
+			}
+		}
+	}
+}
+
+func TestSidebar_RepoPathHint(t *testing.T) {
+	sidebar := NewSidebar()
+	sidebar.SetSize(60, 20)
+	sidebar.SetSessions([]config.Session{
+		{ID: "s1", RepoPath: "/srv/work/api", Branch: "b1", Name: "one"},
+		{ID: "s2", RepoPath: "/srv/home/api", Branch: "b2", Name: "two"},
+		{ID: "s3", RepoPath: "/srv/web", Branch: "b3", Name: "three"},
+	})
+
+	if view := ansi.Strip(sidebar.View()); !strings.Contains(view, "/srv/work/api") {
+		t.Errorf("expected the full path of a disambiguated repo, got:\n%s", view)
+	}
+
+	// A header showing the plain basename needs no hint
+	sidebar.SetSessions([]config.Session{{ID: "s3", RepoPath: "/srv/web", Branch: "b3", Name: "three"}})
+	if view := ansi.Strip(sidebar.View()); strings.Contains(view, "/srv/web") {
+		t.Errorf("expected no path hint for a plain header, got:\n%s", view)
+	}
+}
+
+func TestSidebar_RepoLabelsCoverConfiguredRepos(t *testing.T) {
+	sidebar := NewSidebar()
+	sidebar.SetSize(60, 20)
+	sessions := []config.Session{{ID: "s1", RepoPath: "/srv/work/api", Branch: "b1", Name: "one"}}
+	sidebar.SetSessions(sessions)
+	if got := sidebar.groups[0].Label; got != "api" {
+		t.Fatalf("label = %q, want %q", got, "api")
+	}
+
+	// A configured repo sharing the basename has no sessions, yet the header must tell them apart
+	sidebar.SetRepos([]string{"/srv/work/api", "/srv/home/api"})
+	sidebar.SetSessions(sessions)
+	if got := sidebar.groups[0].Label; got != "work/api" {
+		t.Errorf("label = %q, want %q", got, "work/api")
+	}
+}
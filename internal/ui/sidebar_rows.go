@@ -0,0 +1,266 @@
+package ui
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/config"
+)
+
+// sidebarRowBuffer is how many rows either side of the visible window are
+// rendered as well, so their heights are known before they scroll into view.
+const sidebarRowBuffer = 5
+
+// sidebarEntry is an item in the sidebar's list: a repo group's header, or a
+// session's row.
+type sidebarEntry struct {
+	header      []string        // Lines of a group's header; nil for a session's row
+	sess        *config.Session // Session of a row
+	index       int             // Position of the row's session, as selectedIdx counts
+	depth       int
+	hasChildren bool
+	isLastChild bool
+	flat        bool // Row of the filtered list, shown without the tree
+}
+
+// cachedRow is a session's row as last rendered unselected, along with the
+// hash of everything it was rendered from.
+type cachedRow struct {
+	hash  uint64
+	lines []string
+}
+
+// listEntries returns the items of the list shown: each repo group's header
+// followed by its session tree, or while filtered, the header of each group
+// with matches followed by them.
+func (s *Sidebar) listEntries(innerWidth int) []sidebarEntry {
+	repoStyle := lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Bold(true)
+	if s.filterQuery != "" {
+		return s.filteredEntries(innerWidth, repoStyle)
+	}
+
+	entries := make([]sidebarEntry, 0, len(s.sessions)+len(s.groups))
+	sessionIdx := 0
+	var addNode func(node *sessionNode, depth int, isLastChild bool)
+	addNode = func(node *sessionNode, depth int, isLastChild bool) {
+		entries = append(entries, sidebarEntry{
+			sess:        &node.Session,
+			index:       sessionIdx,
+			depth:       depth,
+			hasChildren: len(node.Children) > 0,
+			isLastChild: isLastChild,
+		})
+		sessionIdx++
+		for i := range node.Children {
+			addNode(&node.Children[i], depth+1, i == len(node.Children)-1)
+		}
+	}
+	for i, group := range s.groups {
+		header := []string{repoStyle.Render(fitRepoLabel(group.Label, innerWidth))}
+		// Blank line between repos (not before the first one)
+		if i > 0 {
+			header = append([]string{""}, header...)
+		}
+		entries = append(entries, sidebarEntry{header: header})
+		for j := range group.RootNodes {
+			addNode(&group.RootNodes[j], 0, j == len(group.RootNodes)-1)
+		}
+	}
+	return entries
+}
+
+// filteredEntries returns the list's items while filtered: the matching
+// sessions, flat, under the headers of the groups they are in.
+func (s *Sidebar) filteredEntries(innerWidth int, repoStyle lipgloss.Style) []sidebarEntry {
+	matchIdx := make(map[string]int, len(s.filteredSessions))
+	for i, sess := range s.filteredSessions {
+		matchIdx[sess.ID] = i
+	}
+	entries := make([]sidebarEntry, 0, len(s.filteredSessions)+len(s.groups))
+	for _, group := range s.groups {
+		var groupSessions []config.Session
+		flattenSessionTree(group.RootNodes, &groupSessions)
+		header := []string{repoStyle.Render(fitRepoLabel(group.Label, innerWidth))}
+		// Blank line between repos (not before the first one)
+		if len(entries) > 0 {
+			header = append([]string{""}, header...)
+		}
+		headerAdded := false
+		for _, sess := range groupSessions {
+			i, ok := matchIdx[sess.ID]
+			if !ok {
+				continue
+			}
+			// Groups without matches are left out
+			if !headerAdded {
+				entries = append(entries, sidebarEntry{header: header})
+				headerAdded = true
+			}
+			entries = append(entries, sidebarEntry{sess: &s.filteredSessions[i], index: i, isLastChild: true, flat: true})
+		}
+	}
+	return entries
+}
+
+// renderList renders the part of the list in the window of visibleHeight
+// lines at the scroll offset, first scrolling to keep the selected session in
+// view. Only rows in or near the window are rendered; those further away count
+// as the height they were last rendered at, or one line if they never were.
+func (s *Sidebar) renderList(visibleHeight, innerWidth int) string {
+	if visibleHeight <= 0 {
+		return ""
+	}
+	entries := s.listEntries(innerWidth)
+	rendered := make([][]string, len(entries))
+	heights := make([]int, len(entries))
+	starts := make([]int, len(entries))
+	for i, e := range entries {
+		if e.sess == nil || e.index == s.selectedIdx {
+			rendered[i] = s.entryLines(e, innerWidth)
+			heights[i] = len(rendered[i])
+		} else if row, ok := s.rowCache[e.sess.ID]; ok {
+			heights[i] = len(row.lines)
+		} else {
+			heights[i] = 1
+		}
+	}
+
+	// Rendering rows may show their heights were guessed wrong, moving the
+	// window; repeat until every row in it is rendered
+	var first, last int
+	for {
+		total, selectedStart := 0, 0
+		for i, e := range entries {
+			starts[i] = total
+			if e.sess != nil && e.index == s.selectedIdx {
+				selectedStart = total
+			}
+			total += heights[i]
+		}
+
+		// Adjust scroll to keep selected session visible
+		if selectedStart < s.scrollOffset {
+			s.scrollOffset = selectedStart
+		} else if selectedStart >= s.scrollOffset+visibleHeight {
+			s.scrollOffset = selectedStart - visibleHeight + 1
+		}
+		maxScroll := max(total-visibleHeight, 0)
+		s.scrollOffset = max(min(s.scrollOffset, maxScroll), 0)
+
+		first, last = 0, len(entries)-1
+		for first < last && starts[first]+heights[first] <= s.scrollOffset {
+			first++
+		}
+		for last > first && starts[last] >= s.scrollOffset+visibleHeight {
+			last--
+		}
+
+		changed := false
+		for i := max(first-sidebarRowBuffer, 0); i <= min(last+sidebarRowBuffer, len(entries)-1); i++ {
+			if rendered[i] != nil {
+				continue
+			}
+			rendered[i] = s.entryLines(entries[i], innerWidth)
+			if len(rendered[i]) != heights[i] {
+				heights[i] = len(rendered[i])
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	var lines []string
+	for i := first; i <= last; i++ {
+		lines = append(lines, rendered[i]...)
+	}
+	lines = lines[min(s.scrollOffset-starts[first], len(lines)):]
+	if len(lines) > visibleHeight {
+		lines = lines[:visibleHeight]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// entryLines returns the lines of an item in the list. The selected row is
+// rendered afresh; other rows come from the row cache unless anything they are
+// rendered from has changed since.
+func (s *Sidebar) entryLines(e sidebarEntry, innerWidth int) []string {
+	if e.sess == nil {
+		return e.header
+	}
+	if e.index == s.selectedIdx {
+		displayName := s.renderSessionNode(*e.sess, e.depth, true, e.hasChildren, e.isLastChild)
+		if e.flat {
+			displayName = "> " + strings.TrimPrefix(displayName, "  ")
+		}
+		return strings.Split(SidebarSelectedStyle.Width(innerWidth).Render(displayName), "\n")
+	}
+
+	hash := s.rowHash(e, innerWidth)
+	if row, ok := s.rowCache[e.sess.ID]; ok && row.hash == hash {
+		return row.lines
+	}
+	if s.onRowRender != nil {
+		s.onRowRender(e.sess.ID)
+	}
+	displayName := s.renderSessionNode(*e.sess, e.depth, false, e.hasChildren, e.isLastChild)
+	lines := strings.Split(SidebarItemStyle.Width(innerWidth).Render(displayName), "\n")
+	s.rowCache[e.sess.ID] = cachedRow{hash: hash, lines: lines}
+	return lines
+}
+
+// rowHash computes a hash of everything an unselected session's row is
+// rendered from, so that a row is rendered again only when one of them changes.
+func (s *Sidebar) rowHash(e sidebarEntry, innerWidth int) uint64 {
+	h := fnv.New64a()
+	writeString := func(v string) {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	writeBool := func(v bool) {
+		if v {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	}
+
+	sess, id := e.sess, e.sess.ID
+	writeString(sess.Name)
+	writeString(s.pinnedRepos[id])
+	writeString(strings.Join(sess.Tags, ","))
+	writeString(string(CurrentThemeName()))
+	writeString(strconv.Itoa(innerWidth))
+	writeString(strconv.Itoa(e.depth))
+	streaming := s.IsSessionStreaming(id)
+	if streaming {
+		writeString(s.spinner.View())
+	}
+	for _, v := range []bool{
+		e.hasChildren, e.isLastChild, streaming,
+		s.missing[id], s.HasPendingPermission(id), s.hasNewComments[id], s.fileOverlaps[id], s.backgroundPaused,
+		s.multiSelectMode, s.selectedSessions[id],
+		sess.Merged, sess.MergedToParent, sess.PRMerged, sess.MergedUpstream, sess.PRClosed, sess.PRCreated, sess.Autonomous,
+	} {
+		writeBool(v)
+	}
+	return h.Sum64()
+}
+
+// pruneRowCache drops the cached rows of sessions no longer in the list.
+func (s *Sidebar) pruneRowCache() {
+	ids := make(map[string]bool, len(s.sessions))
+	for _, sess := range s.sessions {
+		ids[sess.ID] = true
+	}
+	for id := range s.rowCache {
+		if !ids[id] {
+			delete(s.rowCache, id)
+		}
+	}
+}
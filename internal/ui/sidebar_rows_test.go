@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/config"
+)
+
+// manySessions returns n sessions spread over a few repos, every tenth one a
+// fork of the session before it.
+func manySessions(n int) []config.Session {
+	sessions := make([]config.Session, n)
+	for i := range sessions {
+		sessions[i] = config.Session{
+			ID:       fmt.Sprintf("s%03d", i),
+			RepoPath: fmt.Sprintf("/code/repo%d", i%4),
+			Branch:   fmt.Sprintf("b%03d", i),
+			Name:     fmt.Sprintf("repo/session-%03d", i),
+		}
+		if i%10 == 9 {
+			sessions[i].ParentID = sessions[i-4].ID
+		}
+	}
+	return sessions
+}
+
+// countRowRenders returns the number of times each session's row is rendered
+// from now on.
+func countRowRenders(sidebar *Sidebar) map[string]int {
+	renders := make(map[string]int)
+	sidebar.onRowRender = func(id string) { renders[id]++ }
+	return renders
+}
+
+func TestSidebar_ScrollingRendersEachRowOnce(t *testing.T) {
+	sessions := manySessions(300)
+	sidebar := NewSidebar()
+	sidebar.SetSize(40, 20)
+	sidebar.SetFocused(true)
+	sidebar.SetSessions(sessions)
+	renders := countRowRenders(sidebar)
+
+	seen := make(map[string]bool)
+	for i := range sessions {
+		if i > 0 {
+			sidebar.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+		}
+		selected := sidebar.SelectedSession()
+		view := ansi.Strip(sidebar.View())
+		if !strings.Contains(view, strings.TrimPrefix(selected.Name, "repo/")) {
+			t.Fatalf("expected the selected session %s in view, got:\n%s", selected.ID, view)
+		}
+		for _, sess := range sessions {
+			if strings.Contains(view, strings.TrimPrefix(sess.Name, "repo/")) {
+				seen[sess.ID] = true
+			}
+		}
+	}
+
+	for _, sess := range sessions {
+		if !seen[sess.ID] {
+			t.Errorf("expected %s shown while scrolling", sess.ID)
+		}
+		if renders[sess.ID] != 1 {
+			t.Errorf("expected %s rendered once, rendered %d times", sess.ID, renders[sess.ID])
+		}
+	}
+}
+
+func TestSidebar_OnlyChangedRowRenderedAgain(t *testing.T) {
+	sidebar := NewSidebar()
+	sidebar.SetSize(40, 20)
+	sidebar.SetSessions(manySessions(50))
+	sidebar.View()
+	renders := countRowRenders(sidebar)
+
+	sessions := manySessions(50)
+	sessions[4].Name = "repo/renamed"
+	sidebar.SetSessions(sessions)
+	view := ansi.Strip(sidebar.View())
+
+	if !strings.Contains(view, "renamed") {
+		t.Errorf("expected the new name shown, got:\n%s", view)
+	}
+	if len(renders) != 1 || renders["s004"] != 1 {
+		t.Errorf("expected only the changed row rendered again, got %v", renders)
+	}
+}
+
+func TestSidebar_RowsOutsideWindowNotRendered(t *testing.T) {
+	sidebar := NewSidebar()
+	sidebar.SetSize(40, 20)
+	renders := countRowRenders(sidebar)
+	sidebar.SetSessions(manySessions(500))
+	sidebar.View()
+
+	if len(renders) == 0 || len(renders) > 20+2*sidebarRowBuffer {
+		t.Errorf("expected only the rows in or near the window rendered, rendered %d", len(renders))
+	}
+}
+
+func TestSidebar_VirtualizedFilterAndMultiSelect(t *testing.T) {
+	sidebar := NewSidebar()
+	sidebar.SetSize(40, 20)
+	sidebar.SetFocused(true)
+	sidebar.SetSessions(manySessions(300))
+	sidebar.View()
+
+	// Filtering shows the matches flat under their repos, the selected one marked
+	sidebar.EnterSearchMode()
+	for _, r := range "session-27" {
+		sidebar.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	sidebar.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	view := ansi.Strip(sidebar.View())
+	if !strings.Contains(view, "> ") || !strings.Contains(view, "session-272") || strings.Contains(view, "session-280") {
+		t.Errorf("expected only the matches shown, got:\n%s", view)
+	}
+	if sess := sidebar.SelectedSession(); sess == nil || sess.ID != sidebar.filteredSessions[1].ID {
+		t.Errorf("expected the second match selected, got %v", sess)
+	}
+	sidebar.ExitSearchMode()
+
+	// Checkboxes appear on rows already cached
+	sidebar.EnterMultiSelect()
+	sidebar.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	sidebar.ToggleSelected()
+	if view := ansi.Strip(sidebar.View()); strings.Count(view, "[ ]") == 0 || strings.Count(view, "[x]") != 2 {
+		t.Errorf("expected checkboxes on every row and two checked, got:\n%s", view)
+	}
+}
+
+// BenchmarkSidebar_Update500 measures moving the selection and rendering the
+// sidebar with 500 sessions, rendering every row as the sidebar used to
+// against rendering only those in view.
+func BenchmarkSidebar_Update500(b *testing.B) {
+	down := tea.KeyPressMsg{Code: tea.KeyDown}
+	newSidebar := func() *Sidebar {
+		sidebar := NewSidebar()
+		sidebar.SetSize(40, 40)
+		sidebar.SetFocused(true)
+		sidebar.SetSessions(manySessions(500))
+		sidebar.SetStreaming("s003", true)
+		return sidebar
+	}
+
+	b.Run("every-row", func(b *testing.B) {
+		sidebar := newSidebar()
+		width := GetViewContext().InnerWidth(sidebar.width)
+		for b.Loop() {
+			sidebar.Update(down)
+			for _, e := range sidebar.listEntries(width) {
+				if e.sess != nil {
+					displayName := sidebar.renderSessionNode(*e.sess, e.depth, e.index == sidebar.selectedIdx, e.hasChildren, e.isLastChild)
+					SidebarItemStyle.Width(width).Render(displayName)
+				}
+			}
+		}
+	})
+
+	b.Run("virtualized", func(b *testing.B) {
+		sidebar := newSidebar()
+		for b.Loop() {
+			sidebar.Update(down)
+			sidebar.View()
+		}
+	})
+}
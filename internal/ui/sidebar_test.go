@@ -1,10 +1,13 @@
 package ui
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
 	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/zhubert/plural/internal/config"
 )
 
@@ -680,6 +683,35 @@ func TestSidebar_SessionPriority_Ordering(t *testing.T) {
 	}
 }
 
+func TestSidebar_NodePriority_Merged(t *testing.T) {
+	sidebar := NewSidebar()
+	merged := config.Session{ID: "s1", MergedUpstream: true}
+
+	sidebar.SetIdleWithResponse("s1", true)
+	sidebar.SetUncommittedChanges("s1", true)
+	if p := sidebar.nodePriority(merged); p != priorityNormal {
+		t.Errorf("Merged session should not need attention, got %d", p)
+	}
+
+	sidebar.SetPendingQuestion("s1", true)
+	if p := sidebar.nodePriority(merged); p != priorityPermission {
+		t.Errorf("Merged session asking a question should need attention, got %d", p)
+	}
+}
+
+func TestSidebar_MergedBadge(t *testing.T) {
+	sidebar := NewSidebar()
+
+	result := sidebar.renderSessionNode(config.Session{ID: "s1", Name: "repo/merged", MergedUpstream: true}, 0, false, false, true)
+	if !strings.Contains(result, "merged") || !strings.Contains(result, "✓") {
+		t.Errorf("Expected merged badge and symbol in %q", result)
+	}
+	result = sidebar.renderSessionNode(config.Session{ID: "s2", Name: "repo/open"}, 0, false, false, true)
+	if strings.Contains(result, "merged") {
+		t.Errorf("Unexpected merged badge in %q", result)
+	}
+}
+
 func TestSidebar_EffectivePriority(t *testing.T) {
 	sidebar := NewSidebar()
 
@@ -1195,10 +1227,7 @@ func TestSidebar_SelectSession_SearchMode(t *testing.T) {
 	// Enable search mode and filter for "ap" (should match "apple" and "apricot")
 	sidebar.searchMode = true
 	sidebar.searchInput.SetValue("ap")
-	sidebar.filteredSessions = []config.Session{
-		sessions[0], // apple
-		sessions[3], // apricot
-	}
+	sidebar.SetFilter("ap")
 
 	// Select "apricot" (session-4)
 	sidebar.SelectSession("session-4")
@@ -1235,9 +1264,7 @@ func TestSidebar_SelectSession_SearchMode_NotInFilteredList(t *testing.T) {
 	// Enable search mode and filter for "ban" (should match only "banana")
 	sidebar.searchMode = true
 	sidebar.searchInput.SetValue("ban")
-	sidebar.filteredSessions = []config.Session{
-		sessions[1], // banana
-	}
+	sidebar.SetFilter("ban")
 
 	// Try to select "cherry" which is not in the filtered list
 	sidebar.SelectSession("session-3")
@@ -1248,6 +1275,114 @@ func TestSidebar_SelectSession_SearchMode_NotInFilteredList(t *testing.T) {
 	}
 }
 
+func TestSidebar_Filter(t *testing.T) {
+	sidebar := NewSidebar()
+	sidebar.SetSize(60, 20)
+	sidebar.SetFocused(true)
+	sessions := []config.Session{
+		{ID: "s1", RepoPath: "/code/api", Branch: "fix-login", Name: "api/login"},
+		{ID: "s2", RepoPath: "/code/api", Branch: "add-cache", Name: "api/cache"},
+		{ID: "s3", RepoPath: "/code/web", Branch: "fix-logout", Name: "web/logout"},
+		{ID: "s4", RepoPath: "/code/docs", Branch: "typos", Name: "docs/typos"},
+	}
+	sidebar.SetSessions(sessions)
+	ids := func() []string {
+		var ids []string
+		for _, sess := range sidebar.getDisplaySessions() {
+			ids = append(ids, sess.ID)
+		}
+		return ids
+	}
+
+	// Fuzzy matching across name, repo and branch
+	sidebar.SetFilter("flgn")
+	if got := ids(); !slices.Equal(got, []string{"s1"}) {
+		t.Errorf("expected a fuzzy match on the branch, got %v", got)
+	}
+	sidebar.SetFilter("web lgt")
+	if got := ids(); !slices.Equal(got, []string{"s3"}) {
+		t.Errorf("expected each word matched on its own, got %v", got)
+	}
+	sidebar.SetFilter("log")
+	if got := ids(); !slices.Equal(got, []string{"s1", "s3"}) {
+		t.Fatalf("expected both login sessions, got %v", got)
+	}
+
+	// Groups without matches are hidden
+	view := ansi.Strip(sidebar.View())
+	if !strings.Contains(view, "api") || !strings.Contains(view, "web") || strings.Contains(view, "docs") {
+		t.Errorf("expected only the repos with matches, got:\n%s", view)
+	}
+	if strings.Contains(view, "cache") {
+		t.Errorf("expected sessions not matching hidden, got:\n%s", view)
+	}
+
+	// j/k move only through the matches
+	sidebar.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	sidebar.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if sess := sidebar.SelectedSession(); sess == nil || sess.ID != "s3" {
+		t.Errorf("expected the last match selected, got %v", sess)
+	}
+
+	// The selection is clamped as the matches shrink
+	sessions[2].Name, sessions[2].Branch = "web/signup", "signup"
+	sidebar.SetSessions(sessions)
+	if got := ids(); !slices.Equal(got, []string{"s1"}) {
+		t.Fatalf("expected the filter applied to the new sessions, got %v", got)
+	}
+	if sess := sidebar.SelectedSession(); sess == nil || sess.ID != "s1" {
+		t.Errorf("expected the selection clamped to the matches, got %v", sess)
+	}
+
+	// Nothing matching shows an empty list, not every session
+	sidebar.SetFilter("zzz")
+	if sidebar.SelectedSession() != nil {
+		t.Error("expected no selection without matches")
+	}
+	if view := ansi.Strip(sidebar.View()); !strings.Contains(view, "No matches.") {
+		t.Errorf("expected no matches shown, got:\n%s", view)
+	}
+
+	sidebar.ClearFilter()
+	if got := ids(); len(got) != len(sessions) {
+		t.Errorf("expected every session after clearing, got %v", got)
+	}
+}
+
+func TestSidebar_SearchModeKeepsFilter(t *testing.T) {
+	sidebar := NewSidebar()
+	sidebar.SetFocused(true)
+	sidebar.SetSessions([]config.Session{
+		{ID: "s1", RepoPath: "/repo", Branch: "b1", Name: "apple"},
+		{ID: "s2", RepoPath: "/repo", Branch: "b2", Name: "banana"},
+	})
+
+	sidebar.EnterSearchMode()
+	for _, r := range "ban" {
+		sidebar.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	sidebar.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if sidebar.IsSearchMode() || !sidebar.HasFilter() {
+		t.Fatal("expected enter to leave search mode keeping the filter")
+	}
+	if sess := sidebar.SelectedSession(); sess == nil || sess.ID != "s2" {
+		t.Errorf("expected the match selected, got %v", sess)
+	}
+
+	// Searching again edits the filter
+	sidebar.EnterSearchMode()
+	if sidebar.GetSearchQuery() != "ban" {
+		t.Errorf("expected the filter's query to edit, got %q", sidebar.GetSearchQuery())
+	}
+	sidebar.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if sidebar.HasFilter() || len(sidebar.getDisplaySessions()) != 2 {
+		t.Error("expected escape to clear the filter")
+	}
+	if sess := sidebar.SelectedSession(); sess == nil || sess.ID != "s2" {
+		t.Errorf("expected the selection kept, got %v", sess)
+	}
+}
+
 func TestSidebar_HashSessions_PRMergedPRClosed(t *testing.T) {
 	sessBase := []config.Session{
 		{ID: "s1", RepoPath: "/repo", Branch: "b1", PRCreated: true},
@@ -1410,6 +1545,30 @@ func TestSidebar_RenderSessionNode_NewCommentsIndicator(t *testing.T) {
 	}
 }
 
+func TestSidebar_RenderSessionNode_BackgroundPaused(t *testing.T) {
+	sidebar := NewSidebar()
+	session := config.Session{ID: "s1", Name: "test"}
+	sidebar.SetBackgroundPaused(true)
+
+	// No polled badges - nothing to mark stale
+	result := sidebar.renderSessionNode(session, 0, false, false, true)
+	if strings.Contains(result, "⏸") {
+		t.Errorf("Should not mark a session without polled badges stale, got %q", result)
+	}
+
+	sidebar.SetFileOverlap("s1", true)
+	result = sidebar.renderSessionNode(session, 0, false, false, true)
+	if !strings.Contains(result, "!") || !strings.Contains(result, "⏸") {
+		t.Errorf("Should keep the overlap badge and mark it stale, got %q", result)
+	}
+
+	sidebar.SetBackgroundPaused(false)
+	result = sidebar.renderSessionNode(session, 0, false, false, true)
+	if strings.Contains(result, "⏸") {
+		t.Errorf("Should not mark badges stale once resumed, got %q", result)
+	}
+}
+
 func TestSidebar_SelectSession_NormalMode(t *testing.T) {
 	sidebar := NewSidebar()
 
@@ -1440,3 +1599,92 @@ func TestSidebar_SelectSession_NormalMode(t *testing.T) {
 		t.Errorf("Expected selected session-2, got %s", selected.ID)
 	}
 }
+
+func TestSidebar_PinnedGroup(t *testing.T) {
+	sidebar := NewSidebar()
+	sidebar.SetSize(60, 20)
+	sidebar.SetSessions([]config.Session{
+		{ID: "s1", RepoPath: "/code/api", Branch: "b1", Name: "api/one"},
+		{ID: "s2", RepoPath: "/code/web", Branch: "b2", Name: "web/two"},
+		{ID: "s3", RepoPath: "/code/web", Branch: "b3", Name: "web/three", Pinned: true},
+	})
+
+	if len(sidebar.groups) != 3 || !sidebar.groups[0].Pinned {
+		t.Fatalf("expected the Pinned group first, got %+v", sidebar.groups)
+	}
+	// Navigation starts in the Pinned group and continues into the repo groups
+	if sess := sidebar.SelectedSession(); sess == nil || sess.ID != "s3" {
+		t.Fatalf("expected the pinned session selected first, got %v", sess)
+	}
+	sidebar.SetFocused(true)
+	sidebar.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if sess := sidebar.SelectedSession(); sess == nil || sess.ID != "s1" {
+		t.Errorf("expected the next session to be the first repo group's, got %v", sess)
+	}
+
+	view := ansi.Strip(sidebar.View())
+	pinnedAt, apiAt := strings.Index(view, "Pinned"), strings.Index(view, "api")
+	if pinnedAt < 0 || apiAt < pinnedAt {
+		t.Errorf("expected the Pinned header above the repo groups, got:\n%s", view)
+	}
+	if !strings.Contains(view, "three · web") {
+		t.Errorf("expected the pinned session's repo inline, got:\n%s", view)
+	}
+	if strings.Contains(view, "two · web") {
+		t.Errorf("expected unpinned sessions without their repo inline, got:\n%s", view)
+	}
+}
+
+func TestSidebar_Tags(t *testing.T) {
+	sidebar := NewSidebar()
+	sidebar.SetSize(60, 20)
+	sidebar.SetSessions([]config.Session{
+		{ID: "s1", RepoPath: "/code/api", Branch: "b1", Name: "api/one", Tags: []string{"bug"}},
+		{ID: "s2", RepoPath: "/code/web", Branch: "b2", Name: "web/two", Tags: []string{"bug", "spike"}},
+		{ID: "s3", RepoPath: "/code/web", Branch: "b3", Name: "web/three"},
+	})
+
+	view := ansi.Strip(sidebar.View())
+	if !strings.Contains(view, "one #bug") || !strings.Contains(view, "two #bug #spike") {
+		t.Errorf("expected tag chips after the session names, got:\n%s", view)
+	}
+
+	filtered := func(query string) []string {
+		sidebar.applyFilter(query)
+		var ids []string
+		for _, sess := range sidebar.filteredSessions {
+			ids = append(ids, sess.ID)
+		}
+		return ids
+	}
+	if got := filtered("spi"); !slices.Equal(got, []string{"s2"}) {
+		t.Errorf("expected text to match tags, got %v", got)
+	}
+	if got := filtered("#bug"); !slices.Equal(got, []string{"s1", "s2"}) {
+		t.Errorf("expected #bug to require the tag, got %v", got)
+	}
+	if got := filtered("#bug web"); !slices.Equal(got, []string{"s2"}) {
+		t.Errorf("expected a tag and text to compose, got %v", got)
+	}
+	if got := filtered("#bug #spike"); !slices.Equal(got, []string{"s2"}) {
+		t.Errorf("expected every tag required, got %v", got)
+	}
+	sidebar.ClearFilter()
+
+	// Tags changing re-renders the sessions
+	sidebar.SetSessions([]config.Session{
+		{ID: "s1", RepoPath: "/code/api", Branch: "b1", Name: "api/one", Tags: []string{"feature"}},
+	})
+	if view := ansi.Strip(sidebar.View()); !strings.Contains(view, "one #feature") {
+		t.Errorf("expected the new tag shown, got:\n%s", view)
+	}
+
+	sidebar.SetTagFilter("feature")
+	if view := ansi.Strip(sidebar.View()); !strings.Contains(view, "Tagged #feature") {
+		t.Errorf("expected the tag filtered by shown, got:\n%s", view)
+	}
+	sidebar.SetSessions(nil)
+	if view := ansi.Strip(sidebar.View()); !strings.Contains(view, "No sessions with this tag.") {
+		t.Errorf("expected the empty tag filter explained, got:\n%s", view)
+	}
+}
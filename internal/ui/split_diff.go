@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// minSplitDiffWidth is the narrowest width a split diff is rendered at. Below it
+// the columns are too narrow to read, and the unified diff is shown instead.
+const minSplitDiffWidth = 60
+
+// splitDiffSeparator divides the old and new columns of a split diff.
+const splitDiffSeparator = " │ "
+
+// hunkHeaderPattern matches a unified diff hunk header, capturing the start and
+// (optional) line count of the old and new ranges.
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// splitCell is one side of a split diff row.
+type splitCell struct {
+	Kind byte   // '-', '+', ' ', '\\' (no newline note), or 0 for an empty cell
+	Num  int    // Line number in the old or new file (0 if none)
+	Text string // Line content without the diff marker
+}
+
+// splitRow is a row of a split diff: either a line spanning both columns (file
+// headers, hunk markers) or a pair of old and new cells.
+type splitRow struct {
+	Full     string // Line spanning both columns ("" for a paired row)
+	Old, New splitCell
+}
+
+// parseSplitDiff parses a unified diff into split rows. Within a hunk, a run of
+// removed lines is paired row by row with the added lines that follow it.
+// Returns false if a hunk header can't be parsed (e.g., combined diffs).
+func parseSplitDiff(diff string) ([]splitRow, int, bool) {
+	var rows []splitRow
+	var removed, added []splitCell
+	var oldNum, newNum, oldLeft, newLeft, maxNum int
+	var last byte
+
+	flush := func() {
+		for i := range max(len(removed), len(added)) {
+			var row splitRow
+			if i < len(removed) {
+				row.Old = removed[i]
+			}
+			if i < len(added) {
+				row.New = added[i]
+			}
+			rows = append(rows, row)
+		}
+		removed, added = nil, nil
+	}
+
+	for line := range strings.SplitSeq(strings.TrimRight(diff, "\n"), "\n") {
+		inHunk := oldLeft > 0 || newLeft > 0
+		switch {
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file" belongs to the line before it
+			note := splitCell{Kind: '\\', Text: line}
+			switch last {
+			case '-':
+				removed = append(removed, note)
+			case '+':
+				added = append(added, note)
+			default:
+				flush()
+				rows = append(rows, splitRow{Old: note, New: note})
+			}
+		case inHunk && strings.HasPrefix(line, "-"):
+			if len(added) > 0 {
+				flush()
+			}
+			removed = append(removed, splitCell{Kind: '-', Num: oldNum, Text: line[1:]})
+			oldNum++
+			oldLeft--
+			last = '-'
+		case inHunk && strings.HasPrefix(line, "+"):
+			added = append(added, splitCell{Kind: '+', Num: newNum, Text: line[1:]})
+			newNum++
+			newLeft--
+			last = '+'
+		case inHunk:
+			// Context line; an empty line is context whose leading space was stripped
+			flush()
+			text := strings.TrimPrefix(line, " ")
+			rows = append(rows, splitRow{
+				Old: splitCell{Kind: ' ', Num: oldNum, Text: text},
+				New: splitCell{Kind: ' ', Num: newNum, Text: text},
+			})
+			oldNum++
+			newNum++
+			oldLeft--
+			newLeft--
+			last = ' '
+		default:
+			flush()
+			if strings.HasPrefix(line, "@@") {
+				m := hunkHeaderPattern.FindStringSubmatch(line)
+				if m == nil {
+					return nil, 0, false
+				}
+				oldNum, oldLeft = hunkRange(m[1], m[2])
+				newNum, newLeft = hunkRange(m[3], m[4])
+				maxNum = max(maxNum, oldNum+oldLeft, newNum+newLeft)
+			}
+			rows = append(rows, splitRow{Full: line})
+			last = 0
+		}
+	}
+	flush()
+	return rows, maxNum, true
+}
+
+// hunkRange returns the start and line count of a hunk header range. The count
+// defaults to 1 when omitted.
+func hunkRange(start, count string) (int, int) {
+	s, _ := strconv.Atoi(start)
+	n := 1
+	if count != "" {
+		n, _ = strconv.Atoi(count)
+	}
+	return s, n
+}
+
+// renderSplitDiff renders a unified diff side by side, old lines on the left and
+// new lines on the right, wrapping long lines within their column. Returns false
+// when width is too narrow to split usefully or the diff can't be parsed.
+func renderSplitDiff(diff string, width int) (string, bool) {
+	if width < minSplitDiffWidth {
+		return "", false
+	}
+	rows, maxNum, ok := parseSplitDiff(diff)
+	if !ok {
+		return "", false
+	}
+
+	numWidth := len(strconv.Itoa(max(maxNum, 1)))
+	columns := width - ansi.StringWidth(splitDiffSeparator)
+	oldWidth := columns / 2
+	newWidth := columns - oldWidth
+	separator := lipgloss.NewStyle().Foreground(ColorBorder).Render(splitDiffSeparator)
+
+	var result strings.Builder
+	for _, row := range rows {
+		if row.Full != "" || (row.Old.Kind == 0 && row.New.Kind == 0) {
+			result.WriteString(HighlightDiff(row.Full))
+			result.WriteString("\n")
+			continue
+		}
+		oldLines := renderSplitCell(row.Old, oldWidth, numWidth)
+		newLines := renderSplitCell(row.New, newWidth, numWidth)
+		for i := range max(len(oldLines), len(newLines)) {
+			left := strings.Repeat(" ", oldWidth)
+			if i < len(oldLines) {
+				left = oldLines[i]
+			}
+			result.WriteString(left)
+			result.WriteString(separator)
+			if i < len(newLines) {
+				result.WriteString(newLines[i])
+			}
+			result.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(result.String(), "\n"), true
+}
+
+// renderSplitCell renders one side of a split diff row as lines of exactly width
+// cells: the line number gutter, then the line wrapped to the remaining width.
+func renderSplitCell(cell splitCell, width, numWidth int) []string {
+	if cell.Kind == 0 {
+		return nil
+	}
+	textWidth := max(width-numWidth-1, 1)
+
+	var style lipgloss.Style
+	switch cell.Kind {
+	case '-':
+		style = DiffRemovedStyle
+	case '+':
+		style = DiffAddedStyle
+	case '\\':
+		style = lipgloss.NewStyle().Foreground(ColorTextMuted).Italic(true)
+	default:
+		style = lipgloss.NewStyle()
+	}
+	gutterStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+
+	gutter := strings.Repeat(" ", numWidth)
+	if cell.Num > 0 {
+		gutter = strconv.Itoa(cell.Num)
+		gutter = strings.Repeat(" ", numWidth-len(gutter)) + gutter
+	}
+
+	text := strings.ReplaceAll(cell.Text, "\t", "    ")
+	wrapped := strings.Split(ansi.Hardwrap(text, textWidth, true), "\n")
+	lines := make([]string, len(wrapped))
+	for i, segment := range wrapped {
+		if i > 0 {
+			gutter = strings.Repeat(" ", numWidth)
+		}
+		padding := strings.Repeat(" ", max(textWidth-ansi.StringWidth(segment), 0))
+		lines[i] = gutterStyle.Render(gutter) + " " + style.Render(segment) + padding
+	}
+	return lines
+}
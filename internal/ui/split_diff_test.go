@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/zhubert/plural/internal/git"
+)
+
+const splitTestDiff = `diff --git a/main.go b/main.go
+index 1234567..89abcde 100644
+--- a/main.go
++++ b/main.go
+@@ -1,5 +1,6 @@
+ package main
+-import "fmt"
+-func old() {}
++import "os"
++func new() {}
++func extra() {}
+ 
+ func main() {}
+\ No newline at end of file`
+
+func TestParseSplitDiff_PairsChanges(t *testing.T) {
+	rows, maxNum, ok := parseSplitDiff(splitTestDiff)
+	if !ok {
+		t.Fatal("expected diff to parse")
+	}
+	if maxNum != 7 {
+		t.Errorf("maxNum = %d, want 7", maxNum)
+	}
+
+	var paired []splitRow
+	for _, row := range rows {
+		if row.Full == "" {
+			paired = append(paired, row)
+		}
+	}
+	// package, 3 change rows, blank context, func main, no-newline note
+	if len(paired) != 7 {
+		t.Fatalf("got %d paired rows, want 7: %+v", len(paired), paired)
+	}
+	if paired[1].Old.Text != `import "fmt"` || paired[1].New.Text != `import "os"` {
+		t.Errorf("expected removed and added lines paired, got %+v", paired[1])
+	}
+	if paired[3].Old.Kind != 0 || paired[3].New.Text != "func extra() {}" || paired[3].New.Num != 4 {
+		t.Errorf("expected extra added line with empty old side, got %+v", paired[3])
+	}
+	if paired[4].Old.Kind != ' ' || paired[4].Old.Num != 4 || paired[4].New.Num != 5 {
+		t.Errorf("expected blank context line numbered 4/5, got %+v", paired[4])
+	}
+	if paired[6].Old.Kind != '\\' || paired[6].New.Kind != '\\' {
+		t.Errorf("expected no-newline note on both sides after context, got %+v", paired[6])
+	}
+}
+
+func TestParseSplitDiff_DashLinesInHunk(t *testing.T) {
+	// A removed "-- comment" line starts with "---" but is not a file header
+	diff := "@@ -1,2 +1,1 @@\n--- comment\n keep"
+	rows, _, ok := parseSplitDiff(diff)
+	if !ok {
+		t.Fatal("expected diff to parse")
+	}
+	if len(rows) != 3 || rows[1].Old.Kind != '-' || rows[1].Old.Text != "-- comment" {
+		t.Errorf("expected removed line, got %+v", rows)
+	}
+}
+
+func TestParseSplitDiff_CombinedDiffFails(t *testing.T) {
+	if _, _, ok := parseSplitDiff("@@@ -1,2 -1,2 +1,2 @@@\n  a"); ok {
+		t.Error("expected combined diff to fail to parse")
+	}
+}
+
+func TestRenderSplitDiff(t *testing.T) {
+	content, ok := renderSplitDiff(splitTestDiff, 80)
+	if !ok {
+		t.Fatal("expected split render at width 80")
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if w := lipgloss.Width(line); w > 80 {
+			t.Errorf("line width %d exceeds 80: %q", w, stripANSI(line))
+		}
+	}
+
+	stripped := stripANSI(content)
+	var found bool
+	for _, line := range strings.Split(stripped, "\n") {
+		left, right, ok := strings.Cut(line, splitDiffSeparator)
+		if ok && strings.Contains(left, `import "fmt"`) && strings.Contains(right, `import "os"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected old and new import side by side, got:\n%s", stripped)
+	}
+}
+
+func TestRenderSplitDiff_WrapsLongLines(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	diff := "@@ -1 +1 @@\n-" + long + "\n+short"
+	content, ok := renderSplitDiff(diff, 60)
+	if !ok {
+		t.Fatal("expected split render at width 60")
+	}
+	lines := strings.Split(stripANSI(content), "\n")
+	// Hunk header plus the wrapped removed line
+	if len(lines) < 4 {
+		t.Fatalf("expected long line to wrap, got:\n%s", strings.Join(lines, "\n"))
+	}
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w > 60 {
+			t.Errorf("line width %d exceeds 60: %q", w, line)
+		}
+	}
+	if strings.Count(strings.Join(lines, ""), "x") != 100 {
+		t.Error("expected all of the long line to be shown")
+	}
+}
+
+func TestRenderSplitDiff_TooNarrow(t *testing.T) {
+	if _, ok := renderSplitDiff(splitTestDiff, minSplitDiffWidth-1); ok {
+		t.Error("expected no split render below the minimum width")
+	}
+}
+
+func TestViewChanges_ToggleSplit(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(100, 30)
+	chat.EnterViewChangesMode([]git.FileDiff{
+		{Filename: "main.go", Status: "M", Diff: splitTestDiff},
+	})
+	_ = chat.renderViewChangesMode(lipgloss.NewStyle())
+
+	chat, _ = chat.Update(keyPressMsg("s"))
+	if !chat.IsViewChangesSplit() {
+		t.Fatal("expected s to switch to split diffs")
+	}
+	result := stripANSI(chat.renderViewChangesMode(lipgloss.NewStyle()))
+	if !strings.Contains(result, splitDiffSeparator) {
+		t.Errorf("expected split columns, got:\n%s", result)
+	}
+
+	// The choice is remembered the next time the view is opened
+	chat.ExitViewChangesMode()
+	chat.EnterViewChangesMode([]git.FileDiff{
+		{Filename: "main.go", Status: "M", Diff: splitTestDiff},
+	})
+	if !chat.IsViewChangesSplit() {
+		t.Error("expected split diffs to be remembered")
+	}
+
+	// Too narrow to split: the unified diff is shown
+	chat.SetSize(50, 30)
+	result = stripANSI(chat.renderViewChangesMode(lipgloss.NewStyle()))
+	if strings.Contains(result, splitDiffSeparator) || !strings.Contains(result, `-import "fmt"`) {
+		t.Errorf("expected unified diff when narrow, got:\n%s", result)
+	}
+}
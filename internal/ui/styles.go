@@ -19,6 +19,14 @@ var (
 	ColorInfo        = lipgloss.Color("#06B6D4") // Cyan for info/questions
 	ColorError       = lipgloss.Color("#EF4444") // Red for errors
 	ColorSuccess     = lipgloss.Color("#10B981") // Green for success
+
+	// Overlay colors, for the permission, question, plan, and todo boxes
+	ColorOverlayBorder     = lipgloss.Color("#06B6D4") // Question, plan, and todo box borders
+	ColorOverlaySelectedBg = lipgloss.Color("#7C3AED") // Selected question option
+	ColorOverlaySelectedFg = lipgloss.Color("#F9FAFB") // Text of the selected question option
+	ColorWarningText       = lipgloss.Color("#F59E0B") // Warning titles and keys
+	ColorInfoText          = lipgloss.Color("#06B6D4") // Info titles and keys
+	ColorSuccessText       = lipgloss.Color("#10B981") // Completed todo markers
 )
 
 // Header styles
@@ -142,6 +150,16 @@ var (
 
 	ToolUseCompleteStyle = lipgloss.NewStyle().
 				Foreground(ColorSecondary) // Green circle for completed
+
+	// Markers by kind of tool, when colored by category
+	ToolReadStyle = lipgloss.NewStyle().
+			Foreground(ColorInfo)
+
+	ToolMutateStyle = lipgloss.NewStyle().
+			Foreground(ColorWarning)
+
+	ToolExecuteStyle = lipgloss.NewStyle().
+				Foreground(ColorPrimary)
 )
 
 // Permission prompt styles
@@ -152,7 +170,7 @@ var (
 				Padding(0, 1)
 
 	PermissionTitleStyle = lipgloss.NewStyle().
-				Foreground(ColorWarning).
+				Foreground(ColorWarningText).
 				Bold(true)
 
 	PermissionToolStyle = lipgloss.NewStyle().
@@ -167,15 +185,31 @@ var (
 				Italic(true)
 
 	PermissionIndicatorStyle = lipgloss.NewStyle().
-					Foreground(ColorWarning).
+					Foreground(ColorWarningText).
 					Bold(true)
+
+	// Destructive command prompts are red, with the destructive parts
+	// of the command highlighted
+	DestructiveBoxStyle = lipgloss.NewStyle().
+				Border(lipgloss.ThickBorder()).
+				BorderForeground(ColorError).
+				Padding(0, 1)
+
+	DestructiveTitleStyle = lipgloss.NewStyle().
+				Foreground(ColorError).
+				Bold(true)
+
+	DestructiveHighlightStyle = lipgloss.NewStyle().
+					Foreground(ColorError).
+					Bold(true).
+					Underline(true)
 )
 
 // Question prompt styles
 var (
 	QuestionBoxStyle = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorInfo).
+		BorderForeground(ColorOverlayBorder).
 		Padding(0, 1)
 )
 
@@ -183,7 +217,7 @@ var (
 var (
 	PlanApprovalBoxStyle = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorInfo).
+		BorderForeground(ColorOverlayBorder).
 		Padding(1, 2)
 )
 
@@ -192,7 +226,7 @@ var (
 	// Box style for the todo list container (used when inline)
 	TodoListBoxStyle = lipgloss.NewStyle().
 				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ColorInfo).
+				BorderForeground(ColorOverlayBorder).
 				Padding(0, 1)
 
 	// TodoSidebarStyle is for the todo list when shown as a sidebar panel.
@@ -212,10 +246,10 @@ var (
 
 	// Marker styles for different states (updated by regenerateStyles)
 	TodoCompletedMarkerStyle = lipgloss.NewStyle().
-					Foreground(lipgloss.Color(BuiltinThemes[DefaultTheme].DiffAdded))
+					Foreground(ColorSuccessText)
 
 	TodoInProgressMarkerStyle = lipgloss.NewStyle().
-					Foreground(ColorSecondary) // Cyan hourglass
+					Foreground(ColorInfoText)
 
 	TodoPendingMarkerStyle = lipgloss.NewStyle().
 				Foreground(ColorMuted) // Gray circle
@@ -231,6 +265,14 @@ var (
 
 	TodoPendingContentStyle = lipgloss.NewStyle().
 				Foreground(ColorTextMuted)
+
+	// Styles for items whose status the user marked (updated by regenerateStyles)
+	TodoUserMarkedMarkerStyle = lipgloss.NewStyle().
+					Foreground(ColorUser)
+
+	TodoUserMarkedContentStyle = lipgloss.NewStyle().
+					Foreground(ColorUser).
+					Italic(true)
 )
 
 // Markdown rendering styles (updated by regenerateStyles)
@@ -267,6 +309,14 @@ var (
 				Foreground(lipgloss.Color(BuiltinThemes[DefaultTheme].MarkdownCode)).
 				Background(lipgloss.Color(BuiltinThemes[DefaultTheme].MarkdownCodeBg))
 
+	MarkdownStrikethroughStyle = lipgloss.NewStyle().
+					Strikethrough(true).
+					Foreground(ColorTextMuted)
+
+	MarkdownHighlightStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color(BuiltinThemes[DefaultTheme].TextInverse)).
+				Background(lipgloss.Color(BuiltinThemes[DefaultTheme].Warning))
+
 	// Code block
 	MarkdownCodeBlockStyle = lipgloss.NewStyle().
 				Background(lipgloss.Color(BuiltinThemes[DefaultTheme].MarkdownCodeBg))
@@ -293,6 +343,11 @@ var (
 				Foreground(lipgloss.Color(BuiltinThemes[DefaultTheme].MarkdownLink)).
 				Underline(true)
 
+	// File reference (path:line)
+	MarkdownFileRefStyle = lipgloss.NewStyle().
+				Foreground(ColorSecondary).
+				Underline(true)
+
 	// Table
 	MarkdownTableBorderStyle = lipgloss.NewStyle().
 					Foreground(ColorBorder)
@@ -320,6 +375,10 @@ var (
 	DiffHunkStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color(BuiltinThemes[DefaultTheme].DiffHunk))
 
+	// LiveDiffChangedStyle briefly marks the hunks of the live diff that just changed
+	LiveDiffChangedStyle = lipgloss.NewStyle().
+				Background(lipgloss.Color(BuiltinThemes[DefaultTheme].GetBgSelected()))
+
 	// View changes file list selection style (updated by regenerateStyles)
 	ViewChangesSelectedStyle = lipgloss.NewStyle().
 					Background(lipgloss.Color(BuiltinThemes[DefaultTheme].GetBgSelected())).
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// customSyntaxStyles holds the names of chroma styles loaded from files, in load order
+var customSyntaxStyles []string
+
+// syntaxStyleOverride is the chroma style code blocks use instead of the theme's, or "" for the theme's
+var syntaxStyleOverride string
+
+// LoadSyntaxStyle reads a chroma XML style file (a <style name="..."> element of
+// <entry type="..." style="..."/> elements) and registers it with chroma under
+// its name, making it available to SetSyntaxStyle. Returns the style's name.
+func LoadSyntaxStyle(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read syntax style: %w", err)
+	}
+	style, err := parseSyntaxStyle(data)
+	if err != nil {
+		return "", fmt.Errorf("invalid chroma style in %s: %w", path, err)
+	}
+	// Reloading a custom style replaces it, but a built-in one is never replaced
+	if slices.Contains(customSyntaxStyles, style.Name) {
+		styles.Register(style)
+		return style.Name, nil
+	}
+	if _, builtin := styles.Registry[style.Name]; builtin {
+		return "", fmt.Errorf("syntax style %s: name %q is already taken by a built-in style", path, style.Name)
+	}
+	styles.Register(style)
+	customSyntaxStyles = append(customSyntaxStyles, style.Name)
+	return style.Name, nil
+}
+
+// parseSyntaxStyle parses a chroma XML style, which chroma itself validates
+// entry by entry, and checks it is a style with at least one entry.
+func parseSyntaxStyle(data []byte) (*chroma.Style, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if root.XMLName.Local != "style" {
+		return nil, fmt.Errorf("root element is <%s>, expected <style>", root.XMLName.Local)
+	}
+	style, err := chroma.NewXMLStyle(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if len(style.Types()) == 0 {
+		return nil, fmt.Errorf("style %q has no entries", style.Name)
+	}
+	return style, nil
+}
+
+// CustomSyntaxStyles returns the names of the syntax styles loaded from files
+func CustomSyntaxStyles() []string {
+	return customSyntaxStyles
+}
+
+// SetSyntaxStyle sets the chroma style for code blocks, or "" to follow the theme
+func SetSyntaxStyle(name string) {
+	syntaxStyleOverride = name
+}
+
+// SyntaxStyle returns the chroma style set with SetSyntaxStyle, or "" when code blocks follow the theme
+func SyntaxStyle() string {
+	return syntaxStyleOverride
+}
+
+// currentSyntaxStyle returns the chroma style for code blocks: the one set with
+// SetSyntaxStyle, otherwise the theme's. Falls back to DefaultSyntaxStyle when
+// the style is not registered, e.g. because its file failed to load.
+func currentSyntaxStyle() *chroma.Style {
+	name := syntaxStyleOverride
+	if name == "" {
+		name = CurrentTheme().GetSyntaxStyle()
+	}
+	if style, ok := styles.Registry[name]; ok {
+		return style
+	}
+	return styles.Registry[DefaultSyntaxStyle]
+}
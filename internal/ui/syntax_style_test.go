@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// writeStyle writes a chroma style file and returns its path.
+func writeStyle(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "style.xml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// resetSyntaxStyles undoes any custom syntax styles a test registers.
+func resetSyntaxStyles(t *testing.T) {
+	t.Cleanup(func() {
+		for _, name := range customSyntaxStyles {
+			delete(styles.Registry, name)
+		}
+		customSyntaxStyles = nil
+		syntaxStyleOverride = ""
+	})
+}
+
+func TestLoadSyntaxStyle(t *testing.T) {
+	resetSyntaxStyles(t)
+
+	path := writeStyle(t, `<style name="plural-test">
+  <entry type="Background" style="bg:#101010"/>
+  <entry type="Keyword" style="bold #ff8800"/>
+</style>`)
+	name, err := LoadSyntaxStyle(path)
+	if err != nil {
+		t.Fatalf("LoadSyntaxStyle failed: %v", err)
+	}
+	if name != "plural-test" {
+		t.Errorf("name = %q, want plural-test", name)
+	}
+	if !slices.Contains(CustomSyntaxStyles(), "plural-test") {
+		t.Errorf("CustomSyntaxStyles() = %v, want it to include plural-test", CustomSyntaxStyles())
+	}
+
+	SetSyntaxStyle("plural-test")
+	if got := currentSyntaxStyle().Name; got != "plural-test" {
+		t.Errorf("currentSyntaxStyle() = %q, want the custom style", got)
+	}
+
+	// Loading it again replaces it rather than failing
+	if _, err := LoadSyntaxStyle(path); err != nil {
+		t.Errorf("reloading failed: %v", err)
+	}
+}
+
+func TestLoadSyntaxStyle_Malformed(t *testing.T) {
+	resetSyntaxStyles(t)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"not XML", `{"name": "json"}`, "invalid chroma style"},
+		{"wrong root element", `<theme name="x"><entry type="Keyword" style="bold"/></theme>`, "expected <style>"},
+		{"no name", `<style><entry type="Keyword" style="bold"/></style>`, "missing style name"},
+		{"unknown token type", `<style name="x"><entry type="Keywerd" style="bold"/></style>`, "invalid chroma style"},
+		{"bad colour", `<style name="x"><entry type="Keyword" style="#zzzzzz"/></style>`, "invalid chroma style"},
+		{"no entries", `<style name="x"></style>`, "has no entries"},
+		{"built-in name", `<style name="monokai"><entry type="Keyword" style="bold"/></style>`, "built-in style"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadSyntaxStyle(writeStyle(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadSyntaxStyle() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadSyntaxStyle(filepath.Join(t.TempDir(), "missing.xml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if len(CustomSyntaxStyles()) != 0 {
+		t.Errorf("expected no styles registered, got %v", CustomSyntaxStyles())
+	}
+}
+
+func TestCurrentSyntaxStyle_FallsBackToMonokai(t *testing.T) {
+	resetSyntaxStyles(t)
+
+	// The theme's style when none is set
+	if got := currentSyntaxStyle().Name; got != CurrentTheme().GetSyntaxStyle() {
+		t.Errorf("currentSyntaxStyle() = %q, want the theme's %q", got, CurrentTheme().GetSyntaxStyle())
+	}
+
+	// A style whose file failed to load is not registered
+	SetSyntaxStyle("never-loaded")
+	if got := currentSyntaxStyle().Name; got != DefaultSyntaxStyle {
+		t.Errorf("currentSyntaxStyle() = %q, want %q", got, DefaultSyntaxStyle)
+	}
+}
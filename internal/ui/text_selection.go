@@ -63,6 +63,35 @@ type ClipboardErrorMsg struct {
 	Error error
 }
 
+// ClipboardCopiedMsg is sent when text has been copied to the clipboard
+type ClipboardCopiedMsg struct {
+	Confirm   string              // Confirmation to show in the footer
+	Mechanism clipboard.Mechanism // How the text reached the clipboard
+	TooLarge  bool                // Sent over OSC 52 but larger than some terminals accept
+}
+
+// CopyToClipboard returns a command that copies text using the mechanism
+// clipboard.Choose picks, then confirms with a ClipboardCopiedMsg.
+func CopyToClipboard(text, confirm string) tea.Cmd {
+	return func() tea.Msg {
+		mechanism := clipboard.Choose()
+		copied := ClipboardCopiedMsg{Confirm: confirm, Mechanism: mechanism}
+		if mechanism == clipboard.MechanismOSC52 {
+			copied.TooLarge = clipboard.OSC52TooLarge(text)
+			logger.Get().Debug("copying via OSC 52", "bytes", len(text), "tooLarge", copied.TooLarge)
+			return tea.BatchMsg{
+				tea.Raw(clipboard.OSC52(text)),
+				func() tea.Msg { return copied },
+			}
+		}
+		if err := clipboard.WriteText(text); err != nil {
+			logger.Get().Error("Failed to write to clipboard", "error", err)
+			return ClipboardErrorMsg{Error: err}
+		}
+		return copied
+	}
+}
+
 const (
 	doubleClickThreshold = 500 * time.Millisecond
 	clickTolerance       = 2 // pixels
@@ -133,8 +162,12 @@ func (c *Chat) handleMouseClick(x, y int) tea.Cmd {
 		return c.CopySelectedText()
 	case 3:
 		// Triple click - select line/paragraph and copy immediately
-		c.SelectParagraph(x, y)
 		c.selection.ClickCount = 0 // Reset after triple click
+		// A session info value is copied whole, even where it wraps
+		if cmd := c.copySessionInfoValue(y); cmd != nil {
+			return cmd
+		}
+		c.SelectParagraph(x, y)
 		return c.CopySelectedText()
 	}
 
@@ -357,16 +390,7 @@ func (c *Chat) CopySelectedText() tea.Cmd {
 	c.selection.FlashFrame = 0
 
 	return tea.Batch(
-		// OSC 52 escape sequence (works in modern terminals)
-		tea.SetClipboard(selectedText),
-		// Native clipboard fallback - returns error message if it fails
-		func() tea.Msg {
-			if err := clipboard.WriteText(selectedText); err != nil {
-				logger.Get().Error("Failed to write to clipboard", "error", err)
-				return ClipboardErrorMsg{Error: err}
-			}
-			return nil
-		},
+		CopyToClipboard(selectedText, "Copied selection"),
 		// Start flash animation timer
 		SelectionFlashTick(),
 	)
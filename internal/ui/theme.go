@@ -3,7 +3,11 @@
 // to customize the visual appearance of Plural.
 package ui
 
-import "charm.land/lipgloss/v2"
+import (
+	"cmp"
+
+	"charm.land/lipgloss/v2"
+)
 
 // Theme defines a complete color palette for the application.
 // Each theme provides colors for all UI elements, ensuring visual consistency.
@@ -37,6 +41,20 @@ type Theme struct {
 	Border      string // Default borders
 	BorderFocus string // Focused element borders (defaults to Primary if empty)
 
+	// Overlay colors, for the permission, question, plan, and todo boxes drawn
+	// over the chat. Text colors must stay readable on Bg.
+	OverlayBorder     string // Question, plan, and todo box borders (defaults to Info if empty)
+	OverlaySelectedBg string // Selected question option background (defaults to Primary if empty)
+	OverlaySelectedFg string // Selected question option text (defaults to TextInverse if empty)
+	WarningText       string // Warning titles and keys (defaults to Warning if empty)
+	InfoText          string // Info titles and keys (defaults to Info if empty)
+	SuccessText       string // Completed todo markers (defaults to Success if empty)
+
+	// Tool use marker colors by kind of tool, unless tool_category_colors is off
+	ToolRead    string // Tools that only read: Read, Glob, Grep, web fetches and searches (defaults to Info if empty)
+	ToolMutate  string // Tools that change files: Edit, Write (defaults to Warning if empty)
+	ToolExecute string // Tools that run commands or agents: Bash, Task (defaults to Primary if empty)
+
 	// Diff colors (for viewing changes)
 	DiffAdded   string // Added lines
 	DiffRemoved string // Removed lines
@@ -76,6 +94,51 @@ func (t Theme) GetBorderFocus() string {
 	return t.Primary
 }
 
+// GetOverlayBorder returns the overlay box border color, defaulting to Info
+func (t Theme) GetOverlayBorder() string {
+	return cmp.Or(t.OverlayBorder, t.Info)
+}
+
+// GetOverlaySelectedBg returns the selected question option background, defaulting to Primary
+func (t Theme) GetOverlaySelectedBg() string {
+	return cmp.Or(t.OverlaySelectedBg, t.Primary)
+}
+
+// GetOverlaySelectedFg returns the selected question option text color, defaulting to TextInverse
+func (t Theme) GetOverlaySelectedFg() string {
+	return cmp.Or(t.OverlaySelectedFg, t.TextInverse)
+}
+
+// GetWarningText returns the warning text color, defaulting to Warning
+func (t Theme) GetWarningText() string {
+	return cmp.Or(t.WarningText, t.Warning)
+}
+
+// GetInfoText returns the info text color, defaulting to Info
+func (t Theme) GetInfoText() string {
+	return cmp.Or(t.InfoText, t.Info)
+}
+
+// GetSuccessText returns the success text color, defaulting to Success
+func (t Theme) GetSuccessText() string {
+	return cmp.Or(t.SuccessText, t.Success)
+}
+
+// GetToolRead returns the marker color of tools that only read, defaulting to Info
+func (t Theme) GetToolRead() string {
+	return cmp.Or(t.ToolRead, t.Info)
+}
+
+// GetToolMutate returns the marker color of tools that change files, defaulting to Warning
+func (t Theme) GetToolMutate() string {
+	return cmp.Or(t.ToolMutate, t.Warning)
+}
+
+// GetToolExecute returns the marker color of tools that run commands or agents, defaulting to Primary
+func (t Theme) GetToolExecute() string {
+	return cmp.Or(t.ToolExecute, t.Primary)
+}
+
 // GetSyntaxStyle returns the chroma syntax style name, defaulting to "monokai"
 func (t Theme) GetSyntaxStyle() string {
 	if t.SyntaxStyle != "" {
@@ -105,248 +168,296 @@ const DefaultTheme = ThemeTokyoNight
 // BuiltinThemes contains all built-in themes
 var BuiltinThemes = map[ThemeName]Theme{
 	ThemeDarkPurple: {
-		Name:             "Dark Purple",
-		Primary:          "#7C3AED",
-		Secondary:        "#06B6D4",
-		Bg:               "#1F2937",
-		Text:             "#F9FAFB",
-		TextMuted:        "#B0B8C4",
-		TextInverse:      "#1F2937",
-		User:             "#A78BFA",
-		Assistant:        "#22D3EE",
-		Warning:          "#F59E0B",
-		Error:            "#EF4444",
-		Info:             "#06B6D4",
-		Success:          "#10B981",
-		Border:           "#374151",
-		DiffAdded:        "#4ADE80",
-		DiffRemoved:      "#F87171",
-		DiffHeader:       "#60A5FA",
-		DiffHunk:         "#C084FC",
-		MarkdownH1:       "#A78BFA",
-		MarkdownH2:       "#C4B5FD",
-		MarkdownH3:       "#22D3EE",
-		MarkdownCode:     "#67E8F9",
-		MarkdownCodeBg:   "#1E1E2E",
-		MarkdownLink:     "#67E8F9",
-		MarkdownListItem: "#06B6D4",
-		TextSelectionBg:  "#4C1D95",
-		TextSelectionFg:  "#F9FAFB",
-		SyntaxStyle:      "monokai",
+		Name:              "Dark Purple",
+		Primary:           "#7C3AED",
+		Secondary:         "#06B6D4",
+		Bg:                "#1F2937",
+		Text:              "#F9FAFB",
+		TextMuted:         "#B0B8C4",
+		TextInverse:       "#1F2937",
+		User:              "#A78BFA",
+		Assistant:         "#22D3EE",
+		Warning:           "#F59E0B",
+		Error:             "#EF4444",
+		Info:              "#06B6D4",
+		Success:           "#10B981",
+		Border:            "#374151",
+		OverlayBorder:     "#06B6D4",
+		OverlaySelectedBg: "#7C3AED",
+		OverlaySelectedFg: "#F9FAFB",
+		WarningText:       "#F59E0B",
+		InfoText:          "#06B6D4",
+		SuccessText:       "#10B981",
+		DiffAdded:         "#4ADE80",
+		DiffRemoved:       "#F87171",
+		DiffHeader:        "#60A5FA",
+		DiffHunk:          "#C084FC",
+		MarkdownH1:        "#A78BFA",
+		MarkdownH2:        "#C4B5FD",
+		MarkdownH3:        "#22D3EE",
+		MarkdownCode:      "#67E8F9",
+		MarkdownCodeBg:    "#1E1E2E",
+		MarkdownLink:      "#67E8F9",
+		MarkdownListItem:  "#06B6D4",
+		TextSelectionBg:   "#4C1D95",
+		TextSelectionFg:   "#F9FAFB",
+		SyntaxStyle:       "monokai",
 	},
 	ThemeNord: {
-		Name:             "Nord",
-		Primary:          "#88C0D0",
-		Secondary:        "#81A1C1",
-		Bg:               "#2E3440",
-		Text:             "#ECEFF4",
-		TextMuted:        "#D8DEE9",
-		TextInverse:      "#2E3440",
-		User:             "#A3BE8C",
-		Assistant:        "#88C0D0",
-		Warning:          "#EBCB8B",
-		Error:            "#BF616A",
-		Info:             "#81A1C1",
-		Success:          "#A3BE8C",
-		Border:           "#4C566A",
-		DiffAdded:        "#A3BE8C",
-		DiffRemoved:      "#BF616A",
-		DiffHeader:       "#81A1C1",
-		DiffHunk:         "#B48EAD",
-		MarkdownH1:       "#88C0D0",
-		MarkdownH2:       "#81A1C1",
-		MarkdownH3:       "#5E81AC",
-		MarkdownCode:     "#A3BE8C",
-		MarkdownCodeBg:   "#242933",
-		MarkdownLink:     "#88C0D0",
-		MarkdownListItem: "#81A1C1",
-		TextSelectionBg:  "#5E81AC",
-		TextSelectionFg:  "#ECEFF4",
-		SyntaxStyle:      "nord",
+		Name:              "Nord",
+		Primary:           "#88C0D0",
+		Secondary:         "#81A1C1",
+		Bg:                "#2E3440",
+		Text:              "#ECEFF4",
+		TextMuted:         "#D8DEE9",
+		TextInverse:       "#2E3440",
+		User:              "#A3BE8C",
+		Assistant:         "#88C0D0",
+		Warning:           "#EBCB8B",
+		Error:             "#BF616A",
+		Info:              "#81A1C1",
+		Success:           "#A3BE8C",
+		Border:            "#4C566A",
+		OverlayBorder:     "#81A1C1",
+		OverlaySelectedBg: "#88C0D0",
+		OverlaySelectedFg: "#2E3440",
+		WarningText:       "#EBCB8B",
+		InfoText:          "#81A1C1",
+		SuccessText:       "#A3BE8C",
+		DiffAdded:         "#A3BE8C",
+		DiffRemoved:       "#BF616A",
+		DiffHeader:        "#81A1C1",
+		DiffHunk:          "#B48EAD",
+		MarkdownH1:        "#88C0D0",
+		MarkdownH2:        "#81A1C1",
+		MarkdownH3:        "#5E81AC",
+		MarkdownCode:      "#A3BE8C",
+		MarkdownCodeBg:    "#242933",
+		MarkdownLink:      "#88C0D0",
+		MarkdownListItem:  "#81A1C1",
+		TextSelectionBg:   "#5E81AC",
+		TextSelectionFg:   "#ECEFF4",
+		SyntaxStyle:       "nord",
 	},
 	ThemeDracula: {
-		Name:             "Dracula",
-		Primary:          "#BD93F9",
-		Secondary:        "#8BE9FD",
-		Bg:               "#282A36",
-		Text:             "#F8F8F2",
-		TextMuted:        "#8994BD",
-		TextInverse:      "#282A36",
-		User:             "#FF79C6",
-		Assistant:        "#8BE9FD",
-		Warning:          "#FFB86C",
-		Error:            "#FF5555",
-		Info:             "#8BE9FD",
-		Success:          "#50FA7B",
-		Border:           "#44475A",
-		DiffAdded:        "#50FA7B",
-		DiffRemoved:      "#FF5555",
-		DiffHeader:       "#8BE9FD",
-		DiffHunk:         "#BD93F9",
-		MarkdownH1:       "#BD93F9",
-		MarkdownH2:       "#FF79C6",
-		MarkdownH3:       "#8BE9FD",
-		MarkdownCode:     "#50FA7B",
-		MarkdownCodeBg:   "#21222C",
-		MarkdownLink:     "#8BE9FD",
-		MarkdownListItem: "#BD93F9",
-		TextSelectionBg:  "#44475A",
-		TextSelectionFg:  "#F8F8F2",
-		SyntaxStyle:      "dracula",
+		Name:              "Dracula",
+		Primary:           "#BD93F9",
+		Secondary:         "#8BE9FD",
+		Bg:                "#282A36",
+		Text:              "#F8F8F2",
+		TextMuted:         "#8994BD",
+		TextInverse:       "#282A36",
+		User:              "#FF79C6",
+		Assistant:         "#8BE9FD",
+		Warning:           "#FFB86C",
+		Error:             "#FF5555",
+		Info:              "#8BE9FD",
+		Success:           "#50FA7B",
+		Border:            "#44475A",
+		OverlayBorder:     "#8BE9FD",
+		OverlaySelectedBg: "#BD93F9",
+		OverlaySelectedFg: "#282A36",
+		WarningText:       "#FFB86C",
+		InfoText:          "#8BE9FD",
+		SuccessText:       "#50FA7B",
+		DiffAdded:         "#50FA7B",
+		DiffRemoved:       "#FF5555",
+		DiffHeader:        "#8BE9FD",
+		DiffHunk:          "#BD93F9",
+		MarkdownH1:        "#BD93F9",
+		MarkdownH2:        "#FF79C6",
+		MarkdownH3:        "#8BE9FD",
+		MarkdownCode:      "#50FA7B",
+		MarkdownCodeBg:    "#21222C",
+		MarkdownLink:      "#8BE9FD",
+		MarkdownListItem:  "#BD93F9",
+		TextSelectionBg:   "#44475A",
+		TextSelectionFg:   "#F8F8F2",
+		SyntaxStyle:       "dracula",
 	},
 	ThemeGruvbox: {
-		Name:             "Gruvbox Dark",
-		Primary:          "#FE8019",
-		Secondary:        "#83A598",
-		Bg:               "#282828",
-		Text:             "#EBDBB2",
-		TextMuted:        "#A89984",
-		TextInverse:      "#282828",
-		User:             "#FABD2F",
-		Assistant:        "#83A598",
-		Warning:          "#FE8019",
-		Error:            "#FB4934",
-		Info:             "#83A598",
-		Success:          "#B8BB26",
-		Border:           "#504945",
-		DiffAdded:        "#B8BB26",
-		DiffRemoved:      "#FB4934",
-		DiffHeader:       "#83A598",
-		DiffHunk:         "#D3869B",
-		MarkdownH1:       "#FE8019",
-		MarkdownH2:       "#FABD2F",
-		MarkdownH3:       "#83A598",
-		MarkdownCode:     "#B8BB26",
-		MarkdownCodeBg:   "#1D2021",
-		MarkdownLink:     "#83A598",
-		MarkdownListItem: "#FE8019",
-		TextSelectionBg:  "#504945",
-		TextSelectionFg:  "#EBDBB2",
-		SyntaxStyle:      "gruvbox",
+		Name:              "Gruvbox Dark",
+		Primary:           "#FE8019",
+		Secondary:         "#83A598",
+		Bg:                "#282828",
+		Text:              "#EBDBB2",
+		TextMuted:         "#A89984",
+		TextInverse:       "#282828",
+		User:              "#FABD2F",
+		Assistant:         "#83A598",
+		Warning:           "#FE8019",
+		Error:             "#FB4934",
+		Info:              "#83A598",
+		Success:           "#B8BB26",
+		Border:            "#504945",
+		OverlayBorder:     "#83A598",
+		OverlaySelectedBg: "#FE8019",
+		OverlaySelectedFg: "#282828",
+		WarningText:       "#FE8019",
+		InfoText:          "#83A598",
+		SuccessText:       "#B8BB26",
+		DiffAdded:         "#B8BB26",
+		DiffRemoved:       "#FB4934",
+		DiffHeader:        "#83A598",
+		DiffHunk:          "#D3869B",
+		MarkdownH1:        "#FE8019",
+		MarkdownH2:        "#FABD2F",
+		MarkdownH3:        "#83A598",
+		MarkdownCode:      "#B8BB26",
+		MarkdownCodeBg:    "#1D2021",
+		MarkdownLink:      "#83A598",
+		MarkdownListItem:  "#FE8019",
+		TextSelectionBg:   "#504945",
+		TextSelectionFg:   "#EBDBB2",
+		SyntaxStyle:       "gruvbox",
 	},
 	ThemeTokyoNight: {
-		Name:             "Tokyo Night",
-		Primary:          "#7AA2F7",
-		Secondary:        "#BB9AF7",
-		Bg:               "#1A1B26",
-		Text:             "#C0CAF5",
-		TextMuted:        "#7982AC",
-		TextInverse:      "#1A1B26",
-		User:             "#9ECE6A",
-		Assistant:        "#7AA2F7",
-		Warning:          "#E0AF68",
-		Error:            "#F7768E",
-		Info:             "#7DCFFF",
-		Success:          "#9ECE6A",
-		Border:           "#3B4261",
-		DiffAdded:        "#9ECE6A",
-		DiffRemoved:      "#F7768E",
-		DiffHeader:       "#7AA2F7",
-		DiffHunk:         "#BB9AF7",
-		MarkdownH1:       "#7AA2F7",
-		MarkdownH2:       "#BB9AF7",
-		MarkdownH3:       "#7DCFFF",
-		MarkdownCode:     "#9ECE6A",
-		MarkdownCodeBg:   "#16161E",
-		MarkdownLink:     "#7DCFFF",
-		MarkdownListItem: "#BB9AF7",
-		TextSelectionBg:  "#3B4261",
-		TextSelectionFg:  "#C0CAF5",
-		SyntaxStyle:      "native",
+		Name:              "Tokyo Night",
+		Primary:           "#7AA2F7",
+		Secondary:         "#BB9AF7",
+		Bg:                "#1A1B26",
+		Text:              "#C0CAF5",
+		TextMuted:         "#7982AC",
+		TextInverse:       "#1A1B26",
+		User:              "#9ECE6A",
+		Assistant:         "#7AA2F7",
+		Warning:           "#E0AF68",
+		Error:             "#F7768E",
+		Info:              "#7DCFFF",
+		Success:           "#9ECE6A",
+		Border:            "#3B4261",
+		OverlayBorder:     "#7DCFFF",
+		OverlaySelectedBg: "#7AA2F7",
+		OverlaySelectedFg: "#1A1B26",
+		WarningText:       "#E0AF68",
+		InfoText:          "#7DCFFF",
+		SuccessText:       "#9ECE6A",
+		DiffAdded:         "#9ECE6A",
+		DiffRemoved:       "#F7768E",
+		DiffHeader:        "#7AA2F7",
+		DiffHunk:          "#BB9AF7",
+		MarkdownH1:        "#7AA2F7",
+		MarkdownH2:        "#BB9AF7",
+		MarkdownH3:        "#7DCFFF",
+		MarkdownCode:      "#9ECE6A",
+		MarkdownCodeBg:    "#16161E",
+		MarkdownLink:      "#7DCFFF",
+		MarkdownListItem:  "#BB9AF7",
+		TextSelectionBg:   "#3B4261",
+		TextSelectionFg:   "#C0CAF5",
+		SyntaxStyle:       "native",
 	},
 	ThemeCatppuccin: {
-		Name:             "Catppuccin Mocha",
-		Primary:          "#CBA6F7",
-		Secondary:        "#89DCEB",
-		Bg:               "#1E1E2E",
-		Text:             "#CDD6F4",
-		TextMuted:        "#9399B2",
-		TextInverse:      "#1E1E2E",
-		User:             "#F5C2E7",
-		Assistant:        "#89DCEB",
-		Warning:          "#FAB387",
-		Error:            "#F38BA8",
-		Info:             "#89DCEB",
-		Success:          "#A6E3A1",
-		Border:           "#313244",
-		DiffAdded:        "#A6E3A1",
-		DiffRemoved:      "#F38BA8",
-		DiffHeader:       "#89DCEB",
-		DiffHunk:         "#CBA6F7",
-		MarkdownH1:       "#CBA6F7",
-		MarkdownH2:       "#F5C2E7",
-		MarkdownH3:       "#89DCEB",
-		MarkdownCode:     "#A6E3A1",
-		MarkdownCodeBg:   "#181825",
-		MarkdownLink:     "#89DCEB",
-		MarkdownListItem: "#CBA6F7",
-		TextSelectionBg:  "#45475A",
-		TextSelectionFg:  "#CDD6F4",
-		SyntaxStyle:      "catppuccin-mocha",
+		Name:              "Catppuccin Mocha",
+		Primary:           "#CBA6F7",
+		Secondary:         "#89DCEB",
+		Bg:                "#1E1E2E",
+		Text:              "#CDD6F4",
+		TextMuted:         "#9399B2",
+		TextInverse:       "#1E1E2E",
+		User:              "#F5C2E7",
+		Assistant:         "#89DCEB",
+		Warning:           "#FAB387",
+		Error:             "#F38BA8",
+		Info:              "#89DCEB",
+		Success:           "#A6E3A1",
+		Border:            "#313244",
+		OverlayBorder:     "#89DCEB",
+		OverlaySelectedBg: "#CBA6F7",
+		OverlaySelectedFg: "#1E1E2E",
+		WarningText:       "#FAB387",
+		InfoText:          "#89DCEB",
+		SuccessText:       "#A6E3A1",
+		DiffAdded:         "#A6E3A1",
+		DiffRemoved:       "#F38BA8",
+		DiffHeader:        "#89DCEB",
+		DiffHunk:          "#CBA6F7",
+		MarkdownH1:        "#CBA6F7",
+		MarkdownH2:        "#F5C2E7",
+		MarkdownH3:        "#89DCEB",
+		MarkdownCode:      "#A6E3A1",
+		MarkdownCodeBg:    "#181825",
+		MarkdownLink:      "#89DCEB",
+		MarkdownListItem:  "#CBA6F7",
+		TextSelectionBg:   "#45475A",
+		TextSelectionFg:   "#CDD6F4",
+		SyntaxStyle:       "catppuccin-mocha",
 	},
 	ThemeScienceFiction: {
-		Name:             "Science Fiction",
-		Primary:          "#E50914",
-		Secondary:        "#8B0000",
-		Bg:               "#0A0A0A",
-		BgSelected:       "#2D0A0A",
-		Text:             "#E8E8E8",
-		TextMuted:        "#8A8A8A",
-		TextInverse:      "#0A0A0A",
-		User:             "#FF4444",
-		Assistant:        "#CC0000",
-		Warning:          "#FF6600",
-		Error:            "#FF0000",
-		Info:             "#AA0000",
-		Success:          "#00AA00",
-		Border:           "#330000",
-		BorderFocus:      "#E50914",
-		DiffAdded:        "#00AA00",
-		DiffRemoved:      "#FF4444",
-		DiffHeader:       "#E50914",
-		DiffHunk:         "#8B0000",
-		MarkdownH1:       "#E50914",
-		MarkdownH2:       "#CC0000",
-		MarkdownH3:       "#AA0000",
-		MarkdownCode:     "#FF6666",
-		MarkdownCodeBg:   "#1A0000",
-		MarkdownLink:     "#FF4444",
-		MarkdownListItem: "#E50914",
-		TextSelectionBg:  "#4D0000",
-		TextSelectionFg:  "#E8E8E8",
-		SyntaxStyle:      "native",
+		Name:              "Science Fiction",
+		Primary:           "#E50914",
+		Secondary:         "#8B0000",
+		Bg:                "#0A0A0A",
+		BgSelected:        "#2D0A0A",
+		Text:              "#E8E8E8",
+		TextMuted:         "#8A8A8A",
+		TextInverse:       "#0A0A0A",
+		User:              "#FF4444",
+		Assistant:         "#CC0000",
+		Warning:           "#FF6600",
+		Error:             "#FF0000",
+		Info:              "#AA0000",
+		Success:           "#00AA00",
+		Border:            "#330000",
+		BorderFocus:       "#E50914",
+		OverlayBorder:     "#E50914",
+		OverlaySelectedBg: "#E50914",
+		OverlaySelectedFg: "#FFFFFF",
+		WarningText:       "#FF6600",
+		InfoText:          "#FF3333",
+		SuccessText:       "#00AA00",
+		DiffAdded:         "#00AA00",
+		DiffRemoved:       "#FF4444",
+		DiffHeader:        "#E50914",
+		DiffHunk:          "#8B0000",
+		MarkdownH1:        "#E50914",
+		MarkdownH2:        "#CC0000",
+		MarkdownH3:        "#AA0000",
+		MarkdownCode:      "#FF6666",
+		MarkdownCodeBg:    "#1A0000",
+		MarkdownLink:      "#FF4444",
+		MarkdownListItem:  "#E50914",
+		TextSelectionBg:   "#4D0000",
+		TextSelectionFg:   "#E8E8E8",
+		SyntaxStyle:       "native",
 	},
 	ThemeLight: {
-		Name:             "Light",
-		Primary:          "#6366F1",
-		Secondary:        "#0891B2",
-		Bg:               "#FFFFFF",
-		BgSelected:       "#E0E7FF",
-		Text:             "#1F2937",
-		TextMuted:        "#6B7280",
-		TextInverse:      "#FFFFFF",
-		User:             "#7C3AED",
-		Assistant:        "#0891B2",
-		Warning:          "#D97706",
-		Error:            "#DC2626",
-		Info:             "#0891B2",
-		Success:          "#059669",
-		Border:           "#D1D5DB",
-		BorderFocus:      "#6366F1",
-		DiffAdded:        "#16A34A",
-		DiffRemoved:      "#DC2626",
-		DiffHeader:       "#2563EB",
-		DiffHunk:         "#7C3AED",
-		MarkdownH1:       "#6366F1",
-		MarkdownH2:       "#7C3AED",
-		MarkdownH3:       "#0891B2",
-		MarkdownCode:     "#059669",
-		MarkdownCodeBg:   "#F3F4F6",
-		MarkdownLink:     "#0891B2",
-		MarkdownListItem: "#6366F1",
-		TextSelectionBg:  "#BFDBFE",
-		TextSelectionFg:  "#1F2937",
-		SyntaxStyle:      "github",
+		Name:              "Light",
+		Primary:           "#6366F1",
+		Secondary:         "#0891B2",
+		Bg:                "#FFFFFF",
+		BgSelected:        "#E0E7FF",
+		Text:              "#1F2937",
+		TextMuted:         "#6B7280",
+		TextInverse:       "#FFFFFF",
+		User:              "#7C3AED",
+		Assistant:         "#0891B2",
+		Warning:           "#D97706",
+		Error:             "#DC2626",
+		Info:              "#0891B2",
+		Success:           "#059669",
+		Border:            "#D1D5DB",
+		BorderFocus:       "#6366F1",
+		OverlayBorder:     "#0891B2",
+		OverlaySelectedBg: "#4F46E5",
+		OverlaySelectedFg: "#FFFFFF",
+		WarningText:       "#B45309",
+		InfoText:          "#0E7490",
+		SuccessText:       "#047857",
+		DiffAdded:         "#16A34A",
+		DiffRemoved:       "#DC2626",
+		DiffHeader:        "#2563EB",
+		DiffHunk:          "#7C3AED",
+		MarkdownH1:        "#6366F1",
+		MarkdownH2:        "#7C3AED",
+		MarkdownH3:        "#0891B2",
+		MarkdownCode:      "#059669",
+		MarkdownCodeBg:    "#F3F4F6",
+		MarkdownLink:      "#0891B2",
+		MarkdownListItem:  "#6366F1",
+		TextSelectionBg:   "#BFDBFE",
+		TextSelectionFg:   "#1F2937",
+		SyntaxStyle:       "github",
 	},
 }
 
@@ -422,6 +533,12 @@ func regenerateStyles() {
 	ColorInfo = lipgloss.Color(t.Info)
 	ColorError = lipgloss.Color(t.Error)
 	ColorSuccess = lipgloss.Color(t.Success)
+	ColorOverlayBorder = lipgloss.Color(t.GetOverlayBorder())
+	ColorOverlaySelectedBg = lipgloss.Color(t.GetOverlaySelectedBg())
+	ColorOverlaySelectedFg = lipgloss.Color(t.GetOverlaySelectedFg())
+	ColorWarningText = lipgloss.Color(t.GetWarningText())
+	ColorInfoText = lipgloss.Color(t.GetInfoText())
+	ColorSuccessText = lipgloss.Color(t.GetSuccessText())
 
 	// Update header styles
 	HeaderStyle = lipgloss.NewStyle().
@@ -529,6 +646,15 @@ func regenerateStyles() {
 	ToolUseCompleteStyle = lipgloss.NewStyle().
 		Foreground(ColorSecondary)
 
+	ToolReadStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.GetToolRead()))
+
+	ToolMutateStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.GetToolMutate()))
+
+	ToolExecuteStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.GetToolExecute()))
+
 	// Update permission prompt styles
 	PermissionBoxStyle = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -536,7 +662,7 @@ func regenerateStyles() {
 		Padding(0, 1)
 
 	PermissionTitleStyle = lipgloss.NewStyle().
-		Foreground(ColorWarning).
+		Foreground(ColorWarningText).
 		Bold(true)
 
 	PermissionToolStyle = lipgloss.NewStyle().
@@ -551,19 +677,33 @@ func regenerateStyles() {
 		Italic(true)
 
 	PermissionIndicatorStyle = lipgloss.NewStyle().
-		Foreground(ColorWarning).
+		Foreground(ColorWarningText).
 		Bold(true)
 
+	DestructiveBoxStyle = lipgloss.NewStyle().
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(ColorError).
+		Padding(0, 1)
+
+	DestructiveTitleStyle = lipgloss.NewStyle().
+		Foreground(ColorError).
+		Bold(true)
+
+	DestructiveHighlightStyle = lipgloss.NewStyle().
+		Foreground(ColorError).
+		Bold(true).
+		Underline(true)
+
 	// Update question prompt styles
 	QuestionBoxStyle = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorInfo).
+		BorderForeground(ColorOverlayBorder).
 		Padding(0, 1)
 
 	// Update plan approval prompt styles
 	PlanApprovalBoxStyle = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorInfo).
+		BorderForeground(ColorOverlayBorder).
 		Padding(1, 2)
 
 	// Update markdown styles
@@ -597,6 +737,14 @@ func regenerateStyles() {
 		Foreground(lipgloss.Color(t.MarkdownCode)).
 		Background(lipgloss.Color(t.MarkdownCodeBg))
 
+	MarkdownStrikethroughStyle = lipgloss.NewStyle().
+		Strikethrough(true).
+		Foreground(ColorTextMuted)
+
+	MarkdownHighlightStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.TextInverse)).
+		Background(lipgloss.Color(t.Warning))
+
 	MarkdownCodeBlockStyle = lipgloss.NewStyle().
 		Background(lipgloss.Color(t.MarkdownCodeBg))
 
@@ -618,6 +766,10 @@ func regenerateStyles() {
 		Foreground(lipgloss.Color(t.MarkdownLink)).
 		Underline(true)
 
+	MarkdownFileRefStyle = lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		Underline(true)
+
 	// Update diff styles
 	DiffAddedStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(t.DiffAdded))
@@ -632,6 +784,9 @@ func regenerateStyles() {
 	DiffHunkStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(t.DiffHunk))
 
+	LiveDiffChangedStyle = lipgloss.NewStyle().
+		Background(lipgloss.Color(t.GetBgSelected()))
+
 	// Update view changes styles
 	ViewChangesSelectedStyle = lipgloss.NewStyle().
 		Background(lipgloss.Color(t.GetBgSelected())).
@@ -647,7 +802,28 @@ func regenerateStyles() {
 		Background(ColorSuccess).
 		Foreground(lipgloss.Color(t.TextInverse))
 
-	// Update todo marker styles
+	// Update todo list styles
+	TodoListBoxStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorOverlayBorder).
+		Padding(0, 1)
 	TodoCompletedMarkerStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(t.DiffAdded)) // Use DiffAdded (green) for completed checkmarks
+		Foreground(ColorSuccessText)
+	TodoInProgressMarkerStyle = lipgloss.NewStyle().
+		Foreground(ColorInfoText)
+	TodoPendingMarkerStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted)
+	TodoCompletedContentStyle = lipgloss.NewStyle().
+		Foreground(ColorTextMuted).
+		Strikethrough(true)
+	TodoInProgressContentStyle = lipgloss.NewStyle().
+		Foreground(ColorText).
+		Bold(true)
+	TodoPendingContentStyle = lipgloss.NewStyle().
+		Foreground(ColorTextMuted)
+	TodoUserMarkedMarkerStyle = lipgloss.NewStyle().
+		Foreground(ColorUser)
+	TodoUserMarkedContentStyle = lipgloss.NewStyle().
+		Foreground(ColorUser).
+		Italic(true)
 }
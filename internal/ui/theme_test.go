@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Minimum contrast ratios against the background: WCAG AA for text, and the
+// lower bar for non-text elements such as box borders.
+const (
+	minTextContrast   = 4.5
+	minBorderContrast = 3.0
+)
+
+// relativeLuminance returns the WCAG relative luminance of c.
+func relativeLuminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	channel := func(v uint32) float64 {
+		s := float64(v) / 0xFFFF
+		if s <= 0.03928 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(r) + 0.7152*channel(g) + 0.0722*channel(b)
+}
+
+// contrastRatio returns the WCAG contrast ratio between two colors, from 1 to 21.
+func contrastRatio(a, b color.Color) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	return (max(la, lb) + 0.05) / (min(la, lb) + 0.05)
+}
+
+func TestContrastRatio(t *testing.T) {
+	if got := contrastRatio(lipgloss.Color("#000000"), lipgloss.Color("#FFFFFF")); math.Abs(got-21) > 0.01 {
+		t.Errorf("black on white = %.2f, want 21", got)
+	}
+	if got := contrastRatio(lipgloss.Color("#777777"), lipgloss.Color("#777777")); got != 1 {
+		t.Errorf("same color = %.2f, want 1", got)
+	}
+}
+
+func TestTheme_OverlayContrast(t *testing.T) {
+	originalThemeName := CurrentThemeName()
+	defer SetTheme(originalThemeName)
+
+	for _, name := range ThemeNames() {
+		t.Run(string(name), func(t *testing.T) {
+			SetTheme(name)
+
+			pairs := []struct {
+				name   string
+				fg, bg color.Color
+				min    float64
+			}{
+				{"permission border", PermissionBoxStyle.GetBorderTopForeground(), ColorBg, minBorderContrast},
+				{"permission title", PermissionTitleStyle.GetForeground(), ColorBg, minTextContrast},
+				{"permission tool", PermissionToolStyle.GetForeground(), ColorBg, minTextContrast},
+				{"permission description", PermissionDescStyle.GetForeground(), ColorBg, minTextContrast},
+				{"permission hint", PermissionHintStyle.GetForeground(), ColorBg, minTextContrast},
+				{"permission keys", ColorWarningText, ColorBg, minTextContrast},
+				{"question border", QuestionBoxStyle.GetBorderTopForeground(), ColorBg, minBorderContrast},
+				{"question header", ColorInfoText, ColorBg, minTextContrast},
+				{"question text", ColorText, ColorBg, minTextContrast},
+				{"question description", ColorTextMuted, ColorBg, minTextContrast},
+				{"question selected option", ColorOverlaySelectedFg, ColorOverlaySelectedBg, minTextContrast},
+				{"plan border", PlanApprovalBoxStyle.GetBorderTopForeground(), ColorBg, minBorderContrast},
+				{"plan title", ColorInfoText, ColorBg, minTextContrast},
+				{"plan permissions header", ColorWarningText, ColorBg, minTextContrast},
+				{"todo border", TodoListBoxStyle.GetBorderTopForeground(), ColorBg, minBorderContrast},
+				{"todo title", ColorInfoText, ColorBg, minTextContrast},
+				{"todo completed marker", TodoCompletedMarkerStyle.GetForeground(), ColorBg, minTextContrast},
+				{"todo in progress marker", TodoInProgressMarkerStyle.GetForeground(), ColorBg, minTextContrast},
+				{"todo pending marker", TodoPendingMarkerStyle.GetForeground(), ColorBg, minTextContrast},
+				{"todo completed content", TodoCompletedContentStyle.GetForeground(), ColorBg, minTextContrast},
+				{"todo in progress content", TodoInProgressContentStyle.GetForeground(), ColorBg, minTextContrast},
+				{"todo pending content", TodoPendingContentStyle.GetForeground(), ColorBg, minTextContrast},
+				{"todo user marked", TodoUserMarkedContentStyle.GetForeground(), ColorBg, minTextContrast},
+			}
+			for _, p := range pairs {
+				if ratio := contrastRatio(p.fg, p.bg); ratio < p.min {
+					t.Errorf("%s: contrast %.2f, want at least %.1f", p.name, ratio, p.min)
+				}
+			}
+		})
+	}
+}
+
+func TestTheme_OverlayColorDefaults(t *testing.T) {
+	theme := Theme{Primary: "#111111", TextInverse: "#222222", Warning: "#333333", Info: "#444444", Success: "#555555"}
+	if got := theme.GetOverlayBorder(); got != theme.Info {
+		t.Errorf("GetOverlayBorder() = %q, want Info", got)
+	}
+	if got := theme.GetOverlaySelectedBg(); got != theme.Primary {
+		t.Errorf("GetOverlaySelectedBg() = %q, want Primary", got)
+	}
+	if got := theme.GetOverlaySelectedFg(); got != theme.TextInverse {
+		t.Errorf("GetOverlaySelectedFg() = %q, want TextInverse", got)
+	}
+	if got := theme.GetWarningText(); got != theme.Warning {
+		t.Errorf("GetWarningText() = %q, want Warning", got)
+	}
+	if got := theme.GetInfoText(); got != theme.Info {
+		t.Errorf("GetInfoText() = %q, want Info", got)
+	}
+	if got := theme.GetSuccessText(); got != theme.Success {
+		t.Errorf("GetSuccessText() = %q, want Success", got)
+	}
+
+	theme.InfoText = "#666666"
+	if got := theme.GetInfoText(); got != "#666666" {
+		t.Errorf("GetInfoText() = %q, want the InfoText override", got)
+	}
+}
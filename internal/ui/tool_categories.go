@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// ToolCategory is the kind of work a tool does, which its marker's color shows.
+type ToolCategory int
+
+const (
+	ToolCategoryOther   ToolCategory = iota // Tools of no particular kind, such as TodoWrite and MCP tools
+	ToolCategoryRead                        // Tools that only read
+	ToolCategoryMutate                      // Tools that change files
+	ToolCategoryExecute                     // Tools that run commands or agents
+)
+
+// GetToolCategory returns the kind of work a tool does.
+func GetToolCategory(toolName string) ToolCategory {
+	switch toolName {
+	case "Read", "Glob", "Grep", "WebFetch", "WebSearch":
+		return ToolCategoryRead
+	case "Edit", "MultiEdit", "Write", "NotebookEdit":
+		return ToolCategoryMutate
+	case "Bash", "Task":
+		return ToolCategoryExecute
+	default:
+		return ToolCategoryOther
+	}
+}
+
+// toolCategoryColors is whether tool use markers are colored by category.
+var toolCategoryColors = true
+
+// SetToolCategoryColors sets whether tool use markers are colored by the kind
+// of tool rather than only by whether it is complete. Chats render their
+// messages afresh on RefreshStyles.
+func SetToolCategoryColors(enabled bool) {
+	toolCategoryColors = enabled
+}
+
+// toolMarkerStyle returns the style of a tool use's marker. Colored by
+// category, in-progress and complete tool uses are still told apart by the
+// marker's shape.
+func toolMarkerStyle(toolName string, complete bool) lipgloss.Style {
+	if toolCategoryColors {
+		switch GetToolCategory(toolName) {
+		case ToolCategoryRead:
+			return ToolReadStyle
+		case ToolCategoryMutate:
+			return ToolMutateStyle
+		case ToolCategoryExecute:
+			return ToolExecuteStyle
+		}
+	}
+	if complete {
+		return ToolUseCompleteStyle
+	}
+	return ToolUseInProgressStyle
+}
+
+// toolUseLinePattern matches the start of a tool use line as formatToolUseLine
+// formats it, capturing the marker and the tool's name.
+var toolUseLinePattern = regexp.MustCompile(`^\s*(` + ToolUseInProgress + `|` + ToolUseComplete + `) \w+\((\w+)`)
+
+// styleToolUseMarkers renders the tool use markers in a line. The marker
+// starting a tool use line is styled for its tool; any others by whether they
+// are complete.
+func styleToolUseMarkers(line string) string {
+	if m := toolUseLinePattern.FindStringSubmatchIndex(line); m != nil {
+		marker, toolName := line[m[2]:m[3]], line[m[4]:m[5]]
+		return line[:m[2]] + toolMarkerStyle(toolName, marker == ToolUseComplete).Render(marker) + line[m[3]:]
+	}
+	line = strings.ReplaceAll(line, ToolUseInProgress, ToolUseInProgressStyle.Render(ToolUseInProgress))
+	return strings.ReplaceAll(line, ToolUseComplete, ToolUseCompleteStyle.Render(ToolUseComplete))
+}
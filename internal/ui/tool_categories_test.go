@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestGetToolCategory(t *testing.T) {
+	tests := []struct {
+		tool string
+		want ToolCategory
+	}{
+		{"Read", ToolCategoryRead},
+		{"Grep", ToolCategoryRead},
+		{"WebSearch", ToolCategoryRead},
+		{"Edit", ToolCategoryMutate},
+		{"Write", ToolCategoryMutate},
+		{"Bash", ToolCategoryExecute},
+		{"Task", ToolCategoryExecute},
+		{"TodoWrite", ToolCategoryOther},
+		{"mcp__github__create_issue", ToolCategoryOther},
+	}
+	for _, tt := range tests {
+		if got := GetToolCategory(tt.tool); got != tt.want {
+			t.Errorf("GetToolCategory(%q) = %v, want %v", tt.tool, got, tt.want)
+		}
+	}
+}
+
+func TestStyleToolUseMarkers(t *testing.T) {
+	orig := toolCategoryColors
+	defer func() { toolCategoryColors = orig }()
+
+	line := func(tool string, complete bool) string {
+		return formatToolUseLine(ToolUseItem{ToolName: tool, ToolInput: "x", Complete: complete})
+	}
+	marker := func(styled string) string {
+		return styled[:strings.Index(styled, " ")]
+	}
+
+	toolCategoryColors = true
+	read := styleToolUseMarkers(line("Read", true))
+	edit := styleToolUseMarkers(line("Edit", true))
+	bash := styleToolUseMarkers(line("Bash", true))
+	if marker(read) != ToolReadStyle.Render(ToolUseComplete) || marker(edit) != ToolMutateStyle.Render(ToolUseComplete) || marker(bash) != ToolExecuteStyle.Render(ToolUseComplete) {
+		t.Errorf("expected each marker colored by its tool's category, got %q, %q, %q", marker(read), marker(edit), marker(bash))
+	}
+	if marker(read) == marker(edit) || marker(edit) == marker(bash) {
+		t.Error("expected categories colored distinctly")
+	}
+	if got := ansi.Strip(styleToolUseMarkers(line("Read", false))); !strings.HasPrefix(got, ToolUseInProgress+" Reading(Read") {
+		t.Errorf("expected the in-progress marker kept, got %q", got)
+	}
+	if got := styleToolUseMarkers("  " + line("TodoWrite", true)); !strings.HasPrefix(got, "  "+ToolUseCompleteStyle.Render(ToolUseComplete)) {
+		t.Errorf("expected tools of no category styled as before, got %q", got)
+	}
+
+	toolCategoryColors = false
+	if got := styleToolUseMarkers(line("Edit", true)); marker(got) != ToolUseCompleteStyle.Render(ToolUseComplete) {
+		t.Errorf("expected markers colored only by completion when disabled, got %q", marker(got))
+	}
+	if got := styleToolUseMarkers(line("Edit", false)); marker(got) != ToolUseInProgressStyle.Render(ToolUseInProgress) {
+		t.Errorf("expected the in-progress style when disabled, got %q", marker(got))
+	}
+}
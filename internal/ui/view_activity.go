@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/zhubert/plural/internal/activity"
+)
+
+// EnterActivityFeedMode enters the activity feed overlay with the given events (newest first).
+func (c *Chat) EnterActivityFeedMode(events []activity.Event) {
+	c.activityFeed = &ActivityFeedState{
+		Events:   events,
+		Viewport: viewport.New(),
+	}
+
+	// Configure viewport
+	c.activityFeed.Viewport.MouseWheelEnabled = true
+	c.activityFeed.Viewport.MouseWheelDelta = 3
+
+	// Size it - will be adjusted in render, but set initial size
+	c.activityFeed.Viewport.SetWidth(c.viewport.Width())
+	c.activityFeed.Viewport.SetHeight(c.viewport.Height())
+
+	c.updateActivityFeedContent()
+}
+
+// SetActivityFeedEvents replaces the events shown in the feed, keeping the
+// selection on the same event when it is still present.
+func (c *Chat) SetActivityFeedEvents(events []activity.Event) {
+	if c.activityFeed == nil {
+		return
+	}
+	// New events are prepended, so follow the selected entry to its new position.
+	// Stay at the top when the newest event was selected so fresh events stay visible.
+	if selected := c.SelectedActivityEvent(); selected != nil && c.activityFeed.SelectedIndex > 0 {
+		for i, e := range events {
+			if e == *selected {
+				c.activityFeed.SelectedIndex = i
+				break
+			}
+		}
+	}
+	c.activityFeed.Events = events
+	c.updateActivityFeedContent()
+}
+
+// ExitActivityFeedMode exits the activity feed overlay and returns to chat.
+func (c *Chat) ExitActivityFeedMode() {
+	c.activityFeed = nil
+}
+
+// IsInActivityFeedMode returns whether we're currently showing the activity feed overlay.
+func (c *Chat) IsInActivityFeedMode() bool {
+	return c.activityFeed != nil
+}
+
+// MoveActivityFeedSelection moves the highlighted event by delta, clamped to the list.
+func (c *Chat) MoveActivityFeedSelection(delta int) {
+	if c.activityFeed == nil || len(c.activityFeed.Events) == 0 {
+		return
+	}
+	idx := c.activityFeed.SelectedIndex + delta
+	idx = max(0, min(idx, len(c.activityFeed.Events)-1))
+	c.activityFeed.SelectedIndex = idx
+	c.updateActivityFeedContent()
+}
+
+// SelectedActivityEvent returns the highlighted event, or nil if the feed is empty or hidden.
+func (c *Chat) SelectedActivityEvent() *activity.Event {
+	if c.activityFeed == nil || len(c.activityFeed.Events) == 0 {
+		return nil
+	}
+	return &c.activityFeed.Events[c.activityFeed.SelectedIndex]
+}
+
+// updateActivityFeedContent re-renders the feed and keeps the selection visible.
+func (c *Chat) updateActivityFeedContent() {
+	feed := c.activityFeed
+	if len(feed.Events) == 0 {
+		feed.SelectedIndex = 0
+		feed.Viewport.SetContent(lipgloss.NewStyle().Foreground(ColorTextMuted).Render("No activity yet"))
+		return
+	}
+	if feed.SelectedIndex >= len(feed.Events) {
+		feed.SelectedIndex = len(feed.Events) - 1
+	}
+
+	width := feed.Viewport.Width()
+	lines := make([]string, len(feed.Events))
+	for i, e := range feed.Events {
+		lines[i] = renderActivityLine(e, width, i == feed.SelectedIndex)
+	}
+	feed.Viewport.SetContent(strings.Join(lines, "\n"))
+
+	// Scroll so the selected line stays in view
+	if feed.SelectedIndex < feed.Viewport.YOffset() {
+		feed.Viewport.SetYOffset(feed.SelectedIndex)
+	} else if height := feed.Viewport.Height(); height > 0 && feed.SelectedIndex >= feed.Viewport.YOffset()+height {
+		feed.Viewport.SetYOffset(feed.SelectedIndex - height + 1)
+	}
+}
+
+// activitySeverityColor maps an event severity to its theme color.
+func activitySeverityColor(severity activity.Severity) color.Color {
+	switch severity {
+	case activity.SeverityError:
+		return ColorError
+	case activity.SeverityWarning:
+		return ColorWarning
+	case activity.SeveritySuccess:
+		return ColorSuccess
+	default:
+		return ColorInfo
+	}
+}
+
+// renderActivityLine renders a single feed entry: muted timestamp, session tag, and
+// a message colored by severity.
+func renderActivityLine(e activity.Event, width int, selected bool) string {
+	timestamp := e.Time.Format("Jan 02 15:04:05")
+
+	sessionName := e.SessionName
+	if sessionName == "" {
+		sessionName = truncateSessionID(e.SessionID)
+	}
+
+	if selected {
+		// Selected rows use a flat highlight so the whole line reads as one unit
+		line := fmt.Sprintf("> %s  [%s]  %s", timestamp, sessionName, e.Message)
+		if width > 0 {
+			line = ansi.Truncate(line, width, "…")
+		}
+		return ViewChangesSelectedStyle.Width(width).Render(line)
+	}
+
+	timeStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	sessionStyle := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+	messageStyle := lipgloss.NewStyle().Foreground(activitySeverityColor(e.Severity))
+
+	prefix := "  " + timeStyle.Render(timestamp) + "  " + sessionStyle.Render("["+sessionName+"]") + "  "
+	message := e.Message
+	if width > 0 {
+		message = ansi.Truncate(message, max(width-lipgloss.Width(prefix), 10), "…")
+	}
+	return prefix + messageStyle.Render(message)
+}
+
+// renderActivityFeedMode renders the activity feed overlay with a header bar.
+func (c *Chat) renderActivityFeedMode(panelStyle lipgloss.Style) string {
+	if c.activityFeed == nil {
+		return ""
+	}
+
+	// Calculate dimensions
+	innerWidth := c.width - 2 // Account for panel border
+	innerHeight := c.height - 2
+
+	headerHeight := 1
+	feedHeight := innerHeight - headerHeight
+
+	// Resize the viewport and re-render if the width changed
+	widthChanged := c.activityFeed.Viewport.Width() != innerWidth
+	c.activityFeed.Viewport.SetWidth(innerWidth)
+	c.activityFeed.Viewport.SetHeight(feedHeight)
+	if widthChanged {
+		c.updateActivityFeedContent()
+	}
+
+	titleStyle := lipgloss.NewStyle().Foreground(ColorText).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	header := titleStyle.Render("Activity") + " " +
+		hintStyle.Render(fmt.Sprintf("(%d events)  [enter: jump to session] [esc: close]", len(c.activityFeed.Events)))
+	header = lipgloss.NewStyle().Width(innerWidth).MaxHeight(1).Render(header)
+
+	feedContent := lipgloss.NewStyle().
+		MaxHeight(feedHeight).
+		Render(c.activityFeed.Viewport.View())
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		feedContent,
+	)
+
+	return panelStyle.Width(c.width).Height(c.height).Render(content)
+}
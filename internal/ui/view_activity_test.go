@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/zhubert/plural/internal/activity"
+)
+
+func testActivityEvents() []activity.Event {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.Local)
+	return []activity.Event{
+		{Time: base.Add(2 * time.Minute), SessionID: "session-3", SessionName: "bugfix", Kind: activity.KindError, Severity: activity.SeverityError, Message: "Error: boom"},
+		{Time: base.Add(time.Minute), SessionID: "session-2", SessionName: "feature", Kind: activity.KindPermissionRequested, Severity: activity.SeverityWarning, Message: "Permission requested: Bash"},
+		{Time: base, SessionID: "session-1", SessionName: "main-work", Kind: activity.KindResponseCompleted, Severity: activity.SeveritySuccess, Message: "Response completed"},
+	}
+}
+
+func TestActivityFeed_EnterAndExit(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(100, 30)
+
+	if chat.IsInActivityFeedMode() {
+		t.Fatal("should not start in activity feed mode")
+	}
+
+	chat.EnterActivityFeedMode(testActivityEvents())
+	if !chat.IsInActivityFeedMode() {
+		t.Fatal("expected activity feed mode")
+	}
+
+	view := chat.View()
+	for _, want := range []string{"Activity", "bugfix", "Error: boom", "Response completed"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	chat.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if chat.IsInActivityFeedMode() {
+		t.Error("expected Escape to exit activity feed mode")
+	}
+}
+
+func TestActivityFeed_EmptyFeed(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(100, 30)
+	chat.EnterActivityFeedMode(nil)
+
+	if chat.SelectedActivityEvent() != nil {
+		t.Error("expected no selected event for empty feed")
+	}
+	if !strings.Contains(chat.View(), "No activity yet") {
+		t.Error("expected empty feed placeholder")
+	}
+}
+
+func TestActivityFeed_Selection(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []tea.KeyPressMsg
+		wantID string
+	}{
+		{name: "starts at newest", keys: nil, wantID: "session-3"},
+		{name: "down moves to older", keys: []tea.KeyPressMsg{{Code: tea.KeyDown}}, wantID: "session-2"},
+		{name: "j moves down", keys: []tea.KeyPressMsg{{Code: 'j', Text: "j"}, {Code: 'j', Text: "j"}}, wantID: "session-1"},
+		{name: "clamped at bottom", keys: []tea.KeyPressMsg{{Code: tea.KeyDown}, {Code: tea.KeyDown}, {Code: tea.KeyDown}, {Code: tea.KeyDown}}, wantID: "session-1"},
+		{name: "clamped at top", keys: []tea.KeyPressMsg{{Code: tea.KeyUp}}, wantID: "session-3"},
+		{name: "end jumps to oldest", keys: []tea.KeyPressMsg{{Code: tea.KeyEnd}}, wantID: "session-1"},
+		{name: "home jumps to newest", keys: []tea.KeyPressMsg{{Code: tea.KeyEnd}, {Code: tea.KeyHome}}, wantID: "session-3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chat := NewChat()
+			chat.SetSize(100, 30)
+			chat.EnterActivityFeedMode(testActivityEvents())
+
+			for _, k := range tt.keys {
+				chat.Update(k)
+			}
+
+			selected := chat.SelectedActivityEvent()
+			if selected == nil {
+				t.Fatal("expected a selected event")
+			}
+			if selected.SessionID != tt.wantID {
+				t.Errorf("selected session = %q, want %q", selected.SessionID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestActivityFeed_SetEventsKeepsSelection(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(100, 30)
+	events := testActivityEvents()
+	chat.EnterActivityFeedMode(events)
+	chat.MoveActivityFeedSelection(1) // select session-2
+
+	newEvent := activity.Event{Time: time.Now(), SessionID: "session-4", Message: "Merged to main"}
+	chat.SetActivityFeedEvents(append([]activity.Event{newEvent}, events...))
+
+	if got := chat.SelectedActivityEvent(); got == nil || got.SessionID != "session-2" {
+		t.Errorf("expected selection to stay on session-2, got %+v", got)
+	}
+
+	// When the newest entry is selected, selection stays at the top
+	chat.MoveActivityFeedSelection(-10)
+	newer := activity.Event{Time: time.Now().Add(time.Second), SessionID: "session-5", Message: "Response completed"}
+	chat.SetActivityFeedEvents(append([]activity.Event{newer}, chat.activityFeed.Events...))
+	if got := chat.SelectedActivityEvent(); got == nil || got.SessionID != "session-5" {
+		t.Errorf("expected newest event selected, got %+v", got)
+	}
+}
+
+func TestRenderActivityLine(t *testing.T) {
+	e := activity.Event{
+		Time:      time.Date(2025, 6, 1, 9, 30, 15, 0, time.Local),
+		SessionID: "abcdef1234567890",
+		Severity:  activity.SeverityWarning,
+		Message:   "Permission requested: Bash",
+	}
+
+	line := renderActivityLine(e, 0, false)
+	for _, want := range []string{"Jun 01 09:30:15", "[abcdef12]", "Permission requested: Bash"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("line %q should contain %q", line, want)
+		}
+	}
+
+	selected := renderActivityLine(e, 0, true)
+	if !strings.Contains(selected, "> ") {
+		t.Errorf("selected line should have a marker, got %q", selected)
+	}
+}
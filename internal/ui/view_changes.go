@@ -14,6 +14,7 @@ func (c *Chat) EnterViewChangesMode(files []git.FileDiff) {
 		Files:     files,
 		FileIndex: 0,
 		Viewport:  viewport.New(),
+		Split:     c.splitDiff,
 	}
 
 	// Configure viewport
@@ -29,6 +30,14 @@ func (c *Chat) EnterViewChangesMode(files []git.FileDiff) {
 	c.updateViewChangesDiff()
 }
 
+// SetViewChangesHeader sets a summary line shown above the file navigation bar
+// while in view changes mode, e.g. which snapshots are being compared.
+func (c *Chat) SetViewChangesHeader(header string) {
+	if c.viewChanges != nil {
+		c.viewChanges.Header = header
+	}
+}
+
 // updateViewChangesDiff updates the diff viewport with the currently selected file's diff
 func (c *Chat) updateViewChangesDiff() {
 	if c.viewChanges == nil || len(c.viewChanges.Files) == 0 {
@@ -40,12 +49,39 @@ func (c *Chat) updateViewChangesDiff() {
 	if c.viewChanges.FileIndex >= len(c.viewChanges.Files) {
 		c.viewChanges.FileIndex = len(c.viewChanges.Files) - 1
 	}
-	file := c.viewChanges.Files[c.viewChanges.FileIndex]
-	content := HighlightDiff(file.Diff)
-	c.viewChanges.Viewport.SetContent(content)
+	c.viewChanges.Viewport.SetContent(c.renderViewChangesDiff())
 	c.viewChanges.Viewport.GotoTop()
 }
 
+// renderViewChangesDiff renders the selected file's diff at the viewport's
+// width: side by side when split and wide enough, otherwise unified.
+func (c *Chat) renderViewChangesDiff() string {
+	diff := c.viewChanges.Files[c.viewChanges.FileIndex].Diff
+	c.viewChanges.RenderedWidth = c.viewChanges.Viewport.Width()
+	if c.viewChanges.Split {
+		if content, ok := renderSplitDiff(diff, c.viewChanges.RenderedWidth); ok {
+			return content
+		}
+	}
+	return HighlightDiff(diff)
+}
+
+// ToggleViewChangesSplit switches view changes mode between unified and split
+// diffs. The choice is kept for the next time view changes mode is entered.
+func (c *Chat) ToggleViewChangesSplit() {
+	if c.viewChanges == nil {
+		return
+	}
+	c.viewChanges.Split = !c.viewChanges.Split
+	c.splitDiff = c.viewChanges.Split
+	c.updateViewChangesDiff()
+}
+
+// IsViewChangesSplit returns whether view changes mode shows split diffs.
+func (c *Chat) IsViewChangesSplit() bool {
+	return c.viewChanges != nil && c.viewChanges.Split
+}
+
 // ExitViewChangesMode exits the diff view overlay and returns to chat
 func (c *Chat) ExitViewChangesMode() {
 	c.viewChanges = nil
@@ -78,6 +114,15 @@ func (c *Chat) renderViewChangesMode(panelStyle lipgloss.Style) string {
 	// Build the compact navigation bar
 	navBar := c.renderFileNavBar(innerWidth)
 	navBarHeight := 1 // Single line navigation
+	if c.viewChanges.Header != "" {
+		header := lipgloss.NewStyle().
+			Width(innerWidth).
+			MaxHeight(1).
+			Foreground(ColorTextMuted).
+			Render(c.viewChanges.Header)
+		navBar = lipgloss.JoinVertical(lipgloss.Left, header, navBar)
+		navBarHeight++
+	}
 
 	// Diff viewport gets remaining height
 	diffHeight := innerHeight - navBarHeight
@@ -86,6 +131,13 @@ func (c *Chat) renderViewChangesMode(panelStyle lipgloss.Style) string {
 	c.viewChanges.Viewport.SetWidth(innerWidth)
 	c.viewChanges.Viewport.SetHeight(diffHeight)
 
+	// A split diff's columns depend on the width, so re-render it after a resize
+	if c.viewChanges.Split && c.viewChanges.RenderedWidth != innerWidth && len(c.viewChanges.Files) > 0 {
+		offset := c.viewChanges.Viewport.YOffset()
+		c.viewChanges.Viewport.SetContent(c.renderViewChangesDiff())
+		c.viewChanges.Viewport.SetYOffset(offset)
+	}
+
 	// Get viewport content and constrain to max height to prevent layout overflow
 	diffContent := lipgloss.NewStyle().
 		MaxHeight(diffHeight).
@@ -58,3 +58,22 @@ func TestRenderFileNavBar_FilenameNotOverTruncated(t *testing.T) {
 		t.Errorf("renderFileNavBar at width 120 should contain full filename %q, got: %q", filename, stripped)
 	}
 }
+
+func TestRenderViewChangesMode_ShowsHeader(t *testing.T) {
+	chat := NewChat()
+	chat.SetSize(100, 20)
+
+	chat.EnterViewChangesMode([]git.FileDiff{
+		{Filename: "main.go", Status: "M", Diff: "diff"},
+	})
+	chat.SetViewChangesHeader("Snapshot 1 → Now: 1 file changed, +2 -1")
+
+	result := stripANSI(chat.renderViewChangesMode(lipgloss.NewStyle()))
+	lines := strings.Split(result, "\n")
+	if len(lines) < 2 || !strings.Contains(lines[0], "Snapshot 1 → Now") || !strings.Contains(lines[1], "main.go") {
+		t.Errorf("expected header above the file navigation bar, got:\n%s", result)
+	}
+	if len(lines) > 20 {
+		t.Errorf("rendered %d lines, want at most 20", len(lines))
+	}
+}
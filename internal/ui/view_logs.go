@@ -8,6 +8,7 @@ import (
 	"charm.land/bubbles/v2/viewport"
 	"charm.land/lipgloss/v2"
 	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/mcp"
 )
 
 // GetLogFiles returns a list of available log files for viewing.
@@ -201,6 +202,16 @@ func (c *Chat) ToggleLogViewerFollowTail() {
 	}
 }
 
+// ToggleLogViewerWireTrace turns MCP wire tracing on or off, logging every
+// message between the TUI and sessions' MCP servers to their MCP logs.
+func (c *Chat) ToggleLogViewerWireTrace() {
+	enabled := !mcp.WireTraceEnabled()
+	if err := mcp.SetWireTrace(enabled); err != nil {
+		logger.Get().Warn("failed to toggle MCP wire tracing", "enabled", enabled, "error", err)
+	}
+	c.RefreshLogViewer()
+}
+
 // GetLogViewerFollowTail returns whether follow tail mode is enabled.
 func (c *Chat) GetLogViewerFollowTail() bool {
 	if c.logViewer == nil {
@@ -290,8 +301,16 @@ func (c *Chat) renderLogNavBar(width int) string {
 	refreshStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
 	refreshHint := " " + refreshStyle.Render("[r: refresh]")
 
+	// Wire trace indicator
+	if mcp.WireTraceEnabled() {
+		traceStyle := lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
+		refreshHint += " " + traceStyle.Render("[Wire trace]")
+	} else {
+		refreshHint += " " + refreshStyle.Render("[m: wire trace]")
+	}
+
 	// Calculate available width for filename
-	fixedWidth := lipgloss.Width(leftArrow) + lipgloss.Width(counter) + lipgloss.Width(rightArrow) + lipgloss.Width(followIndicator) + lipgloss.Width(refreshHint) + 1 // arrows, counter, follow/refresh indicators, space
+	fixedWidth := lipgloss.Width(leftArrow) + lipgloss.Width(counter) + lipgloss.Width(rightArrow) + lipgloss.Width(followIndicator) + lipgloss.Width(refreshHint) + 1 // arrows, counter, follow/refresh/trace indicators, space
 	maxFilenameWidth := max(width-fixedWidth, 10)
 
 	// Truncate filename if needed
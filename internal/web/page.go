@@ -0,0 +1,128 @@
+package web
+
+import (
+	"html/template"
+
+	"github.com/zhubert/plural/internal/share"
+)
+
+// pageMessage is a transcript message as shown on the page.
+type pageMessage struct {
+	Role    string // "user", "assistant", or "system", used as the CSS class
+	Label   string
+	Content string
+}
+
+// pageData is what the page is rendered from.
+type pageData struct {
+	SessionName string
+	Messages    []pageMessage
+}
+
+// roleLabel returns the label shown above a message, matching the chat's
+// "You:"/"Claude:", and "Plural:" for notices plural added.
+func roleLabel(role string) string {
+	switch role {
+	case "user":
+		return "You"
+	case "system":
+		return "Plural"
+	}
+	return "Claude"
+}
+
+// renderMessages returns the messages as shown on the page. Markdown is shown
+// as written, which reads well as plain text.
+func renderMessages(messages []share.Message) []pageMessage {
+	rendered := make([]pageMessage, len(messages))
+	for i, msg := range messages {
+		rendered[i] = pageMessage{Role: msg.Role, Label: roleLabel(msg.Role), Content: msg.Content}
+	}
+	return rendered
+}
+
+// pageTemplate is the page. Its script applies the share events from /events the
+// way share.Apply does, setting only text so message content can't inject markup.
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{if .SessionName}}{{.SessionName}} - {{end}}Plural</title>
+<style>
+body { margin: 0 auto; max-width: 60rem; padding: 1rem; background: #1a1b26; color: #c0caf5; font: 14px/1.5 ui-monospace, SFMono-Regular, Menlo, monospace; }
+header { display: flex; justify-content: space-between; border-bottom: 1px solid #414868; margin-bottom: 1rem; }
+#status { color: #565f89; }
+.message { margin-bottom: 1rem; }
+.label { font-weight: bold; }
+.user .label { color: #7aa2f7; }
+.assistant .label { color: #bb9af7; }
+.system { color: #565f89; }
+.content { margin: 0; white-space: pre-wrap; overflow-wrap: anywhere; font: inherit; }
+</style>
+</head>
+<body>
+<header><h1 id="session">{{if .SessionName}}{{.SessionName}}{{else}}No session selected{{end}}</h1><span id="status">read-only</span></header>
+<main id="messages">
+{{- range .Messages}}
+<div class="message {{.Role}}"><div class="label">{{.Label}}:</div><pre class="content">{{.Content}}</pre></div>
+{{- end}}
+</main>
+<script>
+(function () {
+  var list = document.getElementById("messages");
+  var status = document.getElementById("status");
+
+  function add(role, content) {
+    var div = document.createElement("div");
+    var kind = role === "user" || role === "system" ? role : "assistant";
+    div.className = "message " + kind;
+    var label = document.createElement("div");
+    label.className = "label";
+    label.textContent = {user: "You", system: "Plural", assistant: "Claude"}[kind] + ":";
+    var pre = document.createElement("pre");
+    pre.className = "content";
+    pre.textContent = content;
+    div.appendChild(label);
+    div.appendChild(pre);
+    list.appendChild(div);
+  }
+
+  function follow(apply) {
+    var atBottom = window.innerHeight + window.scrollY >= document.body.scrollHeight - 40;
+    apply();
+    if (atBottom) window.scrollTo(0, document.body.scrollHeight);
+  }
+
+  var events = new EventSource("events");
+  events.addEventListener("snapshot", function (e) {
+    var data = JSON.parse(e.data);
+    follow(function () {
+      document.getElementById("session").textContent = data.session_name || "No session selected";
+      document.title = (data.session_name ? data.session_name + " - " : "") + "Plural";
+      list.textContent = "";
+      (data.messages || []).forEach(function (m) { add(m.role, m.content); });
+    });
+    status.textContent = "live, read-only";
+  });
+  events.addEventListener("append", function (e) {
+    var data = JSON.parse(e.data);
+    var index = data.index || 0;
+    follow(function () {
+      if (index === list.children.length) {
+        add(data.role, data.content || "");
+      } else if (index < list.children.length) {
+        list.children[index].querySelector(".content").textContent += data.content || "";
+      }
+    });
+  });
+  events.addEventListener("end", function () {
+    status.textContent = "Plural stopped serving this view";
+    events.close();
+  });
+  events.onerror = function () { status.textContent = "reconnecting..."; };
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,108 @@
+// Package web serves a read-only HTML view of the selected session's
+// conversation, for following along on a second screen or in a browser.
+//
+// The page renders the transcript when loaded and then follows a server-sent
+// events stream of the share protocol (see package share) as it streams. Only
+// GET requests are served, so nothing a viewer does can reach the session.
+package web
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/zhubert/plural/internal/logger"
+	"github.com/zhubert/plural/internal/share"
+)
+
+// shutdownTimeout bounds how long Close waits for page connections to finish.
+const shutdownTimeout = 2 * time.Second
+
+// Server mirrors the selected session's conversation to browsers.
+type Server struct {
+	listener net.Listener
+	http     *http.Server
+	hub      *share.Hub
+}
+
+// Start starts a web view server on addr, in host:port form. Without a host,
+// as in ":8099", it only listens on localhost.
+func Start(addr string) (*Server, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid web view address %q: %w", addr, err)
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for web view: %w", err)
+	}
+
+	s := &Server{
+		listener: listener,
+		hub:      share.NewHub("web", ""),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/{$}", s.handlePage)
+	mux.HandleFunc("/events", s.hub.ServeEvents)
+	// Only the address listened on, so other sites can't read the page by
+	// rebinding their DNS name to this machine
+	s.http = &http.Server{Handler: share.RequireHost(listener.Addr(), mux), ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := s.http.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.WithComponent("web").Error("web view server failed", "error", err)
+		}
+	}()
+	logger.WithComponent("web").Info("web view server started", "addr", listener.Addr().String())
+	return s, nil
+}
+
+// URL returns the URL of the page.
+func (s *Server) URL() string {
+	return "http://" + s.listener.Addr().String() + "/"
+}
+
+// Publish shows a session's current transcript. Only what changed since the
+// last call is sent when the same session's transcript grew by appending;
+// otherwise pages get a new snapshot.
+func (s *Server) Publish(sessionName string, messages []share.Message) {
+	s.hub.Publish(sessionName, messages)
+}
+
+// Viewers returns the number of connected pages.
+func (s *Server) Viewers() int {
+	return s.hub.Viewers()
+}
+
+// handlePage serves the page with the current transcript.
+func (s *Server) handlePage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sessionName, transcript := s.hub.Transcript()
+	data := pageData{SessionName: sessionName, Messages: renderMessages(transcript)}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'")
+	if err := pageTemplate.Execute(w, data); err != nil {
+		logger.WithComponent("web").Warn("failed to render web view page", "error", err)
+	}
+}
+
+// Close tells pages the view stopped and stops the server.
+func (s *Server) Close() error {
+	s.hub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	logger.WithComponent("web").Info("web view server stopped")
+	return s.http.Shutdown(ctx)
+}
@@ -0,0 +1,177 @@
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhubert/plural/internal/share"
+)
+
+// startTestServer starts a web view server on a free localhost port that is
+// closed when the test ends.
+func startTestServer(t *testing.T) *Server {
+	t.Helper()
+	s, err := Start(":0")
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// watchEvents follows the server's event stream in the background.
+func watchEvents(t *testing.T, s *Server) <-chan share.Event {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	events := make(chan share.Event, 16)
+	go share.Watch(ctx, s.URL()+"events", func(e share.Event) { events <- e })
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan share.Event) share.Event {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for web view event")
+		return share.Event{}
+	}
+}
+
+// waitForViewers waits until n pages are connected.
+func waitForViewers(t *testing.T, s *Server, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for s.Viewers() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d viewers, have %d", n, s.Viewers())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func get(t *testing.T, url string) (*http.Response, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func TestStart_LocalhostByDefault(t *testing.T) {
+	s := startTestServer(t)
+	if !strings.HasPrefix(s.URL(), "http://127.0.0.1:") {
+		t.Errorf("URL() = %q, want localhost", s.URL())
+	}
+	if _, err := Start("8099"); err == nil {
+		t.Error("expected an address without a port separator to be rejected")
+	}
+}
+
+func TestServer_PageRendersTranscriptEscaped(t *testing.T) {
+	s := startTestServer(t)
+	s.Publish("fix-login", []share.Message{
+		{Role: "user", Content: "fix the login bug"},
+		{Role: "assistant", Content: "Done. See <script>alert(1)</script>"},
+		{Role: "system", Content: "Merged into main"},
+	})
+
+	resp, body := get(t, s.URL())
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Fatalf("GET / = %d %q, want an HTML page", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	for _, want := range []string{"<title>fix-login - Plural</title>", "You:", "fix the login bug", "Claude:", "&lt;script&gt;alert(1)&lt;/script&gt;", `<div class="message system"><div class="label">Plural:</div>`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in the page", want)
+		}
+	}
+	if strings.Contains(body, "<script>alert(1)") {
+		t.Error("message content must be escaped")
+	}
+
+	if resp, _ := get(t, s.URL()+"other"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /other = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestServer_StreamsAndSwitchesSessions(t *testing.T) {
+	s := startTestServer(t)
+	s.Publish("fix-login", []share.Message{{Role: "user", Content: "fix it"}})
+
+	events := watchEvents(t, s)
+	first := nextEvent(t, events)
+	if first.Type != share.EventSnapshot || first.SessionName != "fix-login" || len(first.Messages) != 1 {
+		t.Fatalf("first event = %+v, want snapshot of one message", first)
+	}
+	waitForViewers(t, s, 1)
+
+	s.Publish("fix-login", []share.Message{{Role: "user", Content: "fix it"}, {Role: "assistant", Content: "On it"}})
+	if e := nextEvent(t, events); e.Type != share.EventAppend || e.Content != "On it" {
+		t.Errorf("event = %+v, want the new message appended", e)
+	}
+
+	// Selecting another session replaces the transcript, even one that extends the last
+	s.Publish("add-tests", []share.Message{{Role: "user", Content: "fix it"}, {Role: "assistant", Content: "On it"}, {Role: "user", Content: "more"}})
+	if e := nextEvent(t, events); e.Type != share.EventSnapshot || e.SessionName != "add-tests" || len(e.Messages) != 3 {
+		t.Errorf("event = %+v, want a snapshot of the other session", e)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if e := nextEvent(t, events); e.Type != share.EventEnd {
+		t.Errorf("event = %+v, want end", e)
+	}
+}
+
+func TestServer_IsReadOnly(t *testing.T) {
+	s := startTestServer(t)
+	for _, path := range []string{"", "events"} {
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+			req, err := http.NewRequest(method, s.URL()+path, strings.NewReader(`{"content":"rm -rf"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("%s /%s: %v", method, path, err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusMethodNotAllowed {
+				t.Errorf("%s /%s returned %d, want 405", method, path, resp.StatusCode)
+			}
+		}
+	}
+}
+
+func TestServer_RejectsOtherHosts(t *testing.T) {
+	s := startTestServer(t)
+	for _, path := range []string{"", "events"} {
+		req, err := http.NewRequest(http.MethodGet, s.URL()+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// What a browser sends for a site whose DNS name was rebound to this machine
+		req.Host = "attacker.example:" + req.URL.Port()
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET /%s: %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("GET /%s for another host returned %d, want 403", path, resp.StatusCode)
+		}
+	}
+}
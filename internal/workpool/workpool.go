@@ -0,0 +1,113 @@
+// Package workpool runs batches of independent jobs, such as a git or gh
+// command per session or repo, on a bounded number of goroutines.
+//
+// Multi-session operations go through Run so they share one concurrency limit
+// instead of each picking its own, stop starting jobs once their context is
+// canceled, and survive a job that panics.
+package workpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/zhubert/plural/internal/logger"
+)
+
+// DefaultLimit is how many jobs run at once when Options.Limit is not set.
+const DefaultLimit = 8
+
+// ErrNotStarted is the error of a job that never ran because the batch's
+// context was canceled first. It is joined with the context's error, so
+// errors.Is matches either.
+var ErrNotStarted = errors.New("job not started")
+
+// Options configures a batch.
+type Options struct {
+	Limit int // Jobs running at once; DefaultLimit if <= 0
+
+	// Progress, if set, is called after each job that ran finishes, with how
+	// many have finished and how many jobs the batch has. Calls are made one
+	// at a time from the jobs' goroutines, so it must not block for long.
+	Progress func(done, total int)
+}
+
+// PanicError is the error of a job that panicked.
+type PanicError struct {
+	Value any    // What the job panicked with
+	Stack []byte // Stack of the job's goroutine when it panicked
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("job panicked: %v", e.Value)
+}
+
+// Run runs job for i from 0 to n-1, at most opts.Limit at a time, and returns
+// each job's error by its index once all that started have finished.
+//
+// Once ctx is canceled, jobs not yet started are not, and get an error
+// matching ErrNotStarted; jobs running are passed ctx to stop early. A job
+// that panics gets a *PanicError while the others carry on.
+func Run(ctx context.Context, n int, opts Options, job func(ctx context.Context, i int) error) []error {
+	errs := make([]error, n)
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	var mu sync.Mutex // Serializes progress calls
+	done := 0
+
+	for i := range n {
+		// Checked first, as select picks at random when a slot is free too
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			for j := i; j < n; j++ {
+				errs[j] = errors.Join(ErrNotStarted, ctx.Err())
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = runJob(ctx, i, job)
+			if opts.Progress != nil {
+				mu.Lock()
+				done++
+				opts.Progress(done, n)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return errs
+}
+
+// runJob runs a job, recovering a panic into a *PanicError.
+func runJob(ctx context.Context, i int, job func(ctx context.Context, i int) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			stack := debug.Stack()
+			logger.WithComponent("workpool").Error("job panicked", "job", i, "panic", r, "stack", string(stack))
+			err = &PanicError{Value: r, Stack: stack}
+		}
+	}()
+	return job(ctx, i)
+}
+
+// Started returns whether a job of a batch ran, going by its error from Run.
+func Started(err error) bool {
+	return !errors.Is(err, ErrNotStarted)
+}
@@ -0,0 +1,175 @@
+package workpool
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun_RespectsLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	errs := Run(context.Background(), 20, Options{Limit: 3}, func(ctx context.Context, i int) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	})
+
+	if got := peak.Load(); got != 3 {
+		t.Errorf("expected at most 3 jobs at once and the cap reached, peak was %d", got)
+	}
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("job %d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestRun_DefaultLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	Run(context.Background(), DefaultLimit*3, Options{}, func(ctx context.Context, i int) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	})
+	if got := peak.Load(); got > DefaultLimit {
+		t.Errorf("expected at most %d jobs at once, peak was %d", DefaultLimit, got)
+	}
+}
+
+func TestRun_ReturnsErrorsByIndex(t *testing.T) {
+	failure := errors.New("failed")
+	errs := Run(context.Background(), 4, Options{Limit: 2}, func(ctx context.Context, i int) error {
+		if i%2 == 1 {
+			return failure
+		}
+		return nil
+	})
+	want := []error{nil, failure, nil, failure}
+	if !slices.Equal(errs, want) {
+		t.Errorf("got errors %v, want %v", errs, want)
+	}
+}
+
+func TestRun_CancelMidBatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const n = 10
+	var started atomic.Int32
+	errs := Run(ctx, n, Options{Limit: 2}, func(ctx context.Context, i int) error {
+		if started.Add(1) == 2 {
+			// Both slots are taken; cancel while the rest wait for one
+			cancel()
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if got := started.Load(); got != 2 {
+		t.Fatalf("expected no job to start after cancellation, %d started", got)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("job %d: expected context.Canceled, got %v", i, err)
+		}
+		if wantStarted := i < 2; Started(err) != wantStarted {
+			t.Errorf("job %d: Started = %v, want %v", i, Started(err), wantStarted)
+		}
+	}
+}
+
+func TestRun_CanceledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ran := false
+	errs := Run(ctx, 3, Options{}, func(ctx context.Context, i int) error {
+		ran = true
+		return nil
+	})
+	if ran {
+		t.Error("expected no job to run with a canceled context")
+	}
+	for i, err := range errs {
+		if !errors.Is(err, ErrNotStarted) {
+			t.Errorf("job %d: expected ErrNotStarted, got %v", i, err)
+		}
+	}
+}
+
+func TestRun_PanicDoesNotStopSiblings(t *testing.T) {
+	var mu sync.Mutex
+	var finished []int
+	errs := Run(context.Background(), 5, Options{Limit: 2}, func(ctx context.Context, i int) error {
+		if i == 1 {
+			panic("boom")
+		}
+		mu.Lock()
+		finished = append(finished, i)
+		mu.Unlock()
+		return nil
+	})
+
+	slices.Sort(finished)
+	if want := []int{0, 2, 3, 4}; !slices.Equal(finished, want) {
+		t.Errorf("expected the other jobs to finish, got %v", finished)
+	}
+	var panicErr *PanicError
+	if !errors.As(errs[1], &panicErr) {
+		t.Fatalf("expected a *PanicError for the job that panicked, got %v", errs[1])
+	}
+	if panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+		t.Errorf("expected the panic value and stack recorded, got %v with %d bytes of stack", panicErr.Value, len(panicErr.Stack))
+	}
+	if !Started(errs[1]) {
+		t.Error("expected a job that panicked to count as started")
+	}
+}
+
+func TestRun_Progress(t *testing.T) {
+	var calls [][2]int
+	Run(context.Background(), 4, Options{
+		Limit: 2,
+		Progress: func(done, total int) {
+			calls = append(calls, [2]int{done, total})
+		},
+	}, func(ctx context.Context, i int) error {
+		if i == 2 {
+			panic("boom")
+		}
+		return nil
+	})
+
+	want := [][2]int{{1, 4}, {2, 4}, {3, 4}, {4, 4}}
+	if !slices.Equal(calls, want) {
+		t.Errorf("got progress %v, want %v", calls, want)
+	}
+}
+
+func TestRun_Empty(t *testing.T) {
+	errs := Run(context.Background(), 0, Options{}, func(ctx context.Context, i int) error {
+		t.Error("expected no job to run")
+		return nil
+	})
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}